@@ -0,0 +1,77 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func createCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "create",
+		Usage: "Create an app in a project",
+		Description: `Create an app in a project. The app starts with a production and a preview environment.
+
+The slug must be unique within the project and can be used in place of the app ID in every other command. Pass --repository to connect a GitHub repository right away; the workspace must have the Unkey GitHub App installed with access to it.
+
+Required permissions:
+- project.*.create_app
+- project.<project_id>.create_app
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/apps/create-app` + util.Disclaimer,
+		Examples: []string{
+			"unkey api apps create --project=acme --name=API --slug=api",
+			"unkey api apps create --project=acme --name=API --slug=api --repository=acme/api",
+			"unkey api apps create --project=acme --name=API --slug=api --repository=acme/api --default-branch=develop",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("project", "The project ID or slug.", cli.Required()),
+			cli.String("name", "Human-readable app name.", cli.Required()),
+			cli.String("slug", "URL-safe app slug, unique within the project.", cli.Required()),
+			cli.String("repository", "GitHub repository to connect, as owner/repo."),
+			cli.String("default-branch", "Branch the app's deployments track. Defaults to the repository's default branch."),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			req := openapi.V2AppsCreateAppRequestBody{
+				Project: cmd.String("project"),
+				Name:    cmd.String("name"),
+				Slug:    cmd.String("slug"),
+				Git:     nil,
+			}
+			if v := cmd.String("repository"); v != "" {
+				req.Git = &openapi.AppGitCreateInput{
+					Repository:    v,
+					DefaultBranch: nil,
+				}
+				if b := cmd.String("default-branch"); b != "" {
+					req.Git.DefaultBranch = &b
+				}
+			} else if cmd.String("default-branch") != "" {
+				return fmt.Errorf("--default-branch requires --repository")
+			}
+
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/apps.createApp", req)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2AppsCreateAppResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want openapi.V2AppsCreateAppRequestBody
+	}{
+		{
+			name: "without repository",
+			args: "apps create --project=acme --name=API --slug=api",
+			want: openapi.V2AppsCreateAppRequestBody{
+				Project: "acme",
+				Name:    "API",
+				Slug:    "api",
+			},
+		},
+		{
+			name: "with repository and branch",
+			args: "apps create --project=acme --name=API --slug=api --repository=acme/api --default-branch=develop",
+			want: openapi.V2AppsCreateAppRequestBody{
+				Project: "acme",
+				Name:    "API",
+				Slug:    "api",
+				Git: &openapi.AppGitCreateInput{
+					Repository:    "acme/api",
+					DefaultBranch: ptr.P("develop"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := util.CaptureRequest[openapi.V2AppsCreateAppRequestBody](t, Cmd(), tt.args)
+			require.Equal(t, tt.want, req)
+		})
+	}
+}
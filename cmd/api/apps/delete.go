@@ -0,0 +1,57 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func deleteCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "delete",
+		Usage: "Delete an app and everything in it",
+		Description: `Delete an app together with its environments, deployments, and domains.
+
+Deletion runs in the background: the command returns once it is enqueued. Apps with delete protection enabled must have it disabled first with 'unkey api apps update --delete-protection=false'.
+
+Required permissions:
+- app.*.delete_app
+- app.<app_id>.delete_app
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/apps/delete-app` + util.Disclaimer,
+		Examples: []string{
+			"unkey api apps delete --project=acme --app=api",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("project", "The project ID or slug.", cli.Required()),
+			cli.String("app", "The app ID or slug.", cli.Required()),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/apps.deleteApp", openapi.V2AppsDeleteAppRequestBody{
+				Project: cmd.String("project"),
+				App:     cmd.String("app"),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2AppsDeleteAppResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
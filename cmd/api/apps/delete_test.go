@@ -0,0 +1,17 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestDelete(t *testing.T) {
+	req := util.CaptureRequest[openapi.V2AppsDeleteAppRequestBody](t, Cmd(), "apps delete --project=acme --app=api")
+	require.Equal(t, openapi.V2AppsDeleteAppRequestBody{
+		Project: "acme",
+		App:     "api",
+	}, req)
+}
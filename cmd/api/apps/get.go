@@ -0,0 +1,56 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func getCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "get",
+		Usage: "Get an app by ID or slug",
+		Description: `Retrieve a single app, including its connected repository.
+
+Required permissions:
+- app.*.read_app
+- app.<app_id>.read_app
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/apps/get-app` + util.Disclaimer,
+		Examples: []string{
+			"unkey api apps get --project=acme --app=api",
+			"unkey api apps get --project=proj_1234abcd --app=app_1234abcd --output=json",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("project", "The project ID or slug.", cli.Required()),
+			cli.String("app", "The app ID or slug.", cli.Required()),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/apps.getApp", openapi.V2AppsGetAppRequestBody{
+				Project: cmd.String("project"),
+				App:     cmd.String("app"),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2AppsGetAppResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
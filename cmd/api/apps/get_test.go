@@ -0,0 +1,17 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestGet(t *testing.T) {
+	req := util.CaptureRequest[openapi.V2AppsGetAppRequestBody](t, Cmd(), "apps get --project=proj_1234abcd --app=app_1234abcd")
+	require.Equal(t, openapi.V2AppsGetAppRequestBody{
+		Project: "proj_1234abcd",
+		App:     "app_1234abcd",
+	}, req)
+}
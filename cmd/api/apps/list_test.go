@@ -0,0 +1,41 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want openapi.V2AppsListAppsRequestBody
+	}{
+		{
+			name: "minimal required flags",
+			args: "apps list --project=acme",
+			want: openapi.V2AppsListAppsRequestBody{Project: "acme"},
+		},
+		{
+			name: "all optional flags",
+			args: "apps list --project=acme --search=api --limit=10 --cursor=cursor_123",
+			want: openapi.V2AppsListAppsRequestBody{
+				Project: "acme",
+				Search:  ptr.P("api"),
+				Limit:   ptr.P(10),
+				Cursor:  ptr.P("cursor_123"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := util.CaptureRequestWithData[openapi.V2AppsListAppsRequestBody](t, Cmd(), tt.args, []any{})
+			require.Equal(t, tt.want, req)
+		})
+	}
+}
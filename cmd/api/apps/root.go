@@ -0,0 +1,22 @@
+package apps
+
+import (
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+)
+
+// Cmd returns the apps group command with all subcommands.
+func Cmd() *cli.Command {
+	return &cli.Command{
+		Name:        "apps",
+		Usage:       "Manage apps",
+		Description: "Create, read, update, and delete the apps in a project." + util.Disclaimer,
+		Commands: []*cli.Command{
+			createCmd(),
+			deleteCmd(),
+			getCmd(),
+			listCmd(),
+			updateCmd(),
+		},
+	}
+}
@@ -0,0 +1,104 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/oapi-codegen/nullable"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func updateCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "update",
+		Usage: "Rename an app or change its repository",
+		Description: `Update an app's name, slug, delete protection, or connected GitHub repository.
+
+Only the flags you pass are changed. --repository connects or replaces the repository, --default-branch alone retargets the branch of the connected repository, and --disconnect-git removes the connection.
+
+Required permissions:
+- app.*.update_app
+- app.<app_id>.update_app
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/apps/update-app` + util.Disclaimer,
+		Examples: []string{
+			"unkey api apps update --project=acme --app=api --name=\"Public API\"",
+			"unkey api apps update --project=acme --app=api --repository=acme/api",
+			"unkey api apps update --project=acme --app=api --default-branch=develop",
+			"unkey api apps update --project=acme --app=api --disconnect-git",
+			"unkey api apps update --project=acme --app=api --delete-protection=true",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("project", "The project ID or slug.", cli.Required()),
+			cli.String("app", "The app ID or slug.", cli.Required()),
+			cli.String("name", "New app name."),
+			cli.String("slug", "New app slug."),
+			cli.Bool("delete-protection", "Whether the app is protected from deletion."),
+			cli.String("repository", "GitHub repository to connect, as owner/repo."),
+			cli.String("default-branch", "Branch the app's deployments track."),
+			cli.Bool("disconnect-git", "Disconnect the app's GitHub repository.", cli.Default(false)),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			req := openapi.V2AppsUpdateAppRequestBody{
+				Project:          cmd.String("project"),
+				App:              cmd.String("app"),
+				Name:             nil,
+				Slug:             nil,
+				DeleteProtection: nil,
+				Git:              nil,
+			}
+			if v := cmd.String("name"); v != "" {
+				req.Name = &v
+			}
+			if v := cmd.String("slug"); v != "" {
+				req.Slug = &v
+			}
+			if cmd.FlagIsSet("delete-protection") {
+				req.DeleteProtection = ptr.P(cmd.Bool("delete-protection"))
+			}
+
+			repository, branch := cmd.String("repository"), cmd.String("default-branch")
+			switch {
+			case cmd.Bool("disconnect-git") && (repository != "" || branch != ""):
+				return fmt.Errorf("--disconnect-git cannot be combined with --repository or --default-branch")
+			case cmd.Bool("disconnect-git"):
+				req.Git = nullable.NewNullNullable[openapi.AppGitUpdateInput]()
+			case repository != "" || branch != "":
+				git := openapi.AppGitUpdateInput{
+					Repository:    nil,
+					DefaultBranch: nil,
+				}
+				if repository != "" {
+					git.Repository = &repository
+				}
+				if branch != "" {
+					git.DefaultBranch = &branch
+				}
+				req.Git = nullable.NewNullableWithValue(git)
+			}
+
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/apps.updateApp", req)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2AppsUpdateAppResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
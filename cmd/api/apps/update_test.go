@@ -0,0 +1,66 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/oapi-codegen/nullable"
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want openapi.V2AppsUpdateAppRequestBody
+	}{
+		{
+			name: "rename",
+			args: "apps update --project=acme --app=api --name=Public --slug=public",
+			want: openapi.V2AppsUpdateAppRequestBody{
+				Project: "acme",
+				App:     "api",
+				Name:    ptr.P("Public"),
+				Slug:    ptr.P("public"),
+			},
+		},
+		{
+			name: "enable delete protection",
+			args: "apps update --project=acme --app=api --delete-protection=true",
+			want: openapi.V2AppsUpdateAppRequestBody{
+				Project:          "acme",
+				App:              "api",
+				DeleteProtection: ptr.P(true),
+			},
+		},
+		{
+			name: "retarget branch only",
+			args: "apps update --project=acme --app=api --default-branch=develop",
+			want: openapi.V2AppsUpdateAppRequestBody{
+				Project: "acme",
+				App:     "api",
+				Git: nullable.NewNullableWithValue(openapi.AppGitUpdateInput{
+					DefaultBranch: ptr.P("develop"),
+				}),
+			},
+		},
+		{
+			name: "disconnect repository",
+			args: "apps update --project=acme --app=api --disconnect-git",
+			want: openapi.V2AppsUpdateAppRequestBody{
+				Project: "acme",
+				App:     "api",
+				Git:     nullable.NewNullNullable[openapi.AppGitUpdateInput](),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := util.CaptureRequest[openapi.V2AppsUpdateAppRequestBody](t, Cmd(), tt.args)
+			require.Equal(t, tt.want, req)
+		})
+	}
+}
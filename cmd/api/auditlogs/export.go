@@ -0,0 +1,114 @@
+package auditlogs
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+// maxLineSize bounds a single exported event. Events carry user supplied
+// metadata, so lines can be much longer than bufio's 64KiB default.
+const maxLineSize = 4 << 20
+
+func exportCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Export audit logs as newline-delimited JSON",
+		Description: `Export your workspace's audit logs as newline-delimited JSON, newest first, for ingestion into a SIEM or log pipeline.
+
+Every line written to stdout is one audit log event. The export follows the API's continuation cursors until every matching event is written, unless --no-follow is set, in which case the final cursor line is written as-is.
+
+Required permissions:
+- workspace.*.read_audit_log
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/auditlogs/export-audit-logs` + util.Disclaimer,
+		Examples: []string{
+			"unkey api auditlogs export --start=1701388800000 --end=1701475200000 > audit.ndjson",
+			"unkey api auditlogs export --events=key.create,key.delete",
+			"unkey api auditlogs export --limit=1000 --no-follow",
+		},
+		Flags: append([]cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			cli.Int64("limit", "Maximum number of events per request."),
+			cli.Bool("no-follow", "Stop after the first request instead of following continuation cursors.", cli.Default(false)),
+		}, filterFlags()...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			req := openapi.V2AuditlogsExportAuditLogsRequestBody(filters(cmd))
+			out := bufio.NewWriter(os.Stdout)
+			defer func() { _ = out.Flush() }()
+
+			for {
+				cursor, err := exportPage(ctx, cmd, req, out)
+				if err != nil {
+					return err
+				}
+				if cursor == "" {
+					return nil
+				}
+				if cmd.Bool("no-follow") {
+					_, err := fmt.Fprintf(out, "{\"cursor\":%q}\n", cursor)
+					return err
+				}
+				req.Cursor = &cursor
+			}
+		},
+	}
+}
+
+// exportPage copies one export response to out. It returns the continuation
+// cursor if the response ended with one.
+func exportPage(ctx context.Context, cmd *cli.Command, req openapi.V2AuditlogsExportAuditLogsRequestBody, out io.Writer) (string, error) {
+	res, err := util.Post(ctx, cmd, "/v2/auditlogs.exportAuditLogs", req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	scanner := bufio.NewScanner(res.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		// Events always carry an eventId; the stream's control lines never do.
+		var control struct {
+			EventID *string `json:"eventId"`
+			Cursor  *string `json:"cursor"`
+			Error   *struct {
+				Detail    string `json:"detail"`
+				RequestID string `json:"requestId"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(line, &control); err != nil {
+			return "", fmt.Errorf("failed to decode export line: %w", err)
+		}
+		if control.EventID == nil {
+			if control.Error != nil {
+				return "", fmt.Errorf("%s (request %s)", control.Error.Detail, control.Error.RequestID)
+			}
+			if control.Cursor != nil {
+				return *control.Cursor, nil
+			}
+		}
+
+		if _, err := out.Write(line); err != nil {
+			return "", err
+		}
+		if _, err := io.WriteString(out, "\n"); err != nil {
+			return "", err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read export stream: %w", err)
+	}
+
+	return "", nil
+}
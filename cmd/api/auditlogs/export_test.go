@@ -0,0 +1,77 @@
+package auditlogs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestExport(t *testing.T) {
+	req := util.CaptureRequestWithData[openapi.V2AuditlogsExportAuditLogsRequestBody](t, Cmd(),
+		"auditlogs export --events=key.create --start=1000 --limit=500 --no-follow", []any{})
+	require.Equal(t, openapi.V2AuditlogsExportAuditLogsRequestBody{
+		Events: ptr.P([]string{"key.create"}),
+		Start:  ptr.P(int64(1000)),
+		Limit:  ptr.P(500),
+	}, req)
+}
+
+func TestExportPage(t *testing.T) {
+	stream := func(lines ...string) *httptest.Server {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			_, _ = io.WriteString(w, strings.Join(lines, "\n")+"\n")
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+
+	run := func(srv *httptest.Server) (string, string, error) {
+		var cursor string
+		var pageErr error
+		var out bytes.Buffer
+		cmd := &cli.Command{
+			Name:  "export",
+			Flags: []cli.Flag{util.RootKeyFlag(), util.APIURLFlag(), util.ConfigFlag()},
+			Action: func(ctx context.Context, cmd *cli.Command) error {
+				cursor, pageErr = exportPage(ctx, cmd, openapi.V2AuditlogsExportAuditLogsRequestBody{}, &out)
+				return nil
+			},
+		}
+		require.NoError(t, cmd.Run(context.Background(), []string{"export", "--root-key=test_key", "--api-url=" + srv.URL}))
+		return cursor, out.String(), pageErr
+	}
+
+	t.Run("copies events and returns the cursor", func(t *testing.T) {
+		cursor, out, err := run(stream(`{"eventId":"log_1"}`, `{"eventId":"log_2"}`, `{"cursor":"1000_log_3"}`))
+		require.NoError(t, err)
+		require.Equal(t, "1000_log_3", cursor)
+		require.Equal(t, "{\"eventId\":\"log_1\"}\n{\"eventId\":\"log_2\"}\n", out)
+	})
+
+	t.Run("surfaces a mid-stream error", func(t *testing.T) {
+		_, out, err := run(stream(`{"eventId":"log_1"}`, `{"error":{"detail":"boom","requestId":"req_1"}}`))
+		require.ErrorContains(t, err, "boom")
+		require.Equal(t, "{\"eventId\":\"log_1\"}\n", out)
+	})
+
+	t.Run("keeps events that happen to carry a cursor field", func(t *testing.T) {
+		line, err := json.Marshal(map[string]any{"eventId": "log_1", "cursor": "meta"})
+		require.NoError(t, err)
+		cursor, out, err := run(stream(string(line)))
+		require.NoError(t, err)
+		require.Empty(t, cursor)
+		require.Equal(t, string(line)+"\n", out)
+	})
+}
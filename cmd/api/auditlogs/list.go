@@ -0,0 +1,55 @@
+package auditlogs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func listCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "list",
+		Usage: "List audit logs, newest first",
+		Description: `List your workspace's audit logs, newest first.
+
+Narrow the results by event type, actor, affected resource, correlation id and time range. Only events within your plan's audit log retention window are returned.
+
+Required permissions:
+- workspace.*.read_audit_log
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/auditlogs/list-audit-logs` + util.Disclaimer,
+		Examples: []string{
+			"unkey api auditlogs list",
+			"unkey api auditlogs list --events=key.create,key.delete --limit=50",
+			"unkey api auditlogs list --actor-ids=key_1234abcd",
+			"unkey api auditlogs list --target-ids=api_1234abcd --start=1701388800000 --end=1701475200000",
+		},
+		Flags: append([]cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.Int64("limit", "Maximum number of events to return per page."),
+		}, filterFlags()...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/auditlogs.listAuditLogs", filters(cmd))
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2AuditlogsListAuditLogsResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
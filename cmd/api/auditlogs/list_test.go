@@ -0,0 +1,58 @@
+package auditlogs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want openapi.V2AuditlogsListAuditLogsRequestBody
+	}{
+		{
+			name: "no filters",
+			args: "auditlogs list",
+			want: openapi.V2AuditlogsListAuditLogsRequestBody{},
+		},
+		{
+			name: "with events and limit",
+			args: "auditlogs list --events=key.create,key.delete --limit=50",
+			want: openapi.V2AuditlogsListAuditLogsRequestBody{
+				Events: ptr.P([]string{"key.create", "key.delete"}),
+				Limit:  ptr.P(50),
+			},
+		},
+		{
+			name: "with actor and target",
+			args: "auditlogs list --actor-ids=key_123 --target-ids=api_123,api_456",
+			want: openapi.V2AuditlogsListAuditLogsRequestBody{
+				ActorIds:  ptr.P([]string{"key_123"}),
+				TargetIds: ptr.P([]string{"api_123", "api_456"}),
+			},
+		},
+		{
+			name: "all optional flags",
+			args: "auditlogs list --correlation-id=corr_123 --start=1000 --end=2000 --cursor=1500_log_123 --limit=10",
+			want: openapi.V2AuditlogsListAuditLogsRequestBody{
+				CorrelationId: ptr.P("corr_123"),
+				Start:         ptr.P(int64(1000)),
+				End:           ptr.P(int64(2000)),
+				Cursor:        ptr.P("1500_log_123"),
+				Limit:         ptr.P(10),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := util.CaptureRequestWithData[openapi.V2AuditlogsListAuditLogsRequestBody](t, Cmd(), tt.args, []any{})
+			require.Equal(t, tt.want, req)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package auditlogs
+
+import (
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+// Cmd returns the auditlogs group command with all subcommands.
+func Cmd() *cli.Command {
+	return &cli.Command{
+		Name:        "auditlogs",
+		Usage:       "Read audit logs",
+		Description: "List and export your workspace's audit logs." + util.Disclaimer,
+		Commands: []*cli.Command{
+			listCmd(),
+			exportCmd(),
+		},
+	}
+}
+
+// filterFlags are the filters shared by list and export.
+func filterFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringSlice("events", "Comma-separated list of event types to include, such as key.create."),
+		cli.StringSlice("actor-ids", "Comma-separated list of actor ids, such as root key ids, to include."),
+		cli.StringSlice("target-ids", "Comma-separated list of resource ids; only events affecting one of them are included."),
+		cli.String("correlation-id", "Only include events of this logical action."),
+		cli.Int64("start", "Only include events at or after this unix timestamp in milliseconds."),
+		cli.Int64("end", "Only include events at or before this unix timestamp in milliseconds."),
+		cli.String("cursor", "Pagination cursor from a previous response."),
+	}
+}
+
+// filters builds a request body from the flags in [filterFlags]. The list and
+// export bodies have the same fields, so export converts the result.
+func filters(cmd *cli.Command) openapi.V2AuditlogsListAuditLogsRequestBody {
+	req := openapi.V2AuditlogsListAuditLogsRequestBody{
+		Events:        nil,
+		ActorIds:      nil,
+		TargetIds:     nil,
+		CorrelationId: nil,
+		Start:         nil,
+		End:           nil,
+		Cursor:        nil,
+		Limit:         nil,
+	}
+
+	if v := cmd.StringSlice("events"); len(v) > 0 {
+		req.Events = &v
+	}
+	if v := cmd.StringSlice("actor-ids"); len(v) > 0 {
+		req.ActorIds = &v
+	}
+	if v := cmd.StringSlice("target-ids"); len(v) > 0 {
+		req.TargetIds = &v
+	}
+	if v := cmd.String("correlation-id"); v != "" {
+		req.CorrelationId = &v
+	}
+	if v := cmd.Int64("start"); v != 0 {
+		req.Start = &v
+	}
+	if v := cmd.Int64("end"); v != 0 {
+		req.End = &v
+	}
+	if v := cmd.String("cursor"); v != "" {
+		req.Cursor = &v
+	}
+	if v := cmd.Int64("limit"); v != 0 {
+		req.Limit = ptr.P(int(v))
+	}
+
+	return req
+}
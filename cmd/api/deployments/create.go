@@ -0,0 +1,120 @@
+package deployments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func createCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "create",
+		Usage: "Create a deployment from an image, a git ref, or a previous deployment",
+		Description: `Create a deployment for an app environment.
+
+Choose exactly one source:
+- --image deploys a prebuilt Docker image as-is.
+- --branch, --commit-sha and --repository build from the app's connected GitHub repository. With none of them set the app's default branch is built; --repository builds a fork commit and requires --commit-sha.
+- --redeploy re-runs an existing deployment.
+
+The command returns as soon as the deployment is created. Use 'unkey api deployments get' to follow its status.
+
+Required permissions:
+- environment.*.create_deployment
+- environment.<environment_id>.create_deployment
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/deployments/create-deployment` + util.Disclaimer,
+		Examples: []string{
+			"unkey api deployments create --project=acme --app=api --environment=production --image=ghcr.io/acme/api:v1.2.0",
+			"unkey api deployments create --project=acme --app=api --environment=preview --branch=feature/login",
+			"unkey api deployments create --project=acme --app=api --environment=preview --repository=contributor/api --commit-sha=3f2a1b9",
+			"unkey api deployments create --project=acme --app=api --environment=production --redeploy=d_1234abcd",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("project", "The project ID or slug.", cli.Required()),
+			cli.String("app", "The app ID or slug.", cli.Required()),
+			cli.String("environment", "The environment ID or slug.", cli.Required()),
+			cli.String("image", "Docker image to deploy as-is."),
+			cli.String("branch", "Branch to build from the app's connected repository."),
+			cli.String("commit-sha", "Commit to build. Takes precedence over --branch."),
+			cli.String("repository", "Fork to build from, as owner/repo. Requires --commit-sha."),
+			cli.Bool("git", "Build the app's default branch from its connected repository.", cli.Default(false)),
+			cli.String("redeploy", "ID of an existing deployment to re-run."),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			req := openapi.V2DeploymentsCreateDeploymentRequestBody{
+				Project:     cmd.String("project"),
+				App:         cmd.String("app"),
+				Environment: cmd.String("environment"),
+				Image:       nil,
+				Git:         nil,
+				Deployment:  nil,
+			}
+
+			sources := 0
+			if v := cmd.String("image"); v != "" {
+				sources++
+				req.Image = &openapi.DeploymentSourceImage{DockerImage: v}
+			}
+			if git := gitSource(cmd); git != nil {
+				sources++
+				req.Git = git
+			}
+			if v := cmd.String("redeploy"); v != "" {
+				sources++
+				req.Deployment = &openapi.DeploymentSourceDeployment{DeploymentId: v}
+			}
+			if sources != 1 {
+				return fmt.Errorf("exactly one source is required: --image, --git (or --branch, --commit-sha, --repository), or --redeploy")
+			}
+
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/deployments.createDeployment", req)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2DeploymentsCreateDeploymentResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
+
+// gitSource builds the git source from the create flags, or returns nil when
+// none of them are set.
+func gitSource(cmd *cli.Command) *openapi.DeploymentSourceGit {
+	branch, sha, repository := cmd.String("branch"), cmd.String("commit-sha"), cmd.String("repository")
+	if !cmd.Bool("git") && branch == "" && sha == "" && repository == "" {
+		return nil
+	}
+
+	git := &openapi.DeploymentSourceGit{
+		Branch:     nil,
+		CommitSha:  nil,
+		Repository: nil,
+	}
+	if branch != "" {
+		git.Branch = &branch
+	}
+	if sha != "" {
+		git.CommitSha = &sha
+	}
+	if repository != "" {
+		git.Repository = &repository
+	}
+	return git
+}
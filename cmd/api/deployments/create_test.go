@@ -0,0 +1,59 @@
+package deployments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       string
+		image      *openapi.DeploymentSourceImage
+		git        *openapi.DeploymentSourceGit
+		deployment *openapi.DeploymentSourceDeployment
+	}{
+		{
+			name:  "from image",
+			args:  "--image=ghcr.io/acme/api:v1",
+			image: &openapi.DeploymentSourceImage{DockerImage: "ghcr.io/acme/api:v1"},
+		},
+		{
+			name: "from default branch",
+			args: "--git",
+			git:  &openapi.DeploymentSourceGit{},
+		},
+		{
+			name: "from branch",
+			args: "--branch=feature/login",
+			git:  &openapi.DeploymentSourceGit{Branch: ptr.P("feature/login")},
+		},
+		{
+			name: "from fork commit",
+			args: "--repository=contributor/api --commit-sha=3f2a1b9",
+			git:  &openapi.DeploymentSourceGit{Repository: ptr.P("contributor/api"), CommitSha: ptr.P("3f2a1b9")},
+		},
+		{
+			name:       "redeploy",
+			args:       "--redeploy=d_1234abcd",
+			deployment: &openapi.DeploymentSourceDeployment{DeploymentId: "d_1234abcd"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := util.CaptureRequest[openapi.V2DeploymentsCreateDeploymentRequestBody](t, Cmd(),
+				"deployments create --project=acme --app=api --environment=preview "+tt.args)
+			require.Equal(t, "acme", req.Project)
+			require.Equal(t, "api", req.App)
+			require.Equal(t, "preview", req.Environment)
+			require.Equal(t, tt.image, req.Image)
+			require.Equal(t, tt.git, req.Git)
+			require.Equal(t, tt.deployment, req.Deployment)
+		})
+	}
+}
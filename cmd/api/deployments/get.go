@@ -0,0 +1,55 @@
+package deployments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func getCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "get",
+		Usage: "Get a deployment's status and configuration",
+		Description: `Retrieve a single deployment, including its status and runtime configuration.
+
+Deployments are created asynchronously; poll this command until the status is ready, or one of failed, skipped, superseded, stopped, or cancelled.
+
+Required permissions:
+- environment.*.read_deployment
+- environment.<environment_id>.read_deployment
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/deployments/get-deployment` + util.Disclaimer,
+		Examples: []string{
+			"unkey api deployments get --deployment-id=d_1234abcd",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("deployment-id", "The deployment ID.", cli.Required()),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/deployments.getDeployment", openapi.V2DeploymentsGetDeploymentRequestBody{
+				DeploymentId: cmd.String("deployment-id"),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2DeploymentsGetDeploymentResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
@@ -0,0 +1,91 @@
+package deployments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func listCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "list",
+		Usage: "List deployments, newest first",
+		Description: `List deployments in your workspace, newest first.
+
+All filters are optional, but they nest: --app requires --project, and --environment requires both --project and --app. Results are paginated; when the response reports hasMore, pass its cursor to --cursor to fetch the next page.
+
+Required permissions:
+- environment.*.read_deployment
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/deployments/list-deployments` + util.Disclaimer,
+		Examples: []string{
+			"unkey api deployments list",
+			"unkey api deployments list --project=acme --app=api --environment=production",
+			"unkey api deployments list --project=acme --status=failed,cancelled --limit=20",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("project", "Only include deployments of this project, by ID or slug."),
+			cli.String("app", "Only include deployments of this app, by ID or slug."),
+			cli.String("environment", "Only include deployments in this environment, by ID or slug."),
+			cli.StringSlice("status", "Comma-separated list of statuses to include, such as ready,failed."),
+			cli.Int64("limit", "Maximum number of deployments to return per page."),
+			cli.String("cursor", "Pagination cursor from a previous response."),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			req := openapi.V2DeploymentsListDeploymentsRequestBody{
+				Project:     nil,
+				App:         nil,
+				Environment: nil,
+				Status:      nil,
+				Limit:       nil,
+				Cursor:      nil,
+			}
+			if v := cmd.String("project"); v != "" {
+				req.Project = &v
+			}
+			if v := cmd.String("app"); v != "" {
+				req.App = &v
+			}
+			if v := cmd.String("environment"); v != "" {
+				req.Environment = &v
+			}
+			if v := cmd.StringSlice("status"); len(v) > 0 {
+				statuses := make([]openapi.DeploymentStatus, len(v))
+				for i, s := range v {
+					statuses[i] = openapi.DeploymentStatus(s)
+				}
+				req.Status = &statuses
+			}
+			if v := cmd.Int64("limit"); v != 0 {
+				req.Limit = ptr.P(int(v))
+			}
+			if v := cmd.String("cursor"); v != "" {
+				req.Cursor = &v
+			}
+
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/deployments.listDeployments", req)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2DeploymentsListDeploymentsResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package deployments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want openapi.V2DeploymentsListDeploymentsRequestBody
+	}{
+		{
+			name: "no filters",
+			args: "deployments list",
+			want: openapi.V2DeploymentsListDeploymentsRequestBody{},
+		},
+		{
+			name: "all filters",
+			args: "deployments list --project=acme --app=api --environment=production --status=ready,failed --limit=20 --cursor=cursor_123",
+			want: openapi.V2DeploymentsListDeploymentsRequestBody{
+				Project:     ptr.P("acme"),
+				App:         ptr.P("api"),
+				Environment: ptr.P("production"),
+				Status:      ptr.P([]openapi.DeploymentStatus{openapi.DeploymentStatusReady, openapi.DeploymentStatusFailed}),
+				Limit:       ptr.P(20),
+				Cursor:      ptr.P("cursor_123"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := util.CaptureRequestWithData[openapi.V2DeploymentsListDeploymentsRequestBody](t, Cmd(), tt.args, []any{})
+			require.Equal(t, tt.want, req)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package deployments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func promoteCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "promote",
+		Usage: "Promote a ready deployment to serve live traffic",
+		Description: `Promote a deployment to become the current deployment for its environment.
+
+All sticky domains move from the current deployment to the promoted one, and the previous deployment is scheduled for standby. The deployment must be ready, belong to the production environment, and its app must already have a current deployment.
+
+Promoting the current deployment of a rolled-back app confirms the rollback and re-enables automatic promotion of future deployments.
+
+Required permissions:
+- environment.*.promote_deployment
+- environment.<environment_id>.promote_deployment
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/deployments/promote-deployment` + util.Disclaimer,
+		Examples: []string{
+			"unkey api deployments promote --deployment-id=d_1234abcd",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("deployment-id", "The deployment ID.", cli.Required()),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/deployments.promoteDeployment", openapi.V2DeploymentsPromoteDeploymentRequestBody{
+				DeploymentId: cmd.String("deployment-id"),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2DeploymentsPromoteDeploymentResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
@@ -0,0 +1,16 @@
+package deployments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestPromote(t *testing.T) {
+	req := util.CaptureRequest[openapi.V2DeploymentsPromoteDeploymentRequestBody](t, Cmd(), "deployments promote --deployment-id=d_1234abcd")
+	require.Equal(t, openapi.V2DeploymentsPromoteDeploymentRequestBody{
+		DeploymentId: "d_1234abcd",
+	}, req)
+}
@@ -0,0 +1,55 @@
+package deployments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func rollbackCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "rollback",
+		Usage: "Roll live traffic back to a previous deployment",
+		Description: `Roll live traffic back to a previous deployment. --deployment-id is the deployment to roll back to; the app's current deployment is rolled back from automatically.
+
+The target must be ready, belong to the production environment, and not already be the current deployment. Afterwards the app is marked as rolled back, which stops new deployments from taking over live traffic until you promote one with 'unkey api deployments promote'.
+
+Required permissions:
+- environment.*.rollback_deployment
+- environment.<environment_id>.rollback_deployment
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/deployments/rollback-deployment` + util.Disclaimer,
+		Examples: []string{
+			"unkey api deployments rollback --deployment-id=d_1234abcd",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("deployment-id", "The deployment ID.", cli.Required()),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/deployments.rollbackDeployment", openapi.V2DeploymentsRollbackDeploymentRequestBody{
+				DeploymentId: cmd.String("deployment-id"),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2DeploymentsRollbackDeploymentResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
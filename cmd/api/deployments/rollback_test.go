@@ -0,0 +1,16 @@
+package deployments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestRollback(t *testing.T) {
+	req := util.CaptureRequest[openapi.V2DeploymentsRollbackDeploymentRequestBody](t, Cmd(), "deployments rollback --deployment-id=d_1234abcd")
+	require.Equal(t, openapi.V2DeploymentsRollbackDeploymentRequestBody{
+		DeploymentId: "d_1234abcd",
+	}, req)
+}
@@ -0,0 +1,24 @@
+package deployments
+
+import (
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+)
+
+// Cmd returns the deployments group command with all subcommands.
+func Cmd() *cli.Command {
+	return &cli.Command{
+		Name:        "deployments",
+		Usage:       "Manage deployments",
+		Description: "Create, inspect, promote, roll back, stop, and start deployments." + util.Disclaimer,
+		Commands: []*cli.Command{
+			createCmd(),
+			getCmd(),
+			listCmd(),
+			promoteCmd(),
+			rollbackCmd(),
+			startCmd(),
+			stopCmd(),
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package deployments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func startCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "start",
+		Usage: "Start a stopped preview deployment",
+		Description: `Start a deployment that was previously stopped, so it serves traffic again. Nothing is rebuilt: the deployment keeps the configuration it had when it was stopped.
+
+Only stopped deployments outside the production environment can be started. Starting is asynchronous; use 'unkey api deployments get' until the status is ready.
+
+Required permissions:
+- environment.*.start_deployment
+- environment.<environment_id>.start_deployment
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/deployments/start-deployment` + util.Disclaimer,
+		Examples: []string{
+			"unkey api deployments start --deployment-id=d_1234abcd",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("deployment-id", "The deployment ID.", cli.Required()),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/deployments.startDeployment", openapi.V2DeploymentsStartDeploymentRequestBody{
+				DeploymentId: cmd.String("deployment-id"),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2DeploymentsStartDeploymentResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
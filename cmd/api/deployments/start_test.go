@@ -0,0 +1,16 @@
+package deployments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestStart(t *testing.T) {
+	req := util.CaptureRequest[openapi.V2DeploymentsStartDeploymentRequestBody](t, Cmd(), "deployments start --deployment-id=d_1234abcd")
+	require.Equal(t, openapi.V2DeploymentsStartDeploymentRequestBody{
+		DeploymentId: "d_1234abcd",
+	}, req)
+}
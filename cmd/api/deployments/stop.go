@@ -0,0 +1,55 @@
+package deployments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func stopCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "stop",
+		Usage: "Stop a running preview deployment",
+		Description: `Stop a running preview deployment to free up its resources. Stopped deployments keep their configuration and can be resumed with 'unkey api deployments start'.
+
+Production deployments cannot be stopped. Stopping is asynchronous; use 'unkey api deployments get' until the status is stopped.
+
+Required permissions:
+- environment.*.stop_deployment
+- environment.<environment_id>.stop_deployment
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/deployments/stop-deployment` + util.Disclaimer,
+		Examples: []string{
+			"unkey api deployments stop --deployment-id=d_1234abcd",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("deployment-id", "The deployment ID.", cli.Required()),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/deployments.stopDeployment", openapi.V2DeploymentsStopDeploymentRequestBody{
+				DeploymentId: cmd.String("deployment-id"),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2DeploymentsStopDeploymentResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
@@ -0,0 +1,16 @@
+package deployments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestStop(t *testing.T) {
+	req := util.CaptureRequest[openapi.V2DeploymentsStopDeploymentRequestBody](t, Cmd(), "deployments stop --deployment-id=d_1234abcd")
+	require.Equal(t, openapi.V2DeploymentsStopDeploymentRequestBody{
+		DeploymentId: "d_1234abcd",
+	}, req)
+}
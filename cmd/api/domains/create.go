@@ -0,0 +1,61 @@
+package domains
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func createCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "create",
+		Usage: "Attach a custom domain to an environment",
+		Description: `Attach a custom domain to an app environment.
+
+The domain starts out pending and serves no traffic until verification succeeds. The response lists every DNS record to create at your DNS provider; Unkey checks for them about once a minute for 24 hours. Use 'unkey api domains get' to follow verification and 'unkey api domains verify' to restart it after fixing the records. A domain name can only be attached to one environment per workspace.
+
+Required permissions:
+- environment.*.create_domain
+- environment.<environment_id>.create_domain
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/domains/create-domain` + util.Disclaimer,
+		Examples: []string{
+			"unkey api domains create --project=acme --app=api --environment=production --domain=api.acme.com",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("project", "The project ID or slug.", cli.Required()),
+			cli.String("app", "The app ID or slug.", cli.Required()),
+			cli.String("environment", "The environment ID or slug.", cli.Required()),
+			cli.String("domain", "Fully qualified domain name, without scheme, port, or path.", cli.Required()),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/domains.createDomain", openapi.V2DomainsCreateDomainRequestBody{
+				Project:     cmd.String("project"),
+				App:         cmd.String("app"),
+				Environment: cmd.String("environment"),
+				Domain:      cmd.String("domain"),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2DomainsCreateDomainResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
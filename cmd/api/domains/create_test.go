@@ -0,0 +1,20 @@
+package domains
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestCreate(t *testing.T) {
+	req := util.CaptureRequest[openapi.V2DomainsCreateDomainRequestBody](t, Cmd(),
+		"domains create --project=acme --app=api --environment=production --domain=api.acme.com")
+	require.Equal(t, openapi.V2DomainsCreateDomainRequestBody{
+		Project:     "acme",
+		App:         "api",
+		Environment: "production",
+		Domain:      "api.acme.com",
+	}, req)
+}
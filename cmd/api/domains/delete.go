@@ -0,0 +1,56 @@
+package domains
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func deleteCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "delete",
+		Usage: "Remove a custom domain",
+		Description: `Remove a custom domain from its environment. Unkey stops serving the domain; the DNS records at your provider stay in place.
+
+The domain can be addressed by its ID or by its name, which is unique per workspace.
+
+Required permissions:
+- environment.*.delete_domain
+- environment.<environment_id>.delete_domain
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/domains/delete-domain` + util.Disclaimer,
+		Examples: []string{
+			"unkey api domains delete --domain=api.acme.com",
+			"unkey api domains delete --domain=dom_1234abcd",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("domain", "The domain name or ID.", cli.Required()),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/domains.deleteDomain", openapi.V2DomainsDeleteDomainRequestBody{
+				Domain: cmd.String("domain"),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2DomainsDeleteDomainResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
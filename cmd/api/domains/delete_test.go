@@ -0,0 +1,16 @@
+package domains
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestDelete(t *testing.T) {
+	req := util.CaptureRequest[openapi.V2DomainsDeleteDomainRequestBody](t, Cmd(), "domains delete --domain=api.acme.com")
+	require.Equal(t, openapi.V2DomainsDeleteDomainRequestBody{
+		Domain: "api.acme.com",
+	}, req)
+}
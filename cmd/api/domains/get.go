@@ -0,0 +1,67 @@
+package domains
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func getCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "get",
+		Usage: "Get a custom domain and its DNS records",
+		Description: `Retrieve a custom domain, including its verification status and the DNS records it needs.
+
+The domain can be addressed by its ID or by its name, which is unique per workspace.
+
+Required permissions:
+- environment.*.read_domain
+- environment.<environment_id>.read_domain
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/domains/get-domain` + util.Disclaimer,
+		Examples: []string{
+			"unkey api domains get --domain=api.acme.com",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("domain", "The domain name or ID.", cli.Required()),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			body, err := getDomain(ctx, cmd, cmd.String("domain"))
+			if err != nil {
+				return err
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
+
+// getDomain fetches a domain by name or ID. verify reuses it to show the
+// records after restarting verification.
+func getDomain(ctx context.Context, cmd *cli.Command, domain string) (openapi.V2DomainsGetDomainResponseBody, error) {
+	var body openapi.V2DomainsGetDomainResponseBody
+
+	res, err := util.Post(ctx, cmd, "/v2/domains.getDomain", openapi.V2DomainsGetDomainRequestBody{
+		Domain: domain,
+	})
+	if err != nil {
+		return body, err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return body, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return body, nil
+}
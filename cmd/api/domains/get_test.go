@@ -0,0 +1,16 @@
+package domains
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestGet(t *testing.T) {
+	req := util.CaptureRequest[openapi.V2DomainsGetDomainRequestBody](t, Cmd(), "domains get --domain=dom_1234abcd")
+	require.Equal(t, openapi.V2DomainsGetDomainRequestBody{
+		Domain: "dom_1234abcd",
+	}, req)
+}
@@ -0,0 +1,78 @@
+package domains
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func listCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "list",
+		Usage: "List the custom domains of an environment",
+		Description: `List the custom domains attached to an app environment.
+
+Results are paginated. When the response reports hasMore, pass its cursor to --cursor to fetch the next page.
+
+Required permissions:
+- environment.*.read_domain
+- environment.<environment_id>.read_domain
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/domains/list-domains` + util.Disclaimer,
+		Examples: []string{
+			"unkey api domains list --project=acme --app=api --environment=production",
+			"unkey api domains list --project=acme --app=api --environment=production --search=acme.com",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("project", "The project ID or slug.", cli.Required()),
+			cli.String("app", "The app ID or slug.", cli.Required()),
+			cli.String("environment", "The environment ID or slug.", cli.Required()),
+			cli.String("search", "Only include domains whose ID or name contains this text."),
+			cli.Int64("limit", "Maximum number of domains to return per page."),
+			cli.String("cursor", "Pagination cursor from a previous response."),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			req := openapi.V2DomainsListDomainsRequestBody{
+				Project:     cmd.String("project"),
+				App:         cmd.String("app"),
+				Environment: cmd.String("environment"),
+				Search:      nil,
+				Limit:       nil,
+				Cursor:      nil,
+			}
+			if v := cmd.String("search"); v != "" {
+				req.Search = &v
+			}
+			if v := cmd.Int64("limit"); v != 0 {
+				req.Limit = ptr.P(int(v))
+			}
+			if v := cmd.String("cursor"); v != "" {
+				req.Cursor = &v
+			}
+
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/domains.listDomains", req)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2DomainsListDomainsResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
@@ -0,0 +1,47 @@
+package domains
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want openapi.V2DomainsListDomainsRequestBody
+	}{
+		{
+			name: "minimal required flags",
+			args: "domains list --project=acme --app=api --environment=production",
+			want: openapi.V2DomainsListDomainsRequestBody{
+				Project:     "acme",
+				App:         "api",
+				Environment: "production",
+			},
+		},
+		{
+			name: "all optional flags",
+			args: "domains list --project=acme --app=api --environment=production --search=acme.com --limit=5 --cursor=cursor_123",
+			want: openapi.V2DomainsListDomainsRequestBody{
+				Project:     "acme",
+				App:         "api",
+				Environment: "production",
+				Search:      ptr.P("acme.com"),
+				Limit:       ptr.P(5),
+				Cursor:      ptr.P("cursor_123"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := util.CaptureRequestWithData[openapi.V2DomainsListDomainsRequestBody](t, Cmd(), tt.args, []any{})
+			require.Equal(t, tt.want, req)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package domains
+
+import (
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+)
+
+// Cmd returns the domains group command with all subcommands.
+func Cmd() *cli.Command {
+	return &cli.Command{
+		Name:        "domains",
+		Usage:       "Manage custom domains",
+		Description: "Attach, inspect, verify, and remove the custom domains of an environment." + util.Disclaimer,
+		Commands: []*cli.Command{
+			createCmd(),
+			deleteCmd(),
+			getCmd(),
+			listCmd(),
+			verifyCmd(),
+		},
+	}
+}
@@ -0,0 +1,120 @@
+package domains
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/tui"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func verifyCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "verify",
+		Usage: "Restart verification of a custom domain and show its DNS records",
+		Description: `Restart verification of a custom domain and show the DNS records it needs.
+
+Run this after correcting the DNS records of a domain that failed verification, or to give a pending domain a fresh 24-hour verification period. The domain goes back to pending and Unkey checks the records about once a minute; run 'unkey api domains get' to follow the result.
+
+The records are printed as a table. With --output=json the domain is printed as returned by the API instead.
+
+Required permissions:
+- environment.*.verify_domain
+- environment.<environment_id>.verify_domain
+- environment.*.read_domain
+- environment.<environment_id>.read_domain
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/domains/verify-domain` + util.Disclaimer,
+		Examples: []string{
+			"unkey api domains verify --domain=api.acme.com",
+			"unkey api domains verify --domain=dom_1234abcd --output=json",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("domain", "The domain name or ID.", cli.Required()),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/domains.verifyDomain", openapi.V2DomainsVerifyDomainRequestBody{
+				Domain: cmd.String("domain"),
+			})
+			if err != nil {
+				return err
+			}
+			_ = res.Body.Close()
+
+			body, err := getDomain(ctx, cmd, cmd.String("domain"))
+			if err != nil {
+				return err
+			}
+
+			if cmd.String("output") == "json" {
+				return util.Output(cmd, body, time.Since(start))
+			}
+
+			printDomain(tui.New(os.Stdout), body.Data)
+			return nil
+		},
+	}
+}
+
+// printDomain renders a domain's verification state followed by a table of
+// the DNS records to create at the provider. Record notes are listed below
+// the table rather than in a column, since they are full sentences.
+func printDomain(out *tui.Renderer, domain openapi.Domain) {
+	out.Println(out.Bold(domain.Domain) + "  " + out.Dim(domain.Id))
+	out.KV().Indent(2).
+		Add("status", domainStatusLabel(out, domain.Status)).
+		AddIf("error", ptr.SafeDeref(domain.VerificationError)).
+		Print()
+
+	if len(domain.DnsRecords) == 0 {
+		return
+	}
+
+	out.Blank()
+	table := out.Table("TYPE", "NAME", "VALUE", "TTL", "FOUND").Indent(2)
+	var notes []string
+	for _, record := range domain.DnsRecords {
+		found := out.Yellow("no")
+		if record.Verified {
+			found = out.Green("yes")
+		}
+		table.Row(string(record.Type), record.Name, record.Value, strconv.Itoa(record.Ttl), found)
+		if note := ptr.SafeDeref(record.Note); note != "" {
+			notes = append(notes, record.Name+": "+note)
+		}
+	}
+	table.Print()
+
+	if len(notes) > 0 {
+		out.Blank()
+		for _, note := range notes {
+			out.Println("  " + out.Dim(note))
+		}
+	}
+}
+
+// domainStatusLabel colors a domain status by how much attention it needs:
+// verified is done, pending and verifying resolve on their own once the
+// records exist, failed needs the records fixed and another verify.
+func domainStatusLabel(out *tui.Renderer, status openapi.DomainStatus) string {
+	switch status {
+	case openapi.DomainStatusVerified:
+		return out.Green(string(status))
+	case openapi.DomainStatusPending, openapi.DomainStatusVerifying:
+		return out.Yellow(string(status))
+	case openapi.DomainStatusFailed:
+		return out.Red(string(status))
+	default:
+		return string(status)
+	}
+}
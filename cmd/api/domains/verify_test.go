@@ -0,0 +1,46 @@
+package domains
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/tui"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestVerify(t *testing.T) {
+	// verify restarts verification and then reads the domain back; both
+	// requests carry the same body, so the captured one covers either.
+	req := util.CaptureRequest[openapi.V2DomainsVerifyDomainRequestBody](t, Cmd(), "domains verify --domain=api.acme.com")
+	require.Equal(t, openapi.V2DomainsVerifyDomainRequestBody{
+		Domain: "api.acme.com",
+	}, req)
+}
+
+func TestPrintDomain(t *testing.T) {
+	var buf bytes.Buffer
+	printDomain(tui.NewWithColor(&buf, false), openapi.Domain{
+		Id:                "dom_1234abcd",
+		Domain:            "api.acme.com",
+		Status:            openapi.DomainStatusFailed,
+		VerificationError: ptr.P("no CNAME record found"),
+		DnsRecords: []openapi.DnsRecord{
+			{Type: "CNAME", Name: "api.acme.com", Value: "acme.unkey.app", Ttl: 300, Verified: false},
+			{Type: "TXT", Name: "_unkey.api.acme.com", Value: "unkey-verify=abc", Ttl: 300, Verified: true, Note: ptr.P("Proves ownership.")},
+		},
+	})
+
+	require.Equal(t, `api.acme.com  dom_1234abcd
+  status  failed
+  error   no CNAME record found
+
+  TYPE   NAME                 VALUE             TTL  FOUND
+  CNAME  api.acme.com         acme.unkey.app    300  no
+  TXT    _unkey.api.acme.com  unkey-verify=abc  300  yes
+
+  _unkey.api.acme.com: Proves ownership.
+`, buf.String())
+}
@@ -0,0 +1,188 @@
+package environments
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// dotenvVar is one KEY=VALUE assignment read from a .env file.
+type dotenvVar struct {
+	Key   string
+	Value string
+}
+
+// readDotenv reads and parses a .env file. A path of "-" reads stdin.
+func readDotenv(path string) ([]dotenvVar, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	vars, err := parseDotenv(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return vars, nil
+}
+
+// parseDotenv parses the .env dialect shared by mainstream dotenv loaders:
+//
+//   - blank lines and lines starting with # are ignored;
+//   - a leading "export " is allowed, so the file can also be sourced;
+//   - unquoted values are trimmed and end at a # preceded by whitespace;
+//   - single-quoted values are literal and may span lines;
+//   - double-quoted values may span lines and interpret \n, \r, \t, \", \\
+//     and \$ escapes.
+//
+// Variables are returned in the order they first appear. A key assigned more
+// than once keeps its last value, as a shell sourcing the file would.
+func parseDotenv(src string) ([]dotenvVar, error) {
+	var vars []dotenvVar
+	index := make(map[string]int)
+
+	pos := 0
+	for pos < len(src) {
+		start := pos
+		line, rest := cutLine(src[pos:])
+		pos += len(line) + len(rest)
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNumber(src, start))
+		}
+		key = strings.TrimSpace(strings.TrimPrefix(key, "export "))
+		if !isEnvName(key) {
+			return nil, fmt.Errorf("line %d: %q is not a valid variable name", lineNumber(src, start), key)
+		}
+
+		// Quoted values may run past the end of the current line, so they are
+		// scanned from the raw source rather than from the line.
+		valueStart := start + strings.Index(line, "=") + 1
+		for valueStart < len(src) && (src[valueStart] == ' ' || src[valueStart] == '\t') {
+			valueStart++
+		}
+
+		value = strings.TrimLeft(value, " \t")
+		var parsed string
+		switch {
+		case strings.HasPrefix(value, "'") || strings.HasPrefix(value, `"`):
+			v, end, err := scanQuoted(src, valueStart)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber(src, start), err)
+			}
+			tail, next := cutLine(src[end:])
+			if t := strings.TrimSpace(tail); t != "" && !strings.HasPrefix(t, "#") {
+				return nil, fmt.Errorf("line %d: unexpected %q after closing quote", lineNumber(src, end), t)
+			}
+			parsed = v
+			pos = end + len(tail) + len(next)
+		default:
+			parsed = stripInlineComment(value)
+		}
+
+		if i, seen := index[key]; seen {
+			vars[i].Value = parsed
+			continue
+		}
+		index[key] = len(vars)
+		vars = append(vars, dotenvVar{Key: key, Value: parsed})
+	}
+
+	return vars, nil
+}
+
+// cutLine splits s after its first line, returning the line without its
+// terminator and the terminator itself (empty at the end of input).
+func cutLine(s string) (line, newline string) {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i], s[i : i+1]
+	}
+	return s, ""
+}
+
+// scanQuoted reads the quoted value starting at src[start] and returns it
+// together with the offset just past the closing quote.
+func scanQuoted(src string, start int) (string, int, error) {
+	quote := src[start]
+	if quote == '\'' {
+		end := strings.IndexByte(src[start+1:], '\'')
+		if end < 0 {
+			return "", 0, fmt.Errorf("unterminated single-quoted value")
+		}
+		return src[start+1 : start+1+end], start + end + 2, nil
+	}
+
+	var b strings.Builder
+	for i := start + 1; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '"':
+			return b.String(), i + 1, nil
+		case c == '\\' && i+1 < len(src):
+			i++
+			switch src[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\', '$':
+				b.WriteByte(src[i])
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(src[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated double-quoted value")
+}
+
+// stripInlineComment removes a trailing comment from an unquoted value. Only a
+// # preceded by whitespace starts a comment, so values like a#b survive.
+func stripInlineComment(value string) string {
+	for i := 1; i < len(value); i++ {
+		if value[i] == '#' && (value[i-1] == ' ' || value[i-1] == '\t') {
+			return strings.TrimSpace(value[:i])
+		}
+	}
+	return strings.TrimSpace(value)
+}
+
+// isEnvName reports whether key is a POSIX shell variable name, the only form
+// the API accepts.
+func isEnvName(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case c == '_', c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// lineNumber returns the 1-based line of the byte at offset.
+func lineNumber(src string, offset int) int {
+	return strings.Count(src[:offset], "\n") + 1
+}
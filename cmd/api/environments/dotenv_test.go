@@ -0,0 +1,101 @@
+package environments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDotenv(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []dotenvVar
+	}{
+		{
+			name: "plain assignments, comments and blank lines",
+			src:  "# database\nDB_HOST=localhost\n\nDB_PORT = 5432\n",
+			want: []dotenvVar{{Key: "DB_HOST", Value: "localhost"}, {Key: "DB_PORT", Value: "5432"}},
+		},
+		{
+			name: "export prefix",
+			src:  "export TOKEN=abc\n",
+			want: []dotenvVar{{Key: "TOKEN", Value: "abc"}},
+		},
+		{
+			name: "inline comments need leading whitespace",
+			src:  "A=value # comment\nB=a#b\n",
+			want: []dotenvVar{{Key: "A", Value: "value"}, {Key: "B", Value: "a#b"}},
+		},
+		{
+			name: "empty value",
+			src:  "EMPTY=\n",
+			want: []dotenvVar{{Key: "EMPTY", Value: ""}},
+		},
+		{
+			name: "single quotes are literal",
+			src:  `A='$HOME \n # not a comment'` + "\n",
+			want: []dotenvVar{{Key: "A", Value: `$HOME \n # not a comment`}},
+		},
+		{
+			name: "double quotes interpret escapes",
+			src:  `A="line1\nline2 \"quoted\" \$5"` + "\n",
+			want: []dotenvVar{{Key: "A", Value: "line1\nline2 \"quoted\" $5"}},
+		},
+		{
+			name: "quoted values span lines",
+			src:  "KEY=\"-----BEGIN-----\nabc\n-----END-----\" # pem\nNEXT=1\n",
+			want: []dotenvVar{{Key: "KEY", Value: "-----BEGIN-----\nabc\n-----END-----"}, {Key: "NEXT", Value: "1"}},
+		},
+		{
+			name: "whitespace before a quoted value",
+			src:  "A= 'x y'\n",
+			want: []dotenvVar{{Key: "A", Value: "x y"}},
+		},
+		{
+			name: "crlf line endings",
+			src:  "A=1\r\nB='2'\r\n",
+			want: []dotenvVar{{Key: "A", Value: "1"}, {Key: "B", Value: "2"}},
+		},
+		{
+			name: "last assignment wins, first position kept",
+			src:  "A=1\nB=2\nA=3\n",
+			want: []dotenvVar{{Key: "A", Value: "3"}, {Key: "B", Value: "2"}},
+		},
+		{
+			name: "no trailing newline",
+			src:  "A=1",
+			want: []dotenvVar{{Key: "A", Value: "1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDotenv(tt.src)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseDotenvErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{name: "missing equals", src: "A=1\nJUSTAKEY\n", err: "line 2: expected KEY=VALUE"},
+		{name: "invalid name", src: "1A=1\n", err: `line 1: "1A" is not a valid variable name`},
+		{name: "dashed name", src: "MY-VAR=1\n", err: `line 1: "MY-VAR" is not a valid variable name`},
+		{name: "unterminated single quote", src: "A='abc\n", err: "line 1: unterminated single-quoted value"},
+		{name: "unterminated double quote", src: "A=\"abc\n", err: "line 1: unterminated double-quoted value"},
+		{name: "text after closing quote", src: "A='abc' def\n", err: `line 1: unexpected "def" after closing quote`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDotenv(tt.src)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
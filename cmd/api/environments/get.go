@@ -0,0 +1,55 @@
+package environments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func getCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "get",
+		Usage: "Get an environment and its settings",
+		Description: `Retrieve a single environment, including its build, runtime, and regional settings.
+
+Required permissions:
+- environment.*.read_environment
+- environment.<environment_id>.read_environment
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/environments/get-environment` + util.Disclaimer,
+		Examples: []string{
+			"unkey api environments get --project=acme --app=api --environment=production",
+			"unkey api env get --project=acme --app=api --environment=preview --output=json",
+		},
+		Flags: append([]cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+		}, environmentFlags()...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/environments.getEnvironment", openapi.V2EnvironmentsGetEnvironmentRequestBody{
+				Project:     cmd.String("project"),
+				App:         cmd.String("app"),
+				Environment: cmd.String("environment"),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2EnvironmentsGetEnvironmentResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package environments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+	}{
+		{name: "group name", args: "environments get --project=acme --app=api --environment=production"},
+		{name: "env alias", args: "env get --project=acme --app=api --environment=production"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := util.CaptureRequest[openapi.V2EnvironmentsGetEnvironmentRequestBody](t, Cmd(), tt.args)
+			require.Equal(t, openapi.V2EnvironmentsGetEnvironmentRequestBody{
+				Project:     "acme",
+				App:         "api",
+				Environment: "production",
+			}, req)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package environments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func listCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "list",
+		Usage: "List the environments of an app",
+		Description: `List every environment of an app. Apps have only a handful of environments, so all of them are returned at once.
+
+Required permissions:
+- environment.*.read_environment
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/environments/list-environments` + util.Disclaimer,
+		Examples: []string{
+			"unkey api environments list --project=acme --app=api",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("project", "The project ID or slug.", cli.Required()),
+			cli.String("app", "The app ID or slug.", cli.Required()),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/environments.listEnvironments", openapi.V2EnvironmentsListEnvironmentsRequestBody{
+				Project: cmd.String("project"),
+				App:     cmd.String("app"),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2EnvironmentsListEnvironmentsResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
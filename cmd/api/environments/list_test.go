@@ -0,0 +1,17 @@
+package environments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestList(t *testing.T) {
+	req := util.CaptureRequestWithData[openapi.V2EnvironmentsListEnvironmentsRequestBody](t, Cmd(), "env list --project=acme --app=api", []any{})
+	require.Equal(t, openapi.V2EnvironmentsListEnvironmentsRequestBody{
+		Project: "acme",
+		App:     "api",
+	}, req)
+}
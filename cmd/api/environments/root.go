@@ -0,0 +1,34 @@
+package environments
+
+import (
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+)
+
+// Cmd returns the environments group command with all subcommands.
+func Cmd() *cli.Command {
+	return &cli.Command{
+		Name:        "environments",
+		Aliases:     []string{"env"},
+		Usage:       "Manage app environments and their variables",
+		Description: "Inspect app environments, update their settings, and manage their environment variables." + util.Disclaimer,
+		Commands: []*cli.Command{
+			getCmd(),
+			listCmd(),
+			setCmd(),
+			unsetCmd(),
+			updateCmd(),
+			varsCmd(),
+		},
+	}
+}
+
+// environmentFlags are the flags that locate an environment, shared by every
+// command that addresses a single environment.
+func environmentFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.String("project", "The project ID or slug.", cli.Required()),
+		cli.String("app", "The app ID or slug.", cli.Required()),
+		cli.String("environment", "The environment ID or slug.", cli.Required()),
+	}
+}
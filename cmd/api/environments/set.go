@@ -0,0 +1,97 @@
+package environments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func setCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "set",
+		Usage: "Set environment variables from a .env file",
+		Description: `Set the environment variables of an environment from a .env file, in a single atomic request.
+
+Every variable in the file is created, or overwritten if its key already exists. Variables that are not in the file are left untouched unless --prune is set, in which case they are deleted and the environment ends up with exactly the file's variables.
+
+The file uses the usual .env syntax: KEY=VALUE per line, # comments, an optional export prefix, and single- or double-quoted values that may span lines. Use --file=- to read from stdin. Variables are write-only unless --kind=recoverable is set, and at most 50 can be set per request.
+
+Required permissions:
+- environment.*.set_environment_variables
+- environment.<environment_id>.set_environment_variables
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/environments/set-environment-variables` + util.Disclaimer,
+		Examples: []string{
+			"unkey api env set --project=acme --app=api --environment=production",
+			"unkey api env set --project=acme --app=api --environment=preview --file=.env.preview --kind=recoverable",
+			"unkey api env set --project=acme --app=api --environment=production --file=.env.production --prune",
+			"grep -v ^DEBUG_ .env | unkey api env set --project=acme --app=api --environment=production --file=-",
+		},
+		Flags: append([]cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("file", "Path to the .env file, or - for stdin.", cli.Default(".env")),
+			cli.String("kind", "How values may be read back: writeonly or recoverable. Defaults to writeonly."),
+			cli.Bool("prune", "Delete every variable that is not in the file.", cli.Default(false)),
+		}, environmentFlags()...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			vars, err := readDotenv(cmd.String("file"))
+			if err != nil {
+				return err
+			}
+			if len(vars) == 0 && !cmd.Bool("prune") {
+				return fmt.Errorf("%s contains no variables", cmd.String("file"))
+			}
+
+			var kind *openapi.EnvironmentVariableKind
+			switch v := openapi.EnvironmentVariableKind(cmd.String("kind")); v {
+			case "":
+			case openapi.Writeonly, openapi.Recoverable:
+				kind = &v
+			default:
+				return fmt.Errorf("invalid --kind %q: must be writeonly or recoverable", v)
+			}
+
+			req := openapi.V2EnvironmentsSetEnvironmentVariablesRequestBody{
+				Project:     cmd.String("project"),
+				App:         cmd.String("app"),
+				Environment: cmd.String("environment"),
+				Variables:   make([]openapi.EnvironmentVariableInput, len(vars)),
+				Prune:       nil,
+			}
+			for i, v := range vars {
+				req.Variables[i] = openapi.EnvironmentVariableInput{
+					Key:         v.Key,
+					Value:       v.Value,
+					Kind:        kind,
+					Description: nil,
+				}
+			}
+			if cmd.Bool("prune") {
+				req.Prune = ptr.P(true)
+			}
+
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/environments.setEnvironmentVariables", req)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2EnvironmentsSetEnvironmentVariablesResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
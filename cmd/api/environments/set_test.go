@@ -0,0 +1,58 @@
+package environments
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(path, []byte("# api\nDATABASE_URL=postgres://db\nexport GREETING=\"hello\\nworld\"\n"), 0o600))
+
+	tests := []struct {
+		name string
+		args string
+		want openapi.V2EnvironmentsSetEnvironmentVariablesRequestBody
+	}{
+		{
+			name: "upsert from file",
+			args: "env set --project=acme --app=api --environment=production --file=" + path,
+			want: openapi.V2EnvironmentsSetEnvironmentVariablesRequestBody{
+				Project:     "acme",
+				App:         "api",
+				Environment: "production",
+				Variables: []openapi.EnvironmentVariableInput{
+					{Key: "DATABASE_URL", Value: "postgres://db"},
+					{Key: "GREETING", Value: "hello\nworld"},
+				},
+			},
+		},
+		{
+			name: "recoverable with prune",
+			args: "env set --project=acme --app=api --environment=production --kind=recoverable --prune --file=" + path,
+			want: openapi.V2EnvironmentsSetEnvironmentVariablesRequestBody{
+				Project:     "acme",
+				App:         "api",
+				Environment: "production",
+				Variables: []openapi.EnvironmentVariableInput{
+					{Key: "DATABASE_URL", Value: "postgres://db", Kind: ptr.P(openapi.Recoverable)},
+					{Key: "GREETING", Value: "hello\nworld", Kind: ptr.P(openapi.Recoverable)},
+				},
+				Prune: ptr.P(true),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := util.CaptureRequest[openapi.V2EnvironmentsSetEnvironmentVariablesRequestBody](t, Cmd(), tt.args)
+			require.Equal(t, tt.want, req)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package environments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func unsetCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "unset",
+		Usage: "Remove environment variables",
+		Description: `Remove environment variables from an environment, in a single atomic request.
+
+Name the variables with --keys, or with --file to remove every key listed in a .env file; the file's values are ignored. Both can be combined. Keys that do not exist are ignored. At most 50 variables can be removed per request.
+
+Required permissions:
+- environment.*.remove_environment_variables
+- environment.<environment_id>.remove_environment_variables
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/environments/remove-environment-variables` + util.Disclaimer,
+		Examples: []string{
+			"unkey api env unset --project=acme --app=api --environment=production --keys=LEGACY_TOKEN,OLD_DSN",
+			"unkey api env unset --project=acme --app=api --environment=preview --file=.env.preview",
+		},
+		Flags: append([]cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.StringSlice("keys", "Comma-separated list of variable names to remove."),
+			cli.String("file", "Path to a .env file whose keys to remove, or - for stdin."),
+		}, environmentFlags()...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			keys := cmd.StringSlice("keys")
+			if path := cmd.String("file"); path != "" {
+				vars, err := readDotenv(path)
+				if err != nil {
+					return err
+				}
+				for _, v := range vars {
+					keys = append(keys, v.Key)
+				}
+			}
+			if len(keys) == 0 {
+				return fmt.Errorf("no variables to remove: pass --keys or --file")
+			}
+
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/environments.removeEnvironmentVariables", openapi.V2EnvironmentsRemoveEnvironmentVariablesRequestBody{
+				Project:     cmd.String("project"),
+				App:         cmd.String("app"),
+				Environment: cmd.String("environment"),
+				Variables:   keys,
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2EnvironmentsRemoveEnvironmentVariablesResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
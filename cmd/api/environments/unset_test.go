@@ -0,0 +1,50 @@
+package environments
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestUnset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(path, []byte("OLD_DSN=postgres://old\nLEGACY_FLAG=1\n"), 0o600))
+
+	tests := []struct {
+		name string
+		args string
+		want []string
+	}{
+		{
+			name: "keys",
+			args: "env unset --project=acme --app=api --environment=production --keys=A,B",
+			want: []string{"A", "B"},
+		},
+		{
+			name: "keys from file",
+			args: "env unset --project=acme --app=api --environment=production --file=" + path,
+			want: []string{"OLD_DSN", "LEGACY_FLAG"},
+		},
+		{
+			name: "keys and file combined",
+			args: "env unset --project=acme --app=api --environment=production --keys=A --file=" + path,
+			want: []string{"A", "OLD_DSN", "LEGACY_FLAG"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := util.CaptureRequest[openapi.V2EnvironmentsRemoveEnvironmentVariablesRequestBody](t, Cmd(), tt.args)
+			require.Equal(t, openapi.V2EnvironmentsRemoveEnvironmentVariablesRequestBody{
+				Project:     "acme",
+				App:         "api",
+				Environment: "production",
+				Variables:   tt.want,
+			}, req)
+		})
+	}
+}
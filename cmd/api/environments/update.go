@@ -0,0 +1,158 @@
+package environments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/oapi-codegen/nullable"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func updateCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "update",
+		Usage: "Update an environment's build, runtime, and regional settings",
+		Description: `Update the build, runtime, and regional settings of an environment.
+
+Only the flags you pass are changed. Pass an empty value to --build-command, --dockerfile, or --openapi-spec-path to clear it, and --healthcheck-json=null to remove the healthcheck. --regions-json replaces the full set of regions; regions missing from it are removed.
+
+Required permissions:
+- environment.*.update_environment
+- environment.<environment_id>.update_environment
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/environments/update-environment-settings` + util.Disclaimer,
+		Examples: []string{
+			"unkey api environments update --project=acme --app=api --environment=production --vcpus=1 --memory-mib=1024",
+			"unkey api env update --project=acme --app=api --environment=production --port=3000 --dockerfile=Dockerfile.prod",
+			"unkey api env update --project=acme --app=api --environment=preview --build-command=",
+			`unkey api env update --project=acme --app=api --environment=production --regions-json='[{"name":"us-east-1","replicas":{"min":2,"max":5}}]'`,
+			`unkey api env update --project=acme --app=api --environment=production --healthcheck-json='{"method":"GET","path":"/health"}'`,
+		},
+		Flags: append([]cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.Bool("auto-deploy", "Whether pushes to the tracked branch deploy automatically."),
+			cli.String("root-directory", "Directory the app is built from, relative to the repository root."),
+			cli.String("build-command", "Build command overriding auto-detection. Empty clears it."),
+			cli.String("dockerfile", "Path to the Dockerfile used for builds. Empty clears it."),
+			cli.StringSlice("watch-paths", "Comma-separated glob paths that trigger auto-deploys when changed."),
+			cli.StringSlice("command", "Comma-separated container entrypoint command override."),
+			cli.Int64("port", "Container port the app listens on."),
+			cli.Float("vcpus", "CPU allocation in vCPUs, in steps of 0.25."),
+			cli.Int64("memory-mib", "Memory allocation in MiB, in steps of 256."),
+			cli.Int64("storage-mib", "Ephemeral storage in MiB, in steps of 512 (0 for none)."),
+			cli.String("shutdown-signal", "Signal sent to the container on shutdown: SIGTERM, SIGINT, SIGQUIT, or SIGKILL."),
+			cli.String("upstream-protocol", "Protocol used to reach the container: http1 or h2c."),
+			cli.String("openapi-spec-path", "Path to the OpenAPI spec within the build, starting with /. Empty clears it."),
+			cli.String("healthcheck-json", "Healthcheck configuration as a JSON object, or null to remove it."),
+			cli.String("regions-json", "Regions with replica bounds as a JSON array. Replaces the full set."),
+		}, environmentFlags()...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			req := openapi.V2EnvironmentsUpdateSettingsRequestBody{
+				Project:          cmd.String("project"),
+				App:              cmd.String("app"),
+				Environment:      cmd.String("environment"),
+				AutoDeploy:       nil,
+				RootDirectory:    nil,
+				BuildCommand:     nil,
+				Dockerfile:       nil,
+				WatchPaths:       nil,
+				Command:          nil,
+				Port:             nil,
+				VCpus:            nil,
+				MemoryMib:        nil,
+				StorageMib:       nil,
+				ShutdownSignal:   nil,
+				UpstreamProtocol: nil,
+				OpenapiSpecPath:  nil,
+				Healthcheck:      nil,
+				Regions:          nil,
+			}
+
+			if cmd.FlagIsSet("auto-deploy") {
+				req.AutoDeploy = ptr.P(cmd.Bool("auto-deploy"))
+			}
+			if v := cmd.String("root-directory"); v != "" {
+				req.RootDirectory = &v
+			}
+			req.BuildCommand = clearableString(cmd, "build-command")
+			req.Dockerfile = clearableString(cmd, "dockerfile")
+			req.OpenapiSpecPath = clearableString(cmd, "openapi-spec-path")
+			if v := cmd.StringSlice("watch-paths"); len(v) > 0 {
+				req.WatchPaths = &v
+			}
+			if v := cmd.StringSlice("command"); len(v) > 0 {
+				req.Command = &v
+			}
+			if cmd.FlagIsSet("port") {
+				req.Port = ptr.P(int(cmd.Int64("port")))
+			}
+			if cmd.FlagIsSet("vcpus") {
+				req.VCpus = ptr.P(cmd.Float("vcpus"))
+			}
+			if cmd.FlagIsSet("memory-mib") {
+				req.MemoryMib = ptr.P(int(cmd.Int64("memory-mib")))
+			}
+			if cmd.FlagIsSet("storage-mib") {
+				req.StorageMib = ptr.P(int(cmd.Int64("storage-mib")))
+			}
+			if v := cmd.String("shutdown-signal"); v != "" {
+				req.ShutdownSignal = ptr.P(openapi.EnvironmentShutdownSignal(v))
+			}
+			if v := cmd.String("upstream-protocol"); v != "" {
+				req.UpstreamProtocol = ptr.P(openapi.EnvironmentUpstreamProtocol(v))
+			}
+
+			if v := cmd.String("healthcheck-json"); v == "null" {
+				req.Healthcheck = nullable.NewNullNullable[openapi.EnvironmentHealthcheck]()
+			} else if v != "" {
+				var healthcheck openapi.EnvironmentHealthcheck
+				if err := json.Unmarshal([]byte(v), &healthcheck); err != nil {
+					return fmt.Errorf("invalid JSON for --healthcheck-json: %w", err)
+				}
+				req.Healthcheck = nullable.NewNullableWithValue(healthcheck)
+			}
+
+			if v := cmd.String("regions-json"); v != "" {
+				var regions []openapi.EnvironmentRegion
+				if err := json.Unmarshal([]byte(v), &regions); err != nil {
+					return fmt.Errorf("invalid JSON for --regions-json: %w", err)
+				}
+				req.Regions = &regions
+			}
+
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/environments.updateSettings", req)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2EnvironmentsUpdateSettingsResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
+
+// clearableString maps a string flag onto a nullable field: unset leaves the
+// setting unchanged, an explicit empty value clears it.
+func clearableString(cmd *cli.Command, name string) nullable.Nullable[string] {
+	if !cmd.FlagIsSet(name) {
+		return nil
+	}
+	if v := cmd.String(name); v != "" {
+		return nullable.NewNullableWithValue(v)
+	}
+	return nullable.NewNullNullable[string]()
+}
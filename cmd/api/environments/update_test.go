@@ -0,0 +1,84 @@
+package environments
+
+import (
+	"testing"
+
+	"github.com/oapi-codegen/nullable"
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestUpdate(t *testing.T) {
+	const env = "env update --project=acme --app=api --environment=production "
+
+	tests := []struct {
+		name string
+		args string
+		want openapi.V2EnvironmentsUpdateSettingsRequestBody
+	}{
+		{
+			name: "runtime resources",
+			args: env + "--vcpus=0.5 --memory-mib=1024 --storage-mib=0 --port=3000",
+			want: openapi.V2EnvironmentsUpdateSettingsRequestBody{
+				VCpus:      ptr.P(0.5),
+				MemoryMib:  ptr.P(1024),
+				StorageMib: ptr.P(0),
+				Port:       ptr.P(3000),
+			},
+		},
+		{
+			name: "build settings",
+			args: env + "--auto-deploy=false --root-directory=services/api --dockerfile=Dockerfile.prod --watch-paths=services/api/**,go.mod",
+			want: openapi.V2EnvironmentsUpdateSettingsRequestBody{
+				AutoDeploy:    ptr.P(false),
+				RootDirectory: ptr.P("services/api"),
+				Dockerfile:    nullable.NewNullableWithValue("Dockerfile.prod"),
+				WatchPaths:    ptr.P([]string{"services/api/**", "go.mod"}),
+			},
+		},
+		{
+			name: "clear nullable settings",
+			args: env + "--build-command= --openapi-spec-path= --healthcheck-json=null",
+			want: openapi.V2EnvironmentsUpdateSettingsRequestBody{
+				BuildCommand:    nullable.NewNullNullable[string](),
+				OpenapiSpecPath: nullable.NewNullNullable[string](),
+				Healthcheck:     nullable.NewNullNullable[openapi.EnvironmentHealthcheck](),
+			},
+		},
+		{
+			name: "json settings",
+			args: env + `--healthcheck-json={"method":"GET","path":"/health"} --regions-json=[{"name":"us-east-1","replicas":{"min":2,"max":5}}]`,
+			want: openapi.V2EnvironmentsUpdateSettingsRequestBody{
+				Healthcheck: nullable.NewNullableWithValue(openapi.EnvironmentHealthcheck{
+					Method: "GET",
+					Path:   "/health",
+				}),
+				Regions: ptr.P([]openapi.EnvironmentRegion{
+					{Name: "us-east-1", Replicas: openapi.Replicas{Min: 2, Max: 5}},
+				}),
+			},
+		},
+		{
+			name: "runtime protocol and signal",
+			args: env + "--shutdown-signal=SIGINT --upstream-protocol=h2c --command=node,server.js",
+			want: openapi.V2EnvironmentsUpdateSettingsRequestBody{
+				ShutdownSignal:   ptr.P(openapi.SIGINT),
+				UpstreamProtocol: ptr.P(openapi.H2c),
+				Command:          ptr.P([]string{"node", "server.js"}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.want.Project = "acme"
+			tt.want.App = "api"
+			tt.want.Environment = "production"
+
+			req := util.CaptureRequest[openapi.V2EnvironmentsUpdateSettingsRequestBody](t, Cmd(), tt.args)
+			require.Equal(t, tt.want, req)
+		})
+	}
+}
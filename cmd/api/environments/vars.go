@@ -0,0 +1,70 @@
+package environments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func varsCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "vars",
+		Usage: "List the environment variables of an environment",
+		Description: `List the environment variables of an environment.
+
+Recoverable variables are returned with their decrypted value. Write-only variables never expose their value; only the key, kind, and description are returned. Results are paginated; when the response reports hasMore, pass its cursor to --cursor to fetch the next page.
+
+Required permissions:
+- environment.*.read_environment_variables
+- environment.<environment_id>.read_environment_variables
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/environments/list-environment-variables` + util.Disclaimer,
+		Examples: []string{
+			"unkey api environments vars --project=acme --app=api --environment=production",
+			"unkey api env vars --project=acme --app=api --environment=preview --limit=100",
+		},
+		Flags: append([]cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.Int64("limit", "Maximum number of variables to return per page."),
+			cli.String("cursor", "Pagination cursor from a previous response."),
+		}, environmentFlags()...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			req := openapi.V2EnvironmentsListEnvironmentVariablesRequestBody{
+				Project:     cmd.String("project"),
+				App:         cmd.String("app"),
+				Environment: cmd.String("environment"),
+				Limit:       nil,
+				Cursor:      nil,
+			}
+			if v := cmd.Int64("limit"); v != 0 {
+				req.Limit = ptr.P(int(v))
+			}
+			if v := cmd.String("cursor"); v != "" {
+				req.Cursor = &v
+			}
+
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/environments.listEnvironmentVariables", req)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2EnvironmentsListEnvironmentVariablesResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package environments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestVars(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want openapi.V2EnvironmentsListEnvironmentVariablesRequestBody
+	}{
+		{
+			name: "minimal required flags",
+			args: "env vars --project=acme --app=api --environment=production",
+			want: openapi.V2EnvironmentsListEnvironmentVariablesRequestBody{
+				Project:     "acme",
+				App:         "api",
+				Environment: "production",
+			},
+		},
+		{
+			name: "with pagination",
+			args: "env vars --project=acme --app=api --environment=production --limit=100 --cursor=cursor_123",
+			want: openapi.V2EnvironmentsListEnvironmentVariablesRequestBody{
+				Project:     "acme",
+				App:         "api",
+				Environment: "production",
+				Limit:       ptr.P(100),
+				Cursor:      ptr.P("cursor_123"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := util.CaptureRequestWithData[openapi.V2EnvironmentsListEnvironmentVariablesRequestBody](t, Cmd(), tt.args, []any{})
+			require.Equal(t, tt.want, req)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func listCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "list",
+		Usage: "List an environment's gateway policies in evaluation order",
+		Description: `List an environment's gateway policies in evaluation order. The gateway evaluates them top to bottom and the first rejection short-circuits the request.
+
+The policy IDs in the response are what 'unkey api gateway update' expects.
+
+Required permissions:
+- environment.*.read_policies
+- environment.<environment_id>.read_policies
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/gateway/list-policies` + util.Disclaimer,
+		Examples: []string{
+			"unkey api gateway list --project=acme --app=api --environment=production",
+			"unkey api gateway list --project=acme --app=api --environment=production --output=json | jq .data > policies.json",
+		},
+		Flags: append([]cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+		}, environmentFlags()...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/gateway.listPolicies", openapi.V2GatewayListPoliciesRequestBody{
+				Project:     cmd.String("project"),
+				App:         cmd.String("app"),
+				Environment: cmd.String("environment"),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2GatewayListPoliciesResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
@@ -0,0 +1,19 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestList(t *testing.T) {
+	req := util.CaptureRequestWithData[openapi.V2GatewayListPoliciesRequestBody](t, Cmd(),
+		"gateway list --project=acme --app=api --environment=production", []any{})
+	require.Equal(t, openapi.V2GatewayListPoliciesRequestBody{
+		Project:     "acme",
+		App:         "api",
+		Environment: "production",
+	}, req)
+}
@@ -0,0 +1,30 @@
+package gateway
+
+import (
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+)
+
+// Cmd returns the gateway group command with all subcommands.
+func Cmd() *cli.Command {
+	return &cli.Command{
+		Name:        "gateway",
+		Usage:       "Manage gateway policies",
+		Description: "Read and change the gateway policies that run in front of an environment." + util.Disclaimer,
+		Commands: []*cli.Command{
+			listCmd(),
+			setCmd(),
+			updateCmd(),
+		},
+	}
+}
+
+// environmentFlags are the flags that locate the environment whose policies
+// a command reads or changes.
+func environmentFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.String("project", "The project ID or slug.", cli.Required()),
+		cli.String("app", "The app ID or slug.", cli.Required()),
+		cli.String("environment", "The environment ID or slug.", cli.Required()),
+	}
+}
@@ -0,0 +1,90 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func setCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "set",
+		Usage: "Replace an environment's gateway policies",
+		Description: `Replace an environment's gateway policies with the list in a JSON file, in a single atomic request.
+
+The file holds a JSON array of policies in evaluation order. Each policy sets exactly one of keyauth, ratelimit, firewall, or openapi, plus optional match expressions. Every call is a full replace: an empty array removes all policies, and every policy gets a new ID. If any policy is invalid, nothing is written.
+
+The data of 'unkey api gateway list --output=json' can be edited and passed back as-is; the policy IDs in it are ignored. Use --file=- to read from stdin.
+
+Required permissions:
+- environment.*.set_policies
+- environment.<environment_id>.set_policies
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/gateway/set-policies` + util.Disclaimer,
+		Examples: []string{
+			"unkey api gateway set --project=acme --app=api --environment=production --file=policies.json",
+			"echo '[]' | unkey api gateway set --project=acme --app=api --environment=preview --file=-",
+		},
+		Flags: append([]cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("file", "Path to a JSON file with the policy array, or - for stdin.", cli.Required()),
+		}, environmentFlags()...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			policies, err := readPolicies(cmd.String("file"))
+			if err != nil {
+				return err
+			}
+
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/gateway.setPolicies", openapi.V2GatewaySetPoliciesRequestBody{
+				Project:     cmd.String("project"),
+				App:         cmd.String("app"),
+				Environment: cmd.String("environment"),
+				Policies:    policies,
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2GatewaySetPoliciesResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
+
+// readPolicies reads a JSON policy array from path, or from stdin for "-".
+func readPolicies(path string) ([]openapi.Policy, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	// A nil slice would marshal as null, which the API rejects; an empty file
+	// array means "remove every policy".
+	policies := []openapi.Policy{}
+	if err := json.Unmarshal(data, &policies); err != nil {
+		return nil, fmt.Errorf("invalid policy JSON in %s: %w", path, err)
+	}
+	return policies, nil
+}
@@ -0,0 +1,60 @@
+package gateway
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestSet(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	// The listed policy carries an id, as in `gateway list` output; it is dropped.
+	policies := write("policies.json", `[
+		{"id": "pol_old", "name": "auth", "enabled": true, "keyauth": {"keyspaces": ["ks_123"]}},
+		{"name": "limit", "enabled": false, "ratelimit": {"limit": 100, "windowMs": 60000}}
+	]`)
+	empty := write("empty.json", `[]`)
+
+	tests := []struct {
+		name string
+		file string
+		want []openapi.Policy
+	}{
+		{
+			name: "replace with file",
+			file: policies,
+			want: []openapi.Policy{
+				{Name: "auth", Enabled: true, Keyauth: &openapi.KeyauthPolicy{Keyspaces: []string{"ks_123"}}},
+				{Name: "limit", Enabled: false, Ratelimit: &openapi.RatelimitPolicy{Limit: 100, WindowMs: 60000}},
+			},
+		},
+		{
+			name: "empty array removes every policy",
+			file: empty,
+			want: []openapi.Policy{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := util.CaptureRequest[openapi.V2GatewaySetPoliciesRequestBody](t, Cmd(),
+				"gateway set --project=acme --app=api --environment=production --file="+tt.file)
+			require.Equal(t, openapi.V2GatewaySetPoliciesRequestBody{
+				Project:     "acme",
+				App:         "api",
+				Environment: "production",
+				Policies:    tt.want,
+			}, req)
+		})
+	}
+}
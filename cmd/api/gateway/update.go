@@ -0,0 +1,118 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/oapi-codegen/nullable"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func updateCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "update",
+		Usage: "Update a single gateway policy in place",
+		Description: `Update one gateway policy without resending the environment's full policy list. The policy keeps its ID and its position in the evaluation order.
+
+Only the flags you pass are changed, and at least one is required. Passing one of --keyauth-json, --ratelimit-json, --firewall-json, or --openapi-json replaces the policy's rule entirely, including its type. --match-json=null removes all match expressions so the policy applies to every request.
+
+Policy IDs change whenever 'unkey api gateway set' replaces the list; get the current ones from 'unkey api gateway list'.
+
+Required permissions:
+- environment.*.update_policy
+- environment.<environment_id>.update_policy
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/gateway/update-policy` + util.Disclaimer,
+		Examples: []string{
+			"unkey api gateway update --project=acme --app=api --environment=production --policy-id=pol_1234abcd --enabled=false",
+			"unkey api gateway update --project=acme --app=api --environment=production --policy-id=pol_1234abcd --match-json=null",
+			`unkey api gateway update --project=acme --app=api --environment=production --policy-id=pol_1234abcd --keyauth-json='{"keyspaces":["ks_1234abcd"]}'`,
+		},
+		Flags: append([]cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("policy-id", "The policy ID.", cli.Required()),
+			cli.String("name", "New policy name."),
+			cli.Bool("enabled", "Whether the gateway evaluates the policy."),
+			cli.String("match-json", "Match expressions as a JSON array, or null to remove them."),
+			cli.String("keyauth-json", "Key authentication rule as a JSON object."),
+			cli.String("ratelimit-json", "Rate limit rule as a JSON object."),
+			cli.String("firewall-json", "Firewall rule as a JSON object."),
+			cli.String("openapi-json", "OpenAPI validation rule as a JSON object."),
+			cli.String("logging-json", "Request logging settings as a JSON object."),
+		}, environmentFlags()...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			req := openapi.V2GatewayUpdatePolicyRequestBody{
+				Project:     cmd.String("project"),
+				App:         cmd.String("app"),
+				Environment: cmd.String("environment"),
+				PolicyId:    cmd.String("policy-id"),
+				Name:        nil,
+				Enabled:     nil,
+				Match:       nil,
+				Keyauth:     nil,
+				Ratelimit:   nil,
+				Firewall:    nil,
+				Openapi:     nil,
+				Logging:     nil,
+			}
+			if v := cmd.String("name"); v != "" {
+				req.Name = &v
+			}
+			if cmd.FlagIsSet("enabled") {
+				req.Enabled = ptr.P(cmd.Bool("enabled"))
+			}
+
+			if v := cmd.String("match-json"); v == "null" {
+				req.Match = nullable.NewNullNullable[[]openapi.MatchExpr]()
+			} else if v != "" {
+				var match []openapi.MatchExpr
+				if err := json.Unmarshal([]byte(v), &match); err != nil {
+					return fmt.Errorf("invalid JSON for --match-json: %w", err)
+				}
+				req.Match = nullable.NewNullableWithValue(match)
+			}
+
+			rules := []struct {
+				flag string
+				dst  any
+			}{
+				{"keyauth-json", &req.Keyauth},
+				{"ratelimit-json", &req.Ratelimit},
+				{"firewall-json", &req.Firewall},
+				{"openapi-json", &req.Openapi},
+				{"logging-json", &req.Logging},
+			}
+			for _, rule := range rules {
+				v := cmd.String(rule.flag)
+				if v == "" {
+					continue
+				}
+				if err := json.Unmarshal([]byte(v), rule.dst); err != nil {
+					return fmt.Errorf("invalid JSON for --%s: %w", rule.flag, err)
+				}
+			}
+
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/gateway.updatePolicy", req)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2GatewayUpdatePolicyResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
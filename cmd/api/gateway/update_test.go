@@ -0,0 +1,65 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/oapi-codegen/nullable"
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestUpdate(t *testing.T) {
+	const policy = "gateway update --project=acme --app=api --environment=production --policy-id=pol_123 "
+
+	tests := []struct {
+		name string
+		args string
+		want openapi.V2GatewayUpdatePolicyRequestBody
+	}{
+		{
+			name: "disable and rename",
+			args: policy + "--enabled=false --name=auth",
+			want: openapi.V2GatewayUpdatePolicyRequestBody{
+				Enabled: ptr.P(false),
+				Name:    ptr.P("auth"),
+			},
+		},
+		{
+			name: "remove match expressions",
+			args: policy + "--match-json=null",
+			want: openapi.V2GatewayUpdatePolicyRequestBody{
+				Match: nullable.NewNullNullable[[]openapi.MatchExpr](),
+			},
+		},
+		{
+			name: "replace match expressions",
+			args: policy + `--match-json=[{"path":{"path":{"prefix":"/v1"}}}]`,
+			want: openapi.V2GatewayUpdatePolicyRequestBody{
+				Match: nullable.NewNullableWithValue([]openapi.MatchExpr{
+					{Path: &openapi.PathMatch{Path: openapi.StringMatch{Prefix: ptr.P("/v1")}}},
+				}),
+			},
+		},
+		{
+			name: "replace rule",
+			args: policy + `--ratelimit-json={"limit":10,"windowMs":1000}`,
+			want: openapi.V2GatewayUpdatePolicyRequestBody{
+				Ratelimit: &openapi.RatelimitPolicy{Limit: 10, WindowMs: 1000},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.want.Project = "acme"
+			tt.want.App = "api"
+			tt.want.Environment = "production"
+			tt.want.PolicyId = "pol_123"
+
+			req := util.CaptureRequest[openapi.V2GatewayUpdatePolicyRequestBody](t, Cmd(), tt.args)
+			require.Equal(t, tt.want, req)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package projects
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func createCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "create",
+		Usage: "Create a project",
+		Description: `Create a project in your workspace.
+
+A project groups the apps that make up one product. The slug must be unique within the workspace and can be used in place of the project ID in every other command.
+
+Required permissions:
+- project.*.create_project
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/projects/create-project` + util.Disclaimer,
+		Examples: []string{
+			"unkey api projects create --name=Acme --slug=acme",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("name", "Human-readable project name.", cli.Required()),
+			cli.String("slug", "URL-safe project slug, unique within the workspace.", cli.Required()),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/projects.createProject", openapi.V2ProjectsCreateProjectRequestBody{
+				Name: cmd.String("name"),
+				Slug: cmd.String("slug"),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2ProjectsCreateProjectResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
@@ -0,0 +1,17 @@
+package projects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestCreate(t *testing.T) {
+	req := util.CaptureRequest[openapi.V2ProjectsCreateProjectRequestBody](t, Cmd(), "projects create --name=Acme --slug=acme")
+	require.Equal(t, openapi.V2ProjectsCreateProjectRequestBody{
+		Name: "Acme",
+		Slug: "acme",
+	}, req)
+}
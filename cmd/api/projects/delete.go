@@ -0,0 +1,56 @@
+package projects
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func deleteCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "delete",
+		Usage: "Delete a project and everything in it",
+		Description: `Delete a project together with its apps, environments, deployments, and domains.
+
+Deletion runs in the background: the command returns once it is enqueued. Projects with delete protection enabled must have it disabled first with 'unkey api projects update --delete-protection=false'.
+
+Required permissions:
+- project.*.delete_project
+- project.<project_id>.delete_project
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/projects/delete-project` + util.Disclaimer,
+		Examples: []string{
+			"unkey api projects delete --project=acme",
+			"unkey api projects delete --project=proj_1234abcd",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("project", "The project ID or slug.", cli.Required()),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/projects.deleteProject", openapi.V2ProjectsDeleteProjectRequestBody{
+				Project: cmd.String("project"),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2ProjectsDeleteProjectResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
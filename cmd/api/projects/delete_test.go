@@ -0,0 +1,16 @@
+package projects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestDelete(t *testing.T) {
+	req := util.CaptureRequest[openapi.V2ProjectsDeleteProjectRequestBody](t, Cmd(), "projects delete --project=proj_1234abcd")
+	require.Equal(t, openapi.V2ProjectsDeleteProjectRequestBody{
+		Project: "proj_1234abcd",
+	}, req)
+}
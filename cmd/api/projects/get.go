@@ -0,0 +1,54 @@
+package projects
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func getCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "get",
+		Usage: "Get a project by ID or slug",
+		Description: `Retrieve a single project by its ID or slug.
+
+Required permissions:
+- project.*.read_project
+- project.<project_id>.read_project
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/projects/get-project` + util.Disclaimer,
+		Examples: []string{
+			"unkey api projects get --project=acme",
+			"unkey api projects get --project=proj_1234abcd --output=json",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("project", "The project ID or slug.", cli.Required()),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/projects.getProject", openapi.V2ProjectsGetProjectRequestBody{
+				Project: cmd.String("project"),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2ProjectsGetProjectResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
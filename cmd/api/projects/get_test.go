@@ -0,0 +1,35 @@
+package projects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want openapi.V2ProjectsGetProjectRequestBody
+	}{
+		{
+			name: "by slug",
+			args: "projects get --project=acme",
+			want: openapi.V2ProjectsGetProjectRequestBody{Project: "acme"},
+		},
+		{
+			name: "by id",
+			args: "projects get --project=proj_1234abcd",
+			want: openapi.V2ProjectsGetProjectRequestBody{Project: "proj_1234abcd"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := util.CaptureRequest[openapi.V2ProjectsGetProjectRequestBody](t, Cmd(), tt.args)
+			require.Equal(t, tt.want, req)
+		})
+	}
+}
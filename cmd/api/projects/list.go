@@ -0,0 +1,71 @@
+package projects
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func listCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "list",
+		Usage: "List projects in your workspace",
+		Description: `List the projects in your workspace.
+
+Results are paginated. When the response reports hasMore, pass its cursor to --cursor to fetch the next page.
+
+Required permissions:
+- project.*.read_project
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/projects/list-projects` + util.Disclaimer,
+		Examples: []string{
+			"unkey api projects list",
+			"unkey api projects list --search=acme --limit=10",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("search", "Only include projects whose ID, name, or slug contains this text."),
+			cli.Int64("limit", "Maximum number of projects to return per page."),
+			cli.String("cursor", "Pagination cursor from a previous response."),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			req := openapi.V2ProjectsListProjectsRequestBody{
+				Search: nil,
+				Limit:  nil,
+				Cursor: nil,
+			}
+			if v := cmd.String("search"); v != "" {
+				req.Search = &v
+			}
+			if v := cmd.Int64("limit"); v != 0 {
+				req.Limit = ptr.P(int(v))
+			}
+			if v := cmd.String("cursor"); v != "" {
+				req.Cursor = &v
+			}
+
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/projects.listProjects", req)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2ProjectsListProjectsResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package projects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want openapi.V2ProjectsListProjectsRequestBody
+	}{
+		{
+			name: "no filters",
+			args: "projects list",
+			want: openapi.V2ProjectsListProjectsRequestBody{},
+		},
+		{
+			name: "all optional flags",
+			args: "projects list --search=acme --limit=10 --cursor=cursor_123",
+			want: openapi.V2ProjectsListProjectsRequestBody{
+				Search: ptr.P("acme"),
+				Limit:  ptr.P(10),
+				Cursor: ptr.P("cursor_123"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := util.CaptureRequestWithData[openapi.V2ProjectsListProjectsRequestBody](t, Cmd(), tt.args, []any{})
+			require.Equal(t, tt.want, req)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package projects
+
+import (
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+)
+
+// Cmd returns the projects group command with all subcommands.
+func Cmd() *cli.Command {
+	return &cli.Command{
+		Name:        "projects",
+		Usage:       "Manage projects",
+		Description: "Create, read, update, and delete projects." + util.Disclaimer,
+		Commands: []*cli.Command{
+			createCmd(),
+			deleteCmd(),
+			getCmd(),
+			listCmd(),
+			updateCmd(),
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package projects
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func updateCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "update",
+		Usage: "Rename a project or toggle its delete protection",
+		Description: `Update a project's name, slug, or delete protection.
+
+Only the flags you pass are changed. Changing the slug also changes the deployment domains generated for the project.
+
+Required permissions:
+- project.*.update_project
+- project.<project_id>.update_project
+
+For full documentation, see https://www.unkey.com/docs/api-reference/v2/projects/update-project` + util.Disclaimer,
+		Examples: []string{
+			"unkey api projects update --project=acme --name=\"Acme Inc\"",
+			"unkey api projects update --project=acme --slug=acme-inc",
+			"unkey api projects update --project=acme --delete-protection=true",
+		},
+		Flags: []cli.Flag{
+			util.RootKeyFlag(),
+			util.APIURLFlag(),
+			util.ConfigFlag(),
+			util.OutputFlag(),
+			cli.String("project", "The project ID or slug.", cli.Required()),
+			cli.String("name", "New project name."),
+			cli.String("slug", "New project slug."),
+			cli.Bool("delete-protection", "Whether the project is protected from deletion."),
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			req := openapi.V2ProjectsUpdateProjectRequestBody{
+				Project:          cmd.String("project"),
+				Name:             nil,
+				Slug:             nil,
+				DeleteProtection: nil,
+			}
+			if v := cmd.String("name"); v != "" {
+				req.Name = &v
+			}
+			if v := cmd.String("slug"); v != "" {
+				req.Slug = &v
+			}
+			if cmd.FlagIsSet("delete-protection") {
+				req.DeleteProtection = ptr.P(cmd.Bool("delete-protection"))
+			}
+
+			start := time.Now()
+			res, err := util.Post(ctx, cmd, "/v2/projects.updateProject", req)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = res.Body.Close() }()
+
+			var body openapi.V2ProjectsUpdateProjectResponseBody
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return util.Output(cmd, body, time.Since(start))
+		},
+	}
+}
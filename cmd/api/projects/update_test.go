@@ -0,0 +1,43 @@
+package projects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/cmd/api/util"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want openapi.V2ProjectsUpdateProjectRequestBody
+	}{
+		{
+			name: "rename",
+			args: "projects update --project=acme --name=Acme --slug=acme-inc",
+			want: openapi.V2ProjectsUpdateProjectRequestBody{
+				Project: "acme",
+				Name:    ptr.P("Acme"),
+				Slug:    ptr.P("acme-inc"),
+			},
+		},
+		{
+			name: "disable delete protection",
+			args: "projects update --project=acme --delete-protection=false",
+			want: openapi.V2ProjectsUpdateProjectRequestBody{
+				Project:          "acme",
+				DeleteProtection: ptr.P(false),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := util.CaptureRequest[openapi.V2ProjectsUpdateProjectRequestBody](t, Cmd(), tt.args)
+			require.Equal(t, tt.want, req)
+		})
+	}
+}
@@ -3,9 +3,16 @@ package api
 import (
 	"github.com/unkeyed/unkey/cmd/api/analytics"
 	"github.com/unkeyed/unkey/cmd/api/apis"
+	"github.com/unkeyed/unkey/cmd/api/apps"
+	"github.com/unkeyed/unkey/cmd/api/auditlogs"
+	"github.com/unkeyed/unkey/cmd/api/deployments"
+	"github.com/unkeyed/unkey/cmd/api/domains"
+	"github.com/unkeyed/unkey/cmd/api/environments"
+	"github.com/unkeyed/unkey/cmd/api/gateway"
 	"github.com/unkeyed/unkey/cmd/api/identities"
 	"github.com/unkeyed/unkey/cmd/api/keys"
 	"github.com/unkeyed/unkey/cmd/api/permissions"
+	"github.com/unkeyed/unkey/cmd/api/projects"
 	"github.com/unkeyed/unkey/cmd/api/ratelimit"
 	"github.com/unkeyed/unkey/cmd/api/util"
 	"github.com/unkeyed/unkey/pkg/cli"
@@ -16,13 +23,20 @@ func Cmd() *cli.Command {
 	return &cli.Command{
 		Name:        "api",
 		Usage:       "Interact with the Unkey API",
-		Description: "Manage APIs, keys, identities, permissions, rate limits, and analytics." + util.Disclaimer,
+		Description: "Manage APIs, keys, identities, permissions, rate limits, analytics, projects, apps, environments, deployments, domains, and gateway policies, and read audit logs." + util.Disclaimer,
 		Commands: []*cli.Command{
 			analytics.Cmd(),
 			apis.Cmd(),
+			apps.Cmd(),
+			auditlogs.Cmd(),
+			deployments.Cmd(),
+			domains.Cmd(),
+			environments.Cmd(),
+			gateway.Cmd(),
 			identities.Cmd(),
 			keys.Cmd(),
 			permissions.Cmd(),
+			projects.Cmd(),
 			ratelimit.Cmd(),
 		},
 	}
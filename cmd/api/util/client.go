@@ -11,14 +11,9 @@ import (
 // 1. --root-key flag or UNKEY_ROOT_KEY env var (handled by the flag's EnvVar option)
 // 2. Config file at ~/.unkey/config.toml (from unkey auth login)
 func CreateClient(cmd *cli.Command) (*unkey.Unkey, error) {
-	key := cmd.String("root-key")
-
-	if key == "" {
-		cfg, err := cli.LoadUserConfig(cmd.String("config"))
-		if err != nil {
-			return nil, fmt.Errorf("no root key provided\n\nProvide one via:\n  --root-key flag\n  UNKEY_ROOT_KEY environment variable\n  unkey auth login")
-		}
-		key = cfg.RootKey
+	key, err := RootKey(cmd)
+	if err != nil {
+		return nil, err
 	}
 
 	opts := []unkey.SDKOption{
@@ -30,3 +25,16 @@ func CreateClient(cmd *cli.Command) (*unkey.Unkey, error) {
 
 	return unkey.New(opts...), nil
 }
+
+// RootKey resolves the root key the same way [CreateClient] does.
+func RootKey(cmd *cli.Command) (string, error) {
+	if key := cmd.String("root-key"); key != "" {
+		return key, nil
+	}
+
+	cfg, err := cli.LoadUserConfig(cmd.String("config"))
+	if err != nil {
+		return "", fmt.Errorf("no root key provided\n\nProvide one via:\n  --root-key flag\n  UNKEY_ROOT_KEY environment variable\n  unkey auth login")
+	}
+	return cfg.RootKey, nil
+}
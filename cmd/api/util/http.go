@@ -0,0 +1,84 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/unkeyed/unkey/pkg/cli"
+)
+
+// Post calls an API route directly, for routes the SDK does not cover yet.
+// The body is sent as JSON with the root key from [RootKey]. On success the
+// caller owns the response and must close its body; error responses are
+// closed here and returned as an error in the same wording as [FormatError].
+func Post(ctx context.Context, cmd *cli.Command, path string, body any) (*http.Response, error) {
+	key, err := RootKey(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	url := strings.TrimSuffix(cmd.String("api-url"), "/") + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+key)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return res, nil
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	return nil, fmt.Errorf("%s", formatResponseError(res))
+}
+
+// formatResponseError converts an API error response into a human-readable
+// message.
+func formatResponseError(res *http.Response) string {
+	raw, _ := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+
+	var envelope struct {
+		Error struct {
+			Detail string `json:"detail"`
+			Errors []struct {
+				Location string `json:"location"`
+				Message  string `json:"message"`
+			} `json:"errors"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil || envelope.Error.Detail == "" {
+		return fmt.Sprintf("request failed with status %d: %s", res.StatusCode, strings.TrimSpace(string(raw)))
+	}
+
+	detail := envelope.Error.Detail
+	switch res.StatusCode {
+	case http.StatusForbidden:
+		return fmt.Sprintf("Permission denied: %s", detail)
+	case http.StatusUnauthorized:
+		return fmt.Sprintf("Authentication failed: %s\n\nCheck your root key or run 'unkey auth login'", detail)
+	case http.StatusNotFound:
+		return fmt.Sprintf("Not found: %s", detail)
+	case http.StatusBadRequest:
+		for _, ve := range envelope.Error.Errors {
+			detail += fmt.Sprintf("\n  %s: %s", ve.Location, ve.Message)
+		}
+		return detail
+	default:
+		return detail
+	}
+}
@@ -96,6 +96,7 @@ func seed(ctx context.Context, cmd *cli.Command) error {
 		Consumers:     4,
 		Drop:          false,
 		OnFlushError:  nil,
+		Spool:         nil,
 	})
 
 	rows := gen.generate(buf)
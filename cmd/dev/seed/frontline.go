@@ -64,6 +64,7 @@ func seedFrontline(ctx context.Context, cmd *cli.Command) error {
 		Consumers:     consumers,
 		Drop:          false,
 		OnFlushError:  nil,
+		Spool:         nil,
 	})
 
 	deploymentID := cmd.String("deployment-id")
@@ -272,6 +273,7 @@ func (s *FrontlineSeeder) generateRequests(
 			RequestBody:     "",
 			ResponseHeaders: []string{},
 			ResponseBody:    "",
+			FirewallMatches: []string{},
 		})
 
 		if (i+1)%10000 == 0 {
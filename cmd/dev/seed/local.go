@@ -461,6 +461,7 @@ func seedLocal(ctx context.Context, cmd *cli.Command) error {
 			RefillDay:          sql.NullInt16{},
 			RefillAmount:       sql.NullInt64{},
 			PendingMigrationID: sql.NullString{},
+			Environment:        sql.NullString{},
 		})
 		if err != nil && !db.IsDuplicateKeyError(err) {
 			return fmt.Errorf("failed to create root key: %w", err)
@@ -510,6 +511,10 @@ func seedLocal(ctx context.Context, cmd *cli.Command) error {
 			"portal.*.update_portal",
 			"portal.*.delete_portal",
 			"portal.*.create_portal_session",
+			"webhook.*.create_webhook",
+			"webhook.*.read_webhook",
+			"webhook.*.update_webhook",
+			"webhook.*.delete_webhook",
 		}
 
 		permissionParams := make([]db.InsertPermissionParams, len(allPermissions))
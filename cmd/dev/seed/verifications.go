@@ -67,6 +67,7 @@ func seedVerifications(ctx context.Context, cmd *cli.Command) error {
 		Consumers:     2,
 		Drop:          true,
 		OnFlushError:  nil,
+		Spool:         nil,
 	})
 
 	// Create key service for proper key generation
@@ -295,6 +296,7 @@ func (s *Seeder) createKeysBatched(ctx context.Context, workspaceID, keyAuthID,
 			RefillDay:          sql.NullInt16{},
 			RefillAmount:       sql.NullInt64{},
 			PendingMigrationID: sql.NullString{Valid: false, String: ""},
+			Environment:        sql.NullString{Valid: false, String: ""},
 		}
 
 		allKeys[i] = key
@@ -497,6 +499,7 @@ func (s *Seeder) generateVerifications(_ context.Context, workspaceID string, ke
 			SpentCredits: credit,
 			Source:       schema.SourceAPI,
 			AppID:        "",
+			Environment:  "",
 		})
 
 		// Log progress periodically
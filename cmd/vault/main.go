@@ -0,0 +1,15 @@
+package vault
+
+import (
+	"github.com/unkeyed/unkey/pkg/cli"
+)
+
+// Cmd is the vault command that groups operational tasks for the vault
+// service, such as rotating its master key.
+var Cmd = &cli.Command{
+	Name:  "vault",
+	Usage: "Administer the vault service",
+	Commands: []*cli.Command{
+		rotateMasterKeyCmd,
+	},
+}
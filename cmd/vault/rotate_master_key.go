@@ -0,0 +1,54 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/unkeyed/unkey/pkg/cli"
+	"github.com/unkeyed/unkey/pkg/config"
+	"github.com/unkeyed/unkey/svc/vault"
+)
+
+var rotateMasterKeyCmd = &cli.Command{
+	Name:  "rotate-master-key",
+	Usage: "Re-wrap every data encryption key with a new master key",
+	Description: `Re-wraps every data encryption key in vault's storage backend with the master key from the vault config.
+
+The config's master_key must be the new key and previous_master_key the key it replaces. Roll the same config out to every vault instance first, so that no instance keeps writing keys wrapped with the old master key, then run this command. It talks to storage directly and does not need a running vault.
+
+Progress is checkpointed in storage after every batch. If the command is interrupted, run it again to resume. Remove previous_master_key from the vault config only after the command completes.
+
+EXAMPLES:
+unkey dev generate-master-key                                  # Generate the new master key
+unkey vault rotate-master-key --config /etc/unkey/vault.toml   # Re-wrap all keys
+unkey vault rotate-master-key --config vault.toml --restart    # Start over instead of resuming`,
+	Flags: []cli.Flag{
+		cli.String("config", "Path to a TOML vault config file, or the raw TOML config itself",
+			cli.Default("unkey.toml"), cli.EnvVar("UNKEY_CONFIG")),
+		cli.Int("batch-size", "Number of keys to re-wrap between checkpoints", cli.Default(100)),
+		cli.Bool("restart", "Discard the stored checkpoint and start the pass from the beginning"),
+	},
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		cfg, err := config.Load[vault.Config](cmd.String("config"))
+		if err != nil {
+			return fmt.Errorf("unable to load config: %w", err)
+		}
+		if cfg.Encryption.PreviousMasterKey == nil || *cfg.Encryption.PreviousMasterKey == "" {
+			fmt.Println("No previous_master_key configured; keys wrapped with any other master key cannot be re-wrapped.")
+		}
+
+		progress, err := vault.RotateMasterKey(ctx, cfg, vault.RotateMasterKeyOptions{
+			BatchSize: cmd.Int("batch-size"),
+			Restart:   cmd.Bool("restart"),
+			OnProgress: func(p vault.RotationProgress) {
+				fmt.Printf("rewrapped %d, already current %d, remaining ~%d\n", p.Rewrapped, p.Skipped, p.Remaining)
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to rotate master key: %w", err)
+		}
+
+		fmt.Printf("✓ All keys are wrapped with %s. previous_master_key can now be removed from the vault config.\n", progress.KekID)
+		return nil
+	},
+}
@@ -21,6 +21,70 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type EntityKind int32
+
+const (
+	EntityKind_ENTITY_KIND_UNSPECIFIED         EntityKind = 0
+	EntityKind_ENTITY_KIND_KEY                 EntityKind = 1
+	EntityKind_ENTITY_KIND_API                 EntityKind = 2
+	EntityKind_ENTITY_KIND_WORKSPACE           EntityKind = 3
+	EntityKind_ENTITY_KIND_PORTAL_SESSION      EntityKind = 4
+	EntityKind_ENTITY_KIND_RATELIMIT_NAMESPACE EntityKind = 5
+	EntityKind_ENTITY_KIND_IDENTITY            EntityKind = 6
+	EntityKind_ENTITY_KIND_RATELIMIT_PLAN      EntityKind = 7
+)
+
+// Enum value maps for EntityKind.
+var (
+	EntityKind_name = map[int32]string{
+		0: "ENTITY_KIND_UNSPECIFIED",
+		1: "ENTITY_KIND_KEY",
+		2: "ENTITY_KIND_API",
+		3: "ENTITY_KIND_WORKSPACE",
+		4: "ENTITY_KIND_PORTAL_SESSION",
+		5: "ENTITY_KIND_RATELIMIT_NAMESPACE",
+		6: "ENTITY_KIND_IDENTITY",
+		7: "ENTITY_KIND_RATELIMIT_PLAN",
+	}
+	EntityKind_value = map[string]int32{
+		"ENTITY_KIND_UNSPECIFIED":         0,
+		"ENTITY_KIND_KEY":                 1,
+		"ENTITY_KIND_API":                 2,
+		"ENTITY_KIND_WORKSPACE":           3,
+		"ENTITY_KIND_PORTAL_SESSION":      4,
+		"ENTITY_KIND_RATELIMIT_NAMESPACE": 5,
+		"ENTITY_KIND_IDENTITY":            6,
+		"ENTITY_KIND_RATELIMIT_PLAN":      7,
+	}
+)
+
+func (x EntityKind) Enum() *EntityKind {
+	p := new(EntityKind)
+	*p = x
+	return p
+}
+
+func (x EntityKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EntityKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_cache_v1_invalidation_proto_enumTypes[0].Descriptor()
+}
+
+func (EntityKind) Type() protoreflect.EnumType {
+	return &file_cache_v1_invalidation_proto_enumTypes[0]
+}
+
+func (x EntityKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EntityKind.Descriptor instead.
+func (EntityKind) EnumDescriptor() ([]byte, []int) {
+	return file_cache_v1_invalidation_proto_rawDescGZIP(), []int{0}
+}
+
 // CacheInvalidationEvent represents a cache invalidation event
 type CacheInvalidationEvent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -34,6 +98,7 @@ type CacheInvalidationEvent struct {
 	//
 	//	*CacheInvalidationEvent_CacheKey
 	//	*CacheInvalidationEvent_ClearAll
+	//	*CacheInvalidationEvent_Entities
 	Action        isCacheInvalidationEvent_Action `protobuf_oneof:"action"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -115,6 +180,15 @@ func (x *CacheInvalidationEvent) GetClearAll() bool {
 	return false
 }
 
+func (x *CacheInvalidationEvent) GetEntities() *EntityInvalidation {
+	if x != nil {
+		if x, ok := x.Action.(*CacheInvalidationEvent_Entities); ok {
+			return x.Entities
+		}
+	}
+	return nil
+}
+
 type isCacheInvalidationEvent_Action interface {
 	isCacheInvalidationEvent_Action()
 }
@@ -129,23 +203,167 @@ type CacheInvalidationEvent_ClearAll struct {
 	ClearAll bool `protobuf:"varint,5,opt,name=clear_all,json=clearAll,proto3,oneof"`
 }
 
+type CacheInvalidationEvent_Entities struct {
+	// Invalidate every cache entry derived from these entities. cache_name is
+	// ignored: the receiver fans out to all dependent caches.
+	Entities *EntityInvalidation `protobuf:"bytes,7,opt,name=entities,proto3,oneof"`
+}
+
 func (*CacheInvalidationEvent_CacheKey) isCacheInvalidationEvent_Action() {}
 
 func (*CacheInvalidationEvent_ClearAll) isCacheInvalidationEvent_Action() {}
 
+func (*CacheInvalidationEvent_Entities) isCacheInvalidationEvent_Action() {}
+
+// EntityInvalidation batches the entities changed by one write.
+type EntityInvalidation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entities      []*Entity              `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityInvalidation) Reset() {
+	*x = EntityInvalidation{}
+	mi := &file_cache_v1_invalidation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityInvalidation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityInvalidation) ProtoMessage() {}
+
+func (x *EntityInvalidation) ProtoReflect() protoreflect.Message {
+	mi := &file_cache_v1_invalidation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityInvalidation.ProtoReflect.Descriptor instead.
+func (*EntityInvalidation) Descriptor() ([]byte, []int) {
+	return file_cache_v1_invalidation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EntityInvalidation) GetEntities() []*Entity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+// Entity identifies a database row that one or more caches derive from.
+type Entity struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Kind  EntityKind             `protobuf:"varint,1,opt,name=kind,proto3,enum=cache.v1.EntityKind" json:"kind,omitempty"`
+	// The workspace owning the entity. Required for scoped cache keys.
+	WorkspaceId string `protobuf:"bytes,2,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	// The entity id, e.g. a key id, api id or portal session id.
+	Id string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	// Other keys the entity is cached under, e.g. a key's hash or a ratelimit
+	// namespace's name, so receivers can invalidate without a database lookup.
+	Aliases       []string `protobuf:"bytes,4,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Entity) Reset() {
+	*x = Entity{}
+	mi := &file_cache_v1_invalidation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Entity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entity) ProtoMessage() {}
+
+func (x *Entity) ProtoReflect() protoreflect.Message {
+	mi := &file_cache_v1_invalidation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entity.ProtoReflect.Descriptor instead.
+func (*Entity) Descriptor() ([]byte, []int) {
+	return file_cache_v1_invalidation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Entity) GetKind() EntityKind {
+	if x != nil {
+		return x.Kind
+	}
+	return EntityKind_ENTITY_KIND_UNSPECIFIED
+}
+
+func (x *Entity) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *Entity) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Entity) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
 var File_cache_v1_invalidation_proto protoreflect.FileDescriptor
 
 const file_cache_v1_invalidation_proto_rawDesc = "" +
 	"\n" +
-	"\x1bcache/v1/invalidation.proto\x12\bcache.v1\"\xc6\x01\n" +
+	"\x1bcache/v1/invalidation.proto\x12\bcache.v1\"\x91\x02\n" +
 	"\x16CacheInvalidationEvent\x12\x1d\n" +
 	"\n" +
 	"cache_name\x18\x01 \x01(\tR\tcacheName\x12\x1c\n" +
 	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12'\n" +
 	"\x0fsource_instance\x18\x04 \x01(\tR\x0esourceInstance\x12\x1d\n" +
 	"\tcache_key\x18\x02 \x01(\tH\x00R\bcacheKey\x12\x1d\n" +
-	"\tclear_all\x18\x05 \x01(\bH\x00R\bclearAllB\b\n" +
-	"\x06actionB\x97\x01\n" +
+	"\tclear_all\x18\x05 \x01(\bH\x00R\bclearAll\x12:\n" +
+	"\bentities\x18\a \x01(\v2\x1c.cache.v1.EntityInvalidationH\x00R\bentitiesB\b\n" +
+	"\x06actionJ\x04\b\x06\x10\aR\aversion\"B\n" +
+	"\x12EntityInvalidation\x12,\n" +
+	"\bentities\x18\x01 \x03(\v2\x10.cache.v1.EntityR\bentities\"\x7f\n" +
+	"\x06Entity\x12(\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x14.cache.v1.EntityKindR\x04kind\x12!\n" +
+	"\fworkspace_id\x18\x02 \x01(\tR\vworkspaceId\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\tR\x02id\x12\x18\n" +
+	"\aaliases\x18\x04 \x03(\tR\aaliases*\xed\x01\n" +
+	"\n" +
+	"EntityKind\x12\x1b\n" +
+	"\x17ENTITY_KIND_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fENTITY_KIND_KEY\x10\x01\x12\x13\n" +
+	"\x0fENTITY_KIND_API\x10\x02\x12\x19\n" +
+	"\x15ENTITY_KIND_WORKSPACE\x10\x03\x12\x1e\n" +
+	"\x1aENTITY_KIND_PORTAL_SESSION\x10\x04\x12#\n" +
+	"\x1fENTITY_KIND_RATELIMIT_NAMESPACE\x10\x05\x12\x18\n" +
+	"\x14ENTITY_KIND_IDENTITY\x10\x06\x12\x1e\n" +
+	"\x1aENTITY_KIND_RATELIMIT_PLAN\x10\aB\x97\x01\n" +
 	"\fcom.cache.v1B\x11InvalidationProtoP\x01Z3github.com/unkeyed/unkey/gen/proto/cache/v1;cachev1\xa2\x02\x03CXX\xaa\x02\bCache.V1\xca\x02\bCache\\V1\xe2\x02\x14Cache\\V1\\GPBMetadata\xea\x02\tCache::V1b\x06proto3"
 
 var (
@@ -160,16 +378,23 @@ func file_cache_v1_invalidation_proto_rawDescGZIP() []byte {
 	return file_cache_v1_invalidation_proto_rawDescData
 }
 
-var file_cache_v1_invalidation_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_cache_v1_invalidation_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_cache_v1_invalidation_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_cache_v1_invalidation_proto_goTypes = []any{
-	(*CacheInvalidationEvent)(nil), // 0: cache.v1.CacheInvalidationEvent
+	(EntityKind)(0),                // 0: cache.v1.EntityKind
+	(*CacheInvalidationEvent)(nil), // 1: cache.v1.CacheInvalidationEvent
+	(*EntityInvalidation)(nil),     // 2: cache.v1.EntityInvalidation
+	(*Entity)(nil),                 // 3: cache.v1.Entity
 }
 var file_cache_v1_invalidation_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	2, // 0: cache.v1.CacheInvalidationEvent.entities:type_name -> cache.v1.EntityInvalidation
+	3, // 1: cache.v1.EntityInvalidation.entities:type_name -> cache.v1.Entity
+	0, // 2: cache.v1.Entity.kind:type_name -> cache.v1.EntityKind
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_cache_v1_invalidation_proto_init() }
@@ -180,19 +405,21 @@ func file_cache_v1_invalidation_proto_init() {
 	file_cache_v1_invalidation_proto_msgTypes[0].OneofWrappers = []any{
 		(*CacheInvalidationEvent_CacheKey)(nil),
 		(*CacheInvalidationEvent_ClearAll)(nil),
+		(*CacheInvalidationEvent_Entities)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cache_v1_invalidation_proto_rawDesc), len(file_cache_v1_invalidation_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      1,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_cache_v1_invalidation_proto_goTypes,
 		DependencyIndexes: file_cache_v1_invalidation_proto_depIdxs,
+		EnumInfos:         file_cache_v1_invalidation_proto_enumTypes,
 		MessageInfos:      file_cache_v1_invalidation_proto_msgTypes,
 	}.Build()
 	File_cache_v1_invalidation_proto = out.File
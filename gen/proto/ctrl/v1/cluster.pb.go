@@ -313,6 +313,7 @@ type DeploymentChangeEvent struct {
 	// Types that are valid to be assigned to Event:
 	//
 	//	*DeploymentChangeEvent_Deployment
+	//	*DeploymentChangeEvent_DeleteVolume
 	Event         isDeploymentChangeEvent_Event `protobuf_oneof:"event"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -371,6 +372,15 @@ func (x *DeploymentChangeEvent) GetDeployment() *DeploymentState {
 	return nil
 }
 
+func (x *DeploymentChangeEvent) GetDeleteVolume() *DeleteVolume {
+	if x != nil {
+		if x, ok := x.Event.(*DeploymentChangeEvent_DeleteVolume); ok {
+			return x.DeleteVolume
+		}
+	}
+	return nil
+}
+
 type isDeploymentChangeEvent_Event interface {
 	isDeploymentChangeEvent_Event()
 }
@@ -379,8 +389,14 @@ type DeploymentChangeEvent_Deployment struct {
 	Deployment *DeploymentState `protobuf:"bytes,2,opt,name=deployment,proto3,oneof"`
 }
 
+type DeploymentChangeEvent_DeleteVolume struct {
+	DeleteVolume *DeleteVolume `protobuf:"bytes,3,opt,name=delete_volume,json=deleteVolume,proto3,oneof"`
+}
+
 func (*DeploymentChangeEvent_Deployment) isDeploymentChangeEvent_Event() {}
 
+func (*DeploymentChangeEvent_DeleteVolume) isDeploymentChangeEvent_Event() {}
+
 type GetDesiredDeploymentStateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Cluster       *ClusterKey            `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
@@ -592,10 +608,9 @@ type InstanceEvent struct {
 	Time int64 `protobuf:"varint,12,opt,name=time,proto3" json:"time,omitempty"`
 	// Stable hash krane computes so the dashboard can group identical
 	// incidents without an aggregate table. Inputs differ by state:
-	//
-	//	Running    — (image_id, "running")
-	//	Terminated — (image_id, exit_code, reason, message[:200])
-	//	Waiting    — (image_id, 0, reason, message[:200])
+	//   Running    — (image_id, "running")
+	//   Terminated — (image_id, exit_code, reason, message[:200])
+	//   Waiting    — (image_id, 0, reason, message[:200])
 	EventFingerprint string `protobuf:"bytes,13,opt,name=event_fingerprint,json=eventFingerprint,proto3" json:"event_fingerprint,omitempty"`
 	// Mirrors corev1.ContainerState. Exactly one case is set per event.
 	//
@@ -607,10 +622,8 @@ type InstanceEvent struct {
 	State isInstanceEvent_State `protobuf_oneof:"state"`
 	// Selected k8s metadata for the event row. Stored verbatim into the
 	// ClickHouse `attributes` Map column. Known keys (krane populates):
-	//
-	//	image, image_id, cpu_limit_millicores, memory_limit_mib,
-	//	cpu_request_millicores, memory_request_mib, build_id.
-	//
+	//   image, image_id, cpu_limit_millicores, memory_limit_mib,
+	//   cpu_request_millicores, memory_request_mib, build_id.
 	// Numbers are stringified so the wire shape matches the column type.
 	Attributes    map[string]string `protobuf:"bytes,17,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
@@ -1242,8 +1255,14 @@ type ApplyDeployment struct {
 	// The volume is created when the pod starts and deleted when the pod terminates.
 	// When absent, no ephemeral volume is attached.
 	EphemeralStorage *EphemeralStorage `protobuf:"bytes,29,opt,name=ephemeral_storage,json=ephemeralStorage,proto3,oneof" json:"ephemeral_storage,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// volumes are the app's named persistent volumes in this environment.
+	// Unlike ephemeral_storage they are not owned by the deployment: Krane
+	// provisions one PVC per volume that every deployment of the app mounts, so
+	// the data survives deploys and rollbacks. The claims are deleted only when
+	// the control plane sends DeleteVolume.
+	Volumes       []*VolumeMount `protobuf:"bytes,30,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ApplyDeployment) Reset() {
@@ -1444,6 +1463,88 @@ func (x *ApplyDeployment) GetEphemeralStorage() *EphemeralStorage {
 	return nil
 }
 
+func (x *ApplyDeployment) GetVolumes() []*VolumeMount {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+// VolumeMount attaches a persistent volume to a deployment's pods.
+type VolumeMount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// volume_id is the volumes.id the claim belongs to.
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// k8s_name is the name of the PersistentVolumeClaim. It is stable for the
+	// lifetime of the volume, so every deployment binds the same claim.
+	K8SName string `protobuf:"bytes,2,opt,name=k8s_name,json=k8sName,proto3" json:"k8s_name,omitempty"`
+	// mount_path is the absolute path inside the container.
+	MountPath string `protobuf:"bytes,3,opt,name=mount_path,json=mountPath,proto3" json:"mount_path,omitempty"`
+	// size_mib is the requested size in mebibytes. Volumes can grow but never
+	// shrink.
+	SizeMib       int64 `protobuf:"varint,4,opt,name=size_mib,json=sizeMib,proto3" json:"size_mib,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolumeMount) Reset() {
+	*x = VolumeMount{}
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeMount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeMount) ProtoMessage() {}
+
+func (x *VolumeMount) ProtoReflect() protoreflect.Message {
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeMount.ProtoReflect.Descriptor instead.
+func (*VolumeMount) Descriptor() ([]byte, []int) {
+	return file_ctrl_v1_cluster_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *VolumeMount) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+func (x *VolumeMount) GetK8SName() string {
+	if x != nil {
+		return x.K8SName
+	}
+	return ""
+}
+
+func (x *VolumeMount) GetMountPath() string {
+	if x != nil {
+		return x.MountPath
+	}
+	return ""
+}
+
+func (x *VolumeMount) GetSizeMib() int64 {
+	if x != nil {
+		return x.SizeMib
+	}
+	return 0
+}
+
 // AutoscalingPolicy configures horizontal pod autoscaling for a deployment.
 // Snapshotted from the horizontal_autoscaling_policies table at query time.
 type AutoscalingPolicy struct {
@@ -1458,13 +1559,17 @@ type AutoscalingPolicy struct {
 	// Average memory utilization percentage (0-100) that triggers scale-up.
 	// When omitted, memory is not used as a scaling signal.
 	MemoryThreshold *int32 `protobuf:"varint,4,opt,name=memory_threshold,json=memoryThreshold,proto3,oneof" json:"memory_threshold,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Target requests per second per replica, measured by frontline. When set,
+	// Krane raises the HPA's replica floor to cover the observed request rate.
+	// When omitted, request rate is not used as a scaling signal.
+	RpsThreshold  *int32 `protobuf:"varint,5,opt,name=rps_threshold,json=rpsThreshold,proto3,oneof" json:"rps_threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AutoscalingPolicy) Reset() {
 	*x = AutoscalingPolicy{}
-	mi := &file_ctrl_v1_cluster_proto_msgTypes[15]
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1476,7 +1581,7 @@ func (x *AutoscalingPolicy) String() string {
 func (*AutoscalingPolicy) ProtoMessage() {}
 
 func (x *AutoscalingPolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_ctrl_v1_cluster_proto_msgTypes[15]
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1489,7 +1594,7 @@ func (x *AutoscalingPolicy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AutoscalingPolicy.ProtoReflect.Descriptor instead.
 func (*AutoscalingPolicy) Descriptor() ([]byte, []int) {
-	return file_ctrl_v1_cluster_proto_rawDescGZIP(), []int{15}
+	return file_ctrl_v1_cluster_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *AutoscalingPolicy) GetMinReplicas() uint32 {
@@ -1520,6 +1625,13 @@ func (x *AutoscalingPolicy) GetMemoryThreshold() int32 {
 	return 0
 }
 
+func (x *AutoscalingPolicy) GetRpsThreshold() int32 {
+	if x != nil && x.RpsThreshold != nil {
+		return *x.RpsThreshold
+	}
+	return 0
+}
+
 // DeleteDeployment identifies a deployment to remove from the cluster.
 //
 // The deployment and all its pods will be terminated gracefully according to
@@ -1535,7 +1647,7 @@ type DeleteDeployment struct {
 
 func (x *DeleteDeployment) Reset() {
 	*x = DeleteDeployment{}
-	mi := &file_ctrl_v1_cluster_proto_msgTypes[16]
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1547,7 +1659,7 @@ func (x *DeleteDeployment) String() string {
 func (*DeleteDeployment) ProtoMessage() {}
 
 func (x *DeleteDeployment) ProtoReflect() protoreflect.Message {
-	mi := &file_ctrl_v1_cluster_proto_msgTypes[16]
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1560,7 +1672,7 @@ func (x *DeleteDeployment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteDeployment.ProtoReflect.Descriptor instead.
 func (*DeleteDeployment) Descriptor() ([]byte, []int) {
-	return file_ctrl_v1_cluster_proto_rawDescGZIP(), []int{16}
+	return file_ctrl_v1_cluster_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *DeleteDeployment) GetK8SNamespace() string {
@@ -1577,6 +1689,69 @@ func (x *DeleteDeployment) GetK8SName() string {
 	return ""
 }
 
+// DeleteVolume instructs Krane to delete a persistent volume's claim and,
+// with it, the data. The control plane sends it once the volume's retention
+// window has passed after the workspace was torn down.
+type DeleteVolume struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	K8SNamespace  string                 `protobuf:"bytes,1,opt,name=k8s_namespace,json=k8sNamespace,proto3" json:"k8s_namespace,omitempty"`
+	K8SName       string                 `protobuf:"bytes,2,opt,name=k8s_name,json=k8sName,proto3" json:"k8s_name,omitempty"`
+	VolumeId      string                 `protobuf:"bytes,3,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVolume) Reset() {
+	*x = DeleteVolume{}
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVolume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVolume) ProtoMessage() {}
+
+func (x *DeleteVolume) ProtoReflect() protoreflect.Message {
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVolume.ProtoReflect.Descriptor instead.
+func (*DeleteVolume) Descriptor() ([]byte, []int) {
+	return file_ctrl_v1_cluster_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteVolume) GetK8SNamespace() string {
+	if x != nil {
+		return x.K8SNamespace
+	}
+	return ""
+}
+
+func (x *DeleteVolume) GetK8SName() string {
+	if x != nil {
+		return x.K8SName
+	}
+	return ""
+}
+
+func (x *DeleteVolume) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
 // HeartbeatRequest is sent periodically by krane agents to register their
 // presence. The control plane uses this to populate regions and
 // clusters tables.
@@ -1589,7 +1764,7 @@ type HeartbeatRequest struct {
 
 func (x *HeartbeatRequest) Reset() {
 	*x = HeartbeatRequest{}
-	mi := &file_ctrl_v1_cluster_proto_msgTypes[17]
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1601,7 +1776,7 @@ func (x *HeartbeatRequest) String() string {
 func (*HeartbeatRequest) ProtoMessage() {}
 
 func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ctrl_v1_cluster_proto_msgTypes[17]
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1614,7 +1789,7 @@ func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
 func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
-	return file_ctrl_v1_cluster_proto_rawDescGZIP(), []int{17}
+	return file_ctrl_v1_cluster_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *HeartbeatRequest) GetCluster() *ClusterKey {
@@ -1632,7 +1807,7 @@ type HeartbeatResponse struct {
 
 func (x *HeartbeatResponse) Reset() {
 	*x = HeartbeatResponse{}
-	mi := &file_ctrl_v1_cluster_proto_msgTypes[18]
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1644,7 +1819,7 @@ func (x *HeartbeatResponse) String() string {
 func (*HeartbeatResponse) ProtoMessage() {}
 
 func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ctrl_v1_cluster_proto_msgTypes[18]
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1657,7 +1832,106 @@ func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
 func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
-	return file_ctrl_v1_cluster_proto_rawDescGZIP(), []int{18}
+	return file_ctrl_v1_cluster_proto_rawDescGZIP(), []int{20}
+}
+
+type GetDeploymentRequestRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cluster       *ClusterKey            `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	DeploymentIds []string               `protobuf:"bytes,2,rep,name=deployment_ids,json=deploymentIds,proto3" json:"deployment_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeploymentRequestRatesRequest) Reset() {
+	*x = GetDeploymentRequestRatesRequest{}
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeploymentRequestRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeploymentRequestRatesRequest) ProtoMessage() {}
+
+func (x *GetDeploymentRequestRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeploymentRequestRatesRequest.ProtoReflect.Descriptor instead.
+func (*GetDeploymentRequestRatesRequest) Descriptor() ([]byte, []int) {
+	return file_ctrl_v1_cluster_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetDeploymentRequestRatesRequest) GetCluster() *ClusterKey {
+	if x != nil {
+		return x.Cluster
+	}
+	return nil
+}
+
+func (x *GetDeploymentRequestRatesRequest) GetDeploymentIds() []string {
+	if x != nil {
+		return x.DeploymentIds
+	}
+	return nil
+}
+
+type GetDeploymentRequestRatesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Average requests per second per deployment ID over the last few minutes,
+	// counting only the traffic served in the calling cluster's region.
+	// Deployments without recent traffic there are omitted.
+	RequestsPerSecond map[string]float64 `protobuf:"bytes,1,rep,name=requests_per_second,json=requestsPerSecond,proto3" json:"requests_per_second,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetDeploymentRequestRatesResponse) Reset() {
+	*x = GetDeploymentRequestRatesResponse{}
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeploymentRequestRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeploymentRequestRatesResponse) ProtoMessage() {}
+
+func (x *GetDeploymentRequestRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeploymentRequestRatesResponse.ProtoReflect.Descriptor instead.
+func (*GetDeploymentRequestRatesResponse) Descriptor() ([]byte, []int) {
+	return file_ctrl_v1_cluster_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetDeploymentRequestRatesResponse) GetRequestsPerSecond() map[string]float64 {
+	if x != nil {
+		return x.RequestsPerSecond
+	}
+	return nil
 }
 
 type ReportDeploymentStatusRequest_Update struct {
@@ -1670,7 +1944,7 @@ type ReportDeploymentStatusRequest_Update struct {
 
 func (x *ReportDeploymentStatusRequest_Update) Reset() {
 	*x = ReportDeploymentStatusRequest_Update{}
-	mi := &file_ctrl_v1_cluster_proto_msgTypes[19]
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1682,7 +1956,7 @@ func (x *ReportDeploymentStatusRequest_Update) String() string {
 func (*ReportDeploymentStatusRequest_Update) ProtoMessage() {}
 
 func (x *ReportDeploymentStatusRequest_Update) ProtoReflect() protoreflect.Message {
-	mi := &file_ctrl_v1_cluster_proto_msgTypes[19]
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1721,7 +1995,7 @@ type ReportDeploymentStatusRequest_Delete struct {
 
 func (x *ReportDeploymentStatusRequest_Delete) Reset() {
 	*x = ReportDeploymentStatusRequest_Delete{}
-	mi := &file_ctrl_v1_cluster_proto_msgTypes[20]
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1733,7 +2007,7 @@ func (x *ReportDeploymentStatusRequest_Delete) String() string {
 func (*ReportDeploymentStatusRequest_Delete) ProtoMessage() {}
 
 func (x *ReportDeploymentStatusRequest_Delete) ProtoReflect() protoreflect.Message {
-	mi := &file_ctrl_v1_cluster_proto_msgTypes[20]
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1769,7 +2043,7 @@ type ReportDeploymentStatusRequest_Update_Instance struct {
 
 func (x *ReportDeploymentStatusRequest_Update_Instance) Reset() {
 	*x = ReportDeploymentStatusRequest_Update_Instance{}
-	mi := &file_ctrl_v1_cluster_proto_msgTypes[21]
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1781,7 +2055,7 @@ func (x *ReportDeploymentStatusRequest_Update_Instance) String() string {
 func (*ReportDeploymentStatusRequest_Update_Instance) ProtoMessage() {}
 
 func (x *ReportDeploymentStatusRequest_Update_Instance) ProtoReflect() protoreflect.Message {
-	mi := &file_ctrl_v1_cluster_proto_msgTypes[21]
+	mi := &file_ctrl_v1_cluster_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1847,12 +2121,13 @@ const file_ctrl_v1_cluster_proto_rawDesc = "" +
 	"\x11version_last_seen\x18\x02 \x01(\x04R\x0fversionLastSeen\x12\x16\n" +
 	"\x06replay\x18\x03 \x01(\bR\x06replay\"H\n" +
 	"\x17SyncDesiredStateRequest\x12-\n" +
-	"\acluster\x18\x01 \x01(\v2\x13.ctrl.v1.ClusterKeyR\acluster\"v\n" +
+	"\acluster\x18\x01 \x01(\v2\x13.ctrl.v1.ClusterKeyR\acluster\"\xb4\x01\n" +
 	"\x15DeploymentChangeEvent\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\x04R\aversion\x12:\n" +
 	"\n" +
 	"deployment\x18\x02 \x01(\v2\x18.ctrl.v1.DeploymentStateH\x00R\n" +
-	"deploymentB\a\n" +
+	"deployment\x12<\n" +
+	"\rdelete_volume\x18\x03 \x01(\v2\x15.ctrl.v1.DeleteVolumeH\x00R\fdeleteVolumeB\a\n" +
 	"\x05event\"v\n" +
 	" GetDesiredDeploymentStateRequest\x12-\n" +
 	"\acluster\x18\x01 \x01(\v2\x13.ctrl.v1.ClusterKeyR\acluster\x12#\n" +
@@ -1926,7 +2201,7 @@ const file_ctrl_v1_cluster_proto_rawDesc = "" +
 	"\aversion\x18\x03 \x01(\x04R\aversion\x120\n" +
 	"\x05apply\x18\x01 \x01(\v2\x18.ctrl.v1.ApplyDeploymentH\x00R\x05apply\x123\n" +
 	"\x06delete\x18\x02 \x01(\v2\x19.ctrl.v1.DeleteDeploymentH\x00R\x06deleteB\a\n" +
-	"\x05state\"\xcb\b\n" +
+	"\x05state\"\xfb\b\n" +
 	"\x0fApplyDeployment\x12#\n" +
 	"\rk8s_namespace\x18\x01 \x01(\tR\fk8sNamespace\x12\x19\n" +
 	"\bk8s_name\x18\x02 \x01(\tR\ak8sName\x12!\n" +
@@ -1955,7 +2230,8 @@ const file_ctrl_v1_cluster_proto_rawDesc = "" +
 	"\bgit_repo\x18\x19 \x01(\tH\x06R\agitRepo\x88\x01\x01\x121\n" +
 	"\x12git_commit_message\x18\x1a \x01(\tH\aR\x10gitCommitMessage\x88\x01\x01\x12<\n" +
 	"\vautoscaling\x18\x1b \x01(\v2\x1a.ctrl.v1.AutoscalingPolicyR\vautoscaling\x12K\n" +
-	"\x11ephemeral_storage\x18\x1d \x01(\v2\x19.ctrl.v1.EphemeralStorageH\bR\x10ephemeralStorage\x88\x01\x01B\v\n" +
+	"\x11ephemeral_storage\x18\x1d \x01(\v2\x19.ctrl.v1.EphemeralStorageH\bR\x10ephemeralStorage\x88\x01\x01\x12.\n" +
+	"\avolumes\x18\x1e \x03(\v2\x14.ctrl.v1.VolumeMountR\avolumesB\v\n" +
 	"\t_build_idB\x0e\n" +
 	"\f_healthcheckB\x13\n" +
 	"\x11_environment_slugB\t\n" +
@@ -1964,32 +2240,53 @@ const file_ctrl_v1_cluster_proto_rawDesc = "" +
 	"\v_git_branchB\v\n" +
 	"\t_git_repoB\x15\n" +
 	"\x13_git_commit_messageB\x14\n" +
-	"\x12_ephemeral_storage\"\xda\x01\n" +
+	"\x12_ephemeral_storage\"\x7f\n" +
+	"\vVolumeMount\x12\x1b\n" +
+	"\tvolume_id\x18\x01 \x01(\tR\bvolumeId\x12\x19\n" +
+	"\bk8s_name\x18\x02 \x01(\tR\ak8sName\x12\x1d\n" +
+	"\n" +
+	"mount_path\x18\x03 \x01(\tR\tmountPath\x12\x19\n" +
+	"\bsize_mib\x18\x04 \x01(\x03R\asizeMib\"\x96\x02\n" +
 	"\x11AutoscalingPolicy\x12!\n" +
 	"\fmin_replicas\x18\x01 \x01(\rR\vminReplicas\x12!\n" +
 	"\fmax_replicas\x18\x02 \x01(\rR\vmaxReplicas\x12(\n" +
 	"\rcpu_threshold\x18\x03 \x01(\x05H\x00R\fcpuThreshold\x88\x01\x01\x12.\n" +
-	"\x10memory_threshold\x18\x04 \x01(\x05H\x01R\x0fmemoryThreshold\x88\x01\x01B\x10\n" +
+	"\x10memory_threshold\x18\x04 \x01(\x05H\x01R\x0fmemoryThreshold\x88\x01\x01\x12(\n" +
+	"\rrps_threshold\x18\x05 \x01(\x05H\x02R\frpsThreshold\x88\x01\x01B\x10\n" +
 	"\x0e_cpu_thresholdB\x13\n" +
-	"\x11_memory_threshold\"R\n" +
+	"\x11_memory_thresholdB\x10\n" +
+	"\x0e_rps_threshold\"R\n" +
 	"\x10DeleteDeployment\x12#\n" +
 	"\rk8s_namespace\x18\x01 \x01(\tR\fk8sNamespace\x12\x19\n" +
-	"\bk8s_name\x18\x02 \x01(\tR\ak8sName\"A\n" +
+	"\bk8s_name\x18\x02 \x01(\tR\ak8sName\"k\n" +
+	"\fDeleteVolume\x12#\n" +
+	"\rk8s_namespace\x18\x01 \x01(\tR\fk8sNamespace\x12\x19\n" +
+	"\bk8s_name\x18\x02 \x01(\tR\ak8sName\x12\x1b\n" +
+	"\tvolume_id\x18\x03 \x01(\tR\bvolumeId\"A\n" +
 	"\x10HeartbeatRequest\x12-\n" +
 	"\acluster\x18\x01 \x01(\v2\x13.ctrl.v1.ClusterKeyR\acluster\"\x13\n" +
-	"\x11HeartbeatResponse*]\n" +
+	"\x11HeartbeatResponse\"x\n" +
+	" GetDeploymentRequestRatesRequest\x12-\n" +
+	"\acluster\x18\x01 \x01(\v2\x13.ctrl.v1.ClusterKeyR\acluster\x12%\n" +
+	"\x0edeployment_ids\x18\x02 \x03(\tR\rdeploymentIds\"\xdc\x01\n" +
+	"!GetDeploymentRequestRatesResponse\x12q\n" +
+	"\x13requests_per_second\x18\x01 \x03(\v2A.ctrl.v1.GetDeploymentRequestRatesResponse.RequestsPerSecondEntryR\x11requestsPerSecond\x1aD\n" +
+	"\x16RequestsPerSecondEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01*]\n" +
 	"\x06Health\x12\x16\n" +
 	"\x12HEALTH_UNSPECIFIED\x10\x00\x12\x12\n" +
 	"\x0eHEALTH_HEALTHY\x10\x01\x12\x14\n" +
 	"\x10HEALTH_UNHEALTHY\x10\x02\x12\x11\n" +
-	"\rHEALTH_PAUSED\x10\x032\xc2\x04\n" +
+	"\rHEALTH_PAUSED\x10\x032\xb6\x05\n" +
 	"\x0eClusterService\x12b\n" +
 	"\x16WatchDeploymentChanges\x12&.ctrl.v1.WatchDeploymentChangesRequest\x1a\x1e.ctrl.v1.DeploymentChangeEvent0\x01\x12V\n" +
 	"\x10SyncDesiredState\x12 .ctrl.v1.SyncDesiredStateRequest\x1a\x1e.ctrl.v1.DeploymentChangeEvent0\x01\x12`\n" +
 	"\x19GetDesiredDeploymentState\x12).ctrl.v1.GetDesiredDeploymentStateRequest\x1a\x18.ctrl.v1.DeploymentState\x12i\n" +
 	"\x16ReportDeploymentStatus\x12&.ctrl.v1.ReportDeploymentStatusRequest\x1a'.ctrl.v1.ReportDeploymentStatusResponse\x12c\n" +
 	"\x14ReportInstanceEvents\x12$.ctrl.v1.ReportInstanceEventsRequest\x1a%.ctrl.v1.ReportInstanceEventsResponse\x12B\n" +
-	"\tHeartbeat\x12\x19.ctrl.v1.HeartbeatRequest\x1a\x1a.ctrl.v1.HeartbeatResponseB\x8b\x01\n" +
+	"\tHeartbeat\x12\x19.ctrl.v1.HeartbeatRequest\x1a\x1a.ctrl.v1.HeartbeatResponse\x12r\n" +
+	"\x19GetDeploymentRequestRates\x12).ctrl.v1.GetDeploymentRequestRatesRequest\x1a*.ctrl.v1.GetDeploymentRequestRatesResponseB\x8b\x01\n" +
 	"\vcom.ctrl.v1B\fClusterProtoP\x01Z1github.com/unkeyed/unkey/gen/proto/ctrl/v1;ctrlv1\xa2\x02\x03CXX\xaa\x02\aCtrl.V1\xca\x02\aCtrl\\V1\xe2\x02\x13Ctrl\\V1\\GPBMetadata\xea\x02\bCtrl::V1b\x06proto3"
 
 var (
@@ -2005,7 +2302,7 @@ func file_ctrl_v1_cluster_proto_rawDescGZIP() []byte {
 }
 
 var file_ctrl_v1_cluster_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_ctrl_v1_cluster_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_ctrl_v1_cluster_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_ctrl_v1_cluster_proto_goTypes = []any{
 	(Health)(0), // 0: ctrl.v1.Health
 	(ReportDeploymentStatusRequest_Update_Instance_Status)(0), // 1: ctrl.v1.ReportDeploymentStatusRequest.Update.Instance.Status
@@ -2024,54 +2321,65 @@ var file_ctrl_v1_cluster_proto_goTypes = []any{
 	(*ReportInstanceEventsResponse)(nil),                  // 14: ctrl.v1.ReportInstanceEventsResponse
 	(*DeploymentState)(nil),                               // 15: ctrl.v1.DeploymentState
 	(*ApplyDeployment)(nil),                               // 16: ctrl.v1.ApplyDeployment
-	(*AutoscalingPolicy)(nil),                             // 17: ctrl.v1.AutoscalingPolicy
-	(*DeleteDeployment)(nil),                              // 18: ctrl.v1.DeleteDeployment
-	(*HeartbeatRequest)(nil),                              // 19: ctrl.v1.HeartbeatRequest
-	(*HeartbeatResponse)(nil),                             // 20: ctrl.v1.HeartbeatResponse
-	(*ReportDeploymentStatusRequest_Update)(nil),          // 21: ctrl.v1.ReportDeploymentStatusRequest.Update
-	(*ReportDeploymentStatusRequest_Delete)(nil),          // 22: ctrl.v1.ReportDeploymentStatusRequest.Delete
-	(*ReportDeploymentStatusRequest_Update_Instance)(nil), // 23: ctrl.v1.ReportDeploymentStatusRequest.Update.Instance
-	nil,                      // 24: ctrl.v1.InstanceEvent.AttributesEntry
-	(*EphemeralStorage)(nil), // 25: ctrl.v1.EphemeralStorage
+	(*VolumeMount)(nil),                                   // 17: ctrl.v1.VolumeMount
+	(*AutoscalingPolicy)(nil),                             // 18: ctrl.v1.AutoscalingPolicy
+	(*DeleteDeployment)(nil),                              // 19: ctrl.v1.DeleteDeployment
+	(*DeleteVolume)(nil),                                  // 20: ctrl.v1.DeleteVolume
+	(*HeartbeatRequest)(nil),                              // 21: ctrl.v1.HeartbeatRequest
+	(*HeartbeatResponse)(nil),                             // 22: ctrl.v1.HeartbeatResponse
+	(*GetDeploymentRequestRatesRequest)(nil),              // 23: ctrl.v1.GetDeploymentRequestRatesRequest
+	(*GetDeploymentRequestRatesResponse)(nil),             // 24: ctrl.v1.GetDeploymentRequestRatesResponse
+	(*ReportDeploymentStatusRequest_Update)(nil),          // 25: ctrl.v1.ReportDeploymentStatusRequest.Update
+	(*ReportDeploymentStatusRequest_Delete)(nil),          // 26: ctrl.v1.ReportDeploymentStatusRequest.Delete
+	(*ReportDeploymentStatusRequest_Update_Instance)(nil), // 27: ctrl.v1.ReportDeploymentStatusRequest.Update.Instance
+	nil,                      // 28: ctrl.v1.InstanceEvent.AttributesEntry
+	nil,                      // 29: ctrl.v1.GetDeploymentRequestRatesResponse.RequestsPerSecondEntry
+	(*EphemeralStorage)(nil), // 30: ctrl.v1.EphemeralStorage
 }
 var file_ctrl_v1_cluster_proto_depIdxs = []int32{
 	2,  // 0: ctrl.v1.WatchDeploymentChangesRequest.cluster:type_name -> ctrl.v1.ClusterKey
 	2,  // 1: ctrl.v1.SyncDesiredStateRequest.cluster:type_name -> ctrl.v1.ClusterKey
 	15, // 2: ctrl.v1.DeploymentChangeEvent.deployment:type_name -> ctrl.v1.DeploymentState
-	2,  // 3: ctrl.v1.GetDesiredDeploymentStateRequest.cluster:type_name -> ctrl.v1.ClusterKey
-	2,  // 4: ctrl.v1.ReportDeploymentStatusRequest.cluster:type_name -> ctrl.v1.ClusterKey
-	21, // 5: ctrl.v1.ReportDeploymentStatusRequest.update:type_name -> ctrl.v1.ReportDeploymentStatusRequest.Update
-	22, // 6: ctrl.v1.ReportDeploymentStatusRequest.delete:type_name -> ctrl.v1.ReportDeploymentStatusRequest.Delete
-	10, // 7: ctrl.v1.InstanceEvent.running:type_name -> ctrl.v1.Running
-	11, // 8: ctrl.v1.InstanceEvent.terminated:type_name -> ctrl.v1.Terminated
-	12, // 9: ctrl.v1.InstanceEvent.waiting:type_name -> ctrl.v1.Waiting
-	24, // 10: ctrl.v1.InstanceEvent.attributes:type_name -> ctrl.v1.InstanceEvent.AttributesEntry
-	9,  // 11: ctrl.v1.ReportInstanceEventsRequest.events:type_name -> ctrl.v1.InstanceEvent
-	2,  // 12: ctrl.v1.ReportInstanceEventsRequest.cluster:type_name -> ctrl.v1.ClusterKey
-	16, // 13: ctrl.v1.DeploymentState.apply:type_name -> ctrl.v1.ApplyDeployment
-	18, // 14: ctrl.v1.DeploymentState.delete:type_name -> ctrl.v1.DeleteDeployment
-	17, // 15: ctrl.v1.ApplyDeployment.autoscaling:type_name -> ctrl.v1.AutoscalingPolicy
-	25, // 16: ctrl.v1.ApplyDeployment.ephemeral_storage:type_name -> ctrl.v1.EphemeralStorage
-	2,  // 17: ctrl.v1.HeartbeatRequest.cluster:type_name -> ctrl.v1.ClusterKey
-	23, // 18: ctrl.v1.ReportDeploymentStatusRequest.Update.instances:type_name -> ctrl.v1.ReportDeploymentStatusRequest.Update.Instance
-	1,  // 19: ctrl.v1.ReportDeploymentStatusRequest.Update.Instance.status:type_name -> ctrl.v1.ReportDeploymentStatusRequest.Update.Instance.Status
-	3,  // 20: ctrl.v1.ClusterService.WatchDeploymentChanges:input_type -> ctrl.v1.WatchDeploymentChangesRequest
-	4,  // 21: ctrl.v1.ClusterService.SyncDesiredState:input_type -> ctrl.v1.SyncDesiredStateRequest
-	6,  // 22: ctrl.v1.ClusterService.GetDesiredDeploymentState:input_type -> ctrl.v1.GetDesiredDeploymentStateRequest
-	7,  // 23: ctrl.v1.ClusterService.ReportDeploymentStatus:input_type -> ctrl.v1.ReportDeploymentStatusRequest
-	13, // 24: ctrl.v1.ClusterService.ReportInstanceEvents:input_type -> ctrl.v1.ReportInstanceEventsRequest
-	19, // 25: ctrl.v1.ClusterService.Heartbeat:input_type -> ctrl.v1.HeartbeatRequest
-	5,  // 26: ctrl.v1.ClusterService.WatchDeploymentChanges:output_type -> ctrl.v1.DeploymentChangeEvent
-	5,  // 27: ctrl.v1.ClusterService.SyncDesiredState:output_type -> ctrl.v1.DeploymentChangeEvent
-	15, // 28: ctrl.v1.ClusterService.GetDesiredDeploymentState:output_type -> ctrl.v1.DeploymentState
-	8,  // 29: ctrl.v1.ClusterService.ReportDeploymentStatus:output_type -> ctrl.v1.ReportDeploymentStatusResponse
-	14, // 30: ctrl.v1.ClusterService.ReportInstanceEvents:output_type -> ctrl.v1.ReportInstanceEventsResponse
-	20, // 31: ctrl.v1.ClusterService.Heartbeat:output_type -> ctrl.v1.HeartbeatResponse
-	26, // [26:32] is the sub-list for method output_type
-	20, // [20:26] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	20, // 3: ctrl.v1.DeploymentChangeEvent.delete_volume:type_name -> ctrl.v1.DeleteVolume
+	2,  // 4: ctrl.v1.GetDesiredDeploymentStateRequest.cluster:type_name -> ctrl.v1.ClusterKey
+	2,  // 5: ctrl.v1.ReportDeploymentStatusRequest.cluster:type_name -> ctrl.v1.ClusterKey
+	25, // 6: ctrl.v1.ReportDeploymentStatusRequest.update:type_name -> ctrl.v1.ReportDeploymentStatusRequest.Update
+	26, // 7: ctrl.v1.ReportDeploymentStatusRequest.delete:type_name -> ctrl.v1.ReportDeploymentStatusRequest.Delete
+	10, // 8: ctrl.v1.InstanceEvent.running:type_name -> ctrl.v1.Running
+	11, // 9: ctrl.v1.InstanceEvent.terminated:type_name -> ctrl.v1.Terminated
+	12, // 10: ctrl.v1.InstanceEvent.waiting:type_name -> ctrl.v1.Waiting
+	28, // 11: ctrl.v1.InstanceEvent.attributes:type_name -> ctrl.v1.InstanceEvent.AttributesEntry
+	9,  // 12: ctrl.v1.ReportInstanceEventsRequest.events:type_name -> ctrl.v1.InstanceEvent
+	2,  // 13: ctrl.v1.ReportInstanceEventsRequest.cluster:type_name -> ctrl.v1.ClusterKey
+	16, // 14: ctrl.v1.DeploymentState.apply:type_name -> ctrl.v1.ApplyDeployment
+	19, // 15: ctrl.v1.DeploymentState.delete:type_name -> ctrl.v1.DeleteDeployment
+	18, // 16: ctrl.v1.ApplyDeployment.autoscaling:type_name -> ctrl.v1.AutoscalingPolicy
+	30, // 17: ctrl.v1.ApplyDeployment.ephemeral_storage:type_name -> ctrl.v1.EphemeralStorage
+	17, // 18: ctrl.v1.ApplyDeployment.volumes:type_name -> ctrl.v1.VolumeMount
+	2,  // 19: ctrl.v1.HeartbeatRequest.cluster:type_name -> ctrl.v1.ClusterKey
+	2,  // 20: ctrl.v1.GetDeploymentRequestRatesRequest.cluster:type_name -> ctrl.v1.ClusterKey
+	29, // 21: ctrl.v1.GetDeploymentRequestRatesResponse.requests_per_second:type_name -> ctrl.v1.GetDeploymentRequestRatesResponse.RequestsPerSecondEntry
+	27, // 22: ctrl.v1.ReportDeploymentStatusRequest.Update.instances:type_name -> ctrl.v1.ReportDeploymentStatusRequest.Update.Instance
+	1,  // 23: ctrl.v1.ReportDeploymentStatusRequest.Update.Instance.status:type_name -> ctrl.v1.ReportDeploymentStatusRequest.Update.Instance.Status
+	3,  // 24: ctrl.v1.ClusterService.WatchDeploymentChanges:input_type -> ctrl.v1.WatchDeploymentChangesRequest
+	4,  // 25: ctrl.v1.ClusterService.SyncDesiredState:input_type -> ctrl.v1.SyncDesiredStateRequest
+	6,  // 26: ctrl.v1.ClusterService.GetDesiredDeploymentState:input_type -> ctrl.v1.GetDesiredDeploymentStateRequest
+	7,  // 27: ctrl.v1.ClusterService.ReportDeploymentStatus:input_type -> ctrl.v1.ReportDeploymentStatusRequest
+	13, // 28: ctrl.v1.ClusterService.ReportInstanceEvents:input_type -> ctrl.v1.ReportInstanceEventsRequest
+	21, // 29: ctrl.v1.ClusterService.Heartbeat:input_type -> ctrl.v1.HeartbeatRequest
+	23, // 30: ctrl.v1.ClusterService.GetDeploymentRequestRates:input_type -> ctrl.v1.GetDeploymentRequestRatesRequest
+	5,  // 31: ctrl.v1.ClusterService.WatchDeploymentChanges:output_type -> ctrl.v1.DeploymentChangeEvent
+	5,  // 32: ctrl.v1.ClusterService.SyncDesiredState:output_type -> ctrl.v1.DeploymentChangeEvent
+	15, // 33: ctrl.v1.ClusterService.GetDesiredDeploymentState:output_type -> ctrl.v1.DeploymentState
+	8,  // 34: ctrl.v1.ClusterService.ReportDeploymentStatus:output_type -> ctrl.v1.ReportDeploymentStatusResponse
+	14, // 35: ctrl.v1.ClusterService.ReportInstanceEvents:output_type -> ctrl.v1.ReportInstanceEventsResponse
+	22, // 36: ctrl.v1.ClusterService.Heartbeat:output_type -> ctrl.v1.HeartbeatResponse
+	24, // 37: ctrl.v1.ClusterService.GetDeploymentRequestRates:output_type -> ctrl.v1.GetDeploymentRequestRatesResponse
+	31, // [31:38] is the sub-list for method output_type
+	24, // [24:31] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_ctrl_v1_cluster_proto_init() }
@@ -2082,6 +2390,7 @@ func file_ctrl_v1_cluster_proto_init() {
 	file_ctrl_v1_deployment_proto_init()
 	file_ctrl_v1_cluster_proto_msgTypes[3].OneofWrappers = []any{
 		(*DeploymentChangeEvent_Deployment)(nil),
+		(*DeploymentChangeEvent_DeleteVolume)(nil),
 	}
 	file_ctrl_v1_cluster_proto_msgTypes[5].OneofWrappers = []any{
 		(*ReportDeploymentStatusRequest_Update_)(nil),
@@ -2097,14 +2406,14 @@ func file_ctrl_v1_cluster_proto_init() {
 		(*DeploymentState_Delete)(nil),
 	}
 	file_ctrl_v1_cluster_proto_msgTypes[14].OneofWrappers = []any{}
-	file_ctrl_v1_cluster_proto_msgTypes[15].OneofWrappers = []any{}
+	file_ctrl_v1_cluster_proto_msgTypes[16].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ctrl_v1_cluster_proto_rawDesc), len(file_ctrl_v1_cluster_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   23,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
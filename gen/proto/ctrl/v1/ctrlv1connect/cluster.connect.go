@@ -60,6 +60,9 @@ const (
 	// ClusterServiceHeartbeatProcedure is the fully-qualified name of the ClusterService's Heartbeat
 	// RPC.
 	ClusterServiceHeartbeatProcedure = "/ctrl.v1.ClusterService/Heartbeat"
+	// ClusterServiceGetDeploymentRequestRatesProcedure is the fully-qualified name of the
+	// ClusterService's GetDeploymentRequestRates RPC.
+	ClusterServiceGetDeploymentRequestRatesProcedure = "/ctrl.v1.ClusterService/GetDeploymentRequestRates"
 )
 
 // ClusterServiceClient is a client for the ctrl.v1.ClusterService service.
@@ -90,6 +93,11 @@ type ClusterServiceClient interface {
 	// and region with the control plane. This populates the regions and
 	// clusters tables, making regions dynamically discoverable.
 	Heartbeat(context.Context, *connect.Request[v1.HeartbeatRequest]) (*connect.Response[v1.HeartbeatResponse], error)
+	// GetDeploymentRequestRates returns the recent request rate frontline
+	// observed for each of the given deployments in the calling cluster's
+	// region. Krane polls it to size the replica floor of deployments
+	// whose autoscaling policy sets an rps_threshold.
+	GetDeploymentRequestRates(context.Context, *connect.Request[v1.GetDeploymentRequestRatesRequest]) (*connect.Response[v1.GetDeploymentRequestRatesResponse], error)
 }
 
 // NewClusterServiceClient constructs a client for the ctrl.v1.ClusterService service. By default,
@@ -139,6 +147,12 @@ func NewClusterServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(clusterServiceMethods.ByName("Heartbeat")),
 			connect.WithClientOptions(opts...),
 		),
+		getDeploymentRequestRates: connect.NewClient[v1.GetDeploymentRequestRatesRequest, v1.GetDeploymentRequestRatesResponse](
+			httpClient,
+			baseURL+ClusterServiceGetDeploymentRequestRatesProcedure,
+			connect.WithSchema(clusterServiceMethods.ByName("GetDeploymentRequestRates")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -150,6 +164,7 @@ type clusterServiceClient struct {
 	reportDeploymentStatus    *connect.Client[v1.ReportDeploymentStatusRequest, v1.ReportDeploymentStatusResponse]
 	reportInstanceEvents      *connect.Client[v1.ReportInstanceEventsRequest, v1.ReportInstanceEventsResponse]
 	heartbeat                 *connect.Client[v1.HeartbeatRequest, v1.HeartbeatResponse]
+	getDeploymentRequestRates *connect.Client[v1.GetDeploymentRequestRatesRequest, v1.GetDeploymentRequestRatesResponse]
 }
 
 // WatchDeploymentChanges calls ctrl.v1.ClusterService.WatchDeploymentChanges.
@@ -182,6 +197,11 @@ func (c *clusterServiceClient) Heartbeat(ctx context.Context, req *connect.Reque
 	return c.heartbeat.CallUnary(ctx, req)
 }
 
+// GetDeploymentRequestRates calls ctrl.v1.ClusterService.GetDeploymentRequestRates.
+func (c *clusterServiceClient) GetDeploymentRequestRates(ctx context.Context, req *connect.Request[v1.GetDeploymentRequestRatesRequest]) (*connect.Response[v1.GetDeploymentRequestRatesResponse], error) {
+	return c.getDeploymentRequestRates.CallUnary(ctx, req)
+}
+
 // ClusterServiceHandler is an implementation of the ctrl.v1.ClusterService service.
 type ClusterServiceHandler interface {
 	// WatchDeploymentChanges streams incremental resource changes from the
@@ -210,6 +230,11 @@ type ClusterServiceHandler interface {
 	// and region with the control plane. This populates the regions and
 	// clusters tables, making regions dynamically discoverable.
 	Heartbeat(context.Context, *connect.Request[v1.HeartbeatRequest]) (*connect.Response[v1.HeartbeatResponse], error)
+	// GetDeploymentRequestRates returns the recent request rate frontline
+	// observed for each of the given deployments in the calling cluster's
+	// region. Krane polls it to size the replica floor of deployments
+	// whose autoscaling policy sets an rps_threshold.
+	GetDeploymentRequestRates(context.Context, *connect.Request[v1.GetDeploymentRequestRatesRequest]) (*connect.Response[v1.GetDeploymentRequestRatesResponse], error)
 }
 
 // NewClusterServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -255,6 +280,12 @@ func NewClusterServiceHandler(svc ClusterServiceHandler, opts ...connect.Handler
 		connect.WithSchema(clusterServiceMethods.ByName("Heartbeat")),
 		connect.WithHandlerOptions(opts...),
 	)
+	clusterServiceGetDeploymentRequestRatesHandler := connect.NewUnaryHandler(
+		ClusterServiceGetDeploymentRequestRatesProcedure,
+		svc.GetDeploymentRequestRates,
+		connect.WithSchema(clusterServiceMethods.ByName("GetDeploymentRequestRates")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/ctrl.v1.ClusterService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ClusterServiceWatchDeploymentChangesProcedure:
@@ -269,6 +300,8 @@ func NewClusterServiceHandler(svc ClusterServiceHandler, opts ...connect.Handler
 			clusterServiceReportInstanceEventsHandler.ServeHTTP(w, r)
 		case ClusterServiceHeartbeatProcedure:
 			clusterServiceHeartbeatHandler.ServeHTTP(w, r)
+		case ClusterServiceGetDeploymentRequestRatesProcedure:
+			clusterServiceGetDeploymentRequestRatesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -301,3 +334,7 @@ func (UnimplementedClusterServiceHandler) ReportInstanceEvents(context.Context,
 func (UnimplementedClusterServiceHandler) Heartbeat(context.Context, *connect.Request[v1.HeartbeatRequest]) (*connect.Response[v1.HeartbeatResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ctrl.v1.ClusterService.Heartbeat is not implemented"))
 }
+
+func (UnimplementedClusterServiceHandler) GetDeploymentRequestRates(context.Context, *connect.Request[v1.GetDeploymentRequestRatesRequest]) (*connect.Response[v1.GetDeploymentRequestRatesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ctrl.v1.ClusterService.GetDeploymentRequestRates is not implemented"))
+}
@@ -30,6 +30,17 @@ const (
 	// circuits all further policy evaluation — no downstream policies run and
 	// the upstream service is never invoked.
 	Action_ACTION_DENY Action = 1
+	// Record the match without affecting the request. The policy id is added
+	// to the request's firewall_matches in the request log and evaluation
+	// continues as if the policy had not matched. Use it to observe what a
+	// rule would block before switching it to an enforcing action.
+	Action_ACTION_LOG Action = 2
+	// Redirect the client to [FirewallRedirect.location]. Short-circuits like
+	// ACTION_DENY.
+	Action_ACTION_REDIRECT Action = 3
+	// Serve [FirewallResponse] from the edge, for example a maintenance page.
+	// Short-circuits like ACTION_DENY.
+	Action_ACTION_RESPOND Action = 4
 )
 
 // Enum value maps for Action.
@@ -37,10 +48,16 @@ var (
 	Action_name = map[int32]string{
 		0: "ACTION_UNSPECIFIED",
 		1: "ACTION_DENY",
+		2: "ACTION_LOG",
+		3: "ACTION_REDIRECT",
+		4: "ACTION_RESPOND",
 	}
 	Action_value = map[string]int32{
 		"ACTION_UNSPECIFIED": 0,
 		"ACTION_DENY":        1,
+		"ACTION_LOG":         2,
+		"ACTION_REDIRECT":    3,
+		"ACTION_RESPOND":     4,
 	}
 )
 
@@ -72,13 +89,19 @@ func (Action) EnumDescriptor() ([]byte, []int) {
 }
 
 // Firewall applies an action to any request that matches the policy's
-// [MatchExpr] list. The action enum exists today only to carry ACTION_DENY,
-// but it gives the wire format a place to grow into more outcomes (allow,
-// log, challenge) without restructuring the message.
+// [MatchExpr] list. Enforcing actions (deny, redirect, respond) answer the
+// request at the edge; ACTION_LOG only records the match so a new rule can
+// run in shadow mode before it is enforced.
 type Firewall struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The action to apply when this policy's match expressions all succeed.
-	Action        Action `protobuf:"varint,1,opt,name=action,proto3,enum=frontline.v1.Action" json:"action,omitempty"`
+	Action Action `protobuf:"varint,1,opt,name=action,proto3,enum=frontline.v1.Action" json:"action,omitempty"`
+	// Where to send the client. Required when action is ACTION_REDIRECT and
+	// ignored otherwise.
+	Redirect *FirewallRedirect `protobuf:"bytes,2,opt,name=redirect,proto3" json:"redirect,omitempty"`
+	// The response to serve. Required when action is ACTION_RESPOND and
+	// ignored otherwise.
+	Response      *FirewallResponse `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -120,16 +143,170 @@ func (x *Firewall) GetAction() Action {
 	return Action_ACTION_UNSPECIFIED
 }
 
+func (x *Firewall) GetRedirect() *FirewallRedirect {
+	if x != nil {
+		return x.Redirect
+	}
+	return nil
+}
+
+func (x *Firewall) GetResponse() *FirewallResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// FirewallRedirect configures ACTION_REDIRECT.
+type FirewallRedirect struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The redirect target: an absolute http(s) URL, or a path starting with
+	// "/" on the same host. Sent verbatim in the Location header.
+	Location string `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	// The redirect status code: 301, 302, 303, 307 or 308. Defaults to 302
+	// when unset.
+	StatusCode    int32 `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FirewallRedirect) Reset() {
+	*x = FirewallRedirect{}
+	mi := &file_frontline_policies_v1_firewall_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FirewallRedirect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirewallRedirect) ProtoMessage() {}
+
+func (x *FirewallRedirect) ProtoReflect() protoreflect.Message {
+	mi := &file_frontline_policies_v1_firewall_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirewallRedirect.ProtoReflect.Descriptor instead.
+func (*FirewallRedirect) Descriptor() ([]byte, []int) {
+	return file_frontline_policies_v1_firewall_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FirewallRedirect) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *FirewallRedirect) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+// FirewallResponse configures ACTION_RESPOND.
+type FirewallResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The HTTP status code to respond with, between 200 and 599.
+	StatusCode int32 `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	// Response headers to set. Content-Type defaults to text/plain when not
+	// set here.
+	Headers map[string]string `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The response body, sent verbatim.
+	Body          string `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FirewallResponse) Reset() {
+	*x = FirewallResponse{}
+	mi := &file_frontline_policies_v1_firewall_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FirewallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirewallResponse) ProtoMessage() {}
+
+func (x *FirewallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_frontline_policies_v1_firewall_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirewallResponse.ProtoReflect.Descriptor instead.
+func (*FirewallResponse) Descriptor() ([]byte, []int) {
+	return file_frontline_policies_v1_firewall_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FirewallResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *FirewallResponse) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *FirewallResponse) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
 var File_frontline_policies_v1_firewall_proto protoreflect.FileDescriptor
 
 const file_frontline_policies_v1_firewall_proto_rawDesc = "" +
 	"\n" +
-	"$frontline/policies/v1/firewall.proto\x12\ffrontline.v1\"8\n" +
+	"$frontline/policies/v1/firewall.proto\x12\ffrontline.v1\"\xb0\x01\n" +
 	"\bFirewall\x12,\n" +
-	"\x06action\x18\x01 \x01(\x0e2\x14.frontline.v1.ActionR\x06action*1\n" +
+	"\x06action\x18\x01 \x01(\x0e2\x14.frontline.v1.ActionR\x06action\x12:\n" +
+	"\bredirect\x18\x02 \x01(\v2\x1e.frontline.v1.FirewallRedirectR\bredirect\x12:\n" +
+	"\bresponse\x18\x03 \x01(\v2\x1e.frontline.v1.FirewallResponseR\bresponse\"O\n" +
+	"\x10FirewallRedirect\x12\x1a\n" +
+	"\blocation\x18\x01 \x01(\tR\blocation\x12\x1f\n" +
+	"\vstatus_code\x18\x02 \x01(\x05R\n" +
+	"statusCode\"\xca\x01\n" +
+	"\x10FirewallResponse\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\x05R\n" +
+	"statusCode\x12E\n" +
+	"\aheaders\x18\x02 \x03(\v2+.frontline.v1.FirewallResponse.HeadersEntryR\aheaders\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01*j\n" +
 	"\x06Action\x12\x16\n" +
 	"\x12ACTION_UNSPECIFIED\x10\x00\x12\x0f\n" +
-	"\vACTION_DENY\x10\x01B\xaf\x01\n" +
+	"\vACTION_DENY\x10\x01\x12\x0e\n" +
+	"\n" +
+	"ACTION_LOG\x10\x02\x12\x13\n" +
+	"\x0fACTION_REDIRECT\x10\x03\x12\x12\n" +
+	"\x0eACTION_RESPOND\x10\x04B\xaf\x01\n" +
 	"\x10com.frontline.v1B\rFirewallProtoP\x01Z;github.com/unkeyed/unkey/gen/proto/frontline/v1;frontlinev1\xa2\x02\x03FXX\xaa\x02\fFrontline.V1\xca\x02\fFrontline\\V1\xe2\x02\x18Frontline\\V1\\GPBMetadata\xea\x02\rFrontline::V1b\x06proto3"
 
 var (
@@ -145,18 +322,24 @@ func file_frontline_policies_v1_firewall_proto_rawDescGZIP() []byte {
 }
 
 var file_frontline_policies_v1_firewall_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_frontline_policies_v1_firewall_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_frontline_policies_v1_firewall_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_frontline_policies_v1_firewall_proto_goTypes = []any{
-	(Action)(0),      // 0: frontline.v1.Action
-	(*Firewall)(nil), // 1: frontline.v1.Firewall
+	(Action)(0),              // 0: frontline.v1.Action
+	(*Firewall)(nil),         // 1: frontline.v1.Firewall
+	(*FirewallRedirect)(nil), // 2: frontline.v1.FirewallRedirect
+	(*FirewallResponse)(nil), // 3: frontline.v1.FirewallResponse
+	nil,                      // 4: frontline.v1.FirewallResponse.HeadersEntry
 }
 var file_frontline_policies_v1_firewall_proto_depIdxs = []int32{
 	0, // 0: frontline.v1.Firewall.action:type_name -> frontline.v1.Action
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	2, // 1: frontline.v1.Firewall.redirect:type_name -> frontline.v1.FirewallRedirect
+	3, // 2: frontline.v1.Firewall.response:type_name -> frontline.v1.FirewallResponse
+	4, // 3: frontline.v1.FirewallResponse.headers:type_name -> frontline.v1.FirewallResponse.HeadersEntry
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_frontline_policies_v1_firewall_proto_init() }
@@ -170,7 +353,7 @@ func file_frontline_policies_v1_firewall_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_frontline_policies_v1_firewall_proto_rawDesc), len(file_frontline_policies_v1_firewall_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   1,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -65,10 +65,10 @@ type KeyAuth struct {
 	//
 	// Examples:
 	//
-	//	"api.keys.create"
-	//	"api.keys.read AND api.keys.update"
-	//	"billing.read OR billing.admin"
-	//	"(api.keys.read OR api.keys.list) AND billing.read"
+	//   "api.keys.create"
+	//   "api.keys.read AND api.keys.update"
+	//   "billing.read OR billing.admin"
+	//   "(api.keys.read OR api.keys.list) AND billing.read"
 	//
 	// When set, frontline rejects the request with 403 if the key lacks the
 	// required permissions. When empty, no permission check is performed.
@@ -103,9 +103,15 @@ type KeyAuth struct {
 	// this value.
 	//
 	// Must be non-negative.
-	Credits       *int64 `protobuf:"varint,7,opt,name=credits,proto3,oneof" json:"credits,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Credits *int64 `protobuf:"varint,7,opt,name=credits,proto3,oneof" json:"credits,omitempty"`
+	// Settles the credit cost from the upstream response instead of charging a
+	// fixed amount. Use this for metered endpoints whose cost is only known once
+	// the upstream has answered, e.g. tokens generated by a model.
+	//
+	// Mutually exclusive with credits.
+	ResponseCredits *ResponseCredits `protobuf:"bytes,8,opt,name=response_credits,json=responseCredits,proto3,oneof" json:"response_credits,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *KeyAuth) Reset() {
@@ -173,6 +179,89 @@ func (x *KeyAuth) GetCredits() int64 {
 	return 0
 }
 
+func (x *KeyAuth) GetResponseCredits() *ResponseCredits {
+	if x != nil {
+		return x.ResponseCredits
+	}
+	return nil
+}
+
+// ResponseCredits charges a key what the upstream reports a request cost.
+//
+// Frontline reserves `reserve` credits while verifying the key, exactly as if
+// credits were set to that value, so a key that cannot cover the reservation
+// is rejected with 429 before the upstream is called. Because every in-flight
+// request holds its own reservation, concurrent requests can never spend more
+// than the key's remaining credits.
+//
+// Once the proxied response has completed, frontline reads the cost from the
+// named header, or from a trailer of the same name for streamed responses
+// that only know their cost at the end, and refunds the difference between
+// the reservation and the reported cost. The reservation is an upper bound:
+// reported costs above it are charged as the reservation. When the upstream
+// reports nothing usable (header missing, not a non-negative integer, or the
+// upstream failed to respond), the full reservation is kept. Requests that
+// never reach the upstream, because a later policy rejected or answered
+// them, are refunded in full.
+//
+// Keys with unlimited remaining usage are unaffected.
+type ResponseCredits struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The response header or trailer carrying the cost as a base-10 integer,
+	// e.g. "X-Unkey-Cost". Matched case-insensitively. The header and
+	// trailer are removed before the response reaches the client. Required.
+	Header string `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// Credits held for the request until the response completes. Size it to
+	// the most a single request can cost. Must be at least 1.
+	Reserve       int64 `protobuf:"varint,2,opt,name=reserve,proto3" json:"reserve,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseCredits) Reset() {
+	*x = ResponseCredits{}
+	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseCredits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseCredits) ProtoMessage() {}
+
+func (x *ResponseCredits) ProtoReflect() protoreflect.Message {
+	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseCredits.ProtoReflect.Descriptor instead.
+func (*ResponseCredits) Descriptor() ([]byte, []int) {
+	return file_frontline_policies_v1_keyauth_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ResponseCredits) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *ResponseCredits) GetReserve() int64 {
+	if x != nil {
+		return x.Reserve
+	}
+	return 0
+}
+
 // KeyRatelimit selects a rate limit to enforce on the verified key. It mirrors
 // the per-request `ratelimits` entries accepted by Unkey's verifyKey API so
 // that gateway-enforced limits behave identically to application-enforced ones.
@@ -203,7 +292,7 @@ type KeyRatelimit struct {
 
 func (x *KeyRatelimit) Reset() {
 	*x = KeyRatelimit{}
-	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[1]
+	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -215,7 +304,7 @@ func (x *KeyRatelimit) String() string {
 func (*KeyRatelimit) ProtoMessage() {}
 
 func (x *KeyRatelimit) ProtoReflect() protoreflect.Message {
-	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[1]
+	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -228,7 +317,7 @@ func (x *KeyRatelimit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KeyRatelimit.ProtoReflect.Descriptor instead.
 func (*KeyRatelimit) Descriptor() ([]byte, []int) {
-	return file_frontline_policies_v1_keyauth_proto_rawDescGZIP(), []int{1}
+	return file_frontline_policies_v1_keyauth_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *KeyRatelimit) GetName() string {
@@ -277,7 +366,7 @@ type KeyLocation struct {
 
 func (x *KeyLocation) Reset() {
 	*x = KeyLocation{}
-	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[2]
+	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -289,7 +378,7 @@ func (x *KeyLocation) String() string {
 func (*KeyLocation) ProtoMessage() {}
 
 func (x *KeyLocation) ProtoReflect() protoreflect.Message {
-	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[2]
+	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -302,7 +391,7 @@ func (x *KeyLocation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KeyLocation.ProtoReflect.Descriptor instead.
 func (*KeyLocation) Descriptor() ([]byte, []int) {
-	return file_frontline_policies_v1_keyauth_proto_rawDescGZIP(), []int{2}
+	return file_frontline_policies_v1_keyauth_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *KeyLocation) GetLocation() isKeyLocation_Location {
@@ -380,7 +469,7 @@ type BearerTokenLocation struct {
 
 func (x *BearerTokenLocation) Reset() {
 	*x = BearerTokenLocation{}
-	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[3]
+	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -392,7 +481,7 @@ func (x *BearerTokenLocation) String() string {
 func (*BearerTokenLocation) ProtoMessage() {}
 
 func (x *BearerTokenLocation) ProtoReflect() protoreflect.Message {
-	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[3]
+	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -405,7 +494,7 @@ func (x *BearerTokenLocation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BearerTokenLocation.ProtoReflect.Descriptor instead.
 func (*BearerTokenLocation) Descriptor() ([]byte, []int) {
-	return file_frontline_policies_v1_keyauth_proto_rawDescGZIP(), []int{3}
+	return file_frontline_policies_v1_keyauth_proto_rawDescGZIP(), []int{4}
 }
 
 // HeaderKeyLocation extracts the API key from a named request header. This
@@ -427,7 +516,7 @@ type HeaderKeyLocation struct {
 
 func (x *HeaderKeyLocation) Reset() {
 	*x = HeaderKeyLocation{}
-	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[4]
+	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -439,7 +528,7 @@ func (x *HeaderKeyLocation) String() string {
 func (*HeaderKeyLocation) ProtoMessage() {}
 
 func (x *HeaderKeyLocation) ProtoReflect() protoreflect.Message {
-	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[4]
+	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -452,7 +541,7 @@ func (x *HeaderKeyLocation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeaderKeyLocation.ProtoReflect.Descriptor instead.
 func (*HeaderKeyLocation) Descriptor() ([]byte, []int) {
-	return file_frontline_policies_v1_keyauth_proto_rawDescGZIP(), []int{4}
+	return file_frontline_policies_v1_keyauth_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *HeaderKeyLocation) GetName() string {
@@ -480,7 +569,7 @@ type QueryParamKeyLocation struct {
 
 func (x *QueryParamKeyLocation) Reset() {
 	*x = QueryParamKeyLocation{}
-	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[5]
+	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -492,7 +581,7 @@ func (x *QueryParamKeyLocation) String() string {
 func (*QueryParamKeyLocation) ProtoMessage() {}
 
 func (x *QueryParamKeyLocation) ProtoReflect() protoreflect.Message {
-	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[5]
+	mi := &file_frontline_policies_v1_keyauth_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -505,7 +594,7 @@ func (x *QueryParamKeyLocation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryParamKeyLocation.ProtoReflect.Descriptor instead.
 func (*QueryParamKeyLocation) Descriptor() ([]byte, []int) {
-	return file_frontline_policies_v1_keyauth_proto_rawDescGZIP(), []int{5}
+	return file_frontline_policies_v1_keyauth_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *QueryParamKeyLocation) GetName() string {
@@ -519,7 +608,7 @@ var File_frontline_policies_v1_keyauth_proto protoreflect.FileDescriptor
 
 const file_frontline_policies_v1_keyauth_proto_rawDesc = "" +
 	"\n" +
-	"#frontline/policies/v1/keyauth.proto\x12\ffrontline.v1\"\x92\x02\n" +
+	"#frontline/policies/v1/keyauth.proto\x12\ffrontline.v1\"\xf6\x02\n" +
 	"\aKeyAuth\x12\"\n" +
 	"\rkey_space_ids\x18\x01 \x03(\tR\vkeySpaceIds\x127\n" +
 	"\tlocations\x18\x02 \x03(\v2\x19.frontline.v1.KeyLocationR\tlocations\x12.\n" +
@@ -527,10 +616,15 @@ const file_frontline_policies_v1_keyauth_proto_rawDesc = "" +
 	"\n" +
 	"ratelimits\x18\x06 \x03(\v2\x1a.frontline.v1.KeyRatelimitR\n" +
 	"ratelimits\x12\x1d\n" +
-	"\acredits\x18\a \x01(\x03H\x01R\acredits\x88\x01\x01B\x13\n" +
+	"\acredits\x18\a \x01(\x03H\x01R\acredits\x88\x01\x01\x12M\n" +
+	"\x10response_credits\x18\b \x01(\v2\x1d.frontline.v1.ResponseCreditsH\x02R\x0fresponseCredits\x88\x01\x01B\x13\n" +
 	"\x11_permission_queryB\n" +
 	"\n" +
-	"\b_credits\"\x97\x01\n" +
+	"\b_creditsB\x13\n" +
+	"\x11_response_credits\"C\n" +
+	"\x0fResponseCredits\x12\x16\n" +
+	"\x06header\x18\x01 \x01(\tR\x06header\x12\x18\n" +
+	"\areserve\x18\x02 \x01(\x03R\areserve\"\x97\x01\n" +
 	"\fKeyRatelimit\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
 	"\x05limit\x18\x02 \x01(\x03H\x00R\x05limit\x88\x01\x01\x12\x1f\n" +
@@ -566,26 +660,28 @@ func file_frontline_policies_v1_keyauth_proto_rawDescGZIP() []byte {
 	return file_frontline_policies_v1_keyauth_proto_rawDescData
 }
 
-var file_frontline_policies_v1_keyauth_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_frontline_policies_v1_keyauth_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_frontline_policies_v1_keyauth_proto_goTypes = []any{
 	(*KeyAuth)(nil),               // 0: frontline.v1.KeyAuth
-	(*KeyRatelimit)(nil),          // 1: frontline.v1.KeyRatelimit
-	(*KeyLocation)(nil),           // 2: frontline.v1.KeyLocation
-	(*BearerTokenLocation)(nil),   // 3: frontline.v1.BearerTokenLocation
-	(*HeaderKeyLocation)(nil),     // 4: frontline.v1.HeaderKeyLocation
-	(*QueryParamKeyLocation)(nil), // 5: frontline.v1.QueryParamKeyLocation
+	(*ResponseCredits)(nil),       // 1: frontline.v1.ResponseCredits
+	(*KeyRatelimit)(nil),          // 2: frontline.v1.KeyRatelimit
+	(*KeyLocation)(nil),           // 3: frontline.v1.KeyLocation
+	(*BearerTokenLocation)(nil),   // 4: frontline.v1.BearerTokenLocation
+	(*HeaderKeyLocation)(nil),     // 5: frontline.v1.HeaderKeyLocation
+	(*QueryParamKeyLocation)(nil), // 6: frontline.v1.QueryParamKeyLocation
 }
 var file_frontline_policies_v1_keyauth_proto_depIdxs = []int32{
-	2, // 0: frontline.v1.KeyAuth.locations:type_name -> frontline.v1.KeyLocation
-	1, // 1: frontline.v1.KeyAuth.ratelimits:type_name -> frontline.v1.KeyRatelimit
-	3, // 2: frontline.v1.KeyLocation.bearer:type_name -> frontline.v1.BearerTokenLocation
-	4, // 3: frontline.v1.KeyLocation.header:type_name -> frontline.v1.HeaderKeyLocation
-	5, // 4: frontline.v1.KeyLocation.query_param:type_name -> frontline.v1.QueryParamKeyLocation
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	3, // 0: frontline.v1.KeyAuth.locations:type_name -> frontline.v1.KeyLocation
+	2, // 1: frontline.v1.KeyAuth.ratelimits:type_name -> frontline.v1.KeyRatelimit
+	1, // 2: frontline.v1.KeyAuth.response_credits:type_name -> frontline.v1.ResponseCredits
+	4, // 3: frontline.v1.KeyLocation.bearer:type_name -> frontline.v1.BearerTokenLocation
+	5, // 4: frontline.v1.KeyLocation.header:type_name -> frontline.v1.HeaderKeyLocation
+	6, // 5: frontline.v1.KeyLocation.query_param:type_name -> frontline.v1.QueryParamKeyLocation
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_frontline_policies_v1_keyauth_proto_init() }
@@ -594,8 +690,8 @@ func file_frontline_policies_v1_keyauth_proto_init() {
 		return
 	}
 	file_frontline_policies_v1_keyauth_proto_msgTypes[0].OneofWrappers = []any{}
-	file_frontline_policies_v1_keyauth_proto_msgTypes[1].OneofWrappers = []any{}
-	file_frontline_policies_v1_keyauth_proto_msgTypes[2].OneofWrappers = []any{
+	file_frontline_policies_v1_keyauth_proto_msgTypes[2].OneofWrappers = []any{}
+	file_frontline_policies_v1_keyauth_proto_msgTypes[3].OneofWrappers = []any{
 		(*KeyLocation_Bearer)(nil),
 		(*KeyLocation_Header)(nil),
 		(*KeyLocation_QueryParam)(nil),
@@ -606,7 +702,7 @@ func file_frontline_policies_v1_keyauth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_frontline_policies_v1_keyauth_proto_rawDesc), len(file_frontline_policies_v1_keyauth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
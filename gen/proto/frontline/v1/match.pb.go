@@ -26,11 +26,9 @@ const (
 // A Policy carries a repeated list of MatchExpr. All entries must match for
 // the policy to run (implicit AND). An empty list matches all requests.
 //
-// If you need OR semantics, create multiple policies with the same config
-// and different match lists. This is simpler to reason about than a recursive
-// expression tree, and covers the vast majority of real-world routing needs.
-// Combinators (And/Or/Not) can be added later as new oneof branches without
-// breaking the wire format.
+// For OR semantics within one policy, wrap alternatives in [AnyOfMatch]; to
+// invert a condition, wrap it in `not`. Combinators nest, but keep trees
+// shallow: the API rejects nesting deeper than four levels.
 type MatchExpr struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Expr:
@@ -39,6 +37,11 @@ type MatchExpr struct {
 	//	*MatchExpr_Method
 	//	*MatchExpr_Header
 	//	*MatchExpr_QueryParam
+	//	*MatchExpr_RemoteIp
+	//	*MatchExpr_Country
+	//	*MatchExpr_Principal
+	//	*MatchExpr_Not
+	//	*MatchExpr_AnyOf
 	Expr          isMatchExpr_Expr `protobuf_oneof:"expr"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -117,6 +120,51 @@ func (x *MatchExpr) GetQueryParam() *QueryParamMatch {
 	return nil
 }
 
+func (x *MatchExpr) GetRemoteIp() *RemoteIpMatch {
+	if x != nil {
+		if x, ok := x.Expr.(*MatchExpr_RemoteIp); ok {
+			return x.RemoteIp
+		}
+	}
+	return nil
+}
+
+func (x *MatchExpr) GetCountry() *CountryMatch {
+	if x != nil {
+		if x, ok := x.Expr.(*MatchExpr_Country); ok {
+			return x.Country
+		}
+	}
+	return nil
+}
+
+func (x *MatchExpr) GetPrincipal() *PrincipalMatch {
+	if x != nil {
+		if x, ok := x.Expr.(*MatchExpr_Principal); ok {
+			return x.Principal
+		}
+	}
+	return nil
+}
+
+func (x *MatchExpr) GetNot() *MatchExpr {
+	if x != nil {
+		if x, ok := x.Expr.(*MatchExpr_Not); ok {
+			return x.Not
+		}
+	}
+	return nil
+}
+
+func (x *MatchExpr) GetAnyOf() *AnyOfMatch {
+	if x != nil {
+		if x, ok := x.Expr.(*MatchExpr_AnyOf); ok {
+			return x.AnyOf
+		}
+	}
+	return nil
+}
+
 type isMatchExpr_Expr interface {
 	isMatchExpr_Expr()
 }
@@ -137,6 +185,27 @@ type MatchExpr_QueryParam struct {
 	QueryParam *QueryParamMatch `protobuf:"bytes,4,opt,name=query_param,json=queryParam,proto3,oneof"`
 }
 
+type MatchExpr_RemoteIp struct {
+	RemoteIp *RemoteIpMatch `protobuf:"bytes,5,opt,name=remote_ip,json=remoteIp,proto3,oneof"`
+}
+
+type MatchExpr_Country struct {
+	Country *CountryMatch `protobuf:"bytes,6,opt,name=country,proto3,oneof"`
+}
+
+type MatchExpr_Principal struct {
+	Principal *PrincipalMatch `protobuf:"bytes,7,opt,name=principal,proto3,oneof"`
+}
+
+type MatchExpr_Not struct {
+	// Matches when the wrapped expression does not match.
+	Not *MatchExpr `protobuf:"bytes,8,opt,name=not,proto3,oneof"`
+}
+
+type MatchExpr_AnyOf struct {
+	AnyOf *AnyOfMatch `protobuf:"bytes,9,opt,name=any_of,json=anyOf,proto3,oneof"`
+}
+
 func (*MatchExpr_Path) isMatchExpr_Expr() {}
 
 func (*MatchExpr_Method) isMatchExpr_Expr() {}
@@ -145,6 +214,16 @@ func (*MatchExpr_Header) isMatchExpr_Expr() {}
 
 func (*MatchExpr_QueryParam) isMatchExpr_Expr() {}
 
+func (*MatchExpr_RemoteIp) isMatchExpr_Expr() {}
+
+func (*MatchExpr_Country) isMatchExpr_Expr() {}
+
+func (*MatchExpr_Principal) isMatchExpr_Expr() {}
+
+func (*MatchExpr_Not) isMatchExpr_Expr() {}
+
+func (*MatchExpr_AnyOf) isMatchExpr_Expr() {}
+
 // StringMatch is the shared string matching primitive used by all leaf
 // matchers that compare against string values (paths, header values, query
 // parameter values). Centralizing matching logic in one message ensures
@@ -575,17 +654,270 @@ func (*QueryParamMatch_Present) isQueryParamMatch_Match() {}
 
 func (*QueryParamMatch_Value) isQueryParamMatch_Match() {}
 
+// AnyOfMatch matches when at least one of its expressions matches. An empty
+// list never matches.
+type AnyOfMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exprs         []*MatchExpr           `protobuf:"bytes,1,rep,name=exprs,proto3" json:"exprs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnyOfMatch) Reset() {
+	*x = AnyOfMatch{}
+	mi := &file_frontline_policies_v1_match_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnyOfMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnyOfMatch) ProtoMessage() {}
+
+func (x *AnyOfMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_frontline_policies_v1_match_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnyOfMatch.ProtoReflect.Descriptor instead.
+func (*AnyOfMatch) Descriptor() ([]byte, []int) {
+	return file_frontline_policies_v1_match_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AnyOfMatch) GetExprs() []*MatchExpr {
+	if x != nil {
+		return x.Exprs
+	}
+	return nil
+}
+
+// RemoteIpMatch tests the client IP, the same address rate limiting keys on
+// with RemoteIpKey. The match succeeds if the address falls inside any of the
+// listed networks.
+type RemoteIpMatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Networks in CIDR notation, IPv4 or IPv6, e.g. "203.0.113.0/24" or
+	// "2001:db8::/32". A bare address matches that single address. IPv4-mapped
+	// IPv6 client addresses are compared as IPv4.
+	Cidrs         []string `protobuf:"bytes,1,rep,name=cidrs,proto3" json:"cidrs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoteIpMatch) Reset() {
+	*x = RemoteIpMatch{}
+	mi := &file_frontline_policies_v1_match_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteIpMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteIpMatch) ProtoMessage() {}
+
+func (x *RemoteIpMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_frontline_policies_v1_match_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteIpMatch.ProtoReflect.Descriptor instead.
+func (*RemoteIpMatch) Descriptor() ([]byte, []int) {
+	return file_frontline_policies_v1_match_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RemoteIpMatch) GetCidrs() []string {
+	if x != nil {
+		return x.Cidrs
+	}
+	return nil
+}
+
+// CountryMatch tests the country the client IP is located in, resolved with
+// the GeoIP database frontline loads at startup. Requests whose country
+// cannot be resolved, including every request when no database is
+// configured, do not match.
+type CountryMatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ISO 3166-1 alpha-2 country codes, e.g. ["US", "CA"]. Compared
+	// case-insensitively. The match succeeds if the client's country equals
+	// any entry.
+	Countries     []string `protobuf:"bytes,1,rep,name=countries,proto3" json:"countries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountryMatch) Reset() {
+	*x = CountryMatch{}
+	mi := &file_frontline_policies_v1_match_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountryMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountryMatch) ProtoMessage() {}
+
+func (x *CountryMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_frontline_policies_v1_match_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountryMatch.ProtoReflect.Descriptor instead.
+func (*CountryMatch) Descriptor() ([]byte, []int) {
+	return file_frontline_policies_v1_match_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CountryMatch) GetCountries() []string {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+// PrincipalMatch tests a field of the principal set by an earlier KeyAuth or
+// JWTAuth policy in the same list. Policies are evaluated in order, so a
+// PrincipalMatch placed before the authentication policy, or on a request no
+// authentication policy matched, sees no principal and does not match.
+type PrincipalMatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Dotted path into the principal's JSON form, the same paths
+	// PrincipalFieldKey uses, e.g. "subject", "source.key.meta.plan" or
+	// "source.jwt.payload.tier". Numbers and booleans are compared by their
+	// JSON text, e.g. "true" or "42".
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// Types that are valid to be assigned to Match:
+	//
+	//	*PrincipalMatch_Present
+	//	*PrincipalMatch_Value
+	Match         isPrincipalMatch_Match `protobuf_oneof:"match"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrincipalMatch) Reset() {
+	*x = PrincipalMatch{}
+	mi := &file_frontline_policies_v1_match_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrincipalMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrincipalMatch) ProtoMessage() {}
+
+func (x *PrincipalMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_frontline_policies_v1_match_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrincipalMatch.ProtoReflect.Descriptor instead.
+func (*PrincipalMatch) Descriptor() ([]byte, []int) {
+	return file_frontline_policies_v1_match_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PrincipalMatch) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *PrincipalMatch) GetMatch() isPrincipalMatch_Match {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+func (x *PrincipalMatch) GetPresent() bool {
+	if x != nil {
+		if x, ok := x.Match.(*PrincipalMatch_Present); ok {
+			return x.Present
+		}
+	}
+	return false
+}
+
+func (x *PrincipalMatch) GetValue() *StringMatch {
+	if x != nil {
+		if x, ok := x.Match.(*PrincipalMatch_Value); ok {
+			return x.Value
+		}
+	}
+	return nil
+}
+
+type isPrincipalMatch_Match interface {
+	isPrincipalMatch_Match()
+}
+
+type PrincipalMatch_Present struct {
+	// When true, the match succeeds if the field exists; when false, if it
+	// does not.
+	Present bool `protobuf:"varint,2,opt,name=present,proto3,oneof"`
+}
+
+type PrincipalMatch_Value struct {
+	// Match against the field value using a [StringMatch].
+	Value *StringMatch `protobuf:"bytes,3,opt,name=value,proto3,oneof"`
+}
+
+func (*PrincipalMatch_Present) isPrincipalMatch_Match() {}
+
+func (*PrincipalMatch_Value) isPrincipalMatch_Match() {}
+
 var File_frontline_policies_v1_match_proto protoreflect.FileDescriptor
 
 const file_frontline_policies_v1_match_proto_rawDesc = "" +
 	"\n" +
-	"!frontline/policies/v1/match.proto\x12\ffrontline.v1\"\xee\x01\n" +
+	"!frontline/policies/v1/match.proto\x12\ffrontline.v1\"\x80\x04\n" +
 	"\tMatchExpr\x12-\n" +
 	"\x04path\x18\x01 \x01(\v2\x17.frontline.v1.PathMatchH\x00R\x04path\x123\n" +
 	"\x06method\x18\x02 \x01(\v2\x19.frontline.v1.MethodMatchH\x00R\x06method\x123\n" +
 	"\x06header\x18\x03 \x01(\v2\x19.frontline.v1.HeaderMatchH\x00R\x06header\x12@\n" +
 	"\vquery_param\x18\x04 \x01(\v2\x1d.frontline.v1.QueryParamMatchH\x00R\n" +
-	"queryParamB\x06\n" +
+	"queryParam\x12:\n" +
+	"\tremote_ip\x18\x05 \x01(\v2\x1b.frontline.v1.RemoteIpMatchH\x00R\bremoteIp\x126\n" +
+	"\acountry\x18\x06 \x01(\v2\x1a.frontline.v1.CountryMatchH\x00R\acountry\x12<\n" +
+	"\tprincipal\x18\a \x01(\v2\x1c.frontline.v1.PrincipalMatchH\x00R\tprincipal\x12+\n" +
+	"\x03not\x18\b \x01(\v2\x17.frontline.v1.MatchExprH\x00R\x03not\x121\n" +
+	"\x06any_of\x18\t \x01(\v2\x18.frontline.v1.AnyOfMatchH\x00R\x05anyOfB\x06\n" +
 	"\x04expr\"\x81\x01\n" +
 	"\vStringMatch\x12\x1f\n" +
 	"\vignore_case\x18\x01 \x01(\bR\n" +
@@ -607,6 +939,18 @@ const file_frontline_policies_v1_match_proto_rawDesc = "" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
 	"\apresent\x18\x02 \x01(\bH\x00R\apresent\x121\n" +
 	"\x05value\x18\x03 \x01(\v2\x19.frontline.v1.StringMatchH\x00R\x05valueB\a\n" +
+	"\x05match\";\n" +
+	"\n" +
+	"AnyOfMatch\x12-\n" +
+	"\x05exprs\x18\x01 \x03(\v2\x17.frontline.v1.MatchExprR\x05exprs\"%\n" +
+	"\rRemoteIpMatch\x12\x14\n" +
+	"\x05cidrs\x18\x01 \x03(\tR\x05cidrs\",\n" +
+	"\fCountryMatch\x12\x1c\n" +
+	"\tcountries\x18\x01 \x03(\tR\tcountries\"~\n" +
+	"\x0ePrincipalMatch\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1a\n" +
+	"\apresent\x18\x02 \x01(\bH\x00R\apresent\x121\n" +
+	"\x05value\x18\x03 \x01(\v2\x19.frontline.v1.StringMatchH\x00R\x05valueB\a\n" +
 	"\x05matchB\xac\x01\n" +
 	"\x10com.frontline.v1B\n" +
 	"MatchProtoP\x01Z;github.com/unkeyed/unkey/gen/proto/frontline/v1;frontlinev1\xa2\x02\x03FXX\xaa\x02\fFrontline.V1\xca\x02\fFrontline\\V1\xe2\x02\x18Frontline\\V1\\GPBMetadata\xea\x02\rFrontline::V1b\x06proto3"
@@ -623,7 +967,7 @@ func file_frontline_policies_v1_match_proto_rawDescGZIP() []byte {
 	return file_frontline_policies_v1_match_proto_rawDescData
 }
 
-var file_frontline_policies_v1_match_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_frontline_policies_v1_match_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_frontline_policies_v1_match_proto_goTypes = []any{
 	(*MatchExpr)(nil),       // 0: frontline.v1.MatchExpr
 	(*StringMatch)(nil),     // 1: frontline.v1.StringMatch
@@ -631,20 +975,31 @@ var file_frontline_policies_v1_match_proto_goTypes = []any{
 	(*MethodMatch)(nil),     // 3: frontline.v1.MethodMatch
 	(*HeaderMatch)(nil),     // 4: frontline.v1.HeaderMatch
 	(*QueryParamMatch)(nil), // 5: frontline.v1.QueryParamMatch
+	(*AnyOfMatch)(nil),      // 6: frontline.v1.AnyOfMatch
+	(*RemoteIpMatch)(nil),   // 7: frontline.v1.RemoteIpMatch
+	(*CountryMatch)(nil),    // 8: frontline.v1.CountryMatch
+	(*PrincipalMatch)(nil),  // 9: frontline.v1.PrincipalMatch
 }
 var file_frontline_policies_v1_match_proto_depIdxs = []int32{
-	2, // 0: frontline.v1.MatchExpr.path:type_name -> frontline.v1.PathMatch
-	3, // 1: frontline.v1.MatchExpr.method:type_name -> frontline.v1.MethodMatch
-	4, // 2: frontline.v1.MatchExpr.header:type_name -> frontline.v1.HeaderMatch
-	5, // 3: frontline.v1.MatchExpr.query_param:type_name -> frontline.v1.QueryParamMatch
-	1, // 4: frontline.v1.PathMatch.path:type_name -> frontline.v1.StringMatch
-	1, // 5: frontline.v1.HeaderMatch.value:type_name -> frontline.v1.StringMatch
-	1, // 6: frontline.v1.QueryParamMatch.value:type_name -> frontline.v1.StringMatch
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	2,  // 0: frontline.v1.MatchExpr.path:type_name -> frontline.v1.PathMatch
+	3,  // 1: frontline.v1.MatchExpr.method:type_name -> frontline.v1.MethodMatch
+	4,  // 2: frontline.v1.MatchExpr.header:type_name -> frontline.v1.HeaderMatch
+	5,  // 3: frontline.v1.MatchExpr.query_param:type_name -> frontline.v1.QueryParamMatch
+	7,  // 4: frontline.v1.MatchExpr.remote_ip:type_name -> frontline.v1.RemoteIpMatch
+	8,  // 5: frontline.v1.MatchExpr.country:type_name -> frontline.v1.CountryMatch
+	9,  // 6: frontline.v1.MatchExpr.principal:type_name -> frontline.v1.PrincipalMatch
+	0,  // 7: frontline.v1.MatchExpr.not:type_name -> frontline.v1.MatchExpr
+	6,  // 8: frontline.v1.MatchExpr.any_of:type_name -> frontline.v1.AnyOfMatch
+	1,  // 9: frontline.v1.PathMatch.path:type_name -> frontline.v1.StringMatch
+	1,  // 10: frontline.v1.HeaderMatch.value:type_name -> frontline.v1.StringMatch
+	1,  // 11: frontline.v1.QueryParamMatch.value:type_name -> frontline.v1.StringMatch
+	0,  // 12: frontline.v1.AnyOfMatch.exprs:type_name -> frontline.v1.MatchExpr
+	1,  // 13: frontline.v1.PrincipalMatch.value:type_name -> frontline.v1.StringMatch
+	14, // [14:14] is the sub-list for method output_type
+	14, // [14:14] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_frontline_policies_v1_match_proto_init() }
@@ -657,6 +1012,11 @@ func file_frontline_policies_v1_match_proto_init() {
 		(*MatchExpr_Method)(nil),
 		(*MatchExpr_Header)(nil),
 		(*MatchExpr_QueryParam)(nil),
+		(*MatchExpr_RemoteIp)(nil),
+		(*MatchExpr_Country)(nil),
+		(*MatchExpr_Principal)(nil),
+		(*MatchExpr_Not)(nil),
+		(*MatchExpr_AnyOf)(nil),
 	}
 	file_frontline_policies_v1_match_proto_msgTypes[1].OneofWrappers = []any{
 		(*StringMatch_Exact)(nil),
@@ -671,13 +1031,17 @@ func file_frontline_policies_v1_match_proto_init() {
 		(*QueryParamMatch_Present)(nil),
 		(*QueryParamMatch_Value)(nil),
 	}
+	file_frontline_policies_v1_match_proto_msgTypes[9].OneofWrappers = []any{
+		(*PrincipalMatch_Present)(nil),
+		(*PrincipalMatch_Value)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_frontline_policies_v1_match_proto_rawDesc), len(file_frontline_policies_v1_match_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
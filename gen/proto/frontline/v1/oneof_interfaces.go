@@ -20,6 +20,9 @@ type IsHeaderMatch_Match = isHeaderMatch_Match
 // IsQueryParamMatch_Match is the exported form of the protobuf oneof interface isQueryParamMatch_Match.
 type IsQueryParamMatch_Match = isQueryParamMatch_Match
 
+// IsPrincipalMatch_Match is the exported form of the protobuf oneof interface isPrincipalMatch_Match.
+type IsPrincipalMatch_Match = isPrincipalMatch_Match
+
 // IsPolicy_Config is the exported form of the protobuf oneof interface isPolicy_Config.
 type IsPolicy_Config = isPolicy_Config
 
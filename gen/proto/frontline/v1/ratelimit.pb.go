@@ -21,6 +21,70 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// RateLimitAlgorithm selects how a [RateLimit] policy counts requests. All
+// algorithms share the same distributed counters; they differ in how those
+// counts turn into a decision.
+type RateLimitAlgorithm int32
+
+const (
+	RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_UNSPECIFIED RateLimitAlgorithm = 0
+	// Counts the current window plus a weighted share of the previous one,
+	// which smooths the burst a fixed window allows at its boundary.
+	RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_SLIDING_WINDOW RateLimitAlgorithm = 1
+	// Counts only the current window. Windows are epoch-aligned, not
+	// calendar-aligned: they start at multiples of window_ms since the Unix
+	// epoch, so hourly and daily limits reset on UTC hours and days, weekly
+	// limits on Thursdays at 00:00 UTC, and there are no monthly or time zone
+	// aligned windows.
+	RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_FIXED_WINDOW RateLimitAlgorithm = 2
+	// Treats limit as a bucket capacity refilled by refill_rate tokens every
+	// window_ms, allowing a burst of up to limit followed by a steady rate.
+	RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_TOKEN_BUCKET RateLimitAlgorithm = 3
+)
+
+// Enum value maps for RateLimitAlgorithm.
+var (
+	RateLimitAlgorithm_name = map[int32]string{
+		0: "RATE_LIMIT_ALGORITHM_UNSPECIFIED",
+		1: "RATE_LIMIT_ALGORITHM_SLIDING_WINDOW",
+		2: "RATE_LIMIT_ALGORITHM_FIXED_WINDOW",
+		3: "RATE_LIMIT_ALGORITHM_TOKEN_BUCKET",
+	}
+	RateLimitAlgorithm_value = map[string]int32{
+		"RATE_LIMIT_ALGORITHM_UNSPECIFIED":    0,
+		"RATE_LIMIT_ALGORITHM_SLIDING_WINDOW": 1,
+		"RATE_LIMIT_ALGORITHM_FIXED_WINDOW":   2,
+		"RATE_LIMIT_ALGORITHM_TOKEN_BUCKET":   3,
+	}
+)
+
+func (x RateLimitAlgorithm) Enum() *RateLimitAlgorithm {
+	p := new(RateLimitAlgorithm)
+	*p = x
+	return p
+}
+
+func (x RateLimitAlgorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RateLimitAlgorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_frontline_policies_v1_ratelimit_proto_enumTypes[0].Descriptor()
+}
+
+func (RateLimitAlgorithm) Type() protoreflect.EnumType {
+	return &file_frontline_policies_v1_ratelimit_proto_enumTypes[0]
+}
+
+func (x RateLimitAlgorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RateLimitAlgorithm.Descriptor instead.
+func (RateLimitAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return file_frontline_policies_v1_ratelimit_proto_rawDescGZIP(), []int{0}
+}
+
 // RateLimit enforces request rate limits at the gateway, protecting upstream
 // services from being overwhelmed by traffic spikes, abusive clients, or
 // misconfigured integrations.
@@ -62,7 +126,15 @@ type RateLimit struct {
 	// rate limit bucket, so [authenticated_subject, path] limits each subject
 	// independently on each path. Every unique value tuple gets its own
 	// counter with the same limit and window.
-	Identifiers   []*RateLimitIdentifier `protobuf:"bytes,4,rep,name=identifiers,proto3" json:"identifiers,omitempty"`
+	Identifiers []*RateLimitIdentifier `protobuf:"bytes,4,rep,name=identifiers,proto3" json:"identifiers,omitempty"`
+	// How requests are counted against limit. Unspecified means
+	// RATE_LIMIT_ALGORITHM_SLIDING_WINDOW, which is how policies stored before
+	// this field existed behave.
+	Algorithm RateLimitAlgorithm `protobuf:"varint,5,opt,name=algorithm,proto3,enum=frontline.v1.RateLimitAlgorithm" json:"algorithm,omitempty"`
+	// Tokens a RATE_LIMIT_ALGORITHM_TOKEN_BUCKET regains every window_ms, at
+	// most limit. Zero refills the whole bucket once per window. Ignored by the
+	// window algorithms.
+	RefillRate    int64 `protobuf:"varint,6,opt,name=refill_rate,json=refillRate,proto3" json:"refill_rate,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -126,6 +198,20 @@ func (x *RateLimit) GetIdentifiers() []*RateLimitIdentifier {
 	return nil
 }
 
+func (x *RateLimit) GetAlgorithm() RateLimitAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_UNSPECIFIED
+}
+
+func (x *RateLimit) GetRefillRate() int64 {
+	if x != nil {
+		return x.RefillRate
+	}
+	return 0
+}
+
 // RateLimitIdentifier determines how frontline identifies the entity being rate
 // limited. The choice of identifier fundamentally changes the limiting
 // behavior, so it should match the threat model and use case.
@@ -511,14 +597,17 @@ var File_frontline_policies_v1_ratelimit_proto protoreflect.FileDescriptor
 
 const file_frontline_policies_v1_ratelimit_proto_rawDesc = "" +
 	"\n" +
-	"%frontline/policies/v1/ratelimit.proto\x12\ffrontline.v1\"\xca\x01\n" +
+	"%frontline/policies/v1/ratelimit.proto\x12\ffrontline.v1\"\xab\x02\n" +
 	"\tRateLimit\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x03R\x05limit\x12\x1b\n" +
 	"\twindow_ms\x18\x02 \x01(\x03R\bwindowMs\x12E\n" +
 	"\n" +
 	"identifier\x18\x03 \x01(\v2!.frontline.v1.RateLimitIdentifierB\x02\x18\x01R\n" +
 	"identifier\x12C\n" +
-	"\videntifiers\x18\x04 \x03(\v2!.frontline.v1.RateLimitIdentifierR\videntifiers\"\xe3\x02\n" +
+	"\videntifiers\x18\x04 \x03(\v2!.frontline.v1.RateLimitIdentifierR\videntifiers\x12>\n" +
+	"\talgorithm\x18\x05 \x01(\x0e2 .frontline.v1.RateLimitAlgorithmR\talgorithm\x12\x1f\n" +
+	"\vrefill_rate\x18\x06 \x01(\x03R\n" +
+	"refillRate\"\xe3\x02\n" +
 	"\x13RateLimitIdentifier\x128\n" +
 	"\tremote_ip\x18\x01 \x01(\v2\x19.frontline.v1.RemoteIpKeyH\x00R\bremoteIp\x121\n" +
 	"\x06header\x18\x02 \x01(\v2\x17.frontline.v1.HeaderKeyH\x00R\x06header\x12\\\n" +
@@ -532,7 +621,12 @@ const file_frontline_policies_v1_ratelimit_proto_rawDesc = "" +
 	"\x17AuthenticatedSubjectKey\"\t\n" +
 	"\aPathKey\"'\n" +
 	"\x11PrincipalFieldKey\x12\x12\n" +
-	"\x04path\x18\x01 \x01(\tR\x04pathB\xb0\x01\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path*\xb1\x01\n" +
+	"\x12RateLimitAlgorithm\x12$\n" +
+	" RATE_LIMIT_ALGORITHM_UNSPECIFIED\x10\x00\x12'\n" +
+	"#RATE_LIMIT_ALGORITHM_SLIDING_WINDOW\x10\x01\x12%\n" +
+	"!RATE_LIMIT_ALGORITHM_FIXED_WINDOW\x10\x02\x12%\n" +
+	"!RATE_LIMIT_ALGORITHM_TOKEN_BUCKET\x10\x03B\xb0\x01\n" +
 	"\x10com.frontline.v1B\x0eRatelimitProtoP\x01Z;github.com/unkeyed/unkey/gen/proto/frontline/v1;frontlinev1\xa2\x02\x03FXX\xaa\x02\fFrontline.V1\xca\x02\fFrontline\\V1\xe2\x02\x18Frontline\\V1\\GPBMetadata\xea\x02\rFrontline::V1b\x06proto3"
 
 var (
@@ -547,29 +641,32 @@ func file_frontline_policies_v1_ratelimit_proto_rawDescGZIP() []byte {
 	return file_frontline_policies_v1_ratelimit_proto_rawDescData
 }
 
+var file_frontline_policies_v1_ratelimit_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_frontline_policies_v1_ratelimit_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_frontline_policies_v1_ratelimit_proto_goTypes = []any{
-	(*RateLimit)(nil),               // 0: frontline.v1.RateLimit
-	(*RateLimitIdentifier)(nil),     // 1: frontline.v1.RateLimitIdentifier
-	(*RemoteIpKey)(nil),             // 2: frontline.v1.RemoteIpKey
-	(*HeaderKey)(nil),               // 3: frontline.v1.HeaderKey
-	(*AuthenticatedSubjectKey)(nil), // 4: frontline.v1.AuthenticatedSubjectKey
-	(*PathKey)(nil),                 // 5: frontline.v1.PathKey
-	(*PrincipalFieldKey)(nil),       // 6: frontline.v1.PrincipalFieldKey
+	(RateLimitAlgorithm)(0),         // 0: frontline.v1.RateLimitAlgorithm
+	(*RateLimit)(nil),               // 1: frontline.v1.RateLimit
+	(*RateLimitIdentifier)(nil),     // 2: frontline.v1.RateLimitIdentifier
+	(*RemoteIpKey)(nil),             // 3: frontline.v1.RemoteIpKey
+	(*HeaderKey)(nil),               // 4: frontline.v1.HeaderKey
+	(*AuthenticatedSubjectKey)(nil), // 5: frontline.v1.AuthenticatedSubjectKey
+	(*PathKey)(nil),                 // 6: frontline.v1.PathKey
+	(*PrincipalFieldKey)(nil),       // 7: frontline.v1.PrincipalFieldKey
 }
 var file_frontline_policies_v1_ratelimit_proto_depIdxs = []int32{
-	1, // 0: frontline.v1.RateLimit.identifier:type_name -> frontline.v1.RateLimitIdentifier
-	1, // 1: frontline.v1.RateLimit.identifiers:type_name -> frontline.v1.RateLimitIdentifier
-	2, // 2: frontline.v1.RateLimitIdentifier.remote_ip:type_name -> frontline.v1.RemoteIpKey
-	3, // 3: frontline.v1.RateLimitIdentifier.header:type_name -> frontline.v1.HeaderKey
-	4, // 4: frontline.v1.RateLimitIdentifier.authenticated_subject:type_name -> frontline.v1.AuthenticatedSubjectKey
-	5, // 5: frontline.v1.RateLimitIdentifier.path:type_name -> frontline.v1.PathKey
-	6, // 6: frontline.v1.RateLimitIdentifier.principal_field:type_name -> frontline.v1.PrincipalFieldKey
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	2, // 0: frontline.v1.RateLimit.identifier:type_name -> frontline.v1.RateLimitIdentifier
+	2, // 1: frontline.v1.RateLimit.identifiers:type_name -> frontline.v1.RateLimitIdentifier
+	0, // 2: frontline.v1.RateLimit.algorithm:type_name -> frontline.v1.RateLimitAlgorithm
+	3, // 3: frontline.v1.RateLimitIdentifier.remote_ip:type_name -> frontline.v1.RemoteIpKey
+	4, // 4: frontline.v1.RateLimitIdentifier.header:type_name -> frontline.v1.HeaderKey
+	5, // 5: frontline.v1.RateLimitIdentifier.authenticated_subject:type_name -> frontline.v1.AuthenticatedSubjectKey
+	6, // 6: frontline.v1.RateLimitIdentifier.path:type_name -> frontline.v1.PathKey
+	7, // 7: frontline.v1.RateLimitIdentifier.principal_field:type_name -> frontline.v1.PrincipalFieldKey
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_frontline_policies_v1_ratelimit_proto_init() }
@@ -589,13 +686,14 @@ func file_frontline_policies_v1_ratelimit_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_frontline_policies_v1_ratelimit_proto_rawDesc), len(file_frontline_policies_v1_ratelimit_proto_rawDesc)),
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_frontline_policies_v1_ratelimit_proto_goTypes,
 		DependencyIndexes: file_frontline_policies_v1_ratelimit_proto_depIdxs,
+		EnumInfos:         file_frontline_policies_v1_ratelimit_proto_enumTypes,
 		MessageInfos:      file_frontline_policies_v1_ratelimit_proto_msgTypes,
 	}.Build()
 	File_frontline_policies_v1_ratelimit_proto = out.File
@@ -155,10 +155,12 @@ func (*RunKeyRefillRequest) Descriptor() ([]byte, []int) {
 }
 
 type RunKeyRefillResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	KeysRefilled  int32                  `protobuf:"varint,1,opt,name=keys_refilled,json=keysRefilled,proto3" json:"keys_refilled,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	KeysRefilled int32                  `protobuf:"varint,1,opt,name=keys_refilled,json=keysRefilled,proto3" json:"keys_refilled,omitempty"`
+	// identities_refilled counts identity credit pools refilled.
+	IdentitiesRefilled int32 `protobuf:"varint,2,opt,name=identities_refilled,json=identitiesRefilled,proto3" json:"identities_refilled,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *RunKeyRefillResponse) Reset() {
@@ -198,6 +200,262 @@ func (x *RunKeyRefillResponse) GetKeysRefilled() int32 {
 	return 0
 }
 
+func (x *RunKeyRefillResponse) GetIdentitiesRefilled() int32 {
+	if x != nil {
+		return x.IdentitiesRefilled
+	}
+	return 0
+}
+
+type RunKeyRotationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunKeyRotationRequest) Reset() {
+	*x = RunKeyRotationRequest{}
+	mi := &file_hydra_v1_cron_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunKeyRotationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunKeyRotationRequest) ProtoMessage() {}
+
+func (x *RunKeyRotationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_cron_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunKeyRotationRequest.ProtoReflect.Descriptor instead.
+func (*RunKeyRotationRequest) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{4}
+}
+
+type RunKeyRotationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeysRotated   int32                  `protobuf:"varint,1,opt,name=keys_rotated,json=keysRotated,proto3" json:"keys_rotated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunKeyRotationResponse) Reset() {
+	*x = RunKeyRotationResponse{}
+	mi := &file_hydra_v1_cron_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunKeyRotationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunKeyRotationResponse) ProtoMessage() {}
+
+func (x *RunKeyRotationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_cron_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunKeyRotationResponse.ProtoReflect.Descriptor instead.
+func (*RunKeyRotationResponse) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RunKeyRotationResponse) GetKeysRotated() int32 {
+	if x != nil {
+		return x.KeysRotated
+	}
+	return 0
+}
+
+type ExpireRotatedKeyRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	// Hash of the replaced key, which its verification cache entry is keyed by.
+	Hash          string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpireRotatedKeyRequest) Reset() {
+	*x = ExpireRotatedKeyRequest{}
+	mi := &file_hydra_v1_cron_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpireRotatedKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpireRotatedKeyRequest) ProtoMessage() {}
+
+func (x *ExpireRotatedKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_cron_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpireRotatedKeyRequest.ProtoReflect.Descriptor instead.
+func (*ExpireRotatedKeyRequest) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExpireRotatedKeyRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *ExpireRotatedKeyRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type ExpireRotatedKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpireRotatedKeyResponse) Reset() {
+	*x = ExpireRotatedKeyResponse{}
+	mi := &file_hydra_v1_cron_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpireRotatedKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpireRotatedKeyResponse) ProtoMessage() {}
+
+func (x *ExpireRotatedKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_cron_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpireRotatedKeyResponse.ProtoReflect.Descriptor instead.
+func (*ExpireRotatedKeyResponse) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{7}
+}
+
+type RunKeyExpiryNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunKeyExpiryNotificationsRequest) Reset() {
+	*x = RunKeyExpiryNotificationsRequest{}
+	mi := &file_hydra_v1_cron_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunKeyExpiryNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunKeyExpiryNotificationsRequest) ProtoMessage() {}
+
+func (x *RunKeyExpiryNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_cron_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunKeyExpiryNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*RunKeyExpiryNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{8}
+}
+
+type RunKeyExpiryNotificationsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	NotificationsSent int32                  `protobuf:"varint,1,opt,name=notifications_sent,json=notificationsSent,proto3" json:"notifications_sent,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RunKeyExpiryNotificationsResponse) Reset() {
+	*x = RunKeyExpiryNotificationsResponse{}
+	mi := &file_hydra_v1_cron_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunKeyExpiryNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunKeyExpiryNotificationsResponse) ProtoMessage() {}
+
+func (x *RunKeyExpiryNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_cron_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunKeyExpiryNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*RunKeyExpiryNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RunKeyExpiryNotificationsResponse) GetNotificationsSent() int32 {
+	if x != nil {
+		return x.NotificationsSent
+	}
+	return 0
+}
+
 type RunKeyLastUsedSyncRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -206,7 +464,7 @@ type RunKeyLastUsedSyncRequest struct {
 
 func (x *RunKeyLastUsedSyncRequest) Reset() {
 	*x = RunKeyLastUsedSyncRequest{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[4]
+	mi := &file_hydra_v1_cron_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -218,7 +476,7 @@ func (x *RunKeyLastUsedSyncRequest) String() string {
 func (*RunKeyLastUsedSyncRequest) ProtoMessage() {}
 
 func (x *RunKeyLastUsedSyncRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[4]
+	mi := &file_hydra_v1_cron_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -231,7 +489,7 @@ func (x *RunKeyLastUsedSyncRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunKeyLastUsedSyncRequest.ProtoReflect.Descriptor instead.
 func (*RunKeyLastUsedSyncRequest) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{4}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{10}
 }
 
 type RunKeyLastUsedSyncResponse struct {
@@ -243,7 +501,7 @@ type RunKeyLastUsedSyncResponse struct {
 
 func (x *RunKeyLastUsedSyncResponse) Reset() {
 	*x = RunKeyLastUsedSyncResponse{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[5]
+	mi := &file_hydra_v1_cron_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -255,7 +513,7 @@ func (x *RunKeyLastUsedSyncResponse) String() string {
 func (*RunKeyLastUsedSyncResponse) ProtoMessage() {}
 
 func (x *RunKeyLastUsedSyncResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[5]
+	mi := &file_hydra_v1_cron_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -268,7 +526,7 @@ func (x *RunKeyLastUsedSyncResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunKeyLastUsedSyncResponse.ProtoReflect.Descriptor instead.
 func (*RunKeyLastUsedSyncResponse) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{5}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *RunKeyLastUsedSyncResponse) GetKeysSynced() int32 {
@@ -286,7 +544,7 @@ type RunAuditLogExportRequest struct {
 
 func (x *RunAuditLogExportRequest) Reset() {
 	*x = RunAuditLogExportRequest{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[6]
+	mi := &file_hydra_v1_cron_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -298,7 +556,7 @@ func (x *RunAuditLogExportRequest) String() string {
 func (*RunAuditLogExportRequest) ProtoMessage() {}
 
 func (x *RunAuditLogExportRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[6]
+	mi := &file_hydra_v1_cron_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -311,7 +569,7 @@ func (x *RunAuditLogExportRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunAuditLogExportRequest.ProtoReflect.Descriptor instead.
 func (*RunAuditLogExportRequest) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{6}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{12}
 }
 
 type RunAuditLogExportResponse struct {
@@ -323,7 +581,7 @@ type RunAuditLogExportResponse struct {
 
 func (x *RunAuditLogExportResponse) Reset() {
 	*x = RunAuditLogExportResponse{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[7]
+	mi := &file_hydra_v1_cron_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -335,7 +593,7 @@ func (x *RunAuditLogExportResponse) String() string {
 func (*RunAuditLogExportResponse) ProtoMessage() {}
 
 func (x *RunAuditLogExportResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[7]
+	mi := &file_hydra_v1_cron_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,7 +606,7 @@ func (x *RunAuditLogExportResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunAuditLogExportResponse.ProtoReflect.Descriptor instead.
 func (*RunAuditLogExportResponse) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{7}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *RunAuditLogExportResponse) GetEventsExported() int32 {
@@ -366,7 +624,7 @@ type RunRatelimitGlobalCountersCleanupRequest struct {
 
 func (x *RunRatelimitGlobalCountersCleanupRequest) Reset() {
 	*x = RunRatelimitGlobalCountersCleanupRequest{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[8]
+	mi := &file_hydra_v1_cron_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -378,7 +636,7 @@ func (x *RunRatelimitGlobalCountersCleanupRequest) String() string {
 func (*RunRatelimitGlobalCountersCleanupRequest) ProtoMessage() {}
 
 func (x *RunRatelimitGlobalCountersCleanupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[8]
+	mi := &file_hydra_v1_cron_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -391,7 +649,7 @@ func (x *RunRatelimitGlobalCountersCleanupRequest) ProtoReflect() protoreflect.M
 
 // Deprecated: Use RunRatelimitGlobalCountersCleanupRequest.ProtoReflect.Descriptor instead.
 func (*RunRatelimitGlobalCountersCleanupRequest) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{8}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{14}
 }
 
 type RunRatelimitGlobalCountersCleanupResponse struct {
@@ -404,7 +662,7 @@ type RunRatelimitGlobalCountersCleanupResponse struct {
 
 func (x *RunRatelimitGlobalCountersCleanupResponse) Reset() {
 	*x = RunRatelimitGlobalCountersCleanupResponse{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[9]
+	mi := &file_hydra_v1_cron_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -416,7 +674,7 @@ func (x *RunRatelimitGlobalCountersCleanupResponse) String() string {
 func (*RunRatelimitGlobalCountersCleanupResponse) ProtoMessage() {}
 
 func (x *RunRatelimitGlobalCountersCleanupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[9]
+	mi := &file_hydra_v1_cron_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -429,7 +687,7 @@ func (x *RunRatelimitGlobalCountersCleanupResponse) ProtoReflect() protoreflect.
 
 // Deprecated: Use RunRatelimitGlobalCountersCleanupResponse.ProtoReflect.Descriptor instead.
 func (*RunRatelimitGlobalCountersCleanupResponse) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{9}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *RunRatelimitGlobalCountersCleanupResponse) GetRowsDeleted() int64 {
@@ -447,7 +705,7 @@ type RunAuditLogOutboxCleanupRequest struct {
 
 func (x *RunAuditLogOutboxCleanupRequest) Reset() {
 	*x = RunAuditLogOutboxCleanupRequest{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[10]
+	mi := &file_hydra_v1_cron_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -459,7 +717,7 @@ func (x *RunAuditLogOutboxCleanupRequest) String() string {
 func (*RunAuditLogOutboxCleanupRequest) ProtoMessage() {}
 
 func (x *RunAuditLogOutboxCleanupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[10]
+	mi := &file_hydra_v1_cron_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -472,7 +730,7 @@ func (x *RunAuditLogOutboxCleanupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunAuditLogOutboxCleanupRequest.ProtoReflect.Descriptor instead.
 func (*RunAuditLogOutboxCleanupRequest) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{10}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{16}
 }
 
 type RunAuditLogOutboxCleanupResponse struct {
@@ -485,7 +743,7 @@ type RunAuditLogOutboxCleanupResponse struct {
 
 func (x *RunAuditLogOutboxCleanupResponse) Reset() {
 	*x = RunAuditLogOutboxCleanupResponse{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[11]
+	mi := &file_hydra_v1_cron_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -497,7 +755,7 @@ func (x *RunAuditLogOutboxCleanupResponse) String() string {
 func (*RunAuditLogOutboxCleanupResponse) ProtoMessage() {}
 
 func (x *RunAuditLogOutboxCleanupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[11]
+	mi := &file_hydra_v1_cron_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -510,7 +768,7 @@ func (x *RunAuditLogOutboxCleanupResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunAuditLogOutboxCleanupResponse.ProtoReflect.Descriptor instead.
 func (*RunAuditLogOutboxCleanupResponse) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{11}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *RunAuditLogOutboxCleanupResponse) GetRowsDeleted() int64 {
@@ -520,6 +778,87 @@ func (x *RunAuditLogOutboxCleanupResponse) GetRowsDeleted() int64 {
 	return 0
 }
 
+type RunIdempotencyKeysCleanupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunIdempotencyKeysCleanupRequest) Reset() {
+	*x = RunIdempotencyKeysCleanupRequest{}
+	mi := &file_hydra_v1_cron_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunIdempotencyKeysCleanupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunIdempotencyKeysCleanupRequest) ProtoMessage() {}
+
+func (x *RunIdempotencyKeysCleanupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_cron_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunIdempotencyKeysCleanupRequest.ProtoReflect.Descriptor instead.
+func (*RunIdempotencyKeysCleanupRequest) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{18}
+}
+
+type RunIdempotencyKeysCleanupResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of rows deleted.
+	RowsDeleted   int64 `protobuf:"varint,1,opt,name=rows_deleted,json=rowsDeleted,proto3" json:"rows_deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunIdempotencyKeysCleanupResponse) Reset() {
+	*x = RunIdempotencyKeysCleanupResponse{}
+	mi := &file_hydra_v1_cron_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunIdempotencyKeysCleanupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunIdempotencyKeysCleanupResponse) ProtoMessage() {}
+
+func (x *RunIdempotencyKeysCleanupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_cron_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunIdempotencyKeysCleanupResponse.ProtoReflect.Descriptor instead.
+func (*RunIdempotencyKeysCleanupResponse) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RunIdempotencyKeysCleanupResponse) GetRowsDeleted() int64 {
+	if x != nil {
+		return x.RowsDeleted
+	}
+	return 0
+}
+
 type RunDeployBillingPushRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -528,7 +867,7 @@ type RunDeployBillingPushRequest struct {
 
 func (x *RunDeployBillingPushRequest) Reset() {
 	*x = RunDeployBillingPushRequest{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[12]
+	mi := &file_hydra_v1_cron_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -540,7 +879,7 @@ func (x *RunDeployBillingPushRequest) String() string {
 func (*RunDeployBillingPushRequest) ProtoMessage() {}
 
 func (x *RunDeployBillingPushRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[12]
+	mi := &file_hydra_v1_cron_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -553,7 +892,7 @@ func (x *RunDeployBillingPushRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunDeployBillingPushRequest.ProtoReflect.Descriptor instead.
 func (*RunDeployBillingPushRequest) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{12}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{20}
 }
 
 // RunDeployBillingPushResponse is intentionally empty: the run's outcome
@@ -567,7 +906,7 @@ type RunDeployBillingPushResponse struct {
 
 func (x *RunDeployBillingPushResponse) Reset() {
 	*x = RunDeployBillingPushResponse{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[13]
+	mi := &file_hydra_v1_cron_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -579,7 +918,7 @@ func (x *RunDeployBillingPushResponse) String() string {
 func (*RunDeployBillingPushResponse) ProtoMessage() {}
 
 func (x *RunDeployBillingPushResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[13]
+	mi := &file_hydra_v1_cron_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -592,7 +931,7 @@ func (x *RunDeployBillingPushResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunDeployBillingPushResponse.ProtoReflect.Descriptor instead.
 func (*RunDeployBillingPushResponse) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{13}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{21}
 }
 
 type RunScaleDownIdlePreviewDeploymentsRequest struct {
@@ -603,7 +942,7 @@ type RunScaleDownIdlePreviewDeploymentsRequest struct {
 
 func (x *RunScaleDownIdlePreviewDeploymentsRequest) Reset() {
 	*x = RunScaleDownIdlePreviewDeploymentsRequest{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[14]
+	mi := &file_hydra_v1_cron_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -615,7 +954,7 @@ func (x *RunScaleDownIdlePreviewDeploymentsRequest) String() string {
 func (*RunScaleDownIdlePreviewDeploymentsRequest) ProtoMessage() {}
 
 func (x *RunScaleDownIdlePreviewDeploymentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[14]
+	mi := &file_hydra_v1_cron_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,7 +967,7 @@ func (x *RunScaleDownIdlePreviewDeploymentsRequest) ProtoReflect() protoreflect.
 
 // Deprecated: Use RunScaleDownIdlePreviewDeploymentsRequest.ProtoReflect.Descriptor instead.
 func (*RunScaleDownIdlePreviewDeploymentsRequest) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{14}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{22}
 }
 
 type RunScaleDownIdlePreviewDeploymentsResponse struct {
@@ -639,7 +978,7 @@ type RunScaleDownIdlePreviewDeploymentsResponse struct {
 
 func (x *RunScaleDownIdlePreviewDeploymentsResponse) Reset() {
 	*x = RunScaleDownIdlePreviewDeploymentsResponse{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[15]
+	mi := &file_hydra_v1_cron_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -651,7 +990,7 @@ func (x *RunScaleDownIdlePreviewDeploymentsResponse) String() string {
 func (*RunScaleDownIdlePreviewDeploymentsResponse) ProtoMessage() {}
 
 func (x *RunScaleDownIdlePreviewDeploymentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[15]
+	mi := &file_hydra_v1_cron_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -664,7 +1003,7 @@ func (x *RunScaleDownIdlePreviewDeploymentsResponse) ProtoReflect() protoreflect
 
 // Deprecated: Use RunScaleDownIdlePreviewDeploymentsResponse.ProtoReflect.Descriptor instead.
 func (*RunScaleDownIdlePreviewDeploymentsResponse) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{15}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{23}
 }
 
 type RunDeployBillingCloseRequest struct {
@@ -679,7 +1018,7 @@ type RunDeployBillingCloseRequest struct {
 
 func (x *RunDeployBillingCloseRequest) Reset() {
 	*x = RunDeployBillingCloseRequest{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[16]
+	mi := &file_hydra_v1_cron_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -691,7 +1030,7 @@ func (x *RunDeployBillingCloseRequest) String() string {
 func (*RunDeployBillingCloseRequest) ProtoMessage() {}
 
 func (x *RunDeployBillingCloseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[16]
+	mi := &file_hydra_v1_cron_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -704,7 +1043,7 @@ func (x *RunDeployBillingCloseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunDeployBillingCloseRequest.ProtoReflect.Descriptor instead.
 func (*RunDeployBillingCloseRequest) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{16}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *RunDeployBillingCloseRequest) GetPeriodEnd() int64 {
@@ -728,7 +1067,7 @@ type RunDeployBillingCloseResponse struct {
 
 func (x *RunDeployBillingCloseResponse) Reset() {
 	*x = RunDeployBillingCloseResponse{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[17]
+	mi := &file_hydra_v1_cron_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -740,7 +1079,7 @@ func (x *RunDeployBillingCloseResponse) String() string {
 func (*RunDeployBillingCloseResponse) ProtoMessage() {}
 
 func (x *RunDeployBillingCloseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[17]
+	mi := &file_hydra_v1_cron_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -753,7 +1092,7 @@ func (x *RunDeployBillingCloseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunDeployBillingCloseResponse.ProtoReflect.Descriptor instead.
 func (*RunDeployBillingCloseResponse) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{17}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *RunDeployBillingCloseResponse) GetWorkspacesPushed() int32 {
@@ -792,7 +1131,7 @@ type CloseDeployBillingWorkspaceRequest struct {
 
 func (x *CloseDeployBillingWorkspaceRequest) Reset() {
 	*x = CloseDeployBillingWorkspaceRequest{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[18]
+	mi := &file_hydra_v1_cron_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -804,7 +1143,7 @@ func (x *CloseDeployBillingWorkspaceRequest) String() string {
 func (*CloseDeployBillingWorkspaceRequest) ProtoMessage() {}
 
 func (x *CloseDeployBillingWorkspaceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[18]
+	mi := &file_hydra_v1_cron_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -817,7 +1156,7 @@ func (x *CloseDeployBillingWorkspaceRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use CloseDeployBillingWorkspaceRequest.ProtoReflect.Descriptor instead.
 func (*CloseDeployBillingWorkspaceRequest) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{18}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *CloseDeployBillingWorkspaceRequest) GetPeriod() string {
@@ -851,7 +1190,7 @@ type CloseDeployBillingWorkspaceResponse struct {
 
 func (x *CloseDeployBillingWorkspaceResponse) Reset() {
 	*x = CloseDeployBillingWorkspaceResponse{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[19]
+	mi := &file_hydra_v1_cron_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -863,7 +1202,7 @@ func (x *CloseDeployBillingWorkspaceResponse) String() string {
 func (*CloseDeployBillingWorkspaceResponse) ProtoMessage() {}
 
 func (x *CloseDeployBillingWorkspaceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[19]
+	mi := &file_hydra_v1_cron_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -876,7 +1215,7 @@ func (x *CloseDeployBillingWorkspaceResponse) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use CloseDeployBillingWorkspaceResponse.ProtoReflect.Descriptor instead.
 func (*CloseDeployBillingWorkspaceResponse) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{19}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{27}
 }
 
 type RunDeploySpendCheckRequest struct {
@@ -887,7 +1226,7 @@ type RunDeploySpendCheckRequest struct {
 
 func (x *RunDeploySpendCheckRequest) Reset() {
 	*x = RunDeploySpendCheckRequest{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[20]
+	mi := &file_hydra_v1_cron_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -899,7 +1238,7 @@ func (x *RunDeploySpendCheckRequest) String() string {
 func (*RunDeploySpendCheckRequest) ProtoMessage() {}
 
 func (x *RunDeploySpendCheckRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[20]
+	mi := &file_hydra_v1_cron_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -912,7 +1251,7 @@ func (x *RunDeploySpendCheckRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunDeploySpendCheckRequest.ProtoReflect.Descriptor instead.
 func (*RunDeploySpendCheckRequest) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{20}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{28}
 }
 
 type RunDeploySpendCheckResponse struct {
@@ -925,7 +1264,7 @@ type RunDeploySpendCheckResponse struct {
 
 func (x *RunDeploySpendCheckResponse) Reset() {
 	*x = RunDeploySpendCheckResponse{}
-	mi := &file_hydra_v1_cron_proto_msgTypes[21]
+	mi := &file_hydra_v1_cron_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -937,7 +1276,7 @@ func (x *RunDeploySpendCheckResponse) String() string {
 func (*RunDeploySpendCheckResponse) ProtoMessage() {}
 
 func (x *RunDeploySpendCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hydra_v1_cron_proto_msgTypes[21]
+	mi := &file_hydra_v1_cron_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -950,7 +1289,7 @@ func (x *RunDeploySpendCheckResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunDeploySpendCheckResponse.ProtoReflect.Descriptor instead.
 func (*RunDeploySpendCheckResponse) Descriptor() ([]byte, []int) {
-	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{21}
+	return file_hydra_v1_cron_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *RunDeploySpendCheckResponse) GetWorkspacesDispatched() int32 {
@@ -970,9 +1309,20 @@ const file_hydra_v1_cron_proto_rawDesc = "" +
 	"\x12workspaces_checked\x18\x01 \x01(\x05R\x11workspacesChecked\x12/\n" +
 	"\x13workspaces_exceeded\x18\x02 \x01(\x05R\x12workspacesExceeded\x12-\n" +
 	"\x12notifications_sent\x18\x03 \x01(\x05R\x11notificationsSent\"\x15\n" +
-	"\x13RunKeyRefillRequest\";\n" +
+	"\x13RunKeyRefillRequest\"l\n" +
 	"\x14RunKeyRefillResponse\x12#\n" +
-	"\rkeys_refilled\x18\x01 \x01(\x05R\fkeysRefilled\"\x1b\n" +
+	"\rkeys_refilled\x18\x01 \x01(\x05R\fkeysRefilled\x12/\n" +
+	"\x13identities_refilled\x18\x02 \x01(\x05R\x12identitiesRefilled\"\x17\n" +
+	"\x15RunKeyRotationRequest\";\n" +
+	"\x16RunKeyRotationResponse\x12!\n" +
+	"\fkeys_rotated\x18\x01 \x01(\x05R\vkeysRotated\"P\n" +
+	"\x17ExpireRotatedKeyRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\tR\x04hash\"\x1a\n" +
+	"\x18ExpireRotatedKeyResponse\"\"\n" +
+	" RunKeyExpiryNotificationsRequest\"R\n" +
+	"!RunKeyExpiryNotificationsResponse\x12-\n" +
+	"\x12notifications_sent\x18\x01 \x01(\x05R\x11notificationsSent\"\x1b\n" +
 	"\x19RunKeyLastUsedSyncRequest\"=\n" +
 	"\x1aRunKeyLastUsedSyncResponse\x12\x1f\n" +
 	"\vkeys_synced\x18\x01 \x01(\x05R\n" +
@@ -985,6 +1335,9 @@ const file_hydra_v1_cron_proto_rawDesc = "" +
 	"\frows_deleted\x18\x01 \x01(\x03R\vrowsDeleted\"!\n" +
 	"\x1fRunAuditLogOutboxCleanupRequest\"E\n" +
 	" RunAuditLogOutboxCleanupResponse\x12!\n" +
+	"\frows_deleted\x18\x01 \x01(\x03R\vrowsDeleted\"\"\n" +
+	" RunIdempotencyKeysCleanupRequest\"F\n" +
+	"!RunIdempotencyKeysCleanupResponse\x12!\n" +
 	"\frows_deleted\x18\x01 \x01(\x03R\vrowsDeleted\"\x1d\n" +
 	"\x1bRunDeployBillingPushRequest\"\x1e\n" +
 	"\x1cRunDeployBillingPushResponse\"+\n" +
@@ -1006,14 +1359,18 @@ const file_hydra_v1_cron_proto_rawDesc = "" +
 	"#CloseDeployBillingWorkspaceResponse\"\x1c\n" +
 	"\x1aRunDeploySpendCheckRequest\"R\n" +
 	"\x1bRunDeploySpendCheckResponse\x123\n" +
-	"\x15workspaces_dispatched\x18\x01 \x01(\x05R\x14workspacesDispatched2\xce\t\n" +
+	"\x15workspaces_dispatched\x18\x01 \x01(\x05R\x14workspacesDispatched2\xf2\f\n" +
 	"\vCronService\x12R\n" +
 	"\rRunQuotaCheck\x12\x1e.hydra.v1.RunQuotaCheckRequest\x1a\x1f.hydra.v1.RunQuotaCheckResponse\"\x00\x12O\n" +
-	"\fRunKeyRefill\x12\x1d.hydra.v1.RunKeyRefillRequest\x1a\x1e.hydra.v1.RunKeyRefillResponse\"\x00\x12a\n" +
+	"\fRunKeyRefill\x12\x1d.hydra.v1.RunKeyRefillRequest\x1a\x1e.hydra.v1.RunKeyRefillResponse\"\x00\x12U\n" +
+	"\x0eRunKeyRotation\x12\x1f.hydra.v1.RunKeyRotationRequest\x1a .hydra.v1.RunKeyRotationResponse\"\x00\x12[\n" +
+	"\x10ExpireRotatedKey\x12!.hydra.v1.ExpireRotatedKeyRequest\x1a\".hydra.v1.ExpireRotatedKeyResponse\"\x00\x12v\n" +
+	"\x19RunKeyExpiryNotifications\x12*.hydra.v1.RunKeyExpiryNotificationsRequest\x1a+.hydra.v1.RunKeyExpiryNotificationsResponse\"\x00\x12a\n" +
 	"\x12RunKeyLastUsedSync\x12#.hydra.v1.RunKeyLastUsedSyncRequest\x1a$.hydra.v1.RunKeyLastUsedSyncResponse\"\x00\x12^\n" +
 	"\x11RunAuditLogExport\x12\".hydra.v1.RunAuditLogExportRequest\x1a#.hydra.v1.RunAuditLogExportResponse\"\x00\x12\x8e\x01\n" +
 	"!RunRatelimitGlobalCountersCleanup\x122.hydra.v1.RunRatelimitGlobalCountersCleanupRequest\x1a3.hydra.v1.RunRatelimitGlobalCountersCleanupResponse\"\x00\x12s\n" +
-	"\x18RunAuditLogOutboxCleanup\x12).hydra.v1.RunAuditLogOutboxCleanupRequest\x1a*.hydra.v1.RunAuditLogOutboxCleanupResponse\"\x00\x12g\n" +
+	"\x18RunAuditLogOutboxCleanup\x12).hydra.v1.RunAuditLogOutboxCleanupRequest\x1a*.hydra.v1.RunAuditLogOutboxCleanupResponse\"\x00\x12v\n" +
+	"\x19RunIdempotencyKeysCleanup\x12*.hydra.v1.RunIdempotencyKeysCleanupRequest\x1a+.hydra.v1.RunIdempotencyKeysCleanupResponse\"\x00\x12g\n" +
 	"\x14RunDeployBillingPush\x12%.hydra.v1.RunDeployBillingPushRequest\x1a&.hydra.v1.RunDeployBillingPushResponse\"\x00\x12\x91\x01\n" +
 	"\"RunScaleDownIdlePreviewDeployments\x123.hydra.v1.RunScaleDownIdlePreviewDeploymentsRequest\x1a4.hydra.v1.RunScaleDownIdlePreviewDeploymentsResponse\"\x00\x12j\n" +
 	"\x15RunDeployBillingClose\x12&.hydra.v1.RunDeployBillingCloseRequest\x1a'.hydra.v1.RunDeployBillingCloseResponse\"\x00\x12|\n" +
@@ -1033,56 +1390,72 @@ func file_hydra_v1_cron_proto_rawDescGZIP() []byte {
 	return file_hydra_v1_cron_proto_rawDescData
 }
 
-var file_hydra_v1_cron_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_hydra_v1_cron_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_hydra_v1_cron_proto_goTypes = []any{
 	(*RunQuotaCheckRequest)(nil),                       // 0: hydra.v1.RunQuotaCheckRequest
 	(*RunQuotaCheckResponse)(nil),                      // 1: hydra.v1.RunQuotaCheckResponse
 	(*RunKeyRefillRequest)(nil),                        // 2: hydra.v1.RunKeyRefillRequest
 	(*RunKeyRefillResponse)(nil),                       // 3: hydra.v1.RunKeyRefillResponse
-	(*RunKeyLastUsedSyncRequest)(nil),                  // 4: hydra.v1.RunKeyLastUsedSyncRequest
-	(*RunKeyLastUsedSyncResponse)(nil),                 // 5: hydra.v1.RunKeyLastUsedSyncResponse
-	(*RunAuditLogExportRequest)(nil),                   // 6: hydra.v1.RunAuditLogExportRequest
-	(*RunAuditLogExportResponse)(nil),                  // 7: hydra.v1.RunAuditLogExportResponse
-	(*RunRatelimitGlobalCountersCleanupRequest)(nil),   // 8: hydra.v1.RunRatelimitGlobalCountersCleanupRequest
-	(*RunRatelimitGlobalCountersCleanupResponse)(nil),  // 9: hydra.v1.RunRatelimitGlobalCountersCleanupResponse
-	(*RunAuditLogOutboxCleanupRequest)(nil),            // 10: hydra.v1.RunAuditLogOutboxCleanupRequest
-	(*RunAuditLogOutboxCleanupResponse)(nil),           // 11: hydra.v1.RunAuditLogOutboxCleanupResponse
-	(*RunDeployBillingPushRequest)(nil),                // 12: hydra.v1.RunDeployBillingPushRequest
-	(*RunDeployBillingPushResponse)(nil),               // 13: hydra.v1.RunDeployBillingPushResponse
-	(*RunScaleDownIdlePreviewDeploymentsRequest)(nil),  // 14: hydra.v1.RunScaleDownIdlePreviewDeploymentsRequest
-	(*RunScaleDownIdlePreviewDeploymentsResponse)(nil), // 15: hydra.v1.RunScaleDownIdlePreviewDeploymentsResponse
-	(*RunDeployBillingCloseRequest)(nil),               // 16: hydra.v1.RunDeployBillingCloseRequest
-	(*RunDeployBillingCloseResponse)(nil),              // 17: hydra.v1.RunDeployBillingCloseResponse
-	(*CloseDeployBillingWorkspaceRequest)(nil),         // 18: hydra.v1.CloseDeployBillingWorkspaceRequest
-	(*CloseDeployBillingWorkspaceResponse)(nil),        // 19: hydra.v1.CloseDeployBillingWorkspaceResponse
-	(*RunDeploySpendCheckRequest)(nil),                 // 20: hydra.v1.RunDeploySpendCheckRequest
-	(*RunDeploySpendCheckResponse)(nil),                // 21: hydra.v1.RunDeploySpendCheckResponse
+	(*RunKeyRotationRequest)(nil),                      // 4: hydra.v1.RunKeyRotationRequest
+	(*RunKeyRotationResponse)(nil),                     // 5: hydra.v1.RunKeyRotationResponse
+	(*ExpireRotatedKeyRequest)(nil),                    // 6: hydra.v1.ExpireRotatedKeyRequest
+	(*ExpireRotatedKeyResponse)(nil),                   // 7: hydra.v1.ExpireRotatedKeyResponse
+	(*RunKeyExpiryNotificationsRequest)(nil),           // 8: hydra.v1.RunKeyExpiryNotificationsRequest
+	(*RunKeyExpiryNotificationsResponse)(nil),          // 9: hydra.v1.RunKeyExpiryNotificationsResponse
+	(*RunKeyLastUsedSyncRequest)(nil),                  // 10: hydra.v1.RunKeyLastUsedSyncRequest
+	(*RunKeyLastUsedSyncResponse)(nil),                 // 11: hydra.v1.RunKeyLastUsedSyncResponse
+	(*RunAuditLogExportRequest)(nil),                   // 12: hydra.v1.RunAuditLogExportRequest
+	(*RunAuditLogExportResponse)(nil),                  // 13: hydra.v1.RunAuditLogExportResponse
+	(*RunRatelimitGlobalCountersCleanupRequest)(nil),   // 14: hydra.v1.RunRatelimitGlobalCountersCleanupRequest
+	(*RunRatelimitGlobalCountersCleanupResponse)(nil),  // 15: hydra.v1.RunRatelimitGlobalCountersCleanupResponse
+	(*RunAuditLogOutboxCleanupRequest)(nil),            // 16: hydra.v1.RunAuditLogOutboxCleanupRequest
+	(*RunAuditLogOutboxCleanupResponse)(nil),           // 17: hydra.v1.RunAuditLogOutboxCleanupResponse
+	(*RunIdempotencyKeysCleanupRequest)(nil),           // 18: hydra.v1.RunIdempotencyKeysCleanupRequest
+	(*RunIdempotencyKeysCleanupResponse)(nil),          // 19: hydra.v1.RunIdempotencyKeysCleanupResponse
+	(*RunDeployBillingPushRequest)(nil),                // 20: hydra.v1.RunDeployBillingPushRequest
+	(*RunDeployBillingPushResponse)(nil),               // 21: hydra.v1.RunDeployBillingPushResponse
+	(*RunScaleDownIdlePreviewDeploymentsRequest)(nil),  // 22: hydra.v1.RunScaleDownIdlePreviewDeploymentsRequest
+	(*RunScaleDownIdlePreviewDeploymentsResponse)(nil), // 23: hydra.v1.RunScaleDownIdlePreviewDeploymentsResponse
+	(*RunDeployBillingCloseRequest)(nil),               // 24: hydra.v1.RunDeployBillingCloseRequest
+	(*RunDeployBillingCloseResponse)(nil),              // 25: hydra.v1.RunDeployBillingCloseResponse
+	(*CloseDeployBillingWorkspaceRequest)(nil),         // 26: hydra.v1.CloseDeployBillingWorkspaceRequest
+	(*CloseDeployBillingWorkspaceResponse)(nil),        // 27: hydra.v1.CloseDeployBillingWorkspaceResponse
+	(*RunDeploySpendCheckRequest)(nil),                 // 28: hydra.v1.RunDeploySpendCheckRequest
+	(*RunDeploySpendCheckResponse)(nil),                // 29: hydra.v1.RunDeploySpendCheckResponse
 }
 var file_hydra_v1_cron_proto_depIdxs = []int32{
 	0,  // 0: hydra.v1.CronService.RunQuotaCheck:input_type -> hydra.v1.RunQuotaCheckRequest
 	2,  // 1: hydra.v1.CronService.RunKeyRefill:input_type -> hydra.v1.RunKeyRefillRequest
-	4,  // 2: hydra.v1.CronService.RunKeyLastUsedSync:input_type -> hydra.v1.RunKeyLastUsedSyncRequest
-	6,  // 3: hydra.v1.CronService.RunAuditLogExport:input_type -> hydra.v1.RunAuditLogExportRequest
-	8,  // 4: hydra.v1.CronService.RunRatelimitGlobalCountersCleanup:input_type -> hydra.v1.RunRatelimitGlobalCountersCleanupRequest
-	10, // 5: hydra.v1.CronService.RunAuditLogOutboxCleanup:input_type -> hydra.v1.RunAuditLogOutboxCleanupRequest
-	12, // 6: hydra.v1.CronService.RunDeployBillingPush:input_type -> hydra.v1.RunDeployBillingPushRequest
-	14, // 7: hydra.v1.CronService.RunScaleDownIdlePreviewDeployments:input_type -> hydra.v1.RunScaleDownIdlePreviewDeploymentsRequest
-	16, // 8: hydra.v1.CronService.RunDeployBillingClose:input_type -> hydra.v1.RunDeployBillingCloseRequest
-	18, // 9: hydra.v1.CronService.CloseDeployBillingWorkspace:input_type -> hydra.v1.CloseDeployBillingWorkspaceRequest
-	20, // 10: hydra.v1.CronService.RunDeploySpendCheck:input_type -> hydra.v1.RunDeploySpendCheckRequest
-	1,  // 11: hydra.v1.CronService.RunQuotaCheck:output_type -> hydra.v1.RunQuotaCheckResponse
-	3,  // 12: hydra.v1.CronService.RunKeyRefill:output_type -> hydra.v1.RunKeyRefillResponse
-	5,  // 13: hydra.v1.CronService.RunKeyLastUsedSync:output_type -> hydra.v1.RunKeyLastUsedSyncResponse
-	7,  // 14: hydra.v1.CronService.RunAuditLogExport:output_type -> hydra.v1.RunAuditLogExportResponse
-	9,  // 15: hydra.v1.CronService.RunRatelimitGlobalCountersCleanup:output_type -> hydra.v1.RunRatelimitGlobalCountersCleanupResponse
-	11, // 16: hydra.v1.CronService.RunAuditLogOutboxCleanup:output_type -> hydra.v1.RunAuditLogOutboxCleanupResponse
-	13, // 17: hydra.v1.CronService.RunDeployBillingPush:output_type -> hydra.v1.RunDeployBillingPushResponse
-	15, // 18: hydra.v1.CronService.RunScaleDownIdlePreviewDeployments:output_type -> hydra.v1.RunScaleDownIdlePreviewDeploymentsResponse
-	17, // 19: hydra.v1.CronService.RunDeployBillingClose:output_type -> hydra.v1.RunDeployBillingCloseResponse
-	19, // 20: hydra.v1.CronService.CloseDeployBillingWorkspace:output_type -> hydra.v1.CloseDeployBillingWorkspaceResponse
-	21, // 21: hydra.v1.CronService.RunDeploySpendCheck:output_type -> hydra.v1.RunDeploySpendCheckResponse
-	11, // [11:22] is the sub-list for method output_type
-	0,  // [0:11] is the sub-list for method input_type
+	4,  // 2: hydra.v1.CronService.RunKeyRotation:input_type -> hydra.v1.RunKeyRotationRequest
+	6,  // 3: hydra.v1.CronService.ExpireRotatedKey:input_type -> hydra.v1.ExpireRotatedKeyRequest
+	8,  // 4: hydra.v1.CronService.RunKeyExpiryNotifications:input_type -> hydra.v1.RunKeyExpiryNotificationsRequest
+	10, // 5: hydra.v1.CronService.RunKeyLastUsedSync:input_type -> hydra.v1.RunKeyLastUsedSyncRequest
+	12, // 6: hydra.v1.CronService.RunAuditLogExport:input_type -> hydra.v1.RunAuditLogExportRequest
+	14, // 7: hydra.v1.CronService.RunRatelimitGlobalCountersCleanup:input_type -> hydra.v1.RunRatelimitGlobalCountersCleanupRequest
+	16, // 8: hydra.v1.CronService.RunAuditLogOutboxCleanup:input_type -> hydra.v1.RunAuditLogOutboxCleanupRequest
+	18, // 9: hydra.v1.CronService.RunIdempotencyKeysCleanup:input_type -> hydra.v1.RunIdempotencyKeysCleanupRequest
+	20, // 10: hydra.v1.CronService.RunDeployBillingPush:input_type -> hydra.v1.RunDeployBillingPushRequest
+	22, // 11: hydra.v1.CronService.RunScaleDownIdlePreviewDeployments:input_type -> hydra.v1.RunScaleDownIdlePreviewDeploymentsRequest
+	24, // 12: hydra.v1.CronService.RunDeployBillingClose:input_type -> hydra.v1.RunDeployBillingCloseRequest
+	26, // 13: hydra.v1.CronService.CloseDeployBillingWorkspace:input_type -> hydra.v1.CloseDeployBillingWorkspaceRequest
+	28, // 14: hydra.v1.CronService.RunDeploySpendCheck:input_type -> hydra.v1.RunDeploySpendCheckRequest
+	1,  // 15: hydra.v1.CronService.RunQuotaCheck:output_type -> hydra.v1.RunQuotaCheckResponse
+	3,  // 16: hydra.v1.CronService.RunKeyRefill:output_type -> hydra.v1.RunKeyRefillResponse
+	5,  // 17: hydra.v1.CronService.RunKeyRotation:output_type -> hydra.v1.RunKeyRotationResponse
+	7,  // 18: hydra.v1.CronService.ExpireRotatedKey:output_type -> hydra.v1.ExpireRotatedKeyResponse
+	9,  // 19: hydra.v1.CronService.RunKeyExpiryNotifications:output_type -> hydra.v1.RunKeyExpiryNotificationsResponse
+	11, // 20: hydra.v1.CronService.RunKeyLastUsedSync:output_type -> hydra.v1.RunKeyLastUsedSyncResponse
+	13, // 21: hydra.v1.CronService.RunAuditLogExport:output_type -> hydra.v1.RunAuditLogExportResponse
+	15, // 22: hydra.v1.CronService.RunRatelimitGlobalCountersCleanup:output_type -> hydra.v1.RunRatelimitGlobalCountersCleanupResponse
+	17, // 23: hydra.v1.CronService.RunAuditLogOutboxCleanup:output_type -> hydra.v1.RunAuditLogOutboxCleanupResponse
+	19, // 24: hydra.v1.CronService.RunIdempotencyKeysCleanup:output_type -> hydra.v1.RunIdempotencyKeysCleanupResponse
+	21, // 25: hydra.v1.CronService.RunDeployBillingPush:output_type -> hydra.v1.RunDeployBillingPushResponse
+	23, // 26: hydra.v1.CronService.RunScaleDownIdlePreviewDeployments:output_type -> hydra.v1.RunScaleDownIdlePreviewDeploymentsResponse
+	25, // 27: hydra.v1.CronService.RunDeployBillingClose:output_type -> hydra.v1.RunDeployBillingCloseResponse
+	27, // 28: hydra.v1.CronService.CloseDeployBillingWorkspace:output_type -> hydra.v1.CloseDeployBillingWorkspaceResponse
+	29, // 29: hydra.v1.CronService.RunDeploySpendCheck:output_type -> hydra.v1.RunDeploySpendCheckResponse
+	15, // [15:30] is the sub-list for method output_type
+	0,  // [0:15] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -1099,7 +1472,7 @@ func file_hydra_v1_cron_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hydra_v1_cron_proto_rawDesc), len(file_hydra_v1_cron_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   22,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -36,9 +36,26 @@ type CronServiceClient interface {
 	// quotas. Key = billing period "YYYY-MM"; state tracks notified
 	// workspaces per period.
 	RunQuotaCheck(opts ...sdk_go.ClientOption) sdk_go.Client[*RunQuotaCheckRequest, *RunQuotaCheckResponse]
-	// RunKeyRefill processes keys whose usage limits should refill today.
-	// Key = "YYYY-MM-DD"; state tracks processed key IDs for resumability.
+	// RunKeyRefill processes keys and identity credit pools whose usage
+	// limits should refill today.
+	// Key = "YYYY-MM-DD"; state tracks processed key and identity IDs for
+	// resumability.
 	RunKeyRefill(opts ...sdk_go.ClientOption) sdk_go.Client[*RunKeyRefillRequest, *RunKeyRefillResponse]
+	// RunKeyRotation issues a successor for every recoverable key whose
+	// rotation policy is due and shortens the replaced key to the policy's
+	// grace period. Key is the fixed slug "key-rotation"; each run rotates
+	// whatever is due at its start, so a missed run is caught up by the next.
+	RunKeyRotation(opts ...sdk_go.ClientOption) sdk_go.Client[*RunKeyRotationRequest, *RunKeyRotationResponse]
+	// ExpireRotatedKey evicts a replaced key from the API caches once its
+	// grace period ends, so nodes that cached it before the rotation stop
+	// verifying it. Key = the replaced key's id. Sent by RunKeyRotation with
+	// a delay until the end of the grace period.
+	ExpireRotatedKey(opts ...sdk_go.ClientOption) sdk_go.Client[*ExpireRotatedKeyRequest, *ExpireRotatedKeyResponse]
+	// RunKeyExpiryNotifications warns the keyspaces that opted in about keys
+	// expiring within one of their thresholds, once per key and threshold.
+	// Key is the fixed slug "key-expiry-notifications"; each run warns about
+	// whatever is within a threshold at its start.
+	RunKeyExpiryNotifications(opts ...sdk_go.ClientOption) sdk_go.Client[*RunKeyExpiryNotificationsRequest, *RunKeyExpiryNotificationsResponse]
 	// RunKeyLastUsedSync orchestrates the per-partition key_last_used sync
 	// by fanning out to KeyLastUsedPartitionService. Key is the fixed slug
 	// "key-last-used-sync" so the orchestrator runs as a singleton without
@@ -59,6 +76,11 @@ type CronServiceClient interface {
 	// stays bounded. Stateless; key is the fixed slug "audit-log-outbox-cleanup"
 	// so a paused/wedged invocation cannot block other handlers. Daily schedule.
 	RunAuditLogOutboxCleanup(opts ...sdk_go.ClientOption) sdk_go.Client[*RunAuditLogOutboxCleanupRequest, *RunAuditLogOutboxCleanupResponse]
+	// RunIdempotencyKeysCleanup deletes idempotency_keys rows whose replay
+	// window has ended. Stateless; key is the fixed slug
+	// "idempotency-keys-cleanup" so a paused/wedged invocation cannot block
+	// other handlers. Hourly schedule.
+	RunIdempotencyKeysCleanup(opts ...sdk_go.ClientOption) sdk_go.Client[*RunIdempotencyKeysCleanupRequest, *RunIdempotencyKeysCleanupResponse]
 	// RunDeployBillingPush computes month-to-date Deploy usage (CPU, memory,
 	// egress, disk, active keys) from ClickHouse, fans out one
 	// DeployBillingPushService.PushWorkspaceUsage invocation per billable
@@ -127,6 +149,30 @@ func (c *cronServiceClient) RunKeyRefill(opts ...sdk_go.ClientOption) sdk_go.Cli
 	return sdk_go.WithRequestType[*RunKeyRefillRequest](sdk_go.Object[*RunKeyRefillResponse](c.ctx, "hydra.v1.CronService", c.key, "RunKeyRefill", cOpts...))
 }
 
+func (c *cronServiceClient) RunKeyRotation(opts ...sdk_go.ClientOption) sdk_go.Client[*RunKeyRotationRequest, *RunKeyRotationResponse] {
+	cOpts := c.options
+	if len(opts) > 0 {
+		cOpts = append(append([]sdk_go.ClientOption{}, cOpts...), opts...)
+	}
+	return sdk_go.WithRequestType[*RunKeyRotationRequest](sdk_go.Object[*RunKeyRotationResponse](c.ctx, "hydra.v1.CronService", c.key, "RunKeyRotation", cOpts...))
+}
+
+func (c *cronServiceClient) ExpireRotatedKey(opts ...sdk_go.ClientOption) sdk_go.Client[*ExpireRotatedKeyRequest, *ExpireRotatedKeyResponse] {
+	cOpts := c.options
+	if len(opts) > 0 {
+		cOpts = append(append([]sdk_go.ClientOption{}, cOpts...), opts...)
+	}
+	return sdk_go.WithRequestType[*ExpireRotatedKeyRequest](sdk_go.Object[*ExpireRotatedKeyResponse](c.ctx, "hydra.v1.CronService", c.key, "ExpireRotatedKey", cOpts...))
+}
+
+func (c *cronServiceClient) RunKeyExpiryNotifications(opts ...sdk_go.ClientOption) sdk_go.Client[*RunKeyExpiryNotificationsRequest, *RunKeyExpiryNotificationsResponse] {
+	cOpts := c.options
+	if len(opts) > 0 {
+		cOpts = append(append([]sdk_go.ClientOption{}, cOpts...), opts...)
+	}
+	return sdk_go.WithRequestType[*RunKeyExpiryNotificationsRequest](sdk_go.Object[*RunKeyExpiryNotificationsResponse](c.ctx, "hydra.v1.CronService", c.key, "RunKeyExpiryNotifications", cOpts...))
+}
+
 func (c *cronServiceClient) RunKeyLastUsedSync(opts ...sdk_go.ClientOption) sdk_go.Client[*RunKeyLastUsedSyncRequest, *RunKeyLastUsedSyncResponse] {
 	cOpts := c.options
 	if len(opts) > 0 {
@@ -159,6 +205,14 @@ func (c *cronServiceClient) RunAuditLogOutboxCleanup(opts ...sdk_go.ClientOption
 	return sdk_go.WithRequestType[*RunAuditLogOutboxCleanupRequest](sdk_go.Object[*RunAuditLogOutboxCleanupResponse](c.ctx, "hydra.v1.CronService", c.key, "RunAuditLogOutboxCleanup", cOpts...))
 }
 
+func (c *cronServiceClient) RunIdempotencyKeysCleanup(opts ...sdk_go.ClientOption) sdk_go.Client[*RunIdempotencyKeysCleanupRequest, *RunIdempotencyKeysCleanupResponse] {
+	cOpts := c.options
+	if len(opts) > 0 {
+		cOpts = append(append([]sdk_go.ClientOption{}, cOpts...), opts...)
+	}
+	return sdk_go.WithRequestType[*RunIdempotencyKeysCleanupRequest](sdk_go.Object[*RunIdempotencyKeysCleanupResponse](c.ctx, "hydra.v1.CronService", c.key, "RunIdempotencyKeysCleanup", cOpts...))
+}
+
 func (c *cronServiceClient) RunDeployBillingPush(opts ...sdk_go.ClientOption) sdk_go.Client[*RunDeployBillingPushRequest, *RunDeployBillingPushResponse] {
 	cOpts := c.options
 	if len(opts) > 0 {
@@ -207,9 +261,26 @@ type CronServiceIngressClient interface {
 	// quotas. Key = billing period "YYYY-MM"; state tracks notified
 	// workspaces per period.
 	RunQuotaCheck() ingress.Requester[*RunQuotaCheckRequest, *RunQuotaCheckResponse]
-	// RunKeyRefill processes keys whose usage limits should refill today.
-	// Key = "YYYY-MM-DD"; state tracks processed key IDs for resumability.
+	// RunKeyRefill processes keys and identity credit pools whose usage
+	// limits should refill today.
+	// Key = "YYYY-MM-DD"; state tracks processed key and identity IDs for
+	// resumability.
 	RunKeyRefill() ingress.Requester[*RunKeyRefillRequest, *RunKeyRefillResponse]
+	// RunKeyRotation issues a successor for every recoverable key whose
+	// rotation policy is due and shortens the replaced key to the policy's
+	// grace period. Key is the fixed slug "key-rotation"; each run rotates
+	// whatever is due at its start, so a missed run is caught up by the next.
+	RunKeyRotation() ingress.Requester[*RunKeyRotationRequest, *RunKeyRotationResponse]
+	// ExpireRotatedKey evicts a replaced key from the API caches once its
+	// grace period ends, so nodes that cached it before the rotation stop
+	// verifying it. Key = the replaced key's id. Sent by RunKeyRotation with
+	// a delay until the end of the grace period.
+	ExpireRotatedKey() ingress.Requester[*ExpireRotatedKeyRequest, *ExpireRotatedKeyResponse]
+	// RunKeyExpiryNotifications warns the keyspaces that opted in about keys
+	// expiring within one of their thresholds, once per key and threshold.
+	// Key is the fixed slug "key-expiry-notifications"; each run warns about
+	// whatever is within a threshold at its start.
+	RunKeyExpiryNotifications() ingress.Requester[*RunKeyExpiryNotificationsRequest, *RunKeyExpiryNotificationsResponse]
 	// RunKeyLastUsedSync orchestrates the per-partition key_last_used sync
 	// by fanning out to KeyLastUsedPartitionService. Key is the fixed slug
 	// "key-last-used-sync" so the orchestrator runs as a singleton without
@@ -230,6 +301,11 @@ type CronServiceIngressClient interface {
 	// stays bounded. Stateless; key is the fixed slug "audit-log-outbox-cleanup"
 	// so a paused/wedged invocation cannot block other handlers. Daily schedule.
 	RunAuditLogOutboxCleanup() ingress.Requester[*RunAuditLogOutboxCleanupRequest, *RunAuditLogOutboxCleanupResponse]
+	// RunIdempotencyKeysCleanup deletes idempotency_keys rows whose replay
+	// window has ended. Stateless; key is the fixed slug
+	// "idempotency-keys-cleanup" so a paused/wedged invocation cannot block
+	// other handlers. Hourly schedule.
+	RunIdempotencyKeysCleanup() ingress.Requester[*RunIdempotencyKeysCleanupRequest, *RunIdempotencyKeysCleanupResponse]
 	// RunDeployBillingPush computes month-to-date Deploy usage (CPU, memory,
 	// egress, disk, active keys) from ClickHouse, fans out one
 	// DeployBillingPushService.PushWorkspaceUsage invocation per billable
@@ -292,6 +368,21 @@ func (c *cronServiceIngressClient) RunKeyRefill() ingress.Requester[*RunKeyRefil
 	return ingress.NewRequester[*RunKeyRefillRequest, *RunKeyRefillResponse](c.client, c.serviceName, "RunKeyRefill", &c.key, &codec)
 }
 
+func (c *cronServiceIngressClient) RunKeyRotation() ingress.Requester[*RunKeyRotationRequest, *RunKeyRotationResponse] {
+	codec := encoding.ProtoJSONCodec
+	return ingress.NewRequester[*RunKeyRotationRequest, *RunKeyRotationResponse](c.client, c.serviceName, "RunKeyRotation", &c.key, &codec)
+}
+
+func (c *cronServiceIngressClient) ExpireRotatedKey() ingress.Requester[*ExpireRotatedKeyRequest, *ExpireRotatedKeyResponse] {
+	codec := encoding.ProtoJSONCodec
+	return ingress.NewRequester[*ExpireRotatedKeyRequest, *ExpireRotatedKeyResponse](c.client, c.serviceName, "ExpireRotatedKey", &c.key, &codec)
+}
+
+func (c *cronServiceIngressClient) RunKeyExpiryNotifications() ingress.Requester[*RunKeyExpiryNotificationsRequest, *RunKeyExpiryNotificationsResponse] {
+	codec := encoding.ProtoJSONCodec
+	return ingress.NewRequester[*RunKeyExpiryNotificationsRequest, *RunKeyExpiryNotificationsResponse](c.client, c.serviceName, "RunKeyExpiryNotifications", &c.key, &codec)
+}
+
 func (c *cronServiceIngressClient) RunKeyLastUsedSync() ingress.Requester[*RunKeyLastUsedSyncRequest, *RunKeyLastUsedSyncResponse] {
 	codec := encoding.ProtoJSONCodec
 	return ingress.NewRequester[*RunKeyLastUsedSyncRequest, *RunKeyLastUsedSyncResponse](c.client, c.serviceName, "RunKeyLastUsedSync", &c.key, &codec)
@@ -312,6 +403,11 @@ func (c *cronServiceIngressClient) RunAuditLogOutboxCleanup() ingress.Requester[
 	return ingress.NewRequester[*RunAuditLogOutboxCleanupRequest, *RunAuditLogOutboxCleanupResponse](c.client, c.serviceName, "RunAuditLogOutboxCleanup", &c.key, &codec)
 }
 
+func (c *cronServiceIngressClient) RunIdempotencyKeysCleanup() ingress.Requester[*RunIdempotencyKeysCleanupRequest, *RunIdempotencyKeysCleanupResponse] {
+	codec := encoding.ProtoJSONCodec
+	return ingress.NewRequester[*RunIdempotencyKeysCleanupRequest, *RunIdempotencyKeysCleanupResponse](c.client, c.serviceName, "RunIdempotencyKeysCleanup", &c.key, &codec)
+}
+
 func (c *cronServiceIngressClient) RunDeployBillingPush() ingress.Requester[*RunDeployBillingPushRequest, *RunDeployBillingPushResponse] {
 	codec := encoding.ProtoJSONCodec
 	return ingress.NewRequester[*RunDeployBillingPushRequest, *RunDeployBillingPushResponse](c.client, c.serviceName, "RunDeployBillingPush", &c.key, &codec)
@@ -362,9 +458,26 @@ type CronServiceServer interface {
 	// quotas. Key = billing period "YYYY-MM"; state tracks notified
 	// workspaces per period.
 	RunQuotaCheck(ctx sdk_go.ObjectContext, req *RunQuotaCheckRequest) (*RunQuotaCheckResponse, error)
-	// RunKeyRefill processes keys whose usage limits should refill today.
-	// Key = "YYYY-MM-DD"; state tracks processed key IDs for resumability.
+	// RunKeyRefill processes keys and identity credit pools whose usage
+	// limits should refill today.
+	// Key = "YYYY-MM-DD"; state tracks processed key and identity IDs for
+	// resumability.
 	RunKeyRefill(ctx sdk_go.ObjectContext, req *RunKeyRefillRequest) (*RunKeyRefillResponse, error)
+	// RunKeyRotation issues a successor for every recoverable key whose
+	// rotation policy is due and shortens the replaced key to the policy's
+	// grace period. Key is the fixed slug "key-rotation"; each run rotates
+	// whatever is due at its start, so a missed run is caught up by the next.
+	RunKeyRotation(ctx sdk_go.ObjectContext, req *RunKeyRotationRequest) (*RunKeyRotationResponse, error)
+	// ExpireRotatedKey evicts a replaced key from the API caches once its
+	// grace period ends, so nodes that cached it before the rotation stop
+	// verifying it. Key = the replaced key's id. Sent by RunKeyRotation with
+	// a delay until the end of the grace period.
+	ExpireRotatedKey(ctx sdk_go.ObjectContext, req *ExpireRotatedKeyRequest) (*ExpireRotatedKeyResponse, error)
+	// RunKeyExpiryNotifications warns the keyspaces that opted in about keys
+	// expiring within one of their thresholds, once per key and threshold.
+	// Key is the fixed slug "key-expiry-notifications"; each run warns about
+	// whatever is within a threshold at its start.
+	RunKeyExpiryNotifications(ctx sdk_go.ObjectContext, req *RunKeyExpiryNotificationsRequest) (*RunKeyExpiryNotificationsResponse, error)
 	// RunKeyLastUsedSync orchestrates the per-partition key_last_used sync
 	// by fanning out to KeyLastUsedPartitionService. Key is the fixed slug
 	// "key-last-used-sync" so the orchestrator runs as a singleton without
@@ -385,6 +498,11 @@ type CronServiceServer interface {
 	// stays bounded. Stateless; key is the fixed slug "audit-log-outbox-cleanup"
 	// so a paused/wedged invocation cannot block other handlers. Daily schedule.
 	RunAuditLogOutboxCleanup(ctx sdk_go.ObjectContext, req *RunAuditLogOutboxCleanupRequest) (*RunAuditLogOutboxCleanupResponse, error)
+	// RunIdempotencyKeysCleanup deletes idempotency_keys rows whose replay
+	// window has ended. Stateless; key is the fixed slug
+	// "idempotency-keys-cleanup" so a paused/wedged invocation cannot block
+	// other handlers. Hourly schedule.
+	RunIdempotencyKeysCleanup(ctx sdk_go.ObjectContext, req *RunIdempotencyKeysCleanupRequest) (*RunIdempotencyKeysCleanupResponse, error)
 	// RunDeployBillingPush computes month-to-date Deploy usage (CPU, memory,
 	// egress, disk, active keys) from ClickHouse, fans out one
 	// DeployBillingPushService.PushWorkspaceUsage invocation per billable
@@ -436,6 +554,15 @@ func (UnimplementedCronServiceServer) RunQuotaCheck(ctx sdk_go.ObjectContext, re
 func (UnimplementedCronServiceServer) RunKeyRefill(ctx sdk_go.ObjectContext, req *RunKeyRefillRequest) (*RunKeyRefillResponse, error) {
 	return nil, sdk_go.TerminalError(fmt.Errorf("method RunKeyRefill not implemented"), 501)
 }
+func (UnimplementedCronServiceServer) RunKeyRotation(ctx sdk_go.ObjectContext, req *RunKeyRotationRequest) (*RunKeyRotationResponse, error) {
+	return nil, sdk_go.TerminalError(fmt.Errorf("method RunKeyRotation not implemented"), 501)
+}
+func (UnimplementedCronServiceServer) ExpireRotatedKey(ctx sdk_go.ObjectContext, req *ExpireRotatedKeyRequest) (*ExpireRotatedKeyResponse, error) {
+	return nil, sdk_go.TerminalError(fmt.Errorf("method ExpireRotatedKey not implemented"), 501)
+}
+func (UnimplementedCronServiceServer) RunKeyExpiryNotifications(ctx sdk_go.ObjectContext, req *RunKeyExpiryNotificationsRequest) (*RunKeyExpiryNotificationsResponse, error) {
+	return nil, sdk_go.TerminalError(fmt.Errorf("method RunKeyExpiryNotifications not implemented"), 501)
+}
 func (UnimplementedCronServiceServer) RunKeyLastUsedSync(ctx sdk_go.ObjectContext, req *RunKeyLastUsedSyncRequest) (*RunKeyLastUsedSyncResponse, error) {
 	return nil, sdk_go.TerminalError(fmt.Errorf("method RunKeyLastUsedSync not implemented"), 501)
 }
@@ -448,6 +575,9 @@ func (UnimplementedCronServiceServer) RunRatelimitGlobalCountersCleanup(ctx sdk_
 func (UnimplementedCronServiceServer) RunAuditLogOutboxCleanup(ctx sdk_go.ObjectContext, req *RunAuditLogOutboxCleanupRequest) (*RunAuditLogOutboxCleanupResponse, error) {
 	return nil, sdk_go.TerminalError(fmt.Errorf("method RunAuditLogOutboxCleanup not implemented"), 501)
 }
+func (UnimplementedCronServiceServer) RunIdempotencyKeysCleanup(ctx sdk_go.ObjectContext, req *RunIdempotencyKeysCleanupRequest) (*RunIdempotencyKeysCleanupResponse, error) {
+	return nil, sdk_go.TerminalError(fmt.Errorf("method RunIdempotencyKeysCleanup not implemented"), 501)
+}
 func (UnimplementedCronServiceServer) RunDeployBillingPush(ctx sdk_go.ObjectContext, req *RunDeployBillingPushRequest) (*RunDeployBillingPushResponse, error) {
 	return nil, sdk_go.TerminalError(fmt.Errorf("method RunDeployBillingPush not implemented"), 501)
 }
@@ -484,10 +614,14 @@ func NewCronServiceServer(srv CronServiceServer, opts ...sdk_go.ServiceDefinitio
 	router := sdk_go.NewObject("hydra.v1.CronService", sOpts...)
 	router = router.Handler("RunQuotaCheck", sdk_go.NewObjectHandler(srv.RunQuotaCheck))
 	router = router.Handler("RunKeyRefill", sdk_go.NewObjectHandler(srv.RunKeyRefill))
+	router = router.Handler("RunKeyRotation", sdk_go.NewObjectHandler(srv.RunKeyRotation))
+	router = router.Handler("ExpireRotatedKey", sdk_go.NewObjectHandler(srv.ExpireRotatedKey))
+	router = router.Handler("RunKeyExpiryNotifications", sdk_go.NewObjectHandler(srv.RunKeyExpiryNotifications))
 	router = router.Handler("RunKeyLastUsedSync", sdk_go.NewObjectHandler(srv.RunKeyLastUsedSync))
 	router = router.Handler("RunAuditLogExport", sdk_go.NewObjectHandler(srv.RunAuditLogExport))
 	router = router.Handler("RunRatelimitGlobalCountersCleanup", sdk_go.NewObjectHandler(srv.RunRatelimitGlobalCountersCleanup))
 	router = router.Handler("RunAuditLogOutboxCleanup", sdk_go.NewObjectHandler(srv.RunAuditLogOutboxCleanup))
+	router = router.Handler("RunIdempotencyKeysCleanup", sdk_go.NewObjectHandler(srv.RunIdempotencyKeysCleanup))
 	router = router.Handler("RunDeployBillingPush", sdk_go.NewObjectHandler(srv.RunDeployBillingPush))
 	router = router.Handler("RunScaleDownIdlePreviewDeployments", sdk_go.NewObjectHandler(srv.RunScaleDownIdlePreviewDeployments))
 	router = router.Handler("RunDeployBillingClose", sdk_go.NewObjectHandler(srv.RunDeployBillingClose))
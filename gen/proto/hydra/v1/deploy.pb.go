@@ -32,7 +32,8 @@ type TeardownMode int32
 const (
 	TeardownMode_TEARDOWN_MODE_UNSPECIFIED TeardownMode = 0
 	// ARCHIVE permanently decommissions the deployments (cancel). The cleared
-	// current_deployment_id stays cleared.
+	// current_deployment_id stays cleared, and the workspace's persistent
+	// volumes are released and purged after the retention window.
 	TeardownMode_TEARDOWN_MODE_ARCHIVE TeardownMode = 1
 	// SUSPEND stops the deployments but records what it stopped so Resume can
 	// bring them back (spend cap).
@@ -1053,6 +1054,98 @@ func (x *ResumeResponse) GetDeploymentsResumed() int32 {
 	return 0
 }
 
+type PurgeVolumesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ReleasedBefore is a unix milli timestamp. Only volumes released at or
+	// before it are purged, so a later release gets its own full window.
+	ReleasedBefore int64 `protobuf:"varint,1,opt,name=released_before,json=releasedBefore,proto3" json:"released_before,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PurgeVolumesRequest) Reset() {
+	*x = PurgeVolumesRequest{}
+	mi := &file_hydra_v1_deploy_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeVolumesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeVolumesRequest) ProtoMessage() {}
+
+func (x *PurgeVolumesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_deploy_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeVolumesRequest.ProtoReflect.Descriptor instead.
+func (*PurgeVolumesRequest) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_deploy_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PurgeVolumesRequest) GetReleasedBefore() int64 {
+	if x != nil {
+		return x.ReleasedBefore
+	}
+	return 0
+}
+
+type PurgeVolumesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// VolumesPurged is how many volumes were marked purged and handed to krane
+	// for deletion.
+	VolumesPurged int32 `protobuf:"varint,1,opt,name=volumes_purged,json=volumesPurged,proto3" json:"volumes_purged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeVolumesResponse) Reset() {
+	*x = PurgeVolumesResponse{}
+	mi := &file_hydra_v1_deploy_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeVolumesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeVolumesResponse) ProtoMessage() {}
+
+func (x *PurgeVolumesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_deploy_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeVolumesResponse.ProtoReflect.Descriptor instead.
+func (*PurgeVolumesResponse) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_deploy_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PurgeVolumesResponse) GetVolumesPurged() int32 {
+	if x != nil {
+		return x.VolumesPurged
+	}
+	return 0
+}
+
 var File_hydra_v1_deploy_proto protoreflect.FileDescriptor
 
 const file_hydra_v1_deploy_proto_rawDesc = "" +
@@ -1112,7 +1205,11 @@ const file_hydra_v1_deploy_proto_rawDesc = "" +
 	"\adrained\x18\x02 \x01(\bR\adrained\"\x0f\n" +
 	"\rResumeRequest\"A\n" +
 	"\x0eResumeResponse\x12/\n" +
-	"\x13deployments_resumed\x18\x01 \x01(\x05R\x12deploymentsResumed*c\n" +
+	"\x13deployments_resumed\x18\x01 \x01(\x05R\x12deploymentsResumed\">\n" +
+	"\x13PurgeVolumesRequest\x12'\n" +
+	"\x0freleased_before\x18\x01 \x01(\x03R\x0ereleasedBefore\"=\n" +
+	"\x14PurgeVolumesResponse\x12%\n" +
+	"\x0evolumes_purged\x18\x01 \x01(\x05R\rvolumesPurged*c\n" +
 	"\fTeardownMode\x12\x1d\n" +
 	"\x19TEARDOWN_MODE_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15TEARDOWN_MODE_ARCHIVE\x10\x01\x12\x19\n" +
@@ -1123,10 +1220,11 @@ const file_hydra_v1_deploy_proto_rawDesc = "" +
 	"\aPromote\x12\x18.hydra.v1.PromoteRequest\x1a\x19.hydra.v1.PromoteResponse\"\x00\x12U\n" +
 	"\x0eStopDeployment\x12\x1f.hydra.v1.StopDeploymentRequest\x1a .hydra.v1.StopDeploymentResponse\"\x00\x12U\n" +
 	"\x0eWakeDeployment\x12\x1f.hydra.v1.WakeDeploymentRequest\x1a .hydra.v1.WakeDeploymentResponse\"\x00\x12k\n" +
-	"\x14NotifyInstancesReady\x12%.hydra.v1.NotifyInstancesReadyRequest\x1a&.hydra.v1.NotifyInstancesReadyResponse\"\x04\x98\x80\x01\x02\x1a\x04\x98\x80\x01\x012\xa1\x01\n" +
+	"\x14NotifyInstancesReady\x12%.hydra.v1.NotifyInstancesReadyRequest\x1a&.hydra.v1.NotifyInstancesReadyResponse\"\x04\x98\x80\x01\x02\x1a\x04\x98\x80\x01\x012\xf2\x01\n" +
 	"\x15DeployTeardownService\x12C\n" +
 	"\bTeardown\x12\x19.hydra.v1.TeardownRequest\x1a\x1a.hydra.v1.TeardownResponse\"\x00\x12=\n" +
-	"\x06Resume\x12\x17.hydra.v1.ResumeRequest\x1a\x18.hydra.v1.ResumeResponse\"\x00\x1a\x04\x98\x80\x01\x01B\x91\x01\n" +
+	"\x06Resume\x12\x17.hydra.v1.ResumeRequest\x1a\x18.hydra.v1.ResumeResponse\"\x00\x12O\n" +
+	"\fPurgeVolumes\x12\x1d.hydra.v1.PurgeVolumesRequest\x1a\x1e.hydra.v1.PurgeVolumesResponse\"\x00\x1a\x04\x98\x80\x01\x01B\x91\x01\n" +
 	"\fcom.hydra.v1B\vDeployProtoP\x01Z3github.com/unkeyed/unkey/gen/proto/hydra/v1;hydrav1\xa2\x02\x03HXX\xaa\x02\bHydra.V1\xca\x02\bHydra\\V1\xe2\x02\x14Hydra\\V1\\GPBMetadata\xea\x02\tHydra::V1b\x06proto3"
 
 var (
@@ -1142,7 +1240,7 @@ func file_hydra_v1_deploy_proto_rawDescGZIP() []byte {
 }
 
 var file_hydra_v1_deploy_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_hydra_v1_deploy_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_hydra_v1_deploy_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_hydra_v1_deploy_proto_goTypes = []any{
 	(TeardownMode)(0),                    // 0: hydra.v1.TeardownMode
 	(*StopDeploymentRequest)(nil),        // 1: hydra.v1.StopDeploymentRequest
@@ -1163,15 +1261,17 @@ var file_hydra_v1_deploy_proto_goTypes = []any{
 	(*TeardownResponse)(nil),             // 16: hydra.v1.TeardownResponse
 	(*ResumeRequest)(nil),                // 17: hydra.v1.ResumeRequest
 	(*ResumeResponse)(nil),               // 18: hydra.v1.ResumeResponse
-	(*v1.ActorInfo)(nil),                 // 19: ctrl.v1.ActorInfo
+	(*PurgeVolumesRequest)(nil),          // 19: hydra.v1.PurgeVolumesRequest
+	(*PurgeVolumesResponse)(nil),         // 20: hydra.v1.PurgeVolumesResponse
+	(*v1.ActorInfo)(nil),                 // 21: ctrl.v1.ActorInfo
 }
 var file_hydra_v1_deploy_proto_depIdxs = []int32{
-	19, // 0: hydra.v1.StopDeploymentRequest.actor:type_name -> ctrl.v1.ActorInfo
-	19, // 1: hydra.v1.WakeDeploymentRequest.actor:type_name -> ctrl.v1.ActorInfo
+	21, // 0: hydra.v1.StopDeploymentRequest.actor:type_name -> ctrl.v1.ActorInfo
+	21, // 1: hydra.v1.WakeDeploymentRequest.actor:type_name -> ctrl.v1.ActorInfo
 	8,  // 2: hydra.v1.DeployRequest.git:type_name -> hydra.v1.GitSource
 	7,  // 3: hydra.v1.DeployRequest.docker_image:type_name -> hydra.v1.DockerImage
-	19, // 4: hydra.v1.RollbackRequest.actor:type_name -> ctrl.v1.ActorInfo
-	19, // 5: hydra.v1.PromoteRequest.actor:type_name -> ctrl.v1.ActorInfo
+	21, // 4: hydra.v1.RollbackRequest.actor:type_name -> ctrl.v1.ActorInfo
+	21, // 5: hydra.v1.PromoteRequest.actor:type_name -> ctrl.v1.ActorInfo
 	0,  // 6: hydra.v1.TeardownRequest.mode:type_name -> hydra.v1.TeardownMode
 	9,  // 7: hydra.v1.DeployService.Deploy:input_type -> hydra.v1.DeployRequest
 	11, // 8: hydra.v1.DeployService.Rollback:input_type -> hydra.v1.RollbackRequest
@@ -1181,16 +1281,18 @@ var file_hydra_v1_deploy_proto_depIdxs = []int32{
 	5,  // 12: hydra.v1.DeployService.NotifyInstancesReady:input_type -> hydra.v1.NotifyInstancesReadyRequest
 	15, // 13: hydra.v1.DeployTeardownService.Teardown:input_type -> hydra.v1.TeardownRequest
 	17, // 14: hydra.v1.DeployTeardownService.Resume:input_type -> hydra.v1.ResumeRequest
-	10, // 15: hydra.v1.DeployService.Deploy:output_type -> hydra.v1.DeployResponse
-	12, // 16: hydra.v1.DeployService.Rollback:output_type -> hydra.v1.RollbackResponse
-	14, // 17: hydra.v1.DeployService.Promote:output_type -> hydra.v1.PromoteResponse
-	2,  // 18: hydra.v1.DeployService.StopDeployment:output_type -> hydra.v1.StopDeploymentResponse
-	4,  // 19: hydra.v1.DeployService.WakeDeployment:output_type -> hydra.v1.WakeDeploymentResponse
-	6,  // 20: hydra.v1.DeployService.NotifyInstancesReady:output_type -> hydra.v1.NotifyInstancesReadyResponse
-	16, // 21: hydra.v1.DeployTeardownService.Teardown:output_type -> hydra.v1.TeardownResponse
-	18, // 22: hydra.v1.DeployTeardownService.Resume:output_type -> hydra.v1.ResumeResponse
-	15, // [15:23] is the sub-list for method output_type
-	7,  // [7:15] is the sub-list for method input_type
+	19, // 15: hydra.v1.DeployTeardownService.PurgeVolumes:input_type -> hydra.v1.PurgeVolumesRequest
+	10, // 16: hydra.v1.DeployService.Deploy:output_type -> hydra.v1.DeployResponse
+	12, // 17: hydra.v1.DeployService.Rollback:output_type -> hydra.v1.RollbackResponse
+	14, // 18: hydra.v1.DeployService.Promote:output_type -> hydra.v1.PromoteResponse
+	2,  // 19: hydra.v1.DeployService.StopDeployment:output_type -> hydra.v1.StopDeploymentResponse
+	4,  // 20: hydra.v1.DeployService.WakeDeployment:output_type -> hydra.v1.WakeDeploymentResponse
+	6,  // 21: hydra.v1.DeployService.NotifyInstancesReady:output_type -> hydra.v1.NotifyInstancesReadyResponse
+	16, // 22: hydra.v1.DeployTeardownService.Teardown:output_type -> hydra.v1.TeardownResponse
+	18, // 23: hydra.v1.DeployTeardownService.Resume:output_type -> hydra.v1.ResumeResponse
+	20, // 24: hydra.v1.DeployTeardownService.PurgeVolumes:output_type -> hydra.v1.PurgeVolumesResponse
+	16, // [16:25] is the sub-list for method output_type
+	7,  // [7:16] is the sub-list for method input_type
 	7,  // [7:7] is the sub-list for extension type_name
 	7,  // [7:7] is the sub-list for extension extendee
 	0,  // [0:7] is the sub-list for field type_name
@@ -1211,7 +1313,7 @@ func file_hydra_v1_deploy_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hydra_v1_deploy_proto_rawDesc), len(file_hydra_v1_deploy_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   18,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
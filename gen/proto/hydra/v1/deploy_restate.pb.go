@@ -310,6 +310,11 @@ type DeployTeardownServiceClient interface {
 	// Teardown(SUSPEND) saved. Idempotent: a no-op when the workspace was not
 	// suspended (no record).
 	Resume(opts ...sdk_go.ClientOption) sdk_go.Client[*ResumeRequest, *ResumeResponse]
+	// PurgeVolumes deletes the data of the workspace's persistent volumes once
+	// their retention window has passed. Teardown(ARCHIVE) releases the volumes
+	// and schedules this with a delay; volumes reactivated in the meantime are
+	// skipped. Idempotent.
+	PurgeVolumes(opts ...sdk_go.ClientOption) sdk_go.Client[*PurgeVolumesRequest, *PurgeVolumesResponse]
 }
 
 type deployTeardownServiceClient struct {
@@ -342,6 +347,14 @@ func (c *deployTeardownServiceClient) Resume(opts ...sdk_go.ClientOption) sdk_go
 	return sdk_go.WithRequestType[*ResumeRequest](sdk_go.Object[*ResumeResponse](c.ctx, "hydra.v1.DeployTeardownService", c.key, "Resume", cOpts...))
 }
 
+func (c *deployTeardownServiceClient) PurgeVolumes(opts ...sdk_go.ClientOption) sdk_go.Client[*PurgeVolumesRequest, *PurgeVolumesResponse] {
+	cOpts := c.options
+	if len(opts) > 0 {
+		cOpts = append(append([]sdk_go.ClientOption{}, cOpts...), opts...)
+	}
+	return sdk_go.WithRequestType[*PurgeVolumesRequest](sdk_go.Object[*PurgeVolumesResponse](c.ctx, "hydra.v1.DeployTeardownService", c.key, "PurgeVolumes", cOpts...))
+}
+
 // DeployTeardownServiceIngressClient is the ingress client API for hydra.v1.DeployTeardownService service.
 //
 // This client is used to call the service from outside of a Restate context.
@@ -357,6 +370,11 @@ type DeployTeardownServiceIngressClient interface {
 	// Teardown(SUSPEND) saved. Idempotent: a no-op when the workspace was not
 	// suspended (no record).
 	Resume() ingress.Requester[*ResumeRequest, *ResumeResponse]
+	// PurgeVolumes deletes the data of the workspace's persistent volumes once
+	// their retention window has passed. Teardown(ARCHIVE) releases the volumes
+	// and schedules this with a delay; volumes reactivated in the meantime are
+	// skipped. Idempotent.
+	PurgeVolumes() ingress.Requester[*PurgeVolumesRequest, *PurgeVolumesResponse]
 }
 
 type deployTeardownServiceIngressClient struct {
@@ -383,6 +401,11 @@ func (c *deployTeardownServiceIngressClient) Resume() ingress.Requester[*ResumeR
 	return ingress.NewRequester[*ResumeRequest, *ResumeResponse](c.client, c.serviceName, "Resume", &c.key, &codec)
 }
 
+func (c *deployTeardownServiceIngressClient) PurgeVolumes() ingress.Requester[*PurgeVolumesRequest, *PurgeVolumesResponse] {
+	codec := encoding.ProtoJSONCodec
+	return ingress.NewRequester[*PurgeVolumesRequest, *PurgeVolumesResponse](c.client, c.serviceName, "PurgeVolumes", &c.key, &codec)
+}
+
 // DeployTeardownServiceServer is the server API for hydra.v1.DeployTeardownService service.
 // All implementations should embed UnimplementedDeployTeardownServiceServer
 // for forward compatibility.
@@ -401,6 +424,11 @@ type DeployTeardownServiceServer interface {
 	// Teardown(SUSPEND) saved. Idempotent: a no-op when the workspace was not
 	// suspended (no record).
 	Resume(ctx sdk_go.ObjectContext, req *ResumeRequest) (*ResumeResponse, error)
+	// PurgeVolumes deletes the data of the workspace's persistent volumes once
+	// their retention window has passed. Teardown(ARCHIVE) releases the volumes
+	// and schedules this with a delay; volumes reactivated in the meantime are
+	// skipped. Idempotent.
+	PurgeVolumes(ctx sdk_go.ObjectContext, req *PurgeVolumesRequest) (*PurgeVolumesResponse, error)
 }
 
 // UnimplementedDeployTeardownServiceServer should be embedded to have
@@ -416,6 +444,9 @@ func (UnimplementedDeployTeardownServiceServer) Teardown(ctx sdk_go.ObjectContex
 func (UnimplementedDeployTeardownServiceServer) Resume(ctx sdk_go.ObjectContext, req *ResumeRequest) (*ResumeResponse, error) {
 	return nil, sdk_go.TerminalError(fmt.Errorf("method Resume not implemented"), 501)
 }
+func (UnimplementedDeployTeardownServiceServer) PurgeVolumes(ctx sdk_go.ObjectContext, req *PurgeVolumesRequest) (*PurgeVolumesResponse, error) {
+	return nil, sdk_go.TerminalError(fmt.Errorf("method PurgeVolumes not implemented"), 501)
+}
 func (UnimplementedDeployTeardownServiceServer) testEmbeddedByValue() {}
 
 // UnsafeDeployTeardownServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -437,5 +468,6 @@ func NewDeployTeardownServiceServer(srv DeployTeardownServiceServer, opts ...sdk
 	router := sdk_go.NewObject("hydra.v1.DeployTeardownService", sOpts...)
 	router = router.Handler("Teardown", sdk_go.NewObjectHandler(srv.Teardown))
 	router = router.Handler("Resume", sdk_go.NewObjectHandler(srv.Resume))
+	router = router.Handler("PurgeVolumes", sdk_go.NewObjectHandler(srv.PurgeVolumes))
 	return router
 }
@@ -0,0 +1,653 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: hydra/v1/key_bulk.proto
+
+package hydrav1
+
+import (
+	_ "github.com/restatedev/sdk-go/generated/dev/restate/sdk"
+	v1 "github.com/unkeyed/unkey/gen/proto/ctrl/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RunKeyBulkOperationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// actor is the caller of the bulk route; every per-key audit log names it.
+	Actor *v1.ActorInfo `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor,omitempty"`
+	// correlation_id groups the per-key audit logs of one operation.
+	CorrelationId string `protobuf:"bytes,2,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	WorkspaceId   string `protobuf:"bytes,3,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	ApiId         string `protobuf:"bytes,4,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	// key_auth_id is the keyspace of api_id. Keys outside it fail.
+	KeyAuthId string `protobuf:"bytes,5,opt,name=key_auth_id,json=keyAuthId,proto3" json:"key_auth_id,omitempty"`
+	// filter selects the keys when the caller did not list key ids. When it is
+	// unset, the API has already written one item per listed id.
+	Filter *KeyBulkFilter `protobuf:"bytes,6,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Types that are valid to be assigned to Operation:
+	//
+	//	*RunKeyBulkOperationRequest_Update
+	//	*RunKeyBulkOperationRequest_Delete
+	//	*RunKeyBulkOperationRequest_SetPermissions
+	Operation     isRunKeyBulkOperationRequest_Operation `protobuf_oneof:"operation"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunKeyBulkOperationRequest) Reset() {
+	*x = RunKeyBulkOperationRequest{}
+	mi := &file_hydra_v1_key_bulk_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunKeyBulkOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunKeyBulkOperationRequest) ProtoMessage() {}
+
+func (x *RunKeyBulkOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_key_bulk_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunKeyBulkOperationRequest.ProtoReflect.Descriptor instead.
+func (*RunKeyBulkOperationRequest) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_key_bulk_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RunKeyBulkOperationRequest) GetActor() *v1.ActorInfo {
+	if x != nil {
+		return x.Actor
+	}
+	return nil
+}
+
+func (x *RunKeyBulkOperationRequest) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *RunKeyBulkOperationRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *RunKeyBulkOperationRequest) GetApiId() string {
+	if x != nil {
+		return x.ApiId
+	}
+	return ""
+}
+
+func (x *RunKeyBulkOperationRequest) GetKeyAuthId() string {
+	if x != nil {
+		return x.KeyAuthId
+	}
+	return ""
+}
+
+func (x *RunKeyBulkOperationRequest) GetFilter() *KeyBulkFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *RunKeyBulkOperationRequest) GetOperation() isRunKeyBulkOperationRequest_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+func (x *RunKeyBulkOperationRequest) GetUpdate() *KeyBulkUpdate {
+	if x != nil {
+		if x, ok := x.Operation.(*RunKeyBulkOperationRequest_Update); ok {
+			return x.Update
+		}
+	}
+	return nil
+}
+
+func (x *RunKeyBulkOperationRequest) GetDelete() *KeyBulkDelete {
+	if x != nil {
+		if x, ok := x.Operation.(*RunKeyBulkOperationRequest_Delete); ok {
+			return x.Delete
+		}
+	}
+	return nil
+}
+
+func (x *RunKeyBulkOperationRequest) GetSetPermissions() *KeyBulkSetPermissions {
+	if x != nil {
+		if x, ok := x.Operation.(*RunKeyBulkOperationRequest_SetPermissions); ok {
+			return x.SetPermissions
+		}
+	}
+	return nil
+}
+
+type isRunKeyBulkOperationRequest_Operation interface {
+	isRunKeyBulkOperationRequest_Operation()
+}
+
+type RunKeyBulkOperationRequest_Update struct {
+	Update *KeyBulkUpdate `protobuf:"bytes,7,opt,name=update,proto3,oneof"`
+}
+
+type RunKeyBulkOperationRequest_Delete struct {
+	Delete *KeyBulkDelete `protobuf:"bytes,8,opt,name=delete,proto3,oneof"`
+}
+
+type RunKeyBulkOperationRequest_SetPermissions struct {
+	SetPermissions *KeyBulkSetPermissions `protobuf:"bytes,9,opt,name=set_permissions,json=setPermissions,proto3,oneof"`
+}
+
+func (*RunKeyBulkOperationRequest_Update) isRunKeyBulkOperationRequest_Operation() {}
+
+func (*RunKeyBulkOperationRequest_Delete) isRunKeyBulkOperationRequest_Operation() {}
+
+func (*RunKeyBulkOperationRequest_SetPermissions) isRunKeyBulkOperationRequest_Operation() {}
+
+// KeyBulkFilter selects live keys of the api. Every set criterion must match;
+// a filter with none selects every key.
+type KeyBulkFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// identity_id matches keys owned by this identity. Empty matches any.
+	IdentityId string `protobuf:"bytes,1,opt,name=identity_id,json=identityId,proto3" json:"identity_id,omitempty"`
+	// meta is a JSON object whose top level entries must all equal the key's.
+	// Empty matches any.
+	Meta []byte `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
+	// last_used_before matches keys last used before this unix millisecond
+	// timestamp, including keys never used. Zero matches any.
+	LastUsedBefore int64 `protobuf:"varint,3,opt,name=last_used_before,json=lastUsedBefore,proto3" json:"last_used_before,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *KeyBulkFilter) Reset() {
+	*x = KeyBulkFilter{}
+	mi := &file_hydra_v1_key_bulk_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyBulkFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyBulkFilter) ProtoMessage() {}
+
+func (x *KeyBulkFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_key_bulk_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyBulkFilter.ProtoReflect.Descriptor instead.
+func (*KeyBulkFilter) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_key_bulk_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *KeyBulkFilter) GetIdentityId() string {
+	if x != nil {
+		return x.IdentityId
+	}
+	return ""
+}
+
+func (x *KeyBulkFilter) GetMeta() []byte {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *KeyBulkFilter) GetLastUsedBefore() int64 {
+	if x != nil {
+		return x.LastUsedBefore
+	}
+	return 0
+}
+
+// KeyBulkUpdate changes the listed fields and leaves the others alone. The
+// clear_* flags remove a value and win over a value set alongside them.
+type KeyBulkUpdate struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      *string                `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	ClearName bool                   `protobuf:"varint,2,opt,name=clear_name,json=clearName,proto3" json:"clear_name,omitempty"`
+	// meta is the new metadata as a JSON object.
+	Meta      *string `protobuf:"bytes,3,opt,name=meta,proto3,oneof" json:"meta,omitempty"`
+	ClearMeta bool    `protobuf:"varint,4,opt,name=clear_meta,json=clearMeta,proto3" json:"clear_meta,omitempty"`
+	// expires is a unix millisecond timestamp.
+	Expires       *int64 `protobuf:"varint,5,opt,name=expires,proto3,oneof" json:"expires,omitempty"`
+	ClearExpires  bool   `protobuf:"varint,6,opt,name=clear_expires,json=clearExpires,proto3" json:"clear_expires,omitempty"`
+	Enabled       *bool  `protobuf:"varint,7,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyBulkUpdate) Reset() {
+	*x = KeyBulkUpdate{}
+	mi := &file_hydra_v1_key_bulk_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyBulkUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyBulkUpdate) ProtoMessage() {}
+
+func (x *KeyBulkUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_key_bulk_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyBulkUpdate.ProtoReflect.Descriptor instead.
+func (*KeyBulkUpdate) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_key_bulk_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *KeyBulkUpdate) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *KeyBulkUpdate) GetClearName() bool {
+	if x != nil {
+		return x.ClearName
+	}
+	return false
+}
+
+func (x *KeyBulkUpdate) GetMeta() string {
+	if x != nil && x.Meta != nil {
+		return *x.Meta
+	}
+	return ""
+}
+
+func (x *KeyBulkUpdate) GetClearMeta() bool {
+	if x != nil {
+		return x.ClearMeta
+	}
+	return false
+}
+
+func (x *KeyBulkUpdate) GetExpires() int64 {
+	if x != nil && x.Expires != nil {
+		return *x.Expires
+	}
+	return 0
+}
+
+func (x *KeyBulkUpdate) GetClearExpires() bool {
+	if x != nil {
+		return x.ClearExpires
+	}
+	return false
+}
+
+func (x *KeyBulkUpdate) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+// KeyBulkDelete soft deletes the keys.
+type KeyBulkDelete struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyBulkDelete) Reset() {
+	*x = KeyBulkDelete{}
+	mi := &file_hydra_v1_key_bulk_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyBulkDelete) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyBulkDelete) ProtoMessage() {}
+
+func (x *KeyBulkDelete) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_key_bulk_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyBulkDelete.ProtoReflect.Descriptor instead.
+func (*KeyBulkDelete) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_key_bulk_proto_rawDescGZIP(), []int{3}
+}
+
+// KeyBulkSetPermissions replaces each key's direct permissions. The API
+// resolves the permissions before submitting, so they all exist.
+type KeyBulkSetPermissions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Permissions   []*KeyBulkPermission   `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyBulkSetPermissions) Reset() {
+	*x = KeyBulkSetPermissions{}
+	mi := &file_hydra_v1_key_bulk_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyBulkSetPermissions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyBulkSetPermissions) ProtoMessage() {}
+
+func (x *KeyBulkSetPermissions) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_key_bulk_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyBulkSetPermissions.ProtoReflect.Descriptor instead.
+func (*KeyBulkSetPermissions) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_key_bulk_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *KeyBulkSetPermissions) GetPermissions() []*KeyBulkPermission {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+type KeyBulkPermission struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyBulkPermission) Reset() {
+	*x = KeyBulkPermission{}
+	mi := &file_hydra_v1_key_bulk_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyBulkPermission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyBulkPermission) ProtoMessage() {}
+
+func (x *KeyBulkPermission) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_key_bulk_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyBulkPermission.ProtoReflect.Descriptor instead.
+func (*KeyBulkPermission) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_key_bulk_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *KeyBulkPermission) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *KeyBulkPermission) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *KeyBulkPermission) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RunKeyBulkOperationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Succeeded     int32                  `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed        int32                  `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunKeyBulkOperationResponse) Reset() {
+	*x = RunKeyBulkOperationResponse{}
+	mi := &file_hydra_v1_key_bulk_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunKeyBulkOperationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunKeyBulkOperationResponse) ProtoMessage() {}
+
+func (x *RunKeyBulkOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_key_bulk_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunKeyBulkOperationResponse.ProtoReflect.Descriptor instead.
+func (*RunKeyBulkOperationResponse) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_key_bulk_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RunKeyBulkOperationResponse) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *RunKeyBulkOperationResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+var File_hydra_v1_key_bulk_proto protoreflect.FileDescriptor
+
+const file_hydra_v1_key_bulk_proto_rawDesc = "" +
+	"\n" +
+	"\x17hydra/v1/key_bulk.proto\x12\bhydra.v1\x1a\x13ctrl/v1/actor.proto\x1a\x18dev/restate/sdk/go.proto\"\xb7\x03\n" +
+	"\x1aRunKeyBulkOperationRequest\x12(\n" +
+	"\x05actor\x18\x01 \x01(\v2\x12.ctrl.v1.ActorInfoR\x05actor\x12%\n" +
+	"\x0ecorrelation_id\x18\x02 \x01(\tR\rcorrelationId\x12!\n" +
+	"\fworkspace_id\x18\x03 \x01(\tR\vworkspaceId\x12\x15\n" +
+	"\x06api_id\x18\x04 \x01(\tR\x05apiId\x12\x1e\n" +
+	"\vkey_auth_id\x18\x05 \x01(\tR\tkeyAuthId\x12/\n" +
+	"\x06filter\x18\x06 \x01(\v2\x17.hydra.v1.KeyBulkFilterR\x06filter\x121\n" +
+	"\x06update\x18\a \x01(\v2\x17.hydra.v1.KeyBulkUpdateH\x00R\x06update\x121\n" +
+	"\x06delete\x18\b \x01(\v2\x17.hydra.v1.KeyBulkDeleteH\x00R\x06delete\x12J\n" +
+	"\x0fset_permissions\x18\t \x01(\v2\x1f.hydra.v1.KeyBulkSetPermissionsH\x00R\x0esetPermissionsB\v\n" +
+	"\toperation\"n\n" +
+	"\rKeyBulkFilter\x12\x1f\n" +
+	"\videntity_id\x18\x01 \x01(\tR\n" +
+	"identityId\x12\x12\n" +
+	"\x04meta\x18\x02 \x01(\fR\x04meta\x12(\n" +
+	"\x10last_used_before\x18\x03 \x01(\x03R\x0elastUsedBefore\"\x8c\x02\n" +
+	"\rKeyBulkUpdate\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"clear_name\x18\x02 \x01(\bR\tclearName\x12\x17\n" +
+	"\x04meta\x18\x03 \x01(\tH\x01R\x04meta\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"clear_meta\x18\x04 \x01(\bR\tclearMeta\x12\x1d\n" +
+	"\aexpires\x18\x05 \x01(\x03H\x02R\aexpires\x88\x01\x01\x12#\n" +
+	"\rclear_expires\x18\x06 \x01(\bR\fclearExpires\x12\x1d\n" +
+	"\aenabled\x18\a \x01(\bH\x03R\aenabled\x88\x01\x01B\a\n" +
+	"\x05_nameB\a\n" +
+	"\x05_metaB\n" +
+	"\n" +
+	"\b_expiresB\n" +
+	"\n" +
+	"\b_enabled\"\x0f\n" +
+	"\rKeyBulkDelete\"V\n" +
+	"\x15KeyBulkSetPermissions\x12=\n" +
+	"\vpermissions\x18\x01 \x03(\v2\x1b.hydra.v1.KeyBulkPermissionR\vpermissions\"K\n" +
+	"\x11KeyBulkPermission\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"S\n" +
+	"\x1bRunKeyBulkOperationResponse\x12\x1c\n" +
+	"\tsucceeded\x18\x01 \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x02 \x01(\x05R\x06failed2l\n" +
+	"\x0eKeyBulkService\x12T\n" +
+	"\x03Run\x12$.hydra.v1.RunKeyBulkOperationRequest\x1a%.hydra.v1.RunKeyBulkOperationResponse\"\x00\x1a\x04\x98\x80\x01\x01B\x92\x01\n" +
+	"\fcom.hydra.v1B\fKeyBulkProtoP\x01Z3github.com/unkeyed/unkey/gen/proto/hydra/v1;hydrav1\xa2\x02\x03HXX\xaa\x02\bHydra.V1\xca\x02\bHydra\\V1\xe2\x02\x14Hydra\\V1\\GPBMetadata\xea\x02\tHydra::V1b\x06proto3"
+
+var (
+	file_hydra_v1_key_bulk_proto_rawDescOnce sync.Once
+	file_hydra_v1_key_bulk_proto_rawDescData []byte
+)
+
+func file_hydra_v1_key_bulk_proto_rawDescGZIP() []byte {
+	file_hydra_v1_key_bulk_proto_rawDescOnce.Do(func() {
+		file_hydra_v1_key_bulk_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hydra_v1_key_bulk_proto_rawDesc), len(file_hydra_v1_key_bulk_proto_rawDesc)))
+	})
+	return file_hydra_v1_key_bulk_proto_rawDescData
+}
+
+var file_hydra_v1_key_bulk_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_hydra_v1_key_bulk_proto_goTypes = []any{
+	(*RunKeyBulkOperationRequest)(nil),  // 0: hydra.v1.RunKeyBulkOperationRequest
+	(*KeyBulkFilter)(nil),               // 1: hydra.v1.KeyBulkFilter
+	(*KeyBulkUpdate)(nil),               // 2: hydra.v1.KeyBulkUpdate
+	(*KeyBulkDelete)(nil),               // 3: hydra.v1.KeyBulkDelete
+	(*KeyBulkSetPermissions)(nil),       // 4: hydra.v1.KeyBulkSetPermissions
+	(*KeyBulkPermission)(nil),           // 5: hydra.v1.KeyBulkPermission
+	(*RunKeyBulkOperationResponse)(nil), // 6: hydra.v1.RunKeyBulkOperationResponse
+	(*v1.ActorInfo)(nil),                // 7: ctrl.v1.ActorInfo
+}
+var file_hydra_v1_key_bulk_proto_depIdxs = []int32{
+	7, // 0: hydra.v1.RunKeyBulkOperationRequest.actor:type_name -> ctrl.v1.ActorInfo
+	1, // 1: hydra.v1.RunKeyBulkOperationRequest.filter:type_name -> hydra.v1.KeyBulkFilter
+	2, // 2: hydra.v1.RunKeyBulkOperationRequest.update:type_name -> hydra.v1.KeyBulkUpdate
+	3, // 3: hydra.v1.RunKeyBulkOperationRequest.delete:type_name -> hydra.v1.KeyBulkDelete
+	4, // 4: hydra.v1.RunKeyBulkOperationRequest.set_permissions:type_name -> hydra.v1.KeyBulkSetPermissions
+	5, // 5: hydra.v1.KeyBulkSetPermissions.permissions:type_name -> hydra.v1.KeyBulkPermission
+	0, // 6: hydra.v1.KeyBulkService.Run:input_type -> hydra.v1.RunKeyBulkOperationRequest
+	6, // 7: hydra.v1.KeyBulkService.Run:output_type -> hydra.v1.RunKeyBulkOperationResponse
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_hydra_v1_key_bulk_proto_init() }
+func file_hydra_v1_key_bulk_proto_init() {
+	if File_hydra_v1_key_bulk_proto != nil {
+		return
+	}
+	file_hydra_v1_key_bulk_proto_msgTypes[0].OneofWrappers = []any{
+		(*RunKeyBulkOperationRequest_Update)(nil),
+		(*RunKeyBulkOperationRequest_Delete)(nil),
+		(*RunKeyBulkOperationRequest_SetPermissions)(nil),
+	}
+	file_hydra_v1_key_bulk_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hydra_v1_key_bulk_proto_rawDesc), len(file_hydra_v1_key_bulk_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hydra_v1_key_bulk_proto_goTypes,
+		DependencyIndexes: file_hydra_v1_key_bulk_proto_depIdxs,
+		MessageInfos:      file_hydra_v1_key_bulk_proto_msgTypes,
+	}.Build()
+	File_hydra_v1_key_bulk_proto = out.File
+	file_hydra_v1_key_bulk_proto_goTypes = nil
+	file_hydra_v1_key_bulk_proto_depIdxs = nil
+}
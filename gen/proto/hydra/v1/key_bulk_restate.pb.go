@@ -0,0 +1,131 @@
+// Code generated by protoc-gen-go-restate. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-restate v0.1
+// - protoc             (unknown)
+// source: hydra/v1/key_bulk.proto
+
+package hydrav1
+
+import (
+	fmt "fmt"
+	sdk_go "github.com/restatedev/sdk-go"
+	encoding "github.com/restatedev/sdk-go/encoding"
+	ingress "github.com/restatedev/sdk-go/ingress"
+)
+
+// KeyBulkServiceClient is the client API for hydra.v1.KeyBulkService service.
+//
+// KeyBulkService runs keys.bulkUpdate, keys.bulkDelete and
+// keys.bulkSetPermissions jobs. The API inserts the key_bulk_operations row
+// before submitting, and the service reports progress on that row and its
+// items.
+// Key: operation_id
+type KeyBulkServiceClient interface {
+	// Run applies the operation to every selected key and records the outcome
+	// per key. A key that fails is recorded and skipped; it does not fail the
+	// operation.
+	// Key: operation_id
+	Run(opts ...sdk_go.ClientOption) sdk_go.Client[*RunKeyBulkOperationRequest, *RunKeyBulkOperationResponse]
+}
+
+type keyBulkServiceClient struct {
+	ctx     sdk_go.Context
+	key     string
+	options []sdk_go.ClientOption
+}
+
+func NewKeyBulkServiceClient(ctx sdk_go.Context, key string, opts ...sdk_go.ClientOption) KeyBulkServiceClient {
+	cOpts := append([]sdk_go.ClientOption{sdk_go.WithProtoJSON}, opts...)
+	return &keyBulkServiceClient{
+		ctx,
+		key,
+		cOpts,
+	}
+}
+func (c *keyBulkServiceClient) Run(opts ...sdk_go.ClientOption) sdk_go.Client[*RunKeyBulkOperationRequest, *RunKeyBulkOperationResponse] {
+	cOpts := c.options
+	if len(opts) > 0 {
+		cOpts = append(append([]sdk_go.ClientOption{}, cOpts...), opts...)
+	}
+	return sdk_go.WithRequestType[*RunKeyBulkOperationRequest](sdk_go.Object[*RunKeyBulkOperationResponse](c.ctx, "hydra.v1.KeyBulkService", c.key, "Run", cOpts...))
+}
+
+// KeyBulkServiceIngressClient is the ingress client API for hydra.v1.KeyBulkService service.
+//
+// This client is used to call the service from outside of a Restate context.
+type KeyBulkServiceIngressClient interface {
+	// Run applies the operation to every selected key and records the outcome
+	// per key. A key that fails is recorded and skipped; it does not fail the
+	// operation.
+	// Key: operation_id
+	Run() ingress.Requester[*RunKeyBulkOperationRequest, *RunKeyBulkOperationResponse]
+}
+
+type keyBulkServiceIngressClient struct {
+	client      *ingress.Client
+	serviceName string
+	key         string
+}
+
+func NewKeyBulkServiceIngressClient(client *ingress.Client, key string) KeyBulkServiceIngressClient {
+	return &keyBulkServiceIngressClient{
+		client,
+		"hydra.v1.KeyBulkService",
+		key,
+	}
+}
+
+func (c *keyBulkServiceIngressClient) Run() ingress.Requester[*RunKeyBulkOperationRequest, *RunKeyBulkOperationResponse] {
+	codec := encoding.ProtoJSONCodec
+	return ingress.NewRequester[*RunKeyBulkOperationRequest, *RunKeyBulkOperationResponse](c.client, c.serviceName, "Run", &c.key, &codec)
+}
+
+// KeyBulkServiceServer is the server API for hydra.v1.KeyBulkService service.
+// All implementations should embed UnimplementedKeyBulkServiceServer
+// for forward compatibility.
+//
+// KeyBulkService runs keys.bulkUpdate, keys.bulkDelete and
+// keys.bulkSetPermissions jobs. The API inserts the key_bulk_operations row
+// before submitting, and the service reports progress on that row and its
+// items.
+// Key: operation_id
+type KeyBulkServiceServer interface {
+	// Run applies the operation to every selected key and records the outcome
+	// per key. A key that fails is recorded and skipped; it does not fail the
+	// operation.
+	// Key: operation_id
+	Run(ctx sdk_go.ObjectContext, req *RunKeyBulkOperationRequest) (*RunKeyBulkOperationResponse, error)
+}
+
+// UnimplementedKeyBulkServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedKeyBulkServiceServer struct{}
+
+func (UnimplementedKeyBulkServiceServer) Run(ctx sdk_go.ObjectContext, req *RunKeyBulkOperationRequest) (*RunKeyBulkOperationResponse, error) {
+	return nil, sdk_go.TerminalError(fmt.Errorf("method Run not implemented"), 501)
+}
+func (UnimplementedKeyBulkServiceServer) testEmbeddedByValue() {}
+
+// UnsafeKeyBulkServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KeyBulkServiceServer will
+// result in compilation errors.
+type UnsafeKeyBulkServiceServer interface {
+	mustEmbedUnimplementedKeyBulkServiceServer()
+}
+
+func NewKeyBulkServiceServer(srv KeyBulkServiceServer, opts ...sdk_go.ServiceDefinitionOption) sdk_go.ServiceDefinition {
+	// If the following call panics, it indicates UnimplementedKeyBulkServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	sOpts := append([]sdk_go.ServiceDefinitionOption{sdk_go.WithProtoJSON}, opts...)
+	router := sdk_go.NewObject("hydra.v1.KeyBulkService", sOpts...)
+	router = router.Handler("Run", sdk_go.NewObjectHandler(srv.Run))
+	return router
+}
@@ -4,3 +4,6 @@ package hydrav1
 
 // IsDeployRequest_Source is the exported form of the protobuf oneof interface isDeployRequest_Source.
 type IsDeployRequest_Source = isDeployRequest_Source
+
+// IsRunKeyBulkOperationRequest_Operation is the exported form of the protobuf oneof interface isRunKeyBulkOperationRequest_Operation.
+type IsRunKeyBulkOperationRequest_Operation = isRunKeyBulkOperationRequest_Operation
@@ -0,0 +1,313 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: hydra/v1/webhook.proto
+
+package hydrav1
+
+import (
+	_ "github.com/restatedev/sdk-go/generated/dev/restate/sdk"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DispatchWebhookRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	EventId     string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType   string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// Unix milliseconds when the event happened.
+	CreatedAt int64 `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// JSON encoded event data, placed verbatim under "data" in the envelope.
+	Data          []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DispatchWebhookRequest) Reset() {
+	*x = DispatchWebhookRequest{}
+	mi := &file_hydra_v1_webhook_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DispatchWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DispatchWebhookRequest) ProtoMessage() {}
+
+func (x *DispatchWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_webhook_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DispatchWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DispatchWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_webhook_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DispatchWebhookRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *DispatchWebhookRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *DispatchWebhookRequest) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *DispatchWebhookRequest) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *DispatchWebhookRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type DispatchWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryIds   []string               `protobuf:"bytes,1,rep,name=delivery_ids,json=deliveryIds,proto3" json:"delivery_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DispatchWebhookResponse) Reset() {
+	*x = DispatchWebhookResponse{}
+	mi := &file_hydra_v1_webhook_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DispatchWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DispatchWebhookResponse) ProtoMessage() {}
+
+func (x *DispatchWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_webhook_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DispatchWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DispatchWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_webhook_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DispatchWebhookResponse) GetDeliveryIds() []string {
+	if x != nil {
+		return x.DeliveryIds
+	}
+	return nil
+}
+
+type DeliverWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryId    string                 `protobuf:"bytes,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliverWebhookRequest) Reset() {
+	*x = DeliverWebhookRequest{}
+	mi := &file_hydra_v1_webhook_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliverWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliverWebhookRequest) ProtoMessage() {}
+
+func (x *DeliverWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_webhook_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliverWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeliverWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_webhook_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DeliverWebhookRequest) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+type DeliverWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delivered     bool                   `protobuf:"varint,1,opt,name=delivered,proto3" json:"delivered,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliverWebhookResponse) Reset() {
+	*x = DeliverWebhookResponse{}
+	mi := &file_hydra_v1_webhook_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliverWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliverWebhookResponse) ProtoMessage() {}
+
+func (x *DeliverWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hydra_v1_webhook_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliverWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeliverWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_hydra_v1_webhook_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeliverWebhookResponse) GetDelivered() bool {
+	if x != nil {
+		return x.Delivered
+	}
+	return false
+}
+
+var File_hydra_v1_webhook_proto protoreflect.FileDescriptor
+
+const file_hydra_v1_webhook_proto_rawDesc = "" +
+	"\n" +
+	"\x16hydra/v1/webhook.proto\x12\bhydra.v1\x1a\x18dev/restate/sdk/go.proto\"\xa8\x01\n" +
+	"\x16DispatchWebhookRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tR\teventType\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data\"<\n" +
+	"\x17DispatchWebhookResponse\x12!\n" +
+	"\fdelivery_ids\x18\x01 \x03(\tR\vdeliveryIds\"8\n" +
+	"\x15DeliverWebhookRequest\x12\x1f\n" +
+	"\vdelivery_id\x18\x01 \x01(\tR\n" +
+	"deliveryId\"6\n" +
+	"\x16DeliverWebhookResponse\x12\x1c\n" +
+	"\tdelivered\x18\x01 \x01(\bR\tdelivered2\xb9\x01\n" +
+	"\x0eWebhookService\x12Q\n" +
+	"\bDispatch\x12 .hydra.v1.DispatchWebhookRequest\x1a!.hydra.v1.DispatchWebhookResponse\"\x00\x12N\n" +
+	"\aDeliver\x12\x1f.hydra.v1.DeliverWebhookRequest\x1a .hydra.v1.DeliverWebhookResponse\"\x00\x1a\x04\x98\x80\x01\x00B\x92\x01\n" +
+	"\fcom.hydra.v1B\fWebhookProtoP\x01Z3github.com/unkeyed/unkey/gen/proto/hydra/v1;hydrav1\xa2\x02\x03HXX\xaa\x02\bHydra.V1\xca\x02\bHydra\\V1\xe2\x02\x14Hydra\\V1\\GPBMetadata\xea\x02\tHydra::V1b\x06proto3"
+
+var (
+	file_hydra_v1_webhook_proto_rawDescOnce sync.Once
+	file_hydra_v1_webhook_proto_rawDescData []byte
+)
+
+func file_hydra_v1_webhook_proto_rawDescGZIP() []byte {
+	file_hydra_v1_webhook_proto_rawDescOnce.Do(func() {
+		file_hydra_v1_webhook_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_hydra_v1_webhook_proto_rawDesc), len(file_hydra_v1_webhook_proto_rawDesc)))
+	})
+	return file_hydra_v1_webhook_proto_rawDescData
+}
+
+var file_hydra_v1_webhook_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_hydra_v1_webhook_proto_goTypes = []any{
+	(*DispatchWebhookRequest)(nil),  // 0: hydra.v1.DispatchWebhookRequest
+	(*DispatchWebhookResponse)(nil), // 1: hydra.v1.DispatchWebhookResponse
+	(*DeliverWebhookRequest)(nil),   // 2: hydra.v1.DeliverWebhookRequest
+	(*DeliverWebhookResponse)(nil),  // 3: hydra.v1.DeliverWebhookResponse
+}
+var file_hydra_v1_webhook_proto_depIdxs = []int32{
+	0, // 0: hydra.v1.WebhookService.Dispatch:input_type -> hydra.v1.DispatchWebhookRequest
+	2, // 1: hydra.v1.WebhookService.Deliver:input_type -> hydra.v1.DeliverWebhookRequest
+	1, // 2: hydra.v1.WebhookService.Dispatch:output_type -> hydra.v1.DispatchWebhookResponse
+	3, // 3: hydra.v1.WebhookService.Deliver:output_type -> hydra.v1.DeliverWebhookResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_hydra_v1_webhook_proto_init() }
+func file_hydra_v1_webhook_proto_init() {
+	if File_hydra_v1_webhook_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hydra_v1_webhook_proto_rawDesc), len(file_hydra_v1_webhook_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hydra_v1_webhook_proto_goTypes,
+		DependencyIndexes: file_hydra_v1_webhook_proto_depIdxs,
+		MessageInfos:      file_hydra_v1_webhook_proto_msgTypes,
+	}.Build()
+	File_hydra_v1_webhook_proto = out.File
+	file_hydra_v1_webhook_proto_goTypes = nil
+	file_hydra_v1_webhook_proto_depIdxs = nil
+}
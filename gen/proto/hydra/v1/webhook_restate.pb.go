@@ -0,0 +1,158 @@
+// Code generated by protoc-gen-go-restate. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-restate v0.1
+// - protoc             (unknown)
+// source: hydra/v1/webhook.proto
+
+package hydrav1
+
+import (
+	fmt "fmt"
+	sdk_go "github.com/restatedev/sdk-go"
+	encoding "github.com/restatedev/sdk-go/encoding"
+	ingress "github.com/restatedev/sdk-go/ingress"
+)
+
+// WebhookServiceClient is the client API for hydra.v1.WebhookService service.
+//
+// WebhookService delivers signed outbound webhooks to workspace endpoints.
+//
+// The API emits one Dispatch per event with the event id as the Restate
+// idempotency key, so an event fans out at most once even when the emitter
+// retries. Dispatch writes one webhook_deliveries row per subscribed endpoint
+// and hands each to Deliver, which owns retries for that row.
+type WebhookServiceClient interface {
+	// Dispatch builds the event envelope, finds the enabled endpoints that
+	// subscribe to the event type and starts one Deliver per endpoint.
+	// Scheduled events (key.expired) are re-checked against the database first
+	// and dropped when they no longer hold.
+	Dispatch(opts ...sdk_go.ClientOption) sdk_go.Client[*DispatchWebhookRequest, *DispatchWebhookResponse]
+	// Deliver signs and POSTs one delivery, retrying with exponential backoff
+	// until the endpoint answers 2xx or the attempts run out. Every attempt is
+	// recorded on the delivery row so the delivery log shows progress.
+	Deliver(opts ...sdk_go.ClientOption) sdk_go.Client[*DeliverWebhookRequest, *DeliverWebhookResponse]
+}
+
+type webhookServiceClient struct {
+	ctx     sdk_go.Context
+	options []sdk_go.ClientOption
+}
+
+func NewWebhookServiceClient(ctx sdk_go.Context, opts ...sdk_go.ClientOption) WebhookServiceClient {
+	cOpts := append([]sdk_go.ClientOption{sdk_go.WithProtoJSON}, opts...)
+	return &webhookServiceClient{
+		ctx,
+		cOpts,
+	}
+}
+func (c *webhookServiceClient) Dispatch(opts ...sdk_go.ClientOption) sdk_go.Client[*DispatchWebhookRequest, *DispatchWebhookResponse] {
+	cOpts := c.options
+	if len(opts) > 0 {
+		cOpts = append(append([]sdk_go.ClientOption{}, cOpts...), opts...)
+	}
+	return sdk_go.WithRequestType[*DispatchWebhookRequest](sdk_go.Service[*DispatchWebhookResponse](c.ctx, "hydra.v1.WebhookService", "Dispatch", cOpts...))
+}
+
+func (c *webhookServiceClient) Deliver(opts ...sdk_go.ClientOption) sdk_go.Client[*DeliverWebhookRequest, *DeliverWebhookResponse] {
+	cOpts := c.options
+	if len(opts) > 0 {
+		cOpts = append(append([]sdk_go.ClientOption{}, cOpts...), opts...)
+	}
+	return sdk_go.WithRequestType[*DeliverWebhookRequest](sdk_go.Service[*DeliverWebhookResponse](c.ctx, "hydra.v1.WebhookService", "Deliver", cOpts...))
+}
+
+// WebhookServiceIngressClient is the ingress client API for hydra.v1.WebhookService service.
+//
+// This client is used to call the service from outside of a Restate context.
+type WebhookServiceIngressClient interface {
+	// Dispatch builds the event envelope, finds the enabled endpoints that
+	// subscribe to the event type and starts one Deliver per endpoint.
+	// Scheduled events (key.expired) are re-checked against the database first
+	// and dropped when they no longer hold.
+	Dispatch() ingress.Requester[*DispatchWebhookRequest, *DispatchWebhookResponse]
+	// Deliver signs and POSTs one delivery, retrying with exponential backoff
+	// until the endpoint answers 2xx or the attempts run out. Every attempt is
+	// recorded on the delivery row so the delivery log shows progress.
+	Deliver() ingress.Requester[*DeliverWebhookRequest, *DeliverWebhookResponse]
+}
+
+type webhookServiceIngressClient struct {
+	client      *ingress.Client
+	serviceName string
+}
+
+func NewWebhookServiceIngressClient(client *ingress.Client) WebhookServiceIngressClient {
+	return &webhookServiceIngressClient{
+		client,
+		"hydra.v1.WebhookService",
+	}
+}
+
+func (c *webhookServiceIngressClient) Dispatch() ingress.Requester[*DispatchWebhookRequest, *DispatchWebhookResponse] {
+	codec := encoding.ProtoJSONCodec
+	return ingress.NewRequester[*DispatchWebhookRequest, *DispatchWebhookResponse](c.client, c.serviceName, "Dispatch", nil, &codec)
+}
+
+func (c *webhookServiceIngressClient) Deliver() ingress.Requester[*DeliverWebhookRequest, *DeliverWebhookResponse] {
+	codec := encoding.ProtoJSONCodec
+	return ingress.NewRequester[*DeliverWebhookRequest, *DeliverWebhookResponse](c.client, c.serviceName, "Deliver", nil, &codec)
+}
+
+// WebhookServiceServer is the server API for hydra.v1.WebhookService service.
+// All implementations should embed UnimplementedWebhookServiceServer
+// for forward compatibility.
+//
+// WebhookService delivers signed outbound webhooks to workspace endpoints.
+//
+// The API emits one Dispatch per event with the event id as the Restate
+// idempotency key, so an event fans out at most once even when the emitter
+// retries. Dispatch writes one webhook_deliveries row per subscribed endpoint
+// and hands each to Deliver, which owns retries for that row.
+type WebhookServiceServer interface {
+	// Dispatch builds the event envelope, finds the enabled endpoints that
+	// subscribe to the event type and starts one Deliver per endpoint.
+	// Scheduled events (key.expired) are re-checked against the database first
+	// and dropped when they no longer hold.
+	Dispatch(ctx sdk_go.Context, req *DispatchWebhookRequest) (*DispatchWebhookResponse, error)
+	// Deliver signs and POSTs one delivery, retrying with exponential backoff
+	// until the endpoint answers 2xx or the attempts run out. Every attempt is
+	// recorded on the delivery row so the delivery log shows progress.
+	Deliver(ctx sdk_go.Context, req *DeliverWebhookRequest) (*DeliverWebhookResponse, error)
+}
+
+// UnimplementedWebhookServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWebhookServiceServer struct{}
+
+func (UnimplementedWebhookServiceServer) Dispatch(ctx sdk_go.Context, req *DispatchWebhookRequest) (*DispatchWebhookResponse, error) {
+	return nil, sdk_go.TerminalError(fmt.Errorf("method Dispatch not implemented"), 501)
+}
+func (UnimplementedWebhookServiceServer) Deliver(ctx sdk_go.Context, req *DeliverWebhookRequest) (*DeliverWebhookResponse, error) {
+	return nil, sdk_go.TerminalError(fmt.Errorf("method Deliver not implemented"), 501)
+}
+func (UnimplementedWebhookServiceServer) testEmbeddedByValue() {}
+
+// UnsafeWebhookServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebhookServiceServer will
+// result in compilation errors.
+type UnsafeWebhookServiceServer interface {
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+func NewWebhookServiceServer(srv WebhookServiceServer, opts ...sdk_go.ServiceDefinitionOption) sdk_go.ServiceDefinition {
+	// If the following call panics, it indicates UnimplementedWebhookServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	sOpts := append([]sdk_go.ServiceDefinitionOption{sdk_go.WithProtoJSON}, opts...)
+	router := sdk_go.NewService("hydra.v1.WebhookService", sOpts...)
+	router = router.Handler("Dispatch", sdk_go.NewServiceHandler(srv.Dispatch))
+	router = router.Handler("Deliver", sdk_go.NewServiceHandler(srv.Deliver))
+	return router
+}
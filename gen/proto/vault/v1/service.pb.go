@@ -414,26 +414,31 @@ func (x *ReEncryptResponse) GetKeyId() string {
 	return ""
 }
 
-type ReEncryptDEKsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type RotateMasterKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of data encryption keys to re-wrap in this call.
+	// Defaults to 100 and may not exceed 1000.
+	BatchSize uint32 `protobuf:"varint,1,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// Discard the stored checkpoint and start the pass from the beginning.
+	Restart       bool `protobuf:"varint,2,opt,name=restart,proto3" json:"restart,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReEncryptDEKsRequest) Reset() {
-	*x = ReEncryptDEKsRequest{}
+func (x *RotateMasterKeyRequest) Reset() {
+	*x = RotateMasterKeyRequest{}
 	mi := &file_vault_v1_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReEncryptDEKsRequest) String() string {
+func (x *RotateMasterKeyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReEncryptDEKsRequest) ProtoMessage() {}
+func (*RotateMasterKeyRequest) ProtoMessage() {}
 
-func (x *ReEncryptDEKsRequest) ProtoReflect() protoreflect.Message {
+func (x *RotateMasterKeyRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_vault_v1_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -445,31 +450,54 @@ func (x *ReEncryptDEKsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReEncryptDEKsRequest.ProtoReflect.Descriptor instead.
-func (*ReEncryptDEKsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RotateMasterKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateMasterKeyRequest) Descriptor() ([]byte, []int) {
 	return file_vault_v1_service_proto_rawDescGZIP(), []int{8}
 }
 
-type ReEncryptDEKsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *RotateMasterKeyRequest) GetBatchSize() uint32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *RotateMasterKeyRequest) GetRestart() bool {
+	if x != nil {
+		return x.Restart
+	}
+	return false
+}
+
+type RotateMasterKeyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The id of the master key every data encryption key is re-wrapped with.
+	KekId string `protobuf:"bytes,1,opt,name=kek_id,json=kekId,proto3" json:"kek_id,omitempty"`
+	// Progress of the whole pass, not just this call.
+	Rewrapped uint64 `protobuf:"varint,2,opt,name=rewrapped,proto3" json:"rewrapped,omitempty"`
+	Skipped   uint64 `protobuf:"varint,3,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Remaining uint64 `protobuf:"varint,4,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	// All data encryption keys are wrapped with kek_id. The previous master key
+	// is no longer needed for decryption once this is true.
+	Done          bool `protobuf:"varint,5,opt,name=done,proto3" json:"done,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReEncryptDEKsResponse) Reset() {
-	*x = ReEncryptDEKsResponse{}
+func (x *RotateMasterKeyResponse) Reset() {
+	*x = RotateMasterKeyResponse{}
 	mi := &file_vault_v1_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReEncryptDEKsResponse) String() string {
+func (x *RotateMasterKeyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReEncryptDEKsResponse) ProtoMessage() {}
+func (*RotateMasterKeyResponse) ProtoMessage() {}
 
-func (x *ReEncryptDEKsResponse) ProtoReflect() protoreflect.Message {
+func (x *RotateMasterKeyResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_vault_v1_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -481,11 +509,46 @@ func (x *ReEncryptDEKsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReEncryptDEKsResponse.ProtoReflect.Descriptor instead.
-func (*ReEncryptDEKsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RotateMasterKeyResponse.ProtoReflect.Descriptor instead.
+func (*RotateMasterKeyResponse) Descriptor() ([]byte, []int) {
 	return file_vault_v1_service_proto_rawDescGZIP(), []int{9}
 }
 
+func (x *RotateMasterKeyResponse) GetKekId() string {
+	if x != nil {
+		return x.KekId
+	}
+	return ""
+}
+
+func (x *RotateMasterKeyResponse) GetRewrapped() uint64 {
+	if x != nil {
+		return x.Rewrapped
+	}
+	return 0
+}
+
+func (x *RotateMasterKeyResponse) GetSkipped() uint64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *RotateMasterKeyResponse) GetRemaining() uint64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *RotateMasterKeyResponse) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
 type EncryptBulkRequest struct {
 	state   protoimpl.MessageState `protogen:"open.v1"`
 	Keyring string                 `protobuf:"bytes,1,opt,name=keyring,proto3" json:"keyring,omitempty"`
@@ -759,9 +822,17 @@ const file_vault_v1_service_proto_rawDesc = "" +
 	"\a_key_id\"H\n" +
 	"\x11ReEncryptResponse\x12\x1c\n" +
 	"\tencrypted\x18\x01 \x01(\tR\tencrypted\x12\x15\n" +
-	"\x06key_id\x18\x02 \x01(\tR\x05keyId\"\x16\n" +
-	"\x14ReEncryptDEKsRequest\"\x17\n" +
-	"\x15ReEncryptDEKsResponse\"\xa7\x01\n" +
+	"\x06key_id\x18\x02 \x01(\tR\x05keyId\"Q\n" +
+	"\x16RotateMasterKeyRequest\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x01 \x01(\rR\tbatchSize\x12\x18\n" +
+	"\arestart\x18\x02 \x01(\bR\arestart\"\x9a\x01\n" +
+	"\x17RotateMasterKeyResponse\x12\x15\n" +
+	"\x06kek_id\x18\x01 \x01(\tR\x05kekId\x12\x1c\n" +
+	"\trewrapped\x18\x02 \x01(\x04R\trewrapped\x12\x18\n" +
+	"\askipped\x18\x03 \x01(\x04R\askipped\x12\x1c\n" +
+	"\tremaining\x18\x04 \x01(\x04R\tremaining\x12\x12\n" +
+	"\x04done\x18\x05 \x01(\bR\x04done\"\xa7\x01\n" +
 	"\x12EncryptBulkRequest\x12\x18\n" +
 	"\akeyring\x18\x01 \x01(\tR\akeyring\x12=\n" +
 	"\x05items\x18\x02 \x03(\v2'.vault.v1.EncryptBulkRequest.ItemsEntryR\x05items\x1a8\n" +
@@ -790,14 +861,15 @@ const file_vault_v1_service_proto_rawDesc = "" +
 	"\n" +
 	"ItemsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xbb\x03\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\x95\x04\n" +
 	"\fVaultService\x12C\n" +
 	"\bLiveness\x12\x19.vault.v1.LivenessRequest\x1a\x1a.vault.v1.LivenessResponse\"\x00\x12@\n" +
 	"\aEncrypt\x12\x18.vault.v1.EncryptRequest\x1a\x19.vault.v1.EncryptResponse\"\x00\x12@\n" +
 	"\aDecrypt\x12\x18.vault.v1.DecryptRequest\x1a\x19.vault.v1.DecryptResponse\"\x00\x12L\n" +
 	"\vEncryptBulk\x12\x1c.vault.v1.EncryptBulkRequest\x1a\x1d.vault.v1.EncryptBulkResponse\"\x00\x12L\n" +
 	"\vDecryptBulk\x12\x1c.vault.v1.DecryptBulkRequest\x1a\x1d.vault.v1.DecryptBulkResponse\"\x00\x12F\n" +
-	"\tReEncrypt\x12\x1a.vault.v1.ReEncryptRequest\x1a\x1b.vault.v1.ReEncryptResponse\"\x00B\x92\x01\n" +
+	"\tReEncrypt\x12\x1a.vault.v1.ReEncryptRequest\x1a\x1b.vault.v1.ReEncryptResponse\"\x00\x12X\n" +
+	"\x0fRotateMasterKey\x12 .vault.v1.RotateMasterKeyRequest\x1a!.vault.v1.RotateMasterKeyResponse\"\x00B\x92\x01\n" +
 	"\fcom.vault.v1B\fServiceProtoP\x01Z3github.com/unkeyed/unkey/gen/proto/vault/v1;vaultv1\xa2\x02\x03VXX\xaa\x02\bVault.V1\xca\x02\bVault\\V1\xe2\x02\x14Vault\\V1\\GPBMetadata\xea\x02\tVault::V1b\x06proto3"
 
 var (
@@ -822,8 +894,8 @@ var file_vault_v1_service_proto_goTypes = []any{
 	(*DecryptResponse)(nil),         // 5: vault.v1.DecryptResponse
 	(*ReEncryptRequest)(nil),        // 6: vault.v1.ReEncryptRequest
 	(*ReEncryptResponse)(nil),       // 7: vault.v1.ReEncryptResponse
-	(*ReEncryptDEKsRequest)(nil),    // 8: vault.v1.ReEncryptDEKsRequest
-	(*ReEncryptDEKsResponse)(nil),   // 9: vault.v1.ReEncryptDEKsResponse
+	(*RotateMasterKeyRequest)(nil),  // 8: vault.v1.RotateMasterKeyRequest
+	(*RotateMasterKeyResponse)(nil), // 9: vault.v1.RotateMasterKeyResponse
 	(*EncryptBulkRequest)(nil),      // 10: vault.v1.EncryptBulkRequest
 	(*EncryptBulkResponseItem)(nil), // 11: vault.v1.EncryptBulkResponseItem
 	(*EncryptBulkResponse)(nil),     // 12: vault.v1.EncryptBulkResponse
@@ -846,14 +918,16 @@ var file_vault_v1_service_proto_depIdxs = []int32{
 	10, // 8: vault.v1.VaultService.EncryptBulk:input_type -> vault.v1.EncryptBulkRequest
 	13, // 9: vault.v1.VaultService.DecryptBulk:input_type -> vault.v1.DecryptBulkRequest
 	6,  // 10: vault.v1.VaultService.ReEncrypt:input_type -> vault.v1.ReEncryptRequest
-	1,  // 11: vault.v1.VaultService.Liveness:output_type -> vault.v1.LivenessResponse
-	3,  // 12: vault.v1.VaultService.Encrypt:output_type -> vault.v1.EncryptResponse
-	5,  // 13: vault.v1.VaultService.Decrypt:output_type -> vault.v1.DecryptResponse
-	12, // 14: vault.v1.VaultService.EncryptBulk:output_type -> vault.v1.EncryptBulkResponse
-	14, // 15: vault.v1.VaultService.DecryptBulk:output_type -> vault.v1.DecryptBulkResponse
-	7,  // 16: vault.v1.VaultService.ReEncrypt:output_type -> vault.v1.ReEncryptResponse
-	11, // [11:17] is the sub-list for method output_type
-	5,  // [5:11] is the sub-list for method input_type
+	8,  // 11: vault.v1.VaultService.RotateMasterKey:input_type -> vault.v1.RotateMasterKeyRequest
+	1,  // 12: vault.v1.VaultService.Liveness:output_type -> vault.v1.LivenessResponse
+	3,  // 13: vault.v1.VaultService.Encrypt:output_type -> vault.v1.EncryptResponse
+	5,  // 14: vault.v1.VaultService.Decrypt:output_type -> vault.v1.DecryptResponse
+	12, // 15: vault.v1.VaultService.EncryptBulk:output_type -> vault.v1.EncryptBulkResponse
+	14, // 16: vault.v1.VaultService.DecryptBulk:output_type -> vault.v1.DecryptBulkResponse
+	7,  // 17: vault.v1.VaultService.ReEncrypt:output_type -> vault.v1.ReEncryptResponse
+	9,  // 18: vault.v1.VaultService.RotateMasterKey:output_type -> vault.v1.RotateMasterKeyResponse
+	12, // [12:19] is the sub-list for method output_type
+	5,  // [5:12] is the sub-list for method input_type
 	5,  // [5:5] is the sub-list for extension type_name
 	5,  // [5:5] is the sub-list for extension extendee
 	0,  // [0:5] is the sub-list for field type_name
@@ -47,6 +47,9 @@ const (
 	VaultServiceDecryptBulkProcedure = "/vault.v1.VaultService/DecryptBulk"
 	// VaultServiceReEncryptProcedure is the fully-qualified name of the VaultService's ReEncrypt RPC.
 	VaultServiceReEncryptProcedure = "/vault.v1.VaultService/ReEncrypt"
+	// VaultServiceRotateMasterKeyProcedure is the fully-qualified name of the VaultService's
+	// RotateMasterKey RPC.
+	VaultServiceRotateMasterKeyProcedure = "/vault.v1.VaultService/RotateMasterKey"
 )
 
 // VaultServiceClient is a client for the vault.v1.VaultService service.
@@ -58,6 +61,10 @@ type VaultServiceClient interface {
 	DecryptBulk(context.Context, *connect.Request[v1.DecryptBulkRequest]) (*connect.Response[v1.DecryptBulkResponse], error)
 	// ReEncrypt rec
 	ReEncrypt(context.Context, *connect.Request[v1.ReEncryptRequest]) (*connect.Response[v1.ReEncryptResponse], error)
+	// RotateMasterKey re-wraps the next batch of stored data encryption keys with
+	// the current master key. Progress is checkpointed in storage, so callers
+	// repeat the call until done is true and may resume after an interruption.
+	RotateMasterKey(context.Context, *connect.Request[v1.RotateMasterKeyRequest]) (*connect.Response[v1.RotateMasterKeyResponse], error)
 }
 
 // NewVaultServiceClient constructs a client for the vault.v1.VaultService service. By default, it
@@ -107,17 +114,24 @@ func NewVaultServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(vaultServiceMethods.ByName("ReEncrypt")),
 			connect.WithClientOptions(opts...),
 		),
+		rotateMasterKey: connect.NewClient[v1.RotateMasterKeyRequest, v1.RotateMasterKeyResponse](
+			httpClient,
+			baseURL+VaultServiceRotateMasterKeyProcedure,
+			connect.WithSchema(vaultServiceMethods.ByName("RotateMasterKey")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // vaultServiceClient implements VaultServiceClient.
 type vaultServiceClient struct {
-	liveness    *connect.Client[v1.LivenessRequest, v1.LivenessResponse]
-	encrypt     *connect.Client[v1.EncryptRequest, v1.EncryptResponse]
-	decrypt     *connect.Client[v1.DecryptRequest, v1.DecryptResponse]
-	encryptBulk *connect.Client[v1.EncryptBulkRequest, v1.EncryptBulkResponse]
-	decryptBulk *connect.Client[v1.DecryptBulkRequest, v1.DecryptBulkResponse]
-	reEncrypt   *connect.Client[v1.ReEncryptRequest, v1.ReEncryptResponse]
+	liveness        *connect.Client[v1.LivenessRequest, v1.LivenessResponse]
+	encrypt         *connect.Client[v1.EncryptRequest, v1.EncryptResponse]
+	decrypt         *connect.Client[v1.DecryptRequest, v1.DecryptResponse]
+	encryptBulk     *connect.Client[v1.EncryptBulkRequest, v1.EncryptBulkResponse]
+	decryptBulk     *connect.Client[v1.DecryptBulkRequest, v1.DecryptBulkResponse]
+	reEncrypt       *connect.Client[v1.ReEncryptRequest, v1.ReEncryptResponse]
+	rotateMasterKey *connect.Client[v1.RotateMasterKeyRequest, v1.RotateMasterKeyResponse]
 }
 
 // Liveness calls vault.v1.VaultService.Liveness.
@@ -150,6 +164,11 @@ func (c *vaultServiceClient) ReEncrypt(ctx context.Context, req *connect.Request
 	return c.reEncrypt.CallUnary(ctx, req)
 }
 
+// RotateMasterKey calls vault.v1.VaultService.RotateMasterKey.
+func (c *vaultServiceClient) RotateMasterKey(ctx context.Context, req *connect.Request[v1.RotateMasterKeyRequest]) (*connect.Response[v1.RotateMasterKeyResponse], error) {
+	return c.rotateMasterKey.CallUnary(ctx, req)
+}
+
 // VaultServiceHandler is an implementation of the vault.v1.VaultService service.
 type VaultServiceHandler interface {
 	Liveness(context.Context, *connect.Request[v1.LivenessRequest]) (*connect.Response[v1.LivenessResponse], error)
@@ -159,6 +178,10 @@ type VaultServiceHandler interface {
 	DecryptBulk(context.Context, *connect.Request[v1.DecryptBulkRequest]) (*connect.Response[v1.DecryptBulkResponse], error)
 	// ReEncrypt rec
 	ReEncrypt(context.Context, *connect.Request[v1.ReEncryptRequest]) (*connect.Response[v1.ReEncryptResponse], error)
+	// RotateMasterKey re-wraps the next batch of stored data encryption keys with
+	// the current master key. Progress is checkpointed in storage, so callers
+	// repeat the call until done is true and may resume after an interruption.
+	RotateMasterKey(context.Context, *connect.Request[v1.RotateMasterKeyRequest]) (*connect.Response[v1.RotateMasterKeyResponse], error)
 }
 
 // NewVaultServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -204,6 +227,12 @@ func NewVaultServiceHandler(svc VaultServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(vaultServiceMethods.ByName("ReEncrypt")),
 		connect.WithHandlerOptions(opts...),
 	)
+	vaultServiceRotateMasterKeyHandler := connect.NewUnaryHandler(
+		VaultServiceRotateMasterKeyProcedure,
+		svc.RotateMasterKey,
+		connect.WithSchema(vaultServiceMethods.ByName("RotateMasterKey")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/vault.v1.VaultService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case VaultServiceLivenessProcedure:
@@ -218,6 +247,8 @@ func NewVaultServiceHandler(svc VaultServiceHandler, opts ...connect.HandlerOpti
 			vaultServiceDecryptBulkHandler.ServeHTTP(w, r)
 		case VaultServiceReEncryptProcedure:
 			vaultServiceReEncryptHandler.ServeHTTP(w, r)
+		case VaultServiceRotateMasterKeyProcedure:
+			vaultServiceRotateMasterKeyHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -250,3 +281,7 @@ func (UnimplementedVaultServiceHandler) DecryptBulk(context.Context, *connect.Re
 func (UnimplementedVaultServiceHandler) ReEncrypt(context.Context, *connect.Request[v1.ReEncryptRequest]) (*connect.Response[v1.ReEncryptResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vault.v1.VaultService.ReEncrypt is not implemented"))
 }
+
+func (UnimplementedVaultServiceHandler) RotateMasterKey(context.Context, *connect.Request[v1.RotateMasterKeyRequest]) (*connect.Response[v1.RotateMasterKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vault.v1.VaultService.RotateMasterKey is not implemented"))
+}
@@ -20,6 +20,7 @@ type ClusterServiceClient interface {
 	ReportDeploymentStatus(ctx context.Context, req *v1.ReportDeploymentStatusRequest) (*v1.ReportDeploymentStatusResponse, error)
 	ReportInstanceEvents(ctx context.Context, req *v1.ReportInstanceEventsRequest) (*v1.ReportInstanceEventsResponse, error)
 	Heartbeat(ctx context.Context, req *v1.HeartbeatRequest) (*v1.HeartbeatResponse, error)
+	GetDeploymentRequestRates(ctx context.Context, req *v1.GetDeploymentRequestRatesRequest) (*v1.GetDeploymentRequestRatesResponse, error)
 }
 
 var _ ClusterServiceClient = (*ConnectClusterServiceClient)(nil)
@@ -93,3 +94,16 @@ func (c *ConnectClusterServiceClient) Heartbeat(ctx context.Context, req *v1.Hea
 	}
 	return resp.Msg, nil
 }
+
+func (c *ConnectClusterServiceClient) GetDeploymentRequestRates(ctx context.Context, req *v1.GetDeploymentRequestRatesRequest) (*v1.GetDeploymentRequestRatesResponse, error) {
+	ctx, span := tracing.Start(ctx, "ClusterService.GetDeploymentRequestRates")
+	defer span.End()
+	resp, err := c.inner.GetDeploymentRequestRates(ctx, connect.NewRequest(req))
+	if err != nil {
+		if connect.CodeOf(err) != connect.CodeNotFound {
+			tracing.RecordError(span, err)
+		}
+		return nil, err
+	}
+	return resp.Msg, nil
+}
@@ -20,6 +20,7 @@ type VaultServiceClient interface {
 	EncryptBulk(ctx context.Context, req *v1.EncryptBulkRequest) (*v1.EncryptBulkResponse, error)
 	DecryptBulk(ctx context.Context, req *v1.DecryptBulkRequest) (*v1.DecryptBulkResponse, error)
 	ReEncrypt(ctx context.Context, req *v1.ReEncryptRequest) (*v1.ReEncryptResponse, error)
+	RotateMasterKey(ctx context.Context, req *v1.RotateMasterKeyRequest) (*v1.RotateMasterKeyResponse, error)
 }
 
 var _ VaultServiceClient = (*ConnectVaultServiceClient)(nil)
@@ -111,3 +112,16 @@ func (c *ConnectVaultServiceClient) ReEncrypt(ctx context.Context, req *v1.ReEnc
 	}
 	return resp.Msg, nil
 }
+
+func (c *ConnectVaultServiceClient) RotateMasterKey(ctx context.Context, req *v1.RotateMasterKeyRequest) (*v1.RotateMasterKeyResponse, error) {
+	ctx, span := tracing.Start(ctx, "VaultService.RotateMasterKey")
+	defer span.End()
+	resp, err := c.inner.RotateMasterKey(ctx, connect.NewRequest(req))
+	if err != nil {
+		if connect.CodeOf(err) != connect.CodeNotFound {
+			tracing.RecordError(span, err)
+		}
+		return nil, err
+	}
+	return resp.Msg, nil
+}
@@ -20,6 +20,10 @@ type Caches struct {
 	// Keys are cache.ScopedKey and values are db.FindRatelimitNamespace.
 	RatelimitNamespace cache.Cache[cache.ScopedKey, db.FindRatelimitNamespace]
 
+	// RatelimitPlan caches ratelimit plan lookups by name or ID.
+	// Keys are cache.ScopedKey and values are db.FindRatelimitPlan.
+	RatelimitPlan cache.Cache[cache.ScopedKey, db.FindRatelimitPlan]
+
 	// VerificationKeyByHash caches verification key lookups by their hash with pre-parsed data.
 	// Keys are string (hash) and values are keysdb.CachedKeyData (includes pre-parsed IP whitelist).
 	VerificationKeyByHash cache.Cache[string, keysdb.CachedKeyData]
@@ -41,7 +45,8 @@ type Caches struct {
 	WorkspaceLimits cache.Cache[string, keysdb.Limit]
 
 	// PortalSession caches portal session lookups by session token.
-	// Keys are string (session token ID) and values are db.PortalSession.
+	// Keys are string (SHA-256 hash of the access token) and values are
+	// db.PortalSession.
 	// Short fresh window because sessions can expire; stale window allows
 	// serving slightly-stale data while revalidating in the background.
 	PortalSession cache.Cache[string, db.PortalSession]
@@ -52,6 +57,21 @@ type Caches struct {
 	// JWT principals quickly; long stale window keeps the effectively
 	// immutable org-to-workspace mapping served from memory.
 	WorkspaceByOrgID cache.Cache[string, db.Workspace]
+
+	// JwtConfigsByIssuer caches the JWT configs of a workspace's apis by
+	// issuer. Keys are cache.ScopedKey (workspace ID, issuer) and values are
+	// every config trusting that issuer, empty if none does.
+	JwtConfigsByIssuer cache.Cache[cache.ScopedKey, []keysdb.FindJwtConfigsByIssuerRow]
+
+	// JwtIdentityByExternalID caches the identities verified JWTs map to.
+	// Keys are cache.ScopedKey (workspace ID, external ID) and values are
+	// keysdb.CachedJwtIdentity (includes pre-parsed rate limits).
+	JwtIdentityByExternalID cache.Cache[cache.ScopedKey, keysdb.CachedJwtIdentity]
+
+	// Invalidations removes cached data by the entity it derives from, on
+	// this node and, with a Broadcaster configured, on every other node.
+	// Prefer it over removing keys from individual caches after writes.
+	Invalidations *Invalidator
 }
 
 // Close shuts down the caches and cleans up resources.
@@ -66,6 +86,10 @@ type Config struct {
 
 	// NodeID identifies this node (defaults to hostname-uniqueid to ensure uniqueness).
 	NodeID string
+
+	// Broadcaster sends entity invalidations to the other nodes. Optional:
+	// when nil, invalidations only apply to this node.
+	Broadcaster Broadcaster
 }
 
 // New creates and initializes all cache instances with appropriate settings.
@@ -92,6 +116,17 @@ func New(config Config) (Caches, error) {
 		return Caches{}, err
 	}
 
+	ratelimitPlan, err := cache.New(cache.Config[cache.ScopedKey, db.FindRatelimitPlan]{
+		Fresh:    time.Minute,
+		Stale:    24 * time.Hour,
+		MaxSize:  100_000,
+		Resource: "ratelimit_plan",
+		Clock:    config.Clock,
+	})
+	if err != nil {
+		return Caches{}, err
+	}
+
 	verificationKeyByHash, err := cache.New(cache.Config[string, keysdb.CachedKeyData]{
 		Fresh:    10 * time.Second,
 		Stale:    10 * time.Minute,
@@ -169,14 +204,43 @@ func New(config Config) (Caches, error) {
 		return Caches{}, err
 	}
 
-	return Caches{
-		RatelimitNamespace:    middleware.WithTracing(ratelimitNamespace),
-		LiveApiByID:           middleware.WithTracing(liveApiByID),
-		VerificationKeyByHash: middleware.WithTracing(verificationKeyByHash),
-		ClickhouseSetting:     middleware.WithTracing(clickhouseSetting),
-		ApiToKeyAuthRow:       middleware.WithTracing(apiToKeyAuthRow),
-		WorkspaceLimits:       middleware.WithTracing(workspaceLimits),
-		PortalSession:         middleware.WithTracing(portalSession),
-		WorkspaceByOrgID:      middleware.WithTracing(workspaceByOrgID),
-	}, nil
+	jwtConfigsByIssuer, err := cache.New(cache.Config[cache.ScopedKey, []keysdb.FindJwtConfigsByIssuerRow]{
+		Fresh:    10 * time.Second,
+		Stale:    10 * time.Minute,
+		MaxSize:  100_000,
+		Resource: "jwt_configs_by_issuer",
+		Clock:    config.Clock,
+	})
+	if err != nil {
+		return Caches{}, err
+	}
+
+	jwtIdentityByExternalID, err := cache.New(cache.Config[cache.ScopedKey, keysdb.CachedJwtIdentity]{
+		Fresh:    10 * time.Second,
+		Stale:    10 * time.Minute,
+		MaxSize:  1_000_000,
+		Resource: "jwt_identity_by_external_id",
+		Clock:    config.Clock,
+	})
+	if err != nil {
+		return Caches{}, err
+	}
+
+	c := Caches{
+		RatelimitNamespace:      middleware.WithTracing(ratelimitNamespace),
+		RatelimitPlan:           middleware.WithTracing(ratelimitPlan),
+		LiveApiByID:             middleware.WithTracing(liveApiByID),
+		VerificationKeyByHash:   middleware.WithTracing(verificationKeyByHash),
+		ClickhouseSetting:       middleware.WithTracing(clickhouseSetting),
+		ApiToKeyAuthRow:         middleware.WithTracing(apiToKeyAuthRow),
+		WorkspaceLimits:         middleware.WithTracing(workspaceLimits),
+		PortalSession:           middleware.WithTracing(portalSession),
+		WorkspaceByOrgID:        middleware.WithTracing(workspaceByOrgID),
+		JwtConfigsByIssuer:      middleware.WithTracing(jwtConfigsByIssuer),
+		JwtIdentityByExternalID: middleware.WithTracing(jwtIdentityByExternalID),
+		Invalidations:           nil,
+	}
+	c.Invalidations = newInvalidator(config.NodeID, config.Clock, config.Broadcaster, c)
+
+	return c, nil
 }
@@ -13,4 +13,20 @@
 //
 // All caches are initialized with appropriate TTL settings and size limits,
 // and include OpenTelemetry tracing for observability.
+//
+// After a write, invalidate by entity rather than by cache:
+//
+//	caches.Invalidations.Invalidate(ctx,
+//	    caches.KeyEntity(key.WorkspaceID, key.ID, key.Hash),
+//	)
+//
+// The [Invalidator] knows which caches derive from which entity, removes
+// them in one batch per cache and broadcasts a single
+// cachev1.CacheInvalidationEvent to the other nodes.
+//
+// Events travel between nodes through a [Broadcaster]. [RedisBroadcaster]
+// publishes them over Redis pub/sub; run its Listen method to feed the
+// events of other nodes to [Invalidator.HandleEvent]:
+//
+//	go broadcaster.Listen(ctx, caches.Invalidations.HandleEvent)
 package caches
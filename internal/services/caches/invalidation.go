@@ -0,0 +1,336 @@
+package caches
+
+import (
+	"context"
+
+	cachev1 "github.com/unkeyed/unkey/gen/proto/cache/v1"
+	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
+	"github.com/unkeyed/unkey/pkg/assert"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/clock"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/logger"
+)
+
+// Broadcaster delivers invalidation events to the other nodes of the
+// cluster, which pass them to [Invalidator.HandleEvent].
+//
+// Delivery needs neither ordering nor exactly-once semantics: removing an
+// entry is idempotent, so receivers apply every event they get, including
+// redelivered and overtaken ones.
+type Broadcaster interface {
+	Broadcast(ctx context.Context, event *cachev1.CacheInvalidationEvent) error
+}
+
+// maxEntitiesPerEvent bounds the size of a single broadcast event. Larger
+// invalidations are split across several events.
+const maxEntitiesPerEvent = 1000
+
+// Invalidator invalidates cached data by the entity it derives from, so
+// callers say "key k_123 changed" instead of knowing every cache that holds
+// a copy of it. It fans each entity out to the dependent caches on this
+// node and, when a [Broadcaster] is configured, on every other node.
+//
+// Removing a key also stops refreshes that are already in flight for it
+// from writing their result back; see [cache.Cache.Remove].
+type Invalidator struct {
+	nodeID      string
+	clock       clock.Clock
+	broadcaster Broadcaster
+
+	// local is false for invalidators of processes that hold none of the
+	// caches below and only broadcast.
+	local bool
+
+	verificationKeyByHash cache.Cache[string, keysdb.CachedKeyData]
+	apiToKeyAuthRow       cache.Cache[cache.ScopedKey, db.FindKeyAuthsByIdsRow]
+	liveApiByID           cache.Cache[cache.ScopedKey, db.FindLiveApiByIDRow]
+	jwtConfigsByIssuer    cache.Cache[cache.ScopedKey, []keysdb.FindJwtConfigsByIssuerRow]
+	jwtIdentity           cache.Cache[cache.ScopedKey, keysdb.CachedJwtIdentity]
+	workspaceLimits       cache.Cache[string, keysdb.Limit]
+	clickhouseSetting     cache.Cache[string, db.FindClickhouseWorkspaceSettingsByWorkspaceIDRow]
+	portalSession         cache.Cache[string, db.PortalSession]
+	ratelimitNamespace    cache.Cache[cache.ScopedKey, db.FindRatelimitNamespace]
+	ratelimitPlan         cache.Cache[cache.ScopedKey, db.FindRatelimitPlan]
+}
+
+func newInvalidator(nodeID string, clk clock.Clock, broadcaster Broadcaster, c Caches) *Invalidator {
+	return &Invalidator{
+		nodeID:                nodeID,
+		clock:                 clk,
+		broadcaster:           broadcaster,
+		local:                 true,
+		verificationKeyByHash: c.VerificationKeyByHash,
+		apiToKeyAuthRow:       c.ApiToKeyAuthRow,
+		liveApiByID:           c.LiveApiByID,
+		jwtConfigsByIssuer:    c.JwtConfigsByIssuer,
+		jwtIdentity:           c.JwtIdentityByExternalID,
+		workspaceLimits:       c.WorkspaceLimits,
+		clickhouseSetting:     c.ClickhouseSetting,
+		portalSession:         c.PortalSession,
+		ratelimitNamespace:    c.RatelimitNamespace,
+		ratelimitPlan:         c.RatelimitPlan,
+	}
+}
+
+// RemoteInvalidatorConfig configures an invalidator created with
+// [NewRemoteInvalidator].
+type RemoteInvalidatorConfig struct {
+	// Clock provides time functionality, allowing easier testing.
+	Clock clock.Clock
+
+	// NodeID identifies this process as the source of its events.
+	NodeID string
+
+	// Broadcaster sends the invalidations to the nodes holding the caches.
+	// Must not be nil.
+	Broadcaster Broadcaster
+}
+
+// NewRemoteInvalidator creates an [Invalidator] for processes that change
+// data but hold none of the caches themselves, such as the control plane
+// worker. Its invalidations are only broadcast; the API nodes apply them.
+func NewRemoteInvalidator(config RemoteInvalidatorConfig) (*Invalidator, error) {
+	err := assert.All(
+		assert.NotNil(config.Clock, "Clock must not be nil"),
+		assert.NotEmpty(config.NodeID, "NodeID must not be empty"),
+		assert.NotNil(config.Broadcaster, "Broadcaster must not be nil"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Invalidator{
+		nodeID:                config.NodeID,
+		clock:                 config.Clock,
+		broadcaster:           config.Broadcaster,
+		local:                 false,
+		verificationKeyByHash: nil,
+		apiToKeyAuthRow:       nil,
+		liveApiByID:           nil,
+		jwtConfigsByIssuer:    nil,
+		jwtIdentity:           nil,
+		workspaceLimits:       nil,
+		clickhouseSetting:     nil,
+		portalSession:         nil,
+		ratelimitNamespace:    nil,
+		ratelimitPlan:         nil,
+	}, nil
+}
+
+// KeyEntity identifies a key. The hash is required to invalidate the
+// verification cache, which is keyed by it.
+func KeyEntity(workspaceID, keyID, hash string) *cachev1.Entity {
+	return &cachev1.Entity{
+		Kind:        cachev1.EntityKind_ENTITY_KIND_KEY,
+		WorkspaceId: workspaceID,
+		Id:          keyID,
+		Aliases:     []string{hash},
+	}
+}
+
+// ApiEntity identifies an api. Pass the JWT issuers the api trusted before
+// and after the write, since verifications look its JWT config up by issuer.
+func ApiEntity(workspaceID, apiID string, issuers ...string) *cachev1.Entity {
+	return &cachev1.Entity{
+		Kind:        cachev1.EntityKind_ENTITY_KIND_API,
+		WorkspaceId: workspaceID,
+		Id:          apiID,
+		Aliases:     issuers,
+	}
+}
+
+// WorkspaceEntity identifies a workspace, including its limits and
+// analytics settings.
+func WorkspaceEntity(workspaceID string) *cachev1.Entity {
+	return &cachev1.Entity{
+		Kind:        cachev1.EntityKind_ENTITY_KIND_WORKSPACE,
+		WorkspaceId: workspaceID,
+		Id:          workspaceID,
+		Aliases:     nil,
+	}
+}
+
+// PortalSessionEntity identifies a portal session by the SHA-256 hash of its
+// access token, which is what the session cache is keyed by.
+func PortalSessionEntity(workspaceID, accessTokenHash string) *cachev1.Entity {
+	return &cachev1.Entity{
+		Kind:        cachev1.EntityKind_ENTITY_KIND_PORTAL_SESSION,
+		WorkspaceId: workspaceID,
+		Id:          accessTokenHash,
+		Aliases:     nil,
+	}
+}
+
+// RatelimitNamespaceEntity identifies a ratelimit namespace, which is cached
+// under both its ID and its name.
+func RatelimitNamespaceEntity(workspaceID, namespaceID, name string) *cachev1.Entity {
+	return &cachev1.Entity{
+		Kind:        cachev1.EntityKind_ENTITY_KIND_RATELIMIT_NAMESPACE,
+		WorkspaceId: workspaceID,
+		Id:          namespaceID,
+		Aliases:     []string{name},
+	}
+}
+
+// RatelimitPlanEntity identifies a ratelimit plan, which is cached under both
+// its ID and its name.
+func RatelimitPlanEntity(workspaceID, planID, name string) *cachev1.Entity {
+	return &cachev1.Entity{
+		Kind:        cachev1.EntityKind_ENTITY_KIND_RATELIMIT_PLAN,
+		WorkspaceId: workspaceID,
+		Id:          planID,
+		Aliases:     []string{name},
+	}
+}
+
+// IdentityEntity identifies an identity. Pass its external IDs before and
+// after the write, since JWT verifications look identities up by them.
+func IdentityEntity(workspaceID, identityID string, externalIDs ...string) *cachev1.Entity {
+	return &cachev1.Entity{
+		Kind:        cachev1.EntityKind_ENTITY_KIND_IDENTITY,
+		WorkspaceId: workspaceID,
+		Id:          identityID,
+		Aliases:     externalIDs,
+	}
+}
+
+// Invalidate removes everything cached for the given entities on this node
+// and broadcasts the invalidation to the other nodes. Call it after the
+// write that changed the entities has committed.
+//
+// Broadcast failures are logged, not returned: the write already happened
+// and remote caches converge once their fresh window passes.
+func (i *Invalidator) Invalidate(ctx context.Context, entities ...*cachev1.Entity) {
+	if len(entities) == 0 {
+		return
+	}
+
+	if i.local {
+		i.apply(ctx, entities)
+	}
+
+	if i.broadcaster == nil {
+		return
+	}
+
+	for start := 0; start < len(entities); start += maxEntitiesPerEvent {
+		end := min(start+maxEntitiesPerEvent, len(entities))
+		event := &cachev1.CacheInvalidationEvent{
+			CacheName:      "",
+			Timestamp:      i.clock.Now().UnixMilli(),
+			SourceInstance: i.nodeID,
+			Action: &cachev1.CacheInvalidationEvent_Entities{
+				Entities: &cachev1.EntityInvalidation{Entities: entities[start:end]},
+			},
+		}
+
+		if err := i.broadcaster.Broadcast(ctx, event); err != nil {
+			logger.Error("failed to broadcast cache invalidation",
+				"error", err.Error(),
+				"entities", end-start,
+			)
+		}
+	}
+}
+
+// HandleEvent applies an invalidation received from another node. It
+// returns false for events it ignored: its own, ones without entities, and
+// every event on an invalidator without caches.
+//
+// Events carry no version to order them by. Applying one twice or late is
+// harmless, and a stale refresh racing with the removal is already dropped by
+// [cache.Cache.Remove] on this node.
+func (i *Invalidator) HandleEvent(ctx context.Context, event *cachev1.CacheInvalidationEvent) bool {
+	if !i.local || event.GetSourceInstance() == i.nodeID {
+		return false
+	}
+
+	entities := event.GetEntities().GetEntities()
+	if len(entities) == 0 {
+		return false
+	}
+
+	i.apply(ctx, entities)
+	return true
+}
+
+// apply fans entities out to their dependent caches, removing all keys of
+// one cache in a single call.
+func (i *Invalidator) apply(ctx context.Context, entities []*cachev1.Entity) {
+	var (
+		hashes     []string
+		apis       []cache.ScopedKey
+		issuers    []cache.ScopedKey
+		workspaces []string
+		sessions   []string
+		namespaces []cache.ScopedKey
+		plans      []cache.ScopedKey
+		identities []cache.ScopedKey
+	)
+
+	for _, e := range entities {
+		switch e.GetKind() {
+		case cachev1.EntityKind_ENTITY_KIND_KEY:
+			for _, hash := range e.GetAliases() {
+				if hash != "" {
+					hashes = append(hashes, hash)
+				}
+			}
+		case cachev1.EntityKind_ENTITY_KIND_API:
+			apis = append(apis, cache.ScopedKey{WorkspaceID: e.GetWorkspaceId(), Key: e.GetId()})
+			for _, issuer := range e.GetAliases() {
+				issuers = append(issuers, cache.ScopedKey{WorkspaceID: e.GetWorkspaceId(), Key: issuer})
+			}
+		case cachev1.EntityKind_ENTITY_KIND_WORKSPACE:
+			workspaces = append(workspaces, e.GetWorkspaceId())
+		case cachev1.EntityKind_ENTITY_KIND_PORTAL_SESSION:
+			sessions = append(sessions, e.GetId())
+		case cachev1.EntityKind_ENTITY_KIND_RATELIMIT_NAMESPACE:
+			namespaces = append(namespaces, cache.ScopedKey{WorkspaceID: e.GetWorkspaceId(), Key: e.GetId()})
+			for _, name := range e.GetAliases() {
+				namespaces = append(namespaces, cache.ScopedKey{WorkspaceID: e.GetWorkspaceId(), Key: name})
+			}
+		case cachev1.EntityKind_ENTITY_KIND_RATELIMIT_PLAN:
+			plans = append(plans, cache.ScopedKey{WorkspaceID: e.GetWorkspaceId(), Key: e.GetId()})
+			for _, name := range e.GetAliases() {
+				plans = append(plans, cache.ScopedKey{WorkspaceID: e.GetWorkspaceId(), Key: name})
+			}
+		case cachev1.EntityKind_ENTITY_KIND_IDENTITY:
+			for _, externalID := range e.GetAliases() {
+				if externalID != "" {
+					identities = append(identities, cache.ScopedKey{WorkspaceID: e.GetWorkspaceId(), Key: externalID})
+				}
+			}
+		case cachev1.EntityKind_ENTITY_KIND_UNSPECIFIED:
+			logger.Warn("ignoring cache invalidation without entity kind", "id", e.GetId())
+		}
+	}
+
+	if len(hashes) > 0 {
+		i.verificationKeyByHash.Remove(ctx, hashes...)
+	}
+	if len(apis) > 0 {
+		i.apiToKeyAuthRow.Remove(ctx, apis...)
+		i.liveApiByID.Remove(ctx, apis...)
+	}
+	if len(issuers) > 0 {
+		i.jwtConfigsByIssuer.Remove(ctx, issuers...)
+	}
+	if len(workspaces) > 0 {
+		i.workspaceLimits.Remove(ctx, workspaces...)
+		i.clickhouseSetting.Remove(ctx, workspaces...)
+	}
+	if len(sessions) > 0 {
+		i.portalSession.Remove(ctx, sessions...)
+	}
+	if len(namespaces) > 0 {
+		i.ratelimitNamespace.Remove(ctx, namespaces...)
+	}
+	if len(plans) > 0 {
+		i.ratelimitPlan.Remove(ctx, plans...)
+	}
+	if len(identities) > 0 {
+		i.jwtIdentity.Remove(ctx, identities...)
+	}
+}
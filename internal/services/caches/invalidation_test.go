@@ -0,0 +1,191 @@
+package caches
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	cachev1 "github.com/unkeyed/unkey/gen/proto/cache/v1"
+	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/clock"
+	"github.com/unkeyed/unkey/pkg/db"
+)
+
+type recordingBroadcaster struct {
+	mu     sync.Mutex
+	events []*cachev1.CacheInvalidationEvent
+}
+
+func (b *recordingBroadcaster) Broadcast(_ context.Context, event *cachev1.CacheInvalidationEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, event)
+	return nil
+}
+
+func newTestCaches(t *testing.T, nodeID string, broadcaster Broadcaster) Caches {
+	t.Helper()
+	c, err := New(Config{Clock: clock.NewTestClock(), NodeID: nodeID, Broadcaster: broadcaster})
+	require.NoError(t, err)
+	return c
+}
+
+// populate caches one entry for every entity kind of workspace ws.
+func populate(ctx context.Context, c Caches, ws string) {
+	c.VerificationKeyByHash.Set(ctx, "hash_1", keysdb.CachedKeyData{})
+	c.ApiToKeyAuthRow.Set(ctx, cache.ScopedKey{WorkspaceID: ws, Key: "api_1"}, db.FindKeyAuthsByIdsRow{})
+	c.LiveApiByID.Set(ctx, cache.ScopedKey{WorkspaceID: ws, Key: "api_1"}, db.FindLiveApiByIDRow{})
+	c.JwtConfigsByIssuer.Set(ctx, cache.ScopedKey{WorkspaceID: ws, Key: "https://auth.example.com"}, []keysdb.FindJwtConfigsByIssuerRow{})
+	c.WorkspaceLimits.Set(ctx, ws, keysdb.Limit{})
+	c.ClickhouseSetting.Set(ctx, ws, db.FindClickhouseWorkspaceSettingsByWorkspaceIDRow{})
+	c.PortalSession.Set(ctx, "session_1", db.PortalSession{})
+	c.RatelimitNamespace.Set(ctx, cache.ScopedKey{WorkspaceID: ws, Key: "ns_1"}, db.FindRatelimitNamespace{})
+	c.RatelimitNamespace.Set(ctx, cache.ScopedKey{WorkspaceID: ws, Key: "email"}, db.FindRatelimitNamespace{})
+	c.RatelimitPlan.Set(ctx, cache.ScopedKey{WorkspaceID: ws, Key: "plan_1"}, db.FindRatelimitPlan{})
+	c.RatelimitPlan.Set(ctx, cache.ScopedKey{WorkspaceID: ws, Key: "pro"}, db.FindRatelimitPlan{})
+	c.JwtIdentityByExternalID.Set(ctx, cache.ScopedKey{WorkspaceID: ws, Key: "user_1"}, keysdb.CachedJwtIdentity{})
+}
+
+func requireEvicted(t *testing.T, ctx context.Context, c Caches, ws string) {
+	t.Helper()
+	_, hit := c.VerificationKeyByHash.Get(ctx, "hash_1")
+	require.Equal(t, cache.Miss, hit, "verification key")
+	_, hit = c.ApiToKeyAuthRow.Get(ctx, cache.ScopedKey{WorkspaceID: ws, Key: "api_1"})
+	require.Equal(t, cache.Miss, hit, "api to key auth")
+	_, hit = c.LiveApiByID.Get(ctx, cache.ScopedKey{WorkspaceID: ws, Key: "api_1"})
+	require.Equal(t, cache.Miss, hit, "live api")
+	_, hit = c.JwtConfigsByIssuer.Get(ctx, cache.ScopedKey{WorkspaceID: ws, Key: "https://auth.example.com"})
+	require.Equal(t, cache.Miss, hit, "jwt configs by issuer")
+	_, hit = c.WorkspaceLimits.Get(ctx, ws)
+	require.Equal(t, cache.Miss, hit, "workspace limits")
+	_, hit = c.ClickhouseSetting.Get(ctx, ws)
+	require.Equal(t, cache.Miss, hit, "clickhouse setting")
+	_, hit = c.PortalSession.Get(ctx, "session_1")
+	require.Equal(t, cache.Miss, hit, "portal session")
+	_, hit = c.RatelimitNamespace.Get(ctx, cache.ScopedKey{WorkspaceID: ws, Key: "ns_1"})
+	require.Equal(t, cache.Miss, hit, "namespace by id")
+	_, hit = c.RatelimitNamespace.Get(ctx, cache.ScopedKey{WorkspaceID: ws, Key: "email"})
+	require.Equal(t, cache.Miss, hit, "namespace by name")
+	_, hit = c.RatelimitPlan.Get(ctx, cache.ScopedKey{WorkspaceID: ws, Key: "plan_1"})
+	require.Equal(t, cache.Miss, hit, "plan by id")
+	_, hit = c.RatelimitPlan.Get(ctx, cache.ScopedKey{WorkspaceID: ws, Key: "pro"})
+	require.Equal(t, cache.Miss, hit, "plan by name")
+	_, hit = c.JwtIdentityByExternalID.Get(ctx, cache.ScopedKey{WorkspaceID: ws, Key: "user_1"})
+	require.Equal(t, cache.Miss, hit, "jwt identity")
+}
+
+func allEntities(ws string) []*cachev1.Entity {
+	return []*cachev1.Entity{
+		KeyEntity(ws, "key_1", "hash_1"),
+		ApiEntity(ws, "api_1", "https://auth.example.com"),
+		WorkspaceEntity(ws),
+		PortalSessionEntity(ws, "session_1"),
+		RatelimitNamespaceEntity(ws, "ns_1", "email"),
+		RatelimitPlanEntity(ws, "plan_1", "pro"),
+		IdentityEntity(ws, "id_1", "user_1"),
+	}
+}
+
+func TestInvalidate_FansOutToDependentCaches(t *testing.T) {
+	ctx := context.Background()
+	broadcaster := &recordingBroadcaster{}
+	c := newTestCaches(t, "node_a", broadcaster)
+
+	populate(ctx, c, "ws_1")
+	populate(ctx, c, "ws_2")
+	c.Invalidations.Invalidate(ctx, allEntities("ws_1")...)
+	requireEvicted(t, ctx, c, "ws_1")
+
+	// Other workspaces keep their entries.
+	_, hit := c.WorkspaceLimits.Get(ctx, "ws_2")
+	require.Equal(t, cache.Hit, hit)
+
+	require.Len(t, broadcaster.events, 1, "one write is one event")
+	event := broadcaster.events[0]
+	require.Equal(t, "node_a", event.GetSourceInstance())
+	require.Len(t, event.GetEntities().GetEntities(), 7)
+}
+
+func TestInvalidate_SplitsLargeBatches(t *testing.T) {
+	ctx := context.Background()
+	broadcaster := &recordingBroadcaster{}
+	c := newTestCaches(t, "node_a", broadcaster)
+
+	entities := make([]*cachev1.Entity, 0, 2*maxEntitiesPerEvent+1)
+	for range 2*maxEntitiesPerEvent + 1 {
+		entities = append(entities, ApiEntity("ws_1", "api_1"))
+	}
+	c.Invalidations.Invalidate(ctx, entities...)
+
+	require.Len(t, broadcaster.events, 3)
+	require.Len(t, broadcaster.events[0].GetEntities().GetEntities(), maxEntitiesPerEvent)
+	require.Len(t, broadcaster.events[1].GetEntities().GetEntities(), maxEntitiesPerEvent)
+	require.Len(t, broadcaster.events[2].GetEntities().GetEntities(), 1)
+}
+
+func TestHandleEvent(t *testing.T) {
+	ctx := context.Background()
+	broadcaster := &recordingBroadcaster{}
+	source := newTestCaches(t, "node_a", broadcaster)
+	receiver := newTestCaches(t, "node_b", nil)
+
+	source.Invalidations.Invalidate(ctx, allEntities("ws_1")...)
+	source.Invalidations.Invalidate(ctx, WorkspaceEntity("ws_2"))
+	first, second := broadcaster.events[0], broadcaster.events[1]
+
+	t.Run("applies events from other nodes", func(t *testing.T) {
+		populate(ctx, receiver, "ws_1")
+		require.True(t, receiver.Invalidations.HandleEvent(ctx, first))
+		requireEvicted(t, ctx, receiver, "ws_1")
+	})
+
+	t.Run("applies redelivered events", func(t *testing.T) {
+		populate(ctx, receiver, "ws_1")
+		require.True(t, receiver.Invalidations.HandleEvent(ctx, first))
+		requireEvicted(t, ctx, receiver, "ws_1")
+	})
+
+	t.Run("applies events that arrive out of order", func(t *testing.T) {
+		populate(ctx, receiver, "ws_1")
+		populate(ctx, receiver, "ws_2")
+		require.True(t, receiver.Invalidations.HandleEvent(ctx, second))
+		require.True(t, receiver.Invalidations.HandleEvent(ctx, first))
+
+		requireEvicted(t, ctx, receiver, "ws_1")
+		_, hit := receiver.WorkspaceLimits.Get(ctx, "ws_2")
+		require.Equal(t, cache.Miss, hit)
+	})
+
+	t.Run("ignores its own events", func(t *testing.T) {
+		require.False(t, source.Invalidations.HandleEvent(ctx, first))
+	})
+}
+
+func TestRemoteInvalidator(t *testing.T) {
+	ctx := context.Background()
+	broadcaster := &recordingBroadcaster{}
+	remote, err := NewRemoteInvalidator(RemoteInvalidatorConfig{
+		Clock:       clock.NewTestClock(),
+		NodeID:      "worker",
+		Broadcaster: broadcaster,
+	})
+	require.NoError(t, err)
+
+	remote.Invalidate(ctx, KeyEntity("ws_1", "key_1", "hash_1"))
+	require.Len(t, broadcaster.events, 1)
+	require.Equal(t, "worker", broadcaster.events[0].GetSourceInstance())
+
+	// The API nodes apply what the worker broadcasts.
+	node := newTestCaches(t, "node_a", nil)
+	populate(ctx, node, "ws_1")
+	require.True(t, node.Invalidations.HandleEvent(ctx, broadcaster.events[0]))
+	_, hit := node.VerificationKeyByHash.Get(ctx, "hash_1")
+	require.Equal(t, cache.Miss, hit)
+
+	require.False(t, remote.HandleEvent(ctx, broadcaster.events[0]))
+
+	_, err = NewRemoteInvalidator(RemoteInvalidatorConfig{Clock: clock.NewTestClock(), NodeID: "worker", Broadcaster: nil})
+	require.Error(t, err)
+}
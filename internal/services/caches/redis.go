@@ -0,0 +1,113 @@
+package caches
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	cachev1 "github.com/unkeyed/unkey/gen/proto/cache/v1"
+	"github.com/unkeyed/unkey/pkg/assert"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultInvalidationChannel is the Redis pub/sub channel invalidation
+// events are published on unless [RedisBroadcasterConfig.Channel] is set.
+const DefaultInvalidationChannel = "unkey:cache:invalidations"
+
+// RedisBroadcasterConfig configures a [RedisBroadcaster].
+type RedisBroadcasterConfig struct {
+	// RedisURL is the connection URL for Redis.
+	// Format: redis://[[username][:password]@][host][:port][/database]
+	RedisURL string
+
+	// Channel is the pub/sub channel shared by all nodes of a cluster.
+	// Defaults to [DefaultInvalidationChannel].
+	Channel string
+}
+
+// RedisBroadcaster delivers invalidation events between nodes over Redis
+// pub/sub. Every node publishes to and listens on the same channel; events
+// a node receives from itself are ignored by [Invalidator.HandleEvent].
+//
+// Pub/sub is at-most-once: events published while a node is disconnected
+// are lost to it, and its entries converge once their fresh window passes.
+type RedisBroadcaster struct {
+	client  *redis.Client
+	channel string
+}
+
+var _ Broadcaster = (*RedisBroadcaster)(nil)
+
+// NewRedisBroadcaster creates a broadcaster with its own Redis connection.
+// It does not connect until the first publish or [RedisBroadcaster.Listen].
+func NewRedisBroadcaster(config RedisBroadcasterConfig) (*RedisBroadcaster, error) {
+	err := assert.All(
+		assert.NotEmpty(config.RedisURL, "Redis URL must not be empty"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := redis.ParseURL(config.RedisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis url: %w", err)
+	}
+
+	channel := config.Channel
+	if channel == "" {
+		channel = DefaultInvalidationChannel
+	}
+
+	return &RedisBroadcaster{
+		client:  redis.NewClient(opts),
+		channel: channel,
+	}, nil
+}
+
+// Broadcast publishes the event to every node listening on the channel.
+func (b *RedisBroadcaster) Broadcast(ctx context.Context, event *cachev1.CacheInvalidationEvent) error {
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache invalidation event: %w", err)
+	}
+
+	return b.client.Publish(ctx, b.channel, payload).Err()
+}
+
+// Listen passes every event published on the channel to handle, usually
+// [Invalidator.HandleEvent], until ctx is cancelled. The subscription
+// reconnects on its own when Redis goes away.
+func (b *RedisBroadcaster) Listen(ctx context.Context, handle func(context.Context, *cachev1.CacheInvalidationEvent) bool) error {
+	sub := b.client.Subscribe(ctx, b.channel)
+	defer func() {
+		if err := sub.Close(); err != nil {
+			logger.Warn("failed to close cache invalidation subscription", "error", err.Error())
+		}
+	}()
+
+	messages := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			event := &cachev1.CacheInvalidationEvent{}
+			if err := proto.Unmarshal([]byte(msg.Payload), event); err != nil {
+				logger.Warn("dropping malformed cache invalidation event", "error", err.Error())
+				continue
+			}
+
+			handle(ctx, event)
+		}
+	}
+}
+
+// Close closes the Redis connection.
+func (b *RedisBroadcaster) Close() error {
+	return b.client.Close()
+}
@@ -0,0 +1,65 @@
+package caches
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/testutil/containers"
+	"github.com/unkeyed/unkey/pkg/uid"
+)
+
+// newRedisNode starts a node whose invalidations travel over Redis and
+// which listens for the invalidations of its peers on the same channel.
+func newRedisNode(t *testing.T, ctx context.Context, redisURL, channel, nodeID string) Caches {
+	t.Helper()
+
+	broadcaster, err := NewRedisBroadcaster(RedisBroadcasterConfig{RedisURL: redisURL, Channel: channel})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, broadcaster.Close()) })
+
+	c := newTestCaches(t, nodeID, broadcaster)
+
+	done := make(chan error, 1)
+	go func() { done <- broadcaster.Listen(ctx, c.Invalidations.HandleEvent) }()
+	t.Cleanup(func() { require.NoError(t, <-done) })
+
+	return c
+}
+
+func TestRedisBroadcaster_InvalidatesAcrossNodes(t *testing.T) {
+	redisURL := containers.Redis(t)
+	channel := uid.New(uid.TestPrefix)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	nodeA := newRedisNode(t, ctx, redisURL, channel, "node_a")
+	nodeB := newRedisNode(t, ctx, redisURL, channel, "node_b")
+
+	// Pub/sub drops events published before a subscription is established,
+	// so keep writing until node B has seen one. Invalidations are
+	// idempotent, which is what makes retrying them safe.
+	require.Eventually(t, func() bool {
+		populate(ctx, nodeB, "ws_1")
+		nodeA.Invalidations.Invalidate(ctx, allEntities("ws_1")...)
+
+		time.Sleep(50 * time.Millisecond)
+		_, hit := nodeB.VerificationKeyByHash.Get(ctx, "hash_1")
+		return hit == cache.Miss
+	}, 10*time.Second, 100*time.Millisecond)
+
+	requireEvicted(t, ctx, nodeB, "ws_1")
+
+	t.Run("in both directions", func(t *testing.T) {
+		populate(ctx, nodeA, "ws_2")
+		nodeB.Invalidations.Invalidate(ctx, WorkspaceEntity("ws_2"))
+
+		require.Eventually(t, func() bool {
+			_, hit := nodeA.WorkspaceLimits.Get(ctx, "ws_2")
+			return hit == cache.Miss
+		}, 5*time.Second, 20*time.Millisecond)
+	})
+}
@@ -12,7 +12,14 @@ import (
 	"github.com/unkeyed/unkey/pkg/hash"
 )
 
-func (s *service) CreateKey(ctx context.Context, req CreateKeyRequest) (CreateKeyResponse, error) {
+func (s *service) CreateKey(_ context.Context, req CreateKeyRequest) (CreateKeyResponse, error) {
+	return Generate(req)
+}
+
+// Generate creates a new random key in the format every Unkey key shares,
+// without touching the database. CreateKey is a thin wrapper around it; the
+// ctrl worker calls it directly to issue rotated keys.
+func Generate(req CreateKeyRequest) (CreateKeyResponse, error) {
 	// Validate input parameters
 	err := assert.InRange(req.ByteLength, 16, 255, "byte length must be between 16 and 255")
 	if err != nil {
@@ -0,0 +1,8 @@
+package db
+
+// CachedJwtIdentity embeds FindIdentityForJwtVerificationRow and adds the
+// identity's rate limits decoded once, so cache hits skip the JSON parsing.
+type CachedJwtIdentity struct {
+	FindIdentityForJwtVerificationRow
+	RatelimitConfigs map[string]KeyFindForVerificationRatelimit
+}
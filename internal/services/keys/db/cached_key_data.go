@@ -1,5 +1,7 @@
 package db
 
+import "github.com/unkeyed/unkey/pkg/rbac"
+
 // CachedKeyData embeds FindKeyForVerificationRow and adds pre-processed data for caching.
 // This struct is stored in the cache to avoid redundant parsing operations.
 type CachedKeyData struct {
@@ -7,5 +9,6 @@ type CachedKeyData struct {
 	ParsedIPWhitelist map[string]struct{} // Pre-parsed IP addresses for O(1) lookup
 	Roles             []string
 	Permissions       []string
+	Grants            []rbac.Grant // Permissions with their conditions, for conditional RBAC
 	RatelimitConfigs  map[string]KeyFindForVerificationRatelimit
 }
@@ -0,0 +1,102 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: identity_find_for_jwt_verification.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const findIdentityForJwtVerification = `-- name: FindIdentityForJwtVerification :one
+SELECT i.id,
+       i.external_id,
+       i.meta,
+       coalesce(
+               (select json_arrayagg(
+                    json_object(
+                       'id', rl.id,
+                       'name', rl.name,
+                       'key_id', rl.key_id,
+                       'identity_id', rl.identity_id,
+                       'limit', rl.` + "`" + `limit` + "`" + `,
+                       'duration', rl.duration,
+                       'auto_apply', rl.auto_apply
+                    )
+                )
+                from ` + "`" + `ratelimits` + "`" + ` rl
+                where rl.identity_id = i.id),
+               json_array()
+       ) as ratelimits,
+       ic.remaining     as identity_remaining_credits,
+       ic.refill_day    as identity_refill_day,
+       ic.refill_amount as identity_refill_amount
+FROM identities i
+         LEFT JOIN identity_credits ic ON ic.identity_id = i.id
+WHERE i.workspace_id = ?
+  AND i.external_id = ?
+  AND i.deleted = 0
+`
+
+type FindIdentityForJwtVerificationParams struct {
+	WorkspaceID string `db:"workspace_id"`
+	ExternalID  string `db:"external_id"`
+}
+
+type FindIdentityForJwtVerificationRow struct {
+	ID                       string        `db:"id"`
+	ExternalID               string        `db:"external_id"`
+	Meta                     []byte        `db:"meta"`
+	Ratelimits               interface{}   `db:"ratelimits"`
+	IdentityRemainingCredits sql.NullInt64 `db:"identity_remaining_credits"`
+	IdentityRefillDay        sql.NullInt16 `db:"identity_refill_day"`
+	IdentityRefillAmount     sql.NullInt64 `db:"identity_refill_amount"`
+}
+
+// FindIdentityForJwtVerification loads the identity a verified JWT maps to
+// by its external id, together with the identity's rate limits in the same
+// JSON shape FindKeyForVerification returns them in, and its credit pool.
+//
+//	SELECT i.id,
+//	       i.external_id,
+//	       i.meta,
+//	       coalesce(
+//	               (select json_arrayagg(
+//	                    json_object(
+//	                       'id', rl.id,
+//	                       'name', rl.name,
+//	                       'key_id', rl.key_id,
+//	                       'identity_id', rl.identity_id,
+//	                       'limit', rl.`limit`,
+//	                       'duration', rl.duration,
+//	                       'auto_apply', rl.auto_apply
+//	                    )
+//	                )
+//	                from `ratelimits` rl
+//	                where rl.identity_id = i.id),
+//	               json_array()
+//	       ) as ratelimits,
+//	       ic.remaining     as identity_remaining_credits,
+//	       ic.refill_day    as identity_refill_day,
+//	       ic.refill_amount as identity_refill_amount
+//	FROM identities i
+//	         LEFT JOIN identity_credits ic ON ic.identity_id = i.id
+//	WHERE i.workspace_id = ?
+//	  AND i.external_id = ?
+//	  AND i.deleted = 0
+func (q *Queries) FindIdentityForJwtVerification(ctx context.Context, db DBTX, arg FindIdentityForJwtVerificationParams) (FindIdentityForJwtVerificationRow, error) {
+	row := db.QueryRowContext(ctx, findIdentityForJwtVerification, arg.WorkspaceID, arg.ExternalID)
+	var i FindIdentityForJwtVerificationRow
+	err := row.Scan(
+		&i.ID,
+		&i.ExternalID,
+		&i.Meta,
+		&i.Ratelimits,
+		&i.IdentityRemainingCredits,
+		&i.IdentityRefillDay,
+		&i.IdentityRefillAmount,
+	)
+	return i, err
+}
@@ -0,0 +1,113 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: jwt_config_find_by_issuer.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const findJwtConfigsByIssuer = `-- name: FindJwtConfigsByIssuer :many
+SELECT c.api_id,
+       c.issuer,
+       c.audience,
+       c.jwks_url,
+       c.public_key,
+       c.identity_claim,
+       c.permissions_claim,
+       c.created_at_m,
+       c.updated_at_m,
+       a.key_auth_id,
+       a.ip_whitelist,
+       ws.enabled as workspace_enabled
+FROM api_jwt_configs c
+         JOIN apis a ON a.id = c.api_id
+         JOIN workspaces ws ON ws.id = c.workspace_id
+WHERE c.workspace_id = ?
+  AND c.issuer = ?
+  AND a.auth_type = 'jwt'
+  AND a.deleted_at_m IS NULL
+`
+
+type FindJwtConfigsByIssuerParams struct {
+	WorkspaceID string `db:"workspace_id"`
+	Issuer      string `db:"issuer"`
+}
+
+type FindJwtConfigsByIssuerRow struct {
+	ApiID            string         `db:"api_id"`
+	Issuer           string         `db:"issuer"`
+	Audience         sql.NullString `db:"audience"`
+	JwksUrl          sql.NullString `db:"jwks_url"`
+	PublicKey        sql.NullString `db:"public_key"`
+	IdentityClaim    string         `db:"identity_claim"`
+	PermissionsClaim sql.NullString `db:"permissions_claim"`
+	CreatedAtM       int64          `db:"created_at_m"`
+	UpdatedAtM       sql.NullInt64  `db:"updated_at_m"`
+	KeyAuthID        sql.NullString `db:"key_auth_id"`
+	IpWhitelist      sql.NullString `db:"ip_whitelist"`
+	WorkspaceEnabled bool           `db:"workspace_enabled"`
+}
+
+// FindJwtConfigsByIssuer returns the JWT config of every live api in the
+// workspace that accepts tokens from the issuer, together with the keyspace,
+// IP whitelist and workspace state a JWT verification needs. Several apis may
+// trust the same issuer; the caller tries each until one accepts the token.
+//
+//	SELECT c.api_id,
+//	       c.issuer,
+//	       c.audience,
+//	       c.jwks_url,
+//	       c.public_key,
+//	       c.identity_claim,
+//	       c.permissions_claim,
+//	       c.created_at_m,
+//	       c.updated_at_m,
+//	       a.key_auth_id,
+//	       a.ip_whitelist,
+//	       ws.enabled as workspace_enabled
+//	FROM api_jwt_configs c
+//	         JOIN apis a ON a.id = c.api_id
+//	         JOIN workspaces ws ON ws.id = c.workspace_id
+//	WHERE c.workspace_id = ?
+//	  AND c.issuer = ?
+//	  AND a.auth_type = 'jwt'
+//	  AND a.deleted_at_m IS NULL
+func (q *Queries) FindJwtConfigsByIssuer(ctx context.Context, db DBTX, arg FindJwtConfigsByIssuerParams) ([]FindJwtConfigsByIssuerRow, error) {
+	rows, err := db.QueryContext(ctx, findJwtConfigsByIssuer, arg.WorkspaceID, arg.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindJwtConfigsByIssuerRow
+	for rows.Next() {
+		var i FindJwtConfigsByIssuerRow
+		if err := rows.Scan(
+			&i.ApiID,
+			&i.Issuer,
+			&i.Audience,
+			&i.JwksUrl,
+			&i.PublicKey,
+			&i.IdentityClaim,
+			&i.PermissionsClaim,
+			&i.CreatedAtM,
+			&i.UpdatedAtM,
+			&i.KeyAuthID,
+			&i.IpWhitelist,
+			&i.WorkspaceEnabled,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
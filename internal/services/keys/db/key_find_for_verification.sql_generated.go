@@ -25,6 +25,8 @@ select k.id,
        k.enabled,
        k.remaining_requests,
        k.pending_migration_id,
+       k.environment,
+       kse.test_mode   as environment_test_mode,
        a.ip_whitelist,
        a.workspace_id  as api_workspace_id,
        a.id            as api_id,
@@ -56,6 +58,25 @@ select k.id,
                JSON_ARRAY()
        )               as permissions,
 
+       COALESCE(
+               (SELECT JSON_OBJECTAGG(slug, conditions)
+                FROM (SELECT slug, conditions
+                      FROM keys_permissions kp
+                               JOIN permissions p ON kp.permission_id = p.id
+                      WHERE kp.key_id = k.id
+                        AND p.conditions IS NOT NULL
+
+                      UNION ALL
+
+                      SELECT slug, conditions
+                      FROM keys_roles kr
+                               JOIN roles_permissions rp ON kr.role_id = rp.role_id
+                               JOIN permissions p ON rp.permission_id = p.id
+                      WHERE kr.key_id = k.id
+                        AND p.conditions IS NOT NULL) as conditional_perms),
+               JSON_OBJECT()
+       )               as permission_conditions,
+
        coalesce(
                (select json_arrayagg(
                     json_object(
@@ -83,6 +104,9 @@ select k.id,
        i.id as identity_id,
        i.external_id,
        i.meta          as identity_meta,
+       ic.remaining     as identity_remaining_credits,
+       ic.refill_day    as identity_refill_day,
+       ic.refill_amount as identity_refill_amount,
        ka.deleted_at_m as key_auth_deleted_at_m,
        ws.enabled      as workspace_enabled,
        fws.enabled     as for_workspace_enabled
@@ -92,46 +116,59 @@ from ` + "`" + `keys` + "`" + ` k
          JOIN workspaces ws ON ws.id = k.workspace_id
          LEFT JOIN workspaces fws ON fws.id = k.for_workspace_id
          LEFT JOIN identities i ON i.id = k.identity_id AND i.deleted = 0
+         LEFT JOIN identity_credits ic ON ic.identity_id = i.id
+         LEFT JOIN key_space_environments kse
+                   ON kse.key_auth_id = k.key_auth_id AND kse.name = k.environment
 where k.hash = ?
   and k.deleted_at_m is null
 `
 
 type FindKeyForVerificationRow struct {
-	ID                  string         `db:"id"`
-	KeyAuthID           string         `db:"key_auth_id"`
-	WorkspaceID         string         `db:"workspace_id"`
-	ForWorkspaceID      sql.NullString `db:"for_workspace_id"`
-	Name                sql.NullString `db:"name"`
-	Meta                sql.NullString `db:"meta"`
-	Expires             sql.NullTime   `db:"expires"`
-	DeletedAtM          sql.NullInt64  `db:"deleted_at_m"`
-	RefillDay           sql.NullInt16  `db:"refill_day"`
-	RefillAmount        sql.NullInt64  `db:"refill_amount"`
-	LastRefillAt        sql.NullTime   `db:"last_refill_at"`
-	Enabled             bool           `db:"enabled"`
-	RemainingRequests   sql.NullInt64  `db:"remaining_requests"`
-	PendingMigrationID  sql.NullString `db:"pending_migration_id"`
-	IpWhitelist         sql.NullString `db:"ip_whitelist"`
-	ApiWorkspaceID      string         `db:"api_workspace_id"`
-	ApiID               string         `db:"api_id"`
-	ApiDeletedAtM       sql.NullInt64  `db:"api_deleted_at_m"`
-	Roles               interface{}    `db:"roles"`
-	Permissions         interface{}    `db:"permissions"`
-	Ratelimits          interface{}    `db:"ratelimits"`
-	IdentityID          sql.NullString `db:"identity_id"`
-	ExternalID          sql.NullString `db:"external_id"`
-	IdentityMeta        []byte         `db:"identity_meta"`
-	KeyAuthDeletedAtM   sql.NullInt64  `db:"key_auth_deleted_at_m"`
-	WorkspaceEnabled    bool           `db:"workspace_enabled"`
-	ForWorkspaceEnabled sql.NullBool   `db:"for_workspace_enabled"`
+	ID                       string         `db:"id"`
+	KeyAuthID                string         `db:"key_auth_id"`
+	WorkspaceID              string         `db:"workspace_id"`
+	ForWorkspaceID           sql.NullString `db:"for_workspace_id"`
+	Name                     sql.NullString `db:"name"`
+	Meta                     sql.NullString `db:"meta"`
+	Expires                  sql.NullTime   `db:"expires"`
+	DeletedAtM               sql.NullInt64  `db:"deleted_at_m"`
+	RefillDay                sql.NullInt16  `db:"refill_day"`
+	RefillAmount             sql.NullInt64  `db:"refill_amount"`
+	LastRefillAt             sql.NullTime   `db:"last_refill_at"`
+	Enabled                  bool           `db:"enabled"`
+	RemainingRequests        sql.NullInt64  `db:"remaining_requests"`
+	PendingMigrationID       sql.NullString `db:"pending_migration_id"`
+	Environment              sql.NullString `db:"environment"`
+	EnvironmentTestMode      sql.NullBool   `db:"environment_test_mode"`
+	IpWhitelist              sql.NullString `db:"ip_whitelist"`
+	ApiWorkspaceID           string         `db:"api_workspace_id"`
+	ApiID                    string         `db:"api_id"`
+	ApiDeletedAtM            sql.NullInt64  `db:"api_deleted_at_m"`
+	Roles                    interface{}    `db:"roles"`
+	Permissions              interface{}    `db:"permissions"`
+	PermissionConditions     interface{}    `db:"permission_conditions"`
+	Ratelimits               interface{}    `db:"ratelimits"`
+	IdentityID               sql.NullString `db:"identity_id"`
+	ExternalID               sql.NullString `db:"external_id"`
+	IdentityMeta             []byte         `db:"identity_meta"`
+	IdentityRemainingCredits sql.NullInt64  `db:"identity_remaining_credits"`
+	IdentityRefillDay        sql.NullInt16  `db:"identity_refill_day"`
+	IdentityRefillAmount     sql.NullInt64  `db:"identity_refill_amount"`
+	KeyAuthDeletedAtM        sql.NullInt64  `db:"key_auth_deleted_at_m"`
+	WorkspaceEnabled         bool           `db:"workspace_enabled"`
+	ForWorkspaceEnabled      sql.NullBool   `db:"for_workspace_enabled"`
 }
 
 // FindKeyForVerification loads a key by its SHA-256 hash together with its
 // workspace status, RBAC roles and permissions, identity, and rate limit
 // configuration in a single round trip. Roles, permissions, and rate limits
 // are returned as JSON arrays via JSON_ARRAYAGG so the caller can unmarshal
-// them into typed Go structs. Key-level and identity-level rate limits are
-// unioned so that both sources are available for the verification pipeline.
+// them into typed Go structs. Conditions of conditional permissions are
+// returned as a JSON object keyed by permission slug. Key-level and
+// identity-level rate limits are unioned so that both sources are available
+// for the verification pipeline. The identity's credit pool, if any, comes
+// along so keys can draw from it, and so does the key's environment together
+// with whether the keyspace runs that environment in test mode.
 //
 //	select k.id,
 //	       k.key_auth_id,
@@ -147,6 +184,8 @@ type FindKeyForVerificationRow struct {
 //	       k.enabled,
 //	       k.remaining_requests,
 //	       k.pending_migration_id,
+//	       k.environment,
+//	       kse.test_mode   as environment_test_mode,
 //	       a.ip_whitelist,
 //	       a.workspace_id  as api_workspace_id,
 //	       a.id            as api_id,
@@ -178,6 +217,25 @@ type FindKeyForVerificationRow struct {
 //	               JSON_ARRAY()
 //	       )               as permissions,
 //
+//	       COALESCE(
+//	               (SELECT JSON_OBJECTAGG(slug, conditions)
+//	                FROM (SELECT slug, conditions
+//	                      FROM keys_permissions kp
+//	                               JOIN permissions p ON kp.permission_id = p.id
+//	                      WHERE kp.key_id = k.id
+//	                        AND p.conditions IS NOT NULL
+//
+//	                      UNION ALL
+//
+//	                      SELECT slug, conditions
+//	                      FROM keys_roles kr
+//	                               JOIN roles_permissions rp ON kr.role_id = rp.role_id
+//	                               JOIN permissions p ON rp.permission_id = p.id
+//	                      WHERE kr.key_id = k.id
+//	                        AND p.conditions IS NOT NULL) as conditional_perms),
+//	               JSON_OBJECT()
+//	       )               as permission_conditions,
+//
 //	       coalesce(
 //	               (select json_arrayagg(
 //	                    json_object(
@@ -205,6 +263,9 @@ type FindKeyForVerificationRow struct {
 //	       i.id as identity_id,
 //	       i.external_id,
 //	       i.meta          as identity_meta,
+//	       ic.remaining     as identity_remaining_credits,
+//	       ic.refill_day    as identity_refill_day,
+//	       ic.refill_amount as identity_refill_amount,
 //	       ka.deleted_at_m as key_auth_deleted_at_m,
 //	       ws.enabled      as workspace_enabled,
 //	       fws.enabled     as for_workspace_enabled
@@ -214,6 +275,9 @@ type FindKeyForVerificationRow struct {
 //	         JOIN workspaces ws ON ws.id = k.workspace_id
 //	         LEFT JOIN workspaces fws ON fws.id = k.for_workspace_id
 //	         LEFT JOIN identities i ON i.id = k.identity_id AND i.deleted = 0
+//	         LEFT JOIN identity_credits ic ON ic.identity_id = i.id
+//	         LEFT JOIN key_space_environments kse
+//	                   ON kse.key_auth_id = k.key_auth_id AND kse.name = k.environment
 //	where k.hash = ?
 //	  and k.deleted_at_m is null
 func (q *Queries) FindKeyForVerification(ctx context.Context, db DBTX, hash string) (FindKeyForVerificationRow, error) {
@@ -234,16 +298,22 @@ func (q *Queries) FindKeyForVerification(ctx context.Context, db DBTX, hash stri
 		&i.Enabled,
 		&i.RemainingRequests,
 		&i.PendingMigrationID,
+		&i.Environment,
+		&i.EnvironmentTestMode,
 		&i.IpWhitelist,
 		&i.ApiWorkspaceID,
 		&i.ApiID,
 		&i.ApiDeletedAtM,
 		&i.Roles,
 		&i.Permissions,
+		&i.PermissionConditions,
 		&i.Ratelimits,
 		&i.IdentityID,
 		&i.ExternalID,
 		&i.IdentityMeta,
+		&i.IdentityRemainingCredits,
+		&i.IdentityRefillDay,
+		&i.IdentityRefillAmount,
 		&i.KeyAuthDeletedAtM,
 		&i.WorkspaceEnabled,
 		&i.ForWorkspaceEnabled,
@@ -123,6 +123,18 @@ type Identity struct {
 	UpdatedAt   sql.NullInt64 `db:"updated_at"`
 }
 
+type IdentityCredit struct {
+	Pk           uint64        `db:"pk"`
+	IdentityID   string        `db:"identity_id"`
+	WorkspaceID  string        `db:"workspace_id"`
+	Remaining    uint64        `db:"remaining"`
+	RefillDay    sql.NullInt16 `db:"refill_day"`
+	RefillAmount sql.NullInt64 `db:"refill_amount"`
+	LastRefillAt sql.NullTime  `db:"last_refill_at"`
+	CreatedAtM   int64         `db:"created_at_m"`
+	UpdatedAtM   sql.NullInt64 `db:"updated_at_m"`
+}
+
 type Key struct {
 	Pk                 uint64         `db:"pk"`
 	ID                 string         `db:"id"`
@@ -170,6 +182,17 @@ type KeyMigration struct {
 	Algorithm   KeyMigrationsAlgorithm `db:"algorithm"`
 }
 
+type KeySpaceEnvironment struct {
+	Pk          uint64         `db:"pk"`
+	WorkspaceID string         `db:"workspace_id"`
+	KeyAuthID   string         `db:"key_auth_id"`
+	Name        string         `db:"name"`
+	Prefix      sql.NullString `db:"prefix"`
+	TestMode    bool           `db:"test_mode"`
+	CreatedAtM  int64          `db:"created_at_m"`
+	UpdatedAtM  sql.NullInt64  `db:"updated_at_m"`
+}
+
 type KeysPermission struct {
 	Pk           uint64        `db:"pk"`
 	KeyID        string        `db:"key_id"`
@@ -215,6 +238,7 @@ type Permission struct {
 	Name        string         `db:"name"`
 	Slug        string         `db:"slug"`
 	Description sql.NullString `db:"description"`
+	Conditions  []byte         `db:"conditions"`
 	CreatedAtM  int64          `db:"created_at_m"`
 	UpdatedAtM  sql.NullInt64  `db:"updated_at_m"`
 }
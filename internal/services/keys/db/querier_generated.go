@@ -9,12 +9,73 @@ import (
 )
 
 type Querier interface {
+	// FindIdentityForJwtVerification loads the identity a verified JWT maps to
+	// by its external id, together with the identity's rate limits in the same
+	// JSON shape FindKeyForVerification returns them in, and its credit pool.
+	//
+	//  SELECT i.id,
+	//         i.external_id,
+	//         i.meta,
+	//         coalesce(
+	//                 (select json_arrayagg(
+	//                      json_object(
+	//                         'id', rl.id,
+	//                         'name', rl.name,
+	//                         'key_id', rl.key_id,
+	//                         'identity_id', rl.identity_id,
+	//                         'limit', rl.`limit`,
+	//                         'duration', rl.duration,
+	//                         'auto_apply', rl.auto_apply
+	//                      )
+	//                  )
+	//                  from `ratelimits` rl
+	//                  where rl.identity_id = i.id),
+	//                 json_array()
+	//         ) as ratelimits,
+	//         ic.remaining     as identity_remaining_credits,
+	//         ic.refill_day    as identity_refill_day,
+	//         ic.refill_amount as identity_refill_amount
+	//  FROM identities i
+	//           LEFT JOIN identity_credits ic ON ic.identity_id = i.id
+	//  WHERE i.workspace_id = ?
+	//    AND i.external_id = ?
+	//    AND i.deleted = 0
+	FindIdentityForJwtVerification(ctx context.Context, db DBTX, arg FindIdentityForJwtVerificationParams) (FindIdentityForJwtVerificationRow, error)
+	// FindJwtConfigsByIssuer returns the JWT config of every live api in the
+	// workspace that accepts tokens from the issuer, together with the keyspace,
+	// IP whitelist and workspace state a JWT verification needs. Several apis may
+	// trust the same issuer; the caller tries each until one accepts the token.
+	//
+	//  SELECT c.api_id,
+	//         c.issuer,
+	//         c.audience,
+	//         c.jwks_url,
+	//         c.public_key,
+	//         c.identity_claim,
+	//         c.permissions_claim,
+	//         c.created_at_m,
+	//         c.updated_at_m,
+	//         a.key_auth_id,
+	//         a.ip_whitelist,
+	//         ws.enabled as workspace_enabled
+	//  FROM api_jwt_configs c
+	//           JOIN apis a ON a.id = c.api_id
+	//           JOIN workspaces ws ON ws.id = c.workspace_id
+	//  WHERE c.workspace_id = ?
+	//    AND c.issuer = ?
+	//    AND a.auth_type = 'jwt'
+	//    AND a.deleted_at_m IS NULL
+	FindJwtConfigsByIssuer(ctx context.Context, db DBTX, arg FindJwtConfigsByIssuerParams) ([]FindJwtConfigsByIssuerRow, error)
 	// FindKeyForVerification loads a key by its SHA-256 hash together with its
 	// workspace status, RBAC roles and permissions, identity, and rate limit
 	// configuration in a single round trip. Roles, permissions, and rate limits
 	// are returned as JSON arrays via JSON_ARRAYAGG so the caller can unmarshal
-	// them into typed Go structs. Key-level and identity-level rate limits are
-	// unioned so that both sources are available for the verification pipeline.
+	// them into typed Go structs. Conditions of conditional permissions are
+	// returned as a JSON object keyed by permission slug. Key-level and
+	// identity-level rate limits are unioned so that both sources are available
+	// for the verification pipeline. The identity's credit pool, if any, comes
+	// along so keys can draw from it, and so does the key's environment together
+	// with whether the keyspace runs that environment in test mode.
 	//
 	//  select k.id,
 	//         k.key_auth_id,
@@ -30,6 +91,8 @@ type Querier interface {
 	//         k.enabled,
 	//         k.remaining_requests,
 	//         k.pending_migration_id,
+	//         k.environment,
+	//         kse.test_mode   as environment_test_mode,
 	//         a.ip_whitelist,
 	//         a.workspace_id  as api_workspace_id,
 	//         a.id            as api_id,
@@ -61,6 +124,25 @@ type Querier interface {
 	//                 JSON_ARRAY()
 	//         )               as permissions,
 	//
+	//         COALESCE(
+	//                 (SELECT JSON_OBJECTAGG(slug, conditions)
+	//                  FROM (SELECT slug, conditions
+	//                        FROM keys_permissions kp
+	//                                 JOIN permissions p ON kp.permission_id = p.id
+	//                        WHERE kp.key_id = k.id
+	//                          AND p.conditions IS NOT NULL
+	//
+	//                        UNION ALL
+	//
+	//                        SELECT slug, conditions
+	//                        FROM keys_roles kr
+	//                                 JOIN roles_permissions rp ON kr.role_id = rp.role_id
+	//                                 JOIN permissions p ON rp.permission_id = p.id
+	//                        WHERE kr.key_id = k.id
+	//                          AND p.conditions IS NOT NULL) as conditional_perms),
+	//                 JSON_OBJECT()
+	//         )               as permission_conditions,
+	//
 	//         coalesce(
 	//                 (select json_arrayagg(
 	//                      json_object(
@@ -88,6 +170,9 @@ type Querier interface {
 	//         i.id as identity_id,
 	//         i.external_id,
 	//         i.meta          as identity_meta,
+	//         ic.remaining     as identity_remaining_credits,
+	//         ic.refill_day    as identity_refill_day,
+	//         ic.refill_amount as identity_refill_amount,
 	//         ka.deleted_at_m as key_auth_deleted_at_m,
 	//         ws.enabled      as workspace_enabled,
 	//         fws.enabled     as for_workspace_enabled
@@ -97,6 +182,9 @@ type Querier interface {
 	//           JOIN workspaces ws ON ws.id = k.workspace_id
 	//           LEFT JOIN workspaces fws ON fws.id = k.for_workspace_id
 	//           LEFT JOIN identities i ON i.id = k.identity_id AND i.deleted = 0
+	//           LEFT JOIN identity_credits ic ON ic.identity_id = i.id
+	//           LEFT JOIN key_space_environments kse
+	//                     ON kse.key_auth_id = k.key_auth_id AND kse.name = k.environment
 	//  where k.hash = ?
 	//    and k.deleted_at_m is null
 	FindKeyForVerification(ctx context.Context, db DBTX, hash string) (FindKeyForVerificationRow, error)
@@ -0,0 +1,31 @@
+-- name: FindIdentityForJwtVerification :one
+-- FindIdentityForJwtVerification loads the identity a verified JWT maps to
+-- by its external id, together with the identity's rate limits in the same
+-- JSON shape FindKeyForVerification returns them in, and its credit pool.
+SELECT i.id,
+       i.external_id,
+       i.meta,
+       coalesce(
+               (select json_arrayagg(
+                    json_object(
+                       'id', rl.id,
+                       'name', rl.name,
+                       'key_id', rl.key_id,
+                       'identity_id', rl.identity_id,
+                       'limit', rl.`limit`,
+                       'duration', rl.duration,
+                       'auto_apply', rl.auto_apply
+                    )
+                )
+                from `ratelimits` rl
+                where rl.identity_id = i.id),
+               json_array()
+       ) as ratelimits,
+       ic.remaining     as identity_remaining_credits,
+       ic.refill_day    as identity_refill_day,
+       ic.refill_amount as identity_refill_amount
+FROM identities i
+         LEFT JOIN identity_credits ic ON ic.identity_id = i.id
+WHERE i.workspace_id = sqlc.arg(workspace_id)
+  AND i.external_id = sqlc.arg(external_id)
+  AND i.deleted = 0;
@@ -0,0 +1,24 @@
+-- name: FindJwtConfigsByIssuer :many
+-- FindJwtConfigsByIssuer returns the JWT config of every live api in the
+-- workspace that accepts tokens from the issuer, together with the keyspace,
+-- IP whitelist and workspace state a JWT verification needs. Several apis may
+-- trust the same issuer; the caller tries each until one accepts the token.
+SELECT c.api_id,
+       c.issuer,
+       c.audience,
+       c.jwks_url,
+       c.public_key,
+       c.identity_claim,
+       c.permissions_claim,
+       c.created_at_m,
+       c.updated_at_m,
+       a.key_auth_id,
+       a.ip_whitelist,
+       ws.enabled as workspace_enabled
+FROM api_jwt_configs c
+         JOIN apis a ON a.id = c.api_id
+         JOIN workspaces ws ON ws.id = c.workspace_id
+WHERE c.workspace_id = sqlc.arg(workspace_id)
+  AND c.issuer = sqlc.arg(issuer)
+  AND a.auth_type = 'jwt'
+  AND a.deleted_at_m IS NULL;
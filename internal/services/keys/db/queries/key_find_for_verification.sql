@@ -3,8 +3,12 @@
 -- workspace status, RBAC roles and permissions, identity, and rate limit
 -- configuration in a single round trip. Roles, permissions, and rate limits
 -- are returned as JSON arrays via JSON_ARRAYAGG so the caller can unmarshal
--- them into typed Go structs. Key-level and identity-level rate limits are
--- unioned so that both sources are available for the verification pipeline.
+-- them into typed Go structs. Conditions of conditional permissions are
+-- returned as a JSON object keyed by permission slug. Key-level and
+-- identity-level rate limits are unioned so that both sources are available
+-- for the verification pipeline. The identity's credit pool, if any, comes
+-- along so keys can draw from it, and so does the key's environment together
+-- with whether the keyspace runs that environment in test mode.
 select k.id,
        k.key_auth_id,
        k.workspace_id,
@@ -19,6 +23,8 @@ select k.id,
        k.enabled,
        k.remaining_requests,
        k.pending_migration_id,
+       k.environment,
+       kse.test_mode   as environment_test_mode,
        a.ip_whitelist,
        a.workspace_id  as api_workspace_id,
        a.id            as api_id,
@@ -50,6 +56,25 @@ select k.id,
                JSON_ARRAY()
        )               as permissions,
 
+       COALESCE(
+               (SELECT JSON_OBJECTAGG(slug, conditions)
+                FROM (SELECT slug, conditions
+                      FROM keys_permissions kp
+                               JOIN permissions p ON kp.permission_id = p.id
+                      WHERE kp.key_id = k.id
+                        AND p.conditions IS NOT NULL
+
+                      UNION ALL
+
+                      SELECT slug, conditions
+                      FROM keys_roles kr
+                               JOIN roles_permissions rp ON kr.role_id = rp.role_id
+                               JOIN permissions p ON rp.permission_id = p.id
+                      WHERE kr.key_id = k.id
+                        AND p.conditions IS NOT NULL) as conditional_perms),
+               JSON_OBJECT()
+       )               as permission_conditions,
+
        coalesce(
                (select json_arrayagg(
                     json_object(
@@ -77,6 +102,9 @@ select k.id,
        i.id as identity_id,
        i.external_id,
        i.meta          as identity_meta,
+       ic.remaining     as identity_remaining_credits,
+       ic.refill_day    as identity_refill_day,
+       ic.refill_amount as identity_refill_amount,
        ka.deleted_at_m as key_auth_deleted_at_m,
        ws.enabled      as workspace_enabled,
        fws.enabled     as for_workspace_enabled
@@ -86,5 +114,8 @@ from `keys` k
          JOIN workspaces ws ON ws.id = k.workspace_id
          LEFT JOIN workspaces fws ON fws.id = k.for_workspace_id
          LEFT JOIN identities i ON i.id = k.identity_id AND i.deleted = 0
+         LEFT JOIN identity_credits ic ON ic.identity_id = i.id
+         LEFT JOIN key_space_environments kse
+                   ON kse.key_auth_id = k.key_auth_id AND kse.name = k.environment
 where k.hash = ?
   and k.deleted_at_m is null;
@@ -2,6 +2,7 @@ package keys
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -19,6 +20,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/logger"
 	"github.com/unkeyed/unkey/pkg/mysql"
 	"github.com/unkeyed/unkey/pkg/otel/tracing"
+	"github.com/unkeyed/unkey/pkg/rbac"
 	"github.com/unkeyed/unkey/pkg/zen"
 )
 
@@ -112,16 +114,7 @@ func (s *service) Get(ctx context.Context, sess *zen.Session, sha256Hash string)
 		}
 
 		// Parse IP whitelist once during cache population for performance
-		parsedIPWhitelist := make(map[string]struct{})
-		if row.IpWhitelist.Valid && row.IpWhitelist.String != "" {
-			ips := strings.Split(row.IpWhitelist.String, ",")
-			for _, ip := range ips {
-				trimmed := strings.TrimSpace(ip)
-				if trimmed != "" {
-					parsedIPWhitelist[trimmed] = struct{}{}
-				}
-			}
-		}
+		parsedIPWhitelist := parseIPWhitelist(row.IpWhitelist)
 
 		// Decode roles / permissions / ratelimits once during cache population so
 		// that cache hits don't re-parse these JSON columns on every verify call.
@@ -141,6 +134,19 @@ func (s *service) Get(ctx context.Context, sess *zen.Session, sha256Hash string)
 			permissions = []string{}
 		}
 
+		permissionConditions, err := db.UnmarshalNullableJSONTo[map[string]rbac.Conditions](row.PermissionConditions)
+		if err != nil {
+			return keysdb.CachedKeyData{}, fault.Wrap(err, fault.Internal("failed to unmarshal permission conditions"))
+		}
+
+		grants := make([]rbac.Grant, len(permissions))
+		for i, permission := range permissions {
+			grants[i] = rbac.Grant{Permission: permission, Conditions: nil}
+			if conditions, ok := permissionConditions[permission]; ok {
+				grants[i].Conditions = &conditions
+			}
+		}
+
 		ratelimitArr, err := db.UnmarshalNullableJSONTo[[]keysdb.KeyFindForVerificationRatelimit](row.Ratelimits)
 		if err != nil {
 			return keysdb.CachedKeyData{}, fault.Wrap(err, fault.Internal("failed to unmarshal ratelimits"))
@@ -165,6 +171,7 @@ func (s *service) Get(ctx context.Context, sess *zen.Session, sha256Hash string)
 			ParsedIPWhitelist:         parsedIPWhitelist,
 			Roles:                     roles,
 			Permissions:               permissions,
+			Grants:                    grants,
 			RatelimitConfigs:          ratelimitConfigs,
 		}, nil
 	}, caches.DefaultFindFirstOp)
@@ -225,6 +232,7 @@ func (s *service) Get(ctx context.Context, sess *zen.Session, sha256Hash string)
 			source:                s.source,
 			rateLimiter:           s.rateLimiter,
 			usageLimiter:          s.usageLimiter,
+			webhooks:              s.webhooks,
 			AuthorizedWorkspaceID: key.WorkspaceID,
 			isRootKey:             key.ForWorkspaceID.Valid,
 			Key:                   key.FindKeyForVerificationRow,
@@ -240,6 +248,7 @@ func (s *service) Get(ctx context.Context, sess *zen.Session, sha256Hash string)
 		Key:                   key.FindKeyForVerificationRow,
 		rateLimiter:           s.rateLimiter,
 		usageLimiter:          s.usageLimiter,
+		webhooks:              s.webhooks,
 		AuthorizedWorkspaceID: key.WorkspaceID,
 		rBAC:                  s.rbac,
 		session:               sess,
@@ -256,6 +265,7 @@ func (s *service) Get(ctx context.Context, sess *zen.Session, sha256Hash string)
 		parsedIPWhitelist: key.ParsedIPWhitelist, // Use pre-parsed IPs from cache
 		Roles:             key.Roles,
 		Permissions:       key.Permissions,
+		grants:            key.Grants,
 		RatelimitResults:  nil,
 	}
 
@@ -281,3 +291,18 @@ func (s *service) Get(ctx context.Context, sess *zen.Session, sha256Hash string)
 
 	return kv, nil
 }
+
+// parseIPWhitelist splits an api's comma separated IP whitelist into a set.
+func parseIPWhitelist(whitelist sql.NullString) map[string]struct{} {
+	parsed := make(map[string]struct{})
+	if !whitelist.Valid || whitelist.String == "" {
+		return parsed
+	}
+	for _, ip := range strings.Split(whitelist.String, ",") {
+		trimmed := strings.TrimSpace(ip)
+		if trimmed != "" {
+			parsed[trimmed] = struct{}{}
+		}
+	}
+	return parsed
+}
@@ -19,6 +19,10 @@ type KeyService interface {
 	// If migration is pending, it performs on-demand migration and returns a KeyVerifier for further validation.
 	GetMigrated(ctx context.Context, sess *zen.Session, rawKey string, migrationID string) (*KeyVerifier, error)
 
+	// GetJWT verifies a JWT against the workspace's JWT-enabled apis and
+	// returns a KeyVerifier for the identity it maps to
+	GetJWT(ctx context.Context, sess *zen.Session, workspaceID string, token string) (*KeyVerifier, error)
+
 	// CreateKey generates a new secure API key
 	CreateKey(ctx context.Context, req CreateKeyRequest) (CreateKeyResponse, error)
 }
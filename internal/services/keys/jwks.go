@@ -0,0 +1,116 @@
+package keys
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/jwks"
+	"github.com/unkeyed/unkey/pkg/jwt"
+	"github.com/unkeyed/unkey/pkg/ssrf"
+)
+
+const (
+	// jwksFresh is how long a fetched key set is used before it is refreshed
+	// in the background. Identity providers publish new signing keys ahead of
+	// using them, so a rotation is picked up well before its first token.
+	jwksFresh = 5 * time.Minute
+
+	// jwksMaxBytes caps how much of a JWKS response is read.
+	jwksMaxBytes = 256 * 1024
+)
+
+// jwtClaims is the payload type tokens are verified into. Which claims carry
+// the identity and permissions is configured per api, so they stay untyped.
+type jwtClaims = jwks.Claims
+
+// loadJWTKeySet returns the verification keys of a JWT config. Sets are
+// cached per config version, so updating a config takes effect with its next
+// cache refresh. A failed background refresh keeps serving the previous set,
+// so a JWKS outage does not reject tokens signed by already known keys.
+func (s *service) loadJWTKeySet(ctx context.Context, cfg keysdb.FindJwtConfigsByIssuerRow) (*jwks.KeySet, error) {
+	version := cfg.CreatedAtM
+	if cfg.UpdatedAtM.Valid {
+		version = cfg.UpdatedAtM.Int64
+	}
+
+	set, _, err := s.jwtKeySets.SWR(ctx, fmt.Sprintf("%s:%d", cfg.ApiID, version), func(ctx context.Context) (*jwks.KeySet, error) {
+		return s.buildJWTKeySet(ctx, cfg)
+	}, func(err error) cache.Op {
+		if err != nil {
+			return cache.Noop
+		}
+		return cache.WriteValue
+	})
+	return set, err
+}
+
+// buildJWTKeySet parses the config's static public key, or fetches its JWKS
+// and indexes a verifier per usable signing key.
+func (s *service) buildJWTKeySet(ctx context.Context, cfg keysdb.FindJwtConfigsByIssuerRow) (*jwks.KeySet, error) {
+	opts := []jwt.VerifyOption{jwt.WithIssuer(cfg.Issuer)}
+	if cfg.Audience.Valid && cfg.Audience.String != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience.String))
+	}
+
+	if cfg.PublicKey.Valid && cfg.PublicKey.String != "" {
+		return jwks.FromPEM(cfg.PublicKey.String, jwks.SupportedAlgorithms, opts...)
+	}
+
+	if !cfg.JwksUrl.Valid || cfg.JwksUrl.String == "" {
+		return nil, errors.New("JWT config has neither a JWKS URL nor a public key")
+	}
+
+	doc, err := s.fetchJWKS(ctx, cfg.JwksUrl.String)
+	if err != nil {
+		return nil, err
+	}
+	return jwks.FromJWKS(doc, jwks.SupportedAlgorithms, opts...)
+}
+
+// fetchJWKS downloads and decodes a JWKS document.
+func (s *service) fetchJWKS(ctx context.Context, url string) (jwt.JWKS, error) {
+	ctx, cancel := context.WithTimeout(ctx, jwks.FetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return jwt.JWKS{}, fmt.Errorf("create JWKS request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.jwksClient.Do(req)
+	if err != nil {
+		return jwt.JWKS{}, fmt.Errorf("fetch JWKS: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return jwt.JWKS{}, fmt.Errorf("fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var doc jwt.JWKS
+	if err := json.NewDecoder(io.LimitReader(resp.Body, jwksMaxBytes)).Decode(&doc); err != nil {
+		return jwt.JWKS{}, fmt.Errorf("decode JWKS: %w", err)
+	}
+	return doc, nil
+}
+
+// newJWKSClient returns the client used to fetch JWKS documents. The URLs are
+// customer supplied and fetched from inside our network, so the client
+// refuses any address that is not publicly routable.
+func newJWKSClient() *http.Client {
+	return ssrf.NewClient(ssrf.Config{
+		Timeout:              jwks.FetchTimeout,
+		AllowPrivateNetworks: false,
+		FollowRedirects:      true,
+	})
+}
@@ -0,0 +1,157 @@
+package keys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
+	"github.com/unkeyed/unkey/pkg/jwt"
+)
+
+func TestBuildJWTKeySet_JWKS(t *testing.T) {
+	t.Parallel()
+
+	first, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	second, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	rsaJWK := func(kid string, key *rsa.PrivateKey) jwt.JWK {
+		return jwt.JWK{
+			KeyType:  "RSA",
+			KeyID:    kid,
+			Use:      "sig",
+			Modulus:  base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			Exponent: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(jwt.JWKS{Keys: []jwt.JWK{
+			rsaJWK("first", first),
+			rsaJWK("second", second),
+			{KeyType: "oct", KeyID: "hmac"},
+			{KeyType: "RSA", KeyID: "encryption", Use: "enc", Modulus: "AQAB", Exponent: "AQAB"},
+		}})
+	}))
+	defer server.Close()
+
+	s := &service{jwksClient: server.Client()}
+	set, err := s.buildJWTKeySet(context.Background(), keysdb.FindJwtConfigsByIssuerRow{
+		ApiID:    "api_test",
+		Issuer:   "https://auth.example.com/",
+		Audience: sql.NullString{String: "https://api.example.com", Valid: true},
+		JwksUrl:  sql.NullString{String: server.URL, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, set.Len())
+	require.True(t, set.Contains("first"))
+	require.True(t, set.Contains("second"))
+
+	sign := func(t *testing.T, key *rsa.PrivateKey, claims jwt.RegisteredClaims) string {
+		signer, err := jwt.NewRS256Signer[jwt.RegisteredClaims](string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		})))
+		require.NoError(t, err)
+		token, err := signer.Sign(claims)
+		require.NoError(t, err)
+		return token
+	}
+
+	valid := jwt.RegisteredClaims{
+		Issuer:    "https://auth.example.com/",
+		Subject:   "user_123",
+		Audience:  []string{"https://api.example.com"},
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}
+
+	t.Run("tokens without kid try every key", func(t *testing.T) {
+		claims, err := set.Verify("", "RS256", sign(t, second, valid))
+		require.NoError(t, err)
+		require.Equal(t, "user_123", claims["sub"])
+	})
+
+	t.Run("kid selects the key", func(t *testing.T) {
+		token := sign(t, second, valid)
+		_, err := set.Verify("second", "RS256", token)
+		require.NoError(t, err)
+		_, err = set.Verify("first", "RS256", token)
+		require.Error(t, err)
+		_, err = set.Verify("unknown", "RS256", token)
+		require.Error(t, err)
+	})
+
+	t.Run("algorithm must match the key", func(t *testing.T) {
+		_, err := set.Verify("first", "ES256", sign(t, first, valid))
+		require.Error(t, err)
+	})
+
+	t.Run("audience is enforced", func(t *testing.T) {
+		claims := valid
+		claims.Audience = []string{"https://other.example.com"}
+		_, err := set.Verify("first", "RS256", sign(t, first, claims))
+		require.Error(t, err)
+	})
+
+	t.Run("expiry is reported", func(t *testing.T) {
+		claims := valid
+		claims.ExpiresAt = time.Now().Add(-time.Hour).Unix()
+		_, err := set.Verify("first", "RS256", sign(t, first, claims))
+		require.ErrorIs(t, err, jwt.ErrTokenExpired)
+	})
+}
+
+func TestBuildJWTKeySet_NoUsableKeys(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"keys":[{"kty":"oct","kid":"hmac"}]}`))
+	}))
+	defer server.Close()
+
+	s := &service{jwksClient: server.Client()}
+	_, err := s.buildJWTKeySet(context.Background(), keysdb.FindJwtConfigsByIssuerRow{
+		Issuer:  "https://auth.example.com/",
+		JwksUrl: sql.NullString{String: server.URL, Valid: true},
+	})
+	require.Error(t, err)
+}
+
+func TestJWKSClient_RefusesNonPublicAddresses(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"keys":[]}`))
+	}))
+	defer server.Close()
+
+	s := &service{jwksClient: newJWKSClient()}
+	_, err := s.fetchJWKS(context.Background(), server.URL)
+	require.ErrorContains(t, err, "non-public address")
+
+}
+
+func TestJWTPermissions(t *testing.T) {
+	t.Parallel()
+
+	claim := sql.NullString{String: "scope", Valid: true}
+
+	require.Equal(t, []string{"a.read", "b.write"}, jwtPermissions(jwtClaims{"scope": "a.read  b.write"}, claim))
+	require.Equal(t, []string{"a.read", "b.write"}, jwtPermissions(jwtClaims{"scope": []any{"a.read", 1, "", "b.write"}}, claim))
+	require.Empty(t, jwtPermissions(jwtClaims{"scope": 42}, claim))
+	require.Empty(t, jwtPermissions(jwtClaims{}, claim))
+	require.Empty(t, jwtPermissions(jwtClaims{"scope": "a.read"}, sql.NullString{}))
+}
@@ -0,0 +1,290 @@
+package keys
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/unkeyed/unkey/internal/services/caches"
+	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
+	"github.com/unkeyed/unkey/internal/services/keys/metrics"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/jwt"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/pkg/mysql"
+	"github.com/unkeyed/unkey/pkg/otel/tracing"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+)
+
+// GetJWT verifies a JWT against the JWT configs of the workspace's apis and
+// returns a KeyVerifier for the identity the token maps to.
+//
+// The token's issuer selects the candidate configs; the first config whose
+// keys, issuer and audience accept the token wins. The identity claim is
+// looked up as an identity external id, and the identity's rate limits apply
+// as they would to a key owned by it. Permissions come from the optional
+// permissions claim. There is no key row, so Key.ID is empty and credits are
+// drawn from the identity's credit pool alone, if it has one.
+//
+// Like Get, a token that does not verify yields a KeyVerifier with a non-valid
+// status rather than an error. Errors are reserved for system problems, such
+// as every candidate's JWKS being unreachable.
+func (s *service) GetJWT(ctx context.Context, sess *zen.Session, workspaceID string, token string) (kv *KeyVerifier, err error) {
+	ctx, span := tracing.Start(ctx, "keys.GetJWT")
+	defer span.End()
+
+	defer func() {
+		if kv == nil {
+			return
+		}
+		metrics.KeyVerificationsTotal.WithLabelValues("jwt", string(kv.Status)).Inc()
+	}()
+
+	startTime := time.Now()
+
+	// nolint:exhaustruct
+	notFound := func(message string) *KeyVerifier {
+		return &KeyVerifier{
+			Status:    StatusNotFound,
+			message:   message,
+			session:   sess,
+			region:    s.region,
+			source:    s.source,
+			startTime: startTime,
+			jwt:       true,
+		}
+	}
+
+	header, registered, err := jwt.ParseUnverified(token)
+	if err != nil || registered.Issuer == "" {
+		return notFound("token is not a JWT with an issuer"), nil
+	}
+
+	configs, _, err := s.jwtConfigCache.SWR(ctx, cache.ScopedKey{WorkspaceID: workspaceID, Key: registered.Issuer}, func(ctx context.Context) ([]keysdb.FindJwtConfigsByIssuerRow, error) {
+		return mysql.WithRetryContext(ctx, func() ([]keysdb.FindJwtConfigsByIssuerRow, error) {
+			return keysdb.Query.FindJwtConfigsByIssuer(ctx, s.db.RO(), keysdb.FindJwtConfigsByIssuerParams{
+				WorkspaceID: workspaceID,
+				Issuer:      registered.Issuer,
+			})
+		})
+	}, caches.DefaultFindFirstOp)
+	if err != nil && !mysql.IsNotFound(err) {
+		return nil, fault.Wrap(err,
+			fault.Internal("unable to load JWT configs"),
+			fault.Public("We could not load the JWT configuration."),
+		)
+	}
+	if len(configs) == 0 {
+		return notFound("no api accepts tokens from this issuer"), nil
+	}
+
+	var (
+		cfg        keysdb.FindJwtConfigsByIssuerRow
+		claims     jwtClaims
+		verified   bool
+		expiredBy  *keysdb.FindJwtConfigsByIssuerRow
+		loadErrs   []error
+		verifyErrs []error
+	)
+	for _, candidate := range configs {
+		set, loadErr := s.loadJWTKeySet(ctx, candidate)
+		if loadErr != nil {
+			loadErrs = append(loadErrs, fmt.Errorf("api %s: %w", candidate.ApiID, loadErr))
+			continue
+		}
+		c, verifyErr := set.Verify(header.KID, header.Alg, token)
+		if verifyErr != nil {
+			if errors.Is(verifyErr, jwt.ErrTokenExpired) {
+				expiredBy = &candidate
+			}
+			verifyErrs = append(verifyErrs, verifyErr)
+			continue
+		}
+		cfg, claims, verified = candidate, c, true
+		break
+	}
+
+	if !verified {
+		if len(loadErrs) == len(configs) {
+			return nil, fault.Wrap(errors.Join(loadErrs...),
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("unable to load JWT verification keys"),
+				fault.Public("We could not load the keys to verify this token, please try again."),
+			)
+		}
+		if len(loadErrs) > 0 {
+			logger.Warn("unable to load JWT verification keys", "error", errors.Join(loadErrs...).Error())
+		}
+		// Expiry is checked after the signature, so the token is authentic and
+		// its api is known; report it like an expired key of that api.
+		if expiredBy != nil {
+			// nolint:exhaustruct
+			return &KeyVerifier{
+				Key: keysdb.FindKeyForVerificationRow{
+					WorkspaceID:    workspaceID,
+					ApiWorkspaceID: workspaceID,
+					ApiID:          expiredBy.ApiID,
+					KeyAuthID:      expiredBy.KeyAuthID.String,
+				},
+				AuthorizedWorkspaceID: workspaceID,
+				Status:                StatusExpired,
+				message:               "the token has expired",
+				session:               sess,
+				region:                s.region,
+				source:                s.source,
+				startTime:             startTime,
+				jwt:                   true,
+			}, nil
+		}
+		return notFound(fmt.Sprintf("token did not verify: %s", errors.Join(verifyErrs...))), nil
+	}
+
+	// Short-lived tokens are the point of JWT auth; a token without an expiry
+	// would be a long-lived credential nobody can revoke.
+	if registered.ExpiresAt == 0 {
+		return notFound("token has no exp claim"), nil
+	}
+
+	externalID, ok := claims[cfg.IdentityClaim].(string)
+	if !ok || externalID == "" {
+		return notFound(fmt.Sprintf("token has no string %q claim", cfg.IdentityClaim)), nil
+	}
+
+	identity, hit, err := s.jwtIdentityCache.SWR(ctx, cache.ScopedKey{WorkspaceID: workspaceID, Key: externalID}, func(ctx context.Context) (keysdb.CachedJwtIdentity, error) {
+		row, err := mysql.WithRetryContext(ctx, func() (keysdb.FindIdentityForJwtVerificationRow, error) {
+			return keysdb.Query.FindIdentityForJwtVerification(ctx, s.db.RO(), keysdb.FindIdentityForJwtVerificationParams{
+				WorkspaceID: workspaceID,
+				ExternalID:  externalID,
+			})
+		})
+		if err != nil {
+			return keysdb.CachedJwtIdentity{}, err
+		}
+
+		ratelimits, err := db.UnmarshalNullableJSONTo[[]keysdb.KeyFindForVerificationRatelimit](row.Ratelimits)
+		if err != nil {
+			return keysdb.CachedJwtIdentity{}, fault.Wrap(err, fault.Internal("failed to unmarshal ratelimits"))
+		}
+		ratelimitConfigs := make(map[string]keysdb.KeyFindForVerificationRatelimit, len(ratelimits))
+		for _, rl := range ratelimits {
+			ratelimitConfigs[rl.Name] = rl
+		}
+
+		return keysdb.CachedJwtIdentity{
+			FindIdentityForJwtVerificationRow: row,
+			RatelimitConfigs:                  ratelimitConfigs,
+		}, nil
+	}, caches.DefaultFindFirstOp)
+	if err != nil {
+		if mysql.IsNotFound(err) {
+			return notFound("no identity matches the token's identity claim"), nil
+		}
+		return nil, fault.Wrap(err,
+			fault.Internal("unable to load identity"),
+			fault.Public("We could not load the identity of this token."),
+		)
+	}
+	if hit == cache.Null {
+		return notFound("no identity matches the token's identity claim"), nil
+	}
+
+	permissions := jwtPermissions(claims, cfg.PermissionsClaim)
+	grants := make([]rbac.Grant, len(permissions))
+	for i, permission := range permissions {
+		grants[i] = rbac.Grant{Permission: permission, Conditions: nil}
+	}
+
+	kv = &KeyVerifier{
+		Key: keysdb.FindKeyForVerificationRow{
+			ID:                       "",
+			KeyAuthID:                cfg.KeyAuthID.String,
+			WorkspaceID:              workspaceID,
+			ForWorkspaceID:           sql.NullString{},
+			Name:                     sql.NullString{},
+			Meta:                     sql.NullString{},
+			Expires:                  sql.NullTime{Time: time.Unix(registered.ExpiresAt, 0), Valid: true},
+			DeletedAtM:               sql.NullInt64{},
+			RefillDay:                sql.NullInt16{},
+			RefillAmount:             sql.NullInt64{},
+			LastRefillAt:             sql.NullTime{},
+			Enabled:                  true,
+			RemainingRequests:        sql.NullInt64{},
+			PendingMigrationID:       sql.NullString{},
+			Environment:              sql.NullString{},
+			EnvironmentTestMode:      sql.NullBool{},
+			IpWhitelist:              cfg.IpWhitelist,
+			ApiWorkspaceID:           workspaceID,
+			ApiID:                    cfg.ApiID,
+			ApiDeletedAtM:            sql.NullInt64{},
+			Roles:                    nil,
+			Permissions:              nil,
+			PermissionConditions:     nil,
+			Ratelimits:               nil,
+			IdentityID:               sql.NullString{String: identity.ID, Valid: true},
+			ExternalID:               sql.NullString{String: identity.ExternalID, Valid: true},
+			IdentityMeta:             identity.Meta,
+			IdentityRemainingCredits: identity.IdentityRemainingCredits,
+			IdentityRefillDay:        identity.IdentityRefillDay,
+			IdentityRefillAmount:     identity.IdentityRefillAmount,
+			KeyAuthDeletedAtM:        sql.NullInt64{},
+			WorkspaceEnabled:         cfg.WorkspaceEnabled,
+			ForWorkspaceEnabled:      sql.NullBool{},
+		},
+		Roles:                 []string{},
+		Permissions:           permissions,
+		grants:                grants,
+		Status:                StatusValid,
+		AuthorizedWorkspaceID: workspaceID,
+		ratelimitConfigs:      identity.RatelimitConfigs,
+		RatelimitResults:      nil,
+		parsedIPWhitelist:     parseIPWhitelist(cfg.IpWhitelist),
+		isRootKey:             false,
+		jwt:                   true,
+		message:               "",
+		tags:                  []string{},
+		session:               sess,
+		region:                s.region,
+		source:                s.source,
+		startTime:             startTime,
+		spentCredits:          0,
+		rateLimiter:           s.rateLimiter,
+		usageLimiter:          s.usageLimiter,
+		webhooks:              s.webhooks,
+		rBAC:                  s.rbac,
+	}
+
+	if !cfg.WorkspaceEnabled {
+		kv.setInvalid(StatusWorkspaceDisabled, "workspace is disabled")
+	}
+
+	return kv, nil
+}
+
+// jwtPermissions reads the permission slugs from the claim, which may be an
+// array of strings or a space separated string as in the OAuth scope claim.
+// A missing claim, or no claim configured, grants no permissions.
+func jwtPermissions(claims jwtClaims, claim sql.NullString) []string {
+	permissions := []string{}
+	if !claim.Valid || claim.String == "" {
+		return permissions
+	}
+
+	switch value := claims[claim.String].(type) {
+	case string:
+		permissions = append(permissions, strings.Fields(value)...)
+	case []any:
+		for _, v := range value {
+			if permission, ok := v.(string); ok && permission != "" {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return permissions
+}
@@ -13,6 +13,7 @@ type VerifyOption func(*verifyConfig) error
 
 // verifyConfig holds the internal configuration for verification options.
 type verifyConfig struct {
+	environment *string
 	ipWhitelist bool
 	credits     *int64
 	tags        []string
@@ -33,6 +34,19 @@ func WithCredits(cost int64) VerifyOption {
 	}
 }
 
+// WithEnvironment validates that the key belongs to the given environment of
+// its keyspace. Keys of any other environment, or of none, are forbidden.
+func WithEnvironment(environment string) VerifyOption {
+	return func(config *verifyConfig) error {
+		if environment == "" {
+			return errors.New("environment cannot be empty")
+		}
+
+		config.environment = &environment
+		return nil
+	}
+}
+
 // WithIPWhitelist validates that the client IP address is in the key's IP whitelist.
 // The client IP is extracted from the session. If no whitelist is configured, this check is skipped.
 func WithIPWhitelist() VerifyOption {
@@ -1,10 +1,16 @@
 package keys
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/unkeyed/unkey/internal/services/keys/db"
 	"github.com/unkeyed/unkey/internal/services/ratelimit"
 	"github.com/unkeyed/unkey/internal/services/usagelimiter"
+	"github.com/unkeyed/unkey/internal/services/webhooks"
 	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/clock"
+	"github.com/unkeyed/unkey/pkg/jwks"
 	"github.com/unkeyed/unkey/pkg/mysql"
 	"github.com/unkeyed/unkey/pkg/rbac"
 )
@@ -20,8 +26,21 @@ type Config struct {
 	// for the Deploy gateway), so billing can exclude gateway traffic.
 	Source       string
 	UsageLimiter usagelimiter.Service // Redis Counter for usage limiting
+	// Webhooks receives key.credits_exhausted events. Nil disables them.
+	Webhooks webhooks.Service
 
 	KeyCache cache.Cache[string, db.CachedKeyData] // Cache for key lookups with pre-parsed data
+
+	// JWTConfigCache caches the JWT configs of apis by workspace and issuer.
+	// Nil disables caching.
+	JWTConfigCache cache.Cache[cache.ScopedKey, []db.FindJwtConfigsByIssuerRow]
+	// JWTIdentityCache caches the identities JWTs map to by workspace and
+	// external id. Nil disables caching.
+	JWTIdentityCache cache.Cache[cache.ScopedKey, db.CachedJwtIdentity]
+	// JWKSClient fetches the JWKS documents of JWT configs. Nil uses a client
+	// that refuses to connect to non-public addresses, since the URLs are
+	// customer supplied.
+	JWKSClient *http.Client
 }
 
 type service struct {
@@ -29,23 +48,65 @@ type service struct {
 	rateLimiter  ratelimit.Service
 	usageLimiter usagelimiter.Service
 	rbac         *rbac.RBAC
+	webhooks     webhooks.Service
 	region       string
 	source       string
 
 	// hash -> cached key data (includes pre-parsed IP whitelist)
 	keyCache cache.Cache[string, db.CachedKeyData]
+
+	jwtConfigCache   cache.Cache[cache.ScopedKey, []db.FindJwtConfigsByIssuerRow]
+	jwtIdentityCache cache.Cache[cache.ScopedKey, db.CachedJwtIdentity]
+	jwksClient       *http.Client
+
+	// JWT config version -> parsed verification keys
+	jwtKeySets cache.Cache[string, *jwks.KeySet]
 }
 
 // New creates a new keys service instance with the provided configuration.
 func New(config Config) (*service, error) {
+	emitter := config.Webhooks
+	if emitter == nil {
+		emitter = webhooks.NewNoop()
+	}
+
+	jwtConfigCache := config.JWTConfigCache
+	if jwtConfigCache == nil {
+		jwtConfigCache = cache.NewNoopCache[cache.ScopedKey, []db.FindJwtConfigsByIssuerRow]()
+	}
+	jwtIdentityCache := config.JWTIdentityCache
+	if jwtIdentityCache == nil {
+		jwtIdentityCache = cache.NewNoopCache[cache.ScopedKey, db.CachedJwtIdentity]()
+	}
+	jwksClient := config.JWKSClient
+	if jwksClient == nil {
+		jwksClient = newJWKSClient()
+	}
+
+	jwtKeySets, err := cache.New(cache.Config[string, *jwks.KeySet]{
+		Fresh:    jwksFresh,
+		Stale:    24 * time.Hour,
+		MaxSize:  10_000,
+		Resource: "jwt_key_set",
+		Clock:    clock.New(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return &service{
-		db:           db.New(config.DB),
-		rbac:         config.RBAC,
-		rateLimiter:  config.RateLimiter,
-		usageLimiter: config.UsageLimiter,
-		region:       config.Region,
-		source:       config.Source,
-		keyCache:     config.KeyCache,
+		db:               db.New(config.DB),
+		rbac:             config.RBAC,
+		rateLimiter:      config.RateLimiter,
+		usageLimiter:     config.UsageLimiter,
+		webhooks:         emitter,
+		region:           config.Region,
+		source:           config.Source,
+		keyCache:         config.KeyCache,
+		jwtConfigCache:   jwtConfigCache,
+		jwtIdentityCache: jwtIdentityCache,
+		jwksClient:       jwksClient,
+		jwtKeySets:       jwtKeySets,
 	}, nil
 }
 
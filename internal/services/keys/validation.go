@@ -3,11 +3,14 @@ package keys
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/unkeyed/unkey/internal/services/ratelimit"
 	"github.com/unkeyed/unkey/internal/services/usagelimiter"
+	"github.com/unkeyed/unkey/internal/services/webhooks"
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/fault"
 	"github.com/unkeyed/unkey/pkg/logger"
@@ -19,6 +22,13 @@ import (
 
 // withCredits validates that the key has sufficient usage credits and deducts the specified cost.
 // It updates the key's remaining request count and marks the key as invalid if the limit is exceeded.
+//
+// Keys of an identity with a credit pool draw from the pool as well. The key's
+// own balance is charged first; if the pool then denies, that charge is
+// refunded so a rejected request costs nothing.
+//
+// Keys in a test-mode environment only check their balances: they are denied
+// exactly when a live key would be, but never spend anything.
 func (k *KeyVerifier) withCredits(ctx context.Context, cost int64) error {
 	ctx, span := tracing.Start(ctx, "verify.withCredits")
 	defer span.End()
@@ -27,29 +37,112 @@ func (k *KeyVerifier) withCredits(ctx context.Context, cost int64) error {
 		return nil
 	}
 
-	// Key has unlimited requests if set to NULL
-	if !k.Key.RemainingRequests.Valid {
+	keyLimited := k.Key.RemainingRequests.Valid
+	identityLimited := k.Key.IdentityRemainingCredits.Valid
+
+	// Key and identity have unlimited requests if neither has a balance
+	if !keyLimited && !identityLimited {
 		return nil
 	}
 
-	usage, err := k.usageLimiter.Limit(ctx, usagelimiter.UsageRequest{
-		KeyID: k.Key.ID,
-		Cost:  cost,
+	testMode := k.IsTestMode()
+	spend := k.usageLimiter.Limit
+	if testMode {
+		spend = k.usageLimiter.Check
+	}
+	charged := keyLimited && !testMode
+
+	if keyLimited {
+		usage, err := spend(ctx, usagelimiter.UsageRequest{
+			KeyID:      k.Key.ID,
+			IdentityID: "",
+			Cost:       cost,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Always update remaining requests with the accurate count from the usageLimiter
+		k.Key.RemainingRequests = sql.NullInt64{Int64: usage.Remaining, Valid: true}
+		if !usage.Valid {
+			k.setInvalid(StatusUsageExceeded, "Key usage limit exceeded.")
+			return nil
+		}
+	}
+
+	if identityLimited {
+		usage, err := spend(ctx, usagelimiter.UsageRequest{
+			KeyID:      "",
+			IdentityID: k.Key.IdentityID.String,
+			Cost:       cost,
+		})
+		if err != nil {
+			k.refundKey(ctx, cost, charged)
+			return err
+		}
+
+		// A pool removed since the key was cached reports no limit.
+		k.Key.IdentityRemainingCredits = sql.NullInt64{Int64: usage.Remaining, Valid: usage.Remaining >= 0}
+		if !usage.Valid {
+			k.refundKey(ctx, cost, charged)
+			k.setInvalid(StatusUsageExceeded, "Identity credit pool exhausted.")
+			return nil
+		}
+	}
+
+	if testMode {
+		return nil
+	}
+
+	// Only track spent credits if they were actually spent (usage was valid)
+	k.spentCredits = cost
+
+	// The limiter decrements atomically, so exactly one verification
+	// observes the transition to zero.
+	if keyLimited && k.Key.RemainingRequests.Int64 == 0 && cost > 0 && k.webhooks != nil {
+		k.webhooks.Emit(ctx, webhooks.Event{
+			ID:          "",
+			WorkspaceID: k.Key.WorkspaceID,
+			Type:        webhooks.EventKeyCreditsExhausted,
+			Data:        webhooks.KeyData{KeyID: k.Key.ID, APIID: k.Key.ApiID},
+			At:          time.Time{},
+		})
+	}
+
+	return nil
+}
+
+// refundKey gives back the cost already taken from the key's own balance when
+// the identity pool rejects the request. A failed refund only under-admits
+// the key, so it is logged rather than failing the verification.
+func (k *KeyVerifier) refundKey(ctx context.Context, cost int64, charged bool) {
+	if !charged || cost == 0 {
+		return
+	}
+
+	err := k.usageLimiter.Refund(ctx, usagelimiter.UsageRequest{
+		KeyID:      k.Key.ID,
+		IdentityID: "",
+		Cost:       cost,
 	})
 	if err != nil {
-		return err
+		logger.Error("failed to refund key credits after identity pool denial", "error", err, "keyID", k.Key.ID)
+		return
 	}
+	k.Key.RemainingRequests.Int64 += cost
+}
 
-	// Always update remaining requests with the accurate count from the usageLimiter
-	k.Key.RemainingRequests = sql.NullInt64{Int64: usage.Remaining, Valid: true}
-	if !usage.Valid {
-		k.setInvalid(StatusUsageExceeded, "Key usage limit exceeded.")
-	} else {
-		// Only track spent credits if they were actually spent (usage was valid)
-		k.spentCredits = cost
+// withEnvironment validates that the key belongs to the required environment.
+// JWTs and keys created without an environment belong to none, so they never
+// match.
+func (k *KeyVerifier) withEnvironment(environment string) {
+	if k.Status != StatusValid {
+		return
 	}
 
-	return nil
+	if !k.Key.Environment.Valid || k.Key.Environment.String != environment {
+		k.setInvalid(StatusForbidden, fmt.Sprintf("key does not belong to environment %q", environment))
+	}
 }
 
 // withIPWhitelist validates that the client IP address is in the key's IP whitelist.
@@ -79,6 +172,8 @@ func (k *KeyVerifier) withIPWhitelist() error {
 
 // withPermissions validates that the key has the required RBAC permissions.
 // It uses the configured RBAC system to evaluate the permission query against the key's permissions.
+// Conditional permissions are evaluated against the query's attributes, the
+// current time and the key's meta.
 func (k *KeyVerifier) withPermissions(ctx context.Context, query rbac.PermissionQuery) error {
 	_, span := tracing.Start(ctx, "verify.withPermissions")
 	defer span.End()
@@ -87,7 +182,20 @@ func (k *KeyVerifier) withPermissions(ctx context.Context, query rbac.Permission
 		return nil
 	}
 
-	allowed, err := k.rBAC.EvaluatePermissions(query, k.Permissions)
+	// Meta is only decoded when a condition may need it. Undecodable meta
+	// simply fails every meta condition.
+	var meta map[string]any
+	conditional := slices.ContainsFunc(k.grants, func(g rbac.Grant) bool { return g.Conditions != nil })
+	if conditional && k.Key.Meta.Valid && k.Key.Meta.String != "" {
+		if err := json.Unmarshal([]byte(k.Key.Meta.String), &meta); err != nil {
+			meta = nil
+		}
+	}
+
+	allowed, err := k.rBAC.EvaluateGrants(query, k.grants, rbac.EvaluationContext{
+		Time: time.Now(),
+		Meta: meta,
+	})
 	if err != nil {
 		return err
 	}
@@ -117,7 +225,7 @@ func (k *KeyVerifier) withRateLimits(ctx context.Context, specifiedLimits []open
 			continue
 		}
 
-		identifier := k.Key.ID
+		identifier := k.subjectID()
 		if rl.IdentityID != "" {
 			identifier = rl.IdentityID
 		}
@@ -143,7 +251,7 @@ func (k *KeyVerifier) withRateLimits(ctx context.Context, specifiedLimits []open
 				Duration:   time.Duration(*rl.Duration) * time.Millisecond,
 				Limit:      int64(*rl.Limit),
 				AutoApply:  false,
-				Identifier: k.Key.ID,
+				Identifier: k.subjectID(),
 				Response:   nil,
 				ID:         "", // Doesn't exist and is custom so no ID
 			}
@@ -167,7 +275,7 @@ func (k *KeyVerifier) withRateLimits(ctx context.Context, specifiedLimits []open
 			)
 		}
 
-		identifier := k.Key.ID
+		identifier := k.subjectID()
 		if dbRl.IdentityID != "" {
 			identifier = dbRl.IdentityID
 		}
@@ -7,6 +7,7 @@ import (
 	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
 	"github.com/unkeyed/unkey/internal/services/ratelimit"
 	"github.com/unkeyed/unkey/internal/services/usagelimiter"
+	"github.com/unkeyed/unkey/internal/services/webhooks"
 	"github.com/unkeyed/unkey/pkg/clickhouse/schema"
 	"github.com/unkeyed/unkey/pkg/rbac"
 	"github.com/unkeyed/unkey/pkg/zen"
@@ -30,6 +31,7 @@ type KeyVerifier struct {
 	Key                   keysdb.FindKeyForVerificationRow // The key data from the database
 	Roles                 []string                         // RBAC roles assigned to this key
 	Permissions           []string                         // RBAC permissions assigned to this key
+	grants                []rbac.Grant                     // Permissions with the conditions under which they apply
 	Status                KeyStatus                        // The current validation status
 	AuthorizedWorkspaceID string                           // The workspace ID this key is authorized for
 
@@ -38,6 +40,7 @@ type KeyVerifier struct {
 
 	parsedIPWhitelist map[string]struct{} // Pre-parsed IP whitelist for O(1) lookup
 	isRootKey         bool                // Whether this is a root key (special handling)
+	jwt               bool                // Whether this verifies a JWT rather than a stored key
 
 	message string   // Internal message for validation failures
 	tags    []string // Tags associated with this verification
@@ -53,9 +56,31 @@ type KeyVerifier struct {
 	// Services
 	rateLimiter  ratelimit.Service    // Rate limiting service
 	usageLimiter usagelimiter.Service // Usage limiting service
+	webhooks     webhooks.Service     // Emits key.credits_exhausted
 	rBAC         *rbac.RBAC           // Role-based access control service
 }
 
+// IsJWT reports whether this verifier was created by GetJWT. JWTs have no key
+// row, so Key.ID is empty and the identity is the verified subject.
+func (k *KeyVerifier) IsJWT() bool {
+	return k.jwt
+}
+
+// subjectID identifies what is being verified in rate limits: the key, or the
+// identity for JWTs, which have no key.
+func (k *KeyVerifier) subjectID() string {
+	if k.Key.ID == "" {
+		return k.Key.IdentityID.String
+	}
+	return k.Key.ID
+}
+
+// IsTestMode reports whether the key belongs to a test-mode environment of its
+// keyspace. Verifications of such keys check credits without spending them.
+func (k *KeyVerifier) IsTestMode() bool {
+	return k.Key.EnvironmentTestMode.Valid && k.Key.EnvironmentTestMode.Bool
+}
+
 // GetRatelimitConfigs returns the rate limit configurations
 func (k *KeyVerifier) GetRatelimitConfigs() map[string]keysdb.KeyFindForVerificationRatelimit {
 	return k.ratelimitConfigs
@@ -91,6 +116,10 @@ func (k *KeyVerifier) Verify(ctx context.Context, opts ...VerifyOption) error {
 		k.tags = config.tags
 	}
 
+	if config.environment != nil {
+		k.withEnvironment(*config.environment)
+	}
+
 	var err error
 	if config.ipWhitelist {
 		err = k.withIPWhitelist()
@@ -139,6 +168,7 @@ func (k *KeyVerifier) TelemetrySnapshot() schema.KeyVerification {
 		Region:       k.region,
 		Source:       k.source,
 		AppID:        "",
+		Environment:  k.Key.Environment.String,
 		ExternalID:   k.Key.ExternalID.String,
 		SpentCredits: k.spentCredits,
 		Latency:      float64(time.Since(k.startTime).Milliseconds()),
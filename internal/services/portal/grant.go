@@ -1,5 +1,16 @@
 package portal
 
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+)
+
 // Grant is the JSON object stored on a portal session's `scopes` column: the
 // simplified capability model that portal.createSession writes and GetSession
 // reads back. The portal_session resolver expands the verbs into RBAC
@@ -13,3 +24,171 @@ type Grant struct {
 	KeyspaceIDs []string `json:"keyspaceIds"`
 	Scopes      []string `json:"scopes"`
 }
+
+// RatelimitTemplate is a ratelimit the portal stamps onto every key an end
+// user creates. The end user cannot choose or change it. It is the JSON shape of
+// one entry in portals.ratelimit_templates.
+type RatelimitTemplate struct {
+	Name string `json:"name"`
+	// Limit is the number of requests allowed per Duration.
+	Limit int64 `json:"limit"`
+	// Duration is the window in milliseconds.
+	Duration  int64 `json:"duration"`
+	AutoApply bool  `json:"autoApply"`
+}
+
+// KeyPolicy bounds the keys an end user may create through a portal, on top of
+// the session's keys:create capability. It is read from the portal row on
+// every request rather than captured on the session, so tightening a policy
+// applies to sessions that are already live.
+//
+// The zero value allows any number of keys, with any expiry or none, and no
+// permissions or roles.
+type KeyPolicy struct {
+	// MaxKeys caps the end user's live keys across the portal's keyspaces.
+	// Zero means unlimited.
+	MaxKeys int64
+
+	// AllowedPermissions and AllowedRoles are the only permission slugs and
+	// role names an end user may attach to a key they create.
+	AllowedPermissions []string
+	AllowedRoles       []string
+
+	// RatelimitTemplates are attached to every key the end user creates.
+	RatelimitTemplates []RatelimitTemplate
+
+	// MinExpiry and MaxExpiry bound how far in the future a created key may
+	// expire. A non-zero MaxExpiry requires every created key to expire.
+	MinExpiry time.Duration
+	MaxExpiry time.Duration
+}
+
+// PolicyFromPortal decodes a portal row's policy columns. A malformed JSON
+// column is an internal error: end users never write these columns, so it
+// means the row was corrupted rather than that the caller did something wrong.
+func PolicyFromPortal(p db.Portal) (KeyPolicy, error) {
+	permissions, err := decodePolicyList[string]("allowed_permissions", p.AllowedPermissions)
+	if err != nil {
+		return KeyPolicy{}, err
+	}
+	roles, err := decodePolicyList[string]("allowed_roles", p.AllowedRoles)
+	if err != nil {
+		return KeyPolicy{}, err
+	}
+	templates, err := decodePolicyList[RatelimitTemplate]("ratelimit_templates", p.RatelimitTemplates)
+	if err != nil {
+		return KeyPolicy{}, err
+	}
+
+	return KeyPolicy{
+		MaxKeys:            int64(p.MaxKeys.Int32),
+		AllowedPermissions: permissions,
+		AllowedRoles:       roles,
+		RatelimitTemplates: templates,
+		MinExpiry:          time.Duration(p.MinExpiryMs.Int64) * time.Millisecond,
+		MaxExpiry:          time.Duration(p.MaxExpiryMs.Int64) * time.Millisecond,
+	}, nil
+}
+
+// decodePolicyList decodes one of the portal's JSON list columns. NULL decodes
+// to an empty list.
+func decodePolicyList[T any](column string, raw []byte) ([]T, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var list []T
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fault.Wrap(err,
+			fault.Code(codes.App.Internal.UnexpectedError.URN()),
+			fault.Internal(fmt.Sprintf("failed to unmarshal portal %s", column)),
+			fault.Public("Portal configuration is invalid."),
+		)
+	}
+	return list, nil
+}
+
+// CheckKeyCount rejects a create when the end user already holds MaxKeys live
+// keys.
+func (p KeyPolicy) CheckKeyCount(live int64) error {
+	if p.MaxKeys > 0 && live >= p.MaxKeys {
+		return fault.New("portal key limit reached",
+			fault.Code(codes.Auth.Authorization.Forbidden.URN()),
+			fault.Internal(fmt.Sprintf("identity holds %d of %d allowed keys", live, p.MaxKeys)),
+			fault.Public(fmt.Sprintf("You can have at most %d keys. Delete a key before creating a new one.", p.MaxKeys)),
+		)
+	}
+	return nil
+}
+
+// CheckExpiry validates a requested expiry, in unix milliseconds, against the
+// policy's bounds at now. A nil expiry means the key never expires.
+func (p KeyPolicy) CheckExpiry(expires *int64, now time.Time) error {
+	if expires == nil {
+		if p.MaxExpiry > 0 {
+			return fault.New("portal key must expire",
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Internal("policy requires an expiry"),
+				fault.Public(fmt.Sprintf("`expires` is required and must be at most %s away.", formatDuration(p.MaxExpiry))),
+			)
+		}
+		return nil
+	}
+
+	lifetime := time.UnixMilli(*expires).Sub(now)
+	if lifetime < p.MinExpiry || lifetime <= 0 {
+		return fault.New("portal key expires too soon",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal(fmt.Sprintf("expiry %s away is below the minimum of %s", lifetime, p.MinExpiry)),
+			fault.Public(fmt.Sprintf("`expires` must be at least %s in the future.", formatDuration(max(p.MinExpiry, time.Millisecond)))),
+		)
+	}
+	if p.MaxExpiry > 0 && lifetime > p.MaxExpiry {
+		return fault.New("portal key expires too late",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal(fmt.Sprintf("expiry %s away is above the maximum of %s", lifetime, p.MaxExpiry)),
+			fault.Public(fmt.Sprintf("`expires` must be at most %s in the future.", formatDuration(p.MaxExpiry))),
+		)
+	}
+	return nil
+}
+
+// CheckPermissions rejects any permission slug the portal does not allow.
+func (p KeyPolicy) CheckPermissions(slugs []string) error {
+	for _, slug := range slugs {
+		if !slices.Contains(p.AllowedPermissions, slug) {
+			return fault.New("portal permission not allowed",
+				fault.Code(codes.Auth.Authorization.Forbidden.URN()),
+				fault.Internal(fmt.Sprintf("permission %q is not in the portal allow list", slug)),
+				fault.Public(fmt.Sprintf("Permission '%s' cannot be added to keys created here.", slug)),
+			)
+		}
+	}
+	return nil
+}
+
+// CheckRoles rejects any role name the portal does not allow.
+func (p KeyPolicy) CheckRoles(names []string) error {
+	for _, name := range names {
+		if !slices.Contains(p.AllowedRoles, name) {
+			return fault.New("portal role not allowed",
+				fault.Code(codes.Auth.Authorization.Forbidden.URN()),
+				fault.Internal(fmt.Sprintf("role %q is not in the portal allow list", name)),
+				fault.Public(fmt.Sprintf("Role '%s' cannot be added to keys created here.", name)),
+			)
+		}
+	}
+	return nil
+}
+
+// formatDuration renders a policy bound in whole days when it is one, which
+// is how the dashboard configures them, and in Go duration syntax otherwise.
+func formatDuration(d time.Duration) string {
+	const day = 24 * time.Hour
+	if d >= day && d%day == 0 {
+		if d == day {
+			return "1 day"
+		}
+		return fmt.Sprintf("%d days", d/day)
+	}
+	return d.String()
+}
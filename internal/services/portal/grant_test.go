@@ -0,0 +1,115 @@
+package portal
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/ptr"
+)
+
+func TestPolicyFromPortal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("null columns leave every dimension open but allow no permissions", func(t *testing.T) {
+		t.Parallel()
+
+		policy, err := PolicyFromPortal(db.Portal{})
+		require.NoError(t, err)
+		require.Equal(t, KeyPolicy{}, policy)
+	})
+
+	t.Run("decodes every column", func(t *testing.T) {
+		t.Parallel()
+
+		policy, err := PolicyFromPortal(db.Portal{
+			MaxKeys:            sql.NullInt32{Int32: 3, Valid: true},
+			AllowedPermissions: []byte(`["documents.read"]`),
+			AllowedRoles:       []byte(`["viewer"]`),
+			RatelimitTemplates: []byte(`[{"name":"requests","limit":100,"duration":60000,"autoApply":true}]`),
+			MinExpiryMs:        nullInt(24 * 60 * 60 * 1000),
+			MaxExpiryMs:        nullInt(90 * 24 * 60 * 60 * 1000),
+		})
+		require.NoError(t, err)
+		require.Equal(t, KeyPolicy{
+			MaxKeys:            3,
+			AllowedPermissions: []string{"documents.read"},
+			AllowedRoles:       []string{"viewer"},
+			RatelimitTemplates: []RatelimitTemplate{{Name: "requests", Limit: 100, Duration: 60000, AutoApply: true}},
+			MinExpiry:          24 * time.Hour,
+			MaxExpiry:          90 * 24 * time.Hour,
+		}, policy)
+	})
+
+	t.Run("malformed json is an error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := PolicyFromPortal(db.Portal{AllowedRoles: []byte(`{"viewer":true}`)})
+		require.Error(t, err)
+	})
+}
+
+func TestKeyPolicyCheckKeyCount(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, KeyPolicy{}.CheckKeyCount(1000), "zero means unlimited")
+	require.NoError(t, KeyPolicy{MaxKeys: 2}.CheckKeyCount(1))
+	require.Error(t, KeyPolicy{MaxKeys: 2}.CheckKeyCount(2))
+}
+
+func TestKeyPolicyCheckExpiry(t *testing.T) {
+	t.Parallel()
+
+	now := time.UnixMilli(nowMs)
+	in := func(d time.Duration) *int64 { return ptr.P(now.Add(d).UnixMilli()) }
+
+	bounded := KeyPolicy{MinExpiry: time.Hour, MaxExpiry: 30 * 24 * time.Hour}
+
+	tests := []struct {
+		name    string
+		policy  KeyPolicy
+		expires *int64
+		wantErr bool
+	}{
+		{name: "open policy allows no expiry", policy: KeyPolicy{}, expires: nil, wantErr: false},
+		{name: "open policy allows any future expiry", policy: KeyPolicy{}, expires: in(time.Minute), wantErr: false},
+		{name: "an expiry in the past is rejected", policy: KeyPolicy{}, expires: in(-time.Minute), wantErr: true},
+		{name: "a max requires an expiry", policy: bounded, expires: nil, wantErr: true},
+		{name: "below the min", policy: bounded, expires: in(30 * time.Minute), wantErr: true},
+		{name: "within bounds", policy: bounded, expires: in(7 * 24 * time.Hour), wantErr: false},
+		{name: "exactly the max", policy: bounded, expires: in(30 * 24 * time.Hour), wantErr: false},
+		{name: "above the max", policy: bounded, expires: in(31 * 24 * time.Hour), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.policy.CheckExpiry(tt.expires, now)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestKeyPolicyCheckPermissionsAndRoles(t *testing.T) {
+	t.Parallel()
+
+	policy := KeyPolicy{
+		AllowedPermissions: []string{"documents.read", "documents.write"},
+		AllowedRoles:       []string{"viewer"},
+	}
+
+	require.NoError(t, policy.CheckPermissions(nil))
+	require.NoError(t, policy.CheckPermissions([]string{"documents.read"}))
+	require.Error(t, policy.CheckPermissions([]string{"documents.read", "admin"}))
+
+	require.NoError(t, policy.CheckRoles([]string{"viewer"}))
+	require.Error(t, policy.CheckRoles([]string{"owner"}))
+
+	require.Error(t, KeyPolicy{}.CheckPermissions([]string{"documents.read"}), "an empty allow list allows nothing")
+}
@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// bucketLevel is the number of tokens a token bucket held when one window
+// cell opened. Published values are immutable so a reader always sees a
+// consistent (sequence, tokens) pair through a single atomic pointer load.
+type bucketLevel struct {
+	sequence int64
+	tokens   int64
+}
+
+// refillBucket folds one closed window cell into a bucket level: the bucket
+// regains refillRate tokens over the cell, never holding more than capacity,
+// and loses what the cell consumed. The result can be negative when nodes
+// admitted more than the level allowed, which later refills pay back.
+func refillBucket(tokens, capacity, refillRate, consumed int64) int64 {
+	return min(capacity, tokens+refillRate) - consumed
+}
+
+// bucketTokens returns the tokens a token bucket held when window cell seq
+// opened. prev is the already-refreshed counter for cell seq-1.
+//
+// A token bucket is not additive, but the counters Redis and
+// ratelimit_global_counters converge on are, so the level is derived from
+// them instead of being stored: every node replays the same per-cell
+// consumption and arrives at the same level. Each node carries the level at
+// the start of the previous cell in s.buckets and advances it as cells close,
+// which keeps the full history without keeping old counters around. The
+// previous cell is folded in fresh on every request because late increments
+// can still reach it from Redis and other regions.
+//
+// A node without a carried level, or whose level is older than the counters
+// it could replay, assumes the bucket was full two cells ago. That bounds how
+// far a cold node can over-admit to what those two cells would have refilled.
+func (s *service) bucketTokens(ctx context.Context, req RatelimitRequest, seq int64, prev *counterEntry) int64 {
+	key := bucketKey{
+		workspaceID: req.WorkspaceID,
+		namespace:   req.Namespace,
+		identifier:  req.Identifier,
+		durationMs:  req.Duration.Milliseconds(),
+		capacity:    req.Limit,
+		refillRate:  req.RefillRate,
+	}
+	v, _ := s.buckets.LoadOrStore(key, &atomic.Pointer[bucketLevel]{})
+	carried := v.(*atomic.Pointer[bucketLevel])
+
+	// The settled level is the one at the start of the previous cell. A
+	// carried level ahead of it belongs to a request with a later timestamp
+	// and cannot be rewound, so rebuild from a full bucket instead.
+	target := seq - 1
+	level := carried.Load()
+	if level == nil || level.sequence < target-1 || level.sequence > target {
+		level = &bucketLevel{sequence: target - 1, tokens: req.Limit}
+	}
+
+	if level.sequence < target {
+		cellKey := counterKey{
+			workspaceID: req.WorkspaceID,
+			namespace:   req.Namespace,
+			identifier:  req.Identifier,
+			durationMs:  key.durationMs,
+			sequence:    level.sequence,
+		}
+		cell := s.loadCounter(cellKey)
+		cell.EnsureFreshFromOrigin(ctx, req.Time)
+
+		consumed := cell.val.Load() + cell.globalCount.Load()
+		level = &bucketLevel{
+			sequence: target,
+			tokens:   refillBucket(level.tokens, req.Limit, req.RefillRate, consumed),
+		}
+		publishBucketLevel(carried, level)
+	}
+
+	consumed := prev.val.Load() + prev.globalCount.Load()
+	return refillBucket(level.tokens, req.Limit, req.RefillRate, consumed)
+}
+
+// publishBucketLevel stores level unless a level for the same or a later cell
+// is already published. Concurrent requests that advance the same bucket
+// compute the same level from the same counters, so losing the race is fine.
+func publishBucketLevel(carried *atomic.Pointer[bucketLevel], level *bucketLevel) {
+	for range maxCASRetries {
+		cur := carried.Load()
+		if cur != nil && cur.sequence >= level.sequence {
+			return
+		}
+		if carried.CompareAndSwap(cur, level) {
+			return
+		}
+	}
+}
@@ -1,6 +1,6 @@
 /*
-Package ratelimit implements lockless distributed rate limiting using a sliding window
-algorithm with atomic counters.
+Package ratelimit implements lockless distributed rate limiting using sliding-window,
+fixed-window, and token-bucket algorithms over atomic window counters.
 
 # Architecture
 
@@ -33,9 +33,13 @@ while still sharing counts once they can affect another region's decision.
 The floor is stored as a threshold rather than a request-path latch so regional
 Redis convergence can make an entry eligible after the request that created it.
 
-# Rate limit algorithm
+# Rate limit algorithms
 
-The sliding window implementation:
+Every algorithm counts consumed tokens in the same per-window cells, so local
+counters, Redis replay, and ratelimit_global_counters work the same way for all
+of them. [RatelimitRequest.Algorithm] only changes the arithmetic over the cells.
+
+The sliding window implementation, which is the default:
 
  1. Computes sequence numbers for current and previous windows from the request time.
  2. Loads both atomic counters from the sync.Map (creating them if needed).
@@ -45,12 +49,47 @@ The sliding window implementation:
  5. Otherwise, atomically commits the current-window increment and buffers the
     request for async replay to Redis.
 
+[AlgorithmFixedWindow] skips the previous window entirely. Windows are aligned to
+multiples of Duration since the Unix epoch rather than to calendar units: hourly and
+daily limits reset on UTC hours and days, but a weekly limit resets on Thursdays,
+the weekday of the epoch, and months and time zones are not expressible. Every
+counter, local, Redis, and MySQL, is keyed by the window sequence now/Duration,
+which only a fixed-length window has.
+
+[AlgorithmTokenBucket] treats Limit as the bucket capacity and RefillRate as the
+tokens regained per Duration. A bucket level is not a sum, so it cannot live in
+Redis or MySQL next to the counters. Instead each node carries the level at the
+start of the previous window and advances it from the shared per-window counts as
+windows close: every node replays the same consumption and converges on the same
+level. Within the current window the bucket refills linearly up to its capacity.
+A node with no carried level assumes the bucket was full two windows ago, which
+bounds how far a cold node can over-admit. Cells read by a token bucket are
+shared across regions for one window longer than sliding-window cells.
+
 For [Service.RatelimitMany], the package applies all increments first, evaluates
 every requested limit against the post-increment values, then either keeps the
 entire batch or rolls every increment back. The cross-region push subtracts those
 speculative increments while a batch is in flight, so a batch that rolls back
 cannot publish temporary state to ratelimit_global_counters.
 
+# Leases
+
+[Service.Acquire] enforces a max-in-flight limit instead of a rate. A lease counts
+against the limit until [Service.Release] returns it or its TTL lapses, whichever
+comes first, so a crashed worker only pins capacity for one TTL.
+
+Leases converge within a region through the same Redis origin as window counters.
+Redis keeps one counter per (workspace, namespace, identifier) and expiry slot of
+[LeaseSlotDuration]; the in-flight cost is the sum of every slot that has not ended
+yet, so expired leases drop out of the count without anyone releasing them. Each
+lease also keeps its cost under its own key, which makes releases idempotent.
+Acquisitions add optimistically and roll back when the sum exceeds the limit,
+mirroring RatelimitMany.
+
+Each node also tracks the leases it granted. It decides on those alone while Redis
+is unavailable, and the janitor releases them once their TTL lapses. Leases are not
+shared across regions.
+
 # Thread safety
 
 All operations are safe for concurrent use without external synchronization.
@@ -78,9 +78,11 @@ func (s *service) startGlobalPush() {
 // MySQL in a single bulk upsert. Wrapped in globalCircuitBreaker so a
 // sick database fails fast rather than blocking subsequent ticks.
 //
-// expires_at is sequence-derived ((sequence+2) * duration_ms): the row
-// matters until the counter rotates out of sliding-window math, after
-// which receivers ignore it regardless of clock drift between regions.
+// expires_at is sequence-derived ((sequence+cells) * duration_ms, where
+// cells is the widest [Algorithm.windowCells] that committed to the entry):
+// the row matters until the counter rotates out of every decision that reads
+// it, after which receivers ignore it regardless of clock drift between
+// regions.
 //
 // lastPushed is committed only after the upsert succeeds, so a transient
 // MySQL failure leaves entries eligible for retry on the next tick.
@@ -123,7 +125,7 @@ func (s *service) runGlobalPushOnce() {
 				Sequence:    key.sequence,
 				Region:      s.region,
 				Count:       uint64(val),
-				ExpiresAt:   uint64((key.sequence + 2) * key.durationMs),
+				ExpiresAt:   uint64(entry.globalExpiresAtMs(key)),
 				UpdatedAt:   uint64(nowMs),
 			},
 		})
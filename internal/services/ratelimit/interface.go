@@ -1,4 +1,5 @@
-// Package ratelimit provides distributed rate limiting with sliding-window counters.
+// Package ratelimit provides distributed rate limiting with sliding-window,
+// fixed-window, and token-bucket algorithms built on shared window counters.
 package ratelimit
 
 import (
@@ -6,14 +7,64 @@ import (
 	"time"
 )
 
+// Algorithm selects how a [RatelimitRequest] turns window counters into a
+// decision. Every algorithm counts consumed tokens in the same per-window
+// cells, so local counters, Redis convergence, and cross-region propagation
+// are shared; only the arithmetic over those cells differs.
+type Algorithm string
+
+const (
+	// AlgorithmSlidingWindow counts the current window plus a weighted share
+	// of the previous one, smoothing the burst a fixed window allows at its
+	// boundary. It is the default when Algorithm is empty.
+	AlgorithmSlidingWindow Algorithm = "sliding_window"
+
+	// AlgorithmFixedWindow counts only the current window. Windows are
+	// epoch-aligned, not calendar-aligned: they start at multiples of Duration
+	// since the Unix epoch, so an hourly or daily limit resets on the UTC hour
+	// or day, a weekly one on Thursdays at 00:00 UTC, and there are no
+	// monthly or time zone aligned windows.
+	AlgorithmFixedWindow Algorithm = "fixed_window"
+
+	// AlgorithmTokenBucket treats Limit as the bucket capacity and refills
+	// RefillRate tokens every Duration, so a client can burst up to Limit and
+	// then sustain RefillRate per Duration.
+	AlgorithmTokenBucket Algorithm = "token_bucket"
+)
+
+// windowCells returns how many consecutive window cells, counting the
+// current one, the algorithm reads when deciding. Counter state for a cell
+// must stay reachable locally and across regions for this many windows after
+// the cell opens.
+func (a Algorithm) windowCells() int64 {
+	switch a {
+	case AlgorithmFixedWindow:
+		return 1
+	case AlgorithmTokenBucket:
+		return 3
+	case AlgorithmSlidingWindow:
+	}
+	return 2
+}
+
+// valid reports whether a is a known algorithm or empty.
+func (a Algorithm) valid() bool {
+	switch a {
+	case "", AlgorithmSlidingWindow, AlgorithmFixedWindow, AlgorithmTokenBucket:
+		return true
+	default:
+		return false
+	}
+}
+
 // Service checks and consumes rate-limit tokens.
 //
 // Implementations must be safe for concurrent use. The concrete service in
-// this package enforces a sliding window locally, converges nodes within a
+// this package enforces the requested [Algorithm] locally, converges nodes within a
 // region through Redis, and imports foreign-region counts through MySQL.
 type Service interface {
 	// Ratelimit checks one limit and consumes req.Cost tokens when the request
-	// fits in the sliding window. A denied request returns a nil error with
+	// fits under the limit. A denied request returns a nil error with
 	// RatelimitResponse.Success set to false; validation failures return an
 	// empty response and a non-nil error.
 	Ratelimit(context.Context, RatelimitRequest) (RatelimitResponse, error)
@@ -23,9 +74,28 @@ type Service interface {
 	// effects are committed only when every request passes. If any request fails,
 	// all optimistic increments are rolled back before the method returns.
 	RatelimitMany(context.Context, []RatelimitRequest) ([]RatelimitResponse, error)
+
+	// Peek reports whether req would pass without consuming anything or
+	// otherwise changing limiter state: a denied peek does not enter strict
+	// mode and nothing is replayed to Redis or propagated to other regions.
+	// Remaining and Current describe the window before req.Cost, and Success
+	// says whether req.Cost fits in Remaining.
+	Peek(context.Context, RatelimitRequest) (RatelimitResponse, error)
+
+	// Acquire takes a lease of req.Cost against a max-in-flight limit. Unlike
+	// a window, a lease keeps counting until it is released or its TTL
+	// lapses. A denied lease returns a nil error with AcquireResponse.Success
+	// set to false; validation failures return an empty response and a
+	// non-nil error.
+	Acquire(context.Context, AcquireRequest) (AcquireResponse, error)
+
+	// Release returns a lease before its TTL lapses and reports whether it
+	// was still held. Releasing a lease twice, or after it expired, is a
+	// no-op that reports false.
+	Release(context.Context, ReleaseRequest) (bool, error)
 }
 
-// RatelimitRequest describes one sliding-window rate-limit check.
+// RatelimitRequest describes one rate-limit check.
 //
 // WorkspaceID, Namespace, Identifier, Duration, and Time select the window cell.
 // Limit and Cost determine whether that cell can accept more work. The value is
@@ -57,17 +127,29 @@ type RatelimitRequest struct {
 
 	// Limit specifies the maximum number of tokens allowed within Duration.
 	// Once this limit is reached, subsequent requests will be denied until the
-	// window rolls over.
+	// window rolls over. For [AlgorithmTokenBucket] it is the bucket capacity.
 	//
 	// Must be greater than 0.
 	Limit int64
 
 	// Duration specifies the time window for the rate limit.
 	// After this duration, a new window begins and the token count resets.
+	// For [AlgorithmTokenBucket] it is the refill interval.
 	//
 	// Must be at least 1 second.
 	Duration time.Duration
 
+	// Algorithm selects the rate-limit algorithm. Empty means
+	// [AlgorithmSlidingWindow].
+	Algorithm Algorithm
+
+	// RefillRate is the number of tokens a token bucket regains every
+	// Duration. Zero means Limit, so the empty bucket refills completely
+	// within one Duration. Ignored by the window algorithms.
+	//
+	// Must be between 0 and Limit.
+	RefillRate int64
+
 	// Cost specifies the number of tokens to consume in this request.
 	// Higher values can be used for operations that should count more
 	// heavily against the rate limit, such as batch operations.
@@ -76,7 +158,7 @@ type RatelimitRequest struct {
 	// tokens. The service does not default zero to one.
 	Cost int64
 
-	// Time is the request timestamp used to choose the sliding-window sequence.
+	// Time is the request timestamp used to choose the window sequence.
 	// If zero, the service uses its own clock.
 	Time time.Time
 }
@@ -98,18 +180,99 @@ type RatelimitResponse struct {
 
 	// Reset is when the current fixed window expires. Sliding-window math still
 	// carries a weighted portion of the previous window after this timestamp.
+	// For a token bucket it is when the bucket is full again, or, for a denied
+	// request, when enough tokens will have refilled to cover its cost.
 	Reset time.Time
 
 	// Success indicates whether the rate limit check passed.
 	// When false, callers can use Reset to decide when to retry.
 	Success bool
 
-	// Current is the effective sliding-window count after applying the request
-	// cost. It includes the weighted previous window and imported cross-region
-	// counts when those values are present.
+	// Current is the effective count after applying the request cost. For the
+	// sliding window it includes the weighted previous window, and for a token
+	// bucket it is the number of tokens missing from a full bucket. Imported
+	// cross-region counts are included when those values are present.
 	Current int64
 }
 
+// AcquireRequest describes one lease acquisition.
+//
+// WorkspaceID, Namespace, and Identifier select the set of leases the limit
+// applies to. Leases are kept apart from window counters, so a namespace and
+// identifier can carry both a windowed limit and a concurrency limit.
+type AcquireRequest struct {
+	// WorkspaceID scopes every other field of this request.
+	//
+	// Must be non-empty.
+	WorkspaceID string
+
+	// Namespace identifies the concurrency limit within a workspace. The
+	// ratelimit API uses the namespace row ID.
+	//
+	// Must be non-empty.
+	Namespace string
+
+	// Identifier uniquely identifies the subject holding the leases, such as
+	// a customer or job queue.
+	//
+	// Must be non-empty.
+	Identifier string
+
+	// Limit is the maximum total cost of leases held at the same time.
+	//
+	// Must be greater than 0.
+	Limit int64
+
+	// Cost is the share of Limit this lease holds until it is released.
+	//
+	// Must be greater than 0.
+	Cost int64
+
+	// TTL bounds how long the lease is held if it is never released, so a
+	// crashed worker cannot pin capacity forever. Expiry is rounded up to
+	// the next [LeaseSlotDuration] boundary.
+	//
+	// Must be between [MinLeaseTTL] and [MaxLeaseTTL].
+	TTL time.Duration
+
+	// Time is the request timestamp the TTL counts from. If zero, the
+	// service uses its own clock.
+	Time time.Time
+}
+
+// AcquireResponse contains the result of a lease acquisition.
+type AcquireResponse struct {
+	// Success indicates whether the lease was granted.
+	Success bool
+
+	// LeaseID identifies the granted lease for [Service.Release]. It is
+	// empty when Success is false.
+	LeaseID string
+
+	// Limit is the maximum in-flight cost from the request.
+	Limit int64
+
+	// Remaining is how much of Limit is still free after this request.
+	Remaining int64
+
+	// Current is the in-flight cost including this lease when it was
+	// granted, or excluding it when it was denied.
+	Current int64
+
+	// ExpiresAt is when the lease lapses unless it is released first. It is
+	// the zero time when Success is false.
+	ExpiresAt time.Time
+}
+
+// ReleaseRequest identifies a lease to release. WorkspaceID, Namespace, and
+// Identifier must match the [AcquireRequest] that granted it.
+type ReleaseRequest struct {
+	WorkspaceID string
+	Namespace   string
+	Identifier  string
+	LeaseID     string
+}
+
 // Middleware wraps a [Service] with cross-cutting behavior such as logging,
 // metrics, or validation.
 type Middleware func(Service) Service
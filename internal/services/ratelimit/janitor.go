@@ -15,11 +15,16 @@ func (s *service) startJanitor() {
 }
 
 // runJanitorOnce performs a single cleanup pass. It prevents unbounded
-// memory growth from two sources:
+// memory growth from these sources:
 //
 //   - Sliding-counters whose window ended more than 3x its duration
 //     ago.
 //   - Strict-mode deadlines that are already in the past.
+//   - Token-bucket levels too old for bucketTokens to advance, which it
+//     would rebuild from a full bucket anyway.
+//   - Leases whose TTL lapsed without a release. Redis already stopped
+//     counting them; removing them here releases them for local fallback
+//     decisions too.
 //
 // Uses sync.Map.Range + CompareAndDelete so cleanup never blocks rate
 // limit operations.
@@ -51,4 +56,36 @@ func (s *service) runJanitorOnce() {
 		}
 		return true
 	})
+
+	// A level carried for cell N is advanced by requests in cell N+2 at the
+	// latest, so once cell N+3 has opened nothing will read it again.
+	s.buckets.Range(func(key, value any) bool {
+		k := key.(bucketKey)
+		level := value.(*atomic.Pointer[bucketLevel]).Load()
+		if level == nil || nowMs >= (level.sequence+3)*k.durationMs {
+			s.buckets.CompareAndDelete(key, value)
+		}
+		return true
+	})
+
+	held, expired := 0, 0
+	s.leases.Range(func(key, value any) bool {
+		table := value.(*leaseTable)
+		table.mu.Lock()
+		for id, l := range table.held {
+			if l.expiresAtMs <= nowMs {
+				delete(table.held, id)
+				expired++
+			}
+		}
+		held += len(table.held)
+		// Delete under the lock so lockLeaseTable notices the table is gone.
+		if len(table.held) == 0 {
+			s.leases.CompareAndDelete(key, value)
+		}
+		table.mu.Unlock()
+		return true
+	})
+	metrics.RatelimitLeasesExpired.Add(float64(expired))
+	metrics.RatelimitLeases.Set(float64(held))
 }
@@ -1,6 +1,7 @@
 package ratelimit
 
 import (
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -67,3 +68,36 @@ func TestJanitor_EvictsExpiredStrictUntils(t *testing.T) {
 	require.False(t, pastStillExists, "past deadline should be evicted")
 	require.True(t, futureStillExists, "future deadline should survive")
 }
+
+// TestJanitor_EvictsStaleBucketLevels asserts carried token-bucket levels are
+// dropped once no request can advance them, while current ones are kept.
+func TestJanitor_EvictsStaleBucketLevels(t *testing.T) {
+	t.Parallel()
+
+	clk := clock.NewTestClock()
+	svc, err := New(Config{
+		Clock: clk, Counter: counter.NewMemory(), DB: newTestDB(t), Region: "test-region"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = svc.Close() })
+
+	duration := time.Minute
+	durationMs := duration.Milliseconds()
+	seq := calculateSequence(clk.Now(), duration)
+
+	store := func(identifier string, sequence int64) bucketKey {
+		key := bucketKey{workspaceID: "ws", namespace: "ns", identifier: identifier, durationMs: durationMs, capacity: 10, refillRate: 1}
+		level := &atomic.Pointer[bucketLevel]{}
+		level.Store(&bucketLevel{sequence: sequence, tokens: 5})
+		svc.buckets.Store(key, level)
+		return key
+	}
+	staleKey := store("stale", seq-3)
+	freshKey := store("fresh", seq-1)
+
+	svc.runJanitorOnce()
+
+	_, staleStillExists := svc.buckets.Load(staleKey)
+	_, freshStillExists := svc.buckets.Load(freshKey)
+	require.False(t, staleStillExists, "stale bucket level should be evicted")
+	require.True(t, freshStillExists, "current bucket level should survive")
+}
@@ -29,3 +29,39 @@ type strictKey struct {
 	identifier  string
 	durationMs  int64
 }
+
+// bucketKey identifies the carried token level of one token bucket. Capacity
+// and refill rate are part of the key because the level is derived from them:
+// two policies sharing an identifier but configured with different buckets
+// read the same window counters yet must not share a level.
+type bucketKey struct {
+	workspaceID string
+	namespace   string
+	identifier  string
+	durationMs  int64
+	capacity    int64
+	refillRate  int64
+}
+
+// leaseKey identifies the leases held by one (workspace, namespace,
+// identifier). Leases are not windowed, so there is no duration or sequence.
+type leaseKey struct {
+	workspaceID string
+	namespace   string
+	identifier  string
+}
+
+// slotRedisKey returns the Redis key summing the cost of every lease whose
+// expiry falls into slot.
+// Format: "{workspaceID}-{namespace}-{identifier}-lease:{slot}".
+func (k leaseKey) slotRedisKey(slot int64) string {
+	return fmt.Sprintf("%s-%s-%s-lease:%d", k.workspaceID, k.namespace, k.identifier, slot)
+}
+
+// leaseRedisKey returns the Redis key holding the cost of a single lease
+// until it is released. Lease IDs contain a letter, so they never collide
+// with a numeric slot.
+// Format: "{workspaceID}-{namespace}-{identifier}-lease:{leaseID}".
+func (k leaseKey) leaseRedisKey(leaseID string) string {
+	return fmt.Sprintf("%s-%s-%s-lease:%s", k.workspaceID, k.namespace, k.identifier, leaseID)
+}
@@ -0,0 +1,361 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/unkeyed/unkey/pkg/assert"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/pkg/otel/tracing"
+	"github.com/unkeyed/unkey/pkg/uid"
+
+	"github.com/unkeyed/unkey/internal/services/ratelimit/metrics"
+)
+
+const (
+	// LeaseSlotDuration is the granularity of lease expiry. Redis sums lease
+	// costs per slot of expiry, so a lease is counted until the end of the
+	// slot its TTL lapses in.
+	LeaseSlotDuration = 5 * time.Second
+
+	// MinLeaseTTL is the shortest TTL [Service.Acquire] accepts.
+	MinLeaseTTL = time.Second
+
+	// MaxLeaseTTL is the longest TTL [Service.Acquire] accepts. It bounds how
+	// many slots an acquisition reads: MaxLeaseTTL / LeaseSlotDuration + 1.
+	MaxLeaseTTL = 10 * time.Minute
+)
+
+// leaseOriginTimeout caps the Redis round trips of one acquisition or
+// release. An acquisition makes up to three, so this is looser than
+// originFetchTimeout, but still short enough that a stalled Redis falls back
+// to local state instead of holding up the caller.
+const leaseOriginTimeout = time.Second
+
+// heldLease is a lease this node granted and has not seen released.
+type heldLease struct {
+	cost        int64
+	expiresAtMs int64
+}
+
+// leaseTable holds the leases this node granted for one leaseKey. Redis is
+// the source of truth within a region; the table is what the node decides on
+// while Redis is unavailable, and what the janitor releases once TTLs lapse.
+type leaseTable struct {
+	mu   sync.Mutex
+	held map[string]heldLease
+}
+
+// inFlight returns the cost of unexpired leases in the table. The caller
+// must hold t.mu.
+func (t *leaseTable) inFlight(nowMs int64) int64 {
+	total := int64(0)
+	for _, l := range t.held {
+		if l.expiresAtMs > nowMs {
+			total += l.cost
+		}
+	}
+	return total
+}
+
+// leaseSlot returns the slot expiresAt falls into. Slots are rounded up, so
+// a lease is never dropped from the count before its TTL lapses.
+func leaseSlot(expiresAt time.Time) int64 {
+	slotMs := LeaseSlotDuration.Milliseconds()
+	return (expiresAt.UnixMilli() + slotMs - 1) / slotMs
+}
+
+// newLeaseID returns a random lease ID carrying its expiry slot, so any node
+// in the region can release it from the ID alone.
+func newLeaseID(slot int64) string {
+	return uid.New(uid.RatelimitLeasePrefix) + "." + strconv.FormatInt(slot, 36)
+}
+
+// parseLeaseID returns the expiry slot encoded by newLeaseID.
+func parseLeaseID(leaseID string) (int64, bool) {
+	i := strings.LastIndexByte(leaseID, '.')
+	if i < 0 {
+		return 0, false
+	}
+	slot, err := strconv.ParseInt(leaseID[i+1:], 36, 64)
+	if err != nil {
+		return 0, false
+	}
+	return slot, true
+}
+
+// validateAcquireRequest checks a request against the contract documented on
+// [AcquireRequest].
+func validateAcquireRequest(req AcquireRequest) error {
+	return assert.All(
+		assert.NotEmpty(req.WorkspaceID, "lease workspace id must not be empty"),
+		assert.NotEmpty(req.Namespace, "lease namespace must not be empty"),
+		assert.NotEmpty(req.Identifier, "lease identifier must not be empty"),
+		assert.Greater(req.Limit, 0, "lease limit must be greater than zero"),
+		assert.Greater(req.Cost, 0, "lease cost must be greater than zero"),
+		assert.GreaterOrEqual(req.TTL, MinLeaseTTL, "lease ttl must be at least 1s"),
+		assert.LessOrEqual(req.TTL, MaxLeaseTTL, "lease ttl must be at most 10m"),
+		assert.False(req.Time.IsZero(), "request time must not be zero"),
+	)
+}
+
+// Acquire grants a lease when the in-flight cost of the identifier plus
+// req.Cost fits under req.Limit.
+//
+// Within a region the decision is made against Redis with an optimistic add:
+// the cost is added to the slot of the lease's expiry first, every live slot
+// is summed, and the add is rolled back when the sum exceeds the limit. Two
+// racing acquisitions can therefore both be denied near the limit, but never
+// both granted past it. When Redis is unavailable the node decides on the
+// leases it granted itself, the same fallback window counters use.
+//
+// Leases are regional: they are not shared through ratelimit_global_counters.
+func (s *service) Acquire(ctx context.Context, req AcquireRequest) (AcquireResponse, error) {
+	ctx, span := tracing.Start(ctx, "Acquire")
+	defer span.End()
+
+	if req.Time.IsZero() {
+		req.Time = s.clock.Now()
+	}
+
+	err := validateAcquireRequest(req)
+	if err != nil {
+		return AcquireResponse{}, err
+	}
+
+	key := leaseKey{workspaceID: req.WorkspaceID, namespace: req.Namespace, identifier: req.Identifier}
+	expiresAt := req.Time.Add(req.TTL)
+	slot := leaseSlot(expiresAt)
+	leaseID := newLeaseID(slot)
+	nowMs := req.Time.UnixMilli()
+
+	// Holding the table across the origin round trips serializes this node's
+	// acquisitions for one identifier, which keeps them from denying each
+	// other in the optimistic add below.
+	table := s.lockLeaseTable(key)
+	defer table.mu.Unlock()
+
+	source := "origin"
+	current, err := s.acquireAtOrigin(ctx, key, leaseID, slot, req)
+	if err != nil {
+		metrics.RatelimitOriginErrors.WithLabelValues("lease_acquire", errorReason(err)).Inc()
+		if !isCircuitOpen(err) {
+			logger.Error("unable to acquire lease at origin",
+				"workspace_id", req.WorkspaceID,
+				"namespace", req.Namespace,
+				"error", err.Error(),
+			)
+		}
+		source = "local"
+		current = table.inFlight(nowMs) + req.Cost
+	}
+
+	if current > req.Limit {
+		metrics.RatelimitLeaseDecision.WithLabelValues(req.WorkspaceID, source, "denied").Inc()
+		span.SetAttributes(attribute.Bool("passed", false))
+		return AcquireResponse{
+			Success:   false,
+			LeaseID:   "",
+			Limit:     req.Limit,
+			Remaining: max(0, req.Limit-(current-req.Cost)),
+			Current:   current - req.Cost,
+			ExpiresAt: time.Time{},
+		}, nil
+	}
+
+	table.held[leaseID] = heldLease{cost: req.Cost, expiresAtMs: expiresAt.UnixMilli()}
+	metrics.RatelimitLeaseDecision.WithLabelValues(req.WorkspaceID, source, "acquired").Inc()
+	span.SetAttributes(attribute.Bool("passed", true))
+	return AcquireResponse{
+		Success:   true,
+		LeaseID:   leaseID,
+		Limit:     req.Limit,
+		Remaining: req.Limit - current,
+		Current:   current,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// acquireAtOrigin adds the lease to Redis and returns the in-flight cost
+// including it. A lease that does not fit is rolled back before returning,
+// so the caller only has to compare the result against the limit.
+func (s *service) acquireAtOrigin(ctx context.Context, key leaseKey, leaseID string, slot int64, req AcquireRequest) (int64, error) {
+	metrics.RatelimitOriginOperations.WithLabelValues("lease_acquire").Inc()
+
+	return s.originCircuitBreaker.Do(ctx, func(ctx context.Context) (int64, error) {
+		start := time.Now()
+		defer func() {
+			metrics.RatelimitOriginLatency.WithLabelValues("lease_acquire").Observe(time.Since(start).Seconds())
+		}()
+
+		ctx, cancel := context.WithTimeout(ctx, leaseOriginTimeout)
+		defer cancel()
+
+		// Keep the slot around for one more slot so a release racing the
+		// expiry still finds it.
+		slotMs := LeaseSlotDuration.Milliseconds()
+		ttl := time.Duration(slot*slotMs-req.Time.UnixMilli())*time.Millisecond + LeaseSlotDuration
+		slotKey := key.slotRedisKey(slot)
+
+		_, err := s.origin.Increment(ctx, slotKey, req.Cost, ttl)
+		if err != nil {
+			return 0, err
+		}
+
+		current, err := s.sumLeaseSlots(ctx, key, req.Time)
+		if err == nil && current <= req.Limit {
+			_, err = s.origin.SetIfNotExists(ctx, key.leaseRedisKey(leaseID), req.Cost, ttl)
+			if err == nil {
+				return current, nil
+			}
+		}
+
+		// Either the lease does not fit or we cannot tell, so undo the add.
+		// A failed rollback over-counts until the slot expires, which errs on
+		// the side of denying.
+		_, rollbackErr := s.origin.Decrement(ctx, slotKey, req.Cost, ttl)
+		if rollbackErr != nil {
+			logger.Error("unable to roll back lease at origin", "key", slotKey, "error", rollbackErr.Error())
+		}
+		return current, err
+	})
+}
+
+// sumLeaseSlots returns the cost of every lease in Redis that has not
+// expired at now. Only slots ending after now can hold live leases, and no
+// lease expires later than now plus MaxLeaseTTL.
+func (s *service) sumLeaseSlots(ctx context.Context, key leaseKey, now time.Time) (int64, error) {
+	slotMs := LeaseSlotDuration.Milliseconds()
+	first := now.UnixMilli()/slotMs + 1
+	last := leaseSlot(now.Add(MaxLeaseTTL))
+
+	keys := make([]string, 0, last-first+1)
+	for slot := first; slot <= last; slot++ {
+		keys = append(keys, key.slotRedisKey(slot))
+	}
+
+	values, err := s.origin.MultiGet(ctx, keys)
+	if err != nil {
+		return 0, err
+	}
+
+	total := int64(0)
+	for _, v := range values {
+		total += v
+	}
+	return total, nil
+}
+
+// Release removes a lease from Redis and from this node's table. The lease's
+// Redis key holds its cost, and only the caller that takes that cost back
+// out of it releases the slot, so concurrent or repeated releases of the same
+// lease return the capacity once.
+//
+// When Redis is unavailable the lease is only released locally; Redis drops
+// it once the TTL lapses.
+func (s *service) Release(ctx context.Context, req ReleaseRequest) (bool, error) {
+	ctx, span := tracing.Start(ctx, "Release")
+	defer span.End()
+
+	err := assert.All(
+		assert.NotEmpty(req.WorkspaceID, "lease workspace id must not be empty"),
+		assert.NotEmpty(req.Namespace, "lease namespace must not be empty"),
+		assert.NotEmpty(req.Identifier, "lease identifier must not be empty"),
+		assert.NotEmpty(req.LeaseID, "lease id must not be empty"),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	slot, ok := parseLeaseID(req.LeaseID)
+	if !ok {
+		return false, nil
+	}
+
+	key := leaseKey{workspaceID: req.WorkspaceID, namespace: req.Namespace, identifier: req.Identifier}
+	heldLocally := false
+	if v, found := s.leases.Load(key); found {
+		table := v.(*leaseTable)
+		table.mu.Lock()
+		_, heldLocally = table.held[req.LeaseID]
+		delete(table.held, req.LeaseID)
+		table.mu.Unlock()
+	}
+
+	metrics.RatelimitOriginOperations.WithLabelValues("lease_release").Inc()
+	cost, err := s.originCircuitBreaker.Do(ctx, func(ctx context.Context) (int64, error) {
+		ctx, cancel := context.WithTimeout(ctx, leaseOriginTimeout)
+		defer cancel()
+		return s.releaseAtOrigin(ctx, key, req.LeaseID, slot)
+	})
+	if err != nil {
+		metrics.RatelimitOriginErrors.WithLabelValues("lease_release", errorReason(err)).Inc()
+		if !isCircuitOpen(err) {
+			logger.Error("unable to release lease at origin",
+				"workspace_id", req.WorkspaceID,
+				"namespace", req.Namespace,
+				"error", err.Error(),
+			)
+		}
+		span.SetAttributes(attribute.Bool("released", heldLocally))
+		return heldLocally, nil
+	}
+
+	span.SetAttributes(attribute.Bool("released", cost > 0))
+	return cost > 0, nil
+}
+
+// releaseAtOrigin takes the lease's cost back out of its slot and returns it,
+// or 0 if the lease was already released or has expired.
+func (s *service) releaseAtOrigin(ctx context.Context, key leaseKey, leaseID string, slot int64) (int64, error) {
+	markerKey := key.leaseRedisKey(leaseID)
+
+	cost, err := s.origin.Get(ctx, markerKey)
+	if err != nil || cost <= 0 {
+		return 0, err
+	}
+
+	// Only one caller can take the full cost out of the lease key.
+	_, existed, success, err := s.origin.DecrementIfExists(ctx, markerKey, cost)
+	if err != nil || !existed || !success {
+		return 0, err
+	}
+
+	// The slot expires together with the lease key, so if it is gone the
+	// lease lapsed in between and there is nothing left to give back.
+	_, _, _, err = s.origin.DecrementIfExists(ctx, key.slotRedisKey(slot), cost)
+	if err != nil {
+		return 0, fmt.Errorf("unable to release lease slot: %w", err)
+	}
+
+	err = s.origin.Delete(ctx, markerKey)
+	if err != nil {
+		logger.Warn("unable to delete released lease", "key", markerKey, "error", err.Error())
+	}
+
+	return cost, nil
+}
+
+// lockLeaseTable returns the locked table for key, creating it if needed.
+// The janitor deletes empty tables under their lock, so a table that is no
+// longer in the map after locking it is dropped and the lookup retried;
+// otherwise a lease granted into it would be invisible to the next caller.
+func (s *service) lockLeaseTable(key leaseKey) *leaseTable {
+	for {
+		v, _ := s.leases.LoadOrStore(key, &leaseTable{ //nolint:exhaustruct // mu zero value is ready to use
+			held: make(map[string]heldLease),
+		})
+		table := v.(*leaseTable)
+		table.mu.Lock()
+		if current, ok := s.leases.Load(key); ok && current == table {
+			return table
+		}
+		table.mu.Unlock()
+	}
+}
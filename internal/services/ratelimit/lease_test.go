@@ -0,0 +1,186 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/circuitbreaker"
+	"github.com/unkeyed/unkey/pkg/clock"
+	"github.com/unkeyed/unkey/pkg/counter"
+	"github.com/unkeyed/unkey/pkg/uid"
+)
+
+// newLeaseTestService returns a service with only what leases use: a clock
+// and a regional origin. Leases never touch MySQL, so no container is needed.
+func newLeaseTestService(clk clock.Clock, origin counter.Counter) *service {
+	return &service{ //nolint:exhaustruct // leases only need the clock and origin
+		clock:                clk,
+		origin:               origin,
+		originCircuitBreaker: circuitbreaker.New[int64]("test_lease_origin"),
+	}
+}
+
+func acquireRequest(ws string, limit, cost int64, ttl time.Duration) AcquireRequest {
+	return AcquireRequest{
+		WorkspaceID: ws,
+		Namespace:   "jobs",
+		Identifier:  "customer_1",
+		Limit:       limit,
+		Cost:        cost,
+		TTL:         ttl,
+		Time:        time.Time{},
+	}
+}
+
+func releaseRequest(ws, leaseID string) ReleaseRequest {
+	return ReleaseRequest{WorkspaceID: ws, Namespace: "jobs", Identifier: "customer_1", LeaseID: leaseID}
+}
+
+// TestLease_AcquireUpToLimit asserts leases count until released and that a
+// release returns the capacity exactly once.
+func TestLease_AcquireUpToLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := newLeaseTestService(clock.NewTestClock(), counter.NewMemory())
+	ws := uid.New(uid.WorkspacePrefix)
+
+	first, err := svc.Acquire(ctx, acquireRequest(ws, 3, 2, time.Minute))
+	require.NoError(t, err)
+	require.True(t, first.Success)
+	require.NotEmpty(t, first.LeaseID)
+	require.Equal(t, int64(1), first.Remaining)
+
+	second, err := svc.Acquire(ctx, acquireRequest(ws, 3, 1, time.Minute))
+	require.NoError(t, err)
+	require.True(t, second.Success)
+	require.Equal(t, int64(0), second.Remaining)
+
+	denied, err := svc.Acquire(ctx, acquireRequest(ws, 3, 1, time.Minute))
+	require.NoError(t, err)
+	require.False(t, denied.Success)
+	require.Empty(t, denied.LeaseID)
+	require.Equal(t, int64(3), denied.Current, "a denied lease must not count")
+
+	released, err := svc.Release(ctx, releaseRequest(ws, first.LeaseID))
+	require.NoError(t, err)
+	require.True(t, released)
+
+	released, err = svc.Release(ctx, releaseRequest(ws, first.LeaseID))
+	require.NoError(t, err)
+	require.False(t, released, "a lease must only be released once")
+
+	third, err := svc.Acquire(ctx, acquireRequest(ws, 3, 2, time.Minute))
+	require.NoError(t, err)
+	require.True(t, third.Success)
+	require.Equal(t, int64(3), third.Current)
+}
+
+// TestLease_ExpiresAfterTTL asserts an unreleased lease stops counting once
+// its TTL lapses, and that the janitor drops it from the local table.
+func TestLease_ExpiresAfterTTL(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clk := clock.NewTestClock()
+	svc := newLeaseTestService(clk, counter.NewMemory())
+	ws := uid.New(uid.WorkspacePrefix)
+
+	res, err := svc.Acquire(ctx, acquireRequest(ws, 1, 1, 10*time.Second))
+	require.NoError(t, err)
+	require.True(t, res.Success)
+
+	res, err = svc.Acquire(ctx, acquireRequest(ws, 1, 1, 10*time.Second))
+	require.NoError(t, err)
+	require.False(t, res.Success)
+
+	// Expiry is rounded up to the next slot.
+	clk.Tick(10*time.Second + LeaseSlotDuration)
+
+	svc.runJanitorOnce()
+	_, tableExists := svc.leases.Load(leaseKey{workspaceID: ws, namespace: "jobs", identifier: "customer_1"})
+	require.False(t, tableExists, "janitor should drop the expired lease")
+
+	res, err = svc.Acquire(ctx, acquireRequest(ws, 1, 1, 10*time.Second))
+	require.NoError(t, err)
+	require.True(t, res.Success, "an expired lease must not count")
+}
+
+// TestLease_ConvergesWithinRegion asserts two nodes sharing one origin see
+// each other's leases, and that any node can release them.
+func TestLease_ConvergesWithinRegion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clk := clock.NewTestClock()
+	origin := counter.NewMemory()
+	nodeA := newLeaseTestService(clk, origin)
+	nodeB := newLeaseTestService(clk, origin)
+	ws := uid.New(uid.WorkspacePrefix)
+
+	res, err := nodeA.Acquire(ctx, acquireRequest(ws, 1, 1, time.Minute))
+	require.NoError(t, err)
+	require.True(t, res.Success)
+
+	denied, err := nodeB.Acquire(ctx, acquireRequest(ws, 1, 1, time.Minute))
+	require.NoError(t, err)
+	require.False(t, denied.Success)
+
+	released, err := nodeB.Release(ctx, releaseRequest(ws, res.LeaseID))
+	require.NoError(t, err)
+	require.True(t, released)
+
+	res, err = nodeA.Acquire(ctx, acquireRequest(ws, 1, 1, time.Minute))
+	require.NoError(t, err)
+	require.True(t, res.Success)
+}
+
+// TestLease_FallsBackToLocalState asserts a node keeps enforcing the limit
+// on its own leases while the origin is unavailable.
+func TestLease_FallsBackToLocalState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := newLeaseTestService(clock.NewTestClock(), newFailingCounter(errors.New("redis down")))
+	ws := uid.New(uid.WorkspacePrefix)
+
+	res, err := svc.Acquire(ctx, acquireRequest(ws, 2, 2, time.Minute))
+	require.NoError(t, err)
+	require.True(t, res.Success)
+
+	denied, err := svc.Acquire(ctx, acquireRequest(ws, 2, 1, time.Minute))
+	require.NoError(t, err)
+	require.False(t, denied.Success)
+
+	released, err := svc.Release(ctx, releaseRequest(ws, res.LeaseID))
+	require.NoError(t, err)
+	require.True(t, released)
+
+	res, err = svc.Acquire(ctx, acquireRequest(ws, 2, 1, time.Minute))
+	require.NoError(t, err)
+	require.True(t, res.Success)
+}
+
+func TestLease_Validation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := newLeaseTestService(clock.NewTestClock(), counter.NewMemory())
+	ws := uid.New(uid.WorkspacePrefix)
+
+	_, err := svc.Acquire(ctx, acquireRequest(ws, 1, 1, 500*time.Millisecond))
+	require.Error(t, err)
+
+	_, err = svc.Acquire(ctx, acquireRequest(ws, 1, 1, MaxLeaseTTL+time.Second))
+	require.Error(t, err)
+
+	_, err = svc.Acquire(ctx, acquireRequest(ws, 1, 0, time.Minute))
+	require.Error(t, err)
+
+	released, err := svc.Release(ctx, releaseRequest(ws, "not_a_lease"))
+	require.NoError(t, err)
+	require.False(t, released)
+}
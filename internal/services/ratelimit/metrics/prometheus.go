@@ -159,6 +159,8 @@ var (
 //   - op="fetch_strict" — forced GET after strict mode activates.
 //   - op="sync" — INCR from the replay workers. Latency here predicts how
 //     quickly local counters across nodes converge.
+//   - op="lease_acquire" — optimistic add and slot sum for a lease.
+//   - op="lease_release" — taking a lease's cost back out of its slot.
 var (
 	// RatelimitOriginOperations counts origin operations attempted by the
 	// ratelimit service. Use this as the primary source for operation rates;
@@ -313,3 +315,55 @@ var (
 		},
 	)
 )
+
+// Leases: concurrency limits held until released or expired. Redis holds the
+// regional count; these track what each node granted and what lapsed.
+var (
+	// RatelimitLeaseDecision counts lease acquisitions.
+	//
+	// Labels:
+	//   - workspace_id: the workspace the lease was requested for.
+	//   - source: "origin" when Redis decided; "local" when Redis was
+	//     unavailable and only this node's leases were counted.
+	//   - outcome: "acquired" or "denied".
+	//
+	// Example usage:
+	//   metrics.RatelimitLeaseDecision.WithLabelValues(workspaceID, "origin", "acquired").Inc()
+	RatelimitLeaseDecision = lazy.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "unkey",
+			Subsystem: "ratelimit",
+			Name:      "lease_decisions_total",
+			Help:      "Total number of lease acquisitions, labeled by workspace_id, source (origin|local) and outcome (acquired|denied).",
+		},
+		[]string{"workspace_id", "source", "outcome"},
+	)
+
+	// RatelimitLeases is the number of leases this node granted that are
+	// neither released nor expired, as of the last janitor pass.
+	//
+	// Example usage:
+	//   metrics.RatelimitLeases.Set(float64(held))
+	RatelimitLeases = lazy.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "unkey",
+			Subsystem: "ratelimit",
+			Name:      "leases",
+			Help:      "Current number of leases held on this node.",
+		},
+	)
+
+	// RatelimitLeasesExpired counts leases the janitor released because their
+	// TTL lapsed. A steady rate means workers crash or forget to release.
+	//
+	// Example usage:
+	//   metrics.RatelimitLeasesExpired.Add(float64(expired))
+	RatelimitLeasesExpired = lazy.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "unkey",
+			Subsystem: "ratelimit",
+			Name:      "leases_expired_total",
+			Help:      "Total number of leases released by the janitor after their TTL lapsed.",
+		},
+	)
+)
@@ -0,0 +1,72 @@
+package namespace
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/match"
+)
+
+// MatchOverride returns the override that applies to identifier at now.
+//
+// Expired overrides are ignored. Among the remaining matches the highest
+// priority wins; on equal priority an exact identifier beats a wildcard
+// pattern, and a pattern with more literal characters beats a broader one.
+// Remaining ties go to the lexicographically smaller pattern so every node
+// picks the same override.
+func MatchOverride(ns db.FindRatelimitNamespace, identifier string, now time.Time) (db.FindRatelimitNamespaceLimitOverride, bool, error) {
+	nowMilli := now.UnixMilli()
+
+	exact, found := ns.DirectOverrides[identifier]
+	if found && expired(exact, nowMilli) {
+		found = false
+	}
+
+	// WildcardOverrides is sorted by precedence, so the first live match is
+	// the best pattern.
+	for _, override := range ns.WildcardOverrides {
+		if found && override.Priority <= exact.Priority {
+			break
+		}
+		if expired(override, nowMilli) {
+			continue
+		}
+
+		ok, err := match.Wildcard(identifier, override.Identifier)
+		if err != nil {
+			return db.FindRatelimitNamespaceLimitOverride{}, false, err //nolint:exhaustruct
+		}
+		if ok {
+			return override, true, nil
+		}
+	}
+
+	if found {
+		return exact, true, nil
+	}
+
+	return db.FindRatelimitNamespaceLimitOverride{}, false, nil //nolint:exhaustruct
+}
+
+func expired(override db.FindRatelimitNamespaceLimitOverride, nowMilli int64) bool {
+	return override.ExpiresAt != 0 && override.ExpiresAt <= nowMilli
+}
+
+// sortWildcardOverrides orders patterns by the precedence MatchOverride
+// applies between them.
+func sortWildcardOverrides(overrides []db.FindRatelimitNamespaceLimitOverride) {
+	slices.SortFunc(overrides, func(a, b db.FindRatelimitNamespaceLimitOverride) int {
+		return cmp.Or(
+			cmp.Compare(b.Priority, a.Priority),
+			cmp.Compare(literalLen(b.Identifier), literalLen(a.Identifier)),
+			strings.Compare(a.Identifier, b.Identifier),
+		)
+	})
+}
+
+func literalLen(pattern string) int {
+	return len(pattern) - strings.Count(pattern, "*")
+}
@@ -0,0 +1,129 @@
+package namespace
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+)
+
+func TestMatchOverride(t *testing.T) {
+	t.Parallel()
+
+	now := time.UnixMilli(1_700_000_000_000)
+
+	type override = db.FindRatelimitNamespaceLimitOverride
+	parse := func(t *testing.T, overrides ...override) db.FindRatelimitNamespace {
+		raw, err := json.Marshal(overrides)
+		require.NoError(t, err)
+		//nolint:exhaustruct
+		return ParseNamespaceRow(db.FindRatelimitNamespaceRow{Overrides: raw})
+	}
+
+	tests := []struct {
+		name       string
+		overrides  []override
+		identifier string
+		wantID     string
+	}{
+		{
+			name:       "no overrides",
+			identifier: "user_1",
+			wantID:     "",
+		},
+		{
+			name:       "exact match",
+			overrides:  []override{{ID: "exact", Identifier: "user_1"}},
+			identifier: "user_1",
+			wantID:     "exact",
+		},
+		{
+			name: "exact beats wildcard on equal priority",
+			overrides: []override{
+				{ID: "wildcard", Identifier: "user_*"},
+				{ID: "exact", Identifier: "user_1"},
+			},
+			identifier: "user_1",
+			wantID:     "exact",
+		},
+		{
+			name: "higher priority wildcard beats exact",
+			overrides: []override{
+				{ID: "exact", Identifier: "org_1:tier_pro", Priority: 1},
+				{ID: "wildcard", Identifier: "org_*:tier_pro", Priority: 2},
+			},
+			identifier: "org_1:tier_pro",
+			wantID:     "wildcard",
+		},
+		{
+			name: "more literal characters win on equal priority",
+			overrides: []override{
+				{ID: "broad", Identifier: "org_*"},
+				{ID: "specific", Identifier: "org_*:tier_pro"},
+			},
+			identifier: "org_1:tier_pro",
+			wantID:     "specific",
+		},
+		{
+			name: "priority beats specificity",
+			overrides: []override{
+				{ID: "broad", Identifier: "org_*", Priority: 5},
+				{ID: "specific", Identifier: "org_*:tier_pro"},
+			},
+			identifier: "org_1:tier_pro",
+			wantID:     "broad",
+		},
+		{
+			name: "equal specificity breaks ties by pattern",
+			overrides: []override{
+				{ID: "b", Identifier: "*_1"},
+				{ID: "a", Identifier: "a_*"},
+			},
+			identifier: "a_1",
+			wantID:     "b",
+		},
+		{
+			name:       "expired exact match is ignored",
+			overrides:  []override{{ID: "exact", Identifier: "user_1", ExpiresAt: now.UnixMilli()}},
+			identifier: "user_1",
+			wantID:     "",
+		},
+		{
+			name: "expired wildcard falls through to the next match",
+			overrides: []override{
+				{ID: "expired", Identifier: "org_*:tier_pro", Priority: 9, ExpiresAt: now.Add(-time.Second).UnixMilli()},
+				{ID: "fallback", Identifier: "org_*"},
+			},
+			identifier: "org_1:tier_pro",
+			wantID:     "fallback",
+		},
+		{
+			name: "expired exact falls through to a wildcard",
+			overrides: []override{
+				{ID: "exact", Identifier: "user_1", ExpiresAt: now.Add(-time.Second).UnixMilli()},
+				{ID: "wildcard", Identifier: "user_*"},
+			},
+			identifier: "user_1",
+			wantID:     "wildcard",
+		},
+		{
+			name:       "override before its expiry applies",
+			overrides:  []override{{ID: "exact", Identifier: "user_1", ExpiresAt: now.Add(time.Second).UnixMilli()}},
+			identifier: "user_1",
+			wantID:     "exact",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, found, err := MatchOverride(parse(t, tt.overrides...), tt.identifier, now)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantID != "", found)
+			require.Equal(t, tt.wantID, got.ID)
+		})
+	}
+}
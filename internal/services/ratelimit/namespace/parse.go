@@ -1,6 +1,8 @@
 package namespace
 
 import (
+	"cmp"
+	"slices"
 	"strings"
 
 	"github.com/unkeyed/unkey/pkg/db"
@@ -30,6 +32,7 @@ func ParseNamespaceRow(row db.FindRatelimitNamespaceRow) db.FindRatelimitNamespa
 			result.WildcardOverrides = append(result.WildcardOverrides, override)
 		}
 	}
+	sortWildcardOverrides(result.WildcardOverrides)
 
 	return result
 }
@@ -58,6 +61,36 @@ func RowToNamespace(row db.FindManyRatelimitNamespacesRow) db.FindRatelimitNames
 			result.WildcardOverrides = append(result.WildcardOverrides, override)
 		}
 	}
+	sortWildcardOverrides(result.WildcardOverrides)
+
+	return result
+}
+
+// ParsePlanRow converts a raw DB row into a FindRatelimitPlan with parsed
+// limits, ordered by namespace and then by duration so every node applies
+// them in the same order.
+func ParsePlanRow(row db.FindRatelimitPlanRow) db.FindRatelimitPlan {
+	result := db.FindRatelimitPlan{
+		ID:          row.ID,
+		WorkspaceID: row.WorkspaceID,
+		Name:        row.Name,
+		CreatedAtM:  row.CreatedAtM,
+		UpdatedAtM:  row.UpdatedAtM,
+		Limits:      make([]db.FindRatelimitPlanLimit, 0),
+	}
+
+	limits, err := db.UnmarshalNullableJSONTo[[]db.FindRatelimitPlanLimit](row.Limits)
+	if err != nil {
+		return result
+	}
+
+	slices.SortFunc(limits, func(a, b db.FindRatelimitPlanLimit) int {
+		return cmp.Or(
+			cmp.Compare(a.NamespaceID, b.NamespaceID),
+			cmp.Compare(a.Duration, b.Duration),
+		)
+	})
+	result.Limits = append(result.Limits, limits...)
 
 	return result
 }
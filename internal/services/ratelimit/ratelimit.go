@@ -2,6 +2,7 @@ package ratelimit
 
 import (
 	"context"
+	"math"
 	"time"
 
 	"github.com/unkeyed/unkey/pkg/assert"
@@ -12,11 +13,14 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
-// checkState holds the precomputed state needed for a sliding window
+// checkState holds the precomputed state needed for one rate-limit
 // check. One instance is built per request by prepareCheck and consumed
 // by the CAS loop in Ratelimit (or the per-entry pass in RatelimitMany).
 type checkState struct {
-	cur       *counterEntry
+	cur *counterEntry
+
+	// prev is the previous window's counter. It is nil for
+	// AlgorithmFixedWindow, which never reads it.
 	prev      *counterEntry
 	strictKey strictKey
 
@@ -30,14 +34,26 @@ type checkState struct {
 	curGlobal  int64
 	prevGlobal int64
 
+	algorithm Algorithm
+	limit     int64
+
+	// refillRate and bucketTokens describe a token bucket: the tokens it
+	// regains per window and the tokens it held when the current window
+	// opened. bucketTokens can be negative when other nodes admitted more
+	// than this node's view allowed; the debt is repaid by later refills.
+	refillRate   int64
+	bucketTokens int64
+
 	curSequence   int64
 	windowElapsed float64
+	duration      time.Duration
+	now           time.Time
 	reset         time.Time
 	source        string
 }
 
-// prepareCheck loads both counters for a request and fetches from
-// Redis when strict mode is active for this identifier after a recent
+// prepareCheck loads the counters the request's algorithm reads and fetches
+// from Redis when strict mode is active for this identifier after a recent
 // denial.
 func (s *service) prepareCheck(ctx context.Context, req RatelimitRequest) checkState {
 	durationMs := req.Duration.Milliseconds()
@@ -48,13 +64,16 @@ func (s *service) prepareCheck(ctx context.Context, req RatelimitRequest) checkS
 	sk := strictKey{workspaceID: req.WorkspaceID, namespace: req.Namespace, identifier: req.Identifier, durationMs: durationMs}
 
 	cur := s.loadCounter(curKey)
-	prev := s.loadCounter(prevKey)
+	var prev *counterEntry
+	if req.Algorithm.windowCells() > 1 {
+		prev = s.loadCounter(prevKey)
+	}
 
 	// A cold (unhydrated) entry forces this caller to pay the synchronous
 	// fetch_cold or block inside Do until the first caller's fetch returns —
 	// either way the decision is informed by origin state, not local.
 	source := "local"
-	if !cur.hydrated.Load() || !prev.hydrated.Load() {
+	if !cur.hydrated.Load() || (prev != nil && !prev.hydrated.Load()) {
 		source = "origin"
 	}
 
@@ -63,7 +82,9 @@ func (s *service) prepareCheck(ctx context.Context, req RatelimitRequest) checkS
 	// last origin fetch is stale, preventing idle replicas from serving an old
 	// local view for the rest of a long window.
 	cur.EnsureFreshFromOrigin(ctx, req.Time)
-	prev.EnsureFreshFromOrigin(ctx, req.Time)
+	if prev != nil {
+		prev.EnsureFreshFromOrigin(ctx, req.Time)
+	}
 
 	// Strict mode always refreshes the current window before deciding. The
 	// previous window cannot receive new local decisions anymore, so its normal
@@ -82,44 +103,107 @@ func (s *service) prepareCheck(ctx context.Context, req RatelimitRequest) checkS
 	windowElapsed := float64(elapsedMs) / float64(durationMs)
 	reset := time.UnixMilli(windowStartMs).Add(req.Duration)
 
-	return checkState{
+	cs := checkState{
 		cur:           cur,
 		prev:          prev,
 		strictKey:     sk,
 		curGlobal:     cur.globalCount.Load(),
-		prevGlobal:    prev.globalCount.Load(),
+		prevGlobal:    0,
+		algorithm:     req.Algorithm,
+		limit:         req.Limit,
+		refillRate:    req.RefillRate,
+		bucketTokens:  0,
 		curSequence:   curSeq,
 		windowElapsed: windowElapsed,
+		duration:      req.Duration,
+		now:           req.Time,
 		reset:         reset,
 		source:        source,
 	}
+	if prev != nil {
+		cs.prevGlobal = prev.globalCount.Load()
+	}
+	if req.Algorithm == AlgorithmTokenBucket {
+		cs.bucketTokens = s.bucketTokens(ctx, req, curSeq, prev)
+	}
+
+	return cs
 }
 
-// slidingWindowCount computes the effective request count for the
-// sliding window given the current window's local counter value. Each
-// window's total count is its own region's val (passed in for cur,
-// atomically loaded for prev) plus the cross-region sum of other regions'
-// contributions from the most recent cross-region pull. The two
-// contributions are tracked separately to keep the cross-region merge
-// from feeding back into the next flush, but for the deny decision
-// they're equivalent: any source of count increases pressure on the
-// limit. Cross-region snapshots come from checkState so the CAS retry
-// loop doesn't re-pay the atomic load.
-func (cs *checkState) slidingWindowCount(curCount int64) int64 {
+// effectiveCount computes the count the limit is compared against, given the
+// current window's local counter value. Each window's total count is its own
+// region's val (passed in for cur, atomically loaded for prev) plus the
+// cross-region sum of other regions' contributions from the most recent
+// cross-region pull. The two contributions are tracked separately to keep the
+// cross-region merge from feeding back into the next flush, but for the deny
+// decision they're equivalent: any source of count increases pressure on the
+// limit. Cross-region snapshots come from checkState so the CAS retry loop
+// doesn't re-pay the atomic load.
+//
+// A sliding window adds the previous window weighted by how much of it still
+// overlaps the trailing Duration. A fixed window counts the current window
+// alone. A token bucket reports the tokens missing from a full bucket: the
+// bucket held bucketTokens when the window opened, regains refillRate evenly
+// across the window up to its capacity, and loses whatever the window has
+// consumed so far.
+func (cs *checkState) effectiveCount(curCount int64) int64 {
 	cur := curCount + cs.curGlobal
+
+	switch cs.algorithm {
+	case AlgorithmFixedWindow:
+		return cur
+	case AlgorithmTokenBucket:
+		refilled := int64(float64(cs.refillRate) * cs.windowElapsed)
+		available := min(cs.limit, cs.bucketTokens+refilled)
+		return cur + cs.limit - available
+	case AlgorithmSlidingWindow:
+	}
+
 	prev := cs.prev.val.Load() + cs.prevGlobal
 	return cur + int64(float64(prev)*(1.0-cs.windowElapsed))
 }
 
-func (s *service) Ratelimit(ctx context.Context, req RatelimitRequest) (RatelimitResponse, error) {
-	_, span := tracing.Start(ctx, "Ratelimit")
-	defer span.End()
+// resetAt returns the Reset timestamp for a decision with the given effective
+// count. Window algorithms reset when the current window ends. A token bucket
+// has no window to wait for, so it reports when refills cover what is missing:
+// the whole bucket after an allowed request, or only the shortfall after a
+// denial so callers know when a retry can succeed.
+func (cs *checkState) resetAt(effective int64, passed bool) time.Time {
+	if cs.algorithm != AlgorithmTokenBucket {
+		return cs.reset
+	}
+
+	missing := effective
+	if !passed {
+		missing = effective - cs.limit
+	}
+	if missing <= 0 {
+		return cs.now
+	}
 
+	refillMs := math.Ceil(float64(missing) / float64(cs.refillRate) * float64(cs.duration.Milliseconds()))
+	return cs.now.Add(time.Duration(refillMs) * time.Millisecond)
+}
+
+// normalizeRequest fills in the defaults callers may leave empty: the service
+// clock for Time, the sliding window for Algorithm, and a refill of the whole
+// bucket per Duration for a token bucket without RefillRate.
+func normalizeRequest(req *RatelimitRequest, now time.Time) {
 	if req.Time.IsZero() {
-		req.Time = s.clock.Now()
+		req.Time = now
+	}
+	if req.Algorithm == "" {
+		req.Algorithm = AlgorithmSlidingWindow
 	}
+	if req.Algorithm == AlgorithmTokenBucket && req.RefillRate == 0 {
+		req.RefillRate = req.Limit
+	}
+}
 
-	err := assert.All(
+// validateRequest checks a normalized request against the contract documented
+// on [RatelimitRequest].
+func validateRequest(req RatelimitRequest) error {
+	return assert.All(
 		assert.NotEmpty(req.WorkspaceID, "ratelimit workspace id must not be empty"),
 		assert.NotEmpty(req.Namespace, "ratelimit namespace must not be empty"),
 		assert.NotEmpty(req.Identifier, "ratelimit identifier must not be empty"),
@@ -127,14 +211,26 @@ func (s *service) Ratelimit(ctx context.Context, req RatelimitRequest) (Ratelimi
 		assert.GreaterOrEqual(req.Cost, 0, "ratelimit cost must not be negative"),
 		assert.GreaterOrEqual(req.Duration.Milliseconds(), 1000, "ratelimit duration must be at least 1s"),
 		assert.False(req.Time.IsZero(), "request time must not be zero"),
+		assert.True(req.Algorithm.valid(), "ratelimit algorithm must be sliding_window, fixed_window or token_bucket"),
+		assert.GreaterOrEqual(req.RefillRate, 0, "ratelimit refill rate must not be negative"),
+		assert.LessOrEqual(req.RefillRate, req.Limit, "ratelimit refill rate must not exceed the limit"),
 	)
+}
+
+func (s *service) Ratelimit(ctx context.Context, req RatelimitRequest) (RatelimitResponse, error) {
+	_, span := tracing.Start(ctx, "Ratelimit")
+	defer span.End()
+
+	normalizeRequest(&req, s.clock.Now())
+
+	err := validateRequest(req)
 	if err != nil {
 		return RatelimitResponse{}, err
 	}
 
 	cs := s.prepareCheck(ctx, req)
 
-	// CAS loop: atomically check the sliding window and increment if allowed.
+	// CAS loop: atomically check the limit and increment if allowed.
 	// Denials are wait-free (single Load, no CAS, no retry).
 	// Allows retry only when another goroutine incremented the same counter
 	// between our Load and CAS — nanoseconds of spinning.
@@ -143,7 +239,7 @@ func (s *service) Ratelimit(ctx context.Context, req RatelimitRequest) (Ratelimi
 	// should never occur in practice.
 	for range maxCASRetries {
 		curCount := cs.cur.val.Load()
-		effectiveCount := cs.slidingWindowCount(curCount) + req.Cost
+		effectiveCount := cs.effectiveCount(curCount) + req.Cost
 
 		if effectiveCount > req.Limit {
 			// Enter strict mode: force origin fetches for the rest of the
@@ -154,7 +250,7 @@ func (s *service) Ratelimit(ctx context.Context, req RatelimitRequest) (Ratelimi
 			return RatelimitResponse{
 				Success:   false,
 				Remaining: 0,
-				Reset:     cs.reset,
+				Reset:     cs.resetAt(effectiveCount, false),
 				Limit:     req.Limit,
 				Current:   effectiveCount,
 			}, nil
@@ -163,12 +259,13 @@ func (s *service) Ratelimit(ctx context.Context, req RatelimitRequest) (Ratelimi
 		if cs.cur.val.CompareAndSwap(curCount, curCount+req.Cost) {
 			s.replayBuffer.Buffer(req)
 			cs.cur.observeGlobalPushLimit(req.Limit)
+			cs.cur.observeWindowCells(req.Algorithm.windowCells())
 			metrics.RatelimitDecision.WithLabelValues(req.WorkspaceID, cs.source, "passed").Inc()
 			span.SetAttributes(attribute.Bool("passed", true))
 			return RatelimitResponse{
 				Success:   true,
 				Remaining: max(0, req.Limit-effectiveCount),
-				Reset:     cs.reset,
+				Reset:     cs.resetAt(effectiveCount, true),
 				Limit:     req.Limit,
 				Current:   effectiveCount,
 			}, nil
@@ -187,12 +284,39 @@ func (s *service) Ratelimit(ctx context.Context, req RatelimitRequest) (Ratelimi
 	return RatelimitResponse{
 		Success:   false,
 		Remaining: 0,
-		Reset:     cs.reset,
+		Reset:     cs.resetAt(req.Limit+req.Cost, false),
 		Limit:     req.Limit,
 		Current:   req.Limit,
 	}, nil
 }
 
+func (s *service) Peek(ctx context.Context, req RatelimitRequest) (RatelimitResponse, error) {
+	_, span := tracing.Start(ctx, "Peek")
+	defer span.End()
+
+	normalizeRequest(&req, s.clock.Now())
+
+	err := validateRequest(req)
+	if err != nil {
+		return RatelimitResponse{}, err
+	}
+
+	// prepareCheck only reads: cold counters are hydrated from origin like
+	// any other reader would, which does not count towards the limit.
+	cs := s.prepareCheck(ctx, req)
+	effectiveCount := cs.effectiveCount(cs.cur.val.Load())
+	passed := effectiveCount+req.Cost <= req.Limit
+
+	span.SetAttributes(attribute.Bool("passed", passed))
+	return RatelimitResponse{
+		Success:   passed,
+		Remaining: max(0, req.Limit-effectiveCount),
+		Reset:     cs.resetAt(effectiveCount, true),
+		Limit:     req.Limit,
+		Current:   effectiveCount,
+	}, nil
+}
+
 func (s *service) RatelimitMany(ctx context.Context, reqs []RatelimitRequest) ([]RatelimitResponse, error) {
 	_, span := tracing.Start(ctx, "RatelimitMany")
 	defer span.End()
@@ -200,19 +324,9 @@ func (s *service) RatelimitMany(ctx context.Context, reqs []RatelimitRequest) ([
 	reqs = append([]RatelimitRequest(nil), reqs...)
 	now := s.clock.Now()
 	for i := range reqs {
-		if reqs[i].Time.IsZero() {
-			reqs[i].Time = now
-		}
+		normalizeRequest(&reqs[i], now)
 
-		err := assert.All(
-			assert.NotEmpty(reqs[i].WorkspaceID, "ratelimit workspace id must not be empty"),
-			assert.NotEmpty(reqs[i].Namespace, "ratelimit namespace must not be empty"),
-			assert.NotEmpty(reqs[i].Identifier, "ratelimit identifier must not be empty"),
-			assert.Greater(reqs[i].Limit, 0, "ratelimit limit must be greater than zero"),
-			assert.GreaterOrEqual(reqs[i].Cost, 0, "ratelimit cost must not be negative"),
-			assert.GreaterOrEqual(reqs[i].Duration.Milliseconds(), 1000, "ratelimit duration must be at least 1s"),
-			assert.False(reqs[i].Time.IsZero(), "request time must not be zero"),
-		)
+		err := validateRequest(reqs[i])
 		if err != nil {
 			return []RatelimitResponse{}, err
 		}
@@ -236,7 +350,7 @@ func (s *service) RatelimitMany(ctx context.Context, reqs []RatelimitRequest) ([
 	allPassed := true
 	effectives := make([]int64, len(reqs))
 	for i, req := range reqs {
-		effectives[i] = checks[i].slidingWindowCount(newCounts[i])
+		effectives[i] = checks[i].effectiveCount(newCounts[i])
 		if effectives[i] > req.Limit {
 			allPassed = false
 		}
@@ -264,6 +378,7 @@ func (s *service) RatelimitMany(ctx context.Context, reqs []RatelimitRequest) ([
 		// the push goroutine can evaluate the live counter value later.
 		for i, req := range reqs {
 			checks[i].cur.observeGlobalPushLimit(req.Limit)
+			checks[i].cur.observeWindowCells(req.Algorithm.windowCells())
 			checks[i].cur.speculative.Add(-req.Cost)
 		}
 	}
@@ -279,18 +394,20 @@ func (s *service) RatelimitMany(ctx context.Context, reqs []RatelimitRequest) ([
 		effective := effectives[i]
 		individualPassed := effective <= req.Limit
 		remaining := req.Limit - effective
+		reset := checks[i].resetAt(effective, individualPassed)
 		if !allPassed {
 			// Counters were rolled back. For entries that passed individually,
 			// add the cost back to Remaining since nothing was actually consumed.
 			if individualPassed {
 				remaining += req.Cost
+				reset = checks[i].resetAt(effective-req.Cost, true)
 			}
 		}
 
 		responses[i] = RatelimitResponse{
 			Success:   individualPassed,
 			Remaining: max(0, remaining),
-			Reset:     checks[i].reset,
+			Reset:     reset,
 			Limit:     req.Limit,
 			Current:   effective,
 		}
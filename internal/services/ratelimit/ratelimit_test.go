@@ -128,6 +128,55 @@ func TestRatelimit_DenialSetsStrictUntil(t *testing.T) {
 	require.Equal(t, want, got, "strictUntil should equal req.Time + req.Duration")
 }
 
+// TestPeek_LeavesLimiterStateUntouched asserts a peek reports the current
+// headroom and whether its cost fits, without consuming tokens or entering
+// strict mode, even when it would be denied.
+func TestPeek_LeavesLimiterStateUntouched(t *testing.T) {
+	t.Parallel()
+
+	clk := clock.NewTestClock()
+	svc, err := New(Config{
+		Clock: clk, Counter: counter.NewMemory(), DB: newTestDB(t), Region: "test-region",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = svc.Close() })
+
+	duration := time.Minute
+	req := RatelimitRequest{
+		WorkspaceID: uid.New(uid.WorkspacePrefix),
+		Namespace:   "ns",
+		Identifier:  "id",
+		Limit:       2,
+		Duration:    duration,
+		Cost:        1,
+		Time:        clk.Now(),
+	}
+
+	resp, err := svc.Ratelimit(context.Background(), req)
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+
+	peek := req
+	peek.Cost = 2
+	resp, err = svc.Peek(context.Background(), peek)
+	require.NoError(t, err)
+	require.False(t, resp.Success, "a cost of 2 does not fit the remaining token")
+	require.Equal(t, int64(1), resp.Remaining)
+
+	resp, err = svc.Peek(context.Background(), req)
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+	require.Equal(t, int64(1), resp.Remaining, "a passing peek consumes nothing")
+
+	sk := strictKey{workspaceID: req.WorkspaceID, namespace: req.Namespace, identifier: req.Identifier, durationMs: duration.Milliseconds()}
+	require.Zero(t, svc.loadStrictUntil(sk), "a denied peek must not enter strict mode")
+
+	resp, err = svc.Ratelimit(context.Background(), req)
+	require.NoError(t, err)
+	require.True(t, resp.Success, "the last token is still there after peeking")
+	require.Zero(t, resp.Remaining)
+}
+
 // TestRatelimit_StrictModeForcesOriginFetch asserts that once strict mode is
 // active, every request fetches the current window from origin before deciding.
 // We verify by observing local state converge to a seeded origin value — the
@@ -580,3 +629,212 @@ func TestRatelimitMany_LongWindowLowTrafficRefreshesStaleReplica(t *testing.T) {
 	require.True(t, resp[1].Success, "hourly limit passes individually, but the batch must not commit because daily failed")
 	require.Equal(t, int64(12), resp[1].Remaining, "hourly remaining is unchanged because RatelimitMany rolls back the whole batch")
 }
+
+// TestRatelimit_FixedWindowDecision asserts a fixed window counts only the
+// current window: a full previous window does not carry over, and the
+// window resets exactly on the Duration boundary.
+func TestRatelimit_FixedWindowDecision(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		curCount  int64
+		prevCount int64
+		elapsed   float64
+		cost      int64
+		limit     int64
+		wantPass  bool
+		wantRem   int64
+	}{
+		{name: "empty window allows", curCount: 0, prevCount: 0, elapsed: 0.0, cost: 1, limit: 10, wantPass: true, wantRem: 9},
+		{name: "full previous window does not carry over", curCount: 0, prevCount: 10, elapsed: 0.0, cost: 1, limit: 10, wantPass: true, wantRem: 9},
+		{name: "exactly at limit allows", curCount: 9, prevCount: 10, elapsed: 0.5, cost: 1, limit: 10, wantPass: true, wantRem: 0},
+		{name: "one over limit denies", curCount: 10, prevCount: 0, elapsed: 0.99, cost: 1, limit: 10, wantPass: false, wantRem: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clk := clock.NewTestClock()
+			svc, err := New(Config{
+				Clock: clk, Counter: counter.NewMemory(), DB: newTestDB(t), Region: "test-region",
+			})
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = svc.Close() })
+
+			ws := uid.New(uid.WorkspacePrefix)
+			duration := time.Minute
+			durationMs := duration.Milliseconds()
+
+			curSeq := calculateSequence(clk.Now(), duration)
+			windowStartMs := curSeq * durationMs
+			reqTime := time.UnixMilli(windowStartMs + int64(tt.elapsed*float64(durationMs)))
+
+			curKey := counterKey{workspaceID: ws, namespace: "ns", identifier: "id", durationMs: durationMs, sequence: curSeq}
+			prevKey := counterKey{workspaceID: ws, namespace: "ns", identifier: "id", durationMs: durationMs, sequence: curSeq - 1}
+			svc.loadCounter(curKey).val.Store(tt.curCount)
+			svc.loadCounter(prevKey).val.Store(tt.prevCount)
+
+			resp, err := svc.Ratelimit(context.Background(), RatelimitRequest{
+				WorkspaceID: ws,
+				Namespace:   "ns",
+				Identifier:  "id",
+				Limit:       tt.limit,
+				Duration:    duration,
+				Algorithm:   AlgorithmFixedWindow,
+				RefillRate:  0,
+				Cost:        tt.cost,
+				Time:        reqTime,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantPass, resp.Success, "Success")
+			require.Equal(t, tt.wantRem, resp.Remaining, "Remaining")
+			require.Equal(t, time.UnixMilli(windowStartMs+durationMs), resp.Reset, "Reset")
+		})
+	}
+}
+
+// TestRatelimit_TokenBucketBurstsThenRefills asserts a token bucket admits a
+// burst of its full capacity, then only what it refills, and that the level
+// carried across windows keeps a steady client at the refill rate rather
+// than letting it burst again every window.
+func TestRatelimit_TokenBucketBurstsThenRefills(t *testing.T) {
+	t.Parallel()
+
+	clk := clock.NewTestClock()
+	svc, err := New(Config{
+		Clock: clk, Counter: counter.NewMemory(), DB: newTestDB(t), Region: "test-region",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = svc.Close() })
+
+	ws := uid.New(uid.WorkspacePrefix)
+	duration := time.Second
+	windowStart := time.UnixMilli(calculateSequence(clk.Now(), duration) * duration.Milliseconds())
+
+	limit := func(at time.Time) RatelimitResponse {
+		resp, err := svc.Ratelimit(context.Background(), RatelimitRequest{
+			WorkspaceID: ws,
+			Namespace:   "ns",
+			Identifier:  "id",
+			Limit:       10,
+			Duration:    duration,
+			Algorithm:   AlgorithmTokenBucket,
+			RefillRate:  2,
+			Cost:        1,
+			Time:        at,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	passed := 0
+	for range 15 {
+		if limit(windowStart).Success {
+			passed++
+		}
+	}
+	require.Equal(t, 10, passed, "a full bucket admits its capacity at once")
+
+	denied := limit(windowStart)
+	require.False(t, denied.Success)
+	require.Equal(t, windowStart.Add(500*time.Millisecond), denied.Reset, "one token refills in half a second")
+
+	// Ask for a token every 100ms for ten seconds. The empty bucket regains
+	// two tokens per second, so roughly twenty requests pass, not the
+	// hundred a new burst per window would allow.
+	passed = 0
+	for ms := 100; ms <= 10_000; ms += 100 {
+		if limit(windowStart.Add(time.Duration(ms) * time.Millisecond)).Success {
+			passed++
+		}
+	}
+	require.InDelta(t, 20, passed, 2)
+
+	// Idle long enough for the bucket to fill again.
+	idle := windowStart.Add(time.Minute)
+	passed = 0
+	for range 15 {
+		if limit(idle).Success {
+			passed++
+		}
+	}
+	require.Equal(t, 10, passed, "an idle bucket refills to capacity")
+}
+
+// TestRatelimit_RejectsInvalidAlgorithm asserts unknown algorithms and refill
+// rates outside [0, Limit] fail validation instead of silently falling back.
+func TestRatelimit_RejectsInvalidAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	svc, err := New(Config{
+		Clock: clock.NewTestClock(), Counter: counter.NewMemory(), DB: newTestDB(t), Region: "test-region",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = svc.Close() })
+
+	base := RatelimitRequest{
+		WorkspaceID: "ws",
+		Namespace:   "ns",
+		Identifier:  "id",
+		Limit:       10,
+		Duration:    time.Second,
+		Algorithm:   AlgorithmTokenBucket,
+		RefillRate:  0,
+		Cost:        1,
+		Time:        time.Time{},
+	}
+
+	unknown := base
+	unknown.Algorithm = "leaky_bucket"
+	_, err = svc.Ratelimit(context.Background(), unknown)
+	require.Error(t, err)
+
+	tooFast := base
+	tooFast.RefillRate = 11
+	_, err = svc.Ratelimit(context.Background(), tooFast)
+	require.Error(t, err)
+
+	negative := base
+	negative.RefillRate = -1
+	_, err = svc.RatelimitMany(context.Background(), []RatelimitRequest{negative})
+	require.Error(t, err)
+}
+
+// TestCounterEntry_GlobalExpiresAtFollowsWindowCells asserts the global row
+// for a cell lives as long as the widest algorithm that reads it.
+func TestCounterEntry_GlobalExpiresAtFollowsWindowCells(t *testing.T) {
+	t.Parallel()
+
+	key := counterKey{workspaceID: "ws", namespace: "ns", identifier: "id", durationMs: 1000, sequence: 100}
+
+	entry := &counterEntry{} //nolint:exhaustruct // only windowCells matters here
+	require.Equal(t, int64(102_000), entry.globalExpiresAtMs(key), "unobserved entries default to the sliding window")
+
+	entry.observeWindowCells(AlgorithmFixedWindow.windowCells())
+	require.Equal(t, int64(101_000), entry.globalExpiresAtMs(key))
+
+	entry.observeWindowCells(AlgorithmTokenBucket.windowCells())
+	entry.observeWindowCells(AlgorithmSlidingWindow.windowCells())
+	require.Equal(t, int64(103_000), entry.globalExpiresAtMs(key), "the widest algorithm wins")
+}
+
+// TestFixedWindow_EpochAligned pins the window boundaries the fixed-window
+// docs promise: UTC midnight for a day, Thursday for a week, since 1970-01-01
+// was a Thursday.
+func TestFixedWindow_EpochAligned(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 17, 15, 4, 5, 0, time.UTC)
+	windowStart := func(d time.Duration) time.Time {
+		return time.UnixMilli(calculateSequence(now, d) * d.Milliseconds()).UTC()
+	}
+
+	require.Equal(t, time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC), windowStart(time.Hour))
+	require.Equal(t, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), windowStart(24*time.Hour))
+
+	week := windowStart(7 * 24 * time.Hour)
+	require.Equal(t, time.Thursday, week.Weekday())
+	require.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), week)
+}
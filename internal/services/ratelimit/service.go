@@ -140,6 +140,13 @@ type counterEntry struct {
 	// entries eligible for retry on the next tick.
 	lastPushed atomic.Int64
 
+	// windowCells is the largest [Algorithm.windowCells] observed for this
+	// cell. The push goroutine derives the row's expires_at from it so a cell
+	// read by a token bucket two windows later is still imported by other
+	// regions, while fixed-window cells expire as soon as their window ends.
+	// A zero value means no request has committed to this entry yet.
+	windowCells atomic.Int64
+
 	// fetch is bound to the entry's key at creation so the hot path doesn't
 	// allocate a per-call closure to pass into EnsureFreshFromOrigin. The boolean
 	// reports whether the count came from origin rather than fallback state.
@@ -169,6 +176,25 @@ func (e *counterEntry) observeGlobalPushLimit(limit int64) {
 	logger.Error("global push threshold update retries exhausted")
 }
 
+// observeWindowCells records that an algorithm reading cells consecutive
+// windows committed an increment to this entry. The value only grows, so an
+// entry shared by several algorithms stays visible for the longest of them.
+func (e *counterEntry) observeWindowCells(cells int64) {
+	atomicMax(&e.windowCells, cells)
+}
+
+// globalExpiresAtMs returns the expires_at for this entry's
+// ratelimit_global_counters row: the end of the last window whose decision
+// reads the cell. Entries no request has committed to yet fall back to the
+// sliding window's span.
+func (e *counterEntry) globalExpiresAtMs(key counterKey) int64 {
+	cells := e.windowCells.Load()
+	if cells == 0 {
+		cells = AlgorithmSlidingWindow.windowCells()
+	}
+	return (key.sequence + cells) * key.durationMs
+}
+
 // shouldPushGlobalCount reports whether the entry's local count should be
 // shared through ratelimit_global_counters.
 func (e *counterEntry) shouldPushGlobalCount(val int64) bool {
@@ -238,8 +264,8 @@ func (e *counterEntry) EnsureFreshFromOrigin(ctx context.Context, now time.Time)
 	}
 }
 
-// service implements lockless distributed rate limiting using a sliding
-// window algorithm with atomic counters. All rate limit state is held in a
+// service implements lockless distributed rate limiting using sliding-window,
+// fixed-window, and token-bucket algorithms over shared atomic counters. All rate limit state is held in a
 // single flat sync.Map with no mutexes in the hot path: per-window counter
 // entries keyed by (workspace, namespace, identifier, duration, sequence),
 // each holding the window's request count plus a sync.Once coordinating the
@@ -285,6 +311,15 @@ type service struct {
 	// concern handled by ratelimit_global_counters.
 	strictUntils sync.Map
 
+	// buckets maps bucketKey -> *atomic.Pointer[bucketLevel], the token
+	// level each token bucket carried into the previous window. See
+	// bucketTokens for how it is derived from the shared window counters.
+	buckets sync.Map
+
+	// leases maps leaseKey -> *leaseTable, the leases this node granted
+	// and has not seen released. See lease.go.
+	leases sync.Map
+
 	// origin is the distributed source-of-truth counter (typically Redis).
 	// Local atomics in `counters` converge toward origin via async replay.
 	origin counter.Counter
@@ -370,6 +405,8 @@ func New(config Config) (*service, error) {
 		clock:        config.Clock,
 		counters:     sync.Map{}, //nolint:exhaustruct // sync.Map zero value is ready to use
 		strictUntils: sync.Map{}, //nolint:exhaustruct // sync.Map zero value is ready to use
+		buckets:      sync.Map{}, //nolint:exhaustruct // sync.Map zero value is ready to use
+		leases:       sync.Map{}, //nolint:exhaustruct // sync.Map zero value is ready to use
 		origin:       config.Counter,
 		region:       config.Region,
 		replayBuffer: buffer.New[RatelimitRequest](buffer.Config{
@@ -67,6 +67,14 @@ func (m *mockCounter) DecrementIfExists(_ context.Context, key string, value int
 	return v.Add(-value), true, true, nil
 }
 
+func (m *mockCounter) IncrementIfExists(_ context.Context, key string, value int64) (int64, bool, error) {
+	v, ok := m.data.Load(key)
+	if !ok {
+		return 0, false, nil
+	}
+	return v.(*atomic.Int64).Add(value), true, nil
+}
+
 func (m *mockCounter) SetIfNotExists(_ context.Context, key string, value int64, _ ...time.Duration) (bool, error) {
 	v := &atomic.Int64{}
 	v.Store(value)
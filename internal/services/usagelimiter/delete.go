@@ -8,3 +8,8 @@ import (
 func (s *service) Invalidate(ctx context.Context, keyID string) error {
 	return nil
 }
+
+// InvalidateIdentity doesn't do anything for the same reason
+func (s *service) InvalidateIdentity(ctx context.Context, identityID string) error {
+	return nil
+}
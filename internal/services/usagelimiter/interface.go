@@ -12,6 +12,19 @@ type FindKeyCreditsFunc func(ctx context.Context, keyID string) (remaining int64
 // DecrementKeyCreditsFunc atomically decrements the credits for a key by the given cost.
 type DecrementKeyCreditsFunc func(ctx context.Context, keyID string, cost int64) error
 
+// IncrementKeyCreditsFunc atomically adds credits back to a key.
+type IncrementKeyCreditsFunc func(ctx context.Context, keyID string, credits int64) error
+
+// FindIdentityCreditsFunc looks up the remaining credits of an identity's
+// shared pool. hasLimit is false when the identity has no pool.
+type FindIdentityCreditsFunc func(ctx context.Context, identityID string) (remaining int64, hasLimit bool, err error)
+
+// DecrementIdentityCreditsFunc atomically decrements an identity's pool by the given cost.
+type DecrementIdentityCreditsFunc func(ctx context.Context, identityID string, cost int64) error
+
+// IncrementIdentityCreditsFunc atomically adds credits back to an identity's pool.
+type IncrementIdentityCreditsFunc func(ctx context.Context, identityID string, credits int64) error
+
 // Service defines the interface for usage limiting operations. It enforces
 // credit-based rate limits on API keys by tracking and decrementing available
 // credits. Implementations may use direct database queries or distributed
@@ -23,21 +36,39 @@ type Service interface {
 	// credits remain.
 	Limit(ctx context.Context, req UsageRequest) (UsageResponse, error)
 
+	// Check reports whether the given key or pool has sufficient credits to
+	// cover the requested cost without taking them. Verifications of
+	// test-mode keys use it so they behave like live ones while their
+	// balance never runs down.
+	Check(ctx context.Context, req UsageRequest) (UsageResponse, error)
+
+	// Refund returns credits taken by an earlier [Limit] call, e.g. when a
+	// caller reserved an upper bound and the actual cost turned out lower.
+	// req.Cost is the number of credits to give back and must not exceed
+	// what was taken; refunding a key without a limit is a no-op.
+	Refund(ctx context.Context, req UsageRequest) error
+
 	// Invalidate removes the cached limit for the given keyID, forcing the
 	// next [Limit] call to reload from the database.
 	Invalidate(ctx context.Context, keyID string) error
 
+	// InvalidateIdentity removes the cached pool of the given identity,
+	// forcing the next [Limit] call to reload it from the database.
+	InvalidateIdentity(ctx context.Context, identityID string) error
+
 	// Close gracefully shuts down the usage limiter service, draining any
 	// pending replay operations before returning.
 	Close() error
 }
 
 // UsageRequest represents a request to check and decrement usage credits
-// for an API key. The Cost field specifies how many credits this operation
-// should consume.
+// for an API key or for the credit pool of an identity. Exactly one of KeyID
+// and IdentityID is set. The Cost field specifies how many credits this
+// operation should consume.
 type UsageRequest struct {
-	KeyID string
-	Cost  int64
+	KeyID      string
+	IdentityID string
+	Cost       int64
 }
 
 // UsageResponse represents the result of a usage limit check. Valid indicates
@@ -12,11 +12,11 @@ func (s *service) Limit(ctx context.Context, req UsageRequest) (UsageResponse, e
 	ctx, span := tracing.Start(ctx, "usagelimiter.Limit")
 	defer span.End()
 
-	if err := assert.GreaterOrEqual(req.Cost, 0, "usagelimiter cost must not be negative"); err != nil {
+	if err := validate(req, "usagelimiter cost must not be negative"); err != nil {
 		return UsageResponse{}, err
 	}
 
-	remaining, hasLimit, err := s.findKeyCredits(ctx, req.KeyID)
+	remaining, hasLimit, err := s.find(ctx, req.KeyID, req.IdentityID)
 	if err != nil {
 		return UsageResponse{Valid: false, Remaining: 0}, err
 	}
@@ -25,13 +25,13 @@ func (s *service) Limit(ctx context.Context, req UsageRequest) (UsageResponse, e
 		return UsageResponse{Valid: true, Remaining: -1}, nil
 	}
 
-	// Key doesn't have enough credits to cover the request cost
+	// Key or pool doesn't have enough credits to cover the request cost
 	if req.Cost > 0 && remaining < req.Cost {
 		metrics.UsagelimiterDecisions.WithLabelValues("db", "denied").Inc()
 		return UsageResponse{Valid: false, Remaining: 0}, nil
 	}
 
-	err = s.decrementKeyCredits(ctx, req.KeyID, req.Cost)
+	err = s.decrement(ctx, req.KeyID, req.IdentityID, req.Cost)
 	if err != nil {
 		return UsageResponse{}, err
 	}
@@ -40,6 +40,54 @@ func (s *service) Limit(ctx context.Context, req UsageRequest) (UsageResponse, e
 	return UsageResponse{Valid: true, Remaining: max(0, remaining-req.Cost)}, nil
 }
 
+func (s *service) Check(ctx context.Context, req UsageRequest) (UsageResponse, error) {
+	ctx, span := tracing.Start(ctx, "usagelimiter.Check")
+	defer span.End()
+
+	if err := validate(req, "usagelimiter cost must not be negative"); err != nil {
+		return UsageResponse{}, err
+	}
+
+	remaining, hasLimit, err := s.find(ctx, req.KeyID, req.IdentityID)
+	if err != nil {
+		return UsageResponse{Valid: false, Remaining: 0}, err
+	}
+
+	if !hasLimit {
+		return UsageResponse{Valid: true, Remaining: -1}, nil
+	}
+
+	return UsageResponse{Valid: remaining >= req.Cost, Remaining: max(0, remaining)}, nil
+}
+
+func (s *service) Refund(ctx context.Context, req UsageRequest) error {
+	ctx, span := tracing.Start(ctx, "usagelimiter.Refund")
+	defer span.End()
+
+	if err := validate(req, "usagelimiter refund must not be negative"); err != nil {
+		return err
+	}
+	if req.Cost == 0 {
+		return nil
+	}
+
+	if err := s.increment(ctx, req.KeyID, req.IdentityID, req.Cost); err != nil {
+		return err
+	}
+
+	metrics.UsagelimiterCreditsRefunded.Add(float64(req.Cost))
+	return nil
+}
+
+// validate checks that req addresses exactly one balance and that its cost
+// is not negative.
+func validate(req UsageRequest, negativeCost string) error {
+	return assert.All(
+		assert.True((req.KeyID == "") != (req.IdentityID == ""), "usagelimiter request must set exactly one of KeyID and IdentityID"),
+		assert.GreaterOrEqual(req.Cost, 0, negativeCost),
+	)
+}
+
 func (s *service) Close() error {
 	// Direct DB service has no resources to clean up
 	return nil
@@ -64,6 +64,20 @@ var (
 		[]string{"status"},
 	)
 
+	// UsagelimiterCreditsRefunded counts credits given back through Refund,
+	// e.g. when a reserved cost settles lower.
+	//
+	// Example usage:
+	//   metrics.UsagelimiterCreditsRefunded.Add(float64(cost))
+	UsagelimiterCreditsRefunded = lazy.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "unkey",
+			Subsystem: "usagelimiter",
+			Name:      "credits_refunded_total",
+			Help:      "Total number of credits refunded to keys.",
+		},
+	)
+
 	// UsagelimiterReplayLatency measures the latency of replay operations to the database
 	// This histogram helps track the performance of async database updates.
 	//
@@ -26,7 +26,12 @@ type CreditChange struct {
 	// KeyID is the unique identifier of the key whose credits changed
 	KeyID string
 
-	// Cost is the number of credits that we should deduct
+	// IdentityID is set instead of KeyID when the change is to an
+	// identity's shared pool.
+	IdentityID string
+
+	// Cost is the number of credits that we should deduct. Refunds are
+	// buffered with a negative cost and replayed as an increment.
 	Cost int64
 }
 
@@ -34,6 +39,11 @@ type CreditChange struct {
 type RedisConfig struct {
 	FindKeyCredits      FindKeyCreditsFunc
 	DecrementKeyCredits DecrementKeyCreditsFunc
+	IncrementKeyCredits IncrementKeyCreditsFunc
+
+	FindIdentityCredits      FindIdentityCreditsFunc
+	DecrementIdentityCredits DecrementIdentityCreditsFunc
+	IncrementIdentityCredits IncrementIdentityCreditsFunc
 
 	// Counter is the counter implementation to use.
 	Counter counter.Counter
@@ -45,9 +55,8 @@ type RedisConfig struct {
 // counterService implements usage limiting using the counter interface
 // This provides truly atomic operations via Redis INCRBY commands
 type counterService struct {
-	findKeyCredits      FindKeyCreditsFunc
-	decrementKeyCredits DecrementKeyCreditsFunc
-	counter             counter.Counter
+	creditStore
+	counter counter.Counter
 
 	// Fallback to direct DB implementation when Redis fails
 	dbFallback Service
@@ -68,6 +77,11 @@ var _ Service = (*counterService)(nil)
 type CounterConfig struct {
 	FindKeyCredits      FindKeyCreditsFunc
 	DecrementKeyCredits DecrementKeyCreditsFunc
+	IncrementKeyCredits IncrementKeyCreditsFunc
+
+	FindIdentityCredits      FindIdentityCreditsFunc
+	DecrementIdentityCredits DecrementIdentityCreditsFunc
+	IncrementIdentityCredits IncrementIdentityCreditsFunc
 
 	// Counter is the distributed counter implementation to use
 	Counter counter.Counter
@@ -104,6 +118,10 @@ func NewCounter(config CounterConfig) (Service, error) {
 		assert.NotNil(config.Counter),
 		assert.NotNil(config.FindKeyCredits),
 		assert.NotNil(config.DecrementKeyCredits),
+		assert.NotNil(config.IncrementKeyCredits),
+		assert.NotNil(config.FindIdentityCredits),
+		assert.NotNil(config.DecrementIdentityCredits),
+		assert.NotNil(config.IncrementIdentityCredits),
 	); err != nil {
 		return nil, err
 	}
@@ -115,19 +133,29 @@ func NewCounter(config CounterConfig) (Service, error) {
 
 	// Create the direct DB fallback service
 	dbFallback, err := New(Config{
-		FindKeyCredits:      config.FindKeyCredits,
-		DecrementKeyCredits: config.DecrementKeyCredits,
+		FindKeyCredits:           config.FindKeyCredits,
+		DecrementKeyCredits:      config.DecrementKeyCredits,
+		IncrementKeyCredits:      config.IncrementKeyCredits,
+		FindIdentityCredits:      config.FindIdentityCredits,
+		DecrementIdentityCredits: config.DecrementIdentityCredits,
+		IncrementIdentityCredits: config.IncrementIdentityCredits,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create DB fallback: %w", err)
 	}
 
 	s := &counterService{
-		findKeyCredits:      config.FindKeyCredits,
-		decrementKeyCredits: config.DecrementKeyCredits,
-		counter:             config.Counter,
-		dbFallback:          dbFallback,
-		ttl:                 ttl,
+		creditStore: creditStore{
+			findKeyCredits:           config.FindKeyCredits,
+			decrementKeyCredits:      config.DecrementKeyCredits,
+			incrementKeyCredits:      config.IncrementKeyCredits,
+			findIdentityCredits:      config.FindIdentityCredits,
+			decrementIdentityCredits: config.DecrementIdentityCredits,
+			incrementIdentityCredits: config.IncrementIdentityCredits,
+		},
+		counter:    config.Counter,
+		dbFallback: dbFallback,
+		ttl:        ttl,
 		replayBuffer: buffer.New[CreditChange](buffer.Config{
 			Name:     "usagelimiter_replays",
 			Capacity: 10_000,
@@ -177,11 +205,11 @@ func (s *counterService) Limit(ctx context.Context, req UsageRequest) (UsageResp
 	ctx, span := tracing.Start(ctx, "usagelimiter.counter.Limit")
 	defer span.End()
 
-	if err := assert.GreaterOrEqual(req.Cost, 0, "usagelimiter cost must not be negative"); err != nil {
+	if err := validate(req, "usagelimiter cost must not be negative"); err != nil {
 		return UsageResponse{}, err
 	}
 
-	redisKey := s.redisKey(req.KeyID)
+	redisKey := s.redisKey(req)
 
 	// Attempt decrement if key already exists in Redis
 	remaining, exists, success, err := s.counter.DecrementIfExists(ctx, redisKey, int64(req.Cost))
@@ -198,12 +226,73 @@ func (s *counterService) Limit(ctx context.Context, req UsageRequest) (UsageResp
 	return s.handleResult(req, remaining, success), nil
 }
 
+// Check reports whether a balance covers req.Cost without decrementing it. A
+// zero decrement reads the counter atomically; balances that are not cached
+// yet are read from the database without caching them.
+func (s *counterService) Check(ctx context.Context, req UsageRequest) (UsageResponse, error) {
+	ctx, span := tracing.Start(ctx, "usagelimiter.counter.Check")
+	defer span.End()
+
+	if err := validate(req, "usagelimiter cost must not be negative"); err != nil {
+		return UsageResponse{}, err
+	}
+
+	remaining, exists, _, err := s.counter.DecrementIfExists(ctx, s.redisKey(req), 0)
+	if err != nil || !exists {
+		return s.dbFallback.Check(ctx, req)
+	}
+
+	return UsageResponse{Valid: remaining >= req.Cost, Remaining: remaining}, nil
+}
+
+// Refund gives credits back to a key. The Redis counter is only incremented
+// when it still exists: a counter that expired in the meantime is reloaded
+// from the database on the next [Limit], so creating it here would start it
+// from the refund alone. The database is updated through the replay buffer
+// either way, after the deduction being refunded.
+func (s *counterService) Refund(ctx context.Context, req UsageRequest) error {
+	ctx, span := tracing.Start(ctx, "usagelimiter.counter.Refund")
+	defer span.End()
+
+	if err := validate(req, "usagelimiter refund must not be negative"); err != nil {
+		return err
+	}
+	if req.Cost == 0 {
+		return nil
+	}
+
+	if _, _, err := s.counter.IncrementIfExists(ctx, s.redisKey(req), req.Cost); err != nil {
+		// The counter keeps the lower value until it expires, which only
+		// ever under-admits. The database still gets the refund.
+		logger.Warn("failed to refund credits in counter", "error", err, "keyID", req.KeyID, "identityID", req.IdentityID)
+	}
+
+	s.replayBuffer.Buffer(CreditChange{
+		KeyID:      req.KeyID,
+		IdentityID: req.IdentityID,
+		Cost:       -req.Cost,
+	})
+	metrics.UsagelimiterCreditsRefunded.Add(float64(req.Cost))
+	return nil
+}
+
 func (s *counterService) Invalidate(ctx context.Context, keyID string) error {
-	return s.counter.Delete(ctx, s.redisKey(keyID))
+	//nolint:exhaustruct // only the subject matters for the counter key
+	return s.counter.Delete(ctx, s.redisKey(UsageRequest{KeyID: keyID}))
 }
 
-func (s *counterService) redisKey(keyID string) string {
-	return fmt.Sprintf("credits:%s", keyID)
+func (s *counterService) InvalidateIdentity(ctx context.Context, identityID string) error {
+	//nolint:exhaustruct // only the subject matters for the counter key
+	return s.counter.Delete(ctx, s.redisKey(UsageRequest{IdentityID: identityID}))
+}
+
+// redisKey returns the counter of the balance req addresses. Identity pools
+// get their own namespace so they can never collide with a key's counter.
+func (s *counterService) redisKey(req UsageRequest) string {
+	if req.IdentityID != "" {
+		return fmt.Sprintf("credits:identity:%s", req.IdentityID)
+	}
+	return fmt.Sprintf("credits:%s", req.KeyID)
 }
 
 // handleResult processes the result of a decrement operation using an explicit success flag.
@@ -212,8 +301,9 @@ func (s *counterService) handleResult(req UsageRequest, remaining int64, success
 	if success {
 		// decrement succeeded - buffer the change for async database sync
 		s.replayBuffer.Buffer(CreditChange{
-			KeyID: req.KeyID,
-			Cost:  req.Cost,
+			KeyID:      req.KeyID,
+			IdentityID: req.IdentityID,
+			Cost:       req.Cost,
 		})
 
 		metrics.UsagelimiterDecisions.WithLabelValues("redis", "allowed").Inc()
@@ -233,7 +323,7 @@ func (s *counterService) initializeFromDatabase(ctx context.Context, req UsageRe
 	ctx, span := tracing.Start(ctx, "usagelimiter.counter.initializeFromDatabase")
 	defer span.End()
 
-	remaining, hasLimit, err := s.findKeyCredits(ctx, req.KeyID)
+	remaining, hasLimit, err := s.find(ctx, req.KeyID, req.IdentityID)
 	if err != nil {
 		return UsageResponse{Valid: false, Remaining: 0}, err
 	}
@@ -259,7 +349,7 @@ func (s *counterService) initializeFromDatabase(ctx context.Context, req UsageRe
 
 	wasSet, err := s.counter.SetIfNotExists(ctx, redisKey, initValue, s.ttl)
 	if err != nil {
-		logger.Debug("failed to initialize counter with SetIfNotExists, falling back to DB", "error", err, "keyID", req.KeyID)
+		logger.Debug("failed to initialize counter with SetIfNotExists, falling back to DB", "error", err, "keyID", req.KeyID, "identityID", req.IdentityID)
 		return s.dbFallback.Limit(ctx, req)
 	}
 
@@ -277,7 +367,7 @@ func (s *counterService) initializeFromDatabase(ctx context.Context, req UsageRe
 	// Another node already initialized the key, check if we have enough after decrement
 	remainingCounter, exists, success, err := s.counter.DecrementIfExists(ctx, redisKey, int64(req.Cost))
 	if err != nil || !exists {
-		logger.Debug("failed to decrement after initialization attempt", "error", err, "exists", exists, "keyID", req.KeyID)
+		logger.Debug("failed to decrement after initialization attempt", "error", err, "exists", exists, "keyID", req.KeyID, "identityID", req.IdentityID)
 		return s.dbFallback.Limit(ctx, req)
 	}
 
@@ -308,7 +398,10 @@ func (s *counterService) syncWithDB(ctx context.Context, change CreditChange) er
 	}()
 
 	_, err := s.dbCircuitBreaker.Do(ctx, func(ctx context.Context) (any, error) {
-		return nil, s.decrementKeyCredits(ctx, change.KeyID, change.Cost)
+		if change.Cost < 0 {
+			return nil, s.increment(ctx, change.KeyID, change.IdentityID, -change.Cost)
+		}
+		return nil, s.decrement(ctx, change.KeyID, change.IdentityID, change.Cost)
 	})
 	if err != nil {
 		metrics.UsagelimiterReplayOperations.WithLabelValues("error").Inc()
@@ -0,0 +1,158 @@
+package usagelimiter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/counter"
+)
+
+// balances is an in-memory stand-in for the keys and identity_credits tables.
+type balances struct {
+	mu         sync.Mutex
+	keys       map[string]int64
+	identities map[string]int64
+}
+
+func (b *balances) find(m map[string]int64) func(context.Context, string) (int64, bool, error) {
+	return func(_ context.Context, id string) (int64, bool, error) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		remaining, ok := m[id]
+		return remaining, ok, nil
+	}
+}
+
+func (b *balances) add(m map[string]int64, sign int64) func(context.Context, string, int64) error {
+	return func(_ context.Context, id string, credits int64) error {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		m[id] = max(0, m[id]+sign*credits)
+		return nil
+	}
+}
+
+func (b *balances) get(m map[string]int64, id string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return m[id]
+}
+
+func newTestCounter(t *testing.T, b *balances) Service {
+	t.Helper()
+
+	svc, err := NewCounter(CounterConfig{
+		FindKeyCredits:           b.find(b.keys),
+		DecrementKeyCredits:      b.add(b.keys, -1),
+		IncrementKeyCredits:      b.add(b.keys, 1),
+		FindIdentityCredits:      b.find(b.identities),
+		DecrementIdentityCredits: b.add(b.identities, -1),
+		IncrementIdentityCredits: b.add(b.identities, 1),
+		Counter:                  counter.NewMemory(),
+		TTL:                      0,
+		ReplayWorkers:            1,
+	})
+	require.NoError(t, err)
+	return svc
+}
+
+func TestCounterIdentityPool(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// The key and the identity share an id to prove their counters are
+	// separate.
+	b := &balances{
+		mu:         sync.Mutex{},
+		keys:       map[string]int64{"shared": 100},
+		identities: map[string]int64{"shared": 10},
+	}
+	svc := newTestCounter(t, b)
+
+	pool := UsageRequest{KeyID: "", IdentityID: "shared", Cost: 4}
+
+	res, err := svc.Limit(ctx, pool)
+	require.NoError(t, err)
+	require.Equal(t, UsageResponse{Valid: true, Remaining: 6}, res)
+
+	res, err = svc.Limit(ctx, pool)
+	require.NoError(t, err)
+	require.Equal(t, UsageResponse{Valid: true, Remaining: 2}, res)
+
+	res, err = svc.Limit(ctx, pool)
+	require.NoError(t, err)
+	require.Equal(t, UsageResponse{Valid: false, Remaining: 2}, res)
+
+	res, err = svc.Limit(ctx, UsageRequest{KeyID: "shared", IdentityID: "", Cost: 1})
+	require.NoError(t, err)
+	require.Equal(t, UsageResponse{Valid: true, Remaining: 99}, res)
+
+	require.NoError(t, svc.Refund(ctx, UsageRequest{KeyID: "", IdentityID: "shared", Cost: 3}))
+
+	res, err = svc.Limit(ctx, pool)
+	require.NoError(t, err)
+	require.Equal(t, UsageResponse{Valid: true, Remaining: 1}, res)
+
+	// Changes reach the database through the replay buffer.
+	require.NoError(t, svc.Close())
+	require.Eventually(t, func() bool {
+		return b.get(b.identities, "shared") == 1 && b.get(b.keys, "shared") == 99
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestCounterIdentityWithoutPool(t *testing.T) {
+	t.Parallel()
+
+	b := &balances{mu: sync.Mutex{}, keys: map[string]int64{}, identities: map[string]int64{}}
+	svc := newTestCounter(t, b)
+	t.Cleanup(func() { require.NoError(t, svc.Close()) })
+
+	res, err := svc.Limit(context.Background(), UsageRequest{KeyID: "", IdentityID: "id_1", Cost: 1})
+	require.NoError(t, err)
+	require.Equal(t, UsageResponse{Valid: true, Remaining: -1}, res)
+}
+
+func TestCounterCheckDoesNotSpend(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	b := &balances{mu: sync.Mutex{}, keys: map[string]int64{"key_1": 5}, identities: map[string]int64{}}
+	svc := newTestCounter(t, b)
+	t.Cleanup(func() { require.NoError(t, svc.Close()) })
+
+	// Uncached balances are read from the database.
+	res, err := svc.Check(ctx, UsageRequest{KeyID: "key_1", IdentityID: "", Cost: 5})
+	require.NoError(t, err)
+	require.Equal(t, UsageResponse{Valid: true, Remaining: 5}, res)
+
+	res, err = svc.Limit(ctx, UsageRequest{KeyID: "key_1", IdentityID: "", Cost: 2})
+	require.NoError(t, err)
+	require.Equal(t, UsageResponse{Valid: true, Remaining: 3}, res)
+
+	for range 3 {
+		res, err = svc.Check(ctx, UsageRequest{KeyID: "key_1", IdentityID: "", Cost: 3})
+		require.NoError(t, err)
+		require.Equal(t, UsageResponse{Valid: true, Remaining: 3}, res)
+	}
+
+	res, err = svc.Check(ctx, UsageRequest{KeyID: "key_1", IdentityID: "", Cost: 4})
+	require.NoError(t, err)
+	require.Equal(t, UsageResponse{Valid: false, Remaining: 3}, res)
+}
+
+func TestRequestMustSetOneSubject(t *testing.T) {
+	t.Parallel()
+
+	b := &balances{mu: sync.Mutex{}, keys: map[string]int64{}, identities: map[string]int64{}}
+	svc := newTestCounter(t, b)
+	t.Cleanup(func() { require.NoError(t, svc.Close()) })
+
+	_, err := svc.Limit(context.Background(), UsageRequest{KeyID: "key_1", IdentityID: "id_1", Cost: 1})
+	require.Error(t, err)
+
+	_, err = svc.Limit(context.Background(), UsageRequest{KeyID: "", IdentityID: "", Cost: 1})
+	require.Error(t, err)
+}
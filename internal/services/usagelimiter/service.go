@@ -8,8 +8,7 @@ import (
 
 // service implements the direct DB-based usage limiter
 type service struct {
-	findKeyCredits      FindKeyCreditsFunc
-	decrementKeyCredits DecrementKeyCreditsFunc
+	creditStore
 }
 
 var _ Service = (*service)(nil)
@@ -18,6 +17,11 @@ var _ Service = (*service)(nil)
 type Config struct {
 	FindKeyCredits      FindKeyCreditsFunc
 	DecrementKeyCredits DecrementKeyCreditsFunc
+	IncrementKeyCredits IncrementKeyCreditsFunc
+
+	FindIdentityCredits      FindIdentityCreditsFunc
+	DecrementIdentityCredits DecrementIdentityCreditsFunc
+	IncrementIdentityCredits IncrementIdentityCreditsFunc
 }
 
 // New creates a new direct DB-based usage limiter service.
@@ -27,13 +31,23 @@ func New(config Config) (*service, error) {
 	if err := assert.All(
 		assert.NotNil(config.FindKeyCredits, "FindKeyCredits is required"),
 		assert.NotNil(config.DecrementKeyCredits, "DecrementKeyCredits is required"),
+		assert.NotNil(config.IncrementKeyCredits, "IncrementKeyCredits is required"),
+		assert.NotNil(config.FindIdentityCredits, "FindIdentityCredits is required"),
+		assert.NotNil(config.DecrementIdentityCredits, "DecrementIdentityCredits is required"),
+		assert.NotNil(config.IncrementIdentityCredits, "IncrementIdentityCredits is required"),
 	); err != nil {
 		return nil, fmt.Errorf("invalid usagelimiter service config: %w", err)
 	}
 
 	return &service{
-		findKeyCredits:      config.FindKeyCredits,
-		decrementKeyCredits: config.DecrementKeyCredits,
+		creditStore: creditStore{
+			findKeyCredits:           config.FindKeyCredits,
+			decrementKeyCredits:      config.DecrementKeyCredits,
+			incrementKeyCredits:      config.IncrementKeyCredits,
+			findIdentityCredits:      config.FindIdentityCredits,
+			decrementIdentityCredits: config.DecrementIdentityCredits,
+			incrementIdentityCredits: config.IncrementIdentityCredits,
+		},
 	}, nil
 }
 
@@ -58,9 +72,13 @@ func New(config Config) (*service, error) {
 func NewRedisWithCounter(config RedisConfig) (Service, error) {
 	//nolint:exhaustruct // ReplayWorkers defaults to 8 in NewCounter when unset
 	return NewCounter(CounterConfig{
-		FindKeyCredits:      config.FindKeyCredits,
-		DecrementKeyCredits: config.DecrementKeyCredits,
-		Counter:             config.Counter,
-		TTL:                 config.TTL,
+		FindKeyCredits:           config.FindKeyCredits,
+		DecrementKeyCredits:      config.DecrementKeyCredits,
+		IncrementKeyCredits:      config.IncrementKeyCredits,
+		FindIdentityCredits:      config.FindIdentityCredits,
+		DecrementIdentityCredits: config.DecrementIdentityCredits,
+		IncrementIdentityCredits: config.IncrementIdentityCredits,
+		Counter:                  config.Counter,
+		TTL:                      config.TTL,
 	})
 }
@@ -0,0 +1,37 @@
+package usagelimiter
+
+import (
+	"context"
+)
+
+// creditStore holds the database functions of both balances a [UsageRequest]
+// can address: a key's own remaining credits and an identity's shared pool.
+type creditStore struct {
+	findKeyCredits           FindKeyCreditsFunc
+	decrementKeyCredits      DecrementKeyCreditsFunc
+	incrementKeyCredits      IncrementKeyCreditsFunc
+	findIdentityCredits      FindIdentityCreditsFunc
+	decrementIdentityCredits DecrementIdentityCreditsFunc
+	incrementIdentityCredits IncrementIdentityCreditsFunc
+}
+
+func (c creditStore) find(ctx context.Context, keyID, identityID string) (int64, bool, error) {
+	if identityID != "" {
+		return c.findIdentityCredits(ctx, identityID)
+	}
+	return c.findKeyCredits(ctx, keyID)
+}
+
+func (c creditStore) decrement(ctx context.Context, keyID, identityID string, cost int64) error {
+	if identityID != "" {
+		return c.decrementIdentityCredits(ctx, identityID, cost)
+	}
+	return c.decrementKeyCredits(ctx, keyID, cost)
+}
+
+func (c creditStore) increment(ctx context.Context, keyID, identityID string, credits int64) error {
+	if identityID != "" {
+		return c.incrementIdentityCredits(ctx, identityID, credits)
+	}
+	return c.incrementKeyCredits(ctx, keyID, credits)
+}
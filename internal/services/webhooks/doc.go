@@ -0,0 +1,18 @@
+// Package webhooks emits outbound webhook events from the API.
+//
+// Handlers call [Service.Emit] after the change they describe has committed.
+// Emit never blocks the request: events are buffered and handed to the
+// hydra.v1.WebhookService Dispatch handler in the ctrl worker through the
+// Restate ingress, with the event id as the idempotency key. The worker owns
+// everything after that: finding subscribed endpoints, signing, retries and
+// the delivery log.
+//
+// Delivery is best effort up to the hand-off. An event is dropped, and a
+// warning logged, when the buffer is full or Restate rejects the dispatch;
+// once Restate has accepted it, delivery is durable.
+//
+// Events with [Event.At] in the future, such as key.expired, are dispatched
+// with a Restate delay so they fire when the event actually happens. The
+// worker re-checks them against the database at that point, because the
+// state that scheduled them may have changed since.
+package webhooks
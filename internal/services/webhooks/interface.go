@@ -0,0 +1,113 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+)
+
+// EventType names an event endpoints can subscribe to.
+type EventType string
+
+const (
+	EventKeyCreated               EventType = "key.created"
+	EventKeyDeleted               EventType = "key.deleted"
+	EventKeyRerolled              EventType = "key.rerolled"
+	EventKeyRotated               EventType = "key.rotated"
+	EventKeyCreditsExhausted      EventType = "key.credits_exhausted"
+	EventKeyExpired               EventType = "key.expired"
+	EventRatelimitOverrideSet     EventType = "ratelimit.override.set"
+	EventRatelimitOverrideDeleted EventType = "ratelimit.override.deleted"
+)
+
+// EventTypes lists every event type in the order they are documented.
+var EventTypes = []EventType{
+	EventKeyCreated,
+	EventKeyDeleted,
+	EventKeyRerolled,
+	EventKeyRotated,
+	EventKeyCreditsExhausted,
+	EventKeyExpired,
+	EventRatelimitOverrideSet,
+	EventRatelimitOverrideDeleted,
+}
+
+// IsEventType reports whether s names a known event type.
+func IsEventType(s string) bool {
+	for _, t := range EventTypes {
+		if string(t) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is one occurrence to deliver to every endpoint of the workspace that
+// subscribed to its type.
+type Event struct {
+	// ID identifies the event across all its deliveries. Generated when empty.
+	ID string
+	// WorkspaceID owns the endpoints the event is delivered to.
+	WorkspaceID string
+	Type        EventType
+	// Data is marshalled to JSON and delivered as the envelope's "data".
+	Data any
+	// At is when the event happens. Zero means now; a time in the future
+	// delays the dispatch until then.
+	At time.Time
+}
+
+// KeyData is the payload of key.created, key.deleted and
+// key.credits_exhausted.
+type KeyData struct {
+	KeyID string `json:"keyId"`
+	APIID string `json:"apiId"`
+}
+
+// KeyRerolledData is the payload of key.rerolled. KeyID is the new key.
+type KeyRerolledData struct {
+	KeyID         string `json:"keyId"`
+	PreviousKeyID string `json:"previousKeyId"`
+	APIID         string `json:"apiId"`
+}
+
+// KeyRotatedData is the payload of key.rotated. KeyID is the successor the
+// rotation issued; the previous key keeps working until PreviousKeyExpires,
+// in unix milliseconds.
+type KeyRotatedData struct {
+	KeyID              string `json:"keyId"`
+	PreviousKeyID      string `json:"previousKeyId"`
+	APIID              string `json:"apiId"`
+	PreviousKeyExpires int64  `json:"previousKeyExpires"`
+}
+
+// KeyExpiredData is the payload of key.expired. Expires is the unix
+// millisecond expiry the event was scheduled for; the worker drops the event
+// when the key no longer expires at exactly that time.
+type KeyExpiredData struct {
+	KeyID   string `json:"keyId"`
+	APIID   string `json:"apiId"`
+	Expires int64  `json:"expires"`
+}
+
+// RatelimitOverrideData is the payload of the ratelimit.override.* events.
+// Limit, Duration and Priority are omitted for deletions, ExpiresAt whenever
+// the override does not expire.
+type RatelimitOverrideData struct {
+	OverrideID  string `json:"overrideId"`
+	NamespaceID string `json:"namespaceId"`
+	Identifier  string `json:"identifier"`
+	Limit       int64  `json:"limit,omitempty"`
+	Duration    int64  `json:"duration,omitempty"`
+	Priority    int32  `json:"priority,omitempty"`
+	ExpiresAt   int64  `json:"expiresAt,omitempty"`
+}
+
+// Service emits webhook events.
+type Service interface {
+	// Emit queues event for dispatch and returns immediately. It never fails
+	// the caller; problems are logged.
+	Emit(ctx context.Context, event Event)
+
+	// Close flushes buffered events and stops the service.
+	Close() error
+}
@@ -0,0 +1,17 @@
+package webhooks
+
+import "context"
+
+type noop struct{}
+
+var _ Service = noop{}
+
+// NewNoop returns a Service that discards every event, for binaries and
+// tests that have no Restate ingress.
+func NewNoop() Service {
+	return noop{}
+}
+
+func (noop) Emit(context.Context, Event) {}
+
+func (noop) Close() error { return nil }
@@ -0,0 +1,121 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	restateingress "github.com/restatedev/sdk-go/ingress"
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/pkg/assert"
+	"github.com/unkeyed/unkey/pkg/batch"
+	"github.com/unkeyed/unkey/pkg/clock"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/pkg/uid"
+)
+
+// dispatchTimeout bounds one Dispatch submission to the Restate ingress.
+const dispatchTimeout = 5 * time.Second
+
+// Config holds the dependencies of the Restate backed Service.
+type Config struct {
+	// Restate submits Dispatch invocations to the ctrl worker.
+	Restate *restateingress.Client
+	// Clock stamps events that do not carry their own time.
+	Clock clock.Clock
+}
+
+type service struct {
+	restate *restateingress.Client
+	clock   clock.Clock
+	batch   *batch.BatchProcessor[Event]
+}
+
+var _ Service = (*service)(nil)
+
+// New creates a Service that dispatches events through the Restate ingress.
+func New(cfg Config) (*service, error) {
+	if err := assert.All(
+		assert.NotNil(cfg.Restate, "restate ingress client is required"),
+		assert.NotNil(cfg.Clock, "clock is required"),
+	); err != nil {
+		return nil, fmt.Errorf("invalid webhooks service config: %w", err)
+	}
+
+	s := &service{
+		restate: cfg.Restate,
+		clock:   cfg.Clock,
+		batch:   nil,
+	}
+
+	// Dropping on a full buffer keeps a Restate outage from stalling the
+	// request path; webhooks are a notification channel, not a ledger.
+	s.batch = batch.New(batch.Config[Event]{
+		Name:          "webhook_events",
+		Drop:          true,
+		BatchSize:     100,
+		BufferSize:    10_000,
+		FlushInterval: 100 * time.Millisecond,
+		Consumers:     2,
+		Flush:         s.flush,
+		Overflow:      nil,
+		OnClose:       nil,
+	})
+
+	return s, nil
+}
+
+func (s *service) Emit(_ context.Context, event Event) {
+	if event.ID == "" {
+		event.ID = uid.New(uid.WebhookEventPrefix)
+	}
+	if event.At.IsZero() {
+		event.At = s.clock.Now()
+	}
+
+	s.batch.Buffer(event)
+}
+
+func (s *service) Close() error {
+	s.batch.Close()
+	return nil
+}
+
+func (s *service) flush(ctx context.Context, events []Event) {
+	for _, event := range events {
+		if err := s.dispatch(ctx, event); err != nil {
+			logger.Warn("failed to dispatch webhook event",
+				"event_id", event.ID,
+				"event_type", string(event.Type),
+				"workspace_id", event.WorkspaceID,
+				"error", err.Error(),
+			)
+		}
+	}
+}
+
+func (s *service) dispatch(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("marshal event data: %w", err)
+	}
+
+	// A zero delay sends immediately.
+	delay := max(event.At.Sub(s.clock.Now()), 0)
+
+	ctx, cancel := context.WithTimeout(ctx, dispatchTimeout)
+	defer cancel()
+
+	_, err = hydrav1.NewWebhookServiceIngressClient(s.restate).
+		Dispatch().
+		Send(ctx, &hydrav1.DispatchWebhookRequest{
+			WorkspaceId: event.WorkspaceID,
+			EventId:     event.ID,
+			EventType:   string(event.Type),
+			CreatedAt:   event.At.UnixMilli(),
+			Data:        data,
+		}, restate.WithIdempotencyKey(event.ID), restate.WithDelay(delay))
+	return err
+}
@@ -0,0 +1,135 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	restateingress "github.com/restatedev/sdk-go/ingress"
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/clock"
+)
+
+type recordedDispatch struct {
+	path           string
+	delay          string
+	idempotencyKey string
+	body           map[string]any
+}
+
+func newIngress(t *testing.T) (*restateingress.Client, func() []recordedDispatch) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var calls []recordedDispatch
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(raw, &body))
+
+		mu.Lock()
+		calls = append(calls, recordedDispatch{
+			path:           r.URL.Path,
+			delay:          r.URL.Query().Get("delay"),
+			idempotencyKey: r.Header.Get("idempotency-key"),
+			body:           body,
+		})
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = io.WriteString(w, `{"invocationId":"inv_test","status":"Accepted"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	return restateingress.NewClient(server.URL), func() []recordedDispatch {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]recordedDispatch(nil), calls...)
+	}
+}
+
+func TestEmitDispatchesThroughRestate(t *testing.T) {
+	ingress, calls := newIngress(t)
+	clk := clock.NewTestClock(time.UnixMilli(1_700_000_000_000))
+
+	svc, err := New(Config{Restate: ingress, Clock: clk})
+	require.NoError(t, err)
+
+	svc.Emit(t.Context(), Event{
+		ID:          "evt_1",
+		WorkspaceID: "ws_1",
+		Type:        EventKeyCreated,
+		Data:        KeyData{KeyID: "key_1", APIID: "api_1"},
+		At:          time.Time{},
+	})
+	require.NoError(t, svc.Close())
+
+	got := calls()
+	require.Len(t, got, 1)
+	require.Equal(t, "/hydra.v1.WebhookService/Dispatch/send", got[0].path)
+	require.Equal(t, "evt_1", got[0].idempotencyKey)
+	require.Empty(t, got[0].delay)
+	require.Equal(t, "ws_1", got[0].body["workspaceId"])
+	require.Equal(t, "key.created", got[0].body["eventType"])
+	require.Equal(t, "1700000000000", got[0].body["createdAt"])
+}
+
+func TestDispatchDelaysFutureEvents(t *testing.T) {
+	ingress, calls := newIngress(t)
+	now := time.UnixMilli(1_700_000_000_000)
+	clk := clock.NewTestClock(now)
+
+	svc, err := New(Config{Restate: ingress, Clock: clk})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, svc.Close()) })
+
+	err = svc.dispatch(t.Context(), Event{
+		ID:          "evt_2",
+		WorkspaceID: "ws_1",
+		Type:        EventKeyExpired,
+		Data:        KeyExpiredData{KeyID: "key_1", APIID: "api_1", Expires: now.Add(time.Hour).UnixMilli()},
+		At:          now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	got := calls()
+	require.Len(t, got, 1)
+	require.Equal(t, "3600000ms", got[0].delay)
+	require.Equal(t, "evt_2", got[0].idempotencyKey)
+}
+
+func TestEmitGeneratesEventIDs(t *testing.T) {
+	ingress, calls := newIngress(t)
+
+	svc, err := New(Config{Restate: ingress, Clock: clock.New()})
+	require.NoError(t, err)
+
+	for range 2 {
+		svc.Emit(t.Context(), Event{
+			ID:          "",
+			WorkspaceID: "ws_1",
+			Type:        EventKeyDeleted,
+			Data:        KeyData{KeyID: "key_1", APIID: "api_1"},
+			At:          time.Time{},
+		})
+	}
+	require.NoError(t, svc.Close())
+
+	got := calls()
+	require.Len(t, got, 2)
+	require.NotEmpty(t, got[0].idempotencyKey)
+	require.NotEqual(t, got[0].idempotencyKey, got[1].idempotencyKey)
+}
+
+func TestIsEventType(t *testing.T) {
+	for _, eventType := range EventTypes {
+		require.True(t, IsEventType(string(eventType)))
+	}
+	require.False(t, IsEventType("key.updated"))
+	require.False(t, IsEventType(""))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/unkeyed/unkey/cmd/deploy"
 	dev "github.com/unkeyed/unkey/cmd/dev"
 	"github.com/unkeyed/unkey/cmd/healthcheck"
+	"github.com/unkeyed/unkey/cmd/vault"
 	"github.com/unkeyed/unkey/cmd/version"
 	"github.com/unkeyed/unkey/pkg/buildinfo"
 	"github.com/unkeyed/unkey/pkg/cli"
@@ -31,6 +32,7 @@ func main() {
 			deploy.Cmd,
 			healthcheck.Cmd,
 			dev.Cmd,
+			vault.Cmd,
 		},
 	}
 
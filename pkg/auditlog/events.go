@@ -19,6 +19,7 @@ const (
 	// Key events
 	KeyCreateEvent AuditLogEvent = "key.create"
 	KeyRerollEvent AuditLogEvent = "key.reroll"
+	KeyRotateEvent AuditLogEvent = "key.rotate"
 	KeyUpdateEvent AuditLogEvent = "key.update"
 	KeyDeleteEvent AuditLogEvent = "key.delete"
 
@@ -57,11 +58,14 @@ const (
 	RatelimitSetOverrideEvent    AuditLogEvent = "ratelimit.set_override"
 	RatelimitReadOverrideEvent   AuditLogEvent = "ratelimit.read_override"
 	RatelimitDeleteOverrideEvent AuditLogEvent = "ratelimit.delete_override"
+	RatelimitSetPlanEvent        AuditLogEvent = "ratelimit.set_plan"
+	RatelimitDeletePlanEvent     AuditLogEvent = "ratelimit.delete_plan"
 
 	// Audit log bucket events
 	AuditLogBucketCreateEvent AuditLogEvent = "auditLogBucket.create"
 
 	// Portal events
+	PortalUpdateEvent          AuditLogEvent = "portal.update"
 	PortalSessionCreateEvent   AuditLogEvent = "portal.session.create"
 	PortalSessionExchangeEvent AuditLogEvent = "portal.session.exchange"
 
@@ -93,4 +97,10 @@ const (
 	DomainCreateEvent AuditLogEvent = "domain.create"
 	DomainDeleteEvent AuditLogEvent = "domain.delete"
 	DomainVerifyEvent AuditLogEvent = "domain.verify"
+
+	// Webhook endpoint events
+	WebhookCreateEvent         AuditLogEvent = "webhook.create"
+	WebhookUpdateEvent         AuditLogEvent = "webhook.update"
+	WebhookDeleteEvent         AuditLogEvent = "webhook.delete"
+	WebhookReplayDeliveryEvent AuditLogEvent = "webhook.replay_delivery"
 )
@@ -13,12 +13,16 @@ const (
 	RatelimitResourceType          AuditLogResourceType = "ratelimit"
 	RatelimitNamespaceResourceType AuditLogResourceType = "ratelimitNamespace"
 	RatelimitOverrideResourceType  AuditLogResourceType = "ratelimitOverride"
+	RatelimitPlanResourceType      AuditLogResourceType = "ratelimitPlan"
 	RoleResourceType               AuditLogResourceType = "role"
 	WorkspaceResourceType          AuditLogResourceType = "workspace"
+	PortalResourceType             AuditLogResourceType = "portal"
 	PortalSessionResourceType      AuditLogResourceType = "portalSession"
 	DeploymentResourceType         AuditLogResourceType = "deployment"
 	ProjectResourceType            AuditLogResourceType = "project"
 	AppResourceType                AuditLogResourceType = "app"
 	EnvironmentResourceType        AuditLogResourceType = "environment"
 	DomainResourceType             AuditLogResourceType = "domain"
+	WebhookEndpointResourceType    AuditLogResourceType = "webhookEndpoint"
+	WebhookDeliveryResourceType    AuditLogResourceType = "webhookDelivery"
 )
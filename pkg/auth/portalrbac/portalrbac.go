@@ -16,6 +16,9 @@ const (
 	// CapKeysReroll lets the end user rotate the secret of an existing key.
 	CapKeysReroll = "keys:reroll"
 
+	// CapKeysDelete lets the end user delete their own keys.
+	CapKeysDelete = "keys:delete"
+
 	// CapAnalyticsRead lets the end user read their verification analytics.
 	CapAnalyticsRead = "analytics:read"
 )
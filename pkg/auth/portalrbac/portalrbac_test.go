@@ -21,4 +21,6 @@ func TestCapabilitiesUseExactMatching(t *testing.T) {
 	require.NoError(t, rbac.Check(rbac.S(portalrbac.CapAnalyticsRead), granted))
 	require.Error(t, rbac.Check(rbac.S(portalrbac.CapKeysReroll), granted),
 		"keys:create must not imply keys:reroll")
+	require.Error(t, rbac.Check(rbac.S(portalrbac.CapKeysDelete), granted),
+		"keys:create must not imply keys:delete")
 }
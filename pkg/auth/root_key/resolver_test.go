@@ -47,6 +47,10 @@ func (s *stubKeyService) GetMigrated(_ context.Context, _ *zen.Session, _, _ str
 	return nil, errors.New("not implemented")
 }
 
+func (s *stubKeyService) GetJWT(_ context.Context, _ *zen.Session, _, _ string) (*keys.KeyVerifier, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (s *stubKeyService) CreateKey(_ context.Context, _ keys.CreateKeyRequest) (keys.CreateKeyResponse, error) {
 	return keys.CreateKeyResponse{}, errors.New("not implemented")
 }
@@ -18,5 +18,7 @@ func NewNoop[T any]() *BatchProcessor[T] {
 		Drop:          true,
 		Consumers:     1,
 		Flush:         func(_ context.Context, _ []T) {},
+		Overflow:      nil,
+		OnClose:       nil,
 	})
 }
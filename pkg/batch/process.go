@@ -62,6 +62,16 @@ type Config[T any] struct {
 	// Multiple consumers can improve throughput for CPU-bound flush operations.
 	// Defaults to 1 if not specified or <= 0.
 	Consumers int
+
+	// Overflow receives items that do not fit into a full buffer instead of
+	// dropping them, for callers that keep a fallback such as a disk spool.
+	// Only used when Drop is true; when nil, such items are dropped.
+	Overflow func(T)
+
+	// OnClose runs once at the end of Close, after every consumer flushed its
+	// final batch, so resources the Flush function writes to can be released
+	// last.
+	OnClose func()
 }
 
 // New creates a new BatchProcessor with the specified configuration.
@@ -170,7 +180,8 @@ func (bp *BatchProcessor[T]) process() {
 }
 
 // Buffer adds an item to the batch processor.
-// If the Drop option is enabled and the buffer is full, the item will be silently dropped.
+// If the Drop option is enabled and the buffer is full, the item is handed to
+// Overflow when set, and silently dropped otherwise.
 // Otherwise, this method will block until there is room in the buffer.
 //
 // Example:
@@ -182,8 +193,13 @@ func (bp *BatchProcessor[T]) process() {
 //	    Message:   "Database connection failed",
 //	})
 func (bp *BatchProcessor[T]) Buffer(t T) {
+	if bp.config.Drop && bp.config.Overflow != nil {
+		if !bp.buffer.TryBuffer(t) {
+			bp.config.Overflow(t)
+		}
+		return
+	}
 	bp.buffer.Buffer(t)
-
 }
 
 // Close gracefully shuts down the batch processor.
@@ -205,4 +221,8 @@ func (bp *BatchProcessor[T]) Close() {
 	// flush and any downstream resource (ClickHouse connection, etc) the
 	// caller tears down after Close() could be gone before the flush lands.
 	bp.consumers.Wait()
+
+	if bp.config.OnClose != nil {
+		bp.config.OnClose()
+	}
 }
@@ -120,6 +120,32 @@ func (b *Buffer[T]) Buffer(t T) {
 	}
 }
 
+// TryBuffer adds an element to the buffer without ever blocking, regardless
+// of the Drop setting, and reports whether it was accepted. A full or closed
+// buffer rejects the element so the caller can keep it somewhere else
+// instead of losing it.
+func (b *Buffer[T]) TryBuffer(t T) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.isClosed {
+		metrics.BufferState.WithLabelValues(b.name, "closed").Inc()
+		return false
+	}
+
+	ptr := new(T)
+	*ptr = t
+
+	select {
+	case b.c <- ptr:
+		metrics.BufferState.WithLabelValues(b.name, "buffered").Inc()
+		return true
+	default:
+		metrics.BufferState.WithLabelValues(b.name, "rejected").Inc()
+		return false
+	}
+}
+
 // Consume returns a receive-only channel that can be used to read elements from the buffer.
 // Elements are removed from the buffer as they are read from the channel.
 // The channel will remain open until the Buffer.Close() method is called.
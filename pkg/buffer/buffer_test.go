@@ -330,3 +330,17 @@ func BenchmarkBufferMemoryFootprint(b *testing.B) {
 		}
 	})
 }
+
+func TestTryBuffer(t *testing.T) {
+	// Even without Drop, TryBuffer must hand a full buffer's item back
+	// instead of blocking.
+	b := New[int](Config{Name: "try", Capacity: 2, Drop: false})
+
+	assert.True(t, b.TryBuffer(1))
+	assert.True(t, b.TryBuffer(2))
+	assert.False(t, b.TryBuffer(3), "full buffer should reject")
+	assert.Equal(t, 2, b.Size())
+
+	b.Close()
+	assert.False(t, b.TryBuffer(4), "closed buffer should reject")
+}
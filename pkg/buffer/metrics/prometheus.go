@@ -18,6 +18,7 @@ var (
 	// - "buffered": The item was added to the buffer.
 	// - "dropped": The buffer was removed from the system.
 	// - "closed": The buffer was closed.
+	// - "rejected": TryBuffer found the buffer full and handed the item back.
 	//
 	// Example usage:
 	//   metrics.BufferInserts.WithLabelValues(b.String(), "buffered").Inc()
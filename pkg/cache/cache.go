@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/maypok86/otter"
@@ -30,8 +31,26 @@ type cache[K comparable, V any] struct {
 
 	inflightMu        sync.Mutex
 	inflightRefreshes map[K]bool
+
+	// version increases on every Remove and Clear. Origin refreshes capture
+	// it before they start and drop their result if the key was invalidated
+	// in the meantime, so a slow refresh cannot write back the data an
+	// invalidation just removed.
+	version   atomic.Uint64
+	clearedAt atomic.Uint64
+	removedMu sync.Mutex
+	removed   map[K]removal
+}
+
+// removal records when a key was last removed. Entries are kept for
+// removalRetention, far longer than any origin refresh should take.
+type removal struct {
+	version uint64
+	at      time.Time
 }
 
+const removalRetention = time.Minute
+
 type Config[K comparable, V any] struct {
 	// How long the data is considered fresh
 	// Subsequent requests in this time will try to use the cache
@@ -86,6 +105,10 @@ func New[K comparable, V any](config Config[K, V]) (Cache[K, V], error) {
 		revalidateC:       make(chan func(), 1000),
 		inflightMu:        sync.Mutex{},
 		inflightRefreshes: make(map[K]bool),
+		version:           atomic.Uint64{},
+		clearedAt:         atomic.Uint64{},
+		removedMu:         sync.Mutex{},
+		removed:           make(map[K]removal),
 	}
 
 	for range 10 {
@@ -104,9 +127,72 @@ func (c *cache[K, V]) registerMetrics() {
 	repeat.Every(60*time.Second, func() {
 		metrics.CacheSize.WithLabelValues(c.resource).Set(float64(c.otter.Size()))
 		metrics.CacheCapacity.WithLabelValues(c.resource).Set(float64(c.otter.Capacity()))
+		c.pruneRemovals()
 	})
 }
 
+// pruneRemovals forgets removals older than removalRetention.
+func (c *cache[K, V]) pruneRemovals() {
+	cutoff := c.clock.Now().Add(-removalRetention)
+
+	c.removedMu.Lock()
+	defer c.removedMu.Unlock()
+	for key, r := range c.removed {
+		if r.at.Before(cutoff) {
+			delete(c.removed, key)
+		}
+	}
+}
+
+// invalidatedSince reports whether key was removed, or the cache cleared,
+// after version start was observed.
+func (c *cache[K, V]) invalidatedSince(key K, start uint64) bool {
+	if c.version.Load() == start {
+		return false
+	}
+	if c.clearedAt.Load() > start {
+		return true
+	}
+
+	c.removedMu.Lock()
+	r, ok := c.removed[key]
+	c.removedMu.Unlock()
+	return ok && r.version > start
+}
+
+// writeRefreshed applies op to the result of an origin refresh that started
+// at version start, unless key was invalidated while it ran.
+func (c *cache[K, V]) writeRefreshed(ctx context.Context, key K, v V, op Op, start uint64) {
+	if op == Noop {
+		return
+	}
+	if c.invalidatedSince(key, start) {
+		metrics.CacheStaleRefreshesDropped.WithLabelValues(c.resource).Inc()
+		return
+	}
+
+	switch op {
+	case WriteValue:
+		c.Set(ctx, key, v)
+	case WriteNull:
+		c.SetNull(ctx, key)
+	case Noop:
+	}
+}
+
+// writeRefreshedMany is writeRefreshed for a batch: found keys are written
+// as values, the rest of keys as null.
+func (c *cache[K, V]) writeRefreshedMany(ctx context.Context, keys []K, values map[K]V, start uint64) {
+	for _, key := range keys {
+		if v, found := values[key]; found {
+			c.writeRefreshed(ctx, key, v, WriteValue, start)
+		} else {
+			var zero V
+			c.writeRefreshed(ctx, key, zero, WriteNull, start)
+		}
+	}
+}
+
 func (c *cache[K, V]) recordTiming(ctx context.Context, name, status string, start time.Time) {
 	timing.Record(ctx, timing.Entry{
 		Name:     name,
@@ -227,6 +313,20 @@ func (c *cache[K, V]) get(_ context.Context, key K) (swrEntry[V], bool) {
 }
 
 func (c *cache[K, V]) Remove(ctx context.Context, keys ...K) {
+	if len(keys) == 0 {
+		return
+	}
+
+	// Record the removal before deleting, so a refresh finishing in between
+	// already sees it.
+	v := c.version.Add(1)
+	now := c.clock.Now()
+	c.removedMu.Lock()
+	for _, key := range keys {
+		c.removed[key] = removal{version: v, at: now}
+	}
+	c.removedMu.Unlock()
+
 	for _, key := range keys {
 		c.otter.Delete(key)
 	}
@@ -267,6 +367,7 @@ func (c *cache[K, V]) Restore(ctx context.Context, b []byte) error {
 }
 
 func (c *cache[K, V]) Clear(ctx context.Context) {
+	c.clearedAt.Store(c.version.Add(1))
 	c.otter.Clear()
 }
 
@@ -296,20 +397,14 @@ func (c *cache[K, V]) revalidate(
 	}()
 
 	metrics.CacheRevalidations.WithLabelValues(c.resource).Inc()
+	start := c.version.Load()
 	v, err := refreshFromOrigin(ctx)
 
 	if err != nil && !db.IsNotFound(err) {
 		logger.Warn("failed to revalidate", "error", err.Error(), "key", key)
 	}
 
-	switch op(err) {
-	case WriteValue:
-		c.Set(ctx, key, v)
-	case WriteNull:
-		c.SetNull(ctx, key)
-	case Noop:
-		break
-	}
+	c.writeRefreshed(ctx, key, v, op(err), start)
 }
 
 func (c *cache[K, V]) SWR(
@@ -344,17 +439,11 @@ func (c *cache[K, V]) SWR(
 	}
 
 	// Cache Miss - measure total time including all overhead
+	version := c.version.Load()
 	v, err := refreshFromOrigin(ctx)
 	c.recordTiming(ctx, "cache_swr", "miss", start)
 
-	switch op(err) {
-	case WriteValue:
-		c.Set(ctx, key, v)
-	case WriteNull:
-		c.SetNull(ctx, key)
-	case Noop:
-		break
-	}
+	c.writeRefreshed(ctx, key, v, op(err), version)
 
 	if err != nil {
 		// Error occurred, return Miss as the cache hit status
@@ -427,35 +516,25 @@ func (c *cache[K, V]) SWRMany(
 
 	// Fetch missing keys synchronously
 	if len(missingKeys) > 0 {
+		version := c.version.Load()
 		fetchedValues, err := refreshFromOrigin(ctx, missingKeys)
 
 		switch op(err) {
 		case WriteValue:
 			if fetchedValues != nil {
-				// Write the values we got
-				c.SetMany(ctx, fetchedValues)
-				for key, value := range fetchedValues {
-					values[key] = value
-					hits[key] = Hit
-				}
-
-				// Automatically write NULL for keys that weren't returned
-				var notFoundKeys []K
+				// Write the values we got, and NULL for keys that weren't returned
+				c.writeRefreshedMany(ctx, missingKeys, fetchedValues, version)
 				for _, key := range missingKeys {
-					if _, found := fetchedValues[key]; !found {
-						notFoundKeys = append(notFoundKeys, key)
-					}
-				}
-
-				if len(notFoundKeys) > 0 {
-					c.SetNullMany(ctx, notFoundKeys)
-					for _, key := range notFoundKeys {
+					if value, found := fetchedValues[key]; found {
+						values[key] = value
+						hits[key] = Hit
+					} else {
 						hits[key] = Null
 					}
 				}
 			}
 		case WriteNull:
-			c.SetNullMany(ctx, missingKeys)
+			c.writeRefreshedMany(ctx, missingKeys, nil, version)
 			for _, key := range missingKeys {
 				hits[key] = Null
 			}
@@ -514,6 +593,7 @@ func (c *cache[K, V]) SWRWithFallback(
 	}
 
 	// Cache miss on all candidates - fetch from origin
+	version := c.version.Load()
 	v, canonicalKey, err := refreshFromOrigin(ctx)
 	c.recordTiming(ctx, "cache_swr_fallback", "miss", start)
 
@@ -527,14 +607,13 @@ func (c *cache[K, V]) SWRWithFallback(
 	var hit CacheHit
 	switch operation {
 	case WriteValue:
-		c.Set(ctx, canonicalKey, v)
 		hit = Hit
 	case WriteNull:
-		c.SetNull(ctx, canonicalKey)
 		hit = Null
 	case Noop:
 		hit = Miss
 	}
+	c.writeRefreshed(ctx, canonicalKey, v, operation, version)
 
 	return v, hit, nil
 }
@@ -552,6 +631,7 @@ func (c *cache[K, V]) revalidateWithCanonicalKey(
 	}()
 
 	metrics.CacheRevalidations.WithLabelValues(c.resource).Inc()
+	start := c.version.Load()
 	v, canonicalKey, err := refreshFromOrigin(ctx)
 
 	if err != nil && !db.IsNotFound(err) {
@@ -559,14 +639,7 @@ func (c *cache[K, V]) revalidateWithCanonicalKey(
 		return
 	}
 
-	switch op(err) {
-	case WriteValue:
-		c.Set(ctx, canonicalKey, v)
-	case WriteNull:
-		c.SetNull(ctx, canonicalKey)
-	case Noop:
-		break
-	}
+	c.writeRefreshed(ctx, canonicalKey, v, op(err), start)
 }
 
 func (c *cache[K, V]) revalidateMany(
@@ -599,6 +672,7 @@ func (c *cache[K, V]) revalidateMany(
 	}()
 
 	metrics.CacheRevalidations.WithLabelValues(c.resource).Add(float64(len(keysToRefresh)))
+	start := c.version.Load()
 	values, err := refreshFromOrigin(ctx, keysToRefresh)
 
 	if err != nil && !db.IsNotFound(err) {
@@ -608,22 +682,11 @@ func (c *cache[K, V]) revalidateMany(
 	switch op(err) {
 	case WriteValue:
 		if values != nil {
-			// Write the values we got
-			c.SetMany(ctx, values)
-
-			// Automatically write NULL for keys that weren't returned
-			var notFoundKeys []K
-			for _, key := range keysToRefresh {
-				if _, found := values[key]; !found {
-					notFoundKeys = append(notFoundKeys, key)
-				}
-			}
-			if len(notFoundKeys) > 0 {
-				c.SetNullMany(ctx, notFoundKeys)
-			}
+			// Write the values we got, and NULL for keys that weren't returned
+			c.writeRefreshedMany(ctx, keysToRefresh, values, start)
 		}
 	case WriteNull:
-		c.SetNullMany(ctx, keysToRefresh)
+		c.writeRefreshedMany(ctx, keysToRefresh, nil, start)
 	case Noop:
 		// Don't cache anything
 	}
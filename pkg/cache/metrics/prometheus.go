@@ -100,6 +100,23 @@ var (
 		[]string{"resource"},
 	)
 
+	// CacheStaleRefreshesDropped counts origin refreshes whose result was
+	// discarded because the key was removed or the cache cleared while the
+	// refresh was in flight, labeled by resource type. Writing the result
+	// back would resurrect data the caller just invalidated.
+	//
+	// Example usage:
+	//   metrics.CacheStaleRefreshesDropped.WithLabelValues("user_profile").Inc()
+	CacheStaleRefreshesDropped = lazy.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "unkey",
+			Subsystem: "cache",
+			Name:      "stale_refreshes_dropped_total",
+			Help:      "Total number of refresh results dropped because the key was invalidated during the refresh, by resource type.",
+		},
+		[]string{"resource"},
+	)
+
 	// CacheReadsErrorsTotal tracks the total number of cache read errors,
 	// labeled by resource type. Use this counter to monitor cache read error rates.
 	//
@@ -152,3 +152,70 @@ func TestSWR_CacheHit(t *testing.T) {
 		require.Equal(t, cache.Miss, hit)
 	})
 }
+
+// TestSWR_DropsRefreshRacingWithInvalidation asserts a refresh that was
+// already talking to the origin when the key got invalidated does not write
+// its now outdated result back into the cache.
+func TestSWR_DropsRefreshRacingWithInvalidation(t *testing.T) {
+	ctx := context.Background()
+	mockClock := clock.NewTestClock()
+
+	c, err := cache.New(cache.Config[string, string]{
+		Fresh:    1 * time.Minute,
+		Stale:    5 * time.Minute,
+		MaxSize:  100,
+		Resource: "test",
+		Clock:    mockClock,
+	})
+	require.NoError(t, err)
+
+	writeValue := func(err error) cache.Op { return cache.WriteValue }
+
+	t.Run("remove during refresh", func(t *testing.T) {
+		value, _, err := c.SWR(ctx, "key1", func(ctx context.Context) (string, error) {
+			// The row changes and is invalidated while we hold the old copy.
+			c.Remove(ctx, "key1")
+			return "outdated", nil
+		}, writeValue)
+		require.NoError(t, err)
+		require.Equal(t, "outdated", value, "the caller still gets what the origin returned")
+
+		_, hit := c.Get(ctx, "key1")
+		require.Equal(t, cache.Miss, hit)
+	})
+
+	t.Run("clear during refresh", func(t *testing.T) {
+		_, _, err := c.SWRMany(ctx, []string{"key2", "key3"}, func(ctx context.Context, keys []string) (map[string]string, error) {
+			c.Clear(ctx)
+			return map[string]string{"key2": "outdated"}, nil
+		}, writeValue)
+		require.NoError(t, err)
+
+		_, hits := c.GetMany(ctx, []string{"key2", "key3"})
+		require.Equal(t, cache.Miss, hits["key2"])
+		require.Equal(t, cache.Miss, hits["key3"])
+	})
+
+	t.Run("unrelated removals do not drop refreshes", func(t *testing.T) {
+		_, _, err := c.SWR(ctx, "key4", func(ctx context.Context) (string, error) {
+			c.Remove(ctx, "other")
+			return "value4", nil
+		}, writeValue)
+		require.NoError(t, err)
+
+		value, hit := c.Get(ctx, "key4")
+		require.Equal(t, cache.Hit, hit)
+		require.Equal(t, "value4", value)
+	})
+
+	t.Run("later refreshes write again", func(t *testing.T) {
+		_, _, err := c.SWR(ctx, "key1", func(ctx context.Context) (string, error) {
+			return "current", nil
+		}, writeValue)
+		require.NoError(t, err)
+
+		value, hit := c.Get(ctx, "key1")
+		require.Equal(t, cache.Hit, hit)
+		require.Equal(t, "current", value)
+	})
+}
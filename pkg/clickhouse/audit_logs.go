@@ -2,8 +2,12 @@ package clickhouse
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/unkeyed/unkey/pkg/clickhouse/schema"
+	"github.com/unkeyed/unkey/pkg/fault"
 )
 
 // InsertAuditLogs writes a batch of audit log rows to audit_logs_raw_v1 and
@@ -18,3 +22,136 @@ func (c *Client) InsertAuditLogs(ctx context.Context, rows []schema.AuditLogV1)
 	}
 	return flush(c, ctx, rows)
 }
+
+// AuditLogCursor is the composite keyset cursor for paging audit logs newest
+// first. Using (Time, EventID) keeps pages stable when several events share a
+// millisecond.
+type AuditLogCursor struct {
+	Time    int64
+	EventID string
+}
+
+// ListAuditLogsRequest filters one page of a workspace's audit logs. Empty
+// slices and strings disable the corresponding filter.
+type ListAuditLogsRequest struct {
+	WorkspaceID string
+	Bucket      string
+
+	// StartTime and EndTime bound the window in unix milliseconds, both
+	// inclusive.
+	StartTime int64
+	EndTime   int64
+
+	Events        []string
+	ActorIDs      []string
+	TargetIDs     []string
+	CorrelationID string
+
+	// Cursor, when set, starts the page at this event (inclusive) and
+	// continues towards older events.
+	Cursor *AuditLogCursor
+	Limit  int
+}
+
+// AuditLog is a single audit log row as read back from audit_logs_raw_v1.
+// The JSON columns are returned as serialized strings so callers decide how
+// to decode them.
+type AuditLog struct {
+	EventID       string   `ch:"event_id"`
+	Time          int64    `ch:"time"`
+	Bucket        string   `ch:"bucket"`
+	Source        string   `ch:"source"`
+	Event         string   `ch:"event"`
+	Description   string   `ch:"description"`
+	ActorType     string   `ch:"actor_type"`
+	ActorID       string   `ch:"actor_id"`
+	ActorName     string   `ch:"actor_name"`
+	ActorMeta     string   `ch:"actor_meta"`
+	RemoteIP      string   `ch:"remote_ip"`
+	UserAgent     string   `ch:"user_agent"`
+	Meta          string   `ch:"meta"`
+	TargetTypes   []string `ch:"target_types"`
+	TargetIDs     []string `ch:"target_ids"`
+	TargetNames   []string `ch:"target_names"`
+	TargetMetas   []string `ch:"target_metas"`
+	CorrelationID string   `ch:"correlation_id"`
+}
+
+// ListAuditLogs returns up to req.Limit audit logs for one workspace and
+// bucket, newest first. The filters map onto the table's skip indexes
+// (event, actor_id, targets.id, correlation_id) and the (workspace_id,
+// bucket, time) sort key, so narrow filters stay cheap even on busy
+// workspaces.
+func (c *Client) ListAuditLogs(ctx context.Context, req ListAuditLogsRequest) ([]AuditLog, error) {
+	params := map[string]string{
+		"workspace_id": req.WorkspaceID,
+		"bucket":       req.Bucket,
+		"start":        strconv.FormatInt(req.StartTime, 10),
+		"end":          strconv.FormatInt(req.EndTime, 10),
+		"limit":        strconv.Itoa(req.Limit),
+	}
+
+	// Only fixed condition fragments are interpolated; every caller-supplied
+	// value goes through a typed named parameter.
+	conditions := []string{
+		"workspace_id = {workspace_id:String}",
+		"bucket = {bucket:String}",
+		"time >= {start:Int64}",
+		"time <= {end:Int64}",
+	}
+	if len(req.Events) > 0 {
+		conditions = append(conditions, "event IN {events:Array(String)}")
+		params["events"] = stringArrayParam(req.Events)
+	}
+	if len(req.ActorIDs) > 0 {
+		conditions = append(conditions, "actor_id IN {actor_ids:Array(String)}")
+		params["actor_ids"] = stringArrayParam(req.ActorIDs)
+	}
+	if len(req.TargetIDs) > 0 {
+		conditions = append(conditions, "hasAny(`targets.id`, {target_ids:Array(String)})")
+		params["target_ids"] = stringArrayParam(req.TargetIDs)
+	}
+	if req.CorrelationID != "" {
+		conditions = append(conditions, "correlation_id = {correlation_id:String}")
+		params["correlation_id"] = req.CorrelationID
+	}
+	if req.Cursor != nil {
+		conditions = append(conditions, "(time, event_id) <= ({cursor_time:Int64}, {cursor_event_id:String})")
+		params["cursor_time"] = strconv.FormatInt(req.Cursor.Time, 10)
+		params["cursor_event_id"] = req.Cursor.EventID
+	}
+
+	query := fmt.Sprintf(`
+	SELECT
+		event_id,
+		time,
+		bucket,
+		source,
+		event,
+		description,
+		actor_type,
+		actor_id,
+		actor_name,
+		toJSONString(actor_meta) AS actor_meta,
+		remote_ip,
+		user_agent,
+		toJSONString(meta) AS meta,
+		`+"`targets.type`"+` AS target_types,
+		`+"`targets.id`"+` AS target_ids,
+		`+"`targets.name`"+` AS target_names,
+		arrayMap(m -> toJSONString(m), `+"`targets.meta`"+`) AS target_metas,
+		correlation_id
+	FROM default.audit_logs_raw_v1
+	WHERE %s
+	ORDER BY time DESC, event_id DESC
+	LIMIT {limit:UInt32}`,
+		strings.Join(conditions, "\n\t\tAND "),
+	)
+
+	rows, err := Select[AuditLog](ctx, c.conn, query, params)
+	if err != nil {
+		return nil, fault.Wrap(err, fault.Internal("failed to query audit logs"))
+	}
+
+	return rows, nil
+}
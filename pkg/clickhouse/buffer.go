@@ -35,6 +35,12 @@ type BufferConfig struct {
 	// via logger.Error (best-effort). Callers that need strict error handling
 	// can supply their own callback.
 	OnFlushError func(ctx context.Context, table string, rowCount int, err error)
+
+	// Spool, when set, keeps rows on disk instead of losing them: rows that
+	// do not fit into a full buffer (with Drop) and batches whose flush
+	// failed are appended to the spool and replayed later. OnFlushError
+	// still runs for failed flushes. See SpoolConfig.
+	Spool *SpoolConfig
 }
 
 // NewBuffer creates a *batch.BatchProcessor[T] that flushes rows to T's
@@ -54,6 +60,9 @@ type BufferConfig struct {
 //	    Consumers:     2,
 //	})
 //	defer buf.Close()
+//
+// If cfg.Spool is set but the spool cannot be opened, the error is logged
+// and the buffer runs without it.
 func NewBuffer[T schema.Row](c *Client, cfg BufferConfig) *batch.BatchProcessor[T] {
 	var zero T
 	table := zero.Table()
@@ -65,6 +74,28 @@ func NewBuffer[T schema.Row](c *Client, cfg BufferConfig) *batch.BatchProcessor[
 		}
 	}
 
+	var s *spool[T]
+	if cfg.Spool != nil {
+		var err error
+		s, err = newSpool(cfg.Name, *cfg.Spool, cfg.BatchSize, cfg.FlushInterval, func(ctx context.Context, rows []T) error {
+			return flush(c, ctx, rows)
+		})
+		if err != nil {
+			logger.Error("failed to open spool, rows that cannot be buffered or flushed will be dropped",
+				"buffer", cfg.Name,
+				"error", err.Error(),
+			)
+			s = nil
+		}
+	}
+
+	var overflow func(T)
+	var onClose func()
+	if s != nil {
+		overflow = s.overflow
+		onClose = s.close
+	}
+
 	return batch.New(batch.Config[T]{
 		Name:          cfg.Name,
 		Drop:          cfg.Drop,
@@ -72,8 +103,13 @@ func NewBuffer[T schema.Row](c *Client, cfg BufferConfig) *batch.BatchProcessor[
 		BufferSize:    cfg.BufferSize,
 		FlushInterval: cfg.FlushInterval,
 		Consumers:     cfg.Consumers,
+		Overflow:      overflow,
+		OnClose:       onClose,
 		Flush: func(ctx context.Context, rows []T) {
 			if err := flush(c, ctx, rows); err != nil {
+				if s != nil {
+					s.append(rows)
+				}
 				onErr(ctx, table, len(rows), err)
 			}
 		},
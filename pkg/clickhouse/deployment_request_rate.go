@@ -0,0 +1,86 @@
+package clickhouse
+
+import (
+	"context"
+	"time"
+
+	ch "github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/unkeyed/unkey/pkg/fault"
+)
+
+// GetDeploymentRequestRates returns the average requests per second frontline
+// routed to each of the given deployments in one region over a recent window.
+// It backs request-rate autoscaling, where krane sizes a deployment's replica
+// floor in its region from the traffic that region actually served.
+//
+// The window is read from the per-region aggregate, which is ordered by
+// deployment so only the requested deployments' recent rows are scanned. It
+// ends at the start of the current minute: the current minute is still being
+// filled, so including it would systematically under-report the rate.
+//
+// Deployments without traffic in the window are absent from the returned map
+// rather than mapped to zero.
+func (c *Client) GetDeploymentRequestRates(ctx context.Context, req GetDeploymentRequestRatesRequest) (map[string]float64, error) {
+	rates := make(map[string]float64, len(req.DeploymentIDs))
+	if len(req.DeploymentIDs) == 0 || req.Window < time.Minute {
+		return rates, nil
+	}
+
+	query := `
+	SELECT deployment_id, toInt64(sum(count)) as count
+	FROM default.frontline_requests_per_region_per_minute_v1
+	WHERE deployment_id IN {deployment_ids:Array(String)}
+	  AND platform = {platform:String}
+	  AND region = {region:String}
+	  AND time >= fromUnixTimestamp64Milli({since_ms:Int64})
+	  AND time < fromUnixTimestamp64Milli({until_ms:Int64})
+	GROUP BY deployment_id
+	`
+
+	window := req.Window.Truncate(time.Minute)
+	until := time.Now().Truncate(time.Minute)
+	since := until.Add(-window)
+
+	rows, err := c.conn.Query(ctx, query,
+		ch.Named("deployment_ids", req.DeploymentIDs),
+		ch.Named("platform", req.Platform),
+		ch.Named("region", req.Region),
+		ch.Named("since_ms", since.UnixMilli()),
+		ch.Named("until_ms", until.UnixMilli()),
+	)
+	if err != nil {
+		return nil, fault.Wrap(err, fault.Internal("failed to query deployment request rates"))
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var deploymentID string
+		var count int64
+		if err := rows.Scan(&deploymentID, &count); err != nil {
+			return nil, fault.Wrap(err, fault.Internal("failed to scan deployment request rate"))
+		}
+		rates[deploymentID] = float64(count) / window.Seconds()
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fault.Wrap(err, fault.Internal("error iterating deployment request rate rows"))
+	}
+
+	return rates, nil
+}
+
+// GetDeploymentRequestRatesRequest holds the parameters for querying the recent
+// request rate of a batch of deployments in one region.
+type GetDeploymentRequestRatesRequest struct {
+	DeploymentIDs []string
+
+	// Platform and Region name the region whose traffic is counted, as
+	// frontline records them on each request.
+	Platform string
+	Region   string
+
+	// Window is the lookback measured back from the start of the current
+	// minute, truncated to whole minutes. Windows shorter than a minute
+	// return no rates.
+	Window time.Duration
+}
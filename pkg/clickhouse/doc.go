@@ -12,6 +12,7 @@
 //   - Automatic connection management
 //   - Graceful shutdown with final flush capability
 //   - Support for multiple event types with dedicated buffers
+//   - Optional on-disk spool that replays rows a buffer could not hold or flush
 //
 // Example usage:
 //
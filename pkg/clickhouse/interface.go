@@ -49,6 +49,11 @@ type Querier interface {
 	// Returns 0 (not an error) when the deployment has received no traffic.
 	GetDeploymentRequestCount(ctx context.Context, req GetDeploymentRequestCountRequest) (int64, error)
 
+	// GetDeploymentRequestRates returns the average requests per second routed to
+	// each deployment over a recent window, used by request-rate autoscaling.
+	// Deployments without traffic are absent from the map.
+	GetDeploymentRequestRates(ctx context.Context, req GetDeploymentRequestRatesRequest) (map[string]float64, error)
+
 	// GetKeyLastUsedBatchPartitioned returns keys in a specific hash partition
 	// (cityHash64(key_id) % totalPartitions == partition) after the given cursor,
 	// ordered by (time, key_id). Used by the KeyLastUsedSync partition workers.
@@ -59,6 +64,11 @@ type Querier interface {
 	// only after ClickHouse confirms the insert so the caller can safely
 	// mark the source MySQL rows as exported.
 	InsertAuditLogs(ctx context.Context, rows []schema.AuditLogV1) error
+
+	// ListAuditLogs returns one page of a workspace's audit logs, newest
+	// first, narrowed by the request's filters. Used by the auditlogs list and
+	// export endpoints.
+	ListAuditLogs(ctx context.Context, req ListAuditLogsRequest) ([]AuditLog, error)
 }
 
 type ClickHouse interface {
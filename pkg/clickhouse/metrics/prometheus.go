@@ -0,0 +1,77 @@
+// Package metrics provides Prometheus collectors for the ClickHouse client.
+//
+// The collectors describe the on-disk spool that keeps rows a buffer could
+// not hold or flush: how much is waiting, how far replay is behind, and
+// what happened to each spooled row.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/unkeyed/unkey/pkg/prometheus/lazy"
+)
+
+var (
+	// SpoolBytes is the size of the segments not yet replayed, per buffer.
+	// Compare against the configured MaxBytes: at the cap, new rows are
+	// dropped.
+	//
+	// Example usage:
+	//   metrics.SpoolBytes.WithLabelValues("key_verifications").Set(float64(bytes))
+	SpoolBytes = lazy.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "unkey",
+			Subsystem: "clickhouse",
+			Name:      "spool_bytes",
+			Help:      "Bytes of spooled rows waiting to be replayed to ClickHouse, labeled by buffer.",
+		},
+		[]string{"buffer"},
+	)
+
+	// SpoolRows is the number of rows waiting to be replayed, per buffer.
+	//
+	// Example usage:
+	//   metrics.SpoolRows.WithLabelValues("key_verifications").Set(float64(rows))
+	SpoolRows = lazy.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "unkey",
+			Subsystem: "clickhouse",
+			Name:      "spool_rows",
+			Help:      "Number of spooled rows waiting to be replayed to ClickHouse, labeled by buffer.",
+		},
+		[]string{"buffer"},
+	)
+
+	// SpoolReplayLag is how long ago the oldest row still in the spool was
+	// written, in seconds. Zero when the spool is empty.
+	//
+	// Example usage:
+	//   metrics.SpoolReplayLag.WithLabelValues("key_verifications").Set(lag.Seconds())
+	SpoolReplayLag = lazy.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "unkey",
+			Subsystem: "clickhouse",
+			Name:      "spool_replay_lag_seconds",
+			Help:      "Age in seconds of the oldest row waiting in the spool, labeled by buffer.",
+		},
+		[]string{"buffer"},
+	)
+
+	// SpoolRowsTotal counts spooled rows by what happened to them.
+	//
+	// Possible event values are:
+	// - "spooled": the row was written to disk.
+	// - "replayed": the row was flushed to ClickHouse from disk.
+	// - "dropped": the spool was full or the disk write failed.
+	//
+	// Example usage:
+	//   metrics.SpoolRowsTotal.WithLabelValues("key_verifications", "spooled").Add(float64(len(rows)))
+	SpoolRowsTotal = lazy.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "unkey",
+			Subsystem: "clickhouse",
+			Name:      "spool_rows_total",
+			Help:      "Total number of rows handled by the spool, labeled by buffer and event (spooled|replayed|dropped).",
+		},
+		[]string{"buffer", "event"},
+	)
+)
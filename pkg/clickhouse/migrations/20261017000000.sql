@@ -0,0 +1,13 @@
+-- Record shadow-mode firewall matches on the request log.
+--
+-- Firewall policies with ACTION_LOG let matching requests through and add
+-- their policy id to `firewall_matches`, so a rule can be observed before it
+-- is switched to an enforcing action. Requests answered at the edge (deny,
+-- redirect, respond) never reach an instance and are not logged here.
+--
+-- DEPLOYMENT ORDER: apply this migration before deploying frontline writers
+-- that include `firewall_matches` in their insert column list. The empty
+-- array default keeps old writers and existing parts readable.
+
+ALTER TABLE `default`.`frontline_requests_raw_v1`
+  ADD COLUMN `firewall_matches` Array(String) DEFAULT [] AFTER `gateway_latency`;
@@ -0,0 +1,24 @@
+-- Persistent volume usage written by heimdall. See
+-- pkg/clickhouse/schema/042_volume_checkpoints_v1.sql for the billing rules.
+
+CREATE TABLE IF NOT EXISTS default.volume_checkpoints_v1 (
+  node_id LowCardinality(String),
+  workspace_id String,
+  project_id LowCardinality(String),
+  app_id LowCardinality(String),
+  environment_id LowCardinality(String),
+  volume_id String,
+  pod_uid String,
+  ts Int64 CODEC(Delta, ZSTD(1)),
+  allocated_bytes Int64 CODEC(DoubleDelta, ZSTD(3)),
+  used_bytes Int64 CODEC(DoubleDelta, ZSTD(1)),
+  region LowCardinality(String),
+  platform LowCardinality(String),
+  INDEX idx_app app_id TYPE bloom_filter(0.01) GRANULARITY 4,
+  INDEX idx_ts ts TYPE minmax GRANULARITY 1
+)
+ENGINE = ReplacingMergeTree
+ORDER BY (workspace_id, volume_id, ts, pod_uid)
+PARTITION BY toYYYYMMDD(fromUnixTimestamp64Milli(ts))
+TTL toDateTime(fromUnixTimestamp64Milli(ts)) + INTERVAL 95 DAY DELETE
+SETTINGS ttl_only_drop_parts = 1;
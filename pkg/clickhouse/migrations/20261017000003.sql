@@ -0,0 +1,30 @@
+-- Per-region request counts for request-rate autoscaling. See
+-- pkg/clickhouse/schema/043_frontline_requests_per_region_per_minute_v1.sql.
+
+CREATE TABLE IF NOT EXISTS default.frontline_requests_per_region_per_minute_v1 (
+  time DateTime,
+  deployment_id String,
+  platform LowCardinality(String),
+  region LowCardinality(String),
+  count SimpleAggregateFunction(sum, Int64)
+)
+ENGINE = AggregatingMergeTree()
+ORDER BY (deployment_id, platform, region, time)
+PARTITION BY toYYYYMMDD(time)
+TTL time + INTERVAL 1 DAY DELETE
+SETTINGS index_granularity = 8192, ttl_only_drop_parts = 1;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS default.frontline_requests_per_region_per_minute_mv_v1
+TO default.frontline_requests_per_region_per_minute_v1 AS
+SELECT
+  toStartOfMinute(fromUnixTimestamp64Milli(time)) AS time,
+  deployment_id,
+  platform,
+  region,
+  toInt64(count()) AS count
+FROM default.frontline_requests_raw_v1
+GROUP BY
+  time,
+  deployment_id,
+  platform,
+  region;
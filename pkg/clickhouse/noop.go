@@ -44,6 +44,11 @@ func (n *noop) GetDeploymentRequestCount(ctx context.Context, req GetDeploymentR
 	return 0, nil
 }
 
+// GetDeploymentRequestRates implements the Querier interface but always returns an empty map.
+func (n *noop) GetDeploymentRequestRates(ctx context.Context, req GetDeploymentRequestRatesRequest) (map[string]float64, error) {
+	return make(map[string]float64), nil
+}
+
 // GetKeyLastUsedBatchPartitioned implements the Querier interface but always returns an empty slice.
 func (n *noop) GetKeyLastUsedBatchPartitioned(ctx context.Context, req GetKeyLastUsedBatchRequest) ([]KeyLastUsed, error) {
 	return nil, nil
@@ -54,6 +59,11 @@ func (n *noop) InsertAuditLogs(ctx context.Context, rows []schema.AuditLogV1) er
 	return nil
 }
 
+// ListAuditLogs implements the Querier interface but always returns an empty slice.
+func (n *noop) ListAuditLogs(ctx context.Context, req ListAuditLogsRequest) ([]AuditLog, error) {
+	return nil, nil
+}
+
 func (n *noop) Conn() ch.Conn {
 	return nil
 }
@@ -25,6 +25,10 @@ CREATE TABLE key_verifications_raw_v2
   -- did not originate from the gateway.
   app_id LowCardinality(String) DEFAULT '' CODEC(ZSTD(1)),
 
+  -- The keyspace environment of the key, e.g. 'test' or 'live'. Empty for
+  -- keys created without one.
+  environment LowCardinality(String) DEFAULT '' CODEC(ZSTD(1)),
+
   -- Examples:
   -- - "VALID"
   -- - "RATE_LIMITED"
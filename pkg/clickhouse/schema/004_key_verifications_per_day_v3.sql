@@ -8,6 +8,7 @@ CREATE TABLE key_verifications_per_day_v3 (
   outcome LowCardinality (String),
   source LowCardinality (String),
   app_id LowCardinality (String),
+  environment LowCardinality (String),
   tags Array(String),
   count SimpleAggregateFunction(sum, Int64),
   spent_credits SimpleAggregateFunction(sum, Int64),
@@ -29,7 +30,8 @@ ORDER BY
     outcome,
     tags,
     source,
-    app_id
+    app_id,
+    environment
   )
 PARTITION BY toStartOfMonth(time)
 TTL time + INTERVAL 365 DAY DELETE
@@ -45,6 +47,7 @@ SELECT
   outcome,
   source,
   app_id,
+  environment,
   tags,
   sum(count) as count,
   sum(spent_credits) as spent_credits,
@@ -64,5 +67,6 @@ GROUP BY
   outcome,
   source,
   app_id,
+  environment,
   tags
 ;
@@ -35,6 +35,8 @@ CREATE TABLE frontline_requests_raw_v1 (
   instance_latency Int64,
   -- Milliseconds - gateway overhead
   gateway_latency Int64,
+  -- IDs of ACTION_LOG firewall policies that matched the request
+  firewall_matches Array(String) DEFAULT [],
   INDEX idx_request_id (request_id) TYPE bloom_filter GRANULARITY 1,
   INDEX idx_deployment_id (deployment_id) TYPE bloom_filter GRANULARITY 1,
   INDEX idx_instance_id (instance_id) TYPE bloom_filter GRANULARITY 1,
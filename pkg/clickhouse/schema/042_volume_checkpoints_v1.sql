@@ -0,0 +1,48 @@
+-- Persistent volume usage, written by heimdall once per collection tick for
+-- every krane-managed PersistentVolumeClaim mounted by a running pod on the
+-- node.
+--
+-- Unlike the ephemeral disk columns on instance_checkpoints_v1, a persistent
+-- volume outlives the pods that mount it, so it is keyed by volume_id rather
+-- than container_uid. During a rollout the old and new deployment briefly
+-- share the volume on the same node and both pods produce a row for the same
+-- tick; billing must collapse them by (volume_id, ts) before integrating.
+--
+-- Billing query rules:
+--   1. Use FINAL, for the same reason as instance_checkpoints_v1.
+--   2. Group by volume_id and keep one row per ts (any(allocated_bytes)).
+--   3. Integrate allocated_bytes over consecutive ts pairs and drop any pair
+--      with dt > maxSampleGapMillis (2 min), same gap rule as instances:
+--        sum(least(allocated_bytes, leadInFrame(allocated_bytes)) *
+--            (leadInFrame(ts) - ts)) / 1000
+--
+-- used_bytes is observational (statfs on the CSI mount), not billed.
+
+CREATE TABLE volume_checkpoints_v1 (
+  node_id LowCardinality(String),
+  workspace_id String,
+  project_id LowCardinality(String),
+  app_id LowCardinality(String),
+  environment_id LowCardinality(String),
+  volume_id String,
+  -- Pod that had the volume mounted when the sample was taken. Part of the
+  -- dedup key so the two pods of a rollout don't overwrite each other.
+  pod_uid String,
+  -- Unix milliseconds from heimdall's monotonic-anchored clock.
+  ts Int64 CODEC(Delta, ZSTD(1)),
+  -- Requested size of the claim (bytes). Grows when the volume is resized.
+  allocated_bytes Int64 CODEC(DoubleDelta, ZSTD(3)),
+  -- Used bytes on the volume's filesystem (statfs). Zero when the mount
+  -- could not be read.
+  used_bytes Int64 CODEC(DoubleDelta, ZSTD(1)),
+  region LowCardinality(String),
+  platform LowCardinality(String),
+
+  INDEX idx_app app_id TYPE bloom_filter(0.01) GRANULARITY 4,
+  INDEX idx_ts ts TYPE minmax GRANULARITY 1
+)
+ENGINE = ReplacingMergeTree
+ORDER BY (workspace_id, volume_id, ts, pod_uid)
+PARTITION BY toYYYYMMDD(fromUnixTimestamp64Milli(ts))
+TTL toDateTime(fromUnixTimestamp64Milli(ts)) + INTERVAL 95 DAY DELETE
+SETTINGS ttl_only_drop_parts = 1;
@@ -0,0 +1,29 @@
+-- Requests per deployment and region, for krane's request-rate autoscaler.
+-- Ordered by deployment so a poll for a batch of deployments reads only their
+-- recent rows, and kept only as long as the autoscaler looks back.
+CREATE TABLE frontline_requests_per_region_per_minute_v1 (
+  time DateTime,
+  deployment_id String,
+  platform LowCardinality (String),
+  region LowCardinality (String),
+  count SimpleAggregateFunction(sum, Int64)
+) ENGINE = AggregatingMergeTree()
+ORDER BY (deployment_id, platform, region, time)
+PARTITION BY toYYYYMMDD(time)
+TTL time + INTERVAL 1 DAY DELETE
+SETTINGS index_granularity = 8192, ttl_only_drop_parts = 1;
+
+CREATE MATERIALIZED VIEW frontline_requests_per_region_per_minute_mv_v1
+TO frontline_requests_per_region_per_minute_v1 AS
+SELECT
+  toStartOfMinute(fromUnixTimestamp64Milli(time)) AS time,
+  deployment_id,
+  platform,
+  region,
+  toInt64(count()) AS count
+FROM frontline_requests_raw_v1
+GROUP BY
+  time,
+  deployment_id,
+  platform,
+  region;
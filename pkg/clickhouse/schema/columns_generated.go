@@ -9,7 +9,7 @@ func (KeyVerification) Table() string {
 
 // InsertColumns implements [Row]; derived from KeyVerification's ch tags.
 func (KeyVerification) InsertColumns() string {
-	return "`request_id`, `time`, `workspace_id`, `key_space_id`, `identity_id`, `external_id`, `key_id`, `region`, `source`, `app_id`, `outcome`, `tags`, `spent_credits`, `latency`"
+	return "`request_id`, `time`, `workspace_id`, `key_space_id`, `identity_id`, `external_id`, `key_id`, `region`, `source`, `app_id`, `environment`, `outcome`, `tags`, `spent_credits`, `latency`"
 }
 
 // Table implements [Row].
@@ -62,6 +62,16 @@ func (InstanceCheckpoint) InsertColumns() string {
 	return "`node_id`, `workspace_id`, `project_id`, `app_id`, `environment_id`, `resource_type`, `resource_id`, `pod_uid`, `instance_id`, `container_uid`, `restart_count`, `ts`, `event_kind`, `cpu_usage_usec`, `memory_bytes`, `cpu_allocated_millicores`, `memory_allocated_bytes`, `disk_allocated_bytes`, `disk_used_bytes`, `network_egress_public_bytes`, `network_egress_private_bytes`, `network_ingress_public_bytes`, `network_ingress_private_bytes`, `region`, `platform`, `attributes`"
 }
 
+// Table implements [Row].
+func (VolumeCheckpoint) Table() string {
+	return "default.volume_checkpoints_v1"
+}
+
+// InsertColumns implements [Row]; derived from VolumeCheckpoint's ch tags.
+func (VolumeCheckpoint) InsertColumns() string {
+	return "`node_id`, `workspace_id`, `project_id`, `app_id`, `environment_id`, `volume_id`, `pod_uid`, `ts`, `allocated_bytes`, `used_bytes`, `region`, `platform`"
+}
+
 // Table implements [Row].
 func (InstanceEventV1) Table() string {
 	return "default.instance_events_raw_v1"
@@ -79,7 +89,7 @@ func (FrontlineRequest) Table() string {
 
 // InsertColumns implements [Row]; derived from FrontlineRequest's ch tags.
 func (FrontlineRequest) InsertColumns() string {
-	return "`request_id`, `time`, `workspace_id`, `project_id`, `app_id`, `environment_id`, `frontline_id`, `deployment_id`, `instance_id`, `instance_address`, `region`, `platform`, `method`, `host`, `path`, `query_string`, `query_params`, `request_headers`, `request_body`, `response_status`, `response_headers`, `response_body`, `user_agent`, `ip_address`, `total_latency`, `instance_latency`, `gateway_latency`"
+	return "`request_id`, `time`, `workspace_id`, `project_id`, `app_id`, `environment_id`, `frontline_id`, `deployment_id`, `instance_id`, `instance_address`, `region`, `platform`, `method`, `host`, `path`, `query_string`, `query_params`, `request_headers`, `request_body`, `response_status`, `response_headers`, `response_body`, `user_agent`, `ip_address`, `total_latency`, `instance_latency`, `gateway_latency`, `firewall_matches`"
 }
 
 // Table implements [Row].
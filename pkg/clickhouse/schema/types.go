@@ -31,7 +31,10 @@ type KeyVerification struct {
 	Source string `ch:"source" json:"source"`
 	// AppID identifies the app whose gateway ran the verification. It is empty
 	// for verifications that did not originate from the gateway.
-	AppID        string   `ch:"app_id" json:"app_id"`
+	AppID string `ch:"app_id" json:"app_id"`
+	// Environment is the keyspace environment of the verified key, e.g.
+	// "test" or "live". It is empty for keys created without one.
+	Environment  string   `ch:"environment" json:"environment"`
 	Outcome      string   `ch:"outcome" json:"outcome"`
 	Tags         []string `ch:"tags" json:"tags"`
 	SpentCredits int64    `ch:"spent_credits" json:"spent_credits"`
@@ -264,6 +267,27 @@ func (a InstanceCheckpointAttributes) Marshal() string {
 	return string(b)
 }
 
+// VolumeCheckpoint is a single usage reading for one persistent volume,
+// written by heimdall for every pod that has the volume mounted. Billing
+// integrates allocated_bytes over time per volume_id; see
+// 042_volume_checkpoints_v1.sql.
+//
+//unkey:table default.volume_checkpoints_v1
+type VolumeCheckpoint struct {
+	NodeID         string `ch:"node_id" json:"node_id"`
+	WorkspaceID    string `ch:"workspace_id" json:"workspace_id"`
+	ProjectID      string `ch:"project_id" json:"project_id"`
+	AppID          string `ch:"app_id" json:"app_id"`
+	EnvironmentID  string `ch:"environment_id" json:"environment_id"`
+	VolumeID       string `ch:"volume_id" json:"volume_id"`
+	PodUID         string `ch:"pod_uid" json:"pod_uid"`
+	Ts             int64  `ch:"ts" json:"ts"`
+	AllocatedBytes int64  `ch:"allocated_bytes" json:"allocated_bytes"`
+	UsedBytes      int64  `ch:"used_bytes" json:"used_bytes"`
+	Region         string `ch:"region" json:"region"`
+	Platform       string `ch:"platform" json:"platform"`
+}
+
 // InstanceEventV1 represents the v1 instance event raw table structure.
 // Captured by krane's pod watch on container running, termination, and
 // waiting (CrashLoopBackOff, ImagePullBackOff, …) transitions. Mirrors
@@ -340,6 +364,7 @@ type FrontlineRequest struct {
 	TotalLatency    int64               `ch:"total_latency" json:"total_latency"`
 	InstanceLatency int64               `ch:"instance_latency" json:"instance_latency"`
 	GatewayLatency  int64               `ch:"gateway_latency" json:"gateway_latency"`
+	FirewallMatches []string            `ch:"firewall_matches" json:"firewall_matches"`
 }
 
 // AuditLogV1 represents one logical audit event in audit_logs_raw_v1.
@@ -0,0 +1,767 @@
+package clickhouse
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/unkeyed/unkey/pkg/clickhouse/metrics"
+	"github.com/unkeyed/unkey/pkg/clickhouse/schema"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/pkg/repeat"
+)
+
+// FsyncPolicy controls when spooled rows are forced to stable storage.
+type FsyncPolicy string
+
+const (
+	// FsyncAlways syncs after every appended record: each failed batch, and
+	// each batch of overflowed rows. Nothing written to the spool is lost on
+	// power failure.
+	FsyncAlways FsyncPolicy = "always"
+
+	// FsyncInterval syncs every SpoolConfig.FsyncInterval. A process crash
+	// loses nothing; a power failure loses at most one interval.
+	FsyncInterval FsyncPolicy = "interval"
+
+	// FsyncNever leaves syncing to the operating system.
+	FsyncNever FsyncPolicy = "never"
+)
+
+// SpoolConfig configures the on-disk spool of a buffer created via NewBuffer.
+//
+// Rows that do not fit into a full buffer, and batches whose flush failed
+// after all retries, are appended to the spool instead of being dropped. A
+// background loop replays them to ClickHouse in the order they were written
+// once inserts succeed again. Spooled rows survive restarts as long as Dir
+// is on a persistent volume.
+type SpoolConfig struct {
+	// Dir is the parent directory of the spool. Each buffer writes into its
+	// own subdirectory named after BufferConfig.Name.
+	Dir string
+
+	// MaxSegmentBytes is the size at which the active segment file is sealed
+	// and a new one is started. Fully replayed segments are deleted.
+	// Defaults to 64 MiB.
+	MaxSegmentBytes int64
+
+	// MaxBytes caps the disk space used by rows waiting to be replayed,
+	// summed over every buffer spooling into Dir. Rows that would exceed it
+	// are dropped and counted.
+	// Defaults to 1 GiB.
+	MaxBytes int64
+
+	// Fsync controls durability of appended rows. Defaults to FsyncInterval.
+	Fsync FsyncPolicy
+
+	// FsyncInterval is how often the active segment is synced under
+	// FsyncInterval. Defaults to 1 second.
+	FsyncInterval time.Duration
+
+	// ReplayInterval is how often the spool tries to replay rows to
+	// ClickHouse. Defaults to 5 seconds.
+	ReplayInterval time.Duration
+}
+
+const (
+	defaultSpoolMaxSegmentBytes = 64 << 20
+	defaultSpoolMaxBytes        = 1 << 30
+	defaultSpoolFsyncInterval   = time.Second
+	defaultSpoolReplayInterval  = 5 * time.Second
+
+	// defaultSpoolOverflowInterval applies when the buffer has no flush
+	// interval of its own.
+	defaultSpoolOverflowInterval = time.Second
+
+	spoolSegmentExt = ".seg"
+	spoolPosFile    = "replay.pos"
+
+	// spoolHeaderSize is the size of a record header: payload length,
+	// crc32 of the payload, row count (all uint32) and the unix millisecond
+	// write time (int64), little endian. The payload is the JSON encoded rows.
+	spoolHeaderSize = 20
+)
+
+var errSpoolCorrupt = errors.New("corrupt spool record")
+
+// spoolUsage holds the bytes waiting to be replayed per spool directory, so
+// MaxBytes caps all buffers sharing a SpoolConfig.Dir together rather than
+// each of them on its own.
+var spoolUsage sync.Map // map[string]*atomic.Int64
+
+func spoolUsageFor(dir string) *atomic.Int64 {
+	usage, _ := spoolUsage.LoadOrStore(filepath.Clean(dir), new(atomic.Int64))
+	return usage.(*atomic.Int64)
+}
+
+// spoolPosition is the replay cursor: everything before offset in segment
+// seq, and every segment before seq, has been flushed to ClickHouse.
+type spoolPosition struct {
+	seq    int64
+	offset int64
+}
+
+type spoolHeader struct {
+	length    uint32
+	checksum  uint32
+	rows      uint32
+	writtenAt int64
+}
+
+// spool is a segmented append-only log of rows waiting to be written to
+// ClickHouse. Appends go to the active segment; replay reads from the
+// oldest segment with its own file handle, so neither blocks the other
+// beyond the bookkeeping under mu.
+type spool[T schema.Row] struct {
+	name      string
+	dir       string
+	cfg       SpoolConfig
+	batchSize int
+	flush     func(context.Context, []T) error
+
+	mu sync.Mutex
+	// segments holds the sequence numbers of all segment files on disk in
+	// ascending order, including the active one.
+	segments   []int64
+	active     *os.File
+	activeSeq  int64
+	activeSize int64
+	dirty      bool
+	bytes      int64
+	rows       int64
+	closed     bool
+
+	// usage is shared with every spool in the same cfg.Dir and is kept in
+	// step with bytes.
+	usage *atomic.Int64
+
+	// pendingMu guards pending: overflowed rows that are written as one
+	// record per batch instead of one record per row.
+	pendingMu sync.Mutex
+	pending   []T
+
+	// replayMu serializes replay passes and guards pos.
+	replayMu sync.Mutex
+	pos      spoolPosition
+
+	stop []func()
+}
+
+// newSpool opens the spool in cfg.Dir/name, recovers rows left behind by a
+// previous process and starts the replay loop. flush must not spool on its
+// own failure; replay retries failed batches on the next pass. Overflowed
+// rows are written at least every overflowInterval.
+func newSpool[T schema.Row](name string, cfg SpoolConfig, batchSize int, overflowInterval time.Duration, flush func(context.Context, []T) error) (*spool[T], error) {
+	if cfg.Dir == "" {
+		return nil, fault.New("spool directory must not be empty")
+	}
+	if cfg.MaxSegmentBytes <= 0 {
+		cfg.MaxSegmentBytes = defaultSpoolMaxSegmentBytes
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = defaultSpoolMaxBytes
+	}
+	if cfg.Fsync == "" {
+		cfg.Fsync = FsyncInterval
+	}
+	if cfg.FsyncInterval <= 0 {
+		cfg.FsyncInterval = defaultSpoolFsyncInterval
+	}
+	if cfg.ReplayInterval <= 0 {
+		cfg.ReplayInterval = defaultSpoolReplayInterval
+	}
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	if overflowInterval <= 0 {
+		overflowInterval = defaultSpoolOverflowInterval
+	}
+
+	s := &spool[T]{ //nolint:exhaustruct // the remaining fields are recovered below
+		name:      name,
+		dir:       filepath.Join(cfg.Dir, name),
+		cfg:       cfg,
+		batchSize: batchSize,
+		flush:     flush,
+		usage:     spoolUsageFor(cfg.Dir),
+	}
+
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return nil, fault.Wrap(err, fault.Internal("failed to create spool directory"))
+	}
+	if err := s.recover(); err != nil {
+		return nil, err
+	}
+	s.updateMetrics()
+
+	s.stop = append(s.stop, repeat.Every(cfg.ReplayInterval, s.replay))
+	s.stop = append(s.stop, repeat.Every(overflowInterval, s.flushPending))
+	if cfg.Fsync == FsyncInterval {
+		s.stop = append(s.stop, repeat.Every(cfg.FsyncInterval, s.sync))
+	}
+
+	return s, nil
+}
+
+// recover scans the segments on disk, drops those already replayed and
+// truncates torn records at the tail of a segment. New rows always go into
+// a fresh segment so recovered files are never appended to.
+func (s *spool[T]) recover() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fault.Wrap(err, fault.Internal("failed to read spool directory"))
+	}
+
+	pos, err := s.readPosition()
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, spoolSegmentExt) {
+			continue
+		}
+		seq, parseErr := strconv.ParseInt(strings.TrimSuffix(name, spoolSegmentExt), 10, 64)
+		if parseErr != nil {
+			continue
+		}
+		if seq < pos.seq {
+			// Fully replayed, but the process exited before deleting it.
+			_ = os.Remove(s.segmentPath(seq))
+			continue
+		}
+		s.segments = append(s.segments, seq)
+	}
+	slices.Sort(s.segments)
+
+	if len(s.segments) == 0 || s.segments[0] != pos.seq {
+		// The segment the cursor pointed into is gone; start at the
+		// beginning of whatever is left.
+		pos.offset = 0
+		if len(s.segments) > 0 {
+			pos.seq = s.segments[0]
+		}
+	}
+	s.pos = pos
+
+	for _, seq := range s.segments {
+		start := int64(0)
+		if seq == pos.seq {
+			start = pos.offset
+		}
+		rows, end, scanErr := s.scanSegment(seq, start)
+		if scanErr != nil {
+			return scanErr
+		}
+		s.rows += rows
+		s.addBytesLocked(end - start)
+	}
+
+	if n := len(s.segments); n > 0 {
+		s.activeSeq = s.segments[n-1] + 1
+	} else {
+		s.activeSeq = pos.seq + 1
+	}
+
+	return nil
+}
+
+// scanSegment counts the intact records of a segment starting at offset and
+// truncates the file after the last one.
+func (s *spool[T]) scanSegment(seq, offset int64) (rows int64, end int64, err error) {
+	path := s.segmentPath(seq)
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, fault.Wrap(err, fault.Internal("failed to open spool segment"))
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, 0, fault.Wrap(err, fault.Internal("failed to stat spool segment"))
+	}
+	size := info.Size()
+
+	end = offset
+	for end < size {
+		header, _, readErr := readSpoolRecord(f, end, size)
+		if readErr != nil {
+			logger.Warn("truncating spool segment after unreadable record",
+				"buffer", s.name,
+				"segment", path,
+				"offset", end,
+				"error", readErr.Error(),
+			)
+			if truncErr := os.Truncate(path, end); truncErr != nil {
+				return 0, 0, fault.Wrap(truncErr, fault.Internal("failed to truncate spool segment"))
+			}
+			break
+		}
+		rows += int64(header.rows)
+		end += spoolHeaderSize + int64(header.length)
+	}
+
+	return rows, end, nil
+}
+
+// overflow queues a row that did not fit into the buffer. Queued rows are
+// appended as a single record once a batch is full, and by the background
+// loop otherwise, so a burst of overflow costs one write and at most one
+// fsync per batch rather than per row.
+func (s *spool[T]) overflow(row T) {
+	s.pendingMu.Lock()
+	s.pending = append(s.pending, row)
+	var full []T
+	if len(s.pending) >= s.batchSize {
+		full = s.pending
+		s.pending = nil
+	}
+	s.pendingMu.Unlock()
+
+	s.append(full)
+}
+
+// flushPending appends the queued overflow rows, if any.
+func (s *spool[T]) flushPending() {
+	s.pendingMu.Lock()
+	rows := s.pending
+	s.pending = nil
+	s.pendingMu.Unlock()
+
+	s.append(rows)
+}
+
+// append writes rows to the active segment. It never blocks on ClickHouse;
+// rows that do not fit under MaxBytes, or cannot be written, are dropped.
+func (s *spool[T]) append(rows []T) {
+	if len(rows) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(rows)
+	if err != nil {
+		s.drop(len(rows), "failed to encode rows", err)
+		return
+	}
+
+	record := make([]byte, spoolHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(record[0:4], uint32(len(payload))) //nolint:gosec // bounded by MaxSegmentBytes in practice
+	binary.LittleEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(payload))
+	binary.LittleEndian.PutUint32(record[8:12], uint32(len(rows)))               //nolint:gosec // batch sizes are far below 2^32
+	binary.LittleEndian.PutUint64(record[12:20], uint64(time.Now().UnixMilli())) //nolint:gosec // unix millis are positive
+	copy(record[spoolHeaderSize:], payload)
+	size := int64(len(record))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		s.drop(len(rows), "spool is closed", nil)
+		return
+	}
+	// Reserve the space up front: other spools in the same directory
+	// update usage under their own locks.
+	if s.usage.Add(size) > s.cfg.MaxBytes {
+		s.usage.Add(-size)
+		s.drop(len(rows), "spool is full", nil)
+		return
+	}
+
+	if s.active != nil && s.activeSize > 0 && s.activeSize+size > s.cfg.MaxSegmentBytes {
+		s.sealLocked()
+	}
+	if s.active == nil {
+		f, openErr := os.OpenFile(s.segmentPath(s.activeSeq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+		if openErr != nil {
+			s.usage.Add(-size)
+			s.drop(len(rows), "failed to create spool segment", openErr)
+			return
+		}
+		s.active = f
+		s.activeSize = 0
+		s.segments = append(s.segments, s.activeSeq)
+	}
+
+	// A single write per record means a crash leaves at most one torn
+	// record at the tail, which recovery truncates.
+	n, err := s.active.Write(record)
+	s.activeSize += int64(n)
+	if err != nil {
+		s.usage.Add(-size)
+		s.drop(len(rows), "failed to write spool record", err)
+		// The segment may now end in a partial record. Seal it so replay
+		// stops there and new rows start in a clean segment.
+		s.sealLocked()
+		return
+	}
+
+	s.bytes += size // already reserved in usage
+	s.rows += int64(len(rows))
+	if s.cfg.Fsync == FsyncAlways {
+		if syncErr := s.active.Sync(); syncErr != nil {
+			logger.Error("failed to sync spool segment", "buffer", s.name, "error", syncErr.Error())
+		}
+	} else {
+		s.dirty = true
+	}
+
+	metrics.SpoolRowsTotal.WithLabelValues(s.name, "spooled").Add(float64(len(rows)))
+	metrics.SpoolBytes.WithLabelValues(s.name).Set(float64(s.bytes))
+	metrics.SpoolRows.WithLabelValues(s.name).Set(float64(s.rows))
+}
+
+// addBytesLocked adjusts the bytes waiting to be replayed, both for this
+// spool and for its directory. Callers must hold mu.
+func (s *spool[T]) addBytesLocked(delta int64) {
+	s.bytes += delta
+	s.usage.Add(delta)
+}
+
+func (s *spool[T]) drop(rows int, reason string, err error) {
+	metrics.SpoolRowsTotal.WithLabelValues(s.name, "dropped").Add(float64(rows))
+	args := []any{"buffer", s.name, "rows", rows}
+	if err != nil {
+		args = append(args, "error", err.Error())
+	}
+	logger.Error("dropping rows: "+reason, args...)
+}
+
+// sealLocked syncs and closes the active segment. The next append starts a
+// new one. Callers must hold mu.
+func (s *spool[T]) sealLocked() {
+	if s.active == nil {
+		return
+	}
+	if s.cfg.Fsync != FsyncNever {
+		if err := s.active.Sync(); err != nil {
+			logger.Error("failed to sync spool segment", "buffer", s.name, "error", err.Error())
+		}
+	}
+	if err := s.active.Close(); err != nil {
+		logger.Error("failed to close spool segment", "buffer", s.name, "error", err.Error())
+	}
+	s.active = nil
+	s.activeSize = 0
+	s.dirty = false
+	s.activeSeq++
+}
+
+// sync flushes the active segment to stable storage if it was written to
+// since the last sync.
+func (s *spool[T]) sync() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.active == nil || !s.dirty {
+		return
+	}
+	if err := s.active.Sync(); err != nil {
+		logger.Error("failed to sync spool segment", "buffer", s.name, "error", err.Error())
+		return
+	}
+	s.dirty = false
+}
+
+// replay flushes spooled rows to ClickHouse in write order until the spool
+// is empty or a flush fails. It reads the active segment up to the last
+// complete record without sealing it, so an outage does not produce a
+// segment per pass.
+func (s *spool[T]) replay() {
+	s.replayMu.Lock()
+	defer s.replayMu.Unlock()
+	defer s.updateMetrics()
+
+	for {
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			return
+		}
+		if len(s.segments) == 0 {
+			// Skipped corrupt records have unknown row counts; an empty
+			// spool is the point where the counters are exact again.
+			s.addBytesLocked(-s.bytes)
+			s.rows = 0
+			s.mu.Unlock()
+			return
+		}
+		seq := s.segments[0]
+		sealed := !(seq == s.activeSeq && s.active != nil)
+		limit := s.activeSize
+		s.mu.Unlock()
+
+		if s.pos.seq != seq {
+			s.pos = spoolPosition{seq: seq, offset: 0}
+		}
+
+		rows, records, next, err := s.readBatch(seq, sealed, limit)
+		if err != nil {
+			logger.Error("failed to read spool segment", "buffer", s.name, "segment", s.segmentPath(seq), "error", err.Error())
+			return
+		}
+
+		if next == s.pos.offset {
+			if !sealed {
+				return
+			}
+			s.finishSegment(seq)
+			continue
+		}
+
+		if len(rows) > 0 {
+			if err = s.flush(context.Background(), rows); err != nil {
+				logger.Warn("failed to replay spooled rows, will retry",
+					"buffer", s.name,
+					"rows", len(rows),
+					"error", err.Error(),
+				)
+				return
+			}
+		}
+
+		consumed := next - s.pos.offset
+		s.pos.offset = next
+		if err = s.writePosition(); err != nil {
+			// The rows are in ClickHouse, but a restart would replay them
+			// again. Async insert deduplication absorbs identical blocks.
+			logger.Error("failed to persist spool position", "buffer", s.name, "error", err.Error())
+		}
+
+		s.mu.Lock()
+		s.addBytesLocked(-consumed)
+		s.rows -= records
+		s.mu.Unlock()
+		metrics.SpoolRowsTotal.WithLabelValues(s.name, "replayed").Add(float64(len(rows)))
+	}
+}
+
+// readBatch reads records from the replay position until at least batchSize
+// rows are collected or the readable end of the segment is reached. It
+// returns the decoded rows, the row count of the records read and the
+// offset after the last one. For sealed segments, an unreadable record ends
+// the segment and everything after it is skipped.
+func (s *spool[T]) readBatch(seq int64, sealed bool, limit int64) (rows []T, records int64, next int64, err error) {
+	f, err := os.Open(s.segmentPath(seq))
+	if err != nil {
+		return nil, 0, 0, fault.Wrap(err, fault.Internal("failed to open spool segment"))
+	}
+	defer func() { _ = f.Close() }()
+
+	if sealed {
+		info, statErr := f.Stat()
+		if statErr != nil {
+			return nil, 0, 0, fault.Wrap(statErr, fault.Internal("failed to stat spool segment"))
+		}
+		limit = info.Size()
+	}
+
+	next = s.pos.offset
+	for next < limit && len(rows) < s.batchSize {
+		header, payload, readErr := readSpoolRecord(f, next, limit)
+		if readErr != nil {
+			if !sealed {
+				// Appends happen under mu and limit was taken after the
+				// last complete one, so this is a read error, not a tear.
+				return nil, 0, 0, readErr
+			}
+			logger.Error("skipping the rest of an unreadable spool segment",
+				"buffer", s.name,
+				"segment", s.segmentPath(seq),
+				"offset", next,
+				"error", readErr.Error(),
+			)
+			next = limit
+			break
+		}
+
+		var decoded []T
+		if decodeErr := json.Unmarshal(payload, &decoded); decodeErr != nil {
+			s.drop(int(header.rows), "failed to decode spooled rows", decodeErr)
+		} else {
+			rows = append(rows, decoded...)
+		}
+		records += int64(header.rows)
+		next += spoolHeaderSize + int64(header.length)
+	}
+
+	return rows, records, next, nil
+}
+
+// finishSegment deletes a fully replayed segment and moves the cursor to
+// the next one.
+func (s *spool[T]) finishSegment(seq int64) {
+	s.mu.Lock()
+	s.segments = s.segments[1:]
+	s.mu.Unlock()
+
+	s.pos = spoolPosition{seq: seq + 1, offset: 0}
+	if err := s.writePosition(); err != nil {
+		logger.Error("failed to persist spool position", "buffer", s.name, "error", err.Error())
+	}
+	if err := os.Remove(s.segmentPath(seq)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Error("failed to remove replayed spool segment", "buffer", s.name, "error", err.Error())
+	}
+}
+
+// updateMetrics publishes the spool depth and the age of the oldest row
+// still waiting to be replayed.
+func (s *spool[T]) updateMetrics() {
+	s.mu.Lock()
+	bytes, rows := s.bytes, s.rows
+	s.mu.Unlock()
+
+	metrics.SpoolBytes.WithLabelValues(s.name).Set(float64(bytes))
+	metrics.SpoolRows.WithLabelValues(s.name).Set(float64(rows))
+
+	lag := 0.0
+	if oldest, ok := s.oldestRecord(); ok {
+		lag = time.Since(oldest).Seconds()
+	}
+	metrics.SpoolReplayLag.WithLabelValues(s.name).Set(lag)
+}
+
+// oldestRecord returns the write time of the record at the replay position.
+func (s *spool[T]) oldestRecord() (time.Time, bool) {
+	s.mu.Lock()
+	if len(s.segments) == 0 || s.rows <= 0 {
+		s.mu.Unlock()
+		return time.Time{}, false
+	}
+	seq := s.segments[0]
+	s.mu.Unlock()
+
+	offset := int64(0)
+	if s.pos.seq == seq {
+		offset = s.pos.offset
+	}
+
+	f, err := os.Open(s.segmentPath(seq))
+	if err != nil {
+		return time.Time{}, false
+	}
+	defer func() { _ = f.Close() }()
+
+	buf := make([]byte, spoolHeaderSize)
+	if _, err = f.ReadAt(buf, offset); err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(int64(binary.LittleEndian.Uint64(buf[12:20]))), true //nolint:gosec // written from UnixMilli
+}
+
+// close stops the background loops, writes queued overflow rows and syncs
+// the active segment. Rows still in the spool are replayed by the next
+// process using the same directory.
+func (s *spool[T]) close() {
+	for _, stop := range s.stop {
+		stop()
+	}
+	s.flushPending()
+
+	// Wait for a replay pass in flight; later passes see closed and return.
+	s.replayMu.Lock()
+	defer s.replayMu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sealLocked()
+	s.closed = true
+
+	// The rows stay on disk, but no longer count against the directory in
+	// this process; reopening the spool counts them again.
+	s.usage.Add(-s.bytes)
+}
+
+func (s *spool[T]) segmentPath(seq int64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", seq, spoolSegmentExt))
+}
+
+func (s *spool[T]) readPosition() (spoolPosition, error) {
+	buf, err := os.ReadFile(filepath.Join(s.dir, spoolPosFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return spoolPosition{seq: 0, offset: 0}, nil
+	}
+	if err != nil {
+		return spoolPosition{}, fault.Wrap(err, fault.Internal("failed to read spool position"))
+	}
+	if len(buf) != 16 {
+		logger.Warn("ignoring malformed spool position", "buffer", s.name)
+		return spoolPosition{seq: 0, offset: 0}, nil
+	}
+	return spoolPosition{
+		seq:    int64(binary.LittleEndian.Uint64(buf[0:8])),  //nolint:gosec // written by writePosition
+		offset: int64(binary.LittleEndian.Uint64(buf[8:16])), //nolint:gosec // written by writePosition
+	}, nil
+}
+
+// writePosition atomically replaces the persisted replay cursor.
+func (s *spool[T]) writePosition() error {
+	buf := make([]byte, 16)
+	binary.LittleEndian.PutUint64(buf[0:8], uint64(s.pos.seq))     //nolint:gosec // sequence numbers are positive
+	binary.LittleEndian.PutUint64(buf[8:16], uint64(s.pos.offset)) //nolint:gosec // offsets are positive
+
+	path := filepath.Join(s.dir, spoolPosFile)
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(buf); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if s.cfg.Fsync != FsyncNever {
+		if err = f.Sync(); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// readSpoolRecord reads the record at offset, refusing to read past limit.
+func readSpoolRecord(f io.ReaderAt, offset, limit int64) (spoolHeader, []byte, error) {
+	if offset+spoolHeaderSize > limit {
+		return spoolHeader{}, nil, fmt.Errorf("%w: truncated header", errSpoolCorrupt)
+	}
+
+	buf := make([]byte, spoolHeaderSize)
+	if _, err := f.ReadAt(buf, offset); err != nil {
+		return spoolHeader{}, nil, err
+	}
+	header := spoolHeader{
+		length:    binary.LittleEndian.Uint32(buf[0:4]),
+		checksum:  binary.LittleEndian.Uint32(buf[4:8]),
+		rows:      binary.LittleEndian.Uint32(buf[8:12]),
+		writtenAt: int64(binary.LittleEndian.Uint64(buf[12:20])), //nolint:gosec // written from UnixMilli
+	}
+
+	if offset+spoolHeaderSize+int64(header.length) > limit {
+		return spoolHeader{}, nil, fmt.Errorf("%w: truncated payload", errSpoolCorrupt)
+	}
+	payload := make([]byte, header.length)
+	if _, err := f.ReadAt(payload, offset+spoolHeaderSize); err != nil {
+		return spoolHeader{}, nil, err
+	}
+	if crc32.ChecksumIEEE(payload) != header.checksum {
+		return spoolHeader{}, nil, fmt.Errorf("%w: checksum mismatch", errSpoolCorrupt)
+	}
+
+	return header, payload, nil
+}
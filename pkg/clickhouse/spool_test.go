@@ -0,0 +1,248 @@
+package clickhouse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/clickhouse/schema"
+)
+
+// recordingFlush stands in for ClickHouse. It fails until healthy is set and
+// records every row it accepted in order.
+type recordingFlush struct {
+	healthy atomic.Bool
+	mu      sync.Mutex
+	rows    []string
+}
+
+func (r *recordingFlush) flush(_ context.Context, rows []schema.KeyVerification) error {
+	if !r.healthy.Load() {
+		return errors.New("clickhouse unavailable")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, row := range rows {
+		r.rows = append(r.rows, row.RequestID)
+	}
+	return nil
+}
+
+func (r *recordingFlush) received() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.rows...)
+}
+
+func spoolRows(from, to int) []schema.KeyVerification {
+	rows := make([]schema.KeyVerification, 0, to-from)
+	for i := from; i < to; i++ {
+		rows = append(rows, schema.KeyVerification{RequestID: fmt.Sprintf("req_%03d", i)})
+	}
+	return rows
+}
+
+func requestIDs(from, to int) []string {
+	ids := make([]string, 0, to-from)
+	for i := from; i < to; i++ {
+		ids = append(ids, fmt.Sprintf("req_%03d", i))
+	}
+	return ids
+}
+
+// openTestSpool opens a spool whose background replay effectively never
+// fires after the initial pass, so tests drive replay explicitly.
+func openTestSpool(t *testing.T, dir string, cfg SpoolConfig, flush *recordingFlush) *spool[schema.KeyVerification] {
+	t.Helper()
+	cfg.Dir = dir
+	cfg.ReplayInterval = time.Hour
+	cfg.Fsync = FsyncNever
+
+	s, err := newSpool("key_verifications", cfg, 4, time.Hour, flush.flush)
+	require.NoError(t, err)
+	return s
+}
+
+func TestSpool_ReplaysInOrder(t *testing.T) {
+	t.Parallel()
+
+	flush := &recordingFlush{}
+	s := openTestSpool(t, t.TempDir(), SpoolConfig{MaxSegmentBytes: 512}, flush)
+	defer s.close()
+
+	for i := 0; i < 10; i++ {
+		s.append(spoolRows(i*3, i*3+3))
+	}
+
+	// ClickHouse is down: nothing is lost, nothing is replayed.
+	s.replay()
+	require.Empty(t, flush.received())
+	require.Equal(t, int64(30), s.rows)
+	require.Greater(t, len(s.segments), 1, "small segments should rotate")
+
+	flush.healthy.Store(true)
+	s.replay()
+	require.Equal(t, requestIDs(0, 30), flush.received())
+	require.Equal(t, int64(0), s.rows)
+	require.Equal(t, int64(0), s.bytes)
+
+	// Only the active segment remains on disk.
+	segments, err := filepath.Glob(filepath.Join(s.dir, "*"+spoolSegmentExt))
+	require.NoError(t, err)
+	require.Len(t, segments, 1)
+}
+
+func TestSpool_RecoversAfterRestart(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	flush := &recordingFlush{}
+
+	s := openTestSpool(t, dir, SpoolConfig{MaxSegmentBytes: 256}, flush)
+	for i := 0; i < 5; i++ {
+		s.append(spoolRows(i*4, i*4+4))
+	}
+
+	// Persist the position after the first batch, as replay would after a
+	// successful flush, then stop before the rest is flushed.
+	s.replayMu.Lock()
+	s.pos = spoolPosition{seq: s.segments[0], offset: 0}
+	_, _, next, err := s.readBatch(s.segments[0], true, 0)
+	require.NoError(t, err)
+	s.pos.offset = next
+	require.NoError(t, s.writePosition())
+	s.replayMu.Unlock()
+	s.close()
+
+	restarted := openTestSpool(t, dir, SpoolConfig{MaxSegmentBytes: 256}, flush)
+	defer restarted.close()
+	require.Equal(t, int64(16), restarted.rows, "rows before the persisted position are not replayed again")
+
+	flush.healthy.Store(true)
+	restarted.replay()
+	require.Equal(t, requestIDs(4, 20), flush.received())
+}
+
+func TestSpool_DropsBeyondMaxBytes(t *testing.T) {
+	t.Parallel()
+
+	flush := &recordingFlush{}
+	s := openTestSpool(t, t.TempDir(), SpoolConfig{MaxBytes: 1024}, flush)
+	defer s.close()
+
+	for i := 0; i < 100; i++ {
+		s.append(spoolRows(i, i+1))
+	}
+	require.LessOrEqual(t, s.bytes, int64(1024))
+	require.Less(t, s.rows, int64(100))
+
+	flush.healthy.Store(true)
+	s.replay()
+	received := flush.received()
+	require.Equal(t, requestIDs(0, len(received)), received, "the oldest rows are kept")
+
+	// Replaying frees space for new rows.
+	s.append(spoolRows(100, 101))
+	s.replay()
+	require.Equal(t, "req_100", flush.received()[len(received)])
+}
+
+func TestSpool_TruncatesTornTail(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	flush := &recordingFlush{}
+
+	s := openTestSpool(t, dir, SpoolConfig{}, flush)
+	s.append(spoolRows(0, 3))
+	s.append(spoolRows(3, 6))
+	path := s.segmentPath(s.activeSeq)
+	s.close()
+
+	// Simulate a crash halfway through writing a third record.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o640)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{42, 0, 0, 0, 1, 2, 3})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	restarted := openTestSpool(t, dir, SpoolConfig{}, flush)
+	defer restarted.close()
+	require.Equal(t, int64(6), restarted.rows)
+
+	flush.healthy.Store(true)
+	restarted.replay()
+	require.Equal(t, requestIDs(0, 6), flush.received())
+}
+
+func TestSpool_BatchesOverflowedRows(t *testing.T) {
+	t.Parallel()
+
+	flush := &recordingFlush{}
+	s := openTestSpool(t, t.TempDir(), SpoolConfig{}, flush)
+	defer s.close()
+
+	// The test spool's batch size is 4: the fourth row writes one record.
+	for _, row := range spoolRows(0, 6) {
+		s.overflow(row)
+	}
+	require.Equal(t, int64(4), s.rows)
+	require.Len(t, s.pending, 2)
+
+	s.flushPending()
+	require.Equal(t, int64(6), s.rows)
+	require.Empty(t, s.pending)
+
+	// Two records, one per batch, rather than one per row.
+	want := 2*spoolHeaderSize + len(mustMarshal(t, spoolRows(0, 4))) + len(mustMarshal(t, spoolRows(4, 6)))
+	require.Equal(t, int64(want), s.activeSize)
+
+	flush.healthy.Store(true)
+	s.replay()
+	require.Equal(t, requestIDs(0, 6), flush.received())
+}
+
+func TestSpool_MaxBytesSpansBuffersInDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := SpoolConfig{Dir: dir, MaxBytes: 1024, Fsync: FsyncNever, ReplayInterval: time.Hour}
+
+	flush := &recordingFlush{}
+	a, err := newSpool("a", cfg, 4, time.Hour, flush.flush)
+	require.NoError(t, err)
+	defer a.close()
+	b, err := newSpool("b", cfg, 4, time.Hour, flush.flush)
+	require.NoError(t, err)
+	defer b.close()
+
+	for i := 0; i < 100; i++ {
+		a.append(spoolRows(i, i+1))
+		b.append(spoolRows(i, i+1))
+	}
+	require.LessOrEqual(t, a.bytes+b.bytes, int64(1024))
+	require.Equal(t, a.bytes+b.bytes, spoolUsageFor(dir).Load())
+
+	// Replaying one buffer frees space for the other.
+	flush.healthy.Store(true)
+	a.replay()
+	require.Equal(t, b.bytes, spoolUsageFor(dir).Load())
+	before := b.rows
+	b.append(spoolRows(100, 101))
+	require.Equal(t, before+1, b.rows)
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
@@ -56,6 +56,9 @@ const (
 	// CategoryUserTooManyRequests represents rate limit exceeded errors.
 	CategoryUserTooManyRequests Category = "too_many_requests"
 
+	// CategoryUserConflict represents requests that conflict with another request in flight.
+	CategoryUserConflict Category = "conflict"
+
 	// CategoryNotFound represents resource not found errors.
 	CategoryNotFound Category = "not_found"
 
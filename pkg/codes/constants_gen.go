@@ -34,6 +34,8 @@ const (
 	UserErrorsBadRequestInvalidAnalyticsQueryType URN = "err:user:bad_request:invalid_analytics_query_type"
 	// QueryRangeExceedsRetention indicates the query attempts to access data older than the workspace's retention period.
 	UserErrorsBadRequestQueryRangeExceedsRetention URN = "err:user:bad_request:query_range_exceeds_retention"
+	// InvalidIdempotencyKey indicates the Idempotency-Key header is empty, too long, or contains unsupported characters.
+	UserErrorsBadRequestInvalidIdempotencyKey URN = "err:user:bad_request:invalid_idempotency_key"
 
 	// UnprocessableEntity
 
@@ -43,6 +45,8 @@ const (
 	UserErrorsUnprocessableEntityQueryMemoryLimitExceeded URN = "err:user:unprocessable_entity:query_memory_limit_exceeded"
 	// QueryRowsLimitExceeded indicates the query exceeded the maximum rows to read limit.
 	UserErrorsUnprocessableEntityQueryRowsLimitExceeded URN = "err:user:unprocessable_entity:query_rows_limit_exceeded"
+	// IdempotencyKeyMismatch indicates an Idempotency-Key was reused for a request with a different method, path, or body.
+	UserErrorsUnprocessableEntityIdempotencyKeyMismatch URN = "err:user:unprocessable_entity:idempotency_key_mismatch"
 
 	// TooManyRequests
 
@@ -51,6 +55,13 @@ const (
 	// WorkspaceRateLimited indicates the workspace has exceeded its API rate limit for the current window.
 	UserErrorsTooManyRequestsWorkspaceRateLimited URN = "err:user:too_many_requests:workspace_rate_limited"
 
+	// Conflict
+
+	// IdempotencyKeyInUse indicates another request with the same Idempotency-Key is still being processed.
+	UserErrorsConflictIdempotencyKeyInUse URN = "err:user:conflict:idempotency_key_in_use"
+	// IdempotencyKeyConsumed indicates the response stored for an Idempotency-Key contained a secret and was already replayed once.
+	UserErrorsConflictIdempotencyKeyConsumed URN = "err:user:conflict:idempotency_key_consumed"
+
 	// ----------------
 	// UnkeyAuthErrors
 	// ----------------
@@ -114,6 +125,11 @@ const (
 	// NotFound indicates the requested environment does not exist.
 	UnkeyDataErrorsEnvironmentNotFound URN = "err:unkey:data:environment_not_found"
 
+	// Volume
+
+	// Duplicate indicates the environment already has an active volume with this name.
+	UnkeyDataErrorsVolumeDuplicate URN = "err:unkey:data:volume_already_exists"
+
 	// Domain
 
 	// Duplicate indicates the domain is already attached to this workspace.
@@ -172,6 +188,11 @@ const (
 	// NotFound indicates the requested rate limit override was not found.
 	UnkeyDataErrorsRatelimitOverrideNotFound URN = "err:unkey:data:ratelimit_override_not_found"
 
+	// RatelimitPlan
+
+	// NotFound indicates the requested rate limit plan was not found.
+	UnkeyDataErrorsRatelimitPlanNotFound URN = "err:unkey:data:ratelimit_plan_not_found"
+
 	// Identity
 
 	// NotFound indicates the requested identity was not found.
@@ -189,6 +210,21 @@ const (
 	// NotFound indicates the requested portal was not found.
 	UnkeyDataErrorsPortalNotFound URN = "err:unkey:data:portal_not_found"
 
+	// WebhookEndpoint
+
+	// NotFound indicates the requested webhook endpoint was not found.
+	UnkeyDataErrorsWebhookEndpointNotFound URN = "err:unkey:data:webhook_endpoint_not_found"
+
+	// WebhookDelivery
+
+	// NotFound indicates the requested webhook delivery was not found.
+	UnkeyDataErrorsWebhookDeliveryNotFound URN = "err:unkey:data:webhook_delivery_not_found"
+
+	// KeyBulkOperation
+
+	// NotFound indicates the requested bulk key operation was not found.
+	UnkeyDataErrorsKeyBulkOperationNotFound URN = "err:unkey:data:key_bulk_operation_not_found"
+
 	// Analytics
 
 	// NotConfigured indicates analytics is not configured for the workspace.
@@ -308,6 +344,11 @@ const (
 	UnkeyFrontlineErrorsAuthMissingCredentials URN = "err:frontline:client:missing_credentials"
 	// InvalidKey represents a 401 error - key not found, disabled, or expired.
 	UnkeyFrontlineErrorsAuthInvalidKey URN = "err:frontline:client:invalid_key"
+	// InvalidToken represents a 401 error - bearer token has an invalid signature, is expired, or fails issuer/audience checks.
+	UnkeyFrontlineErrorsAuthInvalidToken URN = "err:frontline:client:invalid_token"
+	// JWKSUnavailable represents a 503 error - the JWKS or OIDC discovery endpoint configured on a JWTAuth policy could not be fetched.
+	// Attributed to the upstream domain because the signing keys are served by the customer's identity provider.
+	UnkeyFrontlineErrorsAuthJWKSUnavailable URN = "err:frontline:upstream:jwks_unavailable"
 	// InsufficientPermissions represents a 403 error - the credential lacks the permissions required by a permission_query.
 	UnkeyFrontlineErrorsAuthInsufficientPermissions URN = "err:frontline:client:insufficient_permissions"
 	// RateLimited represents a 429 error - a configured request rate limit was exceeded.
@@ -62,6 +62,13 @@ type frontlineAuth struct {
 	// InvalidKey represents a 401 error - key not found, disabled, or expired.
 	InvalidKey Code
 
+	// InvalidToken represents a 401 error - bearer token has an invalid signature, is expired, or fails issuer/audience checks.
+	InvalidToken Code
+
+	// JWKSUnavailable represents a 503 error - the JWKS or OIDC discovery endpoint configured on a JWTAuth policy could not be fetched.
+	// Attributed to the upstream domain because the signing keys are served by the customer's identity provider.
+	JWKSUnavailable Code
+
 	// InsufficientPermissions represents a 403 error - the credential lacks the permissions required by a permission_query.
 	InsufficientPermissions Code
 
@@ -135,6 +142,8 @@ var Frontline = UnkeyFrontlineErrors{
 	Auth: frontlineAuth{
 		MissingCredentials:      Code{SystemFrontline, CategoryClient, "missing_credentials"},
 		InvalidKey:              Code{SystemFrontline, CategoryClient, "invalid_key"},
+		InvalidToken:            Code{SystemFrontline, CategoryClient, "invalid_token"},
+		JWKSUnavailable:         Code{SystemFrontline, CategoryUpstream, "jwks_unavailable"},
 		InsufficientPermissions: Code{SystemFrontline, CategoryClient, "insufficient_permissions"},
 		RateLimited:             Code{SystemFrontline, CategoryClient, "rate_limited"},
 		UsageExceeded:           Code{SystemFrontline, CategoryClient, "usage_exceeded"},
@@ -21,6 +21,7 @@ func TestFrontlineURNsEncodeAttributionDomain(t *testing.T) {
 		{codes.Frontline.Proxy.ServiceUnavailable, "err:frontline:upstream:service_unavailable"},
 		{codes.Frontline.Proxy.GatewayTimeout, "err:frontline:upstream:gateway_timeout"},
 		{codes.Frontline.Proxy.ProxyForwardFailed, "err:frontline:upstream:proxy_forward_failed"},
+		{codes.Frontline.Auth.JWKSUnavailable, "err:frontline:upstream:jwks_unavailable"},
 		// routing
 		{codes.Frontline.Routing.ConfigNotFound, "err:frontline:routing:config_not_found"},
 		{codes.Frontline.Routing.DeploymentNotFound, "err:frontline:routing:deployment_not_found"},
@@ -34,6 +35,7 @@ func TestFrontlineURNsEncodeAttributionDomain(t *testing.T) {
 		// client
 		{codes.Frontline.Auth.MissingCredentials, "err:frontline:client:missing_credentials"},
 		{codes.Frontline.Auth.InvalidKey, "err:frontline:client:invalid_key"},
+		{codes.Frontline.Auth.InvalidToken, "err:frontline:client:invalid_token"},
 		{codes.Frontline.Auth.InsufficientPermissions, "err:frontline:client:insufficient_permissions"},
 		{codes.Frontline.Auth.RateLimited, "err:frontline:client:rate_limited"},
 		{codes.Frontline.Auth.UsageExceeded, "err:frontline:client:usage_exceeded"},
@@ -55,6 +55,12 @@ type dataEnvironment struct {
 	NotFound Code
 }
 
+// dataVolume defines errors related to persistent volume operations.
+type dataVolume struct {
+	// Duplicate indicates the environment already has an active volume with this name.
+	Duplicate Code
+}
+
 // dataDomain defines errors related to custom domain operations.
 type dataDomain struct {
 	// Duplicate indicates the domain is already attached to this workspace.
@@ -113,6 +119,12 @@ type dataRatelimitOverride struct {
 	NotFound Code
 }
 
+// dataRatelimitPlan defines errors related to rate limit plan operations.
+type dataRatelimitPlan struct {
+	// NotFound indicates the requested rate limit plan was not found.
+	NotFound Code
+}
+
 // dataIdentity defines errors related to identity operations.
 type dataIdentity struct {
 	// NotFound indicates the requested identity was not found.
@@ -134,6 +146,24 @@ type dataPortal struct {
 	NotFound Code
 }
 
+// dataWebhookEndpoint defines errors related to webhook endpoint operations.
+type dataWebhookEndpoint struct {
+	// NotFound indicates the requested webhook endpoint was not found.
+	NotFound Code
+}
+
+// dataWebhookDelivery defines errors related to webhook delivery operations.
+type dataWebhookDelivery struct {
+	// NotFound indicates the requested webhook delivery was not found.
+	NotFound Code
+}
+
+// dataKeyBulkOperation defines errors related to bulk key operations.
+type dataKeyBulkOperation struct {
+	// NotFound indicates the requested bulk key operation was not found.
+	NotFound Code
+}
+
 // dataAnalytics defines errors related to analytics operations.
 type dataAnalytics struct {
 	// NotConfigured indicates analytics is not configured for the workspace.
@@ -153,6 +183,7 @@ type UnkeyDataErrors struct {
 	Project            dataProject
 	App                dataApp
 	Environment        dataEnvironment
+	Volume             dataVolume
 	Domain             dataDomain
 	Deployment         dataDeployment
 	Policy             dataPolicy
@@ -163,9 +194,13 @@ type UnkeyDataErrors struct {
 	KeyAuth            dataKeyAuth
 	RatelimitNamespace dataRatelimitNamespace
 	RatelimitOverride  dataRatelimitOverride
+	RatelimitPlan      dataRatelimitPlan
 	Identity           dataIdentity
 	AuditLog           dataAuditLog
 	Portal             dataPortal
+	WebhookEndpoint    dataWebhookEndpoint
+	WebhookDelivery    dataWebhookDelivery
+	KeyBulkOperation   dataKeyBulkOperation
 	Analytics          dataAnalytics
 }
 
@@ -207,6 +242,10 @@ var Data = UnkeyDataErrors{
 		NotFound: Code{SystemUnkey, CategoryUnkeyData, "environment_not_found"},
 	},
 
+	Volume: dataVolume{
+		Duplicate: Code{SystemUnkey, CategoryUnkeyData, "volume_already_exists"},
+	},
+
 	Domain: dataDomain{
 		Duplicate: Code{SystemUnkey, CategoryUnkeyData, "domain_already_exists"},
 		NotFound:  Code{SystemUnkey, CategoryUnkeyData, "domain_not_found"},
@@ -243,6 +282,10 @@ var Data = UnkeyDataErrors{
 		NotFound: Code{SystemUnkey, CategoryUnkeyData, "ratelimit_override_not_found"},
 	},
 
+	RatelimitPlan: dataRatelimitPlan{
+		NotFound: Code{SystemUnkey, CategoryUnkeyData, "ratelimit_plan_not_found"},
+	},
+
 	Identity: dataIdentity{
 		NotFound:  Code{SystemUnkey, CategoryUnkeyData, "identity_not_found"},
 		Duplicate: Code{SystemUnkey, CategoryUnkeyData, "identity_already_exists"},
@@ -256,6 +299,18 @@ var Data = UnkeyDataErrors{
 		NotFound: Code{SystemUnkey, CategoryUnkeyData, "portal_not_found"},
 	},
 
+	WebhookEndpoint: dataWebhookEndpoint{
+		NotFound: Code{SystemUnkey, CategoryUnkeyData, "webhook_endpoint_not_found"},
+	},
+
+	WebhookDelivery: dataWebhookDelivery{
+		NotFound: Code{SystemUnkey, CategoryUnkeyData, "webhook_delivery_not_found"},
+	},
+
+	KeyBulkOperation: dataKeyBulkOperation{
+		NotFound: Code{SystemUnkey, CategoryUnkeyData, "key_bulk_operation_not_found"},
+	},
+
 	Analytics: dataAnalytics{
 		NotConfigured:    Code{SystemUnkey, CategoryUnkeyData, "analytics_not_configured"},
 		ConnectionFailed: Code{SystemUnkey, CategoryUnkeyData, "analytics_connection_failed"},
@@ -24,6 +24,8 @@ type userBadRequest struct {
 	InvalidAnalyticsQueryType Code
 	// QueryRangeExceedsRetention indicates the query attempts to access data older than the workspace's retention period.
 	QueryRangeExceedsRetention Code
+	// InvalidIdempotencyKey indicates the Idempotency-Key header is empty, too long, or contains unsupported characters.
+	InvalidIdempotencyKey Code
 }
 
 // userUnprocessableEntity defines errors for requests that are syntactically correct but cannot be processed.
@@ -34,6 +36,8 @@ type userUnprocessableEntity struct {
 	QueryMemoryLimitExceeded Code
 	// QueryRowsLimitExceeded indicates the query exceeded the maximum rows to read limit.
 	QueryRowsLimitExceeded Code
+	// IdempotencyKeyMismatch indicates an Idempotency-Key was reused for a request with a different method, path, or body.
+	IdempotencyKeyMismatch Code
 }
 
 // userTooManyRequests defines errors related to rate limiting and quota exceeded.
@@ -44,6 +48,14 @@ type userTooManyRequests struct {
 	WorkspaceRateLimited Code
 }
 
+// userConflict defines errors for requests that conflict with another request.
+type userConflict struct {
+	// IdempotencyKeyInUse indicates another request with the same Idempotency-Key is still being processed.
+	IdempotencyKeyInUse Code
+	// IdempotencyKeyConsumed indicates the response stored for an Idempotency-Key contained a secret and was already replayed once.
+	IdempotencyKeyConsumed Code
+}
+
 // UserErrors defines all user-related errors in the Unkey system.
 // These errors are caused by invalid user inputs or client behavior.
 type UserErrors struct {
@@ -53,6 +65,8 @@ type UserErrors struct {
 	UnprocessableEntity userUnprocessableEntity
 	// TooManyRequests contains errors related to rate limiting.
 	TooManyRequests userTooManyRequests
+	// Conflict contains errors for requests that conflict with another request.
+	Conflict userConflict
 }
 
 // User contains all predefined user error codes.
@@ -71,14 +85,20 @@ var User = UserErrors{
 		InvalidAnalyticsFunction:    Code{SystemUser, CategoryUserBadRequest, "invalid_analytics_function"},
 		InvalidAnalyticsQueryType:   Code{SystemUser, CategoryUserBadRequest, "invalid_analytics_query_type"},
 		QueryRangeExceedsRetention:  Code{SystemUser, CategoryUserBadRequest, "query_range_exceeds_retention"},
+		InvalidIdempotencyKey:       Code{SystemUser, CategoryUserBadRequest, "invalid_idempotency_key"},
 	},
 	UnprocessableEntity: userUnprocessableEntity{
 		QueryExecutionTimeout:    Code{SystemUser, CategoryUserUnprocessableEntity, "query_execution_timeout"},
 		QueryMemoryLimitExceeded: Code{SystemUser, CategoryUserUnprocessableEntity, "query_memory_limit_exceeded"},
 		QueryRowsLimitExceeded:   Code{SystemUser, CategoryUserUnprocessableEntity, "query_rows_limit_exceeded"},
+		IdempotencyKeyMismatch:   Code{SystemUser, CategoryUserUnprocessableEntity, "idempotency_key_mismatch"},
 	},
 	TooManyRequests: userTooManyRequests{
 		QueryQuotaExceeded:   Code{SystemUser, CategoryUserTooManyRequests, "query_quota_exceeded"},
 		WorkspaceRateLimited: Code{SystemUser, CategoryUserTooManyRequests, "workspace_rate_limited"},
 	},
+	Conflict: userConflict{
+		IdempotencyKeyInUse:    Code{SystemUser, CategoryUserConflict, "idempotency_key_in_use"},
+		IdempotencyKeyConsumed: Code{SystemUser, CategoryUserConflict, "idempotency_key_consumed"},
+	},
 }
@@ -113,6 +113,21 @@ type Counter interface {
 	// eliminating the need to infer success from the returned value.
 	DecrementIfExists(ctx context.Context, key string, value int64) (int64, bool, bool, error)
 
+	// IncrementIfExists increases a counter only if it already exists. Unlike
+	// Increment it never creates the counter, so returning credits to a
+	// counter that has expired cannot resurrect it with a partial value.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation and tracing
+	//   - key: Unique identifier for the counter
+	//   - value: Amount to increment the counter by (must be positive, > 0)
+	//
+	// Returns:
+	//   - int64: The new counter value, or 0 when the key did not exist
+	//   - bool: Whether the key existed (and was therefore incremented)
+	//   - error: Any errors that occurred during the operation
+	IncrementIfExists(ctx context.Context, key string, value int64) (int64, bool, error)
+
 	// SetIfNotExists sets a counter to a specific value only if it doesn't already exist.
 	// This is useful for atomic initialization without race conditions.
 	//
@@ -114,6 +114,21 @@ func (m *memoryCounter) DecrementIfExists(_ context.Context, key string, value i
 	return e.value, true, true, nil
 }
 
+func (m *memoryCounter) IncrementIfExists(_ context.Context, key string, value int64) (int64, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok || e.expired(time.Now()) {
+		delete(m.entries, key)
+		return 0, false, nil
+	}
+
+	e.value += value
+	m.entries[key] = e
+	return e.value, true, nil
+}
+
 func (m *memoryCounter) SetIfNotExists(_ context.Context, key string, value int64, ttl ...time.Duration) (bool, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
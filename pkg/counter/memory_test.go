@@ -87,6 +87,27 @@ func TestMemoryCounter_DecrementIfExists(t *testing.T) {
 	require.False(t, success)
 }
 
+func TestMemoryCounter_IncrementIfExists(t *testing.T) {
+	c := NewMemory()
+	ctx := context.Background()
+
+	val, existed, err := c.IncrementIfExists(ctx, "key1", 5)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), val)
+	require.False(t, existed)
+
+	got, err := c.Get(ctx, "key1")
+	require.NoError(t, err)
+	require.Equal(t, int64(0), got, "missing key must not be created")
+
+	_, _ = c.Increment(ctx, "key1", 10)
+
+	val, existed, err = c.IncrementIfExists(ctx, "key1", 5)
+	require.NoError(t, err)
+	require.Equal(t, int64(15), val)
+	require.True(t, existed)
+}
+
 func TestMemoryCounter_SetIfNotExists(t *testing.T) {
 	c := NewMemory()
 	ctx := context.Background()
@@ -44,11 +44,27 @@ const (
 		local newValue = redis.call('DECRBY', key, decrement)
 		return {newValue, 1, 1}  -- {new_decremented_value, existed=true, success=true}
 	`
+
+	// incrementIfExistsScript increments a key only if it exists, preserving
+	// its TTL. Returns {new_value, 1} or {0, 0} when the key is missing.
+	incrementIfExistsScript = `
+		local key = KEYS[1]
+		local increment = tonumber(ARGV[1])
+
+		if redis.call('EXISTS', key) == 0 then
+			return {0, 0}
+		end
+
+		return {redis.call('INCRBY', key, increment), 1}
+	`
 )
 
 var (
 	// decrementIfExistsScriptCached is the cached script for atomic decrement operations
 	decrementIfExistsScriptCached = redis.NewScript(decrementIfExistsScript)
+
+	// incrementIfExistsScriptCached is the cached script for conditional increments
+	incrementIfExistsScriptCached = redis.NewScript(incrementIfExistsScript)
 )
 
 // redisCounter implements the Counter interface using Redis.
@@ -260,6 +276,35 @@ func (r *redisCounter) DecrementIfExists(ctx context.Context, key string, value
 	return actualValue, existedFlag == 1, successFlag == 1, nil
 }
 
+// IncrementIfExists atomically increments a counter that already exists,
+// using a cached Lua script so a missing key is never created.
+func (r *redisCounter) IncrementIfExists(ctx context.Context, key string, value int64) (int64, bool, error) {
+	ctx, span := tracing.Start(ctx, "RedisCounter.IncrementIfExists")
+	defer span.End()
+
+	result, err := incrementIfExistsScriptCached.Run(ctx, r.redis, []string{key}, value).Result()
+	if err != nil {
+		return 0, false, err
+	}
+
+	resultSlice, ok := result.([]interface{})
+	if !ok || len(resultSlice) != 2 {
+		return 0, false, fmt.Errorf("unexpected result format from Lua script")
+	}
+
+	newValue, err := parseNumericValue(resultSlice[0])
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid value in result: %w", err)
+	}
+
+	existedFlag, err := parseNumericValue(resultSlice[1])
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid existed flag in result: %w", err)
+	}
+
+	return newValue, existedFlag == 1, nil
+}
+
 // parseNumericValue safely converts various numeric types to int64
 func parseNumericValue(v interface{}) (int64, error) {
 	switch val := v.(type) {
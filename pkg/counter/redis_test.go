@@ -536,6 +536,44 @@ func TestRedisCounterDecrement(t *testing.T) {
 	})
 }
 
+func TestRedisCounterIncrementIfExists(t *testing.T) {
+	ctx := context.Background()
+	redisURL := containers.Redis(t)
+
+	ctr, err := newTestRedis(t, redisURL)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ctr.Close()) }()
+
+	t.Run("IncrementNonExistentKey", func(t *testing.T) {
+		key := uid.New(uid.TestPrefix)
+
+		val, existed, err := ctr.IncrementIfExists(ctx, key, 5)
+		require.NoError(t, err)
+		require.False(t, existed)
+		require.Equal(t, int64(0), val)
+
+		exists, err := ctr.(*redisCounter).redis.Exists(ctx, key).Result()
+		require.NoError(t, err)
+		require.Equal(t, int64(0), exists, "missing key must not be created")
+	})
+
+	t.Run("IncrementExistingKeyKeepsTTL", func(t *testing.T) {
+		key := uid.New(uid.TestPrefix)
+
+		_, err := ctr.Increment(ctx, key, 10, time.Minute)
+		require.NoError(t, err)
+
+		val, existed, err := ctr.IncrementIfExists(ctx, key, 5)
+		require.NoError(t, err)
+		require.True(t, existed)
+		require.Equal(t, int64(15), val)
+
+		ttl, err := ctr.(*redisCounter).redis.TTL(ctx, key).Result()
+		require.NoError(t, err)
+		require.Greater(t, ttl, time.Duration(0))
+	})
+}
+
 func TestRedisCounterDecrementIfExists(t *testing.T) {
 	ctx := context.Background()
 	redisURL := containers.Redis(t)
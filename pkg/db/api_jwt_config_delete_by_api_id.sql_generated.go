@@ -0,0 +1,22 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: api_jwt_config_delete_by_api_id.sql
+
+package db
+
+import (
+	"context"
+)
+
+const deleteApiJwtConfigByApiID = `-- name: DeleteApiJwtConfigByApiID :exec
+DELETE FROM api_jwt_configs WHERE api_id = ?
+`
+
+// DeleteApiJwtConfigByApiID
+//
+//	DELETE FROM api_jwt_configs WHERE api_id = ?
+func (q *Queries) DeleteApiJwtConfigByApiID(ctx context.Context, db DBTX, apiID string) error {
+	_, err := db.ExecContext(ctx, deleteApiJwtConfigByApiID, apiID)
+	return err
+}
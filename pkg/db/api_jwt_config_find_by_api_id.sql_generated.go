@@ -0,0 +1,36 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: api_jwt_config_find_by_api_id.sql
+
+package db
+
+import (
+	"context"
+)
+
+const findApiJwtConfigByApiID = `-- name: FindApiJwtConfigByApiID :one
+SELECT pk, api_id, workspace_id, issuer, audience, jwks_url, public_key, identity_claim, permissions_claim, created_at_m, updated_at_m FROM api_jwt_configs WHERE api_id = ?
+`
+
+// FindApiJwtConfigByApiID
+//
+//	SELECT pk, api_id, workspace_id, issuer, audience, jwks_url, public_key, identity_claim, permissions_claim, created_at_m, updated_at_m FROM api_jwt_configs WHERE api_id = ?
+func (q *Queries) FindApiJwtConfigByApiID(ctx context.Context, db DBTX, apiID string) (ApiJwtConfig, error) {
+	row := db.QueryRowContext(ctx, findApiJwtConfigByApiID, apiID)
+	var i ApiJwtConfig
+	err := row.Scan(
+		&i.Pk,
+		&i.ApiID,
+		&i.WorkspaceID,
+		&i.Issuer,
+		&i.Audience,
+		&i.JwksUrl,
+		&i.PublicKey,
+		&i.IdentityClaim,
+		&i.PermissionsClaim,
+		&i.CreatedAtM,
+		&i.UpdatedAtM,
+	)
+	return i, err
+}
@@ -0,0 +1,99 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: api_jwt_config_upsert.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const upsertApiJwtConfig = `-- name: UpsertApiJwtConfig :exec
+INSERT INTO api_jwt_configs (
+    api_id,
+    workspace_id,
+    issuer,
+    audience,
+    jwks_url,
+    public_key,
+    identity_claim,
+    permissions_claim,
+    created_at_m
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+) ON DUPLICATE KEY UPDATE
+    issuer = VALUES(issuer),
+    audience = VALUES(audience),
+    jwks_url = VALUES(jwks_url),
+    public_key = VALUES(public_key),
+    identity_claim = VALUES(identity_claim),
+    permissions_claim = VALUES(permissions_claim),
+    updated_at_m = VALUES(created_at_m)
+`
+
+type UpsertApiJwtConfigParams struct {
+	ApiID            string         `db:"api_id"`
+	WorkspaceID      string         `db:"workspace_id"`
+	Issuer           string         `db:"issuer"`
+	Audience         sql.NullString `db:"audience"`
+	JwksUrl          sql.NullString `db:"jwks_url"`
+	PublicKey        sql.NullString `db:"public_key"`
+	IdentityClaim    string         `db:"identity_claim"`
+	PermissionsClaim sql.NullString `db:"permissions_claim"`
+	CreatedAtM       int64          `db:"created_at_m"`
+}
+
+// UpsertApiJwtConfig
+//
+//	INSERT INTO api_jwt_configs (
+//	    api_id,
+//	    workspace_id,
+//	    issuer,
+//	    audience,
+//	    jwks_url,
+//	    public_key,
+//	    identity_claim,
+//	    permissions_claim,
+//	    created_at_m
+//	) VALUES (
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?
+//	) ON DUPLICATE KEY UPDATE
+//	    issuer = VALUES(issuer),
+//	    audience = VALUES(audience),
+//	    jwks_url = VALUES(jwks_url),
+//	    public_key = VALUES(public_key),
+//	    identity_claim = VALUES(identity_claim),
+//	    permissions_claim = VALUES(permissions_claim),
+//	    updated_at_m = VALUES(created_at_m)
+func (q *Queries) UpsertApiJwtConfig(ctx context.Context, db DBTX, arg UpsertApiJwtConfigParams) error {
+	_, err := db.ExecContext(ctx, upsertApiJwtConfig,
+		arg.ApiID,
+		arg.WorkspaceID,
+		arg.Issuer,
+		arg.Audience,
+		arg.JwksUrl,
+		arg.PublicKey,
+		arg.IdentityClaim,
+		arg.PermissionsClaim,
+		arg.CreatedAtM,
+	)
+	return err
+}
@@ -0,0 +1,33 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: api_update_auth_type.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const updateApiAuthType = `-- name: UpdateApiAuthType :exec
+UPDATE apis
+SET auth_type = ?, updated_at_m = ?
+WHERE id = ?
+`
+
+type UpdateApiAuthTypeParams struct {
+	AuthType   NullApisAuthType `db:"auth_type"`
+	UpdatedAtM sql.NullInt64    `db:"updated_at_m"`
+	ApiID      string           `db:"api_id"`
+}
+
+// UpdateApiAuthType
+//
+//	UPDATE apis
+//	SET auth_type = ?, updated_at_m = ?
+//	WHERE id = ?
+func (q *Queries) UpdateApiAuthType(ctx context.Context, db DBTX, arg UpdateApiAuthTypeParams) error {
+	_, err := db.ExecContext(ctx, updateApiAuthType, arg.AuthType, arg.UpdatedAtM, arg.ApiID)
+	return err
+}
@@ -19,7 +19,8 @@ SELECT
 	hap.replicas_min AS autoscaling_replicas_min,
 	hap.replicas_max AS autoscaling_replicas_max,
 	hap.cpu_threshold AS autoscaling_threshold_cpu,
-	hap.memory_threshold AS autoscaling_threshold_memory
+	hap.memory_threshold AS autoscaling_threshold_memory,
+	hap.rps_threshold AS autoscaling_threshold_rps
 FROM app_regional_settings ars
 JOIN regions r ON ars.region_id = r.id
 LEFT JOIN horizontal_autoscaling_policies hap ON hap.id = ars.horizontal_autoscaling_policy_id
@@ -41,6 +42,7 @@ type FindAppRegionalSettingsByAppAndEnvRow struct {
 	AutoscalingReplicasMax     sql.NullInt32 `db:"autoscaling_replicas_max"`
 	AutoscalingThresholdCpu    sql.NullInt16 `db:"autoscaling_threshold_cpu"`
 	AutoscalingThresholdMemory sql.NullInt16 `db:"autoscaling_threshold_memory"`
+	AutoscalingThresholdRps    sql.NullInt16 `db:"autoscaling_threshold_rps"`
 }
 
 // FindAppRegionalSettingsByAppAndEnv returns per-region deployment settings
@@ -55,7 +57,8 @@ type FindAppRegionalSettingsByAppAndEnvRow struct {
 //		hap.replicas_min AS autoscaling_replicas_min,
 //		hap.replicas_max AS autoscaling_replicas_max,
 //		hap.cpu_threshold AS autoscaling_threshold_cpu,
-//		hap.memory_threshold AS autoscaling_threshold_memory
+//		hap.memory_threshold AS autoscaling_threshold_memory,
+//		hap.rps_threshold AS autoscaling_threshold_rps
 //	FROM app_regional_settings ars
 //	JOIN regions r ON ars.region_id = r.id
 //	LEFT JOIN horizontal_autoscaling_policies hap ON hap.id = ars.horizontal_autoscaling_policy_id
@@ -79,6 +82,7 @@ func (q *Queries) FindAppRegionalSettingsByAppAndEnv(ctx context.Context, db DBT
 			&i.AutoscalingReplicasMax,
 			&i.AutoscalingThresholdCpu,
 			&i.AutoscalingThresholdMemory,
+			&i.AutoscalingThresholdRps,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,53 @@
+// Code generated by sqlc bulk insert plugin. DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// bulkUpsertApiJwtConfig is the base query for bulk insert
+const bulkUpsertApiJwtConfig = `INSERT INTO api_jwt_configs ( api_id, workspace_id, issuer, audience, jwks_url, public_key, identity_claim, permissions_claim, created_at_m ) VALUES %s ON DUPLICATE KEY UPDATE
+    issuer = VALUES(issuer),
+    audience = VALUES(audience),
+    jwks_url = VALUES(jwks_url),
+    public_key = VALUES(public_key),
+    identity_claim = VALUES(identity_claim),
+    permissions_claim = VALUES(permissions_claim),
+    updated_at_m = VALUES(created_at_m)`
+
+// UpsertApiJwtConfig performs bulk insert in a single query
+func (q *BulkQueries) UpsertApiJwtConfig(ctx context.Context, db DBTX, args []UpsertApiJwtConfigParams) error {
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	// Build the bulk insert query
+	valueClauses := make([]string, len(args))
+	for i := range args {
+		valueClauses[i] = "( ?, ?, ?, ?, ?, ?, ?, ?, ? )"
+	}
+
+	bulkQuery := fmt.Sprintf(bulkUpsertApiJwtConfig, strings.Join(valueClauses, ", "))
+
+	// Collect all arguments
+	var allArgs []any
+	for _, arg := range args {
+		allArgs = append(allArgs, arg.ApiID)
+		allArgs = append(allArgs, arg.WorkspaceID)
+		allArgs = append(allArgs, arg.Issuer)
+		allArgs = append(allArgs, arg.Audience)
+		allArgs = append(allArgs, arg.JwksUrl)
+		allArgs = append(allArgs, arg.PublicKey)
+		allArgs = append(allArgs, arg.IdentityClaim)
+		allArgs = append(allArgs, arg.PermissionsClaim)
+		allArgs = append(allArgs, arg.CreatedAtM)
+	}
+
+	// Execute the bulk insert
+	_, err := db.ExecContext(ctx, bulkQuery, allArgs...)
+	return err
+}
@@ -0,0 +1,43 @@
+// Code generated by sqlc bulk insert plugin. DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// bulkInsertIdempotencyKey is the base query for bulk insert
+const bulkInsertIdempotencyKey = `INSERT INTO idempotency_keys ( workspace_id, subject_id, idempotency_key, fingerprint, created_at_m, expires_at_m ) VALUES %s`
+
+// InsertIdempotencyKeys performs bulk insert in a single query
+func (q *BulkQueries) InsertIdempotencyKeys(ctx context.Context, db DBTX, args []InsertIdempotencyKeyParams) error {
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	// Build the bulk insert query
+	valueClauses := make([]string, len(args))
+	for i := range args {
+		valueClauses[i] = "( ?, ?, ?, ?, ?, ? )"
+	}
+
+	bulkQuery := fmt.Sprintf(bulkInsertIdempotencyKey, strings.Join(valueClauses, ", "))
+
+	// Collect all arguments
+	var allArgs []any
+	for _, arg := range args {
+		allArgs = append(allArgs, arg.WorkspaceID)
+		allArgs = append(allArgs, arg.SubjectID)
+		allArgs = append(allArgs, arg.IdempotencyKey)
+		allArgs = append(allArgs, arg.Fingerprint)
+		allArgs = append(allArgs, arg.CreatedAtM)
+		allArgs = append(allArgs, arg.ExpiresAtM)
+	}
+
+	// Execute the bulk insert
+	_, err := db.ExecContext(ctx, bulkQuery, allArgs...)
+	return err
+}
@@ -0,0 +1,47 @@
+// Code generated by sqlc bulk insert plugin. DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// bulkUpsertIdentityCredits is the base query for bulk insert
+const bulkUpsertIdentityCredits = `INSERT INTO ` + "`" + `identity_credits` + "`" + ` ( identity_id, workspace_id, remaining, refill_day, refill_amount, created_at_m ) VALUES %s ON DUPLICATE KEY UPDATE
+    remaining = VALUES(remaining),
+    refill_day = VALUES(refill_day),
+    refill_amount = VALUES(refill_amount),
+    updated_at_m = VALUES(created_at_m)`
+
+// UpsertIdentityCredits performs bulk insert in a single query
+func (q *BulkQueries) UpsertIdentityCredits(ctx context.Context, db DBTX, args []UpsertIdentityCreditsParams) error {
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	// Build the bulk insert query
+	valueClauses := make([]string, len(args))
+	for i := range args {
+		valueClauses[i] = "( ?, ?, ?, ?, ?, ? )"
+	}
+
+	bulkQuery := fmt.Sprintf(bulkUpsertIdentityCredits, strings.Join(valueClauses, ", "))
+
+	// Collect all arguments
+	var allArgs []any
+	for _, arg := range args {
+		allArgs = append(allArgs, arg.IdentityID)
+		allArgs = append(allArgs, arg.WorkspaceID)
+		allArgs = append(allArgs, arg.Remaining)
+		allArgs = append(allArgs, arg.RefillDay)
+		allArgs = append(allArgs, arg.RefillAmount)
+		allArgs = append(allArgs, arg.Now)
+	}
+
+	// Execute the bulk insert
+	_, err := db.ExecContext(ctx, bulkQuery, allArgs...)
+	return err
+}
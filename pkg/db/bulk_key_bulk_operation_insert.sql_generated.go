@@ -0,0 +1,43 @@
+// Code generated by sqlc bulk insert plugin. DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// bulkInsertKeyBulkOperation is the base query for bulk insert
+const bulkInsertKeyBulkOperation = `INSERT INTO key_bulk_operations ( id, workspace_id, api_id, operation, status, total, created_at_m ) VALUES %s`
+
+// InsertKeyBulkOperations performs bulk insert in a single query
+func (q *BulkQueries) InsertKeyBulkOperations(ctx context.Context, db DBTX, args []InsertKeyBulkOperationParams) error {
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	// Build the bulk insert query
+	valueClauses := make([]string, len(args))
+	for i := range args {
+		valueClauses[i] = "( ?, ?, ?, ?, 'pending', ?, ? )"
+	}
+
+	bulkQuery := fmt.Sprintf(bulkInsertKeyBulkOperation, strings.Join(valueClauses, ", "))
+
+	// Collect all arguments
+	var allArgs []any
+	for _, arg := range args {
+		allArgs = append(allArgs, arg.ID)
+		allArgs = append(allArgs, arg.WorkspaceID)
+		allArgs = append(allArgs, arg.ApiID)
+		allArgs = append(allArgs, arg.Operation)
+		allArgs = append(allArgs, arg.Total)
+		allArgs = append(allArgs, arg.CreatedAtM)
+	}
+
+	// Execute the bulk insert
+	_, err := db.ExecContext(ctx, bulkQuery, allArgs...)
+	return err
+}
@@ -0,0 +1,39 @@
+// Code generated by sqlc bulk insert plugin. DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// bulkInsertKeyBulkOperationItem is the base query for bulk insert
+const bulkInsertKeyBulkOperationItem = `INSERT INTO key_bulk_operation_items ( operation_id, key_id, status ) VALUES %s`
+
+// InsertKeyBulkOperationItems performs bulk insert in a single query
+func (q *BulkQueries) InsertKeyBulkOperationItems(ctx context.Context, db DBTX, args []InsertKeyBulkOperationItemParams) error {
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	// Build the bulk insert query
+	valueClauses := make([]string, len(args))
+	for i := range args {
+		valueClauses[i] = "( ?, ?, 'pending' )"
+	}
+
+	bulkQuery := fmt.Sprintf(bulkInsertKeyBulkOperationItem, strings.Join(valueClauses, ", "))
+
+	// Collect all arguments
+	var allArgs []any
+	for _, arg := range args {
+		allArgs = append(allArgs, arg.OperationID)
+		allArgs = append(allArgs, arg.KeyID)
+	}
+
+	// Execute the bulk insert
+	_, err := db.ExecContext(ctx, bulkQuery, allArgs...)
+	return err
+}
@@ -0,0 +1,46 @@
+// Code generated by sqlc bulk insert plugin. DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// bulkUpsertKeyRotationPolicy is the base query for bulk insert
+const bulkUpsertKeyRotationPolicy = `INSERT INTO key_rotation_policies ( scope_id, workspace_id, key_auth_id, interval_ms, grace_period_ms, created_at_m ) VALUES %s ON DUPLICATE KEY UPDATE
+    interval_ms = VALUES(interval_ms),
+    grace_period_ms = VALUES(grace_period_ms),
+    updated_at_m = VALUES(created_at_m)`
+
+// UpsertKeyRotationPolicy performs bulk insert in a single query
+func (q *BulkQueries) UpsertKeyRotationPolicy(ctx context.Context, db DBTX, args []UpsertKeyRotationPolicyParams) error {
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	// Build the bulk insert query
+	valueClauses := make([]string, len(args))
+	for i := range args {
+		valueClauses[i] = "( ?, ?, ?, ?, ?, ? )"
+	}
+
+	bulkQuery := fmt.Sprintf(bulkUpsertKeyRotationPolicy, strings.Join(valueClauses, ", "))
+
+	// Collect all arguments
+	var allArgs []any
+	for _, arg := range args {
+		allArgs = append(allArgs, arg.ScopeID)
+		allArgs = append(allArgs, arg.WorkspaceID)
+		allArgs = append(allArgs, arg.KeyAuthID)
+		allArgs = append(allArgs, arg.IntervalMs)
+		allArgs = append(allArgs, arg.GracePeriodMs)
+		allArgs = append(allArgs, arg.CreatedAtM)
+	}
+
+	// Execute the bulk insert
+	_, err := db.ExecContext(ctx, bulkQuery, allArgs...)
+	return err
+}
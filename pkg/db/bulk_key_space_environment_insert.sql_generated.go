@@ -0,0 +1,43 @@
+// Code generated by sqlc bulk insert plugin. DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// bulkInsertKeySpaceEnvironment is the base query for bulk insert
+const bulkInsertKeySpaceEnvironment = `INSERT INTO key_space_environments ( workspace_id, key_auth_id, name, prefix, test_mode, created_at_m ) VALUES %s`
+
+// InsertKeySpaceEnvironments performs bulk insert in a single query
+func (q *BulkQueries) InsertKeySpaceEnvironments(ctx context.Context, db DBTX, args []InsertKeySpaceEnvironmentParams) error {
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	// Build the bulk insert query
+	valueClauses := make([]string, len(args))
+	for i := range args {
+		valueClauses[i] = "( ?, ?, ?, ?, ?, ? )"
+	}
+
+	bulkQuery := fmt.Sprintf(bulkInsertKeySpaceEnvironment, strings.Join(valueClauses, ", "))
+
+	// Collect all arguments
+	var allArgs []any
+	for _, arg := range args {
+		allArgs = append(allArgs, arg.WorkspaceID)
+		allArgs = append(allArgs, arg.KeyAuthID)
+		allArgs = append(allArgs, arg.Name)
+		allArgs = append(allArgs, arg.Prefix)
+		allArgs = append(allArgs, arg.TestMode)
+		allArgs = append(allArgs, arg.CreatedAtM)
+	}
+
+	// Execute the bulk insert
+	_, err := db.ExecContext(ctx, bulkQuery, allArgs...)
+	return err
+}
@@ -9,9 +9,11 @@ import (
 )
 
 // bulkInsertRatelimitOverride is the base query for bulk insert
-const bulkInsertRatelimitOverride = `INSERT INTO ratelimit_overrides ( id, workspace_id, namespace_id, identifier, ` + "`" + `limit` + "`" + `, duration, created_at_m ) VALUES %s ON DUPLICATE KEY UPDATE
+const bulkInsertRatelimitOverride = `INSERT INTO ratelimit_overrides ( id, workspace_id, namespace_id, identifier, ` + "`" + `limit` + "`" + `, duration, priority, expires_at_m, created_at_m ) VALUES %s ON DUPLICATE KEY UPDATE
     ` + "`" + `limit` + "`" + ` = VALUES(` + "`" + `limit` + "`" + `),
     duration = VALUES(duration),
+    priority = VALUES(priority),
+    expires_at_m = VALUES(expires_at_m),
     updated_at_m = ?`
 
 // InsertRatelimitOverrides performs bulk insert in a single query
@@ -24,7 +26,7 @@ func (q *BulkQueries) InsertRatelimitOverrides(ctx context.Context, db DBTX, arg
 	// Build the bulk insert query
 	valueClauses := make([]string, len(args))
 	for i := range args {
-		valueClauses[i] = "( ?, ?, ?, ?, ?, ?, ? )"
+		valueClauses[i] = "( ?, ?, ?, ?, ?, ?, ?, ?, ? )"
 	}
 
 	bulkQuery := fmt.Sprintf(bulkInsertRatelimitOverride, strings.Join(valueClauses, ", "))
@@ -38,6 +40,8 @@ func (q *BulkQueries) InsertRatelimitOverrides(ctx context.Context, db DBTX, arg
 		allArgs = append(allArgs, arg.Identifier)
 		allArgs = append(allArgs, arg.Limit)
 		allArgs = append(allArgs, arg.Duration)
+		allArgs = append(allArgs, arg.Priority)
+		allArgs = append(allArgs, arg.ExpiresAt)
 		allArgs = append(allArgs, arg.CreatedAt)
 	}
 
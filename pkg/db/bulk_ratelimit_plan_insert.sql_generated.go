@@ -0,0 +1,47 @@
+// Code generated by sqlc bulk insert plugin. DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// bulkInsertRatelimitPlan is the base query for bulk insert
+const bulkInsertRatelimitPlan = `INSERT INTO ratelimit_plans ( id, workspace_id, name, created_at_m ) VALUES %s ON DUPLICATE KEY UPDATE
+    updated_at_m = ?`
+
+// InsertRatelimitPlans performs bulk insert in a single query
+func (q *BulkQueries) InsertRatelimitPlans(ctx context.Context, db DBTX, args []InsertRatelimitPlanParams) error {
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	// Build the bulk insert query
+	valueClauses := make([]string, len(args))
+	for i := range args {
+		valueClauses[i] = "( ?, ?, ?, ? )"
+	}
+
+	bulkQuery := fmt.Sprintf(bulkInsertRatelimitPlan, strings.Join(valueClauses, ", "))
+
+	// Collect all arguments
+	var allArgs []any
+	for _, arg := range args {
+		allArgs = append(allArgs, arg.ID)
+		allArgs = append(allArgs, arg.WorkspaceID)
+		allArgs = append(allArgs, arg.Name)
+		allArgs = append(allArgs, arg.CreatedAt)
+	}
+
+	// Add ON DUPLICATE KEY UPDATE parameters (only once, not per row)
+	if len(args) > 0 {
+		allArgs = append(allArgs, args[0].UpdatedAt)
+	}
+
+	// Execute the bulk insert
+	_, err := db.ExecContext(ctx, bulkQuery, allArgs...)
+	return err
+}
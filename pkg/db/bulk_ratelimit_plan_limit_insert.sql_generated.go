@@ -0,0 +1,43 @@
+// Code generated by sqlc bulk insert plugin. DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// bulkInsertRatelimitPlanLimit is the base query for bulk insert
+const bulkInsertRatelimitPlanLimit = `INSERT INTO ratelimit_plan_limits ( plan_id, workspace_id, namespace_id, ` + "`" + `limit` + "`" + `, duration, created_at_m ) VALUES %s`
+
+// InsertRatelimitPlanLimits performs bulk insert in a single query
+func (q *BulkQueries) InsertRatelimitPlanLimits(ctx context.Context, db DBTX, args []InsertRatelimitPlanLimitParams) error {
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	// Build the bulk insert query
+	valueClauses := make([]string, len(args))
+	for i := range args {
+		valueClauses[i] = "( ?, ?, ?, ?, ?, ? )"
+	}
+
+	bulkQuery := fmt.Sprintf(bulkInsertRatelimitPlanLimit, strings.Join(valueClauses, ", "))
+
+	// Collect all arguments
+	var allArgs []any
+	for _, arg := range args {
+		allArgs = append(allArgs, arg.PlanID)
+		allArgs = append(allArgs, arg.WorkspaceID)
+		allArgs = append(allArgs, arg.NamespaceID)
+		allArgs = append(allArgs, arg.Limit)
+		allArgs = append(allArgs, arg.Duration)
+		allArgs = append(allArgs, arg.CreatedAt)
+	}
+
+	// Execute the bulk insert
+	_, err := db.ExecContext(ctx, bulkQuery, allArgs...)
+	return err
+}
@@ -0,0 +1,48 @@
+// Code generated by sqlc bulk insert plugin. DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// bulkInsertVolume is the base query for bulk insert
+const bulkInsertVolume = `INSERT INTO ` + "`" + `volumes` + "`" + ` ( id, workspace_id, project_id, app_id, environment_id, name, mount_path, size_mib, k8s_name, status, created_at ) VALUES %s`
+
+// InsertVolumes performs bulk insert in a single query
+func (q *BulkQueries) InsertVolumes(ctx context.Context, db DBTX, args []InsertVolumeParams) error {
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	// Build the bulk insert query
+	valueClauses := make([]string, len(args))
+	for i := range args {
+		valueClauses[i] = "( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )"
+	}
+
+	bulkQuery := fmt.Sprintf(bulkInsertVolume, strings.Join(valueClauses, ", "))
+
+	// Collect all arguments
+	var allArgs []any
+	for _, arg := range args {
+		allArgs = append(allArgs, arg.ID)
+		allArgs = append(allArgs, arg.WorkspaceID)
+		allArgs = append(allArgs, arg.ProjectID)
+		allArgs = append(allArgs, arg.AppID)
+		allArgs = append(allArgs, arg.EnvironmentID)
+		allArgs = append(allArgs, arg.Name)
+		allArgs = append(allArgs, arg.MountPath)
+		allArgs = append(allArgs, arg.SizeMib)
+		allArgs = append(allArgs, arg.K8sName)
+		allArgs = append(allArgs, arg.Status)
+		allArgs = append(allArgs, arg.CreatedAt)
+	}
+
+	// Execute the bulk insert
+	_, err := db.ExecContext(ctx, bulkQuery, allArgs...)
+	return err
+}
@@ -0,0 +1,44 @@
+// Code generated by sqlc bulk insert plugin. DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// bulkInsertWebhookDelivery is the base query for bulk insert
+const bulkInsertWebhookDelivery = `INSERT INTO webhook_deliveries ( id, workspace_id, endpoint_id, event_id, event_type, payload, status, attempts, created_at ) VALUES %s`
+
+// InsertWebhookDeliveries performs bulk insert in a single query
+func (q *BulkQueries) InsertWebhookDeliveries(ctx context.Context, db DBTX, args []InsertWebhookDeliveryParams) error {
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	// Build the bulk insert query
+	valueClauses := make([]string, len(args))
+	for i := range args {
+		valueClauses[i] = "( ?, ?, ?, ?, ?, ?, 'pending', 0, ? )"
+	}
+
+	bulkQuery := fmt.Sprintf(bulkInsertWebhookDelivery, strings.Join(valueClauses, ", "))
+
+	// Collect all arguments
+	var allArgs []any
+	for _, arg := range args {
+		allArgs = append(allArgs, arg.ID)
+		allArgs = append(allArgs, arg.WorkspaceID)
+		allArgs = append(allArgs, arg.EndpointID)
+		allArgs = append(allArgs, arg.EventID)
+		allArgs = append(allArgs, arg.EventType)
+		allArgs = append(allArgs, arg.Payload)
+		allArgs = append(allArgs, arg.CreatedAt)
+	}
+
+	// Execute the bulk insert
+	_, err := db.ExecContext(ctx, bulkQuery, allArgs...)
+	return err
+}
@@ -0,0 +1,46 @@
+// Code generated by sqlc bulk insert plugin. DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// bulkInsertWebhookEndpoint is the base query for bulk insert
+const bulkInsertWebhookEndpoint = `INSERT INTO webhook_endpoints ( id, workspace_id, url, description, event_types, encrypted_secret, encryption_key_id, enabled, created_at ) VALUES %s`
+
+// InsertWebhookEndpoints performs bulk insert in a single query
+func (q *BulkQueries) InsertWebhookEndpoints(ctx context.Context, db DBTX, args []InsertWebhookEndpointParams) error {
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	// Build the bulk insert query
+	valueClauses := make([]string, len(args))
+	for i := range args {
+		valueClauses[i] = "( ?, ?, ?, ?, ?, ?, ?, ?, ? )"
+	}
+
+	bulkQuery := fmt.Sprintf(bulkInsertWebhookEndpoint, strings.Join(valueClauses, ", "))
+
+	// Collect all arguments
+	var allArgs []any
+	for _, arg := range args {
+		allArgs = append(allArgs, arg.ID)
+		allArgs = append(allArgs, arg.WorkspaceID)
+		allArgs = append(allArgs, arg.Url)
+		allArgs = append(allArgs, arg.Description)
+		allArgs = append(allArgs, arg.EventTypes)
+		allArgs = append(allArgs, arg.EncryptedSecret)
+		allArgs = append(allArgs, arg.EncryptionKeyID)
+		allArgs = append(allArgs, arg.Enabled)
+		allArgs = append(allArgs, arg.CreatedAt)
+	}
+
+	// Execute the bulk insert
+	_, err := db.ExecContext(ctx, bulkQuery, allArgs...)
+	return err
+}
@@ -19,6 +19,7 @@ INSERT INTO ` + "`" + `deployment_topology` + "`" + ` (
     autoscaling_replicas_max,
     autoscaling_threshold_cpu,
     autoscaling_threshold_memory,
+    autoscaling_threshold_rps,
     desired_status,
     created_at
 ) VALUES (
@@ -30,6 +31,7 @@ INSERT INTO ` + "`" + `deployment_topology` + "`" + ` (
     ?,
     ?,
     ?,
+    ?,
     ?
 )
 `
@@ -42,6 +44,7 @@ type InsertDeploymentTopologyParams struct {
 	AutoscalingReplicasMax     uint32                          `db:"autoscaling_replicas_max"`
 	AutoscalingThresholdCpu    sql.NullInt16                   `db:"autoscaling_threshold_cpu"`
 	AutoscalingThresholdMemory sql.NullInt16                   `db:"autoscaling_threshold_memory"`
+	AutoscalingThresholdRps    sql.NullInt16                   `db:"autoscaling_threshold_rps"`
 	DesiredStatus              DeploymentTopologyDesiredStatus `db:"desired_status"`
 	CreatedAt                  int64                           `db:"created_at"`
 }
@@ -56,6 +59,7 @@ type InsertDeploymentTopologyParams struct {
 //	    autoscaling_replicas_max,
 //	    autoscaling_threshold_cpu,
 //	    autoscaling_threshold_memory,
+//	    autoscaling_threshold_rps,
 //	    desired_status,
 //	    created_at
 //	) VALUES (
@@ -67,6 +71,7 @@ type InsertDeploymentTopologyParams struct {
 //	    ?,
 //	    ?,
 //	    ?,
+//	    ?,
 //	    ?
 //	)
 func (q *Queries) InsertDeploymentTopology(ctx context.Context, db DBTX, arg InsertDeploymentTopologyParams) error {
@@ -78,6 +83,7 @@ func (q *Queries) InsertDeploymentTopology(ctx context.Context, db DBTX, arg Ins
 		arg.AutoscalingReplicasMax,
 		arg.AutoscalingThresholdCpu,
 		arg.AutoscalingThresholdMemory,
+		arg.AutoscalingThresholdRps,
 		arg.DesiredStatus,
 		arg.CreatedAt,
 	)
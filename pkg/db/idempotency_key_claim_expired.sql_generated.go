@@ -0,0 +1,65 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: idempotency_key_claim_expired.sql
+
+package db
+
+import (
+	"context"
+)
+
+const claimExpiredIdempotencyKey = `-- name: ClaimExpiredIdempotencyKey :execrows
+UPDATE idempotency_keys
+SET fingerprint = ?,
+    response_status = NULL,
+    response_content_type = NULL,
+    response_body = NULL,
+    created_at_m = ?,
+    expires_at_m = ?
+WHERE workspace_id = ?
+  AND subject_id = ?
+  AND idempotency_key = ?
+  AND expires_at_m <= ?
+`
+
+type ClaimExpiredIdempotencyKeyParams struct {
+	Fingerprint    string `db:"fingerprint"`
+	CreatedAtM     int64  `db:"created_at_m"`
+	ExpiresAtM     int64  `db:"expires_at_m"`
+	WorkspaceID    string `db:"workspace_id"`
+	SubjectID      string `db:"subject_id"`
+	IdempotencyKey string `db:"idempotency_key"`
+}
+
+// Takes over a reservation whose window has passed but that the cleanup cron
+// has not deleted yet, discarding the stored response. Callers decide via the
+// affected rows; zero means the key is still live, or another request claimed
+// it first.
+//
+//	UPDATE idempotency_keys
+//	SET fingerprint = ?,
+//	    response_status = NULL,
+//	    response_content_type = NULL,
+//	    response_body = NULL,
+//	    created_at_m = ?,
+//	    expires_at_m = ?
+//	WHERE workspace_id = ?
+//	  AND subject_id = ?
+//	  AND idempotency_key = ?
+//	  AND expires_at_m <= ?
+func (q *Queries) ClaimExpiredIdempotencyKey(ctx context.Context, db DBTX, arg ClaimExpiredIdempotencyKeyParams) (int64, error) {
+	result, err := db.ExecContext(ctx, claimExpiredIdempotencyKey,
+		arg.Fingerprint,
+		arg.CreatedAtM,
+		arg.ExpiresAtM,
+		arg.WorkspaceID,
+		arg.SubjectID,
+		arg.IdempotencyKey,
+		arg.CreatedAtM,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
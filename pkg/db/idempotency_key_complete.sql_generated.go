@@ -0,0 +1,58 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: idempotency_key_complete.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const completeIdempotencyKey = `-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET response_status = ?,
+    response_content_type = ?,
+    response_body = ?
+WHERE workspace_id = ?
+  AND subject_id = ?
+  AND idempotency_key = ?
+  AND fingerprint = ?
+  AND response_status IS NULL
+`
+
+type CompleteIdempotencyKeyParams struct {
+	ResponseStatus      sql.NullInt32  `db:"response_status"`
+	ResponseContentType sql.NullString `db:"response_content_type"`
+	ResponseBody        sql.NullString `db:"response_body"`
+	WorkspaceID         string         `db:"workspace_id"`
+	SubjectID           string         `db:"subject_id"`
+	IdempotencyKey      string         `db:"idempotency_key"`
+	Fingerprint         string         `db:"fingerprint"`
+}
+
+// Stores the response of the request that reserved the key, so retries
+// replay it.
+//
+//	UPDATE idempotency_keys
+//	SET response_status = ?,
+//	    response_content_type = ?,
+//	    response_body = ?
+//	WHERE workspace_id = ?
+//	  AND subject_id = ?
+//	  AND idempotency_key = ?
+//	  AND fingerprint = ?
+//	  AND response_status IS NULL
+func (q *Queries) CompleteIdempotencyKey(ctx context.Context, db DBTX, arg CompleteIdempotencyKeyParams) error {
+	_, err := db.ExecContext(ctx, completeIdempotencyKey,
+		arg.ResponseStatus,
+		arg.ResponseContentType,
+		arg.ResponseBody,
+		arg.WorkspaceID,
+		arg.SubjectID,
+		arg.IdempotencyKey,
+		arg.Fingerprint,
+	)
+	return err
+}
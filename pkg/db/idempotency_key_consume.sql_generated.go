@@ -0,0 +1,53 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: idempotency_key_consume.sql
+
+package db
+
+import (
+	"context"
+)
+
+const consumeIdempotencyKey = `-- name: ConsumeIdempotencyKey :execrows
+UPDATE idempotency_keys
+SET response_body = NULL
+WHERE workspace_id = ?
+  AND subject_id = ?
+  AND idempotency_key = ?
+  AND fingerprint = ?
+  AND response_status IS NOT NULL
+  AND response_body IS NOT NULL
+`
+
+type ConsumeIdempotencyKeyParams struct {
+	WorkspaceID    string `db:"workspace_id"`
+	SubjectID      string `db:"subject_id"`
+	IdempotencyKey string `db:"idempotency_key"`
+	Fingerprint    string `db:"fingerprint"`
+}
+
+// Clears the stored body of a response that carries a secret once it has been
+// replayed. Callers replay the body only when a row was affected, so
+// concurrent retries cannot both receive the secret.
+//
+//	UPDATE idempotency_keys
+//	SET response_body = NULL
+//	WHERE workspace_id = ?
+//	  AND subject_id = ?
+//	  AND idempotency_key = ?
+//	  AND fingerprint = ?
+//	  AND response_status IS NOT NULL
+//	  AND response_body IS NOT NULL
+func (q *Queries) ConsumeIdempotencyKey(ctx context.Context, db DBTX, arg ConsumeIdempotencyKeyParams) (int64, error) {
+	result, err := db.ExecContext(ctx, consumeIdempotencyKey,
+		arg.WorkspaceID,
+		arg.SubjectID,
+		arg.IdempotencyKey,
+		arg.Fingerprint,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
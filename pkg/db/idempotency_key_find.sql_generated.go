@@ -0,0 +1,47 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: idempotency_key_find.sql
+
+package db
+
+import (
+	"context"
+)
+
+const findIdempotencyKey = `-- name: FindIdempotencyKey :one
+SELECT pk, workspace_id, subject_id, idempotency_key, fingerprint, response_status, response_content_type, response_body, created_at_m, expires_at_m FROM idempotency_keys
+WHERE workspace_id = ?
+  AND subject_id = ?
+  AND idempotency_key = ?
+`
+
+type FindIdempotencyKeyParams struct {
+	WorkspaceID    string `db:"workspace_id"`
+	SubjectID      string `db:"subject_id"`
+	IdempotencyKey string `db:"idempotency_key"`
+}
+
+// FindIdempotencyKey
+//
+//	SELECT pk, workspace_id, subject_id, idempotency_key, fingerprint, response_status, response_content_type, response_body, created_at_m, expires_at_m FROM idempotency_keys
+//	WHERE workspace_id = ?
+//	  AND subject_id = ?
+//	  AND idempotency_key = ?
+func (q *Queries) FindIdempotencyKey(ctx context.Context, db DBTX, arg FindIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := db.QueryRowContext(ctx, findIdempotencyKey, arg.WorkspaceID, arg.SubjectID, arg.IdempotencyKey)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Pk,
+		&i.WorkspaceID,
+		&i.SubjectID,
+		&i.IdempotencyKey,
+		&i.Fingerprint,
+		&i.ResponseStatus,
+		&i.ResponseContentType,
+		&i.ResponseBody,
+		&i.CreatedAtM,
+		&i.ExpiresAtM,
+	)
+	return i, err
+}
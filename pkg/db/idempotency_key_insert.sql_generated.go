@@ -0,0 +1,69 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: idempotency_key_insert.sql
+
+package db
+
+import (
+	"context"
+)
+
+const insertIdempotencyKey = `-- name: InsertIdempotencyKey :exec
+INSERT INTO idempotency_keys (
+    workspace_id,
+    subject_id,
+    idempotency_key,
+    fingerprint,
+    created_at_m,
+    expires_at_m
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
+`
+
+type InsertIdempotencyKeyParams struct {
+	WorkspaceID    string `db:"workspace_id"`
+	SubjectID      string `db:"subject_id"`
+	IdempotencyKey string `db:"idempotency_key"`
+	Fingerprint    string `db:"fingerprint"`
+	CreatedAtM     int64  `db:"created_at_m"`
+	ExpiresAtM     int64  `db:"expires_at_m"`
+}
+
+// Reserves an idempotency key for an in-flight request. The UNIQUE index on
+// (workspace_id, subject_id, idempotency_key) makes concurrent reservations
+// race on a single row; the loser gets a duplicate key error and reads the
+// winner's row.
+//
+//	INSERT INTO idempotency_keys (
+//	    workspace_id,
+//	    subject_id,
+//	    idempotency_key,
+//	    fingerprint,
+//	    created_at_m,
+//	    expires_at_m
+//	) VALUES (
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?
+//	)
+func (q *Queries) InsertIdempotencyKey(ctx context.Context, db DBTX, arg InsertIdempotencyKeyParams) error {
+	_, err := db.ExecContext(ctx, insertIdempotencyKey,
+		arg.WorkspaceID,
+		arg.SubjectID,
+		arg.IdempotencyKey,
+		arg.Fingerprint,
+		arg.CreatedAtM,
+		arg.ExpiresAtM,
+	)
+	return err
+}
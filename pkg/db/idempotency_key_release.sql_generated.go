@@ -0,0 +1,46 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: idempotency_key_release.sql
+
+package db
+
+import (
+	"context"
+)
+
+const releaseIdempotencyKey = `-- name: ReleaseIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE workspace_id = ?
+  AND subject_id = ?
+  AND idempotency_key = ?
+  AND fingerprint = ?
+  AND response_status IS NULL
+`
+
+type ReleaseIdempotencyKeyParams struct {
+	WorkspaceID    string `db:"workspace_id"`
+	SubjectID      string `db:"subject_id"`
+	IdempotencyKey string `db:"idempotency_key"`
+	Fingerprint    string `db:"fingerprint"`
+}
+
+// Drops the reservation of a request that failed, so a retry with the same
+// key runs again instead of waiting for the window to pass. Completed
+// reservations are never released.
+//
+//	DELETE FROM idempotency_keys
+//	WHERE workspace_id = ?
+//	  AND subject_id = ?
+//	  AND idempotency_key = ?
+//	  AND fingerprint = ?
+//	  AND response_status IS NULL
+func (q *Queries) ReleaseIdempotencyKey(ctx context.Context, db DBTX, arg ReleaseIdempotencyKeyParams) error {
+	_, err := db.ExecContext(ctx, releaseIdempotencyKey,
+		arg.WorkspaceID,
+		arg.SubjectID,
+		arg.IdempotencyKey,
+		arg.Fingerprint,
+	)
+	return err
+}
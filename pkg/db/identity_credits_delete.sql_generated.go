@@ -0,0 +1,22 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: identity_credits_delete.sql
+
+package db
+
+import (
+	"context"
+)
+
+const deleteIdentityCredits = `-- name: DeleteIdentityCredits :exec
+DELETE FROM ` + "`" + `identity_credits` + "`" + ` WHERE identity_id = ?
+`
+
+// DeleteIdentityCredits
+//
+//	DELETE FROM `identity_credits` WHERE identity_id = ?
+func (q *Queries) DeleteIdentityCredits(ctx context.Context, db DBTX, identityID string) error {
+	_, err := db.ExecContext(ctx, deleteIdentityCredits, identityID)
+	return err
+}
@@ -0,0 +1,34 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: identity_credits_find.sql
+
+package db
+
+import (
+	"context"
+)
+
+const findIdentityCredits = `-- name: FindIdentityCredits :one
+SELECT pk, identity_id, workspace_id, remaining, refill_day, refill_amount, last_refill_at, created_at_m, updated_at_m FROM ` + "`" + `identity_credits` + "`" + ` WHERE identity_id = ?
+`
+
+// FindIdentityCredits
+//
+//	SELECT pk, identity_id, workspace_id, remaining, refill_day, refill_amount, last_refill_at, created_at_m, updated_at_m FROM `identity_credits` WHERE identity_id = ?
+func (q *Queries) FindIdentityCredits(ctx context.Context, db DBTX, identityID string) (IdentityCredit, error) {
+	row := db.QueryRowContext(ctx, findIdentityCredits, identityID)
+	var i IdentityCredit
+	err := row.Scan(
+		&i.Pk,
+		&i.IdentityID,
+		&i.WorkspaceID,
+		&i.Remaining,
+		&i.RefillDay,
+		&i.RefillAmount,
+		&i.LastRefillAt,
+		&i.CreatedAtM,
+		&i.UpdatedAtM,
+	)
+	return i, err
+}
@@ -0,0 +1,37 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: identity_credits_update_decrement.sql
+
+package db
+
+import (
+	"context"
+)
+
+const updateIdentityCreditsDecrement = `-- name: UpdateIdentityCreditsDecrement :exec
+UPDATE ` + "`" + `identity_credits` + "`" + `
+SET remaining = CASE
+    WHEN remaining >= ? THEN remaining - ?
+    ELSE 0
+END
+WHERE identity_id = ?
+`
+
+type UpdateIdentityCreditsDecrementParams struct {
+	Credits    uint64 `db:"credits"`
+	IdentityID string `db:"identity_id"`
+}
+
+// UpdateIdentityCreditsDecrement
+//
+//	UPDATE `identity_credits`
+//	SET remaining = CASE
+//	    WHEN remaining >= ? THEN remaining - ?
+//	    ELSE 0
+//	END
+//	WHERE identity_id = ?
+func (q *Queries) UpdateIdentityCreditsDecrement(ctx context.Context, db DBTX, arg UpdateIdentityCreditsDecrementParams) error {
+	_, err := db.ExecContext(ctx, updateIdentityCreditsDecrement, arg.Credits, arg.Credits, arg.IdentityID)
+	return err
+}
@@ -0,0 +1,31 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: identity_credits_update_increment.sql
+
+package db
+
+import (
+	"context"
+)
+
+const updateIdentityCreditsIncrement = `-- name: UpdateIdentityCreditsIncrement :exec
+UPDATE ` + "`" + `identity_credits` + "`" + `
+SET remaining = remaining + ?
+WHERE identity_id = ?
+`
+
+type UpdateIdentityCreditsIncrementParams struct {
+	Credits    uint64 `db:"credits"`
+	IdentityID string `db:"identity_id"`
+}
+
+// UpdateIdentityCreditsIncrement
+//
+//	UPDATE `identity_credits`
+//	SET remaining = remaining + ?
+//	WHERE identity_id = ?
+func (q *Queries) UpdateIdentityCreditsIncrement(ctx context.Context, db DBTX, arg UpdateIdentityCreditsIncrementParams) error {
+	_, err := db.ExecContext(ctx, updateIdentityCreditsIncrement, arg.Credits, arg.IdentityID)
+	return err
+}
@@ -0,0 +1,76 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: identity_credits_upsert.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const upsertIdentityCredits = `-- name: UpsertIdentityCredits :exec
+INSERT INTO ` + "`" + `identity_credits` + "`" + ` (
+    identity_id,
+    workspace_id,
+    remaining,
+    refill_day,
+    refill_amount,
+    created_at_m
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+) ON DUPLICATE KEY UPDATE
+    remaining = VALUES(remaining),
+    refill_day = VALUES(refill_day),
+    refill_amount = VALUES(refill_amount),
+    updated_at_m = VALUES(created_at_m)
+`
+
+type UpsertIdentityCreditsParams struct {
+	IdentityID   string        `db:"identity_id"`
+	WorkspaceID  string        `db:"workspace_id"`
+	Remaining    uint64        `db:"remaining"`
+	RefillDay    sql.NullInt16 `db:"refill_day"`
+	RefillAmount sql.NullInt64 `db:"refill_amount"`
+	Now          int64         `db:"now"`
+}
+
+// Creates the identity's credit pool or replaces its balance and refill
+// settings.
+//
+//	INSERT INTO `identity_credits` (
+//	    identity_id,
+//	    workspace_id,
+//	    remaining,
+//	    refill_day,
+//	    refill_amount,
+//	    created_at_m
+//	) VALUES (
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?
+//	) ON DUPLICATE KEY UPDATE
+//	    remaining = VALUES(remaining),
+//	    refill_day = VALUES(refill_day),
+//	    refill_amount = VALUES(refill_amount),
+//	    updated_at_m = VALUES(created_at_m)
+func (q *Queries) UpsertIdentityCredits(ctx context.Context, db DBTX, arg UpsertIdentityCreditsParams) error {
+	_, err := db.ExecContext(ctx, upsertIdentityCredits,
+		arg.IdentityID,
+		arg.WorkspaceID,
+		arg.Remaining,
+		arg.RefillDay,
+		arg.RefillAmount,
+		arg.Now,
+	)
+	return err
+}
@@ -0,0 +1,38 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_bulk_operation_find_by_id.sql
+
+package db
+
+import (
+	"context"
+)
+
+const findKeyBulkOperationByID = `-- name: FindKeyBulkOperationByID :one
+SELECT pk, id, workspace_id, api_id, operation, status, total, succeeded, failed, error, created_at_m, updated_at_m, completed_at_m FROM key_bulk_operations WHERE id = ?
+`
+
+// FindKeyBulkOperationByID
+//
+//	SELECT pk, id, workspace_id, api_id, operation, status, total, succeeded, failed, error, created_at_m, updated_at_m, completed_at_m FROM key_bulk_operations WHERE id = ?
+func (q *Queries) FindKeyBulkOperationByID(ctx context.Context, db DBTX, id string) (KeyBulkOperation, error) {
+	row := db.QueryRowContext(ctx, findKeyBulkOperationByID, id)
+	var i KeyBulkOperation
+	err := row.Scan(
+		&i.Pk,
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ApiID,
+		&i.Operation,
+		&i.Status,
+		&i.Total,
+		&i.Succeeded,
+		&i.Failed,
+		&i.Error,
+		&i.CreatedAtM,
+		&i.UpdatedAtM,
+		&i.CompletedAtM,
+	)
+	return i, err
+}
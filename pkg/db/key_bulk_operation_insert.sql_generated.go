@@ -0,0 +1,70 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_bulk_operation_insert.sql
+
+package db
+
+import (
+	"context"
+)
+
+const insertKeyBulkOperation = `-- name: InsertKeyBulkOperation :exec
+INSERT INTO key_bulk_operations (
+    id,
+    workspace_id,
+    api_id,
+    operation,
+    status,
+    total,
+    created_at_m
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    'pending',
+    ?,
+    ?
+)
+`
+
+type InsertKeyBulkOperationParams struct {
+	ID          string                     `db:"id"`
+	WorkspaceID string                     `db:"workspace_id"`
+	ApiID       string                     `db:"api_id"`
+	Operation   KeyBulkOperationsOperation `db:"operation"`
+	Total       uint32                     `db:"total"`
+	CreatedAtM  int64                      `db:"created_at_m"`
+}
+
+// InsertKeyBulkOperation
+//
+//	INSERT INTO key_bulk_operations (
+//	    id,
+//	    workspace_id,
+//	    api_id,
+//	    operation,
+//	    status,
+//	    total,
+//	    created_at_m
+//	) VALUES (
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    'pending',
+//	    ?,
+//	    ?
+//	)
+func (q *Queries) InsertKeyBulkOperation(ctx context.Context, db DBTX, arg InsertKeyBulkOperationParams) error {
+	_, err := db.ExecContext(ctx, insertKeyBulkOperation,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.ApiID,
+		arg.Operation,
+		arg.Total,
+		arg.CreatedAtM,
+	)
+	return err
+}
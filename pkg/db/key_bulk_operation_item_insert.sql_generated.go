@@ -0,0 +1,43 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_bulk_operation_item_insert.sql
+
+package db
+
+import (
+	"context"
+)
+
+const insertKeyBulkOperationItem = `-- name: InsertKeyBulkOperationItem :exec
+INSERT INTO key_bulk_operation_items (
+    operation_id,
+    key_id,
+    status
+) VALUES (
+    ?,
+    ?,
+    'pending'
+)
+`
+
+type InsertKeyBulkOperationItemParams struct {
+	OperationID string `db:"operation_id"`
+	KeyID       string `db:"key_id"`
+}
+
+// InsertKeyBulkOperationItem
+//
+//	INSERT INTO key_bulk_operation_items (
+//	    operation_id,
+//	    key_id,
+//	    status
+//	) VALUES (
+//	    ?,
+//	    ?,
+//	    'pending'
+//	)
+func (q *Queries) InsertKeyBulkOperationItem(ctx context.Context, db DBTX, arg InsertKeyBulkOperationItemParams) error {
+	_, err := db.ExecContext(ctx, insertKeyBulkOperationItem, arg.OperationID, arg.KeyID)
+	return err
+}
@@ -0,0 +1,69 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_bulk_operation_item_list.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const listKeyBulkOperationItems = `-- name: ListKeyBulkOperationItems :many
+SELECT pk, key_id, status, error
+FROM key_bulk_operation_items
+WHERE operation_id = ?
+  AND pk >= ?
+ORDER BY pk
+LIMIT ?
+`
+
+type ListKeyBulkOperationItemsParams struct {
+	OperationID string `db:"operation_id"`
+	PkCursor    uint64 `db:"pk_cursor"`
+	Limit       int32  `db:"limit"`
+}
+
+type ListKeyBulkOperationItemsRow struct {
+	Pk     uint64                      `db:"pk"`
+	KeyID  string                      `db:"key_id"`
+	Status KeyBulkOperationItemsStatus `db:"status"`
+	Error  sql.NullString              `db:"error"`
+}
+
+// ListKeyBulkOperationItems
+//
+//	SELECT pk, key_id, status, error
+//	FROM key_bulk_operation_items
+//	WHERE operation_id = ?
+//	  AND pk >= ?
+//	ORDER BY pk
+//	LIMIT ?
+func (q *Queries) ListKeyBulkOperationItems(ctx context.Context, db DBTX, arg ListKeyBulkOperationItemsParams) ([]ListKeyBulkOperationItemsRow, error) {
+	rows, err := db.QueryContext(ctx, listKeyBulkOperationItems, arg.OperationID, arg.PkCursor, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListKeyBulkOperationItemsRow
+	for rows.Next() {
+		var i ListKeyBulkOperationItemsRow
+		if err := rows.Scan(
+			&i.Pk,
+			&i.KeyID,
+			&i.Status,
+			&i.Error,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
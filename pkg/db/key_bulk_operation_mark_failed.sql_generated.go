@@ -0,0 +1,44 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_bulk_operation_mark_failed.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const markKeyBulkOperationFailed = `-- name: MarkKeyBulkOperationFailed :exec
+UPDATE key_bulk_operations
+SET status = 'failed',
+    error = ?,
+    updated_at_m = ?,
+    completed_at_m = ?
+WHERE id = ?
+`
+
+type MarkKeyBulkOperationFailedParams struct {
+	Error sql.NullString `db:"error"`
+	Now   sql.NullInt64  `db:"now"`
+	ID    string         `db:"id"`
+}
+
+// MarkKeyBulkOperationFailed ends an operation that never reached the worker.
+//
+//	UPDATE key_bulk_operations
+//	SET status = 'failed',
+//	    error = ?,
+//	    updated_at_m = ?,
+//	    completed_at_m = ?
+//	WHERE id = ?
+func (q *Queries) MarkKeyBulkOperationFailed(ctx context.Context, db DBTX, arg MarkKeyBulkOperationFailedParams) error {
+	_, err := db.ExecContext(ctx, markKeyBulkOperationFailed,
+		arg.Error,
+		arg.Now,
+		arg.Now,
+		arg.ID,
+	)
+	return err
+}
@@ -0,0 +1,51 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_count_live_by_identity_and_key_space_ids.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+const countLiveKeysByIdentityAndKeySpaceIDs = `-- name: CountLiveKeysByIdentityAndKeySpaceIDs :one
+SELECT COUNT(*)
+FROM ` + "`" + `keys` + "`" + ` k
+WHERE k.identity_id = ?
+  AND k.key_auth_id IN (/*SLICE:key_space_ids*/?)
+  AND k.deleted_at_m IS NULL
+`
+
+type CountLiveKeysByIdentityAndKeySpaceIDsParams struct {
+	IdentityID  sql.NullString `db:"identity_id"`
+	KeySpaceIds []string       `db:"key_space_ids"`
+}
+
+// Counts an identity's live keys across a set of keyspaces. Used by
+// portal.createKey to enforce the portal's max_keys.
+//
+//	SELECT COUNT(*)
+//	FROM `keys` k
+//	WHERE k.identity_id = ?
+//	  AND k.key_auth_id IN (/*SLICE:key_space_ids*/?)
+//	  AND k.deleted_at_m IS NULL
+func (q *Queries) CountLiveKeysByIdentityAndKeySpaceIDs(ctx context.Context, db DBTX, arg CountLiveKeysByIdentityAndKeySpaceIDsParams) (int64, error) {
+	query := countLiveKeysByIdentityAndKeySpaceIDs
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.IdentityID)
+	if len(arg.KeySpaceIds) > 0 {
+		for _, v := range arg.KeySpaceIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:key_space_ids*/?", strings.Repeat(",?", len(arg.KeySpaceIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:key_space_ids*/?", "NULL", 1)
+	}
+	row := db.QueryRowContext(ctx, query, queryParams...)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
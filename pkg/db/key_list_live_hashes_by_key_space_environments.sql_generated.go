@@ -0,0 +1,73 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_list_live_hashes_by_key_space_environments.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+const listLiveKeyHashesByKeySpaceEnvironments = `-- name: ListLiveKeyHashesByKeySpaceEnvironments :many
+SELECT id, hash
+FROM ` + "`" + `keys` + "`" + `
+WHERE key_auth_id = ?
+  AND environment IN (/*SLICE:environments*/?)
+  AND deleted_at_m IS NULL
+`
+
+type ListLiveKeyHashesByKeySpaceEnvironmentsParams struct {
+	KeyAuthID    string           `db:"key_auth_id"`
+	Environments []sql.NullString `db:"environments"`
+}
+
+type ListLiveKeyHashesByKeySpaceEnvironmentsRow struct {
+	ID   string `db:"id"`
+	Hash string `db:"hash"`
+}
+
+// Lists the live keys of a keyspace that belong to any of the given
+// environments. Used to invalidate their cached verifications when an
+// environment's test mode changes.
+//
+//	SELECT id, hash
+//	FROM `keys`
+//	WHERE key_auth_id = ?
+//	  AND environment IN (/*SLICE:environments*/?)
+//	  AND deleted_at_m IS NULL
+func (q *Queries) ListLiveKeyHashesByKeySpaceEnvironments(ctx context.Context, db DBTX, arg ListLiveKeyHashesByKeySpaceEnvironmentsParams) ([]ListLiveKeyHashesByKeySpaceEnvironmentsRow, error) {
+	query := listLiveKeyHashesByKeySpaceEnvironments
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.KeyAuthID)
+	if len(arg.Environments) > 0 {
+		for _, v := range arg.Environments {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:environments*/?", strings.Repeat(",?", len(arg.Environments))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:environments*/?", "NULL", 1)
+	}
+	rows, err := db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLiveKeyHashesByKeySpaceEnvironmentsRow
+	for rows.Next() {
+		var i ListLiveKeyHashesByKeySpaceEnvironmentsRow
+		if err := rows.Scan(&i.ID, &i.Hash); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,66 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_rotation_insert.sql
+
+package db
+
+import (
+	"context"
+)
+
+const insertKeyRotation = `-- name: InsertKeyRotation :exec
+INSERT INTO key_rotations (
+    key_id,
+    successor_key_id,
+    workspace_id,
+    key_auth_id,
+    reason,
+    created_at_m
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
+`
+
+type InsertKeyRotationParams struct {
+	KeyID          string             `db:"key_id"`
+	SuccessorKeyID string             `db:"successor_key_id"`
+	WorkspaceID    string             `db:"workspace_id"`
+	KeyAuthID      string             `db:"key_auth_id"`
+	Reason         KeyRotationsReason `db:"reason"`
+	CreatedAtM     int64              `db:"created_at_m"`
+}
+
+// InsertKeyRotation
+//
+//	INSERT INTO key_rotations (
+//	    key_id,
+//	    successor_key_id,
+//	    workspace_id,
+//	    key_auth_id,
+//	    reason,
+//	    created_at_m
+//	) VALUES (
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?
+//	)
+func (q *Queries) InsertKeyRotation(ctx context.Context, db DBTX, arg InsertKeyRotationParams) error {
+	_, err := db.ExecContext(ctx, insertKeyRotation,
+		arg.KeyID,
+		arg.SuccessorKeyID,
+		arg.WorkspaceID,
+		arg.KeyAuthID,
+		arg.Reason,
+		arg.CreatedAtM,
+	)
+	return err
+}
@@ -0,0 +1,22 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_rotation_policy_delete_by_scope_id.sql
+
+package db
+
+import (
+	"context"
+)
+
+const deleteKeyRotationPolicyByScopeID = `-- name: DeleteKeyRotationPolicyByScopeID :exec
+DELETE FROM key_rotation_policies WHERE scope_id = ?
+`
+
+// DeleteKeyRotationPolicyByScopeID
+//
+//	DELETE FROM key_rotation_policies WHERE scope_id = ?
+func (q *Queries) DeleteKeyRotationPolicyByScopeID(ctx context.Context, db DBTX, scopeID string) error {
+	_, err := db.ExecContext(ctx, deleteKeyRotationPolicyByScopeID, scopeID)
+	return err
+}
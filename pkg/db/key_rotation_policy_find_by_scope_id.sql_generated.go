@@ -0,0 +1,33 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_rotation_policy_find_by_scope_id.sql
+
+package db
+
+import (
+	"context"
+)
+
+const findKeyRotationPolicyByScopeID = `-- name: FindKeyRotationPolicyByScopeID :one
+SELECT pk, scope_id, workspace_id, key_auth_id, interval_ms, grace_period_ms, created_at_m, updated_at_m FROM key_rotation_policies WHERE scope_id = ?
+`
+
+// FindKeyRotationPolicyByScopeID
+//
+//	SELECT pk, scope_id, workspace_id, key_auth_id, interval_ms, grace_period_ms, created_at_m, updated_at_m FROM key_rotation_policies WHERE scope_id = ?
+func (q *Queries) FindKeyRotationPolicyByScopeID(ctx context.Context, db DBTX, scopeID string) (KeyRotationPolicy, error) {
+	row := db.QueryRowContext(ctx, findKeyRotationPolicyByScopeID, scopeID)
+	var i KeyRotationPolicy
+	err := row.Scan(
+		&i.Pk,
+		&i.ScopeID,
+		&i.WorkspaceID,
+		&i.KeyAuthID,
+		&i.IntervalMs,
+		&i.GracePeriodMs,
+		&i.CreatedAtM,
+		&i.UpdatedAtM,
+	)
+	return i, err
+}
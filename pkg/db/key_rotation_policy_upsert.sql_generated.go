@@ -0,0 +1,72 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_rotation_policy_upsert.sql
+
+package db
+
+import (
+	"context"
+)
+
+const upsertKeyRotationPolicy = `-- name: UpsertKeyRotationPolicy :exec
+INSERT INTO key_rotation_policies (
+    scope_id,
+    workspace_id,
+    key_auth_id,
+    interval_ms,
+    grace_period_ms,
+    created_at_m
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+) ON DUPLICATE KEY UPDATE
+    interval_ms = VALUES(interval_ms),
+    grace_period_ms = VALUES(grace_period_ms),
+    updated_at_m = VALUES(created_at_m)
+`
+
+type UpsertKeyRotationPolicyParams struct {
+	ScopeID       string `db:"scope_id"`
+	WorkspaceID   string `db:"workspace_id"`
+	KeyAuthID     string `db:"key_auth_id"`
+	IntervalMs    int64  `db:"interval_ms"`
+	GracePeriodMs int64  `db:"grace_period_ms"`
+	CreatedAtM    int64  `db:"created_at_m"`
+}
+
+// UpsertKeyRotationPolicy
+//
+//	INSERT INTO key_rotation_policies (
+//	    scope_id,
+//	    workspace_id,
+//	    key_auth_id,
+//	    interval_ms,
+//	    grace_period_ms,
+//	    created_at_m
+//	) VALUES (
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?
+//	) ON DUPLICATE KEY UPDATE
+//	    interval_ms = VALUES(interval_ms),
+//	    grace_period_ms = VALUES(grace_period_ms),
+//	    updated_at_m = VALUES(created_at_m)
+func (q *Queries) UpsertKeyRotationPolicy(ctx context.Context, db DBTX, arg UpsertKeyRotationPolicyParams) error {
+	_, err := db.ExecContext(ctx, upsertKeyRotationPolicy,
+		arg.ScopeID,
+		arg.WorkspaceID,
+		arg.KeyAuthID,
+		arg.IntervalMs,
+		arg.GracePeriodMs,
+		arg.CreatedAtM,
+	)
+	return err
+}
@@ -0,0 +1,22 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_space_environment_delete_by_key_auth_id.sql
+
+package db
+
+import (
+	"context"
+)
+
+const deleteKeySpaceEnvironments = `-- name: DeleteKeySpaceEnvironments :exec
+DELETE FROM key_space_environments WHERE key_auth_id = ?
+`
+
+// DeleteKeySpaceEnvironments
+//
+//	DELETE FROM key_space_environments WHERE key_auth_id = ?
+func (q *Queries) DeleteKeySpaceEnvironments(ctx context.Context, db DBTX, keyAuthID string) error {
+	_, err := db.ExecContext(ctx, deleteKeySpaceEnvironments, keyAuthID)
+	return err
+}
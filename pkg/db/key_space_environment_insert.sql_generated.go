@@ -0,0 +1,67 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_space_environment_insert.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const insertKeySpaceEnvironment = `-- name: InsertKeySpaceEnvironment :exec
+INSERT INTO key_space_environments (
+    workspace_id,
+    key_auth_id,
+    name,
+    prefix,
+    test_mode,
+    created_at_m
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
+`
+
+type InsertKeySpaceEnvironmentParams struct {
+	WorkspaceID string         `db:"workspace_id"`
+	KeyAuthID   string         `db:"key_auth_id"`
+	Name        string         `db:"name"`
+	Prefix      sql.NullString `db:"prefix"`
+	TestMode    bool           `db:"test_mode"`
+	CreatedAtM  int64          `db:"created_at_m"`
+}
+
+// InsertKeySpaceEnvironment
+//
+//	INSERT INTO key_space_environments (
+//	    workspace_id,
+//	    key_auth_id,
+//	    name,
+//	    prefix,
+//	    test_mode,
+//	    created_at_m
+//	) VALUES (
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?
+//	)
+func (q *Queries) InsertKeySpaceEnvironment(ctx context.Context, db DBTX, arg InsertKeySpaceEnvironmentParams) error {
+	_, err := db.ExecContext(ctx, insertKeySpaceEnvironment,
+		arg.WorkspaceID,
+		arg.KeyAuthID,
+		arg.Name,
+		arg.Prefix,
+		arg.TestMode,
+		arg.CreatedAtM,
+	)
+	return err
+}
@@ -0,0 +1,49 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_space_environment_list_by_key_auth_id.sql
+
+package db
+
+import (
+	"context"
+)
+
+const listKeySpaceEnvironments = `-- name: ListKeySpaceEnvironments :many
+SELECT pk, workspace_id, key_auth_id, name, prefix, test_mode, created_at_m, updated_at_m FROM key_space_environments WHERE key_auth_id = ? ORDER BY name
+`
+
+// ListKeySpaceEnvironments
+//
+//	SELECT pk, workspace_id, key_auth_id, name, prefix, test_mode, created_at_m, updated_at_m FROM key_space_environments WHERE key_auth_id = ? ORDER BY name
+func (q *Queries) ListKeySpaceEnvironments(ctx context.Context, db DBTX, keyAuthID string) ([]KeySpaceEnvironment, error) {
+	rows, err := db.QueryContext(ctx, listKeySpaceEnvironments, keyAuthID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []KeySpaceEnvironment
+	for rows.Next() {
+		var i KeySpaceEnvironment
+		if err := rows.Scan(
+			&i.Pk,
+			&i.WorkspaceID,
+			&i.KeyAuthID,
+			&i.Name,
+			&i.Prefix,
+			&i.TestMode,
+			&i.CreatedAtM,
+			&i.UpdatedAtM,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -535,6 +535,136 @@ func (ns NullFrontlineRoutesSticky) Value() (driver.Value, error) {
 	return string(ns.FrontlineRoutesSticky), nil
 }
 
+type KeyBulkOperationItemsStatus string
+
+const (
+	KeyBulkOperationItemsStatusPending   KeyBulkOperationItemsStatus = "pending"
+	KeyBulkOperationItemsStatusSucceeded KeyBulkOperationItemsStatus = "succeeded"
+	KeyBulkOperationItemsStatusFailed    KeyBulkOperationItemsStatus = "failed"
+)
+
+func (e *KeyBulkOperationItemsStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = KeyBulkOperationItemsStatus(s)
+	case string:
+		*e = KeyBulkOperationItemsStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for KeyBulkOperationItemsStatus: %T", src)
+	}
+	return nil
+}
+
+type NullKeyBulkOperationItemsStatus struct {
+	KeyBulkOperationItemsStatus KeyBulkOperationItemsStatus
+	Valid                       bool // Valid is true if KeyBulkOperationItemsStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullKeyBulkOperationItemsStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.KeyBulkOperationItemsStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.KeyBulkOperationItemsStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullKeyBulkOperationItemsStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.KeyBulkOperationItemsStatus), nil
+}
+
+type KeyBulkOperationsOperation string
+
+const (
+	KeyBulkOperationsOperationUpdate         KeyBulkOperationsOperation = "update"
+	KeyBulkOperationsOperationDelete         KeyBulkOperationsOperation = "delete"
+	KeyBulkOperationsOperationSetPermissions KeyBulkOperationsOperation = "set_permissions"
+)
+
+func (e *KeyBulkOperationsOperation) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = KeyBulkOperationsOperation(s)
+	case string:
+		*e = KeyBulkOperationsOperation(s)
+	default:
+		return fmt.Errorf("unsupported scan type for KeyBulkOperationsOperation: %T", src)
+	}
+	return nil
+}
+
+type NullKeyBulkOperationsOperation struct {
+	KeyBulkOperationsOperation KeyBulkOperationsOperation
+	Valid                      bool // Valid is true if KeyBulkOperationsOperation is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullKeyBulkOperationsOperation) Scan(value interface{}) error {
+	if value == nil {
+		ns.KeyBulkOperationsOperation, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.KeyBulkOperationsOperation.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullKeyBulkOperationsOperation) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.KeyBulkOperationsOperation), nil
+}
+
+type KeyBulkOperationsStatus string
+
+const (
+	KeyBulkOperationsStatusPending   KeyBulkOperationsStatus = "pending"
+	KeyBulkOperationsStatusRunning   KeyBulkOperationsStatus = "running"
+	KeyBulkOperationsStatusCompleted KeyBulkOperationsStatus = "completed"
+	KeyBulkOperationsStatusFailed    KeyBulkOperationsStatus = "failed"
+)
+
+func (e *KeyBulkOperationsStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = KeyBulkOperationsStatus(s)
+	case string:
+		*e = KeyBulkOperationsStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for KeyBulkOperationsStatus: %T", src)
+	}
+	return nil
+}
+
+type NullKeyBulkOperationsStatus struct {
+	KeyBulkOperationsStatus KeyBulkOperationsStatus
+	Valid                   bool // Valid is true if KeyBulkOperationsStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullKeyBulkOperationsStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.KeyBulkOperationsStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.KeyBulkOperationsStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullKeyBulkOperationsStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.KeyBulkOperationsStatus), nil
+}
+
 type KeyMigrationsAlgorithm string
 
 const (
@@ -577,6 +707,134 @@ func (ns NullKeyMigrationsAlgorithm) Value() (driver.Value, error) {
 	return string(ns.KeyMigrationsAlgorithm), nil
 }
 
+type KeyRotationsReason string
+
+const (
+	KeyRotationsReasonReroll   KeyRotationsReason = "reroll"
+	KeyRotationsReasonSchedule KeyRotationsReason = "schedule"
+)
+
+func (e *KeyRotationsReason) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = KeyRotationsReason(s)
+	case string:
+		*e = KeyRotationsReason(s)
+	default:
+		return fmt.Errorf("unsupported scan type for KeyRotationsReason: %T", src)
+	}
+	return nil
+}
+
+type NullKeyRotationsReason struct {
+	KeyRotationsReason KeyRotationsReason
+	Valid              bool // Valid is true if KeyRotationsReason is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullKeyRotationsReason) Scan(value interface{}) error {
+	if value == nil {
+		ns.KeyRotationsReason, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.KeyRotationsReason.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullKeyRotationsReason) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.KeyRotationsReason), nil
+}
+
+type VolumesStatus string
+
+const (
+	VolumesStatusActive   VolumesStatus = "active"
+	VolumesStatusReleased VolumesStatus = "released"
+	VolumesStatusPurged   VolumesStatus = "purged"
+)
+
+func (e *VolumesStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = VolumesStatus(s)
+	case string:
+		*e = VolumesStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for VolumesStatus: %T", src)
+	}
+	return nil
+}
+
+type NullVolumesStatus struct {
+	VolumesStatus VolumesStatus
+	Valid         bool // Valid is true if VolumesStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullVolumesStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.VolumesStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.VolumesStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullVolumesStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.VolumesStatus), nil
+}
+
+type WebhookDeliveriesStatus string
+
+const (
+	WebhookDeliveriesStatusPending   WebhookDeliveriesStatus = "pending"
+	WebhookDeliveriesStatusDelivered WebhookDeliveriesStatus = "delivered"
+	WebhookDeliveriesStatusFailed    WebhookDeliveriesStatus = "failed"
+)
+
+func (e *WebhookDeliveriesStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebhookDeliveriesStatus(s)
+	case string:
+		*e = WebhookDeliveriesStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebhookDeliveriesStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWebhookDeliveriesStatus struct {
+	WebhookDeliveriesStatus WebhookDeliveriesStatus
+	Valid                   bool // Valid is true if WebhookDeliveriesStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebhookDeliveriesStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebhookDeliveriesStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebhookDeliveriesStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebhookDeliveriesStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebhookDeliveriesStatus), nil
+}
+
 type Api struct {
 	Pk               uint64           `db:"pk"`
 	ID               string           `db:"id"`
@@ -592,6 +850,20 @@ type Api struct {
 	DeleteProtection sql.NullBool     `db:"delete_protection"`
 }
 
+type ApiJwtConfig struct {
+	Pk               uint64         `db:"pk"`
+	ApiID            string         `db:"api_id"`
+	WorkspaceID      string         `db:"workspace_id"`
+	Issuer           string         `db:"issuer"`
+	Audience         sql.NullString `db:"audience"`
+	JwksUrl          sql.NullString `db:"jwks_url"`
+	PublicKey        sql.NullString `db:"public_key"`
+	IdentityClaim    string         `db:"identity_claim"`
+	PermissionsClaim sql.NullString `db:"permissions_claim"`
+	CreatedAtM       int64          `db:"created_at_m"`
+	UpdatedAtM       sql.NullInt64  `db:"updated_at_m"`
+}
+
 type App struct {
 	Pk                  uint64         `db:"pk"`
 	ID                  string         `db:"id"`
@@ -780,6 +1052,19 @@ type GithubRepoConnection struct {
 	UpdatedAt          sql.NullInt64 `db:"updated_at"`
 }
 
+type IdempotencyKey struct {
+	Pk                  uint64         `db:"pk"`
+	WorkspaceID         string         `db:"workspace_id"`
+	SubjectID           string         `db:"subject_id"`
+	IdempotencyKey      string         `db:"idempotency_key"`
+	Fingerprint         string         `db:"fingerprint"`
+	ResponseStatus      sql.NullInt32  `db:"response_status"`
+	ResponseContentType sql.NullString `db:"response_content_type"`
+	ResponseBody        sql.NullString `db:"response_body"`
+	CreatedAtM          int64          `db:"created_at_m"`
+	ExpiresAtM          int64          `db:"expires_at_m"`
+}
+
 type Identity struct {
 	Pk          uint64        `db:"pk"`
 	ID          string        `db:"id"`
@@ -793,6 +1078,18 @@ type Identity struct {
 	UpdatedAt   sql.NullInt64 `db:"updated_at"`
 }
 
+type IdentityCredit struct {
+	Pk           uint64        `db:"pk"`
+	IdentityID   string        `db:"identity_id"`
+	WorkspaceID  string        `db:"workspace_id"`
+	Remaining    uint64        `db:"remaining"`
+	RefillDay    sql.NullInt16 `db:"refill_day"`
+	RefillAmount sql.NullInt64 `db:"refill_amount"`
+	LastRefillAt sql.NullTime  `db:"last_refill_at"`
+	CreatedAtM   int64         `db:"created_at_m"`
+	UpdatedAtM   sql.NullInt64 `db:"updated_at_m"`
+}
+
 type Key struct {
 	Pk                 uint64         `db:"pk"`
 	ID                 string         `db:"id"`
@@ -833,6 +1130,44 @@ type KeyAuth struct {
 	SizeLastUpdatedAt  int64          `db:"size_last_updated_at"`
 }
 
+type KeyBulkOperation struct {
+	Pk           uint64                     `db:"pk"`
+	ID           string                     `db:"id"`
+	WorkspaceID  string                     `db:"workspace_id"`
+	ApiID        string                     `db:"api_id"`
+	Operation    KeyBulkOperationsOperation `db:"operation"`
+	Status       KeyBulkOperationsStatus    `db:"status"`
+	Total        uint32                     `db:"total"`
+	Succeeded    uint32                     `db:"succeeded"`
+	Failed       uint32                     `db:"failed"`
+	Error        sql.NullString             `db:"error"`
+	CreatedAtM   int64                      `db:"created_at_m"`
+	UpdatedAtM   sql.NullInt64              `db:"updated_at_m"`
+	CompletedAtM sql.NullInt64              `db:"completed_at_m"`
+}
+
+type KeyRotationPolicy struct {
+	Pk            uint64        `db:"pk"`
+	ScopeID       string        `db:"scope_id"`
+	WorkspaceID   string        `db:"workspace_id"`
+	KeyAuthID     string        `db:"key_auth_id"`
+	IntervalMs    int64         `db:"interval_ms"`
+	GracePeriodMs int64         `db:"grace_period_ms"`
+	CreatedAtM    int64         `db:"created_at_m"`
+	UpdatedAtM    sql.NullInt64 `db:"updated_at_m"`
+}
+
+type KeySpaceEnvironment struct {
+	Pk          uint64         `db:"pk"`
+	WorkspaceID string         `db:"workspace_id"`
+	KeyAuthID   string         `db:"key_auth_id"`
+	Name        string         `db:"name"`
+	Prefix      sql.NullString `db:"prefix"`
+	TestMode    bool           `db:"test_mode"`
+	CreatedAtM  int64          `db:"created_at_m"`
+	UpdatedAtM  sql.NullInt64  `db:"updated_at_m"`
+}
+
 type KeysRole struct {
 	Pk          uint64        `db:"pk"`
 	KeyID       string        `db:"key_id"`
@@ -869,22 +1204,29 @@ type Permission struct {
 	Name        string            `db:"name"`
 	Slug        string            `db:"slug"`
 	Description dbtype.NullString `db:"description"`
+	Conditions  []byte            `db:"conditions"`
 	CreatedAtM  int64             `db:"created_at_m"`
 	UpdatedAtM  sql.NullInt64     `db:"updated_at_m"`
 }
 
 type Portal struct {
-	Pk           uint64         `db:"pk"`
-	ID           string         `db:"id"`
-	WorkspaceID  string         `db:"workspace_id"`
-	Slug         string         `db:"slug"`
-	AppID        sql.NullString `db:"app_id"`
-	KeyAuthID    sql.NullString `db:"key_auth_id"`
-	Enabled      bool           `db:"enabled"`
-	LogoUrl      sql.NullString `db:"logo_url"`
-	PrimaryColor sql.NullString `db:"primary_color"`
-	CreatedAt    int64          `db:"created_at"`
-	UpdatedAt    sql.NullInt64  `db:"updated_at"`
+	Pk                 uint64         `db:"pk"`
+	ID                 string         `db:"id"`
+	WorkspaceID        string         `db:"workspace_id"`
+	Slug               string         `db:"slug"`
+	AppID              sql.NullString `db:"app_id"`
+	KeyAuthID          sql.NullString `db:"key_auth_id"`
+	Enabled            bool           `db:"enabled"`
+	LogoUrl            sql.NullString `db:"logo_url"`
+	PrimaryColor       sql.NullString `db:"primary_color"`
+	MaxKeys            sql.NullInt32  `db:"max_keys"`
+	AllowedPermissions []byte         `db:"allowed_permissions"`
+	AllowedRoles       []byte         `db:"allowed_roles"`
+	RatelimitTemplates []byte         `db:"ratelimit_templates"`
+	MinExpiryMs        sql.NullInt64  `db:"min_expiry_ms"`
+	MaxExpiryMs        sql.NullInt64  `db:"max_expiry_ms"`
+	CreatedAt          int64          `db:"created_at"`
+	UpdatedAt          sql.NullInt64  `db:"updated_at"`
 }
 
 type PortalSession struct {
@@ -950,6 +1292,8 @@ type RatelimitOverride struct {
 	Identifier  string        `db:"identifier"`
 	Limit       uint64        `db:"limit"`
 	Duration    uint64        `db:"duration"`
+	Priority    int32         `db:"priority"`
+	ExpiresAtM  sql.NullInt64 `db:"expires_at_m"`
 	CreatedAtM  int64         `db:"created_at_m"`
 	UpdatedAtM  sql.NullInt64 `db:"updated_at_m"`
 	DeletedAtM  sql.NullInt64 `db:"deleted_at_m"`
@@ -983,6 +1327,55 @@ type RolesPermission struct {
 	UpdatedAtM   sql.NullInt64 `db:"updated_at_m"`
 }
 
+type Volume struct {
+	Pk            uint64        `db:"pk"`
+	ID            string        `db:"id"`
+	WorkspaceID   string        `db:"workspace_id"`
+	ProjectID     string        `db:"project_id"`
+	AppID         string        `db:"app_id"`
+	EnvironmentID string        `db:"environment_id"`
+	Name          string        `db:"name"`
+	MountPath     string        `db:"mount_path"`
+	SizeMib       uint32        `db:"size_mib"`
+	K8sName       string        `db:"k8s_name"`
+	Status        VolumesStatus `db:"status"`
+	ReleasedAt    sql.NullInt64 `db:"released_at"`
+	CreatedAt     int64         `db:"created_at"`
+	UpdatedAt     sql.NullInt64 `db:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	Pk             uint64                  `db:"pk"`
+	ID             string                  `db:"id"`
+	WorkspaceID    string                  `db:"workspace_id"`
+	EndpointID     string                  `db:"endpoint_id"`
+	EventID        string                  `db:"event_id"`
+	EventType      string                  `db:"event_type"`
+	Payload        json.RawMessage         `db:"payload"`
+	Status         WebhookDeliveriesStatus `db:"status"`
+	Attempts       uint32                  `db:"attempts"`
+	ResponseStatus sql.NullInt32           `db:"response_status"`
+	LastError      sql.NullString          `db:"last_error"`
+	NextAttemptAt  sql.NullInt64           `db:"next_attempt_at"`
+	DeliveredAt    sql.NullInt64           `db:"delivered_at"`
+	CreatedAt      int64                   `db:"created_at"`
+	UpdatedAt      sql.NullInt64           `db:"updated_at"`
+}
+
+type WebhookEndpoint struct {
+	Pk              uint64          `db:"pk"`
+	ID              string          `db:"id"`
+	WorkspaceID     string          `db:"workspace_id"`
+	Url             string          `db:"url"`
+	Description     sql.NullString  `db:"description"`
+	EventTypes      json.RawMessage `db:"event_types"`
+	EncryptedSecret string          `db:"encrypted_secret"`
+	EncryptionKeyID string          `db:"encryption_key_id"`
+	Enabled         bool            `db:"enabled"`
+	CreatedAt       int64           `db:"created_at"`
+	UpdatedAt       sql.NullInt64   `db:"updated_at"`
+}
+
 type Workspace struct {
 	Pk               uint64          `db:"pk"`
 	ID               string          `db:"id"`
@@ -10,7 +10,7 @@ import (
 )
 
 const findPermissionBySlugAndWorkspaceID = `-- name: FindPermissionBySlugAndWorkspaceID :one
-SELECT pk, id, workspace_id, project_id, name, slug, description, created_at_m, updated_at_m
+SELECT pk, id, workspace_id, project_id, name, slug, description, conditions, created_at_m, updated_at_m
 FROM permissions
 WHERE slug = ?
 AND workspace_id = ?
@@ -24,7 +24,7 @@ type FindPermissionBySlugAndWorkspaceIDParams struct {
 
 // FindPermissionBySlugAndWorkspaceID
 //
-//	SELECT pk, id, workspace_id, project_id, name, slug, description, created_at_m, updated_at_m
+//	SELECT pk, id, workspace_id, project_id, name, slug, description, conditions, created_at_m, updated_at_m
 //	FROM permissions
 //	WHERE slug = ?
 //	AND workspace_id = ?
@@ -40,6 +40,7 @@ func (q *Queries) FindPermissionBySlugAndWorkspaceID(ctx context.Context, db DBT
 		&i.Name,
 		&i.Slug,
 		&i.Description,
+		&i.Conditions,
 		&i.CreatedAtM,
 		&i.UpdatedAtM,
 	)
@@ -11,7 +11,7 @@ import (
 )
 
 const findPermissionsBySlugs = `-- name: FindPermissionsBySlugs :many
-SELECT pk, id, workspace_id, project_id, name, slug, description, created_at_m, updated_at_m FROM permissions WHERE workspace_id = ? AND slug IN (/*SLICE:slugs*/?)
+SELECT pk, id, workspace_id, project_id, name, slug, description, conditions, created_at_m, updated_at_m FROM permissions WHERE workspace_id = ? AND slug IN (/*SLICE:slugs*/?)
 `
 
 type FindPermissionsBySlugsParams struct {
@@ -21,7 +21,7 @@ type FindPermissionsBySlugsParams struct {
 
 // FindPermissionsBySlugs
 //
-//	SELECT pk, id, workspace_id, project_id, name, slug, description, created_at_m, updated_at_m FROM permissions WHERE workspace_id = ? AND slug IN (/*SLICE:slugs*/?)
+//	SELECT pk, id, workspace_id, project_id, name, slug, description, conditions, created_at_m, updated_at_m FROM permissions WHERE workspace_id = ? AND slug IN (/*SLICE:slugs*/?)
 func (q *Queries) FindPermissionsBySlugs(ctx context.Context, db DBTX, arg FindPermissionsBySlugsParams) ([]Permission, error) {
 	query := findPermissionsBySlugs
 	var queryParams []interface{}
@@ -50,6 +50,7 @@ func (q *Queries) FindPermissionsBySlugs(ctx context.Context, db DBTX, arg FindP
 			&i.Name,
 			&i.Slug,
 			&i.Description,
+			&i.Conditions,
 			&i.CreatedAtM,
 			&i.UpdatedAtM,
 		); err != nil {
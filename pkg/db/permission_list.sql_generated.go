@@ -11,7 +11,7 @@ import (
 )
 
 const listPermissions = `-- name: ListPermissions :many
-SELECT p.pk, p.id, p.workspace_id, p.project_id, p.name, p.slug, p.description, p.created_at_m, p.updated_at_m
+SELECT p.pk, p.id, p.workspace_id, p.project_id, p.name, p.slug, p.description, p.conditions, p.created_at_m, p.updated_at_m
 FROM permissions p
 WHERE p.workspace_id = ?
   AND p.id >= ?
@@ -34,7 +34,7 @@ type ListPermissionsParams struct {
 
 // ListPermissions
 //
-//	SELECT p.pk, p.id, p.workspace_id, p.project_id, p.name, p.slug, p.description, p.created_at_m, p.updated_at_m
+//	SELECT p.pk, p.id, p.workspace_id, p.project_id, p.name, p.slug, p.description, p.conditions, p.created_at_m, p.updated_at_m
 //	FROM permissions p
 //	WHERE p.workspace_id = ?
 //	  AND p.id >= ?
@@ -71,6 +71,7 @@ func (q *Queries) ListPermissions(ctx context.Context, db DBTX, arg ListPermissi
 			&i.Name,
 			&i.Slug,
 			&i.Description,
+			&i.Conditions,
 			&i.CreatedAtM,
 			&i.UpdatedAtM,
 		); err != nil {
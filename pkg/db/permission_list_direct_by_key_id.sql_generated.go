@@ -10,7 +10,7 @@ import (
 )
 
 const listDirectPermissionsByKeyID = `-- name: ListDirectPermissionsByKeyID :many
-SELECT p.pk, p.id, p.workspace_id, p.project_id, p.name, p.slug, p.description, p.created_at_m, p.updated_at_m
+SELECT p.pk, p.id, p.workspace_id, p.project_id, p.name, p.slug, p.description, p.conditions, p.created_at_m, p.updated_at_m
 FROM keys_permissions kp
 JOIN permissions p ON kp.permission_id = p.id
 WHERE kp.key_id = ?
@@ -19,7 +19,7 @@ ORDER BY p.slug
 
 // ListDirectPermissionsByKeyID
 //
-//	SELECT p.pk, p.id, p.workspace_id, p.project_id, p.name, p.slug, p.description, p.created_at_m, p.updated_at_m
+//	SELECT p.pk, p.id, p.workspace_id, p.project_id, p.name, p.slug, p.description, p.conditions, p.created_at_m, p.updated_at_m
 //	FROM keys_permissions kp
 //	JOIN permissions p ON kp.permission_id = p.id
 //	WHERE kp.key_id = ?
@@ -41,6 +41,7 @@ func (q *Queries) ListDirectPermissionsByKeyID(ctx context.Context, db DBTX, key
 			&i.Name,
 			&i.Slug,
 			&i.Description,
+			&i.Conditions,
 			&i.CreatedAtM,
 			&i.UpdatedAtM,
 		); err != nil {
@@ -0,0 +1,36 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: permission_update_conditions.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const updatePermissionConditions = `-- name: UpdatePermissionConditions :exec
+UPDATE permissions
+SET conditions = ?,
+    updated_at_m = ?
+WHERE id = ?
+`
+
+type UpdatePermissionConditionsParams struct {
+	Conditions   []byte        `db:"conditions"`
+	UpdatedAtM   sql.NullInt64 `db:"updated_at_m"`
+	PermissionID string        `db:"permission_id"`
+}
+
+// Sets the conditions under which a permission applies. NULL makes the
+// permission apply unconditionally.
+//
+//	UPDATE permissions
+//	SET conditions = ?,
+//	    updated_at_m = ?
+//	WHERE id = ?
+func (q *Queries) UpdatePermissionConditions(ctx context.Context, db DBTX, arg UpdatePermissionConditionsParams) error {
+	_, err := db.ExecContext(ctx, updatePermissionConditions, arg.Conditions, arg.UpdatedAtM, arg.PermissionID)
+	return err
+}
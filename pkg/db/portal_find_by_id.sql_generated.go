@@ -0,0 +1,53 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: portal_find_by_id.sql
+
+package db
+
+import (
+	"context"
+)
+
+const findPortalByID = `-- name: FindPortalByID :one
+SELECT pk, id, workspace_id, slug, app_id, key_auth_id, enabled, logo_url, primary_color, max_keys, allowed_permissions, allowed_roles, ratelimit_templates, min_expiry_ms, max_expiry_ms, created_at, updated_at FROM portals
+WHERE id = ?
+  AND workspace_id = ?
+`
+
+type FindPortalByIDParams struct {
+	ID          string `db:"id"`
+	WorkspaceID string `db:"workspace_id"`
+}
+
+// Loads the portal a session was issued for. Portal routes read its key policy
+// per request rather than from the session, so tightening a policy applies to
+// sessions that are already live.
+//
+//	SELECT pk, id, workspace_id, slug, app_id, key_auth_id, enabled, logo_url, primary_color, max_keys, allowed_permissions, allowed_roles, ratelimit_templates, min_expiry_ms, max_expiry_ms, created_at, updated_at FROM portals
+//	WHERE id = ?
+//	  AND workspace_id = ?
+func (q *Queries) FindPortalByID(ctx context.Context, db DBTX, arg FindPortalByIDParams) (Portal, error) {
+	row := db.QueryRowContext(ctx, findPortalByID, arg.ID, arg.WorkspaceID)
+	var i Portal
+	err := row.Scan(
+		&i.Pk,
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Slug,
+		&i.AppID,
+		&i.KeyAuthID,
+		&i.Enabled,
+		&i.LogoUrl,
+		&i.PrimaryColor,
+		&i.MaxKeys,
+		&i.AllowedPermissions,
+		&i.AllowedRoles,
+		&i.RatelimitTemplates,
+		&i.MinExpiryMs,
+		&i.MaxExpiryMs,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -10,7 +10,7 @@ import (
 )
 
 const findPortalByIdOrSlug = `-- name: FindPortalByIdOrSlug :one
-SELECT p.pk, p.id, p.workspace_id, p.slug, p.app_id, p.key_auth_id, p.enabled, p.logo_url, p.primary_color, p.created_at, p.updated_at
+SELECT p.pk, p.id, p.workspace_id, p.slug, p.app_id, p.key_auth_id, p.enabled, p.logo_url, p.primary_color, p.max_keys, p.allowed_permissions, p.allowed_roles, p.ratelimit_templates, p.min_expiry_ms, p.max_expiry_ms, p.created_at, p.updated_at
 FROM portals p
 JOIN (
     SELECT p1.id
@@ -35,7 +35,7 @@ type FindPortalByIdOrSlugParams struct {
 // UNION ALL of two index seeks instead of `id = ? OR slug = ?`, which would
 // force a scan: `portals_id_unique` and `idx_workspace_slug` each serve one arm.
 //
-//	SELECT p.pk, p.id, p.workspace_id, p.slug, p.app_id, p.key_auth_id, p.enabled, p.logo_url, p.primary_color, p.created_at, p.updated_at
+//	SELECT p.pk, p.id, p.workspace_id, p.slug, p.app_id, p.key_auth_id, p.enabled, p.logo_url, p.primary_color, p.max_keys, p.allowed_permissions, p.allowed_roles, p.ratelimit_templates, p.min_expiry_ms, p.max_expiry_ms, p.created_at, p.updated_at
 //	FROM portals p
 //	JOIN (
 //	    SELECT p1.id
@@ -65,6 +65,12 @@ func (q *Queries) FindPortalByIdOrSlug(ctx context.Context, db DBTX, arg FindPor
 		&i.Enabled,
 		&i.LogoUrl,
 		&i.PrimaryColor,
+		&i.MaxKeys,
+		&i.AllowedPermissions,
+		&i.AllowedRoles,
+		&i.RatelimitTemplates,
+		&i.MinExpiryMs,
+		&i.MaxExpiryMs,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
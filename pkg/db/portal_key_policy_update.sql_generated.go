@@ -0,0 +1,65 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: portal_key_policy_update.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const updatePortalKeyPolicy = `-- name: UpdatePortalKeyPolicy :exec
+UPDATE portals
+SET max_keys = ?,
+    allowed_permissions = ?,
+    allowed_roles = ?,
+    ratelimit_templates = ?,
+    min_expiry_ms = ?,
+    max_expiry_ms = ?,
+    updated_at = ?
+WHERE id = ?
+  AND workspace_id = ?
+`
+
+type UpdatePortalKeyPolicyParams struct {
+	MaxKeys            sql.NullInt32 `db:"max_keys"`
+	AllowedPermissions []byte        `db:"allowed_permissions"`
+	AllowedRoles       []byte        `db:"allowed_roles"`
+	RatelimitTemplates []byte        `db:"ratelimit_templates"`
+	MinExpiryMs        sql.NullInt64 `db:"min_expiry_ms"`
+	MaxExpiryMs        sql.NullInt64 `db:"max_expiry_ms"`
+	UpdatedAt          sql.NullInt64 `db:"updated_at"`
+	ID                 string        `db:"id"`
+	WorkspaceID        string        `db:"workspace_id"`
+}
+
+// The key policy bounds what an end user may create through the portal. Like
+// branding it lives on the portal row and is written as a whole, so a NULL
+// column clears that part of the policy.
+//
+//	UPDATE portals
+//	SET max_keys = ?,
+//	    allowed_permissions = ?,
+//	    allowed_roles = ?,
+//	    ratelimit_templates = ?,
+//	    min_expiry_ms = ?,
+//	    max_expiry_ms = ?,
+//	    updated_at = ?
+//	WHERE id = ?
+//	  AND workspace_id = ?
+func (q *Queries) UpdatePortalKeyPolicy(ctx context.Context, db DBTX, arg UpdatePortalKeyPolicyParams) error {
+	_, err := db.ExecContext(ctx, updatePortalKeyPolicy,
+		arg.MaxKeys,
+		arg.AllowedPermissions,
+		arg.AllowedRoles,
+		arg.RatelimitTemplates,
+		arg.MinExpiryMs,
+		arg.MaxExpiryMs,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.WorkspaceID,
+	)
+	return err
+}
@@ -7,6 +7,7 @@ import "context"
 // BulkQuerier contains bulk insert methods.
 type BulkQuerier interface {
 	InsertApis(ctx context.Context, db DBTX, args []InsertApiParams) error
+	UpsertApiJwtConfig(ctx context.Context, db DBTX, args []UpsertApiJwtConfigParams) error
 	UpsertAppBuildSettings(ctx context.Context, db DBTX, args []UpsertAppBuildSettingsParams) error
 	InsertAppEnvironmentVariables(ctx context.Context, db DBTX, args []InsertAppEnvironmentVariableParams) error
 	InsertApps(ctx context.Context, db DBTX, args []InsertAppParams) error
@@ -24,16 +25,23 @@ type BulkQuerier interface {
 	InsertGithubRepoConnections(ctx context.Context, db DBTX, args []InsertGithubRepoConnectionParams) error
 	UpsertGithubRepoConnection(ctx context.Context, db DBTX, args []UpsertGithubRepoConnectionParams) error
 	InsertHorizontalAutoscalingPolicies(ctx context.Context, db DBTX, args []InsertHorizontalAutoscalingPolicyParams) error
+	InsertIdempotencyKeys(ctx context.Context, db DBTX, args []InsertIdempotencyKeyParams) error
+	UpsertIdentityCredits(ctx context.Context, db DBTX, args []UpsertIdentityCreditsParams) error
 	InsertIdentities(ctx context.Context, db DBTX, args []InsertIdentityParams) error
 	InsertIdentityRatelimits(ctx context.Context, db DBTX, args []InsertIdentityRatelimitParams) error
 	UpsertIdentity(ctx context.Context, db DBTX, args []UpsertIdentityParams) error
 	InsertFrontlineRoutes(ctx context.Context, db DBTX, args []InsertFrontlineRouteParams) error
+	InsertKeyBulkOperations(ctx context.Context, db DBTX, args []InsertKeyBulkOperationParams) error
+	InsertKeyBulkOperationItems(ctx context.Context, db DBTX, args []InsertKeyBulkOperationItemParams) error
 	InsertKeyEncryptions(ctx context.Context, db DBTX, args []InsertKeyEncryptionParams) error
 	InsertKeys(ctx context.Context, db DBTX, args []InsertKeyParams) error
 	InsertKeyRatelimits(ctx context.Context, db DBTX, args []InsertKeyRatelimitParams) error
 	InsertKeyMigrations(ctx context.Context, db DBTX, args []InsertKeyMigrationParams) error
 	InsertKeyPermissions(ctx context.Context, db DBTX, args []InsertKeyPermissionParams) error
 	InsertKeyRoles(ctx context.Context, db DBTX, args []InsertKeyRoleParams) error
+	InsertKeyRotations(ctx context.Context, db DBTX, args []InsertKeyRotationParams) error
+	UpsertKeyRotationPolicy(ctx context.Context, db DBTX, args []UpsertKeyRotationPolicyParams) error
+	InsertKeySpaceEnvironments(ctx context.Context, db DBTX, args []InsertKeySpaceEnvironmentParams) error
 	InsertKeySpaces(ctx context.Context, db DBTX, args []InsertKeySpaceParams) error
 	UpsertKeySpace(ctx context.Context, db DBTX, args []UpsertKeySpaceParams) error
 	UpsertLimit(ctx context.Context, db DBTX, args []UpsertLimitParams) error
@@ -44,8 +52,13 @@ type BulkQuerier interface {
 	InsertProjects(ctx context.Context, db DBTX, args []InsertProjectParams) error
 	InsertRatelimitNamespaces(ctx context.Context, db DBTX, args []InsertRatelimitNamespaceParams) error
 	InsertRatelimitOverrides(ctx context.Context, db DBTX, args []InsertRatelimitOverrideParams) error
+	InsertRatelimitPlans(ctx context.Context, db DBTX, args []InsertRatelimitPlanParams) error
+	InsertRatelimitPlanLimits(ctx context.Context, db DBTX, args []InsertRatelimitPlanLimitParams) error
 	InsertRoles(ctx context.Context, db DBTX, args []InsertRoleParams) error
 	InsertRolePermissions(ctx context.Context, db DBTX, args []InsertRolePermissionParams) error
+	InsertVolumes(ctx context.Context, db DBTX, args []InsertVolumeParams) error
+	InsertWebhookDeliveries(ctx context.Context, db DBTX, args []InsertWebhookDeliveryParams) error
+	InsertWebhookEndpoints(ctx context.Context, db DBTX, args []InsertWebhookEndpointParams) error
 	UpsertWorkspaceBillingPlanOverride(ctx context.Context, db DBTX, args []UpsertWorkspaceBillingPlanOverrideParams) error
 	UpsertWorkspaceBillingSpendSuspended(ctx context.Context, db DBTX, args []UpsertWorkspaceBillingSpendSuspendedParams) error
 	InsertWorkspaces(ctx context.Context, db DBTX, args []InsertWorkspaceParams) error
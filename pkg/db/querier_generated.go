@@ -10,12 +10,64 @@ import (
 )
 
 type Querier interface {
+	// Takes over a reservation whose window has passed but that the cleanup cron
+	// has not deleted yet, discarding the stored response. Callers decide via the
+	// affected rows; zero means the key is still live, or another request claimed
+	// it first.
+	//
+	//  UPDATE idempotency_keys
+	//  SET fingerprint = ?,
+	//      response_status = NULL,
+	//      response_content_type = NULL,
+	//      response_body = NULL,
+	//      created_at_m = ?,
+	//      expires_at_m = ?
+	//  WHERE workspace_id = ?
+	//    AND subject_id = ?
+	//    AND idempotency_key = ?
+	//    AND expires_at_m <= ?
+	ClaimExpiredIdempotencyKey(ctx context.Context, db DBTX, arg ClaimExpiredIdempotencyKeyParams) (int64, error)
+	// Stores the response of the request that reserved the key, so retries
+	// replay it.
+	//
+	//  UPDATE idempotency_keys
+	//  SET response_status = ?,
+	//      response_content_type = ?,
+	//      response_body = ?
+	//  WHERE workspace_id = ?
+	//    AND subject_id = ?
+	//    AND idempotency_key = ?
+	//    AND fingerprint = ?
+	//    AND response_status IS NULL
+	CompleteIdempotencyKey(ctx context.Context, db DBTX, arg CompleteIdempotencyKeyParams) error
+	// Clears the stored body of a response that carries a secret once it has been
+	// replayed. Callers replay the body only when a row was affected, so
+	// concurrent retries cannot both receive the secret.
+	//
+	//  UPDATE idempotency_keys
+	//  SET response_body = NULL
+	//  WHERE workspace_id = ?
+	//    AND subject_id = ?
+	//    AND idempotency_key = ?
+	//    AND fingerprint = ?
+	//    AND response_status IS NOT NULL
+	//    AND response_body IS NOT NULL
+	ConsumeIdempotencyKey(ctx context.Context, db DBTX, arg ConsumeIdempotencyKeyParams) (int64, error)
 	// Covered by unique_domain_workspace_idx, which leads on workspace_id.
 	//
 	//  SELECT COUNT(*)
 	//  FROM custom_domains
 	//  WHERE workspace_id = ?
 	CountCustomDomainsByWorkspace(ctx context.Context, db DBTX, workspaceID string) (int64, error)
+	// Counts an identity's live keys across a set of keyspaces. Used by
+	// portal.createKey to enforce the portal's max_keys.
+	//
+	//  SELECT COUNT(*)
+	//  FROM `keys` k
+	//  WHERE k.identity_id = ?
+	//    AND k.key_auth_id IN (/*SLICE:key_space_ids*/?)
+	//    AND k.deleted_at_m IS NULL
+	CountLiveKeysByIdentityAndKeySpaceIDs(ctx context.Context, db DBTX, arg CountLiveKeysByIdentityAndKeySpaceIDsParams) (int64, error)
 	//DeleteAllKeyPermissionsByKeyID
 	//
 	//  DELETE FROM keys_permissions
@@ -26,6 +78,10 @@ type Querier interface {
 	//  DELETE FROM keys_roles
 	//  WHERE key_id = ?
 	DeleteAllKeyRolesByKeyID(ctx context.Context, db DBTX, keyID string) error
+	//DeleteApiJwtConfigByApiID
+	//
+	//  DELETE FROM api_jwt_configs WHERE api_id = ?
+	DeleteApiJwtConfigByApiID(ctx context.Context, db DBTX, apiID string) error
 	//DeleteAppBuildSettingsByEnvironmentId
 	//
 	//  DELETE FROM app_build_settings WHERE environment_id = ?
@@ -66,6 +122,10 @@ type Querier interface {
 	//
 	//  DELETE FROM github_repo_connections WHERE app_id = ?
 	DeleteGithubRepoConnectionsByAppId(ctx context.Context, db DBTX, appID string) error
+	//DeleteIdentityCredits
+	//
+	//  DELETE FROM `identity_credits` WHERE identity_id = ?
+	DeleteIdentityCredits(ctx context.Context, db DBTX, identityID string) error
 	//DeleteKeyByID
 	//
 	//  DELETE k, kp, kr, rl, ek
@@ -76,6 +136,14 @@ type Querier interface {
 	//  LEFT JOIN encrypted_keys ek ON k.id = ek.key_id
 	//  WHERE k.id = ?
 	DeleteKeyByID(ctx context.Context, db DBTX, id string) error
+	//DeleteKeyRotationPolicyByScopeID
+	//
+	//  DELETE FROM key_rotation_policies WHERE scope_id = ?
+	DeleteKeyRotationPolicyByScopeID(ctx context.Context, db DBTX, scopeID string) error
+	//DeleteKeySpaceEnvironments
+	//
+	//  DELETE FROM key_space_environments WHERE key_auth_id = ?
+	DeleteKeySpaceEnvironments(ctx context.Context, db DBTX, keyAuthID string) error
 	//DeleteManyKeyPermissionByKeyAndPermissionIDs
 	//
 	//  DELETE FROM keys_permissions
@@ -130,11 +198,29 @@ type Querier interface {
 	//  DELETE FROM permissions
 	//  WHERE id = ?
 	DeletePermission(ctx context.Context, db DBTX, permissionID string) error
+	//DeleteRatelimitPlan
+	//
+	//  DELETE FROM ratelimit_plans
+	//  WHERE id = ?
+	DeleteRatelimitPlan(ctx context.Context, db DBTX, id string) error
+	//DeleteRatelimitPlanLimits
+	//
+	//  DELETE FROM ratelimit_plan_limits
+	//  WHERE plan_id = ?
+	DeleteRatelimitPlanLimits(ctx context.Context, db DBTX, planID string) error
 	//DeleteRoleByID
 	//
 	//  DELETE FROM roles
 	//  WHERE id = ?
 	DeleteRoleByID(ctx context.Context, db DBTX, roleID string) error
+	//DeleteWebhookDeliveriesByEndpointID
+	//
+	//  DELETE FROM webhook_deliveries WHERE endpoint_id = ?
+	DeleteWebhookDeliveriesByEndpointID(ctx context.Context, db DBTX, endpointID string) error
+	//DeleteWebhookEndpoint
+	//
+	//  DELETE FROM webhook_endpoints WHERE id = ?
+	DeleteWebhookEndpoint(ctx context.Context, db DBTX, id string) error
 	// Removes every Stripe subscription row for a workspace. Paired with
 	// ResetWorkspaceBilling by the `unkey dev stripe reset` tooling.
 	//
@@ -163,10 +249,25 @@ type Querier interface {
 	//    AND access_token_hash IS NULL
 	//    AND exchange_code_expires_at > ?
 	ExchangePortalSessionCode(ctx context.Context, db DBTX, arg ExchangePortalSessionCodeParams) (sql.Result, error)
+	// Returns the active volume mounted at a path in an app's environment. Two
+	// volumes at one path would make every deployment's pod spec invalid.
+	//
+	//  SELECT pk, id, workspace_id, project_id, app_id, environment_id, name, mount_path, size_mib, k8s_name, status, released_at, created_at, updated_at
+	//  FROM `volumes`
+	//  WHERE app_id = ?
+	//    AND environment_id = ?
+	//    AND mount_path = ?
+	//    AND status = 'active'
+	//  LIMIT 1
+	FindActiveVolumeByMountPath(ctx context.Context, db DBTX, arg FindActiveVolumeByMountPathParams) (Volume, error)
 	//FindApiByID
 	//
 	//  SELECT pk, id, name, workspace_id, project_id, ip_whitelist, auth_type, key_auth_id, created_at_m, updated_at_m, deleted_at_m, delete_protection FROM apis WHERE id = ?
 	FindApiByID(ctx context.Context, db DBTX, id string) (Api, error)
+	//FindApiJwtConfigByApiID
+	//
+	//  SELECT pk, api_id, workspace_id, issuer, audience, jwks_url, public_key, identity_claim, permissions_claim, created_at_m, updated_at_m FROM api_jwt_configs WHERE api_id = ?
+	FindApiJwtConfigByApiID(ctx context.Context, db DBTX, apiID string) (ApiJwtConfig, error)
 	// Maps keyspace ids back to the api that owns them, scoped to a workspace.
 	// apis.key_auth_id is unique, so each keyspace resolves to at most one api.
 	//
@@ -247,7 +348,8 @@ type Querier interface {
 	//  	hap.replicas_min AS autoscaling_replicas_min,
 	//  	hap.replicas_max AS autoscaling_replicas_max,
 	//  	hap.cpu_threshold AS autoscaling_threshold_cpu,
-	//  	hap.memory_threshold AS autoscaling_threshold_memory
+	//  	hap.memory_threshold AS autoscaling_threshold_memory,
+	//  	hap.rps_threshold AS autoscaling_threshold_rps
 	//  FROM app_regional_settings ars
 	//  JOIN regions r ON ars.region_id = r.id
 	//  LEFT JOIN horizontal_autoscaling_policies hap ON hap.id = ars.horizontal_autoscaling_policy_id
@@ -404,6 +506,13 @@ type Querier interface {
 	//  FROM github_repo_connections
 	//  WHERE app_id = ?
 	FindGithubRepoConnectionByAppId(ctx context.Context, db DBTX, appID string) (GithubRepoConnection, error)
+	//FindIdempotencyKey
+	//
+	//  SELECT pk, workspace_id, subject_id, idempotency_key, fingerprint, response_status, response_content_type, response_body, created_at_m, expires_at_m FROM idempotency_keys
+	//  WHERE workspace_id = ?
+	//    AND subject_id = ?
+	//    AND idempotency_key = ?
+	FindIdempotencyKey(ctx context.Context, db DBTX, arg FindIdempotencyKeyParams) (IdempotencyKey, error)
 	//FindIdentitiesByExternalId
 	//
 	//  SELECT pk, id, external_id, workspace_id, project_id, environment, meta, deleted, created_at, updated_at
@@ -459,6 +568,10 @@ type Querier interface {
 	//    AND id = ?
 	//    AND deleted = ?
 	FindIdentityByID(ctx context.Context, db DBTX, arg FindIdentityByIDParams) (Identity, error)
+	//FindIdentityCredits
+	//
+	//  SELECT pk, identity_id, workspace_id, remaining, refill_day, refill_amount, last_refill_at, created_at_m, updated_at_m FROM `identity_credits` WHERE identity_id = ?
+	FindIdentityCredits(ctx context.Context, db DBTX, identityID string) (IdentityCredit, error)
 	//FindKeyAuthsByIds
 	//
 	//  SELECT ka.id as key_auth_id, a.id as api_id
@@ -477,6 +590,10 @@ type Querier interface {
 	//    AND id IN (/*SLICE:key_auth_ids*/?)
 	//    AND deleted_at_m IS NULL
 	FindKeyAuthsByIdsAndWorkspace(ctx context.Context, db DBTX, arg FindKeyAuthsByIdsAndWorkspaceParams) ([]FindKeyAuthsByIdsAndWorkspaceRow, error)
+	//FindKeyBulkOperationByID
+	//
+	//  SELECT pk, id, workspace_id, api_id, operation, status, total, succeeded, failed, error, created_at_m, updated_at_m, completed_at_m FROM key_bulk_operations WHERE id = ?
+	FindKeyBulkOperationByID(ctx context.Context, db DBTX, id string) (KeyBulkOperation, error)
 	//FindKeyByID
 	//
 	//  SELECT
@@ -518,6 +635,10 @@ type Querier interface {
 	//  WHERE key_id = ?
 	//    AND role_id = ?
 	FindKeyRoleByKeyAndRoleID(ctx context.Context, db DBTX, arg FindKeyRoleByKeyAndRoleIDParams) ([]KeysRole, error)
+	//FindKeyRotationPolicyByScopeID
+	//
+	//  SELECT pk, scope_id, workspace_id, key_auth_id, interval_ms, grace_period_ms, created_at_m, updated_at_m FROM key_rotation_policies WHERE scope_id = ?
+	FindKeyRotationPolicyByScopeID(ctx context.Context, db DBTX, scopeID string) (KeyRotationPolicy, error)
 	//FindKeySpaceByID
 	//
 	//  SELECT pk, id, workspace_id, project_id, created_at_m, updated_at_m, deleted_at_m, store_encrypted_keys, default_prefix, default_bytes, size_approx, size_last_updated_at FROM `key_auth` WHERE id = ?
@@ -741,7 +862,9 @@ type Querier interface {
 	//                                         'id', ro.id,
 	//                                         'identifier', ro.identifier,
 	//                                         'limit', ro.limit,
-	//                                         'duration', ro.duration
+	//                                         'duration', ro.duration,
+	//                                         'priority', ro.priority,
+	//                                         'expires_at', ro.expires_at_m
 	//                                 )
 	//                         )
 	//                  from ratelimit_overrides ro where ns.id = ro.namespace_id AND ro.deleted_at_m IS NULL),
@@ -774,20 +897,20 @@ type Querier interface {
 	// Finds a permission record by its ID
 	// Returns: The permission record if found
 	//
-	//  SELECT pk, id, workspace_id, project_id, name, slug, description, created_at_m, updated_at_m
+	//  SELECT pk, id, workspace_id, project_id, name, slug, description, conditions, created_at_m, updated_at_m
 	//  FROM permissions
 	//  WHERE id = ?
 	//  LIMIT 1
 	FindPermissionByID(ctx context.Context, db DBTX, permissionID string) (Permission, error)
 	//FindPermissionByIdOrSlug
 	//
-	//  SELECT pk, id, workspace_id, project_id, name, slug, description, created_at_m, updated_at_m
+	//  SELECT pk, id, workspace_id, project_id, name, slug, description, conditions, created_at_m, updated_at_m
 	//  FROM permissions
 	//  WHERE workspace_id = ? AND (id = ? OR slug = ?)
 	FindPermissionByIdOrSlug(ctx context.Context, db DBTX, arg FindPermissionByIdOrSlugParams) (Permission, error)
 	//FindPermissionByNameAndWorkspaceID
 	//
-	//  SELECT pk, id, workspace_id, project_id, name, slug, description, created_at_m, updated_at_m
+	//  SELECT pk, id, workspace_id, project_id, name, slug, description, conditions, created_at_m, updated_at_m
 	//  FROM permissions
 	//  WHERE name = ?
 	//  AND workspace_id = ?
@@ -795,7 +918,7 @@ type Querier interface {
 	FindPermissionByNameAndWorkspaceID(ctx context.Context, db DBTX, arg FindPermissionByNameAndWorkspaceIDParams) (Permission, error)
 	//FindPermissionBySlugAndWorkspaceID
 	//
-	//  SELECT pk, id, workspace_id, project_id, name, slug, description, created_at_m, updated_at_m
+	//  SELECT pk, id, workspace_id, project_id, name, slug, description, conditions, created_at_m, updated_at_m
 	//  FROM permissions
 	//  WHERE slug = ?
 	//  AND workspace_id = ?
@@ -803,7 +926,7 @@ type Querier interface {
 	FindPermissionBySlugAndWorkspaceID(ctx context.Context, db DBTX, arg FindPermissionBySlugAndWorkspaceIDParams) (Permission, error)
 	//FindPermissionsBySlugs
 	//
-	//  SELECT pk, id, workspace_id, project_id, name, slug, description, created_at_m, updated_at_m FROM permissions WHERE workspace_id = ? AND slug IN (/*SLICE:slugs*/?)
+	//  SELECT pk, id, workspace_id, project_id, name, slug, description, conditions, created_at_m, updated_at_m FROM permissions WHERE workspace_id = ? AND slug IN (/*SLICE:slugs*/?)
 	FindPermissionsBySlugs(ctx context.Context, db DBTX, arg FindPermissionsBySlugsParams) ([]Permission, error)
 	//FindPermissionsBySlugsForUpdate
 	//
@@ -814,13 +937,21 @@ type Querier interface {
 	//  ORDER BY slug
 	//  FOR UPDATE
 	FindPermissionsBySlugsForUpdate(ctx context.Context, db DBTX, arg FindPermissionsBySlugsForUpdateParams) ([]FindPermissionsBySlugsForUpdateRow, error)
+	// Loads the portal a session was issued for. Portal routes read its key policy
+	// per request rather than from the session, so tightening a policy applies to
+	// sessions that are already live.
+	//
+	//  SELECT pk, id, workspace_id, slug, app_id, key_auth_id, enabled, logo_url, primary_color, max_keys, allowed_permissions, allowed_roles, ratelimit_templates, min_expiry_ms, max_expiry_ms, created_at, updated_at FROM portals
+	//  WHERE id = ?
+	//    AND workspace_id = ?
+	FindPortalByID(ctx context.Context, db DBTX, arg FindPortalByIDParams) (Portal, error)
 	// Resolves a portal within a workspace by either its id or its slug, matching
 	// how projects/apps/environments accept a ResourceIdentifier.
 	//
 	// UNION ALL of two index seeks instead of `id = ? OR slug = ?`, which would
 	// force a scan: `portals_id_unique` and `idx_workspace_slug` each serve one arm.
 	//
-	//  SELECT p.pk, p.id, p.workspace_id, p.slug, p.app_id, p.key_auth_id, p.enabled, p.logo_url, p.primary_color, p.created_at, p.updated_at
+	//  SELECT p.pk, p.id, p.workspace_id, p.slug, p.app_id, p.key_auth_id, p.enabled, p.logo_url, p.primary_color, p.max_keys, p.allowed_permissions, p.allowed_roles, p.ratelimit_templates, p.min_expiry_ms, p.max_expiry_ms, p.created_at, p.updated_at
 	//  FROM portals p
 	//  JOIN (
 	//      SELECT p1.id
@@ -896,7 +1027,9 @@ type Querier interface {
 	//                                         'id', ro.id,
 	//                                         'identifier', ro.identifier,
 	//                                         'limit', ro.limit,
-	//                                         'duration', ro.duration
+	//                                         'duration', ro.duration,
+	//                                         'priority', ro.priority,
+	//                                         'expires_at', ro.expires_at_m
 	//                                 )
 	//                         )
 	//                  from ratelimit_overrides ro where ns.id = ro.namespace_id AND ro.deleted_at_m IS NULL),
@@ -914,19 +1047,37 @@ type Querier interface {
 	FindRatelimitNamespaceByName(ctx context.Context, db DBTX, arg FindRatelimitNamespaceByNameParams) (RatelimitNamespace, error)
 	//FindRatelimitOverrideByID
 	//
-	//  SELECT pk, id, workspace_id, namespace_id, identifier, `limit`, duration, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
+	//  SELECT pk, id, workspace_id, namespace_id, identifier, `limit`, duration, priority, expires_at_m, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
 	//  WHERE
 	//      workspace_id = ?
 	//      AND id = ?
 	FindRatelimitOverrideByID(ctx context.Context, db DBTX, arg FindRatelimitOverrideByIDParams) (RatelimitOverride, error)
 	//FindRatelimitOverrideByIdentifier
 	//
-	//  SELECT pk, id, workspace_id, namespace_id, identifier, `limit`, duration, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
+	//  SELECT pk, id, workspace_id, namespace_id, identifier, `limit`, duration, priority, expires_at_m, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
 	//  WHERE
 	//      workspace_id = ?
 	//      AND namespace_id = ?
 	//      AND identifier = ?
 	FindRatelimitOverrideByIdentifier(ctx context.Context, db DBTX, arg FindRatelimitOverrideByIdentifierParams) (RatelimitOverride, error)
+	//FindRatelimitPlan
+	//
+	//  SELECT pk, id, workspace_id, name, created_at_m, updated_at_m,
+	//         coalesce(
+	//                 (select json_arrayagg(
+	//                                 json_object(
+	//                                         'namespace_id', pl.namespace_id,
+	//                                         'limit', pl.limit,
+	//                                         'duration', pl.duration
+	//                                 )
+	//                         )
+	//                  from ratelimit_plan_limits pl where pl.plan_id = p.id),
+	//                 json_array()
+	//         ) as limits
+	//  FROM `ratelimit_plans` p
+	//  WHERE p.workspace_id = ?
+	//  AND (p.id = ? OR p.name = ?)
+	FindRatelimitPlan(ctx context.Context, db DBTX, arg FindRatelimitPlanParams) (FindRatelimitPlanRow, error)
 	//FindRegionByPlatformAndName
 	//
 	//  SELECT
@@ -992,6 +1143,28 @@ type Querier interface {
 	//  ORDER BY created_at ASC, id ASC
 	//  LIMIT 1
 	FindVerifiedCustomDomainByAppID(ctx context.Context, db DBTX, appID string) (CustomDomain, error)
+	// Returns the volume holding a name in an app's environment, whatever its
+	// status. Names are unique per app and environment, so a released or purged
+	// volume still holds its name until it is reactivated or retired.
+	//
+	//  SELECT pk, id, workspace_id, project_id, app_id, environment_id, name, mount_path, size_mib, k8s_name, status, released_at, created_at, updated_at
+	//  FROM `volumes`
+	//  WHERE app_id = ?
+	//    AND environment_id = ?
+	//    AND name = ?
+	FindVolumeByAppEnvironmentAndName(ctx context.Context, db DBTX, arg FindVolumeByAppEnvironmentAndNameParams) (Volume, error)
+	//FindWebhookDeliveryByID
+	//
+	//  SELECT pk, id, workspace_id, endpoint_id, event_id, event_type, payload, status, attempts, response_status, last_error, next_attempt_at, delivered_at, created_at, updated_at FROM webhook_deliveries
+	//  WHERE workspace_id = ?
+	//    AND id = ?
+	FindWebhookDeliveryByID(ctx context.Context, db DBTX, arg FindWebhookDeliveryByIDParams) (WebhookDelivery, error)
+	//FindWebhookEndpointByID
+	//
+	//  SELECT pk, id, workspace_id, url, description, event_types, encrypted_secret, encryption_key_id, enabled, created_at, updated_at FROM webhook_endpoints
+	//  WHERE workspace_id = ?
+	//    AND id = ?
+	FindWebhookEndpointByID(ctx context.Context, db DBTX, arg FindWebhookEndpointByIDParams) (WebhookEndpoint, error)
 	// Reads a workspace's billing row directly (Stripe linkage, tier, Compute plan,
 	// spend budget and spend-cap state). Use this when only billing state is needed;
 	// when a workspace is already being fetched, prefer joining workspace_billing in
@@ -1286,6 +1459,7 @@ type Querier interface {
 	//      autoscaling_replicas_max,
 	//      autoscaling_threshold_cpu,
 	//      autoscaling_threshold_memory,
+	//      autoscaling_threshold_rps,
 	//      desired_status,
 	//      created_at
 	//  ) VALUES (
@@ -1297,6 +1471,7 @@ type Querier interface {
 	//      ?,
 	//      ?,
 	//      ?,
+	//      ?,
 	//      ?
 	//  )
 	InsertDeploymentTopology(ctx context.Context, db DBTX, arg InsertDeploymentTopologyParams) error
@@ -1382,6 +1557,27 @@ type Querier interface {
 	//      ?
 	//  )
 	InsertHorizontalAutoscalingPolicy(ctx context.Context, db DBTX, arg InsertHorizontalAutoscalingPolicyParams) error
+	// Reserves an idempotency key for an in-flight request. The UNIQUE index on
+	// (workspace_id, subject_id, idempotency_key) makes concurrent reservations
+	// race on a single row; the loser gets a duplicate key error and reads the
+	// winner's row.
+	//
+	//  INSERT INTO idempotency_keys (
+	//      workspace_id,
+	//      subject_id,
+	//      idempotency_key,
+	//      fingerprint,
+	//      created_at_m,
+	//      expires_at_m
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?
+	//  )
+	InsertIdempotencyKey(ctx context.Context, db DBTX, arg InsertIdempotencyKeyParams) error
 	//InsertIdentity
 	//
 	//  INSERT INTO `identities` (
@@ -1447,7 +1643,8 @@ type Querier interface {
 	//      remaining_requests,
 	//      refill_day,
 	//      refill_amount,
-	//      pending_migration_id
+	//      pending_migration_id,
+	//      environment
 	//  ) VALUES (
 	//      ?,
 	//      ?,
@@ -1464,9 +1661,42 @@ type Querier interface {
 	//      ?,
 	//      ?,
 	//      ?,
+	//      ?,
 	//      ?
 	//  )
 	InsertKey(ctx context.Context, db DBTX, arg InsertKeyParams) error
+	//InsertKeyBulkOperation
+	//
+	//  INSERT INTO key_bulk_operations (
+	//      id,
+	//      workspace_id,
+	//      api_id,
+	//      operation,
+	//      status,
+	//      total,
+	//      created_at_m
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      'pending',
+	//      ?,
+	//      ?
+	//  )
+	InsertKeyBulkOperation(ctx context.Context, db DBTX, arg InsertKeyBulkOperationParams) error
+	//InsertKeyBulkOperationItem
+	//
+	//  INSERT INTO key_bulk_operation_items (
+	//      operation_id,
+	//      key_id,
+	//      status
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      'pending'
+	//  )
+	InsertKeyBulkOperationItem(ctx context.Context, db DBTX, arg InsertKeyBulkOperationItemParams) error
 	//InsertKeyEncryption
 	//
 	//  INSERT INTO encrypted_keys
@@ -1540,6 +1770,24 @@ type Querier interface {
 	//    ?
 	//  )
 	InsertKeyRole(ctx context.Context, db DBTX, arg InsertKeyRoleParams) error
+	//InsertKeyRotation
+	//
+	//  INSERT INTO key_rotations (
+	//      key_id,
+	//      successor_key_id,
+	//      workspace_id,
+	//      key_auth_id,
+	//      reason,
+	//      created_at_m
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?
+	//  )
+	InsertKeyRotation(ctx context.Context, db DBTX, arg InsertKeyRotationParams) error
 	//InsertKeySpace
 	//
 	//  INSERT INTO `key_auth` (
@@ -1564,6 +1812,24 @@ type Querier interface {
 	//      0
 	//  )
 	InsertKeySpace(ctx context.Context, db DBTX, arg InsertKeySpaceParams) error
+	//InsertKeySpaceEnvironment
+	//
+	//  INSERT INTO key_space_environments (
+	//      workspace_id,
+	//      key_auth_id,
+	//      name,
+	//      prefix,
+	//      test_mode,
+	//      created_at_m
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?
+	//  )
+	InsertKeySpaceEnvironment(ctx context.Context, db DBTX, arg InsertKeySpaceEnvironmentParams) error
 	//InsertPermission
 	//
 	//  INSERT INTO permissions (
@@ -1685,6 +1951,8 @@ type Querier interface {
 	//      identifier,
 	//      `limit`,
 	//      duration,
+	//      priority,
+	//      expires_at_m,
 	//      created_at_m
 	//  )
 	//  VALUES (
@@ -1694,13 +1962,53 @@ type Querier interface {
 	//      ?,
 	//      ?,
 	//      ?,
+	//      ?,
+	//      ?,
 	//      ?
 	//  )
 	//  ON DUPLICATE KEY UPDATE
 	//      `limit` = VALUES(`limit`),
 	//      duration = VALUES(duration),
+	//      priority = VALUES(priority),
+	//      expires_at_m = VALUES(expires_at_m),
 	//      updated_at_m = ?
 	InsertRatelimitOverride(ctx context.Context, db DBTX, arg InsertRatelimitOverrideParams) error
+	//InsertRatelimitPlan
+	//
+	//  INSERT INTO ratelimit_plans (
+	//      id,
+	//      workspace_id,
+	//      name,
+	//      created_at_m
+	//  )
+	//  VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?
+	//  )
+	//  ON DUPLICATE KEY UPDATE
+	//      updated_at_m = ?
+	InsertRatelimitPlan(ctx context.Context, db DBTX, arg InsertRatelimitPlanParams) error
+	//InsertRatelimitPlanLimit
+	//
+	//  INSERT INTO ratelimit_plan_limits (
+	//      plan_id,
+	//      workspace_id,
+	//      namespace_id,
+	//      `limit`,
+	//      duration,
+	//      created_at_m
+	//  )
+	//  VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?
+	//  )
+	InsertRatelimitPlanLimit(ctx context.Context, db DBTX, arg InsertRatelimitPlanLimitParams) error
 	//InsertRole
 	//
 	//  INSERT INTO roles (
@@ -1735,6 +2043,82 @@ type Querier interface {
 	//    ?
 	//  )
 	InsertRolePermission(ctx context.Context, db DBTX, arg InsertRolePermissionParams) error
+	//InsertVolume
+	//
+	//  INSERT INTO `volumes` (
+	//      id,
+	//      workspace_id,
+	//      project_id,
+	//      app_id,
+	//      environment_id,
+	//      name,
+	//      mount_path,
+	//      size_mib,
+	//      k8s_name,
+	//      status,
+	//      created_at
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?
+	//  )
+	InsertVolume(ctx context.Context, db DBTX, arg InsertVolumeParams) error
+	//InsertWebhookDelivery
+	//
+	//  INSERT INTO webhook_deliveries (
+	//      id,
+	//      workspace_id,
+	//      endpoint_id,
+	//      event_id,
+	//      event_type,
+	//      payload,
+	//      status,
+	//      attempts,
+	//      created_at
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      'pending',
+	//      0,
+	//      ?
+	//  )
+	InsertWebhookDelivery(ctx context.Context, db DBTX, arg InsertWebhookDeliveryParams) error
+	//InsertWebhookEndpoint
+	//
+	//  INSERT INTO webhook_endpoints (
+	//      id,
+	//      workspace_id,
+	//      url,
+	//      description,
+	//      event_types,
+	//      encrypted_secret,
+	//      encryption_key_id,
+	//      enabled,
+	//      created_at
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?
+	//  )
+	InsertWebhookEndpoint(ctx context.Context, db DBTX, arg InsertWebhookEndpointParams) error
 	//InsertWorkspace
 	//
 	//  INSERT INTO `workspaces` (
@@ -1933,7 +2317,7 @@ type Querier interface {
 	ListDeployments(ctx context.Context, db DBTX, arg ListDeploymentsParams) ([]Deployment, error)
 	//ListDirectPermissionsByKeyID
 	//
-	//  SELECT p.pk, p.id, p.workspace_id, p.project_id, p.name, p.slug, p.description, p.created_at_m, p.updated_at_m
+	//  SELECT p.pk, p.id, p.workspace_id, p.project_id, p.name, p.slug, p.description, p.conditions, p.created_at_m, p.updated_at_m
 	//  FROM keys_permissions kp
 	//  JOIN permissions p ON kp.permission_id = p.id
 	//  WHERE kp.key_id = ?
@@ -2012,6 +2396,29 @@ type Querier interface {
 	//
 	//  SELECT pk, id, name, workspace_id, created_at, updated_at, key_id, identity_id, `limit`, duration, auto_apply FROM ratelimits WHERE identity_id = ?
 	ListIdentityRatelimitsByID(ctx context.Context, db DBTX, identityID sql.NullString) ([]Ratelimit, error)
+	//ListKeyBulkOperationItems
+	//
+	//  SELECT pk, key_id, status, error
+	//  FROM key_bulk_operation_items
+	//  WHERE operation_id = ?
+	//    AND pk >= ?
+	//  ORDER BY pk
+	//  LIMIT ?
+	ListKeyBulkOperationItems(ctx context.Context, db DBTX, arg ListKeyBulkOperationItemsParams) ([]ListKeyBulkOperationItemsRow, error)
+	//ListKeySpaceEnvironments
+	//
+	//  SELECT pk, workspace_id, key_auth_id, name, prefix, test_mode, created_at_m, updated_at_m FROM key_space_environments WHERE key_auth_id = ? ORDER BY name
+	ListKeySpaceEnvironments(ctx context.Context, db DBTX, keyAuthID string) ([]KeySpaceEnvironment, error)
+	// Lists the live keys of a keyspace that belong to any of the given
+	// environments. Used to invalidate their cached verifications when an
+	// environment's test mode changes.
+	//
+	//  SELECT id, hash
+	//  FROM `keys`
+	//  WHERE key_auth_id = ?
+	//    AND environment IN (/*SLICE:environments*/?)
+	//    AND deleted_at_m IS NULL
+	ListLiveKeyHashesByKeySpaceEnvironments(ctx context.Context, db DBTX, arg ListLiveKeyHashesByKeySpaceEnvironmentsParams) ([]ListLiveKeyHashesByKeySpaceEnvironmentsRow, error)
 	//ListLiveKeysByKeySpaceID
 	//
 	//  SELECT k.pk, k.id, k.key_auth_id, k.hash, k.start, k.workspace_id, k.for_workspace_id,
@@ -2204,7 +2611,7 @@ type Querier interface {
 	ListLiveKeysByKeySpaceIDs(ctx context.Context, db DBTX, arg ListLiveKeysByKeySpaceIDsParams) ([]ListLiveKeysByKeySpaceIDsRow, error)
 	//ListPermissions
 	//
-	//  SELECT p.pk, p.id, p.workspace_id, p.project_id, p.name, p.slug, p.description, p.created_at_m, p.updated_at_m
+	//  SELECT p.pk, p.id, p.workspace_id, p.project_id, p.name, p.slug, p.description, p.conditions, p.created_at_m, p.updated_at_m
 	//  FROM permissions p
 	//  WHERE p.workspace_id = ?
 	//    AND p.id >= ?
@@ -2260,7 +2667,7 @@ type Querier interface {
 	ListProjectsByWorkspaceId(ctx context.Context, db DBTX, arg ListProjectsByWorkspaceIdParams) ([]ListProjectsByWorkspaceIdRow, error)
 	//ListRatelimitOverridesByNamespaceID
 	//
-	//  SELECT pk, id, workspace_id, namespace_id, identifier, `limit`, duration, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
+	//  SELECT pk, id, workspace_id, namespace_id, identifier, `limit`, duration, priority, expires_at_m, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
 	//  WHERE
 	//  workspace_id = ?
 	//  AND namespace_id = ?
@@ -2330,6 +2737,24 @@ type Querier interface {
 	//  WHERE kr.key_id = ?
 	//  ORDER BY r.name
 	ListRolesByKeyID(ctx context.Context, db DBTX, keyID string) ([]ListRolesByKeyIDRow, error)
+	// Delivery ids are random, so the log pages newest first by pk and the
+	// cursor is the pk of the first row of the next page.
+	//
+	//  SELECT pk, id, workspace_id, endpoint_id, event_id, event_type, payload, status, attempts, response_status, last_error, next_attempt_at, delivered_at, created_at, updated_at FROM webhook_deliveries
+	//  WHERE workspace_id = ?
+	//    AND endpoint_id = ?
+	//    AND pk <= ?
+	//  ORDER BY pk DESC
+	//  LIMIT ?
+	ListWebhookDeliveriesByEndpointID(ctx context.Context, db DBTX, arg ListWebhookDeliveriesByEndpointIDParams) ([]WebhookDelivery, error)
+	//ListWebhookEndpointsByWorkspace
+	//
+	//  SELECT pk, id, workspace_id, url, description, event_types, encrypted_secret, encryption_key_id, enabled, created_at, updated_at FROM webhook_endpoints
+	//  WHERE workspace_id = ?
+	//    AND id >= ?
+	//  ORDER BY id ASC
+	//  LIMIT ?
+	ListWebhookEndpointsByWorkspace(ctx context.Context, db DBTX, arg ListWebhookEndpointsByWorkspaceParams) ([]WebhookEndpoint, error)
 	// Fetches the Stripe customer identity for a batch of workspaces, used by the
 	// hourly Deploy billing push to decide where each workspace's month-to-date
 	// usage gets reported. The Stripe Billing Meters map usage to a customer by
@@ -2383,6 +2808,39 @@ type Querier interface {
 	//  WHERE id = ? AND workspace_id = ?
 	//  FOR UPDATE
 	LockRoleByIDAndWorkspaceID(ctx context.Context, db DBTX, arg LockRoleByIDAndWorkspaceIDParams) (LockRoleByIDAndWorkspaceIDRow, error)
+	// MarkKeyBulkOperationFailed ends an operation that never reached the worker.
+	//
+	//  UPDATE key_bulk_operations
+	//  SET status = 'failed',
+	//      error = ?,
+	//      updated_at_m = ?,
+	//      completed_at_m = ?
+	//  WHERE id = ?
+	MarkKeyBulkOperationFailed(ctx context.Context, db DBTX, arg MarkKeyBulkOperationFailedParams) error
+	// Returns a released volume to active so deployments mount it again with its
+	// data intact. Gated on status so a volume PurgeVolumes already claimed is
+	// never revived; callers must check the affected row count.
+	//
+	//  UPDATE `volumes`
+	//  SET status = 'active',
+	//      released_at = NULL,
+	//      mount_path = ?,
+	//      size_mib = ?,
+	//      updated_at = ?
+	//  WHERE id = ?
+	//    AND status = 'released'
+	ReactivateVolume(ctx context.Context, db DBTX, arg ReactivateVolumeParams) (int64, error)
+	// Drops the reservation of a request that failed, so a retry with the same
+	// key runs again instead of waiting for the window to pass. Completed
+	// reservations are never released.
+	//
+	//  DELETE FROM idempotency_keys
+	//  WHERE workspace_id = ?
+	//    AND subject_id = ?
+	//    AND idempotency_key = ?
+	//    AND fingerprint = ?
+	//    AND response_status IS NULL
+	ReleaseIdempotencyKey(ctx context.Context, db DBTX, arg ReleaseIdempotencyKeyParams) error
 	// Clears the workspace_billing linkage on a workspace, returning it to the
 	// Free tier. Mirrors what the customer.subscription.deleted webhook writes,
 	// plus stripe_customer_id, which no webhook ever clears. Stripe subscription
@@ -2427,6 +2885,17 @@ type Querier interface {
 	//      AND (e.id = ? OR e.slug = ?)
 	//  LIMIT 1
 	ResolveDeploymentScope(ctx context.Context, db DBTX, arg ResolveDeploymentScopeParams) (ResolveDeploymentScopeRow, error)
+	// Frees the name of a purged volume by renaming it to its id, so a new volume
+	// can take the name. The purged row is kept, since a pending deployment change
+	// still resolves it by id to delete its claim. Ids never match the name
+	// pattern, so the renamed row cannot collide with a real name.
+	//
+	//  UPDATE `volumes`
+	//  SET name = id,
+	//      updated_at = ?
+	//  WHERE id = ?
+	//    AND status = 'purged'
+	RetirePurgedVolumeName(ctx context.Context, db DBTX, arg RetirePurgedVolumeNameParams) (int64, error)
 	//SoftDeleteApi
 	//
 	//  UPDATE apis
@@ -2458,6 +2927,12 @@ type Querier interface {
 	//      deleted_at_m =  ?
 	//  WHERE id = ?
 	SoftDeleteRatelimitOverride(ctx context.Context, db DBTX, arg SoftDeleteRatelimitOverrideParams) error
+	//UpdateApiAuthType
+	//
+	//  UPDATE apis
+	//  SET auth_type = ?, updated_at_m = ?
+	//  WHERE id = ?
+	UpdateApiAuthType(ctx context.Context, db DBTX, arg UpdateApiAuthTypeParams) error
 	//UpdateApiDeleteProtection
 	//
 	//  UPDATE apis
@@ -2611,6 +3086,21 @@ type Querier interface {
 	//  WHERE
 	//      id = ?
 	UpdateIdentity(ctx context.Context, db DBTX, arg UpdateIdentityParams) error
+	//UpdateIdentityCreditsDecrement
+	//
+	//  UPDATE `identity_credits`
+	//  SET remaining = CASE
+	//      WHEN remaining >= ? THEN remaining - ?
+	//      ELSE 0
+	//  END
+	//  WHERE identity_id = ?
+	UpdateIdentityCreditsDecrement(ctx context.Context, db DBTX, arg UpdateIdentityCreditsDecrementParams) error
+	//UpdateIdentityCreditsIncrement
+	//
+	//  UPDATE `identity_credits`
+	//  SET remaining = remaining + ?
+	//  WHERE identity_id = ?
+	UpdateIdentityCreditsIncrement(ctx context.Context, db DBTX, arg UpdateIdentityCreditsIncrementParams) error
 	//UpdateKey
 	//
 	//  UPDATE `keys` k SET
@@ -2678,6 +3168,14 @@ type Querier interface {
 	//
 	//  UPDATE `key_auth` SET store_encrypted_keys = ? WHERE id = ?
 	UpdateKeySpaceKeyEncryption(ctx context.Context, db DBTX, arg UpdateKeySpaceKeyEncryptionParams) error
+	// Sets the conditions under which a permission applies. NULL makes the
+	// permission apply unconditionally.
+	//
+	//  UPDATE permissions
+	//  SET conditions = ?,
+	//      updated_at_m = ?
+	//  WHERE id = ?
+	UpdatePermissionConditions(ctx context.Context, db DBTX, arg UpdatePermissionConditionsParams) error
 	// Branding lives on the portal row, so the dashboard's branding form is one
 	// write against an existing portal rather than an upsert into a side table.
 	// Discrete columns rather than a JSON blob, so each field is typed and length
@@ -2690,6 +3188,21 @@ type Querier interface {
 	//  WHERE id = ?
 	//    AND workspace_id = ?
 	UpdatePortalBranding(ctx context.Context, db DBTX, arg UpdatePortalBrandingParams) error
+	// The key policy bounds what an end user may create through the portal. Like
+	// branding it lives on the portal row and is written as a whole, so a NULL
+	// column clears that part of the policy.
+	//
+	//  UPDATE portals
+	//  SET max_keys = ?,
+	//      allowed_permissions = ?,
+	//      allowed_roles = ?,
+	//      ratelimit_templates = ?,
+	//      min_expiry_ms = ?,
+	//      max_expiry_ms = ?,
+	//      updated_at = ?
+	//  WHERE id = ?
+	//    AND workspace_id = ?
+	UpdatePortalKeyPolicy(ctx context.Context, db DBTX, arg UpdatePortalKeyPolicyParams) error
 	//UpdateProject
 	//
 	//  UPDATE projects p
@@ -2722,12 +3235,54 @@ type Querier interface {
 	//  WHERE
 	//      id = ?
 	UpdateRatelimit(ctx context.Context, db DBTX, arg UpdateRatelimitParams) error
+	//UpdateWebhookEndpoint
+	//
+	//  UPDATE webhook_endpoints
+	//  SET
+	//      url = ?,
+	//      description = ?,
+	//      event_types = ?,
+	//      enabled = ?,
+	//      updated_at = ?
+	//  WHERE id = ?
+	UpdateWebhookEndpoint(ctx context.Context, db DBTX, arg UpdateWebhookEndpointParams) error
 	//UpdateWorkspaceEnabled
 	//
 	//  UPDATE `workspaces`
 	//  SET enabled = ?
 	//  WHERE id = ?
 	UpdateWorkspaceEnabled(ctx context.Context, db DBTX, arg UpdateWorkspaceEnabledParams) (sql.Result, error)
+	//UpsertApiJwtConfig
+	//
+	//  INSERT INTO api_jwt_configs (
+	//      api_id,
+	//      workspace_id,
+	//      issuer,
+	//      audience,
+	//      jwks_url,
+	//      public_key,
+	//      identity_claim,
+	//      permissions_claim,
+	//      created_at_m
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?
+	//  ) ON DUPLICATE KEY UPDATE
+	//      issuer = VALUES(issuer),
+	//      audience = VALUES(audience),
+	//      jwks_url = VALUES(jwks_url),
+	//      public_key = VALUES(public_key),
+	//      identity_claim = VALUES(identity_claim),
+	//      permissions_claim = VALUES(permissions_claim),
+	//      updated_at_m = VALUES(created_at_m)
+	UpsertApiJwtConfig(ctx context.Context, db DBTX, arg UpsertApiJwtConfigParams) error
 	//UpsertAppBuildSettings
 	//
 	//  INSERT INTO app_build_settings (
@@ -2908,6 +3463,50 @@ type Querier interface {
 	//  )
 	//  ON DUPLICATE KEY UPDATE external_id = external_id
 	UpsertIdentity(ctx context.Context, db DBTX, arg UpsertIdentityParams) error
+	// Creates the identity's credit pool or replaces its balance and refill
+	// settings.
+	//
+	//  INSERT INTO `identity_credits` (
+	//      identity_id,
+	//      workspace_id,
+	//      remaining,
+	//      refill_day,
+	//      refill_amount,
+	//      created_at_m
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?
+	//  ) ON DUPLICATE KEY UPDATE
+	//      remaining = VALUES(remaining),
+	//      refill_day = VALUES(refill_day),
+	//      refill_amount = VALUES(refill_amount),
+	//      updated_at_m = VALUES(created_at_m)
+	UpsertIdentityCredits(ctx context.Context, db DBTX, arg UpsertIdentityCreditsParams) error
+	//UpsertKeyRotationPolicy
+	//
+	//  INSERT INTO key_rotation_policies (
+	//      scope_id,
+	//      workspace_id,
+	//      key_auth_id,
+	//      interval_ms,
+	//      grace_period_ms,
+	//      created_at_m
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?
+	//  ) ON DUPLICATE KEY UPDATE
+	//      interval_ms = VALUES(interval_ms),
+	//      grace_period_ms = VALUES(grace_period_ms),
+	//      updated_at_m = VALUES(created_at_m)
+	UpsertKeyRotationPolicy(ctx context.Context, db DBTX, arg UpsertKeyRotationPolicyParams) error
 	//UpsertKeySpace
 	//
 	//  INSERT INTO key_auth (
@@ -0,0 +1,2 @@
+-- name: DeleteApiJwtConfigByApiID :exec
+DELETE FROM api_jwt_configs WHERE api_id = sqlc.arg(api_id);
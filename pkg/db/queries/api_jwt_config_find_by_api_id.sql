@@ -0,0 +1,2 @@
+-- name: FindApiJwtConfigByApiID :one
+SELECT * FROM api_jwt_configs WHERE api_id = sqlc.arg(api_id);
@@ -0,0 +1,29 @@
+-- name: UpsertApiJwtConfig :exec
+INSERT INTO api_jwt_configs (
+    api_id,
+    workspace_id,
+    issuer,
+    audience,
+    jwks_url,
+    public_key,
+    identity_claim,
+    permissions_claim,
+    created_at_m
+) VALUES (
+    sqlc.arg(api_id),
+    sqlc.arg(workspace_id),
+    sqlc.arg(issuer),
+    sqlc.arg(audience),
+    sqlc.arg(jwks_url),
+    sqlc.arg(public_key),
+    sqlc.arg(identity_claim),
+    sqlc.arg(permissions_claim),
+    sqlc.arg(created_at_m)
+) ON DUPLICATE KEY UPDATE
+    issuer = VALUES(issuer),
+    audience = VALUES(audience),
+    jwks_url = VALUES(jwks_url),
+    public_key = VALUES(public_key),
+    identity_claim = VALUES(identity_claim),
+    permissions_claim = VALUES(permissions_claim),
+    updated_at_m = VALUES(created_at_m);
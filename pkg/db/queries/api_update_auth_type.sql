@@ -0,0 +1,4 @@
+-- name: UpdateApiAuthType :exec
+UPDATE apis
+SET auth_type = sqlc.arg(auth_type), updated_at_m = sqlc.arg(updated_at_m)
+WHERE id = sqlc.arg(api_id);
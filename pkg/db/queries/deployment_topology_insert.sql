@@ -7,6 +7,7 @@ INSERT INTO `deployment_topology` (
     autoscaling_replicas_max,
     autoscaling_threshold_cpu,
     autoscaling_threshold_memory,
+    autoscaling_threshold_rps,
     desired_status,
     created_at
 ) VALUES (
@@ -17,6 +18,7 @@ INSERT INTO `deployment_topology` (
     sqlc.arg(autoscaling_replicas_max),
     sqlc.arg(autoscaling_threshold_cpu),
     sqlc.arg(autoscaling_threshold_memory),
+    sqlc.arg(autoscaling_threshold_rps),
     sqlc.arg(desired_status),
     sqlc.arg(created_at)
 );
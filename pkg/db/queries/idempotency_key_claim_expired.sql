@@ -0,0 +1,16 @@
+-- name: ClaimExpiredIdempotencyKey :execrows
+-- Takes over a reservation whose window has passed but that the cleanup cron
+-- has not deleted yet, discarding the stored response. Callers decide via the
+-- affected rows; zero means the key is still live, or another request claimed
+-- it first.
+UPDATE idempotency_keys
+SET fingerprint = sqlc.arg(fingerprint),
+    response_status = NULL,
+    response_content_type = NULL,
+    response_body = NULL,
+    created_at_m = sqlc.arg(created_at_m),
+    expires_at_m = sqlc.arg(expires_at_m)
+WHERE workspace_id = sqlc.arg(workspace_id)
+  AND subject_id = sqlc.arg(subject_id)
+  AND idempotency_key = sqlc.arg(idempotency_key)
+  AND expires_at_m <= sqlc.arg(created_at_m);
@@ -0,0 +1,12 @@
+-- name: CompleteIdempotencyKey :exec
+-- Stores the response of the request that reserved the key, so retries
+-- replay it.
+UPDATE idempotency_keys
+SET response_status = sqlc.arg(response_status),
+    response_content_type = sqlc.arg(response_content_type),
+    response_body = sqlc.arg(response_body)
+WHERE workspace_id = sqlc.arg(workspace_id)
+  AND subject_id = sqlc.arg(subject_id)
+  AND idempotency_key = sqlc.arg(idempotency_key)
+  AND fingerprint = sqlc.arg(fingerprint)
+  AND response_status IS NULL;
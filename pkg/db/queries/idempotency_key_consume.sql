@@ -0,0 +1,12 @@
+-- name: ConsumeIdempotencyKey :execrows
+-- Clears the stored body of a response that carries a secret once it has been
+-- replayed. Callers replay the body only when a row was affected, so
+-- concurrent retries cannot both receive the secret.
+UPDATE idempotency_keys
+SET response_body = NULL
+WHERE workspace_id = sqlc.arg(workspace_id)
+  AND subject_id = sqlc.arg(subject_id)
+  AND idempotency_key = sqlc.arg(idempotency_key)
+  AND fingerprint = sqlc.arg(fingerprint)
+  AND response_status IS NOT NULL
+  AND response_body IS NOT NULL;
@@ -0,0 +1,5 @@
+-- name: FindIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE workspace_id = sqlc.arg(workspace_id)
+  AND subject_id = sqlc.arg(subject_id)
+  AND idempotency_key = sqlc.arg(idempotency_key);
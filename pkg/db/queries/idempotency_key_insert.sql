@@ -0,0 +1,20 @@
+-- name: InsertIdempotencyKey :exec
+-- Reserves an idempotency key for an in-flight request. The UNIQUE index on
+-- (workspace_id, subject_id, idempotency_key) makes concurrent reservations
+-- race on a single row; the loser gets a duplicate key error and reads the
+-- winner's row.
+INSERT INTO idempotency_keys (
+    workspace_id,
+    subject_id,
+    idempotency_key,
+    fingerprint,
+    created_at_m,
+    expires_at_m
+) VALUES (
+    sqlc.arg(workspace_id),
+    sqlc.arg(subject_id),
+    sqlc.arg(idempotency_key),
+    sqlc.arg(fingerprint),
+    sqlc.arg(created_at_m),
+    sqlc.arg(expires_at_m)
+);
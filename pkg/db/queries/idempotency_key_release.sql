@@ -0,0 +1,10 @@
+-- name: ReleaseIdempotencyKey :exec
+-- Drops the reservation of a request that failed, so a retry with the same
+-- key runs again instead of waiting for the window to pass. Completed
+-- reservations are never released.
+DELETE FROM idempotency_keys
+WHERE workspace_id = sqlc.arg(workspace_id)
+  AND subject_id = sqlc.arg(subject_id)
+  AND idempotency_key = sqlc.arg(idempotency_key)
+  AND fingerprint = sqlc.arg(fingerprint)
+  AND response_status IS NULL;
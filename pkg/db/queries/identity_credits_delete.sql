@@ -0,0 +1,2 @@
+-- name: DeleteIdentityCredits :exec
+DELETE FROM `identity_credits` WHERE identity_id = sqlc.arg('identity_id');
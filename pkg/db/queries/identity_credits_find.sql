@@ -0,0 +1,2 @@
+-- name: FindIdentityCredits :one
+SELECT * FROM `identity_credits` WHERE identity_id = sqlc.arg('identity_id');
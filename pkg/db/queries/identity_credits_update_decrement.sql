@@ -0,0 +1,7 @@
+-- name: UpdateIdentityCreditsDecrement :exec
+UPDATE `identity_credits`
+SET remaining = CASE
+    WHEN remaining >= sqlc.arg('credits') THEN remaining - sqlc.arg('credits')
+    ELSE 0
+END
+WHERE identity_id = sqlc.arg('identity_id');
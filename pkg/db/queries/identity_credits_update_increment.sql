@@ -0,0 +1,4 @@
+-- name: UpdateIdentityCreditsIncrement :exec
+UPDATE `identity_credits`
+SET remaining = remaining + sqlc.arg('credits')
+WHERE identity_id = sqlc.arg('identity_id');
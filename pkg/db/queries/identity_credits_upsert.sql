@@ -0,0 +1,22 @@
+-- name: UpsertIdentityCredits :exec
+-- Creates the identity's credit pool or replaces its balance and refill
+-- settings.
+INSERT INTO `identity_credits` (
+    identity_id,
+    workspace_id,
+    remaining,
+    refill_day,
+    refill_amount,
+    created_at_m
+) VALUES (
+    sqlc.arg('identity_id'),
+    sqlc.arg('workspace_id'),
+    sqlc.arg('remaining'),
+    sqlc.arg('refill_day'),
+    sqlc.arg('refill_amount'),
+    sqlc.arg('now')
+) ON DUPLICATE KEY UPDATE
+    remaining = VALUES(remaining),
+    refill_day = VALUES(refill_day),
+    refill_amount = VALUES(refill_amount),
+    updated_at_m = VALUES(created_at_m);
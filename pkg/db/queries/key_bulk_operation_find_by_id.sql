@@ -0,0 +1,2 @@
+-- name: FindKeyBulkOperationByID :one
+SELECT * FROM key_bulk_operations WHERE id = sqlc.arg(id);
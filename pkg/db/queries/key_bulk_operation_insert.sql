@@ -0,0 +1,18 @@
+-- name: InsertKeyBulkOperation :exec
+INSERT INTO key_bulk_operations (
+    id,
+    workspace_id,
+    api_id,
+    operation,
+    status,
+    total,
+    created_at_m
+) VALUES (
+    sqlc.arg(id),
+    sqlc.arg(workspace_id),
+    sqlc.arg(api_id),
+    sqlc.arg(operation),
+    'pending',
+    sqlc.arg(total),
+    sqlc.arg(created_at_m)
+);
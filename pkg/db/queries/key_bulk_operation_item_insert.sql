@@ -0,0 +1,10 @@
+-- name: InsertKeyBulkOperationItem :exec
+INSERT INTO key_bulk_operation_items (
+    operation_id,
+    key_id,
+    status
+) VALUES (
+    sqlc.arg(operation_id),
+    sqlc.arg(key_id),
+    'pending'
+);
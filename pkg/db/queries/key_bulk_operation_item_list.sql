@@ -0,0 +1,7 @@
+-- name: ListKeyBulkOperationItems :many
+SELECT pk, key_id, status, error
+FROM key_bulk_operation_items
+WHERE operation_id = sqlc.arg(operation_id)
+  AND pk >= sqlc.arg(pk_cursor)
+ORDER BY pk
+LIMIT ?;
@@ -0,0 +1,8 @@
+-- name: MarkKeyBulkOperationFailed :exec
+-- MarkKeyBulkOperationFailed ends an operation that never reached the worker.
+UPDATE key_bulk_operations
+SET status = 'failed',
+    error = sqlc.arg(error),
+    updated_at_m = sqlc.arg(now),
+    completed_at_m = sqlc.arg(now)
+WHERE id = sqlc.arg(id);
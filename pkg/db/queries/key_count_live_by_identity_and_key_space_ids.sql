@@ -0,0 +1,8 @@
+-- name: CountLiveKeysByIdentityAndKeySpaceIDs :one
+-- Counts an identity's live keys across a set of keyspaces. Used by
+-- portal.createKey to enforce the portal's max_keys.
+SELECT COUNT(*)
+FROM `keys` k
+WHERE k.identity_id = sqlc.arg(identity_id)
+  AND k.key_auth_id IN (sqlc.slice(key_space_ids))
+  AND k.deleted_at_m IS NULL;
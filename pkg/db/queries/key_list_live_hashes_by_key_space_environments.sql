@@ -0,0 +1,9 @@
+-- name: ListLiveKeyHashesByKeySpaceEnvironments :many
+-- Lists the live keys of a keyspace that belong to any of the given
+-- environments. Used to invalidate their cached verifications when an
+-- environment's test mode changes.
+SELECT id, hash
+FROM `keys`
+WHERE key_auth_id = sqlc.arg(key_auth_id)
+  AND environment IN (sqlc.slice(environments))
+  AND deleted_at_m IS NULL;
@@ -0,0 +1,16 @@
+-- name: InsertKeyRotation :exec
+INSERT INTO key_rotations (
+    key_id,
+    successor_key_id,
+    workspace_id,
+    key_auth_id,
+    reason,
+    created_at_m
+) VALUES (
+    sqlc.arg(key_id),
+    sqlc.arg(successor_key_id),
+    sqlc.arg(workspace_id),
+    sqlc.arg(key_auth_id),
+    sqlc.arg(reason),
+    sqlc.arg(created_at_m)
+);
@@ -0,0 +1,2 @@
+-- name: FindKeyRotationPolicyByScopeID :one
+SELECT * FROM key_rotation_policies WHERE scope_id = sqlc.arg(scope_id);
@@ -0,0 +1,19 @@
+-- name: UpsertKeyRotationPolicy :exec
+INSERT INTO key_rotation_policies (
+    scope_id,
+    workspace_id,
+    key_auth_id,
+    interval_ms,
+    grace_period_ms,
+    created_at_m
+) VALUES (
+    sqlc.arg(scope_id),
+    sqlc.arg(workspace_id),
+    sqlc.arg(key_auth_id),
+    sqlc.arg(interval_ms),
+    sqlc.arg(grace_period_ms),
+    sqlc.arg(created_at_m)
+) ON DUPLICATE KEY UPDATE
+    interval_ms = VALUES(interval_ms),
+    grace_period_ms = VALUES(grace_period_ms),
+    updated_at_m = VALUES(created_at_m);
@@ -0,0 +1,2 @@
+-- name: DeleteKeySpaceEnvironments :exec
+DELETE FROM key_space_environments WHERE key_auth_id = sqlc.arg(key_auth_id);
@@ -0,0 +1,16 @@
+-- name: InsertKeySpaceEnvironment :exec
+INSERT INTO key_space_environments (
+    workspace_id,
+    key_auth_id,
+    name,
+    prefix,
+    test_mode,
+    created_at_m
+) VALUES (
+    sqlc.arg(workspace_id),
+    sqlc.arg(key_auth_id),
+    sqlc.arg(name),
+    sqlc.arg(prefix),
+    sqlc.arg(test_mode),
+    sqlc.arg(created_at_m)
+);
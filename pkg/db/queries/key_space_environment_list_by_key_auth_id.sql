@@ -0,0 +1,2 @@
+-- name: ListKeySpaceEnvironments :many
+SELECT * FROM key_space_environments WHERE key_auth_id = sqlc.arg(key_auth_id) ORDER BY name;
@@ -0,0 +1,7 @@
+-- name: UpdatePermissionConditions :exec
+-- Sets the conditions under which a permission applies. NULL makes the
+-- permission apply unconditionally.
+UPDATE permissions
+SET conditions = sqlc.narg(conditions),
+    updated_at_m = sqlc.arg(updated_at_m)
+WHERE id = sqlc.arg(permission_id);
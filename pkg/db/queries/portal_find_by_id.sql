@@ -0,0 +1,7 @@
+-- name: FindPortalByID :one
+-- Loads the portal a session was issued for. Portal routes read its key policy
+-- per request rather than from the session, so tightening a policy applies to
+-- sessions that are already live.
+SELECT * FROM portals
+WHERE id = sqlc.arg(id)
+  AND workspace_id = sqlc.arg(workspace_id);
@@ -0,0 +1,14 @@
+-- name: UpdatePortalKeyPolicy :exec
+-- The key policy bounds what an end user may create through the portal. Like
+-- branding it lives on the portal row and is written as a whole, so a NULL
+-- column clears that part of the policy.
+UPDATE portals
+SET max_keys = sqlc.narg(max_keys),
+    allowed_permissions = sqlc.narg(allowed_permissions),
+    allowed_roles = sqlc.narg(allowed_roles),
+    ratelimit_templates = sqlc.narg(ratelimit_templates),
+    min_expiry_ms = sqlc.narg(min_expiry_ms),
+    max_expiry_ms = sqlc.narg(max_expiry_ms),
+    updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id)
+  AND workspace_id = sqlc.arg(workspace_id);
@@ -6,7 +6,9 @@ SELECT *,
                                        'id', ro.id,
                                        'identifier', ro.identifier,
                                        'limit', ro.limit,
-                                       'duration', ro.duration
+                                       'duration', ro.duration,
+                                       'priority', ro.priority,
+                                       'expires_at', ro.expires_at_m
                                )
                        )
                 from ratelimit_overrides ro where ns.id = ro.namespace_id AND ro.deleted_at_m IS NULL),
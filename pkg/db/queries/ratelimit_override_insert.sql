@@ -6,6 +6,8 @@ INSERT INTO ratelimit_overrides (
     identifier,
     `limit`,
     duration,
+    priority,
+    expires_at_m,
     created_at_m
 )
 VALUES (
@@ -15,9 +17,13 @@ VALUES (
     sqlc.arg("identifier"),
     sqlc.arg("limit"),
     sqlc.arg("duration"),
+    sqlc.arg("priority"),
+    sqlc.arg("expires_at"),
     sqlc.arg("created_at")
 )
 ON DUPLICATE KEY UPDATE
     `limit` = VALUES(`limit`),
     duration = VALUES(duration),
+    priority = VALUES(priority),
+    expires_at_m = VALUES(expires_at_m),
     updated_at_m = sqlc.arg('updated_at')
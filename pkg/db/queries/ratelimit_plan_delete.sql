@@ -0,0 +1,3 @@
+-- name: DeleteRatelimitPlan :exec
+DELETE FROM ratelimit_plans
+WHERE id = sqlc.arg(id);
@@ -0,0 +1,16 @@
+-- name: FindRatelimitPlan :one
+SELECT *,
+       coalesce(
+               (select json_arrayagg(
+                               json_object(
+                                       'namespace_id', pl.namespace_id,
+                                       'limit', pl.limit,
+                                       'duration', pl.duration
+                               )
+                       )
+                from ratelimit_plan_limits pl where pl.plan_id = p.id),
+               json_array()
+       ) as limits
+FROM `ratelimit_plans` p
+WHERE p.workspace_id = sqlc.arg(workspace_id)
+AND (p.id = sqlc.arg(plan) OR p.name = sqlc.arg(plan));
@@ -0,0 +1,15 @@
+-- name: InsertRatelimitPlan :exec
+INSERT INTO ratelimit_plans (
+    id,
+    workspace_id,
+    name,
+    created_at_m
+)
+VALUES (
+    sqlc.arg("id"),
+    sqlc.arg("workspace_id"),
+    sqlc.arg("name"),
+    sqlc.arg("created_at")
+)
+ON DUPLICATE KEY UPDATE
+    updated_at_m = sqlc.arg('updated_at');
@@ -0,0 +1,3 @@
+-- name: DeleteRatelimitPlanLimits :exec
+DELETE FROM ratelimit_plan_limits
+WHERE plan_id = sqlc.arg(plan_id);
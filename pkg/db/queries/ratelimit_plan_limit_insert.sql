@@ -0,0 +1,17 @@
+-- name: InsertRatelimitPlanLimit :exec
+INSERT INTO ratelimit_plan_limits (
+    plan_id,
+    workspace_id,
+    namespace_id,
+    `limit`,
+    duration,
+    created_at_m
+)
+VALUES (
+    sqlc.arg("plan_id"),
+    sqlc.arg("workspace_id"),
+    sqlc.arg("namespace_id"),
+    sqlc.arg("limit"),
+    sqlc.arg("duration"),
+    sqlc.arg("created_at")
+);
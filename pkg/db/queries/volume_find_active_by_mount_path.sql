@@ -0,0 +1,10 @@
+-- name: FindActiveVolumeByMountPath :one
+-- Returns the active volume mounted at a path in an app's environment. Two
+-- volumes at one path would make every deployment's pod spec invalid.
+SELECT *
+FROM `volumes`
+WHERE app_id = sqlc.arg(app_id)
+  AND environment_id = sqlc.arg(environment_id)
+  AND mount_path = sqlc.arg(mount_path)
+  AND status = 'active'
+LIMIT 1;
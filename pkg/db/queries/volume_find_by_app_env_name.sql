@@ -0,0 +1,9 @@
+-- name: FindVolumeByAppEnvironmentAndName :one
+-- Returns the volume holding a name in an app's environment, whatever its
+-- status. Names are unique per app and environment, so a released or purged
+-- volume still holds its name until it is reactivated or retired.
+SELECT *
+FROM `volumes`
+WHERE app_id = sqlc.arg(app_id)
+  AND environment_id = sqlc.arg(environment_id)
+  AND name = sqlc.arg(name);
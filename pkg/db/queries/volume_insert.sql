@@ -0,0 +1,26 @@
+-- name: InsertVolume :exec
+INSERT INTO `volumes` (
+    id,
+    workspace_id,
+    project_id,
+    app_id,
+    environment_id,
+    name,
+    mount_path,
+    size_mib,
+    k8s_name,
+    status,
+    created_at
+) VALUES (
+    sqlc.arg(id),
+    sqlc.arg(workspace_id),
+    sqlc.arg(project_id),
+    sqlc.arg(app_id),
+    sqlc.arg(environment_id),
+    sqlc.arg(name),
+    sqlc.arg(mount_path),
+    sqlc.arg(size_mib),
+    sqlc.arg(k8s_name),
+    sqlc.arg(status),
+    sqlc.arg(created_at)
+);
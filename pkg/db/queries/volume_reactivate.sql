@@ -0,0 +1,12 @@
+-- name: ReactivateVolume :execrows
+-- Returns a released volume to active so deployments mount it again with its
+-- data intact. Gated on status so a volume PurgeVolumes already claimed is
+-- never revived; callers must check the affected row count.
+UPDATE `volumes`
+SET status = 'active',
+    released_at = NULL,
+    mount_path = sqlc.arg(mount_path),
+    size_mib = sqlc.arg(size_mib),
+    updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id)
+  AND status = 'released';
@@ -0,0 +1,10 @@
+-- name: RetirePurgedVolumeName :execrows
+-- Frees the name of a purged volume by renaming it to its id, so a new volume
+-- can take the name. The purged row is kept, since a pending deployment change
+-- still resolves it by id to delete its claim. Ids never match the name
+-- pattern, so the renamed row cannot collide with a real name.
+UPDATE `volumes`
+SET name = id,
+    updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id)
+  AND status = 'purged';
@@ -0,0 +1,2 @@
+-- name: DeleteWebhookDeliveriesByEndpointID :exec
+DELETE FROM webhook_deliveries WHERE endpoint_id = sqlc.arg(endpoint_id);
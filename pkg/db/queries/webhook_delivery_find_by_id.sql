@@ -0,0 +1,4 @@
+-- name: FindWebhookDeliveryByID :one
+SELECT * FROM webhook_deliveries
+WHERE workspace_id = sqlc.arg(workspace_id)
+  AND id = sqlc.arg(id);
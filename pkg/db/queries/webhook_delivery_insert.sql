@@ -0,0 +1,22 @@
+-- name: InsertWebhookDelivery :exec
+INSERT INTO webhook_deliveries (
+    id,
+    workspace_id,
+    endpoint_id,
+    event_id,
+    event_type,
+    payload,
+    status,
+    attempts,
+    created_at
+) VALUES (
+    sqlc.arg(id),
+    sqlc.arg(workspace_id),
+    sqlc.arg(endpoint_id),
+    sqlc.arg(event_id),
+    sqlc.arg(event_type),
+    sqlc.arg(payload),
+    'pending',
+    0,
+    sqlc.arg(created_at)
+);
@@ -0,0 +1,9 @@
+-- name: ListWebhookDeliveriesByEndpointID :many
+-- Delivery ids are random, so the log pages newest first by pk and the
+-- cursor is the pk of the first row of the next page.
+SELECT * FROM webhook_deliveries
+WHERE workspace_id = sqlc.arg(workspace_id)
+  AND endpoint_id = sqlc.arg(endpoint_id)
+  AND pk <= sqlc.arg(pk_cursor)
+ORDER BY pk DESC
+LIMIT ?;
@@ -0,0 +1,2 @@
+-- name: DeleteWebhookEndpoint :exec
+DELETE FROM webhook_endpoints WHERE id = sqlc.arg(id);
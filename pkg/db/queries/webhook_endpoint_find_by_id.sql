@@ -0,0 +1,4 @@
+-- name: FindWebhookEndpointByID :one
+SELECT * FROM webhook_endpoints
+WHERE workspace_id = sqlc.arg(workspace_id)
+  AND id = sqlc.arg(id);
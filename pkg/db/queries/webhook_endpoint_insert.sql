@@ -0,0 +1,22 @@
+-- name: InsertWebhookEndpoint :exec
+INSERT INTO webhook_endpoints (
+    id,
+    workspace_id,
+    url,
+    description,
+    event_types,
+    encrypted_secret,
+    encryption_key_id,
+    enabled,
+    created_at
+) VALUES (
+    sqlc.arg(id),
+    sqlc.arg(workspace_id),
+    sqlc.arg(url),
+    sqlc.narg(description),
+    sqlc.arg(event_types),
+    sqlc.arg(encrypted_secret),
+    sqlc.arg(encryption_key_id),
+    sqlc.arg(enabled),
+    sqlc.arg(created_at)
+);
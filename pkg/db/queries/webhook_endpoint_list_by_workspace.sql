@@ -0,0 +1,6 @@
+-- name: ListWebhookEndpointsByWorkspace :many
+SELECT * FROM webhook_endpoints
+WHERE workspace_id = sqlc.arg(workspace_id)
+  AND id >= sqlc.arg(id_cursor)
+ORDER BY id ASC
+LIMIT ?;
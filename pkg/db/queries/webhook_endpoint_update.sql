@@ -0,0 +1,9 @@
+-- name: UpdateWebhookEndpoint :exec
+UPDATE webhook_endpoints
+SET
+    url = sqlc.arg(url),
+    description = sqlc.narg(description),
+    event_types = sqlc.arg(event_types),
+    enabled = sqlc.arg(enabled),
+    updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id);
@@ -9,6 +9,10 @@ type FindRatelimitNamespaceLimitOverride struct {
 	Identifier string `json:"identifier"`
 	Limit      int64  `json:"limit"`
 	Duration   int64  `json:"duration"`
+	Priority   int32  `json:"priority"`
+	// ExpiresAt is the unix milli after which the override no longer applies,
+	// 0 if it never expires.
+	ExpiresAt int64 `json:"expires_at"`
 }
 
 type FindRatelimitNamespace struct {
@@ -18,7 +18,9 @@ SELECT pk, id, workspace_id, project_id, name, created_at_m, updated_at_m, delet
                                        'id', ro.id,
                                        'identifier', ro.identifier,
                                        'limit', ro.limit,
-                                       'duration', ro.duration
+                                       'duration', ro.duration,
+                                       'priority', ro.priority,
+                                       'expires_at', ro.expires_at_m
                                )
                        )
                 from ratelimit_overrides ro where ns.id = ro.namespace_id AND ro.deleted_at_m IS NULL),
@@ -55,7 +57,9 @@ type FindRatelimitNamespaceRow struct {
 //	                                       'id', ro.id,
 //	                                       'identifier', ro.identifier,
 //	                                       'limit', ro.limit,
-//	                                       'duration', ro.duration
+//	                                       'duration', ro.duration,
+//	                                       'priority', ro.priority,
+//	                                       'expires_at', ro.expires_at_m
 //	                               )
 //	                       )
 //	                from ratelimit_overrides ro where ns.id = ro.namespace_id AND ro.deleted_at_m IS NULL),
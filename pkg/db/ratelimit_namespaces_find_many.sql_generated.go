@@ -19,7 +19,9 @@ SELECT pk, id, workspace_id, project_id, name, created_at_m, updated_at_m, delet
                                        'id', ro.id,
                                        'identifier', ro.identifier,
                                        'limit', ro.limit,
-                                       'duration', ro.duration
+                                       'duration', ro.duration,
+                                       'priority', ro.priority,
+                                       'expires_at', ro.expires_at_m
                                )
                        )
                 from ratelimit_overrides ro where ns.id = ro.namespace_id AND ro.deleted_at_m IS NULL),
@@ -56,7 +58,9 @@ type FindManyRatelimitNamespacesRow struct {
 //	                                       'id', ro.id,
 //	                                       'identifier', ro.identifier,
 //	                                       'limit', ro.limit,
-//	                                       'duration', ro.duration
+//	                                       'duration', ro.duration,
+//	                                       'priority', ro.priority,
+//	                                       'expires_at', ro.expires_at_m
 //	                               )
 //	                       )
 //	                from ratelimit_overrides ro where ns.id = ro.namespace_id AND ro.deleted_at_m IS NULL),
@@ -10,7 +10,7 @@ import (
 )
 
 const findRatelimitOverrideByID = `-- name: FindRatelimitOverrideByID :one
-SELECT pk, id, workspace_id, namespace_id, identifier, ` + "`" + `limit` + "`" + `, duration, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
+SELECT pk, id, workspace_id, namespace_id, identifier, ` + "`" + `limit` + "`" + `, duration, priority, expires_at_m, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
 WHERE
     workspace_id = ?
     AND id = ?
@@ -23,7 +23,7 @@ type FindRatelimitOverrideByIDParams struct {
 
 // FindRatelimitOverrideByID
 //
-//	SELECT pk, id, workspace_id, namespace_id, identifier, `limit`, duration, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
+//	SELECT pk, id, workspace_id, namespace_id, identifier, `limit`, duration, priority, expires_at_m, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
 //	WHERE
 //	    workspace_id = ?
 //	    AND id = ?
@@ -38,6 +38,8 @@ func (q *Queries) FindRatelimitOverrideByID(ctx context.Context, db DBTX, arg Fi
 		&i.Identifier,
 		&i.Limit,
 		&i.Duration,
+		&i.Priority,
+		&i.ExpiresAtM,
 		&i.CreatedAtM,
 		&i.UpdatedAtM,
 		&i.DeletedAtM,
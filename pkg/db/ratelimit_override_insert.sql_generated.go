@@ -18,6 +18,8 @@ INSERT INTO ratelimit_overrides (
     identifier,
     ` + "`" + `limit` + "`" + `,
     duration,
+    priority,
+    expires_at_m,
     created_at_m
 )
 VALUES (
@@ -27,11 +29,15 @@ VALUES (
     ?,
     ?,
     ?,
+    ?,
+    ?,
     ?
 )
 ON DUPLICATE KEY UPDATE
     ` + "`" + `limit` + "`" + ` = VALUES(` + "`" + `limit` + "`" + `),
     duration = VALUES(duration),
+    priority = VALUES(priority),
+    expires_at_m = VALUES(expires_at_m),
     updated_at_m = ?
 `
 
@@ -42,6 +48,8 @@ type InsertRatelimitOverrideParams struct {
 	Identifier  string        `db:"identifier"`
 	Limit       uint64        `db:"limit"`
 	Duration    uint64        `db:"duration"`
+	Priority    int32         `db:"priority"`
+	ExpiresAt   sql.NullInt64 `db:"expires_at"`
 	CreatedAt   int64         `db:"created_at"`
 	UpdatedAt   sql.NullInt64 `db:"updated_at"`
 }
@@ -55,6 +63,8 @@ type InsertRatelimitOverrideParams struct {
 //	    identifier,
 //	    `limit`,
 //	    duration,
+//	    priority,
+//	    expires_at_m,
 //	    created_at_m
 //	)
 //	VALUES (
@@ -64,11 +74,15 @@ type InsertRatelimitOverrideParams struct {
 //	    ?,
 //	    ?,
 //	    ?,
+//	    ?,
+//	    ?,
 //	    ?
 //	)
 //	ON DUPLICATE KEY UPDATE
 //	    `limit` = VALUES(`limit`),
 //	    duration = VALUES(duration),
+//	    priority = VALUES(priority),
+//	    expires_at_m = VALUES(expires_at_m),
 //	    updated_at_m = ?
 func (q *Queries) InsertRatelimitOverride(ctx context.Context, db DBTX, arg InsertRatelimitOverrideParams) error {
 	_, err := db.ExecContext(ctx, insertRatelimitOverride,
@@ -78,6 +92,8 @@ func (q *Queries) InsertRatelimitOverride(ctx context.Context, db DBTX, arg Inse
 		arg.Identifier,
 		arg.Limit,
 		arg.Duration,
+		arg.Priority,
+		arg.ExpiresAt,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
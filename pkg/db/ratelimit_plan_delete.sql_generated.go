@@ -0,0 +1,24 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: ratelimit_plan_delete.sql
+
+package db
+
+import (
+	"context"
+)
+
+const deleteRatelimitPlan = `-- name: DeleteRatelimitPlan :exec
+DELETE FROM ratelimit_plans
+WHERE id = ?
+`
+
+// DeleteRatelimitPlan
+//
+//	DELETE FROM ratelimit_plans
+//	WHERE id = ?
+func (q *Queries) DeleteRatelimitPlan(ctx context.Context, db DBTX, id string) error {
+	_, err := db.ExecContext(ctx, deleteRatelimitPlan, id)
+	return err
+}
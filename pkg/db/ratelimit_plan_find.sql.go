@@ -0,0 +1,20 @@
+package db
+
+import (
+	"database/sql"
+)
+
+type FindRatelimitPlanLimit struct {
+	NamespaceID string `json:"namespace_id"`
+	Limit       int64  `json:"limit"`
+	Duration    int64  `json:"duration"`
+}
+
+type FindRatelimitPlan struct {
+	ID          string                   `db:"id"`
+	WorkspaceID string                   `db:"workspace_id"`
+	Name        string                   `db:"name"`
+	CreatedAtM  int64                    `db:"created_at_m"`
+	UpdatedAtM  sql.NullInt64            `db:"updated_at_m"`
+	Limits      []FindRatelimitPlanLimit `db:"limits"`
+}
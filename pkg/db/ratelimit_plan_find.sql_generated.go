@@ -0,0 +1,76 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: ratelimit_plan_find.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const findRatelimitPlan = `-- name: FindRatelimitPlan :one
+SELECT pk, id, workspace_id, name, created_at_m, updated_at_m,
+       coalesce(
+               (select json_arrayagg(
+                               json_object(
+                                       'namespace_id', pl.namespace_id,
+                                       'limit', pl.limit,
+                                       'duration', pl.duration
+                               )
+                       )
+                from ratelimit_plan_limits pl where pl.plan_id = p.id),
+               json_array()
+       ) as limits
+FROM ` + "`" + `ratelimit_plans` + "`" + ` p
+WHERE p.workspace_id = ?
+AND (p.id = ? OR p.name = ?)
+`
+
+type FindRatelimitPlanParams struct {
+	WorkspaceID string `db:"workspace_id"`
+	Plan        string `db:"plan"`
+}
+
+type FindRatelimitPlanRow struct {
+	Pk          uint64        `db:"pk"`
+	ID          string        `db:"id"`
+	WorkspaceID string        `db:"workspace_id"`
+	Name        string        `db:"name"`
+	CreatedAtM  int64         `db:"created_at_m"`
+	UpdatedAtM  sql.NullInt64 `db:"updated_at_m"`
+	Limits      interface{}   `db:"limits"`
+}
+
+// FindRatelimitPlan
+//
+//	SELECT pk, id, workspace_id, name, created_at_m, updated_at_m,
+//	       coalesce(
+//	               (select json_arrayagg(
+//	                               json_object(
+//	                                       'namespace_id', pl.namespace_id,
+//	                                       'limit', pl.limit,
+//	                                       'duration', pl.duration
+//	                               )
+//	                       )
+//	                from ratelimit_plan_limits pl where pl.plan_id = p.id),
+//	               json_array()
+//	       ) as limits
+//	FROM `ratelimit_plans` p
+//	WHERE p.workspace_id = ?
+//	AND (p.id = ? OR p.name = ?)
+func (q *Queries) FindRatelimitPlan(ctx context.Context, db DBTX, arg FindRatelimitPlanParams) (FindRatelimitPlanRow, error) {
+	row := db.QueryRowContext(ctx, findRatelimitPlan, arg.WorkspaceID, arg.Plan, arg.Plan)
+	var i FindRatelimitPlanRow
+	err := row.Scan(
+		&i.Pk,
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Name,
+		&i.CreatedAtM,
+		&i.UpdatedAtM,
+		&i.Limits,
+	)
+	return i, err
+}
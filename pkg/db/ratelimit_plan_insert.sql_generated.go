@@ -0,0 +1,63 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: ratelimit_plan_insert.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const insertRatelimitPlan = `-- name: InsertRatelimitPlan :exec
+INSERT INTO ratelimit_plans (
+    id,
+    workspace_id,
+    name,
+    created_at_m
+)
+VALUES (
+    ?,
+    ?,
+    ?,
+    ?
+)
+ON DUPLICATE KEY UPDATE
+    updated_at_m = ?
+`
+
+type InsertRatelimitPlanParams struct {
+	ID          string        `db:"id"`
+	WorkspaceID string        `db:"workspace_id"`
+	Name        string        `db:"name"`
+	CreatedAt   int64         `db:"created_at"`
+	UpdatedAt   sql.NullInt64 `db:"updated_at"`
+}
+
+// InsertRatelimitPlan
+//
+//	INSERT INTO ratelimit_plans (
+//	    id,
+//	    workspace_id,
+//	    name,
+//	    created_at_m
+//	)
+//	VALUES (
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?
+//	)
+//	ON DUPLICATE KEY UPDATE
+//	    updated_at_m = ?
+func (q *Queries) InsertRatelimitPlan(ctx context.Context, db DBTX, arg InsertRatelimitPlanParams) error {
+	_, err := db.ExecContext(ctx, insertRatelimitPlan,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.Name,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
@@ -0,0 +1,24 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: ratelimit_plan_limit_delete_by_plan_id.sql
+
+package db
+
+import (
+	"context"
+)
+
+const deleteRatelimitPlanLimits = `-- name: DeleteRatelimitPlanLimits :exec
+DELETE FROM ratelimit_plan_limits
+WHERE plan_id = ?
+`
+
+// DeleteRatelimitPlanLimits
+//
+//	DELETE FROM ratelimit_plan_limits
+//	WHERE plan_id = ?
+func (q *Queries) DeleteRatelimitPlanLimits(ctx context.Context, db DBTX, planID string) error {
+	_, err := db.ExecContext(ctx, deleteRatelimitPlanLimits, planID)
+	return err
+}
@@ -0,0 +1,68 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: ratelimit_plan_limit_insert.sql
+
+package db
+
+import (
+	"context"
+)
+
+const insertRatelimitPlanLimit = `-- name: InsertRatelimitPlanLimit :exec
+INSERT INTO ratelimit_plan_limits (
+    plan_id,
+    workspace_id,
+    namespace_id,
+    ` + "`" + `limit` + "`" + `,
+    duration,
+    created_at_m
+)
+VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
+`
+
+type InsertRatelimitPlanLimitParams struct {
+	PlanID      string `db:"plan_id"`
+	WorkspaceID string `db:"workspace_id"`
+	NamespaceID string `db:"namespace_id"`
+	Limit       uint64 `db:"limit"`
+	Duration    uint64 `db:"duration"`
+	CreatedAt   int64  `db:"created_at"`
+}
+
+// InsertRatelimitPlanLimit
+//
+//	INSERT INTO ratelimit_plan_limits (
+//	    plan_id,
+//	    workspace_id,
+//	    namespace_id,
+//	    `limit`,
+//	    duration,
+//	    created_at_m
+//	)
+//	VALUES (
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?
+//	)
+func (q *Queries) InsertRatelimitPlanLimit(ctx context.Context, db DBTX, arg InsertRatelimitPlanLimitParams) error {
+	_, err := db.ExecContext(ctx, insertRatelimitPlanLimit,
+		arg.PlanID,
+		arg.WorkspaceID,
+		arg.NamespaceID,
+		arg.Limit,
+		arg.Duration,
+		arg.CreatedAt,
+	)
+	return err
+}
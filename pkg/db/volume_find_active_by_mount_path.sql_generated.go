@@ -0,0 +1,58 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: volume_find_active_by_mount_path.sql
+
+package db
+
+import (
+	"context"
+)
+
+const findActiveVolumeByMountPath = `-- name: FindActiveVolumeByMountPath :one
+SELECT pk, id, workspace_id, project_id, app_id, environment_id, name, mount_path, size_mib, k8s_name, status, released_at, created_at, updated_at
+FROM ` + "`" + `volumes` + "`" + `
+WHERE app_id = ?
+  AND environment_id = ?
+  AND mount_path = ?
+  AND status = 'active'
+LIMIT 1
+`
+
+type FindActiveVolumeByMountPathParams struct {
+	AppID         string `db:"app_id"`
+	EnvironmentID string `db:"environment_id"`
+	MountPath     string `db:"mount_path"`
+}
+
+// Returns the active volume mounted at a path in an app's environment. Two
+// volumes at one path would make every deployment's pod spec invalid.
+//
+//	SELECT pk, id, workspace_id, project_id, app_id, environment_id, name, mount_path, size_mib, k8s_name, status, released_at, created_at, updated_at
+//	FROM `volumes`
+//	WHERE app_id = ?
+//	  AND environment_id = ?
+//	  AND mount_path = ?
+//	  AND status = 'active'
+//	LIMIT 1
+func (q *Queries) FindActiveVolumeByMountPath(ctx context.Context, db DBTX, arg FindActiveVolumeByMountPathParams) (Volume, error) {
+	row := db.QueryRowContext(ctx, findActiveVolumeByMountPath, arg.AppID, arg.EnvironmentID, arg.MountPath)
+	var i Volume
+	err := row.Scan(
+		&i.Pk,
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ProjectID,
+		&i.AppID,
+		&i.EnvironmentID,
+		&i.Name,
+		&i.MountPath,
+		&i.SizeMib,
+		&i.K8sName,
+		&i.Status,
+		&i.ReleasedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
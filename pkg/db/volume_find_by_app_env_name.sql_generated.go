@@ -0,0 +1,55 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: volume_find_by_app_env_name.sql
+
+package db
+
+import (
+	"context"
+)
+
+const findVolumeByAppEnvironmentAndName = `-- name: FindVolumeByAppEnvironmentAndName :one
+SELECT pk, id, workspace_id, project_id, app_id, environment_id, name, mount_path, size_mib, k8s_name, status, released_at, created_at, updated_at
+FROM ` + "`" + `volumes` + "`" + `
+WHERE app_id = ?
+  AND environment_id = ?
+  AND name = ?
+`
+
+type FindVolumeByAppEnvironmentAndNameParams struct {
+	AppID         string `db:"app_id"`
+	EnvironmentID string `db:"environment_id"`
+	Name          string `db:"name"`
+}
+
+// Returns the volume holding a name in an app's environment, whatever its
+// status. Names are unique per app and environment, so a released or purged
+// volume still holds its name until it is reactivated or retired.
+//
+//	SELECT pk, id, workspace_id, project_id, app_id, environment_id, name, mount_path, size_mib, k8s_name, status, released_at, created_at, updated_at
+//	FROM `volumes`
+//	WHERE app_id = ?
+//	  AND environment_id = ?
+//	  AND name = ?
+func (q *Queries) FindVolumeByAppEnvironmentAndName(ctx context.Context, db DBTX, arg FindVolumeByAppEnvironmentAndNameParams) (Volume, error) {
+	row := db.QueryRowContext(ctx, findVolumeByAppEnvironmentAndName, arg.AppID, arg.EnvironmentID, arg.Name)
+	var i Volume
+	err := row.Scan(
+		&i.Pk,
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ProjectID,
+		&i.AppID,
+		&i.EnvironmentID,
+		&i.Name,
+		&i.MountPath,
+		&i.SizeMib,
+		&i.K8sName,
+		&i.Status,
+		&i.ReleasedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
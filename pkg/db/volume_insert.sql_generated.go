@@ -0,0 +1,96 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: volume_insert.sql
+
+package db
+
+import (
+	"context"
+)
+
+const insertVolume = `-- name: InsertVolume :exec
+INSERT INTO ` + "`" + `volumes` + "`" + ` (
+    id,
+    workspace_id,
+    project_id,
+    app_id,
+    environment_id,
+    name,
+    mount_path,
+    size_mib,
+    k8s_name,
+    status,
+    created_at
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
+`
+
+type InsertVolumeParams struct {
+	ID            string        `db:"id"`
+	WorkspaceID   string        `db:"workspace_id"`
+	ProjectID     string        `db:"project_id"`
+	AppID         string        `db:"app_id"`
+	EnvironmentID string        `db:"environment_id"`
+	Name          string        `db:"name"`
+	MountPath     string        `db:"mount_path"`
+	SizeMib       uint32        `db:"size_mib"`
+	K8sName       string        `db:"k8s_name"`
+	Status        VolumesStatus `db:"status"`
+	CreatedAt     int64         `db:"created_at"`
+}
+
+// InsertVolume
+//
+//	INSERT INTO `volumes` (
+//	    id,
+//	    workspace_id,
+//	    project_id,
+//	    app_id,
+//	    environment_id,
+//	    name,
+//	    mount_path,
+//	    size_mib,
+//	    k8s_name,
+//	    status,
+//	    created_at
+//	) VALUES (
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?
+//	)
+func (q *Queries) InsertVolume(ctx context.Context, db DBTX, arg InsertVolumeParams) error {
+	_, err := db.ExecContext(ctx, insertVolume,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.ProjectID,
+		arg.AppID,
+		arg.EnvironmentID,
+		arg.Name,
+		arg.MountPath,
+		arg.SizeMib,
+		arg.K8sName,
+		arg.Status,
+		arg.CreatedAt,
+	)
+	return err
+}
@@ -0,0 +1,54 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: volume_reactivate.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const reactivateVolume = `-- name: ReactivateVolume :execrows
+UPDATE ` + "`" + `volumes` + "`" + `
+SET status = 'active',
+    released_at = NULL,
+    mount_path = ?,
+    size_mib = ?,
+    updated_at = ?
+WHERE id = ?
+  AND status = 'released'
+`
+
+type ReactivateVolumeParams struct {
+	MountPath string        `db:"mount_path"`
+	SizeMib   uint32        `db:"size_mib"`
+	UpdatedAt sql.NullInt64 `db:"updated_at"`
+	ID        string        `db:"id"`
+}
+
+// Returns a released volume to active so deployments mount it again with its
+// data intact. Gated on status so a volume PurgeVolumes already claimed is
+// never revived; callers must check the affected row count.
+//
+//	UPDATE `volumes`
+//	SET status = 'active',
+//	    released_at = NULL,
+//	    mount_path = ?,
+//	    size_mib = ?,
+//	    updated_at = ?
+//	WHERE id = ?
+//	  AND status = 'released'
+func (q *Queries) ReactivateVolume(ctx context.Context, db DBTX, arg ReactivateVolumeParams) (int64, error) {
+	result, err := db.ExecContext(ctx, reactivateVolume,
+		arg.MountPath,
+		arg.SizeMib,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,42 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: volume_retire_name.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const retirePurgedVolumeName = `-- name: RetirePurgedVolumeName :execrows
+UPDATE ` + "`" + `volumes` + "`" + `
+SET name = id,
+    updated_at = ?
+WHERE id = ?
+  AND status = 'purged'
+`
+
+type RetirePurgedVolumeNameParams struct {
+	UpdatedAt sql.NullInt64 `db:"updated_at"`
+	ID        string        `db:"id"`
+}
+
+// Frees the name of a purged volume by renaming it to its id, so a new volume
+// can take the name. The purged row is kept, since a pending deployment change
+// still resolves it by id to delete its claim. Ids never match the name
+// pattern, so the renamed row cannot collide with a real name.
+//
+//	UPDATE `volumes`
+//	SET name = id,
+//	    updated_at = ?
+//	WHERE id = ?
+//	  AND status = 'purged'
+func (q *Queries) RetirePurgedVolumeName(ctx context.Context, db DBTX, arg RetirePurgedVolumeNameParams) (int64, error) {
+	result, err := db.ExecContext(ctx, retirePurgedVolumeName, arg.UpdatedAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,22 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: webhook_delivery_delete_by_endpoint_id.sql
+
+package db
+
+import (
+	"context"
+)
+
+const deleteWebhookDeliveriesByEndpointID = `-- name: DeleteWebhookDeliveriesByEndpointID :exec
+DELETE FROM webhook_deliveries WHERE endpoint_id = ?
+`
+
+// DeleteWebhookDeliveriesByEndpointID
+//
+//	DELETE FROM webhook_deliveries WHERE endpoint_id = ?
+func (q *Queries) DeleteWebhookDeliveriesByEndpointID(ctx context.Context, db DBTX, endpointID string) error {
+	_, err := db.ExecContext(ctx, deleteWebhookDeliveriesByEndpointID, endpointID)
+	return err
+}
@@ -0,0 +1,81 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: webhook_delivery_insert.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const insertWebhookDelivery = `-- name: InsertWebhookDelivery :exec
+INSERT INTO webhook_deliveries (
+    id,
+    workspace_id,
+    endpoint_id,
+    event_id,
+    event_type,
+    payload,
+    status,
+    attempts,
+    created_at
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    'pending',
+    0,
+    ?
+)
+`
+
+type InsertWebhookDeliveryParams struct {
+	ID          string          `db:"id"`
+	WorkspaceID string          `db:"workspace_id"`
+	EndpointID  string          `db:"endpoint_id"`
+	EventID     string          `db:"event_id"`
+	EventType   string          `db:"event_type"`
+	Payload     json.RawMessage `db:"payload"`
+	CreatedAt   int64           `db:"created_at"`
+}
+
+// InsertWebhookDelivery
+//
+//	INSERT INTO webhook_deliveries (
+//	    id,
+//	    workspace_id,
+//	    endpoint_id,
+//	    event_id,
+//	    event_type,
+//	    payload,
+//	    status,
+//	    attempts,
+//	    created_at
+//	) VALUES (
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    'pending',
+//	    0,
+//	    ?
+//	)
+func (q *Queries) InsertWebhookDelivery(ctx context.Context, db DBTX, arg InsertWebhookDeliveryParams) error {
+	_, err := db.ExecContext(ctx, insertWebhookDelivery,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.EndpointID,
+		arg.EventID,
+		arg.EventType,
+		arg.Payload,
+		arg.CreatedAt,
+	)
+	return err
+}
@@ -0,0 +1,79 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: webhook_delivery_list_by_endpoint_id.sql
+
+package db
+
+import (
+	"context"
+)
+
+const listWebhookDeliveriesByEndpointID = `-- name: ListWebhookDeliveriesByEndpointID :many
+SELECT pk, id, workspace_id, endpoint_id, event_id, event_type, payload, status, attempts, response_status, last_error, next_attempt_at, delivered_at, created_at, updated_at FROM webhook_deliveries
+WHERE workspace_id = ?
+  AND endpoint_id = ?
+  AND pk <= ?
+ORDER BY pk DESC
+LIMIT ?
+`
+
+type ListWebhookDeliveriesByEndpointIDParams struct {
+	WorkspaceID string `db:"workspace_id"`
+	EndpointID  string `db:"endpoint_id"`
+	PkCursor    uint64 `db:"pk_cursor"`
+	Limit       int32  `db:"limit"`
+}
+
+// Delivery ids are random, so the log pages newest first by pk and the
+// cursor is the pk of the first row of the next page.
+//
+//	SELECT pk, id, workspace_id, endpoint_id, event_id, event_type, payload, status, attempts, response_status, last_error, next_attempt_at, delivered_at, created_at, updated_at FROM webhook_deliveries
+//	WHERE workspace_id = ?
+//	  AND endpoint_id = ?
+//	  AND pk <= ?
+//	ORDER BY pk DESC
+//	LIMIT ?
+func (q *Queries) ListWebhookDeliveriesByEndpointID(ctx context.Context, db DBTX, arg ListWebhookDeliveriesByEndpointIDParams) ([]WebhookDelivery, error) {
+	rows, err := db.QueryContext(ctx, listWebhookDeliveriesByEndpointID,
+		arg.WorkspaceID,
+		arg.EndpointID,
+		arg.PkCursor,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.Pk,
+			&i.ID,
+			&i.WorkspaceID,
+			&i.EndpointID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.ResponseStatus,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
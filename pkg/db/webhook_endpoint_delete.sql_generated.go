@@ -0,0 +1,22 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: webhook_endpoint_delete.sql
+
+package db
+
+import (
+	"context"
+)
+
+const deleteWebhookEndpoint = `-- name: DeleteWebhookEndpoint :exec
+DELETE FROM webhook_endpoints WHERE id = ?
+`
+
+// DeleteWebhookEndpoint
+//
+//	DELETE FROM webhook_endpoints WHERE id = ?
+func (q *Queries) DeleteWebhookEndpoint(ctx context.Context, db DBTX, id string) error {
+	_, err := db.ExecContext(ctx, deleteWebhookEndpoint, id)
+	return err
+}
@@ -0,0 +1,86 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: webhook_endpoint_insert.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+const insertWebhookEndpoint = `-- name: InsertWebhookEndpoint :exec
+INSERT INTO webhook_endpoints (
+    id,
+    workspace_id,
+    url,
+    description,
+    event_types,
+    encrypted_secret,
+    encryption_key_id,
+    enabled,
+    created_at
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
+`
+
+type InsertWebhookEndpointParams struct {
+	ID              string          `db:"id"`
+	WorkspaceID     string          `db:"workspace_id"`
+	Url             string          `db:"url"`
+	Description     sql.NullString  `db:"description"`
+	EventTypes      json.RawMessage `db:"event_types"`
+	EncryptedSecret string          `db:"encrypted_secret"`
+	EncryptionKeyID string          `db:"encryption_key_id"`
+	Enabled         bool            `db:"enabled"`
+	CreatedAt       int64           `db:"created_at"`
+}
+
+// InsertWebhookEndpoint
+//
+//	INSERT INTO webhook_endpoints (
+//	    id,
+//	    workspace_id,
+//	    url,
+//	    description,
+//	    event_types,
+//	    encrypted_secret,
+//	    encryption_key_id,
+//	    enabled,
+//	    created_at
+//	) VALUES (
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?
+//	)
+func (q *Queries) InsertWebhookEndpoint(ctx context.Context, db DBTX, arg InsertWebhookEndpointParams) error {
+	_, err := db.ExecContext(ctx, insertWebhookEndpoint,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.Url,
+		arg.Description,
+		arg.EventTypes,
+		arg.EncryptedSecret,
+		arg.EncryptionKeyID,
+		arg.Enabled,
+		arg.CreatedAt,
+	)
+	return err
+}
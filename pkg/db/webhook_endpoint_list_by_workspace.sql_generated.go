@@ -0,0 +1,66 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: webhook_endpoint_list_by_workspace.sql
+
+package db
+
+import (
+	"context"
+)
+
+const listWebhookEndpointsByWorkspace = `-- name: ListWebhookEndpointsByWorkspace :many
+SELECT pk, id, workspace_id, url, description, event_types, encrypted_secret, encryption_key_id, enabled, created_at, updated_at FROM webhook_endpoints
+WHERE workspace_id = ?
+  AND id >= ?
+ORDER BY id ASC
+LIMIT ?
+`
+
+type ListWebhookEndpointsByWorkspaceParams struct {
+	WorkspaceID string `db:"workspace_id"`
+	IDCursor    string `db:"id_cursor"`
+	Limit       int32  `db:"limit"`
+}
+
+// ListWebhookEndpointsByWorkspace
+//
+//	SELECT pk, id, workspace_id, url, description, event_types, encrypted_secret, encryption_key_id, enabled, created_at, updated_at FROM webhook_endpoints
+//	WHERE workspace_id = ?
+//	  AND id >= ?
+//	ORDER BY id ASC
+//	LIMIT ?
+func (q *Queries) ListWebhookEndpointsByWorkspace(ctx context.Context, db DBTX, arg ListWebhookEndpointsByWorkspaceParams) ([]WebhookEndpoint, error) {
+	rows, err := db.QueryContext(ctx, listWebhookEndpointsByWorkspace, arg.WorkspaceID, arg.IDCursor, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookEndpoint
+	for rows.Next() {
+		var i WebhookEndpoint
+		if err := rows.Scan(
+			&i.Pk,
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Url,
+			&i.Description,
+			&i.EventTypes,
+			&i.EncryptedSecret,
+			&i.EncryptionKeyID,
+			&i.Enabled,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
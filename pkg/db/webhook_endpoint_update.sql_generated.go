@@ -0,0 +1,54 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: webhook_endpoint_update.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+const updateWebhookEndpoint = `-- name: UpdateWebhookEndpoint :exec
+UPDATE webhook_endpoints
+SET
+    url = ?,
+    description = ?,
+    event_types = ?,
+    enabled = ?,
+    updated_at = ?
+WHERE id = ?
+`
+
+type UpdateWebhookEndpointParams struct {
+	Url         string          `db:"url"`
+	Description sql.NullString  `db:"description"`
+	EventTypes  json.RawMessage `db:"event_types"`
+	Enabled     bool            `db:"enabled"`
+	UpdatedAt   sql.NullInt64   `db:"updated_at"`
+	ID          string          `db:"id"`
+}
+
+// UpdateWebhookEndpoint
+//
+//	UPDATE webhook_endpoints
+//	SET
+//	    url = ?,
+//	    description = ?,
+//	    event_types = ?,
+//	    enabled = ?,
+//	    updated_at = ?
+//	WHERE id = ?
+func (q *Queries) UpdateWebhookEndpoint(ctx context.Context, db DBTX, arg UpdateWebhookEndpointParams) error {
+	_, err := db.ExecContext(ctx, updateWebhookEndpoint,
+		arg.Url,
+		arg.Description,
+		arg.EventTypes,
+		arg.Enabled,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	return err
+}
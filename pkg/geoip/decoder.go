@@ -0,0 +1,321 @@
+package geoip
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// dataType is a MaxMind DB data section field type. Types 12 (data cache
+// container) and 13 (end marker) never appear in lookups and are rejected.
+type dataType byte
+
+const (
+	typeExtended dataType = 0
+	typePointer  dataType = 1
+	typeString   dataType = 2
+	typeDouble   dataType = 3
+	typeBytes    dataType = 4
+	typeUint16   dataType = 5
+	typeUint32   dataType = 6
+	typeMap      dataType = 7
+	typeInt32    dataType = 8
+	typeUint64   dataType = 9
+	typeUint128  dataType = 10
+	typeArray    dataType = 11
+	typeBool     dataType = 14
+	typeFloat    dataType = 15
+)
+
+// maxDepth bounds nesting and pointer chains so a corrupt or hostile file
+// cannot recurse without end.
+const maxDepth = 32
+
+var errOutOfBounds = errors.New("geoip: unexpected end of data")
+
+// decoder reads values from a data section. Pointers are offsets into buf.
+type decoder struct {
+	buf []byte
+}
+
+// control reads the control byte(s) of the field at offset. For pointers,
+// size is the pointer target; for every other type it is the payload size.
+// next is the offset of the payload.
+func (d decoder) control(offset int) (typ dataType, size int, next int, err error) {
+	if offset < 0 || offset >= len(d.buf) {
+		return 0, 0, 0, errOutOfBounds
+	}
+	ctrl := d.buf[offset]
+	offset++
+
+	typ = dataType(ctrl >> 5)
+	if typ == typeExtended {
+		if offset >= len(d.buf) {
+			return 0, 0, 0, errOutOfBounds
+		}
+		typ = dataType(7 + d.buf[offset])
+		offset++
+	}
+
+	if typ == typePointer {
+		n := int((ctrl>>3)&0x3) + 1
+		if offset+n > len(d.buf) {
+			return 0, 0, 0, errOutOfBounds
+		}
+		b := d.buf[offset : offset+n]
+		v := int(ctrl & 0x7)
+		switch n {
+		case 1:
+			size = v<<8 | int(b[0])
+		case 2:
+			size = (v<<16 | int(b[0])<<8 | int(b[1])) + 2048
+		case 3:
+			size = (v<<24 | int(b[0])<<16 | int(b[1])<<8 | int(b[2])) + 526336
+		default:
+			size = int(binary.BigEndian.Uint32(b))
+		}
+		return typ, size, offset + n, nil
+	}
+
+	size = int(ctrl & 0x1f)
+	if size >= 29 {
+		n := size - 28
+		if offset+n > len(d.buf) {
+			return 0, 0, 0, errOutOfBounds
+		}
+		b := d.buf[offset : offset+n]
+		switch n {
+		case 1:
+			size = 29 + int(b[0])
+		case 2:
+			size = 285 + (int(b[0])<<8 | int(b[1]))
+		default:
+			size = 65821 + (int(b[0])<<16 | int(b[1])<<8 | int(b[2]))
+		}
+		offset += n
+	}
+	return typ, size, offset, nil
+}
+
+// payload returns the size bytes starting at offset.
+func (d decoder) payload(offset, size int) ([]byte, error) {
+	if size < 0 || offset+size > len(d.buf) {
+		return nil, errOutOfBounds
+	}
+	return d.buf[offset : offset+size], nil
+}
+
+// decode returns the value at offset and the offset of the field after it.
+// Maps decode to map[string]any, arrays to []any, unsigned integers to
+// uint64, signed integers to int64, and uint128 to its big-endian bytes.
+func (d decoder) decode(offset, depth int) (any, int, error) {
+	if depth > maxDepth {
+		return nil, 0, errors.New("geoip: data nested too deeply")
+	}
+	typ, size, next, err := d.control(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch typ {
+	case typePointer:
+		v, _, err := d.decode(size, depth+1)
+		return v, next, err
+
+	case typeMap:
+		m := make(map[string]any, size)
+		for range size {
+			key, afterKey, err := d.key(next, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			v, afterValue, err := d.decode(afterKey, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			m[string(key)] = v
+			next = afterValue
+		}
+		return m, next, nil
+
+	case typeArray:
+		a := make([]any, 0, size)
+		for range size {
+			v, afterValue, err := d.decode(next, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			a = append(a, v)
+			next = afterValue
+		}
+		return a, next, nil
+
+	case typeBool:
+		return size != 0, next, nil
+	}
+
+	b, err := d.payload(next, size)
+	if err != nil {
+		return nil, 0, err
+	}
+	next += size
+
+	switch typ {
+	case typeString:
+		return string(b), next, nil
+	case typeBytes, typeUint128:
+		return append([]byte(nil), b...), next, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("geoip: invalid double size %d", size)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), next, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("geoip: invalid float size %d", size)
+		}
+		return math.Float32frombits(binary.BigEndian.Uint32(b)), next, nil
+	case typeUint16, typeUint32, typeUint64:
+		if size > 8 {
+			return nil, 0, fmt.Errorf("geoip: invalid integer size %d", size)
+		}
+		var v uint64
+		for _, c := range b {
+			v = v<<8 | uint64(c)
+		}
+		return v, next, nil
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, fmt.Errorf("geoip: invalid int32 size %d", size)
+		}
+		var v uint32
+		for _, c := range b {
+			v = v<<8 | uint32(c)
+		}
+		// Shorter encodings are zero-padded on the left, so the value is
+		// already in two's complement once widened to 32 bits.
+		return int64(int32(v)), next, nil
+	default:
+		return nil, 0, fmt.Errorf("geoip: unexpected data type %d", typ)
+	}
+}
+
+// key reads a map key, which is a string or a pointer to one, without
+// copying it.
+func (d decoder) key(offset, depth int) ([]byte, int, error) {
+	typ, size, next, err := d.control(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	if typ == typePointer {
+		if depth > maxDepth {
+			return nil, 0, errors.New("geoip: data nested too deeply")
+		}
+		key, _, err := d.key(size, depth+1)
+		return key, next, err
+	}
+	if typ != typeString {
+		return nil, 0, fmt.Errorf("geoip: map key has type %d, want string", typ)
+	}
+	b, err := d.payload(next, size)
+	if err != nil {
+		return nil, 0, err
+	}
+	return b, next + size, nil
+}
+
+// skip returns the offset of the field after the one at offset, without
+// decoding it. Pointers are not followed.
+func (d decoder) skip(offset, depth int) (int, error) {
+	if depth > maxDepth {
+		return 0, errors.New("geoip: data nested too deeply")
+	}
+	typ, size, next, err := d.control(offset)
+	if err != nil {
+		return 0, err
+	}
+	switch typ {
+	case typePointer, typeBool:
+		return next, nil
+	case typeMap:
+		size *= 2
+		fallthrough
+	case typeArray:
+		for range size {
+			if next, err = d.skip(next, depth+1); err != nil {
+				return 0, err
+			}
+		}
+		return next, nil
+	default:
+		if next+size > len(d.buf) {
+			return 0, errOutOfBounds
+		}
+		return next + size, nil
+	}
+}
+
+// deref follows pointers starting at offset and returns the offset of the
+// value they resolve to.
+func (d decoder) deref(offset int) (int, error) {
+	for range maxDepth {
+		typ, size, _, err := d.control(offset)
+		if err != nil {
+			return 0, err
+		}
+		if typ != typePointer {
+			return offset, nil
+		}
+		offset = size
+	}
+	return 0, errors.New("geoip: pointer chain too long")
+}
+
+// findString descends through nested maps along path from the value at
+// offset and returns the string found there. It returns false when a key is
+// missing or a value has an unexpected type.
+func (d decoder) findString(offset int, path ...string) (string, bool) {
+	for _, want := range path {
+		var err error
+		if offset, err = d.deref(offset); err != nil {
+			return "", false
+		}
+		typ, size, next, err := d.control(offset)
+		if err != nil || typ != typeMap {
+			return "", false
+		}
+
+		found := false
+		for range size {
+			key, afterKey, err := d.key(next, 0)
+			if err != nil {
+				return "", false
+			}
+			if string(key) == want {
+				offset, found = afterKey, true
+				break
+			}
+			if next, err = d.skip(afterKey, 0); err != nil {
+				return "", false
+			}
+		}
+		if !found {
+			return "", false
+		}
+	}
+
+	offset, err := d.deref(offset)
+	if err != nil {
+		return "", false
+	}
+	typ, size, next, err := d.control(offset)
+	if err != nil || typ != typeString {
+		return "", false
+	}
+	b, err := d.payload(next, size)
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
@@ -0,0 +1,25 @@
+// Package geoip resolves IP addresses to countries using a MaxMind DB
+// (.mmdb) file, such as GeoLite2-Country or GeoIP2-Country, or any database
+// in the same format that stores country.iso_code per network.
+//
+// The database is read fully into memory when opened and never written, so a
+// [DB] is safe for concurrent use. Lookups walk the binary search tree and
+// decode only the fields they need from the data section; they do not
+// allocate for the common case.
+//
+// Only the reader side of the format is implemented, and only what a country
+// lookup needs. The format is described at
+// https://maxmind.github.io/MaxMind-DB/.
+//
+// # Usage
+//
+//	db, err := geoip.Open("/etc/unkey/GeoLite2-Country.mmdb")
+//	if err != nil {
+//	    return err
+//	}
+//	country, ok := db.Country(netip.MustParseAddr("81.2.69.142"))
+//	// country == "GB", ok == true
+//
+// Reloading a newer database means opening a new [DB] and swapping it in;
+// there is no in-place update.
+package geoip
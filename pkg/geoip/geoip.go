@@ -0,0 +1,181 @@
+package geoip
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+)
+
+// metadataMarker precedes the metadata map at the end of every MaxMind DB
+// file. The metadata is located by searching backwards for it.
+var metadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// dataSectionSeparator is the number of zero bytes between the search tree
+// and the data section. Record values pointing into the data section count
+// from the start of the tree plus this separator.
+const dataSectionSeparator = 16
+
+// DB is a MaxMind DB loaded into memory. Create one with [Open] or [New].
+type DB struct {
+	tree       []byte
+	data       decoder
+	nodeCount  uint32
+	recordSize uint16
+	ipVersion  uint16
+	dbType     string
+
+	// ipv4Start is the node reached by following 96 zero bits from the root
+	// of an IPv6 tree, where IPv4 addresses are stored as ::a.b.c.d. Zero
+	// for IPv4-only databases.
+	ipv4Start uint32
+}
+
+// Open reads the database at path into memory.
+func Open(path string) (*DB, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read geoip database: %w", err)
+	}
+	return New(buf)
+}
+
+// New parses a database from buf. The DB keeps a reference to buf, which
+// must not be modified afterwards.
+func New(buf []byte) (*DB, error) {
+	markerAt := bytes.LastIndex(buf, metadataMarker)
+	if markerAt < 0 {
+		return nil, errors.New("geoip: metadata marker not found, not a MaxMind DB file")
+	}
+
+	// Pointers inside the metadata are relative to the start of the
+	// metadata, so it gets its own decoder.
+	meta := decoder{buf: buf[markerAt+len(metadataMarker):]}
+	raw, _, err := meta.decode(0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("geoip: decode metadata: %w", err)
+	}
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return nil, errors.New("geoip: metadata is not a map")
+	}
+
+	nodeCount, ok := m["node_count"].(uint64)
+	if !ok || nodeCount == 0 || nodeCount > 1<<32-1 {
+		return nil, errors.New("geoip: metadata has no valid node_count")
+	}
+	recordSize, _ := m["record_size"].(uint64)
+	if recordSize != 24 && recordSize != 28 && recordSize != 32 {
+		return nil, fmt.Errorf("geoip: unsupported record_size %d", recordSize)
+	}
+	ipVersion, _ := m["ip_version"].(uint64)
+	if ipVersion != 4 && ipVersion != 6 {
+		return nil, fmt.Errorf("geoip: unsupported ip_version %d", ipVersion)
+	}
+	dbType, _ := m["database_type"].(string)
+
+	treeSize := int(nodeCount) * int(recordSize) / 4
+	if treeSize+dataSectionSeparator > markerAt {
+		return nil, errors.New("geoip: search tree exceeds file size")
+	}
+
+	db := &DB{
+		tree:       buf[:treeSize],
+		data:       decoder{buf: buf[treeSize+dataSectionSeparator : markerAt]},
+		nodeCount:  uint32(nodeCount),
+		recordSize: uint16(recordSize),
+		ipVersion:  uint16(ipVersion),
+		dbType:     dbType,
+		ipv4Start:  0,
+	}
+
+	if db.ipVersion == 6 {
+		node := uint32(0)
+		for i := 0; i < 96 && node < db.nodeCount; i++ {
+			node = db.readNode(node, 0)
+		}
+		db.ipv4Start = node
+	}
+
+	return db, nil
+}
+
+// DatabaseType returns the database_type from the metadata, for example
+// "GeoLite2-Country".
+func (db *DB) DatabaseType() string {
+	return db.dbType
+}
+
+// Country returns the ISO 3166-1 alpha-2 code of the country ip is located
+// in, falling back to the country the network is registered in. It returns
+// false when the database has no record for ip or the record carries no
+// country, which is common for private and reserved ranges.
+func (db *DB) Country(ip netip.Addr) (string, bool) {
+	offset, ok := db.lookup(ip)
+	if !ok {
+		return "", false
+	}
+	for _, field := range []string{"country", "registered_country"} {
+		if code, ok := db.data.findString(offset, field, "iso_code"); ok && code != "" {
+			return code, true
+		}
+	}
+	return "", false
+}
+
+// lookup walks the search tree for ip and returns the offset of its record
+// in the data section.
+func (db *DB) lookup(ip netip.Addr) (int, bool) {
+	ip = ip.Unmap()
+
+	var addr []byte
+	node := uint32(0)
+	switch {
+	case ip.Is4():
+		a := ip.As4()
+		addr = a[:]
+		node = db.ipv4Start
+	case ip.Is6() && db.ipVersion == 6:
+		a := ip.As16()
+		addr = a[:]
+	default:
+		return 0, false
+	}
+
+	for i := 0; i < len(addr)*8 && node < db.nodeCount; i++ {
+		bit := (addr[i>>3] >> (7 - uint(i&7))) & 1
+		node = db.readNode(node, bit)
+	}
+
+	// node == nodeCount means "no data"; node < nodeCount means the tree is
+	// deeper than the address, which only happens in corrupt databases.
+	if node <= db.nodeCount {
+		return 0, false
+	}
+	offset := int(node-db.nodeCount) - dataSectionSeparator
+	if offset < 0 || offset >= len(db.data.buf) {
+		return 0, false
+	}
+	return offset, true
+}
+
+// readNode returns the left (bit 0) or right (bit 1) record of node.
+func (db *DB) readNode(node uint32, bit byte) uint32 {
+	switch db.recordSize {
+	case 24:
+		off := int(node)*6 + int(bit)*3
+		b := db.tree[off : off+3]
+		return uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2])
+	case 28:
+		b := db.tree[int(node)*7 : int(node)*7+7]
+		if bit == 0 {
+			return uint32(b[3]&0xF0)<<20 | uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2])
+		}
+		return uint32(b[3]&0x0F)<<24 | uint32(b[4])<<16 | uint32(b[5])<<8 | uint32(b[6])
+	default:
+		off := int(node)*8 + int(bit)*4
+		b := db.tree[off : off+4]
+		return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+	}
+}
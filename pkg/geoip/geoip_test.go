@@ -0,0 +1,271 @@
+package geoip
+
+import (
+	"encoding/binary"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mmdbWriter builds a small MaxMind DB in memory. It supports exactly what
+// the tests need: an IPv6 tree, string/uint/map values, and pointers.
+type mmdbWriter struct {
+	recordSize int
+	// nodes holds left/right records. A record is a node index, emptyRecord,
+	// or dataRecord(i) for the i-th entry in data.
+	nodes [][2]int
+	data  [][]byte
+}
+
+const emptyRecord = -1
+
+func dataRecord(i int) int { return -2 - i }
+
+func newWriter(recordSize int) *mmdbWriter {
+	return &mmdbWriter{recordSize: recordSize, nodes: [][2]int{{emptyRecord, emptyRecord}}, data: nil}
+}
+
+// insert maps prefix to an encoded data section value.
+func (w *mmdbWriter) insert(prefix string, value []byte) {
+	p := netip.MustParsePrefix(prefix)
+	addr := p.Addr().As16()
+	bits := p.Bits()
+	if p.Addr().Is4() {
+		// IPv4 lives at ::a.b.c.d in an IPv6 tree, not at the mapped
+		// ::ffff:a.b.c.d that As16 returns.
+		addr = [16]byte{}
+		v4 := p.Addr().As4()
+		copy(addr[12:], v4[:])
+		bits += 96
+	}
+
+	w.data = append(w.data, value)
+	target := dataRecord(len(w.data) - 1)
+
+	node := 0
+	for i := 0; i < bits; i++ {
+		bit := (addr[i>>3] >> (7 - uint(i&7))) & 1
+		if i == bits-1 {
+			w.nodes[node][bit] = target
+			return
+		}
+		next := w.nodes[node][bit]
+		if next < 0 {
+			w.nodes = append(w.nodes, [2]int{emptyRecord, emptyRecord})
+			next = len(w.nodes) - 1
+			w.nodes[node][bit] = next
+		}
+		node = next
+	}
+}
+
+func (w *mmdbWriter) bytes(t *testing.T) []byte {
+	t.Helper()
+
+	nodeCount := len(w.nodes)
+	var data []byte
+	offsets := make([]int, len(w.data))
+	for i, v := range w.data {
+		offsets[i] = len(data)
+		data = append(data, v...)
+	}
+
+	resolve := func(r int) uint32 {
+		switch {
+		case r == emptyRecord:
+			return uint32(nodeCount)
+		case r < emptyRecord:
+			return uint32(nodeCount + dataSectionSeparator + offsets[-2-r])
+		default:
+			return uint32(r)
+		}
+	}
+
+	var tree []byte
+	for _, n := range w.nodes {
+		left, right := resolve(n[0]), resolve(n[1])
+		switch w.recordSize {
+		case 24:
+			tree = append(tree, byte(left>>16), byte(left>>8), byte(left), byte(right>>16), byte(right>>8), byte(right))
+		case 28:
+			tree = append(tree, byte(left>>16), byte(left>>8), byte(left),
+				byte((left>>20)&0xF0|(right>>24)&0x0F),
+				byte(right>>16), byte(right>>8), byte(right))
+		default:
+			tree = binary.BigEndian.AppendUint32(tree, left)
+			tree = binary.BigEndian.AppendUint32(tree, right)
+		}
+	}
+
+	out := append(tree, make([]byte, dataSectionSeparator)...)
+	out = append(out, data...)
+	out = append(out, metadataMarker...)
+	out = append(out, encMap(map[string][]byte{
+		"node_count":                  encUint(typeUint32, uint64(nodeCount)),
+		"record_size":                 encUint(typeUint16, uint64(w.recordSize)),
+		"ip_version":                  encUint(typeUint16, 6),
+		"database_type":               encString("Test-Country"),
+		"binary_format_major_version": encUint(typeUint16, 2),
+	})...)
+	return out
+}
+
+func encControl(typ dataType, size int) []byte {
+	var b []byte
+	if typ > 7 {
+		b = []byte{byte(size), byte(typ - 7)}
+	} else {
+		b = []byte{byte(typ)<<5 | byte(size)}
+	}
+	return b
+}
+
+func encString(s string) []byte {
+	return append(encControl(typeString, len(s)), s...)
+}
+
+func encUint(typ dataType, v uint64) []byte {
+	var payload []byte
+	for v > 0 {
+		payload = append([]byte{byte(v)}, payload...)
+		v >>= 8
+	}
+	return append(encControl(typ, len(payload)), payload...)
+}
+
+func encPointer(offset int) []byte {
+	return []byte{byte(typePointer)<<5 | byte(offset>>8&0x7), byte(offset)}
+}
+
+func encMap(m map[string][]byte) []byte {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := encControl(typeMap, len(m))
+	for _, k := range keys {
+		out = append(out, encString(k)...)
+		out = append(out, m[k]...)
+	}
+	return out
+}
+
+func countryRecord(code string) []byte {
+	return encMap(map[string][]byte{
+		"continent": encMap(map[string][]byte{"code": encString("EU")}),
+		"country": encMap(map[string][]byte{
+			"geoname_id": encUint(typeUint32, 2635167),
+			"iso_code":   encString(code),
+			"names":      encMap(map[string][]byte{"en": encString("Somewhere")}),
+		}),
+	})
+}
+
+func TestCountry(t *testing.T) {
+	t.Parallel()
+
+	for _, recordSize := range []int{24, 28, 32} {
+		w := newWriter(recordSize)
+		w.insert("81.2.69.0/24", countryRecord("GB"))
+		w.insert("2001:db8::/32", countryRecord("DE"))
+		// Only the registered country is known, as for some anycast ranges.
+		w.insert("198.51.100.0/24", encMap(map[string][]byte{
+			"registered_country": encMap(map[string][]byte{"iso_code": encString("US")}),
+		}))
+		// The key "country" is stored once and referenced through a pointer.
+		// Data offsets count from the start of the data section, so the
+		// pointer targets the key inside the first record.
+		first := countryRecord("GB")
+		keyOffset := len(encControl(typeMap, 2)) + len(encString("continent")) +
+			len(encMap(map[string][]byte{"code": encString("EU")}))
+		require.Equal(t, encString("country"), first[keyOffset:keyOffset+len(encString("country"))])
+		w.insert("203.0.113.0/24", append(append(encControl(typeMap, 1), encPointer(keyOffset)...),
+			encMap(map[string][]byte{"iso_code": encString("JP")})...))
+		w.insert("192.0.2.0/24", encMap(map[string][]byte{"city": encString("nowhere")}))
+
+		db, err := New(w.bytes(t))
+		require.NoError(t, err)
+		require.Equal(t, "Test-Country", db.DatabaseType())
+
+		tests := []struct {
+			ip   string
+			want string
+			ok   bool
+		}{
+			{ip: "81.2.69.142", want: "GB", ok: true},
+			{ip: "::ffff:81.2.69.1", want: "GB", ok: true},
+			{ip: "81.2.70.1", want: "", ok: false},
+			{ip: "2001:db8:1::1", want: "DE", ok: true},
+			{ip: "2001:db9::1", want: "", ok: false},
+			{ip: "198.51.100.7", want: "US", ok: true},
+			{ip: "203.0.113.9", want: "JP", ok: true},
+			{ip: "192.0.2.1", want: "", ok: false},
+		}
+		for _, tt := range tests {
+			got, ok := db.Country(netip.MustParseAddr(tt.ip))
+			require.Equal(t, tt.ok, ok, "record size %d, ip %s", recordSize, tt.ip)
+			require.Equal(t, tt.want, got, "record size %d, ip %s", recordSize, tt.ip)
+		}
+	}
+}
+
+func TestOpen(t *testing.T) {
+	t.Parallel()
+
+	w := newWriter(24)
+	w.insert("81.2.69.0/24", countryRecord("GB"))
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+	require.NoError(t, os.WriteFile(path, w.bytes(t), 0o600))
+
+	db, err := Open(path)
+	require.NoError(t, err)
+	got, ok := db.Country(netip.MustParseAddr("81.2.69.1"))
+	require.True(t, ok)
+	require.Equal(t, "GB", got)
+
+	_, err = Open(filepath.Join(t.TempDir(), "missing.mmdb"))
+	require.Error(t, err)
+}
+
+func TestNew_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := New([]byte("not a database"))
+	require.ErrorContains(t, err, "metadata marker not found")
+
+	bad := append([]byte{}, metadataMarker...)
+	bad = append(bad, encMap(map[string][]byte{
+		"node_count":  encUint(typeUint32, 10),
+		"record_size": encUint(typeUint16, 24),
+		"ip_version":  encUint(typeUint16, 6),
+	})...)
+	_, err = New(bad)
+	require.ErrorContains(t, err, "search tree exceeds file size")
+
+	bad = append([]byte{}, metadataMarker...)
+	bad = append(bad, encMap(map[string][]byte{
+		"node_count":  encUint(typeUint32, 1),
+		"record_size": encUint(typeUint16, 20),
+		"ip_version":  encUint(typeUint16, 6),
+	})...)
+	_, err = New(bad)
+	require.ErrorContains(t, err, "unsupported record_size")
+}
+
+func TestDecode_Truncated(t *testing.T) {
+	t.Parallel()
+
+	full := countryRecord("GB")
+	for i := range len(full) {
+		d := decoder{buf: full[:i]}
+		_, _, err := d.decode(0, 0)
+		require.Error(t, err, "truncated at %d", i)
+		// Must not panic; the result depends on where the cut falls.
+		_, _ = d.findString(0, "country", "iso_code")
+	}
+}
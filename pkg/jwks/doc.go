@@ -0,0 +1,29 @@
+// Package jwks verifies JWTs against the signing keys an identity provider
+// publishes, as a JWKS document, through OIDC discovery or as a single PEM
+// public key.
+//
+// A [KeySet] is one immutable snapshot of a provider's keys. A [Source]
+// holds the current set for one configuration: remote sets are fetched on
+// first use, refreshed once their TTL lapses and refetched when a token names
+// a key the set does not contain, so signing-key rotations are picked up
+// without a configuration change. A failed refresh keeps the previous set in
+// service, so a provider outage does not reject tokens signed by keys that
+// are already known.
+//
+// Errors from [Source.Verify] wrap [ErrUnavailable] when no key set could be
+// fetched at all; any other error is a rejection of the token itself.
+//
+// Example usage:
+//
+//	source, err := jwks.NewSource(jwks.SourceConfig{
+//	    JWKSURI:       "https://auth.example.com/.well-known/jwks.json",
+//	    IssuerURL:     "",
+//	    PublicKeyPEM:  "",
+//	    Algorithms:    jwks.SupportedAlgorithms,
+//	    VerifyOptions: []jwt.VerifyOption{jwt.WithIssuer("https://auth.example.com/")},
+//	    TTL:           5 * time.Minute,
+//	    HTTPClient:    ssrf.NewClient(ssrf.Config{Timeout: jwks.FetchTimeout, AllowPrivateNetworks: false, FollowRedirects: true}),
+//	    Clock:         clock.New(),
+//	})
+//	claims, err := source.Verify(ctx, header.KID, header.Alg, token)
+package jwks
@@ -0,0 +1,133 @@
+package jwks
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/unkeyed/unkey/pkg/jwt"
+)
+
+// SupportedAlgorithms are the signing algorithms pkg/jwt can verify with a
+// public key.
+var SupportedAlgorithms = []string{"RS256", "ES256"}
+
+// Claims is the payload type tokens are verified into. Which claims matter
+// is up to the caller, so they stay untyped.
+type Claims = map[string]any
+
+// key verifies tokens signed by one key with one algorithm.
+type key struct {
+	alg      string
+	verifier jwt.Verifier[Claims]
+}
+
+// KeySet holds the verification keys of one provider. Sets are immutable; a
+// [Source] replaces its set as a whole.
+type KeySet struct {
+	// byKID selects the key named by a token's kid header.
+	byKID map[string]key
+	// ordered preserves document order for tokens that name no kid.
+	ordered []key
+}
+
+// Contains reports whether the set has a key for the kid.
+func (s *KeySet) Contains(kid string) bool {
+	_, ok := s.byKID[kid]
+	return ok
+}
+
+// Len returns the number of usable keys in the set.
+func (s *KeySet) Len() int {
+	return len(s.ordered)
+}
+
+// Verify checks the token against the key its kid names, or against every
+// key using the token's algorithm when the token names no kid.
+func (s *KeySet) Verify(kid, alg, token string) (Claims, error) {
+	if kid != "" {
+		k, ok := s.byKID[kid]
+		if !ok {
+			return nil, fmt.Errorf("no signing key matches kid %q", kid)
+		}
+		if k.alg != alg {
+			return nil, fmt.Errorf("signing key %q does not accept algorithm %s", kid, alg)
+		}
+		return k.verifier.Verify(token)
+	}
+
+	lastErr := fmt.Errorf("no signing key accepts algorithm %s", alg)
+	for _, k := range s.ordered {
+		if k.alg != alg {
+			continue
+		}
+		claims, err := k.verifier.Verify(token)
+		if err == nil {
+			return claims, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// FromJWKS indexes a verifier per usable signing key of doc. Keys for
+// anything but signing, or for an algorithm not in algorithms, are left out.
+// A malformed entry is skipped rather than failing the whole set, so one bad
+// key cannot invalidate the usable keys published next to it; only a
+// document with no usable key at all is an error.
+func FromJWKS(doc jwt.JWKS, algorithms []string, opts ...jwt.VerifyOption) (*KeySet, error) {
+	set := &KeySet{
+		byKID:   map[string]key{},
+		ordered: nil,
+	}
+	var keyErrs []error
+	for _, jwk := range doc.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		publicKeyPEM, alg, err := jwk.PublicKeyPEM()
+		if err != nil {
+			keyErrs = append(keyErrs, err)
+			continue
+		}
+		if alg == "" || !slices.Contains(algorithms, alg) || (jwk.Algorithm != "" && jwk.Algorithm != alg) {
+			continue
+		}
+		verifier, err := jwt.NewPublicKeyVerifier[Claims](alg, publicKeyPEM, opts...)
+		if err != nil {
+			keyErrs = append(keyErrs, fmt.Errorf("JWKS key %q: %w", jwk.KeyID, err))
+			continue
+		}
+		k := key{alg: alg, verifier: verifier}
+		set.ordered = append(set.ordered, k)
+		if jwk.KeyID != "" {
+			set.byKID[jwk.KeyID] = k
+		}
+	}
+	if len(set.ordered) == 0 {
+		return nil, errors.Join(fmt.Errorf("JWKS contains no usable signing keys for %s", strings.Join(algorithms, ", ")), errors.Join(keyErrs...))
+	}
+	return set, nil
+}
+
+// FromPEM builds the single-key set for a static public key. The algorithm
+// is inferred from the key type and must be one of algorithms.
+func FromPEM(publicKeyPEM string, algorithms []string, opts ...jwt.VerifyOption) (*KeySet, error) {
+	alg, err := jwt.PublicKeyAlgorithm(publicKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(algorithms, alg) {
+		return nil, fmt.Errorf("public key is a %s key but only %s are allowed", alg, strings.Join(algorithms, ", "))
+	}
+
+	verifier, err := jwt.NewPublicKeyVerifier[Claims](alg, publicKeyPEM, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &KeySet{
+		byKID:   map[string]key{},
+		ordered: []key{{alg: alg, verifier: verifier}},
+	}, nil
+}
@@ -0,0 +1,135 @@
+package jwks
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/jwt"
+)
+
+func rsaJWK(kid string, key *rsa.PrivateKey) jwt.JWK {
+	return jwt.JWK{
+		KeyType:  "RSA",
+		KeyID:    kid,
+		Use:      "sig",
+		Modulus:  base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		Exponent: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func sign(t *testing.T, key *rsa.PrivateKey, claims jwt.RegisteredClaims) string {
+	t.Helper()
+	signer, err := jwt.NewRS256Signer[jwt.RegisteredClaims](string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})))
+	require.NoError(t, err)
+	token, err := signer.Sign(claims)
+	require.NoError(t, err)
+	return token
+}
+
+func validClaims() jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Issuer:    "https://auth.example.com/",
+		Subject:   "user_123",
+		Audience:  []string{"https://api.example.com"},
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestFromJWKS(t *testing.T) {
+	t.Parallel()
+
+	first, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	second, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	set, err := FromJWKS(jwt.JWKS{Keys: []jwt.JWK{
+		rsaJWK("first", first),
+		rsaJWK("second", second),
+		{KeyType: "oct", KeyID: "hmac"},
+		{KeyType: "RSA", KeyID: "encryption", Use: "enc", Modulus: "AQAB", Exponent: "AQAB"},
+	}}, SupportedAlgorithms,
+		jwt.WithIssuer("https://auth.example.com/"),
+		jwt.WithAudience("https://api.example.com"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 2, set.Len())
+	require.True(t, set.Contains("first"))
+	require.True(t, set.Contains("second"))
+	require.False(t, set.Contains("hmac"))
+
+	t.Run("tokens without kid try every key", func(t *testing.T) {
+		claims, err := set.Verify("", "RS256", sign(t, second, validClaims()))
+		require.NoError(t, err)
+		require.Equal(t, "user_123", claims["sub"])
+	})
+
+	t.Run("kid selects the key", func(t *testing.T) {
+		token := sign(t, second, validClaims())
+		_, err := set.Verify("second", "RS256", token)
+		require.NoError(t, err)
+		_, err = set.Verify("first", "RS256", token)
+		require.Error(t, err)
+		_, err = set.Verify("unknown", "RS256", token)
+		require.Error(t, err)
+	})
+
+	t.Run("algorithm must match the key", func(t *testing.T) {
+		_, err := set.Verify("first", "ES256", sign(t, first, validClaims()))
+		require.Error(t, err)
+	})
+
+	t.Run("audience is enforced", func(t *testing.T) {
+		claims := validClaims()
+		claims.Audience = []string{"https://other.example.com"}
+		_, err := set.Verify("first", "RS256", sign(t, first, claims))
+		require.Error(t, err)
+	})
+
+	t.Run("expiry is reported", func(t *testing.T) {
+		claims := validClaims()
+		claims.ExpiresAt = time.Now().Add(-time.Hour).Unix()
+		_, err := set.Verify("first", "RS256", sign(t, first, claims))
+		require.ErrorIs(t, err, jwt.ErrTokenExpired)
+	})
+
+	t.Run("disallowed algorithms are left out", func(t *testing.T) {
+		_, err := FromJWKS(jwt.JWKS{Keys: []jwt.JWK{rsaJWK("first", first)}}, []string{"ES256"})
+		require.Error(t, err)
+	})
+}
+
+func TestFromJWKS_NoUsableKeys(t *testing.T) {
+	t.Parallel()
+
+	_, err := FromJWKS(jwt.JWKS{Keys: []jwt.JWK{{KeyType: "oct", KeyID: "hmac"}}}, SupportedAlgorithms)
+	require.Error(t, err)
+}
+
+func TestFromPEM(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicKeyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	set, err := FromPEM(publicKeyPEM, SupportedAlgorithms)
+	require.NoError(t, err)
+	_, err = set.Verify("", "RS256", sign(t, key, validClaims()))
+	require.NoError(t, err)
+
+	_, err = FromPEM(publicKeyPEM, []string{"ES256"})
+	require.ErrorContains(t, err, "RS256")
+}
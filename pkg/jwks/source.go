@@ -0,0 +1,338 @@
+package jwks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/unkeyed/unkey/pkg/assert"
+	"github.com/unkeyed/unkey/pkg/clock"
+	"github.com/unkeyed/unkey/pkg/jwt"
+)
+
+const (
+	// FetchTimeout bounds a single JWKS or discovery document fetch,
+	// discovery included.
+	FetchTimeout = 10 * time.Second
+
+	// RefetchMinInterval rate-limits fetches triggered by tokens naming an
+	// unknown signing key, so a storm of forged tokens cannot turn us into a
+	// load generator against the provider.
+	RefetchMinInterval = time.Minute
+
+	// maxDocumentBytes caps how much of a JWKS or discovery response is read.
+	maxDocumentBytes = 256 * 1024
+)
+
+// ErrUnavailable is wrapped by the errors of [Source.Verify] when no key set
+// could be fetched.
+var ErrUnavailable = errors.New("signing keys unavailable")
+
+// SourceConfig configures a [Source]. Exactly one of JWKSURI, IssuerURL and
+// PublicKeyPEM must be set.
+type SourceConfig struct {
+	// JWKSURI is the URL of the provider's JWKS document.
+	JWKSURI string
+
+	// IssuerURL is the provider's OIDC issuer. Its discovery document names
+	// the JWKS URI, and must name the same issuer.
+	IssuerURL string
+
+	// PublicKeyPEM is a single static public key. It is parsed right away,
+	// so a bad key fails [NewSource].
+	PublicKeyPEM string
+
+	// Algorithms are the signing algorithms tokens may use. Keys for any
+	// other algorithm are left out of the set.
+	Algorithms []string
+
+	// VerifyOptions are applied to every key, such as the required issuer
+	// and audience.
+	VerifyOptions []jwt.VerifyOption
+
+	// TTL is how long a fetched set is used before it is refreshed.
+	TTL time.Duration
+
+	// HTTPClient fetches the documents. The URLs are usually customer
+	// supplied, so pass a client from pkg/ssrf.
+	HTTPClient *http.Client
+
+	// Clock measures the TTL and the refetch interval.
+	Clock clock.Clock
+}
+
+// fetched is one key set together with when it was fetched.
+type fetched struct {
+	set *KeySet
+	at  time.Time
+}
+
+// Source resolves the signing keys of one provider configuration. It is
+// safe for concurrent use.
+type Source struct {
+	jwksURI    string
+	issuerURL  string
+	algorithms []string
+	opts       []jwt.VerifyOption
+	ttl        time.Duration
+	httpClient *http.Client
+	clock      clock.Clock
+
+	// current is read lock-free on every verification. fetchMu serializes
+	// only the HTTP fetch and its backoff bookkeeping, so a slow provider
+	// never delays tokens that verify against the cached set.
+	current     atomic.Pointer[fetched]
+	fetchMu     sync.Mutex
+	lastAttempt time.Time // guarded by fetchMu
+}
+
+// NewSource creates a source. Remote sets are not fetched here; the first
+// token to need them triggers the fetch.
+func NewSource(config SourceConfig) (*Source, error) {
+	err := assert.All(
+		assert.NotEmpty(config.Algorithms, "Algorithms must not be empty"),
+		assert.NotNil(config.HTTPClient, "HTTPClient must not be nil"),
+		assert.NotNil(config.Clock, "Clock must not be nil"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Source{
+		jwksURI:     config.JWKSURI,
+		issuerURL:   config.IssuerURL,
+		algorithms:  config.Algorithms,
+		opts:        config.VerifyOptions,
+		ttl:         config.TTL,
+		httpClient:  config.HTTPClient,
+		clock:       config.Clock,
+		current:     atomic.Pointer[fetched]{},
+		fetchMu:     sync.Mutex{},
+		lastAttempt: time.Time{},
+	}
+
+	set := 0
+	for _, v := range []string{config.JWKSURI, config.IssuerURL, config.PublicKeyPEM} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, errors.New("exactly one of a JWKS URI, an OIDC issuer or a public key is required")
+	}
+
+	if config.PublicKeyPEM != "" {
+		keySet, err := FromPEM(config.PublicKeyPEM, config.Algorithms, config.VerifyOptions...)
+		if err != nil {
+			return nil, err
+		}
+		s.current.Store(&fetched{set: keySet, at: s.clock.Now()})
+	}
+
+	return s, nil
+}
+
+// Algorithms returns the signing algorithms tokens may use.
+func (s *Source) Algorithms() []string {
+	return s.algorithms
+}
+
+// Verify checks the token's signature and registered claims against the
+// source's keys. kid and alg come from the token's header.
+func (s *Source) Verify(ctx context.Context, kid, alg, token string) (Claims, error) {
+	set, err := s.load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	claims, verifyErr := set.Verify(kid, alg, token)
+	if verifyErr == nil {
+		return claims, nil
+	}
+
+	// A refetch can only help when the signing key may be absent from the
+	// cached set. A key the set already contains fails identically against a
+	// fresh fetch, so those rejections trigger nothing.
+	if s.fetchable() && (kid == "" || !set.Contains(kid)) {
+		if fresh, changed := s.refetch(ctx, set); changed {
+			if claims, retryErr := fresh.Verify(kid, alg, token); retryErr == nil {
+				return claims, nil
+			}
+		}
+	}
+
+	return nil, verifyErr
+}
+
+// fetchable reports whether keys come from a remote document rather than a
+// static PEM key.
+func (s *Source) fetchable() bool {
+	return s.jwksURI != "" || s.issuerURL != ""
+}
+
+// load returns the current key set, fetching it on first use. A set older
+// than the TTL is refreshed by whichever caller gets there first; concurrent
+// callers keep verifying against the stale set in the meantime, and a failed
+// refresh keeps it in service.
+func (s *Source) load(ctx context.Context) (*KeySet, error) {
+	current := s.current.Load()
+	if current == nil {
+		set, _, err := s.fetchLatest(ctx, nil)
+		return set, err
+	}
+	if !s.fetchable() || s.clock.Now().Sub(current.at) < s.ttl {
+		return current.set, nil
+	}
+	if !s.fetchMu.TryLock() {
+		return current.set, nil
+	}
+	defer s.fetchMu.Unlock()
+	if latest := s.current.Load(); latest != current {
+		return latest.set, nil
+	}
+	s.lastAttempt = s.clock.Now()
+	set, err := s.fetch(ctx)
+	if err != nil {
+		return current.set, nil
+	}
+	s.current.Store(&fetched{set: set, at: s.clock.Now()})
+	return set, nil
+}
+
+// refetch refreshes the key set after a token failed against seen. It returns
+// the freshest available set and whether it differs from seen.
+func (s *Source) refetch(ctx context.Context, seen *KeySet) (*KeySet, bool) {
+	set, changed, err := s.fetchLatest(ctx, seen)
+	if err != nil {
+		return seen, false
+	}
+	return set, changed
+}
+
+// fetchLatest serializes fetches behind fetchMu. seen is the set the caller
+// already failed against, or nil on first use. Once a set has been served,
+// fetch attempts are rate-limited to one per RefetchMinInterval; before the
+// first success every caller retries, so a transient cold-start failure does
+// not wedge verification for the full interval.
+func (s *Source) fetchLatest(ctx context.Context, seen *KeySet) (*KeySet, bool, error) {
+	s.fetchMu.Lock()
+	defer s.fetchMu.Unlock()
+
+	// A fetch may have completed while this goroutine waited for the lock.
+	current := s.current.Load()
+	if current != nil && current.set != seen {
+		return current.set, true, nil
+	}
+
+	if current != nil && !s.lastAttempt.IsZero() && s.clock.Now().Sub(s.lastAttempt) < RefetchMinInterval {
+		return seen, false, nil
+	}
+	s.lastAttempt = s.clock.Now()
+
+	set, err := s.fetch(ctx)
+	if err != nil {
+		if seen != nil {
+			return seen, false, nil
+		}
+		return nil, false, err
+	}
+	s.current.Store(&fetched{set: set, at: s.clock.Now()})
+	return set, true, nil
+}
+
+// fetch resolves the JWKS URI, through OIDC discovery when configured, and
+// builds a key set from the document it serves.
+func (s *Source) fetch(ctx context.Context) (*KeySet, error) {
+	ctx, cancel := context.WithTimeout(ctx, FetchTimeout)
+	defer cancel()
+
+	jwksURI := s.jwksURI
+	if s.issuerURL != "" {
+		discovered, err := s.discover(ctx)
+		if err != nil {
+			return nil, err
+		}
+		jwksURI = discovered
+	}
+
+	var doc jwt.JWKS
+	if err := s.getJSON(ctx, jwksURI, &doc); err != nil {
+		return nil, fmt.Errorf("fetch JWKS: %w", err)
+	}
+	return FromJWKS(doc, s.algorithms, s.opts...)
+}
+
+// discoveryDocument is the subset of an OpenID Provider configuration the
+// source needs.
+type discoveryDocument struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// discover fetches the OIDC discovery document and returns its jwks_uri. The
+// document's issuer must match the configured issuer, as required by OpenID
+// Connect Discovery 1.0 Section 4.3, so a compromised or misrouted discovery
+// endpoint cannot substitute another provider's keys.
+func (s *Source) discover(ctx context.Context) (string, error) {
+	var doc discoveryDocument
+	url := strings.TrimSuffix(s.issuerURL, "/") + "/.well-known/openid-configuration"
+	if err := s.getJSON(ctx, url, &doc); err != nil {
+		return "", fmt.Errorf("fetch OIDC discovery document: %w", err)
+	}
+	if strings.TrimSuffix(doc.Issuer, "/") != strings.TrimSuffix(s.issuerURL, "/") {
+		return "", fmt.Errorf("OIDC discovery issuer %q does not match configured issuer %q", doc.Issuer, s.issuerURL)
+	}
+	if err := ValidateURL(doc.JWKSURI); err != nil {
+		return "", fmt.Errorf("OIDC discovery jwks_uri: %w", err)
+	}
+	return doc.JWKSURI, nil
+}
+
+// getJSON fetches url and decodes the JSON response body into v.
+func (s *Source) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxDocumentBytes)).Decode(v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// ValidateURL checks that a JWKS or discovery URL is an absolute https URL.
+// Plain http would let anyone on the path substitute signing keys.
+func ValidateURL(raw string) error {
+	if raw == "" {
+		return errors.New("URL must not be empty")
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", raw, err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("URL %q must be an absolute https URL", raw)
+	}
+	return nil
+}
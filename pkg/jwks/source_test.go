@@ -0,0 +1,170 @@
+package jwks
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/clock"
+	"github.com/unkeyed/unkey/pkg/jwt"
+)
+
+// provider serves a JWKS document whose keys can be swapped and counts how
+// often it is fetched.
+type provider struct {
+	mu      sync.Mutex
+	keys    []jwt.JWK
+	down    bool
+	fetches atomic.Int32
+	server  *httptest.Server
+}
+
+func newProvider(t *testing.T, keys ...jwt.JWK) *provider {
+	t.Helper()
+	p := &provider{keys: keys}
+	p.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		p.fetches.Add(1)
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.down {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(jwt.JWKS{Keys: p.keys})
+	}))
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *provider) set(down bool, keys ...jwt.JWK) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.down = down
+	if keys != nil {
+		p.keys = keys
+	}
+}
+
+func newTestSource(t *testing.T, p *provider, clk clock.Clock) *Source {
+	t.Helper()
+	source, err := NewSource(SourceConfig{
+		JWKSURI:       p.server.URL,
+		IssuerURL:     "",
+		PublicKeyPEM:  "",
+		Algorithms:    SupportedAlgorithms,
+		VerifyOptions: []jwt.VerifyOption{jwt.WithIssuer("https://auth.example.com/")},
+		TTL:           time.Hour,
+		HTTPClient:    p.server.Client(),
+		Clock:         clk,
+	})
+	require.NoError(t, err)
+	return source
+}
+
+func TestSource(t *testing.T) {
+	ctx := context.Background()
+
+	first, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	second, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	t.Run("fetches once and caches", func(t *testing.T) {
+		p := newProvider(t, rsaJWK("first", first))
+		source := newTestSource(t, p, clock.NewTestClock())
+
+		for range 3 {
+			_, err := source.Verify(ctx, "first", "RS256", sign(t, first, validClaims()))
+			require.NoError(t, err)
+		}
+		require.Equal(t, int32(1), p.fetches.Load())
+	})
+
+	t.Run("unknown kid refetches at most once per interval", func(t *testing.T) {
+		clk := clock.NewTestClock()
+		p := newProvider(t, rsaJWK("first", first))
+		source := newTestSource(t, p, clk)
+
+		_, err := source.Verify(ctx, "first", "RS256", sign(t, first, validClaims()))
+		require.NoError(t, err)
+
+		p.set(false, rsaJWK("first", first), rsaJWK("second", second))
+		_, err = source.Verify(ctx, "second", "RS256", sign(t, second, validClaims()))
+		require.Error(t, err, "refetch is throttled right after the first fetch")
+
+		clk.Tick(RefetchMinInterval)
+		_, err = source.Verify(ctx, "second", "RS256", sign(t, second, validClaims()))
+		require.NoError(t, err)
+		require.Equal(t, int32(2), p.fetches.Load())
+	})
+
+	t.Run("unreachable provider is unavailable", func(t *testing.T) {
+		p := newProvider(t)
+		p.set(true)
+		source := newTestSource(t, p, clock.NewTestClock())
+
+		_, err := source.Verify(ctx, "first", "RS256", sign(t, first, validClaims()))
+		require.ErrorIs(t, err, ErrUnavailable)
+	})
+
+	t.Run("failed refresh keeps the previous set", func(t *testing.T) {
+		clk := clock.NewTestClock()
+		p := newProvider(t, rsaJWK("first", first))
+		source := newTestSource(t, p, clk)
+
+		_, err := source.Verify(ctx, "first", "RS256", sign(t, first, validClaims()))
+		require.NoError(t, err)
+
+		p.set(true)
+		clk.Tick(2 * time.Hour)
+		_, err = source.Verify(ctx, "first", "RS256", sign(t, first, validClaims()))
+		require.NoError(t, err)
+		require.Equal(t, int32(2), p.fetches.Load())
+	})
+
+	t.Run("rejections are not unavailable", func(t *testing.T) {
+		p := newProvider(t, rsaJWK("first", first))
+		source := newTestSource(t, p, clock.NewTestClock())
+
+		claims := validClaims()
+		claims.Issuer = "https://other.example.com/"
+		_, err := source.Verify(ctx, "first", "RS256", sign(t, first, claims))
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrUnavailable)
+	})
+}
+
+func TestNewSource_RequiresExactlyOneSource(t *testing.T) {
+	config := SourceConfig{
+		JWKSURI:       "https://auth.example.com/jwks.json",
+		IssuerURL:     "https://auth.example.com/",
+		PublicKeyPEM:  "",
+		Algorithms:    SupportedAlgorithms,
+		VerifyOptions: nil,
+		TTL:           time.Hour,
+		HTTPClient:    http.DefaultClient,
+		Clock:         clock.NewTestClock(),
+	}
+	_, err := NewSource(config)
+	require.Error(t, err)
+
+	config.JWKSURI = ""
+	config.IssuerURL = ""
+	_, err = NewSource(config)
+	require.Error(t, err)
+}
+
+func TestValidateURL(t *testing.T) {
+	require.NoError(t, ValidateURL("https://auth.example.com/jwks.json"))
+	require.Error(t, ValidateURL(""))
+	require.Error(t, ValidateURL("http://auth.example.com/jwks.json"))
+	require.Error(t, ValidateURL("/jwks.json"))
+}
@@ -1,6 +1,8 @@
 package jwt
 
 import (
+	"encoding/json"
+	"fmt"
 	"slices"
 	"time"
 
@@ -109,10 +111,11 @@ func (c RegisteredClaims) validate(now time.Time, cfg *verifyConfig) error {
 		}
 	}
 
-	if c.ExpiresAt != 0 && now.Unix() > c.ExpiresAt {
+	leeway := int64(cfg.leeway / time.Second)
+	if c.ExpiresAt != 0 && now.Unix() > c.ExpiresAt+leeway {
 		return ErrTokenExpired
 	}
-	if c.NotBefore != 0 && now.Unix() < c.NotBefore {
+	if c.NotBefore != 0 && now.Unix() < c.NotBefore-leeway {
 		return ErrTokenNotYetValid
 	}
 
@@ -122,15 +125,57 @@ func (c RegisteredClaims) validate(now time.Time, cfg *verifyConfig) error {
 	if cfg.audience != "" && !slices.Contains(c.Audience, cfg.audience) {
 		return ErrInvalidAudience
 	}
+	if len(cfg.audiences) > 0 && !slices.ContainsFunc(c.Audience, func(aud string) bool {
+		return slices.Contains(cfg.audiences, aud)
+	}) {
+		return ErrInvalidAudience
+	}
 
 	return nil
 }
 
+// decodeRegisteredClaims unmarshals the registered claims from a token payload
+// for validation.
+//
+// RFC 7519 allows aud to be either a single string or an array of strings.
+// [RegisteredClaims.Audience] only accepts the array form so signed tokens
+// stay canonical, but many identity providers emit a bare string for a single
+// audience. Validation accepts both forms so such tokens are not rejected
+// before their audience is even checked.
+func decodeRegisteredClaims(payloadJSON []byte) (RegisteredClaims, error) {
+	type registeredAlias RegisteredClaims
+	var wire struct {
+		registeredAlias
+		Audience json.RawMessage `json:"aud,omitempty"`
+	}
+	if err := json.Unmarshal(payloadJSON, &wire); err != nil {
+		return RegisteredClaims{}, err
+	}
+
+	claims := RegisteredClaims(wire.registeredAlias)
+	claims.Audience = nil
+	if len(wire.Audience) == 0 || string(wire.Audience) == "null" {
+		return claims, nil
+	}
+
+	var single string
+	if err := json.Unmarshal(wire.Audience, &single); err == nil {
+		claims.Audience = []string{single}
+		return claims, nil
+	}
+	if err := json.Unmarshal(wire.Audience, &claims.Audience); err != nil {
+		return RegisteredClaims{}, fmt.Errorf("aud must be a string or an array of strings: %w", err)
+	}
+	return claims, nil
+}
+
 // verifyConfig holds configuration for token verification.
 type verifyConfig struct {
-	issuer   string
-	audience string
-	clock    clock.Clock
+	issuer    string
+	audience  string
+	audiences []string
+	leeway    time.Duration
+	clock     clock.Clock
 }
 
 // VerifyOption configures token verification behavior.
@@ -152,6 +197,27 @@ func WithAudience(aud string) VerifyOption {
 	}
 }
 
+// WithAnyAudience requires tokens to include at least one of the given
+// audiences in their aud claim. Use it when a verifier serves several
+// services that share an identity provider. If none of the values is present,
+// verification fails with [ErrInvalidAudience]. Calling it with no values
+// disables the check.
+func WithAnyAudience(auds ...string) VerifyOption {
+	return func(cfg *verifyConfig) {
+		cfg.audiences = auds
+	}
+}
+
+// WithLeeway tolerates clock skew between the token issuer and the verifier
+// when validating exp and nbf. A token is accepted until leeway after its exp
+// and from leeway before its nbf. Leeway is applied at second granularity
+// because the temporal claims are Unix seconds.
+func WithLeeway(d time.Duration) VerifyOption {
+	return func(cfg *verifyConfig) {
+		cfg.leeway = d
+	}
+}
+
 // WithClock sets a custom clock for temporal claims validation.
 // This is primarily useful for testing with fixed or controlled time.
 func WithClock(c clock.Clock) VerifyOption {
@@ -13,6 +13,10 @@
 // authentication where signing and verification happen on different systems.
 // The private key signs tokens; the public key verifies them.
 //
+// ES256 (ECDSA using P-256 and SHA-256) is the elliptic-curve counterpart to
+// RS256 with much smaller keys and signatures. Many identity providers sign
+// access tokens with it by default.
+//
 // HS256 (HMAC with SHA-256) is appropriate when both parties share a secret,
 // such as a single service signing and verifying its own tokens. The same
 // secret is used for both signing and verification.
@@ -20,8 +24,8 @@
 // # Security Model
 //
 // The verifier checks the algorithm in the token header and rejects tokens that
-// don't match the expected algorithm. An [HS256Verifier] rejects RS256 tokens and
-// vice versa. Tokens with alg=none are always rejected.
+// don't match the expected algorithm. An [HS256Verifier] rejects RS256 and ES256
+// tokens, an [RS256Verifier] rejects HS256 and ES256 tokens, and so on. Tokens with alg=none are always rejected.
 //
 // Signature verification uses constant-time comparison via [crypto/hmac.Equal] for
 // HS256 to prevent timing attacks. RS256 uses Go's standard [crypto/rsa] verification.
@@ -30,13 +34,14 @@
 //   - Size limits on string claims to prevent denial of service (max 255 bytes each)
 //   - Temporal validation of exp (expiration) and nbf (not before) timestamps
 //   - Optional issuer validation via [WithIssuer]
-//   - Optional audience validation via [WithAudience]
+//   - Optional audience validation via [WithAudience] or [WithAnyAudience]
+//   - Optional clock skew tolerance via [WithLeeway]
 //
 // # Key Types
 //
 // [Signer] and [Verifier] are the main interfaces. Use [NewHS256Signer] and
 // [NewHS256Verifier] for symmetric signing, or [NewRS256Signer] and [NewRS256Verifier]
-// for asymmetric signing.
+// (respectively [NewES256Signer] and [NewES256Verifier]) for asymmetric signing.
 //
 // [RegisteredClaims] contains the standard JWT claims (iss, sub, aud, exp, nbf, iat, jti).
 // Embed it in your custom claims struct to automatically include these fields.
@@ -121,14 +126,13 @@
 // # Interoperability
 //
 // Tokens produced by this package are compatible with [github.com/golang-jwt/jwt/v5]
-// and other standard JWT libraries. The Audience claim is serialized as a JSON array;
-// tokens from libraries that serialize single audiences as strings will fail to parse.
+// and other standard JWT libraries. The Audience claim is serialized as a JSON array.
+// Claims validation accepts a bare string audience as RFC 7519 allows, but a custom
+// claims type that embeds [RegisteredClaims] still fails to unmarshal such tokens;
+// verify into map[string]any when accepting tokens from third-party issuers.
 //
 // # Limitations
 //
-// There is no built-in clock skew tolerance. If you need leeway for clock drift
-// between services, use [WithClock] with a clock that returns a time slightly in the past.
-//
 // Key rotation and kid (key ID) headers are not supported. For multi-key scenarios,
 // maintain multiple verifiers and try each one, or use a higher-level abstraction.
 //
@@ -0,0 +1,291 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/unkeyed/unkey/pkg/assert"
+	"github.com/unkeyed/unkey/pkg/clock"
+)
+
+// es256CoordinateSize is the byte length of one P-256 signature component.
+// RFC 7518 Section 3.4 encodes ES256 signatures as the fixed-width
+// concatenation r || s rather than ASN.1 DER.
+const es256CoordinateSize = 32
+
+// ES256Signer creates signed JSON Web Tokens using the ES256 algorithm
+// (ECDSA using P-256 and SHA-256).
+//
+// Like [RS256Signer], ES256 is asymmetric: tokens are signed with a private key
+// and verified with the corresponding public key. ECDSA keys and signatures are
+// much smaller than RSA ones, which is why many identity providers default to
+// ES256 for access tokens.
+//
+// The type parameter T must embed [RegisteredClaims] to include standard JWT fields.
+// ES256Signer is safe for concurrent use; the private key is captured at construction.
+type ES256Signer[T any] struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+// Ensure ES256Signer implements Signer interface.
+var _ Signer[RegisteredClaims] = (*ES256Signer[RegisteredClaims])(nil)
+
+// NewES256Signer creates an ES256Signer from a PEM-encoded P-256 private key.
+//
+// The key must be in SEC 1 format (header "EC PRIVATE KEY") or PKCS#8 format
+// (header "PRIVATE KEY"). Escaped newlines and surrounding quotes are
+// normalized the same way as for [NewRS256Signer].
+//
+// Returns an error if the PEM data is invalid, the key format is unsupported,
+// or the key is not an ECDSA key on the P-256 curve.
+func NewES256Signer[T any](privateKeyPEM string) (*ES256Signer[T], error) {
+	if err := assert.NotEmpty(privateKeyPEM, "private key PEM must not be empty"); err != nil {
+		return nil, err
+	}
+
+	key, err := parseECPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ES256Signer[T]{privateKey: key}, nil
+}
+
+// Sign creates a signed JWT from the given claims.
+//
+// The token header is {"alg":"ES256","typ":"JWT"}. The signature is the ECDSA
+// signature over SHA256(header.payload), encoded as the 64-byte r || s
+// concatenation required by RFC 7518.
+//
+// Returns an error if JSON marshaling fails or if the ECDSA signing operation
+// fails (which should not happen with a valid key).
+func (s *ES256Signer[T]) Sign(claims T) (string, error) {
+	header := map[string]string{
+		"alg": "ES256",
+		"typ": "JWT",
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWT header: %w", err)
+	}
+
+	payloadJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWT payload: %w", err)
+	}
+
+	encodedHeader := base64.RawURLEncoding.EncodeToString(headerJSON)
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payloadJSON)
+	signatureInput := encodedHeader + "." + encodedPayload
+
+	hash := sha256.Sum256([]byte(signatureInput))
+	r, sig, err := ecdsa.Sign(rand.Reader, s.privateKey, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT: %w", err)
+	}
+
+	signature := make([]byte, 2*es256CoordinateSize)
+	r.FillBytes(signature[:es256CoordinateSize])
+	sig.FillBytes(signature[es256CoordinateSize:])
+
+	encodedSignature := base64.RawURLEncoding.EncodeToString(signature)
+	return signatureInput + "." + encodedSignature, nil
+}
+
+// ES256Verifier validates JSON Web Tokens signed with the ES256 algorithm
+// (ECDSA using P-256 and SHA-256).
+//
+// The verifier only accepts tokens with alg=ES256 in the header. Tokens with
+// any other algorithm (including "none", RS256, HS256, etc.) are rejected.
+//
+// The type parameter T must embed [RegisteredClaims] to include standard JWT fields.
+// ES256Verifier is safe for concurrent use.
+type ES256Verifier[T any] struct {
+	publicKey *ecdsa.PublicKey
+	clock     clock.Clock
+	config    verifyConfig
+}
+
+// Ensure ES256Verifier implements Verifier interface.
+var _ Verifier[RegisteredClaims] = (*ES256Verifier[RegisteredClaims])(nil)
+
+// NewES256Verifier creates an ES256Verifier from a PEM-encoded P-256 public key.
+//
+// The key must be in PKIX/SPKI format (header "PUBLIC KEY"). Escaped newlines
+// and surrounding quotes are normalized.
+//
+// Returns an error if the PEM data is invalid or the key is not an ECDSA
+// public key on the P-256 curve.
+func NewES256Verifier[T any](publicKeyPEM string, opts ...VerifyOption) (*ES256Verifier[T], error) {
+	if err := assert.NotEmpty(publicKeyPEM, "public key PEM must not be empty"); err != nil {
+		return nil, err
+	}
+
+	key, err := parseECPublicKey(publicKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := verifyConfig{clock: clock.New(), issuer: "", audience: "", audiences: nil, leeway: 0}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &ES256Verifier[T]{publicKey: key, clock: cfg.clock, config: cfg}, nil
+}
+
+// Verify validates a JWT and returns the typed claims.
+//
+// Verification follows the same order as [RS256Verifier.Verify]; only the
+// algorithm check (alg must be exactly "ES256") and the signature scheme
+// differ. Signatures must be exactly 64 bytes (r || s); ASN.1 DER encoded
+// signatures are rejected.
+//
+// On any error, the returned claims value is the zero value of T. Do not use
+// partial claims from failed verification.
+func (v *ES256Verifier[T]) Verify(token string, at ...time.Time) (T, error) {
+	verifyAt := v.clock.Now()
+	if len(at) > 0 {
+		verifyAt = at[0]
+	}
+	var claims T
+
+	if err := assert.NotEmpty(token, "token must not be empty"); err != nil {
+		return claims, err
+	}
+
+	parts := strings.Split(token, ".")
+	if err := assert.Equal(len(parts), 3, "token must have 3 parts"); err != nil {
+		return claims, err
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return claims, fmt.Errorf("invalid header encoding: %w", err)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return claims, fmt.Errorf("invalid header JSON: %w", err)
+	}
+
+	if err := assert.Equal(header.Alg, "ES256", "unsupported algorithm"); err != nil {
+		return claims, err
+	}
+
+	signatureInput := parts[0] + "." + parts[1]
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return claims, fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	if err := assert.Equal(len(signature), 2*es256CoordinateSize, "invalid signature length"); err != nil {
+		return claims, err
+	}
+
+	r := new(big.Int).SetBytes(signature[:es256CoordinateSize])
+	s := new(big.Int).SetBytes(signature[es256CoordinateSize:])
+	hash := sha256.Sum256([]byte(signatureInput))
+	if !ecdsa.Verify(v.publicKey, hash[:], r, s) {
+		return claims, fmt.Errorf("invalid signature")
+	}
+
+	payloadJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, fmt.Errorf("invalid payload encoding: %w", err)
+	}
+
+	if err := json.Unmarshal(payloadJSON, &claims); err != nil {
+		return claims, fmt.Errorf("invalid payload JSON: %w", err)
+	}
+
+	// Unmarshal registered claims separately for validation
+	registered, err := decodeRegisteredClaims(payloadJSON)
+	if err != nil {
+		return claims, fmt.Errorf("invalid registered claims: %w", err)
+	}
+
+	if err := registered.validate(verifyAt, &v.config); err != nil {
+		return claims, err
+	}
+
+	return claims, nil
+}
+
+// parseECPrivateKey extracts a P-256 private key from PEM data.
+//
+// Handles both SEC 1 ("EC PRIVATE KEY") and PKCS#8 ("PRIVATE KEY") formats.
+func parseECPrivateKey(pemData string) (*ecdsa.PrivateKey, error) {
+	pemData = strings.ReplaceAll(pemData, "\\n", "\n")
+	pemData = strings.Trim(pemData, "\"")
+
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil, fmt.Errorf("failed to parse PEM block")
+	}
+
+	var key *ecdsa.PrivateKey
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		parsed, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse EC private key: %w", err)
+		}
+		key = parsed
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse PKCS8 private key: %w", err)
+		}
+		ecKey, ok := parsed.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("key is not ECDSA")
+		}
+		key = ecKey
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", block.Type)
+	}
+
+	if key.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("key is not on the P-256 curve")
+	}
+	return key, nil
+}
+
+// parseECPublicKey extracts a P-256 public key from PKIX/SPKI PEM data.
+func parseECPublicKey(pemData string) (*ecdsa.PublicKey, error) {
+	pemData = strings.ReplaceAll(pemData, "\\n", "\n")
+	pemData = strings.Trim(pemData, "\"")
+
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil, fmt.Errorf("failed to parse PEM block")
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	ecKey, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("key is not ECDSA")
+	}
+	if ecKey.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("key is not on the P-256 curve")
+	}
+
+	return ecKey, nil
+}
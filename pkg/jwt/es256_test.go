@@ -0,0 +1,237 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func generateTestECKeyPair(t *testing.T) (privateKeyPEM, publicKeyPEM string) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	privateKeyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+	privateKeyPEM = string(pem.EncodeToMemory(&pem.Block{
+		Type:  "EC PRIVATE KEY",
+		Bytes: privateKeyBytes,
+	}))
+
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+	publicKeyPEM = string(pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: publicKeyBytes,
+	}))
+
+	return privateKeyPEM, publicKeyPEM
+}
+
+func TestES256_SignAndVerify(t *testing.T) {
+	privateKeyPEM, publicKeyPEM := generateTestECKeyPair(t)
+	signer, err := NewES256Signer[testClaims](privateKeyPEM)
+	require.NoError(t, err)
+	verifier, err := NewES256Verifier[testClaims](publicKeyPEM)
+	require.NoError(t, err)
+
+	now := time.Now()
+	original := testClaims{
+		RegisteredClaims: RegisteredClaims{
+			Issuer:    "test-issuer",
+			Subject:   "user-123",
+			Audience:  []string{"api.example.com"},
+			ExpiresAt: now.Add(time.Hour).Unix(),
+			IssuedAt:  now.Unix(),
+			ID:        "token-abc",
+		},
+		TenantID: "tenant-xyz",
+		Role:     "admin",
+	}
+
+	token, err := signer.Sign(original)
+	require.NoError(t, err)
+
+	signature, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[2])
+	require.NoError(t, err)
+	require.Len(t, signature, 64, "ES256 signatures are fixed-width r || s")
+
+	decoded, err := verifier.Verify(token)
+	require.NoError(t, err)
+	require.Equal(t, original, decoded)
+}
+
+func TestES256_Verify_Errors(t *testing.T) {
+	privateKeyPEM, publicKeyPEM := generateTestECKeyPair(t)
+	signer, err := NewES256Signer[testClaims](privateKeyPEM)
+	require.NoError(t, err)
+	verifier, err := NewES256Verifier[testClaims](publicKeyPEM)
+	require.NoError(t, err)
+
+	t.Run("wrong key", func(t *testing.T) {
+		otherPrivateKeyPEM, _ := generateTestECKeyPair(t)
+		otherSigner, err := NewES256Signer[testClaims](otherPrivateKeyPEM)
+		require.NoError(t, err)
+
+		token, err := otherSigner.Sign(testClaims{RegisteredClaims: RegisteredClaims{Subject: "user"}})
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(token)
+		require.Error(t, err)
+	})
+
+	t.Run("rs256 token rejected", func(t *testing.T) {
+		rsaPrivateKeyPEM, _ := generateTestKeyPair(t)
+		rsaSigner, err := NewRS256Signer[testClaims](rsaPrivateKeyPEM)
+		require.NoError(t, err)
+
+		token, err := rsaSigner.Sign(testClaims{RegisteredClaims: RegisteredClaims{Subject: "user"}})
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(token)
+		require.Error(t, err)
+	})
+
+	t.Run("DER encoded signature rejected", func(t *testing.T) {
+		token, err := signer.Sign(testClaims{RegisteredClaims: RegisteredClaims{Subject: "user"}})
+		require.NoError(t, err)
+
+		parts := strings.Split(token, ".")
+		parts[2] = base64.RawURLEncoding.EncodeToString(make([]byte, 70))
+		_, err = verifier.Verify(strings.Join(parts, "."))
+		require.Error(t, err)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		token, err := signer.Sign(testClaims{RegisteredClaims: RegisteredClaims{
+			ExpiresAt: time.Now().Add(-time.Hour).Unix(),
+		}})
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(token)
+		require.ErrorIs(t, err, ErrTokenExpired)
+	})
+
+	t.Run("rsa public key rejected at construction", func(t *testing.T) {
+		_, rsaPublicKeyPEM := generateTestKeyPair(t)
+		_, err := NewES256Verifier[testClaims](rsaPublicKeyPEM)
+		require.Error(t, err)
+	})
+}
+
+func TestWithLeeway(t *testing.T) {
+	privateKeyPEM, publicKeyPEM := generateTestECKeyPair(t)
+	signer, err := NewES256Signer[testClaims](privateKeyPEM)
+	require.NoError(t, err)
+	verifier, err := NewES256Verifier[testClaims](publicKeyPEM, WithLeeway(30*time.Second))
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	t.Run("accepts token expired within leeway", func(t *testing.T) {
+		token, err := signer.Sign(testClaims{RegisteredClaims: RegisteredClaims{ExpiresAt: now.Add(-10 * time.Second).Unix()}})
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(token, now)
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects token expired beyond leeway", func(t *testing.T) {
+		token, err := signer.Sign(testClaims{RegisteredClaims: RegisteredClaims{ExpiresAt: now.Add(-time.Minute).Unix()}})
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(token, now)
+		require.ErrorIs(t, err, ErrTokenExpired)
+	})
+
+	t.Run("accepts token not yet valid within leeway", func(t *testing.T) {
+		token, err := signer.Sign(testClaims{RegisteredClaims: RegisteredClaims{NotBefore: now.Add(10 * time.Second).Unix()}})
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(token, now)
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects token not yet valid beyond leeway", func(t *testing.T) {
+		token, err := signer.Sign(testClaims{RegisteredClaims: RegisteredClaims{NotBefore: now.Add(time.Minute).Unix()}})
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(token, now)
+		require.ErrorIs(t, err, ErrTokenNotYetValid)
+	})
+}
+
+func TestWithAnyAudience(t *testing.T) {
+	privateKeyPEM, publicKeyPEM := generateTestECKeyPair(t)
+	signer, err := NewES256Signer[testClaims](privateKeyPEM)
+	require.NoError(t, err)
+	verifier, err := NewES256Verifier[testClaims](publicKeyPEM, WithAnyAudience("billing", "orders"))
+	require.NoError(t, err)
+
+	t.Run("accepts any configured audience", func(t *testing.T) {
+		token, err := signer.Sign(testClaims{RegisteredClaims: RegisteredClaims{Audience: []string{"other", "orders"}}})
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(token)
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects unrelated audiences", func(t *testing.T) {
+		token, err := signer.Sign(testClaims{RegisteredClaims: RegisteredClaims{Audience: []string{"other"}}})
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(token)
+		require.ErrorIs(t, err, ErrInvalidAudience)
+	})
+
+	t.Run("rejects missing audience", func(t *testing.T) {
+		token, err := signer.Sign(testClaims{RegisteredClaims: RegisteredClaims{Subject: "user"}})
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(token)
+		require.ErrorIs(t, err, ErrInvalidAudience)
+	})
+}
+
+func TestStringAudience(t *testing.T) {
+	privateKeyPEM, publicKeyPEM := generateTestECKeyPair(t)
+	signer, err := NewES256Signer[map[string]any](privateKeyPEM)
+	require.NoError(t, err)
+	verifier, err := NewES256Verifier[map[string]any](publicKeyPEM, WithAudience("orders"))
+	require.NoError(t, err)
+
+	t.Run("single string audience is validated", func(t *testing.T) {
+		token, err := signer.Sign(map[string]any{"aud": "orders"})
+		require.NoError(t, err)
+
+		claims, err := verifier.Verify(token)
+		require.NoError(t, err)
+		require.Equal(t, "orders", claims["aud"])
+	})
+
+	t.Run("mismatched string audience is rejected", func(t *testing.T) {
+		token, err := signer.Sign(map[string]any{"aud": "billing"})
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(token)
+		require.ErrorIs(t, err, ErrInvalidAudience)
+	})
+
+	t.Run("non-string audience is rejected", func(t *testing.T) {
+		token, err := signer.Sign(map[string]any{"aud": json.Number("42")})
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(token)
+		require.Error(t, err)
+	})
+}
@@ -129,7 +129,7 @@ func NewHS256Verifier[T any](secret []byte, opts ...VerifyOption) (*HS256Verifie
 		return nil, err
 	}
 
-	cfg := verifyConfig{clock: clock.New(), issuer: "", audience: ""}
+	cfg := verifyConfig{clock: clock.New(), issuer: "", audience: "", audiences: nil, leeway: 0}
 	for _, opt := range opts {
 		opt(&cfg)
 	}
@@ -213,8 +213,8 @@ func (v *HS256Verifier[T]) Verify(token string, at ...time.Time) (T, error) {
 	}
 
 	// Unmarshal registered claims separately for validation
-	var registered RegisteredClaims
-	if err := json.Unmarshal(payloadJSON, &registered); err != nil {
+	registered, err := decodeRegisteredClaims(payloadJSON)
+	if err != nil {
 		return claims, fmt.Errorf("invalid registered claims: %w", err)
 	}
 
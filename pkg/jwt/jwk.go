@@ -0,0 +1,149 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// JWKS is the JSON shape of a JSON Web Key Set document (RFC 7517 Section 5),
+// as served by identity providers at their jwks_uri.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK is one JSON Web Key entry. Only the public members of RSA and P-256 EC
+// signing keys are decoded; private members are ignored if present.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	Y         string `json:"y"`
+}
+
+// PublicKeyPEM converts the JWK into a PKIX PEM public key and reports the
+// algorithm it signs with: RS256 for RSA keys and ES256 for P-256 EC keys.
+//
+// Keys of an unsupported type or curve return an empty algorithm and no error
+// so callers iterating a key set can skip them. The key's own alg and use
+// members are not checked; callers decide which keys they accept.
+func (k JWK) PublicKeyPEM() (publicKeyPEM string, alg string, err error) {
+	var pub any
+	switch k.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.Modulus)
+		if err != nil {
+			return "", "", fmt.Errorf("decode JWKS key %q modulus: %w", k.KeyID, err)
+		}
+		eBytes, err := base64.RawURLEncoding.DecodeString(k.Exponent)
+		if err != nil {
+			return "", "", fmt.Errorf("decode JWKS key %q exponent: %w", k.KeyID, err)
+		}
+		e := new(big.Int).SetBytes(eBytes)
+		if !e.IsInt64() || e.Sign() <= 0 || e.Int64() > 1<<31-1 {
+			return "", "", fmt.Errorf("JWKS key %q has invalid exponent", k.KeyID)
+		}
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(e.Int64())}
+		if key.N.Sign() <= 0 {
+			return "", "", fmt.Errorf("JWKS key %q has invalid modulus", k.KeyID)
+		}
+		pub, alg = key, "RS256"
+
+	case "EC":
+		if k.Curve != "P-256" {
+			return "", "", nil
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return "", "", fmt.Errorf("decode JWKS key %q x coordinate: %w", k.KeyID, err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return "", "", fmt.Errorf("decode JWKS key %q y coordinate: %w", k.KeyID, err)
+		}
+		// The uncompressed SEC 1 point encoding lets ecdsa.ParseUncompressedPublicKey
+		// reject coordinates that are not on the curve.
+		point := make([]byte, 0, 1+len(x)+len(y))
+		point = append(point, 4)
+		point = append(point, x...)
+		point = append(point, y...)
+		key, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), point)
+		if err != nil {
+			return "", "", fmt.Errorf("JWKS key %q is not a valid P-256 point: %w", k.KeyID, err)
+		}
+		pub, alg = key, "ES256"
+
+	default:
+		return "", "", nil
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", "", fmt.Errorf("encode JWKS key %q public key: %w", k.KeyID, err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:    "PUBLIC KEY",
+		Headers: nil,
+		Bytes:   der,
+	})), alg, nil
+}
+
+// PublicKeyAlgorithm infers the signing algorithm of a PEM-encoded PKIX public
+// key: RS256 for RSA keys and ES256 for P-256 EC keys. Any other key type or
+// curve is an error.
+func PublicKeyAlgorithm(publicKeyPEM string) (string, error) {
+	block, _ := pem.Decode([]byte(normalizePEM(publicKeyPEM)))
+	if block == nil {
+		return "", errors.New("public key is not PEM encoded")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("parse public key: %w", err)
+	}
+
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		return "RS256", nil
+	case *ecdsa.PublicKey:
+		if key.Curve != elliptic.P256() {
+			return "", errors.New("public key must be an RSA or P-256 EC key")
+		}
+		return "ES256", nil
+	default:
+		return "", errors.New("public key must be an RSA or P-256 EC key")
+	}
+}
+
+// NewPublicKeyVerifier returns the verifier for a PEM-encoded public key and
+// the algorithm it signs with, as reported by [JWK.PublicKeyPEM] or
+// [PublicKeyAlgorithm]. Only RS256 and ES256 are supported; HS256 needs a
+// shared secret rather than a public key.
+func NewPublicKeyVerifier[T any](alg string, publicKeyPEM string, opts ...VerifyOption) (Verifier[T], error) {
+	switch alg {
+	case "RS256":
+		return NewRS256Verifier[T](publicKeyPEM, opts...)
+	case "ES256":
+		return NewES256Verifier[T](publicKeyPEM, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %s", alg)
+	}
+}
+
+// normalizePEM undoes the escaping PEM keys pick up in environment variables
+// and JSON, the same way the verifier constructors do.
+func normalizePEM(pemData string) string {
+	pemData = strings.ReplaceAll(pemData, "\\n", "\n")
+	return strings.Trim(pemData, "\"")
+}
@@ -0,0 +1,166 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWK_PublicKeyPEM(t *testing.T) {
+	now := time.Now()
+	claims := testClaims{
+		RegisteredClaims: RegisteredClaims{
+			Issuer:    "https://auth.example.com",
+			Subject:   "user-123",
+			ExpiresAt: now.Add(time.Hour).Unix(),
+			IssuedAt:  now.Unix(),
+		},
+		TenantID: "tenant-xyz",
+		Role:     "admin",
+	}
+
+	t.Run("RSA key verifies RS256 tokens", func(t *testing.T) {
+		privateKeyPEM, _ := generateTestKeyPair(t)
+		signer, err := NewRS256Signer[testClaims](privateKeyPEM)
+		require.NoError(t, err)
+
+		pub := &signer.privateKey.PublicKey
+		jwk := JWK{
+			KeyType:  "RSA",
+			KeyID:    "rsa-1",
+			Modulus:  base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			Exponent: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}
+
+		publicKeyPEM, alg, err := jwk.PublicKeyPEM()
+		require.NoError(t, err)
+		require.Equal(t, "RS256", alg)
+
+		verifier, err := NewPublicKeyVerifier[testClaims](alg, publicKeyPEM)
+		require.NoError(t, err)
+
+		token, err := signer.Sign(claims)
+		require.NoError(t, err)
+		decoded, err := verifier.Verify(token)
+		require.NoError(t, err)
+		require.Equal(t, claims, decoded)
+	})
+
+	t.Run("P-256 key verifies ES256 tokens", func(t *testing.T) {
+		privateKeyPEM, _ := generateTestECKeyPair(t)
+		signer, err := NewES256Signer[testClaims](privateKeyPEM)
+		require.NoError(t, err)
+
+		pub := &signer.privateKey.PublicKey
+		jwk := JWK{
+			KeyType: "EC",
+			KeyID:   "ec-1",
+			Curve:   "P-256",
+			X:       base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, 32))),
+			Y:       base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, 32))),
+		}
+
+		publicKeyPEM, alg, err := jwk.PublicKeyPEM()
+		require.NoError(t, err)
+		require.Equal(t, "ES256", alg)
+
+		verifier, err := NewPublicKeyVerifier[testClaims](alg, publicKeyPEM)
+		require.NoError(t, err)
+
+		token, err := signer.Sign(claims)
+		require.NoError(t, err)
+		_, err = verifier.Verify(token)
+		require.NoError(t, err)
+	})
+
+	t.Run("unsupported keys are skipped", func(t *testing.T) {
+		for _, jwk := range []JWK{
+			{KeyType: "oct", KeyID: "hmac"},
+			{KeyType: "EC", KeyID: "p384", Curve: "P-384"},
+			{KeyType: "OKP", KeyID: "ed25519", Curve: "Ed25519"},
+		} {
+			publicKeyPEM, alg, err := jwk.PublicKeyPEM()
+			require.NoError(t, err, jwk.KeyID)
+			require.Empty(t, alg, jwk.KeyID)
+			require.Empty(t, publicKeyPEM, jwk.KeyID)
+		}
+	})
+
+	t.Run("malformed keys are errors", func(t *testing.T) {
+		for _, jwk := range []JWK{
+			{KeyType: "RSA", KeyID: "bad-modulus", Modulus: "!!", Exponent: "AQAB"},
+			{KeyType: "RSA", KeyID: "zero-exponent", Modulus: "AQAB", Exponent: ""},
+			{KeyType: "EC", KeyID: "off-curve", Curve: "P-256", X: "AQ", Y: "AQ"},
+		} {
+			_, _, err := jwk.PublicKeyPEM()
+			require.Error(t, err, jwk.KeyID)
+		}
+	})
+}
+
+func TestPublicKeyAlgorithm(t *testing.T) {
+	_, rsaPEM := generateTestKeyPair(t)
+	alg, err := PublicKeyAlgorithm(rsaPEM)
+	require.NoError(t, err)
+	require.Equal(t, "RS256", alg)
+
+	_, ecPEM := generateTestECKeyPair(t)
+	alg, err = PublicKeyAlgorithm(escapeNewlines(ecPEM))
+	require.NoError(t, err)
+	require.Equal(t, "ES256", alg)
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	jwk := JWK{
+		KeyType: "EC",
+		Curve:   "P-256",
+		X:       base64.RawURLEncoding.EncodeToString(p384.X.Bytes()),
+		Y:       base64.RawURLEncoding.EncodeToString(p384.Y.Bytes()),
+	}
+	_, _, err = jwk.PublicKeyPEM()
+	require.Error(t, err, "P-384 coordinates are not a P-256 point")
+
+	_, err = PublicKeyAlgorithm("not a key")
+	require.Error(t, err)
+
+	_, err = NewPublicKeyVerifier[testClaims]("HS256", rsaPEM)
+	require.Error(t, err)
+}
+
+func TestParseUnverified(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer := &RS256Signer[RegisteredClaims]{privateKey: privateKey}
+
+	token, err := signer.Sign(RegisteredClaims{
+		Issuer:   "https://auth.example.com",
+		Subject:  "user-123",
+		Audience: []string{"api"},
+	})
+	require.NoError(t, err)
+
+	header, claims, err := ParseUnverified(token)
+	require.NoError(t, err)
+	require.Equal(t, "RS256", header.Alg)
+	require.Empty(t, header.KID)
+	require.Equal(t, "https://auth.example.com", claims.Issuer)
+	require.Equal(t, "user-123", claims.Subject)
+
+	for _, malformed := range []string{
+		"",
+		"a.b",
+		"!!.e30.sig",
+		base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT"}`)) + ".e30.sig",
+		base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256"}`)) + ".!!.sig",
+	} {
+		_, _, err := ParseUnverified(malformed)
+		require.Error(t, err, malformed)
+	}
+}
@@ -145,7 +145,7 @@ func NewRS256Verifier[T any](publicKeyPEM string, opts ...VerifyOption) (*RS256V
 		return nil, err
 	}
 
-	cfg := verifyConfig{clock: clock.New(), issuer: "", audience: ""}
+	cfg := verifyConfig{clock: clock.New(), issuer: "", audience: "", audiences: nil, leeway: 0}
 	for _, opt := range opts {
 		opt(&cfg)
 	}
@@ -226,8 +226,8 @@ func (v *RS256Verifier[T]) Verify(token string, at ...time.Time) (T, error) {
 	}
 
 	// Unmarshal registered claims separately for validation
-	var registered RegisteredClaims
-	if err := json.Unmarshal(payloadJSON, &registered); err != nil {
+	registered, err := decodeRegisteredClaims(payloadJSON)
+	if err != nil {
 		return claims, fmt.Errorf("invalid registered claims: %w", err)
 	}
 
@@ -0,0 +1,54 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Header is the subset of a JWT's JOSE header needed to pick a verifier.
+type Header struct {
+	// Alg is the signing algorithm, e.g. "RS256".
+	Alg string `json:"alg"`
+	// KID names the signing key within a key set. Empty if the token does
+	// not name one.
+	KID string `json:"kid"`
+}
+
+// ParseUnverified decodes the header and registered claims of a compact JWT
+// WITHOUT checking its signature or validating any claim.
+//
+// Use it only to route a token to the verifier that can check it, for example
+// to select a key set by issuer or a key by kid. Nothing it returns may be
+// trusted until a [Verifier] has accepted the token.
+func ParseUnverified(token string) (Header, RegisteredClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Header{}, RegisteredClaims{}, errors.New("token must have 3 parts")
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return Header{}, RegisteredClaims{}, fmt.Errorf("invalid header encoding: %w", err)
+	}
+	var header Header
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return Header{}, RegisteredClaims{}, fmt.Errorf("invalid header JSON: %w", err)
+	}
+	if header.Alg == "" {
+		return Header{}, RegisteredClaims{}, errors.New("header has no alg")
+	}
+
+	payloadJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Header{}, RegisteredClaims{}, fmt.Errorf("invalid payload encoding: %w", err)
+	}
+	claims, err := decodeRegisteredClaims(payloadJSON)
+	if err != nil {
+		return Header{}, RegisteredClaims{}, fmt.Errorf("invalid registered claims: %w", err)
+	}
+
+	return header, claims, nil
+}
@@ -0,0 +1,17 @@
+CREATE TABLE `api_jwt_configs` (
+	`pk` bigint unsigned AUTO_INCREMENT NOT NULL,
+	`api_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`workspace_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`issuer` varchar(256) NOT NULL,
+	`audience` varchar(256),
+	`jwks_url` varchar(1024),
+	`public_key` text,
+	`identity_claim` varchar(256) NOT NULL DEFAULT 'sub',
+	`permissions_claim` varchar(256),
+	`created_at_m` bigint NOT NULL,
+	`updated_at_m` bigint,
+	CONSTRAINT `api_jwt_configs_pk` PRIMARY KEY(`pk`),
+	CONSTRAINT `api_jwt_configs_api_id_unique` UNIQUE(`api_id`)
+);
+
+CREATE INDEX `workspace_id_issuer_idx` ON `api_jwt_configs` (`workspace_id`,`issuer`);
@@ -1,6 +1,6 @@
 CREATE TABLE `deployment_changes` (
 	`pk` bigint unsigned AUTO_INCREMENT NOT NULL,
-	`resource_type` enum('deployment_topology','sentinel','cilium_network_policy') NOT NULL,
+	`resource_type` enum('deployment_topology','sentinel','cilium_network_policy','volume') NOT NULL,
 	`resource_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
 	`region_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
 	`created_at` bigint NOT NULL,
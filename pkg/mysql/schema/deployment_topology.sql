@@ -7,6 +7,7 @@ CREATE TABLE `deployment_topology` (
 	`autoscaling_replicas_max` int unsigned NOT NULL DEFAULT 1,
 	`autoscaling_threshold_cpu` tinyint unsigned,
 	`autoscaling_threshold_memory` tinyint unsigned,
+	`autoscaling_threshold_rps` tinyint unsigned,
 	`desired_status` enum('stopped','running') NOT NULL,
 	`created_at` bigint NOT NULL,
 	`updated_at` bigint,
@@ -0,0 +1,16 @@
+CREATE TABLE `idempotency_keys` (
+	`pk` bigint unsigned AUTO_INCREMENT NOT NULL,
+	`workspace_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`subject_id` varchar(255) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`idempotency_key` varchar(255) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`fingerprint` varchar(64) NOT NULL,
+	`response_status` int,
+	`response_content_type` varchar(255),
+	`response_body` longblob,
+	`created_at_m` bigint NOT NULL,
+	`expires_at_m` bigint NOT NULL,
+	CONSTRAINT `idempotency_keys_pk` PRIMARY KEY(`pk`),
+	CONSTRAINT `idempotency_keys_workspace_id_subject_id_idempotency_key_unique` UNIQUE(`workspace_id`,`subject_id`,`idempotency_key`)
+);
+
+CREATE INDEX `expires_at_m_idx` ON `idempotency_keys` (`expires_at_m`);
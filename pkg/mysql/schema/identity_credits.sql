@@ -0,0 +1,15 @@
+CREATE TABLE `identity_credits` (
+	`pk` bigint unsigned AUTO_INCREMENT NOT NULL,
+	`identity_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`workspace_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`remaining` bigint unsigned NOT NULL,
+	`refill_day` tinyint,
+	`refill_amount` bigint unsigned,
+	`last_refill_at` datetime(3),
+	`created_at_m` bigint NOT NULL,
+	`updated_at_m` bigint,
+	CONSTRAINT `identity_credits_pk` PRIMARY KEY(`pk`),
+	CONSTRAINT `identity_credits_identity_id_unique` UNIQUE(`identity_id`)
+);
+
+CREATE INDEX `idx_identity_credits_refill` ON `identity_credits` (`refill_amount`);
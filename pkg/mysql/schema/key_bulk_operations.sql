@@ -0,0 +1,32 @@
+CREATE TABLE `key_bulk_operations` (
+	`pk` bigint unsigned AUTO_INCREMENT NOT NULL,
+	`id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`workspace_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`api_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`operation` enum('update','delete','set_permissions') NOT NULL,
+	`status` enum('pending','running','completed','failed') NOT NULL DEFAULT 'pending',
+	`total` int unsigned NOT NULL DEFAULT 0,
+	`succeeded` int unsigned NOT NULL DEFAULT 0,
+	`failed` int unsigned NOT NULL DEFAULT 0,
+	`error` varchar(1024),
+	`created_at_m` bigint NOT NULL,
+	`updated_at_m` bigint,
+	`completed_at_m` bigint,
+	CONSTRAINT `key_bulk_operations_pk` PRIMARY KEY(`pk`),
+	CONSTRAINT `key_bulk_operations_id_unique` UNIQUE(`id`)
+);
+
+CREATE INDEX `workspace_id_idx` ON `key_bulk_operations` (`workspace_id`);
+
+CREATE TABLE `key_bulk_operation_items` (
+	`pk` bigint unsigned AUTO_INCREMENT NOT NULL,
+	`operation_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`key_id` varchar(255) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`status` enum('pending','succeeded','failed') NOT NULL DEFAULT 'pending',
+	`error` varchar(1024),
+	`event_id` varchar(48) COLLATE utf8mb4_0900_as_cs,
+	`expires` bigint,
+	`updated_at_m` bigint,
+	CONSTRAINT `key_bulk_operation_items_pk` PRIMARY KEY(`pk`),
+	CONSTRAINT `operation_id_key_id_unique` UNIQUE(`operation_id`,`key_id`)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE `key_expiry_notification_settings` (
+	`pk` bigint unsigned AUTO_INCREMENT NOT NULL,
+	`key_auth_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`workspace_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`thresholds_ms` json NOT NULL,
+	`email` boolean NOT NULL DEFAULT false,
+	`slack_webhook_url` varchar(1024),
+	`created_at_m` bigint NOT NULL,
+	`updated_at_m` bigint,
+	CONSTRAINT `key_expiry_notification_settings_pk` PRIMARY KEY(`pk`),
+	CONSTRAINT `key_expiry_notification_settings_key_auth_id_unique` UNIQUE(`key_auth_id`)
+);
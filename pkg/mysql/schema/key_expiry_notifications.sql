@@ -0,0 +1,10 @@
+CREATE TABLE `key_expiry_notifications` (
+	`pk` bigint unsigned AUTO_INCREMENT NOT NULL,
+	`key_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`workspace_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`threshold_ms` bigint NOT NULL,
+	`expires` datetime(3) NOT NULL,
+	`created_at_m` bigint NOT NULL,
+	CONSTRAINT `key_expiry_notifications_pk` PRIMARY KEY(`pk`),
+	CONSTRAINT `key_expiry_notifications_key_threshold_expires_unique` UNIQUE(`key_id`,`threshold_ms`,`expires`)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE `key_rotation_policies` (
+	`pk` bigint unsigned AUTO_INCREMENT NOT NULL,
+	`scope_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`workspace_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`key_auth_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`interval_ms` bigint NOT NULL,
+	`grace_period_ms` bigint NOT NULL,
+	`created_at_m` bigint NOT NULL,
+	`updated_at_m` bigint,
+	CONSTRAINT `key_rotation_policies_pk` PRIMARY KEY(`pk`),
+	CONSTRAINT `key_rotation_policies_scope_id_unique` UNIQUE(`scope_id`)
+);
+
+CREATE INDEX `key_auth_id_idx` ON `key_rotation_policies` (`key_auth_id`);
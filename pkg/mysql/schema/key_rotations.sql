@@ -0,0 +1,13 @@
+CREATE TABLE `key_rotations` (
+	`pk` bigint unsigned AUTO_INCREMENT NOT NULL,
+	`key_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`successor_key_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`workspace_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`key_auth_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`reason` enum('reroll','schedule') NOT NULL,
+	`created_at_m` bigint NOT NULL,
+	CONSTRAINT `key_rotations_pk` PRIMARY KEY(`pk`),
+	CONSTRAINT `key_rotations_successor_key_id_unique` UNIQUE(`successor_key_id`)
+);
+
+CREATE INDEX `key_id_idx` ON `key_rotations` (`key_id`);
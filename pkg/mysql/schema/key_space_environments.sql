@@ -0,0 +1,12 @@
+CREATE TABLE `key_space_environments` (
+	`pk` bigint unsigned AUTO_INCREMENT NOT NULL,
+	`workspace_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`key_auth_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`name` varchar(256) NOT NULL,
+	`prefix` varchar(16),
+	`test_mode` boolean NOT NULL DEFAULT false,
+	`created_at_m` bigint NOT NULL,
+	`updated_at_m` bigint,
+	CONSTRAINT `key_space_environments_pk` PRIMARY KEY(`pk`),
+	CONSTRAINT `key_space_environments_key_auth_id_name_unique` UNIQUE(`key_auth_id`,`name`)
+);
@@ -8,6 +8,12 @@ CREATE TABLE `portals` (
 	`enabled` boolean NOT NULL DEFAULT true,
 	`logo_url` varchar(500),
 	`primary_color` varchar(7),
+	`max_keys` int,
+	`allowed_permissions` json,
+	`allowed_roles` json,
+	`ratelimit_templates` json,
+	`min_expiry_ms` bigint,
+	`max_expiry_ms` bigint,
 	`created_at` bigint NOT NULL,
 	`updated_at` bigint,
 	CONSTRAINT `portals_pk` PRIMARY KEY(`pk`),
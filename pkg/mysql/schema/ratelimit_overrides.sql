@@ -6,6 +6,8 @@ CREATE TABLE `ratelimit_overrides` (
 	`identifier` varchar(512) COLLATE utf8mb4_0900_as_cs NOT NULL,
 	`limit` bigint unsigned NOT NULL,
 	`duration` bigint unsigned NOT NULL,
+	`priority` int NOT NULL DEFAULT 0,
+	`expires_at_m` bigint,
 	`created_at_m` bigint NOT NULL DEFAULT 0,
 	`updated_at_m` bigint,
 	`deleted_at_m` bigint,
@@ -0,0 +1,11 @@
+CREATE TABLE `ratelimit_plan_limits` (
+	`pk` bigint unsigned AUTO_INCREMENT NOT NULL,
+	`plan_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`workspace_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`namespace_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`limit` bigint unsigned NOT NULL,
+	`duration` bigint unsigned NOT NULL,
+	`created_at_m` bigint NOT NULL DEFAULT 0,
+	CONSTRAINT `ratelimit_plan_limits_pk` PRIMARY KEY(`pk`),
+	CONSTRAINT `unique_namespace_duration_per_plan_idx` UNIQUE(`plan_id`,`namespace_id`,`duration`)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE `ratelimit_plans` (
+	`pk` bigint unsigned AUTO_INCREMENT NOT NULL,
+	`id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`workspace_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`name` varchar(512) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`created_at_m` bigint NOT NULL DEFAULT 0,
+	`updated_at_m` bigint,
+	CONSTRAINT `ratelimit_plans_pk` PRIMARY KEY(`pk`),
+	CONSTRAINT `ratelimit_plans_id_unique` UNIQUE(`id`),
+	CONSTRAINT `unique_name_per_workspace_idx` UNIQUE(`workspace_id`,`name`)
+);
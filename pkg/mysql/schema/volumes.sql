@@ -0,0 +1,21 @@
+CREATE TABLE `volumes` (
+	`pk` bigint unsigned AUTO_INCREMENT NOT NULL,
+	`id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`workspace_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`project_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`app_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`environment_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`name` varchar(63) NOT NULL,
+	`mount_path` varchar(256) NOT NULL,
+	`size_mib` int unsigned NOT NULL,
+	`k8s_name` varchar(63) NOT NULL,
+	`status` enum('active','released','purged') NOT NULL DEFAULT 'active',
+	`released_at` bigint,
+	`created_at` bigint NOT NULL,
+	`updated_at` bigint,
+	CONSTRAINT `volumes_pk` PRIMARY KEY(`pk`),
+	CONSTRAINT `volumes_id_unique` UNIQUE(`id`),
+	CONSTRAINT `volumes_app_env_name_idx` UNIQUE(`app_id`,`environment_id`,`name`)
+);
+
+CREATE INDEX `workspace_status_idx` ON `volumes` (`workspace_id`,`status`);
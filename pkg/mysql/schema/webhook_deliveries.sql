@@ -0,0 +1,22 @@
+CREATE TABLE `webhook_deliveries` (
+	`pk` bigint unsigned AUTO_INCREMENT NOT NULL,
+	`id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`workspace_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`endpoint_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`event_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`event_type` varchar(64) NOT NULL,
+	`payload` json NOT NULL,
+	`status` enum('pending','delivered','failed') NOT NULL DEFAULT 'pending',
+	`attempts` int unsigned NOT NULL DEFAULT 0,
+	`response_status` int,
+	`last_error` varchar(1024),
+	`next_attempt_at` bigint,
+	`delivered_at` bigint,
+	`created_at` bigint NOT NULL,
+	`updated_at` bigint,
+	CONSTRAINT `webhook_deliveries_pk` PRIMARY KEY(`pk`),
+	CONSTRAINT `webhook_deliveries_id_unique` UNIQUE(`id`)
+);
+
+CREATE INDEX `endpoint_id_idx` ON `webhook_deliveries` (`endpoint_id`,`id`);
+
@@ -0,0 +1,18 @@
+CREATE TABLE `webhook_endpoints` (
+	`pk` bigint unsigned AUTO_INCREMENT NOT NULL,
+	`id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`workspace_id` varchar(48) COLLATE utf8mb4_0900_as_cs NOT NULL,
+	`url` varchar(1024) NOT NULL,
+	`description` varchar(256),
+	`event_types` json NOT NULL,
+	`encrypted_secret` varchar(1024) NOT NULL,
+	`encryption_key_id` varchar(256) NOT NULL,
+	`enabled` boolean NOT NULL DEFAULT true,
+	`created_at` bigint NOT NULL,
+	`updated_at` bigint,
+	CONSTRAINT `webhook_endpoints_pk` PRIMARY KEY(`pk`),
+	CONSTRAINT `webhook_endpoints_id_unique` UNIQUE(`id`)
+);
+
+CREATE INDEX `workspace_id_idx` ON `webhook_endpoints` (`workspace_id`);
+
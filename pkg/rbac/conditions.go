@@ -0,0 +1,207 @@
+package rbac
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/fault"
+)
+
+// AttributeEnvironment is the query attribute matched against
+// [Conditions.Environments], as in "deploy.write[environment=production]".
+const AttributeEnvironment = "environment"
+
+// Conditions restricts when a granted permission applies. A grant without
+// conditions always applies; a grant with conditions only applies when every
+// configured condition is met. Conditions fail closed: a condition that cannot
+// be evaluated, for example because the query did not name an environment,
+// is treated as not met.
+type Conditions struct {
+	// Environments lists the environments the grant applies to. The query
+	// leaf must carry an "environment" attribute with one of these values.
+	Environments []string `json:"environments,omitempty"`
+
+	// TimeWindow limits the grant to a time of day.
+	TimeWindow *TimeWindow `json:"timeWindow,omitempty"`
+
+	// Meta lists values the key's meta must contain. Values are compared as
+	// strings, so {"plan": "pro"} matches a meta of {"plan": "pro"} and
+	// {"tier": "2"} matches a meta of {"tier": 2}.
+	Meta map[string]string `json:"meta,omitempty"`
+}
+
+// TimeWindow is a daily window in which a grant applies. Start is inclusive
+// and End is exclusive. A window whose End is before its Start spans
+// midnight, so 22:00-06:00 covers the night.
+type TimeWindow struct {
+	// Start is the beginning of the window in 24h "HH:MM" format.
+	Start string `json:"start"`
+
+	// End is the end of the window in 24h "HH:MM" format.
+	End string `json:"end"`
+
+	// Timezone is the IANA time zone the window is expressed in.
+	// Defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// Grant is a permission held by a principal together with the conditions
+// under which it applies. Conditions is nil for unconditional grants.
+type Grant struct {
+	Permission string
+	Conditions *Conditions
+}
+
+// EvaluationContext carries the facts about a request that conditional grants
+// are evaluated against, besides the attributes named in the query itself.
+type EvaluationContext struct {
+	// Time is the moment of the request. Defaults to now.
+	Time time.Time
+
+	// Meta is the decoded meta of the key being verified.
+	Meta map[string]any
+}
+
+// Grants converts plain permission strings into unconditional grants.
+func Grants(permissions []string) []Grant {
+	grants := make([]Grant, len(permissions))
+	for i, permission := range permissions {
+		grants[i] = Grant{Permission: permission, Conditions: nil}
+	}
+	return grants
+}
+
+// Validate reports whether the conditions are well formed, so that malformed
+// conditions are rejected when they are stored rather than silently denying
+// every request once they are evaluated.
+func (c Conditions) Validate() error {
+	for _, env := range c.Environments {
+		if env == "" {
+			return invalidConditions("environments must not contain empty values")
+		}
+	}
+
+	if c.TimeWindow != nil {
+		if _, err := parseClock(c.TimeWindow.Start); err != nil {
+			return invalidConditions(fmt.Sprintf("timeWindow.start %q must be in HH:MM format", c.TimeWindow.Start))
+		}
+		if _, err := parseClock(c.TimeWindow.End); err != nil {
+			return invalidConditions(fmt.Sprintf("timeWindow.end %q must be in HH:MM format", c.TimeWindow.End))
+		}
+		if c.TimeWindow.Start == c.TimeWindow.End {
+			return invalidConditions("timeWindow.start and timeWindow.end must differ")
+		}
+		if _, err := time.LoadLocation(c.TimeWindow.Timezone); err != nil {
+			return invalidConditions(fmt.Sprintf("timeWindow.timezone %q is not a known IANA time zone", c.TimeWindow.Timezone))
+		}
+	}
+
+	for key := range c.Meta {
+		if key == "" {
+			return invalidConditions("meta keys must not be empty")
+		}
+	}
+
+	return nil
+}
+
+// unmet returns why the conditions are not met for a leaf with the given
+// attributes, or an empty string when they are.
+func (c *Conditions) unmet(attributes map[string]string, ectx EvaluationContext) string {
+	if c == nil {
+		return ""
+	}
+
+	if len(c.Environments) > 0 {
+		env, ok := attributes[AttributeEnvironment]
+		if !ok {
+			return "the query does not specify an environment"
+		}
+		if !slices.Contains(c.Environments, env) {
+			return fmt.Sprintf("environment '%s' is not allowed", env)
+		}
+	}
+
+	if c.TimeWindow != nil && !c.TimeWindow.contains(ectx.Time) {
+		return fmt.Sprintf("it is only granted between %s and %s", c.TimeWindow.Start, c.TimeWindow.End)
+	}
+
+	for key, want := range c.Meta {
+		if got, ok := metaString(ectx.Meta[key]); !ok || got != want {
+			return fmt.Sprintf("the key's meta does not have %s=%s", key, want)
+		}
+	}
+
+	return ""
+}
+
+// contains reports whether t falls inside the window. Malformed windows
+// contain no time at all.
+func (w *TimeWindow) contains(t time.Time) bool {
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return false
+	}
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return false
+	}
+
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// parseClock parses a 24h "HH:MM" clock time into minutes after midnight.
+func parseClock(s string) (int, error) {
+	hh, mm, ok := strings.Cut(s, ":")
+	if !ok || len(hh) != 2 || len(mm) != 2 {
+		return 0, fmt.Errorf("invalid clock time %q", s)
+	}
+	h, err := strconv.Atoi(hh)
+	if err != nil || h < 0 || h > 23 {
+		return 0, fmt.Errorf("invalid hour in %q", s)
+	}
+	m, err := strconv.Atoi(mm)
+	if err != nil || m < 0 || m > 59 {
+		return 0, fmt.Errorf("invalid minute in %q", s)
+	}
+	return h*60 + m, nil
+}
+
+// metaString renders a scalar meta value as a string. Objects, arrays and
+// null never match a meta condition.
+func metaString(v any) (string, bool) {
+	switch value := v.(type) {
+	case string:
+		return value, true
+	case bool:
+		return strconv.FormatBool(value), true
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), true
+	case json.Number:
+		return value.String(), true
+	default:
+		return "", false
+	}
+}
+
+func invalidConditions(message string) error {
+	return fault.New(
+		"invalid permission conditions: "+message,
+		fault.Code(codes.App.Validation.InvalidInput.URN()),
+		fault.Public("Invalid permission conditions: "+message+"."),
+	)
+}
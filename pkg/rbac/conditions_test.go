@@ -0,0 +1,247 @@
+package rbac
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery_Attributes(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected PermissionQuery
+	}{
+		{
+			name:  "Single attribute",
+			query: "deploy.write[environment=production]",
+			expected: PermissionQuery{
+				Operation:  OperatorNil,
+				Value:      "deploy.write",
+				Children:   []PermissionQuery{},
+				Attributes: map[string]string{"environment": "production"},
+			},
+		},
+		{
+			name:  "Multiple attributes with whitespace",
+			query: "deploy.write[ environment = production , region=eu-west-1 ]",
+			expected: PermissionQuery{
+				Operation:  OperatorNil,
+				Value:      "deploy.write",
+				Children:   []PermissionQuery{},
+				Attributes: map[string]string{"environment": "production", "region": "eu-west-1"},
+			},
+		},
+		{
+			name:  "Attributes inside a boolean expression",
+			query: "read AND (deploy.write[environment=staging] OR admin)",
+			expected: And(
+				S("read"),
+				Or(
+					PermissionQuery{
+						Operation:  OperatorNil,
+						Value:      "deploy.write",
+						Children:   []PermissionQuery{},
+						Attributes: map[string]string{"environment": "staging"},
+					},
+					S("admin"),
+				),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseQuery(tt.query)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestParseQuery_InvalidAttributes(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "Empty list", query: "perm[]"},
+		{name: "Missing value", query: "perm[environment=]"},
+		{name: "Missing equals", query: "perm[environment]"},
+		{name: "Unclosed list", query: "perm[environment=production"},
+		{name: "Duplicate attribute", query: "perm[environment=a,environment=b]"},
+		{name: "Detached list", query: "perm [environment=production]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseQuery(tt.query)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestFormatPermissionQuery_Attributes(t *testing.T) {
+	query, err := ParseQuery("deploy.write[region=eu,environment=production] OR admin")
+	require.NoError(t, err)
+	require.Equal(t, "deploy.write[environment=production,region=eu] or admin", FormatPermissionQuery(query))
+}
+
+func TestRBAC_EvaluateGrants(t *testing.T) {
+	// 2026-03-04 is a Wednesday; 14:30 UTC is 15:30 in Berlin.
+	afternoon := time.Date(2026, 3, 4, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		query     string
+		grants    []Grant
+		ectx      EvaluationContext
+		wantValid bool
+	}{
+		{
+			name:      "Unconditional grant ignores attributes",
+			query:     "deploy.write[environment=production]",
+			grants:    []Grant{{Permission: "deploy.write", Conditions: nil}},
+			wantValid: true,
+		},
+		{
+			name:  "Environment allowed",
+			query: "deploy.write[environment=production]",
+			grants: []Grant{{Permission: "deploy.write", Conditions: &Conditions{
+				Environments: []string{"staging", "production"},
+			}}},
+			wantValid: true,
+		},
+		{
+			name:  "Environment not allowed",
+			query: "deploy.write[environment=production]",
+			grants: []Grant{{Permission: "deploy.write", Conditions: &Conditions{
+				Environments: []string{"staging"},
+			}}},
+			wantValid: false,
+		},
+		{
+			name:  "Environment missing from query fails closed",
+			query: "deploy.write",
+			grants: []Grant{{Permission: "deploy.write", Conditions: &Conditions{
+				Environments: []string{"staging"},
+			}}},
+			wantValid: false,
+		},
+		{
+			name:  "Any matching grant is enough",
+			query: "deploy.write[environment=production]",
+			grants: []Grant{
+				{Permission: "deploy.write", Conditions: &Conditions{Environments: []string{"staging"}}},
+				{Permission: "deploy.write", Conditions: &Conditions{Environments: []string{"production"}}},
+			},
+			wantValid: true,
+		},
+		{
+			name:  "Inside time window",
+			query: "deploy.write",
+			grants: []Grant{{Permission: "deploy.write", Conditions: &Conditions{
+				TimeWindow: &TimeWindow{Start: "09:00", End: "17:00", Timezone: "Europe/Berlin"},
+			}}},
+			ectx:      EvaluationContext{Time: afternoon},
+			wantValid: true,
+		},
+		{
+			name:  "Outside time window",
+			query: "deploy.write",
+			grants: []Grant{{Permission: "deploy.write", Conditions: &Conditions{
+				TimeWindow: &TimeWindow{Start: "09:00", End: "15:00", Timezone: "Europe/Berlin"},
+			}}},
+			ectx:      EvaluationContext{Time: afternoon},
+			wantValid: false,
+		},
+		{
+			name:  "Time window spanning midnight",
+			query: "deploy.write",
+			grants: []Grant{{Permission: "deploy.write", Conditions: &Conditions{
+				TimeWindow: &TimeWindow{Start: "22:00", End: "06:00"},
+			}}},
+			ectx:      EvaluationContext{Time: time.Date(2026, 3, 4, 3, 0, 0, 0, time.UTC)},
+			wantValid: true,
+		},
+		{
+			name:  "Meta matches",
+			query: "deploy.write",
+			grants: []Grant{{Permission: "deploy.write", Conditions: &Conditions{
+				Meta: map[string]string{"plan": "pro", "tier": "2"},
+			}}},
+			ectx:      EvaluationContext{Meta: map[string]any{"plan": "pro", "tier": float64(2)}},
+			wantValid: true,
+		},
+		{
+			name:  "Meta differs",
+			query: "deploy.write",
+			grants: []Grant{{Permission: "deploy.write", Conditions: &Conditions{
+				Meta: map[string]string{"plan": "pro"},
+			}}},
+			ectx:      EvaluationContext{Meta: map[string]any{"plan": "free"}},
+			wantValid: false,
+		},
+		{
+			name:  "Conditions apply per leaf",
+			query: "deploy.write[environment=staging] AND deploy.write[environment=production]",
+			grants: []Grant{{Permission: "deploy.write", Conditions: &Conditions{
+				Environments: []string{"staging"},
+			}}},
+			wantValid: false,
+		},
+	}
+
+	r := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			result, err := r.EvaluateGrants(query, tt.grants, tt.ectx)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantValid, result.Valid, result.Message)
+		})
+	}
+}
+
+func TestRBAC_EvaluateGrants_ExplainsUnmetConditions(t *testing.T) {
+	query, err := ParseQuery("deploy.write[environment=production]")
+	require.NoError(t, err)
+
+	result, err := New().EvaluateGrants(query, []Grant{{
+		Permission: "deploy.write",
+		Conditions: &Conditions{Environments: []string{"staging"}},
+	}}, EvaluationContext{})
+	require.NoError(t, err)
+	require.False(t, result.Valid)
+	require.Equal(t, "Permission 'deploy.write[environment=production]' does not apply: environment 'production' is not allowed", result.Message)
+}
+
+func TestConditions_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions Conditions
+		wantErr    bool
+	}{
+		{name: "Empty", conditions: Conditions{}, wantErr: false},
+		{name: "Valid window", conditions: Conditions{TimeWindow: &TimeWindow{Start: "09:00", End: "17:30", Timezone: "America/New_York"}}, wantErr: false},
+		{name: "Empty environment", conditions: Conditions{Environments: []string{""}}, wantErr: true},
+		{name: "Malformed start", conditions: Conditions{TimeWindow: &TimeWindow{Start: "9:00", End: "17:00"}}, wantErr: true},
+		{name: "Hour out of range", conditions: Conditions{TimeWindow: &TimeWindow{Start: "09:00", End: "24:00"}}, wantErr: true},
+		{name: "Empty window", conditions: Conditions{TimeWindow: &TimeWindow{Start: "09:00", End: "09:00"}}, wantErr: true},
+		{name: "Unknown timezone", conditions: Conditions{TimeWindow: &TimeWindow{Start: "09:00", End: "17:00", Timezone: "Mars/Olympus"}}, wantErr: true},
+		{name: "Empty meta key", conditions: Conditions{Meta: map[string]string{"": "x"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.conditions.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
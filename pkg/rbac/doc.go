@@ -20,6 +20,7 @@
 //   - Tuple: Combination of ResourceType, ResourceID, and ActionType that defines a permission
 //   - UnkeyPermission: Combination of a canonical Unkey resource name and action
 //   - PermissionQuery: Logical expressions for permission evaluation using AND/OR operations
+//   - Grant: A granted permission, optionally restricted by Conditions
 //
 // # Programmatic Query Construction
 //
@@ -86,6 +87,7 @@
 //   - Logical operators: AND, OR (case-insensitive)
 //   - Grouping: parentheses () for overriding precedence
 //   - Precedence: AND has higher precedence than OR (like SQL)
+//   - Attributes: "perm[name=value,...]" describing the request for conditional grants
 //
 // Examples:
 //   - "api.key1.read_key"
@@ -97,6 +99,26 @@
 //   - Maximum query length: 1000 characters
 //   - Maximum permissions per query: 100
 //
+// # Conditional Grants
+//
+// A [Grant] may carry [Conditions] that restrict when it applies: a list of
+// environments, a daily time window, or values the key's meta must contain.
+// Queries describe the request they check with bracketed attributes, and
+// [RBAC.EvaluateGrants] only counts a conditional grant for a leaf whose
+// attributes, together with the [EvaluationContext], meet its conditions:
+//
+//	grants := []rbac.Grant{{
+//	    Permission: "deploy.write",
+//	    Conditions: &rbac.Conditions{Environments: []string{"staging"}},
+//	}}
+//
+//	query, _ := rbac.ParseQuery("deploy.write[environment=production]")
+//	result, _ := r.EvaluateGrants(query, grants, rbac.EvaluationContext{Time: time.Now()})
+//	// result.Valid is false
+//
+// Unconditional grants ignore attributes, so existing queries and permission
+// lists behave exactly as before.
+//
 // The package supports complex permission queries through logical operators,
 // allowing you to express requirements like "user must have permission X AND
 // either permission Y OR permission Z".
@@ -65,6 +65,11 @@ type token struct {
 	// pos indicates the starting position of this token in the original input string.
 	// Used for generating precise error messages with location information.
 	pos int
+
+	// attributes holds the bracketed attribute list following a permission,
+	// e.g. {"environment": "production"} for "deploy.write[environment=production]".
+	// Nil for every other token and for permissions without attributes.
+	attributes map[string]string
 }
 
 // lexer performs lexical analysis on permission query strings, breaking them
@@ -196,6 +201,76 @@ func isValidPermissionChar(ch byte) bool {
 	return unicode.IsLetter(rune(ch)) || unicode.IsDigit(rune(ch)) || ch == '.' || ch == '_' || ch == '-' || ch == '*' || ch == ':' || ch == '/'
 }
 
+// readAttributes reads the bracketed attribute list that may follow a
+// permission identifier, such as "[environment=production, region=eu]".
+//
+// Attribute names may contain letters, digits, underscores and hyphens.
+// Attribute values may contain the same characters as permission identifiers.
+// The list must not be empty and must not name an attribute twice.
+//
+// The lexer must be positioned on the opening bracket. On success it is left
+// on the character after the closing bracket.
+func (l *lexer) readAttributes() (map[string]string, error) {
+	start := l.pos - 1
+	l.readChar() // consume [
+
+	attributes := make(map[string]string)
+	for {
+		l.skipWhitespace()
+		name := l.readWhile(isValidAttributeNameChar)
+		l.skipWhitespace()
+		if name == "" || l.ch != '=' {
+			return nil, attributeSyntaxError(start, "expected an attribute such as 'environment=production'")
+		}
+		l.readChar() // consume =
+
+		l.skipWhitespace()
+		value := l.readWhile(isValidPermissionChar)
+		if value == "" {
+			return nil, attributeSyntaxError(start, fmt.Sprintf("attribute '%s' has no value", name))
+		}
+		if _, ok := attributes[name]; ok {
+			return nil, attributeSyntaxError(start, fmt.Sprintf("attribute '%s' is specified more than once", name))
+		}
+		attributes[name] = value
+
+		l.skipWhitespace()
+		switch l.ch {
+		case ',':
+			l.readChar()
+		case ']':
+			l.readChar()
+			return attributes, nil
+		default:
+			return nil, attributeSyntaxError(start, "expected ',' or ']'")
+		}
+	}
+}
+
+// readWhile reads consecutive characters accepted by valid and returns them.
+func (l *lexer) readWhile(valid func(byte) bool) string {
+	position := l.pos - 1
+	for valid(l.ch) {
+		l.readChar()
+	}
+	return l.input[position : l.pos-1]
+}
+
+// isValidAttributeNameChar determines whether a character is allowed in
+// attribute names: letters, digits, underscores and hyphens.
+func isValidAttributeNameChar(ch byte) bool {
+	return unicode.IsLetter(rune(ch)) || unicode.IsDigit(rune(ch)) || ch == '_' || ch == '-'
+}
+
+// attributeSyntaxError reports a malformed attribute list starting at pos.
+func attributeSyntaxError(pos int, detail string) error {
+	return fault.New(
+		fmt.Sprintf("invalid attribute list at position %d in query: %s", pos, detail),
+		fault.Code(codes.User.BadRequest.PermissionsQuerySyntaxError.URN()),
+		fault.Public(fmt.Sprintf("Invalid attribute list at position %d: %s.", pos, detail)),
+	)
+}
+
 // nextToken extracts and returns the next token from the input stream.
 //
 // This is the main entry point for token generation. It skips whitespace,
@@ -205,6 +280,7 @@ func isValidPermissionChar(ch byte) bool {
 // Token recognition logic:
 //   - Parentheses are recognized as single-character tokens
 //   - Identifiers (starting with valid permission characters) are read completely
+//   - A "[...]" attribute list directly after a permission is attached to its token
 //   - Operators "AND" and "OR" are recognized case-insensitively but only as complete words
 //   - End of input produces an EOF token
 //   - Invalid characters produce error tokens with descriptive messages
@@ -221,13 +297,13 @@ func (l *lexer) nextToken() token {
 
 	switch l.ch {
 	case '(':
-		tok = token{typ: lparen, value: string(l.ch), pos: l.pos - 1}
+		tok = token{typ: lparen, value: string(l.ch), pos: l.pos - 1, attributes: nil}
 		l.readChar()
 	case ')':
-		tok = token{typ: rparen, value: string(l.ch), pos: l.pos - 1}
+		tok = token{typ: rparen, value: string(l.ch), pos: l.pos - 1, attributes: nil}
 		l.readChar()
 	case 0:
-		tok = token{typ: eof, value: "", pos: l.pos - 1}
+		tok = token{typ: eof, value: "", pos: l.pos - 1, attributes: nil}
 	default:
 		if isValidPermissionChar(l.ch) {
 			pos := l.pos - 1
@@ -236,12 +312,19 @@ func (l *lexer) nextToken() token {
 			// Check if it's an operator (case-insensitive) AND it's a complete word
 			upperIdent := strings.ToUpper(identifier)
 			if upperIdent == "AND" && l.isCompleteWord(pos, identifier) {
-				tok = token{typ: and, value: identifier, pos: pos}
+				tok = token{typ: and, value: identifier, pos: pos, attributes: nil}
 			} else if upperIdent == "OR" && l.isCompleteWord(pos, identifier) {
-				tok = token{typ: or, value: identifier, pos: pos}
+				tok = token{typ: or, value: identifier, pos: pos, attributes: nil}
 			} else {
-				// It's a permission
-				tok = token{typ: permission, value: identifier, pos: pos}
+				// It's a permission, optionally followed by attributes
+				tok = token{typ: permission, value: identifier, pos: pos, attributes: nil}
+				if l.ch == '[' {
+					attributes, err := l.readAttributes()
+					if err != nil {
+						return token{typ: errorToken, value: err.Error(), pos: pos, attributes: nil}
+					}
+					tok.attributes = attributes
+				}
 			}
 			return tok
 		} else {
@@ -257,9 +340,10 @@ func (l *lexer) nextToken() token {
 			)
 
 			tok = token{
-				typ:   errorToken,
-				value: err.Error(),
-				pos:   pos,
+				typ:        errorToken,
+				value:      err.Error(),
+				pos:        pos,
+				attributes: nil,
 			}
 			l.readChar()
 		}
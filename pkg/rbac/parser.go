@@ -22,7 +22,8 @@ import (
 //
 //	expression    → andExpression (OR andExpression)*
 //	andExpression → primary (AND primary)*
-//	primary       → PERMISSION | LPAREN expression RPAREN
+//	primary       → PERMISSION attributes? | LPAREN expression RPAREN
+//	attributes    → "[" NAME "=" VALUE ("," NAME "=" VALUE)* "]"
 //
 // This grammar ensures AND has higher precedence than OR, matching SQL conventions.
 //
@@ -167,9 +168,10 @@ func (p *parser) parsePrimary() (PermissionQuery, error) {
 	switch p.currentToken.typ {
 	case permission:
 		// Simple permission
-		perm := p.currentToken.value
+		query := S(p.currentToken.value)
+		query.Attributes = p.currentToken.attributes
 		p.readToken() // consume permission
-		return S(perm), nil
+		return query, nil
 
 	case lparen:
 		// Parenthesized expression
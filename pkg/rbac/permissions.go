@@ -42,6 +42,9 @@ const (
 	// workspace rather than under a project or app
 	Portal ResourceType = "portal"
 
+	// Webhook represents outbound webhook endpoints and their deliveries
+	Webhook ResourceType = "webhook"
+
 	// Workspace represents workspace-wide operations that are not scoped to any
 	// project, app, or api (e.g. installing the Unkey GitHub App). There is one
 	// workspace per root key, so permissions use the "*" id.
@@ -95,6 +98,11 @@ const (
 	// (minting the install URL and binding the resulting installation). It is a
 	// workspace-wide action, so it is granted as workspace.*.install_github.
 	InstallGithub ActionType = "install_github"
+
+	// ReadAuditLog permits listing and exporting the workspace's audit logs.
+	// Audit logs are not owned by any single resource, so it is granted as
+	// workspace.*.read_audit_log.
+	ReadAuditLog ActionType = "read_audit_log"
 )
 
 // Predefined rate limiting actions. These constants define operations
@@ -311,6 +319,23 @@ const (
 	CreatePortalSession ActionType = "create_portal_session"
 )
 
+// Predefined webhook actions. These constants define operations that can be
+// performed on outbound webhook endpoints. Reading an endpoint includes its
+// delivery log, and replaying a delivery counts as an update.
+const (
+	// CreateWebhook permits registering new webhook endpoints
+	CreateWebhook ActionType = "create_webhook"
+
+	// ReadWebhook permits listing webhook endpoints and their deliveries
+	ReadWebhook ActionType = "read_webhook"
+
+	// UpdateWebhook permits modifying webhook endpoints and replaying deliveries
+	UpdateWebhook ActionType = "update_webhook"
+
+	// DeleteWebhook permits removing webhook endpoints
+	DeleteWebhook ActionType = "delete_webhook"
+)
+
 // Tuple represents a specific permission as a combination of resource type,
 // resource ID, and action. It forms the basic unit of permission definition
 // in the RBAC system.
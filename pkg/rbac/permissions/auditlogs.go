@@ -0,0 +1,11 @@
+package permissions
+
+import "github.com/unkeyed/unkey/pkg/urn"
+
+// ReadAuditLog authorizes listing and exporting a workspace's audit logs.
+//
+// Valid resource: urn.AuditLogs.
+type ReadAuditLog struct{}
+
+func (ReadAuditLog) ActionFor(urn.AuditLogs) {}
+func (ReadAuditLog) String() string          { return "read_audit_log" }
@@ -32,6 +32,11 @@ type PermissionQuery struct {
 	// Children contains sub-queries for non-leaf nodes (OperatorAnd/OperatorOr)
 	Children []PermissionQuery `json:"children,omitempty"`
 
+	// Attributes describes the request a leaf is evaluated for, such as
+	// {"environment": "production"}. They are matched against the
+	// [Conditions] of conditional grants and ignored by unconditional ones.
+	Attributes map[string]string `json:"attributes,omitempty"`
+
 	// When true, this leaf opts into Unkey resource permission matching. The
 	// marker is intentionally not serialized or set by ParseQuery because
 	// wildcard semantics must be chosen by typed call sites through U().
@@ -53,6 +58,7 @@ func And(queries ...PermissionQuery) PermissionQuery {
 		Operation:            OperatorAnd,
 		Value:                "",
 		Children:             queries,
+		Attributes:           nil,
 		matchUnkeyPermission: false,
 	}
 }
@@ -72,6 +78,7 @@ func Or(queries ...PermissionQuery) PermissionQuery {
 		Operation:            OperatorOr,
 		Value:                "",
 		Children:             queries,
+		Attributes:           nil,
 		matchUnkeyPermission: false,
 	}
 }
@@ -93,6 +100,7 @@ func T(tuple Tuple) PermissionQuery {
 		Operation:            OperatorNil,
 		Value:                tuple.String(),
 		Children:             []PermissionQuery{},
+		Attributes:           nil,
 		matchUnkeyPermission: false,
 	}
 }
@@ -110,6 +118,7 @@ func S(s string) PermissionQuery {
 		Operation:            OperatorNil,
 		Value:                s,
 		Children:             []PermissionQuery{},
+		Attributes:           nil,
 		matchUnkeyPermission: false,
 	}
 }
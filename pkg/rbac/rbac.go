@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/fault"
@@ -67,27 +68,58 @@ type EvaluationResult struct {
 //	    fmt.Printf("Access denied: %s\n", result.Message)
 //	}
 func (r *RBAC) EvaluatePermissions(query PermissionQuery, permissions []string) (EvaluationResult, error) {
-	return r.evaluateQueryV1(query, permissions)
+	return r.evaluateQueryV1(query, Grants(permissions), EvaluationContext{Time: time.Now(), Meta: nil})
+}
+
+// EvaluateGrants checks if the provided grants satisfy the permission query.
+// Unconditional grants behave exactly like the permission strings passed to
+// [RBAC.EvaluatePermissions]. A conditional grant only counts for a leaf when
+// its [Conditions] are met by the leaf's attributes and the evaluation
+// context.
+//
+// Example:
+//
+//	grants := []rbac.Grant{{
+//	    Permission: "deploy.write",
+//	    Conditions: &rbac.Conditions{Environments: []string{"staging"}},
+//	}}
+//
+//	query, _ := rbac.ParseQuery("deploy.write[environment=production]")
+//
+//	result, err := r.EvaluateGrants(query, grants, rbac.EvaluationContext{Time: time.Now()})
+//	// result.Valid is false: the grant does not cover production
+func (r *RBAC) EvaluateGrants(query PermissionQuery, grants []Grant, ectx EvaluationContext) (EvaluationResult, error) {
+	if ectx.Time.IsZero() {
+		ectx.Time = time.Now()
+	}
+	return r.evaluateQueryV1(query, grants, ectx)
 }
 
 // evaluateQueryV1 recursively evaluates a permission query tree against the
-// granted permission strings, combining child results per the node operator.
-func (r *RBAC) evaluateQueryV1(query PermissionQuery, permissions []string) (EvaluationResult, error) {
+// grants, combining child results per the node operator.
+func (r *RBAC) evaluateQueryV1(query PermissionQuery, grants []Grant, ectx EvaluationContext) (EvaluationResult, error) {
 	// Handle simple permission check
 	if query.Value != "" {
-		if evaluateLeafPermission(query, permissions) {
+		ok, reason := evaluateLeafPermission(query, grants, ectx)
+		if ok {
 			return EvaluationResult{Valid: true, Message: ""}, nil
 		}
+		if reason != "" {
+			return EvaluationResult{
+				Valid:   false,
+				Message: fmt.Sprintf("Permission '%s' does not apply: %s", FormatPermissionQuery(query), reason),
+			}, nil
+		}
 		return EvaluationResult{
 			Valid:   false,
-			Message: fmt.Sprintf("Missing permission: '%s'", query.Value),
+			Message: fmt.Sprintf("Missing permission: '%s'", FormatPermissionQuery(query)),
 		}, nil
 	}
 
 	// Handle AND operation
 	if query.Operation == OperatorAnd {
 		for _, child := range query.Children {
-			result, err := r.evaluateQueryV1(child, permissions)
+			result, err := r.evaluateQueryV1(child, grants, ectx)
 			if err != nil {
 				return EvaluationResult{}, err
 			}
@@ -102,7 +134,7 @@ func (r *RBAC) evaluateQueryV1(query PermissionQuery, permissions []string) (Eva
 	if query.Operation == OperatorOr {
 		missingPerms := make([]string, 0)
 		for _, child := range query.Children {
-			result, err := r.evaluateQueryV1(child, permissions)
+			result, err := r.evaluateQueryV1(child, grants, ectx)
 			if err != nil {
 				return EvaluationResult{}, err
 			}
@@ -129,7 +161,7 @@ func (r *RBAC) evaluateQueryV1(query PermissionQuery, permissions []string) (Eva
 // in authorization errors and diagnostics.
 func FormatPermissionQuery(query PermissionQuery) string {
 	if query.Value != "" {
-		return query.Value
+		return query.Value + formatAttributes(query.Attributes)
 	}
 
 	parts := make([]string, 0, len(query.Children))
@@ -149,24 +181,60 @@ func FormatPermissionQuery(query PermissionQuery) string {
 	}
 }
 
+// formatAttributes renders leaf attributes in query syntax, sorted by name so
+// messages are stable.
+func formatAttributes(attributes map[string]string) string {
+	if len(attributes) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + "=" + attributes[name]
+	}
+	return "[" + strings.Join(pairs, ",") + "]"
+}
+
 // evaluateLeafPermission is the shared leaf evaluator for the boolean query
 // tree. Exact string matching is always available. Unkey wildcard matching is
 // an explicit opt-in carried by U(), not a behavior inferred from string shape.
-func evaluateLeafPermission(query PermissionQuery, permissions []string) bool {
+//
+// Only grants whose conditions are met take part in matching. When the leaf
+// fails although a grant names it exactly, the returned reason explains which
+// condition was not met.
+func evaluateLeafPermission(query PermissionQuery, grants []Grant, ectx EvaluationContext) (bool, string) {
+	permissions := make([]string, 0, len(grants))
+	reason := ""
+	for _, grant := range grants {
+		if unmet := grant.Conditions.unmet(query.Attributes, ectx); unmet != "" {
+			if grant.Permission == query.Value && reason == "" {
+				reason = unmet
+			}
+			continue
+		}
+		permissions = append(permissions, grant.Permission)
+	}
+
 	if slices.Contains(permissions, query.Value) {
-		return true
+		return true, ""
 	}
 
 	if !query.matchUnkeyPermission {
-		return false
+		return false, reason
 	}
 
 	requiredPermission, err := parseUrnPermission(query.Value)
 	if err != nil {
-		return false
+		return false, reason
 	}
 
-	return evaluateUnkeyPermission(requiredPermission, permissions)
+	return evaluateUnkeyPermission(requiredPermission, permissions), reason
 }
 
 // ParseQuery parses a SQL-like permission query string and returns a PermissionQuery.
@@ -176,6 +244,8 @@ func evaluateLeafPermission(query PermissionQuery, permissions []string) bool {
 //   - Operators: AND, OR (case-insensitive)
 //   - Grouping: parentheses ()
 //   - Precedence: AND has higher precedence than OR
+//   - Attributes: a bracketed list directly after a permission, e.g. "deploy.write[environment=production]",
+//     describing the request for conditional grants (see [RBAC.EvaluateGrants])
 //
 // Important: Asterisks (*) in permission names are treated as literal characters,
 // NOT as wildcard patterns. For example, "api.*" will only match a permission
@@ -188,6 +258,7 @@ func evaluateLeafPermission(query PermissionQuery, permissions []string) bool {
 //   - "perm1 AND perm2"
 //   - "perm1 OR perm2 AND perm3" (parsed as "perm1 OR (perm2 AND perm3)")
 //   - "(perm1 OR perm2) AND perm3"
+//   - "deploy.write[environment=production,region=eu]"
 //
 // Limits:
 //   - Maximum query length: 1000 characters
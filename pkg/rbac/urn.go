@@ -39,6 +39,7 @@ func U[R fmt.Stringer, A permissions.Action[R]](resource R, action A) Permission
 		Operation:            OperatorNil,
 		Value:                fmt.Sprintf("%s#%s", resource.String(), action.String()),
 		Children:             []PermissionQuery{},
+		Attributes:           nil,
 		matchUnkeyPermission: true,
 	}
 }
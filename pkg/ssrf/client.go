@@ -0,0 +1,79 @@
+package ssrf
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// Config configures a client created by [NewClient].
+type Config struct {
+	// Timeout bounds a whole request, and separately the dial and the wait
+	// for response headers.
+	Timeout time.Duration
+
+	// AllowPrivateNetworks disables the address check. Only local
+	// development, where the targets run next to us, should set it.
+	AllowPrivateNetworks bool
+
+	// FollowRedirects makes the client follow redirects. Redirect targets
+	// go through the same address check. When false, a 3xx response is
+	// returned to the caller as is.
+	FollowRedirects bool
+}
+
+// NewClient returns an HTTP client that only connects to public addresses.
+func NewClient(config Config) *http.Client {
+	//nolint:exhaustruct
+	dialer := &net.Dialer{
+		Timeout:   config.Timeout,
+		KeepAlive: 30 * time.Second,
+	}
+	if !config.AllowPrivateNetworks {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !IsPublicAddr(addrPort.Addr()) {
+				return fmt.Errorf("refusing to connect to non-public address %s", addrPort.Addr())
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	transport.ResponseHeaderTimeout = config.Timeout
+
+	//nolint:exhaustruct
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   config.Timeout,
+	}
+	if !config.FollowRedirects {
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	return client
+}
+
+// IsPublicAddr reports whether addr is a globally routable unicast address.
+// IPv4-mapped IPv6 addresses are judged by the IPv4 address they carry.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!addr.IsLoopback() &&
+		!addr.IsLinkLocalUnicast() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+// sharedAddressSpace is the RFC 6598 carrier-grade NAT range, which
+// IsPrivate does not cover but which some clouds use for internal services.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
@@ -0,0 +1,63 @@
+package ssrf
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	for addr, public := range map[string]bool{
+		"1.1.1.1":         true,
+		"8.8.8.8":         true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"10.0.0.1":        false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"::1":             false,
+		"fd00::1":         false,
+		"::ffff:10.0.0.1": false,
+		"0.0.0.0":         false,
+	} {
+		require.Equal(t, public, IsPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	t.Run("refuses non-public addresses", func(t *testing.T) {
+		client := NewClient(Config{Timeout: time.Second, AllowPrivateNetworks: false, FollowRedirects: true})
+		_, err := client.Get(server.URL)
+		require.ErrorContains(t, err, "non-public address")
+	})
+
+	t.Run("private networks can be allowed", func(t *testing.T) {
+		client := NewClient(Config{Timeout: time.Second, AllowPrivateNetworks: true, FollowRedirects: true})
+		resp, err := client.Get(server.URL + "/redirect")
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("redirects are returned when not followed", func(t *testing.T) {
+		client := NewClient(Config{Timeout: time.Second, AllowPrivateNetworks: true, FollowRedirects: false})
+		resp, err := client.Get(server.URL + "/redirect")
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+	})
+}
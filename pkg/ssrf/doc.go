@@ -0,0 +1,20 @@
+// Package ssrf builds HTTP clients for fetching customer-supplied URLs from
+// inside our network, such as JWKS documents and webhook endpoints.
+//
+// The clients refuse to connect to any address that is not publicly
+// routable: loopback, private, link-local (including cloud metadata
+// endpoints) and the RFC 6598 shared address space. The check runs on the
+// resolved IP at dial time rather than on the hostname, so DNS names and
+// redirects pointing at internal addresses are refused as well. Proxies from
+// the environment are ignored, since a proxy would dial on our behalf and
+// bypass the check.
+//
+// Example usage:
+//
+//	client := ssrf.NewClient(ssrf.Config{
+//	    Timeout:              10 * time.Second,
+//	    AllowPrivateNetworks: false,
+//	    FollowRedirects:      true,
+//	})
+//	resp, err := client.Get(customerURL)
+package ssrf
@@ -13,6 +13,8 @@ const (
 	TestPrefix                Prefix = "test" // for tests only
 	RatelimitNamespacePrefix  Prefix = "rlns"
 	RatelimitOverridePrefix   Prefix = "rlor"
+	RatelimitPlanPrefix       Prefix = "rlpl"
+	RatelimitLeasePrefix      Prefix = "rlls"
 	PermissionPrefix          Prefix = "perm"
 	IdentityPrefix            Prefix = "id"
 	RatelimitPrefix           Prefix = "rl"
@@ -24,6 +26,7 @@ const (
 	ClusterPrefix             Prefix = "cls"
 	RegionPrefix              Prefix = "rgn"
 	OrgPrefix                 Prefix = "org"
+	KeyBulkOperationPrefix    Prefix = "kbop"
 
 	// Portal prefixes
 	//
@@ -52,6 +55,16 @@ const (
 	FrontlineRoutePrefix      Prefix = "flr"
 	CertificatePrefix         Prefix = "cert"
 	PolicyPrefix              Prefix = "pol"
+	VolumePrefix              Prefix = "vol"
 
 	AutoscalingPolicyPrefix Prefix = "asp"
+
+	// Webhook prefixes
+	//
+	// One event fans out to one delivery per subscribed endpoint, so the event
+	// id is shared across deliveries while each delivery id is unique and is
+	// what receivers see as the webhook-id header.
+	WebhookEndpointPrefix Prefix = "whep"
+	WebhookDeliveryPrefix Prefix = "whdl"
+	WebhookEventPrefix    Prefix = "evt"
 )
@@ -0,0 +1,17 @@
+package urn
+
+// AuditLogs builds the audit log resource path.
+//
+// Hierarchy:
+//
+//	workspace
+//	└── audit_logs
+type AuditLogs struct {
+	workspaceID string
+	path        string
+}
+
+// String returns the audit log resource path.
+func (a AuditLogs) String() string {
+	return V1{WorkspaceID: a.workspaceID, Resource: a.path}.String()
+}
@@ -13,7 +13,8 @@ import "fmt"
 //	├── ratelimits/namespaces/{namespace_id}
 //	├── rbac
 //	├── projects/{project_id}
-//	└── portals/{portal_id}
+//	├── portals/{portal_id}
+//	└── audit_logs
 //
 // Children with their own descendants return another typed builder. Leaf
 // resources return V1 directly.
@@ -76,3 +77,13 @@ func (w workspace) Project(projectID string) Project {
 func (w workspace) Portal(portalID string) Portal {
 	return Portal{workspaceID: w.workspaceID, path: fmt.Sprintf("portals/%s", portalID)}
 }
+
+// AuditLogs returns the audit log resource path.
+//
+// Subresource:
+//
+//	workspace
+//	└── audit_logs
+func (w workspace) AuditLogs() AuditLogs {
+	return AuditLogs{workspaceID: w.workspaceID, path: "audit_logs"}
+}
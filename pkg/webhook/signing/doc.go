@@ -0,0 +1,19 @@
+// Package signing signs and verifies outbound webhook requests.
+//
+// The scheme follows the Standard Webhooks specification so receivers can
+// use any off-the-shelf verifier instead of an Unkey-specific one. Every
+// request carries three headers:
+//
+//   - webhook-id: the delivery id. Stable across retries of one delivery, so
+//     receivers can use it to deduplicate.
+//   - webhook-timestamp: unix seconds when the attempt was signed. Receivers
+//     reject stale timestamps to bound replay of a captured request.
+//   - webhook-signature: space-separated "v1,<base64>" entries, each an
+//     HMAC-SHA256 over "<id>.<timestamp>.<body>".
+//
+// Secrets are "whsec_" followed by the base64 encoded key bytes. The prefix
+// is stripped and the remainder decoded before it is used as the HMAC key.
+//
+// Inbound provider webhooks (GitHub, Stripe) live in the parent package;
+// this package only concerns the webhooks Unkey sends.
+package signing
@@ -0,0 +1,149 @@
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// HeaderID carries the delivery id.
+	HeaderID = "webhook-id"
+	// HeaderTimestamp carries the signing time in unix seconds.
+	HeaderTimestamp = "webhook-timestamp"
+	// HeaderSignature carries one or more "v1,<base64>" signatures.
+	HeaderSignature = "webhook-signature"
+
+	// SecretPrefix marks a webhook signing secret.
+	SecretPrefix = "whsec_"
+
+	// DefaultTolerance is how far a timestamp may drift from the verifier's
+	// clock before Verify rejects the request.
+	DefaultTolerance = 5 * time.Minute
+
+	secretBytes      = 32
+	signatureVersion = "v1"
+)
+
+var (
+	// ErrInvalidSecret is returned when a secret is not "whsec_" followed by
+	// valid base64.
+	ErrInvalidSecret = errors.New("invalid webhook secret")
+	// ErrMissingHeaders is returned when any of the signing headers is absent.
+	ErrMissingHeaders = errors.New("missing webhook signature headers")
+	// ErrTimestampOutOfRange is returned when the timestamp is further from
+	// now than the tolerance allows.
+	ErrTimestampOutOfRange = errors.New("webhook timestamp out of range")
+	// ErrNoMatchingSignature is returned when no v1 signature matches.
+	ErrNoMatchingSignature = errors.New("no matching webhook signature")
+)
+
+// NewSecret generates a random signing secret.
+func NewSecret() (string, error) {
+	key := make([]byte, secretBytes)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	return SecretPrefix + base64.StdEncoding.EncodeToString(key), nil
+}
+
+// Sign returns the webhook-signature header value for one request.
+func Sign(secret, id string, timestamp time.Time, body []byte) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return signatureVersion + "," + base64.StdEncoding.EncodeToString(mac(key, id, timestamp.Unix(), body)), nil
+}
+
+// SetHeaders signs body and sets all three signing headers on h.
+func SetHeaders(h http.Header, secret, id string, timestamp time.Time, body []byte) error {
+	signature, err := Sign(secret, id, timestamp, body)
+	if err != nil {
+		return err
+	}
+
+	h.Set(HeaderID, id)
+	h.Set(HeaderTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+	h.Set(HeaderSignature, signature)
+	return nil
+}
+
+// Verify checks the signing headers in h against body. It accepts the
+// request if any v1 signature matches, which lets a receiver keep verifying
+// while a secret is being rotated.
+func Verify(secret string, h http.Header, body []byte, now time.Time, tolerance time.Duration) error {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return err
+	}
+
+	id := h.Get(HeaderID)
+	ts := h.Get(HeaderTimestamp)
+	signatures := h.Get(HeaderSignature)
+	if id == "" || ts == "" || signatures == "" {
+		return ErrMissingHeaders
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %q is not a unix timestamp", ErrTimestampOutOfRange, ts)
+	}
+
+	signedAt := time.Unix(unix, 0)
+	if signedAt.Before(now.Add(-tolerance)) || signedAt.After(now.Add(tolerance)) {
+		return ErrTimestampOutOfRange
+	}
+
+	expected := mac(key, id, unix, body)
+	for _, entry := range strings.Fields(signatures) {
+		version, encoded, ok := strings.Cut(entry, ",")
+		if !ok || version != signatureVersion {
+			continue
+		}
+
+		got, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+
+		if hmac.Equal(got, expected) {
+			return nil
+		}
+	}
+
+	return ErrNoMatchingSignature
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	encoded, ok := strings.CutPrefix(secret, SecretPrefix)
+	if !ok {
+		return nil, ErrInvalidSecret
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+
+	return key, nil
+}
+
+func mac(key []byte, id string, unix int64, body []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(id))
+	h.Write([]byte{'.'})
+	h.Write(strconv.AppendInt(nil, unix, 10))
+	h.Write([]byte{'.'})
+	h.Write(body)
+	return h.Sum(nil)
+}
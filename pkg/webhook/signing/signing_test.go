@@ -0,0 +1,89 @@
+package signing
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test vector from the Standard Webhooks reference implementations.
+const (
+	vectorSecret    = "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"
+	vectorID        = "msg_p5jXN8AQM9LWM0D4loKWxJek"
+	vectorTimestamp = 1614265330
+	vectorBody      = `{"test": 2432232314}`
+	vectorSignature = "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE="
+)
+
+func TestSignMatchesStandardWebhooksVector(t *testing.T) {
+	got, err := Sign(vectorSecret, vectorID, time.Unix(vectorTimestamp, 0), []byte(vectorBody))
+	require.NoError(t, err)
+	require.Equal(t, vectorSignature, got)
+}
+
+func TestVerify(t *testing.T) {
+	secret, err := NewSecret()
+	require.NoError(t, err)
+
+	now := time.Unix(1_700_000_000, 0)
+	body := []byte(`{"type":"key.created"}`)
+
+	signed := func(t *testing.T) http.Header {
+		t.Helper()
+		h := http.Header{}
+		require.NoError(t, SetHeaders(h, secret, "whdl_123", now, body))
+		return h
+	}
+
+	t.Run("accepts a valid signature", func(t *testing.T) {
+		require.NoError(t, Verify(secret, signed(t), body, now, DefaultTolerance))
+	})
+
+	t.Run("accepts any matching signature in the list", func(t *testing.T) {
+		h := signed(t)
+		h.Set(HeaderSignature, "v1,bm90IGl0 "+h.Get(HeaderSignature))
+		require.NoError(t, Verify(secret, h, body, now, DefaultTolerance))
+	})
+
+	t.Run("rejects a tampered body", func(t *testing.T) {
+		err := Verify(secret, signed(t), []byte(`{"type":"key.deleted"}`), now, DefaultTolerance)
+		require.ErrorIs(t, err, ErrNoMatchingSignature)
+	})
+
+	t.Run("rejects a different secret", func(t *testing.T) {
+		other, err := NewSecret()
+		require.NoError(t, err)
+		require.ErrorIs(t, Verify(other, signed(t), body, now, DefaultTolerance), ErrNoMatchingSignature)
+	})
+
+	t.Run("rejects stale timestamps", func(t *testing.T) {
+		err := Verify(secret, signed(t), body, now.Add(DefaultTolerance+time.Second), DefaultTolerance)
+		require.ErrorIs(t, err, ErrTimestampOutOfRange)
+	})
+
+	t.Run("rejects timestamps from the future", func(t *testing.T) {
+		err := Verify(secret, signed(t), body, now.Add(-DefaultTolerance-time.Second), DefaultTolerance)
+		require.ErrorIs(t, err, ErrTimestampOutOfRange)
+	})
+
+	t.Run("rejects missing headers", func(t *testing.T) {
+		h := signed(t)
+		h.Del(HeaderID)
+		require.ErrorIs(t, Verify(secret, h, body, now, DefaultTolerance), ErrMissingHeaders)
+	})
+
+	t.Run("ignores unknown signature versions", func(t *testing.T) {
+		h := signed(t)
+		h.Set(HeaderSignature, "v1a,"+h.Get(HeaderSignature)[3:])
+		require.ErrorIs(t, Verify(secret, h, body, now, DefaultTolerance), ErrNoMatchingSignature)
+	})
+}
+
+func TestInvalidSecret(t *testing.T) {
+	for _, secret := range []string{"", "MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw", "whsec_", "whsec_!!!"} {
+		_, err := Sign(secret, "id", time.Now(), nil)
+		require.ErrorIs(t, err, ErrInvalidSecret, secret)
+	}
+}
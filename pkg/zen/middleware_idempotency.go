@@ -0,0 +1,209 @@
+package zen
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/logger"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header clients use to mark a request
+	// as safe to retry.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is set to "true" on responses that were replayed
+	// from a previous request with the same idempotency key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// MaxIdempotencyKeyLength is the longest idempotency key accepted.
+	MaxIdempotencyKeyLength = 255
+
+	// DefaultIdempotencyTTL is how long idempotency records are kept when no
+	// window is configured.
+	DefaultIdempotencyTTL = 24 * time.Hour
+)
+
+// IdempotencyScope identifies who owns an idempotency key. The same key can be
+// used independently by different workspaces, and by different root keys of
+// one workspace.
+type IdempotencyScope struct {
+	// WorkspaceID is the workspace of the authenticated principal.
+	WorkspaceID string
+
+	// SubjectID is the root key, or user, that sent the request.
+	SubjectID string
+}
+
+// IdempotencyRecord is the state stored for a single idempotency key.
+type IdempotencyRecord struct {
+	// Fingerprint identifies the request the key was first used with.
+	Fingerprint string
+
+	// Completed is false while the first request is still being processed.
+	Completed bool
+
+	// Status, ContentType and Body hold the response to replay. They are only
+	// set when Completed is true.
+	Status      int
+	ContentType string
+	Body        []byte
+
+	// Consumed is true when the response held a secret and was already
+	// replayed once. Such responses are not stored after their first replay.
+	Consumed bool
+}
+
+// IdempotencyStore persists idempotency records. Records are scoped to the
+// principal that sent the request, see [IdempotencyScope].
+type IdempotencyStore interface {
+	// Reserve claims key for a request with the given fingerprint until ttl
+	// elapses. It returns true if the caller now owns the key. Otherwise it
+	// returns the record that already holds the key.
+	Reserve(ctx context.Context, scope IdempotencyScope, key, fingerprint string, ttl time.Duration) (IdempotencyRecord, bool, error)
+
+	// Complete stores the response for a key reserved with fingerprint.
+	Complete(ctx context.Context, scope IdempotencyScope, key, fingerprint string, status int, contentType string, body []byte) error
+
+	// Release drops a reservation so the request can be retried.
+	Release(ctx context.Context, scope IdempotencyScope, key, fingerprint string) error
+}
+
+// WithIdempotency returns middleware that makes requests carrying an
+// [IdempotencyKeyHeader] safe to retry. The first request with a key is
+// processed normally and its response is stored; later requests with the same
+// key and an identical body get the stored response replayed instead of being
+// processed again.
+//
+// Records are scoped to the authenticated workspace and subject, so the
+// middleware must run after authentication. Attach it only to routes that
+// change state; reads are safe to retry without it. Responses with a status of 500 or above, and requests
+// whose handler returned an error, are not stored: the reservation is released
+// and a retry is processed again.
+//
+// Requests without the header, and servers that stream request bodies, pass
+// through untouched.
+func WithIdempotency(store IdempotencyStore, ttl time.Duration) Middleware {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+
+	return func(next HandleFunc) HandleFunc {
+		return func(ctx context.Context, s *Session) error {
+			key := s.Request().Header.Get(IdempotencyKeyHeader)
+			if key == "" || s.streamRequestBody {
+				return next(ctx, s)
+			}
+
+			if !validIdempotencyKey(key) {
+				return fault.New("invalid idempotency key",
+					fault.Code(codes.User.BadRequest.InvalidIdempotencyKey.URN()),
+					fault.Internal("idempotency key is empty, too long or not printable ascii"),
+					fault.Public("The Idempotency-Key header must be between 1 and 255 printable ASCII characters."),
+				)
+			}
+
+			p, err := s.GetPrincipal()
+			if err != nil {
+				return err
+			}
+			scope := IdempotencyScope{WorkspaceID: p.WorkspaceID, SubjectID: p.Subject.ID}
+			fingerprint := idempotencyFingerprint(s.Request(), s.requestBody)
+
+			record, reserved, err := store.Reserve(ctx, scope, key, fingerprint, ttl)
+			if err != nil {
+				return fault.Wrap(err,
+					fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+					fault.Internal("unable to reserve idempotency key"),
+					fault.Public("We could not process the Idempotency-Key, please try again."),
+				)
+			}
+
+			if !reserved {
+				if record.Fingerprint != fingerprint {
+					return fault.New("idempotency key mismatch",
+						fault.Code(codes.User.UnprocessableEntity.IdempotencyKeyMismatch.URN()),
+						fault.Internal("idempotency key was used with a different request fingerprint"),
+						fault.Public("This Idempotency-Key was already used for a request with a different endpoint or body."),
+					)
+				}
+				if !record.Completed {
+					return fault.New("idempotency key in use",
+						fault.Code(codes.User.Conflict.IdempotencyKeyInUse.URN()),
+						fault.Internal("idempotency key is reserved by a request in flight"),
+						fault.Public("A request with this Idempotency-Key is still being processed. Retry once it has completed."),
+					)
+				}
+				if record.Consumed {
+					return fault.New("idempotent response consumed",
+						fault.Code(codes.User.Conflict.IdempotencyKeyConsumed.URN()),
+						fault.Internal("stored response held a secret and was already replayed"),
+						fault.Public("The response for this Idempotency-Key contained a secret and was already returned once."),
+					)
+				}
+
+				if record.ContentType != "" {
+					s.w.Header().Set("Content-Type", record.ContentType)
+				}
+				s.w.Header().Set(IdempotentReplayedHeader, "true")
+				return s.send(record.Status, record.Body)
+			}
+
+			err = next(ctx, s)
+
+			// The request context may already be cancelled, but the record must
+			// still be settled or the key stays blocked until it expires.
+			settleCtx := context.WithoutCancel(ctx)
+			if err != nil || s.responseStatus == 0 || s.responseStatus >= http.StatusInternalServerError {
+				if releaseErr := store.Release(settleCtx, scope, key, fingerprint); releaseErr != nil {
+					logger.Error("failed to release idempotency key",
+						"workspaceId", scope.WorkspaceID,
+						"error", releaseErr.Error(),
+					)
+				}
+				return err
+			}
+
+			if completeErr := store.Complete(settleCtx, scope, key, fingerprint,
+				s.responseStatus, s.w.Header().Get("Content-Type"), s.responseBody,
+			); completeErr != nil {
+				logger.Error("failed to store idempotent response",
+					"workspaceId", scope.WorkspaceID,
+					"error", completeErr.Error(),
+				)
+			}
+			return nil
+		}
+	}
+}
+
+// validIdempotencyKey reports whether key is 1 to [MaxIdempotencyKeyLength]
+// printable ASCII characters.
+func validIdempotencyKey(key string) bool {
+	if len(key) == 0 || len(key) > MaxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// idempotencyFingerprint hashes everything that must match for a stored
+// response to be a valid answer to a retried request.
+func idempotencyFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(r.URL.Path))
+	h.Write([]byte{'\n'})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,236 @@
+package zen
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/auth/principal"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/fault"
+)
+
+// memoryIdempotencyStore keeps records in a map. With sealed set it replays
+// each stored body once, like the MySQL store does for secret-bearing routes.
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	sealed  bool
+	records map[IdempotencyScope]map[string]IdempotencyRecord
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{mu: sync.Mutex{}, sealed: false, records: map[IdempotencyScope]map[string]IdempotencyRecord{}}
+}
+
+func (m *memoryIdempotencyStore) Reserve(_ context.Context, scope IdempotencyScope, key, fingerprint string, _ time.Duration) (IdempotencyRecord, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.records[scope][key]; ok {
+		if m.sealed && existing.Completed && existing.Fingerprint == fingerprint {
+			consumed := existing
+			consumed.Body = nil
+			consumed.Consumed = true
+			m.records[scope][key] = consumed
+		}
+		return existing, false, nil
+	}
+	if m.records[scope] == nil {
+		m.records[scope] = map[string]IdempotencyRecord{}
+	}
+	m.records[scope][key] = IdempotencyRecord{Fingerprint: fingerprint}
+	return IdempotencyRecord{}, true, nil
+}
+
+func (m *memoryIdempotencyStore) Complete(_ context.Context, scope IdempotencyScope, key, fingerprint string, status int, contentType string, body []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records[scope][key] = IdempotencyRecord{
+		Fingerprint: fingerprint,
+		Completed:   true,
+		Status:      status,
+		ContentType: contentType,
+		Body:        body,
+		Consumed:    false,
+	}
+	return nil
+}
+
+func (m *memoryIdempotencyStore) Release(_ context.Context, scope IdempotencyScope, key, _ string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.records[scope], key)
+	return nil
+}
+
+func runIdempotent(t *testing.T, handler HandleFunc, workspaceID, key, body string) (*httptest.ResponseRecorder, error) {
+	t.Helper()
+	return runIdempotentAs(t, handler, workspaceID, "root_1", key, body)
+}
+
+func runIdempotentAs(t *testing.T, handler HandleFunc, workspaceID, subjectID, key, body string) (*httptest.ResponseRecorder, error) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/v2/keys.createKey", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+
+	sess := &Session{}
+	require.NoError(t, sess.Init(w, req, 0))
+	sess.SetPrincipal(&principal.Principal{WorkspaceID: workspaceID, Subject: principal.Subject{ID: subjectID}})
+
+	return w, handler(context.Background(), sess)
+}
+
+func TestWithIdempotency(t *testing.T) {
+	t.Run("replays the stored response", func(t *testing.T) {
+		calls := 0
+		handler := WithIdempotency(newMemoryIdempotencyStore(), time.Hour)(func(ctx context.Context, s *Session) error {
+			calls++
+			return s.JSON(http.StatusOK, map[string]int{"calls": calls})
+		})
+
+		first, err := runIdempotent(t, handler, "ws_1", "key_1", `{"a":1}`)
+		require.NoError(t, err)
+		require.Equal(t, `{"calls":1}`, first.Body.String())
+		require.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+
+		second, err := runIdempotent(t, handler, "ws_1", "key_1", `{"a":1}`)
+		require.NoError(t, err)
+		require.Equal(t, 1, calls)
+		require.Equal(t, http.StatusOK, second.Code)
+		require.Equal(t, `{"calls":1}`, second.Body.String())
+		require.Equal(t, "application/json", second.Header().Get("Content-Type"))
+		require.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+	})
+
+	t.Run("scopes keys to the workspace", func(t *testing.T) {
+		calls := 0
+		handler := WithIdempotency(newMemoryIdempotencyStore(), time.Hour)(func(ctx context.Context, s *Session) error {
+			calls++
+			return s.JSON(http.StatusOK, nil)
+		})
+
+		_, err := runIdempotent(t, handler, "ws_1", "key_1", `{}`)
+		require.NoError(t, err)
+		_, err = runIdempotent(t, handler, "ws_2", "key_1", `{}`)
+		require.NoError(t, err)
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("scopes keys to the root key", func(t *testing.T) {
+		calls := 0
+		handler := WithIdempotency(newMemoryIdempotencyStore(), time.Hour)(func(ctx context.Context, s *Session) error {
+			calls++
+			return s.JSON(http.StatusOK, nil)
+		})
+
+		_, err := runIdempotentAs(t, handler, "ws_1", "root_1", "key_1", `{}`)
+		require.NoError(t, err)
+		_, err = runIdempotentAs(t, handler, "ws_1", "root_2", "key_1", `{}`)
+		require.NoError(t, err)
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("replays a sealed response once", func(t *testing.T) {
+		store := newMemoryIdempotencyStore()
+		store.sealed = true
+		handler := WithIdempotency(store, time.Hour)(func(ctx context.Context, s *Session) error {
+			return s.JSON(http.StatusOK, map[string]string{"key": "sk_123"})
+		})
+
+		_, err := runIdempotent(t, handler, "ws_1", "key_1", `{}`)
+		require.NoError(t, err)
+
+		replayed, err := runIdempotent(t, handler, "ws_1", "key_1", `{}`)
+		require.NoError(t, err)
+		require.Equal(t, `{"key":"sk_123"}`, replayed.Body.String())
+
+		_, err = runIdempotent(t, handler, "ws_1", "key_1", `{}`)
+		urn, ok := fault.GetCode(err)
+		require.True(t, ok)
+		require.Equal(t, codes.User.Conflict.IdempotencyKeyConsumed.URN(), urn)
+	})
+
+	t.Run("rejects a different body", func(t *testing.T) {
+		handler := WithIdempotency(newMemoryIdempotencyStore(), time.Hour)(func(ctx context.Context, s *Session) error {
+			return s.JSON(http.StatusOK, nil)
+		})
+
+		_, err := runIdempotent(t, handler, "ws_1", "key_1", `{"a":1}`)
+		require.NoError(t, err)
+
+		_, err = runIdempotent(t, handler, "ws_1", "key_1", `{"a":2}`)
+		urn, ok := fault.GetCode(err)
+		require.True(t, ok)
+		require.Equal(t, codes.User.UnprocessableEntity.IdempotencyKeyMismatch.URN(), urn)
+	})
+
+	t.Run("rejects a retry while the first request is in flight", func(t *testing.T) {
+		store := newMemoryIdempotencyStore()
+		handler := WithIdempotency(store, time.Hour)(func(ctx context.Context, s *Session) error {
+			return s.JSON(http.StatusOK, nil)
+		})
+
+		_, reserved, err := store.Reserve(context.Background(), IdempotencyScope{WorkspaceID: "ws_1", SubjectID: "root_1"}, "key_1", idempotencyFingerprint(httptest.NewRequest(http.MethodPost, "/v2/keys.createKey", nil), []byte(`{}`)), time.Hour)
+		require.NoError(t, err)
+		require.True(t, reserved)
+
+		_, err = runIdempotent(t, handler, "ws_1", "key_1", `{}`)
+		urn, ok := fault.GetCode(err)
+		require.True(t, ok)
+		require.Equal(t, codes.User.Conflict.IdempotencyKeyInUse.URN(), urn)
+	})
+
+	t.Run("releases the key when the handler fails", func(t *testing.T) {
+		calls := 0
+		handler := WithIdempotency(newMemoryIdempotencyStore(), time.Hour)(func(ctx context.Context, s *Session) error {
+			calls++
+			if calls == 1 {
+				return errors.New("boom")
+			}
+			return s.JSON(http.StatusOK, nil)
+		})
+
+		_, err := runIdempotent(t, handler, "ws_1", "key_1", `{}`)
+		require.Error(t, err)
+
+		_, err = runIdempotent(t, handler, "ws_1", "key_1", `{}`)
+		require.NoError(t, err)
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("rejects invalid keys", func(t *testing.T) {
+		handler := WithIdempotency(newMemoryIdempotencyStore(), time.Hour)(func(ctx context.Context, s *Session) error {
+			return s.JSON(http.StatusOK, nil)
+		})
+
+		for _, key := range []string{strings.Repeat("a", MaxIdempotencyKeyLength+1), "key\twith\ttabs", "ключ"} {
+			_, err := runIdempotent(t, handler, "ws_1", key, `{}`)
+			urn, ok := fault.GetCode(err)
+			require.True(t, ok, key)
+			require.Equal(t, codes.User.BadRequest.InvalidIdempotencyKey.URN(), urn)
+		}
+	})
+
+	t.Run("passes through without a key", func(t *testing.T) {
+		calls := 0
+		handler := WithIdempotency(newMemoryIdempotencyStore(), time.Hour)(func(ctx context.Context, s *Session) error {
+			calls++
+			return s.JSON(http.StatusOK, nil)
+		})
+
+		for range 2 {
+			_, err := runIdempotent(t, handler, "ws_1", "", `{}`)
+			require.NoError(t, err)
+		}
+		require.Equal(t, 2, calls)
+	})
+}
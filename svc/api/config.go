@@ -5,6 +5,7 @@ import (
 	"net"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/unkeyed/unkey/pkg/clock"
 	"github.com/unkeyed/unkey/pkg/config"
@@ -26,6 +27,22 @@ type ClickHouseConfig struct {
 	// and a [VaultConfig] are configured.
 	// Example: "http://clickhouse:8123/default"
 	AnalyticsURL string `toml:"analytics_url"`
+
+	// SpoolDir enables the on-disk spool for the analytics buffers. Rows that
+	// do not fit into a full buffer, or whose insert failed after retries,
+	// are written below this directory and replayed once ClickHouse accepts
+	// inserts again. Use a persistent volume so rows survive restarts. When
+	// empty, such rows are dropped.
+	SpoolDir string `toml:"spool_dir"`
+
+	// SpoolMaxBytes caps the disk space used below SpoolDir, summed over all
+	// analytics buffers. Rows beyond it are dropped. Defaults to 1 GiB.
+	SpoolMaxBytes int64 `toml:"spool_max_bytes" config:"default=1073741824,min=1"`
+
+	// SpoolFsync controls when spooled rows are synced to disk: after every
+	// write ("always"), once per second ("interval"), or never ("never"),
+	// leaving it to the operating system.
+	SpoolFsync string `toml:"spool_fsync" config:"default=interval,oneof=always|interval|never"`
 }
 
 // GitHubConfig holds the API's GitHub App settings: the App slug and private
@@ -351,6 +368,10 @@ type Config struct {
 	// Set to 0 or negative to disable the limit. Defaults to 10 MiB.
 	MaxRequestBodySize int64 `toml:"max_request_body_size" config:"default=10485760"`
 
+	// IdempotencyTTL is how long a response stored for an Idempotency-Key is
+	// replayed. A key can be reused for a new request once it expires.
+	IdempotencyTTL time.Duration `toml:"idempotency_ttl" config:"default=24h"`
+
 	// Database configures MySQL connections. See [config.DatabaseConfig].
 	Database config.DatabaseConfig `toml:"database"`
 
@@ -0,0 +1,233 @@
+// Package auditquery turns the filters of the auditlogs.* routes into
+// ClickHouse audit log queries and maps the resulting rows onto their openapi
+// wire type.
+package auditquery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unkeyed/unkey/internal/services/caches"
+	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/clickhouse"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+// Bucket is the audit log bucket platform events are written to.
+const Bucket = "unkey_mutations"
+
+// defaultRetentionDays applies when the workspace has no audit log retention
+// limit configured. It matches the free tier, which is also what the dashboard
+// falls back to.
+const defaultRetentionDays = 30
+
+// millisPerDay is the width of one retention day in unix milliseconds.
+const millisPerDay = 24 * 60 * 60 * 1000
+
+// Filter holds the filters shared by auditlogs.listAuditLogs and
+// auditlogs.exportAuditLogs. Nil fields do not filter.
+type Filter struct {
+	Start         *int64
+	End           *int64
+	Events        *[]string
+	ActorIDs      *[]string
+	TargetIDs     *[]string
+	CorrelationID *string
+	Cursor        *string
+}
+
+// RetentionDays returns how many days of audit logs the workspace may read.
+func RetentionDays(ctx context.Context, limitsCache cache.Cache[string, keysdb.Limit], database db.Database, workspaceID string) (int, error) {
+	limits, _, err := limitsCache.SWR(ctx, workspaceID, func(ctx context.Context) (keysdb.Limit, error) {
+		return keysdb.Query.FindLimitsByWorkspaceID(ctx, database.RO(), workspaceID)
+	}, caches.DefaultFindFirstOp)
+	if err != nil {
+		return 0, fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("failed to load workspace limits"),
+			fault.Public("Failed to load the audit log retention of your workspace."),
+		)
+	}
+
+	if limits.LogsAuditRetentionDaysMax == 0 {
+		return defaultRetentionDays, nil
+	}
+	return int(limits.LogsAuditRetentionDaysMax), nil
+}
+
+// Request builds the ClickHouse request for one page of audit logs. The start
+// of the window is clamped to the retention cutoff so a workspace never reads
+// events older than its plan allows, even while ClickHouse still holds them.
+// A window that is empty after clamping is rejected rather than answered with
+// an empty page.
+func Request(workspaceID string, f Filter, retentionDays int, now time.Time, limit int) (clickhouse.ListAuditLogsRequest, error) {
+	cutoff := now.UnixMilli() - int64(retentionDays)*millisPerDay
+
+	start := max(ptr.SafeDeref(f.Start, cutoff), cutoff)
+	end := ptr.SafeDeref(f.End, now.UnixMilli())
+	if end < cutoff {
+		return clickhouse.ListAuditLogsRequest{}, fault.New("invalid time window",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal("end is before the retention cutoff"),
+			fault.Public(fmt.Sprintf("`end` must be within the audit log retention of your workspace, the last %d days.", retentionDays)),
+		)
+	}
+	if end < start {
+		return clickhouse.ListAuditLogsRequest{}, fault.New("invalid time window",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal("end is before start"),
+			fault.Public("`end` must not be before `start`. Without `end`, `start` must not be in the future."),
+		)
+	}
+
+	var cursor *clickhouse.AuditLogCursor
+	if f.Cursor != nil {
+		c, err := ParseCursor(*f.Cursor)
+		if err != nil {
+			return clickhouse.ListAuditLogsRequest{}, err
+		}
+		cursor = &c
+	}
+
+	return clickhouse.ListAuditLogsRequest{
+		WorkspaceID:   workspaceID,
+		Bucket:        Bucket,
+		StartTime:     start,
+		EndTime:       end,
+		Events:        ptr.SafeDeref(f.Events),
+		ActorIDs:      ptr.SafeDeref(f.ActorIDs),
+		TargetIDs:     ptr.SafeDeref(f.TargetIDs),
+		CorrelationID: ptr.SafeDeref(f.CorrelationID),
+		Cursor:        cursor,
+		Limit:         limit,
+	}, nil
+}
+
+// Cursor encodes the position of row so the next page starts at it.
+func Cursor(row clickhouse.AuditLog) string {
+	return fmt.Sprintf("%d_%s", row.Time, row.EventID)
+}
+
+// ParseCursor decodes a cursor produced by [Cursor].
+func ParseCursor(raw string) (clickhouse.AuditLogCursor, error) {
+	invalid := func(err error) error {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal("invalid audit log cursor"),
+			fault.Public("The cursor is invalid. Use the cursor from a previous response."),
+		)
+	}
+
+	rawTime, eventID, ok := strings.Cut(raw, "_")
+	if !ok || eventID == "" {
+		return clickhouse.AuditLogCursor{}, invalid(fmt.Errorf("malformed cursor %q", raw))
+	}
+	t, err := strconv.ParseInt(rawTime, 10, 64)
+	if err != nil {
+		return clickhouse.AuditLogCursor{}, invalid(err)
+	}
+
+	return clickhouse.AuditLogCursor{Time: t, EventID: eventID}, nil
+}
+
+// Log builds the wire representation of an audit log row.
+func Log(row clickhouse.AuditLog) (openapi.AuditLog, error) {
+	meta, err := object(row.Meta)
+	if err != nil {
+		return openapi.AuditLog{}, unreadable(err, row.EventID)
+	}
+	if meta == nil {
+		meta = map[string]any{}
+	}
+
+	actorMeta, err := object(row.ActorMeta)
+	if err != nil {
+		return openapi.AuditLog{}, unreadable(err, row.EventID)
+	}
+
+	targets := make([]openapi.AuditLogTarget, 0, len(row.TargetIDs))
+	for i, id := range row.TargetIDs {
+		target := openapi.AuditLogTarget{
+			Type: at(row.TargetTypes, i),
+			Id:   id,
+			Name: nonEmpty(at(row.TargetNames, i)),
+			Meta: nil,
+		}
+		targetMeta, err := object(at(row.TargetMetas, i))
+		if err != nil {
+			return openapi.AuditLog{}, unreadable(err, row.EventID)
+		}
+		if len(targetMeta) > 0 {
+			target.Meta = &targetMeta
+		}
+		targets = append(targets, target)
+	}
+
+	log := openapi.AuditLog{
+		EventId:     row.EventID,
+		Time:        row.Time,
+		Event:       row.Event,
+		Description: row.Description,
+		Actor: openapi.AuditLogActor{
+			Type: row.ActorType,
+			Id:   row.ActorID,
+			Name: nonEmpty(row.ActorName),
+			Meta: nil,
+		},
+		Targets:       targets,
+		RemoteIp:      row.RemoteIP,
+		UserAgent:     row.UserAgent,
+		Meta:          meta,
+		CorrelationId: nonEmpty(row.CorrelationID),
+	}
+	if len(actorMeta) > 0 {
+		log.Actor.Meta = &actorMeta
+	}
+
+	return log, nil
+}
+
+// object decodes a serialized JSON object. Empty input and JSON null decode to
+// a nil map.
+func object(raw string) (map[string]any, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var m map[string]any
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func unreadable(err error, eventID string) error {
+	return fault.Wrap(err,
+		fault.Code(codes.App.Internal.UnexpectedError.URN()),
+		fault.Internal(fmt.Sprintf("unmarshal metadata of audit log %s", eventID)),
+		fault.Public("Failed to read audit logs."),
+	)
+}
+
+// at returns s[i], or "" if the parallel target arrays are misaligned.
+func at(s []string, i int) string {
+	if i < len(s) {
+		return s[i]
+	}
+	return ""
+}
+
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
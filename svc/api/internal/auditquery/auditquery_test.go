@@ -0,0 +1,110 @@
+package auditquery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/clickhouse"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/ptr"
+)
+
+func TestCursor(t *testing.T) {
+	row := clickhouse.AuditLog{Time: 1701425400000, EventID: "log_1234_abcd"}
+
+	c, err := ParseCursor(Cursor(row))
+	require.NoError(t, err)
+	require.Equal(t, clickhouse.AuditLogCursor{Time: row.Time, EventID: row.EventID}, c)
+
+	for _, raw := range []string{"", "log_1234", "1701425400000_", "1701425400000"} {
+		_, err := ParseCursor(raw)
+		require.Error(t, err, raw)
+	}
+}
+
+func TestRequest(t *testing.T) {
+	now := time.UnixMilli(100 * millisPerDay)
+
+	t.Run("defaults to the retention window", func(t *testing.T) {
+		req, err := Request("ws_1", Filter{}, 30, now, 10)
+		require.NoError(t, err)
+		require.Equal(t, now.UnixMilli()-30*millisPerDay, req.StartTime)
+		require.Equal(t, now.UnixMilli(), req.EndTime)
+		require.Equal(t, Bucket, req.Bucket)
+		require.Nil(t, req.Cursor)
+	})
+
+	t.Run("clamps start to the retention window", func(t *testing.T) {
+		req, err := Request("ws_1", Filter{Start: ptr.P(int64(0))}, 30, now, 10)
+		require.NoError(t, err)
+		require.Equal(t, now.UnixMilli()-30*millisPerDay, req.StartTime)
+	})
+
+	t.Run("rejects end before start", func(t *testing.T) {
+		_, err := Request("ws_1", Filter{Start: ptr.P(int64(2)), End: ptr.P(int64(1))}, 30, now, 10)
+		require.Error(t, err)
+	})
+
+	invalid := func(t *testing.T, f Filter) {
+		t.Helper()
+		_, err := Request("ws_1", f, 30, now, 10)
+		require.Error(t, err)
+		code, ok := fault.GetCode(err)
+		require.True(t, ok)
+		require.Equal(t, codes.App.Validation.InvalidInput.URN(), code)
+	}
+
+	t.Run("rejects end before the clamped start", func(t *testing.T) {
+		invalid(t, Filter{Start: ptr.P(now.UnixMilli() - 2*millisPerDay), End: ptr.P(now.UnixMilli() - 3*millisPerDay)})
+	})
+
+	t.Run("rejects a start in the future without end", func(t *testing.T) {
+		invalid(t, Filter{Start: ptr.P(now.UnixMilli() + 1)})
+	})
+
+	t.Run("rejects a window entirely outside retention", func(t *testing.T) {
+		invalid(t, Filter{End: ptr.P(now.UnixMilli() - 31*millisPerDay)})
+		invalid(t, Filter{Start: ptr.P(int64(0)), End: ptr.P(now.UnixMilli() - 31*millisPerDay)})
+	})
+
+	t.Run("accepts a window ending at the retention cutoff", func(t *testing.T) {
+		cutoff := now.UnixMilli() - 30*millisPerDay
+		req, err := Request("ws_1", Filter{Start: ptr.P(int64(0)), End: ptr.P(cutoff)}, 30, now, 10)
+		require.NoError(t, err)
+		require.Equal(t, cutoff, req.StartTime)
+		require.Equal(t, cutoff, req.EndTime)
+	})
+}
+
+func TestLog(t *testing.T) {
+	log, err := Log(clickhouse.AuditLog{
+		EventID:       "log_1",
+		Time:          1,
+		Event:         "key.create",
+		ActorType:     "rootkey",
+		ActorID:       "key_actor",
+		ActorMeta:     "{}",
+		Meta:          `{"a":1}`,
+		TargetTypes:   []string{"key", "api"},
+		TargetIDs:     []string{"key_1", "api_1"},
+		TargetNames:   []string{"", "prod"},
+		TargetMetas:   []string{"{}", `{"b":true}`},
+		CorrelationID: "",
+	})
+	require.NoError(t, err)
+
+	require.Nil(t, log.Actor.Meta)
+	require.Nil(t, log.Actor.Name)
+	require.Nil(t, log.CorrelationId)
+	require.Equal(t, map[string]any{"a": float64(1)}, log.Meta)
+	require.Len(t, log.Targets, 2)
+	require.Nil(t, log.Targets[0].Name)
+	require.Nil(t, log.Targets[0].Meta)
+	require.Equal(t, "prod", *log.Targets[1].Name)
+	require.Equal(t, map[string]any{"b": true}, *log.Targets[1].Meta)
+
+	_, err = Log(clickhouse.AuditLog{EventID: "log_2", Meta: "not json"})
+	require.Error(t, err)
+}
@@ -0,0 +1,65 @@
+// Package conditions maps permission conditions between the openapi wire
+// types and the rbac types that are stored and evaluated.
+package conditions
+
+import (
+	"encoding/json"
+
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+// ToRBAC converts request conditions into their stored form.
+func ToRBAC(c openapi.PermissionConditions) rbac.Conditions {
+	conditions := rbac.Conditions{
+		Environments: ptr.SafeDeref(c.Environments),
+		TimeWindow:   nil,
+		Meta:         ptr.SafeDeref(c.Meta),
+	}
+	if tw := c.TimeWindow; tw != nil {
+		conditions.TimeWindow = &rbac.TimeWindow{
+			Start:    tw.Start,
+			End:      tw.End,
+			Timezone: ptr.SafeDeref(tw.Timezone),
+		}
+	}
+	return conditions
+}
+
+// ToResponse converts the stored conditions column into the wire type. It
+// returns nil for unconditional permissions and for values it cannot decode,
+// so a malformed row never fails a read endpoint.
+func ToResponse(raw []byte) *openapi.PermissionConditions {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var stored rbac.Conditions
+	if err := json.Unmarshal(raw, &stored); err != nil {
+		return nil
+	}
+
+	conditions := &openapi.PermissionConditions{
+		Environments: nil,
+		TimeWindow:   nil,
+		Meta:         nil,
+	}
+	if len(stored.Environments) > 0 {
+		conditions.Environments = ptr.P(stored.Environments)
+	}
+	if len(stored.Meta) > 0 {
+		conditions.Meta = ptr.P(stored.Meta)
+	}
+	if tw := stored.TimeWindow; tw != nil {
+		conditions.TimeWindow = &openapi.PermissionTimeWindow{
+			Start:    tw.Start,
+			End:      tw.End,
+			Timezone: nil,
+		}
+		if tw.Timezone != "" {
+			conditions.TimeWindow.Timezone = ptr.P(tw.Timezone)
+		}
+	}
+	return conditions
+}
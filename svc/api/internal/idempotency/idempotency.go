@@ -0,0 +1,211 @@
+// Package idempotency backs [zen.WithIdempotency] with the idempotency_keys
+// MySQL table. A key is reserved by inserting its row; the unique index on
+// (workspace_id, subject_id, idempotency_key) makes concurrent reservations
+// race safely. Expired rows are deleted by the control plane's cleanup cron;
+// until then they are taken over in place by the next request that uses the
+// key.
+//
+// Routes whose responses carry a secret, such as the plaintext of a new key,
+// use a store created with [NewSealed]. It stores bodies encrypted with the
+// workspace's vault keyring and clears them after their first replay.
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	vaultv1 "github.com/unkeyed/unkey/gen/proto/vault/v1"
+	"github.com/unkeyed/unkey/gen/rpc/vault"
+	"github.com/unkeyed/unkey/pkg/clock"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/zen"
+)
+
+// maxReserveAttempts bounds how often Reserve retries when the row it raced
+// against disappears before it could be read.
+const maxReserveAttempts = 3
+
+// Store is a MySQL-backed [zen.IdempotencyStore].
+type Store struct {
+	db    db.Database
+	clock clock.Clock
+
+	// vault seals response bodies. Nil stores them as they are.
+	vault vault.VaultServiceClient
+}
+
+var _ zen.IdempotencyStore = (*Store)(nil)
+
+// New creates a [Store] that stores response bodies as they are. A nil clock
+// uses the real clock.
+func New(database db.Database, clk clock.Clock) *Store {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &Store{db: database, clock: clk, vault: nil}
+}
+
+// NewSealed creates a [Store] for routes whose responses carry a secret. It
+// encrypts response bodies with v and replays each body only once. A nil clock
+// uses the real clock.
+func NewSealed(database db.Database, clk clock.Clock, v vault.VaultServiceClient) *Store {
+	s := New(database, clk)
+	s.vault = v
+	return s
+}
+
+// Reserve implements [zen.IdempotencyStore].
+func (s *Store) Reserve(ctx context.Context, scope zen.IdempotencyScope, key, fingerprint string, ttl time.Duration) (zen.IdempotencyRecord, bool, error) {
+	for range maxReserveAttempts {
+		now := s.clock.Now()
+		createdAt := now.UnixMilli()
+		expiresAt := now.Add(ttl).UnixMilli()
+
+		err := db.Query.InsertIdempotencyKey(ctx, s.db.RW(), db.InsertIdempotencyKeyParams{
+			WorkspaceID:    scope.WorkspaceID,
+			SubjectID:      scope.SubjectID,
+			IdempotencyKey: key,
+			Fingerprint:    fingerprint,
+			CreatedAtM:     createdAt,
+			ExpiresAtM:     expiresAt,
+		})
+		if err == nil {
+			return zen.IdempotencyRecord{}, true, nil
+		}
+		if !db.IsDuplicateKeyError(err) {
+			return zen.IdempotencyRecord{}, false, fmt.Errorf("failed to insert idempotency key: %w", err)
+		}
+
+		claimed, err := db.Query.ClaimExpiredIdempotencyKey(ctx, s.db.RW(), db.ClaimExpiredIdempotencyKeyParams{
+			Fingerprint:    fingerprint,
+			CreatedAtM:     createdAt,
+			ExpiresAtM:     expiresAt,
+			WorkspaceID:    scope.WorkspaceID,
+			SubjectID:      scope.SubjectID,
+			IdempotencyKey: key,
+		})
+		if err != nil {
+			return zen.IdempotencyRecord{}, false, fmt.Errorf("failed to claim expired idempotency key: %w", err)
+		}
+		if claimed > 0 {
+			return zen.IdempotencyRecord{}, true, nil
+		}
+
+		// Read from the primary: the row was written moments ago and a replica
+		// may not have it yet.
+		row, err := db.Query.FindIdempotencyKey(ctx, s.db.RW(), db.FindIdempotencyKeyParams{
+			WorkspaceID:    scope.WorkspaceID,
+			SubjectID:      scope.SubjectID,
+			IdempotencyKey: key,
+		})
+		if db.IsNotFound(err) {
+			// Released or cleaned up between our insert and this read.
+			continue
+		}
+		if err != nil {
+			return zen.IdempotencyRecord{}, false, fmt.Errorf("failed to find idempotency key: %w", err)
+		}
+
+		record := zen.IdempotencyRecord{
+			Fingerprint: row.Fingerprint,
+			Completed:   row.ResponseStatus.Valid,
+			Status:      int(row.ResponseStatus.Int32),
+			ContentType: row.ResponseContentType.String,
+			Body:        []byte(row.ResponseBody.String),
+			Consumed:    false,
+		}
+		if s.vault == nil || !record.Completed || record.Fingerprint != fingerprint {
+			return record, false, nil
+		}
+
+		record, err = s.unseal(ctx, scope, key, record, row.ResponseBody)
+		if err != nil {
+			return zen.IdempotencyRecord{}, false, err
+		}
+		return record, false, nil
+	}
+
+	return zen.IdempotencyRecord{}, false, fmt.Errorf("failed to reserve idempotency key after %d attempts", maxReserveAttempts)
+}
+
+// unseal decrypts the sealed body of a completed record and clears it, so it
+// is replayed only once. The body is cleared only after it was decrypted, and
+// only the caller whose update cleared it replays it; everyone else gets a
+// consumed record.
+func (s *Store) unseal(ctx context.Context, scope zen.IdempotencyScope, key string, record zen.IdempotencyRecord, sealed sql.NullString) (zen.IdempotencyRecord, error) {
+	record.Body = nil
+	if !sealed.Valid {
+		record.Consumed = true
+		return record, nil
+	}
+
+	decrypted, err := s.vault.Decrypt(ctx, &vaultv1.DecryptRequest{
+		Keyring:   scope.WorkspaceID,
+		Encrypted: sealed.String,
+	})
+	if err != nil {
+		return zen.IdempotencyRecord{}, fmt.Errorf("failed to decrypt idempotent response: %w", err)
+	}
+
+	consumed, err := db.Query.ConsumeIdempotencyKey(ctx, s.db.RW(), db.ConsumeIdempotencyKeyParams{
+		WorkspaceID:    scope.WorkspaceID,
+		SubjectID:      scope.SubjectID,
+		IdempotencyKey: key,
+		Fingerprint:    record.Fingerprint,
+	})
+	if err != nil {
+		return zen.IdempotencyRecord{}, fmt.Errorf("failed to consume idempotent response: %w", err)
+	}
+	if consumed == 0 {
+		record.Consumed = true
+		return record, nil
+	}
+
+	record.Body = []byte(decrypted.GetPlaintext())
+	return record, nil
+}
+
+// Complete implements [zen.IdempotencyStore].
+func (s *Store) Complete(ctx context.Context, scope zen.IdempotencyScope, key, fingerprint string, status int, contentType string, body []byte) error {
+	stored := string(body)
+	if s.vault != nil {
+		encrypted, err := s.vault.Encrypt(ctx, &vaultv1.EncryptRequest{
+			Keyring: scope.WorkspaceID,
+			Data:    stored,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to encrypt idempotent response: %w", err)
+		}
+		stored = encrypted.GetEncrypted()
+	}
+
+	err := db.Query.CompleteIdempotencyKey(ctx, s.db.RW(), db.CompleteIdempotencyKeyParams{
+		ResponseStatus:      sql.NullInt32{Int32: int32(status), Valid: true}, //nolint:gosec // HTTP status codes fit in int32
+		ResponseContentType: sql.NullString{String: contentType, Valid: contentType != ""},
+		ResponseBody:        sql.NullString{String: stored, Valid: true},
+		WorkspaceID:         scope.WorkspaceID,
+		SubjectID:           scope.SubjectID,
+		IdempotencyKey:      key,
+		Fingerprint:         fingerprint,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Release implements [zen.IdempotencyStore].
+func (s *Store) Release(ctx context.Context, scope zen.IdempotencyScope, key, fingerprint string) error {
+	err := db.Query.ReleaseIdempotencyKey(ctx, s.db.RW(), db.ReleaseIdempotencyKeyParams{
+		WorkspaceID:    scope.WorkspaceID,
+		SubjectID:      scope.SubjectID,
+		IdempotencyKey: key,
+		Fingerprint:    fingerprint,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Package identitycredits converts the credit pool of an identity between its
+// identity_credits row and the API's representation.
+//
+// A pool is shared by every key of the identity: verification charges it in
+// addition to the key's own credits, and cron/keyrefill refills it on the
+// same daily or monthly schedule keys use.
+package identitycredits
+
+import (
+	"database/sql"
+
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+// Response builds the API form of a pool from its balance and refill
+// settings. A NULL refill_amount means the pool is never refilled; a NULL
+// refill_day with an amount means it is refilled daily.
+func Response(remaining int64, refillDay sql.NullInt16, refillAmount sql.NullInt64) *openapi.IdentityCredits {
+	credits := &openapi.IdentityCredits{
+		Remaining: remaining,
+		Refill:    nil,
+	}
+
+	if refillAmount.Valid {
+		var day int
+		interval := openapi.KeyCreditsRefillIntervalDaily
+		if refillDay.Valid {
+			interval = openapi.KeyCreditsRefillIntervalMonthly
+			day = int(refillDay.Int16)
+		}
+
+		credits.Refill = &openapi.KeyCreditsRefill{
+			Amount:    refillAmount.Int64,
+			Interval:  interval,
+			RefillDay: day,
+		}
+	}
+
+	return credits
+}
+
+// Params turns the credits of an identities.updateIdentity request into the
+// upsert of the identity's pool.
+func Params(workspaceID, identityID string, credits openapi.UpdateIdentityCreditsData, now int64) (db.UpsertIdentityCreditsParams, error) {
+	params := db.UpsertIdentityCreditsParams{
+		IdentityID:   identityID,
+		WorkspaceID:  workspaceID,
+		Remaining:    uint64(credits.Remaining), // nolint:gosec // the schema requires a non-negative value
+		RefillDay:    sql.NullInt16{Valid: false, Int16: 0},
+		RefillAmount: sql.NullInt64{Valid: false, Int64: 0},
+		Now:          now,
+	}
+
+	if credits.Refill == nil {
+		return params, nil
+	}
+
+	refill := credits.Refill
+	params.RefillAmount = sql.NullInt64{Valid: true, Int64: refill.Amount}
+
+	switch refill.Interval {
+	case openapi.KeyCreditsRefillIntervalMonthly:
+		if refill.RefillDay == 0 {
+			return db.UpsertIdentityCreditsParams{}, fault.New("missing refillDay",
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Internal("refillDay required for monthly interval"),
+				fault.Public("`credits.refill.refillDay` must be provided when the refill interval is `monthly`."),
+			)
+		}
+		params.RefillDay = sql.NullInt16{Valid: true, Int16: int16(refill.RefillDay)} // nolint:gosec // the schema bounds refillDay to 1-31
+	case openapi.KeyCreditsRefillIntervalDaily:
+		if refill.RefillDay != 0 {
+			return db.UpsertIdentityCreditsParams{}, fault.New("invalid refillDay",
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Internal("refillDay cannot be set for daily interval"),
+				fault.Public("`credits.refill.refillDay` must not be provided when the refill interval is `daily`."),
+			)
+		}
+	default:
+		return db.UpsertIdentityCreditsParams{}, fault.New("invalid refill interval",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal("unknown refill interval "+string(refill.Interval)),
+			fault.Public("`credits.refill.interval` must be `daily` or `monthly`."),
+		)
+	}
+
+	return params, nil
+}
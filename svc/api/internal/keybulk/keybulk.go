@@ -0,0 +1,271 @@
+// Package keybulk holds the submission path shared by keys.bulkUpdate,
+// keys.bulkDelete and keys.bulkSetPermissions.
+//
+// Each route validates and authorizes its request inline, then calls [Submit],
+// which records the key_bulk_operations row, writes one item per listed key
+// and hands the job to the ctrl worker's KeyBulkService. The worker applies
+// the change key by key; callers follow it through keys.getBulkOperation.
+package keybulk
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	restateingress "github.com/restatedev/sdk-go/ingress"
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/internal/services/caches"
+	"github.com/unkeyed/unkey/pkg/auditlog"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/ctrlclient"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+// ValidateTarget checks that a request selects its keys either by id or by
+// filter. The OpenAPI schema cannot express the exclusive choice.
+func ValidateTarget(keyIDs *[]string, filter *openapi.KeyBulkFilter) error {
+	hasKeyIDs := keyIDs != nil
+	hasFilter := filter != nil
+	if hasKeyIDs == hasFilter {
+		return fault.New("keyIds and filter are mutually exclusive",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal("request must set exactly one of keyIds and filter"),
+			fault.Public("Provide either `keyIds` or `filter`, not both and not neither."),
+		)
+	}
+	return nil
+}
+
+// RequireCriteria checks that a filter, if given, sets at least one
+// criterion. keys.bulkDelete calls it so that an empty filter, which selects
+// every key of the api, cannot delete them all.
+func RequireCriteria(filter *openapi.KeyBulkFilter) error {
+	if filter == nil || filter.ExternalId != nil || filter.LastUsedBefore != nil || (filter.Meta != nil && len(*filter.Meta) > 0) {
+		return nil
+	}
+	return fault.New("empty filter",
+		fault.Code(codes.App.Validation.InvalidInput.URN()),
+		fault.Internal("filter sets no criterion"),
+		fault.Public("`filter` must set at least one criterion."),
+	)
+}
+
+// FindAPI loads a live api with a keyspace in the caller's workspace. Apis of
+// other workspaces are masked as not found.
+//
+// It deliberately does no authorization: each handler authorizes inline so
+// the exact permission checked stays visible at the call site.
+func FindAPI(
+	ctx context.Context,
+	database db.Database,
+	apiCache cache.Cache[cache.ScopedKey, db.FindLiveApiByIDRow],
+	workspaceID, apiID string,
+) (db.FindLiveApiByIDRow, error) {
+	api, hit, err := apiCache.SWR(ctx, cache.ScopedKey{WorkspaceID: workspaceID, Key: apiID}, func(ctx context.Context) (db.FindLiveApiByIDRow, error) {
+		return db.Query.FindLiveApiByID(ctx, database.RO(), apiID)
+	}, caches.DefaultFindFirstOp)
+	if err != nil && !db.IsNotFound(err) {
+		return db.FindLiveApiByIDRow{}, fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to retrieve API information."),
+		)
+	}
+
+	// An api without a keyspace has no keys to change, so it is reported the
+	// same way as a missing one.
+	if db.IsNotFound(err) || hit == cache.Null || api.WorkspaceID != workspaceID || !api.KeyAuthID.Valid {
+		return db.FindLiveApiByIDRow{}, fault.New("api not found",
+			fault.Code(codes.Data.Api.NotFound.URN()),
+			fault.Internal("api not found, without keyspace or in another workspace"),
+			fault.Public("The requested API does not exist or has been deleted."),
+		)
+	}
+
+	return api, nil
+}
+
+// Job describes one bulk operation to submit.
+type Job struct {
+	DB      db.Database
+	Restate *restateingress.Client
+
+	WorkspaceID string
+	API         db.FindLiveApiByIDRow
+	Kind        db.KeyBulkOperationsOperation
+	KeyIDs      *[]string
+	Filter      *openapi.KeyBulkFilter
+
+	// Operation is the change the worker applies to every key.
+	Operation hydrav1.IsRunKeyBulkOperationRequest_Operation
+}
+
+// Submit records the operation and its listed keys, then sends the job to
+// KeyBulkService and returns the operation ID. If the job cannot be sent, the
+// operation is marked failed so keys.getBulkOperation does not report it as
+// pending forever.
+func Submit(ctx context.Context, s *zen.Session, job Job) (string, error) {
+	actor, err := ctrlclient.Actor(s)
+	if err != nil {
+		return "", err
+	}
+
+	filter, err := resolveFilter(ctx, job.DB, job.WorkspaceID, job.Filter)
+	if err != nil {
+		return "", err
+	}
+
+	keyIDs := dedupe(job.KeyIDs)
+	operationID := uid.New(uid.KeyBulkOperationPrefix)
+
+	err = db.TxRetry(ctx, job.DB.RW(), func(ctx context.Context, tx db.DBTX) error {
+		err := db.Query.InsertKeyBulkOperation(ctx, tx, db.InsertKeyBulkOperationParams{
+			ID:          operationID,
+			WorkspaceID: job.WorkspaceID,
+			ApiID:       job.API.ID,
+			Operation:   job.Kind,
+			Total:       uint32(len(keyIDs)), // nolint:gosec // request validation bounds keyIds
+			CreatedAtM:  time.Now().UnixMilli(),
+		})
+		if err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("unable to insert bulk operation"),
+				fault.Public("Failed to start the bulk operation."),
+			)
+		}
+
+		if len(keyIDs) == 0 {
+			return nil
+		}
+
+		items := make([]db.InsertKeyBulkOperationItemParams, len(keyIDs))
+		for i, keyID := range keyIDs {
+			items[i] = db.InsertKeyBulkOperationItemParams{
+				OperationID: operationID,
+				KeyID:       keyID,
+			}
+		}
+		if err := db.BulkQuery.InsertKeyBulkOperationItems(ctx, tx, items); err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("unable to insert bulk operation items"),
+				fault.Public("Failed to start the bulk operation."),
+			)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	_, err = hydrav1.NewKeyBulkServiceIngressClient(job.Restate, operationID).
+		Run().
+		Send(ctx, &hydrav1.RunKeyBulkOperationRequest{
+			Actor:         actor,
+			CorrelationId: auditlog.NewCorrelationID(),
+			WorkspaceId:   job.WorkspaceID,
+			ApiId:         job.API.ID,
+			KeyAuthId:     job.API.KeyAuthID.String,
+			Filter:        filter,
+			Operation:     job.Operation,
+		})
+	if err != nil {
+		now := time.Now().UnixMilli()
+		markErr := db.Query.MarkKeyBulkOperationFailed(ctx, job.DB.RW(), db.MarkKeyBulkOperationFailedParams{
+			Error: sql.NullString{String: "the operation could not be submitted", Valid: true},
+			Now:   sql.NullInt64{Int64: now, Valid: true},
+			ID:    operationID,
+		})
+		if markErr != nil {
+			err = fmt.Errorf("%w; mark failed: %w", err, markErr)
+		}
+		return "", fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("failed to submit key bulk operation to Restate"),
+			fault.Public("Failed to start the bulk operation."),
+		)
+	}
+
+	return operationID, nil
+}
+
+// resolveFilter converts the request filter into the worker's form. The
+// identity is looked up here so an unknown externalId fails the request
+// instead of silently matching no keys.
+func resolveFilter(ctx context.Context, database db.Database, workspaceID string, filter *openapi.KeyBulkFilter) (*hydrav1.KeyBulkFilter, error) {
+	if filter == nil {
+		return nil, nil
+	}
+
+	out := &hydrav1.KeyBulkFilter{
+		IdentityId:     "",
+		Meta:           nil,
+		LastUsedBefore: 0,
+	}
+
+	if filter.ExternalId != nil {
+		identity, err := db.Query.FindIdentityByExternalID(ctx, database.RO(), db.FindIdentityByExternalIDParams{
+			WorkspaceID: workspaceID,
+			ExternalID:  *filter.ExternalId,
+			Deleted:     false,
+		})
+		if err != nil {
+			if db.IsNotFound(err) {
+				return nil, fault.New("identity not found",
+					fault.Code(codes.Data.Identity.NotFound.URN()),
+					fault.Internal("identity of filter.externalId not found"),
+					fault.Public("The identity in `filter.externalId` does not exist."),
+				)
+			}
+			return nil, fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database error"),
+				fault.Public("Failed to retrieve identity."),
+			)
+		}
+		out.IdentityId = identity.ID
+	}
+
+	if filter.Meta != nil && len(*filter.Meta) > 0 {
+		meta, err := json.Marshal(*filter.Meta)
+		if err != nil {
+			return nil, fault.Wrap(err,
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Internal("unable to marshal filter.meta"),
+				fault.Public("`filter.meta` must be a JSON object."),
+			)
+		}
+		out.Meta = meta
+	}
+
+	if filter.LastUsedBefore != nil {
+		out.LastUsedBefore = *filter.LastUsedBefore
+	}
+
+	return out, nil
+}
+
+// dedupe drops repeated key ids while keeping the order the caller sent.
+func dedupe(keyIDs *[]string) []string {
+	if keyIDs == nil {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(*keyIDs))
+	out := make([]string, 0, len(*keyIDs))
+	for _, id := range *keyIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out
+}
@@ -0,0 +1,35 @@
+package keybulk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestValidateTarget(t *testing.T) {
+	keyIDs := []string{"key_1"}
+	filter := &openapi.KeyBulkFilter{}
+
+	require.NoError(t, ValidateTarget(&keyIDs, nil))
+	require.NoError(t, ValidateTarget(nil, filter))
+	require.Error(t, ValidateTarget(nil, nil))
+	require.Error(t, ValidateTarget(&keyIDs, filter))
+}
+
+func TestRequireCriteria(t *testing.T) {
+	externalID := "user_1"
+	empty := map[string]any{}
+	meta := map[string]any{"plan": "free"}
+
+	require.NoError(t, RequireCriteria(nil))
+	require.NoError(t, RequireCriteria(&openapi.KeyBulkFilter{ExternalId: &externalID}))
+	require.NoError(t, RequireCriteria(&openapi.KeyBulkFilter{Meta: &meta}))
+	require.Error(t, RequireCriteria(&openapi.KeyBulkFilter{}))
+	require.Error(t, RequireCriteria(&openapi.KeyBulkFilter{Meta: &empty}))
+}
+
+func TestDedupe(t *testing.T) {
+	require.Nil(t, dedupe(nil))
+	require.Equal(t, []string{"key_2", "key_1", "key_3"}, dedupe(&[]string{"key_2", "key_1", "key_2", "key_3", "key_1"}))
+}
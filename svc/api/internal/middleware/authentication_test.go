@@ -50,6 +50,18 @@ func (r *fakeRatelimit) RatelimitMany(_ context.Context, _ []ratelimit.Ratelimit
 	return nil, errors.New("not implemented")
 }
 
+func (r *fakeRatelimit) Peek(_ context.Context, _ ratelimit.RatelimitRequest) (ratelimit.RatelimitResponse, error) {
+	return ratelimit.RatelimitResponse{}, errors.New("not implemented")
+}
+
+func (r *fakeRatelimit) Acquire(_ context.Context, _ ratelimit.AcquireRequest) (ratelimit.AcquireResponse, error) {
+	return ratelimit.AcquireResponse{}, errors.New("not implemented")
+}
+
+func (r *fakeRatelimit) Release(_ context.Context, _ ratelimit.ReleaseRequest) (bool, error) {
+	return false, errors.New("not implemented")
+}
+
 // TestWithAuthentication_StopsOnAuthError verifies authentication is the first
 // protected-route gate. A failed credential check must return immediately,
 // before workspace policy can spend quota and before handler code can observe
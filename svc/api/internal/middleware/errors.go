@@ -93,9 +93,11 @@ func WithErrorHandling() zen.Middleware {
 				codes.UnkeyDataErrorsKeyAuthNotFound,
 				codes.UnkeyDataErrorsRatelimitNamespaceNotFound,
 				codes.UnkeyDataErrorsRatelimitOverrideNotFound,
+				codes.UnkeyDataErrorsRatelimitPlanNotFound,
 				codes.UnkeyDataErrorsIdentityNotFound,
 				codes.UnkeyDataErrorsAuditLogNotFound,
-				codes.UnkeyDataErrorsPortalNotFound:
+				codes.UnkeyDataErrorsPortalNotFound,
+				codes.UnkeyDataErrorsKeyBulkOperationNotFound:
 				return s.ProblemJSON(http.StatusNotFound, openapi.NotFoundErrorResponse{
 					Meta: openapi.Meta{
 						RequestId: s.RequestID(),
@@ -115,6 +117,7 @@ func WithErrorHandling() zen.Middleware {
 				codes.UnkeyAuthErrorsAuthenticationMalformed,
 				codes.UserErrorsBadRequestPermissionsQuerySyntaxError,
 				codes.UserErrorsBadRequestRequestBodyUnreadable,
+				codes.UserErrorsBadRequestInvalidIdempotencyKey,
 				codes.UnkeyPortalErrorsSessionTokenMissing:
 				return s.ProblemJSON(http.StatusBadRequest, openapi.BadRequestErrorResponse{
 					Meta: openapi.Meta{
@@ -150,6 +153,7 @@ func WithErrorHandling() zen.Middleware {
 
 			// Unprocessable Entity - Query resource limits
 			case codes.UserErrorsUnprocessableEntityQueryExecutionTimeout,
+				codes.UserErrorsUnprocessableEntityIdempotencyKeyMismatch,
 				codes.UserErrorsUnprocessableEntityQueryMemoryLimitExceeded,
 				codes.UserErrorsUnprocessableEntityQueryRowsLimitExceeded:
 				return s.ProblemJSON(http.StatusUnprocessableEntity, openapi.UnprocessableEntityErrorResponse{
@@ -303,7 +307,8 @@ func WithErrorHandling() zen.Middleware {
 				codes.UnkeyDataErrorsPermissionDuplicate,
 				codes.UnkeyDataErrorsProjectDuplicate,
 				codes.UnkeyDataErrorsAppDuplicate,
-				codes.UnkeyDataErrorsDomainDuplicate:
+				codes.UnkeyDataErrorsDomainDuplicate,
+				codes.UnkeyDataErrorsVolumeDuplicate:
 				return s.ProblemJSON(http.StatusConflict, openapi.ConflictErrorResponse{
 					Meta: openapi.Meta{
 						RequestId: s.RequestID(),
@@ -316,6 +321,22 @@ func WithErrorHandling() zen.Middleware {
 					},
 				})
 
+			// Conflict - a request with the same idempotency key is in flight,
+			// or its secret-bearing response was already replayed
+			case codes.UserErrorsConflictIdempotencyKeyInUse,
+				codes.UserErrorsConflictIdempotencyKeyConsumed:
+				return s.ProblemJSON(http.StatusConflict, openapi.ConflictErrorResponse{
+					Meta: openapi.Meta{
+						RequestId: s.RequestID(),
+					},
+					Error: openapi.BaseError{
+						Title:  http.StatusText(http.StatusConflict),
+						Type:   code.DocsURL(),
+						Detail: fault.UserFacingMessage(err),
+						Status: http.StatusConflict,
+					},
+				})
+
 			// Protected Resource
 			case codes.UnkeyAppErrorsProtectionProtectedResource:
 				return s.ProblemJSON(http.StatusPreconditionFailed, openapi.PreconditionFailedErrorResponse{
@@ -63,9 +63,25 @@ func PolicyFromProto(p *frontlinev1.Policy) (openapi.PolicyResponse, error) {
 		ratelimit := &openapi.RatelimitPolicy{
 			Limit:       config.Ratelimit.GetLimit(),
 			WindowMs:    config.Ratelimit.GetWindowMs(),
+			Algorithm:   nil,
+			RefillRate:  nil,
 			Identifier:  nil,
 			Identifiers: nil,
 		}
+		switch config.Ratelimit.GetAlgorithm() {
+		case frontlinev1.RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_UNSPECIFIED:
+		case frontlinev1.RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_SLIDING_WINDOW:
+			ratelimit.Algorithm = ptr.P(openapi.SlidingWindow)
+		case frontlinev1.RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_FIXED_WINDOW:
+			ratelimit.Algorithm = ptr.P(openapi.FixedWindow)
+		case frontlinev1.RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_TOKEN_BUCKET:
+			ratelimit.Algorithm = ptr.P(openapi.TokenBucket)
+		default:
+			return openapi.PolicyResponse{}, unmappable(p.GetId(), "ratelimit algorithm "+config.Ratelimit.GetAlgorithm().String())
+		}
+		if refill := config.Ratelimit.GetRefillRate(); refill > 0 {
+			ratelimit.RefillRate = ptr.P(refill)
+		}
 		// Stored policies carry either the deprecated single identifier or the
 		// repeated list. Responses always render the identifiers array -- a
 		// stored single identifier becomes a one-entry list -- so clients only
@@ -90,7 +106,25 @@ func PolicyFromProto(p *frontlinev1.Policy) (openapi.PolicyResponse, error) {
 
 	case *frontlinev1.Policy_Firewall:
 		out.Firewall = &openapi.FirewallPolicy{
-			Action: openapi.FirewallPolicyAction(config.Firewall.GetAction().String()),
+			Action:   openapi.FirewallPolicyAction(config.Firewall.GetAction().String()),
+			Redirect: nil,
+			Response: nil,
+		}
+		if r := config.Firewall.GetRedirect(); r != nil {
+			out.Firewall.Redirect = &openapi.FirewallRedirect{
+				Location:   r.GetLocation(),
+				StatusCode: ptr.P(r.GetStatusCode()),
+			}
+		}
+		if r := config.Firewall.GetResponse(); r != nil {
+			out.Firewall.Response = &openapi.FirewallResponse{
+				StatusCode: r.GetStatusCode(),
+				Headers:    nil,
+				Body:       ptr.P(r.GetBody()),
+			}
+			if len(r.GetHeaders()) > 0 {
+				out.Firewall.Response.Headers = ptr.P(r.GetHeaders())
+			}
 		}
 
 	case *frontlinev1.Policy_Openapi:
@@ -148,10 +182,15 @@ func mapKeyauthFromProto(policyID string, k *frontlinev1.KeyAuth) (*openapi.Keya
 		Keyspaces:       k.GetKeySpaceIds(),
 		PermissionQuery: k.PermissionQuery,
 		Credits:         k.Credits,
+		ResponseCredits: nil,
 		Locations:       nil,
 		Ratelimits:      nil,
 	}
 
+	if rc := k.GetResponseCredits(); rc != nil {
+		out.ResponseCredits = &openapi.KeyauthResponseCredits{Header: rc.GetHeader(), Reserve: rc.GetReserve()}
+	}
+
 	if len(k.GetLocations()) > 0 {
 		locations := make([]openapi.KeyLocation, 0, len(k.GetLocations()))
 		for _, loc := range k.GetLocations() {
@@ -200,6 +239,11 @@ func mapMatchExprFromProto(m *frontlinev1.MatchExpr) (openapi.MatchExpr, error)
 		Method:     nil,
 		Header:     nil,
 		QueryParam: nil,
+		RemoteIp:   nil,
+		Country:    nil,
+		Principal:  nil,
+		Not:        nil,
+		AnyOf:      nil,
 	}
 
 	switch expr := m.GetExpr().(type) {
@@ -257,6 +301,46 @@ func mapMatchExprFromProto(m *frontlinev1.MatchExpr) (openapi.MatchExpr, error)
 		}
 		out.QueryParam = &field
 
+	case *frontlinev1.MatchExpr_RemoteIp:
+		out.RemoteIp = &openapi.RemoteIpMatch{Cidrs: expr.RemoteIp.GetCidrs()}
+
+	case *frontlinev1.MatchExpr_Country:
+		out.Country = &openapi.CountryMatch{Countries: expr.Country.GetCountries()}
+
+	case *frontlinev1.MatchExpr_Principal:
+		field := openapi.PrincipalMatch{Field: expr.Principal.GetField(), Present: nil, Value: nil}
+		switch match := expr.Principal.GetMatch().(type) {
+		case *frontlinev1.PrincipalMatch_Present:
+			field.Present = ptr.P(match.Present)
+		case *frontlinev1.PrincipalMatch_Value:
+			sm, err := mapStringMatchFromProto(match.Value)
+			if err != nil {
+				return out, err
+			}
+			field.Value = &sm
+		default:
+			return out, unmappable("", "principal match")
+		}
+		out.Principal = &field
+
+	case *frontlinev1.MatchExpr_Not:
+		inner, err := mapMatchExprFromProto(expr.Not)
+		if err != nil {
+			return out, err
+		}
+		out.Not = &inner
+
+	case *frontlinev1.MatchExpr_AnyOf:
+		exprs := make([]openapi.MatchExpr, 0, len(expr.AnyOf.GetExprs()))
+		for _, e := range expr.AnyOf.GetExprs() {
+			inner, err := mapMatchExprFromProto(e)
+			if err != nil {
+				return out, err
+			}
+			exprs = append(exprs, inner)
+		}
+		out.AnyOf = &openapi.AnyOfMatch{Exprs: exprs}
+
 	default:
 		return out, unmappable("", "match expression")
 	}
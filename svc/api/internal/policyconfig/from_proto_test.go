@@ -69,6 +69,22 @@ func TestMapPolicyFromProtoVariants(t *testing.T) {
 		require.Nil(t, got.Keyauth.Ratelimits)
 		require.Nil(t, got.Keyauth.PermissionQuery)
 		require.Nil(t, got.Keyauth.Credits)
+		require.Nil(t, got.Keyauth.ResponseCredits)
+	})
+
+	t.Run("keyauth response credits", func(t *testing.T) {
+		got, err := PolicyFromProto(&frontlinev1.Policy{
+			Id:      "pol_1",
+			Name:    "metered",
+			Enabled: proto.Bool(true),
+			Config: &frontlinev1.Policy_Keyauth{Keyauth: &frontlinev1.KeyAuth{
+				KeySpaceIds:     []string{"ks_1"},
+				ResponseCredits: &frontlinev1.ResponseCredits{Header: "X-Unkey-Cost", Reserve: 500},
+			}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &openapi.KeyauthResponseCredits{Header: "X-Unkey-Cost", Reserve: 500}, got.Keyauth.ResponseCredits)
+		require.Nil(t, got.Keyauth.Credits)
 	})
 
 	t.Run("unset enabled maps to false", func(t *testing.T) {
@@ -150,6 +166,35 @@ func TestMapPolicyFromProtoVariants(t *testing.T) {
 		}
 	})
 
+	t.Run("ratelimit algorithm renders only when set", func(t *testing.T) {
+		policy := func(algorithm frontlinev1.RateLimitAlgorithm, refillRate int64) *frontlinev1.Policy {
+			return &frontlinev1.Policy{
+				Id:      "pol_1",
+				Name:    "rl",
+				Enabled: proto.Bool(true),
+				Config: &frontlinev1.Policy_Ratelimit{Ratelimit: &frontlinev1.RateLimit{
+					Limit:      100,
+					WindowMs:   60000,
+					Algorithm:  algorithm,
+					RefillRate: refillRate,
+					Identifiers: []*frontlinev1.RateLimitIdentifier{
+						{Source: &frontlinev1.RateLimitIdentifier_RemoteIp{RemoteIp: &frontlinev1.RemoteIpKey{}}},
+					},
+				}},
+			}
+		}
+
+		legacy, err := PolicyFromProto(policy(frontlinev1.RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_UNSPECIFIED, 0))
+		require.NoError(t, err)
+		require.Nil(t, legacy.Ratelimit.Algorithm)
+		require.Nil(t, legacy.Ratelimit.RefillRate)
+
+		bucket, err := PolicyFromProto(policy(frontlinev1.RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_TOKEN_BUCKET, 5))
+		require.NoError(t, err)
+		require.Equal(t, openapi.TokenBucket, ptr.SafeDeref(bucket.Ratelimit.Algorithm))
+		require.Equal(t, int64(5), ptr.SafeDeref(bucket.Ratelimit.RefillRate))
+	})
+
 	t.Run("compound ratelimit identifiers render as a list", func(t *testing.T) {
 		got, err := PolicyFromProto(&frontlinev1.Policy{
 			Id:      "pol_1",
@@ -198,6 +243,41 @@ func TestMapPolicyFromProtoVariants(t *testing.T) {
 		require.Equal(t, openapi.FirewallPolicyAction("ACTION_DENY"), got.Firewall.Action)
 	})
 
+	t.Run("firewall redirect and response round trip", func(t *testing.T) {
+		redirect := openapi.Policy{
+			Name: "moved", Enabled: true,
+			Firewall: &openapi.FirewallPolicy{
+				Action:   openapi.ACTIONREDIRECT,
+				Redirect: &openapi.FirewallRedirect{Location: "https://example.com/new"},
+			},
+		}
+		converted, err := PolicyToProto("policies[0]", redirect)
+		require.NoError(t, err)
+		got, err := PolicyFromProto(converted)
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/new", got.Firewall.Redirect.Location)
+		require.Equal(t, int32(302), ptr.SafeDeref(got.Firewall.Redirect.StatusCode))
+		require.Nil(t, got.Firewall.Response)
+
+		respond := openapi.Policy{
+			Name: "maintenance", Enabled: true,
+			Firewall: &openapi.FirewallPolicy{
+				Action: openapi.ACTIONRESPOND,
+				Response: &openapi.FirewallResponse{
+					StatusCode: 503,
+					Headers:    &map[string]string{"Retry-After": "60"},
+					Body:       ptr.P("down"),
+				},
+			},
+		}
+		converted, err = PolicyToProto("policies[0]", respond)
+		require.NoError(t, err)
+		got, err = PolicyFromProto(converted)
+		require.NoError(t, err)
+		require.Equal(t, respond.Firewall.Response, got.Firewall.Response)
+		require.Nil(t, got.Firewall.Redirect)
+	})
+
 	t.Run("logging maps to empty object", func(t *testing.T) {
 		got, err := PolicyFromProto(&frontlinev1.Policy{
 			Id:      "pol_1",
@@ -338,6 +418,54 @@ func TestMapMatchExprFromProto(t *testing.T) {
 		require.Equal(t, ptr.P("1"), got.QueryParam.Value.Exact)
 	})
 
+	t.Run("remoteIp and country", func(t *testing.T) {
+		got, err := mapMatchExprFromProto(&frontlinev1.MatchExpr{
+			Expr: &frontlinev1.MatchExpr_RemoteIp{RemoteIp: &frontlinev1.RemoteIpMatch{Cidrs: []string{"203.0.113.0/24"}}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"203.0.113.0/24"}, got.RemoteIp.Cidrs)
+
+		got, err = mapMatchExprFromProto(&frontlinev1.MatchExpr{
+			Expr: &frontlinev1.MatchExpr_Country{Country: &frontlinev1.CountryMatch{Countries: []string{"US"}}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"US"}, got.Country.Countries)
+	})
+
+	t.Run("principal absent-match", func(t *testing.T) {
+		got, err := mapMatchExprFromProto(&frontlinev1.MatchExpr{
+			Expr: &frontlinev1.MatchExpr_Principal{Principal: &frontlinev1.PrincipalMatch{
+				Field: "source.key.meta.plan",
+				Match: &frontlinev1.PrincipalMatch_Present{Present: false},
+			}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "source.key.meta.plan", got.Principal.Field)
+		require.Equal(t, ptr.P(false), got.Principal.Present)
+	})
+
+	t.Run("not and anyOf nest", func(t *testing.T) {
+		got, err := mapMatchExprFromProto(&frontlinev1.MatchExpr{
+			Expr: &frontlinev1.MatchExpr_Not{Not: &frontlinev1.MatchExpr{
+				Expr: &frontlinev1.MatchExpr_AnyOf{AnyOf: &frontlinev1.AnyOfMatch{Exprs: []*frontlinev1.MatchExpr{
+					{Expr: &frontlinev1.MatchExpr_Method{Method: &frontlinev1.MethodMatch{Methods: []string{"GET"}}}},
+					{Expr: &frontlinev1.MatchExpr_Country{Country: &frontlinev1.CountryMatch{Countries: []string{"DE"}}}},
+				}}},
+			}},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, got.Not)
+		require.Len(t, got.Not.AnyOf.Exprs, 2)
+		require.Equal(t, []string{"DE"}, got.Not.AnyOf.Exprs[1].Country.Countries)
+	})
+
+	t.Run("unmappable expression inside not", func(t *testing.T) {
+		_, err := mapMatchExprFromProto(&frontlinev1.MatchExpr{
+			Expr: &frontlinev1.MatchExpr_Not{Not: &frontlinev1.MatchExpr{}},
+		})
+		require.Error(t, err)
+	})
+
 	t.Run("empty expr is unmappable", func(t *testing.T) {
 		_, err := mapMatchExprFromProto(&frontlinev1.MatchExpr{})
 		require.Error(t, err)
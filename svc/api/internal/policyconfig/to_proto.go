@@ -2,7 +2,11 @@ package policyconfig
 
 import (
 	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
 	"regexp"
+	"strings"
 
 	frontlinev1 "github.com/unkeyed/unkey/gen/proto/frontline/v1"
 	"github.com/unkeyed/unkey/pkg/codes"
@@ -11,6 +15,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/rbac"
 	"github.com/unkeyed/unkey/pkg/uid"
 	"github.com/unkeyed/unkey/svc/api/openapi"
+	"golang.org/x/net/http/httpguts"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -66,7 +71,7 @@ func PolicyToProto(path string, p openapi.Policy) (*frontlinev1.Policy, error) {
 
 	// match is a sibling of the oneof, present on every variant.
 	for i, m := range ptr.SafeDeref(p.Match) {
-		expr, err := mapMatchExprToProto(fmt.Sprintf("%s.match[%d]", path, i), m)
+		expr, err := mapMatchExprToProto(fmt.Sprintf("%s.match[%d]", path, i), m, 0)
 		if err != nil {
 			return nil, err
 		}
@@ -89,11 +94,11 @@ func PolicyToProto(path string, p openapi.Policy) (*frontlinev1.Policy, error) {
 		out.Config = &frontlinev1.Policy_Ratelimit{Ratelimit: ratelimit}
 
 	case p.Firewall != nil:
-		action, ok := frontlinev1.Action_value[string(p.Firewall.Action)]
-		if !ok {
-			return nil, invalid(fmt.Sprintf("%s.firewall.action %q is not a known action.", path, p.Firewall.Action))
+		firewall, err := mapFirewallToProto(path+".firewall", *p.Firewall)
+		if err != nil {
+			return nil, err
 		}
-		out.Config = &frontlinev1.Policy_Firewall{Firewall: &frontlinev1.Firewall{Action: frontlinev1.Action(action)}}
+		out.Config = &frontlinev1.Policy_Firewall{Firewall: firewall}
 
 	case p.Openapi != nil:
 		out.Config = &frontlinev1.Policy_Openapi{Openapi: &frontlinev1.OpenApiRequestValidation{}}
@@ -111,6 +116,93 @@ func PolicyToProto(path string, p openapi.Policy) (*frontlinev1.Policy, error) {
 	return out, nil
 }
 
+// mapFirewallToProto validates that redirect is set exactly when the action
+// is ACTION_REDIRECT and response exactly when it is ACTION_RESPOND, so a
+// stored policy never carries configuration its action ignores.
+func mapFirewallToProto(path string, f openapi.FirewallPolicy) (*frontlinev1.Firewall, error) {
+	value, ok := frontlinev1.Action_value[string(f.Action)]
+	if !ok {
+		return nil, invalid(fmt.Sprintf("%s.action %q is not a known action.", path, f.Action))
+	}
+	action := frontlinev1.Action(value)
+	out := &frontlinev1.Firewall{Action: action}
+
+	isRedirect := action == frontlinev1.Action_ACTION_REDIRECT
+	if isRedirect != (f.Redirect != nil) {
+		return nil, invalid(fmt.Sprintf("%s.redirect must be set if and only if action is ACTION_REDIRECT.", path))
+	}
+	isRespond := action == frontlinev1.Action_ACTION_RESPOND
+	if isRespond != (f.Response != nil) {
+		return nil, invalid(fmt.Sprintf("%s.response must be set if and only if action is ACTION_RESPOND.", path))
+	}
+
+	if f.Redirect != nil {
+		if err := validateRedirectLocation(path+".redirect.location", f.Redirect.Location); err != nil {
+			return nil, err
+		}
+		status := ptr.SafeDeref(f.Redirect.StatusCode)
+		if status == 0 {
+			status = http.StatusFound
+		}
+		if !redirectStatusCodes[status] {
+			return nil, invalid(fmt.Sprintf("%s.redirect.statusCode must be one of 301, 302, 303, 307 or 308.", path))
+		}
+		out.Redirect = &frontlinev1.FirewallRedirect{Location: f.Redirect.Location, StatusCode: status}
+	}
+
+	if f.Response != nil {
+		if f.Response.StatusCode < 200 || f.Response.StatusCode > 599 {
+			return nil, invalid(fmt.Sprintf("%s.response.statusCode must be between 200 and 599.", path))
+		}
+		headers := ptr.SafeDeref(f.Response.Headers)
+		for name, value := range headers {
+			if !httpguts.ValidHeaderFieldName(name) {
+				return nil, invalid(fmt.Sprintf("%s.response.headers has an invalid header name %q.", path, name))
+			}
+			if !httpguts.ValidHeaderFieldValue(value) {
+				return nil, invalid(fmt.Sprintf("%s.response.headers[%q] has an invalid value.", path, name))
+			}
+		}
+		out.Response = &frontlinev1.FirewallResponse{
+			StatusCode: f.Response.StatusCode,
+			Headers:    headers,
+			Body:       ptr.SafeDeref(f.Response.Body),
+		}
+	}
+
+	return out, nil
+}
+
+// redirectStatusCodes are the status codes a firewall redirect may use.
+// Frontline enforces the same set when it serves the redirect.
+var redirectStatusCodes = map[int32]bool{
+	http.StatusMovedPermanently:  true,
+	http.StatusFound:             true,
+	http.StatusSeeOther:          true,
+	http.StatusTemporaryRedirect: true,
+	http.StatusPermanentRedirect: true,
+}
+
+// validateRedirectLocation accepts an absolute http(s) URL or a path on the
+// same host. Protocol-relative "//host" values are rejected because browsers
+// follow them to another host.
+func validateRedirectLocation(path, location string) error {
+	if strings.ContainsAny(location, "\r\n") {
+		return invalid(fmt.Sprintf("%s must not contain line breaks.", path))
+	}
+	if strings.HasPrefix(location, "/") {
+		if strings.HasPrefix(location, "//") || strings.HasPrefix(location, "/\\") {
+			return invalid(fmt.Sprintf("%s must be an absolute http(s) URL or a path starting with a single /.", path))
+		}
+		return nil
+	}
+	u, err := url.Parse(location)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return invalid(fmt.Sprintf("%s must be an absolute http(s) URL or a path starting with a single /.", path))
+	}
+	return nil
+}
+
 // mapRatelimitToProto validates the single/compound identifier duality:
 // exactly one of identifier or identifiers must be set on input. Both forms
 // normalize to the repeated identifiers proto field -- the legacy single
@@ -123,9 +215,16 @@ func mapRatelimitToProto(path string, r openapi.RatelimitPolicy) (*frontlinev1.R
 		return nil, err
 	}
 
+	algorithm, err := mapRatelimitAlgorithmToProto(path, r)
+	if err != nil {
+		return nil, err
+	}
+
 	out := &frontlinev1.RateLimit{
-		Limit:    r.Limit,
-		WindowMs: r.WindowMs,
+		Limit:      r.Limit,
+		WindowMs:   r.WindowMs,
+		Algorithm:  algorithm,
+		RefillRate: ptr.SafeDeref(r.RefillRate),
 	}
 
 	if r.Identifier != nil {
@@ -150,6 +249,37 @@ func mapRatelimitToProto(path string, r openapi.RatelimitPolicy) (*frontlinev1.R
 	return out, nil
 }
 
+// mapRatelimitAlgorithmToProto maps the algorithm name and checks that
+// refillRate is only set on a token bucket and never exceeds its capacity.
+// An omitted algorithm stays unspecified, which frontline runs as a sliding
+// window, so responses echo back exactly what was written.
+func mapRatelimitAlgorithmToProto(path string, r openapi.RatelimitPolicy) (frontlinev1.RateLimitAlgorithm, error) {
+	algorithm := frontlinev1.RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_UNSPECIFIED
+	if r.Algorithm != nil {
+		switch *r.Algorithm {
+		case openapi.SlidingWindow:
+			algorithm = frontlinev1.RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_SLIDING_WINDOW
+		case openapi.FixedWindow:
+			algorithm = frontlinev1.RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_FIXED_WINDOW
+		case openapi.TokenBucket:
+			algorithm = frontlinev1.RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_TOKEN_BUCKET
+		default:
+			return algorithm, invalid(fmt.Sprintf("%s.algorithm %q is not a known algorithm.", path, *r.Algorithm))
+		}
+	}
+
+	if r.RefillRate == nil {
+		return algorithm, nil
+	}
+	if algorithm != frontlinev1.RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_TOKEN_BUCKET {
+		return algorithm, invalid(fmt.Sprintf("%s.refillRate is only valid with algorithm token_bucket.", path))
+	}
+	if *r.RefillRate < 1 || *r.RefillRate > r.Limit {
+		return algorithm, invalid(fmt.Sprintf("%s.refillRate must be between 1 and limit.", path))
+	}
+	return algorithm, nil
+}
+
 // maxCompoundIdentifiers caps the dimensions of a compound rate limit key.
 // Mirrors the OpenAPI schema's maxItems; enforced here too because the
 // conversion pass is the validation layer for anything callers bypass.
@@ -231,14 +361,40 @@ func mapKeyauthToProto(path string, k openapi.KeyauthPolicy) (*frontlinev1.KeyAu
 		return nil, invalid(fmt.Sprintf("%s.credits must not be negative.", path))
 	}
 
+	// The gateway rejects both at once per request, since it cannot tell
+	// which cost was meant.
+	if rc := k.ResponseCredits; rc != nil {
+		if k.Credits != nil {
+			return nil, invalid(fmt.Sprintf("%s.credits and %s.responseCredits cannot be combined.", path, path))
+		}
+		if rc.Header == "" {
+			return nil, invalid(fmt.Sprintf("%s.responseCredits.header must not be empty.", path))
+		}
+		if rc.Reserve < 1 {
+			return nil, invalid(fmt.Sprintf("%s.responseCredits.reserve must be at least 1.", path))
+		}
+		out.ResponseCredits = &frontlinev1.ResponseCredits{Header: rc.Header, Reserve: rc.Reserve}
+	}
+
 	return out, nil
 }
 
-func mapMatchExprToProto(path string, m openapi.MatchExpr) (*frontlinev1.MatchExpr, error) {
-	if err := exactlyOne(path, "path, method, header or queryParam",
-		m.Path != nil, m.Method != nil, m.Header != nil, m.QueryParam != nil); err != nil {
+// maxMatchNesting is how many not/anyOf combinators may enclose a match
+// expression. Frontline evaluates deeper trees too; the limit keeps stored
+// policies readable.
+const maxMatchNesting = 4
+
+// mapMatchExprToProto converts one match expression. depth counts the
+// not/anyOf combinators enclosing m.
+func mapMatchExprToProto(path string, m openapi.MatchExpr, depth int) (*frontlinev1.MatchExpr, error) {
+	if err := exactlyOne(path, "path, method, header, queryParam, remoteIp, country, principal, not or anyOf",
+		m.Path != nil, m.Method != nil, m.Header != nil, m.QueryParam != nil,
+		m.RemoteIp != nil, m.Country != nil, m.Principal != nil, m.Not != nil, m.AnyOf != nil); err != nil {
 		return nil, err
 	}
+	if (m.Not != nil || m.AnyOf != nil) && depth >= maxMatchNesting {
+		return nil, invalid(fmt.Sprintf("%s nests not and anyOf more than %d levels deep.", path, maxMatchNesting))
+	}
 
 	switch {
 	case m.Path != nil:
@@ -272,6 +428,59 @@ func mapMatchExprToProto(path string, m openapi.MatchExpr) (*frontlinev1.MatchEx
 		}
 		return &frontlinev1.MatchExpr{Expr: &frontlinev1.MatchExpr_Header{Header: header}}, nil
 
+	case m.RemoteIp != nil:
+		for i, cidr := range m.RemoteIp.Cidrs {
+			if err := validateNetwork(fmt.Sprintf("%s.remoteIp.cidrs[%d]", path, i), cidr); err != nil {
+				return nil, err
+			}
+		}
+		return &frontlinev1.MatchExpr{Expr: &frontlinev1.MatchExpr_RemoteIp{RemoteIp: &frontlinev1.RemoteIpMatch{Cidrs: m.RemoteIp.Cidrs}}}, nil
+
+	case m.Country != nil:
+		countries := make([]string, 0, len(m.Country.Countries))
+		for i, country := range m.Country.Countries {
+			if !isCountryCode(country) {
+				return nil, invalid(fmt.Sprintf("%s.country.countries[%d] must be a two-letter ISO 3166-1 country code.", path, i))
+			}
+			countries = append(countries, strings.ToUpper(country))
+		}
+		return &frontlinev1.MatchExpr{Expr: &frontlinev1.MatchExpr_Country{Country: &frontlinev1.CountryMatch{Countries: countries}}}, nil
+
+	case m.Principal != nil:
+		principal := &frontlinev1.PrincipalMatch{Field: m.Principal.Field}
+		if err := exactlyOne(path+".principal", "present or value",
+			m.Principal.Present != nil, m.Principal.Value != nil); err != nil {
+			return nil, err
+		}
+		if m.Principal.Present != nil {
+			principal.Match = &frontlinev1.PrincipalMatch_Present{Present: *m.Principal.Present}
+		} else {
+			sm, err := mapStringMatchToProto(path+".principal.value", *m.Principal.Value)
+			if err != nil {
+				return nil, err
+			}
+			principal.Match = &frontlinev1.PrincipalMatch_Value{Value: sm}
+		}
+		return &frontlinev1.MatchExpr{Expr: &frontlinev1.MatchExpr_Principal{Principal: principal}}, nil
+
+	case m.Not != nil:
+		inner, err := mapMatchExprToProto(path+".not", *m.Not, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		return &frontlinev1.MatchExpr{Expr: &frontlinev1.MatchExpr_Not{Not: inner}}, nil
+
+	case m.AnyOf != nil:
+		exprs := make([]*frontlinev1.MatchExpr, 0, len(m.AnyOf.Exprs))
+		for i, e := range m.AnyOf.Exprs {
+			inner, err := mapMatchExprToProto(fmt.Sprintf("%s.anyOf.exprs[%d]", path, i), e, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			exprs = append(exprs, inner)
+		}
+		return &frontlinev1.MatchExpr{Expr: &frontlinev1.MatchExpr_AnyOf{AnyOf: &frontlinev1.AnyOfMatch{Exprs: exprs}}}, nil
+
 	default: // m.QueryParam != nil, guaranteed by exactlyOne above
 		queryParam := &frontlinev1.QueryParamMatch{Name: m.QueryParam.Name}
 		if err := exactlyOne(path+".queryParam", "present or value",
@@ -291,6 +500,43 @@ func mapMatchExprToProto(path string, m openapi.MatchExpr) (*frontlinev1.MatchEx
 	}
 }
 
+// validateNetwork accepts what frontline's RemoteIpMatch accepts: a CIDR
+// prefix or a bare address, IPv4 or IPv6, without a zone.
+func validateNetwork(path, network string) error {
+	var addr netip.Addr
+	if strings.Contains(network, "/") {
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return invalid(fmt.Sprintf("%s must be an IP address or a network in CIDR notation, e.g. 203.0.113.0/24.", path))
+		}
+		addr = prefix.Addr()
+	} else {
+		var err error
+		if addr, err = netip.ParseAddr(network); err != nil {
+			return invalid(fmt.Sprintf("%s must be an IP address or a network in CIDR notation, e.g. 203.0.113.0/24.", path))
+		}
+	}
+	if addr.Zone() != "" {
+		return invalid(fmt.Sprintf("%s must not carry an IPv6 zone.", path))
+	}
+	return nil
+}
+
+// isCountryCode reports whether s looks like an ISO 3166-1 alpha-2 code. It
+// does not check the code is assigned, so codes added later keep working.
+func isCountryCode(s string) bool {
+	if len(s) != 2 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i] | 0x20
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
 func mapStringMatchToProto(path string, s openapi.StringMatch) (*frontlinev1.StringMatch, error) {
 	if err := exactlyOne(path, "exact, prefix or regex",
 		s.Exact != nil, s.Prefix != nil, s.Regex != nil); err != nil {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	frontlinev1 "github.com/unkeyed/unkey/gen/proto/frontline/v1"
 	"github.com/unkeyed/unkey/pkg/fault"
 	"github.com/unkeyed/unkey/pkg/ptr"
 	"github.com/unkeyed/unkey/svc/api/openapi"
@@ -91,6 +92,68 @@ func TestMapPoliciesToProtoValidation(t *testing.T) {
 				Match: &[]openapi.MatchExpr{{QueryParam: &openapi.FieldMatch{Name: "token", Present: &present}}},
 			}},
 		},
+		{
+			name: "remote ip match with networks and a bare address",
+			policies: []openapi.Policy{{
+				Name: "m", Enabled: true, Firewall: firewall,
+				Match: &[]openapi.MatchExpr{{RemoteIp: &openapi.RemoteIpMatch{Cidrs: []string{"203.0.113.0/24", "2001:db8::/32", "198.51.100.7"}}}},
+			}},
+		},
+		{
+			name: "remote ip match with invalid network",
+			policies: []openapi.Policy{{
+				Name: "m", Enabled: true, Firewall: firewall,
+				Match: &[]openapi.MatchExpr{{RemoteIp: &openapi.RemoteIpMatch{Cidrs: []string{"203.0.113.0/24", "203.0.113.0/33"}}}},
+			}},
+			wantErr: "policies[0].match[0].remoteIp.cidrs[1] must be an IP address or a network in CIDR notation",
+		},
+		{
+			name: "country match with invalid code",
+			policies: []openapi.Policy{{
+				Name: "m", Enabled: true, Firewall: firewall,
+				Match: &[]openapi.MatchExpr{{Country: &openapi.CountryMatch{Countries: []string{"US", "USA"}}}},
+			}},
+			wantErr: "policies[0].match[0].country.countries[1] must be a two-letter ISO 3166-1 country code.",
+		},
+		{
+			name: "principal match with neither present nor value",
+			policies: []openapi.Policy{{
+				Name: "m", Enabled: true, Firewall: firewall,
+				Match: &[]openapi.MatchExpr{{Principal: &openapi.PrincipalMatch{Field: "subject"}}},
+			}},
+			wantErr: "policies[0].match[0].principal must set exactly one of present or value",
+		},
+		{
+			name: "combinators within the nesting limit",
+			policies: []openapi.Policy{{
+				Name: "m", Enabled: true, Firewall: firewall,
+				Match: &[]openapi.MatchExpr{{Not: &openapi.MatchExpr{Not: &openapi.MatchExpr{AnyOf: &openapi.AnyOfMatch{Exprs: []openapi.MatchExpr{
+					{Not: &openapi.MatchExpr{Principal: &openapi.PrincipalMatch{Field: "subject", Present: ptr.P(false)}}},
+					{Country: &openapi.CountryMatch{Countries: []string{"de"}}},
+				}}}}}},
+			}},
+		},
+		{
+			name: "combinators nested too deep",
+			policies: []openapi.Policy{{
+				Name: "m", Enabled: true, Firewall: firewall,
+				Match: &[]openapi.MatchExpr{{Not: &openapi.MatchExpr{Not: &openapi.MatchExpr{Not: &openapi.MatchExpr{Not: &openapi.MatchExpr{
+					Not: &openapi.MatchExpr{Method: &openapi.MethodMatch{Methods: []openapi.MethodMatchMethods{"GET"}}},
+				}}}}}},
+			}},
+			wantErr: "policies[0].match[0].not.not.not.not nests not and anyOf more than 4 levels deep.",
+		},
+		{
+			name: "invalid expression inside anyOf names its index",
+			policies: []openapi.Policy{{
+				Name: "m", Enabled: true, Firewall: firewall,
+				Match: &[]openapi.MatchExpr{{AnyOf: &openapi.AnyOfMatch{Exprs: []openapi.MatchExpr{
+					{Method: &openapi.MethodMatch{Methods: []openapi.MethodMatchMethods{"GET"}}},
+					{},
+				}}}},
+			}},
+			wantErr: "policies[0].match[0].anyOf.exprs[1] must set exactly one of",
+		},
 		{
 			name: "key location with no variant",
 			policies: []openapi.Policy{{
@@ -144,6 +207,39 @@ func TestMapPoliciesToProtoValidation(t *testing.T) {
 			}},
 			wantErr: "policies[0].keyauth.credits must not be negative",
 		},
+		{
+			name: "keyauth with response credits",
+			policies: []openapi.Policy{{
+				Name: "k", Enabled: true,
+				Keyauth: &openapi.KeyauthPolicy{
+					Keyspaces:       []string{"ks_1"},
+					ResponseCredits: &openapi.KeyauthResponseCredits{Header: "X-Unkey-Cost", Reserve: 100},
+				},
+			}},
+		},
+		{
+			name: "keyauth with credits and response credits",
+			policies: []openapi.Policy{{
+				Name: "k", Enabled: true,
+				Keyauth: &openapi.KeyauthPolicy{
+					Keyspaces:       []string{"ks_1"},
+					Credits:         ptr.P(int64(1)),
+					ResponseCredits: &openapi.KeyauthResponseCredits{Header: "X-Unkey-Cost", Reserve: 100},
+				},
+			}},
+			wantErr: "policies[0].keyauth.credits and policies[0].keyauth.responseCredits cannot be combined",
+		},
+		{
+			name: "keyauth response credits without reserve",
+			policies: []openapi.Policy{{
+				Name: "k", Enabled: true,
+				Keyauth: &openapi.KeyauthPolicy{
+					Keyspaces:       []string{"ks_1"},
+					ResponseCredits: &openapi.KeyauthResponseCredits{Header: "X-Unkey-Cost", Reserve: 0},
+				},
+			}},
+			wantErr: "policies[0].keyauth.responseCredits.reserve must be at least 1",
+		},
 		{
 			name: "keyauth ratelimit with limit but no duration",
 			policies: []openapi.Policy{{
@@ -205,6 +301,94 @@ func TestMapPoliciesToProtoValidation(t *testing.T) {
 			}},
 			wantErr: "policies[0].ratelimit.identifiers[1] must set exactly one of",
 		},
+		{
+			name: "ratelimit refill rate without token bucket",
+			policies: []openapi.Policy{{
+				Name: "r", Enabled: true,
+				Ratelimit: &openapi.RatelimitPolicy{
+					Limit: 10, WindowMs: 1000, RefillRate: ptr.P(int64(5)),
+					Identifiers: &[]openapi.RatelimitIdentifier{{RemoteIp: &openapi.RemoteIpKey{}}},
+				},
+			}},
+			wantErr: "policies[0].ratelimit.refillRate is only valid with algorithm token_bucket.",
+		},
+		{
+			name: "ratelimit refill rate above limit",
+			policies: []openapi.Policy{{
+				Name: "r", Enabled: true,
+				Ratelimit: &openapi.RatelimitPolicy{
+					Limit: 10, WindowMs: 1000, Algorithm: ptr.P(openapi.TokenBucket), RefillRate: ptr.P(int64(11)),
+					Identifiers: &[]openapi.RatelimitIdentifier{{RemoteIp: &openapi.RemoteIpKey{}}},
+				},
+			}},
+			wantErr: "policies[0].ratelimit.refillRate must be between 1 and limit.",
+		},
+		{
+			name: "ratelimit unknown algorithm",
+			policies: []openapi.Policy{{
+				Name: "r", Enabled: true,
+				Ratelimit: &openapi.RatelimitPolicy{
+					Limit: 10, WindowMs: 1000, Algorithm: ptr.P(openapi.RatelimitAlgorithm("leaky_bucket")),
+					Identifiers: &[]openapi.RatelimitIdentifier{{RemoteIp: &openapi.RemoteIpKey{}}},
+				},
+			}},
+			wantErr: `policies[0].ratelimit.algorithm "leaky_bucket" is not a known algorithm.`,
+		},
+		{
+			name: "firewall redirect without redirect config",
+			policies: []openapi.Policy{{
+				Name: "f", Enabled: true,
+				Firewall: &openapi.FirewallPolicy{Action: openapi.ACTIONREDIRECT},
+			}},
+			wantErr: "policies[0].firewall.redirect must be set if and only if action is ACTION_REDIRECT",
+		},
+		{
+			name: "firewall deny with response config",
+			policies: []openapi.Policy{{
+				Name: "f", Enabled: true,
+				Firewall: &openapi.FirewallPolicy{
+					Action:   openapi.ACTIONDENY,
+					Response: &openapi.FirewallResponse{StatusCode: 503},
+				},
+			}},
+			wantErr: "policies[0].firewall.response must be set if and only if action is ACTION_RESPOND",
+		},
+		{
+			name: "firewall redirect to protocol-relative location",
+			policies: []openapi.Policy{{
+				Name: "f", Enabled: true,
+				Firewall: &openapi.FirewallPolicy{
+					Action:   openapi.ACTIONREDIRECT,
+					Redirect: &openapi.FirewallRedirect{Location: "//evil.example"},
+				},
+			}},
+			wantErr: "policies[0].firewall.redirect.location must be an absolute http(s) URL",
+		},
+		{
+			name: "firewall redirect with non-redirect status",
+			policies: []openapi.Policy{{
+				Name: "f", Enabled: true,
+				Firewall: &openapi.FirewallPolicy{
+					Action:   openapi.ACTIONREDIRECT,
+					Redirect: &openapi.FirewallRedirect{Location: "/login", StatusCode: ptr.P(int32(304))},
+				},
+			}},
+			wantErr: "policies[0].firewall.redirect.statusCode must be one of",
+		},
+		{
+			name: "firewall respond with invalid header name",
+			policies: []openapi.Policy{{
+				Name: "f", Enabled: true,
+				Firewall: &openapi.FirewallPolicy{
+					Action: openapi.ACTIONRESPOND,
+					Response: &openapi.FirewallResponse{
+						StatusCode: 503,
+						Headers:    &map[string]string{"Bad Header": "x"},
+					},
+				},
+			}},
+			wantErr: "policies[0].firewall.response.headers has an invalid header name",
+		},
 		{
 			name: "error names the failing index",
 			policies: []openapi.Policy{
@@ -248,3 +432,28 @@ func TestLegacyIdentifierNormalizesToRepeated(t *testing.T) {
 	require.Len(t, ratelimit.GetIdentifiers(), 1)
 	require.NotNil(t, ratelimit.GetIdentifiers()[0].GetRemoteIp())
 }
+
+func TestRatelimitAlgorithmMapsToProto(t *testing.T) {
+	got, err := ToProto([]openapi.Policy{{
+		Name: "rl", Enabled: true,
+		Ratelimit: &openapi.RatelimitPolicy{
+			Limit: 10, WindowMs: 1000, Algorithm: ptr.P(openapi.TokenBucket), RefillRate: ptr.P(int64(2)),
+			Identifiers: &[]openapi.RatelimitIdentifier{{RemoteIp: &openapi.RemoteIpKey{}}},
+		},
+	}})
+	require.NoError(t, err)
+
+	ratelimit := got[0].GetRatelimit()
+	require.Equal(t, frontlinev1.RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_TOKEN_BUCKET, ratelimit.GetAlgorithm())
+	require.Equal(t, int64(2), ratelimit.GetRefillRate())
+}
+
+func TestCountryCodesNormalizeToUpperCase(t *testing.T) {
+	got, err := ToProto([]openapi.Policy{{
+		Name: "geo", Enabled: true,
+		Firewall: &openapi.FirewallPolicy{Action: "ACTION_DENY"},
+		Match:    &[]openapi.MatchExpr{{Country: &openapi.CountryMatch{Countries: []string{"us", "Ca"}}}},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"US", "CA"}, got[0].GetMatch()[0].GetCountry().GetCountries())
+}
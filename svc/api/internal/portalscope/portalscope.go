@@ -68,3 +68,35 @@ func KeyspaceIDs(s *zen.Session) ([]string, error) {
 
 	return src.KeyspaceIDs, nil
 }
+
+// PortalID returns the portal the session was issued for. Routes that enforce
+// portal configuration, such as the key policy, load it by this id on every
+// request.
+//
+// Like ExternalID, a non-portal principal or an empty portal id is a broken
+// invariant rather than a routine rejection.
+func PortalID(s *zen.Session) (string, error) {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return "", err
+	}
+
+	src, ok := principal.Source.(authprincipal.PortalSessionSource)
+	if !ok {
+		return "", fault.New("non-portal principal on portal route",
+			fault.Code(codes.Auth.Authorization.Forbidden.URN()),
+			fault.Internal("principal source is not a portal session"),
+			fault.Public("This endpoint is only accessible with a portal session."),
+		)
+	}
+
+	if src.PortalID == "" {
+		return "", fault.New("portal session missing portal",
+			fault.Code(codes.App.Internal.UnexpectedError.URN()),
+			fault.Internal("portal session portalId is empty"),
+			fault.Public("An internal error occurred."),
+		)
+	}
+
+	return src.PortalID, nil
+}
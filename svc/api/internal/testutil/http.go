@@ -22,6 +22,7 @@ import (
 	"github.com/unkeyed/unkey/internal/services/ratelimit"
 
 	"github.com/unkeyed/unkey/internal/services/usagelimiter"
+	"github.com/unkeyed/unkey/internal/services/webhooks"
 	"github.com/unkeyed/unkey/pkg/auth"
 	"github.com/unkeyed/unkey/pkg/auth/portal_session"
 	rootkey "github.com/unkeyed/unkey/pkg/auth/root_key"
@@ -80,6 +81,7 @@ type Harness struct {
 	FrontlineRequests          *batch.BatchProcessor[schema.FrontlineRequest]
 	Ratelimit                  ratelimit.Service
 	Vault                      vault.VaultServiceClient
+	Webhooks                   webhooks.Service
 	AnalyticsConnectionManager analytics.ConnectionManager
 	seeder                     *seed.Seeder
 }
@@ -135,8 +137,9 @@ func NewHarness(t *testing.T, configs ...HarnessConfig) *Harness {
 	require.NoError(t, err)
 
 	caches, err := caches.New(caches.Config{
-		NodeID: "",
-		Clock:  clk,
+		NodeID:      "",
+		Clock:       clk,
+		Broadcaster: nil,
 	})
 	require.NoError(t, err)
 
@@ -174,6 +177,7 @@ func NewHarness(t *testing.T, configs ...HarnessConfig) *Harness {
 			Consumers:     2,
 			Drop:          true,
 			OnFlushError:  nil,
+			Spool:         nil,
 		})
 		t.Cleanup(keyVerifications.Close)
 
@@ -185,6 +189,7 @@ func NewHarness(t *testing.T, configs ...HarnessConfig) *Harness {
 			Consumers:     2,
 			Drop:          true,
 			OnFlushError:  nil,
+			Spool:         nil,
 		})
 		t.Cleanup(ratelimitsfer.Close)
 
@@ -196,6 +201,7 @@ func NewHarness(t *testing.T, configs ...HarnessConfig) *Harness {
 			Consumers:     2,
 			Drop:          true,
 			OnFlushError:  nil,
+			Spool:         nil,
 		})
 		t.Cleanup(frontlineRequests.Close)
 	} else {
@@ -244,6 +250,36 @@ func NewHarness(t *testing.T, configs ...HarnessConfig) *Harness {
 				Credits: sql.NullInt64{Int64: cost, Valid: true},
 			})
 		},
+		IncrementKeyCredits: func(ctx context.Context, keyID string, credits int64) error {
+			return db.Query.UpdateKeyCreditsIncrement(ctx, database.RW(), db.UpdateKeyCreditsIncrementParams{
+				ID:      keyID,
+				Credits: sql.NullInt64{Int64: credits, Valid: true},
+			})
+		},
+		FindIdentityCredits: func(ctx context.Context, identityID string) (int64, bool, error) {
+			credits, err := db.WithRetryContext(ctx, func() (db.IdentityCredit, error) {
+				return db.Query.FindIdentityCredits(ctx, database.RO(), identityID)
+			})
+			if db.IsNotFound(err) {
+				return 0, false, nil
+			}
+			if err != nil {
+				return 0, false, err
+			}
+			return int64(credits.Remaining), true, nil // nolint:gosec // balances never approach MaxInt64
+		},
+		DecrementIdentityCredits: func(ctx context.Context, identityID string, cost int64) error {
+			return db.Query.UpdateIdentityCreditsDecrement(ctx, database.RW(), db.UpdateIdentityCreditsDecrementParams{
+				IdentityID: identityID,
+				Credits:    uint64(cost), // nolint:gosec // the usage limiter never passes a negative cost
+			})
+		},
+		IncrementIdentityCredits: func(ctx context.Context, identityID string, credits int64) error {
+			return db.Query.UpdateIdentityCreditsIncrement(ctx, database.RW(), db.UpdateIdentityCreditsIncrementParams{
+				IdentityID: identityID,
+				Credits:    uint64(credits), // nolint:gosec // refunds are never negative
+			})
+		},
 		Counter: ctr,
 		TTL:     60 * time.Second,
 	})
@@ -276,13 +312,15 @@ func NewHarness(t *testing.T, configs ...HarnessConfig) *Harness {
 	require.NoError(t, err)
 
 	keyService, err := keys.New(keys.Config{
-		DB:           db.ToMySQL(database),
-		KeyCache:     caches.VerificationKeyByHash,
-		RateLimiter:  ratelimitService,
-		RBAC:         rbac.New(),
-		Region:       "test",
-		UsageLimiter: ulSvc,
-		Source:       schema.SourceAPI,
+		DB:               db.ToMySQL(database),
+		KeyCache:         caches.VerificationKeyByHash,
+		JWTConfigCache:   caches.JwtConfigsByIssuer,
+		JWTIdentityCache: caches.JwtIdentityByExternalID,
+		RateLimiter:      ratelimitService,
+		RBAC:             rbac.New(),
+		Region:           "test",
+		UsageLimiter:     ulSvc,
+		Source:           schema.SourceAPI,
 	})
 	require.NoError(t, err)
 	portalService := portal.New(portal.Config{
@@ -305,6 +343,7 @@ func NewHarness(t *testing.T, configs ...HarnessConfig) *Harness {
 		UsageLimiter:               ulSvc,
 		Ratelimit:                  ratelimitService,
 		Vault:                      v,
+		Webhooks:                   webhooks.NewNoop(),
 		ClickHouse:                 ch,
 		KeyVerifications:           keyVerifications,
 		RatelimitEvents:            ratelimitsfer,
@@ -392,6 +431,14 @@ func (h *Harness) PortalMiddleware() []zen.Middleware {
 // plaintext access token, which is the only place it exists.
 func (h *Harness) CreatePortalSession(workspaceID, externalID string, keyspaceIDs, scopes []string) http.Header {
 	h.t.Helper()
+	return h.CreatePortalSessionForPortal(workspaceID, uid.New(uid.PortalPrefix), externalID, keyspaceIDs, scopes)
+}
+
+// CreatePortalSessionForPortal is [Harness.CreatePortalSession] for a portal row
+// the test inserted itself. Routes that read the portal's configuration, such
+// as its key policy, need the session to point at a real portal.
+func (h *Harness) CreatePortalSessionForPortal(workspaceID, portalID, externalID string, keyspaceIDs, scopes []string) http.Header {
+	h.t.Helper()
 
 	sessionID := uid.New(uid.PortalSessionPrefix)
 	// Credentials are minted the way the handlers mint them (crypto/rand), so
@@ -414,7 +461,7 @@ func (h *Harness) CreatePortalSession(workspaceID, externalID string, keyspaceID
 	err = db.Query.InsertPortalSession(ctx, h.DB.RW(), db.InsertPortalSessionParams{
 		ID:                    sessionID,
 		WorkspaceID:           workspaceID,
-		PortalID:              uid.New(uid.PortalPrefix),
+		PortalID:              portalID,
 		ExternalID:            externalID,
 		Scopes:                scopesJSON,
 		Preview:               false,
@@ -3,6 +3,7 @@ package seed
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
@@ -19,6 +20,7 @@ import (
 	dbtype "github.com/unkeyed/unkey/pkg/db/types"
 	"github.com/unkeyed/unkey/pkg/hash"
 	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/rbac"
 	"github.com/unkeyed/unkey/pkg/uid"
 	"github.com/unkeyed/unkey/svc/api/internal/projects"
 )
@@ -419,6 +421,7 @@ func (s *Seeder) CreateRootKey(ctx context.Context, workspaceID string, permissi
 		RefillDay:          sql.NullInt16{Int16: 0, Valid: false},
 		RefillAmount:       sql.NullInt64{Int64: 0, Valid: false},
 		PendingMigrationID: sql.NullString{Valid: false, String: ""},
+		Environment:        sql.NullString{Valid: false, String: ""},
 	}
 
 	err := db.Query.InsertKey(ctx, s.DB.RW(), insertKeyParams)
@@ -478,6 +481,7 @@ type CreateKeyRequest struct {
 	Name           *string
 	Deleted        bool
 	ForWorkspaceID *string
+	Environment    *string
 
 	Recoverable bool
 
@@ -525,6 +529,7 @@ func (s *Seeder) CreateKey(ctx context.Context, req CreateKeyRequest) CreateKeyR
 		RefillAmount:       sql.NullInt64{Int64: ptr.SafeDeref(req.RefillAmount, 0), Valid: req.RefillAmount != nil},
 		RefillDay:          sql.NullInt16{Int16: ptr.SafeDeref(req.RefillDay, 0), Valid: req.RefillDay != nil},
 		PendingMigrationID: sql.NullString{Valid: false, String: ""},
+		Environment:        sql.NullString{String: ptr.SafeDeref(req.Environment, ""), Valid: req.Environment != nil},
 	})
 	require.NoError(s.t, err)
 
@@ -771,6 +776,8 @@ type CreatePermissionRequest struct {
 	Slug        string
 	Description *string
 	WorkspaceID string
+	// Conditions makes the permission conditional. Nil means unconditional.
+	Conditions *rbac.Conditions
 }
 
 // CreateDeploymentRequest configures the deployment to create.
@@ -872,6 +879,19 @@ func (s *Seeder) CreatePermission(ctx context.Context, req CreatePermissionReque
 	})
 	require.NoError(s.t, err)
 
+	var conditions []byte
+	if req.Conditions != nil {
+		conditions, err = json.Marshal(req.Conditions)
+		require.NoError(s.t, err)
+
+		err = db.Query.UpdatePermissionConditions(ctx, s.DB.RW(), db.UpdatePermissionConditionsParams{
+			Conditions:   conditions,
+			UpdatedAtM:   sql.NullInt64{Valid: false, Int64: 0},
+			PermissionID: permissionID,
+		})
+		require.NoError(s.t, err)
+	}
+
 	return db.Permission{
 		Pk:          0, // db internal
 		ID:          permissionID,
@@ -880,6 +900,7 @@ func (s *Seeder) CreatePermission(ctx context.Context, req CreatePermissionReque
 		Name:        req.Name,
 		Slug:        req.Slug,
 		Description: dbtype.NullString{Valid: req.Description != nil, String: ptr.SafeDeref(req.Description, "")},
+		Conditions:  conditions,
 		CreatedAtM:  createdAt,
 		UpdatedAtM:  sql.NullInt64{Valid: false, Int64: 0},
 	}
@@ -0,0 +1,159 @@
+// Package webhook maps stored webhook endpoints and deliveries onto their
+// openapi wire types and validates endpoint input shared by the webhooks.*
+// routes.
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+// Endpoint builds the wire representation of an endpoint. The signing secret
+// is never part of it.
+func Endpoint(e db.WebhookEndpoint) (openapi.WebhookEndpoint, error) {
+	var eventTypes []openapi.WebhookEventType
+	if err := json.Unmarshal(e.EventTypes, &eventTypes); err != nil {
+		return openapi.WebhookEndpoint{}, fault.Wrap(err,
+			fault.Code(codes.App.Internal.UnexpectedError.URN()),
+			fault.Internal(fmt.Sprintf("unmarshal event types of webhook endpoint %s", e.ID)),
+			fault.Public("Failed to read webhook endpoint."),
+		)
+	}
+
+	out := openapi.WebhookEndpoint{
+		EndpointId:  e.ID,
+		Url:         e.Url,
+		Description: nil,
+		EventTypes:  eventTypes,
+		Enabled:     e.Enabled,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   nil,
+	}
+	if e.Description.Valid {
+		out.Description = ptr.P(e.Description.String)
+	}
+	if e.UpdatedAt.Valid {
+		out.UpdatedAt = ptr.P(e.UpdatedAt.Int64)
+	}
+	return out, nil
+}
+
+// Delivery builds the wire representation of one delivery log entry.
+func Delivery(d db.WebhookDelivery) openapi.WebhookDelivery {
+	out := openapi.WebhookDelivery{
+		DeliveryId:     d.ID,
+		EndpointId:     d.EndpointID,
+		EventId:        d.EventID,
+		EventType:      openapi.WebhookEventType(d.EventType),
+		Status:         deliveryStatus(d.Status),
+		Attempts:       int(d.Attempts),
+		ResponseStatus: nil,
+		LastError:      nil,
+		NextAttemptAt:  nil,
+		DeliveredAt:    nil,
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.ResponseStatus.Valid {
+		out.ResponseStatus = ptr.P(int(d.ResponseStatus.Int32))
+	}
+	if d.LastError.Valid {
+		out.LastError = ptr.P(d.LastError.String)
+	}
+	if d.NextAttemptAt.Valid {
+		out.NextAttemptAt = ptr.P(d.NextAttemptAt.Int64)
+	}
+	if d.DeliveredAt.Valid {
+		out.DeliveredAt = ptr.P(d.DeliveredAt.Int64)
+	}
+	return out
+}
+
+func deliveryStatus(s db.WebhookDeliveriesStatus) openapi.WebhookDeliveryStatus {
+	switch s {
+	case db.WebhookDeliveriesStatusPending:
+		return openapi.WebhookDeliveryStatusPending
+	case db.WebhookDeliveriesStatusDelivered:
+		return openapi.WebhookDeliveryStatusDelivered
+	case db.WebhookDeliveriesStatusFailed:
+		return openapi.WebhookDeliveryStatusFailed
+	default:
+		return openapi.WebhookDeliveryStatusPending
+	}
+}
+
+// ValidateURL rejects anything but an absolute https URL without credentials.
+// Whether the host resolves to a public address is only known at delivery
+// time, so the ctrl worker enforces that when it dials.
+func ValidateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil {
+		return fault.New("invalid webhook url",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal(fmt.Sprintf("invalid webhook url %q", raw)),
+			fault.Public("The url must be an absolute https URL without credentials."),
+		)
+	}
+	if strings.EqualFold(u.Hostname(), "localhost") {
+		return fault.New("invalid webhook url",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal("webhook url points at localhost"),
+			fault.Public("The url must point at a publicly reachable host."),
+		)
+	}
+	return nil
+}
+
+// MarshalEventTypes stores the subscribed event types sorted and deduplicated,
+// so equal subscriptions compare equal in the database.
+func MarshalEventTypes(eventTypes []openapi.WebhookEventType) (json.RawMessage, error) {
+	sorted := make([]string, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		sorted = append(sorted, string(t))
+	}
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	b, err := json.Marshal(sorted)
+	if err != nil {
+		return nil, fault.Wrap(err,
+			fault.Code(codes.App.Internal.UnexpectedError.URN()),
+			fault.Internal("marshal webhook event types"),
+			fault.Public("Failed to store webhook endpoint."),
+		)
+	}
+	return b, nil
+}
+
+// FindEndpoint loads an endpoint of the workspace, reporting a missing one as
+// webhook_endpoint_not_found.
+func FindEndpoint(ctx context.Context, tx db.DBTX, workspaceID, endpointID string) (db.WebhookEndpoint, error) {
+	endpoint, err := db.Query.FindWebhookEndpointByID(ctx, tx, db.FindWebhookEndpointByIDParams{
+		WorkspaceID: workspaceID,
+		ID:          endpointID,
+	})
+	if err != nil {
+		if db.IsNotFound(err) {
+			return db.WebhookEndpoint{}, fault.Wrap(err,
+				fault.Code(codes.Data.WebhookEndpoint.NotFound.URN()),
+				fault.Internal("webhook endpoint not found"),
+				fault.Public("The requested webhook endpoint does not exist."),
+			)
+		}
+		return db.WebhookEndpoint{}, fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to retrieve webhook endpoint."),
+		)
+	}
+	return endpoint, nil
+}
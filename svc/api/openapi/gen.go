@@ -106,7 +106,10 @@ const (
 
 // Defines values for FirewallPolicyAction.
 const (
-	ACTIONDENY FirewallPolicyAction = "ACTION_DENY"
+	ACTIONDENY     FirewallPolicyAction = "ACTION_DENY"
+	ACTIONLOG      FirewallPolicyAction = "ACTION_LOG"
+	ACTIONREDIRECT FirewallPolicyAction = "ACTION_REDIRECT"
+	ACTIONRESPOND  FirewallPolicyAction = "ACTION_RESPOND"
 )
 
 // Defines values for KeyCreditsRefillInterval.
@@ -126,6 +129,13 @@ const (
 	MethodMatchMethodsPUT     MethodMatchMethods = "PUT"
 )
 
+// Defines values for RatelimitAlgorithm.
+const (
+	FixedWindow   RatelimitAlgorithm = "fixed_window"
+	SlidingWindow RatelimitAlgorithm = "sliding_window"
+	TokenBucket   RatelimitAlgorithm = "token_bucket"
+)
+
 // Defines values for UpdateKeyCreditsRefillInterval.
 const (
 	UpdateKeyCreditsRefillIntervalDaily   UpdateKeyCreditsRefillInterval = "daily"
@@ -150,6 +160,28 @@ const (
 	UNSPECIFIED      V2DeployGetDeploymentResponseDataStatus = "UNSPECIFIED"
 )
 
+// Defines values for V2KeysGetBulkOperationResponseDataItemsStatus.
+const (
+	V2KeysGetBulkOperationResponseDataItemsStatusFailed    V2KeysGetBulkOperationResponseDataItemsStatus = "failed"
+	V2KeysGetBulkOperationResponseDataItemsStatusPending   V2KeysGetBulkOperationResponseDataItemsStatus = "pending"
+	V2KeysGetBulkOperationResponseDataItemsStatusSucceeded V2KeysGetBulkOperationResponseDataItemsStatus = "succeeded"
+)
+
+// Defines values for V2KeysGetBulkOperationResponseDataOperation.
+const (
+	KeyBulkOperationDelete         V2KeysGetBulkOperationResponseDataOperation = "delete"
+	KeyBulkOperationSetPermissions V2KeysGetBulkOperationResponseDataOperation = "set_permissions"
+	KeyBulkOperationUpdate         V2KeysGetBulkOperationResponseDataOperation = "update"
+)
+
+// Defines values for V2KeysGetBulkOperationResponseDataStatus.
+const (
+	V2KeysGetBulkOperationResponseDataStatusCompleted V2KeysGetBulkOperationResponseDataStatus = "completed"
+	V2KeysGetBulkOperationResponseDataStatusFailed    V2KeysGetBulkOperationResponseDataStatus = "failed"
+	V2KeysGetBulkOperationResponseDataStatusPending   V2KeysGetBulkOperationResponseDataStatus = "pending"
+	V2KeysGetBulkOperationResponseDataStatusRunning   V2KeysGetBulkOperationResponseDataStatus = "running"
+)
+
 // Defines values for V2KeysUpdateCreditsRequestBodyOperation.
 const (
 	Decrement V2KeysUpdateCreditsRequestBodyOperation = "decrement"
@@ -173,10 +205,61 @@ const (
 const (
 	AnalyticsRead V2PortalCreateSessionRequestBodyScopes = "analytics:read"
 	KeysCreate    V2PortalCreateSessionRequestBodyScopes = "keys:create"
+	KeysDelete    V2PortalCreateSessionRequestBodyScopes = "keys:delete"
 	KeysRead      V2PortalCreateSessionRequestBodyScopes = "keys:read"
 	KeysReroll    V2PortalCreateSessionRequestBodyScopes = "keys:reroll"
 )
 
+// Defines values for WebhookDeliveryStatus.
+const (
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+)
+
+// Defines values for WebhookEventType.
+const (
+	WebhookEventTypeKeyCreated               WebhookEventType = "key.created"
+	WebhookEventTypeKeyCreditsExhausted      WebhookEventType = "key.credits_exhausted"
+	WebhookEventTypeKeyDeleted               WebhookEventType = "key.deleted"
+	WebhookEventTypeKeyExpired               WebhookEventType = "key.expired"
+	WebhookEventTypeKeyRerolled              WebhookEventType = "key.rerolled"
+	WebhookEventTypeKeyRotated               WebhookEventType = "key.rotated"
+	WebhookEventTypeRatelimitOverrideDeleted WebhookEventType = "ratelimit.override.deleted"
+	WebhookEventTypeRatelimitOverrideSet     WebhookEventType = "ratelimit.override.set"
+)
+
+// AnyOfMatch Matches when at least one of the expressions matches.
+type AnyOfMatch struct {
+	Exprs []MatchExpr `json:"exprs"`
+}
+
+// ApiJwtAuthConfig defines model for ApiJwtAuthConfig.
+type ApiJwtAuthConfig struct {
+	// Audience If set, tokens must list this value in their `aud` claim.
+	Audience *string `json:"audience,omitempty"`
+
+	// IdentityClaim The claim holding the external ID of the identity a token belongs to.
+	// The identity must exist in your workspace; its rate limits apply to the token.
+	IdentityClaim *string `json:"identityClaim,omitempty"`
+
+	// Issuer The exact `iss` claim tokens must carry. Tokens are routed to this API by their issuer.
+	Issuer string `json:"issuer"`
+
+	// JwksUrl HTTPS URL of the JSON Web Key Set holding the issuer's signing keys.
+	// RS256 and P-256 ES256 keys are supported. The set is refreshed every few minutes, so keys your provider rotates in are picked up automatically.
+	// Exactly one of `jwksUrl` and `publicKey` must be set.
+	JwksUrl *string `json:"jwksUrl,omitempty"`
+
+	// PermissionsClaim The claim listing the token's permissions, either as an array of strings or a space separated string like the OAuth `scope` claim.
+	// If unset, tokens carry no permissions.
+	PermissionsClaim *string `json:"permissionsClaim,omitempty"`
+
+	// PublicKey A PEM-encoded RSA or P-256 EC public key that signs every token.
+	// Exactly one of `jwksUrl` and `publicKey` must be set.
+	PublicKey *string `json:"publicKey,omitempty"`
+}
+
 // App defines model for App.
 type App struct {
 	// CreatedAt Unix timestamp in milliseconds when the app was created.
@@ -248,6 +331,68 @@ type AppGitUpdateInput struct {
 	Repository *string `json:"repository,omitempty"`
 }
 
+// AuditLog defines model for AuditLog.
+type AuditLog struct {
+	Actor AuditLogActor `json:"actor"`
+
+	// CorrelationId Groups events that came out of one logical action, such as all events of a bulk operation. Omitted for events that stand alone.
+	CorrelationId *string `json:"correlationId,omitempty"`
+
+	// Description A human-readable summary of what happened.
+	Description string `json:"description"`
+
+	// Event The type of the event, such as `key.create` or `api.delete`.
+	Event string `json:"event"`
+
+	// EventId The unique identifier of the audit log event.
+	EventId string `json:"eventId"`
+
+	// Meta Additional event details.
+	Meta map[string]interface{} `json:"meta"`
+
+	// RemoteIp The IP address the request that caused the event came from. Empty for events emitted by background jobs.
+	RemoteIp string `json:"remoteIp"`
+
+	// Targets The resources the event affected.
+	Targets []AuditLogTarget `json:"targets"`
+
+	// Time When the event happened, in unix milliseconds.
+	Time int64 `json:"time"`
+
+	// UserAgent The user agent of the request that caused the event. Empty for events emitted by background jobs.
+	UserAgent string `json:"userAgent"`
+}
+
+// AuditLogActor defines model for AuditLogActor.
+type AuditLogActor struct {
+	// Id The id of the actor, for example the root key id.
+	Id string `json:"id"`
+
+	// Meta Additional details about the actor.
+	Meta *map[string]interface{} `json:"meta,omitempty"`
+
+	// Name A display name for the actor, if one is known.
+	Name *string `json:"name,omitempty"`
+
+	// Type What kind of actor caused the event, such as `rootkey`, `user`, `system` or `portalEndUser`.
+	Type string `json:"type"`
+}
+
+// AuditLogTarget defines model for AuditLogTarget.
+type AuditLogTarget struct {
+	// Id The id of the affected resource.
+	Id string `json:"id"`
+
+	// Meta Additional details about the resource.
+	Meta *map[string]interface{} `json:"meta,omitempty"`
+
+	// Name A display name for the resource, if one is known.
+	Name *string `json:"name,omitempty"`
+
+	// Type The type of the affected resource, such as `key` or `api`.
+	Type string `json:"type"`
+}
+
 // AuthenticatedSubjectKey Rate limit by the authenticated subject (e.g. the verified key).
 type AuthenticatedSubjectKey = map[string]interface{}
 
@@ -310,6 +455,13 @@ type ConflictErrorResponse struct {
 	Meta Meta `json:"meta"`
 }
 
+// CountryMatch Matches when the client IP is located in any of the listed countries.
+// Requests whose country cannot be resolved do not match.
+type CountryMatch struct {
+	// Countries ISO 3166-1 alpha-2 country codes, compared case-insensitively.
+	Countries []string `json:"countries"`
+}
+
 // Deployment defines model for Deployment.
 type Deployment struct {
 	// App Slug of the app this deployment belongs to.
@@ -769,15 +921,55 @@ type FieldMatch struct {
 // FieldMatchPresent Matches when the field is present, regardless of value.
 type FieldMatchPresent bool
 
-// FirewallPolicy Blocks matching requests.
+// FirewallPolicy Applies an action to matching requests. Set `redirect` when the action
+// is `ACTION_REDIRECT` and `response` when it is `ACTION_RESPOND`.
 type FirewallPolicy struct {
-	// Action What to do with matching requests.
+	// Action What to do with matching requests. `ACTION_DENY` rejects them with a
+	// 403. `ACTION_LOG` only records the match in the request log, so a
+	// rule can be observed before it is enforced. `ACTION_REDIRECT` and
+	// `ACTION_RESPOND` answer the request at the gateway using `redirect`
+	// or `response`.
 	Action FirewallPolicyAction `json:"action"`
+
+	// Redirect Redirect target for a firewall policy with `ACTION_REDIRECT`.
+	Redirect *FirewallRedirect `json:"redirect,omitempty"`
+
+	// Response Response served by the gateway for a firewall policy with
+	// `ACTION_RESPOND`. The request never reaches your app.
+	Response *FirewallResponse `json:"response,omitempty"`
 }
 
-// FirewallPolicyAction What to do with matching requests.
+// FirewallPolicyAction What to do with matching requests. `ACTION_DENY` rejects them with a
+// 403. `ACTION_LOG` only records the match in the request log, so a
+// rule can be observed before it is enforced. `ACTION_REDIRECT` and
+// `ACTION_RESPOND` answer the request at the gateway using `redirect`
+// or `response`.
 type FirewallPolicyAction string
 
+// FirewallRedirect Redirect target for a firewall policy with `ACTION_REDIRECT`.
+type FirewallRedirect struct {
+	// Location Where to send the client: an absolute `http` or `https` URL, or a
+	// path on the same host starting with `/`.
+	Location string `json:"location"`
+
+	// StatusCode The redirect status code. One of 301, 302, 303, 307 or 308.
+	StatusCode *int32 `json:"statusCode,omitempty"`
+}
+
+// FirewallResponse Response served by the gateway for a firewall policy with
+// `ACTION_RESPOND`. The request never reaches your app.
+type FirewallResponse struct {
+	// Body The response body, sent as is.
+	Body *string `json:"body,omitempty"`
+
+	// Headers Response headers. `Content-Type` defaults to
+	// `text/plain; charset=utf-8` when not set.
+	Headers *map[string]string `json:"headers,omitempty"`
+
+	// StatusCode The status code to respond with.
+	StatusCode int32 `json:"statusCode"`
+}
+
 // ForbiddenErrorResponse Error response when the provided credentials are valid but lack sufficient permissions for the requested operation. This occurs when:
 // - The root key doesn't have the required permissions for this endpoint
 // - The operation requires elevated privileges that the current key lacks
@@ -821,6 +1013,10 @@ type HeaderKeyLocation struct {
 
 // Identity defines model for Identity.
 type Identity struct {
+	// Credits Credit pool shared by every key attached to this identity.
+	// Keys draw from the pool in addition to their own credits, so a request is only allowed while both have enough left.
+	Credits *IdentityCredits `json:"credits,omitempty"`
+
 	// ExternalId External identity ID
 	ExternalId string `json:"externalId"`
 
@@ -834,6 +1030,16 @@ type Identity struct {
 	Ratelimits []RatelimitResponse `json:"ratelimits,omitempty"`
 }
 
+// IdentityCredits Credit pool shared by every key attached to this identity.
+// Keys draw from the pool in addition to their own credits, so a request is only allowed while both have enough left.
+type IdentityCredits struct {
+	// Refill Configuration for automatic credit refill behavior.
+	Refill *KeyCreditsRefill `json:"refill,omitempty"`
+
+	// Remaining Number of credits left in the pool.
+	Remaining int64 `json:"remaining"`
+}
+
 // InternalServerErrorResponse Error response when an unexpected error occurs on the server. This indicates a problem with Unkey's systems rather than your request.
 //
 // When you encounter this error:
@@ -848,6 +1054,26 @@ type InternalServerErrorResponse struct {
 	Meta Meta `json:"meta"`
 }
 
+// KeyBulkFilter Selects the keys of the API a bulk operation applies to. Every criterion you set must match; an empty filter selects every key of the API, except in `keys.bulkDelete`, which rejects it.
+// The filter is evaluated when the operation starts, so keys created afterwards are not affected.
+type KeyBulkFilter struct {
+	// ExternalId Selects keys owned by the identity with this external ID. The identity must exist.
+	ExternalId *string `json:"externalId,omitempty"`
+
+	// LastUsedBefore Selects keys last verified before this Unix timestamp in milliseconds, including keys that were never verified.
+	LastUsedBefore *int64 `json:"lastUsedBefore,omitempty"`
+
+	// Meta Selects keys whose metadata contains every top-level entry of this object with an equal value.
+	// Nested objects are compared as a whole.
+	Meta *map[string]interface{} `json:"meta,omitempty"`
+}
+
+// KeyBulkOperationAccepted defines model for KeyBulkOperationAccepted.
+type KeyBulkOperationAccepted struct {
+	// OperationId Identifies the bulk operation. Pass it to `keys.getBulkOperation` to follow its progress and read the outcome of every key.
+	OperationId string `json:"operationId"`
+}
+
 // KeyCreditsData Credit configuration and remaining balance for this key.
 type KeyCreditsData struct {
 	// Refill Configuration for automatic credit refill behavior.
@@ -874,6 +1100,20 @@ type KeyCreditsRefill struct {
 // KeyCreditsRefillInterval How often credits are automatically refilled.
 type KeyCreditsRefillInterval string
 
+// KeyEnvironment defines model for KeyEnvironment.
+type KeyEnvironment struct {
+	// Name Identifies the environment, e.g. `test` or `live`. Keys are created in it by passing this name as `environment` to `keys.createKey`, and `keys.verifyKey` can require it.
+	Name string `json:"name"`
+
+	// Prefix Prefix of keys created in this environment, unless the request sets its own. Falls back to the API's default prefix when omitted.
+	// Distinct prefixes let you and your customers tell test keys from live ones at a glance.
+	Prefix *string `json:"prefix,omitempty"`
+
+	// TestMode Verifications of keys in a test-mode environment never spend credits: the balance is checked like for any other key but is not decremented.
+	// Rate limits are enforced as usual so integrations can be exercised end to end.
+	TestMode *bool `json:"testMode,omitempty"`
+}
+
 // KeyLocation Where to look for the API key on incoming requests. Exactly one of
 // `bearer`, `header` or `queryParam` must be set.
 type KeyLocation struct {
@@ -939,6 +1179,12 @@ type KeyResponseData struct {
 	Ratelimits []RatelimitResponse `json:"ratelimits,omitempty"`
 	Roles      []string            `json:"roles,omitempty"`
 
+	// Rotation Schedule for issuing a successor to this key.
+	// Every `interval` milliseconds after the key was created, Unkey issues a new key with the same configuration and keeps the old one valid for `gracePeriod` more milliseconds.
+	// The `key.rotated` webhook announces each successor; read its plaintext with `keys.getKey` and `decrypt=true`.
+	// Only recoverable keys can be rotated, because the successor's plaintext is only available from the vault.
+	Rotation *KeyRotation `json:"rotation,omitempty"`
+
 	// Start First few characters of the key for identification.
 	Start string `json:"start"`
 
@@ -946,6 +1192,19 @@ type KeyResponseData struct {
 	UpdatedAt int64 `json:"updatedAt,omitempty"`
 }
 
+// KeyRotation Schedule for issuing a successor to this key.
+// Every `interval` milliseconds after the key was created, Unkey issues a new key with the same configuration and keeps the old one valid for `gracePeriod` more milliseconds.
+// The `key.rotated` webhook announces each successor; read its plaintext with `keys.getKey` and `decrypt=true`.
+// Only recoverable keys can be rotated, because the successor's plaintext is only available from the vault.
+type KeyRotation struct {
+	// GracePeriod Milliseconds the old key keeps verifying after its successor is issued. Must not exceed `interval`.
+	// The old key never outlives an expiry it already had.
+	GracePeriod int64 `json:"gracePeriod"`
+
+	// Interval Milliseconds between the key's creation and the issue of its successor. Must be at least one day.
+	Interval int64 `json:"interval"`
+}
+
 // KeyauthPolicy Verifies Unkey API keys on matching requests.
 type KeyauthPolicy struct {
 	// Credits Usage credits a matching request deducts from the verified key. Defaults
@@ -968,6 +1227,30 @@ type KeyauthPolicy struct {
 
 	// Ratelimits Rate limits applied during key verification.
 	Ratelimits *[]KeyRatelimit `json:"ratelimits,omitempty"`
+
+	// ResponseCredits Charges the key what the upstream reports the request cost instead of a
+	// fixed amount. The reserve is deducted before proxying, so concurrent
+	// requests cannot overdraw the key, and the unused part is refunded once the
+	// response completes.
+	ResponseCredits *KeyauthResponseCredits `json:"responseCredits,omitempty"`
+}
+
+// KeyauthResponseCredits Charges the key what the upstream reports the request cost instead of a
+// fixed amount. The reserve is deducted before proxying, so concurrent
+// requests cannot overdraw the key, and the unused part is refunded once the
+// response completes.
+type KeyauthResponseCredits struct {
+	// Header Response header the upstream reports the request's cost in, as a
+	// non-negative integer, e.g. `X-Unkey-Cost`. A trailer of the same name is
+	// read when the header is absent, for streamed responses that only know
+	// their cost at the end.
+	Header string `json:"header"`
+
+	// Reserve Credits held while the request is proxied. Size it to the most one
+	// request can cost: reported costs above it are charged as the reserve,
+	// and requests whose response reports no cost are charged the full
+	// reserve.
+	Reserve int64 `json:"reserve"`
 }
 
 // KeysVerifyKeyCredits Controls credit consumption for usage-based billing and quota enforcement.
@@ -1026,8 +1309,17 @@ type LoggingPolicy struct {
 }
 
 // MatchExpr A single request match expression. Exactly one of `path`, `method`,
-// `header` or `queryParam` must be set.
+// `header`, `queryParam`, `remoteIp`, `country`, `principal`, `not` or
+// `anyOf` must be set. `not` and `anyOf` nest other expressions, at most four
+// levels deep.
 type MatchExpr struct {
+	// AnyOf Matches when at least one of the expressions matches.
+	AnyOf *AnyOfMatch `json:"anyOf,omitempty"`
+
+	// Country Matches when the client IP is located in any of the listed countries.
+	// Requests whose country cannot be resolved do not match.
+	Country *CountryMatch `json:"country,omitempty"`
+
 	// Header Matches a named request field (header or query parameter). Exactly one of
 	// `present` or `value` must be set.
 	Header *FieldMatch `json:"header,omitempty"`
@@ -1035,12 +1327,28 @@ type MatchExpr struct {
 	// Method Matches when the request method is one of the listed methods.
 	Method *MethodMatch `json:"method,omitempty"`
 
+	// Not A single request match expression. Exactly one of `path`, `method`,
+	// `header`, `queryParam`, `remoteIp`, `country`, `principal`, `not` or
+	// `anyOf` must be set. `not` and `anyOf` nest other expressions, at most four
+	// levels deep.
+	Not *MatchExpr `json:"not,omitempty"`
+
 	// Path Matches on the request path.
 	Path *PathMatch `json:"path,omitempty"`
 
+	// Principal Matches a field of the principal set by an earlier `keyauth` or `jwtauth`
+	// policy. Requests without a principal never match. Exactly one of `present`
+	// or `value` must be set.
+	Principal *PrincipalMatch `json:"principal,omitempty"`
+
 	// QueryParam Matches a named request field (header or query parameter). Exactly one of
 	// `present` or `value` must be set.
 	QueryParam *FieldMatch `json:"queryParam,omitempty"`
+
+	// RemoteIp Matches when the client IP falls inside any of the listed networks. The
+	// client IP is the first `X-Forwarded-For` entry, or the connection address
+	// when the header is absent, the same address `remoteIp` rate limit keys use.
+	RemoteIp *RemoteIpMatch `json:"remoteIp,omitempty"`
 }
 
 // Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
@@ -1100,6 +1408,10 @@ type PathMatch struct {
 
 // Permission defines model for Permission.
 type Permission struct {
+	// Conditions Restricts when a permission applies. A key holding a permission with conditions only passes a permission check when every condition is met; unmet or unevaluable conditions deny the check.
+	// Conditions are evaluated during `keys.verifyKey` against the attributes named in the permissions query, such as `deploy.write[environment=production]`, the time of the request and the key's `meta`.
+	Conditions *PermissionConditions `json:"conditions,omitempty"`
+
 	// Description Optional detailed explanation of what this permission grants access to.
 	// Helps team members understand the scope and implications of granting this permission.
 	// Include information about what resources can be accessed and what actions can be performed.
@@ -1121,6 +1433,34 @@ type Permission struct {
 	Slug string `json:"slug"`
 }
 
+// PermissionConditions Restricts when a permission applies. A key holding a permission with conditions only passes a permission check when every condition is met; unmet or unevaluable conditions deny the check.
+// Conditions are evaluated during `keys.verifyKey` against the attributes named in the permissions query, such as `deploy.write[environment=production]`, the time of the request and the key's `meta`.
+type PermissionConditions struct {
+	// Environments Environments the permission applies to.
+	// The permissions query must name one of them with the `environment` attribute, e.g. `deploy.write[environment=production]`.
+	Environments *[]string `json:"environments,omitempty"`
+
+	// Meta Values the verified key's `meta` must contain. Values are compared as strings, so `"2"` matches a meta value of `2`.
+	Meta *map[string]string `json:"meta,omitempty"`
+
+	// TimeWindow Daily window in which the permission applies. `start` is inclusive and `end` is exclusive.
+	// A window whose `end` is before its `start` spans midnight, so `22:00` to `06:00` covers the night.
+	TimeWindow *PermissionTimeWindow `json:"timeWindow,omitempty"`
+}
+
+// PermissionTimeWindow Daily window in which the permission applies. `start` is inclusive and `end` is exclusive.
+// A window whose `end` is before its `start` spans midnight, so `22:00` to `06:00` covers the night.
+type PermissionTimeWindow struct {
+	// End End of the window in 24h `HH:MM` format.
+	End string `json:"end"`
+
+	// Start Beginning of the window in 24h `HH:MM` format.
+	Start string `json:"start"`
+
+	// Timezone IANA time zone the window is expressed in. Defaults to UTC.
+	Timezone *string `json:"timezone,omitempty"`
+}
+
 // Policy A gateway policy. Exactly one of `keyauth`, `ratelimit`, `firewall`,
 // `openapi` or `logging` must be set. The server generates an id for every
 // policy it stores.
@@ -1128,7 +1468,8 @@ type Policy struct {
 	// Enabled Disabled policies are stored but skipped during evaluation.
 	Enabled bool `json:"enabled"`
 
-	// Firewall Blocks matching requests.
+	// Firewall Applies an action to matching requests. Set `redirect` when the action
+	// is `ACTION_REDIRECT` and `response` when it is `ACTION_RESPOND`.
 	Firewall *FirewallPolicy `json:"firewall,omitempty"`
 
 	// Keyauth Verifies Unkey API keys on matching requests.
@@ -1169,7 +1510,8 @@ type PolicyResponse struct {
 	// Enabled Disabled policies are stored but skipped during evaluation.
 	Enabled bool `json:"enabled"`
 
-	// Firewall Blocks matching requests.
+	// Firewall Applies an action to matching requests. Set `redirect` when the action
+	// is `ACTION_REDIRECT` and `response` when it is `ACTION_RESPOND`.
 	Firewall *FirewallPolicy `json:"firewall,omitempty"`
 
 	// Id Server-generated policy id. Regenerated on every `gateway.setPolicies` call, so treat it as stable only until the environment's policies are next replaced.
@@ -1226,6 +1568,22 @@ type PrincipalFieldKey struct {
 	Path string `json:"path"`
 }
 
+// PrincipalMatch Matches a field of the principal set by an earlier `keyauth` or `jwtauth`
+// policy. Requests without a principal never match. Exactly one of `present`
+// or `value` must be set.
+type PrincipalMatch struct {
+	// Field Dotted path into the principal, e.g. `subject`, `source.key.meta.plan`
+	// or `source.jwt.payload.tier`. Numbers and booleans compare by their JSON
+	// text, e.g. `42` or `true`.
+	Field string `json:"field"`
+
+	// Present When true, matches when the field exists; when false, when it does not.
+	Present *bool `json:"present,omitempty"`
+
+	// Value String matcher. Exactly one of `exact`, `prefix` or `regex` must be set.
+	Value *StringMatch `json:"value,omitempty"`
+}
+
 // Project defines model for Project.
 type Project struct {
 	// CreatedAt Unix timestamp in milliseconds when the project was created.
@@ -1255,6 +1613,13 @@ type QueryParamKeyLocation struct {
 	Name string `json:"name"`
 }
 
+// RatelimitAlgorithm How the rate limit counts consumed tokens.
+//
+// - `sliding_window` counts the current window plus a weighted share of the previous one, which smooths the burst a fixed window allows at its boundary. This is the default.
+// - `fixed_window` counts only the current window. Windows are epoch-aligned, not calendar-aligned: they start at multiples of the duration since the Unix epoch, so hourly and daily limits reset on UTC hour and day boundaries, weekly limits reset on Thursdays at 00:00 UTC, and there are no monthly or time zone aligned windows.
+// - `token_bucket` treats the limit as the bucket capacity and refills `refillRate` tokens every duration, allowing a burst of up to the limit followed by a steady rate.
+type RatelimitAlgorithm string
+
 // RatelimitIdentifier How requests are grouped for rate limiting. Exactly one of `remoteIp`,
 // `header`, `authenticatedSubject`, `path` or `principalField` must be set.
 type RatelimitIdentifier struct {
@@ -1279,6 +1644,9 @@ type RatelimitOverride struct {
 	// Duration The duration in milliseconds for this override's rate limit window. This may differ from the default duration for the namespace, allowing custom time windows for specific entities. After this duration elapses, the rate limit counter for affected identifiers resets to zero.
 	Duration int64 `json:"duration"`
 
+	// ExpiresAt Unix timestamp in milliseconds after which this override no longer applies and matching identifiers revert to the limit in the request or the next matching override. Omitted when the override never expires.
+	ExpiresAt *int64 `json:"expiresAt,omitempty"`
+
 	// Identifier The identifier pattern this override applies to. This determines which entities receive the custom rate limit.
 	//
 	// This can be:
@@ -1304,12 +1672,22 @@ type RatelimitOverride struct {
 
 	// OverrideId The unique identifier of this specific rate limit override. This ID is generated when the override is created and can be used for management operations like updating or deleting the override.
 	OverrideId string `json:"overrideId"`
+
+	// Priority Precedence of this override when several match an identifier. The highest priority wins; on equal priority an exact identifier beats a wildcard pattern, and a more specific pattern beats a broader one.
+	Priority int32 `json:"priority"`
 }
 
 // RatelimitPolicy Rate limits matching requests. Set `identifiers` with 1 to 5 sources.
 // The deprecated `identifier` field is accepted in place of a one-entry
 // `identifiers` list; set exactly one of the two.
 type RatelimitPolicy struct {
+	// Algorithm How the rate limit counts consumed tokens.
+	//
+	// - `sliding_window` counts the current window plus a weighted share of the previous one, which smooths the burst a fixed window allows at its boundary. This is the default.
+	// - `fixed_window` counts only the current window. Windows are epoch-aligned, not calendar-aligned: they start at multiples of the duration since the Unix epoch, so hourly and daily limits reset on UTC hour and day boundaries, weekly limits reset on Thursdays at 00:00 UTC, and there are no monthly or time zone aligned windows.
+	// - `token_bucket` treats the limit as the bucket capacity and refills `refillRate` tokens every duration, allowing a burst of up to the limit followed by a steady rate.
+	Algorithm *RatelimitAlgorithm `json:"algorithm,omitempty"`
+
 	// Identifier Deprecated. Accepted for compatibility with old clients. Use
 	// `identifiers` with one entry. Responses always return `identifiers`.
 	// Deprecated: this property has been marked as deprecated upstream, but no `x-deprecated-reason` was set
@@ -1325,6 +1703,11 @@ type RatelimitPolicy struct {
 	// Limit Maximum number of requests per window.
 	Limit int64 `json:"limit"`
 
+	// RefillRate Tokens a `token_bucket` regains every `windowMs`. Must not exceed
+	// `limit`. Defaults to `limit`, so an empty bucket refills within one
+	// window. Only valid with `algorithm: token_bucket`.
+	RefillRate *int64 `json:"refillRate,omitempty"`
+
 	// WindowMs Window duration in milliseconds.
 	WindowMs int64 `json:"windowMs"`
 }
@@ -1389,6 +1772,15 @@ type RatelimitResponse struct {
 // RemoteIpKey Rate limit by the client's IP address.
 type RemoteIpKey = map[string]interface{}
 
+// RemoteIpMatch Matches when the client IP falls inside any of the listed networks. The
+// client IP is the first `X-Forwarded-For` entry, or the connection address
+// when the header is absent, the same address `remoteIp` rate limit keys use.
+type RemoteIpMatch struct {
+	// Cidrs IPv4 or IPv6 networks in CIDR notation, e.g. `203.0.113.0/24` or
+	// `2001:db8::/32`. A bare address matches that single address.
+	Cidrs []string `json:"cidrs"`
+}
+
 // Replicas Min and max replica bounds for autoscaling in a region.
 type Replicas struct {
 	// Max Maximum number of replicas.
@@ -1504,6 +1896,18 @@ type UnprocessableEntityErrorResponse struct {
 	Meta Meta `json:"meta"`
 }
 
+// UpdateIdentityCreditsData Replaces the credit pool shared by all keys of this identity.
+// Omitting this field preserves the existing pool, while `null` removes it so keys only draw from their own credits.
+// Keys draw from the pool in addition to their own credits; a key without credits of its own is limited by the pool alone.
+// Changes may take up to a minute to reach keys that were verified recently.
+type UpdateIdentityCreditsData struct {
+	// Refill Configuration for automatic credit refill behavior.
+	Refill *KeyCreditsRefill `json:"refill,omitempty"`
+
+	// Remaining Number of credits in the pool.
+	Remaining int64 `json:"remaining"`
+}
+
 // UpdateKeyCreditsData Credit configuration and remaining balance for this key.
 type UpdateKeyCreditsData struct {
 	// Refill Configuration for automatic credit refill behavior.
@@ -1530,6 +1934,17 @@ type UpdateKeyCreditsRefill struct {
 // UpdateKeyCreditsRefillInterval How often credits are automatically refilled.
 type UpdateKeyCreditsRefillInterval string
 
+// UpdateKeyRotation Schedule for issuing a successor to this key.
+// Every `interval` milliseconds after the key was created, Unkey issues a new key with the same configuration and keeps the old one valid for `gracePeriod` more milliseconds.
+// Only recoverable keys can be rotated.
+type UpdateKeyRotation struct {
+	// GracePeriod Milliseconds the old key keeps verifying after its successor is issued. Must not exceed `interval`.
+	GracePeriod int64 `json:"gracePeriod"`
+
+	// Interval Milliseconds between the key's creation and the issue of its successor. Must be at least one day.
+	Interval int64 `json:"interval"`
+}
+
 // V2AnalyticsGetGatewayRequestsRequestBody defines model for V2AnalyticsGetGatewayRequestsRequestBody.
 type V2AnalyticsGetGatewayRequestsRequestBody struct {
 	// Query The SQL query to run on your gateway request data.
@@ -1681,6 +2096,10 @@ type V2ApisGetApiResponseBody struct {
 
 // V2ApisGetApiResponseData defines model for V2ApisGetApiResponseData.
 type V2ApisGetApiResponseData struct {
+	// Environments The environments this API separates its keys into, ordered by name. Empty if none are configured.
+	// Configure them with `apis.updateEnvironments`.
+	Environments *[]KeyEnvironment `json:"environments,omitempty"`
+
 	// Id The unique identifier of this API within Unkey's system.
 	// Used in all operations related to this API including key creation, verification, and management.
 	// Always begins with 'api_' followed by alphanumeric characters and underscores.
@@ -1744,6 +2163,45 @@ type V2ApisListKeysResponseBody struct {
 // V2ApisListKeysResponseData Array of API keys with complete configuration and metadata.
 type V2ApisListKeysResponseData = []KeyResponseData
 
+// V2ApisUpdateEnvironmentsRequestBody defines model for V2ApisUpdateEnvironmentsRequestBody.
+type V2ApisUpdateEnvironmentsRequestBody struct {
+	// ApiId The API whose environments to configure.
+	ApiId string `json:"apiId"`
+
+	// Environments The complete set of environments of the API. Replaces any previous configuration; pass an empty array to remove all environments.
+	// Existing keys keep the environment they were created in, even if it is removed here, but environment checks of `keys.verifyKey` still compare against it.
+	Environments []KeyEnvironment `json:"environments"`
+}
+
+// V2ApisUpdateEnvironmentsResponseBody defines model for V2ApisUpdateEnvironmentsResponseBody.
+type V2ApisUpdateEnvironmentsResponseBody struct {
+	// Data Empty response object by design. A successful response indicates this operation was successfully executed.
+	Data EmptyResponse `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2ApisUpdateJwtAuthRequestBody defines model for V2ApisUpdateJwtAuthRequestBody.
+type V2ApisUpdateJwtAuthRequestBody struct {
+	// ApiId The API to configure JWT authentication for.
+	ApiId string `json:"apiId"`
+
+	// Jwt How tokens for this API are verified. Replaces any previous configuration.
+	// Set to `null` to disable JWT authentication; keys of the API are unaffected either way.
+	// Must be present: omitting it is rejected rather than read as either.
+	Jwt nullable.Nullable[ApiJwtAuthConfig] `json:"jwt,omitempty"`
+}
+
+// V2ApisUpdateJwtAuthResponseBody defines model for V2ApisUpdateJwtAuthResponseBody.
+type V2ApisUpdateJwtAuthResponseBody struct {
+	// Data Empty response object by design. A successful response indicates this operation was successfully executed.
+	Data EmptyResponse `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
 // V2AppsCreateAppRequestBody defines model for V2AppsCreateAppRequestBody.
 type V2AppsCreateAppRequestBody struct {
 	// Git Connect a GitHub repository to the app on creation. Omit to create the app
@@ -1884,6 +2342,74 @@ type V2AppsUpdateAppResponseBody struct {
 	Meta Meta `json:"meta"`
 }
 
+// V2AuditlogsExportAuditLogsRequestBody defines model for V2AuditlogsExportAuditLogsRequestBody.
+type V2AuditlogsExportAuditLogsRequestBody struct {
+	// ActorIds Only return events caused by these actors, for example root key ids or user ids.
+	ActorIds *[]string `json:"actorIds,omitempty"`
+
+	// CorrelationId Only return events that belong to this logical action.
+	CorrelationId *string `json:"correlationId,omitempty"`
+
+	// Cursor Pagination cursor from a previous response. Include this when fetching subsequent pages of results.
+	Cursor *string `json:"cursor,omitempty"`
+
+	// End Only return events that happened at or before this time, in unix milliseconds. Defaults to now. Must not be before `start` or before the start of your workspace's audit log retention window.
+	End *int64 `json:"end,omitempty"`
+
+	// Events Only return events of these types, such as `key.create`.
+	Events *[]string `json:"events,omitempty"`
+
+	// Limit Maximum number of events to stream in a single response. When more events match, the stream ends with a cursor line for fetching the rest.
+	Limit *int `json:"limit,omitempty"`
+
+	// Start Only return events that happened at or after this time, in unix milliseconds. Defaults to, and is clamped to, the start of your workspace's audit log retention window.
+	Start *int64 `json:"start,omitempty"`
+
+	// TargetIds Only return events that affected at least one of these resources.
+	TargetIds *[]string `json:"targetIds,omitempty"`
+}
+
+// V2AuditlogsListAuditLogsRequestBody defines model for V2AuditlogsListAuditLogsRequestBody.
+type V2AuditlogsListAuditLogsRequestBody struct {
+	// ActorIds Only return events caused by these actors, for example root key ids or user ids.
+	ActorIds *[]string `json:"actorIds,omitempty"`
+
+	// CorrelationId Only return events that belong to this logical action.
+	CorrelationId *string `json:"correlationId,omitempty"`
+
+	// Cursor Pagination cursor from a previous response. Include this when fetching subsequent pages of results.
+	Cursor *string `json:"cursor,omitempty"`
+
+	// End Only return events that happened at or before this time, in unix milliseconds. Defaults to now. Must not be before `start` or before the start of your workspace's audit log retention window.
+	End *int64 `json:"end,omitempty"`
+
+	// Events Only return events of these types, such as `key.create`.
+	Events *[]string `json:"events,omitempty"`
+
+	// Limit Maximum number of events to return in a single response. Results exceeding this limit are paginated, with a cursor provided for fetching subsequent pages.
+	Limit *int `json:"limit,omitempty"`
+
+	// Start Only return events that happened at or after this time, in unix milliseconds. Defaults to, and is clamped to, the start of your workspace's audit log retention window.
+	Start *int64 `json:"start,omitempty"`
+
+	// TargetIds Only return events that affected at least one of these resources.
+	TargetIds *[]string `json:"targetIds,omitempty"`
+}
+
+// V2AuditlogsListAuditLogsResponseBody defines model for V2AuditlogsListAuditLogsResponseBody.
+type V2AuditlogsListAuditLogsResponseBody struct {
+	Data V2AuditlogsListAuditLogsResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+
+	// Pagination Pagination metadata for list endpoints. Provides information necessary to traverse through large result sets efficiently using cursor-based pagination.
+	Pagination Pagination `json:"pagination"`
+}
+
+// V2AuditlogsListAuditLogsResponseData defines model for V2AuditlogsListAuditLogsResponseData.
+type V2AuditlogsListAuditLogsResponseData = []AuditLog
+
 // V2DeployCreateDeploymentRequestBody Create a deployment from a pre-built Docker image
 type V2DeployCreateDeploymentRequestBody struct {
 	// App App slug within the project
@@ -2307,6 +2833,56 @@ type V2DomainsVerifyDomainResponseBody struct {
 	Meta Meta `json:"meta"`
 }
 
+// V2EnvironmentsCreateVolumeRequestBody defines model for V2EnvironmentsCreateVolumeRequestBody.
+type V2EnvironmentsCreateVolumeRequestBody struct {
+	// App Identifies a resource by either its unique ID or its slug.
+	// Accepts a prefixed ID (such as 'proj_' or 'app_') or a slug.
+	App ResourceIdentifier `json:"app"`
+
+	// Environment Identifies a resource by either its unique ID or its slug.
+	// Accepts a prefixed ID (such as 'proj_' or 'app_') or a slug.
+	Environment ResourceIdentifier `json:"environment"`
+
+	// MountPath Absolute path inside the container where the volume is mounted.
+	MountPath string `json:"mountPath"`
+
+	// Name The volume's name, unique within the app's environment. Lowercase letters,
+	// digits and hyphens, starting and ending with a letter or digit.
+	//
+	// If the environment holds a released volume with this name, that volume is
+	// reattached with its data instead of creating an empty one.
+	Name string `json:"name"`
+
+	// Project Identifies a resource by either its unique ID or its slug.
+	// Accepts a prefixed ID (such as 'proj_' or 'app_') or a slug.
+	Project ResourceIdentifier `json:"project"`
+
+	// SizeMib Size of the volume in MiB, in steps of 512. The upper bound is your
+	// workspace's per-instance storage quota; exceeding it returns 400.
+	//
+	// A reattached volume can grow but never shrink, so a size below its
+	// current one returns 400.
+	SizeMib int `json:"sizeMib"`
+}
+
+// V2EnvironmentsCreateVolumeResponseBody defines model for V2EnvironmentsCreateVolumeResponseBody.
+type V2EnvironmentsCreateVolumeResponseBody struct {
+	Data V2EnvironmentsCreateVolumeResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2EnvironmentsCreateVolumeResponseData defines model for V2EnvironmentsCreateVolumeResponseData.
+type V2EnvironmentsCreateVolumeResponseData struct {
+	// Reattached True when a released volume with this name was reattached with its data,
+	// false when a new, empty volume was created.
+	Reattached bool `json:"reattached"`
+
+	// VolumeId The volume's id.
+	VolumeId string `json:"volumeId"`
+}
+
 // V2EnvironmentsGetEnvironmentRequestBody defines model for V2EnvironmentsGetEnvironmentRequestBody.
 type V2EnvironmentsGetEnvironmentRequestBody struct {
 	// App Identifies a resource by either its unique ID or its slug.
@@ -2626,7 +3202,8 @@ type V2GatewayUpdatePolicyRequestBody struct {
 	// Accepts a prefixed ID (such as 'proj_' or 'app_') or a slug.
 	Environment ResourceIdentifier `json:"environment"`
 
-	// Firewall Blocks matching requests.
+	// Firewall Applies an action to matching requests. Set `redirect` when the action
+	// is `ACTION_REDIRECT` and `response` when it is `ACTION_RESPOND`.
 	Firewall *FirewallPolicy `json:"firewall,omitempty"`
 
 	// Keyauth Verifies Unkey API keys on matching requests.
@@ -2802,6 +3379,12 @@ type V2IdentitiesListIdentitiesResponseData = []Identity
 
 // V2IdentitiesUpdateIdentityRequestBody defines model for V2IdentitiesUpdateIdentityRequestBody.
 type V2IdentitiesUpdateIdentityRequestBody struct {
+	// Credits Replaces the credit pool shared by all keys of this identity.
+	// Omitting this field preserves the existing pool, while `null` removes it so keys only draw from their own credits.
+	// Keys draw from the pool in addition to their own credits; a key without credits of its own is limited by the pool alone.
+	// Changes may take up to a minute to reach keys that were verified recently.
+	Credits nullable.Nullable[UpdateIdentityCreditsData] `json:"credits,omitempty"`
+
 	// Identity The ID of the identity to update. Accepts either the externalId (your system-generated identifier) or the identityId (internal identifier returned by the identity service).
 	Identity string `json:"identity"`
 
@@ -2919,44 +3502,133 @@ type V2KeysAddRolesResponseBody struct {
 // - Role permissions are not expanded in this response - use keys.getKey for full details
 type V2KeysAddRolesResponseData = []Role
 
-// V2KeysCreateKeyRequestBody defines model for V2KeysCreateKeyRequestBody.
-type V2KeysCreateKeyRequestBody struct {
-	// ApiId The API namespace this key belongs to.
-	// Keys from different APIs cannot access each other.
+// V2KeysBulkDeleteRequestBody defines model for V2KeysBulkDeleteRequestBody.
+type V2KeysBulkDeleteRequestBody struct {
+	// ApiId The API whose keys the operation changes. Keys of other APIs are never touched.
 	ApiId string `json:"apiId"`
 
-	// ByteLength Controls the cryptographic strength of the generated key in bytes.
-	// Higher values increase security but result in longer keys that may be more annoying to handle.
-	// The default 16 bytes provides 2^128 possible combinations, sufficient for most applications.
-	// Consider 32 bytes for highly sensitive APIs, but avoid values above 64 bytes unless specifically required.
-	ByteLength *int `json:"byteLength,omitempty"`
+	// Filter Selects the keys of the API a bulk operation applies to. Every criterion you set must match; an empty filter selects every key of the API, except in `keys.bulkDelete`, which rejects it.
+	// The filter is evaluated when the operation starts, so keys created afterwards are not affected.
+	Filter *KeyBulkFilter `json:"filter,omitempty"`
 
-	// Credits Credit configuration and remaining balance for this key.
-	Credits *KeyCreditsData `json:"credits,omitempty"`
+	// KeyIds The keys to change, by the IDs returned from `createKey`. Provide either `keyIds` or `filter`.
+	// IDs that match no key of the API are reported as failed items.
+	KeyIds *[]string `json:"keyIds,omitempty"`
+}
 
-	// Enabled Controls whether the key is active immediately upon creation.
-	// When set to `false`, the key exists but all verification attempts fail with `code=DISABLED`.
-	// Useful for pre-creating keys that will be activated later or for keys requiring manual approval.
-	// Most keys should be created with `enabled=true` for immediate use.
-	Enabled *bool `json:"enabled,omitempty"`
+// V2KeysBulkDeleteResponseBody defines model for V2KeysBulkDeleteResponseBody.
+type V2KeysBulkDeleteResponseBody struct {
+	Data KeyBulkOperationAccepted `json:"data"`
 
-	// Expires Sets when this key automatically expires as a Unix timestamp in milliseconds.
-	// Verification fails with code=EXPIRED immediately after this time passes.
-	// Omitting this field creates a permanent key that never expires.
-	//
-	// Avoid setting timestamps in the past as they immediately invalidate the key.
-	// Keys expire based on server time, not client time, which prevents timezone-related issues.
-	// Essential for trial periods, temporary access, and security compliance requiring key rotation.
-	Expires *int64 `json:"expires,omitempty"`
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
 
-	// ExternalId Links this key to a user or entity in your system using your own identifier.
-	// Returned during verification to identify the key owner without additional database lookups.
-	// Essential for user-specific analytics, billing, and multi-tenant key management.
-	// Use your primary user ID, organization ID, or tenant ID for best results.
-	// Accepts letters, numbers, underscores, dots, and hyphens for flexible identifier formats.
-	ExternalId *string `json:"externalId,omitempty"`
+// V2KeysBulkSetPermissionsRequestBody defines model for V2KeysBulkSetPermissionsRequestBody.
+type V2KeysBulkSetPermissionsRequestBody struct {
+	// ApiId The API whose keys the operation changes. Keys of other APIs are never touched.
+	ApiId string `json:"apiId"`
 
-	// Meta Stores arbitrary JSON metadata returned during key verification for contextual information.
+	// Filter Selects the keys of the API a bulk operation applies to. Every criterion you set must match; an empty filter selects every key of the API, except in `keys.bulkDelete`, which rejects it.
+	// The filter is evaluated when the operation starts, so keys created afterwards are not affected.
+	Filter *KeyBulkFilter `json:"filter,omitempty"`
+
+	// KeyIds The keys to change, by the IDs returned from `createKey`. Provide either `keyIds` or `filter`.
+	// IDs that match no key of the API are reported as failed items.
+	KeyIds *[]string `json:"keyIds,omitempty"`
+
+	// Permissions The direct permissions every selected key ends up with, by slug.
+	//
+	// Like `keys.setPermissions`, this replaces each key's direct permissions; an empty array removes them all. Permissions granted through roles are not affected.
+	// Permissions that do not exist are created if the root key may create permissions, otherwise the request fails with a 403 error.
+	Permissions []string `json:"permissions"`
+}
+
+// V2KeysBulkSetPermissionsResponseBody defines model for V2KeysBulkSetPermissionsResponseBody.
+type V2KeysBulkSetPermissionsResponseBody struct {
+	Data KeyBulkOperationAccepted `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2KeysBulkUpdateRequestBody defines model for V2KeysBulkUpdateRequestBody.
+type V2KeysBulkUpdateRequestBody struct {
+	// ApiId The API whose keys the operation changes. Keys of other APIs are never touched.
+	ApiId string `json:"apiId"`
+
+	// Enabled Enables or disables every selected key. Omitting this field preserves each key's current status.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Expires Sets when every selected key expires, as a Unix timestamp in milliseconds. Omitting this field preserves current expirations, while setting null makes the keys permanent.
+	Expires nullable.Nullable[int64] `json:"expires,omitempty"`
+
+	// Filter Selects the keys of the API a bulk operation applies to. Every criterion you set must match; an empty filter selects every key of the API, except in `keys.bulkDelete`, which rejects it.
+	// The filter is evaluated when the operation starts, so keys created afterwards are not affected.
+	Filter *KeyBulkFilter `json:"filter,omitempty"`
+
+	// KeyIds The keys to change, by the IDs returned from `createKey`. Provide either `keyIds` or `filter`.
+	// IDs that match no key of the API are reported as failed items.
+	KeyIds *[]string `json:"keyIds,omitempty"`
+
+	// Meta Replaces the metadata of every selected key. Omitting this field preserves existing metadata, while setting null removes it.
+	Meta nullable.Nullable[map[string]interface{}] `json:"meta,omitempty"`
+
+	// Name Sets the name of every selected key. Omitting this field leaves names unchanged, while setting null removes them.
+	Name nullable.Nullable[string] `json:"name,omitempty"`
+}
+
+// V2KeysBulkUpdateResponseBody defines model for V2KeysBulkUpdateResponseBody.
+type V2KeysBulkUpdateResponseBody struct {
+	Data KeyBulkOperationAccepted `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2KeysCreateKeyRequestBody defines model for V2KeysCreateKeyRequestBody.
+type V2KeysCreateKeyRequestBody struct {
+	// ApiId The API namespace this key belongs to.
+	// Keys from different APIs cannot access each other.
+	ApiId string `json:"apiId"`
+
+	// ByteLength Controls the cryptographic strength of the generated key in bytes.
+	// Higher values increase security but result in longer keys that may be more annoying to handle.
+	// The default 16 bytes provides 2^128 possible combinations, sufficient for most applications.
+	// Consider 32 bytes for highly sensitive APIs, but avoid values above 64 bytes unless specifically required.
+	ByteLength *int `json:"byteLength,omitempty"`
+
+	// Credits Credit configuration and remaining balance for this key.
+	Credits *KeyCreditsData `json:"credits,omitempty"`
+
+	// Enabled Controls whether the key is active immediately upon creation.
+	// When set to `false`, the key exists but all verification attempts fail with `code=DISABLED`.
+	// Useful for pre-creating keys that will be activated later or for keys requiring manual approval.
+	// Most keys should be created with `enabled=true` for immediate use.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Environment Creates the key in one of the environments configured with `apis.updateEnvironments`, such as `test` or `live`.
+	// The key gets the environment's prefix unless `prefix` is set, and verifications that require another environment reject it.
+	// Keys in a test-mode environment never spend credits when verified.
+	Environment *string `json:"environment,omitempty"`
+
+	// Expires Sets when this key automatically expires as a Unix timestamp in milliseconds.
+	// Verification fails with code=EXPIRED immediately after this time passes.
+	// Omitting this field creates a permanent key that never expires.
+	//
+	// Avoid setting timestamps in the past as they immediately invalidate the key.
+	// Keys expire based on server time, not client time, which prevents timezone-related issues.
+	// Essential for trial periods, temporary access, and security compliance requiring key rotation.
+	Expires *int64 `json:"expires,omitempty"`
+
+	// ExternalId Links this key to a user or entity in your system using your own identifier.
+	// Returned during verification to identify the key owner without additional database lookups.
+	// Essential for user-specific analytics, billing, and multi-tenant key management.
+	// Use your primary user ID, organization ID, or tenant ID for best results.
+	// Accepts letters, numbers, underscores, dots, and hyphens for flexible identifier formats.
+	ExternalId *string `json:"externalId,omitempty"`
+
+	// Meta Stores arbitrary JSON metadata returned during key verification for contextual information.
 	// Eliminates additional database lookups during verification, improving performance for stateless services.
 	// Avoid storing sensitive data here as it's returned in verification responses.
 	// Large metadata objects increase verification latency and should stay under 10KB total size.
@@ -2994,6 +3666,12 @@ type V2KeysCreateKeyRequestBody struct {
 	// During verification, all permissions from assigned roles are checked against requested permissions.
 	// Roles provide a convenient way to group permissions and apply consistent access patterns across multiple keys.
 	Roles *[]string `json:"roles,omitempty"`
+
+	// Rotation Schedule for issuing a successor to this key.
+	// Every `interval` milliseconds after the key was created, Unkey issues a new key with the same configuration and keeps the old one valid for `gracePeriod` more milliseconds.
+	// The `key.rotated` webhook announces each successor; read its plaintext with `keys.getKey` and `decrypt=true`.
+	// Only recoverable keys can be rotated, because the successor's plaintext is only available from the vault.
+	Rotation *KeyRotation `json:"rotation,omitempty"`
 }
 
 // V2KeysCreateKeyResponseBody defines model for V2KeysCreateKeyResponseBody.
@@ -3041,6 +3719,81 @@ type V2KeysDeleteKeyResponseBody struct {
 	Meta Meta `json:"meta"`
 }
 
+// V2KeysGetBulkOperationRequestBody defines model for V2KeysGetBulkOperationRequestBody.
+type V2KeysGetBulkOperationRequestBody struct {
+	// Cursor Pagination cursor from the previous response to fetch the next page of items.
+	// Use when `hasMore: true` in the previous response.
+	Cursor *string `json:"cursor,omitempty"`
+
+	// Limit Maximum number of items to return per request.
+	Limit *int `json:"limit,omitempty"`
+
+	// OperationId The bulk operation to read, as returned by `keys.bulkUpdate`, `keys.bulkDelete` or `keys.bulkSetPermissions`.
+	OperationId string `json:"operationId"`
+}
+
+// V2KeysGetBulkOperationResponseBody defines model for V2KeysGetBulkOperationResponseBody.
+type V2KeysGetBulkOperationResponseBody struct {
+	Data V2KeysGetBulkOperationResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+
+	// Pagination Pagination metadata for list endpoints. Provides information necessary to traverse through large result sets efficiently using cursor-based pagination.
+	Pagination Pagination `json:"pagination"`
+}
+
+// V2KeysGetBulkOperationResponseData defines model for V2KeysGetBulkOperationResponseData.
+type V2KeysGetBulkOperationResponseData struct {
+	ApiId string `json:"apiId"`
+
+	// CompletedAt When the operation finished, as a Unix timestamp in milliseconds.
+	CompletedAt *int64 `json:"completedAt,omitempty"`
+
+	// CreatedAt When the operation was accepted, as a Unix timestamp in milliseconds.
+	CreatedAt int64 `json:"createdAt"`
+
+	// Error Why the operation failed. Only set when `status` is `failed`.
+	Error *string `json:"error,omitempty"`
+
+	// Failed Number of keys that could not be changed so far.
+	Failed int64 `json:"failed"`
+
+	// Items The outcome of each key, in the order the operation processes them.
+	Items []struct {
+		// Error Why the key could not be changed. Only set when `status` is `failed`.
+		Error  *string                                       `json:"error,omitempty"`
+		KeyId  string                                        `json:"keyId"`
+		Status V2KeysGetBulkOperationResponseDataItemsStatus `json:"status"`
+	} `json:"items"`
+
+	// Operation Which bulk route started the operation.
+	Operation   V2KeysGetBulkOperationResponseDataOperation `json:"operation"`
+	OperationId string                                      `json:"operationId"`
+
+	// Status `pending` until the background job starts and `running` while it works through the keys.
+	// `completed` once every key has an outcome, even if some of them failed.
+	// `failed` only if the operation as a whole could not run; `error` explains why.
+	Status V2KeysGetBulkOperationResponseDataStatus `json:"status"`
+
+	// Succeeded Number of keys changed so far.
+	Succeeded int64 `json:"succeeded"`
+
+	// Total Number of keys the operation applies to. For a filter this is known once the filter has been evaluated and is 0 before.
+	Total int64 `json:"total"`
+}
+
+// V2KeysGetBulkOperationResponseDataItemsStatus defines model for V2KeysGetBulkOperationResponseData.Items.Status.
+type V2KeysGetBulkOperationResponseDataItemsStatus string
+
+// V2KeysGetBulkOperationResponseDataOperation Which bulk route started the operation.
+type V2KeysGetBulkOperationResponseDataOperation string
+
+// V2KeysGetBulkOperationResponseDataStatus `pending` until the background job starts and `running` while it works through the keys.
+// `completed` once every key has an outcome, even if some of them failed.
+// `failed` only if the operation as a whole could not run; `error` explains why.
+type V2KeysGetBulkOperationResponseDataStatus string
+
 // V2KeysGetKeyRequestBody defines model for V2KeysGetKeyRequestBody.
 type V2KeysGetKeyRequestBody struct {
 	// Decrypt Controls whether to include the plaintext key value in the response for recovery purposes.
@@ -3494,6 +4247,11 @@ type V2KeysUpdateKeyRequestBody struct {
 	// Multiple rate limits can control different operation types with separate thresholds and windows.
 	Ratelimits *[]RatelimitRequest `json:"ratelimits,omitempty"`
 	Roles      *[]string           `json:"roles,omitempty"`
+
+	// Rotation Schedule for issuing a successor to this key.
+	// Every `interval` milliseconds after the key was created, Unkey issues a new key with the same configuration and keeps the old one valid for `gracePeriod` more milliseconds.
+	// Only recoverable keys can be rotated.
+	Rotation nullable.Nullable[UpdateKeyRotation] `json:"rotation,omitempty"`
 }
 
 // V2KeysUpdateKeyResponseBody defines model for V2KeysUpdateKeyResponseBody.
@@ -3512,8 +4270,16 @@ type V2KeysVerifyKeyRequestBody struct {
 	// Credits provide globally consistent usage tracking, essential for paid APIs with strict quotas.
 	Credits *KeysVerifyKeyCredits `json:"credits,omitempty"`
 
+	// Environment Requires the key to belong to this environment of the API, e.g. `live` on your production servers.
+	// Keys from any other environment, and keys created without one, fail with code `FORBIDDEN`.
+	// Omit it to accept keys from every environment.
+	Environment *string `json:"environment,omitempty"`
+
 	// Key The API key to verify, exactly as provided by your user.
 	// Include any prefix - even small changes will cause verification to fail.
+	//
+	// For APIs with JWT authentication enabled this may also be a JWT from the configured issuer.
+	// It is verified against the issuer's keys and checked on behalf of the identity it belongs to.
 	Key string `json:"key"`
 
 	// MigrationId Migrate keys on demand from your previous system. Reach out for migration support at support@unkey.dev
@@ -3525,7 +4291,10 @@ type V2KeysVerifyKeyRequestBody struct {
 	// - Single permission: "documents.read"
 	// - Multiple permissions: "documents.read AND documents.write"
 	// - Complex queries: "(documents.read OR documents.write) AND users.view"
+	// - Request attributes: "deploy.write[environment=production]"
 	// Verification fails if the key lacks the required permissions through direct assignment or role inheritance.
+	// Permissions created with `conditions` only count when their conditions are met: the attributes in brackets describe the request, and are checked together with the time of the request and the key's `meta`.
+	// Attributes have no effect on permissions without conditions.
 	Permissions *string `json:"permissions,omitempty"`
 
 	// Ratelimits Enforces time-based rate limiting during verification to prevent abuse and ensure fair usage.
@@ -3578,6 +4347,7 @@ type V2KeysVerifyKeyResponseData struct {
 	// KeyId The unique identifier of the verified key in Unkey's system.
 	// Use this ID for operations like updating or revoking the key. This field
 	// is returned for both valid and invalid keys (except when `code=NOT_FOUND`).
+	// It is empty for JWTs, which have no key; use `identity` to tell them apart.
 	KeyId string `json:"keyId,omitempty"`
 
 	// Meta Custom metadata associated with the key. This can include any
@@ -3650,6 +4420,10 @@ type V2LivenessResponseData struct {
 
 // V2PermissionsCreatePermissionRequestBody defines model for V2PermissionsCreatePermissionRequestBody.
 type V2PermissionsCreatePermissionRequestBody struct {
+	// Conditions Restricts when a permission applies. A key holding a permission with conditions only passes a permission check when every condition is met; unmet or unevaluable conditions deny the check.
+	// Conditions are evaluated during `keys.verifyKey` against the attributes named in the permissions query, such as `deploy.write[environment=production]`, the time of the request and the key's `meta`.
+	Conditions *PermissionConditions `json:"conditions,omitempty"`
+
 	// Description Provides detailed documentation of what this permission grants access to.
 	// Include information about affected resources, allowed actions, and any important limitations.
 	// This internal documentation helps team members understand permission scope and security implications.
@@ -3914,6 +4688,30 @@ type V2PermissionsSetRolePermissionsResponseBody struct {
 // V2PermissionsSetRolePermissionsResponseData Complete list of permissions now directly assigned to the role.
 type V2PermissionsSetRolePermissionsResponseData = []Permission
 
+// V2PortalCreateKeyRequestBody defines model for V2PortalCreateKeyRequestBody.
+type V2PortalCreateKeyRequestBody struct {
+	// Expires When the key expires, as a unix timestamp in milliseconds. Must fall within
+	// the expiry bounds configured on the portal. Required when the portal sets a
+	// maximum key lifetime.
+	Expires *int64 `json:"expires,omitempty"`
+
+	// KeyspaceId The keyspace to create the key in. Must be one of the keyspaces the portal
+	// session is scoped to. Optional when the session is scoped to exactly one
+	// keyspace, required otherwise.
+	KeyspaceId *string `json:"keyspaceId,omitempty"`
+
+	// Name A human-readable name chosen by the end user to tell their keys apart.
+	Name *string `json:"name,omitempty"`
+
+	// Permissions Permissions to grant the key. Every slug must be in the portal's list of
+	// allowed permissions.
+	Permissions *[]string `json:"permissions,omitempty"`
+
+	// Roles Existing roles to assign to the key. Every role must be in the portal's
+	// list of allowed roles.
+	Roles *[]string `json:"roles,omitempty"`
+}
+
 // V2PortalCreateSessionRequestBody defines model for V2PortalCreateSessionRequestBody.
 type V2PortalCreateSessionRequestBody struct {
 	// ExternalId The end user's identifier in the customer's system.
@@ -3947,11 +4745,9 @@ type V2PortalCreateSessionRequestBody struct {
 	// - Analytics tab: `analytics:read`
 	// - Docs tab: visible when any scope is present
 	//
-	// `keys:create` is accepted but has no portal route behind it yet. It is
-	// still authorized like the others, so a session minted with it required
-	// `create_key` on the keyspace at mint time, and a future portal create-key
-	// route inherits an enforced ceiling rather than trusting sessions minted
-	// while the capability was inert.
+	// `keys:create` is further limited by the key policy configured on the
+	// portal: the maximum number of keys per end user, the permissions and
+	// roles an end user may grant, and the allowed key lifetime.
 	//
 	// Each scope requires the equivalent permission on your own root key. See
 	// Required Permissions on this operation.
@@ -3980,6 +4776,13 @@ type V2PortalCreateSessionResponseData struct {
 	Url string `json:"url"`
 }
 
+// V2PortalDeleteKeyRequestBody defines model for V2PortalDeleteKeyRequestBody.
+type V2PortalDeleteKeyRequestBody struct {
+	// KeyId The key to delete. It must belong to the authenticated end user; any other
+	// key returns 404. Portal deletions are always soft deletions.
+	KeyId string `json:"keyId"`
+}
+
 // V2PortalExchangeCodeRequestBody defines model for V2PortalExchangeCodeRequestBody.
 type V2PortalExchangeCodeRequestBody struct {
 	// Code The exchange code carried by the portal URL from `portal.createSession`.
@@ -4004,6 +4807,72 @@ type V2PortalExchangeCodeResponseData struct {
 	ExpiresAt int64 `json:"expiresAt"`
 }
 
+// V2PortalGetCreditsRequestBody Empty. The identity is fixed by the portal session.
+type V2PortalGetCreditsRequestBody = map[string]interface{}
+
+// V2PortalGetCreditsResponseBody defines model for V2PortalGetCreditsResponseBody.
+type V2PortalGetCreditsResponseBody struct {
+	Data V2PortalGetCreditsResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2PortalGetCreditsResponseData defines model for V2PortalGetCreditsResponseData.
+type V2PortalGetCreditsResponseData struct {
+	// Credits Credit pool shared by every key attached to this identity.
+	// Keys draw from the pool in addition to their own credits, so a request is only allowed while both have enough left.
+	Credits *IdentityCredits `json:"credits,omitempty"`
+}
+
+// V2PortalGetRatelimitsDataPoint defines model for V2PortalGetRatelimitsDataPoint.
+type V2PortalGetRatelimitsDataPoint struct {
+	// Passed Verifications that were within every rate limit.
+	Passed int64 `json:"passed"`
+
+	// RateLimited Verifications rejected because a rate limit was exceeded.
+	RateLimited int64 `json:"rateLimited"`
+
+	// Time Bucket start as a unix timestamp in milliseconds.
+	Time int64 `json:"time"`
+}
+
+// V2PortalGetRatelimitsRequestBody defines model for V2PortalGetRatelimitsRequestBody.
+type V2PortalGetRatelimitsRequestBody struct {
+	// EndTime End of the query window as a unix timestamp in milliseconds (exclusive).
+	// Bucket granularity (minute, hour, or day) is chosen automatically from the
+	// window size.
+	EndTime int64 `json:"endTime"`
+
+	// KeyId Optional. Restrict results to a single key. The key must belong to the
+	// authenticated end user; results are always scoped to the session identity
+	// regardless of this value.
+	KeyId *string `json:"keyId,omitempty"`
+
+	// StartTime Start of the query window as a unix timestamp in milliseconds (inclusive).
+	StartTime int64 `json:"startTime"`
+}
+
+// V2PortalGetRatelimitsResponseBody defines model for V2PortalGetRatelimitsResponseBody.
+type V2PortalGetRatelimitsResponseBody struct {
+	Data V2PortalGetRatelimitsResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2PortalGetRatelimitsResponseData defines model for V2PortalGetRatelimitsResponseData.
+type V2PortalGetRatelimitsResponseData struct {
+	// History Zero-filled timeseries of the end user's verifications split into passed
+	// and rate limited, ordered by time ascending.
+	History []V2PortalGetRatelimitsDataPoint `json:"history"`
+
+	// Ratelimits Rate limits configured on the end user's identity and shared by all of
+	// their keys. Limits configured on individual keys are returned on each key
+	// by `portal.listKeys`.
+	Ratelimits []RatelimitResponse `json:"ratelimits"`
+}
+
 // V2PortalGetVerificationsDataPoint defines model for V2PortalGetVerificationsDataPoint.
 type V2PortalGetVerificationsDataPoint struct {
 	// Disabled Verifications rejected because the key was disabled.
@@ -4087,6 +4956,59 @@ type V2PortalListKeysResponseBody struct {
 // V2PortalListKeysResponseData Array of the portal end user's API keys.
 type V2PortalListKeysResponseData = []KeyResponseData
 
+// V2PortalUpdatePortalKeyPolicy Bounds the keys end users may create through the portal with the
+// `keys:create` scope. The policy is written as a whole: an omitted field
+// clears that part of it. It is read on every request, so a change applies to
+// sessions that are already live.
+type V2PortalUpdatePortalKeyPolicy struct {
+	// AllowedPermissions The only permission slugs an end user may attach to a key they create.
+	// Omit to allow none.
+	AllowedPermissions *[]string `json:"allowedPermissions,omitempty"`
+
+	// AllowedRoles The only role names an end user may attach to a key they create. Omit to
+	// allow none.
+	AllowedRoles *[]string `json:"allowedRoles,omitempty"`
+
+	// MaxExpiryMs The longest lifetime, in milliseconds, a created key may have. When set,
+	// every created key must expire. Must not be below `minExpiryMs`. Omit or
+	// set to 0 to allow keys that never expire.
+	MaxExpiryMs *int64 `json:"maxExpiryMs,omitempty"`
+
+	// MaxKeys The most live keys an end user may hold across the portal's keyspaces.
+	// Omit or set to 0 for no limit.
+	MaxKeys *int32 `json:"maxKeys,omitempty"`
+
+	// MinExpiryMs The shortest lifetime, in milliseconds, a created key may have. Omit or
+	// set to 0 for no minimum.
+	MinExpiryMs *int64 `json:"minExpiryMs,omitempty"`
+
+	// RatelimitTemplates Ratelimits attached to every key an end user creates. End users cannot
+	// choose or change them. Each name may appear at most once.
+	RatelimitTemplates *[]RatelimitRequest `json:"ratelimitTemplates,omitempty"`
+}
+
+// V2PortalUpdatePortalRequestBody defines model for V2PortalUpdatePortalRequestBody.
+type V2PortalUpdatePortalRequestBody struct {
+	// KeyPolicy Bounds the keys end users may create through the portal with the
+	// `keys:create` scope. The policy is written as a whole: an omitted field
+	// clears that part of it. It is read on every request, so a change applies to
+	// sessions that are already live.
+	KeyPolicy V2PortalUpdatePortalKeyPolicy `json:"keyPolicy"`
+
+	// Portal Identifies a resource by either its unique ID or its slug.
+	// Accepts a prefixed ID (such as 'proj_' or 'app_') or a slug.
+	Portal ResourceIdentifier `json:"portal"`
+}
+
+// V2PortalUpdatePortalResponseBody defines model for V2PortalUpdatePortalResponseBody.
+type V2PortalUpdatePortalResponseBody struct {
+	// Data Empty response object by design. A successful response indicates this operation was successfully executed.
+	Data EmptyResponse `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
 // V2ProjectsCreateProjectRequestBody defines model for V2ProjectsCreateProjectRequestBody.
 type V2ProjectsCreateProjectRequestBody struct {
 	// Name Human-readable name for this project.
@@ -4197,6 +5119,50 @@ type V2ProjectsUpdateProjectResponseBody struct {
 	Meta Meta `json:"meta"`
 }
 
+// V2RatelimitAcquireRequestBody defines model for V2RatelimitAcquireRequestBody.
+type V2RatelimitAcquireRequestBody struct {
+	// Cost How much of `limit` this lease holds. Use a higher cost for work that should take up more than one slot.
+	Cost *int64 `json:"cost,omitempty"`
+
+	// Identifier Identifies who holds the leases, such as a customer ID or queue name. Every identifier has its own concurrency limit.
+	Identifier string `json:"identifier"`
+
+	// Limit The maximum total cost of leases this identifier may hold at the same time.
+	Limit int64 `json:"limit"`
+
+	// Namespace The id or name of the namespace. It must already exist.
+	Namespace string `json:"namespace"`
+
+	// Ttl How long the lease is held in milliseconds if it is not released. Expiry is rounded up to the next 5 second boundary.
+	Ttl *int64 `json:"ttl,omitempty"`
+}
+
+// V2RatelimitAcquireResponseBody defines model for V2RatelimitAcquireResponseBody.
+type V2RatelimitAcquireResponseBody struct {
+	Data V2RatelimitAcquireResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2RatelimitAcquireResponseData defines model for V2RatelimitAcquireResponseData.
+type V2RatelimitAcquireResponseData struct {
+	// ExpiresAt Unix timestamp in milliseconds when the lease lapses unless it is released first. Only present when `success` is `true`.
+	ExpiresAt *int64 `json:"expiresAt,omitempty"`
+
+	// LeaseId Identifies the granted lease. Pass it to `ratelimit.release` when the work is done. Only present when `success` is `true`.
+	LeaseId *string `json:"leaseId,omitempty"`
+
+	// Limit The maximum total cost of leases held at the same time.
+	Limit int64 `json:"limit"`
+
+	// Remaining How much of `limit` is still free after this request.
+	Remaining int64 `json:"remaining"`
+
+	// Success Whether the lease was granted. When `false`, the identifier already holds leases up to `limit`.
+	Success bool `json:"success"`
+}
+
 // V2RatelimitDeleteOverrideRequestBody Deletes an existing rate limit override. This permanently removes a custom rate limit rule, reverting affected identifiers back to the default rate limits for the namespace.
 //
 // Use this endpoint when you need to:
@@ -4234,6 +5200,24 @@ type V2RatelimitDeleteOverrideResponseBody struct {
 // V2RatelimitDeleteOverrideResponseData Empty response object. A successful response indicates the override was successfully deleted. The operation is immediate - as soon as this response is received, the override no longer exists and affected identifiers have reverted to using the default rate limit for the namespace. No other data is returned as part of the deletion operation.
 type V2RatelimitDeleteOverrideResponseData = map[string]interface{}
 
+// V2RatelimitDeletePlanRequestBody defines model for V2RatelimitDeletePlanRequestBody.
+type V2RatelimitDeletePlanRequestBody struct {
+	// Plan The id or name of the plan to delete.
+	Plan string `json:"plan"`
+}
+
+// V2RatelimitDeletePlanResponseBody defines model for V2RatelimitDeletePlanResponseBody.
+type V2RatelimitDeletePlanResponseBody struct {
+	// Data Empty response object. A successful response indicates the plan was deleted. Requests referencing it fail with a 404 once the change reached their region, which can take up to a minute.
+	Data V2RatelimitDeletePlanResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2RatelimitDeletePlanResponseData Empty response object. A successful response indicates the plan was deleted. Requests referencing it fail with a 404 once the change reached their region, which can take up to a minute.
+type V2RatelimitDeletePlanResponseData = map[string]interface{}
+
 // V2RatelimitGetOverrideRequestBody Gets the configuration of an existing rate limit override. Use this to retrieve details about custom rate limit rules that have been created for specific identifiers within a namespace.
 //
 // This endpoint is useful for:
@@ -4267,17 +5251,29 @@ type V2RatelimitGetOverrideResponseBody struct {
 
 // V2RatelimitLimitRequestBody defines model for V2RatelimitLimitRequestBody.
 type V2RatelimitLimitRequestBody struct {
+	// Algorithm How the rate limit counts consumed tokens.
+	//
+	// - `sliding_window` counts the current window plus a weighted share of the previous one, which smooths the burst a fixed window allows at its boundary. This is the default.
+	// - `fixed_window` counts only the current window. Windows are epoch-aligned, not calendar-aligned: they start at multiples of the duration since the Unix epoch, so hourly and daily limits reset on UTC hour and day boundaries, weekly limits reset on Thursdays at 00:00 UTC, and there are no monthly or time zone aligned windows.
+	// - `token_bucket` treats the limit as the bucket capacity and refills `refillRate` tokens every duration, allowing a burst of up to the limit followed by a steady rate.
+	Algorithm *RatelimitAlgorithm `json:"algorithm,omitempty"`
+
 	// Cost Sets how much of the rate limit quota this request consumes, enabling weighted rate limiting.
 	// Use higher values for resource-intensive operations and 0 for tracking without limiting.
 	// When accumulated cost exceeds the limit within the duration window, subsequent requests are rejected.
 	// Essential for implementing fair usage policies and preventing resource abuse through expensive operations.
 	Cost *int64 `json:"cost,omitempty"`
 
+	// DryRun Resolves the limit without consuming any of it. The response reports the override that would apply in `override`, the effective limit, the current `remaining` and whether a request with this `cost` would pass.
+	// Dry runs are not recorded in analytics. Not supported by `ratelimit.multiLimit`.
+	DryRun *bool `json:"dryRun,omitempty"`
+
 	// Duration Sets the rate limit window duration in milliseconds after which the counter resets.
 	// Shorter durations enable faster recovery but may be less effective against sustained abuse.
 	// Common values include 60000 (1 minute), 3600000 (1 hour), and 86400000 (24 hours).
 	// Balance user experience with protection needs when choosing window sizes.
-	Duration int64 `json:"duration"`
+	// Required unless `plan` is set.
+	Duration int64 `json:"duration,omitempty"`
 
 	// Identifier Defines the scope of rate limiting by identifying the entity being limited.
 	// Use user IDs for per-user limits, IP addresses for anonymous limiting, or API key IDs for per-key limits.
@@ -4290,10 +5286,20 @@ type V2RatelimitLimitRequestBody struct {
 	// When this limit is reached, subsequent requests fail with `RATE_LIMITED` until the window resets.
 	// Balance user experience with resource protection when setting limits for different user tiers.
 	// Consider system capacity, business requirements, and fair usage policies in limit determination.
-	Limit int64 `json:"limit"`
+	// Required unless `plan` is set.
+	Limit int64 `json:"limit,omitempty"`
 
-	// Namespace The id or name of the namespace.
-	Namespace string `json:"namespace"`
+	// Namespace The id or name of the namespace. Required unless `plan` is set.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Plan The id or name of a plan created with `ratelimit.setPlan`. Every limit of the plan is applied to `identifier` at once: the request passes and consumes `cost` from all of them only if each one has room for it.
+	// Replaces `namespace`, `limit`, `duration`, `algorithm` and `refillRate`, which must not be set alongside it. Not supported by `ratelimit.multiLimit` or with `dryRun`.
+	Plan *string `json:"plan,omitempty"`
+
+	// RefillRate Sets how many tokens a `token_bucket` regains every `duration`. Defaults to `limit`, so an empty bucket refills completely within one duration.
+	// Must not exceed `limit`. When an override lowers the limit below this value, the refill rate is capped at the override's limit.
+	// Only valid with `algorithm: token_bucket`.
+	RefillRate *int64 `json:"refillRate,omitempty"`
 }
 
 // V2RatelimitLimitResponseBody defines model for V2RatelimitLimitResponseBody.
@@ -4306,11 +5312,15 @@ type V2RatelimitLimitResponseBody struct {
 
 // V2RatelimitLimitResponseData defines model for V2RatelimitLimitResponseData.
 type V2RatelimitLimitResponseData struct {
-	// Limit The maximum number of operations allowed within the time window. This reflects either the default limit specified in the request or an override limit if one exists for this identifier.
+	// Limit The maximum number of operations allowed within the time window. This reflects either the default limit specified in the request or an override limit if one exists for this identifier. For a `token_bucket` it is the bucket capacity.
 	//
 	// This value helps clients understand their total quota for the current window.
 	Limit int64 `json:"limit"`
 
+	// Limits Only set when a `plan` was requested. The result of each limit of the plan. `limit`, `remaining` and `reset` at the top level report the most restrictive of them: the first one that rejected the request, or the one with the least remaining capacity when all of them passed.
+	Limits   *[]V2RatelimitMultiLimitCheck `json:"limits,omitempty"`
+	Override *RatelimitOverride            `json:"override,omitempty"`
+
 	// OverrideId If a rate limit override was applied for this identifier, this field contains the ID of the override that was used. Empty when no override is in effect.
 	//
 	// This can be useful for:
@@ -4337,7 +5347,7 @@ type V2RatelimitLimitResponseData struct {
 	// - Schedule requests to resume after the reset
 	// - Implement exponential backoff when needed
 	//
-	// The reset time is based on a sliding window from the first request in the current window.
+	// For `sliding_window` and `fixed_window` this is the end of the current window. For a `token_bucket` it is when the bucket is full again, or, for a rejected request, when enough tokens will have refilled to cover its cost.
 	Reset int64 `json:"reset"`
 
 	// Success Whether the request passed the rate limit check. If true, the request is allowed to proceed. If false, the request has exceeded the rate limit and should be blocked or rejected.
@@ -4449,6 +5459,32 @@ type V2RatelimitMultiLimitResponseData struct {
 	Passed bool `json:"passed"`
 }
 
+// V2RatelimitReleaseRequestBody defines model for V2RatelimitReleaseRequestBody.
+type V2RatelimitReleaseRequestBody struct {
+	// Identifier The identifier the lease was acquired for.
+	Identifier string `json:"identifier"`
+
+	// LeaseId The `leaseId` returned by `ratelimit.acquire`.
+	LeaseId string `json:"leaseId"`
+
+	// Namespace The id or name of the namespace the lease was acquired in.
+	Namespace string `json:"namespace"`
+}
+
+// V2RatelimitReleaseResponseBody defines model for V2RatelimitReleaseResponseBody.
+type V2RatelimitReleaseResponseBody struct {
+	Data V2RatelimitReleaseResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2RatelimitReleaseResponseData defines model for V2RatelimitReleaseResponseData.
+type V2RatelimitReleaseResponseData struct {
+	// Released Whether the lease was still held. `false` means it was already released or had expired, which is not an error.
+	Released bool `json:"released"`
+}
+
 // V2RatelimitSetOverrideRequestBody Sets a new or overwrites an existing rate limit override. Overrides allow you to apply special rate limit rules to specific identifiers, providing custom limits that differ from the default.
 //
 // Overrides are useful for:
@@ -4467,6 +5503,11 @@ type V2RatelimitSetOverrideRequestBody struct {
 	// - Common values: 60000 (1 minute), 3600000 (1 hour), 86400000 (1 day)
 	Duration int64 `json:"duration"`
 
+	// ExpiresAt Unix timestamp in milliseconds after which the override stops applying, for temporary limit increases or bans. Once expired, matching identifiers revert to the limit in the request or the next matching override, without the override being deleted.
+	//
+	// Must be in the future. Omit it for an override that never expires; setting an existing override without it removes its expiry.
+	ExpiresAt *int64 `json:"expiresAt,omitempty"`
+
 	// Identifier Identifier of the entity receiving this custom rate limit. This can be:
 	//
 	// - A specific user ID for individual custom limits
@@ -4494,6 +5535,11 @@ type V2RatelimitSetOverrideRequestBody struct {
 
 	// Namespace The ID or name of the rate limit namespace.
 	Namespace string `json:"namespace"`
+
+	// Priority Precedence of this override when several overrides match the same identifier, for example `org_*` and `org_*:tier_pro`. The highest priority wins.
+	//
+	// On equal priority an exact identifier beats a wildcard pattern, and a pattern with more literal characters beats a broader one.
+	Priority *int32 `json:"priority,omitempty"`
 }
 
 // V2RatelimitSetOverrideResponseBody defines model for V2RatelimitSetOverrideResponseBody.
@@ -4517,6 +5563,189 @@ type V2RatelimitSetOverrideResponseData struct {
 	OverrideId string `json:"overrideId"`
 }
 
+// V2RatelimitSetPlanLimit defines model for V2RatelimitSetPlanLimit.
+type V2RatelimitSetPlanLimit struct {
+	// Duration The window duration in milliseconds.
+	Duration int64 `json:"duration"`
+
+	// Limit The maximum cost allowed within the duration window.
+	Limit int64 `json:"limit"`
+
+	// Namespace The id or name of an existing namespace this limit counts against.
+	Namespace string `json:"namespace"`
+}
+
+// V2RatelimitSetPlanRequestBody defines model for V2RatelimitSetPlanRequestBody.
+type V2RatelimitSetPlanRequestBody struct {
+	// Limits The limits applied together whenever the plan is checked. Each limit counts against its own namespace, so a plan can combine per-second, per-minute and per-day limits across several namespaces.
+	// A namespace may appear more than once, but only with different durations.
+	Limits []V2RatelimitSetPlanLimit `json:"limits"`
+
+	// Name The name of the plan, unique within your workspace. Reference it as `plan` in `ratelimit.limit`.
+	// Setting a plan that already exists replaces all of its limits.
+	Name string `json:"name"`
+}
+
+// V2RatelimitSetPlanResponseBody defines model for V2RatelimitSetPlanResponseBody.
+type V2RatelimitSetPlanResponseBody struct {
+	Data V2RatelimitSetPlanResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2RatelimitSetPlanResponseData defines model for V2RatelimitSetPlanResponseData.
+type V2RatelimitSetPlanResponseData struct {
+	// PlanId The unique identifier of the created or updated plan. It stays the same when an existing plan is replaced.
+	PlanId string `json:"planId"`
+}
+
+// V2WebhooksCreateEndpointRequestBody defines model for V2WebhooksCreateEndpointRequestBody.
+type V2WebhooksCreateEndpointRequestBody struct {
+	// Description A free-form note to tell endpoints apart.
+	Description *string `json:"description,omitempty"`
+
+	// Enabled Whether to start delivering events right away.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// EventTypes The events to deliver to this endpoint.
+	EventTypes []WebhookEventType `json:"eventTypes"`
+
+	// Url The HTTPS URL to deliver events to. The URL must resolve to a public address; redirects are not followed.
+	Url string `json:"url"`
+}
+
+// V2WebhooksCreateEndpointResponseBody defines model for V2WebhooksCreateEndpointResponseBody.
+type V2WebhooksCreateEndpointResponseBody struct {
+	Data V2WebhooksCreateEndpointResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2WebhooksCreateEndpointResponseData defines model for V2WebhooksCreateEndpointResponseData.
+type V2WebhooksCreateEndpointResponseData struct {
+	// EndpointId The id of the new endpoint.
+	EndpointId string `json:"endpointId"`
+
+	// Secret The signing secret for this endpoint, prefixed with `whsec_`.
+	//
+	// It is returned once and cannot be retrieved later. Store it in your
+	// receiver and use it to verify the `webhook-signature` header of every
+	// delivery.
+	Secret string `json:"secret"`
+}
+
+// V2WebhooksDeleteEndpointRequestBody defines model for V2WebhooksDeleteEndpointRequestBody.
+type V2WebhooksDeleteEndpointRequestBody struct {
+	// EndpointId The id of the webhook endpoint.
+	EndpointId string `json:"endpointId"`
+}
+
+// V2WebhooksDeleteEndpointResponseBody defines model for V2WebhooksDeleteEndpointResponseBody.
+type V2WebhooksDeleteEndpointResponseBody struct {
+	// Data Empty response object by design. A successful response indicates this operation was successfully executed.
+	Data EmptyResponse `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2WebhooksListDeliveriesRequestBody defines model for V2WebhooksListDeliveriesRequestBody.
+type V2WebhooksListDeliveriesRequestBody struct {
+	// Cursor Pagination cursor from a previous response. Include this when fetching subsequent pages of results.
+	Cursor *string `json:"cursor,omitempty"`
+
+	// EndpointId The id of the webhook endpoint.
+	EndpointId string `json:"endpointId"`
+
+	// Limit Maximum number of entries to return in a single response. Results exceeding this limit are paginated, with a cursor provided for fetching subsequent pages.
+	Limit *int `json:"limit,omitempty"`
+}
+
+// V2WebhooksListDeliveriesResponseBody defines model for V2WebhooksListDeliveriesResponseBody.
+type V2WebhooksListDeliveriesResponseBody struct {
+	Data V2WebhooksListDeliveriesResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+
+	// Pagination Pagination metadata for list endpoints. Provides information necessary to traverse through large result sets efficiently using cursor-based pagination.
+	Pagination Pagination `json:"pagination"`
+}
+
+// V2WebhooksListDeliveriesResponseData defines model for V2WebhooksListDeliveriesResponseData.
+type V2WebhooksListDeliveriesResponseData = []WebhookDelivery
+
+// V2WebhooksListEndpointsRequestBody defines model for V2WebhooksListEndpointsRequestBody.
+type V2WebhooksListEndpointsRequestBody struct {
+	// Cursor Pagination cursor from a previous response. Include this when fetching subsequent pages of results.
+	Cursor *string `json:"cursor,omitempty"`
+
+	// Limit Maximum number of entries to return in a single response. Results exceeding this limit are paginated, with a cursor provided for fetching subsequent pages.
+	Limit *int `json:"limit,omitempty"`
+}
+
+// V2WebhooksListEndpointsResponseBody defines model for V2WebhooksListEndpointsResponseBody.
+type V2WebhooksListEndpointsResponseBody struct {
+	Data V2WebhooksListEndpointsResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+
+	// Pagination Pagination metadata for list endpoints. Provides information necessary to traverse through large result sets efficiently using cursor-based pagination.
+	Pagination Pagination `json:"pagination"`
+}
+
+// V2WebhooksListEndpointsResponseData defines model for V2WebhooksListEndpointsResponseData.
+type V2WebhooksListEndpointsResponseData = []WebhookEndpoint
+
+// V2WebhooksReplayDeliveryRequestBody defines model for V2WebhooksReplayDeliveryRequestBody.
+type V2WebhooksReplayDeliveryRequestBody struct {
+	// DeliveryId The id of the delivery to send again.
+	DeliveryId string `json:"deliveryId"`
+}
+
+// V2WebhooksReplayDeliveryResponseBody defines model for V2WebhooksReplayDeliveryResponseBody.
+type V2WebhooksReplayDeliveryResponseBody struct {
+	Data V2WebhooksReplayDeliveryResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2WebhooksReplayDeliveryResponseData defines model for V2WebhooksReplayDeliveryResponseData.
+type V2WebhooksReplayDeliveryResponseData struct {
+	// DeliveryId The id of the new delivery, sent as the `webhook-id` header. The body is the original payload, including the original event id.
+	DeliveryId string `json:"deliveryId"`
+}
+
+// V2WebhooksUpdateEndpointRequestBody Fields that are omitted keep their current value.
+type V2WebhooksUpdateEndpointRequestBody struct {
+	// Description A free-form note to tell endpoints apart.
+	Description *string `json:"description,omitempty"`
+
+	// Enabled Set to false to stop delivering events to this endpoint. Pending retries fail on their next attempt.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// EndpointId The id of the webhook endpoint.
+	EndpointId string `json:"endpointId"`
+
+	// EventTypes The events to deliver to this endpoint.
+	EventTypes *[]WebhookEventType `json:"eventTypes,omitempty"`
+
+	// Url The HTTPS URL to deliver events to. The URL must resolve to a public address; redirects are not followed.
+	Url *string `json:"url,omitempty"`
+}
+
+// V2WebhooksUpdateEndpointResponseBody defines model for V2WebhooksUpdateEndpointResponseBody.
+type V2WebhooksUpdateEndpointResponseBody struct {
+	Data WebhookEndpoint `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
 // ValidationError Individual validation error details. Each validation error provides precise information about what failed, where it failed, and how to fix it, enabling efficient error resolution.
 type ValidationError struct {
 	// Fix A human-readable suggestion describing how to fix the error. This provides practical guidance on what changes would satisfy the validation requirements. Not all validation errors include fix suggestions, but when present, they offer specific remediation advice.
@@ -4562,6 +5791,94 @@ type VerifyKeyRatelimitData struct {
 	Reset int64 `json:"reset"`
 }
 
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	// Attempts How many times delivery was attempted.
+	Attempts int `json:"attempts"`
+
+	// CreatedAt Unix timestamp in milliseconds when the delivery was created.
+	CreatedAt int64 `json:"createdAt"`
+
+	// DeliveredAt Unix timestamp in milliseconds when the delivery succeeded.
+	DeliveredAt *int64 `json:"deliveredAt,omitempty"`
+
+	// DeliveryId The unique identifier of the delivery, sent as the `webhook-id` header. It stays the same across retries of one delivery.
+	DeliveryId string `json:"deliveryId"`
+
+	// EndpointId The endpoint the event was delivered to.
+	EndpointId string `json:"endpointId"`
+
+	// EventId The id of the event, sent as `id` in the request body. It is the same for every endpoint the event was delivered to and for replays, so receivers can use it to deduplicate.
+	EventId string `json:"eventId"`
+
+	// EventType An event a webhook endpoint can subscribe to.
+	//
+	// - `key.created`: a key was created.
+	// - `key.deleted`: a key was deleted.
+	// - `key.rerolled`: a key was rerolled into a new key.
+	// - `key.rotated`: a rotation policy issued a successor for a key.
+	// - `key.credits_exhausted`: a verification spent the last credit of a key.
+	// - `key.expired`: a key reached its expiry time.
+	// - `ratelimit.override.set`: a rate limit override was created or changed.
+	// - `ratelimit.override.deleted`: a rate limit override was deleted.
+	EventType WebhookEventType `json:"eventType"`
+
+	// LastError Why the last attempt failed.
+	LastError *string `json:"lastError,omitempty"`
+
+	// NextAttemptAt Unix timestamp in milliseconds of the next attempt while the delivery is pending.
+	NextAttemptAt *int64 `json:"nextAttemptAt,omitempty"`
+
+	// ResponseStatus The HTTP status of the last response, if the endpoint answered.
+	ResponseStatus *int `json:"responseStatus,omitempty"`
+
+	// Status - `pending`: not yet delivered, another attempt is scheduled.
+	// - `delivered`: the endpoint answered with a 2xx status.
+	// - `failed`: every attempt failed, or the endpoint was disabled.
+	Status WebhookDeliveryStatus `json:"status"`
+}
+
+// WebhookDeliveryStatus - `pending`: not yet delivered, another attempt is scheduled.
+// - `delivered`: the endpoint answered with a 2xx status.
+// - `failed`: every attempt failed, or the endpoint was disabled.
+type WebhookDeliveryStatus string
+
+// WebhookEndpoint defines model for WebhookEndpoint.
+type WebhookEndpoint struct {
+	// CreatedAt Unix timestamp in milliseconds when the endpoint was created.
+	CreatedAt int64 `json:"createdAt"`
+
+	// Description A free-form note to tell endpoints apart.
+	Description *string `json:"description,omitempty"`
+
+	// Enabled Whether events are delivered. Disabled endpoints receive nothing and pending retries to them fail.
+	Enabled bool `json:"enabled"`
+
+	// EndpointId The unique identifier of the webhook endpoint.
+	EndpointId string `json:"endpointId"`
+
+	// EventTypes The events delivered to this endpoint.
+	EventTypes []WebhookEventType `json:"eventTypes"`
+
+	// UpdatedAt Unix timestamp in milliseconds when the endpoint was last updated.
+	UpdatedAt *int64 `json:"updatedAt,omitempty"`
+
+	// Url The HTTPS URL events are delivered to.
+	Url string `json:"url"`
+}
+
+// WebhookEventType An event a webhook endpoint can subscribe to.
+//
+// - `key.created`: a key was created.
+// - `key.deleted`: a key was deleted.
+// - `key.rerolled`: a key was rerolled into a new key.
+// - `key.rotated`: a rotation policy issued a successor for a key.
+// - `key.credits_exhausted`: a verification spent the last credit of a key.
+// - `key.expired`: a key reached its expiry time.
+// - `ratelimit.override.set`: a rate limit override was created or changed.
+// - `ratelimit.override.deleted`: a rate limit override was deleted.
+type WebhookEventType string
+
 // AnalyticsGetGatewayRequestsJSONRequestBody defines body for AnalyticsGetGatewayRequests for application/json ContentType.
 type AnalyticsGetGatewayRequestsJSONRequestBody = V2AnalyticsGetGatewayRequestsRequestBody
 
@@ -4586,6 +5903,12 @@ type ApisGetApiJSONRequestBody = V2ApisGetApiRequestBody
 // ApisListKeysJSONRequestBody defines body for ApisListKeys for application/json ContentType.
 type ApisListKeysJSONRequestBody = V2ApisListKeysRequestBody
 
+// ApisUpdateEnvironmentsJSONRequestBody defines body for ApisUpdateEnvironments for application/json ContentType.
+type ApisUpdateEnvironmentsJSONRequestBody = V2ApisUpdateEnvironmentsRequestBody
+
+// ApisUpdateJwtAuthJSONRequestBody defines body for ApisUpdateJwtAuth for application/json ContentType.
+type ApisUpdateJwtAuthJSONRequestBody = V2ApisUpdateJwtAuthRequestBody
+
 // AppsCreateAppJSONRequestBody defines body for AppsCreateApp for application/json ContentType.
 type AppsCreateAppJSONRequestBody = V2AppsCreateAppRequestBody
 
@@ -4601,6 +5924,12 @@ type AppsListAppsJSONRequestBody = V2AppsListAppsRequestBody
 // AppsUpdateAppJSONRequestBody defines body for AppsUpdateApp for application/json ContentType.
 type AppsUpdateAppJSONRequestBody = V2AppsUpdateAppRequestBody
 
+// AuditlogsExportAuditLogsJSONRequestBody defines body for AuditlogsExportAuditLogs for application/json ContentType.
+type AuditlogsExportAuditLogsJSONRequestBody = V2AuditlogsExportAuditLogsRequestBody
+
+// AuditlogsListAuditLogsJSONRequestBody defines body for AuditlogsListAuditLogs for application/json ContentType.
+type AuditlogsListAuditLogsJSONRequestBody = V2AuditlogsListAuditLogsRequestBody
+
 // DeployCreateDeploymentJSONRequestBody defines body for DeployCreateDeployment for application/json ContentType.
 type DeployCreateDeploymentJSONRequestBody = V2DeployCreateDeploymentRequestBody
 
@@ -4643,6 +5972,9 @@ type DomainsListDomainsJSONRequestBody = V2DomainsListDomainsRequestBody
 // DomainsVerifyDomainJSONRequestBody defines body for DomainsVerifyDomain for application/json ContentType.
 type DomainsVerifyDomainJSONRequestBody = V2DomainsVerifyDomainRequestBody
 
+// EnvironmentsCreateVolumeJSONRequestBody defines body for EnvironmentsCreateVolume for application/json ContentType.
+type EnvironmentsCreateVolumeJSONRequestBody = V2EnvironmentsCreateVolumeRequestBody
+
 // EnvironmentsGetEnvironmentJSONRequestBody defines body for EnvironmentsGetEnvironment for application/json ContentType.
 type EnvironmentsGetEnvironmentJSONRequestBody = V2EnvironmentsGetEnvironmentRequestBody
 
@@ -4691,12 +6023,24 @@ type KeysAddPermissionsJSONRequestBody = V2KeysAddPermissionsRequestBody
 // KeysAddRolesJSONRequestBody defines body for KeysAddRoles for application/json ContentType.
 type KeysAddRolesJSONRequestBody = V2KeysAddRolesRequestBody
 
+// KeysBulkDeleteJSONRequestBody defines body for KeysBulkDelete for application/json ContentType.
+type KeysBulkDeleteJSONRequestBody = V2KeysBulkDeleteRequestBody
+
+// KeysBulkSetPermissionsJSONRequestBody defines body for KeysBulkSetPermissions for application/json ContentType.
+type KeysBulkSetPermissionsJSONRequestBody = V2KeysBulkSetPermissionsRequestBody
+
+// KeysBulkUpdateJSONRequestBody defines body for KeysBulkUpdate for application/json ContentType.
+type KeysBulkUpdateJSONRequestBody = V2KeysBulkUpdateRequestBody
+
 // KeysCreateKeyJSONRequestBody defines body for KeysCreateKey for application/json ContentType.
 type KeysCreateKeyJSONRequestBody = V2KeysCreateKeyRequestBody
 
 // KeysDeleteKeyJSONRequestBody defines body for KeysDeleteKey for application/json ContentType.
 type KeysDeleteKeyJSONRequestBody = V2KeysDeleteKeyRequestBody
 
+// KeysGetBulkOperationJSONRequestBody defines body for KeysGetBulkOperation for application/json ContentType.
+type KeysGetBulkOperationJSONRequestBody = V2KeysGetBulkOperationRequestBody
+
 // KeysGetKeyJSONRequestBody defines body for KeysGetKey for application/json ContentType.
 type KeysGetKeyJSONRequestBody = V2KeysGetKeyRequestBody
 
@@ -4757,12 +6101,24 @@ type PermissionsListRolesJSONRequestBody = V2PermissionsListRolesRequestBody
 // PermissionsSetRolePermissionsJSONRequestBody defines body for PermissionsSetRolePermissions for application/json ContentType.
 type PermissionsSetRolePermissionsJSONRequestBody = V2PermissionsSetRolePermissionsRequestBody
 
+// PortalCreateKeyJSONRequestBody defines body for PortalCreateKey for application/json ContentType.
+type PortalCreateKeyJSONRequestBody = V2PortalCreateKeyRequestBody
+
 // PortalCreateSessionJSONRequestBody defines body for PortalCreateSession for application/json ContentType.
 type PortalCreateSessionJSONRequestBody = V2PortalCreateSessionRequestBody
 
+// PortalDeleteKeyJSONRequestBody defines body for PortalDeleteKey for application/json ContentType.
+type PortalDeleteKeyJSONRequestBody = V2PortalDeleteKeyRequestBody
+
 // PortalExchangeCodeJSONRequestBody defines body for PortalExchangeCode for application/json ContentType.
 type PortalExchangeCodeJSONRequestBody = V2PortalExchangeCodeRequestBody
 
+// PortalGetCreditsJSONRequestBody defines body for PortalGetCredits for application/json ContentType.
+type PortalGetCreditsJSONRequestBody = V2PortalGetCreditsRequestBody
+
+// PortalGetRatelimitsJSONRequestBody defines body for PortalGetRatelimits for application/json ContentType.
+type PortalGetRatelimitsJSONRequestBody = V2PortalGetRatelimitsRequestBody
+
 // PortalGetVerificationsJSONRequestBody defines body for PortalGetVerifications for application/json ContentType.
 type PortalGetVerificationsJSONRequestBody = V2PortalGetVerificationsRequestBody
 
@@ -4772,6 +6128,9 @@ type PortalListKeysJSONRequestBody = V2PortalListKeysRequestBody
 // PortalRerollKeyJSONRequestBody defines body for PortalRerollKey for application/json ContentType.
 type PortalRerollKeyJSONRequestBody = V2KeysRerollKeyRequestBody
 
+// PortalUpdatePortalJSONRequestBody defines body for PortalUpdatePortal for application/json ContentType.
+type PortalUpdatePortalJSONRequestBody = V2PortalUpdatePortalRequestBody
+
 // ProjectsCreateProjectJSONRequestBody defines body for ProjectsCreateProject for application/json ContentType.
 type ProjectsCreateProjectJSONRequestBody = V2ProjectsCreateProjectRequestBody
 
@@ -4787,9 +6146,15 @@ type ProjectsListProjectsJSONRequestBody = V2ProjectsListProjectsRequestBody
 // ProjectsUpdateProjectJSONRequestBody defines body for ProjectsUpdateProject for application/json ContentType.
 type ProjectsUpdateProjectJSONRequestBody = V2ProjectsUpdateProjectRequestBody
 
+// RatelimitAcquireJSONRequestBody defines body for RatelimitAcquire for application/json ContentType.
+type RatelimitAcquireJSONRequestBody = V2RatelimitAcquireRequestBody
+
 // RatelimitDeleteOverrideJSONRequestBody defines body for RatelimitDeleteOverride for application/json ContentType.
 type RatelimitDeleteOverrideJSONRequestBody = V2RatelimitDeleteOverrideRequestBody
 
+// RatelimitDeletePlanJSONRequestBody defines body for RatelimitDeletePlan for application/json ContentType.
+type RatelimitDeletePlanJSONRequestBody = V2RatelimitDeletePlanRequestBody
+
 // RatelimitGetOverrideJSONRequestBody defines body for RatelimitGetOverride for application/json ContentType.
 type RatelimitGetOverrideJSONRequestBody = V2RatelimitGetOverrideRequestBody
 
@@ -4802,9 +6167,33 @@ type RatelimitListOverridesJSONRequestBody = V2RatelimitListOverridesRequestBody
 // RatelimitMultiLimitJSONRequestBody defines body for RatelimitMultiLimit for application/json ContentType.
 type RatelimitMultiLimitJSONRequestBody = V2RatelimitMultiLimitRequestBody
 
+// RatelimitReleaseJSONRequestBody defines body for RatelimitRelease for application/json ContentType.
+type RatelimitReleaseJSONRequestBody = V2RatelimitReleaseRequestBody
+
 // RatelimitSetOverrideJSONRequestBody defines body for RatelimitSetOverride for application/json ContentType.
 type RatelimitSetOverrideJSONRequestBody = V2RatelimitSetOverrideRequestBody
 
+// RatelimitSetPlanJSONRequestBody defines body for RatelimitSetPlan for application/json ContentType.
+type RatelimitSetPlanJSONRequestBody = V2RatelimitSetPlanRequestBody
+
+// WebhooksCreateEndpointJSONRequestBody defines body for WebhooksCreateEndpoint for application/json ContentType.
+type WebhooksCreateEndpointJSONRequestBody = V2WebhooksCreateEndpointRequestBody
+
+// WebhooksDeleteEndpointJSONRequestBody defines body for WebhooksDeleteEndpoint for application/json ContentType.
+type WebhooksDeleteEndpointJSONRequestBody = V2WebhooksDeleteEndpointRequestBody
+
+// WebhooksListDeliveriesJSONRequestBody defines body for WebhooksListDeliveries for application/json ContentType.
+type WebhooksListDeliveriesJSONRequestBody = V2WebhooksListDeliveriesRequestBody
+
+// WebhooksListEndpointsJSONRequestBody defines body for WebhooksListEndpoints for application/json ContentType.
+type WebhooksListEndpointsJSONRequestBody = V2WebhooksListEndpointsRequestBody
+
+// WebhooksReplayDeliveryJSONRequestBody defines body for WebhooksReplayDelivery for application/json ContentType.
+type WebhooksReplayDeliveryJSONRequestBody = V2WebhooksReplayDeliveryRequestBody
+
+// WebhooksUpdateEndpointJSONRequestBody defines body for WebhooksUpdateEndpoint for application/json ContentType.
+type WebhooksUpdateEndpointJSONRequestBody = V2WebhooksUpdateEndpointRequestBody
+
 // AsV2DeploymentsCreateDeploymentRequestBody0 returns the union data inside the V2DeploymentsCreateDeploymentRequestBody as a V2DeploymentsCreateDeploymentRequestBody0
 func (t V2DeploymentsCreateDeploymentRequestBody) AsV2DeploymentsCreateDeploymentRequestBody0() (V2DeploymentsCreateDeploymentRequestBody0, error) {
 	var body V2DeploymentsCreateDeploymentRequestBody0
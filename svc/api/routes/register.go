@@ -10,17 +10,33 @@ import (
 
 	pprofRoute "github.com/unkeyed/unkey/pkg/pprof"
 
+	v2RatelimitAcquire "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_acquire"
 	v2RatelimitDeleteOverride "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_delete_override"
+	v2RatelimitDeletePlan "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_delete_plan"
 	v2RatelimitGetOverride "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_get_override"
 	v2RatelimitLimit "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_limit"
 	v2RatelimitListOverrides "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_list_overrides"
 	v2RatelimitMultiLimit "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_multi_limit"
+	v2RatelimitRelease "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_release"
 	v2RatelimitSetOverride "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_set_override"
+	v2RatelimitSetPlan "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_set_plan"
+
+	v2AuditlogsExportAuditLogs "github.com/unkeyed/unkey/svc/api/routes/v2_auditlogs_export_audit_logs"
+	v2AuditlogsListAuditLogs "github.com/unkeyed/unkey/svc/api/routes/v2_auditlogs_list_audit_logs"
+
+	v2WebhooksCreateEndpoint "github.com/unkeyed/unkey/svc/api/routes/v2_webhooks_create_endpoint"
+	v2WebhooksDeleteEndpoint "github.com/unkeyed/unkey/svc/api/routes/v2_webhooks_delete_endpoint"
+	v2WebhooksListDeliveries "github.com/unkeyed/unkey/svc/api/routes/v2_webhooks_list_deliveries"
+	v2WebhooksListEndpoints "github.com/unkeyed/unkey/svc/api/routes/v2_webhooks_list_endpoints"
+	v2WebhooksReplayDelivery "github.com/unkeyed/unkey/svc/api/routes/v2_webhooks_replay_delivery"
+	v2WebhooksUpdateEndpoint "github.com/unkeyed/unkey/svc/api/routes/v2_webhooks_update_endpoint"
 
 	v2ApisCreateApi "github.com/unkeyed/unkey/svc/api/routes/v2_apis_create_api"
 	v2ApisDeleteApi "github.com/unkeyed/unkey/svc/api/routes/v2_apis_delete_api"
 	v2ApisGetApi "github.com/unkeyed/unkey/svc/api/routes/v2_apis_get_api"
 	v2ApisListKeys "github.com/unkeyed/unkey/svc/api/routes/v2_apis_list_keys"
+	v2ApisUpdateEnvironments "github.com/unkeyed/unkey/svc/api/routes/v2_apis_update_environments"
+	v2ApisUpdateJwtAuth "github.com/unkeyed/unkey/svc/api/routes/v2_apis_update_jwt_auth"
 
 	v2DeployCreateDeployment "github.com/unkeyed/unkey/svc/api/routes/v2_deploy_create_deployment"
 	v2DeployGetDeployment "github.com/unkeyed/unkey/svc/api/routes/v2_deploy_get_deployment"
@@ -50,8 +66,12 @@ import (
 
 	v2KeysAddPermissions "github.com/unkeyed/unkey/svc/api/routes/v2_keys_add_permissions"
 	v2KeysAddRoles "github.com/unkeyed/unkey/svc/api/routes/v2_keys_add_roles"
+	v2KeysBulkDelete "github.com/unkeyed/unkey/svc/api/routes/v2_keys_bulk_delete"
+	v2KeysBulkSetPermissions "github.com/unkeyed/unkey/svc/api/routes/v2_keys_bulk_set_permissions"
+	v2KeysBulkUpdate "github.com/unkeyed/unkey/svc/api/routes/v2_keys_bulk_update"
 	v2KeysCreateKey "github.com/unkeyed/unkey/svc/api/routes/v2_keys_create_key"
 	v2KeysDeleteKey "github.com/unkeyed/unkey/svc/api/routes/v2_keys_delete_key"
+	v2KeysGetBulkOperation "github.com/unkeyed/unkey/svc/api/routes/v2_keys_get_bulk_operation"
 	v2KeysGetKey "github.com/unkeyed/unkey/svc/api/routes/v2_keys_get_key"
 	v2KeysMigrateKeys "github.com/unkeyed/unkey/svc/api/routes/v2_keys_migrate_keys"
 	v2KeysRemovePermissions "github.com/unkeyed/unkey/svc/api/routes/v2_keys_remove_permissions"
@@ -69,11 +89,16 @@ import (
 	v2AnalyticsGetRuntimeLogs "github.com/unkeyed/unkey/svc/api/routes/v2_analytics_get_runtime_logs"
 	v2AnalyticsGetVerifications "github.com/unkeyed/unkey/svc/api/routes/v2_analytics_get_verifications"
 
+	v2PortalCreateKey "github.com/unkeyed/unkey/svc/api/routes/v2_portal_create_key"
 	v2PortalCreateSession "github.com/unkeyed/unkey/svc/api/routes/v2_portal_create_session"
+	v2PortalDeleteKey "github.com/unkeyed/unkey/svc/api/routes/v2_portal_delete_key"
 	v2PortalExchangeCode "github.com/unkeyed/unkey/svc/api/routes/v2_portal_exchange_code"
+	v2PortalGetCredits "github.com/unkeyed/unkey/svc/api/routes/v2_portal_get_credits"
+	v2PortalGetRatelimits "github.com/unkeyed/unkey/svc/api/routes/v2_portal_get_ratelimits"
 	v2PortalGetVerifications "github.com/unkeyed/unkey/svc/api/routes/v2_portal_get_verifications"
 	v2PortalListKeys "github.com/unkeyed/unkey/svc/api/routes/v2_portal_list_keys"
 	v2PortalRerollKey "github.com/unkeyed/unkey/svc/api/routes/v2_portal_reroll_key"
+	v2PortalUpdatePortal "github.com/unkeyed/unkey/svc/api/routes/v2_portal_update_portal"
 
 	v2AppsCreateApp "github.com/unkeyed/unkey/svc/api/routes/v2_apps_create_app"
 	v2AppsDeleteApp "github.com/unkeyed/unkey/svc/api/routes/v2_apps_delete_app"
@@ -85,6 +110,7 @@ import (
 	v2DomainsGetDomain "github.com/unkeyed/unkey/svc/api/routes/v2_domains_get_domain"
 	v2DomainsListDomains "github.com/unkeyed/unkey/svc/api/routes/v2_domains_list_domains"
 	v2DomainsVerifyDomain "github.com/unkeyed/unkey/svc/api/routes/v2_domains_verify_domain"
+	v2EnvironmentsCreateVolume "github.com/unkeyed/unkey/svc/api/routes/v2_environments_create_volume"
 	v2EnvironmentsGetEnvironment "github.com/unkeyed/unkey/svc/api/routes/v2_environments_get_environment"
 	v2EnvironmentsListEnvironmentVariables "github.com/unkeyed/unkey/svc/api/routes/v2_environments_list_environment_variables"
 	v2EnvironmentsListEnvironments "github.com/unkeyed/unkey/svc/api/routes/v2_environments_list_environments"
@@ -111,7 +137,8 @@ import (
 //
 // The function applies a default middleware stack to most routes: panic recovery,
 // observability (tracing), metrics collection to ClickHouse, structured logging,
-// error handling, a one-minute request timeout, and request validation. Internal
+// error handling, a one-minute request timeout, and request validation. Protected
+// routes additionally authenticate and honor the Idempotency-Key header. Internal
 // endpoints (pprof) use reduced middleware stacks appropriate to their
 // needs.
 //
@@ -130,6 +157,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 		LimitsCache: svc.Caches.WorkspaceLimits,
 		Ratelimit:   svc.Ratelimit,
 	})
+	withIdempotency := zen.WithIdempotency(svc.Idempotency, svc.IdempotencyTTL)
 	withPortalAuthentication := middleware.WithAuthentication(middleware.AuthenticationConfig{
 		Auth:        svc.PortalAuth,
 		Database:    svc.Database,
@@ -160,6 +188,39 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 		withAuthentication,
 	}
 
+	// Routes that change state take an Idempotency-Key so a retried request is
+	// not applied twice. Reads are safe to retry and skip the lookup.
+	idempotentMiddlewares := []zen.Middleware{
+		withPanicRecovery,
+		withObservability,
+		zen.WithSQLComment(),
+		withMetrics,
+		withLogging,
+		withErrorHandling,
+		withTimeout,
+		withValidation,
+		withAuthentication,
+		withIdempotency,
+	}
+
+	// Routes whose responses carry a secret store them vault-encrypted and
+	// replay them once. Without a vault they do not take an Idempotency-Key.
+	sealedIdempotentMiddlewares := protectedMiddlewares
+	if svc.SealedIdempotency != nil {
+		sealedIdempotentMiddlewares = []zen.Middleware{
+			withPanicRecovery,
+			withObservability,
+			zen.WithSQLComment(),
+			withMetrics,
+			withLogging,
+			withErrorHandling,
+			withTimeout,
+			withValidation,
+			withAuthentication,
+			zen.WithIdempotency(svc.SealedIdempotency, svc.IdempotencyTTL),
+		}
+	}
+
 	// Portal routes authenticate only portal-session cookies. They share the
 	// protected stack but swap in the portal-only authenticator.
 	portalMiddlewares := []zen.Middleware{
@@ -204,6 +265,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 			RatelimitEvents: svc.RatelimitEvents,
 			Ratelimit:       svc.Ratelimit,
 			NamespaceCache:  svc.Caches.RatelimitNamespace,
+			PlanCache:       svc.Caches.RatelimitPlan,
 			Auditlogs:       svc.Auditlogs,
 			TestMode:        srv.Flags().TestMode,
 		},
@@ -222,16 +284,37 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 		},
 	)
 
-	// v2/ratelimit.setOverride
+	// v2/ratelimit.acquire
 	srv.RegisterRoute(
 		protectedMiddlewares,
-		&v2RatelimitSetOverride.Handler{
+		&v2RatelimitAcquire.Handler{
 			DB:             svc.Database,
-			Auditlogs:      svc.Auditlogs,
+			Ratelimit:      svc.Ratelimit,
+			NamespaceCache: svc.Caches.RatelimitNamespace,
+		},
+	)
+
+	// v2/ratelimit.release
+	srv.RegisterRoute(
+		protectedMiddlewares,
+		&v2RatelimitRelease.Handler{
+			DB:             svc.Database,
+			Ratelimit:      svc.Ratelimit,
 			NamespaceCache: svc.Caches.RatelimitNamespace,
 		},
 	)
 
+	// v2/ratelimit.setOverride
+	srv.RegisterRoute(
+		idempotentMiddlewares,
+		&v2RatelimitSetOverride.Handler{
+			DB:        svc.Database,
+			Auditlogs: svc.Auditlogs,
+			Caches:    svc.Caches.Invalidations,
+			Webhooks:  svc.Webhooks,
+		},
+	)
+
 	// v2/ratelimit.getOverride
 	srv.RegisterRoute(
 		protectedMiddlewares,
@@ -243,11 +326,33 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/ratelimit.deleteOverride
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2RatelimitDeleteOverride.Handler{
 			DB:             svc.Database,
 			Auditlogs:      svc.Auditlogs,
 			NamespaceCache: svc.Caches.RatelimitNamespace,
+			Caches:         svc.Caches.Invalidations,
+			Webhooks:       svc.Webhooks,
+		},
+	)
+
+	// v2/ratelimit.setPlan
+	srv.RegisterRoute(
+		idempotentMiddlewares,
+		&v2RatelimitSetPlan.Handler{
+			DB:        svc.Database,
+			Auditlogs: svc.Auditlogs,
+			Caches:    svc.Caches.Invalidations,
+		},
+	)
+
+	// v2/ratelimit.deletePlan
+	srv.RegisterRoute(
+		idempotentMiddlewares,
+		&v2RatelimitDeletePlan.Handler{
+			DB:        svc.Database,
+			Auditlogs: svc.Auditlogs,
+			Caches:    svc.Caches.Invalidations,
 		},
 	)
 
@@ -260,26 +365,108 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 		},
 	)
 
+	// ---------------------------------------------------------------------------
+	// v2/webhooks
+
+	// v2/webhooks.createEndpoint
+	srv.RegisterRoute(
+		sealedIdempotentMiddlewares,
+		&v2WebhooksCreateEndpoint.Handler{
+			DB:        svc.Database,
+			Auditlogs: svc.Auditlogs,
+			Vault:     svc.Vault,
+		},
+	)
+
+	// v2/webhooks.listEndpoints
+	srv.RegisterRoute(
+		protectedMiddlewares,
+		&v2WebhooksListEndpoints.Handler{
+			DB: svc.Database,
+		},
+	)
+
+	// v2/webhooks.updateEndpoint
+	srv.RegisterRoute(
+		idempotentMiddlewares,
+		&v2WebhooksUpdateEndpoint.Handler{
+			DB:        svc.Database,
+			Auditlogs: svc.Auditlogs,
+		},
+	)
+
+	// v2/webhooks.deleteEndpoint
+	srv.RegisterRoute(
+		idempotentMiddlewares,
+		&v2WebhooksDeleteEndpoint.Handler{
+			DB:        svc.Database,
+			Auditlogs: svc.Auditlogs,
+		},
+	)
+
+	// v2/webhooks.listDeliveries
+	srv.RegisterRoute(
+		protectedMiddlewares,
+		&v2WebhooksListDeliveries.Handler{
+			DB: svc.Database,
+		},
+	)
+
+	// v2/webhooks.replayDelivery
+	srv.RegisterRoute(
+		idempotentMiddlewares,
+		&v2WebhooksReplayDelivery.Handler{
+			DB:        svc.Database,
+			Auditlogs: svc.Auditlogs,
+			Restate:   svc.Restate,
+		},
+	)
+
+	// ---------------------------------------------------------------------------
+	// v2/auditlogs
+
+	// v2/auditlogs.listAuditLogs
+	srv.RegisterRoute(
+		protectedMiddlewares,
+		&v2AuditlogsListAuditLogs.Handler{
+			ClickHouse:  svc.ClickHouse,
+			DB:          svc.Database,
+			LimitsCache: svc.Caches.WorkspaceLimits,
+		},
+	)
+
+	// v2/auditlogs.exportAuditLogs
+	srv.RegisterRoute(
+		protectedMiddlewares,
+		&v2AuditlogsExportAuditLogs.Handler{
+			ClickHouse:  svc.ClickHouse,
+			DB:          svc.Database,
+			LimitsCache: svc.Caches.WorkspaceLimits,
+		},
+	)
+
 	// ---------------------------------------------------------------------------
 	// v2/identities
 
 	// v2/identities.createIdentity
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2IdentitiesCreateIdentity.Handler{
 
 			DB:        svc.Database,
 			Auditlogs: svc.Auditlogs,
+			Caches:    svc.Caches.Invalidations,
 		},
 	)
 
 	// v2/identities.deleteIdentity
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2IdentitiesDeleteIdentity.Handler{
 
 			DB:        svc.Database,
 			Auditlogs: svc.Auditlogs,
+			Caches:    svc.Caches.Invalidations,
 		},
 	)
 
@@ -303,11 +490,13 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/identities.updateIdentity
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2IdentitiesUpdateIdentity.Handler{
 
-			DB:        svc.Database,
-			Auditlogs: svc.Auditlogs,
+			DB:           svc.Database,
+			Auditlogs:    svc.Auditlogs,
+			UsageLimiter: svc.UsageLimiter,
+			Caches:       svc.Caches.Invalidations,
 		},
 	)
 
@@ -316,7 +505,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/apis.createApi
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2ApisCreateApi.Handler{
 
 			DB:        svc.Database,
@@ -344,7 +533,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/apis.deleteApi
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2ApisDeleteApi.Handler{
 
 			DB:        svc.Database,
@@ -353,12 +542,32 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 		},
 	)
 
+	// v2/apis.updateEnvironments
+	srv.RegisterRoute(
+		idempotentMiddlewares,
+		&v2ApisUpdateEnvironments.Handler{
+			DB:        svc.Database,
+			Auditlogs: svc.Auditlogs,
+			Caches:    svc.Caches.Invalidations,
+		},
+	)
+
+	// v2/apis.updateJwtAuth
+	srv.RegisterRoute(
+		idempotentMiddlewares,
+		&v2ApisUpdateJwtAuth.Handler{
+			DB:        svc.Database,
+			Auditlogs: svc.Auditlogs,
+			Caches:    svc.Caches,
+		},
+	)
+
 	// ---------------------------------------------------------------------------
 	// v2/deployments
 
 	// v2/deployments.createDeployment
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2DeploymentsCreateDeployment.Handler{
 			DB:         svc.Database,
 			CtrlClient: svc.CtrlDeploymentClient,
@@ -383,7 +592,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/deployments.stopDeployment
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2DeploymentsStopDeployment.Handler{
 			DB:      svc.Database,
 			Restate: svc.Restate,
@@ -392,7 +601,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/deployments.startDeployment
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2DeploymentsStartDeployment.Handler{
 			DB:      svc.Database,
 			Restate: svc.Restate,
@@ -401,7 +610,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/deployments.promoteDeployment
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2DeploymentsPromoteDeployment.Handler{
 			DB:      svc.Database,
 			Restate: svc.Restate,
@@ -410,7 +619,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/deployments.rollbackDeployment
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2DeploymentsRollbackDeployment.Handler{
 			DB:      svc.Database,
 			Restate: svc.Restate,
@@ -419,7 +628,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/deploy.createDeployment (deprecated)
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2DeployCreateDeployment.Handler{
 			DB:         svc.Database,
 			CtrlClient: svc.CtrlDeploymentClient,
@@ -439,7 +648,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/permissions.createPermission
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2PermissionsCreatePermission.Handler{
 
 			DB:        svc.Database,
@@ -476,7 +685,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/permissions.deletePermission
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2PermissionsDeletePermission.Handler{
 
 			DB:        svc.Database,
@@ -486,7 +695,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/permissions.createRole
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2PermissionsCreateRole.Handler{
 
 			DB:        svc.Database,
@@ -505,7 +714,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/permissions.deleteRole
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2PermissionsDeleteRole.Handler{
 
 			DB:        svc.Database,
@@ -515,7 +724,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/permissions.setRolePermissions
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2PermissionsSetRolePermissions.Handler{
 			DB:        svc.Database,
 			Auditlogs: svc.Auditlogs,
@@ -538,7 +747,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/keys.migrateKeys
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2KeysMigrateKeys.Handler{
 
 			ApiCache:  svc.Caches.LiveApiByID,
@@ -547,49 +756,92 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 		},
 	)
 
-	// v2/keys.createKey
+	// v2/keys.bulkUpdate
+	srv.RegisterRoute(
+		idempotentMiddlewares,
+		&v2KeysBulkUpdate.Handler{
+			DB:       svc.Database,
+			Restate:  svc.Restate,
+			ApiCache: svc.Caches.LiveApiByID,
+		},
+	)
+
+	// v2/keys.bulkDelete
+	srv.RegisterRoute(
+		idempotentMiddlewares,
+		&v2KeysBulkDelete.Handler{
+			DB:       svc.Database,
+			Restate:  svc.Restate,
+			ApiCache: svc.Caches.LiveApiByID,
+		},
+	)
+
+	// v2/keys.bulkSetPermissions
+	srv.RegisterRoute(
+		idempotentMiddlewares,
+		&v2KeysBulkSetPermissions.Handler{
+			DB:        svc.Database,
+			Auditlogs: svc.Auditlogs,
+			Restate:   svc.Restate,
+			ApiCache:  svc.Caches.LiveApiByID,
+		},
+	)
+
+	// v2/keys.getBulkOperation
 	srv.RegisterRoute(
 		protectedMiddlewares,
+		&v2KeysGetBulkOperation.Handler{
+			DB: svc.Database,
+		},
+	)
+
+	// v2/keys.createKey
+	srv.RegisterRoute(
+		sealedIdempotentMiddlewares,
 		&v2KeysCreateKey.Handler{
 
 			DB:        svc.Database,
 			Keys:      svc.Keys,
 			Auditlogs: svc.Auditlogs,
 			Vault:     svc.Vault,
+			Webhooks:  svc.Webhooks,
 		},
 	)
 
 	// v2/keys.rerollKey
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		sealedIdempotentMiddlewares,
 		&v2KeysRerollKey.Handler{
 
 			DB:        svc.Database,
 			Keys:      svc.Keys,
 			Auditlogs: svc.Auditlogs,
 			Vault:     svc.Vault,
+			Webhooks:  svc.Webhooks,
 		},
 	)
 
 	// v2/keys.deleteKey
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2KeysDeleteKey.Handler{
-			KeyCache: svc.Caches.VerificationKeyByHash,
+			Caches: svc.Caches.Invalidations,
 
 			DB:        svc.Database,
 			Auditlogs: svc.Auditlogs,
+			Webhooks:  svc.Webhooks,
 		},
 	)
 
 	// v2/keys.updateKey
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2KeysUpdateKey.Handler{
 			DB:           svc.Database,
 			Auditlogs:    svc.Auditlogs,
-			KeyCache:     svc.Caches.VerificationKeyByHash,
+			Caches:       svc.Caches.Invalidations,
 			UsageLimiter: svc.UsageLimiter,
+			Webhooks:     svc.Webhooks,
 		},
 	)
 
@@ -617,78 +869,78 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/keys.updateCredits
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2KeysUpdateCredits.Handler{
 			DB:           svc.Database,
 			Auditlogs:    svc.Auditlogs,
-			KeyCache:     svc.Caches.VerificationKeyByHash,
+			Caches:       svc.Caches.Invalidations,
 			UsageLimiter: svc.UsageLimiter,
 		},
 	)
 
 	// v2/keys.setRoles
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2KeysSetRoles.Handler{
 
 			DB:        svc.Database,
 			Auditlogs: svc.Auditlogs,
-			KeyCache:  svc.Caches.VerificationKeyByHash,
+			Caches:    svc.Caches.Invalidations,
 		},
 	)
 
 	// v2/keys.setPermissions
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2KeysSetPermissions.Handler{
 
 			DB:        svc.Database,
 			Auditlogs: svc.Auditlogs,
-			KeyCache:  svc.Caches.VerificationKeyByHash,
+			Caches:    svc.Caches.Invalidations,
 		},
 	)
 
 	// v2/keys.addPermissions
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2KeysAddPermissions.Handler{
 
 			DB:        svc.Database,
 			Auditlogs: svc.Auditlogs,
-			KeyCache:  svc.Caches.VerificationKeyByHash,
+			Caches:    svc.Caches.Invalidations,
 		},
 	)
 
 	// v2/keys.addRoles
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2KeysAddRoles.Handler{
 
 			DB:        svc.Database,
 			Auditlogs: svc.Auditlogs,
-			KeyCache:  svc.Caches.VerificationKeyByHash,
+			Caches:    svc.Caches.Invalidations,
 		},
 	)
 
 	// v2/keys.removePermissions
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2KeysRemovePermissions.Handler{
 
 			DB:        svc.Database,
 			Auditlogs: svc.Auditlogs,
-			KeyCache:  svc.Caches.VerificationKeyByHash,
+			Caches:    svc.Caches.Invalidations,
 		},
 	)
 
 	// v2/keys.removeRoles
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2KeysRemoveRoles.Handler{
 
 			DB:        svc.Database,
 			Auditlogs: svc.Auditlogs,
-			KeyCache:  svc.Caches.VerificationKeyByHash,
+			Caches:    svc.Caches.Invalidations,
 		},
 	)
 
@@ -734,7 +986,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/portal.createSession
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		sealedIdempotentMiddlewares,
 		&v2PortalCreateSession.Handler{
 			DB:            svc.Database,
 			Auditlogs:     svc.Auditlogs,
@@ -742,6 +994,15 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 		},
 	)
 
+	// v2/portal.updatePortal
+	srv.RegisterRoute(
+		idempotentMiddlewares,
+		&v2PortalUpdatePortal.Handler{
+			DB:        svc.Database,
+			Auditlogs: svc.Auditlogs,
+		},
+	)
+
 	// v2/portal.exchangeCode
 	srv.RegisterRoute(
 		publicMiddlewares,
@@ -761,6 +1022,18 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 		v2PortalListKeys.New(svc.Database),
 	)
 
+	// v2/portal.createKey
+	srv.RegisterRoute(
+		portalMiddlewares,
+		v2PortalCreateKey.New(&v2KeysCreateKey.Handler{
+			DB:        svc.Database,
+			Keys:      svc.Keys,
+			Auditlogs: svc.Auditlogs,
+			Vault:     svc.Vault,
+			Webhooks:  svc.Webhooks,
+		}),
+	)
+
 	// v2/portal.rerollKey
 	srv.RegisterRoute(
 		portalMiddlewares,
@@ -769,9 +1042,29 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 			Keys:      svc.Keys,
 			Auditlogs: svc.Auditlogs,
 			Vault:     svc.Vault,
+			Webhooks:  svc.Webhooks,
+		}),
+	)
+
+	// v2/portal.deleteKey
+	srv.RegisterRoute(
+		portalMiddlewares,
+		v2PortalDeleteKey.New(&v2KeysDeleteKey.Handler{
+			DB:        svc.Database,
+			Auditlogs: svc.Auditlogs,
+			Caches:    svc.Caches.Invalidations,
+			Webhooks:  svc.Webhooks,
 		}),
 	)
 
+	// v2/portal.getCredits
+	srv.RegisterRoute(
+		portalMiddlewares,
+		&v2PortalGetCredits.Handler{
+			DB: svc.Database,
+		},
+	)
+
 	// v2/portal.getVerifications
 	srv.RegisterRoute(
 		portalMiddlewares,
@@ -782,9 +1075,19 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 		},
 	)
 
+	// v2/portal.getRatelimits
+	srv.RegisterRoute(
+		portalMiddlewares,
+		v2PortalGetRatelimits.New(&v2PortalGetVerifications.Handler{
+			ClickHouse:  svc.ClickHouse,
+			DB:          svc.Database,
+			LimitsCache: svc.Caches.WorkspaceLimits,
+		}),
+	)
+
 	// v2/projects.createProject
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2ProjectsCreateProject.Handler{
 			CtrlClient: svc.CtrlProjectClient,
 		},
@@ -808,7 +1111,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/projects.updateProject
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2ProjectsUpdateProject.Handler{
 			DB:        svc.Database,
 			Auditlogs: svc.Auditlogs,
@@ -817,7 +1120,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/projects.deleteProject
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2ProjectsDeleteProject.Handler{
 			DB:      svc.Database,
 			Restate: svc.Restate,
@@ -826,7 +1129,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/apps.createApp
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2AppsCreateApp.Handler{
 			DB:            svc.Database,
 			CtrlClient:    svc.CtrlAppClient,
@@ -838,7 +1141,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/github.installApp
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2GithubInstallApp.Handler{
 			DB:                  svc.Database,
 			GitHubAppName:       svc.GitHubAppName,
@@ -864,7 +1167,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/apps.updateApp
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2AppsUpdateApp.Handler{
 			DB:            svc.Database,
 			Auditlogs:     svc.Auditlogs,
@@ -875,7 +1178,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/apps.deleteApp
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2AppsDeleteApp.Handler{
 			DB:      svc.Database,
 			Restate: svc.Restate,
@@ -900,7 +1203,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/environments.updateSettings
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2EnvironmentsUpdateSettings.Handler{
 			DB:          svc.Database,
 			Auditlogs:   svc.Auditlogs,
@@ -910,7 +1213,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/environments.setEnvironmentVariables
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2EnvironmentsSetEnvironmentVariables.Handler{
 			DB:        svc.Database,
 			Vault:     svc.Vault,
@@ -920,7 +1223,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/environments.removeEnvironmentVariables
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2EnvironmentsRemoveEnvironmentVariables.Handler{
 			DB:        svc.Database,
 			Auditlogs: svc.Auditlogs,
@@ -936,9 +1239,19 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 		},
 	)
 
+	// v2/environments.createVolume
+	srv.RegisterRoute(
+		idempotentMiddlewares,
+		&v2EnvironmentsCreateVolume.Handler{
+			DB:          svc.Database,
+			Auditlogs:   svc.Auditlogs,
+			LimitsCache: svc.Caches.WorkspaceLimits,
+		},
+	)
+
 	// v2/domains.createDomain
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2DomainsCreateDomain.Handler{
 			DB:          svc.Database,
 			CtrlClient:  svc.CtrlCustomDomainClient,
@@ -964,7 +1277,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/domains.deleteDomain
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2DomainsDeleteDomain.Handler{
 			DB:         svc.Database,
 			CtrlClient: svc.CtrlCustomDomainClient,
@@ -973,7 +1286,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/domains.verifyDomain
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2DomainsVerifyDomain.Handler{
 			DB:         svc.Database,
 			CtrlClient: svc.CtrlCustomDomainClient,
@@ -982,7 +1295,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/gateway.setPolicies
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2GatewaySetPolicies.Handler{
 			DB:        svc.Database,
 			Auditlogs: svc.Auditlogs,
@@ -999,7 +1312,7 @@ func Register(srv *zen.Server, svc *Services, info zen.InstanceInfo) {
 
 	// v2/gateway.updatePolicy
 	srv.RegisterRoute(
-		protectedMiddlewares,
+		idempotentMiddlewares,
 		&v2GatewayUpdatePolicy.Handler{
 			DB:        svc.Database,
 			Auditlogs: svc.Auditlogs,
@@ -1,6 +1,8 @@
 package routes
 
 import (
+	"time"
+
 	restateingress "github.com/restatedev/sdk-go/ingress"
 	"github.com/unkeyed/unkey/gen/rpc/ctrl"
 	"github.com/unkeyed/unkey/gen/rpc/vault"
@@ -11,6 +13,7 @@ import (
 	"github.com/unkeyed/unkey/internal/services/ratelimit"
 
 	"github.com/unkeyed/unkey/internal/services/usagelimiter"
+	"github.com/unkeyed/unkey/internal/services/webhooks"
 	"github.com/unkeyed/unkey/pkg/auth"
 	"github.com/unkeyed/unkey/pkg/batch"
 	"github.com/unkeyed/unkey/pkg/clickhouse"
@@ -18,6 +21,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/db"
 	githubclient "github.com/unkeyed/unkey/pkg/github"
 	"github.com/unkeyed/unkey/pkg/redaction"
+	"github.com/unkeyed/unkey/pkg/zen"
 	"github.com/unkeyed/unkey/pkg/zen/validation"
 )
 
@@ -56,6 +60,18 @@ type Services struct {
 	// by the v2 keys.verifyKey handler.
 	KeyVerifications *batch.BatchProcessor[schema.KeyVerification]
 
+	// Idempotency stores responses for requests sent with an Idempotency-Key
+	// header so retries replay them instead of repeating the mutation.
+	Idempotency zen.IdempotencyStore
+
+	// SealedIdempotency does the same for routes whose responses carry a
+	// secret: it stores them vault-encrypted and replays them once. Nil when
+	// no vault is configured; those routes then ignore the header.
+	SealedIdempotency zen.IdempotencyStore
+
+	// IdempotencyTTL is how long a stored response is replayed.
+	IdempotencyTTL time.Duration
+
 	// Validator performs request payload validation using struct tags.
 	Validator *validation.Validator
 
@@ -95,6 +111,10 @@ type Services struct {
 	// Restate submits durable workflows through the Restate ingress.
 	Restate *restateingress.Client
 
+	// Webhooks emits outbound webhook events after mutations commit. It is a
+	// noop when Restate is not configured.
+	Webhooks webhooks.Service
+
 	// PprofEnabled controls whether pprof profiling endpoints are registered.
 	PprofEnabled bool
 
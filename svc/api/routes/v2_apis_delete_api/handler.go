@@ -153,6 +153,9 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return err
 	}
 
+	h.Caches.Invalidations.Invalidate(ctx, caches.ApiEntity(principal.WorkspaceID, req.ApiId))
+	// The api is gone for good, so this node can answer lookups for it from
+	// the cache instead of refetching the deleted row.
 	h.Caches.LiveApiByID.SetNull(ctx, cache.ScopedKey{WorkspaceID: principal.WorkspaceID, Key: req.ApiId})
 
 	return s.JSON(http.StatusOK, Response{
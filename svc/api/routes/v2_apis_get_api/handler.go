@@ -9,6 +9,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/db"
 	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/ptr"
 	"github.com/unkeyed/unkey/pkg/rbac"
 	"github.com/unkeyed/unkey/pkg/zen"
 	"github.com/unkeyed/unkey/svc/api/openapi"
@@ -95,13 +96,36 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		)
 	}
 
+	environments := []openapi.KeyEnvironment{}
+	if api.KeyAuthID.Valid {
+		rows, err := db.Query.ListKeySpaceEnvironments(ctx, h.DB.RO(), api.KeyAuthID.String)
+		if err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database error"), fault.Public("Failed to retrieve the API's environments."),
+			)
+		}
+		for _, row := range rows {
+			environment := openapi.KeyEnvironment{
+				Name:     row.Name,
+				Prefix:   nil,
+				TestMode: ptr.P(row.TestMode),
+			}
+			if row.Prefix.Valid {
+				environment.Prefix = ptr.P(row.Prefix.String)
+			}
+			environments = append(environments, environment)
+		}
+	}
+
 	return s.JSON(http.StatusOK, Response{
 		Meta: openapi.Meta{
 			RequestId: s.RequestID(),
 		},
 		Data: openapi.V2ApisGetApiResponseData{
-			Id:   api.ID,
-			Name: api.Name,
+			Id:           api.ID,
+			Name:         api.Name,
+			Environments: &environments,
 		},
 	})
 }
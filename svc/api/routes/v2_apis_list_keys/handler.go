@@ -318,6 +318,7 @@ func BuildKeyResponseData(keyData *db.KeyData, plaintext string) openapi.KeyResp
 		Identity:    nil,
 		Permissions: nil,
 		Roles:       nil,
+		Rotation:    nil,
 		Plaintext:   plaintext,
 		CreatedAt:   keyData.Key.CreatedAtM,
 		Enabled:     keyData.Key.Enabled,
@@ -359,6 +360,7 @@ func BuildKeyResponseData(keyData *db.KeyData, plaintext string) openapi.KeyResp
 			Ratelimits: nil,
 			Id:         keyData.Identity.ID,
 			ExternalId: keyData.Identity.ExternalID,
+			Credits:    nil,
 		}
 
 		identityMeta, err := db.UnmarshalNullableJSONTo[map[string]any](keyData.Identity.Meta)
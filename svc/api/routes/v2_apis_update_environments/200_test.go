@@ -0,0 +1,139 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_apis_update_environments"
+	verifykey "github.com/unkeyed/unkey/svc/api/routes/v2_keys_verify_key"
+)
+
+func TestSuccess(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.update_api")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+
+	t.Run("set test and live", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			ApiId: api.ID,
+			Environments: []openapi.KeyEnvironment{
+				{Name: "test", Prefix: ptr.P("sk_test"), TestMode: ptr.P(true)},
+				{Name: "live", Prefix: ptr.P("sk_live")},
+			},
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+
+		environments, err := db.Query.ListKeySpaceEnvironments(ctx, h.DB.RO(), api.KeyAuthID.String)
+		require.NoError(t, err)
+		require.Len(t, environments, 2)
+		require.Equal(t, "live", environments[0].Name)
+		require.Equal(t, "sk_live", environments[0].Prefix.String)
+		require.False(t, environments[0].TestMode)
+		require.Equal(t, "test", environments[1].Name)
+		require.Equal(t, "sk_test", environments[1].Prefix.String)
+		require.True(t, environments[1].TestMode)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			ApiId:        api.ID,
+			Environments: []openapi.KeyEnvironment{{Name: "sandbox", TestMode: ptr.P(true)}},
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+
+		environments, err := db.Query.ListKeySpaceEnvironments(ctx, h.DB.RO(), api.KeyAuthID.String)
+		require.NoError(t, err)
+		require.Len(t, environments, 1)
+		require.Equal(t, "sandbox", environments[0].Name)
+		require.False(t, environments[0].Prefix.Valid)
+	})
+
+	t.Run("remove all", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			ApiId:        api.ID,
+			Environments: []openapi.KeyEnvironment{},
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+
+		environments, err := db.Query.ListKeySpaceEnvironments(ctx, h.DB.RO(), api.KeyAuthID.String)
+		require.NoError(t, err)
+		require.Empty(t, environments)
+	})
+}
+
+func TestTestModeChangeReachesVerifications(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
+	}
+	h.Register(route)
+	verifyRoute := &verifykey.Handler{
+		DB:               h.DB,
+		Keys:             h.Keys,
+		Auditlogs:        h.Auditlogs,
+		KeyVerifications: h.KeyVerifications,
+	}
+	h.Register(verifyRoute)
+
+	workspace := h.Resources().UserWorkspace
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", h.CreateRootKey(workspace.ID, "api.*.update_api", "api.*.verify_key"))},
+	}
+
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	key := h.CreateKey(seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeySpaceID:  api.KeyAuthID.String,
+		Environment: ptr.P("staging"),
+		Remaining:   ptr.P(int64(10)),
+	})
+
+	verify := func(t *testing.T) int64 {
+		res := testutil.CallRoute[verifykey.Request, verifykey.Response](h, verifyRoute, headers, verifykey.Request{Key: key.Key})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Equal(t, openapi.VALID, res.Body.Data.Code)
+		require.NotNil(t, res.Body.Data.Credits)
+		return *res.Body.Data.Credits
+	}
+
+	// The key's environment has no row yet, so it runs live and spends a
+	// credit. This also caches the verification.
+	require.Equal(t, int64(9), verify(t))
+
+	res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+		ApiId:        api.ID,
+		Environments: []openapi.KeyEnvironment{{Name: "staging", TestMode: ptr.P(true)}},
+	})
+	require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+
+	// The cached verification was dropped, so the key now runs in test mode.
+	require.Equal(t, int64(9), verify(t))
+	require.Equal(t, int64(9), verify(t))
+}
@@ -0,0 +1,65 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_apis_update_environments"
+)
+
+func TestValidationErrors(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.update_api")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+
+	testCases := []struct {
+		name         string
+		environments []openapi.KeyEnvironment
+	}{
+		{
+			name:         "duplicate name",
+			environments: []openapi.KeyEnvironment{{Name: "test"}, {Name: "test"}},
+		},
+		{
+			name: "duplicate prefix",
+			environments: []openapi.KeyEnvironment{
+				{Name: "test", Prefix: ptr.P("sk")},
+				{Name: "live", Prefix: ptr.P("sk")},
+			},
+		},
+		{
+			name:         "invalid name",
+			environments: []openapi.KeyEnvironment{{Name: "not allowed"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, handler.Request{
+				ApiId:        api.ID,
+				Environments: tc.environments,
+			})
+			require.Equal(t, 400, res.Status, "expected 400, received: %#v", res)
+		})
+	}
+}
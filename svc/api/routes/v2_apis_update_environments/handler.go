@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	cachev1 "github.com/unkeyed/unkey/gen/proto/cache/v1"
+	"github.com/unkeyed/unkey/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/internal/services/caches"
+	"github.com/unkeyed/unkey/pkg/auditlog"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2ApisUpdateEnvironmentsRequestBody
+	Response = openapi.V2ApisUpdateEnvironmentsResponseBody
+)
+
+// Handler implements zen.Route interface for the v2 APIs update environments endpoint
+type Handler struct {
+	DB        db.Database
+	Auditlogs auditlogs.AuditLogService
+	Caches    *caches.Invalidator
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/apis.updateEnvironments"
+}
+
+// Handle replaces the environments of an api's keyspace. Keys keep the
+// environment they were created in; only what new keys can be created in,
+// and which environments run in test mode, changes.
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	if err := validateEnvironments(req.Environments); err != nil {
+		return err
+	}
+
+	err = principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Api,
+			ResourceID:   "*",
+			Action:       rbac.UpdateAPI,
+		}),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Api,
+			ResourceID:   req.ApiId,
+			Action:       rbac.UpdateAPI,
+		}),
+	))
+	if err != nil {
+		return err
+	}
+
+	api, err := db.Query.FindApiByID(ctx, h.DB.RO(), req.ApiId)
+	if err != nil {
+		if db.IsNotFound(err) {
+			return fault.New("api not found",
+				fault.Code(codes.Data.Api.NotFound.URN()),
+				fault.Internal("api not found"), fault.Public("The requested API does not exist or has been deleted."),
+			)
+		}
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"), fault.Public("Failed to retrieve API information."),
+		)
+	}
+
+	if api.WorkspaceID != principal.WorkspaceID || api.DeletedAtM.Valid {
+		return fault.New("api not found",
+			fault.Code(codes.Data.Api.NotFound.URN()),
+			fault.Internal("wrong workspace or deleted, masking as 404"), fault.Public("The requested API does not exist or has been deleted."),
+		)
+	}
+
+	if !api.KeyAuthID.Valid {
+		return fault.New("api has no keyspace",
+			fault.Code(codes.App.Precondition.PreconditionFailed.URN()),
+			fault.Internal("api has no key_auth_id"),
+			fault.Public("This API is not set up for key authentication, so it has no keys to separate into environments."),
+		)
+	}
+
+	now := time.Now().UnixMilli()
+	params := make([]db.InsertKeySpaceEnvironmentParams, len(req.Environments))
+	names := make([]string, len(req.Environments))
+	for i, environment := range req.Environments {
+		params[i] = db.InsertKeySpaceEnvironmentParams{
+			WorkspaceID: api.WorkspaceID,
+			KeyAuthID:   api.KeyAuthID.String,
+			Name:        environment.Name,
+			Prefix:      sql.NullString{String: ptr.SafeDeref(environment.Prefix), Valid: environment.Prefix != nil},
+			TestMode:    ptr.SafeDeref(environment.TestMode, false),
+			CreatedAtM:  now,
+		}
+		names[i] = environment.Name
+	}
+
+	var changed []sql.NullString
+	err = db.TxRetry(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) error {
+		previous, err := db.Query.ListKeySpaceEnvironments(ctx, tx, api.KeyAuthID.String)
+		if err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database error"), fault.Public("Failed to update the environments."),
+			)
+		}
+		changed = changedTestMode(previous, params)
+
+		err = db.Query.DeleteKeySpaceEnvironments(ctx, tx, api.KeyAuthID.String)
+		if err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database error"), fault.Public("Failed to update the environments."),
+			)
+		}
+
+		if len(params) > 0 {
+			err = db.BulkQuery.InsertKeySpaceEnvironments(ctx, tx, params)
+			if err != nil {
+				return fault.Wrap(err,
+					fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+					fault.Internal("database error"), fault.Public("Failed to update the environments."),
+				)
+			}
+		}
+
+		display := fmt.Sprintf("Removed all environments of API %s", api.ID)
+		if len(names) > 0 {
+			display = fmt.Sprintf("Set environments of API %s to %s", api.ID, strings.Join(names, ", "))
+		}
+
+		return h.Auditlogs.Insert(ctx, tx, []auditlog.AuditLog{{
+			WorkspaceID: principal.WorkspaceID,
+			Event:       auditlog.APIUpdateEvent,
+			ActorType:   auditlog.AuditLogActor(principal.Subject.Type),
+			ActorID:     principal.Subject.ID,
+			ActorName:   principal.Subject.Name,
+			ActorMeta:   map[string]any{},
+			Display:     display,
+			Resources: []auditlog.AuditLogResource{
+				{
+					Type:        auditlog.APIResourceType,
+					ID:          api.ID,
+					DisplayName: api.Name,
+					Name:        api.Name,
+					Meta:        map[string]any{"environments": names},
+				},
+			},
+			RemoteIP:      s.Location(),
+			UserAgent:     s.UserAgent(),
+			CorrelationID: "",
+		}})
+	})
+	if err != nil {
+		return err
+	}
+
+	h.invalidate(ctx, api, changed)
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.EmptyResponse{},
+	})
+}
+
+// changedTestMode returns the environments whose keys verify in a different
+// mode after the update. An environment without a row runs live, so adding or
+// removing one only matters when it runs in test mode.
+func changedTestMode(previous []db.KeySpaceEnvironment, next []db.InsertKeySpaceEnvironmentParams) []sql.NullString {
+	testMode := make(map[string]bool, len(previous))
+	for _, environment := range previous {
+		testMode[environment.Name] = environment.TestMode
+	}
+
+	var changed []sql.NullString
+	for _, environment := range next {
+		if testMode[environment.Name] != environment.TestMode {
+			changed = append(changed, sql.NullString{String: environment.Name, Valid: true})
+		}
+		delete(testMode, environment.Name)
+	}
+	for name, wasTestMode := range testMode {
+		if wasTestMode {
+			changed = append(changed, sql.NullString{String: name, Valid: true})
+		}
+	}
+	return changed
+}
+
+// invalidate drops the cached api and the cached verifications of every key in
+// an environment whose test mode changed, since verifications carry the mode
+// of the key's environment.
+func (h *Handler) invalidate(ctx context.Context, api db.Api, changed []sql.NullString) {
+	entities := []*cachev1.Entity{caches.ApiEntity(api.WorkspaceID, api.ID)}
+
+	if len(changed) > 0 {
+		keys, err := db.Query.ListLiveKeyHashesByKeySpaceEnvironments(ctx, h.DB.RW(), db.ListLiveKeyHashesByKeySpaceEnvironmentsParams{
+			KeyAuthID:    api.KeyAuthID.String,
+			Environments: changed,
+		})
+		if err != nil {
+			logger.Error("failed to list keys of changed environments",
+				"error", err.Error(),
+				"apiId", api.ID,
+			)
+		}
+		for _, key := range keys {
+			entities = append(entities, caches.KeyEntity(api.WorkspaceID, key.ID, key.Hash))
+		}
+	}
+
+	h.Caches.Invalidate(ctx, entities...)
+}
+
+// validateEnvironments checks what the schema cannot express: that names are
+// unique, and that so are prefixes, since telling keys of different
+// environments apart by their prefix is the point of setting one.
+func validateEnvironments(environments []openapi.KeyEnvironment) error {
+	names := make(map[string]struct{}, len(environments))
+	prefixes := make(map[string]struct{}, len(environments))
+	for _, environment := range environments {
+		if _, ok := names[environment.Name]; ok {
+			return fault.New("duplicate environment",
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Internal("duplicate environment name"),
+				fault.Public(fmt.Sprintf("Environment %q is listed more than once.", environment.Name)),
+			)
+		}
+		names[environment.Name] = struct{}{}
+
+		if environment.Prefix == nil {
+			continue
+		}
+		if _, ok := prefixes[*environment.Prefix]; ok {
+			return fault.New("duplicate environment prefix",
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Internal("duplicate environment prefix"),
+				fault.Public(fmt.Sprintf("Prefix %q is used by more than one environment.", *environment.Prefix)),
+			)
+		}
+		prefixes[*environment.Prefix] = struct{}{}
+	}
+
+	return nil
+}
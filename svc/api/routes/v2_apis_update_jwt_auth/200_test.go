@@ -0,0 +1,110 @@
+package handler_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/oapi-codegen/nullable"
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_apis_update_jwt_auth"
+)
+
+func TestSuccess(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.update_api")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+
+	t.Run("enable with a JWKS url", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			ApiId: api.ID,
+			Jwt: nullable.NewNullableWithValue(openapi.ApiJwtAuthConfig{
+				Issuer:           "https://auth.example.com/",
+				Audience:         ptr.P("https://api.example.com"),
+				JwksUrl:          ptr.P("https://auth.example.com/.well-known/jwks.json"),
+				PermissionsClaim: ptr.P("scope"),
+			}),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+
+		cfg, err := db.Query.FindApiJwtConfigByApiID(ctx, h.DB.RO(), api.ID)
+		require.NoError(t, err)
+		require.Equal(t, "https://auth.example.com/", cfg.Issuer)
+		require.Equal(t, "https://auth.example.com/.well-known/jwks.json", cfg.JwksUrl.String)
+		require.False(t, cfg.PublicKey.Valid)
+		require.Equal(t, "sub", cfg.IdentityClaim)
+		require.Equal(t, "scope", cfg.PermissionsClaim.String)
+
+		updated, err := db.Query.FindApiByID(ctx, h.DB.RO(), api.ID)
+		require.NoError(t, err)
+		require.Equal(t, db.ApisAuthTypeJwt, updated.AuthType.ApisAuthType)
+	})
+
+	t.Run("replace with a public key", func(t *testing.T) {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+		require.NoError(t, err)
+		publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			ApiId: api.ID,
+			Jwt: nullable.NewNullableWithValue(openapi.ApiJwtAuthConfig{
+				Issuer:        "https://other.example.com/",
+				PublicKey:     ptr.P(publicKey),
+				IdentityClaim: ptr.P("user_id"),
+			}),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+
+		cfg, err := db.Query.FindApiJwtConfigByApiID(ctx, h.DB.RO(), api.ID)
+		require.NoError(t, err)
+		require.Equal(t, "https://other.example.com/", cfg.Issuer)
+		require.False(t, cfg.JwksUrl.Valid)
+		require.Equal(t, publicKey, cfg.PublicKey.String)
+		require.Equal(t, "user_id", cfg.IdentityClaim)
+		require.False(t, cfg.Audience.Valid)
+		require.True(t, cfg.UpdatedAtM.Valid)
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			ApiId: api.ID,
+			Jwt:   nullable.NewNullNullable[openapi.ApiJwtAuthConfig](),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+
+		_, err := db.Query.FindApiJwtConfigByApiID(ctx, h.DB.RO(), api.ID)
+		require.True(t, db.IsNotFound(err))
+
+		updated, err := db.Query.FindApiByID(ctx, h.DB.RO(), api.ID)
+		require.NoError(t, err)
+		require.Equal(t, db.ApisAuthTypeKey, updated.AuthType.ApisAuthType)
+	})
+}
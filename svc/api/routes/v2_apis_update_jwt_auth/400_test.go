@@ -0,0 +1,91 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/oapi-codegen/nullable"
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_apis_update_jwt_auth"
+)
+
+func TestValidationErrors(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.update_api")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+
+	testCases := []struct {
+		name string
+		req  handler.Request
+	}{
+		{
+			name: "jwt omitted",
+			req:  handler.Request{ApiId: api.ID},
+		},
+		{
+			name: "no key source",
+			req: handler.Request{
+				ApiId: api.ID,
+				Jwt:   nullable.NewNullableWithValue(openapi.ApiJwtAuthConfig{Issuer: "https://auth.example.com/"}),
+			},
+		},
+		{
+			name: "both key sources",
+			req: handler.Request{
+				ApiId: api.ID,
+				Jwt: nullable.NewNullableWithValue(openapi.ApiJwtAuthConfig{
+					Issuer:    "https://auth.example.com/",
+					JwksUrl:   ptr.P("https://auth.example.com/.well-known/jwks.json"),
+					PublicKey: ptr.P("-----BEGIN PUBLIC KEY-----"),
+				}),
+			},
+		},
+		{
+			name: "invalid public key",
+			req: handler.Request{
+				ApiId: api.ID,
+				Jwt: nullable.NewNullableWithValue(openapi.ApiJwtAuthConfig{
+					Issuer:    "https://auth.example.com/",
+					PublicKey: ptr.P("not a key"),
+				}),
+			},
+		},
+		{
+			name: "plain http JWKS url",
+			req: handler.Request{
+				ApiId: api.ID,
+				Jwt: nullable.NewNullableWithValue(openapi.ApiJwtAuthConfig{
+					Issuer:  "https://auth.example.com/",
+					JwksUrl: ptr.P("http://auth.example.com/.well-known/jwks.json"),
+				}),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, tc.req)
+			require.Equal(t, 400, res.Status, "expected 400, received: %#v", res)
+			require.NotEmpty(t, res.Body.Meta.RequestId)
+		})
+	}
+}
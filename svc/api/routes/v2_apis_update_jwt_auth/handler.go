@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/unkeyed/unkey/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/internal/services/caches"
+	"github.com/unkeyed/unkey/pkg/auditlog"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/jwt"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2ApisUpdateJwtAuthRequestBody
+	Response = openapi.V2ApisUpdateJwtAuthResponseBody
+)
+
+// defaultIdentityClaim is the claim mapped to an identity's external id when
+// the request does not name one.
+const defaultIdentityClaim = "sub"
+
+// Handler implements zen.Route interface for the v2 APIs update JWT auth endpoint
+type Handler struct {
+	DB        db.Database
+	Auditlogs auditlogs.AuditLogService
+	Caches    caches.Caches
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/apis.updateJwtAuth"
+}
+
+// Handle enables, replaces or disables the JWT config of an api. Keys keep
+// working either way; only the api's auth type and JWT config change.
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	if !req.Jwt.IsSpecified() {
+		return fault.New("jwt not specified",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal("jwt field missing"),
+			fault.Public("`jwt` must be set to a configuration, or to null to disable JWT authentication."),
+		)
+	}
+
+	var cfg *openapi.ApiJwtAuthConfig
+	if !req.Jwt.IsNull() {
+		c := req.Jwt.MustGet()
+		cfg = &c
+		if err := validateConfig(cfg); err != nil {
+			return err
+		}
+	}
+
+	err = principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Api,
+			ResourceID:   "*",
+			Action:       rbac.UpdateAPI,
+		}),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Api,
+			ResourceID:   req.ApiId,
+			Action:       rbac.UpdateAPI,
+		}),
+	))
+	if err != nil {
+		return err
+	}
+
+	api, err := db.Query.FindApiByID(ctx, h.DB.RO(), req.ApiId)
+	if err != nil {
+		if db.IsNotFound(err) {
+			return fault.New("api not found",
+				fault.Code(codes.Data.Api.NotFound.URN()),
+				fault.Internal("api not found"), fault.Public("The requested API does not exist or has been deleted."),
+			)
+		}
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"), fault.Public("Failed to retrieve API information."),
+		)
+	}
+
+	if api.WorkspaceID != principal.WorkspaceID || api.DeletedAtM.Valid {
+		return fault.New("api not found",
+			fault.Code(codes.Data.Api.NotFound.URN()),
+			fault.Internal("wrong workspace or deleted, masking as 404"), fault.Public("The requested API does not exist or has been deleted."),
+		)
+	}
+
+	// Verified tokens are authorized and reported against the api's keyspace,
+	// like its keys are.
+	if cfg != nil && !api.KeyAuthID.Valid {
+		return fault.New("api has no keyspace",
+			fault.Code(codes.App.Precondition.PreconditionFailed.URN()),
+			fault.Internal("api has no key_auth_id"),
+			fault.Public("This API is not set up for key authentication, so it cannot accept JWTs either."),
+		)
+	}
+
+	previous, err := db.Query.FindApiJwtConfigByApiID(ctx, h.DB.RO(), api.ID)
+	if err != nil && !db.IsNotFound(err) {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"), fault.Public("Failed to retrieve the JWT configuration."),
+		)
+	}
+	hadConfig := err == nil
+
+	now := time.Now().UnixMilli()
+	err = db.TxRetry(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) error {
+		authType := db.ApisAuthTypeKey
+		display := fmt.Sprintf("Disabled JWT authentication for API %s", api.ID)
+		if cfg != nil {
+			authType = db.ApisAuthTypeJwt
+			display = fmt.Sprintf("Configured JWT authentication with issuer %s for API %s", cfg.Issuer, api.ID)
+
+			err = db.Query.UpsertApiJwtConfig(ctx, tx, db.UpsertApiJwtConfigParams{
+				ApiID:            api.ID,
+				WorkspaceID:      api.WorkspaceID,
+				Issuer:           cfg.Issuer,
+				Audience:         nullString(cfg.Audience),
+				JwksUrl:          nullString(cfg.JwksUrl),
+				PublicKey:        nullString(cfg.PublicKey),
+				IdentityClaim:    ptr.SafeDeref(cfg.IdentityClaim, defaultIdentityClaim),
+				PermissionsClaim: nullString(cfg.PermissionsClaim),
+				CreatedAtM:       now,
+			})
+		} else {
+			err = db.Query.DeleteApiJwtConfigByApiID(ctx, tx, api.ID)
+		}
+		if err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database error"), fault.Public("Failed to update the JWT configuration."),
+			)
+		}
+
+		err = db.Query.UpdateApiAuthType(ctx, tx, db.UpdateApiAuthTypeParams{
+			AuthType:   db.NullApisAuthType{ApisAuthType: authType, Valid: true},
+			UpdatedAtM: sql.NullInt64{Int64: now, Valid: true},
+			ApiID:      api.ID,
+		})
+		if err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database error"), fault.Public("Failed to update the API."),
+			)
+		}
+
+		return h.Auditlogs.Insert(ctx, tx, []auditlog.AuditLog{{
+			WorkspaceID: principal.WorkspaceID,
+			Event:       auditlog.APIUpdateEvent,
+			ActorType:   auditlog.AuditLogActor(principal.Subject.Type),
+			ActorID:     principal.Subject.ID,
+			ActorName:   principal.Subject.Name,
+			ActorMeta:   map[string]any{},
+			Display:     display,
+			Resources: []auditlog.AuditLogResource{
+				{
+					Type:        auditlog.APIResourceType,
+					ID:          api.ID,
+					DisplayName: api.Name,
+					Name:        api.Name,
+					Meta:        map[string]any{"authType": string(authType)},
+				},
+			},
+			RemoteIP:      s.Location(),
+			UserAgent:     s.UserAgent(),
+			CorrelationID: "",
+		}})
+	})
+	if err != nil {
+		return err
+	}
+
+	// Verifications find configs by issuer, so both the issuer the api used to
+	// trust and the one it trusts now must be refetched.
+	issuers := []string{}
+	if hadConfig {
+		issuers = append(issuers, previous.Issuer)
+	}
+	if cfg != nil {
+		issuers = append(issuers, cfg.Issuer)
+	}
+	h.Caches.Invalidations.Invalidate(ctx, caches.ApiEntity(api.WorkspaceID, api.ID, issuers...))
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.EmptyResponse{},
+	})
+}
+
+// validateConfig checks what the schema cannot express: that exactly one key
+// source is set, and that a static public key is one tokens can be verified
+// with.
+func validateConfig(cfg *openapi.ApiJwtAuthConfig) error {
+	hasJwks := cfg.JwksUrl != nil && *cfg.JwksUrl != ""
+	hasPublicKey := cfg.PublicKey != nil && *cfg.PublicKey != ""
+	if hasJwks == hasPublicKey {
+		return fault.New("invalid key source",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal("exactly one of jwksUrl and publicKey must be set"),
+			fault.Public("Set exactly one of `jwt.jwksUrl` and `jwt.publicKey`."),
+		)
+	}
+
+	if hasPublicKey {
+		if _, err := jwt.PublicKeyAlgorithm(*cfg.PublicKey); err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Internal("invalid public key"),
+				fault.Public("`jwt.publicKey` must be a PEM-encoded RSA or P-256 EC public key."),
+			)
+		}
+	}
+
+	return nil
+}
+
+func nullString(s *string) sql.NullString {
+	if s == nil || *s == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
@@ -0,0 +1,126 @@
+package handler_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/clickhouse/schema"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/api/internal/auditquery"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_auditlogs_export_audit_logs"
+)
+
+// export calls the route and splits the stream into its lines.
+func export(t *testing.T, h *testutil.Harness, route *handler.Handler, rootKey string, req handler.Request) (int, []json.RawMessage) {
+	t.Helper()
+
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	httpReq := httptest.NewRequest(route.Method(), route.Path(), bytes.NewReader(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+rootKey)
+
+	rr := httptest.NewRecorder()
+	h.Mux().ServeHTTP(rr, httpReq)
+	if rr.Code != http.StatusOK {
+		return rr.Code, nil
+	}
+	require.Equal(t, handler.ContentType, rr.Header().Get("Content-Type"))
+
+	lines := []json.RawMessage{}
+	scanner := bufio.NewScanner(strings.NewReader(rr.Body.String()))
+	for scanner.Scan() {
+		lines = append(lines, json.RawMessage(scanner.Text()))
+	}
+	require.NoError(t, scanner.Err())
+	return rr.Code, lines
+}
+
+func TestExportAuditLogs(t *testing.T) {
+	h := testutil.NewHarness(t, testutil.HarnessConfig{ClickHouse: true})
+
+	route := &handler.Handler{
+		ClickHouse:  h.ClickHouse,
+		DB:          h.DB,
+		LimitsCache: h.Caches.WorkspaceLimits,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "workspace.*.read_audit_log")
+
+	now := time.Now().UnixMilli()
+	rows := make([]schema.AuditLogV1, 0, 5)
+	for i := range 5 {
+		rows = append(rows, schema.AuditLogV1{
+			EventID:       uid.New(uid.AuditLogPrefix),
+			Time:          now - int64(i)*1000,
+			InsertedAt:    now,
+			WorkspaceID:   workspace.ID,
+			Bucket:        auditquery.Bucket,
+			Source:        "platform",
+			Event:         "key.create",
+			Description:   "test event",
+			ActorType:     "rootkey",
+			ActorID:       "key_actor",
+			ActorName:     "",
+			ActorMeta:     json.RawMessage(`{}`),
+			RemoteIP:      "127.0.0.1",
+			UserAgent:     "test",
+			Meta:          json.RawMessage(`{}`),
+			TargetTypes:   []string{"key"},
+			TargetIDs:     []string{"key_target"},
+			TargetNames:   []string{""},
+			TargetMetas:   []json.RawMessage{json.RawMessage(`{}`)},
+			CorrelationID: "",
+		})
+	}
+	require.NoError(t, h.ClickHouse.InsertAuditLogs(context.Background(), rows))
+
+	t.Run("streams every event", func(t *testing.T) {
+		status, lines := export(t, h, route, rootKey, handler.Request{})
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, lines, len(rows))
+
+		for i, line := range lines {
+			var log openapi.AuditLog
+			require.NoError(t, json.Unmarshal(line, &log))
+			require.Equal(t, rows[i].EventID, log.EventId)
+		}
+	})
+
+	t.Run("ends with a cursor when more events match", func(t *testing.T) {
+		status, lines := export(t, h, route, rootKey, handler.Request{Limit: ptr.P(3)})
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, lines, 4)
+
+		var last handler.CursorLine
+		require.NoError(t, json.Unmarshal(lines[3], &last))
+		require.NotEmpty(t, last.Cursor)
+
+		status, rest := export(t, h, route, rootKey, handler.Request{Cursor: ptr.P(last.Cursor)})
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, rest, 2)
+
+		var log openapi.AuditLog
+		require.NoError(t, json.Unmarshal(rest[0], &log))
+		require.Equal(t, rows[3].EventID, log.EventId)
+	})
+
+	t.Run("requires permission", func(t *testing.T) {
+		status, _ := export(t, h, route, h.CreateRootKey(workspace.ID, "api.*.read_api"), handler.Request{})
+		require.Equal(t, http.StatusForbidden, status)
+	})
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/clickhouse"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/rbac/permissions"
+	"github.com/unkeyed/unkey/pkg/urn"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/auditquery"
+	"github.com/unkeyed/unkey/svc/api/internal/pagination"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+// ContentType is the media type of the export stream.
+const ContentType = "application/x-ndjson"
+
+// pageSize is how many events are read from ClickHouse per query. The stream
+// is flushed after every page so clients see events while the export runs.
+const pageSize = 1_000
+
+type Request = openapi.V2AuditlogsExportAuditLogsRequestBody
+
+// CursorLine ends the stream when more events match than the request's limit.
+type CursorLine struct {
+	Cursor string `json:"cursor"`
+}
+
+// ErrorLine ends the stream when reading events fails after the response has
+// started and an error status can no longer be sent.
+type ErrorLine struct {
+	Error ErrorLineDetail `json:"error"`
+}
+
+// ErrorLineDetail describes the failure that cut an export short.
+type ErrorLineDetail struct {
+	Detail    string `json:"detail"`
+	RequestID string `json:"requestId"`
+}
+
+type Handler struct {
+	ClickHouse  clickhouse.ClickHouse
+	DB          db.Database
+	LimitsCache cache.Cache[string, keysdb.Limit]
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/auditlogs.exportAuditLogs"
+}
+
+// Handle streams the workspace's audit logs as newline-delimited JSON, newest
+// first. The first page is read before anything is written, so invalid
+// filters and an unavailable ClickHouse still produce a regular error
+// response.
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	err = principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Workspace,
+			ResourceID:   "*",
+			Action:       rbac.ReadAuditLog,
+		}),
+		rbac.U(
+			urn.New().Workspace(principal.WorkspaceID).AuditLogs(),
+			permissions.ReadAuditLog{},
+		),
+	))
+	if err != nil {
+		return err
+	}
+
+	retentionDays, err := auditquery.RetentionDays(ctx, h.LimitsCache, h.DB, principal.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	p := pagination.Parse(req.Limit, req.Cursor, 10_000)
+
+	query, err := auditquery.Request(principal.WorkspaceID, auditquery.Filter{
+		Start:         req.Start,
+		End:           req.End,
+		Events:        req.Events,
+		ActorIDs:      req.ActorIds,
+		TargetIDs:     req.TargetIds,
+		CorrelationID: req.CorrelationId,
+		Cursor:        req.Cursor,
+	}, retentionDays, time.Now(), 0)
+	if err != nil {
+		return err
+	}
+
+	remaining := p.Limit
+	// Every page over-fetches one event: it tells whether more events match
+	// and is where the next page, or the client's next export, resumes.
+	query.Limit = min(pageSize, remaining) + 1
+	rows, err := h.ClickHouse.ListAuditLogs(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	w := s.ResponseWriter()
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(http.StatusOK)
+
+	// Keep a bounded copy of the stream for request logging, like the proxy
+	// routes do for bodies that bypass Session.send.
+	var captured bytes.Buffer
+	defer func() { s.SetResponseBody(captured.Bytes()) }()
+	enc := json.NewEncoder(io.MultiWriter(w, &zen.LimitedWriter{W: &captured, N: zen.MaxBodyCapture}))
+
+	for {
+		want := min(pageSize, remaining)
+		var next *clickhouse.AuditLog
+		if len(rows) > want {
+			next = &rows[want]
+			rows = rows[:want]
+		}
+
+		for _, row := range rows {
+			log, err := auditquery.Log(row)
+			if err != nil {
+				return h.abort(enc, s, err)
+			}
+			if err := enc.Encode(log); err != nil {
+				// The client went away; there is no one left to tell.
+				return nil
+			}
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+
+		remaining -= len(rows)
+		if next == nil {
+			return nil
+		}
+		if remaining == 0 {
+			_ = enc.Encode(CursorLine{Cursor: auditquery.Cursor(*next)})
+			return nil
+		}
+
+		query.Cursor = &clickhouse.AuditLogCursor{Time: next.Time, EventID: next.EventID}
+		query.Limit = min(pageSize, remaining) + 1
+		rows, err = h.ClickHouse.ListAuditLogs(ctx, query)
+		if err != nil {
+			return h.abort(enc, s, err)
+		}
+	}
+}
+
+// abort ends a stream that has already started with an [ErrorLine]. The
+// status line is gone by now, so the error is logged here instead of being
+// returned to the error middleware.
+func (h *Handler) abort(enc *json.Encoder, s *zen.Session, err error) error {
+	logger.Error("audit log export failed mid-stream",
+		"requestId", s.RequestID(),
+		"error", err.Error(),
+	)
+	_ = enc.Encode(ErrorLine{Error: ErrorLineDetail{
+		Detail:    "Failed to export audit logs, please try again.",
+		RequestID: s.RequestID(),
+	}})
+	return nil
+}
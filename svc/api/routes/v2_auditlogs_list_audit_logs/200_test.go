@@ -0,0 +1,150 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/clickhouse/schema"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/api/internal/auditquery"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_auditlogs_list_audit_logs"
+)
+
+func authHeaders(rootKey string) http.Header {
+	return http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+}
+
+func newHandler(h *testutil.Harness) *handler.Handler {
+	return &handler.Handler{
+		ClickHouse:  h.ClickHouse,
+		DB:          h.DB,
+		LimitsCache: h.Caches.WorkspaceLimits,
+	}
+}
+
+// seedAuditLog writes one platform audit log straight to ClickHouse.
+func seedAuditLog(t *testing.T, h *testutil.Harness, workspaceID, event, actorID, targetID string, at int64) schema.AuditLogV1 {
+	t.Helper()
+
+	row := schema.AuditLogV1{
+		EventID:       uid.New(uid.AuditLogPrefix),
+		Time:          at,
+		InsertedAt:    time.Now().UnixMilli(),
+		WorkspaceID:   workspaceID,
+		Bucket:        auditquery.Bucket,
+		Source:        "platform",
+		Event:         event,
+		Description:   "test event",
+		ActorType:     "rootkey",
+		ActorID:       actorID,
+		ActorName:     "",
+		ActorMeta:     json.RawMessage(`{}`),
+		RemoteIP:      "127.0.0.1",
+		UserAgent:     "test",
+		Meta:          json.RawMessage(`{"source":"test"}`),
+		TargetTypes:   []string{"key"},
+		TargetIDs:     []string{targetID},
+		TargetNames:   []string{""},
+		TargetMetas:   []json.RawMessage{json.RawMessage(`{}`)},
+		CorrelationID: "",
+	}
+	require.NoError(t, h.ClickHouse.InsertAuditLogs(context.Background(), []schema.AuditLogV1{row}))
+	return row
+}
+
+func TestListAuditLogsSuccessfully(t *testing.T) {
+	h := testutil.NewHarness(t, testutil.HarnessConfig{ClickHouse: true})
+
+	route := newHandler(h)
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	headers := authHeaders(h.CreateRootKey(workspace.ID, "workspace.*.read_audit_log"))
+
+	now := time.Now().UnixMilli()
+	ids := []string{}
+	for i := range 5 {
+		ids = append(ids, seedAuditLog(t, h, workspace.ID, "key.create", "key_actor_a", "key_target_a", now-int64(5-i)*1000).EventID)
+	}
+	deleted := seedAuditLog(t, h, workspace.ID, "key.delete", "key_actor_b", "key_target_b", now-10_000)
+
+	// Events of another workspace must not leak into the listing.
+	seedAuditLog(t, h, h.CreateWorkspace().ID, "key.create", "key_actor_a", "key_target_a", now)
+
+	t.Run("newest first across pages", func(t *testing.T) {
+		got := []string{}
+		var cursor *string
+		for {
+			res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+				Events: ptr.P([]string{"key.create"}),
+				Limit:  ptr.P(2),
+				Cursor: cursor,
+			})
+			require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+
+			for _, log := range res.Body.Data {
+				require.Equal(t, "key.create", log.Event)
+				require.Equal(t, "test", log.Meta["source"])
+				got = append(got, log.EventId)
+			}
+
+			if !res.Body.Pagination.HasMore {
+				break
+			}
+			cursor = res.Body.Pagination.Cursor
+		}
+
+		require.Len(t, got, len(ids))
+		for i := range ids {
+			require.Equal(t, ids[len(ids)-1-i], got[i])
+		}
+	})
+
+	t.Run("filters by actor", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			ActorIds: ptr.P([]string{"key_actor_b"}),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.Len(t, res.Body.Data, 1)
+		require.Equal(t, deleted.EventID, res.Body.Data[0].EventId)
+	})
+
+	t.Run("filters by target", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			TargetIds: ptr.P([]string{"key_target_b"}),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.Len(t, res.Body.Data, 1)
+		require.Equal(t, "key_target_b", res.Body.Data[0].Targets[0].Id)
+	})
+
+	t.Run("filters by time range", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Start: ptr.P(now - 3_000),
+			End:   ptr.P(now - 2_000),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.Len(t, res.Body.Data, 2)
+	})
+
+	t.Run("ignores events outside the retention window", func(t *testing.T) {
+		old := seedAuditLog(t, h, workspace.ID, "api.create", "key_actor_c", "api_target_c", now-int64(400*24*time.Hour/time.Millisecond))
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			ActorIds: ptr.P([]string{"key_actor_c"}),
+			Start:    ptr.P(old.Time - 1),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.Empty(t, res.Body.Data)
+	})
+}
@@ -0,0 +1,50 @@
+package handler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_auditlogs_list_audit_logs"
+)
+
+func TestListAuditLogsBadRequest(t *testing.T) {
+	h := testutil.NewHarness(t, testutil.HarnessConfig{ClickHouse: true})
+
+	route := newHandler(h)
+	h.Register(route)
+
+	headers := authHeaders(h.CreateRootKey(h.Resources().UserWorkspace.ID, "workspace.*.read_audit_log"))
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, handler.Request{
+			Cursor: ptr.P("not-a-cursor"),
+		})
+		require.Equal(t, 400, res.Status, "expected 400, received: %s", res.RawBody)
+	})
+
+	t.Run("end before start", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, handler.Request{
+			Start: ptr.P(int64(2_000)),
+			End:   ptr.P(int64(1_000)),
+		})
+		require.Equal(t, 400, res.Status, "expected 400, received: %s", res.RawBody)
+	})
+
+	t.Run("window outside retention", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, handler.Request{
+			End: ptr.P(time.Now().Add(-400 * 24 * time.Hour).UnixMilli()),
+		})
+		require.Equal(t, 400, res.Status, "expected 400, received: %s", res.RawBody)
+	})
+
+	t.Run("limit out of range", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, handler.Request{
+			Limit: ptr.P(1001),
+		})
+		require.Equal(t, 400, res.Status, "expected 400, received: %s", res.RawBody)
+	})
+}
@@ -0,0 +1,22 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_auditlogs_list_audit_logs"
+)
+
+func TestListAuditLogsForbidden(t *testing.T) {
+	h := testutil.NewHarness(t, testutil.HarnessConfig{ClickHouse: true})
+
+	route := newHandler(h)
+	h.Register(route)
+
+	headers := authHeaders(h.CreateRootKey(h.Resources().UserWorkspace.ID, "api.*.read_api"))
+
+	res := testutil.CallRoute[handler.Request, openapi.ForbiddenErrorResponse](h, route, headers, handler.Request{})
+	require.Equal(t, 403, res.Status, "expected 403, received: %s", res.RawBody)
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/clickhouse"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/rbac/permissions"
+	"github.com/unkeyed/unkey/pkg/urn"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/auditquery"
+	"github.com/unkeyed/unkey/svc/api/internal/pagination"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2AuditlogsListAuditLogsRequestBody
+	Response = openapi.V2AuditlogsListAuditLogsResponseBody
+)
+
+type Handler struct {
+	ClickHouse  clickhouse.ClickHouse
+	DB          db.Database
+	LimitsCache cache.Cache[string, keysdb.Limit]
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/auditlogs.listAuditLogs"
+}
+
+// Handle lists the workspace's audit logs newest first. The cursor is the
+// time and event id of the first event on the next page.
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	err = principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Workspace,
+			ResourceID:   "*",
+			Action:       rbac.ReadAuditLog,
+		}),
+		rbac.U(
+			urn.New().Workspace(principal.WorkspaceID).AuditLogs(),
+			permissions.ReadAuditLog{},
+		),
+	))
+	if err != nil {
+		return err
+	}
+
+	retentionDays, err := auditquery.RetentionDays(ctx, h.LimitsCache, h.DB, principal.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	p := pagination.Parse(req.Limit, req.Cursor, 100)
+
+	query, err := auditquery.Request(principal.WorkspaceID, auditquery.Filter{
+		Start:         req.Start,
+		End:           req.End,
+		Events:        req.Events,
+		ActorIDs:      req.ActorIds,
+		TargetIDs:     req.TargetIds,
+		CorrelationID: req.CorrelationId,
+		Cursor:        req.Cursor,
+	}, retentionDays, time.Now(), int(p.FetchLimit()))
+	if err != nil {
+		return err
+	}
+
+	rows, err := h.ClickHouse.ListAuditLogs(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	rows, pg := pagination.Paginate(rows, p, auditquery.Cursor)
+
+	data := make([]openapi.AuditLog, 0, len(rows))
+	for _, row := range rows {
+		log, err := auditquery.Log(row)
+		if err != nil {
+			return err
+		}
+		data = append(data, log)
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data:       data,
+		Pagination: pg,
+	})
+}
@@ -0,0 +1,87 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_environments_create_volume"
+)
+
+func TestCreateVolumeSuccessfully(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{DB: h.DB, Auditlogs: h.Auditlogs, LimitsCache: h.Caches.WorkspaceLimits}
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "environment.*.update_environment")
+	headers := authHeaders(rootKey)
+
+	call := func(t *testing.T, req handler.Request) *handler.Response {
+		t.Helper()
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.NotEmpty(t, res.Body.Meta.RequestId)
+		return res.Body
+	}
+
+	t.Run("creates an empty volume", func(t *testing.T) {
+		env := seedEnvironment(t, h)
+
+		res := call(t, makeRequest(env, "data", "/var/lib/app", 1024))
+		require.NotEmpty(t, res.Data.VolumeId)
+		require.False(t, res.Data.Reattached)
+
+		got := findVolume(t, h, env, "data")
+		require.Equal(t, res.Data.VolumeId, got.ID)
+		require.Equal(t, db.VolumesStatusActive, got.Status)
+		require.Equal(t, "/var/lib/app", got.MountPath)
+		require.Equal(t, uint32(1024), got.SizeMib)
+		require.NotEmpty(t, got.K8sName)
+	})
+
+	t.Run("reattaches a released volume", func(t *testing.T) {
+		env := seedEnvironment(t, h)
+		released := seedVolume(t, h, env, "data", 1024, db.VolumesStatusReleased)
+
+		res := call(t, makeRequest(env, "data", "/srv/data", 2048))
+		require.Equal(t, released.ID, res.Data.VolumeId)
+		require.True(t, res.Data.Reattached)
+
+		got := findVolume(t, h, env, "data")
+		require.Equal(t, db.VolumesStatusActive, got.Status)
+		require.False(t, got.ReleasedAt.Valid)
+		require.Equal(t, released.K8sName, got.K8sName, "the claim holding the data must be kept")
+		require.Equal(t, "/srv/data", got.MountPath)
+		require.Equal(t, uint32(2048), got.SizeMib)
+	})
+
+	t.Run("replaces a purged volume with a new one", func(t *testing.T) {
+		env := seedEnvironment(t, h)
+		purged := seedVolume(t, h, env, "data", 1024, db.VolumesStatusPurged)
+
+		res := call(t, makeRequest(env, "data", "/var/lib/app", 512))
+		require.NotEqual(t, purged.ID, res.Data.VolumeId)
+		require.False(t, res.Data.Reattached)
+
+		got := findVolume(t, h, env, "data")
+		require.Equal(t, res.Data.VolumeId, got.ID)
+		require.NotEqual(t, purged.K8sName, got.K8sName)
+
+		// The purged row stays, renamed to its id, so a pending deletion
+		// still resolves it.
+		retired := findVolume(t, h, env, purged.ID)
+		require.Equal(t, db.VolumesStatusPurged, retired.Status)
+	})
+
+	t.Run("allows several volumes at different paths", func(t *testing.T) {
+		env := seedEnvironment(t, h)
+
+		call(t, makeRequest(env, "uploads", "/srv/uploads", 512))
+		call(t, makeRequest(env, "cache", "/srv/cache", 512))
+
+		require.Equal(t, "/srv/uploads", findVolume(t, h, env, "uploads").MountPath)
+		require.Equal(t, "/srv/cache", findVolume(t, h, env, "cache").MountPath)
+	})
+}
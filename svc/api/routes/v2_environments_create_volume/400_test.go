@@ -0,0 +1,64 @@
+package handler_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_environments_create_volume"
+)
+
+// All bad input returns 400, whether rejected by the OpenAPI validation
+// middleware (shapes, patterns, bounds) or by the handler (mount paths,
+// limits, shrinking). The seeded limits row caps storage at 10240 MiB.
+func TestCreateVolume400(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{DB: h.DB, Auditlogs: h.Auditlogs, LimitsCache: h.Caches.WorkspaceLimits}
+	h.Register(route)
+
+	env := seedEnvironment(t, h)
+	rootKey := h.CreateRootKey(env.workspaceID, "environment.*.update_environment")
+	headers := authHeaders(rootKey)
+
+	seedVolume(t, h, env, "mounted", 512, db.VolumesStatusActive)
+	seedVolume(t, h, env, "released", 2048, db.VolumesStatusReleased)
+
+	testCases := []struct {
+		name string
+		req  handler.Request
+	}{
+		// Name (spec).
+		{name: "name empty", req: makeRequest(env, "", "/srv/data", 512)},
+		{name: "name uppercase", req: makeRequest(env, "Data", "/srv/data", 512)},
+		{name: "name trailing hyphen", req: makeRequest(env, "data-", "/srv/data", 512)},
+		{name: "name too long", req: makeRequest(env, strings.Repeat("a", 64), "/srv/data", 512)},
+
+		// Size (spec and handler).
+		{name: "size below floor", req: makeRequest(env, "data", "/srv/data", 256)},
+		{name: "size off step", req: makeRequest(env, "data", "/srv/data", 1000)},
+		{name: "size over quota", req: makeRequest(env, "data", "/srv/data", 20480)},
+
+		// Mount path (spec and handler).
+		{name: "mount path relative", req: makeRequest(env, "data", "srv/data", 512)},
+		{name: "mount path root", req: makeRequest(env, "data", "/", 512)},
+		{name: "mount path not clean", req: makeRequest(env, "data", "/srv/../data", 512)},
+		{name: "mount path trailing slash", req: makeRequest(env, "data", "/srv/data/", 512)},
+		{name: "mount path ephemeral disk", req: makeRequest(env, "data", "/data", 512)},
+		{name: "mount path in use", req: makeRequest(env, "data", "/var/lib/mounted", 512)},
+
+		// Reattaching (handler).
+		{name: "reattach shrinks", req: makeRequest(env, "released", "/srv/released", 1024)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, tc.req)
+			require.Equal(t, http.StatusBadRequest, res.Status, "expected 400 for %q, got: %s", tc.name, res.RawBody)
+		})
+	}
+}
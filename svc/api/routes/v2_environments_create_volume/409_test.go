@@ -0,0 +1,32 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_environments_create_volume"
+)
+
+func TestCreateVolumeConflict(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{DB: h.DB, Auditlogs: h.Auditlogs, LimitsCache: h.Caches.WorkspaceLimits}
+	h.Register(route)
+
+	env := seedEnvironment(t, h)
+	rootKey := h.CreateRootKey(env.workspaceID, "environment.*.update_environment")
+	headers := authHeaders(rootKey)
+
+	active := seedVolume(t, h, env, "data", 512, db.VolumesStatusActive)
+
+	res := testutil.CallRoute[handler.Request, openapi.ConflictErrorResponse](h, route, headers, makeRequest(env, "data", "/srv/other", 512))
+	require.Equal(t, http.StatusConflict, res.Status, "expected 409, received: %s", res.RawBody)
+	require.Equal(t, "https://unkey.com/docs/errors/unkey/data/volume_already_exists", res.Body.Error.Type)
+
+	got := findVolume(t, h, env, "data")
+	require.Equal(t, active.MountPath, got.MountPath, "the existing volume must be left alone")
+}
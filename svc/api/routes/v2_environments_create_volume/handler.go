@@ -0,0 +1,349 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/unkeyed/unkey/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/internal/services/caches"
+	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
+	"github.com/unkeyed/unkey/pkg/auditlog"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/rbac/permissions"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/pkg/urn"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2EnvironmentsCreateVolumeRequestBody
+	Response = openapi.V2EnvironmentsCreateVolumeResponseBody
+)
+
+// ephemeralDiskPath is where krane mounts the ephemeral disk sized by
+// storageMib. A volume mounted there would make the pod spec invalid.
+const ephemeralDiskPath = "/data"
+
+type Handler struct {
+	DB          db.Database
+	Auditlogs   auditlogs.AuditLogService
+	LimitsCache cache.Cache[string, keysdb.Limit]
+}
+
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+func (h *Handler) Path() string {
+	return "/v2/environments.createVolume"
+}
+
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	env, err := db.Query.FindEnvironmentByIdentifiers(ctx, h.DB.RO(), db.FindEnvironmentByIdentifiersParams{
+		WorkspaceID: principal.WorkspaceID,
+		Project:     req.Project,
+		App:         req.App,
+		Environment: req.Environment,
+	})
+	if err != nil {
+		if db.IsNotFound(err) {
+			return fault.New(
+				"environment not found",
+				fault.Code(codes.Data.Environment.NotFound.URN()),
+				fault.Internal("environment not found"),
+				fault.Public("The requested environment does not exist."),
+			)
+		}
+		return fault.Wrap(
+			err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to retrieve environment."),
+		)
+	}
+
+	err = principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Environment,
+			ResourceID:   "*",
+			Action:       rbac.UpdateEnvironment,
+		}),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Environment,
+			ResourceID:   env.ID,
+			Action:       rbac.UpdateEnvironment,
+		}),
+		rbac.U(
+			urn.New().Workspace(principal.WorkspaceID).Project(env.ProjectID).App(env.AppID).Environment(env.ID),
+			permissions.UpdateEnvironment{},
+		),
+	))
+	if err != nil {
+		return err
+	}
+
+	if err := validateMountPath(req.MountPath); err != nil {
+		return err
+	}
+
+	limits, hit, err := h.LimitsCache.SWR(ctx, principal.WorkspaceID, func(ctx context.Context) (keysdb.Limit, error) {
+		return keysdb.Query.FindLimitsByWorkspaceID(ctx, h.DB.RO(), principal.WorkspaceID)
+	}, caches.DefaultFindFirstOp)
+	if err != nil && !db.IsNotFound(err) {
+		return fault.Wrap(
+			err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to validate resource limits."),
+		)
+	}
+	if db.IsNotFound(err) || hit == cache.Null {
+		return fault.New(
+			"workspace limits not found",
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("workspace has no limits row"),
+			fault.Public("Resource limits are not configured for this workspace. Contact support@unkey.com."),
+		)
+	}
+	if req.SizeMib > int(limits.StorageMibMaxPerInstance) {
+		return invalidInput(
+			"volume size exceeds workspace limit",
+			fmt.Sprintf("Volume size cannot exceed %d MiB. Contact support@unkey.com to increase it.", limits.StorageMibMaxPerInstance),
+		)
+	}
+
+	var (
+		volumeID   string
+		reattached bool
+	)
+	err = db.TxRetry(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) error {
+		// Reset so a retried attempt never skips the insert.
+		volumeID, reattached = "", false
+
+		// Serializes volume changes per environment, so the name and mount
+		// path checks below cannot race another create.
+		if _, lockErr := db.Query.LockEnvironmentForUpdate(ctx, tx, env.ID); lockErr != nil {
+			if db.IsNotFound(lockErr) {
+				return fault.New(
+					"environment not found",
+					fault.Code(codes.Data.Environment.NotFound.URN()),
+					fault.Internal("environment deleted before lock"),
+					fault.Public("The requested environment does not exist."),
+				)
+			}
+			return fault.Wrap(
+				lockErr,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("unable to lock environment"),
+				fault.Public("We're unable to create the volume."),
+			)
+		}
+
+		mounted, err := db.Query.FindActiveVolumeByMountPath(ctx, tx, db.FindActiveVolumeByMountPathParams{
+			AppID:         env.AppID,
+			EnvironmentID: env.ID,
+			MountPath:     req.MountPath,
+		})
+		if err != nil && !db.IsNotFound(err) {
+			return fault.Wrap(
+				err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("unable to find volume by mount path"),
+				fault.Public("We're unable to create the volume."),
+			)
+		}
+		if err == nil && mounted.Name != req.Name {
+			return invalidInput(
+				"mount path in use",
+				fmt.Sprintf("Volume %q is already mounted at %s.", mounted.Name, req.MountPath),
+			)
+		}
+
+		existing, err := db.Query.FindVolumeByAppEnvironmentAndName(ctx, tx, db.FindVolumeByAppEnvironmentAndNameParams{
+			AppID:         env.AppID,
+			EnvironmentID: env.ID,
+			Name:          req.Name,
+		})
+		if err != nil && !db.IsNotFound(err) {
+			return fault.Wrap(
+				err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("unable to find volume by name"),
+				fault.Public("We're unable to create the volume."),
+			)
+		}
+
+		now := time.Now().UnixMilli()
+		if err == nil {
+			switch existing.Status {
+			case db.VolumesStatusActive:
+				return fault.New(
+					"volume already exists",
+					fault.Code(codes.Data.Volume.Duplicate.URN()),
+					fault.Internal("active volume with this name exists"),
+					fault.Public(fmt.Sprintf("A volume named %q already exists in this environment.", req.Name)),
+				)
+
+			case db.VolumesStatusReleased:
+				if req.SizeMib < int(existing.SizeMib) {
+					return invalidInput(
+						"volume cannot shrink",
+						fmt.Sprintf("Volume %q is %d MiB and cannot shrink. Request at least %d MiB.", req.Name, existing.SizeMib, existing.SizeMib),
+					)
+				}
+
+				rows, err := db.Query.ReactivateVolume(ctx, tx, db.ReactivateVolumeParams{
+					MountPath: req.MountPath,
+					SizeMib:   uint32(req.SizeMib),
+					UpdatedAt: sql.NullInt64{Valid: true, Int64: now},
+					ID:        existing.ID,
+				})
+				if err != nil {
+					return fault.Wrap(
+						err,
+						fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+						fault.Internal("unable to reactivate volume"),
+						fault.Public("We're unable to create the volume."),
+					)
+				}
+				if rows > 0 {
+					volumeID = existing.ID
+					reattached = true
+					break
+				}
+				// PurgeVolumes claimed it since the read; its data is gone,
+				// so free the name like any other purged volume.
+				fallthrough
+
+			case db.VolumesStatusPurged:
+				if _, err := db.Query.RetirePurgedVolumeName(ctx, tx, db.RetirePurgedVolumeNameParams{
+					UpdatedAt: sql.NullInt64{Valid: true, Int64: now},
+					ID:        existing.ID,
+				}); err != nil {
+					return fault.Wrap(
+						err,
+						fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+						fault.Internal("unable to retire purged volume name"),
+						fault.Public("We're unable to create the volume."),
+					)
+				}
+			}
+		}
+
+		if volumeID == "" {
+			volumeID = uid.New(uid.VolumePrefix)
+			if err := db.Query.InsertVolume(ctx, tx, db.InsertVolumeParams{
+				ID:            volumeID,
+				WorkspaceID:   env.WorkspaceID,
+				ProjectID:     env.ProjectID,
+				AppID:         env.AppID,
+				EnvironmentID: env.ID,
+				Name:          req.Name,
+				MountPath:     req.MountPath,
+				SizeMib:       uint32(req.SizeMib),
+				K8sName:       uid.DNS1035(12),
+				Status:        db.VolumesStatusActive,
+				CreatedAt:     now,
+			}); err != nil {
+				return fault.Wrap(
+					err,
+					fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+					fault.Internal("unable to insert volume"),
+					fault.Public("We're unable to create the volume."),
+				)
+			}
+		}
+
+		display := fmt.Sprintf("Created volume %s for environment %s", req.Name, env.ID)
+		if reattached {
+			display = fmt.Sprintf("Reattached volume %s for environment %s", req.Name, env.ID)
+		}
+
+		return h.Auditlogs.Insert(ctx, tx, []auditlog.AuditLog{
+			{
+				WorkspaceID:   principal.WorkspaceID,
+				Event:         auditlog.EnvironmentUpdateEvent,
+				Display:       display,
+				ActorID:       principal.Subject.ID,
+				ActorName:     principal.Subject.Name,
+				ActorMeta:     map[string]any{},
+				ActorType:     auditlog.AuditLogActor(principal.Subject.Type),
+				RemoteIP:      s.Location(),
+				UserAgent:     s.UserAgent(),
+				CorrelationID: "",
+				Resources: []auditlog.AuditLogResource{
+					{
+						ID:   env.ID,
+						Type: auditlog.EnvironmentResourceType,
+						Meta: map[string]any{
+							"volume_id":  volumeID,
+							"name":       req.Name,
+							"mount_path": req.MountPath,
+							"size_mib":   req.SizeMib,
+							"reattached": reattached,
+						},
+						Name:        env.Slug,
+						DisplayName: env.Slug,
+					},
+				},
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{RequestId: s.RequestID()},
+		Data: openapi.V2EnvironmentsCreateVolumeResponseData{
+			VolumeId:   volumeID,
+			Reattached: reattached,
+		},
+	})
+}
+
+// validateMountPath rejects paths krane cannot mount: anything not already in
+// clean absolute form, the root, and the ephemeral disk's path.
+func validateMountPath(mountPath string) error {
+	if !path.IsAbs(mountPath) || path.Clean(mountPath) != mountPath {
+		return invalidInput(
+			"mount path not clean",
+			fmt.Sprintf("Mount path %q must be a clean absolute path, such as %q.", mountPath, path.Clean("/"+mountPath)),
+		)
+	}
+	if mountPath == "/" || mountPath == ephemeralDiskPath {
+		return invalidInput(
+			"mount path reserved",
+			fmt.Sprintf("Mount path %q is reserved. Choose another path.", mountPath),
+		)
+	}
+	return nil
+}
+
+func invalidInput(internal, public string) error {
+	return fault.New(
+		internal,
+		fault.Code(codes.App.Validation.InvalidInput.URN()),
+		fault.Internal(internal),
+		fault.Public(public),
+	)
+}
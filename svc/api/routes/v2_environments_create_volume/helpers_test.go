@@ -0,0 +1,114 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	mysqltype "github.com/unkeyed/unkey/pkg/mysql/types"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_environments_create_volume"
+)
+
+// makeRequest builds a create request targeting a seeded environment.
+func makeRequest(env seededEnv, name, mountPath string, sizeMib int) handler.Request {
+	return handler.Request{
+		Project:     env.projectID,
+		App:         env.appID,
+		Environment: env.environmentID,
+		Name:        name,
+		MountPath:   mountPath,
+		SizeMib:     sizeMib,
+	}
+}
+
+type seededEnv struct {
+	workspaceID   string
+	projectID     string
+	appID         string
+	environmentID string
+}
+
+func seedEnvironment(t *testing.T, h *testutil.Harness) seededEnv {
+	t.Helper()
+
+	workspace := h.Resources().UserWorkspace
+
+	project := h.CreateProject(seed.CreateProjectRequest{
+		ID:          uid.New(uid.ProjectPrefix),
+		WorkspaceID: workspace.ID,
+		Name:        "Payments Service",
+		Slug:        strings.ToLower(strings.ReplaceAll(uid.New("test"), "_", "-")),
+	})
+
+	app := h.CreateApp(seed.CreateAppRequest{
+		ID:            uid.New(uid.AppPrefix),
+		WorkspaceID:   workspace.ID,
+		ProjectID:     project.ID,
+		Name:          "Payments API",
+		Slug:          strings.ToLower(strings.ReplaceAll(uid.New("test"), "_", "-")),
+		DefaultBranch: "main",
+	})
+
+	environment := h.CreateEnvironment(seed.CreateEnvironmentRequest{
+		ID:          uid.New(uid.EnvironmentPrefix),
+		WorkspaceID: workspace.ID,
+		ProjectID:   project.ID,
+		AppID:       app.ID,
+		Slug:        "production",
+		Kind:        mysqltype.EnvironmentKindProduction,
+		Description: "Production environment",
+	})
+
+	return seededEnv{
+		workspaceID:   workspace.ID,
+		projectID:     project.ID,
+		appID:         app.ID,
+		environmentID: environment.ID,
+	}
+}
+
+// seedVolume inserts a volume directly, bypassing the handler, so tests can
+// set up released and purged volumes.
+func seedVolume(t *testing.T, h *testutil.Harness, env seededEnv, name string, sizeMib uint32, status db.VolumesStatus) db.InsertVolumeParams {
+	t.Helper()
+	params := db.InsertVolumeParams{
+		ID:            uid.New(uid.VolumePrefix),
+		WorkspaceID:   env.workspaceID,
+		ProjectID:     env.projectID,
+		AppID:         env.appID,
+		EnvironmentID: env.environmentID,
+		Name:          name,
+		MountPath:     "/var/lib/" + name,
+		SizeMib:       sizeMib,
+		K8sName:       uid.DNS1035(12),
+		Status:        status,
+		CreatedAt:     1,
+	}
+	require.NoError(t, db.Query.InsertVolume(context.Background(), h.DB.RW(), params))
+	return params
+}
+
+func findVolume(t *testing.T, h *testutil.Harness, env seededEnv, name string) db.Volume {
+	t.Helper()
+	volume, err := db.Query.FindVolumeByAppEnvironmentAndName(context.Background(), h.DB.RO(), db.FindVolumeByAppEnvironmentAndNameParams{
+		AppID:         env.appID,
+		EnvironmentID: env.environmentID,
+		Name:          name,
+	})
+	require.NoError(t, err)
+	return volume
+}
+
+func authHeaders(rootKey string) http.Header {
+	return http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+}
@@ -23,6 +23,7 @@ func TestCreateIdentitySuccessfully(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
 	}
 
 	h.Register(route)
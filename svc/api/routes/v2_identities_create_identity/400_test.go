@@ -21,6 +21,7 @@ func TestBadRequests(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
 	}
 
 	h.Register(route)
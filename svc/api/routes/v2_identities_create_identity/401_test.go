@@ -17,6 +17,7 @@ func TestUnauthorizedAccess(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
 	}
 
 	h.Register(route)
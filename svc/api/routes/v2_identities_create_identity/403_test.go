@@ -17,6 +17,7 @@ func TestWorkspacePermissions(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
 	}
 
 	h.Register(route)
@@ -18,6 +18,7 @@ func TestCreateIdentityDuplicate(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
 	}
 
 	h.Register(route)
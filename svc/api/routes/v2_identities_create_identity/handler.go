@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/unkeyed/unkey/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/internal/services/caches"
 	"github.com/unkeyed/unkey/pkg/auditlog"
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/db"
@@ -30,6 +31,7 @@ type Response = openapi.V2IdentitiesCreateIdentityResponseBody
 type Handler struct {
 	DB        db.Database
 	Auditlogs auditlogs.AuditLogService
+	Caches    *caches.Invalidator
 }
 
 const (
@@ -221,6 +223,9 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return err
 	}
 
+	// JWT verifications may have cached that this external ID has no identity.
+	h.Caches.Invalidate(ctx, caches.IdentityEntity(principal.WorkspaceID, identityID, req.ExternalId))
+
 	return s.JSON(http.StatusOK, Response{
 		Meta: openapi.Meta{
 			RequestId: s.RequestID(),
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/unkeyed/unkey/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/internal/services/caches"
 	"github.com/unkeyed/unkey/pkg/auditlog"
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/db"
@@ -27,6 +28,7 @@ type (
 type Handler struct {
 	DB        db.Database
 	Auditlogs auditlogs.AuditLogService
+	Caches    *caches.Invalidator
 }
 
 // Method returns the HTTP method this route responds to
@@ -159,6 +161,15 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			)
 		}
 
+		// A deleted identity no longer has keys to share a credit pool with.
+		err = db.Query.DeleteIdentityCredits(ctx, tx, identity.ID)
+		if err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database failed to delete identity credits"), fault.Public("Failed to delete Identity."),
+			)
+		}
+
 		auditLogs := []auditlog.AuditLog{
 			{
 				WorkspaceID:   principal.WorkspaceID,
@@ -225,6 +236,8 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return err
 	}
 
+	h.Caches.Invalidate(ctx, caches.IdentityEntity(principal.WorkspaceID, identity.ID, identity.ExternalID))
+
 	return s.JSON(http.StatusOK, Response{
 		Meta: openapi.Meta{
 			RequestId: s.RequestID(),
@@ -12,6 +12,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/rbac/permissions"
 	"github.com/unkeyed/unkey/pkg/urn"
 	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/identitycredits"
 	"github.com/unkeyed/unkey/svc/api/openapi"
 )
 
@@ -116,6 +117,19 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		})
 	}
 
+	var credits *openapi.IdentityCredits
+	pool, err := db.Query.FindIdentityCredits(ctx, h.DB.RO(), identity.ID)
+	if err != nil && !db.IsNotFound(err) {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("unable to find identity credits"),
+			fault.Public("We're unable to retrieve the identity's credits."),
+		)
+	}
+	if err == nil {
+		credits = identitycredits.Response(int64(pool.Remaining), pool.RefillDay, pool.RefillAmount) // nolint:gosec // balances never approach MaxInt64
+	}
+
 	return s.JSON(http.StatusOK, Response{
 		Meta: openapi.Meta{
 			RequestId: s.RequestID(),
@@ -125,6 +139,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			ExternalId: identity.ExternalID,
 			Meta:       metaMap,
 			Ratelimits: responseRatelimits,
+			Credits:    credits,
 		},
 	})
 }
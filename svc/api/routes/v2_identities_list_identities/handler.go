@@ -133,6 +133,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			ExternalId: identity.ExternalID,
 			Ratelimits: formattedRatelimits,
 			Meta:       metaMap,
+			Credits:    nil,
 		})
 	}
 
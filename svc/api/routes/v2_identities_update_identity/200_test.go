@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/oapi-codegen/nullable"
 	"github.com/stretchr/testify/require"
 	"github.com/unkeyed/unkey/pkg/db"
 	"github.com/unkeyed/unkey/pkg/uid"
@@ -23,6 +24,7 @@ func TestSuccess(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
 	}
 
 	h.Register(route)
@@ -266,6 +268,110 @@ func TestSuccess(t *testing.T) {
 	})
 }
 
+func TestUpdateIdentityCredits(t *testing.T) {
+	h := testutil.NewHarness(t)
+	route := &handler.Handler{
+		DB:           h.DB,
+		Auditlogs:    h.Auditlogs,
+		UsageLimiter: h.UsageLimiter,
+		Caches:       h.Caches.Invalidations,
+	}
+
+	h.Register(route)
+
+	rootKeyID := h.CreateRootKey(h.Resources().UserWorkspace.ID, "identity.*.update_identity")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKeyID)},
+	}
+
+	ctx := context.Background()
+	identityID := uid.New(uid.IdentityPrefix)
+	externalID := "credit_pool_user"
+
+	err := db.Query.InsertIdentity(ctx, h.DB.RW(), db.InsertIdentityParams{
+		ID:          identityID,
+		ExternalID:  externalID,
+		WorkspaceID: h.Resources().UserWorkspace.ID,
+		Environment: "default",
+		CreatedAt:   time.Now().UnixMilli(),
+		Meta:        []byte("{}"),
+	})
+	require.NoError(t, err)
+
+	t.Run("create pool with monthly refill", func(t *testing.T) {
+		req := handler.Request{
+			Identity: externalID,
+			Credits: nullable.NewNullableWithValue(openapi.UpdateIdentityCreditsData{
+				Remaining: 500,
+				Refill: &openapi.KeyCreditsRefill{
+					Amount:    1000,
+					Interval:  openapi.KeyCreditsRefillIntervalMonthly,
+					RefillDay: 15,
+				},
+			}),
+		}
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, got: %s", res.RawBody)
+		require.NotNil(t, res.Body.Data.Credits)
+		require.Equal(t, int64(500), res.Body.Data.Credits.Remaining)
+		require.NotNil(t, res.Body.Data.Credits.Refill)
+		require.Equal(t, int64(1000), res.Body.Data.Credits.Refill.Amount)
+		require.Equal(t, 15, res.Body.Data.Credits.Refill.RefillDay)
+
+		pool, err := db.Query.FindIdentityCredits(ctx, h.DB.RO(), identityID)
+		require.NoError(t, err)
+		require.Equal(t, uint64(500), pool.Remaining)
+		require.Equal(t, int16(15), pool.RefillDay.Int16)
+	})
+
+	t.Run("omitting credits keeps the pool", func(t *testing.T) {
+		meta := map[string]any{"plan": "pro"}
+		req := handler.Request{
+			Identity: externalID,
+			Meta:     &meta,
+		}
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, got: %s", res.RawBody)
+		require.NotNil(t, res.Body.Data.Credits)
+		require.Equal(t, int64(500), res.Body.Data.Credits.Remaining)
+	})
+
+	t.Run("daily refill drops the refill day", func(t *testing.T) {
+		req := handler.Request{
+			Identity: externalID,
+			Credits: nullable.NewNullableWithValue(openapi.UpdateIdentityCreditsData{
+				Remaining: 20,
+				Refill: &openapi.KeyCreditsRefill{
+					Amount:   20,
+					Interval: openapi.KeyCreditsRefillIntervalDaily,
+				},
+			}),
+		}
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, got: %s", res.RawBody)
+		require.Equal(t, int64(20), res.Body.Data.Credits.Remaining)
+		require.Equal(t, openapi.KeyCreditsRefillIntervalDaily, res.Body.Data.Credits.Refill.Interval)
+
+		pool, err := db.Query.FindIdentityCredits(ctx, h.DB.RO(), identityID)
+		require.NoError(t, err)
+		require.False(t, pool.RefillDay.Valid)
+	})
+
+	t.Run("null removes the pool", func(t *testing.T) {
+		req := handler.Request{
+			Identity: externalID,
+			Credits:  nullable.NewNullNullable[openapi.UpdateIdentityCreditsData](),
+		}
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, got: %s", res.RawBody)
+		require.Nil(t, res.Body.Data.Credits)
+
+		_, err := db.Query.FindIdentityCredits(ctx, h.DB.RO(), identityID)
+		require.True(t, db.IsNotFound(err))
+	})
+}
+
 // TestUpdateIdentityConcurrentRatelimits tests that concurrent updates to the
 // same identity's ratelimits don't deadlock. The handler uses SELECT ... FOR UPDATE
 // on the identity row to serialize concurrent modifications.
@@ -278,6 +384,7 @@ func TestUpdateIdentityConcurrentRatelimits(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
 	}
 
 	h.Register(route)
@@ -370,6 +477,7 @@ func TestBulkIdentityUpdateDeadlock(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
 	}
 
 	h.Register(route)
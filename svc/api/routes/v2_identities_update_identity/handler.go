@@ -9,10 +9,13 @@ import (
 	"time"
 
 	"github.com/unkeyed/unkey/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/internal/services/caches"
+	"github.com/unkeyed/unkey/internal/services/usagelimiter"
 	"github.com/unkeyed/unkey/pkg/auditlog"
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/db"
 	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/logger"
 	"github.com/unkeyed/unkey/pkg/ptr"
 	"github.com/unkeyed/unkey/pkg/rbac"
 	"github.com/unkeyed/unkey/pkg/rbac/permissions"
@@ -20,6 +23,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/urn"
 	"github.com/unkeyed/unkey/pkg/zen"
 	apierrors "github.com/unkeyed/unkey/svc/api/internal/errors"
+	"github.com/unkeyed/unkey/svc/api/internal/identitycredits"
 	"github.com/unkeyed/unkey/svc/api/openapi"
 )
 
@@ -30,8 +34,10 @@ type (
 
 // Handler implements zen.Route interface for the v2 identities update identity endpoint
 type Handler struct {
-	DB        db.Database
-	Auditlogs auditlogs.AuditLogService
+	DB           db.Database
+	Auditlogs    auditlogs.AuditLogService
+	UsageLimiter usagelimiter.Service
+	Caches       *caches.Invalidator
 }
 
 const (
@@ -133,6 +139,15 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		)
 	}
 
+	var creditsParams *db.UpsertIdentityCreditsParams
+	if req.Credits.IsSpecified() && !req.Credits.IsNull() {
+		params, paramsErr := identitycredits.Params(principal.WorkspaceID, identityRow.ID, req.Credits.MustGet(), time.Now().UnixMilli())
+		if paramsErr != nil {
+			return paramsErr
+		}
+		creditsParams = &params
+	}
+
 	// Parse existing ratelimits from JSON
 	var existingRatelimits []db.RatelimitInfo
 	if ratelimitBytes, ok := identityRow.Ratelimits.([]byte); ok && ratelimitBytes != nil {
@@ -142,6 +157,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 	type txResult struct {
 		identity        db.FindIdentityRow
 		finalRatelimits []openapi.RatelimitResponse
+		credits         *openapi.IdentityCredits
 	}
 
 	result, err := db.TxWithResultRetry(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) (txResult, error) {
@@ -193,6 +209,11 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			}
 		}
 
+		credits, err := updateCredits(ctx, tx, req, identityRow.ID, creditsParams)
+		if err != nil {
+			return txResult{}, err
+		}
+
 		// Build final ratelimits list (what will exist after this transaction)
 		finalRatelimits := make([]openapi.RatelimitResponse, 0)
 
@@ -408,18 +429,30 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return txResult{
 			identity:        identityRow,
 			finalRatelimits: finalRatelimits,
+			credits:         credits,
 		}, nil
 	})
 	if err != nil {
 		return err
 	}
 
+	h.Caches.Invalidate(ctx, caches.IdentityEntity(principal.WorkspaceID, result.identity.ID, result.identity.ExternalID))
+
+	// Drop the cached pool so the next verification starts from the new
+	// balance instead of the old counter.
+	if req.Credits.IsSpecified() {
+		if err := h.UsageLimiter.InvalidateIdentity(ctx, identityRow.ID); err != nil {
+			logger.Error("failed to invalidate identity credits", "error", err, "identityId", identityRow.ID)
+		}
+	}
+
 	// No extra SELECT query needed - we built the ratelimits list during the transaction!
 	identityData := openapi.Identity{
 		Id:         result.identity.ID,
 		ExternalId: result.identity.ExternalID,
 		Meta:       ptr.SafeDeref(req.Meta),
 		Ratelimits: nil,
+		Credits:    result.credits,
 	}
 
 	identityData.Ratelimits = result.finalRatelimits
@@ -433,3 +466,43 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 
 	return s.JSON(http.StatusOK, response)
 }
+
+// updateCredits applies the credits of the request and returns the pool as
+// it stands afterwards: replaced when credits is set, removed when it is
+// null, and read back unchanged when it is omitted.
+func updateCredits(ctx context.Context, tx db.DBTX, req Request, identityID string, params *db.UpsertIdentityCreditsParams) (*openapi.IdentityCredits, error) {
+	if params != nil {
+		if err := db.Query.UpsertIdentityCredits(ctx, tx, *params); err != nil {
+			return nil, fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("unable to upsert identity credits"),
+				fault.Public("We're unable to update the identity's credits."),
+			)
+		}
+		return identitycredits.Response(int64(params.Remaining), params.RefillDay, params.RefillAmount), nil // nolint:gosec // converted from int64 in Params
+	}
+
+	if req.Credits.IsSpecified() {
+		if err := db.Query.DeleteIdentityCredits(ctx, tx, identityID); err != nil {
+			return nil, fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("unable to delete identity credits"),
+				fault.Public("We're unable to update the identity's credits."),
+			)
+		}
+		return nil, nil
+	}
+
+	credits, err := db.Query.FindIdentityCredits(ctx, tx, identityID)
+	if err != nil {
+		if db.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("unable to find identity credits"),
+			fault.Public("We're unable to retrieve the identity's credits."),
+		)
+	}
+	return identitycredits.Response(int64(credits.Remaining), credits.RefillDay, credits.RefillAmount), nil // nolint:gosec // balances never approach MaxInt64
+}
@@ -22,7 +22,7 @@ func TestSuccess(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
-		KeyCache:  h.Caches.VerificationKeyByHash,
+		Caches:    h.Caches.Invalidations,
 	}
 
 	h.Register(route)
@@ -375,7 +375,7 @@ func TestAddPermissionsConcurrent(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
-		KeyCache:  h.Caches.VerificationKeyByHash,
+		Caches:    h.Caches.Invalidations,
 	}
 
 	h.Register(route)
@@ -25,7 +25,7 @@ func TestValidationErrors(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
-		KeyCache:  h.Caches.VerificationKeyByHash,
+		Caches:    h.Caches.Invalidations,
 	}
 
 	h.Register(route)
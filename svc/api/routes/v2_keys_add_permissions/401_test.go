@@ -23,7 +23,7 @@ func TestAuthenticationErrors(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
-		KeyCache:  h.Caches.VerificationKeyByHash,
+		Caches:    h.Caches.Invalidations,
 	}
 
 	h.Register(route)
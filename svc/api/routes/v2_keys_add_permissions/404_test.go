@@ -24,7 +24,7 @@ func TestNotFoundErrors(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
-		KeyCache:  h.Caches.VerificationKeyByHash,
+		Caches:    h.Caches.Invalidations,
 	}
 
 	h.Register(route)
@@ -8,9 +8,8 @@ import (
 	"time"
 
 	"github.com/unkeyed/unkey/internal/services/auditlogs"
-	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
+	"github.com/unkeyed/unkey/internal/services/caches"
 	"github.com/unkeyed/unkey/pkg/auditlog"
-	"github.com/unkeyed/unkey/pkg/cache"
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/db"
 	dbtype "github.com/unkeyed/unkey/pkg/db/types"
@@ -20,6 +19,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/uid"
 	"github.com/unkeyed/unkey/pkg/urn"
 	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/conditions"
 	"github.com/unkeyed/unkey/svc/api/internal/projects"
 	"github.com/unkeyed/unkey/svc/api/openapi"
 )
@@ -30,7 +30,7 @@ type Response = openapi.V2KeysAddPermissionsResponseBody
 type Handler struct {
 	DB        db.Database
 	Auditlogs auditlogs.AuditLogService
-	KeyCache  cache.Cache[string, keysdb.CachedKeyData]
+	Caches    *caches.Invalidator
 }
 
 // Method returns the HTTP method this route responds to
@@ -202,6 +202,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			ProjectID:   projectID,
 			Slug:        perm,
 			Description: dbtype.NullString{String: "", Valid: false},
+			Conditions:  nil,
 			CreatedAtM:  now,
 			UpdatedAtM:  sql.NullInt64{Int64: now, Valid: true},
 		})
@@ -321,7 +322,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return err
 	}
 
-	h.KeyCache.Remove(ctx, key.Hash)
+	h.Caches.Invalidate(ctx, caches.KeyEntity(key.WorkspaceID, key.ID, key.Hash))
 
 	responseData := make(openapi.V2KeysAddPermissionsResponseData, 0)
 
@@ -331,6 +332,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			Name:        permission.Name,
 			Slug:        permission.Slug,
 			Description: permission.Description.String,
+			Conditions:  conditions.ToResponse(permission.Conditions),
 		}
 
 		responseData = append(responseData, perm)
@@ -342,6 +344,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			Name:        permission.Name,
 			Slug:        permission.Slug,
 			Description: permission.Description.String,
+			Conditions:  conditions.ToResponse(permission.Conditions),
 		}
 
 		responseData = append(responseData, perm)
@@ -23,7 +23,7 @@ func TestSuccess(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
-		KeyCache:  h.Caches.VerificationKeyByHash,
+		Caches:    h.Caches.Invalidations,
 	}
 
 	h.Register(route)
@@ -203,7 +203,7 @@ func TestAddRolesConcurrent(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
-		KeyCache:  h.Caches.VerificationKeyByHash,
+		Caches:    h.Caches.Invalidations,
 	}
 
 	h.Register(route)
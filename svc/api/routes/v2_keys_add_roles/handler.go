@@ -7,9 +7,8 @@ import (
 	"time"
 
 	"github.com/unkeyed/unkey/internal/services/auditlogs"
-	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
+	"github.com/unkeyed/unkey/internal/services/caches"
 	"github.com/unkeyed/unkey/pkg/auditlog"
-	"github.com/unkeyed/unkey/pkg/cache"
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/db"
 	"github.com/unkeyed/unkey/pkg/fault"
@@ -18,6 +17,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/rbac/permissions"
 	"github.com/unkeyed/unkey/pkg/urn"
 	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/conditions"
 	"github.com/unkeyed/unkey/svc/api/openapi"
 )
 
@@ -29,7 +29,7 @@ type (
 type Handler struct {
 	DB        db.Database
 	Auditlogs auditlogs.AuditLogService
-	KeyCache  cache.Cache[string, keysdb.CachedKeyData]
+	Caches    *caches.Invalidator
 }
 
 // Method returns the HTTP method this route responds to
@@ -229,7 +229,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			return err
 		}
 
-		h.KeyCache.Remove(ctx, key.Hash)
+		h.Caches.Invalidate(ctx, caches.KeyEntity(key.WorkspaceID, key.ID, key.Hash))
 	}
 
 	responseData := make(openapi.V2KeysAddRolesResponseData, 0)
@@ -258,6 +258,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 				Name:        permission.Name,
 				Slug:        permission.Slug,
 				Description: permission.Description.String,
+				Conditions:  conditions.ToResponse(permission.Conditions),
 			}
 
 			r.Permissions = append(r.Permissions, perm)
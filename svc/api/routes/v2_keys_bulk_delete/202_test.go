@@ -0,0 +1,86 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_keys_bulk_delete"
+)
+
+func TestBulkDeleteAccepted(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:       h.DB,
+		Restate:  testutil.NewRestateIngressClient(t, http.StatusOK),
+		ApiCache: h.Caches.LiveApiByID,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.delete_key")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	first := h.CreateKey(seed.CreateKeyRequest{WorkspaceID: workspace.ID, KeySpaceID: api.KeyAuthID.String})
+	second := h.CreateKey(seed.CreateKeyRequest{WorkspaceID: workspace.ID, KeySpaceID: api.KeyAuthID.String})
+
+	t.Run("key ids are recorded as pending items", func(t *testing.T) {
+		keyIDs := []string{first.KeyID, second.KeyID, first.KeyID}
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			ApiId:  api.ID,
+			KeyIds: &keyIDs,
+			Filter: nil,
+		})
+		require.Equal(t, http.StatusAccepted, res.Status, "expected 202, received: %s", res.RawBody)
+		require.NotEmpty(t, res.Body.Data.OperationId)
+
+		operation, err := db.Query.FindKeyBulkOperationByID(context.Background(), h.DB.RO(), res.Body.Data.OperationId)
+		require.NoError(t, err)
+		require.Equal(t, workspace.ID, operation.WorkspaceID)
+		require.Equal(t, api.ID, operation.ApiID)
+		require.Equal(t, db.KeyBulkOperationsOperationDelete, operation.Operation)
+		require.Equal(t, db.KeyBulkOperationsStatusPending, operation.Status)
+		require.Equal(t, uint32(2), operation.Total)
+
+		items, err := db.Query.ListKeyBulkOperationItems(context.Background(), h.DB.RO(), db.ListKeyBulkOperationItemsParams{
+			OperationID: operation.ID,
+			PkCursor:    0,
+			Limit:       10,
+		})
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		require.Equal(t, first.KeyID, items[0].KeyID)
+		require.Equal(t, second.KeyID, items[1].KeyID)
+		for _, item := range items {
+			require.Equal(t, db.KeyBulkOperationItemsStatusPending, item.Status)
+		}
+
+		// Nothing is deleted until the worker runs.
+		_, err = db.Query.FindLiveKeyByID(context.Background(), h.DB.RO(), first.KeyID)
+		require.NoError(t, err)
+	})
+
+	t.Run("filter leaves items to the worker", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			ApiId:  api.ID,
+			KeyIds: nil,
+			Filter: &openapi.KeyBulkFilter{ExternalId: nil, LastUsedBefore: nil, Meta: nil},
+		})
+		require.Equal(t, http.StatusAccepted, res.Status, "expected 202, received: %s", res.RawBody)
+
+		operation, err := db.Query.FindKeyBulkOperationByID(context.Background(), h.DB.RO(), res.Body.Data.OperationId)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), operation.Total)
+	})
+}
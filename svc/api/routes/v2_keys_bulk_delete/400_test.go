@@ -0,0 +1,79 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_keys_bulk_delete"
+)
+
+func TestBadRequest(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:       h.DB,
+		Restate:  testutil.NewRestateIngressClient(t, http.StatusOK),
+		ApiCache: h.Caches.LiveApiByID,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.delete_key")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	t.Run("missing apiId", func(t *testing.T) {
+		req := map[string]any{
+			"keyIds": []string{"key_123"},
+		}
+		res := testutil.CallRoute[map[string]any, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status)
+		require.Contains(t, res.Body.Error.Detail, "validate schema")
+	})
+
+	t.Run("empty keyIds", func(t *testing.T) {
+		req := map[string]any{
+			"apiId":  "api_123",
+			"keyIds": []string{},
+		}
+		res := testutil.CallRoute[map[string]any, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status)
+		require.Contains(t, res.Body.Error.Detail, "validate schema")
+	})
+
+	t.Run("neither keyIds nor filter", func(t *testing.T) {
+		req := map[string]any{
+			"apiId": "api_123",
+		}
+		res := testutil.CallRoute[map[string]any, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status)
+		require.Equal(t, "Provide either `keyIds` or `filter`, not both and not neither.", res.Body.Error.Detail)
+	})
+
+	t.Run("both keyIds and filter", func(t *testing.T) {
+		req := map[string]any{
+			"apiId":  "api_123",
+			"keyIds": []string{"key_123"},
+			"filter": map[string]any{},
+		}
+		res := testutil.CallRoute[map[string]any, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status)
+		require.Equal(t, "Provide either `keyIds` or `filter`, not both and not neither.", res.Body.Error.Detail)
+	})
+
+	t.Run("empty filter", func(t *testing.T) {
+		req := map[string]any{
+			"apiId":  "api_123",
+			"filter": map[string]any{},
+		}
+		res := testutil.CallRoute[map[string]any, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status)
+		require.Equal(t, "`filter` must set at least one criterion.", res.Body.Error.Detail)
+	})
+}
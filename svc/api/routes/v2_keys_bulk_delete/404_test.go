@@ -0,0 +1,65 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_keys_bulk_delete"
+)
+
+func TestNotFound(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:       h.DB,
+		Restate:  testutil.NewRestateIngressClient(t, http.StatusOK),
+		ApiCache: h.Caches.LiveApiByID,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.delete_key")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	t.Run("api does not exist", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, headers, handler.Request{
+			ApiId:  "api_does_not_exist",
+			KeyIds: &[]string{"key_123"},
+			Filter: nil,
+		})
+		require.Equal(t, 404, res.Status, "expected 404, received: %s", res.RawBody)
+	})
+
+	t.Run("api of another workspace", func(t *testing.T) {
+		other := h.CreateWorkspace()
+		api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: other.ID})
+
+		res := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, headers, handler.Request{
+			ApiId:  api.ID,
+			KeyIds: &[]string{"key_123"},
+			Filter: nil,
+		})
+		require.Equal(t, 404, res.Status, "expected 404, received: %s", res.RawBody)
+	})
+
+	t.Run("unknown identity in filter", func(t *testing.T) {
+		api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+
+		res := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, headers, handler.Request{
+			ApiId:  api.ID,
+			KeyIds: nil,
+			Filter: &openapi.KeyBulkFilter{ExternalId: ptr.P("user_does_not_exist"), LastUsedBefore: nil, Meta: nil},
+		})
+		require.Equal(t, 404, res.Status, "expected 404, received: %s", res.RawBody)
+		require.Equal(t, "The identity in `filter.externalId` does not exist.", res.Body.Error.Detail)
+	})
+}
@@ -0,0 +1,49 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_keys_bulk_delete"
+)
+
+func TestBulkDeleteRestateFailure(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:       h.DB,
+		Restate:  testutil.NewUnavailableRestateIngressClient(t),
+		ApiCache: h.Caches.LiveApiByID,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.delete_key")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+
+	res := testutil.CallRoute[handler.Request, openapi.InternalServerErrorResponse](h, route, headers, handler.Request{
+		ApiId:  api.ID,
+		KeyIds: &[]string{"key_123"},
+		Filter: nil,
+	})
+	require.Equal(t, http.StatusInternalServerError, res.Status, "expected 500, received: %s", res.RawBody)
+	require.Equal(t, "Failed to start the bulk operation.", res.Body.Error.Detail)
+
+	// The operation the route recorded must not look pending forever.
+	var status db.KeyBulkOperationsStatus
+	require.NoError(t, h.DB.RO().QueryRowContext(context.Background(),
+		"SELECT status FROM key_bulk_operations WHERE api_id = ?", api.ID,
+	).Scan(&status))
+	require.Equal(t, db.KeyBulkOperationsStatusFailed, status)
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	restateingress "github.com/restatedev/sdk-go/ingress"
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/keybulk"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2KeysBulkDeleteRequestBody
+	Response = openapi.V2KeysBulkDeleteResponseBody
+)
+
+// Handler implements zen.Route interface for the v2 keys bulk delete endpoint
+type Handler struct {
+	DB       db.Database
+	Restate  *restateingress.Client
+	ApiCache cache.Cache[cache.ScopedKey, db.FindLiveApiByIDRow]
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/keys.bulkDelete"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	if err := keybulk.ValidateTarget(req.KeyIds, req.Filter); err != nil {
+		return err
+	}
+	if err := keybulk.RequireCriteria(req.Filter); err != nil {
+		return err
+	}
+
+	err = principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Api,
+			ResourceID:   "*",
+			Action:       rbac.DeleteKey,
+		}),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Api,
+			ResourceID:   req.ApiId,
+			Action:       rbac.DeleteKey,
+		}),
+	))
+	if err != nil {
+		return err
+	}
+
+	api, err := keybulk.FindAPI(ctx, h.DB, h.ApiCache, principal.WorkspaceID, req.ApiId)
+	if err != nil {
+		return err
+	}
+
+	operationID, err := keybulk.Submit(ctx, s, keybulk.Job{
+		DB:          h.DB,
+		Restate:     h.Restate,
+		WorkspaceID: principal.WorkspaceID,
+		API:         api,
+		Kind:        db.KeyBulkOperationsOperationDelete,
+		KeyIDs:      req.KeyIds,
+		Filter:      req.Filter,
+		Operation: &hydrav1.RunKeyBulkOperationRequest_Delete{
+			Delete: &hydrav1.KeyBulkDelete{},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.JSON(http.StatusAccepted, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.KeyBulkOperationAccepted{
+			OperationId: operationID,
+		},
+	})
+}
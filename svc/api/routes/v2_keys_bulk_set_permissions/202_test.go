@@ -0,0 +1,87 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_keys_bulk_set_permissions"
+)
+
+func TestBulkSetPermissionsAccepted(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+		Restate:   testutil.NewRestateIngressClient(t, http.StatusOK),
+		ApiCache:  h.Caches.LiveApiByID,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	key := h.CreateKey(seed.CreateKeyRequest{WorkspaceID: workspace.ID, KeySpaceID: api.KeyAuthID.String})
+	h.CreatePermission(seed.CreatePermissionRequest{
+		WorkspaceID: workspace.ID,
+		Name:        "documents.read",
+		Slug:        "documents.read",
+	})
+
+	headersFor := func(permissions ...string) http.Header {
+		return http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {fmt.Sprintf("Bearer %s", h.CreateRootKey(workspace.ID, permissions...))},
+		}
+	}
+
+	t.Run("missing permissions are created", func(t *testing.T) {
+		headers := headersFor("api.*.update_key", "rbac.*.add_permission_to_key", "rbac.*.remove_permission_from_key", "rbac.*.create_permission")
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			ApiId:       api.ID,
+			KeyIds:      &[]string{key.KeyID},
+			Filter:      nil,
+			Permissions: []string{"documents.read", "documents.write"},
+		})
+		require.Equal(t, http.StatusAccepted, res.Status, "expected 202, received: %s", res.RawBody)
+
+		found, err := db.Query.FindPermissionsBySlugs(context.Background(), h.DB.RO(), db.FindPermissionsBySlugsParams{
+			WorkspaceID: workspace.ID,
+			Slugs:       []string{"documents.write"},
+		})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+
+		operation, err := db.Query.FindKeyBulkOperationByID(context.Background(), h.DB.RO(), res.Body.Data.OperationId)
+		require.NoError(t, err)
+		require.Equal(t, db.KeyBulkOperationsOperationSetPermissions, operation.Operation)
+	})
+
+	t.Run("creating permissions requires create_permission", func(t *testing.T) {
+		headers := headersFor("api.*.update_key", "rbac.*.add_permission_to_key", "rbac.*.remove_permission_from_key")
+		res := testutil.CallRoute[handler.Request, openapi.ForbiddenErrorResponse](h, route, headers, handler.Request{
+			ApiId:       api.ID,
+			KeyIds:      &[]string{key.KeyID},
+			Filter:      nil,
+			Permissions: []string{"documents.delete"},
+		})
+		require.Equal(t, 403, res.Status, "expected 403, received: %s", res.RawBody)
+	})
+
+	t.Run("rbac permissions are required", func(t *testing.T) {
+		headers := headersFor("api.*.update_key")
+		res := testutil.CallRoute[handler.Request, openapi.ForbiddenErrorResponse](h, route, headers, handler.Request{
+			ApiId:       api.ID,
+			KeyIds:      &[]string{key.KeyID},
+			Filter:      nil,
+			Permissions: []string{},
+		})
+		require.Equal(t, 403, res.Status, "expected 403, received: %s", res.RawBody)
+	})
+}
@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	restateingress "github.com/restatedev/sdk-go/ingress"
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/pkg/auditlog"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	dbtype "github.com/unkeyed/unkey/pkg/db/types"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/rbac/permissions"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/pkg/urn"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/keybulk"
+	"github.com/unkeyed/unkey/svc/api/internal/projects"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2KeysBulkSetPermissionsRequestBody
+	Response = openapi.V2KeysBulkSetPermissionsResponseBody
+)
+
+// Handler implements zen.Route interface for the v2 keys bulk set permissions endpoint
+type Handler struct {
+	DB        db.Database
+	Auditlogs auditlogs.AuditLogService
+	Restate   *restateingress.Client
+	ApiCache  cache.Cache[cache.ScopedKey, db.FindLiveApiByIDRow]
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/keys.bulkSetPermissions"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	if err := keybulk.ValidateTarget(req.KeyIds, req.Filter); err != nil {
+		return err
+	}
+
+	err = principal.Authorize(rbac.And(
+		rbac.Or(
+			rbac.T(rbac.Tuple{
+				ResourceType: rbac.Api,
+				ResourceID:   "*",
+				Action:       rbac.UpdateKey,
+			}),
+			rbac.T(rbac.Tuple{
+				ResourceType: rbac.Api,
+				ResourceID:   req.ApiId,
+				Action:       rbac.UpdateKey,
+			}),
+		),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Rbac,
+			ResourceID:   "*",
+			Action:       rbac.AddPermissionToKey,
+		}),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Rbac,
+			ResourceID:   "*",
+			Action:       rbac.RemovePermissionFromKey,
+		}),
+	))
+	if err != nil {
+		return err
+	}
+
+	api, err := keybulk.FindAPI(ctx, h.DB, h.ApiCache, principal.WorkspaceID, req.ApiId)
+	if err != nil {
+		return err
+	}
+
+	toSet, err := h.ensurePermissions(ctx, s, req.Permissions)
+	if err != nil {
+		return err
+	}
+
+	operationID, err := keybulk.Submit(ctx, s, keybulk.Job{
+		DB:          h.DB,
+		Restate:     h.Restate,
+		WorkspaceID: principal.WorkspaceID,
+		API:         api,
+		Kind:        db.KeyBulkOperationsOperationSetPermissions,
+		KeyIDs:      req.KeyIds,
+		Filter:      req.Filter,
+		Operation: &hydrav1.RunKeyBulkOperationRequest_SetPermissions{
+			SetPermissions: &hydrav1.KeyBulkSetPermissions{Permissions: toSet},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.JSON(http.StatusAccepted, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.KeyBulkOperationAccepted{
+			OperationId: operationID,
+		},
+	})
+}
+
+// ensurePermissions resolves the requested slugs and creates the ones that do
+// not exist yet, as keys.setPermissions does, so the worker only ever
+// connects existing permissions.
+func (h *Handler) ensurePermissions(ctx context.Context, s *zen.Session, slugs []string) ([]*hydrav1.KeyBulkPermission, error) {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(slugs) == 0 {
+		return []*hydrav1.KeyBulkPermission{}, nil
+	}
+
+	found, err := db.Query.FindPermissionsBySlugs(ctx, h.DB.RO(), db.FindPermissionsBySlugsParams{
+		WorkspaceID: principal.WorkspaceID,
+		Slugs:       slugs,
+	})
+	if err != nil {
+		return nil, fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"), fault.Public("Failed to lookup permissions to set."),
+		)
+	}
+
+	missing := make(map[string]struct{})
+	for _, slug := range slugs {
+		missing[slug] = struct{}{}
+	}
+
+	toSet := make([]*hydrav1.KeyBulkPermission, 0, len(slugs))
+	for _, permission := range found {
+		delete(missing, permission.Slug)
+		toSet = append(toSet, &hydrav1.KeyBulkPermission{
+			Id:   permission.ID,
+			Slug: permission.Slug,
+			Name: permission.Name,
+		})
+	}
+
+	if len(missing) == 0 {
+		return toSet, nil
+	}
+
+	err = principal.Authorize(rbac.Or(
+		rbac.U(
+			urn.New().Workspace(principal.WorkspaceID).RBAC.Permission("*"),
+			permissions.CreatePermission{},
+		),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Rbac,
+			ResourceID:   "*",
+			Action:       rbac.CreatePermission,
+		}),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	projectID, err := projects.EnsureDefaultProject(ctx, h.DB.RW(), principal.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	toInsert := make([]db.InsertPermissionParams, 0, len(missing))
+	auditLogs := make([]auditlog.AuditLog, 0, len(missing))
+	for slug := range missing {
+		permissionID := uid.New(uid.PermissionPrefix)
+		toInsert = append(toInsert, db.InsertPermissionParams{
+			PermissionID: permissionID,
+			Name:         slug,
+			WorkspaceID:  principal.WorkspaceID,
+			ProjectID:    projectID,
+			Slug:         slug,
+			Description:  dbtype.NullString{String: "", Valid: false},
+			CreatedAtM:   now,
+		})
+		toSet = append(toSet, &hydrav1.KeyBulkPermission{
+			Id:   permissionID,
+			Slug: slug,
+			Name: slug,
+		})
+		auditLogs = append(auditLogs, auditlog.AuditLog{
+			WorkspaceID:   principal.WorkspaceID,
+			Event:         auditlog.PermissionCreateEvent,
+			ActorType:     auditlog.AuditLogActor(principal.Subject.Type),
+			ActorID:       principal.Subject.ID,
+			ActorName:     principal.Subject.Name,
+			ActorMeta:     map[string]any{},
+			Display:       fmt.Sprintf("Created %s (%s)", slug, permissionID),
+			RemoteIP:      s.Location(),
+			UserAgent:     s.UserAgent(),
+			CorrelationID: "",
+			Resources: []auditlog.AuditLogResource{
+				{
+					Type:        auditlog.PermissionResourceType,
+					ID:          permissionID,
+					Name:        slug,
+					DisplayName: slug,
+					Meta: map[string]any{
+						"name": slug,
+						"slug": slug,
+					},
+				},
+			},
+		})
+	}
+
+	err = db.TxRetry(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) error {
+		if err := db.BulkQuery.InsertPermissions(ctx, tx, toInsert); err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database error"),
+				fault.Public("Failed to insert permissions."),
+			)
+		}
+		return h.Auditlogs.Insert(ctx, tx, auditLogs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toSet, nil
+}
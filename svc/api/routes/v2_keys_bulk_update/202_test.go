@@ -0,0 +1,70 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_keys_bulk_update"
+)
+
+func TestBulkUpdateAccepted(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:       h.DB,
+		Restate:  testutil.NewRestateIngressClient(t, http.StatusOK),
+		ApiCache: h.Caches.LiveApiByID,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.update_key")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	key := h.CreateKey(seed.CreateKeyRequest{WorkspaceID: workspace.ID, KeySpaceID: api.KeyAuthID.String})
+
+	req := map[string]any{
+		"apiId":   api.ID,
+		"keyIds":  []string{key.KeyID},
+		"enabled": false,
+		"name":    nil,
+	}
+	res := testutil.CallRoute[map[string]any, handler.Response](h, route, headers, req)
+	require.Equal(t, http.StatusAccepted, res.Status, "expected 202, received: %s", res.RawBody)
+
+	operation, err := db.Query.FindKeyBulkOperationByID(context.Background(), h.DB.RO(), res.Body.Data.OperationId)
+	require.NoError(t, err)
+	require.Equal(t, db.KeyBulkOperationsOperationUpdate, operation.Operation)
+	require.Equal(t, uint32(1), operation.Total)
+
+	t.Run("api of another workspace", func(t *testing.T) {
+		other := h.CreateWorkspace()
+		otherAPI := h.CreateApi(seed.CreateApiRequest{WorkspaceID: other.ID})
+
+		res := testutil.CallRoute[map[string]any, openapi.NotFoundErrorResponse](h, route, headers, map[string]any{
+			"apiId":   otherAPI.ID,
+			"keyIds":  []string{key.KeyID},
+			"enabled": false,
+		})
+		require.Equal(t, 404, res.Status, "expected 404, received: %s", res.RawBody)
+	})
+
+	t.Run("insufficient permissions", func(t *testing.T) {
+		readOnly := h.CreateRootKey(workspace.ID, "api.*.read_key")
+		res := testutil.CallRoute[map[string]any, openapi.ForbiddenErrorResponse](h, route, http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {fmt.Sprintf("Bearer %s", readOnly)},
+		}, req)
+		require.Equal(t, 403, res.Status, "expected 403, received: %s", res.RawBody)
+	})
+}
@@ -0,0 +1,61 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_keys_bulk_update"
+)
+
+func TestBadRequest(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:       h.DB,
+		Restate:  testutil.NewRestateIngressClient(t, http.StatusOK),
+		ApiCache: h.Caches.LiveApiByID,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.update_key")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	t.Run("no field to update", func(t *testing.T) {
+		req := map[string]any{
+			"apiId":  "api_123",
+			"keyIds": []string{"key_123"},
+		}
+		res := testutil.CallRoute[map[string]any, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status)
+		require.Equal(t, "Set at least one of `name`, `meta`, `expires` or `enabled`.", res.Body.Error.Detail)
+	})
+
+	t.Run("neither keyIds nor filter", func(t *testing.T) {
+		req := map[string]any{
+			"apiId":   "api_123",
+			"enabled": false,
+		}
+		res := testutil.CallRoute[map[string]any, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status)
+		require.Equal(t, "Provide either `keyIds` or `filter`, not both and not neither.", res.Body.Error.Detail)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		req := map[string]any{
+			"apiId":   "api_123",
+			"keyIds":  []string{"key_123"},
+			"credits": map[string]any{"remaining": 10},
+		}
+		res := testutil.CallRoute[map[string]any, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status)
+		require.Contains(t, res.Body.Error.Detail, "validate schema")
+	})
+}
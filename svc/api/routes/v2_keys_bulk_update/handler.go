@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	restateingress "github.com/restatedev/sdk-go/ingress"
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/keybulk"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2KeysBulkUpdateRequestBody
+	Response = openapi.V2KeysBulkUpdateResponseBody
+)
+
+// Handler implements zen.Route interface for the v2 keys bulk update endpoint
+type Handler struct {
+	DB       db.Database
+	Restate  *restateingress.Client
+	ApiCache cache.Cache[cache.ScopedKey, db.FindLiveApiByIDRow]
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/keys.bulkUpdate"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	if err := keybulk.ValidateTarget(req.KeyIds, req.Filter); err != nil {
+		return err
+	}
+
+	update, err := buildUpdate(req)
+	if err != nil {
+		return err
+	}
+
+	err = principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Api,
+			ResourceID:   "*",
+			Action:       rbac.UpdateKey,
+		}),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Api,
+			ResourceID:   req.ApiId,
+			Action:       rbac.UpdateKey,
+		}),
+	))
+	if err != nil {
+		return err
+	}
+
+	api, err := keybulk.FindAPI(ctx, h.DB, h.ApiCache, principal.WorkspaceID, req.ApiId)
+	if err != nil {
+		return err
+	}
+
+	operationID, err := keybulk.Submit(ctx, s, keybulk.Job{
+		DB:          h.DB,
+		Restate:     h.Restate,
+		WorkspaceID: principal.WorkspaceID,
+		API:         api,
+		Kind:        db.KeyBulkOperationsOperationUpdate,
+		KeyIDs:      req.KeyIds,
+		Filter:      req.Filter,
+		Operation:   &hydrav1.RunKeyBulkOperationRequest_Update{Update: update},
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.JSON(http.StatusAccepted, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.KeyBulkOperationAccepted{
+			OperationId: operationID,
+		},
+	})
+}
+
+// buildUpdate converts the request's nullable fields into the worker's
+// update, where an omitted field is unset and null sets the clear flag.
+func buildUpdate(req Request) (*hydrav1.KeyBulkUpdate, error) {
+	update := &hydrav1.KeyBulkUpdate{
+		Name:         nil,
+		ClearName:    false,
+		Meta:         nil,
+		ClearMeta:    false,
+		Expires:      nil,
+		ClearExpires: false,
+		Enabled:      req.Enabled,
+	}
+
+	if req.Name.IsSpecified() {
+		if req.Name.IsNull() {
+			update.ClearName = true
+		} else {
+			name := req.Name.MustGet()
+			update.Name = &name
+		}
+	}
+
+	if req.Meta.IsSpecified() {
+		if req.Meta.IsNull() {
+			update.ClearMeta = true
+		} else {
+			metaBytes, err := json.Marshal(req.Meta.MustGet())
+			if err != nil {
+				return nil, fault.Wrap(err,
+					fault.Code(codes.App.Validation.InvalidInput.URN()),
+					fault.Internal("failed to marshal meta"),
+					fault.Public("Invalid metadata format."),
+				)
+			}
+			meta := string(metaBytes)
+			update.Meta = &meta
+		}
+	}
+
+	if req.Expires.IsSpecified() {
+		if req.Expires.IsNull() {
+			update.ClearExpires = true
+		} else {
+			expires := req.Expires.MustGet()
+			update.Expires = &expires
+		}
+	}
+
+	if !req.Name.IsSpecified() && !req.Meta.IsSpecified() && !req.Expires.IsSpecified() && req.Enabled == nil {
+		return nil, fault.New("empty update",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal("bulk update sets no field"),
+			fault.Public("Set at least one of `name`, `meta`, `expires` or `enabled`."),
+		)
+	}
+
+	return update, nil
+}
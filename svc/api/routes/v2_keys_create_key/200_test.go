@@ -2,11 +2,13 @@ package handler_test
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"net/http"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/oapi-codegen/nullable"
 	"github.com/stretchr/testify/require"
@@ -30,6 +32,7 @@ func TestCreateKeySuccess(t *testing.T) {
 		Keys:      h.Keys,
 		Auditlogs: h.Auditlogs,
 		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
@@ -82,6 +85,7 @@ func TestCreateKeyWithURNPermission(t *testing.T) {
 		Keys:      h.Keys,
 		Auditlogs: h.Auditlogs,
 		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
@@ -121,6 +125,7 @@ func TestCreateKeyWithLegacyAPIPermission(t *testing.T) {
 		Keys:      h.Keys,
 		Auditlogs: h.Auditlogs,
 		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
@@ -160,6 +165,7 @@ func TestCreateKeyWithOptionalFields(t *testing.T) {
 		Keys:      h.Keys,
 		Auditlogs: h.Auditlogs,
 		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
@@ -230,6 +236,7 @@ func TestCreateKeyWithEncryption(t *testing.T) {
 		Keys:      h.Keys,
 		Auditlogs: h.Auditlogs,
 		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
@@ -298,6 +305,7 @@ func TestCreateRecoverableKeyWithURNPermissions(t *testing.T) {
 		Keys:      h.Keys,
 		Auditlogs: h.Auditlogs,
 		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
@@ -345,6 +353,7 @@ func TestCreateKeyConcurrentWithSameExternalId(t *testing.T) {
 		Keys:      h.Keys,
 		Auditlogs: h.Auditlogs,
 		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
@@ -424,6 +433,7 @@ func TestCreateKeyWithCreditsRemainingNull(t *testing.T) {
 		Keys:      h.Keys,
 		Auditlogs: h.Auditlogs,
 		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
@@ -472,6 +482,7 @@ func TestCreateKeyAppliesKeySpaceDefaults(t *testing.T) {
 		Keys:      h.Keys,
 		Auditlogs: h.Auditlogs,
 		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
@@ -566,6 +577,36 @@ func TestCreateKeyAppliesKeySpaceDefaults(t *testing.T) {
 			"keyspace default should not apply when request supplies prefix, got %q", res.Body.Data.Key)
 	})
 
+	t.Run("environment prefix overrides keyspace default_prefix", func(t *testing.T) {
+		t.Parallel()
+
+		defaultPrefix := "dash"
+		api := h.CreateApi(seed.CreateApiRequest{
+			WorkspaceID:   h.Resources().UserWorkspace.ID,
+			DefaultPrefix: &defaultPrefix,
+		})
+		err := db.Query.InsertKeySpaceEnvironment(context.Background(), h.DB.RW(), db.InsertKeySpaceEnvironmentParams{
+			WorkspaceID: h.Resources().UserWorkspace.ID,
+			KeyAuthID:   api.KeyAuthID.String,
+			Name:        "test",
+			Prefix:      sql.NullString{String: "sk_test", Valid: true},
+			TestMode:    true,
+			CreatedAtM:  time.Now().UnixMilli(),
+		})
+		require.NoError(t, err)
+
+		res := testutil.CallRoute[handler.Request, handler.Response](
+			h, route, headers, handler.Request{ApiId: api.ID, Environment: ptr.P("test")},
+		)
+		require.Equal(t, 200, res.Status)
+		require.True(t, strings.HasPrefix(res.Body.Data.Key, "sk_test_"),
+			"environment prefix should win, got %q", res.Body.Data.Key)
+
+		key, err := db.Query.FindLiveKeyByID(context.Background(), h.DB.RO(), res.Body.Data.KeyId)
+		require.NoError(t, err)
+		require.Equal(t, "test", key.Environment.String)
+	})
+
 	t.Run("request byteLength overrides keyspace default_bytes", func(t *testing.T) {
 		t.Parallel()
 
@@ -625,6 +666,7 @@ func TestCreateKeyWithRolesAndPermissions(t *testing.T) {
 		Keys:      h.Keys,
 		Auditlogs: h.Auditlogs,
 		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
 	}
 	h.Register(route)
 
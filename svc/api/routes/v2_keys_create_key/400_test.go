@@ -24,6 +24,7 @@ func TestCreateKeyBadRequest(t *testing.T) {
 		Keys:      h.Keys,
 		Auditlogs: h.Auditlogs,
 		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
@@ -185,4 +186,62 @@ func TestCreateKeyBadRequest(t *testing.T) {
 		require.NotNil(t, res.Body)
 		require.Contains(t, res.Body.Error.Detail, "credits.remaining")
 	})
+
+	t.Run("rotation without recoverable should error", func(t *testing.T) {
+		req := handler.Request{
+			ApiId: api.ID,
+			Rotation: &openapi.KeyRotation{
+				Interval:    86400000,
+				GracePeriod: 0,
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status)
+		require.NotNil(t, res.Body)
+		require.Contains(t, res.Body.Error.Detail, "recoverable")
+	})
+
+	t.Run("rotation interval too short", func(t *testing.T) {
+		req := handler.Request{
+			ApiId:       api.ID,
+			Recoverable: ptr.P(true),
+			Rotation: &openapi.KeyRotation{
+				Interval:    3600000, // minimum is one day
+				GracePeriod: 0,
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status)
+		require.NotNil(t, res.Body)
+	})
+
+	t.Run("rotation grace period longer than interval", func(t *testing.T) {
+		req := handler.Request{
+			ApiId:       api.ID,
+			Recoverable: ptr.P(true),
+			Rotation: &openapi.KeyRotation{
+				Interval:    86400000,
+				GracePeriod: 2 * 86400000,
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status)
+		require.NotNil(t, res.Body)
+		require.Contains(t, res.Body.Error.Detail, "rotation.gracePeriod")
+	})
+
+	t.Run("unknown environment", func(t *testing.T) {
+		req := handler.Request{
+			ApiId:       api.ID,
+			Environment: ptr.P("staging"),
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status)
+		require.NotNil(t, res.Body)
+		require.Contains(t, res.Body.Error.Detail, "staging")
+	})
 }
@@ -21,6 +21,7 @@ func TestCreateKeyUnauthorized(t *testing.T) {
 		Keys:      h.Keys,
 		Auditlogs: h.Auditlogs,
 		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
@@ -27,6 +27,7 @@ func TestCreateKeyMissingPermissionsDoNotLeakKeyspace(t *testing.T) {
 		Keys:      h.Keys,
 		Auditlogs: h.Auditlogs,
 		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
@@ -25,6 +25,7 @@ func TestCreateKeyNotFound(t *testing.T) {
 		Keys:      h.Keys,
 		Auditlogs: h.Auditlogs,
 		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
@@ -131,6 +132,7 @@ func TestCreateKeyMissingPermissionsDoNotLeakAPIOrKeyspaceState(t *testing.T) {
 		Keys:      h.Keys,
 		Auditlogs: h.Auditlogs,
 		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
 	}
 	h.Register(route)
 
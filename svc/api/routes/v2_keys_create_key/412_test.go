@@ -26,6 +26,7 @@ func TestPreconditionError(t *testing.T) {
 		Keys:      h.Keys,
 		Vault:     h.Vault,
 		Auditlogs: h.Auditlogs,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
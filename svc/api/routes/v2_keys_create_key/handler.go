@@ -12,9 +12,11 @@ import (
 
 	"github.com/unkeyed/unkey/internal/services/auditlogs"
 	"github.com/unkeyed/unkey/internal/services/keys"
+	"github.com/unkeyed/unkey/internal/services/webhooks"
 	"github.com/unkeyed/unkey/svc/api/openapi"
 
 	"github.com/unkeyed/unkey/gen/rpc/vault"
+	"github.com/unkeyed/unkey/pkg/assert"
 	"github.com/unkeyed/unkey/pkg/auditlog"
 	authprincipal "github.com/unkeyed/unkey/pkg/auth/principal"
 	"github.com/unkeyed/unkey/pkg/codes"
@@ -43,6 +45,7 @@ type Handler struct {
 	Keys      keys.KeyService
 	Auditlogs auditlogs.AuditLogService
 	Vault     vault.VaultServiceClient
+	Webhooks  webhooks.Service
 }
 
 // Method returns the HTTP method this route responds to
@@ -57,12 +60,6 @@ func (h *Handler) Path() string {
 
 // Handle processes the HTTP request
 func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
-	// Mint a correlation ID for this user action so the dashboard can drill
-	// from any one of the audit events (key.create + N permission binds + N
-	// role binds) to the rest. Nested helpers that call Auditlogs.Insert
-	// pick this up via auditlog.CorrelationFrom(ctx).
-	ctx = auditlog.WithCorrelation(ctx, auditlog.NewCorrelationID())
-
 	// 1. Authentication
 	principal, err := s.GetPrincipal()
 	if err != nil {
@@ -141,6 +138,78 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		}
 		req.ExternalId = &src.ExternalID
 	}
+
+	encrypt := ptr.SafeDeref(req.Recoverable, false)
+	if req.Rotation != nil {
+		// The successor's plaintext only exists in the vault, so rotating a
+		// key nobody can recover would lock its owner out.
+		if !encrypt {
+			return fault.New("rotation requires recoverable key",
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Internal("rotation requires recoverable key"), fault.Public("Key rotation requires recoverable=true."),
+			)
+		}
+		if req.Rotation.GracePeriod > req.Rotation.Interval {
+			return fault.New("rotation grace period exceeds interval",
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Internal("rotation grace period exceeds interval"), fault.Public("rotation.gracePeriod must not exceed rotation.interval."),
+			)
+		}
+	}
+
+	if encrypt {
+		if h.Vault == nil {
+			return fault.New("vault missing",
+				fault.Code(codes.App.Precondition.PreconditionFailed.URN()),
+				fault.Public("Vault hasn't been set up."),
+			)
+		}
+
+		err = principal.Authorize(rbac.Or(
+			rbac.T(rbac.Tuple{
+				ResourceType: rbac.Api,
+				ResourceID:   "*",
+				Action:       rbac.EncryptKey,
+			}),
+			rbac.T(rbac.Tuple{
+				ResourceType: rbac.Api,
+				ResourceID:   api.ID,
+				Action:       rbac.EncryptKey,
+			}),
+			rbac.U(urn.New().Workspace(principal.WorkspaceID).Keyspace(api.KeyAuthID.String).Key("*"), permissions.EncryptKey{}),
+		))
+		if err != nil {
+			return apierrors.MaskInsufficientPermissionsAsNotFound(
+				err,
+				codes.Data.Api.NotFound.URN(),
+				"The specified API was not found.",
+			)
+		}
+	}
+
+	return h.CreateKey(ctx, s, req, api)
+}
+
+// CreateKey inserts a new key into the API's keyspace after the route handler
+// has completed its authorization checks. The portal create-key route reuses it
+// once it has applied the portal's key policy to the request.
+func (h *Handler) CreateKey(ctx context.Context, s *zen.Session, req Request, api db.Api) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+	if err := assert.All(
+		assert.Equal(api.WorkspaceID, principal.WorkspaceID, "create key api workspace must match principal"),
+		assert.Equal(api.ID, req.ApiId, "preloaded create key api must match request"),
+	); err != nil {
+		return err
+	}
+
+	// Mint a correlation ID for this user action so the dashboard can drill
+	// from any one of the audit events (key.create + N permission binds + N
+	// role binds) to the rest. Nested helpers that call Auditlogs.Insert
+	// pick this up via auditlog.CorrelationFrom(ctx).
+	ctx = auditlog.WithCorrelation(ctx, auditlog.NewCorrelationID())
 	actor := auditactor.FromPrincipal(principal)
 
 	keySpace, err := db.Query.FindKeySpaceByID(ctx, h.DB.RO(), api.KeyAuthID.String)
@@ -158,14 +227,22 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		)
 	}
 
-	// Per-request values win; otherwise fall back to the keyspace
-	// defaults (`default_prefix` / `default_bytes` configured in the
-	// dashboard, persisted on key_auth). Without these fallbacks the
-	// columns round-trip through the DB for nothing.
+	environment, err := findEnvironment(ctx, h.DB.RO(), keySpace.ID, req.Environment)
+	if err != nil {
+		return err
+	}
+
+	// Per-request values win; otherwise fall back to the environment's
+	// prefix and then the keyspace defaults (`default_prefix` /
+	// `default_bytes` configured in the dashboard, persisted on key_auth).
+	// Without these fallbacks the columns round-trip through the DB for
+	// nothing.
 	var prefix string
 	switch {
 	case req.Prefix != nil:
 		prefix = *req.Prefix
+	case environment != nil && environment.Prefix.Valid:
+		prefix = environment.Prefix.String
 	case keySpace.DefaultPrefix.Valid:
 		prefix = keySpace.DefaultPrefix.String
 	}
@@ -190,9 +267,8 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return err
 	}
 
-	encrypt := ptr.SafeDeref(req.Recoverable, false)
 	var encryption *vaultv1.EncryptResponse
-	if encrypt {
+	if ptr.SafeDeref(req.Recoverable, false) {
 		if h.Vault == nil {
 			return fault.New("vault missing",
 				fault.Code(codes.App.Precondition.PreconditionFailed.URN()),
@@ -200,27 +276,6 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			)
 		}
 
-		err = principal.Authorize(rbac.Or(
-			rbac.T(rbac.Tuple{
-				ResourceType: rbac.Api,
-				ResourceID:   "*",
-				Action:       rbac.EncryptKey,
-			}),
-			rbac.T(rbac.Tuple{
-				ResourceType: rbac.Api,
-				ResourceID:   api.ID,
-				Action:       rbac.EncryptKey,
-			}),
-			rbac.U(urn.New().Workspace(principal.WorkspaceID).Keyspace(keySpace.ID).Key("*"), permissions.EncryptKey{}),
-		))
-		if err != nil {
-			return apierrors.MaskInsufficientPermissionsAsNotFound(
-				err,
-				codes.Data.Api.NotFound.URN(),
-				"The specified API was not found.",
-			)
-		}
-
 		if !keySpace.StoreEncryptedKeys {
 			return fault.New("api not set up for key encryption",
 				fault.Code(codes.App.Precondition.PreconditionFailed.URN()),
@@ -272,9 +327,13 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 				Meta:               sql.NullString{String: "", Valid: false},
 				Expires:            sql.NullTime{Time: time.Time{}, Valid: false},
 				PendingMigrationID: sql.NullString{Valid: false, String: ""},
+				Environment:        sql.NullString{String: "", Valid: false},
 			}
 
 			// Set optional fields
+			if environment != nil {
+				insertKeyParams.Environment = sql.NullString{String: environment.Name, Valid: true}
+			}
 			if req.Name != nil {
 				insertKeyParams.Name = sql.NullString{String: *req.Name, Valid: true}
 			}
@@ -406,6 +465,23 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 				}
 			}
 
+			if req.Rotation != nil {
+				err = db.Query.UpsertKeyRotationPolicy(ctx, tx, db.UpsertKeyRotationPolicyParams{
+					ScopeID:       keyID,
+					WorkspaceID:   principal.WorkspaceID,
+					KeyAuthID:     api.KeyAuthID.String,
+					IntervalMs:    req.Rotation.Interval,
+					GracePeriodMs: req.Rotation.GracePeriod,
+					CreatedAtM:    now,
+				})
+				if err != nil {
+					return fault.Wrap(err,
+						fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+						fault.Internal("database error"), fault.Public("Failed to create key rotation policy."),
+					)
+				}
+			}
+
 			if req.Ratelimits != nil && len(*req.Ratelimits) > 0 {
 				ratelimitsToInsert := make([]db.InsertKeyRatelimitParams, len(*req.Ratelimits))
 				for i, ratelimit := range *req.Ratelimits {
@@ -482,6 +558,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 						WorkspaceID: principal.WorkspaceID,
 						ProjectID:   projectID,
 						Description: dbtype.NullString{String: "", Valid: false},
+						Conditions:  nil,
 						UpdatedAtM:  sql.NullInt64{Int64: 0, Valid: false},
 					})
 				}
@@ -679,6 +756,26 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return txErr
 	}
 
+	keyData := webhooks.KeyData{KeyID: keyID, APIID: req.ApiId}
+	h.Webhooks.Emit(ctx, webhooks.Event{
+		ID:          "",
+		WorkspaceID: principal.WorkspaceID,
+		Type:        webhooks.EventKeyCreated,
+		Data:        keyData,
+		At:          time.Time{},
+	})
+	if req.Expires != nil {
+		// Scheduled now for delivery at the expiry; the worker drops it if the
+		// key is deleted or its expiry changes in the meantime.
+		h.Webhooks.Emit(ctx, webhooks.Event{
+			ID:          "",
+			WorkspaceID: principal.WorkspaceID,
+			Type:        webhooks.EventKeyExpired,
+			Data:        webhooks.KeyExpiredData{KeyID: keyID, APIID: req.ApiId, Expires: *req.Expires},
+			At:          time.UnixMilli(*req.Expires),
+		})
+	}
+
 	return s.JSON(http.StatusOK, Response{
 		Meta: openapi.Meta{
 			RequestId: s.RequestID(),
@@ -689,3 +786,33 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		},
 	})
 }
+
+// findEnvironment returns the keyspace environment a new key is created in,
+// or nil if the request names none. Naming an environment the keyspace does
+// not have is rejected, so a typo cannot create a key that no environment
+// check will ever accept.
+func findEnvironment(ctx context.Context, tx db.DBTX, keySpaceID string, name *string) (*db.KeySpaceEnvironment, error) {
+	if name == nil {
+		return nil, nil
+	}
+
+	environments, err := db.Query.ListKeySpaceEnvironments(ctx, tx, keySpaceID)
+	if err != nil {
+		return nil, fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"), fault.Public("Failed to retrieve the API's environments."),
+		)
+	}
+
+	for _, environment := range environments {
+		if environment.Name == *name {
+			return &environment, nil
+		}
+	}
+
+	return nil, fault.New("unknown environment",
+		fault.Code(codes.App.Validation.InvalidInput.URN()),
+		fault.Internal(fmt.Sprintf("keyspace %s has no environment %q", keySpaceID, *name)),
+		fault.Public(fmt.Sprintf("The API has no environment named %q. Configure it with apis.updateEnvironments first.", *name)),
+	)
+}
@@ -24,7 +24,8 @@ func TestKeyDeleteSuccess(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
-		KeyCache:  h.Caches.VerificationKeyByHash,
+		Caches:    h.Caches.Invalidations,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
@@ -160,7 +161,8 @@ func TestKeyDeleteWithURNPermission(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
-		KeyCache:  h.Caches.VerificationKeyByHash,
+		Caches:    h.Caches.Invalidations,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
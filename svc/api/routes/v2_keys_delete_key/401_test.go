@@ -22,7 +22,8 @@ func TestKeyDeleteUnauthorized(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
-		KeyCache:  h.Caches.VerificationKeyByHash,
+		Caches:    h.Caches.Invalidations,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
@@ -23,7 +23,8 @@ func TestKeyDeleteNotFound(t *testing.T) {
 	route := &handler.Handler{
 		DB:        h.DB,
 		Auditlogs: h.Auditlogs,
-		KeyCache:  h.Caches.VerificationKeyByHash,
+		Caches:    h.Caches.Invalidations,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
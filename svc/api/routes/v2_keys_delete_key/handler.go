@@ -8,10 +8,11 @@ import (
 	"time"
 
 	"github.com/unkeyed/unkey/internal/services/auditlogs"
-	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
+	"github.com/unkeyed/unkey/internal/services/caches"
+	"github.com/unkeyed/unkey/internal/services/webhooks"
+	"github.com/unkeyed/unkey/pkg/assert"
 	"github.com/unkeyed/unkey/pkg/auditlog"
 	authprincipal "github.com/unkeyed/unkey/pkg/auth/principal"
-	"github.com/unkeyed/unkey/pkg/cache"
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/db"
 	"github.com/unkeyed/unkey/pkg/fault"
@@ -31,7 +32,8 @@ type Response = openapi.V2KeysDeleteKeyResponseBody
 type Handler struct {
 	DB        db.Database
 	Auditlogs auditlogs.AuditLogService
-	KeyCache  cache.Cache[string, keysdb.CachedKeyData]
+	Caches    *caches.Invalidator
+	Webhooks  webhooks.Service
 }
 
 // Method returns the HTTP method this route responds to
@@ -57,22 +59,9 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return err
 	}
 
-	key, err := db.Query.FindLiveKeyByID(ctx, h.DB.RO(), req.KeyId)
+	key, err := h.FindLiveKey(ctx, req.KeyId)
 	if err != nil {
-		if db.IsNotFound(err) {
-			return fault.Wrap(
-				err,
-				fault.Code(codes.Data.Key.NotFound.URN()),
-				fault.Internal("key does not exist"),
-				fault.Public("We could not find the requested key."),
-			)
-		}
-
-		return fault.Wrap(err,
-			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
-			fault.Internal("database error"),
-			fault.Public("Failed to retrieve Key information."),
-		)
+		return err
 	}
 
 	// Validate key belongs to authorized workspace
@@ -115,20 +104,10 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			)
 		}
 	}
-	actor := auditactor.FromPrincipal(principal)
 
 	// Permission check
 	err = principal.Authorize(rbac.Or(
-		rbac.T(rbac.Tuple{
-			ResourceType: rbac.Api,
-			ResourceID:   "*",
-			Action:       rbac.DeleteKey,
-		}),
-		rbac.T(rbac.Tuple{
-			ResourceType: rbac.Api,
-			ResourceID:   key.Api.ID,
-			Action:       rbac.DeleteKey,
-		}),
+		DeleteKeyPermissions(key.Api.ID),
 		rbac.U(
 			urn.New().Workspace(principal.WorkspaceID).Keyspace(key.KeyAuthID).Key(req.KeyId),
 			permissions.DeleteKey{},
@@ -138,6 +117,57 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return err
 	}
 
+	return h.DeleteKey(ctx, s, req, key)
+}
+
+// FindLiveKey loads a live key by id, mapping not-found and database failures to
+// the appropriate faults. The portal route reuses this to load a key for its
+// ownership guard before delegating to DeleteKey.
+func (h *Handler) FindLiveKey(ctx context.Context, keyID string) (db.FindLiveKeyByIDRow, error) {
+	var zero db.FindLiveKeyByIDRow
+
+	key, err := db.Query.FindLiveKeyByID(ctx, h.DB.RO(), keyID)
+	if err != nil {
+		if db.IsNotFound(err) {
+			return zero, fault.Wrap(
+				err,
+				fault.Code(codes.Data.Key.NotFound.URN()),
+				fault.Internal("key does not exist"),
+				fault.Public("We could not find the requested key."),
+			)
+		}
+
+		return zero, fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to retrieve Key information."),
+		)
+	}
+
+	return key, nil
+}
+
+// DeleteKey deletes a pre-loaded key after the route handler has completed its
+// authorization and scope checks.
+func (h *Handler) DeleteKey(
+	ctx context.Context,
+	s *zen.Session,
+	req Request,
+	key db.FindLiveKeyByIDRow,
+) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+	if err := assert.All(
+		assert.Equal(key.WorkspaceID, principal.WorkspaceID, "delete key workspace must match principal"),
+		assert.Equal(key.ID, req.KeyId, "preloaded delete key must match request"),
+	); err != nil {
+		return err
+	}
+
+	actor := auditactor.FromPrincipal(principal)
+
 	err = db.TxRetry(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) (err error) {
 		description := "Deleted"
 		if ptr.SafeDeref(req.Permanent) {
@@ -188,7 +218,14 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return err
 	}
 
-	h.KeyCache.Remove(ctx, key.Hash)
+	h.Caches.Invalidate(ctx, caches.KeyEntity(key.WorkspaceID, key.ID, key.Hash))
+	h.Webhooks.Emit(ctx, webhooks.Event{
+		ID:          "",
+		WorkspaceID: principal.WorkspaceID,
+		Type:        webhooks.EventKeyDeleted,
+		Data:        webhooks.KeyData{KeyID: key.ID, APIID: key.Api.ID},
+		At:          time.Time{},
+	})
 
 	return s.JSON(http.StatusOK, Response{
 		Meta: openapi.Meta{
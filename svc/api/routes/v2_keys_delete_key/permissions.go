@@ -0,0 +1,28 @@
+package handler
+
+import "github.com/unkeyed/unkey/pkg/rbac"
+
+// DeleteKeyPermissions is the api-scoped legacy-tuple requirement for deleting
+// a key in a keyspace.
+//
+// It is exported because the portal session route needs the same requirement as
+// its authorization ceiling for the keys:delete scope: a portal may never let an
+// end user delete a key this route would have refused to delete.
+//
+// No URN leaf is emitted here. This route Ors its own URN arm on top, because a
+// separate migration moves all of authorization to URN-only and half-converting
+// it from here would leave call sites in two different worlds.
+func DeleteKeyPermissions(apiID string) rbac.PermissionQuery {
+	return rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Api,
+			ResourceID:   "*",
+			Action:       rbac.DeleteKey,
+		}),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Api,
+			ResourceID:   apiID,
+			Action:       rbac.DeleteKey,
+		}),
+	)
+}
@@ -0,0 +1,121 @@
+package handler_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_keys_get_bulk_operation"
+)
+
+// createOperation inserts a bulk operation over keyIDs the way the bulk routes
+// do, before the worker has touched it.
+func createOperation(t *testing.T, h *testutil.Harness, workspaceID, apiID string, keyIDs ...string) string {
+	t.Helper()
+
+	operationID := uid.New(uid.KeyBulkOperationPrefix)
+	ctx := context.Background()
+	require.NoError(t, db.Query.InsertKeyBulkOperation(ctx, h.DB.RW(), db.InsertKeyBulkOperationParams{
+		ID:          operationID,
+		WorkspaceID: workspaceID,
+		ApiID:       apiID,
+		Operation:   db.KeyBulkOperationsOperationDelete,
+		Total:       uint32(len(keyIDs)),
+		CreatedAtM:  time.Now().UnixMilli(),
+	}))
+	for _, keyID := range keyIDs {
+		require.NoError(t, db.Query.InsertKeyBulkOperationItem(ctx, h.DB.RW(), db.InsertKeyBulkOperationItemParams{
+			OperationID: operationID,
+			KeyID:       keyID,
+		}))
+	}
+	return operationID
+}
+
+func TestGetBulkOperation(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{DB: h.DB}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.read_key")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+
+	t.Run("reports status and items", func(t *testing.T) {
+		operationID := createOperation(t, h, workspace.ID, api.ID, "key_a", "key_b")
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			OperationId: operationID,
+			Limit:       nil,
+			Cursor:      nil,
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.Equal(t, operationID, res.Body.Data.OperationId)
+		require.Equal(t, api.ID, res.Body.Data.ApiId)
+		require.Equal(t, openapi.KeyBulkOperationDelete, res.Body.Data.Operation)
+		require.Equal(t, openapi.V2KeysGetBulkOperationResponseDataStatusPending, res.Body.Data.Status)
+		require.Equal(t, int64(2), res.Body.Data.Total)
+		require.Nil(t, res.Body.Data.CompletedAt)
+		require.Len(t, res.Body.Data.Items, 2)
+		require.Equal(t, "key_a", res.Body.Data.Items[0].KeyId)
+		require.Equal(t, openapi.V2KeysGetBulkOperationResponseDataItemsStatusPending, res.Body.Data.Items[0].Status)
+		require.False(t, res.Body.Pagination.HasMore)
+	})
+
+	t.Run("paginates items", func(t *testing.T) {
+		operationID := createOperation(t, h, workspace.ID, api.ID, "key_1", "key_2", "key_3")
+
+		seen := []string{}
+		var cursor *string
+		for range 3 {
+			res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+				OperationId: operationID,
+				Limit:       ptr.P(2),
+				Cursor:      cursor,
+			})
+			require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+			for _, item := range res.Body.Data.Items {
+				seen = append(seen, item.KeyId)
+			}
+			if !res.Body.Pagination.HasMore {
+				break
+			}
+			cursor = res.Body.Pagination.Cursor
+		}
+		require.Equal(t, []string{"key_1", "key_2", "key_3"}, seen)
+	})
+
+	t.Run("reports failure", func(t *testing.T) {
+		operationID := createOperation(t, h, workspace.ID, api.ID, "key_x")
+		require.NoError(t, db.Query.MarkKeyBulkOperationFailed(context.Background(), h.DB.RW(), db.MarkKeyBulkOperationFailedParams{
+			Error: sql.NullString{String: "boom", Valid: true},
+			Now:   sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+			ID:    operationID,
+		}))
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			OperationId: operationID,
+			Limit:       nil,
+			Cursor:      nil,
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.Equal(t, openapi.V2KeysGetBulkOperationResponseDataStatusFailed, res.Body.Data.Status)
+		require.Equal(t, ptr.P("boom"), res.Body.Data.Error)
+		require.NotNil(t, res.Body.Data.CompletedAt)
+	})
+}
@@ -0,0 +1,50 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_keys_get_bulk_operation"
+)
+
+func TestNotFound(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{DB: h.DB}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.read_key")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	t.Run("operation does not exist", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, headers, handler.Request{
+			OperationId: "kbop_does_not_exist",
+			Limit:       nil,
+			Cursor:      nil,
+		})
+		require.Equal(t, 404, res.Status, "expected 404, received: %s", res.RawBody)
+		require.Equal(t, "The requested bulk operation does not exist.", res.Body.Error.Detail)
+	})
+
+	t.Run("operation of another workspace", func(t *testing.T) {
+		other := h.CreateWorkspace()
+		api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: other.ID})
+		operationID := createOperation(t, h, other.ID, api.ID, "key_a")
+
+		res := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, headers, handler.Request{
+			OperationId: operationID,
+			Limit:       nil,
+			Cursor:      nil,
+		})
+		require.Equal(t, 404, res.Status, "expected 404, received: %s", res.RawBody)
+	})
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/pagination"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2KeysGetBulkOperationRequestBody
+	Response = openapi.V2KeysGetBulkOperationResponseBody
+	Item     = struct {
+		Error  *string                                               `json:"error,omitempty"`
+		KeyId  string                                                `json:"keyId"`
+		Status openapi.V2KeysGetBulkOperationResponseDataItemsStatus `json:"status"`
+	}
+)
+
+// Handler implements zen.Route interface for the v2 keys get bulk operation endpoint
+type Handler struct {
+	DB db.Database
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/keys.getBulkOperation"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	p := pagination.Parse(req.Limit, req.Cursor, 100)
+	var pkCursor uint64
+	if p.Cursor != "" {
+		pkCursor, err = strconv.ParseUint(p.Cursor, 10, 64)
+		if err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Internal("invalid cursor"),
+				fault.Public("The cursor is invalid. Pass the cursor of the previous response unchanged."),
+			)
+		}
+	}
+
+	operation, err := db.Query.FindKeyBulkOperationByID(ctx, h.DB.RO(), req.OperationId)
+	if err != nil && !db.IsNotFound(err) {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to retrieve bulk operation."),
+		)
+	}
+	if db.IsNotFound(err) || operation.WorkspaceID != principal.WorkspaceID {
+		return fault.New("bulk operation not found",
+			fault.Code(codes.Data.KeyBulkOperation.NotFound.URN()),
+			fault.Internal("bulk operation not found or belongs to another workspace"),
+			fault.Public("The requested bulk operation does not exist."),
+		)
+	}
+
+	err = principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Api,
+			ResourceID:   "*",
+			Action:       rbac.ReadKey,
+		}),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Api,
+			ResourceID:   operation.ApiID,
+			Action:       rbac.ReadKey,
+		}),
+	))
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.Query.ListKeyBulkOperationItems(ctx, h.DB.RO(), db.ListKeyBulkOperationItemsParams{
+		OperationID: operation.ID,
+		PkCursor:    pkCursor,
+		Limit:       p.FetchLimit(),
+	})
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to retrieve bulk operation items."),
+		)
+	}
+
+	rows, pg := pagination.Paginate(rows, p, func(r db.ListKeyBulkOperationItemsRow) string {
+		return strconv.FormatUint(r.Pk, 10)
+	})
+
+	items := make([]Item, len(rows))
+	for i, row := range rows {
+		items[i] = Item{
+			Error:  nil,
+			KeyId:  row.KeyID,
+			Status: openapi.V2KeysGetBulkOperationResponseDataItemsStatus(row.Status),
+		}
+		if row.Error.Valid {
+			items[i].Error = ptr.P(row.Error.String)
+		}
+	}
+
+	data := openapi.V2KeysGetBulkOperationResponseData{
+		OperationId: operation.ID,
+		ApiId:       operation.ApiID,
+		Operation:   openapi.V2KeysGetBulkOperationResponseDataOperation(operation.Operation),
+		Status:      openapi.V2KeysGetBulkOperationResponseDataStatus(operation.Status),
+		Total:       int64(operation.Total),
+		Succeeded:   int64(operation.Succeeded),
+		Failed:      int64(operation.Failed),
+		Error:       nil,
+		CreatedAt:   operation.CreatedAtM,
+		CompletedAt: nil,
+		Items:       items,
+	}
+	if operation.Error.Valid {
+		data.Error = ptr.P(operation.Error.String)
+	}
+	if operation.CompletedAtM.Valid {
+		data.CompletedAt = ptr.P(operation.CompletedAtM.Int64)
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data:       data,
+		Pagination: pg,
+	})
+}
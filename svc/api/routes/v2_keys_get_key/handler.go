@@ -127,6 +127,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		Identity:    nil,
 		Permissions: nil,
 		Roles:       nil,
+		Rotation:    nil,
 		CreatedAt:   keyData.Key.CreatedAtM,
 		Enabled:     keyData.Key.Enabled,
 		KeyId:       keyData.Key.ID,
@@ -162,6 +163,26 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		}
 	}
 
+	// Set rotation, the key's own policy winning over its keyspace's
+	for _, scopeID := range []string{keyData.Key.ID, keyData.Key.KeyAuthID} {
+		policy, policyErr := db.Query.FindKeyRotationPolicyByScopeID(ctx, h.DB.RO(), scopeID)
+		if policyErr != nil {
+			if db.IsNotFound(policyErr) {
+				continue
+			}
+			return fault.Wrap(policyErr,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database error"),
+				fault.Public("Failed to retrieve key rotation policy."),
+			)
+		}
+		response.Rotation = &openapi.KeyRotation{
+			Interval:    policy.IntervalMs,
+			GracePeriod: policy.GracePeriodMs,
+		}
+		break
+	}
+
 	// Set identity
 	if keyData.Identity != nil {
 		response.Identity = &openapi.Identity{
@@ -169,6 +190,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			Ratelimits: nil,
 			Id:         keyData.Identity.ID,
 			ExternalId: keyData.Identity.ExternalID,
+			Credits:    nil,
 		}
 
 		if len(keyData.Identity.Meta) > 0 {
@@ -185,6 +185,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 				Name:               sql.NullString{Valid: name != "", String: name},
 				Meta:               sql.NullString{Valid: false, String: ""},
 				PendingMigrationID: sql.NullString{Valid: true, String: migration.ID},
+				Environment:        sql.NullString{Valid: false, String: ""},
 				ForWorkspaceID:     sql.NullString{Valid: false, String: ""},
 				IdentityID:         sql.NullString{Valid: false, String: ""},
 				Expires:            sql.NullTime{Valid: false, Time: time.Time{}},
@@ -6,9 +6,8 @@ import (
 	"net/http"
 
 	"github.com/unkeyed/unkey/internal/services/auditlogs"
-	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
+	"github.com/unkeyed/unkey/internal/services/caches"
 	"github.com/unkeyed/unkey/pkg/auditlog"
-	"github.com/unkeyed/unkey/pkg/cache"
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/db"
 	"github.com/unkeyed/unkey/pkg/fault"
@@ -17,6 +16,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/rbac/permissions"
 	"github.com/unkeyed/unkey/pkg/urn"
 	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/conditions"
 	"github.com/unkeyed/unkey/svc/api/openapi"
 )
 
@@ -29,7 +29,7 @@ type (
 type Handler struct {
 	DB        db.Database
 	Auditlogs auditlogs.AuditLogService
-	KeyCache  cache.Cache[string, keysdb.CachedKeyData]
+	Caches    *caches.Invalidator
 }
 
 // Method returns the HTTP method this route responds to
@@ -207,7 +207,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			return err
 		}
 
-		h.KeyCache.Remove(ctx, key.Hash)
+		h.Caches.Invalidate(ctx, caches.KeyEntity(key.WorkspaceID, key.ID, key.Hash))
 	}
 
 	responseData := make(openapi.V2KeysRemoveRolesResponseData, 0)
@@ -230,6 +230,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 				Name:        permission.Name,
 				Slug:        permission.Slug,
 				Description: permission.Description.String,
+				Conditions:  conditions.ToResponse(permission.Conditions),
 			}
 
 			r.Permissions = append(r.Permissions, perm)
@@ -11,6 +11,7 @@ import (
 	vaultv1 "github.com/unkeyed/unkey/gen/proto/vault/v1"
 	"github.com/unkeyed/unkey/internal/services/auditlogs"
 	"github.com/unkeyed/unkey/internal/services/keys"
+	"github.com/unkeyed/unkey/internal/services/webhooks"
 	"github.com/unkeyed/unkey/svc/api/openapi"
 
 	"github.com/unkeyed/unkey/gen/rpc/vault"
@@ -38,6 +39,7 @@ type Handler struct {
 	Keys      keys.KeyService
 	Auditlogs auditlogs.AuditLogService
 	Vault     vault.VaultServiceClient
+	Webhooks  webhooks.Service
 }
 
 // Method returns the HTTP method this route responds to
@@ -184,8 +186,30 @@ func (h *Handler) RerollKey(
 		}
 	}
 
+	// The new key inherits the key's own rotation schedule; a keyspace
+	// schedule applies to it anyway.
+	rotationPolicy, err := db.Query.FindKeyRotationPolicyByScopeID(ctx, h.DB.RO(), key.ID)
+	hasRotationPolicy := err == nil
+	if err != nil && !db.IsNotFound(err) {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"), fault.Public("Failed to retrieve key rotation policy."),
+		)
+	}
+
 	now := time.Now().UnixMilli()
 
+	// Calculate the desired expiry time (rounded up to next minute)
+	expiration := time.Now().Add(time.Millisecond * time.Duration(req.Expiration))
+	// Round up to next minute (ceil)
+	if expiration.Truncate(time.Minute) != expiration {
+		expiration = expiration.Truncate(time.Minute).Add(time.Minute)
+	}
+
+	if req.Expiration == 0 {
+		expiration = time.Now()
+	}
+
 	err = retry.New(
 		retry.Attempts(5),
 		retry.ShouldRetry(db.IsDuplicateKeyError),
@@ -211,6 +235,7 @@ func (h *Handler) RerollKey(
 				Meta:               key.Meta,
 				Expires:            key.Expires,
 				PendingMigrationID: sql.NullString{Valid: false, String: ""},
+				Environment:        key.Environment,
 			})
 			if err != nil {
 				return fault.Wrap(err,
@@ -308,17 +333,6 @@ func (h *Handler) RerollKey(
 				}
 			}
 
-			// Calculate the desired expiry time (rounded up to next minute)
-			expiration := time.Now().Add(time.Millisecond * time.Duration(req.Expiration))
-			// Round up to next minute (ceil)
-			if expiration.Truncate(time.Minute) != expiration {
-				expiration = expiration.Truncate(time.Minute).Add(time.Minute)
-			}
-
-			if req.Expiration == 0 {
-				expiration = time.Now()
-			}
-
 			//nolint: exhaustruct
 			err = db.Query.UpdateKey(ctx, tx, db.UpdateKeyParams{
 				ID:               req.KeyId,
@@ -336,6 +350,40 @@ func (h *Handler) RerollKey(
 				)
 			}
 
+			// Recording the successor stops the rotation cron from issuing
+			// another one for the old key.
+			err = db.Query.InsertKeyRotation(ctx, tx, db.InsertKeyRotationParams{
+				KeyID:          key.ID,
+				SuccessorKeyID: keyID,
+				WorkspaceID:    key.WorkspaceID,
+				KeyAuthID:      key.KeyAuthID,
+				Reason:         db.KeyRotationsReasonReroll,
+				CreatedAtM:     now,
+			})
+			if err != nil {
+				return fault.Wrap(err,
+					fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+					fault.Internal("database error"), fault.Public("Failed to record key rotation."),
+				)
+			}
+
+			if hasRotationPolicy {
+				err = db.Query.UpsertKeyRotationPolicy(ctx, tx, db.UpsertKeyRotationPolicyParams{
+					ScopeID:       keyID,
+					WorkspaceID:   key.WorkspaceID,
+					KeyAuthID:     key.KeyAuthID,
+					IntervalMs:    rotationPolicy.IntervalMs,
+					GracePeriodMs: rotationPolicy.GracePeriodMs,
+					CreatedAtM:    now,
+				})
+				if err != nil {
+					return fault.Wrap(err,
+						fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+						fault.Internal("database error"), fault.Public("Failed to copy key rotation policy."),
+					)
+				}
+			}
+
 			var auditLogs []auditlog.AuditLog
 			auditLogs = append(auditLogs, auditlog.AuditLog{
 				WorkspaceID:   principal.WorkspaceID,
@@ -385,6 +433,32 @@ func (h *Handler) RerollKey(
 		return err
 	}
 
+	h.Webhooks.Emit(ctx, webhooks.Event{
+		ID:          "",
+		WorkspaceID: key.WorkspaceID,
+		Type:        webhooks.EventKeyRerolled,
+		Data:        webhooks.KeyRerolledData{KeyID: keyID, PreviousKeyID: req.KeyId, APIID: key.Api.ID},
+		At:          time.Time{},
+	})
+	// The old key now expires at the end of the grace period and the new key
+	// inherits its former expiry, so both get a fresh key.expired event.
+	h.Webhooks.Emit(ctx, webhooks.Event{
+		ID:          "",
+		WorkspaceID: key.WorkspaceID,
+		Type:        webhooks.EventKeyExpired,
+		Data:        webhooks.KeyExpiredData{KeyID: req.KeyId, APIID: key.Api.ID, Expires: expiration.UnixMilli()},
+		At:          expiration,
+	})
+	if key.Expires.Valid {
+		h.Webhooks.Emit(ctx, webhooks.Event{
+			ID:          "",
+			WorkspaceID: key.WorkspaceID,
+			Type:        webhooks.EventKeyExpired,
+			Data:        webhooks.KeyExpiredData{KeyID: keyID, APIID: key.Api.ID, Expires: key.Expires.Time.UnixMilli()},
+			At:          key.Expires.Time,
+		})
+	}
+
 	return s.JSON(http.StatusOK, Response{
 		Meta: openapi.Meta{
 			RequestId: s.RequestID(),
@@ -8,9 +8,8 @@ import (
 	"time"
 
 	"github.com/unkeyed/unkey/internal/services/auditlogs"
-	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
+	"github.com/unkeyed/unkey/internal/services/caches"
 	"github.com/unkeyed/unkey/pkg/auditlog"
-	"github.com/unkeyed/unkey/pkg/cache"
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/db"
 	dbtype "github.com/unkeyed/unkey/pkg/db/types"
@@ -20,6 +19,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/uid"
 	"github.com/unkeyed/unkey/pkg/urn"
 	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/conditions"
 	"github.com/unkeyed/unkey/svc/api/internal/projects"
 	"github.com/unkeyed/unkey/svc/api/openapi"
 )
@@ -33,7 +33,7 @@ type (
 type Handler struct {
 	DB        db.Database
 	Auditlogs auditlogs.AuditLogService
-	KeyCache  cache.Cache[string, keysdb.CachedKeyData]
+	Caches    *caches.Invalidator
 }
 
 // Method returns the HTTP method this route responds to
@@ -189,6 +189,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			ProjectID:   projectID,
 			Slug:        perm,
 			Description: dbtype.NullString{String: "", Valid: false},
+			Conditions:  nil,
 			CreatedAtM:  now,
 			UpdatedAtM:  sql.NullInt64{Int64: now, Valid: true},
 		})
@@ -389,12 +390,13 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return err
 	}
 
-	h.KeyCache.Remove(ctx, key.Hash)
+	h.Caches.Invalidate(ctx, caches.KeyEntity(key.WorkspaceID, key.ID, key.Hash))
 
 	responseData := make(openapi.V2KeysSetPermissionsResponseData, 0)
 	for _, permission := range permissionsToSet {
 		perm := openapi.Permission{
 			Description: permission.Description.String,
+			Conditions:  conditions.ToResponse(permission.Conditions),
 			Id:          permission.ID,
 			Name:        permission.Name,
 			Slug:        permission.Slug,
@@ -27,7 +27,7 @@ func TestKeyUpdateCreditsSuccess(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
 	}
 
@@ -229,7 +229,7 @@ func TestKeyUpdateCreditsWithURNPermission(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
 	}
 
@@ -19,7 +19,7 @@ func TestKeyUpdateCreditsUnauthorized(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
 	}
 
@@ -20,7 +20,7 @@ func TestKeyUpdateCreditsForbidden(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
 	}
 
@@ -18,7 +18,7 @@ func TestUpdateKeyCreditsNotFound(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
 	}
 
@@ -8,10 +8,9 @@ import (
 
 	"github.com/oapi-codegen/nullable"
 	"github.com/unkeyed/unkey/internal/services/auditlogs"
-	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
+	"github.com/unkeyed/unkey/internal/services/caches"
 	"github.com/unkeyed/unkey/internal/services/usagelimiter"
 	"github.com/unkeyed/unkey/pkg/auditlog"
-	"github.com/unkeyed/unkey/pkg/cache"
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/db"
 	"github.com/unkeyed/unkey/pkg/fault"
@@ -30,7 +29,7 @@ type Response = openapi.V2KeysUpdateCreditsResponseBody
 type Handler struct {
 	DB           db.Database
 	Auditlogs    auditlogs.AuditLogService
-	KeyCache     cache.Cache[string, keysdb.CachedKeyData]
+	Caches       *caches.Invalidator
 	UsageLimiter usagelimiter.Service
 }
 
@@ -267,7 +266,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		}
 	}
 
-	h.KeyCache.Remove(ctx, key.Hash)
+	h.Caches.Invalidate(ctx, caches.KeyEntity(key.WorkspaceID, key.ID, key.Hash))
 	if err := h.UsageLimiter.Invalidate(ctx, key.ID); err != nil {
 		logger.Error("Failed to invalidate usage limit",
 			"error", err.Error(),
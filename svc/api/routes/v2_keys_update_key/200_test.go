@@ -31,8 +31,9 @@ func TestUpdateKeySuccess(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
+		Webhooks:     h.Webhooks,
 	}
 
 	h.Register(route)
@@ -133,8 +134,9 @@ func TestUpdateKeyWithURNPermission(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
+		Webhooks:     h.Webhooks,
 	}
 
 	h.Register(route)
@@ -177,8 +179,9 @@ func TestUpdateKeyWithTranslatedAdminPermission(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
+		Webhooks:     h.Webhooks,
 	}
 
 	h.Register(route)
@@ -221,8 +224,9 @@ func TestUpdateKeyUpdateAllFields(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
+		Webhooks:     h.Webhooks,
 	}
 
 	h.Register(route)
@@ -296,8 +300,9 @@ func TestKeyUpdateCreditsInvalidatesCache(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
+		Webhooks:     h.Webhooks,
 	}
 
 	h.Register(route)
@@ -380,8 +385,9 @@ func TestUpdateKeyConcurrentWithSameExternalId(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
+		Webhooks:     h.Webhooks,
 	}
 
 	h.Register(route)
@@ -474,8 +480,9 @@ func TestUpdateKeyConcurrentRatelimits(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
+		Webhooks:     h.Webhooks,
 	}
 
 	h.Register(route)
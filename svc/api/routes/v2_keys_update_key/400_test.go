@@ -22,8 +22,9 @@ func TestUpdateKeyInvalidRefillConfig(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
+		Webhooks:     h.Webhooks,
 	}
 
 	h.Register(route)
@@ -68,3 +69,52 @@ func TestUpdateKeyInvalidRefillConfig(t *testing.T) {
 		require.Contains(t, res.Body.Error.Detail, "must not be provided when the refill interval is")
 	})
 }
+
+func TestUpdateKeyInvalidRotation(t *testing.T) {
+	t.Parallel()
+
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:           h.DB,
+		Auditlogs:    h.Auditlogs,
+		Caches:       h.Caches.Invalidations,
+		UsageLimiter: h.UsageLimiter,
+		Webhooks:     h.Webhooks,
+	}
+
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "api.*.update_key")
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	api := h.CreateApi(seed.CreateApiRequest{
+		WorkspaceID: h.Resources().UserWorkspace.ID,
+	})
+
+	keyResponse := h.CreateKey(seed.CreateKeyRequest{
+		WorkspaceID: h.Resources().UserWorkspace.ID,
+		KeySpaceID:  api.KeyAuthID.String,
+		Name:        ptr.P("test"),
+	})
+
+	t.Run("reject rotation on non-recoverable key", func(t *testing.T) {
+		t.Parallel()
+		req := handler.Request{
+			KeyId: keyResponse.KeyID,
+			Rotation: nullable.NewNullableWithValue(openapi.UpdateKeyRotation{
+				Interval:    86400000,
+				GracePeriod: 0,
+			}),
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status)
+		require.NotNil(t, res.Body)
+		require.Contains(t, res.Body.Error.Detail, "recoverable")
+	})
+}
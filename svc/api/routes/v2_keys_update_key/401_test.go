@@ -20,8 +20,9 @@ func TestUpdateKeyUnauthorized(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
+		Webhooks:     h.Webhooks,
 	}
 
 	h.Register(route)
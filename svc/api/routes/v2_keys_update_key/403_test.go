@@ -38,8 +38,9 @@ func TestUpdateKeyCorrectPermissions(t *testing.T) {
 			route := &handler.Handler{
 				DB:           h.DB,
 				Auditlogs:    h.Auditlogs,
-				KeyCache:     h.Caches.VerificationKeyByHash,
+				Caches:       h.Caches.Invalidations,
 				UsageLimiter: h.UsageLimiter,
+				Webhooks:     h.Webhooks,
 			}
 
 			h.Register(route)
@@ -89,8 +90,9 @@ func TestUpdateKeyInsufficientPermissions(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
+		Webhooks:     h.Webhooks,
 	}
 
 	h.Register(route)
@@ -133,8 +135,9 @@ func TestUpdateKeyCrossWorkspaceIsolation(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
+		Webhooks:     h.Webhooks,
 	}
 
 	h.Register(route)
@@ -21,8 +21,9 @@ func TestUpdateKeyNotFound(t *testing.T) {
 	route := &handler.Handler{
 		DB:           h.DB,
 		Auditlogs:    h.Auditlogs,
-		KeyCache:     h.Caches.VerificationKeyByHash,
+		Caches:       h.Caches.Invalidations,
 		UsageLimiter: h.UsageLimiter,
+		Webhooks:     h.Webhooks,
 	}
 
 	h.Register(route)
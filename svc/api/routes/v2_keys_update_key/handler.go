@@ -9,13 +9,13 @@ import (
 	"time"
 
 	"github.com/unkeyed/unkey/internal/services/auditlogs"
-	keysdb "github.com/unkeyed/unkey/internal/services/keys/db"
+	"github.com/unkeyed/unkey/internal/services/caches"
 	"github.com/unkeyed/unkey/internal/services/usagelimiter"
+	"github.com/unkeyed/unkey/internal/services/webhooks"
 	"github.com/unkeyed/unkey/svc/api/internal/projects"
 	"github.com/unkeyed/unkey/svc/api/openapi"
 
 	"github.com/unkeyed/unkey/pkg/auditlog"
-	"github.com/unkeyed/unkey/pkg/cache"
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/db"
 	dbtype "github.com/unkeyed/unkey/pkg/db/types"
@@ -36,8 +36,9 @@ type (
 type Handler struct {
 	DB           db.Database
 	Auditlogs    auditlogs.AuditLogService
-	KeyCache     cache.Cache[string, keysdb.CachedKeyData]
+	Caches       *caches.Invalidator
 	UsageLimiter usagelimiter.Service
+	Webhooks     webhooks.Service
 }
 
 // Method returns the HTTP method this route responds to
@@ -113,6 +114,24 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return err
 	}
 
+	if req.Rotation.IsSpecified() && !req.Rotation.IsNull() {
+		rotation := req.Rotation.MustGet()
+		// The successor's plaintext only exists in the vault, so rotating a
+		// key nobody can recover would lock its owner out.
+		if !key.EncryptionKeyID.Valid {
+			return fault.New("rotation requires recoverable key",
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Internal("rotation requires recoverable key"), fault.Public("Only recoverable keys can be rotated."),
+			)
+		}
+		if rotation.GracePeriod > rotation.Interval {
+			return fault.New("rotation grace period exceeds interval",
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Internal("rotation grace period exceeds interval"), fault.Public("rotation.gracePeriod must not exceed rotation.interval."),
+			)
+		}
+	}
+
 	projectID, err := projects.EnsureDefaultProject(ctx, h.DB.RW(), principal.WorkspaceID)
 	if err != nil {
 		return err
@@ -308,6 +327,29 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			)
 		}
 
+		if req.Rotation.IsSpecified() {
+			if req.Rotation.IsNull() {
+				err = db.Query.DeleteKeyRotationPolicyByScopeID(ctx, tx, key.ID)
+			} else {
+				rotation := req.Rotation.MustGet()
+				err = db.Query.UpsertKeyRotationPolicy(ctx, tx, db.UpsertKeyRotationPolicyParams{
+					ScopeID:       key.ID,
+					WorkspaceID:   key.WorkspaceID,
+					KeyAuthID:     key.KeyAuthID,
+					IntervalMs:    rotation.Interval,
+					GracePeriodMs: rotation.GracePeriod,
+					CreatedAtM:    update.Now.Int64,
+				})
+			}
+			if err != nil {
+				return fault.Wrap(err,
+					fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+					fault.Internal("database error"),
+					fault.Public("Failed to update key rotation policy."),
+				)
+			}
+		}
+
 		if req.Ratelimits != nil {
 			var existingRatelimits []db.ListRatelimitsByKeyIDRow
 			existingRatelimits, err = db.Query.ListRatelimitsByKeyID(ctx, tx, sql.NullString{String: key.ID, Valid: true})
@@ -610,7 +652,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return txErr
 	}
 
-	h.KeyCache.Remove(ctx, key.Hash)
+	h.Caches.Invalidate(ctx, caches.KeyEntity(key.WorkspaceID, key.ID, key.Hash))
 	if req.Credits.IsSpecified() {
 		if err := h.UsageLimiter.Invalidate(ctx, key.ID); err != nil {
 			logger.Error("Failed to invalidate usage limit",
@@ -620,6 +662,19 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		}
 	}
 
+	// A changed expiry supersedes any key.expired event scheduled before; the
+	// worker drops those because they no longer match the stored expiry.
+	if req.Expires.IsSpecified() && !req.Expires.IsNull() {
+		expires := req.Expires.MustGet()
+		h.Webhooks.Emit(ctx, webhooks.Event{
+			ID:          "",
+			WorkspaceID: principal.WorkspaceID,
+			Type:        webhooks.EventKeyExpired,
+			Data:        webhooks.KeyExpiredData{KeyID: key.ID, APIID: key.Api.ID, Expires: expires},
+			At:          time.UnixMilli(expires),
+		})
+	}
+
 	// Return success response
 	return s.JSON(http.StatusOK, Response{
 		Meta: openapi.Meta{
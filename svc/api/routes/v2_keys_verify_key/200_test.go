@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/rbac"
 	"github.com/unkeyed/unkey/pkg/uid"
 	"github.com/unkeyed/unkey/svc/api/internal/testutil"
 	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
@@ -495,6 +496,39 @@ func TestSuccess(t *testing.T) {
 			require.Equal(t, openapi.VALID, res.Body.Data.Code, "Key should be valid but got %s", res.Body.Data.Code)
 			require.True(t, res.Body.Data.Valid, "Key should be valid but got %t", res.Body.Data.Valid)
 		})
+
+		t.Run("with conditional permission", func(t *testing.T) {
+			key := h.CreateKey(seed.CreateKeyRequest{
+				WorkspaceID: workspace.ID,
+				KeySpaceID:  api.KeyAuthID.String,
+				Meta:        ptr.P(`{"plan":"enterprise"}`),
+				Permissions: []seed.CreatePermissionRequest{{
+					Name:        "deploy.write",
+					Slug:        "deploy.write",
+					Description: nil,
+					WorkspaceID: workspace.ID,
+					Conditions: &rbac.Conditions{
+						Environments: []string{"staging", "production"},
+						TimeWindow:   nil,
+						Meta:         map[string]string{"plan": "enterprise"},
+					},
+				}},
+			})
+
+			for query, wantCode := range map[string]openapi.V2KeysVerifyKeyResponseDataCode{
+				"deploy.write[environment=production]":  openapi.VALID,
+				"deploy.write[environment=development]": openapi.INSUFFICIENTPERMISSIONS,
+				"deploy.write":                          openapi.INSUFFICIENTPERMISSIONS,
+			} {
+				req := handler.Request{
+					Key:         key.Key,
+					Permissions: ptr.P(query),
+				}
+				res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+				require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+				require.Equal(t, wantCode, res.Body.Data.Code, "unexpected code for %q", query)
+			}
+		})
 	})
 
 	t.Run("key with auto applied ratelimit", func(t *testing.T) {
@@ -0,0 +1,112 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_keys_verify_key"
+)
+
+func TestEnvironments(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:               h.DB,
+		Keys:             h.Keys,
+		Auditlogs:        h.Auditlogs,
+		KeyVerifications: h.KeyVerifications,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.verify_key")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	err := db.BulkQuery.InsertKeySpaceEnvironments(ctx, h.DB.RW(), []db.InsertKeySpaceEnvironmentParams{
+		{WorkspaceID: workspace.ID, KeyAuthID: api.KeyAuthID.String, Name: "test", TestMode: true, CreatedAtM: time.Now().UnixMilli()},
+		{WorkspaceID: workspace.ID, KeyAuthID: api.KeyAuthID.String, Name: "live", TestMode: false, CreatedAtM: time.Now().UnixMilli()},
+	})
+	require.NoError(t, err)
+
+	testKey := h.CreateKey(seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeySpaceID:  api.KeyAuthID.String,
+		Environment: ptr.P("test"),
+		Remaining:   ptr.P(int64(2)),
+	})
+	liveKey := h.CreateKey(seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeySpaceID:  api.KeyAuthID.String,
+		Environment: ptr.P("live"),
+		Remaining:   ptr.P(int64(2)),
+	})
+	plainKey := h.CreateKey(seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeySpaceID:  api.KeyAuthID.String,
+	})
+
+	t.Run("required environment accepts its keys", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Key:         liveKey.Key,
+			Environment: ptr.P("live"),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Equal(t, openapi.VALID, res.Body.Data.Code)
+	})
+
+	t.Run("required environment rejects other keys", func(t *testing.T) {
+		for _, key := range []string{testKey.Key, plainKey.Key} {
+			res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+				Key:         key,
+				Environment: ptr.P("live"),
+			})
+			require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+			require.Equal(t, openapi.FORBIDDEN, res.Body.Data.Code)
+			require.False(t, res.Body.Data.Valid)
+		}
+	})
+
+	t.Run("test mode keys do not spend credits", func(t *testing.T) {
+		for range 3 {
+			res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+				Key: testKey.Key,
+			})
+			require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+			require.Equal(t, openapi.VALID, res.Body.Data.Code)
+			require.NotNil(t, res.Body.Data.Credits)
+			require.Equal(t, int64(2), *res.Body.Data.Credits)
+		}
+
+		// A cost the balance cannot cover is still denied.
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Key:     testKey.Key,
+			Credits: &openapi.KeysVerifyKeyCredits{Cost: 3},
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Equal(t, openapi.USAGEEXCEEDED, res.Body.Data.Code)
+	})
+
+	t.Run("live keys spend credits", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Key: liveKey.Key,
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Equal(t, openapi.VALID, res.Body.Data.Code)
+		require.NotNil(t, res.Body.Data.Credits)
+		require.Less(t, *res.Body.Data.Credits, int64(2))
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/unkeyed/unkey/svc/api/internal/identitycredits"
 	"github.com/unkeyed/unkey/svc/api/openapi"
 
 	"github.com/unkeyed/unkey/internal/services/auditlogs"
@@ -74,6 +76,15 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		}
 	}
 
+	// Keys never contain dots, so a three part input is only worth checking as
+	// a JWT once the key lookup has come up empty.
+	if key.Status == keys.StatusNotFound && strings.Count(req.Key, ".") == 2 {
+		key, err = h.Keys.GetJWT(ctx, s, principal.WorkspaceID, req.Key)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Validate key belongs to authorized workspace
 	if key.Key.WorkspaceID != principal.WorkspaceID {
 		return s.JSON(http.StatusOK, Response{
@@ -102,6 +113,13 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		})
 	}
 
+	// A JWT has no key of its own, so only grants covering every key in the
+	// keyspace authorize verifying it.
+	keyID := key.Key.ID
+	if key.IsJWT() {
+		keyID = "*"
+	}
+
 	err = principal.Authorize(rbac.Or(
 		rbac.T(rbac.Tuple{
 			ResourceType: rbac.Api,
@@ -114,7 +132,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			Action:       rbac.VerifyKey,
 		}),
 		rbac.U(
-			urn.New().Workspace(principal.WorkspaceID).Keyspace(key.Key.KeyAuthID).Key(key.Key.ID),
+			urn.New().Workspace(principal.WorkspaceID).Keyspace(key.Key.KeyAuthID).Key(keyID),
 			permissions.VerifyKey{},
 		),
 	))
@@ -138,10 +156,15 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		keys.WithIPWhitelist(),
 	}
 
+	if req.Environment != nil {
+		opts = append(opts, keys.WithEnvironment(*req.Environment))
+	}
+
 	// If a custom cost was specified, use it, otherwise use a DefaultCost of 1
+	// for keys with credits of their own or of their identity's pool
 	if req.Credits != nil {
 		opts = append(opts, keys.WithCredits(req.Credits.Cost))
-	} else if key.Key.RemainingRequests.Valid {
+	} else if key.Key.RemainingRequests.Valid || key.Key.IdentityRemainingCredits.Valid {
 		opts = append(opts, keys.WithCredits(DefaultCost))
 	}
 
@@ -208,6 +231,15 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			ExternalId: key.Key.ExternalID.String,
 			Ratelimits: nil,
 			Meta:       nil,
+			Credits:    nil,
+		}
+
+		if key.Key.IdentityRemainingCredits.Valid {
+			keyData.Identity.Credits = identitycredits.Response(
+				key.Key.IdentityRemainingCredits.Int64,
+				key.Key.IdentityRefillDay,
+				key.Key.IdentityRefillAmount,
+			)
 		}
 
 		identityRatelimits := make([]openapi.RatelimitResponse, 0)
@@ -0,0 +1,259 @@
+package handler_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"database/sql"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/jwt"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	deleteidentity "github.com/unkeyed/unkey/svc/api/routes/v2_identities_delete_identity"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_keys_verify_key"
+)
+
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Scope string `json:"scope,omitempty"`
+}
+
+func TestVerifyJWT(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:               h.DB,
+		Keys:             h.Keys,
+		Auditlogs:        h.Auditlogs,
+		KeyVerifications: h.KeyVerifications,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.verify_key")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+	signer, err := jwt.NewRS256Signer[tokenClaims](string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})))
+	require.NoError(t, err)
+
+	issuer := fmt.Sprintf("https://%s.example.com/", uid.New("test"))
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	err = db.Query.UpsertApiJwtConfig(ctx, h.DB.RW(), db.UpsertApiJwtConfigParams{
+		ApiID:            api.ID,
+		WorkspaceID:      workspace.ID,
+		Issuer:           issuer,
+		Audience:         sql.NullString{String: "https://api.example.com", Valid: true},
+		JwksUrl:          sql.NullString{},
+		PublicKey:        sql.NullString{String: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDER})), Valid: true},
+		IdentityClaim:    "sub",
+		PermissionsClaim: sql.NullString{String: "scope", Valid: true},
+		CreatedAtM:       time.Now().UnixMilli(),
+	})
+	require.NoError(t, err)
+	err = db.Query.UpdateApiAuthType(ctx, h.DB.RW(), db.UpdateApiAuthTypeParams{
+		AuthType:   db.NullApisAuthType{ApisAuthType: db.ApisAuthTypeJwt, Valid: true},
+		UpdatedAtM: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+		ApiID:      api.ID,
+	})
+	require.NoError(t, err)
+
+	externalID := uid.New("user")
+	identity := h.CreateIdentity(seed.CreateIdentityRequest{
+		WorkspaceID: workspace.ID,
+		ExternalID:  externalID,
+		Meta:        []byte(`{"plan":"pro"}`),
+		Ratelimits: []seed.CreateRatelimitRequest{{
+			Name:        "requests",
+			WorkspaceID: workspace.ID,
+			AutoApply:   true,
+			Duration:    60_000,
+			Limit:       2,
+		}},
+	})
+
+	sign := func(t *testing.T, subject string, expiresAt time.Time, scope string) string {
+		token, err := signer.Sign(tokenClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    issuer,
+				Subject:   subject,
+				Audience:  []string{"https://api.example.com"},
+				ExpiresAt: expiresAt.Unix(),
+				IssuedAt:  time.Now().Unix(),
+			},
+			Scope: scope,
+		})
+		require.NoError(t, err)
+		return token
+	}
+
+	t.Run("valid token verifies as its identity", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Key:         sign(t, externalID, time.Now().Add(time.Hour), "documents.read documents.write"),
+			Permissions: ptr.P("documents.read"),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Equal(t, openapi.VALID, res.Body.Data.Code)
+		require.True(t, res.Body.Data.Valid)
+		require.Empty(t, res.Body.Data.KeyId)
+		require.NotNil(t, res.Body.Data.Identity)
+		require.Equal(t, identity.ID, res.Body.Data.Identity.Id)
+		require.Equal(t, externalID, res.Body.Data.Identity.ExternalId)
+		require.ElementsMatch(t, []string{"documents.read", "documents.write"}, res.Body.Data.Permissions)
+		require.Positive(t, res.Body.Data.Expires)
+	})
+
+	t.Run("identity rate limits apply to tokens", func(t *testing.T) {
+		token := sign(t, externalID, time.Now().Add(time.Hour), "")
+		var last *handler.Response
+		for range 3 {
+			res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{Key: token})
+			require.Equal(t, 200, res.Status)
+			last = res.Body
+		}
+		require.Equal(t, openapi.RATELIMITED, last.Data.Code)
+	})
+
+	t.Run("tokens draw from the identity's credit pool", func(t *testing.T) {
+		pooledExternalID := uid.New("user")
+		pooled := h.CreateIdentity(seed.CreateIdentityRequest{
+			WorkspaceID: workspace.ID,
+			ExternalID:  pooledExternalID,
+			Meta:        nil,
+			Ratelimits:  nil,
+		})
+		require.NoError(t, db.Query.UpsertIdentityCredits(ctx, h.DB.RW(), db.UpsertIdentityCreditsParams{
+			IdentityID:   pooled.ID,
+			WorkspaceID:  workspace.ID,
+			Remaining:    2,
+			RefillDay:    sql.NullInt16{Valid: false},
+			RefillAmount: sql.NullInt64{Valid: false},
+			Now:          time.Now().UnixMilli(),
+		}))
+
+		token := sign(t, pooledExternalID, time.Now().Add(time.Hour), "")
+		for _, remaining := range []int64{1, 0} {
+			res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{Key: token})
+			require.Equal(t, 200, res.Status)
+			require.Equal(t, openapi.VALID, res.Body.Data.Code)
+			require.NotNil(t, res.Body.Data.Identity.Credits)
+			require.Equal(t, remaining, res.Body.Data.Identity.Credits.Remaining)
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{Key: token})
+		require.Equal(t, 200, res.Status)
+		require.Equal(t, openapi.USAGEEXCEEDED, res.Body.Data.Code)
+		require.False(t, res.Body.Data.Valid)
+	})
+
+	t.Run("missing permission is reported", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Key:         sign(t, externalID, time.Now().Add(time.Hour), "documents.read"),
+			Permissions: ptr.P("documents.delete"),
+		})
+		require.Equal(t, 200, res.Status)
+		require.Equal(t, openapi.INSUFFICIENTPERMISSIONS, res.Body.Data.Code)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Key: sign(t, externalID, time.Now().Add(-time.Hour), ""),
+		})
+		require.Equal(t, 200, res.Status)
+		require.Equal(t, openapi.EXPIRED, res.Body.Data.Code)
+		require.False(t, res.Body.Data.Valid)
+	})
+
+	t.Run("unknown identity", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Key: sign(t, uid.New("user"), time.Now().Add(time.Hour), ""),
+		})
+		require.Equal(t, 200, res.Status)
+		require.Equal(t, openapi.NOTFOUND, res.Body.Data.Code)
+	})
+
+	t.Run("deleted identities stop verifying right away", func(t *testing.T) {
+		deletedExternalID := uid.New("user")
+		h.CreateIdentity(seed.CreateIdentityRequest{
+			WorkspaceID: workspace.ID,
+			ExternalID:  deletedExternalID,
+			Meta:        nil,
+			Ratelimits:  nil,
+		})
+		token := sign(t, deletedExternalID, time.Now().Add(time.Hour), "")
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{Key: token})
+		require.Equal(t, 200, res.Status)
+		require.Equal(t, openapi.VALID, res.Body.Data.Code)
+
+		deleteRoute := &deleteidentity.Handler{
+			DB:        h.DB,
+			Auditlogs: h.Auditlogs,
+			Caches:    h.Caches.Invalidations,
+		}
+		h.Register(deleteRoute)
+		deleteRes := testutil.CallRoute[deleteidentity.Request, deleteidentity.Response](h, deleteRoute, http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {fmt.Sprintf("Bearer %s", h.CreateRootKey(workspace.ID, "identity.*.delete_identity"))},
+		}, deleteidentity.Request{Identity: deletedExternalID})
+		require.Equal(t, 200, deleteRes.Status, "expected 200, received: %#v", deleteRes)
+
+		res = testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{Key: token})
+		require.Equal(t, 200, res.Status)
+		require.Equal(t, openapi.NOTFOUND, res.Body.Data.Code)
+	})
+
+	t.Run("token signed by another key", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		otherSigner, err := jwt.NewRS256Signer[tokenClaims](string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(otherKey),
+		})))
+		require.NoError(t, err)
+		token, err := otherSigner.Sign(tokenClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    issuer,
+				Subject:   externalID,
+				Audience:  []string{"https://api.example.com"},
+				ExpiresAt: time.Now().Add(time.Hour).Unix(),
+			},
+		})
+		require.NoError(t, err)
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{Key: token})
+		require.Equal(t, 200, res.Status)
+		require.Equal(t, openapi.NOTFOUND, res.Body.Data.Code)
+	})
+
+	t.Run("root keys for other apis cannot verify tokens", func(t *testing.T) {
+		scopedRootKey := h.CreateRootKey(workspace.ID, fmt.Sprintf("api.%s.verify_key", uid.New(uid.APIPrefix)))
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {fmt.Sprintf("Bearer %s", scopedRootKey)},
+		}, handler.Request{Key: sign(t, externalID, time.Now().Add(time.Hour), "")})
+		require.Equal(t, 200, res.Status)
+		require.Equal(t, openapi.NOTFOUND, res.Body.Data.Code)
+	})
+}
@@ -113,6 +113,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		Identity:    nil,
 		Permissions: nil,
 		Roles:       nil,
+		Rotation:    nil,
 		Expires:     0,
 		UpdatedAt:   keyData.Key.UpdatedAtM.Int64,
 		Name:        keyData.Key.Name.String,
@@ -158,6 +159,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			Ratelimits: nil,
 			Id:         keyData.Identity.ID,
 			ExternalId: keyData.Identity.ExternalID,
+			Credits:    nil,
 		}
 
 		if len(keyData.Identity.Meta) > 0 {
@@ -2,13 +2,17 @@ package handler_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/rbac"
 	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
 	handler "github.com/unkeyed/unkey/svc/api/routes/v2_permissions_create_permission"
 )
 
@@ -109,5 +113,42 @@ func TestSuccess(t *testing.T) {
 		require.Equal(t, req.Slug, perm.Slug)
 		require.False(t, perm.Description.Valid, "Description should be empty")
 		require.Equal(t, workspace.ID, perm.WorkspaceID)
+		require.Nil(t, perm.Conditions, "Permissions are unconditional by default")
+	})
+
+	t.Run("create permission with conditions", func(t *testing.T) {
+		req := handler.Request{
+			Name: "deploy.write",
+			Slug: "deploy.write",
+			Conditions: &openapi.PermissionConditions{
+				Environments: &[]string{"production"},
+				TimeWindow: &openapi.PermissionTimeWindow{
+					Start:    "09:00",
+					End:      "17:00",
+					Timezone: ptr.P("Europe/Berlin"),
+				},
+				Meta: &map[string]string{"plan": "enterprise"},
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](
+			h,
+			route,
+			headers,
+			req,
+		)
+
+		require.Equal(t, http.StatusOK, res.Status, res.RawBody)
+
+		perm, err := db.Query.FindPermissionByID(ctx, h.DB.RO(), res.Body.Data.PermissionId)
+		require.NoError(t, err)
+
+		var stored rbac.Conditions
+		require.NoError(t, json.Unmarshal(perm.Conditions, &stored))
+		require.Equal(t, rbac.Conditions{
+			Environments: []string{"production"},
+			TimeWindow:   &rbac.TimeWindow{Start: "09:00", End: "17:00", Timezone: "Europe/Berlin"},
+			Meta:         map[string]string{"plan": "enterprise"},
+		}, stored)
 	})
 }
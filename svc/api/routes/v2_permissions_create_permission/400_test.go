@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/ptr"
 	"github.com/unkeyed/unkey/svc/api/internal/testutil"
 	"github.com/unkeyed/unkey/svc/api/openapi"
 	handler "github.com/unkeyed/unkey/svc/api/routes/v2_permissions_create_permission"
@@ -247,4 +248,42 @@ func TestValidationErrors(t *testing.T) {
 
 		require.Equal(t, http.StatusBadRequest, res.Status, "Expected status code to be %d, got: %s", http.StatusBadRequest, res.RawBody)
 	})
+	t.Run("invalid conditions", func(t *testing.T) {
+		cases := map[string]openapi.PermissionConditions{
+			"unknown timezone": {
+				Environments: nil,
+				Meta:         nil,
+				TimeWindow:   &openapi.PermissionTimeWindow{Start: "09:00", End: "17:00", Timezone: ptr.P("Mars/Olympus")},
+			},
+			"empty window": {
+				Environments: nil,
+				Meta:         nil,
+				TimeWindow:   &openapi.PermissionTimeWindow{Start: "09:00", End: "09:00", Timezone: nil},
+			},
+			"malformed start": {
+				Environments: nil,
+				Meta:         nil,
+				TimeWindow:   &openapi.PermissionTimeWindow{Start: "9am", End: "17:00", Timezone: nil},
+			},
+		}
+
+		for name, conditions := range cases {
+			t.Run(name, func(t *testing.T) {
+				req := handler.Request{
+					Name:       "test.conditional." + strings.ReplaceAll(name, " ", "-"),
+					Slug:       "test-conditional-" + strings.ReplaceAll(name, " ", "-"),
+					Conditions: &conditions,
+				}
+
+				res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](
+					h,
+					route,
+					headers,
+					req,
+				)
+
+				require.Equal(t, http.StatusBadRequest, res.Status, "Expected status code to be %d, got: %s", http.StatusBadRequest, res.RawBody)
+			})
+		}
+	})
 }
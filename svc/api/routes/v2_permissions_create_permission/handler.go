@@ -2,6 +2,8 @@ package handler
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -16,6 +18,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/rbac"
 	"github.com/unkeyed/unkey/pkg/uid"
 	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/conditions"
 	"github.com/unkeyed/unkey/svc/api/internal/projects"
 	"github.com/unkeyed/unkey/svc/api/openapi"
 )
@@ -60,6 +63,20 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return err
 	}
 
+	// Conditions are validated here rather than at evaluation time, where a
+	// malformed condition would silently deny every verification.
+	var storedConditions []byte
+	if req.Conditions != nil {
+		rbacConditions := conditions.ToRBAC(*req.Conditions)
+		if err = rbacConditions.Validate(); err != nil {
+			return err
+		}
+		storedConditions, err = json.Marshal(rbacConditions)
+		if err != nil {
+			return fault.Wrap(err, fault.Internal("failed to marshal permission conditions"))
+		}
+	}
+
 	permissionID := uid.New(uid.PermissionPrefix)
 
 	description := ptr.SafeDeref(req.Description)
@@ -95,6 +112,20 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			)
 		}
 
+		if storedConditions != nil {
+			err = db.Query.UpdatePermissionConditions(ctx, tx, db.UpdatePermissionConditionsParams{
+				Conditions:   storedConditions,
+				UpdatedAtM:   sql.NullInt64{Valid: false, Int64: 0},
+				PermissionID: permissionID,
+			})
+			if err != nil {
+				return fault.Wrap(err,
+					fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+					fault.Internal("database error"), fault.Public("Failed to create permission."),
+				)
+			}
+		}
+
 		// Create audit log
 		err = h.Auditlogs.Insert(ctx, tx, []auditlog.AuditLog{
 			{
@@ -118,6 +149,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 							"name":        req.Name,
 							"slug":        req.Slug,
 							"description": description,
+							"conditions":  req.Conditions,
 						},
 					},
 				},
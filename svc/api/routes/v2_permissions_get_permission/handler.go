@@ -7,6 +7,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/fault"
 	"github.com/unkeyed/unkey/pkg/rbac"
 	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/conditions"
 	"github.com/unkeyed/unkey/svc/api/openapi"
 	"net/http"
 )
@@ -76,6 +77,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		Name:        permission.Name,
 		Slug:        permission.Slug,
 		Description: permission.Description.String,
+		Conditions:  conditions.ToResponse(permission.Conditions),
 	}
 
 	return s.JSON(http.StatusOK, Response{
@@ -8,6 +8,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/logger"
 	"github.com/unkeyed/unkey/pkg/rbac"
 	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/conditions"
 	"github.com/unkeyed/unkey/svc/api/openapi"
 	"net/http"
 )
@@ -94,6 +95,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			Name:        perm.Name,
 			Slug:        perm.Slug,
 			Description: perm.Description.String,
+			Conditions:  conditions.ToResponse(perm.Conditions),
 		}
 
 		roleResponse.Permissions = append(roleResponse.Permissions, permission)
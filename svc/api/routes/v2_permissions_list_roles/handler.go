@@ -14,6 +14,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/ptr"
 	"github.com/unkeyed/unkey/pkg/rbac"
 	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/conditions"
 	"github.com/unkeyed/unkey/svc/api/internal/pagination"
 	"github.com/unkeyed/unkey/svc/api/openapi"
 )
@@ -102,6 +103,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 					Name:        perm.Name,
 					Slug:        perm.Slug,
 					Description: perm.Description.String,
+					Conditions:  conditions.ToResponse(perm.Conditions),
 				}
 			}),
 		}
@@ -208,7 +208,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 
 	data := make(openapi.V2PermissionsSetRolePermissionsResponseData, 0, len(result))
 	for _, permission := range result {
-		data = append(data, openapi.Permission{Id: permission.ID, Name: permission.Name, Slug: permission.Slug, Description: permission.Description.String})
+		data = append(data, openapi.Permission{Id: permission.ID, Name: permission.Name, Slug: permission.Slug, Description: permission.Description.String, Conditions: nil})
 	}
 	return s.JSON(http.StatusOK, Response{Meta: openapi.Meta{RequestId: s.RequestID()}, Data: data})
 }
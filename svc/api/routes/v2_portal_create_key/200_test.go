@@ -0,0 +1,160 @@
+package handler_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	createkey "github.com/unkeyed/unkey/svc/api/routes/v2_keys_create_key"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_portal_create_key"
+)
+
+type (
+	Request  = handler.Request
+	Response = handler.Response
+)
+
+// newHandler builds the portal.createKey handler backed by a configured
+// keys.createKey handler.
+func newHandler(h *testutil.Harness) *handler.Handler {
+	return handler.New(&createkey.Handler{
+		DB:        h.DB,
+		Keys:      h.Keys,
+		Auditlogs: h.Auditlogs,
+		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
+	})
+}
+
+// createPortal inserts an enabled portal for keyspaceID with the given key
+// policy and returns its id.
+func createPortal(t *testing.T, h *testutil.Harness, workspaceID, keyspaceID string, policy db.UpdatePortalKeyPolicyParams) string {
+	t.Helper()
+	ctx := context.Background()
+
+	portalID := uid.New(uid.PortalPrefix)
+	require.NoError(t, db.Query.InsertPortal(ctx, h.DB.RW(), db.InsertPortalParams{
+		ID:          portalID,
+		WorkspaceID: workspaceID,
+		Slug:        uid.New("portal"),
+		KeyAuthID:   sql.NullString{Valid: true, String: keyspaceID},
+		Enabled:     true,
+		CreatedAt:   time.Now().UnixMilli(),
+	}))
+
+	policy.ID = portalID
+	policy.WorkspaceID = workspaceID
+	require.NoError(t, db.Query.UpdatePortalKeyPolicy(ctx, h.DB.RW(), policy))
+
+	return portalID
+}
+
+func TestPortalCreateKeyAppliesPolicy(t *testing.T) {
+	h := testutil.NewHarness(t)
+	ctx := context.Background()
+
+	route := newHandler(h)
+	h.Register(route, h.PortalMiddleware()...)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	keyspaceID := api.KeyAuthID.String
+
+	portalID := createPortal(t, h, workspace.ID, keyspaceID, db.UpdatePortalKeyPolicyParams{
+		AllowedPermissions: []byte(`["documents.read"]`),
+		RatelimitTemplates: []byte(`[{"name":"requests","limit":100,"duration":60000,"autoApply":true}]`),
+		MaxExpiryMs:        sql.NullInt64{Valid: true, Int64: (30 * 24 * time.Hour).Milliseconds()},
+	})
+
+	externalID := "portal_user_A"
+	headers := h.CreatePortalSessionForPortal(workspace.ID, portalID, externalID, []string{keyspaceID}, []string{"keys:create"})
+
+	expires := time.Now().Add(7 * 24 * time.Hour).UnixMilli()
+	res := testutil.CallRoute[Request, Response](h, route, headers, Request{
+		Name:        ptr.P("Production server"),
+		Expires:     ptr.P(expires),
+		Permissions: ptr.P([]string{"documents.read"}),
+	})
+	require.Equal(t, http.StatusOK, res.Status, res.RawBody)
+	require.NotEmpty(t, res.Body.Data.Key, "the plaintext key is returned once")
+
+	key, err := db.Query.FindKeyByID(ctx, h.DB.RO(), res.Body.Data.KeyId)
+	require.NoError(t, err)
+	require.Equal(t, keyspaceID, key.KeyAuthID)
+	require.Equal(t, "Production server", key.Name.String)
+	require.Equal(t, expires, key.Expires.Time.UnixMilli())
+
+	identity, err := db.Query.FindIdentityByExternalID(ctx, h.DB.RO(), db.FindIdentityByExternalIDParams{
+		WorkspaceID: workspace.ID,
+		ExternalID:  externalID,
+		Deleted:     false,
+	})
+	require.NoError(t, err)
+	require.Equal(t, identity.ID, key.IdentityID.String, "the key is owned by the session identity")
+
+	ratelimits, err := db.Query.ListRatelimitsByKeyID(ctx, h.DB.RO(), sql.NullString{Valid: true, String: key.ID})
+	require.NoError(t, err)
+	require.Len(t, ratelimits, 1, "the portal's ratelimit template is stamped on the key")
+	require.Equal(t, "requests", ratelimits[0].Name)
+	require.Equal(t, uint64(100), ratelimits[0].Limit)
+	require.Equal(t, uint64(60000), ratelimits[0].Duration)
+
+	permissions, err := db.Query.ListDirectPermissionsByKeyID(ctx, h.DB.RO(), key.ID)
+	require.NoError(t, err)
+	require.Len(t, permissions, 1)
+	require.Equal(t, "documents.read", permissions[0].Slug)
+}
+
+func TestPortalCreateKeyEnforcesMaxKeys(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := newHandler(h)
+	h.Register(route, h.PortalMiddleware()...)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	keyspaceID := api.KeyAuthID.String
+
+	portalID := createPortal(t, h, workspace.ID, keyspaceID, db.UpdatePortalKeyPolicyParams{
+		MaxKeys: sql.NullInt32{Valid: true, Int32: 2},
+	})
+
+	identity := h.CreateIdentity(seed.CreateIdentityRequest{
+		WorkspaceID: workspace.ID,
+		ExternalID:  "portal_user_A",
+	})
+	h.CreateKey(seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeySpaceID:  keyspaceID,
+		IdentityID:  ptr.P(identity.ID),
+	})
+
+	// Another identity's keys do not count towards this end user's limit.
+	other := h.CreateIdentity(seed.CreateIdentityRequest{
+		WorkspaceID: workspace.ID,
+		ExternalID:  "portal_user_B",
+	})
+	h.CreateKey(seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeySpaceID:  keyspaceID,
+		IdentityID:  ptr.P(other.ID),
+	})
+
+	headers := h.CreatePortalSessionForPortal(workspace.ID, portalID, identity.ExternalID, []string{keyspaceID}, []string{"keys:create"})
+
+	first := testutil.CallRoute[Request, Response](h, route, headers, Request{})
+	require.Equal(t, http.StatusOK, first.Status, first.RawBody)
+
+	second := testutil.CallRoute[Request, openapi.ForbiddenErrorResponse](h, route, headers, Request{})
+	require.Equal(t, http.StatusForbidden, second.Status)
+	require.Equal(t, "You can have at most 2 keys. Delete a key before creating a new one.", second.Body.Error.Detail)
+}
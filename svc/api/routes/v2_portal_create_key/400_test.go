@@ -0,0 +1,54 @@
+package handler_test
+
+import (
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestPortalCreateKeyBadRequest(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := newHandler(h)
+	h.Register(route, h.PortalMiddleware()...)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	keyspaceID := api.KeyAuthID.String
+
+	portalID := createPortal(t, h, workspace.ID, keyspaceID, db.UpdatePortalKeyPolicyParams{
+		MinExpiryMs: sql.NullInt64{Valid: true, Int64: time.Hour.Milliseconds()},
+		MaxExpiryMs: sql.NullInt64{Valid: true, Int64: (30 * 24 * time.Hour).Milliseconds()},
+	})
+
+	headers := h.CreatePortalSessionForPortal(workspace.ID, portalID, "portal_user_A", []string{keyspaceID}, []string{"keys:create"})
+
+	t.Run("expiry required by a max lifetime", func(t *testing.T) {
+		res := testutil.CallRoute[Request, openapi.BadRequestErrorResponse](h, route, headers, Request{})
+		require.Equal(t, http.StatusBadRequest, res.Status)
+		require.Equal(t, "`expires` is required and must be at most 30 days away.", res.Body.Error.Detail)
+	})
+
+	t.Run("expiry below the minimum", func(t *testing.T) {
+		res := testutil.CallRoute[Request, openapi.BadRequestErrorResponse](h, route, headers, Request{
+			Expires: ptr.P(time.Now().Add(time.Minute).UnixMilli()),
+		})
+		require.Equal(t, http.StatusBadRequest, res.Status)
+	})
+
+	t.Run("expiry above the maximum", func(t *testing.T) {
+		res := testutil.CallRoute[Request, openapi.BadRequestErrorResponse](h, route, headers, Request{
+			Expires: ptr.P(time.Now().Add(60 * 24 * time.Hour).UnixMilli()),
+		})
+		require.Equal(t, http.StatusBadRequest, res.Status)
+		require.Equal(t, "`expires` must be at most 30 days in the future.", res.Body.Error.Detail)
+	})
+}
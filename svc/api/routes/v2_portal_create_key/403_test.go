@@ -0,0 +1,54 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestPortalCreateKeyForbidden(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := newHandler(h)
+	h.Register(route, h.PortalMiddleware()...)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	keyspaceID := api.KeyAuthID.String
+
+	portalID := createPortal(t, h, workspace.ID, keyspaceID, db.UpdatePortalKeyPolicyParams{
+		AllowedPermissions: []byte(`["documents.read"]`),
+		AllowedRoles:       []byte(`["viewer"]`),
+	})
+
+	t.Run("requires the keys:create capability", func(t *testing.T) {
+		headers := h.CreatePortalSessionForPortal(workspace.ID, portalID, "portal_user_A", []string{keyspaceID}, []string{"keys:reroll"})
+
+		res := testutil.CallRoute[Request, openapi.ForbiddenErrorResponse](h, route, headers, Request{})
+		require.Equal(t, http.StatusForbidden, res.Status)
+	})
+
+	headers := h.CreatePortalSessionForPortal(workspace.ID, portalID, "portal_user_A", []string{keyspaceID}, []string{"keys:create"})
+
+	t.Run("permission outside the allow list", func(t *testing.T) {
+		res := testutil.CallRoute[Request, openapi.ForbiddenErrorResponse](h, route, headers, Request{
+			Permissions: ptr.P([]string{"documents.read", "admin"}),
+		})
+		require.Equal(t, http.StatusForbidden, res.Status)
+		require.Equal(t, "Permission 'admin' cannot be added to keys created here.", res.Body.Error.Detail)
+	})
+
+	t.Run("role outside the allow list", func(t *testing.T) {
+		res := testutil.CallRoute[Request, openapi.ForbiddenErrorResponse](h, route, headers, Request{
+			Roles: ptr.P([]string{"owner"}),
+		})
+		require.Equal(t, http.StatusForbidden, res.Status)
+		require.Equal(t, "Role 'owner' cannot be added to keys created here.", res.Body.Error.Detail)
+	})
+}
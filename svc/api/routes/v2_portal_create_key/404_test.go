@@ -0,0 +1,44 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+func TestPortalCreateKeyNotFound(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := newHandler(h)
+	h.Register(route, h.PortalMiddleware()...)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	otherApi := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	keyspaceID := api.KeyAuthID.String
+
+	t.Run("keyspace outside the session", func(t *testing.T) {
+		portalID := createPortal(t, h, workspace.ID, keyspaceID, db.UpdatePortalKeyPolicyParams{})
+		headers := h.CreatePortalSessionForPortal(workspace.ID, portalID, "portal_user_A", []string{keyspaceID}, []string{"keys:create"})
+
+		res := testutil.CallRoute[Request, openapi.NotFoundErrorResponse](h, route, headers, Request{
+			KeyspaceId: ptr.P(otherApi.KeyAuthID.String),
+		})
+		require.Equal(t, http.StatusNotFound, res.Status)
+		require.NotContains(t, res.RawBody, otherApi.KeyAuthID.String)
+	})
+
+	t.Run("portal no longer exists", func(t *testing.T) {
+		headers := h.CreatePortalSession(workspace.ID, "portal_user_A", []string{keyspaceID}, []string{"keys:create"})
+
+		res := testutil.CallRoute[Request, openapi.NotFoundErrorResponse](h, route, headers, Request{})
+		require.Equal(t, http.StatusNotFound, res.Status)
+		require.Equal(t, "Portal not found.", res.Body.Error.Detail)
+	})
+}
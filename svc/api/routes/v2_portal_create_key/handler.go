@@ -0,0 +1,281 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/unkeyed/unkey/internal/services/portal"
+	"github.com/unkeyed/unkey/pkg/auth/portalrbac"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/portalscope"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	createkey "github.com/unkeyed/unkey/svc/api/routes/v2_keys_create_key"
+)
+
+type (
+	// Request is the portal.createKey public contract. It carries only what an
+	// end user may choose; the owner, ratelimits, and everything else a
+	// keys.createKey request can set are fixed by the session and the portal.
+	Request  = openapi.V2PortalCreateKeyRequestBody
+	Response = createkey.Response
+)
+
+// Handler serves the portal-scoped variant of keys.createKey. It authenticates
+// only portal sessions, always creates the key for the session's external
+// identity, and enforces the portal's key policy before delegating to the
+// createKey core.
+//
+// The core is held in an unexported field, not embedded, for the same reason
+// as portal.rerollKey: embedding would promote the core's Method/Path/Handle
+// and let a missing override fall through to the unscoped operator route.
+type Handler struct {
+	create *createkey.Handler
+}
+
+// New builds a portal.createKey handler over the shared keys.createKey core.
+func New(create *createkey.Handler) *Handler {
+	return &Handler{create: create}
+}
+
+// Method returns the HTTP method this route responds to.
+func (h *Handler) Method() string { return "POST" }
+
+// Path returns the URL path pattern this route matches.
+func (h *Handler) Path() string { return "/v2/portal.createKey" }
+
+// Handle creates a key owned by the portal session's external identity.
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+	if err := principal.Authorize(rbac.S(portalrbac.CapKeysCreate)); err != nil {
+		return err
+	}
+
+	externalID, err := portalscope.ExternalID(s)
+	if err != nil {
+		return err
+	}
+	keyspaceIDs, err := portalscope.KeyspaceIDs(s)
+	if err != nil {
+		return err
+	}
+	portalID, err := portalscope.PortalID(s)
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	keyspaceID, err := targetKeyspace(req.KeyspaceId, keyspaceIDs)
+	if err != nil {
+		return err
+	}
+
+	policy, err := h.loadPolicy(ctx, principal.WorkspaceID, portalID)
+	if err != nil {
+		return err
+	}
+	if err := policy.CheckPermissions(ptr.SafeDeref(req.Permissions)); err != nil {
+		return err
+	}
+	if err := policy.CheckRoles(ptr.SafeDeref(req.Roles)); err != nil {
+		return err
+	}
+	if err := policy.CheckExpiry(req.Expires, time.Now()); err != nil {
+		return err
+	}
+	if policy.MaxKeys > 0 {
+		// The count and the insert are not one transaction, so two concurrent
+		// creates can both pass at MaxKeys-1. The limit is a product guardrail
+		// rather than a security boundary, and a row lock on the identity for
+		// every portal create is not worth one key of overshoot.
+		live, err := h.countLiveKeys(ctx, principal.WorkspaceID, externalID, keyspaceIDs)
+		if err != nil {
+			return err
+		}
+		if err := policy.CheckKeyCount(live); err != nil {
+			return err
+		}
+	}
+
+	api, err := h.findApi(ctx, principal.WorkspaceID, keyspaceID)
+	if err != nil {
+		return err
+	}
+
+	var ratelimits *[]openapi.RatelimitRequest
+	if len(policy.RatelimitTemplates) > 0 {
+		limits := make([]openapi.RatelimitRequest, len(policy.RatelimitTemplates))
+		for i, t := range policy.RatelimitTemplates {
+			limits[i] = openapi.RatelimitRequest{
+				Name:      t.Name,
+				Limit:     t.Limit,
+				Duration:  t.Duration,
+				AutoApply: t.AutoApply,
+			}
+		}
+		ratelimits = &limits
+	}
+
+	return h.create.CreateKey(ctx, s, createkey.Request{
+		ApiId:       api.ID,
+		ByteLength:  nil,
+		Credits:     nil,
+		Enabled:     nil,
+		Expires:     req.Expires,
+		ExternalId:  &externalID,
+		Meta:        nil,
+		Name:        req.Name,
+		Permissions: req.Permissions,
+		Prefix:      nil,
+		Ratelimits:  ratelimits,
+		Recoverable: nil,
+		Roles:       req.Roles,
+		Rotation:    nil,
+	}, api)
+}
+
+// targetKeyspace picks the keyspace to create the key in. The request may
+// only name a keyspace the session is scoped to, and must name one when the
+// session spans several. A keyspace outside the session is reported as not
+// found so the end user cannot probe for other keyspaces.
+func targetKeyspace(requested *string, keyspaceIDs []string) (string, error) {
+	if requested == nil {
+		if len(keyspaceIDs) != 1 {
+			return "", fault.New("portal keyspace required",
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Internal("session spans zero or several keyspaces"),
+				fault.Public("`keyspaceId` is required for this portal."),
+			)
+		}
+		return keyspaceIDs[0], nil
+	}
+
+	if !slices.Contains(keyspaceIDs, *requested) {
+		return "", fault.New("keyspace not found",
+			fault.Code(codes.Data.KeySpace.NotFound.URN()),
+			fault.Internal("keyspace is not in the portal session scope"),
+			fault.Public("The specified keyspace was not found."),
+		)
+	}
+	return *requested, nil
+}
+
+// loadPolicy reads the key policy off the session's portal. A portal that was
+// deleted or disabled since the session was minted stops minting keys at once.
+func (h *Handler) loadPolicy(ctx context.Context, workspaceID, portalID string) (portal.KeyPolicy, error) {
+	p, err := db.Query.FindPortalByID(ctx, h.create.DB.RO(), db.FindPortalByIDParams{
+		ID:          portalID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if db.IsNotFound(err) {
+			return portal.KeyPolicy{}, fault.New("portal not found",
+				fault.Code(codes.Data.Portal.NotFound.URN()),
+				fault.Internal("portal session references a missing portal"),
+				fault.Public("Portal not found."),
+			)
+		}
+		return portal.KeyPolicy{}, fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to retrieve portal."),
+		)
+	}
+
+	if !p.Enabled {
+		return portal.KeyPolicy{}, fault.New("portal disabled",
+			fault.Code(codes.Auth.Authorization.Forbidden.URN()),
+			fault.Internal("portal is disabled"),
+			fault.Public("Portal is disabled."),
+		)
+	}
+
+	return portal.PolicyFromPortal(p)
+}
+
+// countLiveKeys counts the end user's live keys across every keyspace of the
+// session, which is what the portal's max_keys bounds. An end user without an
+// identity yet has no keys.
+func (h *Handler) countLiveKeys(ctx context.Context, workspaceID, externalID string, keyspaceIDs []string) (int64, error) {
+	identity, err := db.Query.FindIdentityByExternalID(ctx, h.create.DB.RO(), db.FindIdentityByExternalIDParams{
+		WorkspaceID: workspaceID,
+		ExternalID:  externalID,
+		Deleted:     false,
+	})
+	if err != nil {
+		if db.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to retrieve identity."),
+		)
+	}
+
+	live, err := db.Query.CountLiveKeysByIdentityAndKeySpaceIDs(ctx, h.create.DB.RO(), db.CountLiveKeysByIdentityAndKeySpaceIDsParams{
+		IdentityID:  sql.NullString{String: identity.ID, Valid: true},
+		KeySpaceIds: keyspaceIDs,
+	})
+	if err != nil {
+		return 0, fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to count keys."),
+		)
+	}
+	return live, nil
+}
+
+// findApi resolves the api that owns the keyspace, which is what the createKey
+// core is keyed by.
+func (h *Handler) findApi(ctx context.Context, workspaceID, keyspaceID string) (db.Api, error) {
+	rows, err := db.Query.FindApisByKeyAuthIds(ctx, h.create.DB.RO(), db.FindApisByKeyAuthIdsParams{
+		WorkspaceID: workspaceID,
+		KeyAuthIds:  []string{keyspaceID},
+	})
+	if err != nil {
+		return db.Api{}, fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to retrieve API."),
+		)
+	}
+	if len(rows) == 0 {
+		return db.Api{}, fault.New("api not found",
+			fault.Code(codes.Data.Api.NotFound.URN()),
+			fault.Internal("portal keyspace has no live api"),
+			fault.Public("The specified API was not found."),
+		)
+	}
+
+	api, err := db.Query.FindApiByID(ctx, h.create.DB.RO(), rows[0].ApiID)
+	if err != nil {
+		if db.IsNotFound(err) {
+			return db.Api{}, fault.New("api not found",
+				fault.Code(codes.Data.Api.NotFound.URN()),
+				fault.Internal("api not found"),
+				fault.Public("The specified API was not found."),
+			)
+		}
+		return db.Api{}, fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to retrieve API."),
+		)
+	}
+	return api, nil
+}
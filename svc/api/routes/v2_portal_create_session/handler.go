@@ -27,6 +27,7 @@ import (
 	// The key requirements below are owned by the operator routes that
 	// enforce them, so this route borrows them rather than restating them.
 	listkeys "github.com/unkeyed/unkey/svc/api/routes/v2_apis_list_keys"
+	deletekey "github.com/unkeyed/unkey/svc/api/routes/v2_keys_delete_key"
 	rerollkey "github.com/unkeyed/unkey/svc/api/routes/v2_keys_reroll_key"
 )
 
@@ -391,19 +392,23 @@ func ScopeQueries(
 		// keyspace's encryption on does not make already-existing keys
 		// recoverable and grants a live session nothing new.
 		//
-		// Two paths would escalate once UpdateKeySpaceKeyEncryption gains a
+		// One path would escalate once UpdateKeySpaceKeyEncryption gains a
 		// production caller: a keyspace toggled on, off, then on again around a
-		// mint, and a future portal create-key route where a single flip is
-		// enough. Both belong to the toggle, which must invalidate live portal
-		// sessions on a keyspace when it turns encryption on. Do not close them
-		// here by requiring encrypt_key unconditionally: that would make this
-		// ceiling stricter than the operator route it exists to mirror.
+		// mint. The portal create-key route never creates recoverable keys, so
+		// it adds no second path. The fix belongs to the toggle, which must
+		// invalidate live portal sessions on a keyspace when it turns encryption
+		// on. Do not close it here by requiring encrypt_key unconditionally: that
+		// would make this ceiling stricter than the operator route it exists to
+		// mirror.
 		queries := []rbac.PermissionQuery{rerollkey.CreateKeyPermissions(apiID)}
 		if storeEncryptedKeys {
 			queries = append(queries, rerollkey.EncryptKeyPermissions(apiID))
 		}
 		return queries, true
 
+	case openapi.KeysDelete:
+		return []rbac.PermissionQuery{deletekey.DeleteKeyPermissions(apiID)}, true
+
 	case openapi.AnalyticsRead:
 		return []rbac.PermissionQuery{readAnalyticsPermissions(apiID)}, true
 
@@ -21,7 +21,7 @@ func TestScopeQueriesDeniesUnmappedScope(t *testing.T) {
 
 	t.Run("known scopes map to a non-empty requirement", func(t *testing.T) {
 		for _, s := range []openapi.V2PortalCreateSessionRequestBodyScopes{
-			openapi.KeysRead, openapi.KeysCreate, openapi.KeysReroll, openapi.AnalyticsRead,
+			openapi.KeysRead, openapi.KeysCreate, openapi.KeysReroll, openapi.KeysDelete, openapi.AnalyticsRead,
 		} {
 			queries, ok := handler.ScopeQueries(s, apiID, false)
 			require.True(t, ok, "scope %q must map", s)
@@ -0,0 +1,126 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	deletekey "github.com/unkeyed/unkey/svc/api/routes/v2_keys_delete_key"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_portal_delete_key"
+)
+
+type (
+	Request  = handler.Request
+	Response = handler.Response
+)
+
+// newHandler builds the portal.deleteKey handler backed by a configured
+// keys.deleteKey handler.
+func newHandler(h *testutil.Harness) *handler.Handler {
+	return handler.New(&deletekey.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
+		Webhooks:  h.Webhooks,
+	})
+}
+
+// TestPortalSessionDeleteOwnKey verifies a portal session can delete a key
+// owned by its own identity, and that the delete is a soft delete.
+func TestPortalSessionDeleteOwnKey(t *testing.T) {
+	h := testutil.NewHarness(t)
+	ctx := context.Background()
+
+	route := newHandler(h)
+	h.Register(route, h.PortalMiddleware()...)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	identity := h.CreateIdentity(seed.CreateIdentityRequest{
+		WorkspaceID: workspace.ID,
+		ExternalID:  "portal_user_A",
+	})
+	key := h.CreateKey(seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeySpaceID:  api.KeyAuthID.String,
+		IdentityID:  ptr.P(identity.ID),
+	})
+
+	headers := h.CreatePortalSession(workspace.ID, identity.ExternalID, []string{api.KeyAuthID.String}, []string{"keys:delete"})
+
+	res := testutil.CallRoute[Request, Response](h, route, headers, Request{KeyId: key.KeyID})
+	require.Equal(t, http.StatusOK, res.Status, res.RawBody)
+
+	deleted, err := db.Query.FindKeyByID(ctx, h.DB.RO(), key.KeyID)
+	require.NoError(t, err, "portal deletes are soft deletes")
+	require.True(t, deleted.DeletedAtM.Valid)
+}
+
+func TestPortalSessionRequiresDeleteCapability(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := newHandler(h)
+	h.Register(route, h.PortalMiddleware()...)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	identity := h.CreateIdentity(seed.CreateIdentityRequest{
+		WorkspaceID: workspace.ID,
+		ExternalID:  "portal_user_A",
+	})
+	key := h.CreateKey(seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeySpaceID:  api.KeyAuthID.String,
+		IdentityID:  ptr.P(identity.ID),
+	})
+
+	headers := h.CreatePortalSession(
+		workspace.ID,
+		identity.ExternalID,
+		[]string{api.KeyAuthID.String},
+		[]string{"keys:read", "keys:create", "keys:reroll"},
+	)
+	res := testutil.CallRoute[Request, openapi.ForbiddenErrorResponse](h, route, headers, Request{KeyId: key.KeyID})
+
+	require.Equal(t, http.StatusForbidden, res.Status, "no other capability may imply keys:delete")
+	require.NotContains(t, res.RawBody, key.KeyID)
+}
+
+// TestPortalSessionCannotDeleteOtherIdentityKey verifies a portal session
+// cannot delete a key belonging to a different externalId and receives a 404
+// so the key's existence is not leaked.
+func TestPortalSessionCannotDeleteOtherIdentityKey(t *testing.T) {
+	h := testutil.NewHarness(t)
+	ctx := context.Background()
+
+	route := newHandler(h)
+	h.Register(route, h.PortalMiddleware()...)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	otherIdentity := h.CreateIdentity(seed.CreateIdentityRequest{
+		WorkspaceID: workspace.ID,
+		ExternalID:  "portal_user_B",
+	})
+	otherKey := h.CreateKey(seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeySpaceID:  api.KeyAuthID.String,
+		IdentityID:  ptr.P(otherIdentity.ID),
+	})
+
+	headers := h.CreatePortalSession(workspace.ID, "portal_user_A", []string{api.KeyAuthID.String}, []string{"keys:delete"})
+
+	res := testutil.CallRoute[Request, openapi.NotFoundErrorResponse](h, route, headers, Request{KeyId: otherKey.KeyID})
+	require.Equal(t, http.StatusNotFound, res.Status)
+	require.Equal(t, "The specified key was not found.", res.Body.Error.Detail)
+
+	key, err := db.Query.FindKeyByID(ctx, h.DB.RO(), otherKey.KeyID)
+	require.NoError(t, err)
+	require.False(t, key.DeletedAtM.Valid, "the other identity's key must survive")
+}
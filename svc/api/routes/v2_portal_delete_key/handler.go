@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"slices"
+
+	"github.com/unkeyed/unkey/pkg/auth/portalrbac"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/portalscope"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	deletekey "github.com/unkeyed/unkey/svc/api/routes/v2_keys_delete_key"
+)
+
+type (
+	// Request is the portal.deleteKey public contract. Unlike keys.deleteKey it
+	// has no permanent flag: an end user can only soft delete, so the key stays
+	// in the customer's audit trail.
+	Request  = openapi.V2PortalDeleteKeyRequestBody
+	Response = deletekey.Response
+)
+
+// Handler serves the portal-scoped variant of keys.deleteKey. It authenticates
+// only portal sessions and may only delete keys owned by the session's external
+// identity.
+//
+// The core is held in an unexported field, not embedded, for the same reason
+// as portal.rerollKey: embedding would promote the core's Method/Path/Handle
+// and let a missing override fall through to the unscoped operator route.
+type Handler struct {
+	del *deletekey.Handler
+}
+
+// New builds a portal.deleteKey handler over the shared keys.deleteKey core.
+func New(del *deletekey.Handler) *Handler {
+	return &Handler{del: del}
+}
+
+// Method returns the HTTP method this route responds to.
+func (h *Handler) Method() string { return "POST" }
+
+// Path returns the URL path pattern this route matches.
+func (h *Handler) Path() string { return "/v2/portal.deleteKey" }
+
+// Handle soft deletes a key scoped to the portal session's external identity.
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+	if err := principal.Authorize(rbac.S(portalrbac.CapKeysDelete)); err != nil {
+		return err
+	}
+
+	externalID, err := portalscope.ExternalID(s)
+	if err != nil {
+		return err
+	}
+	keyspaceIDs, err := portalscope.KeyspaceIDs(s)
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	key, err := h.del.FindLiveKey(ctx, req.KeyId)
+	if err != nil {
+		return err
+	}
+
+	// Ownership guard: a portal caller may only delete a key that belongs to its
+	// own external identity within its own workspace. Fail closed with a 404 so
+	// the caller cannot probe for keys it does not own.
+	if key.WorkspaceID != principal.WorkspaceID ||
+		!slices.Contains(keyspaceIDs, key.KeyAuthID) ||
+		!key.IdentityExternalID.Valid ||
+		key.IdentityExternalID.String != externalID {
+		return fault.New("key not found",
+			fault.Code(codes.Data.Key.NotFound.URN()),
+			fault.Internal("key does not belong to portal session identity"),
+			fault.Public("The specified key was not found."),
+		)
+	}
+
+	return h.del.DeleteKey(ctx, s, deletekey.Request{
+		KeyId:     req.KeyId,
+		Permanent: nil,
+	}, key)
+}
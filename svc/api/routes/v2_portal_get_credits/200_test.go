@@ -0,0 +1,87 @@
+package handler_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_portal_get_credits"
+)
+
+type (
+	Request  = handler.Request
+	Response = handler.Response
+)
+
+func TestPortalSessionGetCredits(t *testing.T) {
+	h := testutil.NewHarness(t)
+	ctx := context.Background()
+
+	route := &handler.Handler{DB: h.DB}
+	h.Register(route, h.PortalMiddleware()...)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	keyspaces := []string{api.KeyAuthID.String}
+
+	withPool := h.CreateIdentity(seed.CreateIdentityRequest{
+		WorkspaceID: workspace.ID,
+		ExternalID:  "portal_user_A",
+	})
+	require.NoError(t, db.Query.UpsertIdentityCredits(ctx, h.DB.RW(), db.UpsertIdentityCreditsParams{
+		IdentityID:   withPool.ID,
+		WorkspaceID:  workspace.ID,
+		Remaining:    500,
+		RefillDay:    sql.NullInt16{Valid: false},
+		RefillAmount: sql.NullInt64{Valid: true, Int64: 1000},
+		Now:          time.Now().UnixMilli(),
+	}))
+
+	// Another identity's pool must never be visible.
+	other := h.CreateIdentity(seed.CreateIdentityRequest{
+		WorkspaceID: workspace.ID,
+		ExternalID:  "portal_user_B",
+	})
+	require.NoError(t, db.Query.UpsertIdentityCredits(ctx, h.DB.RW(), db.UpsertIdentityCreditsParams{
+		IdentityID:   other.ID,
+		WorkspaceID:  workspace.ID,
+		Remaining:    7,
+		RefillDay:    sql.NullInt16{Valid: false},
+		RefillAmount: sql.NullInt64{Valid: false},
+		Now:          time.Now().UnixMilli(),
+	}))
+
+	t.Run("returns the session identity's pool", func(t *testing.T) {
+		headers := h.CreatePortalSession(workspace.ID, withPool.ExternalID, keyspaces, []string{"keys:read"})
+
+		res := testutil.CallRoute[Request, Response](h, route, headers, Request{})
+		require.Equal(t, http.StatusOK, res.Status, res.RawBody)
+		require.NotNil(t, res.Body.Data.Credits)
+		require.Equal(t, int64(500), res.Body.Data.Credits.Remaining)
+		require.NotNil(t, res.Body.Data.Credits.Refill)
+		require.Equal(t, int64(1000), res.Body.Data.Credits.Refill.Amount)
+		require.Equal(t, openapi.KeyCreditsRefillIntervalDaily, res.Body.Data.Credits.Refill.Interval)
+	})
+
+	t.Run("an end user without an identity has no pool", func(t *testing.T) {
+		headers := h.CreatePortalSession(workspace.ID, "portal_user_new", keyspaces, []string{"keys:read"})
+
+		res := testutil.CallRoute[Request, Response](h, route, headers, Request{})
+		require.Equal(t, http.StatusOK, res.Status, res.RawBody)
+		require.Nil(t, res.Body.Data.Credits)
+	})
+
+	t.Run("requires the keys:read capability", func(t *testing.T) {
+		headers := h.CreatePortalSession(workspace.ID, withPool.ExternalID, keyspaces, []string{"analytics:read"})
+
+		res := testutil.CallRoute[Request, openapi.ForbiddenErrorResponse](h, route, headers, Request{})
+		require.Equal(t, http.StatusForbidden, res.Status)
+	})
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/unkeyed/unkey/pkg/auth/portalrbac"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/identitycredits"
+	"github.com/unkeyed/unkey/svc/api/internal/portalscope"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2PortalGetCreditsRequestBody
+	Response = openapi.V2PortalGetCreditsResponseBody
+)
+
+// Handler serves portal.getCredits: the credit pool of the portal session's
+// external identity. Per-key balances need no route of their own because
+// portal.listKeys already returns them on each key.
+type Handler struct {
+	DB db.Database
+}
+
+// Method returns the HTTP method this route responds to.
+func (h *Handler) Method() string { return "POST" }
+
+// Path returns the URL path pattern this route matches.
+func (h *Handler) Path() string { return "/v2/portal.getCredits" }
+
+// Handle returns the end user's credit pool, if they have one.
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+	if err := principal.Authorize(rbac.S(portalrbac.CapKeysRead)); err != nil {
+		return err
+	}
+
+	externalID, err := portalscope.ExternalID(s)
+	if err != nil {
+		return err
+	}
+
+	if _, err := zen.BindBody[Request](s); err != nil {
+		return err
+	}
+
+	// An end user without an identity or without a pool simply has no pooled
+	// credits; that is not an error.
+	identity, err := db.Query.FindIdentityByExternalID(ctx, h.DB.RO(), db.FindIdentityByExternalIDParams{
+		WorkspaceID: principal.WorkspaceID,
+		ExternalID:  externalID,
+		Deleted:     false,
+	})
+	if err != nil {
+		if db.IsNotFound(err) {
+			return h.respond(s, nil)
+		}
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to retrieve identity."),
+		)
+	}
+
+	pool, err := db.Query.FindIdentityCredits(ctx, h.DB.RO(), identity.ID)
+	if err != nil {
+		if db.IsNotFound(err) {
+			return h.respond(s, nil)
+		}
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("unable to find identity credits"),
+			fault.Public("We're unable to retrieve your credits."),
+		)
+	}
+
+	return h.respond(s, identitycredits.Response(int64(pool.Remaining), pool.RefillDay, pool.RefillAmount)) // nolint:gosec // balances never approach MaxInt64
+}
+
+func (h *Handler) respond(s *zen.Session, credits *openapi.IdentityCredits) error {
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{RequestId: s.RequestID()},
+		Data: openapi.V2PortalGetCreditsResponseData{Credits: credits},
+	})
+}
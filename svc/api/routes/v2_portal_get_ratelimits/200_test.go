@@ -0,0 +1,138 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/clickhouse/schema"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_portal_get_ratelimits"
+	getverifications "github.com/unkeyed/unkey/svc/api/routes/v2_portal_get_verifications"
+)
+
+type (
+	Request  = handler.Request
+	Response = handler.Response
+)
+
+// TestPortalSessionGetRatelimits verifies the route returns the identity's
+// configured ratelimits and folds the end user's verifications into passed and
+// rate limited, without leaking another identity's events.
+func TestPortalSessionGetRatelimits(t *testing.T) {
+	h := testutil.NewHarness(t, testutil.HarnessConfig{ClickHouse: true})
+
+	workspace := h.CreateWorkspace()
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	h.SetupAnalytics(workspace.ID)
+
+	route := handler.New(&getverifications.Handler{
+		ClickHouse:  h.ClickHouse,
+		DB:          h.DB,
+		LimitsCache: h.Caches.WorkspaceLimits,
+	})
+	h.Register(route, h.PortalMiddleware()...)
+
+	externalA := "portal_user_A"
+	identityA := h.CreateIdentity(seed.CreateIdentityRequest{
+		WorkspaceID: workspace.ID,
+		ExternalID:  externalA,
+		Ratelimits: []seed.CreateRatelimitRequest{{
+			Name:        "requests",
+			WorkspaceID: workspace.ID,
+			AutoApply:   true,
+			Duration:    60_000,
+			Limit:       100,
+		}},
+	})
+	keyA := h.CreateKey(seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeySpaceID:  api.KeyAuthID.String,
+		IdentityID:  ptr.P(identityA.ID),
+	})
+
+	identityB := h.CreateIdentity(seed.CreateIdentityRequest{
+		WorkspaceID: workspace.ID,
+		ExternalID:  "portal_user_B",
+	})
+	keyB := h.CreateKey(seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeySpaceID:  api.KeyAuthID.String,
+		IdentityID:  ptr.P(identityB.ID),
+	})
+
+	now := time.Now().UnixMilli()
+	buffer := func(keyID, externalID, identityID, outcome string, n int) {
+		for i := range n {
+			h.KeyVerifications.Buffer(schema.KeyVerification{
+				RequestID:   uid.New(uid.RequestPrefix),
+				Time:        now - int64(i*1000),
+				WorkspaceID: workspace.ID,
+				KeySpaceID:  api.KeyAuthID.String,
+				KeyID:       keyID,
+				Region:      "us-west-1",
+				Outcome:     outcome,
+				IdentityID:  identityID,
+				ExternalID:  externalID,
+				Tags:        []string{},
+			})
+		}
+	}
+
+	buffer(keyA.KeyID, externalA, identityA.ID, "VALID", 4)
+	buffer(keyA.KeyID, externalA, identityA.ID, "RATE_LIMITED", 2)
+	buffer(keyB.KeyID, identityB.ExternalID, identityB.ID, "RATE_LIMITED", 5) // must not leak
+
+	headers := h.CreatePortalSession(workspace.ID, externalA, []string{api.KeyAuthID.String}, []string{"analytics:read"})
+	req := Request{
+		StartTime: now - time.Hour.Milliseconds(),
+		EndTime:   now + time.Minute.Milliseconds(),
+	}
+
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		res := testutil.CallRoute[Request, Response](h, route, headers, req)
+		require.Equal(c, http.StatusOK, res.Status)
+		require.NotNil(c, res.Body)
+
+		require.Len(c, res.Body.Data.Ratelimits, 1)
+		require.Equal(c, "requests", res.Body.Data.Ratelimits[0].Name)
+		require.Equal(c, int64(100), res.Body.Data.Ratelimits[0].Limit)
+
+		var passed, limited int64
+		for _, p := range res.Body.Data.History {
+			passed += p.Passed
+			limited += p.RateLimited
+		}
+		require.Equal(c, int64(4), passed)
+		require.Equal(c, int64(2), limited)
+	}, 30*time.Second, time.Second)
+}
+
+func TestPortalSessionGetRatelimitsRequiresAnalyticsCapability(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := handler.New(&getverifications.Handler{
+		ClickHouse:  h.ClickHouse,
+		DB:          h.DB,
+		LimitsCache: h.Caches.WorkspaceLimits,
+	})
+	h.Register(route, h.PortalMiddleware()...)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+
+	headers := h.CreatePortalSession(workspace.ID, "portal_user_A", []string{api.KeyAuthID.String}, []string{"keys:read"})
+	now := time.Now().UnixMilli()
+
+	res := testutil.CallRoute[Request, openapi.ForbiddenErrorResponse](h, route, headers, Request{
+		StartTime: now - time.Hour.Milliseconds(),
+		EndTime:   now,
+	})
+	require.Equal(t, http.StatusForbidden, res.Status)
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/unkeyed/unkey/pkg/auth/portalrbac"
+	"github.com/unkeyed/unkey/pkg/clickhouse"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/portalscope"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	getverifications "github.com/unkeyed/unkey/svc/api/routes/v2_portal_get_verifications"
+)
+
+type (
+	Request  = openapi.V2PortalGetRatelimitsRequestBody
+	Response = openapi.V2PortalGetRatelimitsResponseBody
+)
+
+// Handler serves portal.getRatelimits: the rate limits on the portal session's
+// identity and how often the end user hit them. Key verifications already
+// record a RATE_LIMITED outcome, so the history is the verification series of
+// portal.getVerifications folded into passed and rate limited.
+//
+// It holds the portal.getVerifications handler to share its ClickHouse
+// connection and its retention-bounded window check, so both routes bound the
+// same scan the same way.
+type Handler struct {
+	verifications *getverifications.Handler
+}
+
+// New builds a portal.getRatelimits handler over portal.getVerifications.
+func New(verifications *getverifications.Handler) *Handler {
+	return &Handler{verifications: verifications}
+}
+
+// Method returns the HTTP method this route responds to.
+func (h *Handler) Method() string { return "POST" }
+
+// Path returns the URL path pattern this route matches.
+func (h *Handler) Path() string { return "/v2/portal.getRatelimits" }
+
+// Handle returns the end user's identity ratelimits and their ratelimit
+// history over the requested window.
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+	if err := principal.Authorize(rbac.S(portalrbac.CapAnalyticsRead)); err != nil {
+		return err
+	}
+
+	externalID, err := portalscope.ExternalID(s)
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	if err := h.verifications.CheckWindow(ctx, principal.WorkspaceID, req.StartTime, req.EndTime); err != nil {
+		return err
+	}
+
+	ratelimits, err := h.identityRatelimits(ctx, principal.WorkspaceID, externalID)
+	if err != nil {
+		return err
+	}
+
+	points, err := h.verifications.ClickHouse.GetVerificationsByExternalID(ctx, clickhouse.VerificationTimeseriesRequest{
+		WorkspaceID: principal.WorkspaceID,
+		ExternalID:  externalID,
+		KeyID:       ptr.SafeDeref(req.KeyId),
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	history := make([]openapi.V2PortalGetRatelimitsDataPoint, len(points))
+	for i, p := range points {
+		history[i] = openapi.V2PortalGetRatelimitsDataPoint{
+			Time:        p.Time,
+			Passed:      p.Total - p.RateLimited,
+			RateLimited: p.RateLimited,
+		}
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{RequestId: s.RequestID()},
+		Data: openapi.V2PortalGetRatelimitsResponseData{
+			Ratelimits: ratelimits,
+			History:    history,
+		},
+	})
+}
+
+// identityRatelimits lists the rate limits on the end user's identity. An end
+// user without an identity yet has none.
+func (h *Handler) identityRatelimits(ctx context.Context, workspaceID, externalID string) ([]openapi.RatelimitResponse, error) {
+	identity, err := db.Query.FindIdentityByExternalID(ctx, h.verifications.DB.RO(), db.FindIdentityByExternalIDParams{
+		WorkspaceID: workspaceID,
+		ExternalID:  externalID,
+		Deleted:     false,
+	})
+	if err != nil {
+		if db.IsNotFound(err) {
+			return []openapi.RatelimitResponse{}, nil
+		}
+		return nil, fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to retrieve identity."),
+		)
+	}
+
+	rows, err := db.Query.ListIdentityRatelimits(ctx, h.verifications.DB.RO(), sql.NullString{String: identity.ID, Valid: true})
+	if err != nil {
+		return nil, fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to retrieve ratelimits."),
+		)
+	}
+
+	ratelimits := make([]openapi.RatelimitResponse, len(rows))
+	for i, r := range rows {
+		ratelimits[i] = openapi.RatelimitResponse{
+			Id:        r.ID,
+			Name:      r.Name,
+			Limit:     int64(r.Limit),    // nolint:gosec
+			Duration:  int64(r.Duration), // nolint:gosec
+			AutoApply: r.AutoApply,
+		}
+	}
+	return ratelimits, nil
+}
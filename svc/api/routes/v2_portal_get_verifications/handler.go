@@ -71,37 +71,8 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return err
 	}
 
-	if req.EndTime <= req.StartTime {
-		return fault.New("invalid time window",
-			fault.Code(codes.App.Validation.InvalidInput.URN()),
-			fault.Internal("endTime must be greater than startTime"),
-			fault.Public("`endTime` must be greater than `startTime`."),
-		)
-	}
-
-	// Bound the window to the workspace's log retention. This runs on the shared
-	// ClickHouse connection, so an unbounded window (e.g. the unix epoch to a far
-	// future) would let an end user force an arbitrarily large scan and
-	// zero-filled series. We use the same log retention limit that the protected
-	// analytics.getVerifications path uses as MaxQueryRangeDays, so the portal
-	// cannot query a wider range than the workspace itself.
-	limits, _, err := h.LimitsCache.SWR(ctx, principal.WorkspaceID, func(ctx context.Context) (keysdb.Limit, error) {
-		return keysdb.Query.FindLimitsByWorkspaceID(ctx, h.DB.RO(), principal.WorkspaceID)
-	}, caches.DefaultFindFirstOp)
-	if err != nil {
-		return fault.Wrap(err,
-			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
-			fault.Internal("failed to load workspace limits"),
-			fault.Public("Failed to validate the requested time window."),
-		)
-	}
-
-	if limits.LogsRetentionDaysMax > 0 && req.EndTime-req.StartTime > int64(limits.LogsRetentionDaysMax)*millisPerDay {
-		return fault.New("time window too large",
-			fault.Code(codes.App.Validation.InvalidInput.URN()),
-			fault.Internal("requested window exceeds workspace log retention"),
-			fault.Public(fmt.Sprintf("The requested time window is too large. The maximum window is %d days.", limits.LogsRetentionDaysMax)),
-		)
+	if err := h.CheckWindow(ctx, principal.WorkspaceID, req.StartTime, req.EndTime); err != nil {
+		return err
 	}
 
 	points, err := h.ClickHouse.GetVerificationsByExternalID(ctx, clickhouse.VerificationTimeseriesRequest{
@@ -137,3 +108,43 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		Data: data,
 	})
 }
+
+// CheckWindow validates a [startTime, endTime) query window against the
+// workspace's log retention. portal.getRatelimits shares it so both portal
+// analytics routes bound the same scan.
+func (h *Handler) CheckWindow(ctx context.Context, workspaceID string, startTime, endTime int64) error {
+	if endTime <= startTime {
+		return fault.New("invalid time window",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal("endTime must be greater than startTime"),
+			fault.Public("`endTime` must be greater than `startTime`."),
+		)
+	}
+
+	// Bound the window to the workspace's log retention. This runs on the shared
+	// ClickHouse connection, so an unbounded window (e.g. the unix epoch to a far
+	// future) would let an end user force an arbitrarily large scan and
+	// zero-filled series. We use the same log retention limit that the protected
+	// analytics.getVerifications path uses as MaxQueryRangeDays, so the portal
+	// cannot query a wider range than the workspace itself.
+	limits, _, err := h.LimitsCache.SWR(ctx, workspaceID, func(ctx context.Context) (keysdb.Limit, error) {
+		return keysdb.Query.FindLimitsByWorkspaceID(ctx, h.DB.RO(), workspaceID)
+	}, caches.DefaultFindFirstOp)
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("failed to load workspace limits"),
+			fault.Public("Failed to validate the requested time window."),
+		)
+	}
+
+	if limits.LogsRetentionDaysMax > 0 && endTime-startTime > int64(limits.LogsRetentionDaysMax)*millisPerDay {
+		return fault.New("time window too large",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal("requested window exceeds workspace log retention"),
+			fault.Public(fmt.Sprintf("The requested time window is too large. The maximum window is %d days.", limits.LogsRetentionDaysMax)),
+		)
+	}
+
+	return nil
+}
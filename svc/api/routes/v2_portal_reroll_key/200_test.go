@@ -29,6 +29,7 @@ func newHandler(h *testutil.Harness) *handler.Handler {
 		Keys:      h.Keys,
 		Auditlogs: h.Auditlogs,
 		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
 	})
 }
 
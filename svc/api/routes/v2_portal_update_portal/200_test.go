@@ -0,0 +1,124 @@
+package handler_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	createkey "github.com/unkeyed/unkey/svc/api/routes/v2_keys_create_key"
+	portalcreatekey "github.com/unkeyed/unkey/svc/api/routes/v2_portal_create_key"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_portal_update_portal"
+)
+
+// The policy written through portal.updatePortal is the one portal.createKey
+// enforces for end users.
+func TestUpdatePortalKeyPolicyEndToEnd(t *testing.T) {
+	h := testutil.NewHarness(t)
+	ctx := context.Background()
+
+	route := &handler.Handler{DB: h.DB, Auditlogs: h.Auditlogs}
+	h.Register(route)
+
+	createKeyRoute := portalcreatekey.New(&createkey.Handler{
+		DB:        h.DB,
+		Keys:      h.Keys,
+		Auditlogs: h.Auditlogs,
+		Vault:     h.Vault,
+		Webhooks:  h.Webhooks,
+	})
+	h.Register(createKeyRoute, h.PortalMiddleware()...)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	keyspaceID := api.KeyAuthID.String
+	portal := createPortal(t, h, workspace.ID, keyspaceID)
+
+	rootKey := h.CreateRootKey(workspace.ID, "portal.*.update_portal")
+	res := testutil.CallRoute[handler.Request, handler.Response](h, route, authHeaders(rootKey), handler.Request{
+		Portal: portal.Slug,
+		KeyPolicy: openapi.V2PortalUpdatePortalKeyPolicy{
+			MaxKeys:            ptr.P(int32(1)),
+			AllowedPermissions: ptr.P([]string{"documents.read"}),
+			AllowedRoles:       ptr.P([]string{"viewer"}),
+			RatelimitTemplates: ptr.P([]openapi.RatelimitRequest{
+				{Name: "requests", Limit: 100, Duration: 60000, AutoApply: true},
+			}),
+			MinExpiryMs: ptr.P((24 * time.Hour).Milliseconds()),
+			MaxExpiryMs: ptr.P((30 * 24 * time.Hour).Milliseconds()),
+		},
+	})
+	require.Equal(t, http.StatusOK, res.Status, res.RawBody)
+	require.NotEmpty(t, res.Body.Meta.RequestId)
+
+	headers := h.CreatePortalSessionForPortal(workspace.ID, portal.ID, "portal_user_A", []string{keyspaceID}, []string{"keys:create"})
+
+	t.Run("rejects a key without an expiry", func(t *testing.T) {
+		res := testutil.CallRoute[portalcreatekey.Request, openapi.BadRequestErrorResponse](h, createKeyRoute, headers, portalcreatekey.Request{})
+		require.Equal(t, http.StatusBadRequest, res.Status, res.RawBody)
+	})
+
+	t.Run("rejects an expiry below the minimum", func(t *testing.T) {
+		res := testutil.CallRoute[portalcreatekey.Request, openapi.BadRequestErrorResponse](h, createKeyRoute, headers, portalcreatekey.Request{
+			Expires: ptr.P(time.Now().Add(time.Hour).UnixMilli()),
+		})
+		require.Equal(t, http.StatusBadRequest, res.Status, res.RawBody)
+	})
+
+	t.Run("rejects a permission outside the allow list", func(t *testing.T) {
+		res := testutil.CallRoute[portalcreatekey.Request, openapi.ForbiddenErrorResponse](h, createKeyRoute, headers, portalcreatekey.Request{
+			Expires:     ptr.P(time.Now().Add(7 * 24 * time.Hour).UnixMilli()),
+			Permissions: ptr.P([]string{"documents.write"}),
+		})
+		require.Equal(t, http.StatusForbidden, res.Status, res.RawBody)
+	})
+
+	t.Run("creates a key within the policy", func(t *testing.T) {
+		res := testutil.CallRoute[portalcreatekey.Request, portalcreatekey.Response](h, createKeyRoute, headers, portalcreatekey.Request{
+			Expires:     ptr.P(time.Now().Add(7 * 24 * time.Hour).UnixMilli()),
+			Permissions: ptr.P([]string{"documents.read"}),
+		})
+		require.Equal(t, http.StatusOK, res.Status, res.RawBody)
+
+		ratelimits, err := db.Query.ListRatelimitsByKeyID(ctx, h.DB.RO(), sql.NullString{Valid: true, String: res.Body.Data.KeyId})
+		require.NoError(t, err)
+		require.Len(t, ratelimits, 1, "the portal's ratelimit template is stamped on the key")
+		require.Equal(t, "requests", ratelimits[0].Name)
+		require.Equal(t, uint64(100), ratelimits[0].Limit)
+		require.Equal(t, uint64(60000), ratelimits[0].Duration)
+	})
+
+	t.Run("enforces max keys", func(t *testing.T) {
+		res := testutil.CallRoute[portalcreatekey.Request, openapi.ForbiddenErrorResponse](h, createKeyRoute, headers, portalcreatekey.Request{
+			Expires: ptr.P(time.Now().Add(7 * 24 * time.Hour).UnixMilli()),
+		})
+		require.Equal(t, http.StatusForbidden, res.Status, res.RawBody)
+		require.Equal(t, "You can have at most 1 keys. Delete a key before creating a new one.", res.Body.Error.Detail)
+	})
+
+	t.Run("an empty policy clears every bound", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, authHeaders(rootKey), handler.Request{
+			Portal:    portal.ID,
+			KeyPolicy: openapi.V2PortalUpdatePortalKeyPolicy{},
+		})
+		require.Equal(t, http.StatusOK, res.Status, res.RawBody)
+
+		got := findPortal(t, h, workspace.ID, portal.ID)
+		require.False(t, got.MaxKeys.Valid)
+		require.Nil(t, got.AllowedPermissions)
+		require.Nil(t, got.AllowedRoles)
+		require.Nil(t, got.RatelimitTemplates)
+		require.False(t, got.MinExpiryMs.Valid)
+		require.False(t, got.MaxExpiryMs.Valid)
+
+		created := testutil.CallRoute[portalcreatekey.Request, portalcreatekey.Response](h, createKeyRoute, headers, portalcreatekey.Request{})
+		require.Equal(t, http.StatusOK, created.Status, "a key without expiry is allowed again: %s", created.RawBody)
+	})
+}
@@ -0,0 +1,57 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_portal_update_portal"
+)
+
+func TestUpdatePortal400(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{DB: h.DB, Auditlogs: h.Auditlogs}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	portal := createPortal(t, h, workspace.ID, api.KeyAuthID.String)
+
+	rootKey := h.CreateRootKey(workspace.ID, "portal.*.update_portal")
+	headers := authHeaders(rootKey)
+
+	testCases := []struct {
+		name   string
+		policy openapi.V2PortalUpdatePortalKeyPolicy
+	}{
+		{name: "negative max keys", policy: openapi.V2PortalUpdatePortalKeyPolicy{MaxKeys: ptr.P(int32(-1))}},
+		{name: "negative min expiry", policy: openapi.V2PortalUpdatePortalKeyPolicy{MinExpiryMs: ptr.P(int64(-1))}},
+		{name: "min expiry above max expiry", policy: openapi.V2PortalUpdatePortalKeyPolicy{
+			MinExpiryMs: ptr.P(int64(7_200_000)),
+			MaxExpiryMs: ptr.P(int64(3_600_000)),
+		}},
+		{name: "invalid permission slug", policy: openapi.V2PortalUpdatePortalKeyPolicy{AllowedPermissions: ptr.P([]string{"documents read"})}},
+		{name: "ratelimit window too short", policy: openapi.V2PortalUpdatePortalKeyPolicy{RatelimitTemplates: ptr.P([]openapi.RatelimitRequest{
+			{Name: "requests", Limit: 100, Duration: 10, AutoApply: true},
+		})}},
+		{name: "duplicate ratelimit template", policy: openapi.V2PortalUpdatePortalKeyPolicy{RatelimitTemplates: ptr.P([]openapi.RatelimitRequest{
+			{Name: "requests", Limit: 100, Duration: 60000, AutoApply: true},
+			{Name: "requests", Limit: 10, Duration: 1000, AutoApply: false},
+		})}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, handler.Request{
+				Portal:    portal.ID,
+				KeyPolicy: tc.policy,
+			})
+			require.Equal(t, http.StatusBadRequest, res.Status, "expected 400 for %q, got: %s", tc.name, res.RawBody)
+		})
+	}
+}
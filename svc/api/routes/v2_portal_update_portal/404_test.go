@@ -0,0 +1,49 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil/seed"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_portal_update_portal"
+)
+
+// A missing portal and a portal the root key may not update both return 404,
+// so a caller cannot probe for portals it has no access to.
+func TestUpdatePortalNotFound(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{DB: h.DB, Auditlogs: h.Auditlogs}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	portal := createPortal(t, h, workspace.ID, api.KeyAuthID.String)
+
+	testCases := []struct {
+		name        string
+		portal      string
+		permissions []string
+	}{
+		{name: "unknown portal", portal: uid.New(uid.PortalPrefix), permissions: []string{"portal.*.update_portal"}},
+		{name: "session permission is not enough", portal: portal.ID, permissions: []string{"portal.*.create_portal_session"}},
+		{name: "other portal id does not match", portal: portal.ID, permissions: []string{fmt.Sprintf("portal.%s.update_portal", uid.New(uid.PortalPrefix))}},
+		{name: "no permissions", portal: portal.ID, permissions: []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rootKey := h.CreateRootKey(workspace.ID, tc.permissions...)
+			res := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, authHeaders(rootKey), handler.Request{
+				Portal:    tc.portal,
+				KeyPolicy: openapi.V2PortalUpdatePortalKeyPolicy{},
+			})
+			require.Equal(t, http.StatusNotFound, res.Status, "expected 404 for %v, got: %s", tc.permissions, res.RawBody)
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/unkeyed/unkey/internal/services/auditlogs"
+	// Aliased: this handler's local `portal` variable is the db.Portal row it
+	// resolves, which would otherwise shadow the package.
+	portalservice "github.com/unkeyed/unkey/internal/services/portal"
+	"github.com/unkeyed/unkey/pkg/auditlog"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	apierrors "github.com/unkeyed/unkey/svc/api/internal/errors"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2PortalUpdatePortalRequestBody
+	Response = openapi.V2PortalUpdatePortalResponseBody
+)
+
+// Handler implements zen.Route for the portal update endpoint.
+type Handler struct {
+	DB        db.Database
+	Auditlogs auditlogs.AuditLogService
+}
+
+func (h *Handler) Method() string { return "POST" }
+func (h *Handler) Path() string   { return "/v2/portal.updatePortal" }
+
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	workspaceID := principal.WorkspaceID
+
+	portal, err := db.Query.FindPortalByIdOrSlug(ctx, h.DB.RO(), db.FindPortalByIdOrSlugParams{
+		WorkspaceID: workspaceID,
+		Portal:      req.Portal,
+	})
+	if err != nil {
+		if db.IsNotFound(err) {
+			return fault.New("portal not found",
+				fault.Code(codes.Data.Portal.NotFound.URN()),
+				fault.Internal("no portal found for the given id or slug"),
+				fault.Public("Portal not found."),
+			)
+		}
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error looking up portal"),
+			fault.Public("Failed to look up portal configuration."),
+		)
+	}
+
+	// Built from portal.ID rather than req.Portal for the same reasons as
+	// portal.createSession: req.Portal may be a slug, and `*` in a stored
+	// grant is matched literally.
+	if err = principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Portal,
+			ResourceID:   "*",
+			Action:       rbac.UpdatePortal,
+		}),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Portal,
+			ResourceID:   portal.ID,
+			Action:       rbac.UpdatePortal,
+		}),
+	)); err != nil {
+		return apierrors.MaskInsufficientPermissionsAsNotFound(
+			err,
+			codes.Data.Portal.NotFound.URN(),
+			"Portal not found.",
+		)
+	}
+
+	params, err := keyPolicyParams(req.KeyPolicy)
+	if err != nil {
+		return err
+	}
+	params.ID = portal.ID
+	params.WorkspaceID = workspaceID
+	params.UpdatedAt = sql.NullInt64{Valid: true, Int64: time.Now().UnixMilli()}
+
+	err = db.Tx(ctx, h.DB.RW(), func(txCtx context.Context, tx db.DBTX) error {
+		if txErr := db.Query.UpdatePortalKeyPolicy(txCtx, tx, params); txErr != nil {
+			return fault.Wrap(txErr,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("failed to update portal key policy"),
+				fault.Public("Failed to update portal."),
+			)
+		}
+
+		return h.Auditlogs.Insert(txCtx, tx, []auditlog.AuditLog{
+			{
+				Event:         auditlog.PortalUpdateEvent,
+				WorkspaceID:   workspaceID,
+				ActorType:     auditlog.AuditLogActor(principal.Subject.Type),
+				ActorID:       principal.Subject.ID,
+				ActorName:     principal.Subject.Name,
+				ActorMeta:     map[string]any{},
+				Display:       fmt.Sprintf("Updated key policy of portal %s", portal.Slug),
+				RemoteIP:      s.Location(),
+				UserAgent:     s.UserAgent(),
+				CorrelationID: "",
+				Resources: []auditlog.AuditLogResource{
+					{
+						ID:          portal.ID,
+						DisplayName: portal.Slug,
+						Name:        portal.Slug,
+						Meta: map[string]any{
+							"maxKeys":            params.MaxKeys.Int32,
+							"allowedPermissions": ptr.SafeDeref(req.KeyPolicy.AllowedPermissions, nil),
+							"allowedRoles":       ptr.SafeDeref(req.KeyPolicy.AllowedRoles, nil),
+							"minExpiryMs":        params.MinExpiryMs.Int64,
+							"maxExpiryMs":        params.MaxExpiryMs.Int64,
+						},
+						Type: auditlog.PortalResourceType,
+					},
+				},
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{RequestId: s.RequestID()},
+		Data: openapi.EmptyResponse{},
+	})
+}
+
+// keyPolicyParams validates a key policy and encodes it into the portal's
+// policy columns. Unset and zero bounds, and empty lists, are stored as NULL,
+// which portalservice.PolicyFromPortal reads back as "no bound".
+func keyPolicyParams(policy openapi.V2PortalUpdatePortalKeyPolicy) (db.UpdatePortalKeyPolicyParams, error) {
+	minExpiry := ptr.SafeDeref(policy.MinExpiryMs, 0)
+	maxExpiry := ptr.SafeDeref(policy.MaxExpiryMs, 0)
+	if maxExpiry > 0 && minExpiry > maxExpiry {
+		return db.UpdatePortalKeyPolicyParams{}, invalidInput(
+			"min expiry above max expiry",
+			"`keyPolicy.minExpiryMs` must not be greater than `keyPolicy.maxExpiryMs`.",
+		)
+	}
+
+	templates := ptr.SafeDeref(policy.RatelimitTemplates, nil)
+	seen := make(map[string]struct{}, len(templates))
+	encoded := make([]portalservice.RatelimitTemplate, 0, len(templates))
+	for _, t := range templates {
+		if _, dup := seen[t.Name]; dup {
+			return db.UpdatePortalKeyPolicyParams{}, invalidInput(
+				"duplicate ratelimit template",
+				fmt.Sprintf("Ratelimit template %q is listed more than once. Each name may appear at most once.", t.Name),
+			)
+		}
+		seen[t.Name] = struct{}{}
+		encoded = append(encoded, portalservice.RatelimitTemplate{
+			Name:      t.Name,
+			Limit:     t.Limit,
+			Duration:  t.Duration,
+			AutoApply: t.AutoApply,
+		})
+	}
+
+	permissions, err := encodePolicyList(ptr.SafeDeref(policy.AllowedPermissions, nil))
+	if err != nil {
+		return db.UpdatePortalKeyPolicyParams{}, err
+	}
+	roles, err := encodePolicyList(ptr.SafeDeref(policy.AllowedRoles, nil))
+	if err != nil {
+		return db.UpdatePortalKeyPolicyParams{}, err
+	}
+	ratelimits, err := encodePolicyList(encoded)
+	if err != nil {
+		return db.UpdatePortalKeyPolicyParams{}, err
+	}
+
+	maxKeys := ptr.SafeDeref(policy.MaxKeys, 0)
+
+	return db.UpdatePortalKeyPolicyParams{
+		MaxKeys:            sql.NullInt32{Valid: maxKeys > 0, Int32: maxKeys},
+		AllowedPermissions: permissions,
+		AllowedRoles:       roles,
+		RatelimitTemplates: ratelimits,
+		MinExpiryMs:        sql.NullInt64{Valid: minExpiry > 0, Int64: minExpiry},
+		MaxExpiryMs:        sql.NullInt64{Valid: maxExpiry > 0, Int64: maxExpiry},
+		UpdatedAt:          sql.NullInt64{},
+		ID:                 "",
+		WorkspaceID:        "",
+	}, nil
+}
+
+// encodePolicyList encodes one of the portal's JSON list columns. An empty
+// list encodes to NULL.
+func encodePolicyList[T any](list []T) ([]byte, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+	raw, err := json.Marshal(list)
+	if err != nil {
+		return nil, fault.Wrap(err,
+			fault.Code(codes.App.Internal.UnexpectedError.URN()),
+			fault.Internal("failed to marshal portal key policy"),
+			fault.Public("An internal error occurred."),
+		)
+	}
+	return raw, nil
+}
+
+func invalidInput(internal, public string) error {
+	return fault.New(internal,
+		fault.Code(codes.App.Validation.InvalidInput.URN()),
+		fault.Internal(internal),
+		fault.Public(public),
+	)
+}
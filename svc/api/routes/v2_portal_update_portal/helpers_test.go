@@ -0,0 +1,51 @@
+package handler_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+)
+
+// createPortal inserts an enabled portal for keyspaceID without a key policy
+// and returns it.
+func createPortal(t *testing.T, h *testutil.Harness, workspaceID, keyspaceID string) db.Portal {
+	t.Helper()
+	ctx := context.Background()
+
+	portalID := uid.New(uid.PortalPrefix)
+	require.NoError(t, db.Query.InsertPortal(ctx, h.DB.RW(), db.InsertPortalParams{
+		ID:          portalID,
+		WorkspaceID: workspaceID,
+		Slug:        uid.New("portal"),
+		KeyAuthID:   sql.NullString{Valid: true, String: keyspaceID},
+		Enabled:     true,
+		CreatedAt:   time.Now().UnixMilli(),
+	}))
+
+	return findPortal(t, h, workspaceID, portalID)
+}
+
+func findPortal(t *testing.T, h *testutil.Harness, workspaceID, portalID string) db.Portal {
+	t.Helper()
+	portal, err := db.Query.FindPortalByID(context.Background(), h.DB.RO(), db.FindPortalByIDParams{
+		ID:          portalID,
+		WorkspaceID: workspaceID,
+	})
+	require.NoError(t, err)
+	return portal
+}
+
+func authHeaders(rootKey string) http.Header {
+	return http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+}
@@ -0,0 +1,93 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_acquire"
+	release "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_release"
+)
+
+func TestAcquireAndRelease(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:             h.DB,
+		Ratelimit:      h.Ratelimit,
+		NamespaceCache: h.Caches.RatelimitNamespace,
+	}
+	releaseRoute := &release.Handler{
+		DB:             h.DB,
+		Ratelimit:      h.Ratelimit,
+		NamespaceCache: h.Caches.RatelimitNamespace,
+	}
+	h.Register(route)
+	h.Register(releaseRoute)
+
+	namespaceID := uid.New(uid.RatelimitNamespacePrefix)
+	namespaceName := uid.New("test")
+	err := db.Query.InsertRatelimitNamespace(context.Background(), h.DB.RW(), db.InsertRatelimitNamespaceParams{
+		ID:          namespaceID,
+		WorkspaceID: h.Resources().UserWorkspace.ID,
+		Name:        namespaceName,
+		CreatedAt:   time.Now().UnixMilli(),
+	})
+	require.NoError(t, err)
+
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, fmt.Sprintf("ratelimit.%s.limit", namespaceID))
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	identifier := uid.New("job")
+	req := handler.Request{
+		Namespace:  namespaceName,
+		Identifier: identifier,
+		Limit:      2,
+		Cost:       nil,
+		Ttl:        ptr.P(int64(60000)),
+	}
+
+	first := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+	require.Equal(t, http.StatusOK, first.Status, "received: %s", first.RawBody)
+	require.True(t, first.Body.Data.Success)
+	require.NotNil(t, first.Body.Data.LeaseId)
+	require.NotNil(t, first.Body.Data.ExpiresAt)
+	require.Equal(t, int64(1), first.Body.Data.Remaining)
+
+	second := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+	require.Equal(t, http.StatusOK, second.Status, "received: %s", second.RawBody)
+	require.True(t, second.Body.Data.Success)
+	require.Equal(t, int64(0), second.Body.Data.Remaining)
+
+	denied := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+	require.Equal(t, http.StatusOK, denied.Status, "received: %s", denied.RawBody)
+	require.False(t, denied.Body.Data.Success)
+	require.Nil(t, denied.Body.Data.LeaseId)
+
+	releaseReq := release.Request{
+		Namespace:  namespaceID,
+		Identifier: identifier,
+		LeaseId:    *first.Body.Data.LeaseId,
+	}
+	released := testutil.CallRoute[release.Request, release.Response](h, releaseRoute, headers, releaseReq)
+	require.Equal(t, http.StatusOK, released.Status, "received: %s", released.RawBody)
+	require.True(t, released.Body.Data.Released)
+
+	again := testutil.CallRoute[release.Request, release.Response](h, releaseRoute, headers, releaseReq)
+	require.Equal(t, http.StatusOK, again.Status, "received: %s", again.RawBody)
+	require.False(t, again.Body.Data.Released)
+
+	third := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+	require.Equal(t, http.StatusOK, third.Status, "received: %s", third.RawBody)
+	require.True(t, third.Body.Data.Success)
+}
@@ -0,0 +1,40 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_acquire"
+)
+
+func TestNamespaceNotFound(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:             h.DB,
+		Ratelimit:      h.Ratelimit,
+		NamespaceCache: h.Caches.RatelimitNamespace,
+	}
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "ratelimit.*.limit", "ratelimit.*.create_namespace")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	// Leases never create namespaces, even with create_namespace.
+	res := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, headers, handler.Request{
+		Namespace:  "nonexistent_namespace",
+		Identifier: "user_123",
+		Limit:      1,
+		Cost:       nil,
+		Ttl:        nil,
+	})
+	require.Equal(t, http.StatusNotFound, res.Status, "received: %s", res.RawBody)
+	require.Equal(t, "https://unkey.com/docs/errors/unkey/data/ratelimit_namespace_not_found", res.Body.Error.Type)
+}
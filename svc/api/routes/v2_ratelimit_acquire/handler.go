@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/unkeyed/unkey/internal/services/caches"
+	"github.com/unkeyed/unkey/internal/services/ratelimit"
+	"github.com/unkeyed/unkey/internal/services/ratelimit/namespace"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2RatelimitAcquireRequestBody
+	Response = openapi.V2RatelimitAcquireResponseBody
+)
+
+// defaultTTL is how long a lease is held when the request does not set ttl.
+const defaultTTL = time.Minute
+
+// Handler implements zen.Route interface for the v2 ratelimit acquire endpoint
+type Handler struct {
+	DB             db.Database
+	Ratelimit      ratelimit.Service
+	NamespaceCache cache.Cache[cache.ScopedKey, db.FindRatelimitNamespace]
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/ratelimit.acquire"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	ns, err := FindNamespace(ctx, h.DB, h.NamespaceCache, principal.WorkspaceID, req.Namespace)
+	if err != nil {
+		return err
+	}
+
+	err = principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Ratelimit,
+			ResourceID:   ns.ID,
+			Action:       rbac.Limit,
+		}),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Ratelimit,
+			ResourceID:   "*",
+			Action:       rbac.Limit,
+		}),
+	))
+	if err != nil {
+		return err
+	}
+
+	result, err := h.Ratelimit.Acquire(ctx, ratelimit.AcquireRequest{
+		WorkspaceID: principal.WorkspaceID,
+		Namespace:   ns.ID,
+		Identifier:  req.Identifier,
+		Limit:       req.Limit,
+		Cost:        ptr.SafeDeref(req.Cost, 1),
+		TTL:         time.Duration(ptr.SafeDeref(req.Ttl, defaultTTL.Milliseconds())) * time.Millisecond,
+		Time:        time.Time{},
+	})
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.UnexpectedError.URN()),
+			fault.Internal("lease acquisition failed"),
+			fault.Public("We're unable to process the lease request."),
+		)
+	}
+
+	data := openapi.V2RatelimitAcquireResponseData{
+		Success:   result.Success,
+		LeaseId:   nil,
+		Limit:     result.Limit,
+		Remaining: result.Remaining,
+		ExpiresAt: nil,
+	}
+	if result.Success {
+		data.LeaseId = ptr.P(result.LeaseID)
+		data.ExpiresAt = ptr.P(result.ExpiresAt.UnixMilli())
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: data,
+	})
+}
+
+// FindNamespace returns the namespace leases are held in. Unlike
+// ratelimit.limit, lease endpoints never create namespaces, so a missing or
+// deleted namespace is an error. It is shared with ratelimit.release.
+func FindNamespace(ctx context.Context, database db.Database, nsCache cache.Cache[cache.ScopedKey, db.FindRatelimitNamespace], workspaceID, nameOrID string) (db.FindRatelimitNamespace, error) {
+	ns, hit, err := nsCache.SWR(ctx, cache.ScopedKey{WorkspaceID: workspaceID, Key: nameOrID}, func(ctx context.Context) (db.FindRatelimitNamespace, error) {
+		row, dbErr := db.WithRetryContext(ctx, func() (db.FindRatelimitNamespaceRow, error) {
+			return db.Query.FindRatelimitNamespace(ctx, database.RO(), db.FindRatelimitNamespaceParams{
+				WorkspaceID: workspaceID,
+				Namespace:   nameOrID,
+			})
+		})
+		if dbErr != nil {
+			return db.FindRatelimitNamespace{}, dbErr //nolint:exhaustruct
+		}
+		return namespace.ParseNamespaceRow(row), nil
+	}, caches.DefaultFindFirstOp)
+	if err != nil && !db.IsNotFound(err) {
+		return db.FindRatelimitNamespace{}, fault.Wrap(err, //nolint:exhaustruct
+			fault.Code(codes.App.Internal.UnexpectedError.URN()),
+			fault.Public("An unexpected error occurred while fetching the namespace."),
+		)
+	}
+
+	if err != nil || hit == cache.Null {
+		return db.FindRatelimitNamespace{}, fault.New("namespace not found", //nolint:exhaustruct
+			fault.Code(codes.Data.RatelimitNamespace.NotFound.URN()),
+			fault.Public("This namespace does not exist."),
+		)
+	}
+
+	if ns.DeletedAtM.Valid {
+		return db.FindRatelimitNamespace{}, fault.New("namespace was deleted", //nolint:exhaustruct
+			fault.Code(codes.Data.RatelimitNamespace.Gone.URN()),
+			fault.Public("This namespace has been deleted. Contact support to restore."),
+		)
+	}
+
+	return ns, nil
+}
@@ -47,6 +47,8 @@ func TestDeleteOverrideSuccessfully(t *testing.T) {
 		DB:             h.DB,
 		Auditlogs:      h.Auditlogs,
 		NamespaceCache: h.Caches.RatelimitNamespace,
+		Caches:         h.Caches.Invalidations,
+		Webhooks:       h.Webhooks,
 	}
 
 	h.Register(route)
@@ -19,6 +19,8 @@ func TestBadRequests(t *testing.T) {
 	route := &handler.Handler{
 		DB:             h.DB,
 		NamespaceCache: h.Caches.RatelimitNamespace,
+		Caches:         h.Caches.Invalidations,
+		Webhooks:       h.Webhooks,
 	}
 
 	h.Register(route)
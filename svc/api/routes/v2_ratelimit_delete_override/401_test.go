@@ -17,6 +17,8 @@ func TestUnauthorizedAccess(t *testing.T) {
 		DB:             h.DB,
 		Auditlogs:      h.Auditlogs,
 		NamespaceCache: h.Caches.RatelimitNamespace,
+		Caches:         h.Caches.Invalidations,
+		Webhooks:       h.Webhooks,
 	}
 
 	h.Register(route)
@@ -47,6 +47,8 @@ func TestWorkspacePermissions(t *testing.T) {
 		DB:             h.DB,
 		Auditlogs:      h.Auditlogs,
 		NamespaceCache: h.Caches.RatelimitNamespace,
+		Caches:         h.Caches.Invalidations,
+		Webhooks:       h.Webhooks,
 	}
 
 	h.Register(route)
@@ -33,6 +33,8 @@ func TestNotFound(t *testing.T) {
 		DB:             h.DB,
 		Auditlogs:      h.Auditlogs,
 		NamespaceCache: h.Caches.RatelimitNamespace,
+		Caches:         h.Caches.Invalidations,
+		Webhooks:       h.Webhooks,
 	}
 
 	h.Register(route)
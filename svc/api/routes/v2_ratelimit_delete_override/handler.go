@@ -10,6 +10,7 @@ import (
 	"github.com/unkeyed/unkey/internal/services/auditlogs"
 	"github.com/unkeyed/unkey/internal/services/caches"
 	"github.com/unkeyed/unkey/internal/services/ratelimit/namespace"
+	"github.com/unkeyed/unkey/internal/services/webhooks"
 	"github.com/unkeyed/unkey/pkg/auditlog"
 	"github.com/unkeyed/unkey/pkg/cache"
 	"github.com/unkeyed/unkey/pkg/codes"
@@ -29,6 +30,8 @@ type Handler struct {
 	DB             db.Database
 	Auditlogs      auditlogs.AuditLogService
 	NamespaceCache cache.Cache[cache.ScopedKey, db.FindRatelimitNamespace]
+	Caches         *caches.Invalidator
+	Webhooks       webhooks.Service
 }
 
 func (h *Handler) Method() string {
@@ -152,11 +155,26 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return err
 	}
 
-	h.NamespaceCache.Remove(ctx,
-		cache.ScopedKey{WorkspaceID: principal.WorkspaceID, Key: ns.ID},
-		cache.ScopedKey{WorkspaceID: principal.WorkspaceID, Key: ns.Name},
+	h.Caches.Invalidate(ctx,
+		caches.RatelimitNamespaceEntity(principal.WorkspaceID, ns.ID, ns.Name),
 	)
 
+	h.Webhooks.Emit(ctx, webhooks.Event{
+		ID:          "",
+		WorkspaceID: principal.WorkspaceID,
+		Type:        webhooks.EventRatelimitOverrideDeleted,
+		Data: webhooks.RatelimitOverrideData{
+			OverrideID:  override.ID,
+			NamespaceID: ns.ID,
+			Identifier:  override.Identifier,
+			Limit:       0,
+			Duration:    0,
+			Priority:    0,
+			ExpiresAt:   0,
+		},
+		At: time.Time{},
+	})
+
 	return s.JSON(http.StatusOK, Response{
 		Meta: openapi.Meta{
 			RequestId: s.RequestID(),
@@ -0,0 +1,73 @@
+package handler_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_delete_plan"
+)
+
+func TestDeletePlanSuccessfully(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	workspaceID := h.Resources().UserWorkspace.ID
+	namespaceID := uid.New(uid.RatelimitNamespacePrefix)
+	err := db.Query.InsertRatelimitNamespace(ctx, h.DB.RW(), db.InsertRatelimitNamespaceParams{
+		ID:          namespaceID,
+		WorkspaceID: workspaceID,
+		Name:        uid.New("test"),
+		CreatedAt:   time.Now().UnixMilli(),
+	})
+	require.NoError(t, err)
+
+	planID := uid.New(uid.RatelimitPlanPrefix)
+	planName := uid.New("plan")
+	err = db.Query.InsertRatelimitPlan(ctx, h.DB.RW(), db.InsertRatelimitPlanParams{
+		ID:          planID,
+		WorkspaceID: workspaceID,
+		Name:        planName,
+		CreatedAt:   time.Now().UnixMilli(),
+		UpdatedAt:   sql.NullInt64{Valid: false, Int64: 0},
+	})
+	require.NoError(t, err)
+	err = db.Query.InsertRatelimitPlanLimit(ctx, h.DB.RW(), db.InsertRatelimitPlanLimitParams{
+		PlanID:      planID,
+		WorkspaceID: workspaceID,
+		NamespaceID: namespaceID,
+		Limit:       10,
+		Duration:    1000,
+		CreatedAt:   time.Now().UnixMilli(),
+	})
+	require.NoError(t, err)
+
+	route := &handler.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
+	}
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(workspaceID, fmt.Sprintf("ratelimit.%s.update_namespace", namespaceID))
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{Plan: planName})
+	require.Equal(t, http.StatusOK, res.Status, "expected 200, received: %s", res.RawBody)
+
+	_, err = db.Query.FindRatelimitPlan(ctx, h.DB.RO(), db.FindRatelimitPlanParams{
+		WorkspaceID: workspaceID,
+		Plan:        planID,
+	})
+	require.True(t, db.IsNotFound(err), "plan should be gone, got %v", err)
+}
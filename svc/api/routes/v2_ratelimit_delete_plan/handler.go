@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/unkeyed/unkey/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/internal/services/caches"
+	"github.com/unkeyed/unkey/internal/services/ratelimit/namespace"
+	"github.com/unkeyed/unkey/pkg/auditlog"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2RatelimitDeletePlanRequestBody
+	Response = openapi.V2RatelimitDeletePlanResponseBody
+)
+
+// Handler implements zen.Route interface for the v2 ratelimit delete plan endpoint
+type Handler struct {
+	DB        db.Database
+	Auditlogs auditlogs.AuditLogService
+	Caches    *caches.Invalidator
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/ratelimit.deletePlan"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	plan, err := db.TxWithResultRetry(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) (db.FindRatelimitPlan, error) {
+		row, txErr := db.Query.FindRatelimitPlan(ctx, tx, db.FindRatelimitPlanParams{
+			WorkspaceID: principal.WorkspaceID,
+			Plan:        req.Plan,
+		})
+		if txErr != nil {
+			if db.IsNotFound(txErr) {
+				return db.FindRatelimitPlan{}, fault.New("plan not found", //nolint:exhaustruct
+					fault.Code(codes.Data.RatelimitPlan.NotFound.URN()),
+					fault.Public("This plan does not exist."),
+				)
+			}
+			return db.FindRatelimitPlan{}, fault.Wrap(txErr, //nolint:exhaustruct
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database failed"),
+				fault.Public("The database is unavailable."),
+			)
+		}
+		plan := namespace.ParsePlanRow(row)
+
+		perms := make([]rbac.PermissionQuery, 0, len(plan.Limits))
+		for _, l := range plan.Limits {
+			perms = append(perms, rbac.T(rbac.Tuple{
+				ResourceType: rbac.Ratelimit,
+				ResourceID:   l.NamespaceID,
+				Action:       rbac.UpdateNamespace,
+			}))
+		}
+		txErr = principal.Authorize(rbac.Or(
+			rbac.T(rbac.Tuple{
+				ResourceType: rbac.Ratelimit,
+				ResourceID:   "*",
+				Action:       rbac.UpdateNamespace,
+			}),
+			rbac.And(perms...),
+		))
+		if txErr != nil {
+			return db.FindRatelimitPlan{}, txErr //nolint:exhaustruct
+		}
+
+		txErr = db.Query.DeleteRatelimitPlanLimits(ctx, tx, plan.ID)
+		if txErr != nil {
+			return db.FindRatelimitPlan{}, fault.Wrap(txErr, //nolint:exhaustruct
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database failed to delete plan limits"),
+				fault.Public("The database is unavailable."),
+			)
+		}
+
+		txErr = db.Query.DeleteRatelimitPlan(ctx, tx, plan.ID)
+		if txErr != nil {
+			return db.FindRatelimitPlan{}, fault.Wrap(txErr, //nolint:exhaustruct
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database failed to delete plan"),
+				fault.Public("The database is unavailable."),
+			)
+		}
+
+		txErr = h.Auditlogs.Insert(ctx, tx, []auditlog.AuditLog{
+			{
+				WorkspaceID:   principal.WorkspaceID,
+				Event:         auditlog.RatelimitDeletePlanEvent,
+				Display:       fmt.Sprintf("Deleted ratelimit plan %s.", plan.Name),
+				ActorID:       principal.Subject.ID,
+				ActorType:     auditlog.AuditLogActor(principal.Subject.Type),
+				ActorName:     principal.Subject.Name,
+				ActorMeta:     map[string]any{},
+				RemoteIP:      s.Location(),
+				UserAgent:     s.UserAgent(),
+				CorrelationID: "",
+				Resources: []auditlog.AuditLogResource{
+					{
+						ID:          plan.ID,
+						Name:        plan.Name,
+						DisplayName: plan.Name,
+						Type:        auditlog.RatelimitPlanResourceType,
+						Meta:        nil,
+					},
+				},
+			},
+		})
+		if txErr != nil {
+			return db.FindRatelimitPlan{}, txErr //nolint:exhaustruct
+		}
+
+		return plan, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	h.Caches.Invalidate(ctx,
+		caches.RatelimitPlanEntity(principal.WorkspaceID, plan.ID, plan.Name),
+	)
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.V2RatelimitDeletePlanResponseData{},
+	})
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/unkeyed/unkey/internal/services/caches"
 	"github.com/unkeyed/unkey/internal/services/ratelimit/namespace"
@@ -10,7 +11,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/db"
 	"github.com/unkeyed/unkey/pkg/fault"
-	"github.com/unkeyed/unkey/pkg/match"
+	"github.com/unkeyed/unkey/pkg/ptr"
 	"github.com/unkeyed/unkey/pkg/rbac"
 	"github.com/unkeyed/unkey/pkg/rbac/permissions"
 	"github.com/unkeyed/unkey/pkg/urn"
@@ -72,7 +73,13 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		)
 	}
 
-	override, overrideFound, err := matchOverride(req.Identifier, ns)
+	// An override is addressed by its own identifier first, expired or not;
+	// any other identifier resolves to the override that currently applies
+	// to it.
+	override, overrideFound := ns.DirectOverrides[req.Identifier]
+	if !overrideFound {
+		override, overrideFound, err = namespace.MatchOverride(ns, req.Identifier, time.Now())
+	}
 	if err != nil {
 		return fault.Wrap(err,
 			fault.Code(codes.App.Internal.UnexpectedError.URN()),
@@ -129,7 +136,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		)
 	}
 
-	return s.JSON(http.StatusOK, Response{
+	res := Response{
 		Meta: openapi.Meta{
 			RequestId: s.RequestID(),
 		},
@@ -138,8 +145,15 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			Limit:      override.Limit,
 			Duration:   override.Duration,
 			Identifier: override.Identifier,
+			Priority:   override.Priority,
+			ExpiresAt:  nil,
 		},
-	})
+	}
+	if override.ExpiresAt != 0 {
+		res.Data.ExpiresAt = ptr.P(override.ExpiresAt)
+	}
+
+	return s.JSON(http.StatusOK, res)
 }
 
 func (h *Handler) getNamespace(ctx context.Context, workspaceID, nameOrID string) (db.FindRatelimitNamespace, bool, error) {
@@ -170,29 +184,3 @@ func (h *Handler) getNamespace(ctx context.Context, workspaceID, nameOrID string
 
 	return ns, true, nil
 }
-
-func matchOverride(identifier string, namespace db.FindRatelimitNamespace) (db.FindRatelimitNamespaceLimitOverride, bool, error) {
-	if override, ok := namespace.DirectOverrides[identifier]; ok {
-		return override, true, nil
-	}
-
-	for _, override := range namespace.WildcardOverrides {
-		ok, err := match.Wildcard(identifier, override.Identifier)
-		if err != nil {
-			return db.FindRatelimitNamespaceLimitOverride{}, false, err
-		}
-
-		if !ok {
-			continue
-		}
-
-		return override, true, nil
-	}
-
-	return db.FindRatelimitNamespaceLimitOverride{
-		ID:         "",
-		Limit:      0,
-		Identifier: "",
-		Duration:   0,
-	}, false, nil
-}
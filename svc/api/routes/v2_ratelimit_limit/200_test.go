@@ -15,6 +15,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/db"
 	"github.com/unkeyed/unkey/pkg/uid"
 	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
 	handler "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_limit"
 )
 
@@ -298,6 +299,41 @@ func TestLimitSuccessfully(t *testing.T) {
 		require.Equal(t, int64(1), res2.Body.Data.Limit)
 		require.Equal(t, int64(0), res2.Body.Data.Remaining)
 	})
+	t.Run("token bucket bursts to capacity", func(t *testing.T) {
+		namespaceID, namespaceName := createNamespace(t, h)
+		rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, fmt.Sprintf("ratelimit.%s.limit", namespaceID))
+
+		headers := http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+		}
+		algorithm := openapi.TokenBucket
+		refillRate := int64(1)
+		req := handler.Request{
+			Namespace:  namespaceName,
+			Identifier: uid.New("test"),
+			Limit:      3,
+			Duration:   60000,
+			Algorithm:  &algorithm,
+			RefillRate: &refillRate,
+		}
+
+		for i := range 3 {
+			res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+			require.Equal(t, 200, res.Status, "expected 200, received: %v", res.Body)
+			require.True(t, res.Body.Data.Success, "request %d should fit in the bucket", i)
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status)
+		require.False(t, res.Body.Data.Success, "an empty bucket should reject")
+		require.Equal(t, int64(3), res.Body.Data.Limit)
+		require.Equal(t, int64(0), res.Body.Data.Remaining)
+
+		// One token refills per minute, so the retry hint is at most a minute away.
+		require.LessOrEqual(t, res.Body.Data.Reset, time.Now().Add(time.Minute).UnixMilli())
+	})
+
 	// Test namespace accepts any characters within length bounds
 	t.Run("namespace accepts any characters within length bounds", func(t *testing.T) {
 		// Test various character types in namespace names
@@ -69,6 +69,55 @@ func TestBadRequests(t *testing.T) {
 		require.Greater(t, len(res.Body.Error.Errors), 0)
 	})
 
+	t.Run("refill rate without token bucket", func(t *testing.T) {
+		rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "ratelimit.*.limit", "ratelimit.*.create_namespace")
+
+		headers := http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+		}
+
+		algorithm := openapi.FixedWindow
+		req := openapi.V2RatelimitLimitRequestBody{
+			Namespace:  uid.New("test"),
+			Identifier: "user_123",
+			Limit:      100,
+			Duration:   60000,
+			Algorithm:  &algorithm,
+			RefillRate: ptr.P[int64](10),
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+
+		require.Equal(t, 400, res.Status, "expected 400, sent: %+v, received: %s", req, res.RawBody)
+		require.Equal(t, "https://unkey.com/docs/errors/unkey/application/invalid_input", res.Body.Error.Type)
+		require.Equal(t, "refillRate is only valid with algorithm token_bucket.", res.Body.Error.Detail)
+	})
+
+	t.Run("refill rate above limit", func(t *testing.T) {
+		rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "ratelimit.*.limit", "ratelimit.*.create_namespace")
+
+		headers := http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+		}
+
+		algorithm := openapi.TokenBucket
+		req := openapi.V2RatelimitLimitRequestBody{
+			Namespace:  uid.New("test"),
+			Identifier: "user_123",
+			Limit:      10,
+			Duration:   60000,
+			Algorithm:  &algorithm,
+			RefillRate: ptr.P[int64](11),
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+
+		require.Equal(t, 400, res.Status, "expected 400, sent: %+v, received: %s", req, res.RawBody)
+		require.Equal(t, "refillRate must not exceed limit.", res.Body.Error.Detail)
+	})
+
 	t.Run("missing namespace in request", func(t *testing.T) {
 		// Create a root key with wildcard permission for any namespace
 		rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "ratelimit.*.limit")
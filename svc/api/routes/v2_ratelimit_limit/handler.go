@@ -20,7 +20,6 @@ import (
 	"github.com/unkeyed/unkey/pkg/db"
 	"github.com/unkeyed/unkey/pkg/fault"
 	"github.com/unkeyed/unkey/pkg/logger"
-	"github.com/unkeyed/unkey/pkg/match"
 	"github.com/unkeyed/unkey/pkg/ptr"
 	"github.com/unkeyed/unkey/pkg/rbac"
 	sf "github.com/unkeyed/unkey/pkg/singleflight"
@@ -41,6 +40,7 @@ type Handler struct {
 	RatelimitEvents *batch.BatchProcessor[schema.Ratelimit]
 	Ratelimit       ratelimit.Service
 	NamespaceCache  cache.Cache[cache.ScopedKey, db.FindRatelimitNamespace]
+	PlanCache       cache.Cache[cache.ScopedKey, db.FindRatelimitPlan]
 	Auditlogs       auditlogs.AuditLogService
 	TestMode        bool
 	createFlight    sf.Group[db.FindRatelimitNamespace]
@@ -72,6 +72,10 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return err
 	}
 
+	if req.Plan != nil {
+		return h.handlePlan(ctx, s, principal, req)
+	}
+
 	ns, found, err := h.getNamespace(ctx, principal.WorkspaceID, req.Namespace)
 	if err != nil {
 		return fault.Wrap(err,
@@ -121,8 +125,14 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return err
 	}
 
+	requestTime := h.requestTime(s)
+	matchTime := requestTime
+	if matchTime.IsZero() {
+		matchTime = time.Now()
+	}
+
 	// Apply override if found, otherwise use request values
-	limit, duration, overrideID, err := getLimitAndDuration(req, ns)
+	override, overrideFound, err := namespace.MatchOverride(ns, req.Identifier, matchTime)
 	if err != nil {
 		return fault.Wrap(err,
 			fault.Code(codes.App.Internal.UnexpectedError.URN()),
@@ -131,7 +141,18 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		)
 	}
 
-	// Apply rate limit
+	limit, duration, overrideID := req.Limit, req.Duration, ""
+	if overrideFound {
+		limit, duration, overrideID = override.Limit, override.Duration, override.ID
+	}
+
+	algorithm, refillRate, err := getAlgorithm(req, limit)
+	if err != nil {
+		return err
+	}
+
+	// Apply rate limit. A dry run peeks at the window instead, which
+	// neither consumes from it nor changes the limiter's state.
 	cost := ptr.SafeDeref(req.Cost, 1)
 	limitReq := ratelimit.RatelimitRequest{
 		WorkspaceID: principal.WorkspaceID,
@@ -139,20 +160,22 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		Identifier:  req.Identifier,
 		Duration:    time.Duration(duration) * time.Millisecond,
 		Limit:       limit,
+		Algorithm:   algorithm,
+		RefillRate:  refillRate,
 		Cost:        cost,
-		Time:        time.Time{},
+		Time:        requestTime,
 	}
-
-	if h.TestMode {
-		header := s.Request().Header.Get("X-Test-Time")
-		if header != "" {
-			i, parseErr := strconv.ParseInt(header, 10, 64)
-			if parseErr != nil {
-				logger.Warn("invalid test time", "header", header)
-			} else {
-				limitReq.Time = time.UnixMilli(i)
-			}
+	if ptr.SafeDeref(req.DryRun, false) {
+		result, peekErr := h.Ratelimit.Peek(ctx, limitReq)
+		if peekErr != nil {
+			return fault.Wrap(
+				peekErr,
+				fault.Code(codes.App.Internal.UnexpectedError.URN()),
+				fault.Internal("rate limit peek failed"),
+				fault.Public("We're unable to process the rate limit request."),
+			)
 		}
+		return s.JSON(http.StatusOK, dryRunResponse(s.RequestID(), result, override, overrideFound))
 	}
 
 	t0 := time.Now()
@@ -166,6 +189,7 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		)
 	}
 	latency := time.Since(t0).Milliseconds()
+
 	if s.ShouldLogRequestToClickHouse() {
 		nowMillis := time.Now().UnixMilli()
 		h.RatelimitEvents.Buffer(schema.Ratelimit{
@@ -194,6 +218,8 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			Remaining:  result.Remaining,
 			Reset:      result.Reset.UnixMilli(),
 			OverrideId: overrideID,
+			Override:   nil,
+			Limits:     nil,
 		},
 	}
 
@@ -201,6 +227,27 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 	return s.JSON(http.StatusOK, res)
 }
 
+// requestTime returns the time a test pinned the request to through the
+// X-Test-Time header, or the zero time to use the current time.
+func (h *Handler) requestTime(s *zen.Session) time.Time {
+	if !h.TestMode {
+		return time.Time{}
+	}
+
+	header := s.Request().Header.Get("X-Test-Time")
+	if header == "" {
+		return time.Time{}
+	}
+
+	i, err := strconv.ParseInt(header, 10, 64)
+	if err != nil {
+		logger.Warn("invalid test time", "header", header)
+		return time.Time{}
+	}
+
+	return time.UnixMilli(i)
+}
+
 func (h *Handler) getNamespace(ctx context.Context, workspaceID, nameOrID string) (db.FindRatelimitNamespace, bool, error) {
 	cacheKey := cache.ScopedKey{WorkspaceID: workspaceID, Key: nameOrID}
 
@@ -328,41 +375,65 @@ func (h *Handler) createNamespace(ctx context.Context, s *zen.Session, principal
 	})
 }
 
-func getLimitAndDuration(req Request, namespace db.FindRatelimitNamespace) (int64, int64, string, error) {
-	override, found, err := matchOverride(req.Identifier, namespace)
-	if err != nil {
-		return 0, 0, "", err
+// dryRunResponse reports what the request would get without it having
+// consumed anything: result comes from a peek, so Remaining is the current
+// headroom and Success says whether the request's cost fits in it.
+func dryRunResponse(requestID string, result ratelimit.RatelimitResponse, override db.FindRatelimitNamespaceLimitOverride, overrideFound bool) Response {
+	data := openapi.V2RatelimitLimitResponseData{
+		Success:    result.Success,
+		Limit:      result.Limit,
+		Remaining:  result.Remaining,
+		Reset:      result.Reset.UnixMilli(),
+		OverrideId: "",
+		Override:   nil,
+		Limits:     nil,
 	}
 
-	if found {
-		return override.Limit, override.Duration, override.ID, nil
+	if overrideFound {
+		data.OverrideId = override.ID
+		data.Override = &openapi.RatelimitOverride{
+			OverrideId: override.ID,
+			Identifier: override.Identifier,
+			Limit:      override.Limit,
+			Duration:   override.Duration,
+			Priority:   override.Priority,
+			ExpiresAt:  nil,
+		}
+		if override.ExpiresAt != 0 {
+			data.Override.ExpiresAt = ptr.P(override.ExpiresAt)
+		}
 	}
 
-	return req.Limit, req.Duration, "", nil
+	return Response{
+		Meta: openapi.Meta{
+			RequestId: requestID,
+		},
+		Data: data,
+	}
 }
 
-func matchOverride(identifier string, namespace db.FindRatelimitNamespace) (db.FindRatelimitNamespaceLimitOverride, bool, error) {
-	if override, ok := namespace.DirectOverrides[identifier]; ok {
-		return override, true, nil
+// getAlgorithm maps the requested algorithm onto the ratelimit service, whose
+// Algorithm values match the API's. An override replaces only limit and
+// duration, so a refill rate chosen for the requested limit is capped at a
+// lower override limit instead of failing the request.
+func getAlgorithm(req Request, limit int64) (ratelimit.Algorithm, int64, error) {
+	algorithm := ratelimit.Algorithm(ptr.SafeDeref(req.Algorithm, openapi.SlidingWindow))
+	if req.RefillRate == nil {
+		return algorithm, 0, nil
 	}
 
-	for _, override := range namespace.WildcardOverrides {
-		ok, err := match.Wildcard(identifier, override.Identifier)
-		if err != nil {
-			return db.FindRatelimitNamespaceLimitOverride{}, false, err
-		}
-
-		if !ok {
-			continue
-		}
-
-		return override, true, nil
+	if algorithm != ratelimit.AlgorithmTokenBucket {
+		return "", 0, fault.New("refill rate set without token bucket",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Public("refillRate is only valid with algorithm token_bucket."),
+		)
+	}
+	if *req.RefillRate > req.Limit {
+		return "", 0, fault.New("refill rate exceeds limit",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Public("refillRate must not exceed limit."),
+		)
 	}
 
-	return db.FindRatelimitNamespaceLimitOverride{
-		Limit:      0,
-		ID:         "",
-		Identifier: "",
-		Duration:   0,
-	}, false, nil
+	return algorithm, min(*req.RefillRate, limit), nil
 }
@@ -0,0 +1,169 @@
+package v2RatelimitLimit_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_limit"
+)
+
+func TestOverridePrecedence(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		RatelimitEvents: h.RatelimitEvents,
+		Ratelimit:       h.Ratelimit,
+		DB:              h.DB,
+		NamespaceCache:  h.Caches.RatelimitNamespace,
+		Auditlogs:       h.Auditlogs,
+	}
+	h.Register(route)
+
+	type override struct {
+		identifier string
+		limit      uint64
+		priority   int32
+		expiresAt  sql.NullInt64
+	}
+
+	// setup creates a namespace with the given overrides and returns its name,
+	// headers allowed to limit in it and the override ids by identifier.
+	setup := func(t *testing.T, overrides ...override) (string, http.Header, map[string]string) {
+		namespaceID, namespaceName := createNamespace(t, h)
+		rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, fmt.Sprintf("ratelimit.%s.limit", namespaceID))
+
+		ids := make(map[string]string)
+		for _, o := range overrides {
+			id := uid.New(uid.RatelimitOverridePrefix)
+			err := db.Query.InsertRatelimitOverride(context.Background(), h.DB.RW(), db.InsertRatelimitOverrideParams{
+				ID:          id,
+				WorkspaceID: h.Resources().UserWorkspace.ID,
+				NamespaceID: namespaceID,
+				Identifier:  o.identifier,
+				Limit:       o.limit,
+				Duration:    60000,
+				Priority:    o.priority,
+				ExpiresAt:   o.expiresAt,
+				CreatedAt:   time.Now().UnixMilli(),
+			})
+			require.NoError(t, err)
+			ids[o.identifier] = id
+		}
+
+		return namespaceName, http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+		}, ids
+	}
+
+	limit := func(t *testing.T, headers http.Header, namespace, identifier string, dryRun bool) handler.Response {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Namespace:  namespace,
+			Identifier: identifier,
+			Limit:      10,
+			Duration:   60000,
+			DryRun:     ptr.P(dryRun),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %v", res.RawBody)
+		return *res.Body
+	}
+
+	t.Run("higher priority wildcard beats exact identifier", func(t *testing.T) {
+		ns, headers, ids := setup(t,
+			override{identifier: "org_1:tier_pro", limit: 100},
+			override{identifier: "org_*:tier_pro", limit: 500, priority: 10},
+		)
+
+		res := limit(t, headers, ns, "org_1:tier_pro", false)
+		require.Equal(t, ids["org_*:tier_pro"], res.Data.OverrideId)
+		require.Equal(t, int64(500), res.Data.Limit)
+	})
+
+	t.Run("exact identifier wins on equal priority", func(t *testing.T) {
+		ns, headers, ids := setup(t,
+			override{identifier: "org_1:tier_pro", limit: 100},
+			override{identifier: "org_*", limit: 500},
+		)
+
+		res := limit(t, headers, ns, "org_1:tier_pro", false)
+		require.Equal(t, ids["org_1:tier_pro"], res.Data.OverrideId)
+	})
+
+	t.Run("more specific pattern wins on equal priority", func(t *testing.T) {
+		ns, headers, ids := setup(t,
+			override{identifier: "org_*", limit: 100},
+			override{identifier: "org_*:tier_pro", limit: 500},
+		)
+
+		res := limit(t, headers, ns, "org_7:tier_pro", false)
+		require.Equal(t, ids["org_*:tier_pro"], res.Data.OverrideId)
+
+		res = limit(t, headers, ns, "org_7:tier_free", false)
+		require.Equal(t, ids["org_*"], res.Data.OverrideId)
+	})
+
+	t.Run("expired override reverts to the next match", func(t *testing.T) {
+		past := sql.NullInt64{Valid: true, Int64: time.Now().Add(-time.Minute).UnixMilli()}
+		ns, headers, ids := setup(t,
+			override{identifier: "org_*:tier_pro", limit: 500, priority: 10, expiresAt: past},
+			override{identifier: "org_*", limit: 100},
+		)
+
+		res := limit(t, headers, ns, "org_3:tier_pro", false)
+		require.Equal(t, ids["org_*"], res.Data.OverrideId)
+		require.Equal(t, int64(100), res.Data.Limit)
+	})
+
+	t.Run("expired override alone falls back to request values", func(t *testing.T) {
+		past := sql.NullInt64{Valid: true, Int64: time.Now().Add(-time.Minute).UnixMilli()}
+		ns, headers, _ := setup(t, override{identifier: "user_1", limit: 500, expiresAt: past})
+
+		res := limit(t, headers, ns, "user_1", false)
+		require.Empty(t, res.Data.OverrideId)
+		require.Equal(t, int64(10), res.Data.Limit)
+	})
+
+	t.Run("dry run reports the override without consuming", func(t *testing.T) {
+		ns, headers, ids := setup(t, override{identifier: "org_*", limit: 2, priority: 3})
+
+		for range 3 {
+			res := limit(t, headers, ns, "org_9", true)
+			require.True(t, res.Data.Success)
+			require.Equal(t, int64(2), res.Data.Remaining)
+			require.Equal(t, ids["org_*"], res.Data.OverrideId)
+			require.NotNil(t, res.Data.Override)
+			require.Equal(t, "org_*", res.Data.Override.Identifier)
+			require.Equal(t, int32(3), res.Data.Override.Priority)
+			require.Nil(t, res.Data.Override.ExpiresAt)
+		}
+
+		res := limit(t, headers, ns, "org_9", false)
+		require.True(t, res.Data.Success)
+		require.Nil(t, res.Data.Override)
+		res = limit(t, headers, ns, "org_9", false)
+		require.True(t, res.Data.Success)
+
+		res = limit(t, headers, ns, "org_9", true)
+		require.False(t, res.Data.Success, "a request of cost 1 no longer fits")
+		require.Equal(t, int64(0), res.Data.Remaining)
+	})
+
+	t.Run("dry run without a matching override", func(t *testing.T) {
+		ns, headers, _ := setup(t)
+
+		res := limit(t, headers, ns, "user_1", true)
+		require.True(t, res.Data.Success)
+		require.Empty(t, res.Data.OverrideId)
+		require.Nil(t, res.Data.Override)
+		require.Equal(t, int64(10), res.Data.Remaining)
+	})
+}
@@ -0,0 +1,287 @@
+package v2RatelimitLimit
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/unkeyed/unkey/internal/services/caches"
+	"github.com/unkeyed/unkey/internal/services/ratelimit"
+	"github.com/unkeyed/unkey/internal/services/ratelimit/namespace"
+	"github.com/unkeyed/unkey/pkg/auth/principal"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/clickhouse/schema"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+// planLimit is one limit of a plan after namespace overrides were applied.
+type planLimit struct {
+	ns         db.FindRatelimitNamespace
+	limit      int64
+	duration   int64
+	overrideID string
+}
+
+// handlePlan applies every limit of the requested plan to the identifier in
+// a single RatelimitMany call, so either all of them consume the cost or
+// none does.
+func (h *Handler) handlePlan(ctx context.Context, s *zen.Session, principal *principal.Principal, req Request) error {
+	if req.Namespace != "" || req.Limit != 0 || req.Duration != 0 || req.Algorithm != nil || req.RefillRate != nil {
+		return fault.New("plan combined with inline limit",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Public("plan cannot be combined with namespace, limit, duration, algorithm or refillRate."),
+		)
+	}
+	if ptr.SafeDeref(req.DryRun, false) {
+		return fault.New("dry run with plan",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Public("dryRun is not supported with plan."),
+		)
+	}
+
+	plan, found, err := h.getPlan(ctx, principal.WorkspaceID, *req.Plan)
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.UnexpectedError.URN()),
+			fault.Public("An unexpected error occurred while fetching the plan."),
+		)
+	}
+	if !found {
+		return fault.New("plan not found",
+			fault.Code(codes.Data.RatelimitPlan.NotFound.URN()),
+			fault.Public("This plan does not exist."),
+		)
+	}
+
+	namespaces := make(map[string]db.FindRatelimitNamespace)
+	requiredPerms := make([]rbac.PermissionQuery, 0, len(plan.Limits))
+	for _, l := range plan.Limits {
+		if _, ok := namespaces[l.NamespaceID]; ok {
+			continue
+		}
+
+		ns, nsFound, nsErr := h.getNamespace(ctx, principal.WorkspaceID, l.NamespaceID)
+		if nsErr != nil {
+			return fault.Wrap(nsErr,
+				fault.Code(codes.App.Internal.UnexpectedError.URN()),
+				fault.Public("An unexpected error occurred while fetching the namespace."),
+			)
+		}
+		if !nsFound {
+			return fault.New("plan namespace not found",
+				fault.Code(codes.Data.RatelimitNamespace.NotFound.URN()),
+				fault.Public("A namespace of this plan does not exist."),
+			)
+		}
+
+		namespaces[l.NamespaceID] = ns
+		requiredPerms = append(requiredPerms, rbac.T(rbac.Tuple{
+			ResourceType: rbac.Ratelimit,
+			ResourceID:   ns.ID,
+			Action:       rbac.Limit,
+		}))
+	}
+
+	err = principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Ratelimit,
+			ResourceID:   "*",
+			Action:       rbac.Limit,
+		}),
+		rbac.And(requiredPerms...),
+	))
+	if err != nil {
+		return err
+	}
+
+	for _, ns := range namespaces {
+		if ns.DeletedAtM.Valid {
+			return fault.New("namespace was deleted",
+				fault.Code(codes.Data.RatelimitNamespace.Gone.URN()),
+				fault.Public("A namespace of this plan has been deleted. Contact support to restore."),
+			)
+		}
+	}
+
+	requestTime := h.requestTime(s)
+	matchTime := requestTime
+	if matchTime.IsZero() {
+		matchTime = time.Now()
+	}
+
+	limits, err := resolvePlanLimits(plan, namespaces, req.Identifier, matchTime)
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.UnexpectedError.URN()),
+			fault.Internal("error matching overrides"),
+			fault.Public("Error matching ratelimit override"),
+		)
+	}
+
+	cost := ptr.SafeDeref(req.Cost, 1)
+	limitReqs := make([]ratelimit.RatelimitRequest, len(limits))
+	for i, l := range limits {
+		limitReqs[i] = ratelimit.RatelimitRequest{
+			WorkspaceID: principal.WorkspaceID,
+			Namespace:   l.ns.ID,
+			Identifier:  req.Identifier,
+			Duration:    time.Duration(l.duration) * time.Millisecond,
+			Limit:       l.limit,
+			Algorithm:   ratelimit.AlgorithmSlidingWindow,
+			RefillRate:  0,
+			Cost:        cost,
+			Time:        requestTime,
+		}
+	}
+
+	t0 := time.Now()
+	results, err := h.Ratelimit.RatelimitMany(ctx, limitReqs)
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.UnexpectedError.URN()),
+			fault.Internal("rate limit failed"),
+			fault.Public("We're unable to process the rate limit request."),
+		)
+	}
+	latency := time.Since(t0).Milliseconds()
+
+	if s.ShouldLogRequestToClickHouse() {
+		nowMillis := time.Now().UnixMilli()
+		for i, result := range results {
+			h.RatelimitEvents.Buffer(schema.Ratelimit{
+				RequestID:   s.RequestID(),
+				WorkspaceID: principal.WorkspaceID,
+				Time:        nowMillis,
+				NamespaceID: limits[i].ns.ID,
+				Identifier:  req.Identifier,
+				Passed:      result.Success,
+				Latency:     float64(latency) / float64(len(results)),
+				OverrideID:  limits[i].overrideID,
+				Limit:       uint64(result.Limit),
+				Remaining:   uint64(result.Remaining),
+				ResetAt:     result.Reset.UnixMilli(),
+				Tokens:      uint64(cost),
+			})
+		}
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: planResponseData(limits, results, req.Identifier),
+	})
+}
+
+// resolvePlanLimits applies the namespace overrides matching identifier to
+// the limits of plan. An override replaces the duration too, so two limits
+// of the same namespace can end up sharing a window; they would count the
+// same request twice against one counter, so only the lower limit is kept.
+func resolvePlanLimits(plan db.FindRatelimitPlan, namespaces map[string]db.FindRatelimitNamespace, identifier string, now time.Time) ([]planLimit, error) {
+	type window struct {
+		namespaceID string
+		duration    int64
+	}
+
+	limits := make([]planLimit, 0, len(plan.Limits))
+	seen := make(map[window]int, len(plan.Limits))
+	for _, l := range plan.Limits {
+		ns := namespaces[l.NamespaceID]
+		resolved := planLimit{ns: ns, limit: l.Limit, duration: l.Duration, overrideID: ""}
+
+		override, found, err := namespace.MatchOverride(ns, identifier, now)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			resolved.limit, resolved.duration, resolved.overrideID = override.Limit, override.Duration, override.ID
+		}
+
+		w := window{namespaceID: ns.ID, duration: resolved.duration}
+		if i, ok := seen[w]; ok {
+			if resolved.limit < limits[i].limit {
+				limits[i] = resolved
+			}
+			continue
+		}
+
+		seen[w] = len(limits)
+		limits = append(limits, resolved)
+	}
+
+	return limits, nil
+}
+
+// planResponseData reports every limit of the plan and surfaces the most
+// restrictive one at the top level: the first that rejected the request, or
+// the one with the least remaining capacity when all of them passed.
+func planResponseData(limits []planLimit, results []ratelimit.RatelimitResponse, identifier string) openapi.V2RatelimitLimitResponseData {
+	checks := make([]openapi.V2RatelimitMultiLimitCheck, len(results))
+	success := true
+	top := 0
+	for i, result := range results {
+		checks[i] = openapi.V2RatelimitMultiLimitCheck{
+			Namespace:  limits[i].ns.Name,
+			Identifier: identifier,
+			Passed:     result.Success,
+			Limit:      limits[i].limit,
+			Remaining:  result.Remaining,
+			Reset:      result.Reset.UnixMilli(),
+			OverrideId: limits[i].overrideID,
+		}
+
+		switch {
+		case !result.Success && success:
+			success = false
+			top = i
+		case success && result.Remaining < results[top].Remaining:
+			top = i
+		}
+	}
+
+	return openapi.V2RatelimitLimitResponseData{
+		Success:    success,
+		Limit:      checks[top].Limit,
+		Remaining:  checks[top].Remaining,
+		Reset:      checks[top].Reset,
+		OverrideId: checks[top].OverrideId,
+		Override:   nil,
+		Limits:     &checks,
+	}
+}
+
+func (h *Handler) getPlan(ctx context.Context, workspaceID, nameOrID string) (db.FindRatelimitPlan, bool, error) {
+	cacheKey := cache.ScopedKey{WorkspaceID: workspaceID, Key: nameOrID}
+
+	plan, hit, err := h.PlanCache.SWR(ctx, cacheKey, func(ctx context.Context) (db.FindRatelimitPlan, error) {
+		row, dbErr := db.WithRetryContext(ctx, func() (db.FindRatelimitPlanRow, error) {
+			return db.Query.FindRatelimitPlan(ctx, h.DB.RO(), db.FindRatelimitPlanParams{
+				WorkspaceID: workspaceID,
+				Plan:        nameOrID,
+			})
+		})
+		if dbErr != nil {
+			return db.FindRatelimitPlan{}, dbErr //nolint:exhaustruct
+		}
+		return namespace.ParsePlanRow(row), nil
+	}, caches.DefaultFindFirstOp)
+
+	if err != nil {
+		if db.IsNotFound(err) {
+			return db.FindRatelimitPlan{}, false, nil //nolint:exhaustruct
+		}
+		return db.FindRatelimitPlan{}, false, err //nolint:exhaustruct
+	}
+
+	if hit == cache.Null {
+		return db.FindRatelimitPlan{}, false, nil //nolint:exhaustruct
+	}
+
+	return plan, true, nil
+}
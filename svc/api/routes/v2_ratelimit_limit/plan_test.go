@@ -0,0 +1,136 @@
+package v2RatelimitLimit_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_limit"
+)
+
+func TestPlan(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		RatelimitEvents: h.RatelimitEvents,
+		Ratelimit:       h.Ratelimit,
+		DB:              h.DB,
+		NamespaceCache:  h.Caches.RatelimitNamespace,
+		PlanCache:       h.Caches.RatelimitPlan,
+		Auditlogs:       h.Auditlogs,
+	}
+	h.Register(route)
+
+	workspaceID := h.Resources().UserWorkspace.ID
+	burstID, burstName := createNamespace(t, h)
+	dailyID, dailyName := createNamespace(t, h)
+
+	planName := createPlan(t, h, []db.InsertRatelimitPlanLimitParams{
+		{NamespaceID: burstID, Limit: 2, Duration: 60000},
+		{NamespaceID: dailyID, Limit: 5, Duration: 86400000},
+	})
+
+	rootKey := h.CreateRootKey(workspaceID,
+		fmt.Sprintf("ratelimit.%s.limit", burstID),
+		fmt.Sprintf("ratelimit.%s.limit", dailyID),
+	)
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	t.Run("applies every limit atomically", func(t *testing.T) {
+		req := handler.Request{Plan: ptr.P(planName), Identifier: uid.New("user")}
+
+		for i := range 2 {
+			res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+			require.Equal(t, http.StatusOK, res.Status, "received: %s", res.RawBody)
+			require.True(t, res.Body.Data.Success)
+			require.NotNil(t, res.Body.Data.Limits)
+			require.Len(t, *res.Body.Data.Limits, 2)
+
+			// The burst limit has the least room left.
+			require.Equal(t, int64(2), res.Body.Data.Limit)
+			require.Equal(t, int64(1-i), res.Body.Data.Remaining)
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, http.StatusOK, res.Status, "received: %s", res.RawBody)
+		require.False(t, res.Body.Data.Success)
+		require.Equal(t, int64(2), res.Body.Data.Limit)
+
+		checks := make(map[string]openapi.V2RatelimitMultiLimitCheck)
+		for _, check := range *res.Body.Data.Limits {
+			checks[check.Namespace] = check
+		}
+		require.False(t, checks[burstName].Passed)
+		require.True(t, checks[dailyName].Passed)
+		// The rejected request consumed nothing from the daily limit.
+		require.Equal(t, int64(3), checks[dailyName].Remaining)
+	})
+
+	t.Run("plan cannot be combined with an inline limit", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, handler.Request{
+			Plan:       ptr.P(planName),
+			Namespace:  burstName,
+			Identifier: "user_123",
+		})
+		require.Equal(t, http.StatusBadRequest, res.Status, "received: %s", res.RawBody)
+		require.Equal(t, "https://unkey.com/docs/errors/unkey/application/invalid_input", res.Body.Error.Type)
+	})
+
+	t.Run("unknown plan", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, headers, handler.Request{
+			Plan:       ptr.P("does_not_exist"),
+			Identifier: "user_123",
+		})
+		require.Equal(t, http.StatusNotFound, res.Status, "received: %s", res.RawBody)
+		require.Equal(t, "https://unkey.com/docs/errors/unkey/data/ratelimit_plan_not_found", res.Body.Error.Type)
+	})
+
+	t.Run("requires limit permission on every namespace", func(t *testing.T) {
+		rootKey := h.CreateRootKey(workspaceID, fmt.Sprintf("ratelimit.%s.limit", burstID))
+		res := testutil.CallRoute[handler.Request, openapi.ForbiddenErrorResponse](h, route, http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+		}, handler.Request{Plan: ptr.P(planName), Identifier: "user_123"})
+		require.Equal(t, http.StatusForbidden, res.Status, "received: %s", res.RawBody)
+	})
+}
+
+// createPlan creates a plan with the given limits and returns its name. Only
+// NamespaceID, Limit and Duration of each limit are used.
+func createPlan(t *testing.T, h *testutil.Harness, limits []db.InsertRatelimitPlanLimitParams) string {
+	ctx := context.Background()
+	workspaceID := h.Resources().UserWorkspace.ID
+	planID := uid.New(uid.RatelimitPlanPrefix)
+	planName := uid.New("plan")
+
+	err := db.Query.InsertRatelimitPlan(ctx, h.DB.RW(), db.InsertRatelimitPlanParams{
+		ID:          planID,
+		WorkspaceID: workspaceID,
+		Name:        planName,
+		CreatedAt:   time.Now().UnixMilli(),
+		UpdatedAt:   sql.NullInt64{Valid: false, Int64: 0},
+	})
+	require.NoError(t, err)
+
+	for _, l := range limits {
+		l.PlanID = planID
+		l.WorkspaceID = workspaceID
+		l.CreatedAt = time.Now().UnixMilli()
+		err = db.Query.InsertRatelimitPlanLimit(ctx, h.DB.RW(), l)
+		require.NoError(t, err)
+	}
+
+	return planName
+}
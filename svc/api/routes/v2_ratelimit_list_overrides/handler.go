@@ -6,6 +6,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/db"
 	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/ptr"
 	"github.com/unkeyed/unkey/pkg/rbac"
 	"github.com/unkeyed/unkey/pkg/zen"
 	"github.com/unkeyed/unkey/svc/api/internal/pagination"
@@ -105,11 +106,18 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			RequestId: s.RequestID(),
 		},
 		Data: array.Map(overrides, func(override db.RatelimitOverride) openapi.RatelimitOverride {
+			var expiresAt *int64
+			if override.ExpiresAtM.Valid {
+				expiresAt = ptr.P(override.ExpiresAtM.Int64)
+			}
+
 			return openapi.RatelimitOverride{
 				OverrideId: override.ID,
 				Duration:   int64(override.Duration),
 				Identifier: override.Identifier,
 				Limit:      int64(override.Limit),
+				Priority:   override.Priority,
+				ExpiresAt:  expiresAt,
 			}
 		}),
 		Pagination: pg,
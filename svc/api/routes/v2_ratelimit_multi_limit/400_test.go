@@ -136,6 +136,53 @@ func TestMissingAuthorizationHeader(t *testing.T) {
 
 	h.Register(route)
 
+	t.Run("dry run is not supported", func(t *testing.T) {
+		rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "ratelimit.*.limit", "ratelimit.*.create_namespace")
+		headers := http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+		}
+
+		req := handler.Request{
+			{
+				Namespace:  uid.New("test"),
+				Identifier: "user_123",
+				Limit:      100,
+				Duration:   60000,
+				DryRun:     ptr.P(true),
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+
+		require.Equal(t, http.StatusBadRequest, res.Status, "expected 400, received: %s", res.RawBody)
+		require.NotNil(t, res.Body)
+		require.Equal(t, "https://unkey.com/docs/errors/unkey/application/invalid_input", res.Body.Error.Type)
+		require.Equal(t, "dryRun is not supported by ratelimit.multiLimit, use ratelimit.limit instead.", res.Body.Error.Detail)
+	})
+
+	t.Run("plan is not supported", func(t *testing.T) {
+		rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "ratelimit.*.limit")
+		headers := http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+		}
+
+		req := handler.Request{
+			{
+				Plan:       ptr.P("pro"),
+				Identifier: "user_123",
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+
+		require.Equal(t, http.StatusBadRequest, res.Status, "expected 400, received: %s", res.RawBody)
+		require.NotNil(t, res.Body)
+		require.Equal(t, "https://unkey.com/docs/errors/unkey/application/invalid_input", res.Body.Error.Type)
+		require.Equal(t, "plan is not supported by ratelimit.multiLimit, use ratelimit.limit instead.", res.Body.Error.Detail)
+	})
+
 	t.Run("missing authorization header", func(t *testing.T) {
 		headers := http.Header{
 			"Content-Type": {"application/json"},
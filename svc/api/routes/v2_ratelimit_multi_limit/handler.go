@@ -20,7 +20,6 @@ import (
 	"github.com/unkeyed/unkey/pkg/db"
 	"github.com/unkeyed/unkey/pkg/fault"
 	"github.com/unkeyed/unkey/pkg/logger"
-	"github.com/unkeyed/unkey/pkg/match"
 	"github.com/unkeyed/unkey/pkg/ptr"
 	"github.com/unkeyed/unkey/pkg/rbac"
 	"github.com/unkeyed/unkey/pkg/uid"
@@ -69,6 +68,12 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 	// Collect unique namespace names
 	uniqueNames := make(map[string]bool)
 	for _, check := range req {
+		if check.Plan != nil {
+			return fault.New("plan in multi limit",
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Public("plan is not supported by ratelimit.multiLimit, use ratelimit.limit instead."),
+			)
+		}
 		uniqueNames[check.Namespace] = true
 	}
 	names := make([]string, 0, len(uniqueNames))
@@ -156,8 +161,15 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			)
 		}
 
+		if ptr.SafeDeref(check.DryRun, false) {
+			return fault.New("dry run in multi limit",
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Public("dryRun is not supported by ratelimit.multiLimit, use ratelimit.limit instead."),
+			)
+		}
+
 		// Apply override if found, otherwise use request values
-		limit, duration, overrideID, matchErr := getLimitAndDuration(check, ns)
+		limit, duration, overrideID, matchErr := getLimitAndDuration(check, ns, reqTime)
 		if matchErr != nil {
 			return fault.Wrap(matchErr,
 				fault.Code(codes.App.Internal.UnexpectedError.URN()),
@@ -166,6 +178,11 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			)
 		}
 
+		algorithm, refillRate, algorithmErr := getAlgorithm(check, limit)
+		if algorithmErr != nil {
+			return algorithmErr
+		}
+
 		cost := ptr.SafeDeref(check.Cost, 1)
 		ratelimitReqs[i] = ratelimit.RatelimitRequest{
 			WorkspaceID: principal.WorkspaceID,
@@ -173,6 +190,8 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			Identifier:  check.Identifier,
 			Duration:    time.Duration(duration) * time.Millisecond,
 			Limit:       limit,
+			Algorithm:   algorithm,
+			RefillRate:  refillRate,
 			Cost:        cost,
 			Time:        reqTime,
 		}
@@ -436,8 +455,8 @@ type checkMeta struct {
 	cost          int64
 }
 
-func getLimitAndDuration(check openapi.V2RatelimitLimitRequestBody, namespace db.FindRatelimitNamespace) (int64, int64, string, error) {
-	override, found, err := matchOverride(check.Identifier, namespace)
+func getLimitAndDuration(check openapi.V2RatelimitLimitRequestBody, ns db.FindRatelimitNamespace, now time.Time) (int64, int64, string, error) {
+	override, found, err := namespace.MatchOverride(ns, check.Identifier, now)
 	if err != nil {
 		return 0, 0, "", err
 	}
@@ -449,28 +468,28 @@ func getLimitAndDuration(check openapi.V2RatelimitLimitRequestBody, namespace db
 	return check.Limit, check.Duration, "", nil
 }
 
-func matchOverride(identifier string, namespace db.FindRatelimitNamespace) (db.FindRatelimitNamespaceLimitOverride, bool, error) {
-	if override, ok := namespace.DirectOverrides[identifier]; ok {
-		return override, true, nil
+// getAlgorithm maps the requested algorithm onto the ratelimit service, whose
+// Algorithm values match the API's. An override replaces only limit and
+// duration, so a refill rate chosen for the requested limit is capped at a
+// lower override limit instead of failing the request.
+func getAlgorithm(check openapi.V2RatelimitLimitRequestBody, limit int64) (ratelimit.Algorithm, int64, error) {
+	algorithm := ratelimit.Algorithm(ptr.SafeDeref(check.Algorithm, openapi.SlidingWindow))
+	if check.RefillRate == nil {
+		return algorithm, 0, nil
 	}
 
-	for _, override := range namespace.WildcardOverrides {
-		ok, err := match.Wildcard(identifier, override.Identifier)
-		if err != nil {
-			return db.FindRatelimitNamespaceLimitOverride{}, false, err
-		}
-
-		if !ok {
-			continue
-		}
-
-		return override, true, nil
+	if algorithm != ratelimit.AlgorithmTokenBucket {
+		return "", 0, fault.New("refill rate set without token bucket",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Public("refillRate is only valid with algorithm token_bucket."),
+		)
+	}
+	if *check.RefillRate > check.Limit {
+		return "", 0, fault.New("refill rate exceeds limit",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Public("refillRate must not exceed limit."),
+		)
 	}
 
-	return db.FindRatelimitNamespaceLimitOverride{
-		Limit:      0,
-		ID:         "",
-		Identifier: "",
-		Duration:   0,
-	}, false, nil
+	return algorithm, min(*check.RefillRate, limit), nil
 }
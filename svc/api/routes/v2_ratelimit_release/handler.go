@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/unkeyed/unkey/internal/services/ratelimit"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	acquire "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_acquire"
+)
+
+type (
+	Request  = openapi.V2RatelimitReleaseRequestBody
+	Response = openapi.V2RatelimitReleaseResponseBody
+)
+
+// Handler implements zen.Route interface for the v2 ratelimit release endpoint
+type Handler struct {
+	DB             db.Database
+	Ratelimit      ratelimit.Service
+	NamespaceCache cache.Cache[cache.ScopedKey, db.FindRatelimitNamespace]
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/ratelimit.release"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	ns, err := acquire.FindNamespace(ctx, h.DB, h.NamespaceCache, principal.WorkspaceID, req.Namespace)
+	if err != nil {
+		return err
+	}
+
+	err = principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Ratelimit,
+			ResourceID:   ns.ID,
+			Action:       rbac.Limit,
+		}),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Ratelimit,
+			ResourceID:   "*",
+			Action:       rbac.Limit,
+		}),
+	))
+	if err != nil {
+		return err
+	}
+
+	released, err := h.Ratelimit.Release(ctx, ratelimit.ReleaseRequest{
+		WorkspaceID: principal.WorkspaceID,
+		Namespace:   ns.ID,
+		Identifier:  req.Identifier,
+		LeaseID:     req.LeaseId,
+	})
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.UnexpectedError.URN()),
+			fault.Internal("lease release failed"),
+			fault.Public("We're unable to process the lease release."),
+		)
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.V2RatelimitReleaseResponseData{
+			Released: released,
+		},
+	})
+}
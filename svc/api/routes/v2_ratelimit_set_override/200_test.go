@@ -30,9 +30,10 @@ func TestSetOverrideSuccessfully(t *testing.T) {
 	require.NoError(t, err)
 
 	route := &handler.Handler{
-		DB:             h.DB,
-		Auditlogs:      h.Auditlogs,
-		NamespaceCache: h.Caches.RatelimitNamespace,
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
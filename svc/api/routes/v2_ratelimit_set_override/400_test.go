@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/ptr"
 	"github.com/unkeyed/unkey/pkg/uid"
 	"github.com/unkeyed/unkey/svc/api/internal/testutil"
 	"github.com/unkeyed/unkey/svc/api/openapi"
@@ -17,8 +19,9 @@ func TestBadRequests(t *testing.T) {
 
 	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "ratelimit.*.set_override")
 	route := &handler.Handler{
-		DB:             h.DB,
-		NamespaceCache: h.Caches.RatelimitNamespace,
+		DB:       h.DB,
+		Caches:   h.Caches.Invalidations,
+		Webhooks: h.Webhooks,
 	}
 
 	h.Register(route)
@@ -186,4 +189,37 @@ func TestBadRequests(t *testing.T) {
 		require.Equal(t, http.StatusBadRequest, res.Status, "expected 401, sent: %+v, received: %s", req, res.RawBody)
 		require.NotNil(t, res.Body)
 	})
+
+	t.Run("expiresAt in the past", func(t *testing.T) {
+		req := openapi.V2RatelimitSetOverrideRequestBody{
+			Namespace:  "test_namespace_id",
+			Identifier: "user_123",
+			Limit:      10,
+			Duration:   1000,
+			ExpiresAt:  ptr.P(time.Now().Add(-time.Minute).UnixMilli()),
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+
+		require.Equal(t, 400, res.Status, "expected 400, sent: %+v, received: %s", req, res.RawBody)
+		require.NotNil(t, res.Body)
+		require.Equal(t, "https://unkey.com/docs/errors/unkey/application/invalid_input", res.Body.Error.Type)
+		require.Equal(t, "expiresAt must be in the future.", res.Body.Error.Detail)
+	})
+
+	t.Run("negative priority", func(t *testing.T) {
+		req := openapi.V2RatelimitSetOverrideRequestBody{
+			Namespace:  "test_namespace_id",
+			Identifier: "user_*",
+			Limit:      10,
+			Duration:   1000,
+			Priority:   ptr.P(int32(-1)),
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+
+		require.Equal(t, 400, res.Status, "expected 400, sent: %+v, received: %s", req, res.RawBody)
+		require.NotNil(t, res.Body)
+		require.Equal(t, "POST request body for '/v2/ratelimit.setOverride' failed to validate schema", res.Body.Error.Detail)
+	})
 }
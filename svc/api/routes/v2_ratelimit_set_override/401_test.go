@@ -14,9 +14,10 @@ func TestUnauthorizedAccess(t *testing.T) {
 	h := testutil.NewHarness(t)
 
 	route := &handler.Handler{
-		DB:             h.DB,
-		Auditlogs:      h.Auditlogs,
-		NamespaceCache: h.Caches.RatelimitNamespace,
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
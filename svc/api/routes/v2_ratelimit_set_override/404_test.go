@@ -15,9 +15,10 @@ func TestNamespaceNotFound(t *testing.T) {
 	h := testutil.NewHarness(t)
 
 	route := &handler.Handler{
-		DB:             h.DB,
-		Auditlogs:      h.Auditlogs,
-		NamespaceCache: h.Caches.RatelimitNamespace,
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
+		Webhooks:  h.Webhooks,
 	}
 
 	h.Register(route)
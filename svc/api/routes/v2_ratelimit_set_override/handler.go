@@ -8,11 +8,13 @@ import (
 	"time"
 
 	"github.com/unkeyed/unkey/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/internal/services/caches"
+	"github.com/unkeyed/unkey/internal/services/webhooks"
 	"github.com/unkeyed/unkey/pkg/auditlog"
-	"github.com/unkeyed/unkey/pkg/cache"
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/db"
 	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/ptr"
 	"github.com/unkeyed/unkey/pkg/rbac"
 	"github.com/unkeyed/unkey/pkg/uid"
 	"github.com/unkeyed/unkey/pkg/zen"
@@ -26,9 +28,10 @@ type (
 
 // Handler implements zen.Route interface for the v2 ratelimit set override endpoint
 type Handler struct {
-	DB             db.Database
-	Auditlogs      auditlogs.AuditLogService
-	NamespaceCache cache.Cache[cache.ScopedKey, db.FindRatelimitNamespace]
+	DB        db.Database
+	Auditlogs auditlogs.AuditLogService
+	Caches    *caches.Invalidator
+	Webhooks  webhooks.Service
 }
 
 // Method returns the HTTP method this route responds to
@@ -59,6 +62,18 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		return err
 	}
 
+	expiresAt := sql.NullInt64{Valid: false, Int64: 0}
+	if req.ExpiresAt != nil {
+		if *req.ExpiresAt <= time.Now().UnixMilli() {
+			return fault.New("override expiry in the past",
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Internal("expiresAt is not in the future"),
+				fault.Public("expiresAt must be in the future."),
+			)
+		}
+		expiresAt = sql.NullInt64{Valid: true, Int64: *req.ExpiresAt}
+	}
+
 	// Keep the namespace lookup inside the transaction for transactional read consistency.
 	result, err := db.TxWithResultRetry(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) (setOverrideResult, error) {
 		var zero setOverrideResult
@@ -127,6 +142,8 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			Identifier:  req.Identifier,
 			Limit:       uint64(req.Limit),    // nolint:gosec
 			Duration:    uint64(req.Duration), //nolint:gosec
+			Priority:    ptr.SafeDeref(req.Priority, 0),
+			ExpiresAt:   expiresAt,
 			CreatedAt:   now,
 			UpdatedAt:   sql.NullInt64{Int64: now, Valid: true},
 		})
@@ -176,11 +193,26 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 	}
 
 	// Invalidate cache for this namespace after the transaction commits
-	h.NamespaceCache.Remove(ctx,
-		cache.ScopedKey{WorkspaceID: principal.WorkspaceID, Key: result.namespaceID},
-		cache.ScopedKey{WorkspaceID: principal.WorkspaceID, Key: result.namespaceName},
+	h.Caches.Invalidate(ctx,
+		caches.RatelimitNamespaceEntity(principal.WorkspaceID, result.namespaceID, result.namespaceName),
 	)
 
+	h.Webhooks.Emit(ctx, webhooks.Event{
+		ID:          "",
+		WorkspaceID: principal.WorkspaceID,
+		Type:        webhooks.EventRatelimitOverrideSet,
+		Data: webhooks.RatelimitOverrideData{
+			OverrideID:  result.overrideID,
+			NamespaceID: result.namespaceID,
+			Identifier:  req.Identifier,
+			Limit:       req.Limit,
+			Duration:    req.Duration,
+			Priority:    ptr.SafeDeref(req.Priority, 0),
+			ExpiresAt:   expiresAt.Int64,
+		},
+		At: time.Time{},
+	})
+
 	return s.JSON(http.StatusOK, Response{
 		Meta: openapi.Meta{
 			RequestId: s.RequestID(),
@@ -0,0 +1,91 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/internal/services/ratelimit/namespace"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_set_plan"
+)
+
+func TestSetPlanSuccessfully(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	workspaceID := h.Resources().UserWorkspace.ID
+	namespaceID := uid.New(uid.RatelimitNamespacePrefix)
+	namespaceName := uid.New("test")
+	err := db.Query.InsertRatelimitNamespace(ctx, h.DB.RW(), db.InsertRatelimitNamespaceParams{
+		ID:          namespaceID,
+		WorkspaceID: workspaceID,
+		Name:        namespaceName,
+		CreatedAt:   time.Now().UnixMilli(),
+	})
+	require.NoError(t, err)
+
+	route := &handler.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
+	}
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(workspaceID, fmt.Sprintf("ratelimit.%s.update_namespace", namespaceID))
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	findPlan := func(t *testing.T, plan string) db.FindRatelimitPlan {
+		row, findErr := db.Query.FindRatelimitPlan(ctx, h.DB.RO(), db.FindRatelimitPlanParams{
+			WorkspaceID: workspaceID,
+			Plan:        plan,
+		})
+		require.NoError(t, findErr)
+		return namespace.ParsePlanRow(row)
+	}
+
+	planName := uid.New("plan")
+
+	res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+		Name: planName,
+		Limits: []openapi.V2RatelimitSetPlanLimit{
+			{Namespace: namespaceName, Limit: 10, Duration: 1000},
+			{Namespace: namespaceID, Limit: 500, Duration: 60000},
+		},
+	})
+	require.Equal(t, http.StatusOK, res.Status, "expected 200, received: %s", res.RawBody)
+	planID := res.Body.Data.PlanId
+	require.NotEmpty(t, planID)
+
+	plan := findPlan(t, planName)
+	require.Equal(t, planID, plan.ID)
+	require.Equal(t, []db.FindRatelimitPlanLimit{
+		{NamespaceID: namespaceID, Limit: 10, Duration: 1000},
+		{NamespaceID: namespaceID, Limit: 500, Duration: 60000},
+	}, plan.Limits)
+
+	t.Run("setting an existing plan replaces its limits", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Name: planName,
+			Limits: []openapi.V2RatelimitSetPlanLimit{
+				{Namespace: namespaceName, Limit: 100000, Duration: 86400000},
+			},
+		})
+		require.Equal(t, http.StatusOK, res.Status, "expected 200, received: %s", res.RawBody)
+		require.Equal(t, planID, res.Body.Data.PlanId)
+
+		plan := findPlan(t, planID)
+		require.Equal(t, []db.FindRatelimitPlanLimit{
+			{NamespaceID: namespaceID, Limit: 100000, Duration: 86400000},
+		}, plan.Limits)
+	})
+}
@@ -0,0 +1,57 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_ratelimit_set_plan"
+)
+
+func TestRequiresPermissionOnEveryNamespace(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	workspaceID := h.Resources().UserWorkspace.ID
+	namespaceIDs := make([]string, 2)
+	for i := range namespaceIDs {
+		namespaceIDs[i] = uid.New(uid.RatelimitNamespacePrefix)
+		err := db.Query.InsertRatelimitNamespace(ctx, h.DB.RW(), db.InsertRatelimitNamespaceParams{
+			ID:          namespaceIDs[i],
+			WorkspaceID: workspaceID,
+			Name:        uid.New("test"),
+			CreatedAt:   time.Now().UnixMilli(),
+		})
+		require.NoError(t, err)
+	}
+
+	route := &handler.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+		Caches:    h.Caches.Invalidations,
+	}
+	h.Register(route)
+
+	// Allowed on the first namespace only.
+	rootKey := h.CreateRootKey(workspaceID, fmt.Sprintf("ratelimit.%s.update_namespace", namespaceIDs[0]))
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	res := testutil.CallRoute[handler.Request, openapi.ForbiddenErrorResponse](h, route, headers, handler.Request{
+		Name: uid.New("plan"),
+		Limits: []openapi.V2RatelimitSetPlanLimit{
+			{Namespace: namespaceIDs[0], Limit: 10, Duration: 1000},
+			{Namespace: namespaceIDs[1], Limit: 10, Duration: 1000},
+		},
+	})
+	require.Equal(t, http.StatusForbidden, res.Status, "expected 403, received: %s", res.RawBody)
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/unkeyed/unkey/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/internal/services/caches"
+	"github.com/unkeyed/unkey/internal/services/ratelimit/namespace"
+	"github.com/unkeyed/unkey/pkg/auditlog"
+	"github.com/unkeyed/unkey/pkg/auth/principal"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2RatelimitSetPlanRequestBody
+	Response = openapi.V2RatelimitSetPlanResponseBody
+)
+
+// Handler implements zen.Route interface for the v2 ratelimit set plan endpoint
+type Handler struct {
+	DB        db.Database
+	Auditlogs auditlogs.AuditLogService
+	Caches    *caches.Invalidator
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/ratelimit.setPlan"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	// Keep the lookups inside the transaction for transactional read consistency.
+	planID, err := db.TxWithResultRetry(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) (string, error) {
+		namespaceIDs, txErr := resolveNamespaces(ctx, tx, principal.WorkspaceID, req.Limits)
+		if txErr != nil {
+			return "", txErr
+		}
+
+		planID := uid.New(uid.RatelimitPlanPrefix)
+		existing, txErr := db.Query.FindRatelimitPlan(ctx, tx, db.FindRatelimitPlanParams{
+			WorkspaceID: principal.WorkspaceID,
+			Plan:        req.Name,
+		})
+		if txErr != nil && !db.IsNotFound(txErr) {
+			return "", fault.Wrap(txErr,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database failed"),
+				fault.Public("The database is unavailable."),
+			)
+		}
+
+		// Replacing a plan drops its current limits, so those namespaces need
+		// the same permission as the new ones.
+		affected := make([]string, 0, len(namespaceIDs))
+		affected = append(affected, namespaceIDs...)
+		if txErr == nil && existing.Name == req.Name {
+			planID = existing.ID
+			for _, l := range namespace.ParsePlanRow(existing).Limits {
+				affected = append(affected, l.NamespaceID)
+			}
+		}
+
+		txErr = authorize(principal, affected)
+		if txErr != nil {
+			return "", txErr
+		}
+
+		now := time.Now().UnixMilli()
+		txErr = db.Query.InsertRatelimitPlan(ctx, tx, db.InsertRatelimitPlanParams{
+			ID:          planID,
+			WorkspaceID: principal.WorkspaceID,
+			Name:        req.Name,
+			CreatedAt:   now,
+			UpdatedAt:   sql.NullInt64{Int64: now, Valid: true},
+		})
+		if txErr != nil {
+			return "", fault.Wrap(txErr,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database failed to upsert plan"),
+				fault.Public("The database is unavailable."),
+			)
+		}
+
+		txErr = db.Query.DeleteRatelimitPlanLimits(ctx, tx, planID)
+		if txErr != nil {
+			return "", fault.Wrap(txErr,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database failed to delete plan limits"),
+				fault.Public("The database is unavailable."),
+			)
+		}
+
+		limits := make([]db.InsertRatelimitPlanLimitParams, len(req.Limits))
+		for i, l := range req.Limits {
+			limits[i] = db.InsertRatelimitPlanLimitParams{
+				PlanID:      planID,
+				WorkspaceID: principal.WorkspaceID,
+				NamespaceID: namespaceIDs[i],
+				Limit:       uint64(l.Limit),    //nolint:gosec
+				Duration:    uint64(l.Duration), //nolint:gosec
+				CreatedAt:   now,
+			}
+		}
+
+		txErr = db.BulkQuery.InsertRatelimitPlanLimits(ctx, tx, limits)
+		if txErr != nil {
+			return "", fault.Wrap(txErr,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database failed to insert plan limits"),
+				fault.Public("The database is unavailable."),
+			)
+		}
+
+		txErr = h.Auditlogs.Insert(ctx, tx, []auditlog.AuditLog{
+			{
+				WorkspaceID:   principal.WorkspaceID,
+				Event:         auditlog.RatelimitSetPlanEvent,
+				ActorID:       principal.Subject.ID,
+				ActorType:     auditlog.AuditLogActor(principal.Subject.Type),
+				ActorName:     principal.Subject.Name,
+				ActorMeta:     map[string]any{},
+				RemoteIP:      s.Location(),
+				UserAgent:     s.UserAgent(),
+				Display:       fmt.Sprintf("Set ratelimit plan %s with %d limits", req.Name, len(req.Limits)),
+				CorrelationID: "",
+				Resources: []auditlog.AuditLogResource{
+					{
+						Type:        auditlog.RatelimitPlanResourceType,
+						ID:          planID,
+						Name:        req.Name,
+						DisplayName: req.Name,
+						Meta:        nil,
+					},
+				},
+			},
+		})
+		if txErr != nil {
+			return "", txErr
+		}
+
+		return planID, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache for this plan after the transaction commits
+	h.Caches.Invalidate(ctx,
+		caches.RatelimitPlanEntity(principal.WorkspaceID, planID, req.Name),
+	)
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.V2RatelimitSetPlanResponseData{
+			PlanId: planID,
+		},
+	})
+}
+
+// resolveNamespaces returns the namespace ID of every limit, in order. Limits
+// may name their namespace by name or ID, so duplicates are only detected
+// once both forms are resolved to IDs.
+func resolveNamespaces(ctx context.Context, tx db.DBTX, workspaceID string, limits []openapi.V2RatelimitSetPlanLimit) ([]string, error) {
+	type window struct {
+		namespaceID string
+		duration    int64
+	}
+
+	byNameOrID := make(map[string]string, len(limits))
+	seen := make(map[window]bool, len(limits))
+	ids := make([]string, len(limits))
+	for i, l := range limits {
+		id, ok := byNameOrID[l.Namespace]
+		if !ok {
+			row, err := db.Query.FindRatelimitNamespace(ctx, tx, db.FindRatelimitNamespaceParams{
+				WorkspaceID: workspaceID,
+				Namespace:   l.Namespace,
+			})
+			if err != nil && !db.IsNotFound(err) {
+				return nil, fault.Wrap(err,
+					fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+					fault.Internal("database failed"),
+					fault.Public("The database is unavailable."),
+				)
+			}
+			if err != nil || row.DeletedAtM.Valid {
+				return nil, fault.New("namespace not found",
+					fault.Code(codes.Data.RatelimitNamespace.NotFound.URN()),
+					fault.Public(fmt.Sprintf("Namespace %q does not exist.", l.Namespace)),
+				)
+			}
+
+			id = row.ID
+			byNameOrID[l.Namespace] = id
+		}
+
+		w := window{namespaceID: id, duration: l.Duration}
+		if seen[w] {
+			return nil, fault.New("duplicate plan limit",
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Public(fmt.Sprintf("Namespace %q has more than one limit with a duration of %dms.", l.Namespace, l.Duration)),
+			)
+		}
+		seen[w] = true
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// authorize requires update_namespace on every namespace the plan touches.
+func authorize(principal *principal.Principal, namespaceIDs []string) error {
+	perms := make([]rbac.PermissionQuery, 0, len(namespaceIDs))
+	for _, id := range namespaceIDs {
+		perms = append(perms, rbac.T(rbac.Tuple{
+			ResourceType: rbac.Ratelimit,
+			ResourceID:   id,
+			Action:       rbac.UpdateNamespace,
+		}))
+	}
+
+	return principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Ratelimit,
+			ResourceID:   "*",
+			Action:       rbac.UpdateNamespace,
+		}),
+		rbac.And(perms...),
+	))
+}
@@ -0,0 +1,71 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	vaultv1 "github.com/unkeyed/unkey/gen/proto/vault/v1"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_webhooks_create_endpoint"
+)
+
+func TestCreateEndpointSuccessfully(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+		Vault:     h.Vault,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "webhook.*.create_webhook")
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	req := handler.Request{
+		Url: "https://example.com/webhooks/unkey",
+		EventTypes: []openapi.WebhookEventType{
+			openapi.WebhookEventTypeKeyDeleted,
+			openapi.WebhookEventTypeKeyCreated,
+			openapi.WebhookEventTypeKeyCreated,
+		},
+	}
+
+	res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+	require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+	require.NotEmpty(t, res.Body.Data.EndpointId)
+	require.True(t, strings.HasPrefix(res.Body.Data.Secret, "whsec_"))
+
+	endpoint, err := db.Query.FindWebhookEndpointByID(ctx, h.DB.RO(), db.FindWebhookEndpointByIDParams{
+		WorkspaceID: workspace.ID,
+		ID:          res.Body.Data.EndpointId,
+	})
+	require.NoError(t, err)
+	require.Equal(t, req.Url, endpoint.Url)
+	require.True(t, endpoint.Enabled, "endpoints are enabled by default")
+
+	var eventTypes []string
+	require.NoError(t, json.Unmarshal(endpoint.EventTypes, &eventTypes))
+	require.Equal(t, []string{"key.created", "key.deleted"}, eventTypes)
+
+	require.NotContains(t, endpoint.EncryptedSecret, res.Body.Data.Secret, "secret must be stored encrypted")
+	decrypted, err := h.Vault.Decrypt(ctx, &vaultv1.DecryptRequest{
+		Keyring:   workspace.ID,
+		Encrypted: endpoint.EncryptedSecret,
+	})
+	require.NoError(t, err)
+	require.Equal(t, res.Body.Data.Secret, decrypted.GetPlaintext())
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	vaultv1 "github.com/unkeyed/unkey/gen/proto/vault/v1"
+	"github.com/unkeyed/unkey/gen/rpc/vault"
+	"github.com/unkeyed/unkey/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/pkg/auditlog"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/pkg/webhook/signing"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/auditactor"
+	"github.com/unkeyed/unkey/svc/api/internal/webhook"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2WebhooksCreateEndpointRequestBody
+	Response = openapi.V2WebhooksCreateEndpointResponseBody
+)
+
+type Handler struct {
+	DB        db.Database
+	Auditlogs auditlogs.AuditLogService
+	Vault     vault.VaultServiceClient
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/webhooks.createEndpoint"
+}
+
+// Handle registers an endpoint and returns its signing secret. The secret is
+// stored encrypted in vault and never returned again.
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	err = principal.Authorize(rbac.T(rbac.Tuple{
+		ResourceType: rbac.Webhook,
+		ResourceID:   "*",
+		Action:       rbac.CreateWebhook,
+	}))
+	if err != nil {
+		return err
+	}
+
+	if err = webhook.ValidateURL(req.Url); err != nil {
+		return err
+	}
+
+	eventTypes, err := webhook.MarshalEventTypes(req.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	if h.Vault == nil {
+		return fault.New("vault missing",
+			fault.Code(codes.App.Precondition.PreconditionFailed.URN()),
+			fault.Public("Vault hasn't been set up."),
+		)
+	}
+
+	secret, err := signing.NewSecret()
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.UnexpectedError.URN()),
+			fault.Internal("generate webhook secret"),
+			fault.Public("Failed to create webhook endpoint."),
+		)
+	}
+
+	encrypted, err := h.Vault.Encrypt(ctx, &vaultv1.EncryptRequest{
+		Keyring: principal.WorkspaceID,
+		Data:    secret,
+	})
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("vault error"),
+			fault.Public("Failed to encrypt webhook secret in vault."),
+		)
+	}
+
+	description := sql.NullString{Valid: false, String: ""}
+	if req.Description != nil {
+		description = sql.NullString{Valid: true, String: *req.Description}
+	}
+
+	actor := auditactor.FromPrincipal(principal)
+	endpointID := uid.New(uid.WebhookEndpointPrefix)
+
+	err = db.TxRetry(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) error {
+		err = db.Query.InsertWebhookEndpoint(ctx, tx, db.InsertWebhookEndpointParams{
+			ID:              endpointID,
+			WorkspaceID:     principal.WorkspaceID,
+			Url:             req.Url,
+			Description:     description,
+			EventTypes:      eventTypes,
+			EncryptedSecret: encrypted.GetEncrypted(),
+			EncryptionKeyID: encrypted.GetKeyId(),
+			Enabled:         ptr.SafeDeref(req.Enabled, true),
+			CreatedAt:       time.Now().UnixMilli(),
+		})
+		if err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database error"),
+				fault.Public("Failed to create webhook endpoint."),
+			)
+		}
+
+		return h.Auditlogs.Insert(ctx, tx, []auditlog.AuditLog{
+			{
+				WorkspaceID:   principal.WorkspaceID,
+				Event:         auditlog.WebhookCreateEvent,
+				ActorType:     actor.Type,
+				ActorID:       actor.ID,
+				ActorName:     actor.Name,
+				ActorMeta:     actor.Meta,
+				Display:       fmt.Sprintf("Created webhook endpoint %s for %s", endpointID, req.Url),
+				RemoteIP:      s.Location(),
+				UserAgent:     s.UserAgent(),
+				CorrelationID: "",
+				Resources: []auditlog.AuditLogResource{
+					{
+						Type:        auditlog.WebhookEndpointResourceType,
+						ID:          endpointID,
+						Name:        req.Url,
+						DisplayName: req.Url,
+						Meta:        map[string]any{},
+					},
+				},
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.V2WebhooksCreateEndpointResponseData{
+			EndpointId: endpointID,
+			Secret:     secret,
+		},
+	})
+}
@@ -0,0 +1,38 @@
+package handler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_webhooks_delete_endpoint"
+)
+
+func TestDeleteEndpointSuccessfully(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	headers := authHeaders(h.CreateRootKey(workspace.ID, "webhook.*.delete_webhook"))
+
+	endpoint := seedEndpoint(t, h, workspace.ID, true)
+
+	res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+		EndpointId: endpoint.ID,
+	})
+	require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+
+	_, err := db.Query.FindWebhookEndpointByID(ctx, h.DB.RO(), db.FindWebhookEndpointByIDParams{
+		WorkspaceID: workspace.ID,
+		ID:          endpoint.ID,
+	})
+	require.True(t, db.IsNotFound(err), "endpoint should be gone, got %v", err)
+}
@@ -0,0 +1,28 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_webhooks_delete_endpoint"
+)
+
+func TestDeleteEndpointNotFound(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+	}
+	h.Register(route)
+
+	headers := authHeaders(h.CreateRootKey(h.Resources().UserWorkspace.ID, "webhook.*.delete_webhook"))
+
+	res := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, headers, handler.Request{
+		EndpointId: "whep_does_not_exist",
+	})
+	require.Equal(t, 404, res.Status, "expected 404, received: %s", res.RawBody)
+	require.Equal(t, "https://unkey.com/docs/errors/unkey/data/webhook_endpoint_not_found", res.Body.Error.Type)
+}
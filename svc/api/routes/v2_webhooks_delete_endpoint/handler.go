@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/unkeyed/unkey/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/pkg/auditlog"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/auditactor"
+	"github.com/unkeyed/unkey/svc/api/internal/webhook"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2WebhooksDeleteEndpointRequestBody
+	Response = openapi.V2WebhooksDeleteEndpointResponseBody
+)
+
+type Handler struct {
+	DB        db.Database
+	Auditlogs auditlogs.AuditLogService
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/webhooks.deleteEndpoint"
+}
+
+// Handle deletes an endpoint and its delivery log. Retries still scheduled in
+// the ctrl worker find the delivery gone and stop.
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	err = principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Webhook,
+			ResourceID:   "*",
+			Action:       rbac.DeleteWebhook,
+		}),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Webhook,
+			ResourceID:   req.EndpointId,
+			Action:       rbac.DeleteWebhook,
+		}),
+	))
+	if err != nil {
+		return err
+	}
+
+	actor := auditactor.FromPrincipal(principal)
+
+	err = db.TxRetry(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) error {
+		endpoint, txErr := webhook.FindEndpoint(ctx, tx, principal.WorkspaceID, req.EndpointId)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = db.Query.DeleteWebhookDeliveriesByEndpointID(ctx, tx, endpoint.ID)
+		if txErr == nil {
+			txErr = db.Query.DeleteWebhookEndpoint(ctx, tx, endpoint.ID)
+		}
+		if txErr != nil {
+			return fault.Wrap(txErr,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database error"),
+				fault.Public("Failed to delete webhook endpoint."),
+			)
+		}
+
+		return h.Auditlogs.Insert(ctx, tx, []auditlog.AuditLog{
+			{
+				WorkspaceID:   principal.WorkspaceID,
+				Event:         auditlog.WebhookDeleteEvent,
+				ActorType:     actor.Type,
+				ActorID:       actor.ID,
+				ActorName:     actor.Name,
+				ActorMeta:     actor.Meta,
+				Display:       fmt.Sprintf("Deleted webhook endpoint %s", endpoint.ID),
+				RemoteIP:      s.Location(),
+				UserAgent:     s.UserAgent(),
+				CorrelationID: "",
+				Resources: []auditlog.AuditLogResource{
+					{
+						Type:        auditlog.WebhookEndpointResourceType,
+						ID:          endpoint.ID,
+						Name:        endpoint.Url,
+						DisplayName: endpoint.Url,
+						Meta:        map[string]any{},
+					},
+				},
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.EmptyResponse{},
+	})
+}
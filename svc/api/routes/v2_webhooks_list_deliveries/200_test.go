@@ -0,0 +1,66 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_webhooks_list_deliveries"
+)
+
+func TestListDeliveriesSuccessfully(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{DB: h.DB}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	endpoint := seedEndpoint(t, h, workspace.ID, true)
+	headers := authHeaders(h.CreateRootKey(workspace.ID, "webhook."+endpoint.ID+".read_webhook"))
+
+	// Deliveries to another endpoint must not leak into the listing.
+	seedDelivery(t, h, seedEndpoint(t, h, workspace.ID, true))
+
+	ids := []string{}
+	for range 5 {
+		ids = append(ids, seedDelivery(t, h, endpoint).ID)
+	}
+
+	t.Run("newest first across pages", func(t *testing.T) {
+		got := []string{}
+		var cursor *string
+		for {
+			res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+				EndpointId: endpoint.ID,
+				Limit:      ptr.P(2),
+				Cursor:     cursor,
+			})
+			require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+
+			for _, delivery := range res.Body.Data {
+				require.Equal(t, openapi.WebhookDeliveryStatusPending, delivery.Status)
+				got = append(got, delivery.DeliveryId)
+			}
+
+			if !res.Body.Pagination.HasMore {
+				break
+			}
+			cursor = res.Body.Pagination.Cursor
+		}
+
+		require.Len(t, got, len(ids))
+		for i := range ids {
+			require.Equal(t, ids[len(ids)-1-i], got[i])
+		}
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, handler.Request{
+			EndpointId: endpoint.ID,
+			Cursor:     ptr.P("not-a-number"),
+		})
+		require.Equal(t, 400, res.Status, "expected 400, received: %s", res.RawBody)
+	})
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/pagination"
+	"github.com/unkeyed/unkey/svc/api/internal/webhook"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2WebhooksListDeliveriesRequestBody
+	Response = openapi.V2WebhooksListDeliveriesResponseBody
+)
+
+type Handler struct {
+	DB db.Database
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/webhooks.listDeliveries"
+}
+
+// Handle lists an endpoint's deliveries newest first. Delivery ids are random,
+// so the cursor is the internal row number of the first delivery on the next
+// page.
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	err = principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Webhook,
+			ResourceID:   "*",
+			Action:       rbac.ReadWebhook,
+		}),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Webhook,
+			ResourceID:   req.EndpointId,
+			Action:       rbac.ReadWebhook,
+		}),
+	))
+	if err != nil {
+		return err
+	}
+
+	p := pagination.Parse(req.Limit, req.Cursor, 50)
+
+	cursor := uint64(math.MaxUint64)
+	if p.Cursor != "" {
+		cursor, err = strconv.ParseUint(p.Cursor, 10, 64)
+		if err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Internal("invalid delivery cursor"),
+				fault.Public("The cursor is invalid. Use the cursor from a previous response."),
+			)
+		}
+	}
+
+	if _, err = webhook.FindEndpoint(ctx, h.DB.RO(), principal.WorkspaceID, req.EndpointId); err != nil {
+		return err
+	}
+
+	rows, err := db.Query.ListWebhookDeliveriesByEndpointID(ctx, h.DB.RO(), db.ListWebhookDeliveriesByEndpointIDParams{
+		WorkspaceID: principal.WorkspaceID,
+		EndpointID:  req.EndpointId,
+		PkCursor:    cursor,
+		Limit:       p.FetchLimit(),
+	})
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to list webhook deliveries."),
+		)
+	}
+
+	rows, pg := pagination.Paginate(rows, p, func(d db.WebhookDelivery) string {
+		return strconv.FormatUint(d.Pk, 10)
+	})
+
+	data := make([]openapi.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		data = append(data, webhook.Delivery(row))
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data:       data,
+		Pagination: pg,
+	})
+}
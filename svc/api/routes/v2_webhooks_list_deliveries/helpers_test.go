@@ -0,0 +1,73 @@
+package handler_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+)
+
+func authHeaders(rootKey string) http.Header {
+	return http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+}
+
+// seedEndpoint inserts an endpoint directly. The secret is not a real vault
+// ciphertext; none of these routes decrypt it.
+func seedEndpoint(t *testing.T, h *testutil.Harness, workspaceID string, enabled bool) db.WebhookEndpoint {
+	t.Helper()
+	ctx := context.Background()
+
+	params := db.InsertWebhookEndpointParams{
+		ID:              uid.New(uid.WebhookEndpointPrefix),
+		WorkspaceID:     workspaceID,
+		Url:             "https://example.com/hook",
+		Description:     sql.NullString{Valid: false, String: ""},
+		EventTypes:      json.RawMessage(`["key.created"]`),
+		EncryptedSecret: "encrypted",
+		EncryptionKeyID: "key",
+		Enabled:         enabled,
+		CreatedAt:       time.Now().UnixMilli(),
+	}
+	require.NoError(t, db.Query.InsertWebhookEndpoint(ctx, h.DB.RW(), params))
+
+	endpoint, err := db.Query.FindWebhookEndpointByID(ctx, h.DB.RO(), db.FindWebhookEndpointByIDParams{
+		WorkspaceID: workspaceID,
+		ID:          params.ID,
+	})
+	require.NoError(t, err)
+	return endpoint
+}
+
+func seedDelivery(t *testing.T, h *testutil.Harness, endpoint db.WebhookEndpoint) db.WebhookDelivery {
+	t.Helper()
+	ctx := context.Background()
+
+	params := db.InsertWebhookDeliveryParams{
+		ID:          uid.New(uid.WebhookDeliveryPrefix),
+		WorkspaceID: endpoint.WorkspaceID,
+		EndpointID:  endpoint.ID,
+		EventID:     uid.New(uid.WebhookEventPrefix),
+		EventType:   "key.created",
+		Payload:     json.RawMessage(`{"type":"key.created"}`),
+		CreatedAt:   time.Now().UnixMilli(),
+	}
+	require.NoError(t, db.Query.InsertWebhookDelivery(ctx, h.DB.RW(), params))
+
+	delivery, err := db.Query.FindWebhookDeliveryByID(ctx, h.DB.RO(), db.FindWebhookDeliveryByIDParams{
+		WorkspaceID: endpoint.WorkspaceID,
+		ID:          params.ID,
+	})
+	require.NoError(t, err)
+	return delivery
+}
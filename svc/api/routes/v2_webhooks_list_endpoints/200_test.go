@@ -0,0 +1,54 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_webhooks_list_endpoints"
+)
+
+func TestListEndpointsSuccessfully(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{DB: h.DB}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	headers := authHeaders(h.CreateRootKey(workspace.ID, "webhook.*.read_webhook"))
+
+	created := map[string]bool{}
+	for range 3 {
+		created[seedEndpoint(t, h, workspace.ID, true).ID] = true
+	}
+
+	// Endpoints in other workspaces must never show up.
+	otherWorkspace := h.CreateWorkspace()
+	seedEndpoint(t, h, otherWorkspace.ID, true)
+
+	t.Run("pages through all endpoints", func(t *testing.T) {
+		seen := map[string]bool{}
+		var cursor *string
+		for {
+			res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+				Limit:  ptr.P(2),
+				Cursor: cursor,
+			})
+			require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+			require.LessOrEqual(t, len(res.Body.Data), 2)
+
+			for _, endpoint := range res.Body.Data {
+				require.False(t, seen[endpoint.EndpointId], "endpoint returned twice")
+				seen[endpoint.EndpointId] = true
+			}
+
+			if !res.Body.Pagination.HasMore {
+				break
+			}
+			cursor = res.Body.Pagination.Cursor
+		}
+
+		require.Equal(t, created, seen)
+	})
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/pagination"
+	"github.com/unkeyed/unkey/svc/api/internal/webhook"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2WebhooksListEndpointsRequestBody
+	Response = openapi.V2WebhooksListEndpointsResponseBody
+)
+
+type Handler struct {
+	DB db.Database
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/webhooks.listEndpoints"
+}
+
+// Handle lists the workspace's webhook endpoints ordered by id.
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	err = principal.Authorize(rbac.T(rbac.Tuple{
+		ResourceType: rbac.Webhook,
+		ResourceID:   "*",
+		Action:       rbac.ReadWebhook,
+	}))
+	if err != nil {
+		return err
+	}
+
+	p := pagination.Parse(req.Limit, req.Cursor, 50)
+
+	rows, err := db.Query.ListWebhookEndpointsByWorkspace(ctx, h.DB.RO(), db.ListWebhookEndpointsByWorkspaceParams{
+		WorkspaceID: principal.WorkspaceID,
+		IDCursor:    p.Cursor,
+		Limit:       p.FetchLimit(),
+	})
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to list webhook endpoints."),
+		)
+	}
+
+	rows, pg := pagination.Paginate(rows, p, func(e db.WebhookEndpoint) string { return e.ID })
+
+	data := make([]openapi.WebhookEndpoint, 0, len(rows))
+	for _, row := range rows {
+		endpoint, mapErr := webhook.Endpoint(row)
+		if mapErr != nil {
+			return mapErr
+		}
+		data = append(data, endpoint)
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data:       data,
+		Pagination: pg,
+	})
+}
@@ -0,0 +1,41 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_webhooks_replay_delivery"
+)
+
+func TestReplayDeliveryNotFound(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	headers := authHeaders(h.CreateRootKey(workspace.ID, "webhook.*.update_webhook"))
+
+	t.Run("unknown delivery", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, headers, handler.Request{
+			DeliveryId: "whdl_does_not_exist",
+		})
+		require.Equal(t, 404, res.Status, "expected 404, received: %s", res.RawBody)
+		require.Equal(t, "https://unkey.com/docs/errors/unkey/data/webhook_delivery_not_found", res.Body.Error.Type)
+	})
+
+	t.Run("delivery in another workspace", func(t *testing.T) {
+		delivery := seedDelivery(t, h, seedEndpoint(t, h, h.CreateWorkspace().ID, true))
+
+		res := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, headers, handler.Request{
+			DeliveryId: delivery.ID,
+		})
+		require.Equal(t, 404, res.Status, "expected 404, received: %s", res.RawBody)
+		require.Equal(t, "https://unkey.com/docs/errors/unkey/data/webhook_delivery_not_found", res.Body.Error.Type)
+	})
+}
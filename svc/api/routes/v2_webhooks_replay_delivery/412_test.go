@@ -0,0 +1,30 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_webhooks_replay_delivery"
+)
+
+func TestReplayDeliveryToDisabledEndpoint(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	headers := authHeaders(h.CreateRootKey(workspace.ID, "webhook.*.update_webhook"))
+
+	delivery := seedDelivery(t, h, seedEndpoint(t, h, workspace.ID, false))
+
+	res := testutil.CallRoute[handler.Request, openapi.PreconditionFailedErrorResponse](h, route, headers, handler.Request{
+		DeliveryId: delivery.ID,
+	})
+	require.Equal(t, 412, res.Status, "expected 412, received: %s", res.RawBody)
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	restateingress "github.com/restatedev/sdk-go/ingress"
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/pkg/auditlog"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/auditactor"
+	"github.com/unkeyed/unkey/svc/api/internal/webhook"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2WebhooksReplayDeliveryRequestBody
+	Response = openapi.V2WebhooksReplayDeliveryResponseBody
+)
+
+type Handler struct {
+	DB        db.Database
+	Auditlogs auditlogs.AuditLogService
+	Restate   *restateingress.Client
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/webhooks.replayDelivery"
+}
+
+// Handle copies a past delivery into a new one and hands it to the ctrl
+// worker. The copy gets a new delivery id and therefore a new webhook-id
+// header, but the payload and its event id are unchanged.
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	original, err := db.Query.FindWebhookDeliveryByID(ctx, h.DB.RO(), db.FindWebhookDeliveryByIDParams{
+		WorkspaceID: principal.WorkspaceID,
+		ID:          req.DeliveryId,
+	})
+	if err != nil {
+		if db.IsNotFound(err) {
+			return fault.Wrap(err,
+				fault.Code(codes.Data.WebhookDelivery.NotFound.URN()),
+				fault.Internal("webhook delivery not found"),
+				fault.Public("The requested webhook delivery does not exist."),
+			)
+		}
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database error"),
+			fault.Public("Failed to retrieve webhook delivery."),
+		)
+	}
+
+	err = principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Webhook,
+			ResourceID:   "*",
+			Action:       rbac.UpdateWebhook,
+		}),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Webhook,
+			ResourceID:   original.EndpointID,
+			Action:       rbac.UpdateWebhook,
+		}),
+	))
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := webhook.FindEndpoint(ctx, h.DB.RO(), principal.WorkspaceID, original.EndpointID)
+	if err != nil {
+		return err
+	}
+	if !endpoint.Enabled {
+		return fault.New("webhook endpoint disabled",
+			fault.Code(codes.App.Precondition.PreconditionFailed.URN()),
+			fault.Internal("replay to a disabled webhook endpoint"),
+			fault.Public("The webhook endpoint is disabled. Enable it before replaying deliveries."),
+		)
+	}
+
+	actor := auditactor.FromPrincipal(principal)
+	deliveryID := uid.New(uid.WebhookDeliveryPrefix)
+
+	err = db.TxRetry(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) error {
+		txErr := db.Query.InsertWebhookDelivery(ctx, tx, db.InsertWebhookDeliveryParams{
+			ID:          deliveryID,
+			WorkspaceID: principal.WorkspaceID,
+			EndpointID:  original.EndpointID,
+			EventID:     original.EventID,
+			EventType:   original.EventType,
+			Payload:     original.Payload,
+			CreatedAt:   time.Now().UnixMilli(),
+		})
+		if txErr != nil {
+			return fault.Wrap(txErr,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database error"),
+				fault.Public("Failed to replay webhook delivery."),
+			)
+		}
+
+		return h.Auditlogs.Insert(ctx, tx, []auditlog.AuditLog{
+			{
+				WorkspaceID:   principal.WorkspaceID,
+				Event:         auditlog.WebhookReplayDeliveryEvent,
+				ActorType:     actor.Type,
+				ActorID:       actor.ID,
+				ActorName:     actor.Name,
+				ActorMeta:     actor.Meta,
+				Display:       fmt.Sprintf("Replayed webhook delivery %s as %s", original.ID, deliveryID),
+				RemoteIP:      s.Location(),
+				UserAgent:     s.UserAgent(),
+				CorrelationID: "",
+				Resources: []auditlog.AuditLogResource{
+					{
+						Type:        auditlog.WebhookDeliveryResourceType,
+						ID:          deliveryID,
+						Name:        original.EventType,
+						DisplayName: original.EventType,
+						Meta:        map[string]any{"replayOf": original.ID},
+					},
+					{
+						Type:        auditlog.WebhookEndpointResourceType,
+						ID:          endpoint.ID,
+						Name:        endpoint.Url,
+						DisplayName: endpoint.Url,
+						Meta:        map[string]any{},
+					},
+				},
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// If Restate rejects the send the new row stays pending without attempts
+	// and shows up as such in the delivery log; replaying again is safe.
+	_, err = hydrav1.NewWebhookServiceIngressClient(h.Restate).
+		Deliver().
+		Send(ctx, &hydrav1.DeliverWebhookRequest{DeliveryId: deliveryID}, restate.WithIdempotencyKey(deliveryID))
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("failed to submit webhook delivery to Restate"),
+			fault.Public("Failed to replay webhook delivery."),
+		)
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.V2WebhooksReplayDeliveryResponseData{
+			DeliveryId: deliveryID,
+		},
+	})
+}
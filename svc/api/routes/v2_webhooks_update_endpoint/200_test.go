@@ -0,0 +1,54 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_webhooks_update_endpoint"
+)
+
+func TestUpdateEndpointSuccessfully(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+
+	t.Run("omitted fields are kept", func(t *testing.T) {
+		endpoint := seedEndpoint(t, h, workspace.ID, true)
+		headers := authHeaders(h.CreateRootKey(workspace.ID, "webhook.*.update_webhook"))
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			EndpointId: endpoint.ID,
+			Enabled:    ptr.P(false),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.False(t, res.Body.Data.Enabled)
+		require.Equal(t, endpoint.Url, res.Body.Data.Url)
+		require.Equal(t, []openapi.WebhookEventType{openapi.WebhookEventTypeKeyCreated}, res.Body.Data.EventTypes)
+	})
+
+	t.Run("permission scoped to the endpoint", func(t *testing.T) {
+		endpoint := seedEndpoint(t, h, workspace.ID, true)
+		headers := authHeaders(h.CreateRootKey(workspace.ID, "webhook."+endpoint.ID+".update_webhook"))
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			EndpointId:  endpoint.ID,
+			Url:         ptr.P("https://example.org/new"),
+			Description: ptr.P("billing"),
+			EventTypes:  &[]openapi.WebhookEventType{openapi.WebhookEventTypeKeyDeleted},
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.Equal(t, "https://example.org/new", res.Body.Data.Url)
+		require.Equal(t, ptr.P("billing"), res.Body.Data.Description)
+		require.Equal(t, []openapi.WebhookEventType{openapi.WebhookEventTypeKeyDeleted}, res.Body.Data.EventTypes)
+		require.True(t, res.Body.Data.Enabled)
+	})
+}
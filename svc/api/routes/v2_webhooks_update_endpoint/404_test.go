@@ -0,0 +1,44 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/api/internal/testutil"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+	handler "github.com/unkeyed/unkey/svc/api/routes/v2_webhooks_update_endpoint"
+)
+
+func TestUpdateEndpointNotFound(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:        h.DB,
+		Auditlogs: h.Auditlogs,
+	}
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	headers := authHeaders(h.CreateRootKey(workspace.ID, "webhook.*.update_webhook"))
+
+	t.Run("unknown endpoint", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, headers, handler.Request{
+			EndpointId: "whep_does_not_exist",
+			Enabled:    ptr.P(false),
+		})
+		require.Equal(t, 404, res.Status, "expected 404, received: %s", res.RawBody)
+		require.Equal(t, "https://unkey.com/docs/errors/unkey/data/webhook_endpoint_not_found", res.Body.Error.Type)
+	})
+
+	t.Run("endpoint in another workspace", func(t *testing.T) {
+		endpoint := seedEndpoint(t, h, h.CreateWorkspace().ID, true)
+
+		res := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, headers, handler.Request{
+			EndpointId: endpoint.ID,
+			Enabled:    ptr.P(false),
+		})
+		require.Equal(t, 404, res.Status, "expected 404, received: %s", res.RawBody)
+		require.Equal(t, "https://unkey.com/docs/errors/unkey/data/webhook_endpoint_not_found", res.Body.Error.Type)
+	})
+}
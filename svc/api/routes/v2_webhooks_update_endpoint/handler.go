@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/unkeyed/unkey/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/pkg/auditlog"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/rbac"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/api/internal/auditactor"
+	"github.com/unkeyed/unkey/svc/api/internal/webhook"
+	"github.com/unkeyed/unkey/svc/api/openapi"
+)
+
+type (
+	Request  = openapi.V2WebhooksUpdateEndpointRequestBody
+	Response = openapi.V2WebhooksUpdateEndpointResponseBody
+)
+
+type Handler struct {
+	DB        db.Database
+	Auditlogs auditlogs.AuditLogService
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/webhooks.updateEndpoint"
+}
+
+// Handle applies the provided fields to an endpoint; omitted fields keep their
+// current value. Deliveries already queued pick the change up on their next
+// attempt because the worker reads the endpoint fresh every time.
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	principal, err := s.GetPrincipal()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	err = principal.Authorize(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Webhook,
+			ResourceID:   "*",
+			Action:       rbac.UpdateWebhook,
+		}),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Webhook,
+			ResourceID:   req.EndpointId,
+			Action:       rbac.UpdateWebhook,
+		}),
+	))
+	if err != nil {
+		return err
+	}
+
+	if req.Url != nil {
+		if err = webhook.ValidateURL(*req.Url); err != nil {
+			return err
+		}
+	}
+
+	actor := auditactor.FromPrincipal(principal)
+
+	updated, err := db.TxWithResultRetry(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) (db.WebhookEndpoint, error) {
+		endpoint, txErr := webhook.FindEndpoint(ctx, tx, principal.WorkspaceID, req.EndpointId)
+		if txErr != nil {
+			return db.WebhookEndpoint{}, txErr
+		}
+
+		if req.Url != nil {
+			endpoint.Url = *req.Url
+		}
+		if req.Description != nil {
+			endpoint.Description = sql.NullString{Valid: true, String: *req.Description}
+		}
+		if req.EventTypes != nil {
+			endpoint.EventTypes, txErr = webhook.MarshalEventTypes(*req.EventTypes)
+			if txErr != nil {
+				return db.WebhookEndpoint{}, txErr
+			}
+		}
+		if req.Enabled != nil {
+			endpoint.Enabled = *req.Enabled
+		}
+		endpoint.UpdatedAt = sql.NullInt64{Valid: true, Int64: time.Now().UnixMilli()}
+
+		txErr = db.Query.UpdateWebhookEndpoint(ctx, tx, db.UpdateWebhookEndpointParams{
+			Url:         endpoint.Url,
+			Description: endpoint.Description,
+			EventTypes:  endpoint.EventTypes,
+			Enabled:     endpoint.Enabled,
+			UpdatedAt:   endpoint.UpdatedAt,
+			ID:          endpoint.ID,
+		})
+		if txErr != nil {
+			return db.WebhookEndpoint{}, fault.Wrap(txErr,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database error"),
+				fault.Public("Failed to update webhook endpoint."),
+			)
+		}
+
+		txErr = h.Auditlogs.Insert(ctx, tx, []auditlog.AuditLog{
+			{
+				WorkspaceID:   principal.WorkspaceID,
+				Event:         auditlog.WebhookUpdateEvent,
+				ActorType:     actor.Type,
+				ActorID:       actor.ID,
+				ActorName:     actor.Name,
+				ActorMeta:     actor.Meta,
+				Display:       fmt.Sprintf("Updated webhook endpoint %s", endpoint.ID),
+				RemoteIP:      s.Location(),
+				UserAgent:     s.UserAgent(),
+				CorrelationID: "",
+				Resources: []auditlog.AuditLogResource{
+					{
+						Type:        auditlog.WebhookEndpointResourceType,
+						ID:          endpoint.ID,
+						Name:        endpoint.Url,
+						DisplayName: endpoint.Url,
+						Meta:        map[string]any{},
+					},
+				},
+			},
+		})
+		if txErr != nil {
+			return db.WebhookEndpoint{}, txErr
+		}
+
+		return endpoint, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := webhook.Endpoint(updated)
+	if err != nil {
+		return err
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: data,
+	})
+}
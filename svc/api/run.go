@@ -27,6 +27,7 @@ import (
 	promclient "github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/unkeyed/unkey/internal/services/usagelimiter"
+	"github.com/unkeyed/unkey/internal/services/webhooks"
 	"github.com/unkeyed/unkey/pkg/auth"
 	authjwt "github.com/unkeyed/unkey/pkg/auth/jwt"
 	portalsession "github.com/unkeyed/unkey/pkg/auth/portal_session"
@@ -55,6 +56,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/uid"
 	"github.com/unkeyed/unkey/pkg/zen"
 	"github.com/unkeyed/unkey/pkg/zen/validation"
+	"github.com/unkeyed/unkey/svc/api/internal/idempotency"
 	"github.com/unkeyed/unkey/svc/api/openapi"
 	"github.com/unkeyed/unkey/svc/api/routes"
 )
@@ -179,6 +181,18 @@ func Run(ctx context.Context, cfg Config) error {
 		}
 		ch = chClient
 
+		var spool *clickhouse.SpoolConfig
+		if cfg.ClickHouse.SpoolDir != "" {
+			spool = &clickhouse.SpoolConfig{
+				Dir:             cfg.ClickHouse.SpoolDir,
+				MaxSegmentBytes: 0,
+				MaxBytes:        cfg.ClickHouse.SpoolMaxBytes,
+				Fsync:           clickhouse.FsyncPolicy(cfg.ClickHouse.SpoolFsync),
+				FsyncInterval:   0,
+				ReplayInterval:  0,
+			}
+		}
+
 		apiRequests = clickhouse.NewBuffer[schema.ApiRequest](chClient, clickhouse.BufferConfig{
 			Name:          "api_requests",
 			BatchSize:     10_000,
@@ -187,6 +201,7 @@ func Run(ctx context.Context, cfg Config) error {
 			Consumers:     2,
 			Drop:          true,
 			OnFlushError:  nil,
+			Spool:         spool,
 		})
 		keyVerifications = clickhouse.NewBuffer[schema.KeyVerification](chClient, clickhouse.BufferConfig{
 			Name:          "key_verifications",
@@ -196,6 +211,7 @@ func Run(ctx context.Context, cfg Config) error {
 			Consumers:     2,
 			Drop:          true,
 			OnFlushError:  nil,
+			Spool:         spool,
 		})
 		ratelimits = clickhouse.NewBuffer[schema.Ratelimit](chClient, clickhouse.BufferConfig{
 			Name:          "ratelimits",
@@ -205,6 +221,7 @@ func Run(ctx context.Context, cfg Config) error {
 			Consumers:     2,
 			Drop:          true,
 			OnFlushError:  nil,
+			Spool:         spool,
 		})
 
 		// Close buffers before connection (LIFO)
@@ -292,6 +309,36 @@ func Run(ctx context.Context, cfg Config) error {
 				Credits: sql.NullInt64{Int64: cost, Valid: true},
 			})
 		},
+		IncrementKeyCredits: func(ctx context.Context, keyID string, credits int64) error {
+			return db.Query.UpdateKeyCreditsIncrement(ctx, database.RW(), db.UpdateKeyCreditsIncrementParams{
+				ID:      keyID,
+				Credits: sql.NullInt64{Int64: credits, Valid: true},
+			})
+		},
+		FindIdentityCredits: func(ctx context.Context, identityID string) (int64, bool, error) {
+			credits, err := db.WithRetryContext(ctx, func() (db.IdentityCredit, error) {
+				return db.Query.FindIdentityCredits(ctx, database.RO(), identityID)
+			})
+			if db.IsNotFound(err) {
+				return 0, false, nil
+			}
+			if err != nil {
+				return 0, false, err
+			}
+			return int64(credits.Remaining), true, nil // nolint:gosec // balances never approach MaxInt64
+		},
+		DecrementIdentityCredits: func(ctx context.Context, identityID string, cost int64) error {
+			return db.Query.UpdateIdentityCreditsDecrement(ctx, database.RW(), db.UpdateIdentityCreditsDecrementParams{
+				IdentityID: identityID,
+				Credits:    uint64(cost), // nolint:gosec // the usage limiter never passes a negative cost
+			})
+		},
+		IncrementIdentityCredits: func(ctx context.Context, identityID string, credits int64) error {
+			return db.Query.UpdateIdentityCreditsIncrement(ctx, database.RW(), db.UpdateIdentityCreditsIncrementParams{
+				IdentityID: identityID,
+				Credits:    uint64(credits), // nolint:gosec // refunds are never negative
+			})
+		},
 		Counter: ctr,
 		TTL:     60 * time.Second,
 	})
@@ -317,22 +364,64 @@ func Run(ctx context.Context, cfg Config) error {
 		return fmt.Errorf("unable to create auditlogs service: %w", err)
 	}
 
+	// Cache invalidations travel between nodes over the Redis the counters
+	// use. Tests that replace the counter run without a Redis URL and
+	// therefore only invalidate their own node.
+	var broadcaster cachesvc.Broadcaster
+	var redisBroadcaster *cachesvc.RedisBroadcaster
+	if cfg.RedisURL != "" {
+		redisBroadcaster, err = cachesvc.NewRedisBroadcaster(cachesvc.RedisBroadcasterConfig{
+			RedisURL: cfg.RedisURL,
+			Channel:  "",
+		})
+		if err != nil {
+			return fmt.Errorf("unable to create cache invalidation broadcaster: %w", err)
+		}
+		r.Defer(redisBroadcaster.Close)
+		broadcaster = redisBroadcaster
+	}
+
 	caches, err := cachesvc.New(cachesvc.Config{
-		Clock:  clk,
-		NodeID: cfg.InstanceID,
+		Clock:       clk,
+		NodeID:      cfg.InstanceID,
+		Broadcaster: broadcaster,
 	})
 	if err != nil {
 		return fmt.Errorf("unable to create caches: %w", err)
 	}
 
+	if redisBroadcaster != nil {
+		r.Go(func(ctx context.Context) error {
+			return redisBroadcaster.Listen(ctx, caches.Invalidations.HandleEvent)
+		})
+	}
+
+	restateClient := restateingress.NewClient(
+		cfg.Restate.URL,
+		restate.WithAuthKey(cfg.Restate.APIKey),
+		restate.WithHttpClient(&http.Client{Timeout: 30 * time.Second}),
+	)
+
+	webhookSvc, err := webhooks.New(webhooks.Config{
+		Restate: restateClient,
+		Clock:   clk,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create webhooks service: %w", err)
+	}
+	r.Defer(webhookSvc.Close)
+
 	keySvc, err := keys.New(keys.Config{
-		DB:           db.ToMySQL(database),
-		KeyCache:     caches.VerificationKeyByHash,
-		RateLimiter:  rlSvc,
-		RBAC:         rbac.New(),
-		Region:       cfg.Region,
-		UsageLimiter: ulSvc,
-		Source:       schema.SourceAPI,
+		DB:               db.ToMySQL(database),
+		KeyCache:         caches.VerificationKeyByHash,
+		JWTConfigCache:   caches.JwtConfigsByIssuer,
+		JWTIdentityCache: caches.JwtIdentityByExternalID,
+		RateLimiter:      rlSvc,
+		RBAC:             rbac.New(),
+		Region:           cfg.Region,
+		UsageLimiter:     ulSvc,
+		Source:           schema.SourceAPI,
+		Webhooks:         webhookSvc,
 	})
 	if err != nil {
 		return fmt.Errorf("unable to create key service: %w", err)
@@ -469,12 +558,6 @@ func Run(ctx context.Context, cfg Config) error {
 		),
 	)
 
-	restateClient := restateingress.NewClient(
-		cfg.Restate.URL,
-		restate.WithAuthKey(cfg.Restate.APIKey),
-		restate.WithHttpClient(&http.Client{Timeout: 30 * time.Second}),
-	)
-
 	logger.Info("Control plane clients initialized", "url", cfg.Control.URL)
 
 	pprofEnabled := cfg.Pprof != nil && cfg.Pprof.Username != "" && cfg.Pprof.Password != ""
@@ -502,6 +585,15 @@ func Run(ctx context.Context, cfg Config) error {
 		githubClient = ghc
 	}
 
+	// Responses that carry a secret are only stored vault-encrypted. Without a
+	// vault their routes do not take an Idempotency-Key.
+	var sealedIdempotency zen.IdempotencyStore
+	if vaultClient != nil {
+		sealedIdempotency = idempotency.NewSealed(database, clk, vaultClient)
+	} else {
+		logger.Warn("Idempotency-Key disabled on routes that return secrets: vault not configured")
+	}
+
 	routes.Register(srv, &routes.Services{
 		Database:             database,
 		ClickHouse:           ch,
@@ -511,6 +603,9 @@ func Run(ctx context.Context, cfg Config) error {
 		Keys:                 keySvc,
 		Auth:                 authSvc,
 		PortalAuth:           portalAuthSvc,
+		Idempotency:          idempotency.New(database, clk),
+		SealedIdempotency:    sealedIdempotency,
+		IdempotencyTTL:       cfg.IdempotencyTTL,
 		Validator:            validator,
 		Redactor:             redactor,
 		Ratelimit:            rlSvc,
@@ -521,6 +616,7 @@ func Run(ctx context.Context, cfg Config) error {
 		CtrlProjectClient:    ctrlProjectClient,
 		CtrlAppClient:        ctrlAppClient,
 		Restate:              restateClient,
+		Webhooks:             webhookSvc,
 
 		CtrlCustomDomainClient: ctrlCustomDomainClient,
 		PprofEnabled:           pprofEnabled,
@@ -146,7 +146,8 @@ func Run(ctx context.Context, cfg Config) error {
 	// Set up the ClickHouse buffer that absorbs container lifecycle events
 	// reported by krane. Falls back to a noop when no URL is configured so
 	// the api still serves traffic in environments without ClickHouse: the
-	// dashboard's events panel will simply be empty.
+	// dashboard's events panel will simply be empty, and request-rate
+	// autoscaling holds deployments at their policy minimum.
 	//
 	// When a URL *is* configured but the client fails to construct, we fail
 	// the boot — same fail-fast policy every other configured backend uses
@@ -155,6 +156,7 @@ func Run(ctx context.Context, cfg Config) error {
 	// the lifetime of the process and the failure would be invisible until
 	// someone notices the dashboard is empty.
 	instanceEvents := batch.NewNoop[schema.InstanceEventV1]()
+	var requestRates clickhouse.Querier = clickhouse.NewNoop()
 	if cfg.ClickHouse.URL != "" {
 		chClient, chErr := clickhouse.New(clickhouse.Config{URL: cfg.ClickHouse.URL})
 		if chErr != nil {
@@ -168,9 +170,11 @@ func Run(ctx context.Context, cfg Config) error {
 			Consumers:     1,
 			Drop:          true,
 			OnFlushError:  nil,
+			Spool:         nil,
 		})
 		r.Defer(func() error { instanceEvents.Close(); return nil })
 		r.Defer(chClient.Close)
+		requestRates = chClient
 	}
 
 	c, err := cluster.New(cluster.Config{
@@ -180,6 +184,7 @@ func Run(ctx context.Context, cfg Config) error {
 		Clock:          clk,
 		TopologyCache:  topologyCache,
 		InstanceEvents: instanceEvents,
+		ClickHouse:     requestRates,
 		RegionalDomain: cfg.RegionalDomain,
 	})
 	if err != nil {
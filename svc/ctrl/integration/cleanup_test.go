@@ -123,6 +123,7 @@ func TestProjectDeletion_CleansUpAllData(t *testing.T) {
 		AutoscalingReplicasMax:     1,
 		AutoscalingThresholdCpu:    sql.NullInt16{Valid: false},
 		AutoscalingThresholdMemory: sql.NullInt16{Valid: false},
+		AutoscalingThresholdRps:    sql.NullInt16{Valid: false},
 		DesiredStatus:              db.DeploymentTopologyDesiredStatusRunning,
 		CreatedAt:                  now,
 	})
@@ -22,6 +22,9 @@ type Harness struct {
 	ctx  context.Context
 	Seed *seed.Seeder
 	DB   db.Database
+	// DSN connects to the same database, for services that open their own
+	// connection.
+	DSN string
 }
 
 // New creates a new integration test harness.
@@ -44,6 +47,7 @@ func New(t *testing.T) *Harness {
 		ctx:  ctx,
 		Seed: seed.New(t, database, nil),
 		DB:   database,
+		DSN:  mysqlHostDSN,
 	}
 
 	h.Seed.Seed(ctx)
@@ -190,6 +194,7 @@ func (h *Harness) CreateDeployment(ctx context.Context, req CreateDeploymentRequ
 		AutoscalingReplicasMax:     1,
 		AutoscalingThresholdCpu:    sql.NullInt16{Valid: false},
 		AutoscalingThresholdMemory: sql.NullInt16{Valid: false},
+		AutoscalingThresholdRps:    sql.NullInt16{Valid: false},
 		DesiredStatus:              db.DeploymentTopologyDesiredStatusRunning,
 		CreatedAt:                  h.Now(),
 	})
@@ -209,6 +214,7 @@ func (h *Harness) CreateDeployment(ctx context.Context, req CreateDeploymentRequ
 			AutoscalingReplicasMax:     1,
 			AutoscalingThresholdCpu:    sql.NullInt16{Valid: false},
 			AutoscalingThresholdMemory: sql.NullInt16{Valid: false},
+			AutoscalingThresholdRps:    sql.NullInt16{Valid: false},
 			DesiredStatus:              db.DeploymentTopologyDesiredStatusRunning,
 			CreatedAt:                  h.Now(),
 			UpdatedAt:                  sql.NullInt64{Valid: false},
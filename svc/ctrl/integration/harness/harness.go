@@ -217,6 +217,8 @@ func New(t *testing.T, opts ...Option) *Harness {
 		Clickhouse:                chClient,
 		Clock:                     o.clock,
 		RatelimitDB:               ratelimitdb.New(database.RW(), database.RO()),
+		Vault:                     vaultClient,
+		Caches:                    nil,
 		SlackQuotaCheckWebhookURL: "",
 		// Deploy billing is a no-op by default (nil reader + empty Stripe key);
 		// WithDeployBilling injects fakes for tests that exercise the push/close.
@@ -232,9 +234,12 @@ func New(t *testing.T, opts ...Option) *Harness {
 		Heartbeats: cron.Heartbeats{
 			QuotaCheck:         healthcheck.NewNoop(),
 			KeyRefill:          healthcheck.NewNoop(),
+			KeyRotation:        healthcheck.NewNoop(),
+			KeyExpiry:          healthcheck.NewNoop(),
 			KeyLastUsedSync:    healthcheck.NewNoop(),
 			AuditLogExport:     healthcheck.NewNoop(),
 			AuditLogCleanup:    healthcheck.NewNoop(),
+			IdempotencyCleanup: healthcheck.NewNoop(),
 			RatelimitCleanup:   healthcheck.NewNoop(),
 			DeployBillingPush:  healthcheck.NewNoop(),
 			DeployBillingClose: healthcheck.NewNoop(),
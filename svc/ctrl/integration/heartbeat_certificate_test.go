@@ -13,6 +13,7 @@ import (
 	ctrlv1 "github.com/unkeyed/unkey/gen/proto/ctrl/v1"
 	"github.com/unkeyed/unkey/pkg/batch"
 	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/clickhouse"
 	"github.com/unkeyed/unkey/pkg/clickhouse/schema"
 	"github.com/unkeyed/unkey/pkg/clock"
 	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
@@ -126,6 +127,7 @@ func newHeartbeatService(t *testing.T, database db.Database, bearer, regionalDom
 		Clock:          clock.New(),
 		TopologyCache:  topologyCache,
 		InstanceEvents: batch.NewNoop[schema.InstanceEventV1](),
+		ClickHouse:     clickhouse.NewNoop(),
 		RegionalDomain: regionalDomain,
 	})
 	require.NoError(t, err)
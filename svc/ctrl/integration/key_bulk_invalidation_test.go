@@ -0,0 +1,114 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	restatetest "github.com/restatedev/sdk-go/testing"
+	"github.com/stretchr/testify/require"
+	cachev1 "github.com/unkeyed/unkey/gen/proto/cache/v1"
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/internal/services/caches"
+	"github.com/unkeyed/unkey/internal/services/keys"
+	"github.com/unkeyed/unkey/pkg/clock"
+	"github.com/unkeyed/unkey/pkg/hash"
+	"github.com/unkeyed/unkey/pkg/mysql"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/ctrl/integration/seed"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/auditlogs"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
+	"github.com/unkeyed/unkey/svc/ctrl/worker/keybulk"
+)
+
+// apiNode delivers the worker's invalidations straight to an API node's
+// caches, standing in for the Redis channel between them.
+type apiNode struct {
+	caches caches.Caches
+}
+
+func (n apiNode) Broadcast(ctx context.Context, event *cachev1.CacheInvalidationEvent) error {
+	n.caches.Invalidations.HandleEvent(ctx, event)
+	return nil
+}
+
+// TestKeyBulkDelete_FailsVerificationImmediately verifies that a key deleted by
+// a bulk operation stops verifying on an API node that has it cached, without
+// waiting for the cache entry to go stale.
+func TestKeyBulkDelete_FailsVerificationImmediately(t *testing.T) {
+	h := New(t)
+	ctx := h.Context()
+
+	workspaceID := h.Seed.Resources.UserWorkspace.ID
+	api := h.Seed.CreateAPI(ctx, seed.CreateApiRequest{WorkspaceID: workspaceID})
+	key := h.Seed.CreateKey(ctx, seed.CreateKeyRequest{
+		WorkspaceID: workspaceID,
+		KeySpaceID:  api.KeyAuthID.String,
+	})
+	keyHash := hash.Sha256(key.Key)
+
+	// The API node: its own caches in front of the keys service.
+	node, err := caches.New(caches.Config{Clock: clock.New(), NodeID: "api", Broadcaster: nil})
+	require.NoError(t, err)
+
+	mysqlDB, err := mysql.New(mysql.Config{PrimaryDSN: h.DSN})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, mysqlDB.Close()) })
+
+	keySvc, err := keys.New(keys.Config{
+		DB:       mysqlDB,
+		Region:   "test",
+		Source:   "api",
+		KeyCache: node.VerificationKeyByHash,
+	})
+	require.NoError(t, err)
+
+	// Warm the node's cache with the live key.
+	kv, err := keySvc.Get(ctx, nil, keyHash)
+	require.NoError(t, err)
+	require.Equal(t, keys.StatusValid, kv.Status)
+
+	// The worker: no caches of its own, only the broadcast to the API node.
+	invalidations, err := caches.NewRemoteInvalidator(caches.RemoteInvalidatorConfig{
+		Clock:       clock.New(),
+		NodeID:      "ctrl-worker",
+		Broadcaster: apiNode{caches: node},
+	})
+	require.NoError(t, err)
+
+	auditSvc, err := auditlogs.New(auditlogs.Config{DB: h.DB})
+	require.NoError(t, err)
+
+	keyBulkSvc, err := keybulk.New(keybulk.Config{
+		DB:        h.DB,
+		Auditlogs: auditSvc,
+		Caches:    invalidations,
+		Webhooks:  false,
+	})
+	require.NoError(t, err)
+
+	tEnv := restatetest.Start(t, hydrav1.NewKeyBulkServiceServer(keyBulkSvc))
+
+	operationID := uid.New("kbo")
+	err = h.DB.InsertKeyBulkOperationItem(ctx, db.InsertKeyBulkOperationItemParams{
+		OperationID: operationID,
+		KeyID:       key.KeyID,
+	})
+	require.NoError(t, err)
+
+	res, err := hydrav1.NewKeyBulkServiceIngressClient(tEnv.Ingress(), operationID).Run().Request(ctx, &hydrav1.RunKeyBulkOperationRequest{
+		WorkspaceId: workspaceID,
+		ApiId:       api.ID,
+		KeyAuthId:   api.KeyAuthID.String,
+		Operation: &hydrav1.RunKeyBulkOperationRequest_Delete{
+			Delete: &hydrav1.KeyBulkDelete{},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), res.GetSucceeded())
+
+	kv, err = keySvc.Get(ctx, nil, keyHash)
+	require.NoError(t, err)
+	require.Equal(t, keys.StatusNotFound, kv.Status)
+}
@@ -36,6 +36,7 @@ func (s *ClickHouseSeeder) InsertVerifications(ctx context.Context, workspaceID
 			verifications[j] = schema.KeyVerification{
 				Source:       schema.SourceAPI,
 				AppID:        "",
+				Environment:  "",
 				RequestID:    uid.New(uid.RequestPrefix),
 				Time:         timestamp.Add(time.Duration(i+j) * time.Millisecond).UnixMilli(),
 				WorkspaceID:  workspaceID,
@@ -223,6 +224,7 @@ func (s *ClickHouseSeeder) insertVerificationsForKeyChunk(
 				v := schema.KeyVerification{
 					Source:       schema.SourceAPI,
 					AppID:        "",
+					Environment:  "",
 					RequestID:    fmt.Sprintf("perf-%d-%d", i+keyIdx, j),
 					Time:         baseTime + int64(j),
 					WorkspaceID:  workspaceID,
@@ -284,6 +286,7 @@ func (s *ClickHouseSeeder) InsertFrontlineRequests(ctx context.Context, workspac
 				TotalLatency:    10,
 				InstanceLatency: 8,
 				GatewayLatency:  2,
+				FirewallMatches: []string{},
 			}
 		}
 
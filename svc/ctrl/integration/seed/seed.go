@@ -474,6 +474,7 @@ func (s *Seeder) CreateRootKey(ctx context.Context, workspaceID string, permissi
 		RefillDay:          sql.NullInt16{Int16: 0, Valid: false},
 		RefillAmount:       sql.NullInt64{Int64: 0, Valid: false},
 		PendingMigrationID: sql.NullString{Valid: false, String: ""},
+		Environment:        sql.NullString{Valid: false, String: ""},
 	}
 
 	err := s.DB.InsertKey(ctx, insertKeyParams)
@@ -573,6 +574,7 @@ func (s *Seeder) CreateKey(ctx context.Context, req CreateKeyRequest) CreateKeyR
 		RefillAmount:       sql.NullInt64{Int64: ptr.SafeDeref(req.RefillAmount, 0), Valid: req.RefillAmount != nil},
 		RefillDay:          sql.NullInt16{Int16: ptr.SafeDeref(req.RefillDay, 0), Valid: req.RefillDay != nil},
 		PendingMigrationID: sql.NullString{Valid: false, String: ""},
+		Environment:        sql.NullString{Valid: false, String: ""},
 	})
 	require.NoError(s.t, err)
 
@@ -843,6 +845,7 @@ func (s *Seeder) CreatePermission(ctx context.Context, req CreatePermissionReque
 		Name:        req.Name,
 		Slug:        req.Slug,
 		Description: dbtype.NullString{Valid: req.Description != nil, String: ptr.SafeDeref(req.Description, "")},
+		Conditions:  nil,
 		CreatedAtM:  createdAt,
 		UpdatedAtM:  sql.NullInt64{Valid: false, Int64: 0},
 	}
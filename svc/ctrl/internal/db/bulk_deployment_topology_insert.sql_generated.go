@@ -9,7 +9,7 @@ import (
 )
 
 // bulkInsertDeploymentTopology is the base query for bulk insert
-const bulkInsertDeploymentTopology = `INSERT INTO ` + "`" + `deployment_topology` + "`" + ` ( workspace_id, deployment_id, region_id, autoscaling_replicas_min, autoscaling_replicas_max, autoscaling_threshold_cpu, autoscaling_threshold_memory, desired_status, created_at ) VALUES %s`
+const bulkInsertDeploymentTopology = `INSERT INTO ` + "`" + `deployment_topology` + "`" + ` ( workspace_id, deployment_id, region_id, autoscaling_replicas_min, autoscaling_replicas_max, autoscaling_threshold_cpu, autoscaling_threshold_memory, autoscaling_threshold_rps, desired_status, created_at ) VALUES %s`
 
 // InsertDeploymentTopologies performs bulk insert in a single query
 
@@ -22,7 +22,7 @@ func (q *BulkQueries) InsertDeploymentTopologies(ctx context.Context, args []Ins
 	// Build the bulk insert query
 	valueClauses := make([]string, len(args))
 	for i := range args {
-		valueClauses[i] = "( ?, ?, ?, ?, ?, ?, ?, ?, ? )"
+		valueClauses[i] = "( ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )"
 	}
 
 	bulkQuery := fmt.Sprintf(bulkInsertDeploymentTopology, strings.Join(valueClauses, ", "))
@@ -37,6 +37,7 @@ func (q *BulkQueries) InsertDeploymentTopologies(ctx context.Context, args []Ins
 		allArgs = append(allArgs, arg.AutoscalingReplicasMax)
 		allArgs = append(allArgs, arg.AutoscalingThresholdCpu)
 		allArgs = append(allArgs, arg.AutoscalingThresholdMemory)
+		allArgs = append(allArgs, arg.AutoscalingThresholdRps)
 		allArgs = append(allArgs, arg.DesiredStatus)
 		allArgs = append(allArgs, arg.CreatedAt)
 	}
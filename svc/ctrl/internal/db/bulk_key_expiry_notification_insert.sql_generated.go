@@ -0,0 +1,43 @@
+// Code generated by sqlc bulk insert plugin. DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// bulkInsertKeyExpiryNotification is the base query for bulk insert
+const bulkInsertKeyExpiryNotification = `INSERT INTO key_expiry_notifications ( key_id, workspace_id, threshold_ms, expires, created_at_m ) VALUES %s ON DUPLICATE KEY UPDATE pk = pk`
+
+// InsertKeyExpiryNotifications performs bulk insert in a single query
+
+func (q *BulkQueries) InsertKeyExpiryNotifications(ctx context.Context, args []InsertKeyExpiryNotificationParams) error {
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	// Build the bulk insert query
+	valueClauses := make([]string, len(args))
+	for i := range args {
+		valueClauses[i] = "( ?, ?, ?, ?, ? )"
+	}
+
+	bulkQuery := fmt.Sprintf(bulkInsertKeyExpiryNotification, strings.Join(valueClauses, ", "))
+
+	// Collect all arguments
+	var allArgs []any
+	for _, arg := range args {
+		allArgs = append(allArgs, arg.KeyID)
+		allArgs = append(allArgs, arg.WorkspaceID)
+		allArgs = append(allArgs, arg.ThresholdMs)
+		allArgs = append(allArgs, arg.Expires)
+		allArgs = append(allArgs, arg.CreatedAtM)
+	}
+
+	// Execute the bulk insert
+	_, err := q.db.ExecContext(ctx, bulkQuery, allArgs...)
+	return err
+}
@@ -0,0 +1,44 @@
+// Code generated by sqlc bulk insert plugin. DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// bulkInsertKeyRotation is the base query for bulk insert
+const bulkInsertKeyRotation = `INSERT INTO key_rotations ( key_id, successor_key_id, workspace_id, key_auth_id, reason, created_at_m ) VALUES %s`
+
+// InsertKeyRotations performs bulk insert in a single query
+
+func (q *BulkQueries) InsertKeyRotations(ctx context.Context, args []InsertKeyRotationParams) error {
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	// Build the bulk insert query
+	valueClauses := make([]string, len(args))
+	for i := range args {
+		valueClauses[i] = "( ?, ?, ?, ?, ?, ? )"
+	}
+
+	bulkQuery := fmt.Sprintf(bulkInsertKeyRotation, strings.Join(valueClauses, ", "))
+
+	// Collect all arguments
+	var allArgs []any
+	for _, arg := range args {
+		allArgs = append(allArgs, arg.KeyID)
+		allArgs = append(allArgs, arg.SuccessorKeyID)
+		allArgs = append(allArgs, arg.WorkspaceID)
+		allArgs = append(allArgs, arg.KeyAuthID)
+		allArgs = append(allArgs, arg.Reason)
+		allArgs = append(allArgs, arg.CreatedAtM)
+	}
+
+	// Execute the bulk insert
+	_, err := q.db.ExecContext(ctx, bulkQuery, allArgs...)
+	return err
+}
@@ -0,0 +1,44 @@
+// Code generated by sqlc bulk insert plugin. DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// bulkInsertKeyRotationPolicy is the base query for bulk insert
+const bulkInsertKeyRotationPolicy = `INSERT INTO key_rotation_policies ( scope_id, workspace_id, key_auth_id, interval_ms, grace_period_ms, created_at_m ) VALUES %s`
+
+// InsertKeyRotationPolicies performs bulk insert in a single query
+
+func (q *BulkQueries) InsertKeyRotationPolicies(ctx context.Context, args []InsertKeyRotationPolicyParams) error {
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	// Build the bulk insert query
+	valueClauses := make([]string, len(args))
+	for i := range args {
+		valueClauses[i] = "( ?, ?, ?, ?, ?, ? )"
+	}
+
+	bulkQuery := fmt.Sprintf(bulkInsertKeyRotationPolicy, strings.Join(valueClauses, ", "))
+
+	// Collect all arguments
+	var allArgs []any
+	for _, arg := range args {
+		allArgs = append(allArgs, arg.ScopeID)
+		allArgs = append(allArgs, arg.WorkspaceID)
+		allArgs = append(allArgs, arg.KeyAuthID)
+		allArgs = append(allArgs, arg.IntervalMs)
+		allArgs = append(allArgs, arg.GracePeriodMs)
+		allArgs = append(allArgs, arg.CreatedAtM)
+	}
+
+	// Execute the bulk insert
+	_, err := q.db.ExecContext(ctx, bulkQuery, allArgs...)
+	return err
+}
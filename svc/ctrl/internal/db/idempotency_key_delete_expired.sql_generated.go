@@ -0,0 +1,40 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: idempotency_key_delete_expired.sql
+
+package db
+
+import (
+	"context"
+)
+
+const deleteExpiredIdempotencyKeys = `-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at_m < ?
+LIMIT ?
+`
+
+type DeleteExpiredIdempotencyKeysParams struct {
+	Cutoff int64 `db:"cutoff"`
+	Limit  int32 `db:"limit"`
+}
+
+// DeleteExpiredIdempotencyKeys deletes a bounded batch of idempotency_keys
+// rows whose replay window ended before the cutoff, and returns the number of
+// rows deleted so the caller can loop until no expired rows are left.
+//
+// The API takes over expired rows in place when a key is reused, so this
+// sweep only reclaims storage; the expires_at_m_idx index turns it into a
+// range seek.
+//
+//	DELETE FROM idempotency_keys
+//	WHERE expires_at_m < ?
+//	LIMIT ?
+func (q *Queries) DeleteExpiredIdempotencyKeys(ctx context.Context, arg DeleteExpiredIdempotencyKeysParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredIdempotencyKeys, arg.Cutoff, arg.Limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
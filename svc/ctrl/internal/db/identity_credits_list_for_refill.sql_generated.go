@@ -0,0 +1,105 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: identity_credits_list_for_refill.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const listIdentityCreditsForRefill = `-- name: ListIdentityCreditsForRefill :many
+SELECT ic.pk, ic.identity_id, ic.workspace_id, ic.refill_amount, ic.remaining, i.external_id
+FROM ` + "`" + `identity_credits` + "`" + ` ic
+INNER JOIN (
+    SELECT ici.pk
+    FROM ` + "`" + `identity_credits` + "`" + ` ici
+    WHERE ici.refill_amount IS NOT NULL
+      AND ici.refill_amount > ici.remaining
+      AND (
+          ici.refill_day IS NULL
+          OR ici.refill_day = ?
+          OR (? = 1 AND ici.refill_day > ?)
+      )
+      AND ici.pk > ?
+    ORDER BY pk
+    LIMIT ?
+) AS batch ON batch.pk = ic.pk
+LEFT JOIN ` + "`" + `identities` + "`" + ` i ON i.id = ic.identity_id
+`
+
+type ListIdentityCreditsForRefillParams struct {
+	TodayDay         sql.NullInt16 `db:"today_day"`
+	IsLastDayOfMonth interface{}   `db:"is_last_day_of_month"`
+	AfterPk          uint64        `db:"after_pk"`
+	Limit            int32         `db:"limit"`
+}
+
+type ListIdentityCreditsForRefillRow struct {
+	Pk           uint64         `db:"pk"`
+	IdentityID   string         `db:"identity_id"`
+	WorkspaceID  string         `db:"workspace_id"`
+	RefillAmount sql.NullInt64  `db:"refill_amount"`
+	Remaining    uint64         `db:"remaining"`
+	ExternalID   sql.NullString `db:"external_id"`
+}
+
+// ListIdentityCreditsForRefill returns identity credit pools that need their
+// remaining credits refilled, selected by refill_day the same way as
+// ListKeysForRefill and paginated by pk with the same deferred join.
+// Pools are skipped if remaining >= refill_amount (already full).
+//
+//	SELECT ic.pk, ic.identity_id, ic.workspace_id, ic.refill_amount, ic.remaining, i.external_id
+//	FROM `identity_credits` ic
+//	INNER JOIN (
+//	    SELECT ici.pk
+//	    FROM `identity_credits` ici
+//	    WHERE ici.refill_amount IS NOT NULL
+//	      AND ici.refill_amount > ici.remaining
+//	      AND (
+//	          ici.refill_day IS NULL
+//	          OR ici.refill_day = ?
+//	          OR (? = 1 AND ici.refill_day > ?)
+//	      )
+//	      AND ici.pk > ?
+//	    ORDER BY pk
+//	    LIMIT ?
+//	) AS batch ON batch.pk = ic.pk
+//	LEFT JOIN `identities` i ON i.id = ic.identity_id
+func (q *Queries) ListIdentityCreditsForRefill(ctx context.Context, arg ListIdentityCreditsForRefillParams) ([]ListIdentityCreditsForRefillRow, error) {
+	rows, err := q.db.QueryContext(ctx, listIdentityCreditsForRefill,
+		arg.TodayDay,
+		arg.IsLastDayOfMonth,
+		arg.TodayDay,
+		arg.AfterPk,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListIdentityCreditsForRefillRow
+	for rows.Next() {
+		var i ListIdentityCreditsForRefillRow
+		if err := rows.Scan(
+			&i.Pk,
+			&i.IdentityID,
+			&i.WorkspaceID,
+			&i.RefillAmount,
+			&i.Remaining,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,51 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: identity_credits_refill_by_ids.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+const refillIdentityCreditsByIDs = `-- name: RefillIdentityCreditsByIDs :exec
+UPDATE ` + "`" + `identity_credits` + "`" + `
+SET remaining = refill_amount,
+    last_refill_at = NOW(3),
+    updated_at_m = ?
+WHERE identity_id IN (/*SLICE:identity_ids*/?)
+  AND refill_amount IS NOT NULL
+`
+
+type RefillIdentityCreditsByIDsParams struct {
+	Now         sql.NullInt64 `db:"now"`
+	IdentityIds []string      `db:"identity_ids"`
+}
+
+// RefillIdentityCreditsByIDs sets remaining to refill_amount for the given
+// identities' credit pools.
+//
+//	UPDATE `identity_credits`
+//	SET remaining = refill_amount,
+//	    last_refill_at = NOW(3),
+//	    updated_at_m = ?
+//	WHERE identity_id IN (/*SLICE:identity_ids*/?)
+//	  AND refill_amount IS NOT NULL
+func (q *Queries) RefillIdentityCreditsByIDs(ctx context.Context, arg RefillIdentityCreditsByIDsParams) error {
+	query := refillIdentityCreditsByIDs
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.Now)
+	if len(arg.IdentityIds) > 0 {
+		for _, v := range arg.IdentityIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:identity_ids*/?", strings.Repeat(",?", len(arg.IdentityIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:identity_ids*/?", "NULL", 1)
+	}
+	_, err := q.db.ExecContext(ctx, query, queryParams...)
+	return err
+}
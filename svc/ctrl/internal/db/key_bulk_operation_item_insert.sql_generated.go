@@ -0,0 +1,44 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_bulk_operation_item_insert.sql
+
+package db
+
+import (
+	"context"
+)
+
+const insertKeyBulkOperationItem = `-- name: InsertKeyBulkOperationItem :exec
+INSERT INTO key_bulk_operation_items (
+    operation_id,
+    key_id,
+    status
+) VALUES (
+    ?,
+    ?,
+    'pending'
+) ON DUPLICATE KEY UPDATE key_id = key_id
+`
+
+type InsertKeyBulkOperationItemParams struct {
+	OperationID string `db:"operation_id"`
+	KeyID       string `db:"key_id"`
+}
+
+// Items are inserted per resolved page; a retried page leaves the rows it
+// already wrote, and their outcomes, untouched.
+//
+//	INSERT INTO key_bulk_operation_items (
+//	    operation_id,
+//	    key_id,
+//	    status
+//	) VALUES (
+//	    ?,
+//	    ?,
+//	    'pending'
+//	) ON DUPLICATE KEY UPDATE key_id = key_id
+func (q *Queries) InsertKeyBulkOperationItem(ctx context.Context, arg InsertKeyBulkOperationItemParams) error {
+	_, err := q.db.ExecContext(ctx, insertKeyBulkOperationItem, arg.OperationID, arg.KeyID)
+	return err
+}
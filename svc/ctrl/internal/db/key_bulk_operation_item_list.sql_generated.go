@@ -0,0 +1,82 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_bulk_operation_item_list.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const listKeyBulkOperationItems = `-- name: ListKeyBulkOperationItems :many
+SELECT i.pk, i.key_id, i.status, i.error, i.event_id, i.expires, i.updated_at_m, k.hash
+FROM key_bulk_operation_items i
+LEFT JOIN ` + "`" + `keys` + "`" + ` k ON k.id = i.key_id
+WHERE i.operation_id = ?
+  AND i.pk > ?
+ORDER BY i.pk
+LIMIT ?
+`
+
+type ListKeyBulkOperationItemsParams struct {
+	OperationID string `db:"operation_id"`
+	AfterPk     uint64 `db:"after_pk"`
+	Limit       int32  `db:"limit"`
+}
+
+type ListKeyBulkOperationItemsRow struct {
+	Pk         uint64                      `db:"pk"`
+	KeyID      string                      `db:"key_id"`
+	Status     KeyBulkOperationItemsStatus `db:"status"`
+	Error      sql.NullString              `db:"error"`
+	EventID    sql.NullString              `db:"event_id"`
+	Expires    sql.NullInt64               `db:"expires"`
+	UpdatedAtM sql.NullInt64               `db:"updated_at_m"`
+	Hash       sql.NullString              `db:"hash"`
+}
+
+// ListKeyBulkOperationItems returns the next items of an operation together
+// with their key's hash and recorded webhook event, so keys a previous attempt
+// already changed can still have their cached copies invalidated and their
+// webhooks dispatched.
+//
+//	SELECT i.pk, i.key_id, i.status, i.error, i.event_id, i.expires, i.updated_at_m, k.hash
+//	FROM key_bulk_operation_items i
+//	LEFT JOIN `keys` k ON k.id = i.key_id
+//	WHERE i.operation_id = ?
+//	  AND i.pk > ?
+//	ORDER BY i.pk
+//	LIMIT ?
+func (q *Queries) ListKeyBulkOperationItems(ctx context.Context, arg ListKeyBulkOperationItemsParams) ([]ListKeyBulkOperationItemsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listKeyBulkOperationItems, arg.OperationID, arg.AfterPk, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListKeyBulkOperationItemsRow
+	for rows.Next() {
+		var i ListKeyBulkOperationItemsRow
+		if err := rows.Scan(
+			&i.Pk,
+			&i.KeyID,
+			&i.Status,
+			&i.Error,
+			&i.EventID,
+			&i.Expires,
+			&i.UpdatedAtM,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
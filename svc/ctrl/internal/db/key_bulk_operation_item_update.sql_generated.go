@@ -0,0 +1,56 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_bulk_operation_item_update.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const updateKeyBulkOperationItem = `-- name: UpdateKeyBulkOperationItem :exec
+UPDATE key_bulk_operation_items
+SET status = ?,
+    error = ?,
+    event_id = ?,
+    expires = ?,
+    updated_at_m = ?
+WHERE operation_id = ?
+  AND key_id = ?
+`
+
+type UpdateKeyBulkOperationItemParams struct {
+	Status      KeyBulkOperationItemsStatus `db:"status"`
+	Error       sql.NullString              `db:"error"`
+	EventID     sql.NullString              `db:"event_id"`
+	Expires     sql.NullInt64               `db:"expires"`
+	Now         sql.NullInt64               `db:"now"`
+	OperationID string                      `db:"operation_id"`
+	KeyID       string                      `db:"key_id"`
+}
+
+// UpdateKeyBulkOperationItem records an item's outcome together with the
+// webhook event its change produced, so a retried batch can dispatch it.
+//
+//	UPDATE key_bulk_operation_items
+//	SET status = ?,
+//	    error = ?,
+//	    event_id = ?,
+//	    expires = ?,
+//	    updated_at_m = ?
+//	WHERE operation_id = ?
+//	  AND key_id = ?
+func (q *Queries) UpdateKeyBulkOperationItem(ctx context.Context, arg UpdateKeyBulkOperationItemParams) error {
+	_, err := q.db.ExecContext(ctx, updateKeyBulkOperationItem,
+		arg.Status,
+		arg.Error,
+		arg.EventID,
+		arg.Expires,
+		arg.Now,
+		arg.OperationID,
+		arg.KeyID,
+	)
+	return err
+}
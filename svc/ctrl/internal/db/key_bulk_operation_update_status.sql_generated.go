@@ -0,0 +1,55 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_bulk_operation_update_status.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const updateKeyBulkOperationStatus = `-- name: UpdateKeyBulkOperationStatus :exec
+UPDATE key_bulk_operations
+SET status = ?,
+    succeeded = ?,
+    failed = ?,
+    error = ?,
+    updated_at_m = ?,
+    completed_at_m = ?
+WHERE id = ?
+`
+
+type UpdateKeyBulkOperationStatusParams struct {
+	Status       KeyBulkOperationsStatus `db:"status"`
+	Succeeded    uint32                  `db:"succeeded"`
+	Failed       uint32                  `db:"failed"`
+	Error        sql.NullString          `db:"error"`
+	Now          sql.NullInt64           `db:"now"`
+	CompletedAtM sql.NullInt64           `db:"completed_at_m"`
+	ID           string                  `db:"id"`
+}
+
+// UpdateKeyBulkOperationStatus
+//
+//	UPDATE key_bulk_operations
+//	SET status = ?,
+//	    succeeded = ?,
+//	    failed = ?,
+//	    error = ?,
+//	    updated_at_m = ?,
+//	    completed_at_m = ?
+//	WHERE id = ?
+func (q *Queries) UpdateKeyBulkOperationStatus(ctx context.Context, arg UpdateKeyBulkOperationStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updateKeyBulkOperationStatus,
+		arg.Status,
+		arg.Succeeded,
+		arg.Failed,
+		arg.Error,
+		arg.Now,
+		arg.CompletedAtM,
+		arg.ID,
+	)
+	return err
+}
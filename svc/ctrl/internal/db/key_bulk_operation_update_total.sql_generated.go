@@ -0,0 +1,35 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_bulk_operation_update_total.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const updateKeyBulkOperationTotal = `-- name: UpdateKeyBulkOperationTotal :exec
+UPDATE key_bulk_operations
+SET total = ?,
+    updated_at_m = ?
+WHERE id = ?
+`
+
+type UpdateKeyBulkOperationTotalParams struct {
+	Total uint32        `db:"total"`
+	Now   sql.NullInt64 `db:"now"`
+	ID    string        `db:"id"`
+}
+
+// UpdateKeyBulkOperationTotal
+//
+//	UPDATE key_bulk_operations
+//	SET total = ?,
+//	    updated_at_m = ?
+//	WHERE id = ?
+func (q *Queries) UpdateKeyBulkOperationTotal(ctx context.Context, arg UpdateKeyBulkOperationTotalParams) error {
+	_, err := q.db.ExecContext(ctx, updateKeyBulkOperationTotal, arg.Total, arg.Now, arg.ID)
+	return err
+}
@@ -0,0 +1,65 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_expiry_notification_insert.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const insertKeyExpiryNotification = `-- name: InsertKeyExpiryNotification :exec
+INSERT INTO key_expiry_notifications (
+    key_id,
+    workspace_id,
+    threshold_ms,
+    expires,
+    created_at_m
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
+ON DUPLICATE KEY UPDATE pk = pk
+`
+
+type InsertKeyExpiryNotificationParams struct {
+	KeyID       string    `db:"key_id"`
+	WorkspaceID string    `db:"workspace_id"`
+	ThresholdMs int64     `db:"threshold_ms"`
+	Expires     time.Time `db:"expires"`
+	CreatedAtM  int64     `db:"created_at_m"`
+}
+
+// InsertKeyExpiryNotification records that a key was warned about its expiry
+// at a threshold. A retried insert for the same key, threshold and expiry is a
+// no-op.
+//
+//	INSERT INTO key_expiry_notifications (
+//	    key_id,
+//	    workspace_id,
+//	    threshold_ms,
+//	    expires,
+//	    created_at_m
+//	) VALUES (
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?
+//	)
+//	ON DUPLICATE KEY UPDATE pk = pk
+func (q *Queries) InsertKeyExpiryNotification(ctx context.Context, arg InsertKeyExpiryNotificationParams) error {
+	_, err := q.db.ExecContext(ctx, insertKeyExpiryNotification,
+		arg.KeyID,
+		arg.WorkspaceID,
+		arg.ThresholdMs,
+		arg.Expires,
+		arg.CreatedAtM,
+	)
+	return err
+}
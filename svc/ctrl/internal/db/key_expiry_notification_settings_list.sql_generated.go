@@ -0,0 +1,97 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_expiry_notification_settings_list.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+const listKeyExpiryNotificationSettings = `-- name: ListKeyExpiryNotificationSettings :many
+SELECT
+    s.pk, s.key_auth_id, s.workspace_id, s.thresholds_ms, s.email, s.slack_webhook_url, a.id AS api_id, a.name AS api_name, w.name AS workspace_name, w.slug AS workspace_slug, w.org_id
+FROM key_expiry_notification_settings s
+JOIN key_auth ka ON ka.id = s.key_auth_id
+JOIN apis a ON a.key_auth_id = s.key_auth_id
+JOIN workspaces w ON w.id = s.workspace_id
+WHERE s.pk > ?
+  AND ka.deleted_at_m IS NULL
+  AND a.deleted_at_m IS NULL
+  AND w.deleted_at_m IS NULL
+ORDER BY s.pk
+LIMIT ?
+`
+
+type ListKeyExpiryNotificationSettingsParams struct {
+	AfterPk uint64 `db:"after_pk"`
+	Limit   int32  `db:"limit"`
+}
+
+type ListKeyExpiryNotificationSettingsRow struct {
+	Pk              uint64          `db:"pk"`
+	KeyAuthID       string          `db:"key_auth_id"`
+	WorkspaceID     string          `db:"workspace_id"`
+	ThresholdsMs    json.RawMessage `db:"thresholds_ms"`
+	Email           bool            `db:"email"`
+	SlackWebhookUrl sql.NullString  `db:"slack_webhook_url"`
+	ApiID           string          `db:"api_id"`
+	ApiName         string          `db:"api_name"`
+	WorkspaceName   string          `db:"workspace_name"`
+	WorkspaceSlug   string          `db:"workspace_slug"`
+	OrgID           string          `db:"org_id"`
+}
+
+// ListKeyExpiryNotificationSettings pages through the keyspaces that opted
+// into expiry notifications, with what a notification needs to name the api
+// and reach the workspace's admins.
+//
+//	SELECT
+//	    s.pk, s.key_auth_id, s.workspace_id, s.thresholds_ms, s.email, s.slack_webhook_url, a.id AS api_id, a.name AS api_name, w.name AS workspace_name, w.slug AS workspace_slug, w.org_id
+//	FROM key_expiry_notification_settings s
+//	JOIN key_auth ka ON ka.id = s.key_auth_id
+//	JOIN apis a ON a.key_auth_id = s.key_auth_id
+//	JOIN workspaces w ON w.id = s.workspace_id
+//	WHERE s.pk > ?
+//	  AND ka.deleted_at_m IS NULL
+//	  AND a.deleted_at_m IS NULL
+//	  AND w.deleted_at_m IS NULL
+//	ORDER BY s.pk
+//	LIMIT ?
+func (q *Queries) ListKeyExpiryNotificationSettings(ctx context.Context, arg ListKeyExpiryNotificationSettingsParams) ([]ListKeyExpiryNotificationSettingsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listKeyExpiryNotificationSettings, arg.AfterPk, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListKeyExpiryNotificationSettingsRow
+	for rows.Next() {
+		var i ListKeyExpiryNotificationSettingsRow
+		if err := rows.Scan(
+			&i.Pk,
+			&i.KeyAuthID,
+			&i.WorkspaceID,
+			&i.ThresholdsMs,
+			&i.Email,
+			&i.SlackWebhookUrl,
+			&i.ApiID,
+			&i.ApiName,
+			&i.WorkspaceName,
+			&i.WorkspaceSlug,
+			&i.OrgID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -27,7 +27,8 @@ INSERT INTO ` + "`" + `keys` + "`" + ` (
     remaining_requests,
     refill_day,
     refill_amount,
-    pending_migration_id
+    pending_migration_id,
+    environment
 ) VALUES (
     ?,
     ?,
@@ -44,6 +45,7 @@ INSERT INTO ` + "`" + `keys` + "`" + ` (
     ?,
     ?,
     ?,
+    ?,
     ?
 )
 `
@@ -65,6 +67,7 @@ type InsertKeyParams struct {
 	RefillDay          sql.NullInt16  `db:"refill_day"`
 	RefillAmount       sql.NullInt64  `db:"refill_amount"`
 	PendingMigrationID sql.NullString `db:"pending_migration_id"`
+	Environment        sql.NullString `db:"environment"`
 }
 
 // InsertKey
@@ -85,7 +88,8 @@ type InsertKeyParams struct {
 //	    remaining_requests,
 //	    refill_day,
 //	    refill_amount,
-//	    pending_migration_id
+//	    pending_migration_id,
+//	    environment
 //	) VALUES (
 //	    ?,
 //	    ?,
@@ -102,6 +106,7 @@ type InsertKeyParams struct {
 //	    ?,
 //	    ?,
 //	    ?,
+//	    ?,
 //	    ?
 //	)
 func (q *Queries) InsertKey(ctx context.Context, arg InsertKeyParams) error {
@@ -122,6 +127,7 @@ func (q *Queries) InsertKey(ctx context.Context, arg InsertKeyParams) error {
 		arg.RefillDay,
 		arg.RefillAmount,
 		arg.PendingMigrationID,
+		arg.Environment,
 	)
 	return err
 }
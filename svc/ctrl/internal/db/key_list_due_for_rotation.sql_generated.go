@@ -0,0 +1,163 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_list_due_for_rotation.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const listKeysDueForRotation = `-- name: ListKeysDueForRotation :many
+SELECT
+    k.pk, k.id, k.key_auth_id, k.workspace_id, k.for_workspace_id, k.hash, k.start,
+    k.name, k.identity_id, k.meta, k.expires, k.enabled, k.remaining_requests,
+    k.refill_day, k.refill_amount, k.environment,
+    ka.default_prefix, ka.default_bytes,
+    a.id AS api_id,
+    kp.interval_ms AS key_interval_ms,
+    kp.grace_period_ms AS key_grace_period_ms,
+    sp.interval_ms AS keyspace_interval_ms,
+    sp.grace_period_ms AS keyspace_grace_period_ms
+FROM ` + "`" + `keys` + "`" + ` k
+JOIN key_auth ka ON ka.id = k.key_auth_id
+JOIN apis a ON a.key_auth_id = k.key_auth_id
+JOIN encrypted_keys ek ON ek.key_id = k.id
+LEFT JOIN key_rotation_policies kp ON kp.scope_id = k.id
+LEFT JOIN key_rotation_policies sp ON sp.scope_id = k.key_auth_id
+WHERE k.pk > ?
+  AND k.deleted_at_m IS NULL
+  AND ka.deleted_at_m IS NULL
+  AND a.deleted_at_m IS NULL
+  AND ka.store_encrypted_keys = true
+  AND (k.expires IS NULL OR k.expires > NOW(3))
+  AND (
+      (kp.pk IS NOT NULL AND k.created_at_m + kp.interval_ms <= ?)
+      OR (kp.pk IS NULL AND sp.pk IS NOT NULL AND k.created_at_m + sp.interval_ms <= ?)
+  )
+  AND NOT EXISTS (SELECT 1 FROM key_rotations kr WHERE kr.key_id = k.id)
+ORDER BY k.pk
+LIMIT ?
+`
+
+type ListKeysDueForRotationParams struct {
+	AfterPk uint64 `db:"after_pk"`
+	Now     int64  `db:"now"`
+	Limit   int32  `db:"limit"`
+}
+
+type ListKeysDueForRotationRow struct {
+	Pk                    uint64         `db:"pk"`
+	ID                    string         `db:"id"`
+	KeyAuthID             string         `db:"key_auth_id"`
+	WorkspaceID           string         `db:"workspace_id"`
+	ForWorkspaceID        sql.NullString `db:"for_workspace_id"`
+	Hash                  string         `db:"hash"`
+	Start                 string         `db:"start"`
+	Name                  sql.NullString `db:"name"`
+	IdentityID            sql.NullString `db:"identity_id"`
+	Meta                  sql.NullString `db:"meta"`
+	Expires               sql.NullTime   `db:"expires"`
+	Enabled               bool           `db:"enabled"`
+	RemainingRequests     sql.NullInt64  `db:"remaining_requests"`
+	RefillDay             sql.NullInt16  `db:"refill_day"`
+	RefillAmount          sql.NullInt64  `db:"refill_amount"`
+	Environment           sql.NullString `db:"environment"`
+	DefaultPrefix         sql.NullString `db:"default_prefix"`
+	DefaultBytes          sql.NullInt32  `db:"default_bytes"`
+	ApiID                 string         `db:"api_id"`
+	KeyIntervalMs         sql.NullInt64  `db:"key_interval_ms"`
+	KeyGracePeriodMs      sql.NullInt64  `db:"key_grace_period_ms"`
+	KeyspaceIntervalMs    sql.NullInt64  `db:"keyspace_interval_ms"`
+	KeyspaceGracePeriodMs sql.NullInt64  `db:"keyspace_grace_period_ms"`
+}
+
+// ListKeysDueForRotation returns recoverable keys whose rotation policy says a
+// successor is due at now. A key's own policy wins over its keyspace's. Keys
+// that already have a successor, from a reroll or an earlier rotation, are
+// skipped; the successor carries the schedule forward. Keys without an
+// encrypted copy are skipped too, because nobody could retrieve their
+// successor.
+//
+//	SELECT
+//	    k.pk, k.id, k.key_auth_id, k.workspace_id, k.for_workspace_id, k.hash, k.start,
+//	    k.name, k.identity_id, k.meta, k.expires, k.enabled, k.remaining_requests,
+//	    k.refill_day, k.refill_amount, k.environment,
+//	    ka.default_prefix, ka.default_bytes,
+//	    a.id AS api_id,
+//	    kp.interval_ms AS key_interval_ms,
+//	    kp.grace_period_ms AS key_grace_period_ms,
+//	    sp.interval_ms AS keyspace_interval_ms,
+//	    sp.grace_period_ms AS keyspace_grace_period_ms
+//	FROM `keys` k
+//	JOIN key_auth ka ON ka.id = k.key_auth_id
+//	JOIN apis a ON a.key_auth_id = k.key_auth_id
+//	JOIN encrypted_keys ek ON ek.key_id = k.id
+//	LEFT JOIN key_rotation_policies kp ON kp.scope_id = k.id
+//	LEFT JOIN key_rotation_policies sp ON sp.scope_id = k.key_auth_id
+//	WHERE k.pk > ?
+//	  AND k.deleted_at_m IS NULL
+//	  AND ka.deleted_at_m IS NULL
+//	  AND a.deleted_at_m IS NULL
+//	  AND ka.store_encrypted_keys = true
+//	  AND (k.expires IS NULL OR k.expires > NOW(3))
+//	  AND (
+//	      (kp.pk IS NOT NULL AND k.created_at_m + kp.interval_ms <= ?)
+//	      OR (kp.pk IS NULL AND sp.pk IS NOT NULL AND k.created_at_m + sp.interval_ms <= ?)
+//	  )
+//	  AND NOT EXISTS (SELECT 1 FROM key_rotations kr WHERE kr.key_id = k.id)
+//	ORDER BY k.pk
+//	LIMIT ?
+func (q *Queries) ListKeysDueForRotation(ctx context.Context, arg ListKeysDueForRotationParams) ([]ListKeysDueForRotationRow, error) {
+	rows, err := q.db.QueryContext(ctx, listKeysDueForRotation,
+		arg.AfterPk,
+		arg.Now,
+		arg.Now,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListKeysDueForRotationRow
+	for rows.Next() {
+		var i ListKeysDueForRotationRow
+		if err := rows.Scan(
+			&i.Pk,
+			&i.ID,
+			&i.KeyAuthID,
+			&i.WorkspaceID,
+			&i.ForWorkspaceID,
+			&i.Hash,
+			&i.Start,
+			&i.Name,
+			&i.IdentityID,
+			&i.Meta,
+			&i.Expires,
+			&i.Enabled,
+			&i.RemainingRequests,
+			&i.RefillDay,
+			&i.RefillAmount,
+			&i.Environment,
+			&i.DefaultPrefix,
+			&i.DefaultBytes,
+			&i.ApiID,
+			&i.KeyIntervalMs,
+			&i.KeyGracePeriodMs,
+			&i.KeyspaceIntervalMs,
+			&i.KeyspaceGracePeriodMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
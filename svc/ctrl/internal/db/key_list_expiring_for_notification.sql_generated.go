@@ -0,0 +1,98 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_list_expiring_for_notification.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const listKeysExpiringForNotification = `-- name: ListKeysExpiringForNotification :many
+SELECT
+    k.pk, k.id, k.name, k.start, k.expires, CAST(COALESCE(MIN(n.threshold_ms), 0) AS SIGNED) AS notified_threshold_ms
+FROM ` + "`" + `keys` + "`" + ` k
+LEFT JOIN key_expiry_notifications n ON n.key_id = k.id AND n.expires = k.expires
+WHERE k.key_auth_id = ?
+  AND k.pk > ?
+  AND k.deleted_at_m IS NULL
+  AND k.enabled = true
+  AND k.expires > ?
+  AND k.expires <= ?
+GROUP BY k.pk
+ORDER BY k.pk
+LIMIT ?
+`
+
+type ListKeysExpiringForNotificationParams struct {
+	KeyAuthID string       `db:"key_auth_id"`
+	AfterPk   uint64       `db:"after_pk"`
+	Now       sql.NullTime `db:"now"`
+	Horizon   sql.NullTime `db:"horizon"`
+	Limit     int32        `db:"limit"`
+}
+
+type ListKeysExpiringForNotificationRow struct {
+	Pk                  uint64         `db:"pk"`
+	ID                  string         `db:"id"`
+	Name                sql.NullString `db:"name"`
+	Start               string         `db:"start"`
+	Expires             sql.NullTime   `db:"expires"`
+	NotifiedThresholdMs int64          `db:"notified_threshold_ms"`
+}
+
+// ListKeysExpiringForNotification pages through the enabled keys of a keyspace
+// that expire in (now, horizon]. notified_threshold_ms is the tightest
+// threshold the key was already warned at for its current expiry, or 0 if it
+// was not warned yet; moving the expiry starts over.
+//
+//	SELECT
+//	    k.pk, k.id, k.name, k.start, k.expires, CAST(COALESCE(MIN(n.threshold_ms), 0) AS SIGNED) AS notified_threshold_ms
+//	FROM `keys` k
+//	LEFT JOIN key_expiry_notifications n ON n.key_id = k.id AND n.expires = k.expires
+//	WHERE k.key_auth_id = ?
+//	  AND k.pk > ?
+//	  AND k.deleted_at_m IS NULL
+//	  AND k.enabled = true
+//	  AND k.expires > ?
+//	  AND k.expires <= ?
+//	GROUP BY k.pk
+//	ORDER BY k.pk
+//	LIMIT ?
+func (q *Queries) ListKeysExpiringForNotification(ctx context.Context, arg ListKeysExpiringForNotificationParams) ([]ListKeysExpiringForNotificationRow, error) {
+	rows, err := q.db.QueryContext(ctx, listKeysExpiringForNotification,
+		arg.KeyAuthID,
+		arg.AfterPk,
+		arg.Now,
+		arg.Horizon,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListKeysExpiringForNotificationRow
+	for rows.Next() {
+		var i ListKeysExpiringForNotificationRow
+		if err := rows.Scan(
+			&i.Pk,
+			&i.ID,
+			&i.Name,
+			&i.Start,
+			&i.Expires,
+			&i.NotifiedThresholdMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
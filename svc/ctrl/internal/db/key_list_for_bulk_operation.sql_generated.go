@@ -0,0 +1,81 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_list_for_bulk_operation.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const listKeysForBulkOperation = `-- name: ListKeysForBulkOperation :many
+SELECT pk, id, meta
+FROM ` + "`" + `keys` + "`" + `
+WHERE key_auth_id = ?
+  AND deleted_at_m IS NULL
+  AND pk > ?
+  AND (? IS NULL OR identity_id = ?)
+  AND (? IS NULL OR last_used_at < ?)
+ORDER BY pk
+LIMIT ?
+`
+
+type ListKeysForBulkOperationParams struct {
+	KeyAuthID      string         `db:"key_auth_id"`
+	AfterPk        uint64         `db:"after_pk"`
+	IdentityID     sql.NullString `db:"identity_id"`
+	LastUsedBefore sql.NullInt64  `db:"last_used_before"`
+	Limit          int32          `db:"limit"`
+}
+
+type ListKeysForBulkOperationRow struct {
+	Pk   uint64         `db:"pk"`
+	ID   string         `db:"id"`
+	Meta sql.NullString `db:"meta"`
+}
+
+// ListKeysForBulkOperation pages through the live keys of a keyspace that
+// match a bulk operation's filter. A NULL identity_id or last_used_before
+// matches every key; the meta filter is applied by the caller.
+//
+//	SELECT pk, id, meta
+//	FROM `keys`
+//	WHERE key_auth_id = ?
+//	  AND deleted_at_m IS NULL
+//	  AND pk > ?
+//	  AND (? IS NULL OR identity_id = ?)
+//	  AND (? IS NULL OR last_used_at < ?)
+//	ORDER BY pk
+//	LIMIT ?
+func (q *Queries) ListKeysForBulkOperation(ctx context.Context, arg ListKeysForBulkOperationParams) ([]ListKeysForBulkOperationRow, error) {
+	rows, err := q.db.QueryContext(ctx, listKeysForBulkOperation,
+		arg.KeyAuthID,
+		arg.AfterPk,
+		arg.IdentityID,
+		arg.IdentityID,
+		arg.LastUsedBefore,
+		arg.LastUsedBefore,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListKeysForBulkOperationRow
+	for rows.Next() {
+		var i ListKeysForBulkOperationRow
+		if err := rows.Scan(&i.Pk, &i.ID, &i.Meta); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
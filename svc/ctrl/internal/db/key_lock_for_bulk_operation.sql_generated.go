@@ -0,0 +1,52 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_lock_for_bulk_operation.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const lockKeyForBulkOperation = `-- name: LockKeyForBulkOperation :one
+SELECT id, key_auth_id, workspace_id, hash, name, meta, expires, enabled
+FROM ` + "`" + `keys` + "`" + `
+WHERE id = ? AND deleted_at_m IS NULL
+FOR UPDATE
+`
+
+type LockKeyForBulkOperationRow struct {
+	ID          string         `db:"id"`
+	KeyAuthID   string         `db:"key_auth_id"`
+	WorkspaceID string         `db:"workspace_id"`
+	Hash        string         `db:"hash"`
+	Name        sql.NullString `db:"name"`
+	Meta        sql.NullString `db:"meta"`
+	Expires     sql.NullTime   `db:"expires"`
+	Enabled     bool           `db:"enabled"`
+}
+
+// LockKeyForBulkOperation locks a live key for the rest of the transaction so
+// a bulk change serializes with the API's own updates of the same key.
+//
+//	SELECT id, key_auth_id, workspace_id, hash, name, meta, expires, enabled
+//	FROM `keys`
+//	WHERE id = ? AND deleted_at_m IS NULL
+//	FOR UPDATE
+func (q *Queries) LockKeyForBulkOperation(ctx context.Context, id string) (LockKeyForBulkOperationRow, error) {
+	row := q.db.QueryRowContext(ctx, lockKeyForBulkOperation, id)
+	var i LockKeyForBulkOperationRow
+	err := row.Scan(
+		&i.ID,
+		&i.KeyAuthID,
+		&i.WorkspaceID,
+		&i.Hash,
+		&i.Name,
+		&i.Meta,
+		&i.Expires,
+		&i.Enabled,
+	)
+	return i, err
+}
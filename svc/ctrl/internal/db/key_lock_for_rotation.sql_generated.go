@@ -0,0 +1,31 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_lock_for_rotation.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const lockKeyForRotation = `-- name: LockKeyForRotation :one
+SELECT id, expires FROM ` + "`" + `keys` + "`" + ` WHERE id = ? AND deleted_at_m IS NULL FOR UPDATE
+`
+
+type LockKeyForRotationRow struct {
+	ID      string       `db:"id"`
+	Expires sql.NullTime `db:"expires"`
+}
+
+// LockKeyForRotation locks a live key for the rest of the transaction so
+// concurrent or retried rotations of the same key serialize on it.
+//
+//	SELECT id, expires FROM `keys` WHERE id = ? AND deleted_at_m IS NULL FOR UPDATE
+func (q *Queries) LockKeyForRotation(ctx context.Context, id string) (LockKeyForRotationRow, error) {
+	row := q.db.QueryRowContext(ctx, lockKeyForRotation, id)
+	var i LockKeyForRotationRow
+	err := row.Scan(&i.ID, &i.Expires)
+	return i, err
+}
@@ -0,0 +1,40 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_permission_list_ids_by_key_id.sql
+
+package db
+
+import (
+	"context"
+)
+
+const listPermissionIDsByKeyID = `-- name: ListPermissionIDsByKeyID :many
+SELECT permission_id FROM keys_permissions WHERE key_id = ?
+`
+
+// ListPermissionIDsByKeyID
+//
+//	SELECT permission_id FROM keys_permissions WHERE key_id = ?
+func (q *Queries) ListPermissionIDsByKeyID(ctx context.Context, keyID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listPermissionIDsByKeyID, keyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var permissionID string
+		if err := rows.Scan(&permissionID); err != nil {
+			return nil, err
+		}
+		items = append(items, permissionID)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
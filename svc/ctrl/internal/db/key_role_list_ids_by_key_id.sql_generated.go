@@ -0,0 +1,40 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_role_list_ids_by_key_id.sql
+
+package db
+
+import (
+	"context"
+)
+
+const listRoleIDsByKeyID = `-- name: ListRoleIDsByKeyID :many
+SELECT role_id FROM keys_roles WHERE key_id = ?
+`
+
+// ListRoleIDsByKeyID
+//
+//	SELECT role_id FROM keys_roles WHERE key_id = ?
+func (q *Queries) ListRoleIDsByKeyID(ctx context.Context, keyID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listRoleIDsByKeyID, keyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var roleID string
+		if err := rows.Scan(&roleID); err != nil {
+			return nil, err
+		}
+		items = append(items, roleID)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
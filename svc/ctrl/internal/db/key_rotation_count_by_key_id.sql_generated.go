@@ -0,0 +1,24 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_rotation_count_by_key_id.sql
+
+package db
+
+import (
+	"context"
+)
+
+const countKeyRotationsByKeyID = `-- name: CountKeyRotationsByKeyID :one
+SELECT COUNT(*) FROM key_rotations WHERE key_id = ?
+`
+
+// CountKeyRotationsByKeyID
+//
+//	SELECT COUNT(*) FROM key_rotations WHERE key_id = ?
+func (q *Queries) CountKeyRotationsByKeyID(ctx context.Context, keyID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countKeyRotationsByKeyID, keyID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
@@ -0,0 +1,66 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_rotation_policy_insert.sql
+
+package db
+
+import (
+	"context"
+)
+
+const insertKeyRotationPolicy = `-- name: InsertKeyRotationPolicy :exec
+INSERT INTO key_rotation_policies (
+    scope_id,
+    workspace_id,
+    key_auth_id,
+    interval_ms,
+    grace_period_ms,
+    created_at_m
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+)
+`
+
+type InsertKeyRotationPolicyParams struct {
+	ScopeID       string `db:"scope_id"`
+	WorkspaceID   string `db:"workspace_id"`
+	KeyAuthID     string `db:"key_auth_id"`
+	IntervalMs    int64  `db:"interval_ms"`
+	GracePeriodMs int64  `db:"grace_period_ms"`
+	CreatedAtM    int64  `db:"created_at_m"`
+}
+
+// InsertKeyRotationPolicy
+//
+//	INSERT INTO key_rotation_policies (
+//	    scope_id,
+//	    workspace_id,
+//	    key_auth_id,
+//	    interval_ms,
+//	    grace_period_ms,
+//	    created_at_m
+//	) VALUES (
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?,
+//	    ?
+//	)
+func (q *Queries) InsertKeyRotationPolicy(ctx context.Context, arg InsertKeyRotationPolicyParams) error {
+	_, err := q.db.ExecContext(ctx, insertKeyRotationPolicy,
+		arg.ScopeID,
+		arg.WorkspaceID,
+		arg.KeyAuthID,
+		arg.IntervalMs,
+		arg.GracePeriodMs,
+		arg.CreatedAtM,
+	)
+	return err
+}
@@ -0,0 +1,35 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_update_expires.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const updateKeyExpires = `-- name: UpdateKeyExpires :exec
+UPDATE ` + "`" + `keys` + "`" + `
+SET expires = ?,
+    updated_at_m = ?
+WHERE id = ?
+`
+
+type UpdateKeyExpiresParams struct {
+	Expires sql.NullTime  `db:"expires"`
+	Now     sql.NullInt64 `db:"now"`
+	ID      string        `db:"id"`
+}
+
+// UpdateKeyExpires
+//
+//	UPDATE `keys`
+//	SET expires = ?,
+//	    updated_at_m = ?
+//	WHERE id = ?
+func (q *Queries) UpdateKeyExpires(ctx context.Context, arg UpdateKeyExpiresParams) error {
+	_, err := q.db.ExecContext(ctx, updateKeyExpires, arg.Expires, arg.Now, arg.ID)
+	return err
+}
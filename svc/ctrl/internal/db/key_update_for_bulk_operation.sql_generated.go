@@ -0,0 +1,51 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: key_update_for_bulk_operation.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const updateKeyForBulkOperation = `-- name: UpdateKeyForBulkOperation :exec
+UPDATE ` + "`" + `keys` + "`" + `
+SET name = ?,
+    meta = ?,
+    expires = ?,
+    enabled = ?,
+    updated_at_m = ?
+WHERE id = ?
+`
+
+type UpdateKeyForBulkOperationParams struct {
+	Name    sql.NullString `db:"name"`
+	Meta    sql.NullString `db:"meta"`
+	Expires sql.NullTime   `db:"expires"`
+	Enabled bool           `db:"enabled"`
+	Now     sql.NullInt64  `db:"now"`
+	ID      string         `db:"id"`
+}
+
+// UpdateKeyForBulkOperation
+//
+//	UPDATE `keys`
+//	SET name = ?,
+//	    meta = ?,
+//	    expires = ?,
+//	    enabled = ?,
+//	    updated_at_m = ?
+//	WHERE id = ?
+func (q *Queries) UpdateKeyForBulkOperation(ctx context.Context, arg UpdateKeyForBulkOperationParams) error {
+	_, err := q.db.ExecContext(ctx, updateKeyForBulkOperation,
+		arg.Name,
+		arg.Meta,
+		arg.Expires,
+		arg.Enabled,
+		arg.Now,
+		arg.ID,
+	)
+	return err
+}
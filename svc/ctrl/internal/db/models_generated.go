@@ -319,6 +319,7 @@ const (
 	DeploymentChangesResourceTypeDeploymentTopology  DeploymentChangesResourceType = "deployment_topology"
 	DeploymentChangesResourceTypeSentinel            DeploymentChangesResourceType = "sentinel"
 	DeploymentChangesResourceTypeCiliumNetworkPolicy DeploymentChangesResourceType = "cilium_network_policy"
+	DeploymentChangesResourceTypeVolume              DeploymentChangesResourceType = "volume"
 )
 
 func (e *DeploymentChangesResourceType) Scan(src interface{}) error {
@@ -665,6 +666,221 @@ func (ns NullInstancesStatus) Value() (driver.Value, error) {
 	return string(ns.InstancesStatus), nil
 }
 
+type KeyBulkOperationItemsStatus string
+
+const (
+	KeyBulkOperationItemsStatusPending   KeyBulkOperationItemsStatus = "pending"
+	KeyBulkOperationItemsStatusSucceeded KeyBulkOperationItemsStatus = "succeeded"
+	KeyBulkOperationItemsStatusFailed    KeyBulkOperationItemsStatus = "failed"
+)
+
+func (e *KeyBulkOperationItemsStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = KeyBulkOperationItemsStatus(s)
+	case string:
+		*e = KeyBulkOperationItemsStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for KeyBulkOperationItemsStatus: %T", src)
+	}
+	return nil
+}
+
+type NullKeyBulkOperationItemsStatus struct {
+	KeyBulkOperationItemsStatus KeyBulkOperationItemsStatus
+	Valid                       bool // Valid is true if KeyBulkOperationItemsStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullKeyBulkOperationItemsStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.KeyBulkOperationItemsStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.KeyBulkOperationItemsStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullKeyBulkOperationItemsStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.KeyBulkOperationItemsStatus), nil
+}
+
+type KeyBulkOperationsStatus string
+
+const (
+	KeyBulkOperationsStatusPending   KeyBulkOperationsStatus = "pending"
+	KeyBulkOperationsStatusRunning   KeyBulkOperationsStatus = "running"
+	KeyBulkOperationsStatusCompleted KeyBulkOperationsStatus = "completed"
+	KeyBulkOperationsStatusFailed    KeyBulkOperationsStatus = "failed"
+)
+
+func (e *KeyBulkOperationsStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = KeyBulkOperationsStatus(s)
+	case string:
+		*e = KeyBulkOperationsStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for KeyBulkOperationsStatus: %T", src)
+	}
+	return nil
+}
+
+type NullKeyBulkOperationsStatus struct {
+	KeyBulkOperationsStatus KeyBulkOperationsStatus
+	Valid                   bool // Valid is true if KeyBulkOperationsStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullKeyBulkOperationsStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.KeyBulkOperationsStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.KeyBulkOperationsStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullKeyBulkOperationsStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.KeyBulkOperationsStatus), nil
+}
+
+type KeyRotationsReason string
+
+const (
+	KeyRotationsReasonReroll   KeyRotationsReason = "reroll"
+	KeyRotationsReasonSchedule KeyRotationsReason = "schedule"
+)
+
+func (e *KeyRotationsReason) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = KeyRotationsReason(s)
+	case string:
+		*e = KeyRotationsReason(s)
+	default:
+		return fmt.Errorf("unsupported scan type for KeyRotationsReason: %T", src)
+	}
+	return nil
+}
+
+type NullKeyRotationsReason struct {
+	KeyRotationsReason KeyRotationsReason
+	Valid              bool // Valid is true if KeyRotationsReason is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullKeyRotationsReason) Scan(value interface{}) error {
+	if value == nil {
+		ns.KeyRotationsReason, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.KeyRotationsReason.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullKeyRotationsReason) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.KeyRotationsReason), nil
+}
+
+type VolumesStatus string
+
+const (
+	VolumesStatusActive   VolumesStatus = "active"
+	VolumesStatusReleased VolumesStatus = "released"
+	VolumesStatusPurged   VolumesStatus = "purged"
+)
+
+func (e *VolumesStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = VolumesStatus(s)
+	case string:
+		*e = VolumesStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for VolumesStatus: %T", src)
+	}
+	return nil
+}
+
+type NullVolumesStatus struct {
+	VolumesStatus VolumesStatus
+	Valid         bool // Valid is true if VolumesStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullVolumesStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.VolumesStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.VolumesStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullVolumesStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.VolumesStatus), nil
+}
+
+type WebhookDeliveriesStatus string
+
+const (
+	WebhookDeliveriesStatusPending   WebhookDeliveriesStatus = "pending"
+	WebhookDeliveriesStatusDelivered WebhookDeliveriesStatus = "delivered"
+	WebhookDeliveriesStatusFailed    WebhookDeliveriesStatus = "failed"
+)
+
+func (e *WebhookDeliveriesStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebhookDeliveriesStatus(s)
+	case string:
+		*e = WebhookDeliveriesStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebhookDeliveriesStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWebhookDeliveriesStatus struct {
+	WebhookDeliveriesStatus WebhookDeliveriesStatus
+	Valid                   bool // Valid is true if WebhookDeliveriesStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebhookDeliveriesStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebhookDeliveriesStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebhookDeliveriesStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebhookDeliveriesStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebhookDeliveriesStatus), nil
+}
+
 type AcmeChallenge struct {
 	Pk            uint64                      `db:"pk"`
 	DomainID      string                      `db:"domain_id"`
@@ -865,6 +1081,7 @@ type DeploymentTopology struct {
 	AutoscalingReplicasMax     uint32                          `db:"autoscaling_replicas_max"`
 	AutoscalingThresholdCpu    sql.NullInt16                   `db:"autoscaling_threshold_cpu"`
 	AutoscalingThresholdMemory sql.NullInt16                   `db:"autoscaling_threshold_memory"`
+	AutoscalingThresholdRps    sql.NullInt16                   `db:"autoscaling_threshold_rps"`
 	DesiredStatus              DeploymentTopologyDesiredStatus `db:"desired_status"`
 	CreatedAt                  int64                           `db:"created_at"`
 	UpdatedAt                  sql.NullInt64                   `db:"updated_at"`
@@ -1004,6 +1221,7 @@ type Permission struct {
 	Name        string               `db:"name"`
 	Slug        string               `db:"slug"`
 	Description mysqltype.NullString `db:"description"`
+	Conditions  []byte               `db:"conditions"`
 	CreatedAtM  int64                `db:"created_at_m"`
 	UpdatedAtM  sql.NullInt64        `db:"updated_at_m"`
 }
@@ -1028,6 +1246,55 @@ type Region struct {
 	CanSchedule bool   `db:"can_schedule"`
 }
 
+type Volume struct {
+	Pk            uint64        `db:"pk"`
+	ID            string        `db:"id"`
+	WorkspaceID   string        `db:"workspace_id"`
+	ProjectID     string        `db:"project_id"`
+	AppID         string        `db:"app_id"`
+	EnvironmentID string        `db:"environment_id"`
+	Name          string        `db:"name"`
+	MountPath     string        `db:"mount_path"`
+	SizeMib       uint32        `db:"size_mib"`
+	K8sName       string        `db:"k8s_name"`
+	Status        VolumesStatus `db:"status"`
+	ReleasedAt    sql.NullInt64 `db:"released_at"`
+	CreatedAt     int64         `db:"created_at"`
+	UpdatedAt     sql.NullInt64 `db:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	Pk             uint64                  `db:"pk"`
+	ID             string                  `db:"id"`
+	WorkspaceID    string                  `db:"workspace_id"`
+	EndpointID     string                  `db:"endpoint_id"`
+	EventID        string                  `db:"event_id"`
+	EventType      string                  `db:"event_type"`
+	Payload        json.RawMessage         `db:"payload"`
+	Status         WebhookDeliveriesStatus `db:"status"`
+	Attempts       uint32                  `db:"attempts"`
+	ResponseStatus sql.NullInt32           `db:"response_status"`
+	LastError      sql.NullString          `db:"last_error"`
+	NextAttemptAt  sql.NullInt64           `db:"next_attempt_at"`
+	DeliveredAt    sql.NullInt64           `db:"delivered_at"`
+	CreatedAt      int64                   `db:"created_at"`
+	UpdatedAt      sql.NullInt64           `db:"updated_at"`
+}
+
+type WebhookEndpoint struct {
+	Pk              uint64          `db:"pk"`
+	ID              string          `db:"id"`
+	WorkspaceID     string          `db:"workspace_id"`
+	Url             string          `db:"url"`
+	Description     sql.NullString  `db:"description"`
+	EventTypes      json.RawMessage `db:"event_types"`
+	EncryptedSecret string          `db:"encrypted_secret"`
+	EncryptionKeyID string          `db:"encryption_key_id"`
+	Enabled         bool            `db:"enabled"`
+	CreatedAt       int64           `db:"created_at"`
+	UpdatedAt       sql.NullInt64   `db:"updated_at"`
+}
+
 type Workspace struct {
 	Pk               uint64          `db:"pk"`
 	ID               string          `db:"id"`
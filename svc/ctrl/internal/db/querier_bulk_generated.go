@@ -32,11 +32,15 @@ type BulkQuerier interface {
 	InsertIdentityRatelimits(ctx context.Context, args []InsertIdentityRatelimitParams) error
 	InsertFrontlineRoutes(ctx context.Context, args []InsertFrontlineRouteParams) error
 	UpsertInstance(ctx context.Context, args []UpsertInstanceParams) error
+	InsertKeyBulkOperationItems(ctx context.Context, args []InsertKeyBulkOperationItemParams) error
 	InsertKeyEncryptions(ctx context.Context, args []InsertKeyEncryptionParams) error
+	InsertKeyExpiryNotifications(ctx context.Context, args []InsertKeyExpiryNotificationParams) error
 	InsertKeys(ctx context.Context, args []InsertKeyParams) error
 	InsertKeyRatelimits(ctx context.Context, args []InsertKeyRatelimitParams) error
 	InsertKeyPermissions(ctx context.Context, args []InsertKeyPermissionParams) error
 	InsertKeyRoles(ctx context.Context, args []InsertKeyRoleParams) error
+	InsertKeyRotations(ctx context.Context, args []InsertKeyRotationParams) error
+	InsertKeyRotationPolicies(ctx context.Context, args []InsertKeyRotationPolicyParams) error
 	InsertKeySpaces(ctx context.Context, args []InsertKeySpaceParams) error
 	UpsertLimit(ctx context.Context, args []UpsertLimitParams) error
 	UpsertOpenApiSpec(ctx context.Context, args []UpsertOpenApiSpecParams) error
@@ -44,6 +48,7 @@ type BulkQuerier interface {
 	InsertProjects(ctx context.Context, args []InsertProjectParams) error
 	InsertRoles(ctx context.Context, args []InsertRoleParams) error
 	InsertRolePermissions(ctx context.Context, args []InsertRolePermissionParams) error
+	InsertWebhookDeliveries(ctx context.Context, args []InsertWebhookDeliveryParams) error
 	InsertWorkspaceBillings(ctx context.Context, args []InsertWorkspaceBillingParams) error
 	InsertWorkspaces(ctx context.Context, args []InsertWorkspaceParams) error
 }
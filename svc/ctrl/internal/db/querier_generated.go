@@ -58,10 +58,19 @@ type Querier interface {
 	//  FROM custom_domains
 	//  WHERE workspace_id = ?
 	CountCustomDomainsByWorkspace(ctx context.Context, workspaceID string) (int64, error)
+	//CountKeyRotationsByKeyID
+	//
+	//  SELECT COUNT(*) FROM key_rotations WHERE key_id = ?
+	CountKeyRotationsByKeyID(ctx context.Context, keyID string) (int64, error)
 	//DeleteAcmeChallengeByDomainID
 	//
 	//  DELETE FROM acme_challenges WHERE domain_id = ?
 	DeleteAcmeChallengeByDomainID(ctx context.Context, domainID string) error
+	//DeleteAllKeyPermissionsByKeyID
+	//
+	//  DELETE FROM keys_permissions
+	//  WHERE key_id = ?
+	DeleteAllKeyPermissionsByKeyID(ctx context.Context, keyID string) error
 	//DeleteAppBuildSettingsByEnvironmentId
 	//
 	//  DELETE FROM app_build_settings WHERE environment_id = ?
@@ -119,6 +128,18 @@ type Querier interface {
 	//
 	//  DELETE FROM environments WHERE id = ?
 	DeleteEnvironmentById(ctx context.Context, id string) error
+	// DeleteExpiredIdempotencyKeys deletes a bounded batch of idempotency_keys
+	// rows whose replay window ended before the cutoff, and returns the number of
+	// rows deleted so the caller can loop until no expired rows are left.
+	//
+	// The API takes over expired rows in place when a key is reused, so this
+	// sweep only reclaims storage; the expires_at_m_idx index turns it into a
+	// range seek.
+	//
+	//  DELETE FROM idempotency_keys
+	//  WHERE expires_at_m < ?
+	//  LIMIT ?
+	DeleteExpiredIdempotencyKeys(ctx context.Context, arg DeleteExpiredIdempotencyKeysParams) (int64, error)
 	// DeleteExportedClickhouseOutbox hard-deletes a bounded batch of outbox rows
 	// that were already exported to ClickHouse (deleted_at stamped) before the
 	// retention cutoff, and returns the number of rows deleted so the caller can
@@ -251,7 +272,8 @@ type Querier interface {
 	//  	hap.replicas_min AS autoscaling_replicas_min,
 	//  	hap.replicas_max AS autoscaling_replicas_max,
 	//  	hap.cpu_threshold AS autoscaling_threshold_cpu,
-	//  	hap.memory_threshold AS autoscaling_threshold_memory
+	//  	hap.memory_threshold AS autoscaling_threshold_memory,
+	//  	hap.rps_threshold AS autoscaling_threshold_rps
 	//  FROM app_regional_settings ars
 	//  JOIN regions r ON r.id = ars.region_id
 	//  LEFT JOIN horizontal_autoscaling_policies hap ON hap.id = ars.horizontal_autoscaling_policy_id
@@ -413,7 +435,7 @@ type Querier interface {
 	// joined data needed for the Watch stream. Used by the unified WatchDeploymentChanges RPC.
 	//
 	//  SELECT
-	//      dt.pk, dt.workspace_id, dt.deployment_id, dt.region_id, dt.autoscaling_replicas_min, dt.autoscaling_replicas_max, dt.autoscaling_threshold_cpu, dt.autoscaling_threshold_memory, dt.desired_status, dt.created_at, dt.updated_at,
+	//      dt.pk, dt.workspace_id, dt.deployment_id, dt.region_id, dt.autoscaling_replicas_min, dt.autoscaling_replicas_max, dt.autoscaling_threshold_cpu, dt.autoscaling_threshold_memory, dt.autoscaling_threshold_rps, dt.desired_status, dt.created_at, dt.updated_at,
 	//      d.pk, d.id, d.k8s_name, d.workspace_id, d.project_id, d.environment_id, d.app_id, d.image, d.build_id, d.git_commit_sha, d.git_branch, d.git_commit_message, d.git_commit_author_handle, d.git_commit_author_avatar_url, d.git_commit_timestamp, d.sentinel_config, d.cpu_millicores, d.memory_mib, d.storage_mib, d.desired_state, d.encrypted_environment_variables, d.command, d.port, d.shutdown_signal, d.upstream_protocol, d.healthcheck, d.pr_number, d.fork_repository_full_name, d.github_deployment_id, d.invocation_id, d.status, d.`trigger`, d.triggered_by, d.trigger_reason, d.created_at, d.updated_at,
 	//      w.k8s_namespace,
 	//      e.slug AS environment_slug,
@@ -587,7 +609,7 @@ type Querier interface {
 	FindOpenApiSpecByDeploymentID(ctx context.Context, deploymentID sql.NullString) (OpenapiSpec, error)
 	//FindPermissionByNameAndWorkspaceID
 	//
-	//  SELECT pk, id, workspace_id, project_id, name, slug, description, created_at_m, updated_at_m
+	//  SELECT pk, id, workspace_id, project_id, name, slug, description, conditions, created_at_m, updated_at_m
 	//  FROM permissions
 	//  WHERE name = ?
 	//  AND workspace_id = ?
@@ -608,7 +630,9 @@ type Querier interface {
 	//                                         'id', ro.id,
 	//                                         'identifier', ro.identifier,
 	//                                         'limit', ro.limit,
-	//                                         'duration', ro.duration
+	//                                         'duration', ro.duration,
+	//                                         'priority', ro.priority,
+	//                                         'expires_at', ro.expires_at_m
 	//                                 )
 	//                         )
 	//                  from ratelimit_overrides ro where ro.namespace_id = ns.id AND ro.deleted_at_m IS NULL),
@@ -633,6 +657,34 @@ type Querier interface {
 	//    AND verification_status = 'verified'
 	//  LIMIT 1
 	FindVerifiedCustomDomainByDomainExcludingWorkspace(ctx context.Context, arg FindVerifiedCustomDomainByDomainExcludingWorkspaceParams) (CustomDomain, error)
+	// Returns the regions an app has ever been deployed to in an environment.
+	// Each of them holds its own claim for the app's volumes, so purging a volume
+	// fans out a deployment change to every one of them.
+	//
+	//  SELECT dt.region_id
+	//  FROM `deployment_topology` dt
+	//  INNER JOIN `deployments` d ON d.id = dt.deployment_id
+	//  WHERE d.app_id = ?
+	//    AND d.environment_id = ?
+	//  GROUP BY dt.region_id
+	FindVolumeRegions(ctx context.Context, arg FindVolumeRegionsParams) ([]string, error)
+	// Returns a volume together with the Kubernetes namespace its claim lives in.
+	// Used by the WatchDeploymentChanges stream to turn a volume change into an
+	// instruction for krane.
+	//
+	//  SELECT v.pk, v.id, v.workspace_id, v.project_id, v.app_id, v.environment_id, v.name, v.mount_path, v.size_mib, v.k8s_name, v.status, v.released_at, v.created_at, v.updated_at, w.k8s_namespace
+	//  FROM `volumes` v
+	//  INNER JOIN `workspaces` w ON w.id = v.workspace_id
+	//  WHERE v.id = ?
+	FindVolumeWithNamespaceById(ctx context.Context, id string) (FindVolumeWithNamespaceByIdRow, error)
+	//FindWebhookDeliveryByID
+	//
+	//  SELECT pk, id, workspace_id, endpoint_id, event_id, event_type, payload, status, attempts, response_status, last_error, next_attempt_at, delivered_at, created_at, updated_at FROM webhook_deliveries WHERE id = ?
+	FindWebhookDeliveryByID(ctx context.Context, id string) (WebhookDelivery, error)
+	//FindWebhookEndpointByID
+	//
+	//  SELECT pk, id, workspace_id, url, description, event_types, encrypted_secret, encryption_key_id, enabled, created_at, updated_at FROM webhook_endpoints WHERE id = ?
+	FindWebhookEndpointByID(ctx context.Context, id string) (WebhookEndpoint, error)
 	// Reads a workspace's billing row directly (Stripe linkage, tier, Compute plan,
 	// spend budget and spend-cap state). Used by the Deploy cancel path to read the
 	// current plan and Stripe subscription. When a workspace is already being
@@ -1023,6 +1075,7 @@ type Querier interface {
 	//      autoscaling_replicas_max,
 	//      autoscaling_threshold_cpu,
 	//      autoscaling_threshold_memory,
+	//      autoscaling_threshold_rps,
 	//      desired_status,
 	//      created_at
 	//  ) VALUES (
@@ -1034,6 +1087,7 @@ type Querier interface {
 	//      ?,
 	//      ?,
 	//      ?,
+	//      ?,
 	//      ?
 	//  )
 	InsertDeploymentTopology(ctx context.Context, arg InsertDeploymentTopologyParams) error
@@ -1166,7 +1220,8 @@ type Querier interface {
 	//      remaining_requests,
 	//      refill_day,
 	//      refill_amount,
-	//      pending_migration_id
+	//      pending_migration_id,
+	//      environment
 	//  ) VALUES (
 	//      ?,
 	//      ?,
@@ -1183,15 +1238,48 @@ type Querier interface {
 	//      ?,
 	//      ?,
 	//      ?,
+	//      ?,
 	//      ?
 	//  )
 	InsertKey(ctx context.Context, arg InsertKeyParams) error
+	// Items are inserted per resolved page; a retried page leaves the rows it
+	// already wrote, and their outcomes, untouched.
+	//
+	//  INSERT INTO key_bulk_operation_items (
+	//      operation_id,
+	//      key_id,
+	//      status
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      'pending'
+	//  ) ON DUPLICATE KEY UPDATE key_id = key_id
+	InsertKeyBulkOperationItem(ctx context.Context, arg InsertKeyBulkOperationItemParams) error
 	//InsertKeyEncryption
 	//
 	//  INSERT INTO encrypted_keys
 	//  (workspace_id, key_id, encrypted, encryption_key_id, created_at)
 	//  VALUES (?, ?, ?, ?, ?)
 	InsertKeyEncryption(ctx context.Context, arg InsertKeyEncryptionParams) error
+	// InsertKeyExpiryNotification records that a key was warned about its expiry
+	// at a threshold. A retried insert for the same key, threshold and expiry is a
+	// no-op.
+	//
+	//  INSERT INTO key_expiry_notifications (
+	//      key_id,
+	//      workspace_id,
+	//      threshold_ms,
+	//      expires,
+	//      created_at_m
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?
+	//  )
+	//  ON DUPLICATE KEY UPDATE pk = pk
+	InsertKeyExpiryNotification(ctx context.Context, arg InsertKeyExpiryNotificationParams) error
 	//InsertKeyPermission
 	//
 	//  INSERT INTO `keys_permissions` (
@@ -1247,6 +1335,42 @@ type Querier interface {
 	//    ?
 	//  )
 	InsertKeyRole(ctx context.Context, arg InsertKeyRoleParams) error
+	//InsertKeyRotation
+	//
+	//  INSERT INTO key_rotations (
+	//      key_id,
+	//      successor_key_id,
+	//      workspace_id,
+	//      key_auth_id,
+	//      reason,
+	//      created_at_m
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?
+	//  )
+	InsertKeyRotation(ctx context.Context, arg InsertKeyRotationParams) error
+	//InsertKeyRotationPolicy
+	//
+	//  INSERT INTO key_rotation_policies (
+	//      scope_id,
+	//      workspace_id,
+	//      key_auth_id,
+	//      interval_ms,
+	//      grace_period_ms,
+	//      created_at_m
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?
+	//  )
+	InsertKeyRotationPolicy(ctx context.Context, arg InsertKeyRotationPolicyParams) error
 	//InsertKeySpace
 	//
 	//  INSERT INTO `key_auth` (
@@ -1340,6 +1464,30 @@ type Querier interface {
 	//    ?
 	//  )
 	InsertRolePermission(ctx context.Context, arg InsertRolePermissionParams) error
+	//InsertWebhookDelivery
+	//
+	//  INSERT INTO webhook_deliveries (
+	//      id,
+	//      workspace_id,
+	//      endpoint_id,
+	//      event_id,
+	//      event_type,
+	//      payload,
+	//      status,
+	//      attempts,
+	//      created_at
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      'pending',
+	//      0,
+	//      ?
+	//  )
+	InsertWebhookDelivery(ctx context.Context, arg InsertWebhookDeliveryParams) error
 	//InsertWorkspace
 	//
 	//  INSERT INTO `workspaces` (
@@ -1381,11 +1529,21 @@ type Querier interface {
 	//  )
 	//  ON DUPLICATE KEY UPDATE workspace_id = workspace_id
 	InsertWorkspaceBilling(ctx context.Context, arg InsertWorkspaceBillingParams) error
+	// Returns the volumes every deployment of an app in an environment mounts,
+	// ordered by name so the rendered pod spec is stable across calls.
+	//
+	//  SELECT pk, id, workspace_id, project_id, app_id, environment_id, name, mount_path, size_mib, k8s_name, status, released_at, created_at, updated_at
+	//  FROM `volumes`
+	//  WHERE app_id = ?
+	//    AND environment_id = ?
+	//    AND status = 'active'
+	//  ORDER BY name ASC
+	ListActiveVolumesByAppAndEnvironment(ctx context.Context, arg ListActiveVolumesByAppAndEnvironmentParams) ([]Volume, error)
 	// ListAllDeploymentTopologiesByRegion returns running deployment topologies for a region, paginated by pk.
 	// Used by SyncDesiredState to reconcile krane agents with current desired state.
 	//
 	//  SELECT
-	//      dt.pk, dt.workspace_id, dt.deployment_id, dt.region_id, dt.autoscaling_replicas_min, dt.autoscaling_replicas_max, dt.autoscaling_threshold_cpu, dt.autoscaling_threshold_memory, dt.desired_status, dt.created_at, dt.updated_at,
+	//      dt.pk, dt.workspace_id, dt.deployment_id, dt.region_id, dt.autoscaling_replicas_min, dt.autoscaling_replicas_max, dt.autoscaling_threshold_cpu, dt.autoscaling_threshold_memory, dt.autoscaling_threshold_rps, dt.desired_status, dt.created_at, dt.updated_at,
 	//      d.pk, d.id, d.k8s_name, d.workspace_id, d.project_id, d.environment_id, d.app_id, d.image, d.build_id, d.git_commit_sha, d.git_branch, d.git_commit_message, d.git_commit_author_handle, d.git_commit_author_avatar_url, d.git_commit_timestamp, d.sentinel_config, d.cpu_millicores, d.memory_mib, d.storage_mib, d.desired_state, d.encrypted_environment_variables, d.command, d.port, d.shutdown_signal, d.upstream_protocol, d.healthcheck, d.pr_number, d.fork_repository_full_name, d.github_deployment_id, d.invocation_id, d.status, d.`trigger`, d.triggered_by, d.trigger_reason, d.created_at, d.updated_at,
 	//      w.k8s_namespace,
 	//      e.slug AS environment_slug,
@@ -1476,6 +1634,14 @@ type Querier interface {
 	//    AND created_at < ?
 	//    AND (updated_at IS null OR updated_at < ? )
 	ListDeploymentsByEnvironmentIdAndStatus(ctx context.Context, arg ListDeploymentsByEnvironmentIdAndStatusParams) ([]Deployment, error)
+	// Subscription filtering on event_types happens in Go; a workspace has a
+	// handful of endpoints at most, so there is nothing to gain from doing it
+	// in JSON functions here.
+	//
+	//  SELECT pk, id, workspace_id, url, description, event_types, encrypted_secret, encryption_key_id, enabled, created_at, updated_at FROM webhook_endpoints
+	//  WHERE workspace_id = ?
+	//    AND enabled = true
+	ListEnabledWebhookEndpointsByWorkspace(ctx context.Context, workspaceID string) ([]WebhookEndpoint, error)
 	//ListEnvVarsForRepoConnections
 	//
 	//  SELECT aev.app_id, aev.`key`, aev.value
@@ -1504,6 +1670,135 @@ type Querier interface {
 	//  AND dc.challenge_type IN (/*SLICE:verification_types*/?)
 	//  ORDER BY d.created_at ASC
 	ListExecutableChallenges(ctx context.Context, verificationTypes []AcmeChallengesChallengeType) ([]ListExecutableChallengesRow, error)
+	// ListIdentityCreditsForRefill returns identity credit pools that need their
+	// remaining credits refilled, selected by refill_day the same way as
+	// ListKeysForRefill and paginated by pk with the same deferred join.
+	// Pools are skipped if remaining >= refill_amount (already full).
+	//
+	//  SELECT ic.pk, ic.identity_id, ic.workspace_id, ic.refill_amount, ic.remaining, i.external_id
+	//  FROM `identity_credits` ic
+	//  INNER JOIN (
+	//      SELECT ici.pk
+	//      FROM `identity_credits` ici
+	//      WHERE ici.refill_amount IS NOT NULL
+	//        AND ici.refill_amount > ici.remaining
+	//        AND (
+	//            ici.refill_day IS NULL
+	//            OR ici.refill_day = ?
+	//            OR (? = 1 AND ici.refill_day > ?)
+	//        )
+	//        AND ici.pk > ?
+	//      ORDER BY pk
+	//      LIMIT ?
+	//  ) AS batch ON batch.pk = ic.pk
+	//  LEFT JOIN `identities` i ON i.id = ic.identity_id
+	ListIdentityCreditsForRefill(ctx context.Context, arg ListIdentityCreditsForRefillParams) ([]ListIdentityCreditsForRefillRow, error)
+	// ListKeyBulkOperationItems returns the next items of an operation together
+	// with their key's hash and recorded webhook event, so keys a previous attempt
+	// already changed can still have their cached copies invalidated and their
+	// webhooks dispatched.
+	//
+	//  SELECT i.pk, i.key_id, i.status, i.error, i.event_id, i.expires, i.updated_at_m, k.hash
+	//  FROM key_bulk_operation_items i
+	//  LEFT JOIN `keys` k ON k.id = i.key_id
+	//  WHERE i.operation_id = ?
+	//    AND i.pk > ?
+	//  ORDER BY i.pk
+	//  LIMIT ?
+	ListKeyBulkOperationItems(ctx context.Context, arg ListKeyBulkOperationItemsParams) ([]ListKeyBulkOperationItemsRow, error)
+	// ListKeyExpiryNotificationSettings pages through the keyspaces that opted
+	// into expiry notifications, with what a notification needs to name the api
+	// and reach the workspace's admins.
+	//
+	//  SELECT
+	//      s.pk, s.key_auth_id, s.workspace_id, s.thresholds_ms, s.email, s.slack_webhook_url,
+	//      a.id AS api_id,
+	//      a.name AS api_name,
+	//      w.name AS workspace_name,
+	//      w.slug AS workspace_slug,
+	//      w.org_id
+	//  FROM key_expiry_notification_settings s
+	//  JOIN key_auth ka ON ka.id = s.key_auth_id
+	//  JOIN apis a ON a.key_auth_id = s.key_auth_id
+	//  JOIN workspaces w ON w.id = s.workspace_id
+	//  WHERE s.pk > ?
+	//    AND ka.deleted_at_m IS NULL
+	//    AND a.deleted_at_m IS NULL
+	//    AND w.deleted_at_m IS NULL
+	//  ORDER BY s.pk
+	//  LIMIT ?
+	ListKeyExpiryNotificationSettings(ctx context.Context, arg ListKeyExpiryNotificationSettingsParams) ([]ListKeyExpiryNotificationSettingsRow, error)
+	// ListKeysDueForRotation returns recoverable keys whose rotation policy says a
+	// successor is due at now. A key's own policy wins over its keyspace's. Keys
+	// that already have a successor, from a reroll or an earlier rotation, are
+	// skipped; the successor carries the schedule forward. Keys without an
+	// encrypted copy are skipped too, because nobody could retrieve their
+	// successor.
+	//
+	//  SELECT
+	//      k.pk, k.id, k.key_auth_id, k.workspace_id, k.for_workspace_id, k.hash, k.start,
+	//      k.name, k.identity_id, k.meta, k.expires, k.enabled, k.remaining_requests,
+	//      k.refill_day, k.refill_amount, k.environment,
+	//      ka.default_prefix, ka.default_bytes,
+	//      a.id AS api_id,
+	//      kp.interval_ms AS key_interval_ms,
+	//      kp.grace_period_ms AS key_grace_period_ms,
+	//      sp.interval_ms AS keyspace_interval_ms,
+	//      sp.grace_period_ms AS keyspace_grace_period_ms
+	//  FROM `keys` k
+	//  JOIN key_auth ka ON ka.id = k.key_auth_id
+	//  JOIN apis a ON a.key_auth_id = k.key_auth_id
+	//  JOIN encrypted_keys ek ON ek.key_id = k.id
+	//  LEFT JOIN key_rotation_policies kp ON kp.scope_id = k.id
+	//  LEFT JOIN key_rotation_policies sp ON sp.scope_id = k.key_auth_id
+	//  WHERE k.pk > ?
+	//    AND k.deleted_at_m IS NULL
+	//    AND ka.deleted_at_m IS NULL
+	//    AND a.deleted_at_m IS NULL
+	//    AND ka.store_encrypted_keys = true
+	//    AND (k.expires IS NULL OR k.expires > NOW(3))
+	//    AND (
+	//        (kp.pk IS NOT NULL AND k.created_at_m + kp.interval_ms <= ?)
+	//        OR (kp.pk IS NULL AND sp.pk IS NOT NULL AND k.created_at_m + sp.interval_ms <= ?)
+	//    )
+	//    AND NOT EXISTS (SELECT 1 FROM key_rotations kr WHERE kr.key_id = k.id)
+	//  ORDER BY k.pk
+	//  LIMIT ?
+	ListKeysDueForRotation(ctx context.Context, arg ListKeysDueForRotationParams) ([]ListKeysDueForRotationRow, error)
+	// ListKeysExpiringForNotification pages through the enabled keys of a keyspace
+	// that expire in (now, horizon]. notified_threshold_ms is the tightest
+	// threshold the key was already warned at for its current expiry, or 0 if it
+	// was not warned yet; moving the expiry starts over.
+	//
+	//  SELECT
+	//      k.pk, k.id, k.name, k.start, k.expires,
+	//      CAST(COALESCE(MIN(n.threshold_ms), 0) AS SIGNED) AS notified_threshold_ms
+	//  FROM `keys` k
+	//  LEFT JOIN key_expiry_notifications n ON n.key_id = k.id AND n.expires = k.expires
+	//  WHERE k.key_auth_id = ?
+	//    AND k.pk > ?
+	//    AND k.deleted_at_m IS NULL
+	//    AND k.enabled = true
+	//    AND k.expires > ?
+	//    AND k.expires <= ?
+	//  GROUP BY k.pk
+	//  ORDER BY k.pk
+	//  LIMIT ?
+	ListKeysExpiringForNotification(ctx context.Context, arg ListKeysExpiringForNotificationParams) ([]ListKeysExpiringForNotificationRow, error)
+	// ListKeysForBulkOperation pages through the live keys of a keyspace that
+	// match a bulk operation's filter. A NULL identity_id or last_used_before
+	// matches every key; the meta filter is applied by the caller.
+	//
+	//  SELECT pk, id, meta
+	//  FROM `keys`
+	//  WHERE key_auth_id = ?
+	//    AND deleted_at_m IS NULL
+	//    AND pk > ?
+	//    AND (? IS NULL OR identity_id = ?)
+	//    AND (? IS NULL OR last_used_at < ?)
+	//  ORDER BY pk
+	//  LIMIT ?
+	ListKeysForBulkOperation(ctx context.Context, arg ListKeysForBulkOperationParams) ([]ListKeysForBulkOperationRow, error)
 	// ListKeysForRefill returns keys that need their remaining_requests refilled.
 	// Uses a deferred join on pk for stable cursor-based pagination that avoids
 	// OFFSET drift when rows are mutated between batches.
@@ -1546,6 +1841,10 @@ type Querier interface {
 	//    AND id != ?
 	//  ORDER BY created_at ASC
 	ListOlderActiveDeploymentsForDedup(ctx context.Context, arg ListOlderActiveDeploymentsForDedupParams) ([]ListOlderActiveDeploymentsForDedupRow, error)
+	//ListPermissionIDsByKeyID
+	//
+	//  SELECT permission_id FROM keys_permissions WHERE key_id = ?
+	ListPermissionIDsByKeyID(ctx context.Context, keyID string) ([]string, error)
 	//ListPreviewEnvironments
 	//
 	//  SELECT pk, id, workspace_id, project_id, app_id, slug, description, kind, delete_protection, created_at, updated_at
@@ -1568,10 +1867,30 @@ type Querier interface {
 	//  WHERE environment_id = ?
 	//    AND status IN (/*SLICE:progressing_statuses*/?)
 	ListProgressingDeploymentsByEnvironmentId(ctx context.Context, arg ListProgressingDeploymentsByEnvironmentIdParams) ([]ListProgressingDeploymentsByEnvironmentIdRow, error)
+	//ListRatelimitsByKeyID
+	//
+	//  SELECT
+	//    id,
+	//    name,
+	//    `limit`,
+	//    duration,
+	//    auto_apply
+	//  FROM ratelimits
+	//  WHERE key_id = ?
+	ListRatelimitsByKeyID(ctx context.Context, keyID sql.NullString) ([]ListRatelimitsByKeyIDRow, error)
 	//ListRegions
 	//
 	//  SELECT id, name, platform, can_schedule FROM regions
 	ListRegions(ctx context.Context) ([]ListRegionsRow, error)
+	// Returns the volumes of a workspace released at or before released_before,
+	// i.e. the ones whose retention window has passed.
+	//
+	//  SELECT pk, id, workspace_id, project_id, app_id, environment_id, name, mount_path, size_mib, k8s_name, status, released_at, created_at, updated_at
+	//  FROM `volumes`
+	//  WHERE workspace_id = ?
+	//    AND status = 'released'
+	//    AND released_at <= ?
+	ListReleasedVolumesByWorkspace(ctx context.Context, arg ListReleasedVolumesByWorkspaceParams) ([]Volume, error)
 	//ListRepoConnectionDeployContexts
 	//
 	//  SELECT
@@ -1596,6 +1915,10 @@ type Querier interface {
 	//  WHERE gc.installation_id = ?
 	//    AND gc.repository_id = ?
 	ListRepoConnectionDeployContexts(ctx context.Context, arg ListRepoConnectionDeployContextsParams) ([]ListRepoConnectionDeployContextsRow, error)
+	//ListRoleIDsByKeyID
+	//
+	//  SELECT role_id FROM keys_roles WHERE key_id = ?
+	ListRoleIDsByKeyID(ctx context.Context, keyID string) ([]string, error)
 	// ListRunningDeploymentsByBranch returns deployments in the same app,
 	// environment, and branch whose desired state is running, excluding one
 	// deployment id. Used to find sibling running deployments without including
@@ -1678,6 +2001,19 @@ type Querier interface {
 	//    AND w.enabled = true
 	//    AND w.deleted_at_m IS NULL
 	ListWorkspacesWithDeployBudget(ctx context.Context) ([]ListWorkspacesWithDeployBudgetRow, error)
+	// LockKeyForBulkOperation locks a live key for the rest of the transaction so
+	// a bulk change serializes with the API's own updates of the same key.
+	//
+	//  SELECT id, key_auth_id, workspace_id, hash, name, meta, expires, enabled
+	//  FROM `keys`
+	//  WHERE id = ? AND deleted_at_m IS NULL
+	//  FOR UPDATE
+	LockKeyForBulkOperation(ctx context.Context, id string) (LockKeyForBulkOperationRow, error)
+	// LockKeyForRotation locks a live key for the rest of the transaction so
+	// concurrent or retried rotations of the same key serialize on it.
+	//
+	//  SELECT id, expires FROM `keys` WHERE id = ? AND deleted_at_m IS NULL FOR UPDATE
+	LockKeyForRotation(ctx context.Context, id string) (LockKeyForRotationRow, error)
 	// MarkClickhouseOutboxBatchDeleted soft-deletes a set of pks after their CH
 	// insert is confirmed. Called inside the same transaction that selected
 	// them, so the row locks held by FOR UPDATE SKIP LOCKED are released as
@@ -1696,6 +2032,16 @@ type Querier interface {
 	//  WHERE pk IN (/*SLICE:pks*/?)
 	//    AND deleted_at IS NULL
 	MarkClickhouseOutboxBatchDeleted(ctx context.Context, arg MarkClickhouseOutboxBatchDeletedParams) error
+	// Marks a released volume as purged. Gated on status so a volume that was
+	// reactivated during its retention window is left alone; callers must check
+	// the affected row count before deleting any data.
+	//
+	//  UPDATE `volumes`
+	//  SET status = 'purged',
+	//      updated_at = ?
+	//  WHERE id = ?
+	//    AND status = 'released'
+	MarkVolumePurged(ctx context.Context, arg MarkVolumePurgedParams) (int64, error)
 	//ReassignFrontlineRoute
 	//
 	//  UPDATE frontline_routes
@@ -1761,6 +2107,16 @@ type Querier interface {
 	//  		)
 	//  	)
 	RecordInstanceExit(ctx context.Context, arg RecordInstanceExitParams) error
+	// RefillIdentityCreditsByIDs sets remaining to refill_amount for the given
+	// identities' credit pools.
+	//
+	//  UPDATE `identity_credits`
+	//  SET remaining = refill_amount,
+	//      last_refill_at = NOW(3),
+	//      updated_at_m = ?
+	//  WHERE identity_id IN (/*SLICE:identity_ids*/?)
+	//    AND refill_amount IS NOT NULL
+	RefillIdentityCreditsByIDs(ctx context.Context, arg RefillIdentityCreditsByIDsParams) error
 	// RefillKeysByIDs sets remaining_requests to refill_amount for the given keys.
 	// This is a bulk operation to minimize database round trips.
 	//
@@ -1771,6 +2127,17 @@ type Querier interface {
 	//  WHERE id IN (/*SLICE:ids*/?)
 	//    AND deleted_at_m IS NULL
 	RefillKeysByIDs(ctx context.Context, arg RefillKeysByIDsParams) error
+	// Marks every active volume of a workspace as released. Released volumes are
+	// no longer mounted but keep their data until PurgeVolumes runs after the
+	// retention window.
+	//
+	//  UPDATE `volumes`
+	//  SET status = 'released',
+	//      released_at = ?,
+	//      updated_at = ?
+	//  WHERE workspace_id = ?
+	//    AND status = 'active'
+	ReleaseVolumesByWorkspace(ctx context.Context, arg ReleaseVolumesByWorkspaceParams) (int64, error)
 	//ResetCustomDomainVerification
 	//
 	//  UPDATE custom_domains
@@ -1983,6 +2350,53 @@ type Querier interface {
 	//  SET desired_status = ?, updated_at = ?
 	//  WHERE deployment_id = ? AND region_id = ?
 	UpdateDeploymentTopologyDesiredStatus(ctx context.Context, arg UpdateDeploymentTopologyDesiredStatusParams) error
+	// UpdateKeyBulkOperationItem records an item's outcome together with the
+	// webhook event its change produced, so a retried batch can dispatch it.
+	//
+	//  UPDATE key_bulk_operation_items
+	//  SET status = ?,
+	//      error = ?,
+	//      event_id = ?,
+	//      expires = ?,
+	//      updated_at_m = ?
+	//  WHERE operation_id = ?
+	//    AND key_id = ?
+	UpdateKeyBulkOperationItem(ctx context.Context, arg UpdateKeyBulkOperationItemParams) error
+	//UpdateKeyBulkOperationStatus
+	//
+	//  UPDATE key_bulk_operations
+	//  SET status = ?,
+	//      succeeded = ?,
+	//      failed = ?,
+	//      error = ?,
+	//      updated_at_m = ?,
+	//      completed_at_m = ?
+	//  WHERE id = ?
+	UpdateKeyBulkOperationStatus(ctx context.Context, arg UpdateKeyBulkOperationStatusParams) error
+	//UpdateKeyBulkOperationTotal
+	//
+	//  UPDATE key_bulk_operations
+	//  SET total = ?,
+	//      updated_at_m = ?
+	//  WHERE id = ?
+	UpdateKeyBulkOperationTotal(ctx context.Context, arg UpdateKeyBulkOperationTotalParams) error
+	//UpdateKeyExpires
+	//
+	//  UPDATE `keys`
+	//  SET expires = ?,
+	//      updated_at_m = ?
+	//  WHERE id = ?
+	UpdateKeyExpires(ctx context.Context, arg UpdateKeyExpiresParams) error
+	//UpdateKeyForBulkOperation
+	//
+	//  UPDATE `keys`
+	//  SET name = ?,
+	//      meta = ?,
+	//      expires = ?,
+	//      enabled = ?,
+	//      updated_at_m = ?
+	//  WHERE id = ?
+	UpdateKeyForBulkOperation(ctx context.Context, arg UpdateKeyForBulkOperationParams) error
 	//UpdateKeysLastUsed
 	//
 	//  UPDATE `keys`
@@ -1998,6 +2412,19 @@ type Querier interface {
 	//      updated_at = ?
 	//  WHERE id = ?
 	UpdateProjectDepotID(ctx context.Context, arg UpdateProjectDepotIDParams) error
+	//UpdateWebhookDeliveryAttempt
+	//
+	//  UPDATE webhook_deliveries
+	//  SET
+	//      status = ?,
+	//      attempts = ?,
+	//      response_status = ?,
+	//      last_error = ?,
+	//      next_attempt_at = ?,
+	//      delivered_at = ?,
+	//      updated_at = ?
+	//  WHERE id = ?
+	UpdateWebhookDeliveryAttempt(ctx context.Context, arg UpdateWebhookDeliveryAttemptParams) error
 	//UpdateWorkspaceEnabled
 	//
 	//  UPDATE `workspaces`
@@ -10,7 +10,8 @@ SELECT
 	hap.replicas_min AS autoscaling_replicas_min,
 	hap.replicas_max AS autoscaling_replicas_max,
 	hap.cpu_threshold AS autoscaling_threshold_cpu,
-	hap.memory_threshold AS autoscaling_threshold_memory
+	hap.memory_threshold AS autoscaling_threshold_memory,
+	hap.rps_threshold AS autoscaling_threshold_rps
 FROM app_regional_settings ars
 JOIN regions r ON r.id = ars.region_id
 LEFT JOIN horizontal_autoscaling_policies hap ON hap.id = ars.horizontal_autoscaling_policy_id
@@ -0,0 +1,11 @@
+-- name: DeleteExpiredIdempotencyKeys :execrows
+-- DeleteExpiredIdempotencyKeys deletes a bounded batch of idempotency_keys
+-- rows whose replay window ended before the cutoff, and returns the number of
+-- rows deleted so the caller can loop until no expired rows are left.
+--
+-- The API takes over expired rows in place when a key is reused, so this
+-- sweep only reclaims storage; the expires_at_m_idx index turns it into a
+-- range seek.
+DELETE FROM idempotency_keys
+WHERE expires_at_m < sqlc.arg('cutoff')
+LIMIT ?;
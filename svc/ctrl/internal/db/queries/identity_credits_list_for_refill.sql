@@ -0,0 +1,22 @@
+-- name: ListIdentityCreditsForRefill :many
+-- ListIdentityCreditsForRefill returns identity credit pools that need their
+-- remaining credits refilled, selected by refill_day the same way as
+-- ListKeysForRefill and paginated by pk with the same deferred join.
+-- Pools are skipped if remaining >= refill_amount (already full).
+SELECT ic.pk, ic.identity_id, ic.workspace_id, ic.refill_amount, ic.remaining, i.external_id
+FROM `identity_credits` ic
+INNER JOIN (
+    SELECT ici.pk
+    FROM `identity_credits` ici
+    WHERE ici.refill_amount IS NOT NULL
+      AND ici.refill_amount > ici.remaining
+      AND (
+          ici.refill_day IS NULL
+          OR ici.refill_day = sqlc.arg(today_day)
+          OR (sqlc.arg(is_last_day_of_month) = 1 AND ici.refill_day > sqlc.arg(today_day))
+      )
+      AND ici.pk > sqlc.arg(after_pk)
+    ORDER BY pk
+    LIMIT ?
+) AS batch ON batch.pk = ic.pk
+LEFT JOIN `identities` i ON i.id = ic.identity_id;
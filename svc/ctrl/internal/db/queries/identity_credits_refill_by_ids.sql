@@ -0,0 +1,9 @@
+-- name: RefillIdentityCreditsByIDs :exec
+-- RefillIdentityCreditsByIDs sets remaining to refill_amount for the given
+-- identities' credit pools.
+UPDATE `identity_credits`
+SET remaining = refill_amount,
+    last_refill_at = NOW(3),
+    updated_at_m = sqlc.arg(now)
+WHERE identity_id IN (sqlc.slice(identity_ids))
+  AND refill_amount IS NOT NULL;
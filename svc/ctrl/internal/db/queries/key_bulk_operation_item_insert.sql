@@ -0,0 +1,12 @@
+-- name: InsertKeyBulkOperationItem :exec
+-- Items are inserted per resolved page; a retried page leaves the rows it
+-- already wrote, and their outcomes, untouched.
+INSERT INTO key_bulk_operation_items (
+    operation_id,
+    key_id,
+    status
+) VALUES (
+    sqlc.arg(operation_id),
+    sqlc.arg(key_id),
+    'pending'
+) ON DUPLICATE KEY UPDATE key_id = key_id;
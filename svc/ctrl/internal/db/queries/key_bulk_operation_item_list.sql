@@ -0,0 +1,12 @@
+-- name: ListKeyBulkOperationItems :many
+-- ListKeyBulkOperationItems returns the next items of an operation together
+-- with their key's hash and recorded webhook event, so keys a previous attempt
+-- already changed can still have their cached copies invalidated and their
+-- webhooks dispatched.
+SELECT i.pk, i.key_id, i.status, i.error, i.event_id, i.expires, i.updated_at_m, k.hash
+FROM key_bulk_operation_items i
+LEFT JOIN `keys` k ON k.id = i.key_id
+WHERE i.operation_id = sqlc.arg(operation_id)
+  AND i.pk > sqlc.arg(after_pk)
+ORDER BY i.pk
+LIMIT ?;
@@ -0,0 +1,11 @@
+-- name: UpdateKeyBulkOperationItem :exec
+-- UpdateKeyBulkOperationItem records an item's outcome together with the
+-- webhook event its change produced, so a retried batch can dispatch it.
+UPDATE key_bulk_operation_items
+SET status = sqlc.arg(status),
+    error = sqlc.arg(error),
+    event_id = sqlc.arg(event_id),
+    expires = sqlc.arg(expires),
+    updated_at_m = sqlc.arg(now)
+WHERE operation_id = sqlc.arg(operation_id)
+  AND key_id = sqlc.arg(key_id);
@@ -0,0 +1,9 @@
+-- name: UpdateKeyBulkOperationStatus :exec
+UPDATE key_bulk_operations
+SET status = sqlc.arg(status),
+    succeeded = sqlc.arg(succeeded),
+    failed = sqlc.arg(failed),
+    error = sqlc.arg(error),
+    updated_at_m = sqlc.arg(now),
+    completed_at_m = sqlc.arg(completed_at_m)
+WHERE id = sqlc.arg(id);
@@ -0,0 +1,5 @@
+-- name: UpdateKeyBulkOperationTotal :exec
+UPDATE key_bulk_operations
+SET total = sqlc.arg(total),
+    updated_at_m = sqlc.arg(now)
+WHERE id = sqlc.arg(id);
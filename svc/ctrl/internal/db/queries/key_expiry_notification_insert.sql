@@ -0,0 +1,18 @@
+-- name: InsertKeyExpiryNotification :exec
+-- InsertKeyExpiryNotification records that a key was warned about its expiry
+-- at a threshold. A retried insert for the same key, threshold and expiry is a
+-- no-op.
+INSERT INTO key_expiry_notifications (
+    key_id,
+    workspace_id,
+    threshold_ms,
+    expires,
+    created_at_m
+) VALUES (
+    sqlc.arg(key_id),
+    sqlc.arg(workspace_id),
+    sqlc.arg(threshold_ms),
+    sqlc.arg(expires),
+    sqlc.arg(created_at_m)
+)
+ON DUPLICATE KEY UPDATE pk = pk;
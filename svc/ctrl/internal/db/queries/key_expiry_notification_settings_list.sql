@@ -0,0 +1,21 @@
+-- name: ListKeyExpiryNotificationSettings :many
+-- ListKeyExpiryNotificationSettings pages through the keyspaces that opted
+-- into expiry notifications, with what a notification needs to name the api
+-- and reach the workspace's admins.
+SELECT
+    s.pk, s.key_auth_id, s.workspace_id, s.thresholds_ms, s.email, s.slack_webhook_url,
+    a.id AS api_id,
+    a.name AS api_name,
+    w.name AS workspace_name,
+    w.slug AS workspace_slug,
+    w.org_id
+FROM key_expiry_notification_settings s
+JOIN key_auth ka ON ka.id = s.key_auth_id
+JOIN apis a ON a.key_auth_id = s.key_auth_id
+JOIN workspaces w ON w.id = s.workspace_id
+WHERE s.pk > sqlc.arg(after_pk)
+  AND ka.deleted_at_m IS NULL
+  AND a.deleted_at_m IS NULL
+  AND w.deleted_at_m IS NULL
+ORDER BY s.pk
+LIMIT ?;
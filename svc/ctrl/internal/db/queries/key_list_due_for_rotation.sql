@@ -0,0 +1,36 @@
+-- name: ListKeysDueForRotation :many
+-- ListKeysDueForRotation returns recoverable keys whose rotation policy says a
+-- successor is due at now. A key's own policy wins over its keyspace's. Keys
+-- that already have a successor, from a reroll or an earlier rotation, are
+-- skipped; the successor carries the schedule forward. Keys without an
+-- encrypted copy are skipped too, because nobody could retrieve their
+-- successor.
+SELECT
+    k.pk, k.id, k.key_auth_id, k.workspace_id, k.for_workspace_id, k.hash, k.start,
+    k.name, k.identity_id, k.meta, k.expires, k.enabled, k.remaining_requests,
+    k.refill_day, k.refill_amount, k.environment,
+    ka.default_prefix, ka.default_bytes,
+    a.id AS api_id,
+    kp.interval_ms AS key_interval_ms,
+    kp.grace_period_ms AS key_grace_period_ms,
+    sp.interval_ms AS keyspace_interval_ms,
+    sp.grace_period_ms AS keyspace_grace_period_ms
+FROM `keys` k
+JOIN key_auth ka ON ka.id = k.key_auth_id
+JOIN apis a ON a.key_auth_id = k.key_auth_id
+JOIN encrypted_keys ek ON ek.key_id = k.id
+LEFT JOIN key_rotation_policies kp ON kp.scope_id = k.id
+LEFT JOIN key_rotation_policies sp ON sp.scope_id = k.key_auth_id
+WHERE k.pk > sqlc.arg(after_pk)
+  AND k.deleted_at_m IS NULL
+  AND ka.deleted_at_m IS NULL
+  AND a.deleted_at_m IS NULL
+  AND ka.store_encrypted_keys = true
+  AND (k.expires IS NULL OR k.expires > NOW(3))
+  AND (
+      (kp.pk IS NOT NULL AND k.created_at_m + kp.interval_ms <= sqlc.arg(now))
+      OR (kp.pk IS NULL AND sp.pk IS NOT NULL AND k.created_at_m + sp.interval_ms <= sqlc.arg(now))
+  )
+  AND NOT EXISTS (SELECT 1 FROM key_rotations kr WHERE kr.key_id = k.id)
+ORDER BY k.pk
+LIMIT ?;
@@ -0,0 +1,19 @@
+-- name: ListKeysExpiringForNotification :many
+-- ListKeysExpiringForNotification pages through the enabled keys of a keyspace
+-- that expire in (now, horizon]. notified_threshold_ms is the tightest
+-- threshold the key was already warned at for its current expiry, or 0 if it
+-- was not warned yet; moving the expiry starts over.
+SELECT
+    k.pk, k.id, k.name, k.start, k.expires,
+    CAST(COALESCE(MIN(n.threshold_ms), 0) AS SIGNED) AS notified_threshold_ms
+FROM `keys` k
+LEFT JOIN key_expiry_notifications n ON n.key_id = k.id AND n.expires = k.expires
+WHERE k.key_auth_id = sqlc.arg(key_auth_id)
+  AND k.pk > sqlc.arg(after_pk)
+  AND k.deleted_at_m IS NULL
+  AND k.enabled = true
+  AND k.expires > sqlc.arg(now)
+  AND k.expires <= sqlc.arg(horizon)
+GROUP BY k.pk
+ORDER BY k.pk
+LIMIT ?;
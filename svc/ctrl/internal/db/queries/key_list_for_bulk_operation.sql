@@ -0,0 +1,13 @@
+-- name: ListKeysForBulkOperation :many
+-- ListKeysForBulkOperation pages through the live keys of a keyspace that
+-- match a bulk operation's filter. A NULL identity_id or last_used_before
+-- matches every key; the meta filter is applied by the caller.
+SELECT pk, id, meta
+FROM `keys`
+WHERE key_auth_id = sqlc.arg(key_auth_id)
+  AND deleted_at_m IS NULL
+  AND pk > sqlc.arg(after_pk)
+  AND (sqlc.narg(identity_id) IS NULL OR identity_id = sqlc.narg(identity_id))
+  AND (sqlc.narg(last_used_before) IS NULL OR last_used_at < sqlc.narg(last_used_before))
+ORDER BY pk
+LIMIT ?;
@@ -0,0 +1,7 @@
+-- name: LockKeyForBulkOperation :one
+-- LockKeyForBulkOperation locks a live key for the rest of the transaction so
+-- a bulk change serializes with the API's own updates of the same key.
+SELECT id, key_auth_id, workspace_id, hash, name, meta, expires, enabled
+FROM `keys`
+WHERE id = sqlc.arg(id) AND deleted_at_m IS NULL
+FOR UPDATE;
@@ -0,0 +1,4 @@
+-- name: LockKeyForRotation :one
+-- LockKeyForRotation locks a live key for the rest of the transaction so
+-- concurrent or retried rotations of the same key serialize on it.
+SELECT id, expires FROM `keys` WHERE id = sqlc.arg(id) AND deleted_at_m IS NULL FOR UPDATE;
@@ -0,0 +1,3 @@
+-- name: DeleteAllKeyPermissionsByKeyID :exec
+DELETE FROM keys_permissions
+WHERE key_id = sqlc.arg(key_id);
@@ -0,0 +1,2 @@
+-- name: ListPermissionIDsByKeyID :many
+SELECT permission_id FROM keys_permissions WHERE key_id = sqlc.arg(key_id);
@@ -0,0 +1,2 @@
+-- name: ListRoleIDsByKeyID :many
+SELECT role_id FROM keys_roles WHERE key_id = sqlc.arg(key_id);
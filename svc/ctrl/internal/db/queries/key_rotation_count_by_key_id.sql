@@ -0,0 +1,2 @@
+-- name: CountKeyRotationsByKeyID :one
+SELECT COUNT(*) FROM key_rotations WHERE key_id = sqlc.arg(key_id);
@@ -0,0 +1,16 @@
+-- name: InsertKeyRotationPolicy :exec
+INSERT INTO key_rotation_policies (
+    scope_id,
+    workspace_id,
+    key_auth_id,
+    interval_ms,
+    grace_period_ms,
+    created_at_m
+) VALUES (
+    sqlc.arg(scope_id),
+    sqlc.arg(workspace_id),
+    sqlc.arg(key_auth_id),
+    sqlc.arg(interval_ms),
+    sqlc.arg(grace_period_ms),
+    sqlc.arg(created_at_m)
+);
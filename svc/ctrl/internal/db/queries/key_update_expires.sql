@@ -0,0 +1,5 @@
+-- name: UpdateKeyExpires :exec
+UPDATE `keys`
+SET expires = sqlc.arg(expires),
+    updated_at_m = sqlc.arg(now)
+WHERE id = sqlc.arg(id);
@@ -0,0 +1,8 @@
+-- name: UpdateKeyForBulkOperation :exec
+UPDATE `keys`
+SET name = sqlc.arg(name),
+    meta = sqlc.arg(meta),
+    expires = sqlc.arg(expires),
+    enabled = sqlc.arg(enabled),
+    updated_at_m = sqlc.arg(now)
+WHERE id = sqlc.arg(id);
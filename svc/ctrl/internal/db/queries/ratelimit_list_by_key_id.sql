@@ -0,0 +1,9 @@
+-- name: ListRatelimitsByKeyID :many
+SELECT
+  id,
+  name,
+  `limit`,
+  duration,
+  auto_apply
+FROM ratelimits
+WHERE key_id = sqlc.arg(key_id);
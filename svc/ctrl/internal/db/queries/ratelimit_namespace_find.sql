@@ -6,7 +6,9 @@ SELECT *,
                                        'id', ro.id,
                                        'identifier', ro.identifier,
                                        'limit', ro.limit,
-                                       'duration', ro.duration
+                                       'duration', ro.duration,
+                                       'priority', ro.priority,
+                                       'expires_at', ro.expires_at_m
                                )
                        )
                 from ratelimit_overrides ro where ro.namespace_id = ns.id AND ro.deleted_at_m IS NULL),
@@ -0,0 +1,8 @@
+-- name: FindVolumeWithNamespaceById :one
+-- Returns a volume together with the Kubernetes namespace its claim lives in.
+-- Used by the WatchDeploymentChanges stream to turn a volume change into an
+-- instruction for krane.
+SELECT v.*, w.k8s_namespace
+FROM `volumes` v
+INNER JOIN `workspaces` w ON w.id = v.workspace_id
+WHERE v.id = sqlc.arg(id);
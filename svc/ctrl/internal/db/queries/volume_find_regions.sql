@@ -0,0 +1,10 @@
+-- name: FindVolumeRegions :many
+-- Returns the regions an app has ever been deployed to in an environment.
+-- Each of them holds its own claim for the app's volumes, so purging a volume
+-- fans out a deployment change to every one of them.
+SELECT dt.region_id
+FROM `deployment_topology` dt
+INNER JOIN `deployments` d ON d.id = dt.deployment_id
+WHERE d.app_id = sqlc.arg(app_id)
+  AND d.environment_id = sqlc.arg(environment_id)
+GROUP BY dt.region_id;
@@ -0,0 +1,9 @@
+-- name: ListActiveVolumesByAppAndEnvironment :many
+-- Returns the volumes every deployment of an app in an environment mounts,
+-- ordered by name so the rendered pod spec is stable across calls.
+SELECT *
+FROM `volumes`
+WHERE app_id = sqlc.arg(app_id)
+  AND environment_id = sqlc.arg(environment_id)
+  AND status = 'active'
+ORDER BY name ASC;
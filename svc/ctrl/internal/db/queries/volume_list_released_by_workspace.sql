@@ -0,0 +1,8 @@
+-- name: ListReleasedVolumesByWorkspace :many
+-- Returns the volumes of a workspace released at or before released_before,
+-- i.e. the ones whose retention window has passed.
+SELECT *
+FROM `volumes`
+WHERE workspace_id = sqlc.arg(workspace_id)
+  AND status = 'released'
+  AND released_at <= sqlc.arg(released_before);
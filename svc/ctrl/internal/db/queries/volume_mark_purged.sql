@@ -0,0 +1,9 @@
+-- name: MarkVolumePurged :execrows
+-- Marks a released volume as purged. Gated on status so a volume that was
+-- reactivated during its retention window is left alone; callers must check
+-- the affected row count before deleting any data.
+UPDATE `volumes`
+SET status = 'purged',
+    updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id)
+  AND status = 'released';
@@ -0,0 +1,10 @@
+-- name: ReleaseVolumesByWorkspace :execrows
+-- Marks every active volume of a workspace as released. Released volumes are
+-- no longer mounted but keep their data until PurgeVolumes runs after the
+-- retention window.
+UPDATE `volumes`
+SET status = 'released',
+    released_at = sqlc.arg(released_at),
+    updated_at = sqlc.arg(released_at)
+WHERE workspace_id = sqlc.arg(workspace_id)
+  AND status = 'active';
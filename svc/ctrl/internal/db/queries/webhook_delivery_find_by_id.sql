@@ -0,0 +1,2 @@
+-- name: FindWebhookDeliveryByID :one
+SELECT * FROM webhook_deliveries WHERE id = sqlc.arg(id);
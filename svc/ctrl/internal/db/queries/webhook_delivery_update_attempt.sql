@@ -0,0 +1,11 @@
+-- name: UpdateWebhookDeliveryAttempt :exec
+UPDATE webhook_deliveries
+SET
+    status = sqlc.arg(status),
+    attempts = sqlc.arg(attempts),
+    response_status = sqlc.narg(response_status),
+    last_error = sqlc.narg(last_error),
+    next_attempt_at = sqlc.narg(next_attempt_at),
+    delivered_at = sqlc.narg(delivered_at),
+    updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id);
@@ -0,0 +1,7 @@
+-- name: ListEnabledWebhookEndpointsByWorkspace :many
+-- Subscription filtering on event_types happens in Go; a workspace has a
+-- handful of endpoints at most, so there is nothing to gain from doing it
+-- in JSON functions here.
+SELECT * FROM webhook_endpoints
+WHERE workspace_id = sqlc.arg(workspace_id)
+  AND enabled = true;
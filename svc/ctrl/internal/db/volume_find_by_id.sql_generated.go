@@ -0,0 +1,67 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: volume_find_by_id.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const findVolumeWithNamespaceById = `-- name: FindVolumeWithNamespaceById :one
+SELECT v.pk, v.id, v.workspace_id, v.project_id, v.app_id, v.environment_id, v.name, v.mount_path, v.size_mib, v.k8s_name, v.status, v.released_at, v.created_at, v.updated_at, w.k8s_namespace
+FROM ` + "`" + `volumes` + "`" + ` v
+INNER JOIN ` + "`" + `workspaces` + "`" + ` w ON w.id = v.workspace_id
+WHERE v.id = ?
+`
+
+type FindVolumeWithNamespaceByIdRow struct {
+	Pk            uint64         `db:"pk"`
+	ID            string         `db:"id"`
+	WorkspaceID   string         `db:"workspace_id"`
+	ProjectID     string         `db:"project_id"`
+	AppID         string         `db:"app_id"`
+	EnvironmentID string         `db:"environment_id"`
+	Name          string         `db:"name"`
+	MountPath     string         `db:"mount_path"`
+	SizeMib       uint32         `db:"size_mib"`
+	K8sName       string         `db:"k8s_name"`
+	Status        VolumesStatus  `db:"status"`
+	ReleasedAt    sql.NullInt64  `db:"released_at"`
+	CreatedAt     int64          `db:"created_at"`
+	UpdatedAt     sql.NullInt64  `db:"updated_at"`
+	K8sNamespace  sql.NullString `db:"k8s_namespace"`
+}
+
+// Returns a volume together with the Kubernetes namespace its claim lives in.
+// Used by the WatchDeploymentChanges stream to turn a volume change into an
+// instruction for krane.
+//
+//	SELECT v.pk, v.id, v.workspace_id, v.project_id, v.app_id, v.environment_id, v.name, v.mount_path, v.size_mib, v.k8s_name, v.status, v.released_at, v.created_at, v.updated_at, w.k8s_namespace
+//	FROM `volumes` v
+//	INNER JOIN `workspaces` w ON w.id = v.workspace_id
+//	WHERE v.id = ?
+func (q *Queries) FindVolumeWithNamespaceById(ctx context.Context, id string) (FindVolumeWithNamespaceByIdRow, error) {
+	row := q.db.QueryRowContext(ctx, findVolumeWithNamespaceById, id)
+	var i FindVolumeWithNamespaceByIdRow
+	err := row.Scan(
+		&i.Pk,
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ProjectID,
+		&i.AppID,
+		&i.EnvironmentID,
+		&i.Name,
+		&i.MountPath,
+		&i.SizeMib,
+		&i.K8sName,
+		&i.Status,
+		&i.ReleasedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.K8sNamespace,
+	)
+	return i, err
+}
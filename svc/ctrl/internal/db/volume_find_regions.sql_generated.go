@@ -0,0 +1,57 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: volume_find_regions.sql
+
+package db
+
+import (
+	"context"
+)
+
+const findVolumeRegions = `-- name: FindVolumeRegions :many
+SELECT dt.region_id
+FROM ` + "`" + `deployment_topology` + "`" + ` dt
+INNER JOIN ` + "`" + `deployments` + "`" + ` d ON d.id = dt.deployment_id
+WHERE d.app_id = ?
+  AND d.environment_id = ?
+GROUP BY dt.region_id
+`
+
+type FindVolumeRegionsParams struct {
+	AppID         string `db:"app_id"`
+	EnvironmentID string `db:"environment_id"`
+}
+
+// Returns the regions an app has ever been deployed to in an environment.
+// Each of them holds its own claim for the app's volumes, so purging a volume
+// fans out a deployment change to every one of them.
+//
+//	SELECT dt.region_id
+//	FROM `deployment_topology` dt
+//	INNER JOIN `deployments` d ON d.id = dt.deployment_id
+//	WHERE d.app_id = ?
+//	  AND d.environment_id = ?
+//	GROUP BY dt.region_id
+func (q *Queries) FindVolumeRegions(ctx context.Context, arg FindVolumeRegionsParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, findVolumeRegions, arg.AppID, arg.EnvironmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var regionID string
+		if err := rows.Scan(&regionID); err != nil {
+			return nil, err
+		}
+		items = append(items, regionID)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
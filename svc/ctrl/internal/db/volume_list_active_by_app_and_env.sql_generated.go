@@ -0,0 +1,71 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: volume_list_active_by_app_and_env.sql
+
+package db
+
+import (
+	"context"
+)
+
+const listActiveVolumesByAppAndEnvironment = `-- name: ListActiveVolumesByAppAndEnvironment :many
+SELECT pk, id, workspace_id, project_id, app_id, environment_id, name, mount_path, size_mib, k8s_name, status, released_at, created_at, updated_at
+FROM ` + "`" + `volumes` + "`" + `
+WHERE app_id = ?
+  AND environment_id = ?
+  AND status = 'active'
+ORDER BY name ASC
+`
+
+type ListActiveVolumesByAppAndEnvironmentParams struct {
+	AppID         string `db:"app_id"`
+	EnvironmentID string `db:"environment_id"`
+}
+
+// Returns the volumes every deployment of an app in an environment mounts,
+// ordered by name so the rendered pod spec is stable across calls.
+//
+//	SELECT pk, id, workspace_id, project_id, app_id, environment_id, name, mount_path, size_mib, k8s_name, status, released_at, created_at, updated_at
+//	FROM `volumes`
+//	WHERE app_id = ?
+//	  AND environment_id = ?
+//	  AND status = 'active'
+//	ORDER BY name ASC
+func (q *Queries) ListActiveVolumesByAppAndEnvironment(ctx context.Context, arg ListActiveVolumesByAppAndEnvironmentParams) ([]Volume, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveVolumesByAppAndEnvironment, arg.AppID, arg.EnvironmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Volume
+	for rows.Next() {
+		var i Volume
+		if err := rows.Scan(
+			&i.Pk,
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ProjectID,
+			&i.AppID,
+			&i.EnvironmentID,
+			&i.Name,
+			&i.MountPath,
+			&i.SizeMib,
+			&i.K8sName,
+			&i.Status,
+			&i.ReleasedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
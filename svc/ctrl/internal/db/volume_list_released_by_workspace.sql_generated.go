@@ -0,0 +1,70 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: volume_list_released_by_workspace.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const listReleasedVolumesByWorkspace = `-- name: ListReleasedVolumesByWorkspace :many
+SELECT pk, id, workspace_id, project_id, app_id, environment_id, name, mount_path, size_mib, k8s_name, status, released_at, created_at, updated_at
+FROM ` + "`" + `volumes` + "`" + `
+WHERE workspace_id = ?
+  AND status = 'released'
+  AND released_at <= ?
+`
+
+type ListReleasedVolumesByWorkspaceParams struct {
+	WorkspaceID    string        `db:"workspace_id"`
+	ReleasedBefore sql.NullInt64 `db:"released_before"`
+}
+
+// Returns the volumes of a workspace released at or before released_before,
+// i.e. the ones whose retention window has passed.
+//
+//	SELECT pk, id, workspace_id, project_id, app_id, environment_id, name, mount_path, size_mib, k8s_name, status, released_at, created_at, updated_at
+//	FROM `volumes`
+//	WHERE workspace_id = ?
+//	  AND status = 'released'
+//	  AND released_at <= ?
+func (q *Queries) ListReleasedVolumesByWorkspace(ctx context.Context, arg ListReleasedVolumesByWorkspaceParams) ([]Volume, error) {
+	rows, err := q.db.QueryContext(ctx, listReleasedVolumesByWorkspace, arg.WorkspaceID, arg.ReleasedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Volume
+	for rows.Next() {
+		var i Volume
+		if err := rows.Scan(
+			&i.Pk,
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ProjectID,
+			&i.AppID,
+			&i.EnvironmentID,
+			&i.Name,
+			&i.MountPath,
+			&i.SizeMib,
+			&i.K8sName,
+			&i.Status,
+			&i.ReleasedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
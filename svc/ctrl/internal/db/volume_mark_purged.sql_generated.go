@@ -0,0 +1,41 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: volume_mark_purged.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const markVolumePurged = `-- name: MarkVolumePurged :execrows
+UPDATE ` + "`" + `volumes` + "`" + `
+SET status = 'purged',
+    updated_at = ?
+WHERE id = ?
+  AND status = 'released'
+`
+
+type MarkVolumePurgedParams struct {
+	UpdatedAt sql.NullInt64 `db:"updated_at"`
+	ID        string        `db:"id"`
+}
+
+// Marks a released volume as purged. Gated on status so a volume that was
+// reactivated during its retention window is left alone; callers must check
+// the affected row count before deleting any data.
+//
+//	UPDATE `volumes`
+//	SET status = 'purged',
+//	    updated_at = ?
+//	WHERE id = ?
+//	  AND status = 'released'
+func (q *Queries) MarkVolumePurged(ctx context.Context, arg MarkVolumePurgedParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markVolumePurged, arg.UpdatedAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,43 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: volume_release_by_workspace.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const releaseVolumesByWorkspace = `-- name: ReleaseVolumesByWorkspace :execrows
+UPDATE ` + "`" + `volumes` + "`" + `
+SET status = 'released',
+    released_at = ?,
+    updated_at = ?
+WHERE workspace_id = ?
+  AND status = 'active'
+`
+
+type ReleaseVolumesByWorkspaceParams struct {
+	ReleasedAt  sql.NullInt64 `db:"released_at"`
+	WorkspaceID string        `db:"workspace_id"`
+}
+
+// Marks every active volume of a workspace as released. Released volumes are
+// no longer mounted but keep their data until PurgeVolumes runs after the
+// retention window.
+//
+//	UPDATE `volumes`
+//	SET status = 'released',
+//	    released_at = ?,
+//	    updated_at = ?
+//	WHERE workspace_id = ?
+//	  AND status = 'active'
+func (q *Queries) ReleaseVolumesByWorkspace(ctx context.Context, arg ReleaseVolumesByWorkspaceParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, releaseVolumesByWorkspace, arg.ReleasedAt, arg.ReleasedAt, arg.WorkspaceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,40 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: webhook_delivery_find_by_id.sql
+
+package db
+
+import (
+	"context"
+)
+
+const findWebhookDeliveryByID = `-- name: FindWebhookDeliveryByID :one
+SELECT pk, id, workspace_id, endpoint_id, event_id, event_type, payload, status, attempts, response_status, last_error, next_attempt_at, delivered_at, created_at, updated_at FROM webhook_deliveries WHERE id = ?
+`
+
+// FindWebhookDeliveryByID
+//
+//	SELECT pk, id, workspace_id, endpoint_id, event_id, event_type, payload, status, attempts, response_status, last_error, next_attempt_at, delivered_at, created_at, updated_at FROM webhook_deliveries WHERE id = ?
+func (q *Queries) FindWebhookDeliveryByID(ctx context.Context, id string) (WebhookDelivery, error) {
+	row := q.db.QueryRowContext(ctx, findWebhookDeliveryByID, id)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.Pk,
+		&i.ID,
+		&i.WorkspaceID,
+		&i.EndpointID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.ResponseStatus,
+		&i.LastError,
+		&i.NextAttemptAt,
+		&i.DeliveredAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
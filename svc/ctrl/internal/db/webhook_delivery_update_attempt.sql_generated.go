@@ -0,0 +1,61 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: webhook_delivery_update_attempt.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const updateWebhookDeliveryAttempt = `-- name: UpdateWebhookDeliveryAttempt :exec
+UPDATE webhook_deliveries
+SET
+    status = ?,
+    attempts = ?,
+    response_status = ?,
+    last_error = ?,
+    next_attempt_at = ?,
+    delivered_at = ?,
+    updated_at = ?
+WHERE id = ?
+`
+
+type UpdateWebhookDeliveryAttemptParams struct {
+	Status         WebhookDeliveriesStatus `db:"status"`
+	Attempts       uint32                  `db:"attempts"`
+	ResponseStatus sql.NullInt32           `db:"response_status"`
+	LastError      sql.NullString          `db:"last_error"`
+	NextAttemptAt  sql.NullInt64           `db:"next_attempt_at"`
+	DeliveredAt    sql.NullInt64           `db:"delivered_at"`
+	UpdatedAt      sql.NullInt64           `db:"updated_at"`
+	ID             string                  `db:"id"`
+}
+
+// UpdateWebhookDeliveryAttempt
+//
+//	UPDATE webhook_deliveries
+//	SET
+//	    status = ?,
+//	    attempts = ?,
+//	    response_status = ?,
+//	    last_error = ?,
+//	    next_attempt_at = ?,
+//	    delivered_at = ?,
+//	    updated_at = ?
+//	WHERE id = ?
+func (q *Queries) UpdateWebhookDeliveryAttempt(ctx context.Context, arg UpdateWebhookDeliveryAttemptParams) error {
+	_, err := q.db.ExecContext(ctx, updateWebhookDeliveryAttempt,
+		arg.Status,
+		arg.Attempts,
+		arg.ResponseStatus,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.DeliveredAt,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	return err
+}
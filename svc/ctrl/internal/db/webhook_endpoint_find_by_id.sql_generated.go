@@ -0,0 +1,36 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: webhook_endpoint_find_by_id.sql
+
+package db
+
+import (
+	"context"
+)
+
+const findWebhookEndpointByID = `-- name: FindWebhookEndpointByID :one
+SELECT pk, id, workspace_id, url, description, event_types, encrypted_secret, encryption_key_id, enabled, created_at, updated_at FROM webhook_endpoints WHERE id = ?
+`
+
+// FindWebhookEndpointByID
+//
+//	SELECT pk, id, workspace_id, url, description, event_types, encrypted_secret, encryption_key_id, enabled, created_at, updated_at FROM webhook_endpoints WHERE id = ?
+func (q *Queries) FindWebhookEndpointByID(ctx context.Context, id string) (WebhookEndpoint, error) {
+	row := q.db.QueryRowContext(ctx, findWebhookEndpointByID, id)
+	var i WebhookEndpoint
+	err := row.Scan(
+		&i.Pk,
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Url,
+		&i.Description,
+		&i.EventTypes,
+		&i.EncryptedSecret,
+		&i.EncryptionKeyID,
+		&i.Enabled,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,58 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: webhook_endpoint_list_enabled_by_workspace.sql
+
+package db
+
+import (
+	"context"
+)
+
+const listEnabledWebhookEndpointsByWorkspace = `-- name: ListEnabledWebhookEndpointsByWorkspace :many
+SELECT pk, id, workspace_id, url, description, event_types, encrypted_secret, encryption_key_id, enabled, created_at, updated_at FROM webhook_endpoints
+WHERE workspace_id = ?
+  AND enabled = true
+`
+
+// Subscription filtering on event_types happens in Go; a workspace has a
+// handful of endpoints at most, so there is nothing to gain from doing it
+// in JSON functions here.
+//
+//	SELECT pk, id, workspace_id, url, description, event_types, encrypted_secret, encryption_key_id, enabled, created_at, updated_at FROM webhook_endpoints
+//	WHERE workspace_id = ?
+//	  AND enabled = true
+func (q *Queries) ListEnabledWebhookEndpointsByWorkspace(ctx context.Context, workspaceID string) ([]WebhookEndpoint, error) {
+	rows, err := q.db.QueryContext(ctx, listEnabledWebhookEndpointsByWorkspace, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookEndpoint
+	for rows.Next() {
+		var i WebhookEndpoint
+		if err := rows.Scan(
+			&i.Pk,
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Url,
+			&i.Description,
+			&i.EventTypes,
+			&i.EncryptedSecret,
+			&i.EncryptionKeyID,
+			&i.Enabled,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
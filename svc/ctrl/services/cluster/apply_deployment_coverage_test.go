@@ -22,6 +22,7 @@ func fullDeploymentRow() deploymentRow {
 			AutoscalingReplicasMax:     5,
 			AutoscalingThresholdCpu:    sql.NullInt16{Valid: true, Int16: 80},
 			AutoscalingThresholdMemory: sql.NullInt16{Valid: true, Int16: 75},
+			AutoscalingThresholdRps:    sql.NullInt16{Valid: true, Int16: 50},
 		},
 		d: db.Deployment{
 			ID:                            "deploy_sentinel",
@@ -51,6 +52,13 @@ func fullDeploymentRow() deploymentRow {
 		environmentSlug: "production",
 		regionName:      "us-east-1",
 		gitRepo:         sql.NullString{Valid: true, String: "github.com/test/sentinel"},
+		volumes: []db.Volume{{
+			ID:        "vol_sentinel",
+			K8sName:   "vol-sentinel",
+			MountPath: "/var/lib/sentinel",
+			SizeMib:   4096,
+			Status:    db.VolumesStatusActive,
+		}},
 	}
 }
 
@@ -131,11 +139,22 @@ var producerFieldAssertions = map[string]func(t *testing.T, a *ctrlv1.ApplyDeplo
 		require.NotNil(t, a.GetAutoscaling())
 		require.Equal(t, uint32(2), a.GetAutoscaling().GetMinReplicas())
 		require.Equal(t, uint32(5), a.GetAutoscaling().GetMaxReplicas())
+		require.Equal(t, int32(80), a.GetAutoscaling().GetCpuThreshold())
+		require.Equal(t, int32(75), a.GetAutoscaling().GetMemoryThreshold())
+		require.Equal(t, int32(50), a.GetAutoscaling().GetRpsThreshold())
 	},
 	"ephemeral_storage": func(t *testing.T, a *ctrlv1.ApplyDeployment) {
 		require.NotNil(t, a.GetEphemeralStorage())
 		require.Equal(t, int64(2048), a.GetEphemeralStorage().GetSizeMib())
 	},
+	"volumes": func(t *testing.T, a *ctrlv1.ApplyDeployment) {
+		require.Len(t, a.GetVolumes(), 1)
+		v := a.GetVolumes()[0]
+		require.Equal(t, "vol_sentinel", v.GetVolumeId())
+		require.Equal(t, "vol-sentinel", v.GetK8SName())
+		require.Equal(t, "/var/lib/sentinel", v.GetMountPath())
+		require.Equal(t, int64(4096), v.GetSizeMib())
+	},
 }
 
 // TestDeploymentRowToState_PopulatesProtoFields converts a fully-populated row
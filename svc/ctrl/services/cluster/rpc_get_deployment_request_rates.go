@@ -0,0 +1,68 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	ctrlv1 "github.com/unkeyed/unkey/gen/proto/ctrl/v1"
+	"github.com/unkeyed/unkey/pkg/clickhouse"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/auth"
+)
+
+// requestRateWindow is how far back GetDeploymentRequestRates averages
+// frontline traffic. A few minutes smooths out single-minute bursts without
+// making the replica floor slow to follow a sustained ramp.
+const requestRateWindow = 3 * time.Minute
+
+// maxRequestRateDeployments bounds a single GetDeploymentRequestRates call.
+// Krane sends one batch per poll covering every rate-scaled deployment in its
+// cluster, which stays well below this.
+const maxRequestRateDeployments = 1_000
+
+// GetDeploymentRequestRates returns the recent request rate of each requested
+// deployment in the calling cluster's region, as measured by frontline, for
+// krane's request-rate autoscaler.
+//
+// Frontline routes traffic to the nearest healthy region, so regions of one
+// deployment can see very different load. Counting only the traffic served in
+// the caller's region sizes each region's replica floor for what it actually
+// handles.
+//
+// Deployments with no recent traffic in the region are omitted from the
+// response. Returns CodeUnauthenticated if the bearer token is invalid,
+// CodeInvalidArgument if the cluster key is missing or too many deployments
+// are requested, and CodeUnavailable if ClickHouse cannot be queried.
+func (s *Service) GetDeploymentRequestRates(ctx context.Context, req *connect.Request[ctrlv1.GetDeploymentRequestRatesRequest]) (*connect.Response[ctrlv1.GetDeploymentRequestRatesResponse], error) {
+	if err := auth.Authenticate(req, s.bearer); err != nil {
+		return nil, err
+	}
+
+	cluster, err := s.resolveCluster(ctx, req.Msg.GetCluster())
+	if err != nil {
+		return nil, err
+	}
+
+	deploymentIDs := req.Msg.GetDeploymentIds()
+	if len(deploymentIDs) > maxRequestRateDeployments {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("at most %d deployment_ids may be requested, got %d", maxRequestRateDeployments, len(deploymentIDs)))
+	}
+
+	rates, err := s.clickhouse.GetDeploymentRequestRates(ctx, clickhouse.GetDeploymentRequestRatesRequest{
+		DeploymentIDs: deploymentIDs,
+		Platform:      cluster.Region.Platform,
+		Region:        cluster.Region.Name,
+		Window:        requestRateWindow,
+	})
+	if err != nil {
+		logger.Error("failed to query deployment request rates", "error", err, "deployments", len(deploymentIDs))
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	return connect.NewResponse(&ctrlv1.GetDeploymentRequestRatesResponse{
+		RequestsPerSecond: rates,
+	}), nil
+}
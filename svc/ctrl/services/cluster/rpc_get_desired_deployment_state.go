@@ -42,6 +42,11 @@ func (s *Service) GetDesiredDeploymentState(ctx context.Context, req *connect.Re
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
+	volumes, err := s.listVolumes(ctx, row.Deployment)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
 	state, err := deploymentRowToState(deploymentRow{
 		dt:              row.DeploymentTopology,
 		d:               row.Deployment,
@@ -49,6 +54,7 @@ func (s *Service) GetDesiredDeploymentState(ctx context.Context, req *connect.Re
 		environmentSlug: row.EnvironmentSlug,
 		regionName:      row.RegionName,
 		gitRepo:         row.GitRepo,
+		volumes:         volumes,
 	}, 0)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
@@ -60,8 +60,20 @@ func (s *Service) syncDeployments(
 		if err != nil {
 			return connect.NewError(connect.CodeInternal, err)
 		}
+		// Deployments of the same app and environment share their volumes, so
+		// look each pair up once per page.
+		volumesByAppEnv := make(map[[2]string][]db.Volume)
 		for _, row := range rows {
 			afterPk = row.DeploymentTopology.Pk
+			appEnv := [2]string{row.Deployment.AppID, row.Deployment.EnvironmentID}
+			volumes, ok := volumesByAppEnv[appEnv]
+			if !ok {
+				volumes, err = s.listVolumes(ctx, row.Deployment)
+				if err != nil {
+					return connect.NewError(connect.CodeInternal, err)
+				}
+				volumesByAppEnv[appEnv] = volumes
+			}
 			state, err := deploymentRowToState(deploymentRow{
 				dt:              row.DeploymentTopology,
 				d:               row.Deployment,
@@ -69,6 +81,7 @@ func (s *Service) syncDeployments(
 				environmentSlug: row.EnvironmentSlug,
 				regionName:      row.RegionName,
 				gitRepo:         row.GitRepo,
+				volumes:         volumes,
 			}, 0)
 			if err != nil {
 				logger.Error("full sync: failed to convert deployment row", "error", err)
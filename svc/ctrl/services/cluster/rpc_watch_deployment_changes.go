@@ -159,6 +159,10 @@ func (s *Service) loadChangeEvent(ctx context.Context, change db.DeploymentChang
 		if err != nil {
 			return nil, err
 		}
+		volumes, err := s.listVolumes(ctx, row.Deployment)
+		if err != nil {
+			return nil, err
+		}
 		state, err := deploymentRowToState(deploymentRow{
 			dt:              row.DeploymentTopology,
 			d:               row.Deployment,
@@ -166,6 +170,7 @@ func (s *Service) loadChangeEvent(ctx context.Context, change db.DeploymentChang
 			environmentSlug: row.EnvironmentSlug,
 			regionName:      row.RegionName,
 			gitRepo:         row.GitRepo,
+			volumes:         volumes,
 		}, change.Pk)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %w", errUnrecoverable, err)
@@ -178,6 +183,25 @@ func (s *Service) loadChangeEvent(ctx context.Context, change db.DeploymentChang
 			Event:   &ctrlv1.DeploymentChangeEvent_Deployment{Deployment: state},
 		}, nil
 
+	case db.DeploymentChangesResourceTypeVolume:
+		v, err := s.db.FindVolumeWithNamespaceById(ctx, change.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+		// Only purges travel through the outbox. Mounts are part of every
+		// ApplyDeployment, so any other status needs no instruction.
+		if v.Status != db.VolumesStatusPurged {
+			return &ctrlv1.DeploymentChangeEvent{Version: change.Pk}, nil
+		}
+		return &ctrlv1.DeploymentChangeEvent{
+			Version: change.Pk,
+			Event: &ctrlv1.DeploymentChangeEvent_DeleteVolume{DeleteVolume: &ctrlv1.DeleteVolume{
+				K8SNamespace: v.K8sNamespace.String,
+				K8SName:      v.K8sName,
+				VolumeId:     v.ID,
+			}},
+		}, nil
+
 	case db.DeploymentChangesResourceTypeCiliumNetworkPolicy:
 		// Cilium resources are no longer dispatched — frontline took
 		// over the request path. The outbox row exists during the
@@ -204,6 +228,16 @@ type deploymentRow struct {
 	environmentSlug string
 	regionName      string
 	gitRepo         sql.NullString
+	volumes         []db.Volume
+}
+
+// listVolumes returns the persistent volumes every deployment of the app
+// mounts in the deployment's environment.
+func (s *Service) listVolumes(ctx context.Context, d db.Deployment) ([]db.Volume, error) {
+	return s.db.ListActiveVolumesByAppAndEnvironment(ctx, db.ListActiveVolumesByAppAndEnvironmentParams{
+		AppID:         d.AppID,
+		EnvironmentID: d.EnvironmentID,
+	})
 }
 
 // deploymentRowToState converts a deployment row to a proto DeploymentState message.
@@ -276,6 +310,9 @@ func deploymentRowToState(row deploymentRow, version uint64) (*ctrlv1.Deployment
 		if row.dt.AutoscalingThresholdMemory.Valid {
 			policy.MemoryThreshold = ptr.P(int32(row.dt.AutoscalingThresholdMemory.Int16))
 		}
+		if row.dt.AutoscalingThresholdRps.Valid {
+			policy.RpsThreshold = ptr.P(int32(row.dt.AutoscalingThresholdRps.Int16))
+		}
 		apply.Autoscaling = policy
 
 		if row.d.StorageMib > 0 {
@@ -284,6 +321,15 @@ func deploymentRowToState(row deploymentRow, version uint64) (*ctrlv1.Deployment
 			}
 		}
 
+		for _, v := range row.volumes {
+			apply.Volumes = append(apply.Volumes, &ctrlv1.VolumeMount{
+				VolumeId:  v.ID,
+				K8SName:   v.K8sName,
+				MountPath: v.MountPath,
+				SizeMib:   int64(v.SizeMib),
+			})
+		}
+
 		return &ctrlv1.DeploymentState{
 			Version: version,
 			State: &ctrlv1.DeploymentState_Apply{
@@ -8,6 +8,7 @@ import (
 	"github.com/unkeyed/unkey/gen/proto/ctrl/v1/ctrlv1connect"
 	"github.com/unkeyed/unkey/pkg/batch"
 	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/clickhouse"
 	"github.com/unkeyed/unkey/pkg/clickhouse/schema"
 	"github.com/unkeyed/unkey/pkg/clock"
 	"github.com/unkeyed/unkey/pkg/logger"
@@ -73,6 +74,10 @@ type Service struct {
 	// flushing them to ClickHouse. Always non-nil — if ClickHouse isn't
 	// configured for the api process this is a noop processor.
 	instanceEvents *batch.BatchProcessor[schema.InstanceEventV1]
+	// clickhouse reads frontline request aggregates for request-rate
+	// autoscaling. Always non-nil — a noop when ClickHouse isn't configured,
+	// in which case every deployment reports no traffic.
+	clickhouse clickhouse.Querier
 	// regionalDomain is the base domain for per-region wildcard certificates
 	// (*.{region}.{platform}.{regionalDomain}). When a new region registers via
 	// Heartbeat, EnsureInfraCertificate provisions its wildcard cert. Empty
@@ -108,6 +113,11 @@ type Config struct {
 	// (batch.NewNoop) when ClickHouse is unavailable.
 	InstanceEvents *batch.BatchProcessor[schema.InstanceEventV1]
 
+	// ClickHouse serves the frontline request rates behind
+	// GetDeploymentRequestRates. Required — pass clickhouse.NewNoop() when
+	// ClickHouse is unavailable.
+	ClickHouse clickhouse.Querier
+
 	// RegionalDomain is the base domain for per-region wildcard certificates
 	// (*.{region}.{platform}.{RegionalDomain}). Empty disables automatic
 	// region certificate issuance on Heartbeat.
@@ -127,6 +137,9 @@ func New(cfg Config) (*Service, error) {
 	if cfg.InstanceEvents == nil {
 		return nil, fmt.Errorf("cluster: InstanceEvents is required (use batch.NewNoop when ClickHouse is unavailable)")
 	}
+	if cfg.ClickHouse == nil {
+		return nil, fmt.Errorf("cluster: ClickHouse is required (use clickhouse.NewNoop when ClickHouse is unavailable)")
+	}
 
 	clk := cfg.Clock
 	if clk == nil {
@@ -161,6 +174,7 @@ func New(cfg Config) (*Service, error) {
 		clusterCache:                       clusterCache,
 		topologyCache:                      cfg.TopologyCache,
 		instanceEvents:                     cfg.InstanceEvents,
+		clickhouse:                         cfg.ClickHouse,
 		regionalDomain:                     cfg.RegionalDomain,
 		provisionedCerts:                   provisionedCerts,
 	}
@@ -11,13 +11,15 @@ import (
 )
 
 // stubDatabase implements db.Database for fetchDeploymentChangeEvents tests.
-// Only the two methods the function calls are overridden; calling anything
-// else panics via the embedded nil interface.
+// Only the methods the function calls are overridden; calling anything else
+// panics via the embedded nil interface.
 type stubDatabase struct {
 	db.Database
-	changes []db.DeploymentChange
-	findRow db.FindDeploymentTopologyByDeploymentAndRegionRow
-	findErr error
+	changes   []db.DeploymentChange
+	findRow   db.FindDeploymentTopologyByDeploymentAndRegionRow
+	findErr   error
+	volumes   []db.Volume
+	volumeRow db.FindVolumeWithNamespaceByIdRow
 }
 
 func (s *stubDatabase) ListDeploymentChangesByRegionAll(_ context.Context, _ db.ListDeploymentChangesByRegionAllParams) ([]db.DeploymentChange, error) {
@@ -28,6 +30,14 @@ func (s *stubDatabase) FindDeploymentTopologyByDeploymentAndRegion(_ context.Con
 	return s.findRow, s.findErr
 }
 
+func (s *stubDatabase) ListActiveVolumesByAppAndEnvironment(_ context.Context, _ db.ListActiveVolumesByAppAndEnvironmentParams) ([]db.Volume, error) {
+	return s.volumes, nil
+}
+
+func (s *stubDatabase) FindVolumeWithNamespaceById(_ context.Context, _ string) (db.FindVolumeWithNamespaceByIdRow, error) {
+	return s.volumeRow, nil
+}
+
 func topologyChange(pk uint64) db.DeploymentChange {
 	return db.DeploymentChange{
 		Pk:           pk,
@@ -108,3 +118,53 @@ func TestFetchDeploymentChangeEvents_Success(t *testing.T) {
 	require.NotNil(t, events[0].GetDeployment().GetApply())
 	require.Equal(t, "deploy_test", events[0].GetDeployment().GetApply().GetDeploymentId())
 }
+
+// A purged volume becomes a DeleteVolume instruction for krane.
+func TestFetchDeploymentChangeEvents_PurgedVolume(t *testing.T) {
+	svc := &Service{db: &stubDatabase{
+		changes: []db.DeploymentChange{{
+			Pk:           43,
+			ResourceType: db.DeploymentChangesResourceTypeVolume,
+			ResourceID:   "vol_test",
+			RegionID:     "region_test",
+		}},
+		volumeRow: db.FindVolumeWithNamespaceByIdRow{
+			ID:           "vol_test",
+			K8sName:      "vol-test",
+			Status:       db.VolumesStatusPurged,
+			K8sNamespace: sql.NullString{Valid: true, String: "ns-test"},
+		},
+	}}
+
+	events, err := svc.fetchDeploymentChangeEvents(context.Background(), "region_test", 0)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, uint64(43), events[0].GetVersion())
+	del := events[0].GetDeleteVolume()
+	require.NotNil(t, del)
+	require.Equal(t, "ns-test", del.GetK8SNamespace())
+	require.Equal(t, "vol-test", del.GetK8SName())
+	require.Equal(t, "vol_test", del.GetVolumeId())
+}
+
+// A volume that was reactivated before the change was read must not be
+// deleted; the row is acknowledged with a bare version event.
+func TestFetchDeploymentChangeEvents_ActiveVolumeSkips(t *testing.T) {
+	svc := &Service{db: &stubDatabase{
+		changes: []db.DeploymentChange{{
+			Pk:           44,
+			ResourceType: db.DeploymentChangesResourceTypeVolume,
+			ResourceID:   "vol_test",
+			RegionID:     "region_test",
+		}},
+		volumeRow: db.FindVolumeWithNamespaceByIdRow{
+			ID:     "vol_test",
+			Status: db.VolumesStatusActive,
+		},
+	}}
+
+	events, err := svc.fetchDeploymentChangeEvents(context.Background(), "region_test", 0)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Nil(t, events[0].GetEvent())
+}
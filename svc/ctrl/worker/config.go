@@ -216,6 +216,16 @@ type HeartbeatConfig struct {
 	// Optional - if empty, no heartbeat is sent.
 	KeyRefillURL string `toml:"key_refill_url"`
 
+	// KeyRotationURL is the heartbeat URL for the hourly key rotation runs.
+	// When set, a heartbeat is sent after successful rotation runs.
+	// Optional - if empty, no heartbeat is sent.
+	KeyRotationURL string `toml:"key_rotation_url"`
+
+	// KeyExpiryURL is the heartbeat URL for the hourly key expiry
+	// notification runs. When set, a heartbeat is sent after successful runs.
+	// Optional - if empty, no heartbeat is sent.
+	KeyExpiryURL string `toml:"key_expiry_url"`
+
 	// KeyLastUsedSyncURL is the heartbeat URL for key last-used sync runs.
 	// When set, a heartbeat is sent after successful sync runs.
 	// Optional - if empty, no heartbeat is sent.
@@ -232,6 +242,12 @@ type HeartbeatConfig struct {
 	// Optional - if empty, no heartbeat is sent.
 	AuditLogOutboxCleanupURL string `toml:"audit_log_outbox_cleanup_url"`
 
+	// IdempotencyKeysCleanupURL is the heartbeat URL for the hourly sweep
+	// that deletes expired idempotency_keys rows. When set, a heartbeat is
+	// sent after a successful sweep.
+	// Optional - if empty, no heartbeat is sent.
+	IdempotencyKeysCleanupURL string `toml:"idempotency_keys_cleanup_url"`
+
 	// RatelimitGlobalCountersCleanupURL is the heartbeat URL for the hourly
 	// sweep that deletes expired global rate limit counters. When set, a
 	// heartbeat is sent after a successful sweep.
@@ -273,7 +289,8 @@ type SlackConfig struct {
 }
 
 // EmailConfig holds transactional email (Resend) configuration. Used by the
-// spend-cap check to send budget alerts. Disabled (logs instead of sending)
+// spend-cap check to send budget alerts and by the key expiry cron to send
+// expiry warnings. Disabled (logs instead of sending)
 // unless ResendAPIKey is set. Sender and subject come from the published
 // template, so there is no From to configure here.
 type EmailConfig struct {
@@ -281,6 +298,14 @@ type EmailConfig struct {
 	ResendAPIKey string `toml:"resend_api_key"`
 }
 
+// WebhooksConfig holds outbound webhook delivery settings.
+type WebhooksConfig struct {
+	// AllowPrivateNetworks lets webhook deliveries reach loopback and private
+	// addresses. Endpoint URLs are customer-supplied, so this must stay off
+	// everywhere except local development.
+	AllowPrivateNetworks bool `toml:"allow_private_networks"`
+}
+
 // Config holds the complete configuration for the Restate worker service.
 // It is designed to be loaded from a TOML file using [config.Load]:
 //
@@ -366,9 +391,18 @@ type Config struct {
 	// Email configures transactional email (Resend) for budget alerts.
 	Email EmailConfig `toml:"email"`
 
-	// WorkOSAPIKey authenticates the spend-cap check's lookup of org admin
-	// emails (budget-alert recipients). Empty resolves no recipients, so the
-	// check logs crossings but sends no email.
+	// Webhooks configures outbound webhook delivery.
+	Webhooks WebhooksConfig `toml:"webhooks"`
+
+	// RedisURL is the Redis the API nodes exchange cache invalidations on.
+	// When set, workers that change keys invalidate the API's cached copies
+	// of them; when empty, the API picks the changes up once its cache
+	// entries go stale, and the KeyBulkService is not bound.
+	RedisURL string `toml:"redis_url"`
+
+	// WorkOSAPIKey authenticates the lookup of org admin emails, the
+	// recipients of budget alerts and key expiry warnings. Empty resolves no
+	// recipients, so both log what they would send but email nobody.
 	WorkOSAPIKey string `toml:"workos_api_key"`
 
 	// Clock provides time operations for testing and scheduling.
@@ -25,19 +25,25 @@ import (
 	"github.com/unkeyed/unkey/svc/ctrl/internal/billingmeter"
 	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
 	"github.com/unkeyed/unkey/svc/ctrl/internal/invoicecloser"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/slack"
 	"github.com/unkeyed/unkey/svc/ctrl/internal/workos"
 	"github.com/unkeyed/unkey/svc/ctrl/worker/cron/auditlogcleanup"
 	"github.com/unkeyed/unkey/svc/ctrl/worker/cron/auditlogexport"
 	"github.com/unkeyed/unkey/svc/ctrl/worker/cron/deploybilling"
 	"github.com/unkeyed/unkey/svc/ctrl/worker/cron/deployspendcheck"
+	"github.com/unkeyed/unkey/svc/ctrl/worker/cron/idempotencycleanup"
 	"github.com/unkeyed/unkey/svc/ctrl/worker/cron/idlepreview"
+	"github.com/unkeyed/unkey/svc/ctrl/worker/cron/keyexpiry"
 	"github.com/unkeyed/unkey/svc/ctrl/worker/cron/keylastusedsync"
 	"github.com/unkeyed/unkey/svc/ctrl/worker/cron/keyrefill"
+	"github.com/unkeyed/unkey/svc/ctrl/worker/cron/keyrotation"
 	"github.com/unkeyed/unkey/svc/ctrl/worker/cron/quotacheck"
 	"github.com/unkeyed/unkey/svc/ctrl/worker/cron/ratelimitcleanup"
 
 	restate "github.com/restatedev/sdk-go"
 	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/gen/rpc/vault"
+	"github.com/unkeyed/unkey/internal/services/caches"
 	rldb "github.com/unkeyed/unkey/internal/services/ratelimit/db"
 )
 
@@ -53,9 +59,12 @@ type Service struct {
 	deployBillingPush    *deploybilling.PushHandler
 	deploySpendCheck     *deployspendcheck.Handler
 	deploySpendCheckWork *deployspendcheck.CheckHandler
+	idempotencyCleanup   *idempotencycleanup.Handler
 	idlePreview          *idlepreview.Handler
+	keyExpiry            *keyexpiry.Handler
 	keyLastUsedSync      *keylastusedsync.Handler
 	keyRefill            *keyrefill.Handler
+	keyRotation          *keyrotation.Handler
 	quotaCheck           *quotacheck.Handler
 	ratelimitCleanup     *ratelimitcleanup.Handler
 }
@@ -83,9 +92,12 @@ func (s *Service) DeploySpendCheckServer() hydrav1.DeploySpendCheckServiceServer
 type Heartbeats struct {
 	QuotaCheck         healthcheck.Heartbeat
 	KeyRefill          healthcheck.Heartbeat
+	KeyRotation        healthcheck.Heartbeat
+	KeyExpiry          healthcheck.Heartbeat
 	KeyLastUsedSync    healthcheck.Heartbeat
 	AuditLogExport     healthcheck.Heartbeat
 	AuditLogCleanup    healthcheck.Heartbeat
+	IdempotencyCleanup healthcheck.Heartbeat
 	RatelimitCleanup   healthcheck.Heartbeat
 	DeployBillingPush  healthcheck.Heartbeat
 	DeployBillingClose healthcheck.Heartbeat
@@ -104,6 +116,12 @@ type Config struct {
 	Clock clock.Clock
 	// RatelimitDB wraps the ratelimit database. Must not be nil.
 	RatelimitDB *rldb.Database
+	// Vault encrypts the successors issued by key rotation. Nil disables
+	// key rotation.
+	Vault vault.VaultServiceClient
+	// Caches evicts keys replaced by a rotation from the API caches once
+	// their grace period ends. Optional.
+	Caches *caches.Invalidator
 
 	// SlackQuotaCheckWebhookURL is the Slack webhook for quota-exceeded
 	// notifications. Empty disables Slack notifications.
@@ -150,9 +168,12 @@ func New(cfg Config) (*Service, error) {
 		assert.NotNil(cfg.RatelimitDB, "RatelimitDB must not be nil"),
 		assert.NotNil(cfg.Heartbeats.QuotaCheck, "Heartbeats.QuotaCheck must not be nil; use healthcheck.NewNoop()"),
 		assert.NotNil(cfg.Heartbeats.KeyRefill, "Heartbeats.KeyRefill must not be nil; use healthcheck.NewNoop()"),
+		assert.NotNil(cfg.Heartbeats.KeyRotation, "Heartbeats.KeyRotation must not be nil; use healthcheck.NewNoop()"),
+		assert.NotNil(cfg.Heartbeats.KeyExpiry, "Heartbeats.KeyExpiry must not be nil; use healthcheck.NewNoop()"),
 		assert.NotNil(cfg.Heartbeats.KeyLastUsedSync, "Heartbeats.KeyLastUsedSync must not be nil; use healthcheck.NewNoop()"),
 		assert.NotNil(cfg.Heartbeats.AuditLogExport, "Heartbeats.AuditLogExport must not be nil; use healthcheck.NewNoop()"),
 		assert.NotNil(cfg.Heartbeats.AuditLogCleanup, "Heartbeats.AuditLogCleanup must not be nil; use healthcheck.NewNoop()"),
+		assert.NotNil(cfg.Heartbeats.IdempotencyCleanup, "Heartbeats.IdempotencyCleanup must not be nil; use healthcheck.NewNoop()"),
 		assert.NotNil(cfg.Heartbeats.RatelimitCleanup, "Heartbeats.RatelimitCleanup must not be nil; use healthcheck.NewNoop()"),
 		assert.NotNil(cfg.Heartbeats.DeployBillingPush, "Heartbeats.DeployBillingPush must not be nil; use healthcheck.NewNoop()"),
 		assert.NotNil(cfg.Heartbeats.DeployBillingClose, "Heartbeats.DeployBillingClose must not be nil; use healthcheck.NewNoop()"),
@@ -185,6 +206,15 @@ func New(cfg Config) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	keyRotationH, err := keyrotation.New(keyrotation.Config{
+		DB:        cfg.DB,
+		Vault:     cfg.Vault,
+		Caches:    cfg.Caches,
+		Heartbeat: cfg.Heartbeats.KeyRotation,
+	})
+	if err != nil {
+		return nil, err
+	}
 	quotaCheckH, err := quotacheck.New(quotacheck.Config{
 		DB:              cfg.DB,
 		Clickhouse:      cfg.Clickhouse,
@@ -209,6 +239,13 @@ func New(cfg Config) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	idempotencyCleanupH, err := idempotencycleanup.New(idempotencycleanup.Config{
+		DB:        cfg.DB,
+		Heartbeat: cfg.Heartbeats.IdempotencyCleanup,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	// The push is enabled only when ClickHouse (usage source) and Stripe
 	// (sink) are both configured; otherwise it runs as a no-op so the cron
@@ -305,6 +342,20 @@ func New(cfg Config) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Expiry warnings go out through the same sender and admin lookup as the
+	// budget alerts; keyspaces that only configured Slack still get theirs
+	// without either key.
+	keyExpiryH, err := keyexpiry.New(keyexpiry.Config{
+		DB:               cfg.DB,
+		Admins:           admins,
+		Email:            alertSender,
+		Slack:            slack.NewClient(),
+		DashboardBaseURL: cfg.BillingBaseURL,
+		Heartbeat:        cfg.Heartbeats.KeyExpiry,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return &Service{
 		UnimplementedCronServiceServer: hydrav1.UnimplementedCronServiceServer{},
@@ -314,9 +365,12 @@ func New(cfg Config) (*Service, error) {
 		deployBillingPush:              deployBillingPushH,
 		deploySpendCheck:               deploySpendCheckH,
 		deploySpendCheckWork:           deploySpendCheckWorkH,
+		idempotencyCleanup:             idempotencyCleanupH,
 		idlePreview:                    idlePreviewH,
+		keyExpiry:                      keyExpiryH,
 		keyLastUsedSync:                keyLastUsedSyncH,
 		keyRefill:                      keyRefillH,
+		keyRotation:                    keyRotationH,
 		quotaCheck:                     quotaCheckH,
 		ratelimitCleanup:               ratelimitCleanupH,
 	}, nil
@@ -343,6 +397,27 @@ func (s *Service) RunKeyRefill(
 	return s.keyRefill.Handle(ctx, req)
 }
 
+func (s *Service) RunKeyRotation(
+	ctx restate.ObjectContext,
+	req *hydrav1.RunKeyRotationRequest,
+) (*hydrav1.RunKeyRotationResponse, error) {
+	return s.keyRotation.Handle(ctx, req)
+}
+
+func (s *Service) ExpireRotatedKey(
+	ctx restate.ObjectContext,
+	req *hydrav1.ExpireRotatedKeyRequest,
+) (*hydrav1.ExpireRotatedKeyResponse, error) {
+	return s.keyRotation.HandleExpireRotatedKey(ctx, req)
+}
+
+func (s *Service) RunKeyExpiryNotifications(
+	ctx restate.ObjectContext,
+	req *hydrav1.RunKeyExpiryNotificationsRequest,
+) (*hydrav1.RunKeyExpiryNotificationsResponse, error) {
+	return s.keyExpiry.Handle(ctx, req)
+}
+
 func (s *Service) RunQuotaCheck(
 	ctx restate.ObjectContext,
 	req *hydrav1.RunQuotaCheckRequest,
@@ -364,6 +439,13 @@ func (s *Service) RunAuditLogOutboxCleanup(
 	return s.auditLogCleanup.Handle(ctx, req)
 }
 
+func (s *Service) RunIdempotencyKeysCleanup(
+	ctx restate.ObjectContext,
+	req *hydrav1.RunIdempotencyKeysCleanupRequest,
+) (*hydrav1.RunIdempotencyKeysCleanupResponse, error) {
+	return s.idempotencyCleanup.Handle(ctx, req)
+}
+
 func (s *Service) RunDeployBillingPush(
 	ctx restate.ObjectContext,
 	req *hydrav1.RunDeployBillingPushRequest,
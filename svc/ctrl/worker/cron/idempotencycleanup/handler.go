@@ -0,0 +1,115 @@
+// Package idempotencycleanup implements the
+// CronService.RunIdempotencyKeysCleanup handler. The handler deletes
+// idempotency_keys rows whose replay window has ended so the table the API's
+// Idempotency-Key middleware writes to stays bounded.
+package idempotencycleanup
+
+import (
+	"fmt"
+
+	restate "github.com/restatedev/sdk-go"
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/pkg/assert"
+	"github.com/unkeyed/unkey/pkg/healthcheck"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/pkg/restate/restateutil"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
+)
+
+// batchLimit bounds each DELETE so row locks stay short and replication lag
+// stays bounded; the handler loops until a batch deletes fewer than this.
+// Rows carry stored response bodies, so batches are smaller than for the
+// narrow counter tables.
+const batchLimit int32 = 5_000
+
+// maxBatches caps how much one invocation drains so a large backlog cannot
+// grow the journal without limit. Leftover rows are only a storage concern:
+// the API treats expired rows as free and takes them over in place, and the
+// next tick continues where this one stopped.
+const maxBatches = 40
+
+// Config holds the handler's dependencies.
+type Config struct {
+	// DB is the primary application database. Must not be nil.
+	DB db.Database
+
+	// Heartbeat is pinged after a successful sweep. Must not be nil; use
+	// healthcheck.NewNoop() if monitoring is not configured.
+	Heartbeat healthcheck.Heartbeat
+}
+
+// Handler executes RunIdempotencyKeysCleanup.
+type Handler struct {
+	db        db.Database
+	heartbeat healthcheck.Heartbeat
+}
+
+// New constructs a Handler.
+func New(cfg Config) (*Handler, error) {
+	if err := assert.All(
+		assert.NotNil(cfg.DB, "DB must not be nil"),
+		assert.NotNil(cfg.Heartbeat, "Heartbeat must not be nil; use healthcheck.NewNoop()"),
+	); err != nil {
+		return nil, err
+	}
+	return &Handler{db: cfg.DB, heartbeat: cfg.Heartbeat}, nil
+}
+
+// Handle deletes idempotency_keys rows whose expires_at_m is in the past, in
+// bounded batches. Each batch DELETE is wrapped in restate.Run so a crash or
+// retry replays cleanly: at-least-once delivery on a deterministic,
+// cutoff-bounded DELETE is safe — re-running only removes rows that were
+// already eligible.
+//
+// Stateless — the VO key is fixed at "idempotency-keys-cleanup" so a
+// paused/wedged invocation cannot block other cron handlers.
+func (h *Handler) Handle(
+	ctx restate.ObjectContext,
+	_ *hydrav1.RunIdempotencyKeysCleanupRequest,
+) (*hydrav1.RunIdempotencyKeysCleanupResponse, error) {
+	now, err := restateutil.Now(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get now: %w", err)
+	}
+	cutoff := now.UnixMilli()
+
+	var totalDeleted int64
+	drained := false
+	for batchNum := range maxBatches {
+		deleted, err := restate.Run(ctx, func(rc restate.RunContext) (int64, error) {
+			return h.db.DeleteExpiredIdempotencyKeys(rc, db.DeleteExpiredIdempotencyKeysParams{
+				Cutoff: cutoff,
+				Limit:  batchLimit,
+			})
+		}, restate.WithName(fmt.Sprintf("delete batch-%d", batchNum)))
+		if err != nil {
+			return nil, fmt.Errorf("delete expired idempotency keys batch %d: %w", batchNum, err)
+		}
+
+		totalDeleted += deleted
+
+		if deleted < int64(batchLimit) {
+			drained = true
+			break
+		}
+	}
+
+	if !drained {
+		logger.Warn("idempotency keys cleanup hit batch cap; expired rows remain for the next tick",
+			"rows_deleted", totalDeleted,
+			"max_batches", maxBatches,
+			"batch_limit", batchLimit,
+			"cutoff_ms", cutoff,
+		)
+	}
+
+	if err := restate.RunVoid(ctx, func(rc restate.RunContext) error {
+		return h.heartbeat.Ping(rc)
+	}, restate.WithName("send heartbeat")); err != nil {
+		return nil, fmt.Errorf("send heartbeat: %w", err)
+	}
+
+	return &hydrav1.RunIdempotencyKeysCleanupResponse{
+		RowsDeleted: totalDeleted,
+	}, nil
+}
@@ -235,6 +235,67 @@ func TestRunKeyRefill_Integration(t *testing.T) {
 	})
 }
 
+func TestRunKeyRefill_IdentityCredits_Integration(t *testing.T) {
+	h := harness.New(t)
+
+	now := time.Now().UTC()
+	dateKey := fmt.Sprintf("%d-%02d-%02d", now.Year(), int(now.Month()), now.Day())
+	todayDay := now.Day()
+
+	t.Run("refills identity pools with daily refill", func(t *testing.T) {
+		identityID := seedIdentityCredits(t, h, 10, nil, 1000)
+
+		resp, err := callRunKeyRefill(h, fmt.Sprintf("%s-test-identity-daily-%s", dateKey, uid.New("", 8)))
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, resp.GetIdentitiesRefilled(), int32(1))
+		require.Equal(t, int64(1000), identityRemaining(t, h, identityID))
+	})
+
+	t.Run("refills identity pools with matching day of month", func(t *testing.T) {
+		identityID := seedIdentityCredits(t, h, 10, &todayDay, 500)
+
+		resp, err := callRunKeyRefill(h, fmt.Sprintf("%s-test-identity-matching-%s", dateKey, uid.New("", 8)))
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, resp.GetIdentitiesRefilled(), int32(1))
+		require.Equal(t, int64(500), identityRemaining(t, h, identityID))
+	})
+
+	t.Run("skips identity pools with non-matching day of month", func(t *testing.T) {
+		otherDay := todayDay%28 + 1
+		identityID := seedIdentityCredits(t, h, 10, &otherDay, 500)
+
+		_, err := callRunKeyRefill(h, fmt.Sprintf("%s-test-identity-other-%s", dateKey, uid.New("", 8)))
+		require.NoError(t, err)
+		require.Equal(t, int64(10), identityRemaining(t, h, identityID))
+	})
+}
+
+// seedIdentityCredits creates an identity in a fresh workspace and gives it
+// a credit pool. A nil refillDay means a daily refill.
+func seedIdentityCredits(t *testing.T, h *harness.Harness, remaining int64, refillDay *int, refillAmount int64) string {
+	t.Helper()
+	ws := h.Seed.CreateWorkspace(h.Ctx)
+	identityID := h.Seed.CreateIdentity(h.Ctx, seed.CreateIdentityRequest{
+		WorkspaceID: ws.ID,
+		ExternalID:  uid.New("", 12),
+	})
+	_, err := h.DB.RW().ExecContext(
+		h.Ctx,
+		"INSERT INTO identity_credits (identity_id, workspace_id, remaining, refill_day, refill_amount, created_at_m) VALUES (?, ?, ?, ?, ?, ?)",
+		identityID, ws.ID, remaining, refillDay, refillAmount, time.Now().UnixMilli(),
+	)
+	require.NoError(t, err)
+	return identityID
+}
+
+func identityRemaining(t *testing.T, h *harness.Harness, identityID string) int64 {
+	t.Helper()
+	var remaining int64
+	err := h.DB.RO().QueryRowContext(h.Ctx, "SELECT remaining FROM identity_credits WHERE identity_id = ?", identityID).Scan(&remaining)
+	require.NoError(t, err)
+	return remaining
+}
+
 func callRunKeyRefill(h *harness.Harness, dateKey string) (*hydrav1.RunKeyRefillResponse, error) {
 	client := hydrav1.NewCronServiceIngressClient(h.Restate, dateKey)
 	return client.RunKeyRefill().Request(h.Ctx, &hydrav1.RunKeyRefillRequest{})
@@ -0,0 +1,304 @@
+// Package keyexpiry implements the CronService.RunKeyExpiryNotifications
+// handler. Keyspaces opt in with a list of thresholds, e.g. 7 days and 1 day
+// before expiry; every run warns about the keys that crossed one of them,
+// by email to the workspace's admins and to the keyspace's Slack webhook.
+//
+// Each key is warned at most once per threshold and expiry, recorded in
+// key_expiry_notifications. A key that is first seen inside several
+// thresholds at once is only warned at the tightest, and the looser ones
+// are never sent for that expiry. Moving a key's expiry starts over.
+package keyexpiry
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/pkg/assert"
+	"github.com/unkeyed/unkey/pkg/email"
+	"github.com/unkeyed/unkey/pkg/healthcheck"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/pkg/restate/restateutil"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/slack"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/workos"
+)
+
+// batchSize is the number of keyspaces, and of keys per keyspace, fetched
+// per batch.
+const batchSize = 100
+
+// Config holds the handler's dependencies.
+type Config struct {
+	// DB is the primary application database. Must not be nil.
+	DB db.Database
+	// Admins resolves the org admins that receive the emails. Use
+	// workos.NewNoop() to resolve no recipients.
+	Admins workos.Resolver
+	// Email sends the warnings. Use email.NewNoop() to log instead of sending.
+	Email email.Sender
+	// Slack posts the warnings to keyspace webhooks. Must not be nil.
+	Slack *slack.Client
+	// DashboardBaseURL is the dashboard origin used to link to the key, e.g.
+	// "https://app.unkey.com".
+	DashboardBaseURL string
+	// Heartbeat is pinged on successful completion. Must not be nil; use
+	// healthcheck.NewNoop() if monitoring is not configured.
+	Heartbeat healthcheck.Heartbeat
+}
+
+// Handler executes RunKeyExpiryNotifications.
+type Handler struct {
+	db               db.Database
+	admins           workos.Resolver
+	email            email.Sender
+	slack            *slack.Client
+	dashboardBaseURL string
+	heartbeat        healthcheck.Heartbeat
+}
+
+// New constructs a Handler.
+func New(cfg Config) (*Handler, error) {
+	if err := assert.All(
+		assert.NotNil(cfg.DB, "DB must not be nil"),
+		assert.NotNil(cfg.Admins, "Admins must not be nil; use workos.NewNoop()"),
+		assert.NotNil(cfg.Email, "Email must not be nil; use email.NewNoop()"),
+		assert.NotNil(cfg.Slack, "Slack must not be nil"),
+		assert.NotNil(cfg.Heartbeat, "Heartbeat must not be nil; use healthcheck.NewNoop()"),
+	); err != nil {
+		return nil, err
+	}
+	return &Handler{
+		db:               cfg.DB,
+		admins:           cfg.Admins,
+		email:            cfg.Email,
+		slack:            cfg.Slack,
+		dashboardBaseURL: cfg.DashboardBaseURL,
+		heartbeat:        cfg.Heartbeat,
+	}, nil
+}
+
+// Handle warns about every key within a threshold of its keyspace. The VO
+// key only names the object; a run picks up whatever is due at its start, so
+// a missed run is caught up by the next one.
+func (h *Handler) Handle(
+	ctx restate.ObjectContext,
+	_ *hydrav1.RunKeyExpiryNotificationsRequest,
+) (*hydrav1.RunKeyExpiryNotificationsResponse, error) {
+	now, err := restateutil.Now(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get now: %w", err)
+	}
+	logger.Info("running key expiry notifications", "now", now.UnixMilli())
+
+	var sent int
+	var cursor uint64
+	var batchNum int
+
+	for {
+		settings, fetchErr := restate.Run(ctx, func(rc restate.RunContext) ([]db.ListKeyExpiryNotificationSettingsRow, error) {
+			return h.db.ListKeyExpiryNotificationSettings(rc, db.ListKeyExpiryNotificationSettingsParams{
+				AfterPk: cursor,
+				Limit:   batchSize,
+			})
+		}, restate.WithName(fmt.Sprintf("fetch settings batch %d", batchNum)))
+		if fetchErr != nil {
+			return nil, fmt.Errorf("fetch settings: %w", fetchErr)
+		}
+
+		if len(settings) == 0 {
+			break
+		}
+
+		cursor = settings[len(settings)-1].Pk
+
+		for _, s := range settings {
+			n, notifyErr := h.notifyKeyspace(ctx, s, now)
+			if notifyErr != nil {
+				return nil, notifyErr
+			}
+			sent += n
+		}
+
+		batchNum++
+	}
+
+	logger.Info("key expiry notifications complete", "notifications_sent", sent)
+
+	if _, err := restate.Run(ctx, func(rc restate.RunContext) (restate.Void, error) {
+		return restate.Void{}, h.heartbeat.Ping(rc)
+	}, restate.WithName("send heartbeat")); err != nil {
+		return nil, fmt.Errorf("send heartbeat: %w", err)
+	}
+
+	return &hydrav1.RunKeyExpiryNotificationsResponse{
+		NotificationsSent: int32(sent),
+	}, nil
+}
+
+// notifyKeyspace warns about the keys of one keyspace that crossed one of
+// its thresholds and returns how many it warned about. The keys due at the
+// same threshold go out as one digest per channel, and their warnings are
+// recorded together in a single step.
+func (h *Handler) notifyKeyspace(ctx restate.ObjectContext, s db.ListKeyExpiryNotificationSettingsRow, now time.Time) (int, error) {
+	thresholds, err := parseThresholds(s.ThresholdsMs)
+	if err != nil {
+		// A broken row must not hold back every other keyspace.
+		logger.Error("skipping keyspace with invalid expiry notification thresholds",
+			"key_auth_id", s.KeyAuthID,
+			"error", err.Error(),
+		)
+		return 0, nil
+	}
+	if len(thresholds) == 0 || (!s.Email && !s.SlackWebhookUrl.Valid) {
+		return 0, nil
+	}
+
+	horizon := now.Add(time.Duration(thresholds[len(thresholds)-1]) * time.Millisecond)
+
+	due := make(map[int64][]expiringKey)
+	var cursor uint64
+	var batchNum int
+
+	for {
+		keys, fetchErr := restate.Run(ctx, func(rc restate.RunContext) ([]db.ListKeysExpiringForNotificationRow, error) {
+			return h.db.ListKeysExpiringForNotification(rc, db.ListKeysExpiringForNotificationParams{
+				KeyAuthID: s.KeyAuthID,
+				AfterPk:   cursor,
+				Now:       sql.NullTime{Time: now, Valid: true},
+				Horizon:   sql.NullTime{Time: horizon, Valid: true},
+				Limit:     batchSize,
+			})
+		}, restate.WithName(fmt.Sprintf("fetch keys of %s batch %d", s.KeyAuthID, batchNum)))
+		if fetchErr != nil {
+			return 0, fmt.Errorf("fetch keys of %s: %w", s.KeyAuthID, fetchErr)
+		}
+
+		if len(keys) == 0 {
+			break
+		}
+
+		cursor = keys[len(keys)-1].Pk
+
+		for _, key := range keys {
+			if !key.Expires.Valid {
+				continue
+			}
+			threshold, ok := dueThreshold(thresholds, key.Expires.Time.Sub(now), key.NotifiedThresholdMs)
+			if !ok {
+				continue
+			}
+			due[threshold] = append(due[threshold], expiringKey{
+				ID:      key.ID,
+				Name:    key.Name.String,
+				Start:   key.Start,
+				Expires: key.Expires.Time,
+			})
+		}
+
+		batchNum++
+	}
+
+	if len(due) == 0 {
+		return 0, nil
+	}
+
+	// Admins are only looked up for keyspaces that have something to say,
+	// and only once per run.
+	var recipients []string
+	if s.Email {
+		recipients, err = restate.Run(ctx, func(rc restate.RunContext) ([]string, error) {
+			return h.admins.AdminEmails(rc, s.OrgID)
+		}, restate.WithName(fmt.Sprintf("resolve admins of %s", s.KeyAuthID)))
+		if err != nil {
+			return 0, fmt.Errorf("resolve org admins: %w", err)
+		}
+	}
+
+	var sent int
+	for _, threshold := range thresholds {
+		keys := due[threshold]
+		if len(keys) == 0 {
+			continue
+		}
+
+		d := digest{
+			Keys:          keys,
+			ThresholdMs:   threshold,
+			KeyAuthID:     s.KeyAuthID,
+			ApiID:         s.ApiID,
+			ApiName:       s.ApiName,
+			WorkspaceName: s.WorkspaceName,
+			WorkspaceSlug: s.WorkspaceSlug,
+			Now:           now,
+		}
+		if err := h.warn(ctx, d, recipients, s.SlackWebhookUrl.String); err != nil {
+			return 0, err
+		}
+
+		if err := restate.RunVoid(ctx, func(rc restate.RunContext) error {
+			return h.record(rc, s.WorkspaceID, d)
+		}, restate.WithName(fmt.Sprintf("record warnings for %s at %d", s.KeyAuthID, threshold))); err != nil {
+			return 0, fmt.Errorf("record warnings for %s: %w", s.KeyAuthID, err)
+		}
+		sent += len(keys)
+	}
+
+	return sent, nil
+}
+
+// record marks every key of d as warned at its threshold, in chunks of
+// batchSize rows. Rows already recorded by an earlier attempt are kept.
+func (h *Handler) record(ctx context.Context, workspaceID string, d digest) error {
+	for chunk := range slices.Chunk(d.Keys, batchSize) {
+		params := make([]db.InsertKeyExpiryNotificationParams, len(chunk))
+		for i, k := range chunk {
+			params[i] = db.InsertKeyExpiryNotificationParams{
+				KeyID:       k.ID,
+				WorkspaceID: workspaceID,
+				ThresholdMs: d.ThresholdMs,
+				Expires:     k.Expires,
+				CreatedAtM:  d.Now.UnixMilli(),
+			}
+		}
+		if err := h.db.Bulk().InsertKeyExpiryNotifications(ctx, params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseThresholds decodes a keyspace's thresholds into ascending,
+// deduplicated milliseconds. Non-positive values are dropped.
+func parseThresholds(raw json.RawMessage) ([]int64, error) {
+	var thresholds []int64
+	if err := json.Unmarshal(raw, &thresholds); err != nil {
+		return nil, fmt.Errorf("decode thresholds: %w", err)
+	}
+	thresholds = slices.DeleteFunc(thresholds, func(t int64) bool { return t <= 0 })
+	slices.Sort(thresholds)
+	return slices.Compact(thresholds), nil
+}
+
+// dueThreshold returns the tightest threshold that remaining falls within,
+// and whether a warning for it is still owed. notified is the tightest
+// threshold already warned at for the key's current expiry, or 0 for none;
+// a warning is only owed for a tighter one. thresholds must be ascending.
+func dueThreshold(thresholds []int64, remaining time.Duration, notified int64) (int64, bool) {
+	remainingMs := remaining.Milliseconds()
+	for _, t := range thresholds {
+		if remainingMs > t {
+			continue
+		}
+		if notified != 0 && notified <= t {
+			return 0, false
+		}
+		return t, true
+	}
+	return 0, false
+}
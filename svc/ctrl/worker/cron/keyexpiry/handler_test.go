@@ -0,0 +1,92 @@
+package keyexpiry
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const day = int64(24 * time.Hour / time.Millisecond)
+
+func TestParseThresholds_SortsAndDedupes(t *testing.T) {
+	thresholds, err := parseThresholds(json.RawMessage(`[86400000, 604800000, 86400000, 0, -5]`))
+	require.NoError(t, err)
+	require.Equal(t, []int64{day, 7 * day}, thresholds)
+
+	_, err = parseThresholds(json.RawMessage(`{"7d": true}`))
+	require.Error(t, err)
+}
+
+func TestDueThreshold(t *testing.T) {
+	thresholds := []int64{day, 7 * day}
+
+	tests := []struct {
+		name      string
+		remaining time.Duration
+		notified  int64
+		want      int64
+		wantOK    bool
+	}{
+		{name: "outside every threshold", remaining: 8 * 24 * time.Hour, notified: 0, want: 0, wantOK: false},
+		{name: "first threshold crossed", remaining: 6 * 24 * time.Hour, notified: 0, want: 7 * day, wantOK: true},
+		{name: "already warned at that threshold", remaining: 6 * 24 * time.Hour, notified: 7 * day, want: 0, wantOK: false},
+		{name: "tighter threshold crossed after a warning", remaining: 12 * time.Hour, notified: 7 * day, want: day, wantOK: true},
+		{name: "first seen inside both only warns at the tightest", remaining: 12 * time.Hour, notified: 0, want: day, wantOK: true},
+		{name: "already warned at the tightest", remaining: 12 * time.Hour, notified: day, want: 0, wantOK: false},
+		{name: "exactly on the threshold", remaining: 24 * time.Hour, notified: 0, want: day, wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := dueThreshold(thresholds, tt.remaining, tt.notified)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatThreshold(t *testing.T) {
+	require.Equal(t, "7 days", formatThreshold(7*day))
+	require.Equal(t, "1 day", formatThreshold(day))
+	require.Equal(t, "36 hours", formatThreshold(36*int64(time.Hour/time.Millisecond)))
+	require.Equal(t, "30 minutes", formatThreshold(30*int64(time.Minute/time.Millisecond)))
+}
+
+func TestEmailKeyList_EscapesAndCaps(t *testing.T) {
+	expires := time.Date(2026, 10, 24, 12, 0, 0, 0, time.UTC)
+	keys := make([]expiringKey, maxListedKeys+2)
+	for i := range keys {
+		keys[i] = expiringKey{ID: "key_" + strconv.Itoa(i), Name: "", Start: "sk_", Expires: expires}
+	}
+	keys[0].Name = "<b>billing</b>"
+
+	lines := strings.Split(emailKeyList(keys), "\n")
+	require.Len(t, lines, maxListedKeys+1)
+	require.Equal(t, "&lt;b&gt;billing&lt;/b&gt; (key_0), expires Sat, 24 Oct 2026 12:00:00 UTC", lines[0])
+	require.Equal(t, "sk_... (key_1), expires Sat, 24 Oct 2026 12:00:00 UTC", lines[1])
+	require.Equal(t, "and 2 more", lines[maxListedKeys])
+}
+
+func TestSlackPayload_OneMessagePerDigest(t *testing.T) {
+	d := digest{
+		Keys: []expiringKey{
+			{ID: "key_1", Name: "billing", Start: "sk_", Expires: time.Date(2026, 10, 24, 12, 0, 0, 0, time.UTC)},
+			{ID: "key_2", Name: "", Start: "sk_", Expires: time.Date(2026, 10, 23, 12, 0, 0, 0, time.UTC)},
+		},
+		ThresholdMs:   7 * day,
+		KeyAuthID:     "ks_1",
+		ApiID:         "api_1",
+		ApiName:       "Production",
+		WorkspaceName: "Acme",
+		WorkspaceSlug: "acme",
+		Now:           time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+	}
+
+	payload := slackPayload(d, "https://app.unkey.com/acme/apis/api_1/keys/ks_1")
+	require.Equal(t, "2 keys of Production will expire within 7 days", payload.Text)
+	require.Len(t, payload.Blocks, 3)
+	require.Equal(t, "key-expiry/ks_1/604800000/1792238400000", idempotencyKey(d))
+}
@@ -0,0 +1,161 @@
+package keyexpiry
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	"github.com/unkeyed/unkey/pkg/email"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/slack"
+)
+
+// expiryDigestTemplate is the published Resend template alias for the key
+// expiry digest. The template owns its subject and sender; this handler
+// supplies only the recipients and variables.
+const expiryDigestTemplate = "key-expiry-digest"
+
+// maxListedKeys caps the keys named in one digest; the rest are counted. It
+// also keeps the Slack section under its 3000 character limit.
+const maxListedKeys = 25
+
+// expiringKey is one key owed a warning.
+type expiringKey struct {
+	ID      string
+	Name    string
+	Start   string
+	Expires time.Time
+}
+
+// digest is the data for the keys of one keyspace crossing one threshold in
+// the same run.
+type digest struct {
+	Keys          []expiringKey
+	ThresholdMs   int64
+	KeyAuthID     string
+	ApiID         string
+	ApiName       string
+	WorkspaceName string
+	WorkspaceSlug string
+	// Now is the run's start; it tells a retried send from the next run's.
+	Now time.Time
+}
+
+// warn sends d to every configured channel: an email to recipients when there
+// are any and a Slack message when webhookURL is set. Each send is journaled
+// separately, so a retry after a Slack failure does not email again.
+func (h *Handler) warn(ctx restate.ObjectContext, d digest, recipients []string, webhookURL string) error {
+	if len(recipients) > 0 {
+		if err := restate.RunVoid(ctx, func(rc restate.RunContext) error {
+			return h.email.Send(rc, email.Email{
+				To:         recipients,
+				TemplateID: expiryDigestTemplate,
+				Variables: map[string]string{
+					"KEY_COUNT":      plural(int64(len(d.Keys)), "key"),
+					"TIME_LEFT":      formatThreshold(d.ThresholdMs),
+					"API_NAME":       d.ApiName,
+					"WORKSPACE_NAME": d.WorkspaceName,
+					"KEYS":           emailKeyList(d.Keys),
+					"KEYS_URL":       h.keysURL(d),
+					"YEAR":           strconv.Itoa(d.Now.Year()),
+				},
+				From:           "",
+				Subject:        "",
+				IdempotencyKey: idempotencyKey(d),
+			})
+		}, restate.WithName(fmt.Sprintf("email digest for %s at %d", d.KeyAuthID, d.ThresholdMs))); err != nil {
+			return fmt.Errorf("email digest for %s: %w", d.KeyAuthID, err)
+		}
+	}
+
+	if webhookURL != "" {
+		if err := restate.RunVoid(ctx, func(rc restate.RunContext) error {
+			return h.slack.Send(rc, webhookURL, slackPayload(d, h.keysURL(d)))
+		}, restate.WithName(fmt.Sprintf("slack digest for %s at %d", d.KeyAuthID, d.ThresholdMs))); err != nil {
+			return fmt.Errorf("slack digest for %s: %w", d.KeyAuthID, err)
+		}
+	}
+
+	return nil
+}
+
+func slackPayload(d digest, keysURL string) slack.Payload {
+	title := fmt.Sprintf("%s of %s will expire within %s", plural(int64(len(d.Keys)), "key"), d.ApiName, formatThreshold(d.ThresholdMs))
+	lines := listKeys(d.Keys, func(k expiringKey) string {
+		return fmt.Sprintf("• %s `%s` expires %s", keyLabel(k), k.ID, k.Expires.UTC().Format(time.RFC1123))
+	})
+	return slack.Payload{
+		Text: title,
+		Blocks: []slack.Block{
+			slack.NewHeaderBlock(title),
+			slack.NewSectionBlock(slack.NewMarkdownField(strings.Join(lines, "\n"))),
+			slack.NewSectionBlock(slack.NewMarkdownField(fmt.Sprintf("*Dashboard:*\n<%s|Review keys>", keysURL))),
+		},
+	}
+}
+
+// emailKeyList renders the keys one per line. The template inserts it
+// unescaped, so key names are HTML-escaped here.
+func emailKeyList(keys []expiringKey) string {
+	lines := listKeys(keys, func(k expiringKey) string {
+		return fmt.Sprintf("%s (%s), expires %s", html.EscapeString(keyLabel(k)), k.ID, k.Expires.UTC().Format(time.RFC1123))
+	})
+	return strings.Join(lines, "\n")
+}
+
+// listKeys formats up to maxListedKeys keys and appends a line counting the
+// rest.
+func listKeys(keys []expiringKey, format func(expiringKey) string) []string {
+	listed := keys[:min(len(keys), maxListedKeys)]
+	lines := make([]string, 0, len(listed)+1)
+	for _, k := range listed {
+		lines = append(lines, format(k))
+	}
+	if rest := len(keys) - len(listed); rest > 0 {
+		lines = append(lines, fmt.Sprintf("and %d more", rest))
+	}
+	return lines
+}
+
+// keyLabel names the key the way the dashboard lists it: by name, or by its
+// visible start when it has none.
+func keyLabel(k expiringKey) string {
+	if k.Name != "" {
+		return k.Name
+	}
+	return k.Start + "..."
+}
+
+func (h *Handler) keysURL(d digest) string {
+	return fmt.Sprintf("%s/%s/apis/%s/keys/%s", h.dashboardBaseURL, d.WorkspaceSlug, d.ApiID, d.KeyAuthID)
+}
+
+// idempotencyKey dedupes a digest at Resend when the record step after it
+// fails and the run is retried. A later run with keys still owed a warning
+// sends a new digest.
+func idempotencyKey(d digest) string {
+	return fmt.Sprintf("key-expiry/%s/%d/%d", d.KeyAuthID, d.ThresholdMs, d.Now.UnixMilli())
+}
+
+// formatThreshold renders a threshold in the largest whole unit that fits,
+// e.g. "7 days" or "12 hours".
+func formatThreshold(ms int64) string {
+	d := time.Duration(ms) * time.Millisecond
+	switch {
+	case d >= 24*time.Hour && d%(24*time.Hour) == 0:
+		return plural(int64(d/(24*time.Hour)), "day")
+	case d >= time.Hour && d%time.Hour == 0:
+		return plural(int64(d/time.Hour), "hour")
+	default:
+		return plural(int64(d/time.Minute), "minute")
+	}
+}
+
+func plural(n int64, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
@@ -1,6 +1,7 @@
 // Package keyrefill implements the CronService.RunKeyRefill handler.
-// The handler processes all keys that need their usage limits refilled
-// today and writes one clickhouse_outbox row per refilled key.
+// The handler processes all keys and identity credit pools that need their
+// usage limits refilled today and writes one clickhouse_outbox row per
+// refilled key or identity.
 package keyrefill
 
 import (
@@ -26,6 +27,9 @@ import (
 // current VO key (date), so a crash mid-loop resumes cleanly.
 const stateKeyProcessedKeys = "processed_keys"
 
+// stateKeyProcessedIdentities does the same for identity credit pools.
+const stateKeyProcessedIdentities = "processed_identities"
+
 // batchSize is the number of keys to fetch and process in a single batch.
 const batchSize = 100
 
@@ -55,8 +59,9 @@ func New(cfg Config) (*Handler, error) {
 	return &Handler{db: cfg.DB, heartbeat: cfg.Heartbeat}, nil
 }
 
-// Handle processes all keys that need their usage limits refilled
-// today. Keyed by date (YYYY-MM-DD); state tracks processed key IDs.
+// Handle processes all keys and identity credit pools that need their
+// usage limits refilled today. Keyed by date (YYYY-MM-DD); state tracks
+// processed key and identity IDs.
 func (h *Handler) Handle(
 	ctx restate.ObjectContext,
 	_ *hydrav1.RunKeyRefillRequest,
@@ -69,9 +74,44 @@ func (h *Handler) Handle(
 		return nil, fmt.Errorf("invalid date key %q: %w", dateKey, err)
 	}
 
+	isLastDayInt := 0
+	if isLastDay {
+		isLastDayInt = 1
+	}
+
+	totalKeysRefilled, err := h.refillKeys(ctx, todayDay, isLastDayInt)
+	if err != nil {
+		return nil, err
+	}
+
+	totalIdentitiesRefilled, err := h.refillIdentities(ctx, todayDay, isLastDayInt)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("key refill complete",
+		"date", dateKey,
+		"keys_refilled", totalKeysRefilled,
+		"identities_refilled", totalIdentitiesRefilled,
+	)
+
+	if _, err := restate.Run(ctx, func(rc restate.RunContext) (restate.Void, error) {
+		return restate.Void{}, h.heartbeat.Ping(rc)
+	}, restate.WithName("send heartbeat")); err != nil {
+		return nil, fmt.Errorf("send heartbeat: %w", err)
+	}
+
+	return &hydrav1.RunKeyRefillResponse{
+		KeysRefilled:       int32(totalKeysRefilled),
+		IdentitiesRefilled: int32(totalIdentitiesRefilled),
+	}, nil
+}
+
+// refillKeys refills every key due today and returns how many it refilled.
+func (h *Handler) refillKeys(ctx restate.ObjectContext, todayDay, isLastDayInt int) (int, error) {
 	processedKeys, err := restate.Get[map[string]bool](ctx, stateKeyProcessedKeys)
 	if err != nil {
-		return nil, fmt.Errorf("get processed keys state: %w", err)
+		return 0, fmt.Errorf("get processed keys state: %w", err)
 	}
 	if processedKeys == nil {
 		processedKeys = make(map[string]bool)
@@ -81,11 +121,6 @@ func (h *Handler) Handle(
 	var cursor uint64
 	var batchNum int
 
-	isLastDayInt := 0
-	if isLastDay {
-		isLastDayInt = 1
-	}
-
 	for {
 		keys, fetchErr := restate.Run(ctx, func(rc restate.RunContext) ([]db.ListKeysForRefillRow, error) {
 			return h.db.ListKeysForRefill(rc, db.ListKeysForRefillParams{
@@ -96,7 +131,7 @@ func (h *Handler) Handle(
 			})
 		}, restate.WithName(fmt.Sprintf("fetch keys batch %d", batchNum)))
 		if fetchErr != nil {
-			return nil, fmt.Errorf("fetch keys: %w", fetchErr)
+			return 0, fmt.Errorf("fetch keys: %w", fetchErr)
 		}
 
 		if len(keys) == 0 {
@@ -119,7 +154,7 @@ func (h *Handler) Handle(
 
 		nowTime, nowErr := restateutil.Now(ctx)
 		if nowErr != nil {
-			return nil, fmt.Errorf("get now: %w", nowErr)
+			return 0, fmt.Errorf("get now: %w", nowErr)
 		}
 		now := nowTime.UnixMilli()
 
@@ -135,21 +170,15 @@ func (h *Handler) Handle(
 			})
 		}, restate.WithName(fmt.Sprintf("update keys batch %d", batchNum)))
 		if updateErr != nil {
-			return nil, fmt.Errorf("update keys: %w", updateErr)
+			return 0, fmt.Errorf("update keys: %w", updateErr)
 		}
 
 		outboxRows, buildErr := buildOutboxRows(keysToProcess, now)
 		if buildErr != nil {
-			return nil, fmt.Errorf("build outbox rows: %w", buildErr)
+			return 0, fmt.Errorf("build outbox rows: %w", buildErr)
 		}
-		_, auditErr := restate.Run(ctx, func(rc restate.RunContext) (restate.Void, error) {
-			if err := h.db.Bulk().InsertClickhouseOutboxes(rc, outboxRows); err != nil {
-				return restate.Void{}, fmt.Errorf("insert clickhouse outbox rows: %w", err)
-			}
-			return restate.Void{}, nil
-		}, restate.WithName(fmt.Sprintf("insert audit logs batch %d", batchNum)))
-		if auditErr != nil {
-			return nil, fmt.Errorf("insert audit logs: %w", auditErr)
+		if err := h.insertAuditLogs(ctx, outboxRows, fmt.Sprintf("insert audit logs batch %d", batchNum)); err != nil {
+			return 0, err
 		}
 
 		for _, key := range keysToProcess {
@@ -165,20 +194,108 @@ func (h *Handler) Handle(
 		}
 	}
 
-	logger.Info("key refill complete",
-		"date", dateKey,
-		"keys_refilled", totalKeysRefilled,
-	)
+	return totalKeysRefilled, nil
+}
 
-	if _, err := restate.Run(ctx, func(rc restate.RunContext) (restate.Void, error) {
-		return restate.Void{}, h.heartbeat.Ping(rc)
-	}, restate.WithName("send heartbeat")); err != nil {
-		return nil, fmt.Errorf("send heartbeat: %w", err)
+// refillIdentities refills every identity credit pool due today and returns
+// how many it refilled. It mirrors refillKeys with its own cursor and state.
+func (h *Handler) refillIdentities(ctx restate.ObjectContext, todayDay, isLastDayInt int) (int, error) {
+	processed, err := restate.Get[map[string]bool](ctx, stateKeyProcessedIdentities)
+	if err != nil {
+		return 0, fmt.Errorf("get processed identities state: %w", err)
+	}
+	if processed == nil {
+		processed = make(map[string]bool)
 	}
 
-	return &hydrav1.RunKeyRefillResponse{
-		KeysRefilled: int32(totalKeysRefilled),
-	}, nil
+	var total int
+	var cursor uint64
+	var batchNum int
+
+	for {
+		pools, fetchErr := restate.Run(ctx, func(rc restate.RunContext) ([]db.ListIdentityCreditsForRefillRow, error) {
+			return h.db.ListIdentityCreditsForRefill(rc, db.ListIdentityCreditsForRefillParams{
+				TodayDay:         sql.NullInt16{Int16: int16(todayDay), Valid: true},
+				IsLastDayOfMonth: isLastDayInt,
+				AfterPk:          cursor,
+				Limit:            batchSize,
+			})
+		}, restate.WithName(fmt.Sprintf("fetch identities batch %d", batchNum)))
+		if fetchErr != nil {
+			return 0, fmt.Errorf("fetch identities: %w", fetchErr)
+		}
+
+		if len(pools) == 0 {
+			break
+		}
+
+		cursor = pools[len(pools)-1].Pk
+
+		var toProcess []db.ListIdentityCreditsForRefillRow
+		for _, pool := range pools {
+			if !processed[pool.IdentityID] {
+				toProcess = append(toProcess, pool)
+			}
+		}
+
+		if len(toProcess) == 0 {
+			batchNum++
+			continue
+		}
+
+		nowTime, nowErr := restateutil.Now(ctx)
+		if nowErr != nil {
+			return 0, fmt.Errorf("get now: %w", nowErr)
+		}
+		now := nowTime.UnixMilli()
+
+		identityIDs := make([]string, len(toProcess))
+		for i, pool := range toProcess {
+			identityIDs[i] = pool.IdentityID
+		}
+
+		_, updateErr := restate.Run(ctx, func(rc restate.RunContext) (restate.Void, error) {
+			return restate.Void{}, h.db.RefillIdentityCreditsByIDs(rc, db.RefillIdentityCreditsByIDsParams{
+				Now:         sql.NullInt64{Int64: now, Valid: true},
+				IdentityIds: identityIDs,
+			})
+		}, restate.WithName(fmt.Sprintf("update identities batch %d", batchNum)))
+		if updateErr != nil {
+			return 0, fmt.Errorf("update identities: %w", updateErr)
+		}
+
+		outboxRows, buildErr := buildIdentityOutboxRows(toProcess, now)
+		if buildErr != nil {
+			return 0, fmt.Errorf("build identity outbox rows: %w", buildErr)
+		}
+		if err := h.insertAuditLogs(ctx, outboxRows, fmt.Sprintf("insert identity audit logs batch %d", batchNum)); err != nil {
+			return 0, err
+		}
+
+		for _, pool := range toProcess {
+			processed[pool.IdentityID] = true
+		}
+
+		restate.Set(ctx, stateKeyProcessedIdentities, processed)
+		total += len(toProcess)
+		batchNum++
+	}
+
+	return total, nil
+}
+
+// insertAuditLogs writes the outbox rows of one batch in a journaled step.
+func (h *Handler) insertAuditLogs(ctx restate.ObjectContext, rows []db.InsertClickhouseOutboxParams, name string) error {
+	_, err := restate.Run(ctx, func(rc restate.RunContext) (restate.Void, error) {
+		if err := h.db.Bulk().InsertClickhouseOutboxes(rc, rows); err != nil {
+			return restate.Void{}, fmt.Errorf("insert clickhouse outbox rows: %w", err)
+		}
+		return restate.Void{}, nil
+	}, restate.WithName(name))
+	if err != nil {
+		return fmt.Errorf("insert audit logs: %w", err)
+	}
+	return nil
 }
 
 // parseDateKey parses a "YYYY-MM-DD" prefix and returns the day of
@@ -269,6 +386,65 @@ func buildOutboxRows(keys []db.ListKeysForRefillRow, now int64) ([]db.InsertClic
 	return rows, nil
 }
 
+// buildIdentityOutboxRows creates clickhouse_outbox rows for the refilled
+// identity credit pools, in the same shape as buildOutboxRows.
+func buildIdentityOutboxRows(pools []db.ListIdentityCreditsForRefillRow, now int64) ([]db.InsertClickhouseOutboxParams, error) {
+	rows := make([]db.InsertClickhouseOutboxParams, 0, len(pools))
+
+	for _, pool := range pools {
+		name := pool.IdentityID
+		if pool.ExternalID.Valid && pool.ExternalID.String != "" {
+			name = pool.ExternalID.String
+		}
+
+		envelope := auditlog.Event{
+			EventID:       uid.New(uid.AuditLogPrefix, 24),
+			Time:          now,
+			WorkspaceID:   pool.WorkspaceID,
+			Bucket:        "unkey_mutations",
+			Source:        auditlog.EventSourcePlatform,
+			Event:         string(auditlog.IdentityUpdateEvent),
+			Description:   fmt.Sprintf("Refilled credits of identity %s", name),
+			RemoteIP:      "",
+			UserAgent:     "",
+			Meta:          nil,
+			CorrelationID: "",
+			Actor: auditlog.EventActor{
+				Type: "system",
+				ID:   "keyrefill",
+				Name: "Key Refill Service",
+				Meta: nil,
+			},
+			Targets: []auditlog.EventTarget{
+				{
+					Type: "identity",
+					ID:   pool.IdentityID,
+					Name: name,
+					Meta: map[string]any{
+						"refill_amount":      pool.RefillAmount.Int64,
+						"previous_remaining": pool.Remaining,
+						"new_remaining":      pool.RefillAmount.Int64,
+					},
+				},
+			},
+		}
+		payload, err := json.Marshal(envelope)
+		if err != nil {
+			return nil, fmt.Errorf("marshal audit envelope for identity %s: %w", pool.IdentityID, err)
+		}
+
+		rows = append(rows, db.InsertClickhouseOutboxParams{
+			Version:     auditlog.OutboxVersionV1,
+			WorkspaceID: pool.WorkspaceID,
+			EventID:     envelope.EventID,
+			Payload:     payload,
+			CreatedAt:   now,
+		})
+	}
+
+	return rows, nil
+}
+
 // displayName returns a display name for the key, using the name if
 // available, falling back to the ID.
 func displayName(key db.ListKeysForRefillRow) string {
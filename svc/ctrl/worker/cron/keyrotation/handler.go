@@ -0,0 +1,588 @@
+// Package keyrotation implements the CronService.RunKeyRotation handler.
+// The handler issues a successor for every recoverable key whose rotation
+// policy is due, keeps the replaced key valid for the policy's grace period
+// and writes one clickhouse_outbox audit row per rotation. Once the grace
+// period ends, ExpireRotatedKey evicts the replaced key from the API caches.
+package keyrotation
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	vaultv1 "github.com/unkeyed/unkey/gen/proto/vault/v1"
+	"github.com/unkeyed/unkey/gen/rpc/vault"
+	"github.com/unkeyed/unkey/internal/services/caches"
+	"github.com/unkeyed/unkey/internal/services/keys"
+	"github.com/unkeyed/unkey/internal/services/webhooks"
+	"github.com/unkeyed/unkey/pkg/assert"
+	"github.com/unkeyed/unkey/pkg/auditlog"
+	"github.com/unkeyed/unkey/pkg/healthcheck"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/pkg/restate/restateutil"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
+)
+
+// batchSize is the number of due keys to fetch per batch.
+const batchSize = 100
+
+// defaultByteLength matches the API's default when the keyspace has none.
+const defaultByteLength = 16
+
+// Config holds the handler's dependencies.
+type Config struct {
+	// DB is the primary application database. Must not be nil.
+	DB db.Database
+	// Vault encrypts successor keys. Optional: only recoverable keys are
+	// rotated, so without vault the handler logs and does nothing.
+	Vault vault.VaultServiceClient
+	// Caches broadcasts the eviction of replaced keys to the API nodes once
+	// their grace period ends. Optional: when nil, the API stops verifying
+	// them once its cache entries go stale.
+	Caches *caches.Invalidator
+	// Heartbeat is pinged on successful completion. Must not be nil; use
+	// healthcheck.NewNoop() if monitoring is not configured.
+	Heartbeat healthcheck.Heartbeat
+}
+
+// Handler executes RunKeyRotation and ExpireRotatedKey.
+type Handler struct {
+	db        db.Database
+	vault     vault.VaultServiceClient
+	caches    *caches.Invalidator
+	heartbeat healthcheck.Heartbeat
+}
+
+// New constructs a Handler.
+func New(cfg Config) (*Handler, error) {
+	if err := assert.All(
+		assert.NotNil(cfg.DB, "DB must not be nil"),
+		assert.NotNil(cfg.Heartbeat, "Heartbeat must not be nil; use healthcheck.NewNoop()"),
+	); err != nil {
+		return nil, err
+	}
+	return &Handler{db: cfg.DB, vault: cfg.Vault, caches: cfg.Caches, heartbeat: cfg.Heartbeat}, nil
+}
+
+// rotation is the journaled outcome of rotating one key. An empty
+// SuccessorKeyID means the key was deleted or rotated by someone else
+// between the listing and the rotation, and nothing was written.
+type rotation struct {
+	SuccessorKeyID string `json:"successor_key_id"`
+	// PreviousKeyExpires is when the replaced key stops verifying, in unix
+	// milliseconds.
+	PreviousKeyExpires int64 `json:"previous_key_expires"`
+	// SuccessorExpires is the successor's expiry in unix milliseconds, or 0
+	// when it does not expire.
+	SuccessorExpires int64 `json:"successor_expires"`
+	// EventIDs are generated inside the step so retries dispatch the same
+	// webhook events.
+	RotatedEventID          string `json:"rotated_event_id"`
+	PreviousExpiredEventID  string `json:"previous_expired_event_id"`
+	SuccessorExpiredEventID string `json:"successor_expired_event_id"`
+}
+
+// Handle rotates every key whose policy is due. The VO key only names the
+// object; a run picks up whatever is due at its start, so a missed hour is
+// caught up by the next one.
+func (h *Handler) Handle(
+	ctx restate.ObjectContext,
+	_ *hydrav1.RunKeyRotationRequest,
+) (*hydrav1.RunKeyRotationResponse, error) {
+	if h.vault == nil {
+		logger.Warn("key rotation is disabled: no vault configured")
+		if err := h.ping(ctx); err != nil {
+			return nil, err
+		}
+		return &hydrav1.RunKeyRotationResponse{KeysRotated: 0}, nil
+	}
+
+	nowTime, err := restateutil.Now(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get now: %w", err)
+	}
+	now := nowTime.UnixMilli()
+	logger.Info("running key rotation", "now", now)
+
+	var totalKeysRotated int
+	var cursor uint64
+	var batchNum int
+
+	for {
+		dueKeys, fetchErr := restate.Run(ctx, func(rc restate.RunContext) ([]db.ListKeysDueForRotationRow, error) {
+			return h.db.ListKeysDueForRotation(rc, db.ListKeysDueForRotationParams{
+				AfterPk: cursor,
+				Now:     now,
+				Limit:   batchSize,
+			})
+		}, restate.WithName(fmt.Sprintf("fetch keys batch %d", batchNum)))
+		if fetchErr != nil {
+			return nil, fmt.Errorf("fetch keys: %w", fetchErr)
+		}
+
+		if len(dueKeys) == 0 {
+			break
+		}
+
+		cursor = dueKeys[len(dueKeys)-1].Pk
+
+		for _, key := range dueKeys {
+			result, rotateErr := restate.Run(ctx, func(rc restate.RunContext) (rotation, error) {
+				return h.rotate(rc, key, now)
+			}, restate.WithName(fmt.Sprintf("rotate key %s", key.ID)))
+			if rotateErr != nil {
+				if restate.IsTerminalError(rotateErr) {
+					// One broken key must not hold back every other rotation.
+					logger.Error("skipping key rotation",
+						"key_id", key.ID,
+						"error", rotateErr.Error(),
+					)
+					continue
+				}
+				return nil, fmt.Errorf("rotate key %s: %w", key.ID, rotateErr)
+			}
+			if result.SuccessorKeyID == "" {
+				continue
+			}
+
+			if err := emitWebhooks(ctx, key, result, now); err != nil {
+				return nil, err
+			}
+			h.scheduleExpiry(ctx, key, result, now)
+			totalKeysRotated++
+		}
+
+		batchNum++
+	}
+
+	logger.Info("key rotation complete", "keys_rotated", totalKeysRotated)
+
+	if err := h.ping(ctx); err != nil {
+		return nil, err
+	}
+
+	return &hydrav1.RunKeyRotationResponse{
+		KeysRotated: int32(totalKeysRotated),
+	}, nil
+}
+
+// HandleExpireRotatedKey evicts a replaced key from the API caches. Entries
+// cached before the rotation still carry the key's old expiry, so without
+// the eviction the key would keep verifying past its grace period.
+func (h *Handler) HandleExpireRotatedKey(
+	ctx restate.ObjectContext,
+	req *hydrav1.ExpireRotatedKeyRequest,
+) (*hydrav1.ExpireRotatedKeyResponse, error) {
+	if h.caches == nil {
+		return &hydrav1.ExpireRotatedKeyResponse{}, nil
+	}
+
+	keyID := restate.Key(ctx)
+	err := restate.RunVoid(ctx, func(rc restate.RunContext) error {
+		h.caches.Invalidate(rc, caches.KeyEntity(req.GetWorkspaceId(), keyID, req.GetHash()))
+		return nil
+	}, restate.WithName("invalidate key"))
+	if err != nil {
+		return nil, fmt.Errorf("invalidate key %s: %w", keyID, err)
+	}
+
+	return &hydrav1.ExpireRotatedKeyResponse{}, nil
+}
+
+// scheduleExpiry sends ExpireRotatedKey for the replaced key, delayed until
+// its grace period ends.
+func (h *Handler) scheduleExpiry(ctx restate.ObjectContext, key db.ListKeysDueForRotationRow, result rotation, now int64) {
+	if h.caches == nil {
+		return
+	}
+	hydrav1.NewCronServiceClient(ctx, key.ID).ExpireRotatedKey().Send(&hydrav1.ExpireRotatedKeyRequest{
+		WorkspaceId: key.WorkspaceID,
+		Hash:        key.Hash,
+	}, restate.WithDelay(time.Duration(max(result.PreviousKeyExpires-now, 0))*time.Millisecond))
+}
+
+func (h *Handler) ping(ctx restate.ObjectContext) error {
+	if _, err := restate.Run(ctx, func(rc restate.RunContext) (restate.Void, error) {
+		return restate.Void{}, h.heartbeat.Ping(rc)
+	}, restate.WithName("send heartbeat")); err != nil {
+		return fmt.Errorf("send heartbeat: %w", err)
+	}
+	return nil
+}
+
+// rotate issues the successor of key and shortens the key's validity to the
+// grace period, all in one transaction. The successor copies the key's
+// configuration, ratelimits, roles, permissions and key-level policy, the
+// same way a reroll does.
+func (h *Handler) rotate(ctx context.Context, key db.ListKeysDueForRotationRow, now int64) (rotation, error) {
+	policy := policyFor(key)
+
+	generated, err := keys.Generate(keys.CreateKeyRequest{
+		Prefix:     prefixFor(key),
+		ByteLength: byteLengthFor(key),
+	})
+	if err != nil {
+		return rotation{}, restate.TerminalError(fmt.Errorf("generate key: %w", err))
+	}
+
+	encryption, err := h.vault.Encrypt(ctx, &vaultv1.EncryptRequest{
+		Keyring: key.WorkspaceID,
+		Data:    generated.Key,
+	})
+	if err != nil {
+		return rotation{}, fmt.Errorf("encrypt key: %w", err)
+	}
+
+	successorID := uid.New(uid.KeyPrefix)
+
+	return db.TxWithResult(ctx, h.db.RW(), func(txCtx context.Context, tx db.DBTX) (rotation, error) {
+		q := db.NewQueries(tx)
+
+		locked, err := q.LockKeyForRotation(txCtx, key.ID)
+		if err != nil {
+			if db.IsNotFound(err) {
+				return rotation{}, nil
+			}
+			return rotation{}, fmt.Errorf("lock key: %w", err)
+		}
+		successors, err := q.CountKeyRotationsByKeyID(txCtx, key.ID)
+		if err != nil {
+			return rotation{}, fmt.Errorf("count rotations: %w", err)
+		}
+		if successors > 0 {
+			return rotation{}, nil
+		}
+
+		err = q.InsertKey(txCtx, db.InsertKeyParams{
+			ID:                 successorID,
+			KeySpaceID:         key.KeyAuthID,
+			Hash:               generated.Hash,
+			Start:              generated.Start,
+			WorkspaceID:        key.WorkspaceID,
+			ForWorkspaceID:     key.ForWorkspaceID,
+			Name:               key.Name,
+			IdentityID:         key.IdentityID,
+			Meta:               key.Meta,
+			Expires:            locked.Expires,
+			CreatedAtM:         now,
+			Enabled:            key.Enabled,
+			RemainingRequests:  key.RemainingRequests,
+			RefillDay:          key.RefillDay,
+			RefillAmount:       key.RefillAmount,
+			PendingMigrationID: sql.NullString{Valid: false, String: ""},
+			Environment:        key.Environment,
+		})
+		if err != nil {
+			return rotation{}, fmt.Errorf("insert key: %w", err)
+		}
+
+		err = q.InsertKeyEncryption(txCtx, db.InsertKeyEncryptionParams{
+			WorkspaceID:     key.WorkspaceID,
+			KeyID:           successorID,
+			Encrypted:       encryption.GetEncrypted(),
+			EncryptionKeyID: encryption.GetKeyId(),
+			CreatedAt:       now,
+		})
+		if err != nil {
+			return rotation{}, fmt.Errorf("insert key encryption: %w", err)
+		}
+
+		if err := copyAccess(txCtx, q, key, successorID, now); err != nil {
+			return rotation{}, err
+		}
+
+		if key.KeyIntervalMs.Valid {
+			err = q.InsertKeyRotationPolicy(txCtx, db.InsertKeyRotationPolicyParams{
+				ScopeID:       successorID,
+				WorkspaceID:   key.WorkspaceID,
+				KeyAuthID:     key.KeyAuthID,
+				IntervalMs:    key.KeyIntervalMs.Int64,
+				GracePeriodMs: key.KeyGracePeriodMs.Int64,
+				CreatedAtM:    now,
+			})
+			if err != nil {
+				return rotation{}, fmt.Errorf("insert rotation policy: %w", err)
+			}
+		}
+
+		err = q.InsertKeyRotation(txCtx, db.InsertKeyRotationParams{
+			KeyID:          key.ID,
+			SuccessorKeyID: successorID,
+			WorkspaceID:    key.WorkspaceID,
+			KeyAuthID:      key.KeyAuthID,
+			Reason:         db.KeyRotationsReasonSchedule,
+			CreatedAtM:     now,
+		})
+		if err != nil {
+			return rotation{}, fmt.Errorf("insert rotation: %w", err)
+		}
+
+		previousKeyExpires := graceExpiry(locked.Expires, now, policy.gracePeriodMs)
+		err = q.UpdateKeyExpires(txCtx, db.UpdateKeyExpiresParams{
+			Expires: sql.NullTime{Time: time.UnixMilli(previousKeyExpires), Valid: true},
+			Now:     sql.NullInt64{Int64: now, Valid: true},
+			ID:      key.ID,
+		})
+		if err != nil {
+			return rotation{}, fmt.Errorf("update key expiry: %w", err)
+		}
+
+		outboxRow, err := buildOutboxRow(key, successorID, previousKeyExpires, now)
+		if err != nil {
+			return rotation{}, err
+		}
+		if err := q.InsertClickhouseOutbox(txCtx, outboxRow); err != nil {
+			return rotation{}, fmt.Errorf("insert clickhouse outbox row: %w", err)
+		}
+
+		var successorExpires int64
+		if locked.Expires.Valid {
+			successorExpires = locked.Expires.Time.UnixMilli()
+		}
+
+		return rotation{
+			SuccessorKeyID:          successorID,
+			PreviousKeyExpires:      previousKeyExpires,
+			SuccessorExpires:        successorExpires,
+			RotatedEventID:          uid.New(uid.WebhookEventPrefix),
+			PreviousExpiredEventID:  uid.New(uid.WebhookEventPrefix),
+			SuccessorExpiredEventID: uid.New(uid.WebhookEventPrefix),
+		}, nil
+	})
+}
+
+// copyAccess gives the successor the key's own ratelimits, roles and
+// permissions. Identity ratelimits are shared through the identity and need
+// no copy.
+func copyAccess(ctx context.Context, q *db.Queries, key db.ListKeysDueForRotationRow, successorID string, now int64) error {
+	ratelimits, err := q.ListRatelimitsByKeyID(ctx, sql.NullString{String: key.ID, Valid: true})
+	if err != nil {
+		return fmt.Errorf("list ratelimits: %w", err)
+	}
+	for _, rl := range ratelimits {
+		err = q.InsertKeyRatelimit(ctx, db.InsertKeyRatelimitParams{
+			ID:          uid.New(uid.RatelimitPrefix),
+			WorkspaceID: key.WorkspaceID,
+			KeyID:       sql.NullString{String: successorID, Valid: true},
+			Name:        rl.Name,
+			Limit:       rl.Limit,
+			Duration:    rl.Duration,
+			AutoApply:   rl.AutoApply,
+			CreatedAt:   now,
+			UpdatedAt:   sql.NullInt64{Valid: false, Int64: 0},
+		})
+		if err != nil {
+			return fmt.Errorf("insert ratelimit: %w", err)
+		}
+	}
+
+	roleIDs, err := q.ListRoleIDsByKeyID(ctx, key.ID)
+	if err != nil {
+		return fmt.Errorf("list roles: %w", err)
+	}
+	for _, roleID := range roleIDs {
+		err = q.InsertKeyRole(ctx, db.InsertKeyRoleParams{
+			KeyID:       successorID,
+			RoleID:      roleID,
+			WorkspaceID: key.WorkspaceID,
+			CreatedAtM:  now,
+		})
+		if err != nil {
+			return fmt.Errorf("insert key role: %w", err)
+		}
+	}
+
+	permissionIDs, err := q.ListPermissionIDsByKeyID(ctx, key.ID)
+	if err != nil {
+		return fmt.Errorf("list permissions: %w", err)
+	}
+	for _, permissionID := range permissionIDs {
+		err = q.InsertKeyPermission(ctx, db.InsertKeyPermissionParams{
+			KeyID:        successorID,
+			PermissionID: permissionID,
+			WorkspaceID:  key.WorkspaceID,
+			CreatedAt:    now,
+			UpdatedAt:    sql.NullInt64{Valid: false, Int64: 0},
+		})
+		if err != nil {
+			return fmt.Errorf("insert key permission: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// emitWebhooks sends key.rotated now and schedules key.expired for the
+// replaced key, and for the successor when it inherited an expiry.
+func emitWebhooks(ctx restate.ObjectContext, key db.ListKeysDueForRotationRow, result rotation, now int64) error {
+	client := hydrav1.NewWebhookServiceClient(ctx)
+
+	rotated, err := json.Marshal(webhooks.KeyRotatedData{
+		KeyID:              result.SuccessorKeyID,
+		PreviousKeyID:      key.ID,
+		APIID:              key.ApiID,
+		PreviousKeyExpires: result.PreviousKeyExpires,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal key.rotated data: %w", err)
+	}
+	client.Dispatch().Send(&hydrav1.DispatchWebhookRequest{
+		WorkspaceId: key.WorkspaceID,
+		EventId:     result.RotatedEventID,
+		EventType:   string(webhooks.EventKeyRotated),
+		CreatedAt:   now,
+		Data:        rotated,
+	})
+
+	expiries := []struct {
+		eventID string
+		keyID   string
+		expires int64
+	}{
+		{eventID: result.PreviousExpiredEventID, keyID: key.ID, expires: result.PreviousKeyExpires},
+		{eventID: result.SuccessorExpiredEventID, keyID: result.SuccessorKeyID, expires: result.SuccessorExpires},
+	}
+	for _, expiry := range expiries {
+		if expiry.expires == 0 {
+			continue
+		}
+		expired, err := json.Marshal(webhooks.KeyExpiredData{
+			KeyID:   expiry.keyID,
+			APIID:   key.ApiID,
+			Expires: expiry.expires,
+		})
+		if err != nil {
+			return fmt.Errorf("marshal key.expired data: %w", err)
+		}
+		client.Dispatch().Send(&hydrav1.DispatchWebhookRequest{
+			WorkspaceId: key.WorkspaceID,
+			EventId:     expiry.eventID,
+			EventType:   string(webhooks.EventKeyExpired),
+			CreatedAt:   expiry.expires,
+			Data:        expired,
+		}, restate.WithDelay(time.Duration(max(expiry.expires-now, 0))*time.Millisecond))
+	}
+
+	return nil
+}
+
+// policy is the rotation schedule that applies to a key.
+type policy struct {
+	intervalMs    int64
+	gracePeriodMs int64
+}
+
+// policyFor returns the key's own policy when it has one, and its
+// keyspace's otherwise. The listing query only returns keys with at least
+// one of the two.
+func policyFor(key db.ListKeysDueForRotationRow) policy {
+	if key.KeyIntervalMs.Valid {
+		return policy{intervalMs: key.KeyIntervalMs.Int64, gracePeriodMs: key.KeyGracePeriodMs.Int64}
+	}
+	return policy{intervalMs: key.KeyspaceIntervalMs.Int64, gracePeriodMs: key.KeyspaceGracePeriodMs.Int64}
+}
+
+// prefixFor keeps the key's own prefix so clients that route on it keep
+// working, falling back to the keyspace default like a reroll does. The
+// prefix ends at the last "_" of the start, since prefixes may contain "_"
+// but the random part never does.
+func prefixFor(key db.ListKeysDueForRotationRow) string {
+	if i := strings.LastIndex(key.Start, "_"); i >= 0 {
+		return key.Start[:i]
+	}
+	if key.DefaultPrefix.Valid {
+		return key.DefaultPrefix.String
+	}
+	return ""
+}
+
+// byteLengthFor returns the keyspace's default byte length.
+func byteLengthFor(key db.ListKeysDueForRotationRow) int {
+	if key.DefaultBytes.Valid && key.DefaultBytes.Int32 > 0 {
+		return int(key.DefaultBytes.Int32)
+	}
+	return defaultByteLength
+}
+
+// graceExpiry returns when the replaced key stops verifying: after the
+// grace period, but never later than the expiry it already had.
+func graceExpiry(expires sql.NullTime, now, gracePeriodMs int64) int64 {
+	graceEnd := now + gracePeriodMs
+	if expires.Valid && expires.Time.UnixMilli() < graceEnd {
+		return expires.Time.UnixMilli()
+	}
+	return graceEnd
+}
+
+// buildOutboxRow creates the clickhouse_outbox row auditing one rotation.
+// The AuditLogExport drainer ships it into ClickHouse audit_logs_raw_v1.
+func buildOutboxRow(key db.ListKeysDueForRotationRow, successorID string, previousKeyExpires, now int64) (db.InsertClickhouseOutboxParams, error) {
+	envelope := auditlog.Event{
+		EventID:       uid.New(uid.AuditLogPrefix, 24),
+		Time:          now,
+		WorkspaceID:   key.WorkspaceID,
+		Bucket:        "unkey_mutations",
+		Source:        auditlog.EventSourcePlatform,
+		Event:         string(auditlog.KeyRotateEvent),
+		Description:   fmt.Sprintf("Rotated key %s into %s", key.ID, successorID),
+		RemoteIP:      "",
+		UserAgent:     "",
+		Meta:          nil,
+		CorrelationID: "",
+		Actor: auditlog.EventActor{
+			Type: "system",
+			ID:   "keyrotation",
+			Name: "Key Rotation Service",
+			Meta: nil,
+		},
+		Targets: []auditlog.EventTarget{
+			{
+				Type: string(auditlog.KeyResourceType),
+				ID:   successorID,
+				Name: displayName(key),
+				Meta: nil,
+			},
+			{
+				Type: string(auditlog.KeyResourceType),
+				ID:   key.ID,
+				Name: displayName(key),
+				Meta: map[string]any{
+					"expires": previousKeyExpires,
+				},
+			},
+			{
+				Type: string(auditlog.APIResourceType),
+				ID:   key.ApiID,
+				Name: key.ApiID,
+				Meta: nil,
+			},
+		},
+	}
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return db.InsertClickhouseOutboxParams{}, fmt.Errorf("marshal audit envelope for key %s: %w", key.ID, err)
+	}
+
+	return db.InsertClickhouseOutboxParams{
+		Version:     auditlog.OutboxVersionV1,
+		WorkspaceID: key.WorkspaceID,
+		EventID:     envelope.EventID,
+		Payload:     payload,
+		CreatedAt:   now,
+	}, nil
+}
+
+// displayName returns a display name for the key, using the name if
+// available, falling back to the ID.
+func displayName(key db.ListKeysDueForRotationRow) string {
+	if key.Name.Valid && key.Name.String != "" {
+		return key.Name.String
+	}
+	return key.ID
+}
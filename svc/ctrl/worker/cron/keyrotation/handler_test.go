@@ -0,0 +1,63 @@
+package keyrotation
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
+)
+
+func TestPolicyFor_KeyPolicyWinsOverKeyspace(t *testing.T) {
+	key := db.ListKeysDueForRotationRow{
+		KeyIntervalMs:         sql.NullInt64{Int64: 86400000, Valid: true},
+		KeyGracePeriodMs:      sql.NullInt64{Int64: 0, Valid: true},
+		KeyspaceIntervalMs:    sql.NullInt64{Int64: 7 * 86400000, Valid: true},
+		KeyspaceGracePeriodMs: sql.NullInt64{Int64: 3600000, Valid: true},
+	}
+	require.Equal(t, policy{intervalMs: 86400000, gracePeriodMs: 0}, policyFor(key))
+
+	key.KeyIntervalMs = sql.NullInt64{}
+	key.KeyGracePeriodMs = sql.NullInt64{}
+	require.Equal(t, policy{intervalMs: 7 * 86400000, gracePeriodMs: 3600000}, policyFor(key))
+}
+
+func TestPrefixFor(t *testing.T) {
+	tests := []struct {
+		name          string
+		start         string
+		defaultPrefix sql.NullString
+		want          string
+	}{
+		{name: "keeps the key's own prefix", start: "sk_abc", defaultPrefix: sql.NullString{String: "prod", Valid: true}, want: "sk"},
+		{name: "keeps a prefix containing underscores", start: "sk_live_abc", defaultPrefix: sql.NullString{String: "prod", Valid: true}, want: "sk_live"},
+		{name: "falls back to the keyspace default", start: "abcdef", defaultPrefix: sql.NullString{String: "prod", Valid: true}, want: "prod"},
+		{name: "no prefix at all", start: "abcdef", defaultPrefix: sql.NullString{}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, prefixFor(db.ListKeysDueForRotationRow{Start: tt.start, DefaultPrefix: tt.defaultPrefix}))
+		})
+	}
+}
+
+func TestByteLengthFor(t *testing.T) {
+	require.Equal(t, defaultByteLength, byteLengthFor(db.ListKeysDueForRotationRow{}))
+	require.Equal(t, 32, byteLengthFor(db.ListKeysDueForRotationRow{DefaultBytes: sql.NullInt32{Int32: 32, Valid: true}}))
+}
+
+// TestGraceExpiry_NeverExtendsExistingExpiry guards the promise that a
+// rotation only ever shortens the replaced key's lifetime.
+func TestGraceExpiry_NeverExtendsExistingExpiry(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	grace := int64(24 * time.Hour / time.Millisecond)
+
+	require.Equal(t, now+grace, graceExpiry(sql.NullTime{}, now, grace))
+
+	later := sql.NullTime{Time: time.UnixMilli(now + 2*grace), Valid: true}
+	require.Equal(t, now+grace, graceExpiry(later, now, grace))
+
+	sooner := sql.NullTime{Time: time.UnixMilli(now + grace/2), Valid: true}
+	require.Equal(t, now+grace/2, graceExpiry(sooner, now, grace))
+}
@@ -624,6 +624,7 @@ func (w *Workflow) createTopologies(
 			AutoscalingReplicasMax:     autoscalingMax,
 			AutoscalingThresholdCpu:    rs.AutoscalingThresholdCpu,
 			AutoscalingThresholdMemory: rs.AutoscalingThresholdMemory,
+			AutoscalingThresholdRps:    rs.AutoscalingThresholdRps,
 			DesiredStatus:              db.DeploymentTopologyDesiredStatusRunning,
 		})
 	}
@@ -12,6 +12,10 @@
 // the spend cap (ENG-2923) with SUSPEND (resumable). They differ only in the
 // desired state the stopped deployments land in.
 //
+// ARCHIVE also releases the workspace's persistent volumes. Their data is kept
+// for a retention window, after which a delayed PurgeVolumes marks them purged
+// and tells krane in every region to delete the claims.
+//
 // The object is keyed by workspace id, so each workspace's teardowns serialize
 // and a stuck drain in one workspace cannot block another's.
 package deployteardown
@@ -17,6 +17,7 @@ type VirtualObject struct {
 	db                db.Database
 	drainPollInterval time.Duration
 	drainGraceTimeout time.Duration
+	volumeRetention   time.Duration
 }
 
 var _ hydrav1.DeployTeardownServiceServer = (*VirtualObject)(nil)
@@ -37,6 +38,11 @@ type Config struct {
 	// forces completion. Zero uses the production default
 	// (defaultDrainGraceTimeout).
 	DrainGraceTimeout time.Duration
+
+	// VolumeRetention is how long an archived workspace's persistent volumes
+	// keep their data before PurgeVolumes deletes it. Zero uses the production
+	// default (defaultVolumeRetention).
+	VolumeRetention time.Duration
 }
 
 // New constructs a VirtualObject. It returns an error if any required
@@ -51,10 +57,14 @@ func New(cfg Config) (*VirtualObject, error) {
 	if cfg.DrainGraceTimeout <= 0 {
 		cfg.DrainGraceTimeout = defaultDrainGraceTimeout
 	}
+	if cfg.VolumeRetention <= 0 {
+		cfg.VolumeRetention = defaultVolumeRetention
+	}
 	return &VirtualObject{
 		UnimplementedDeployTeardownServiceServer: hydrav1.UnimplementedDeployTeardownServiceServer{},
 		db:                                       cfg.DB,
 		drainPollInterval:                        cfg.DrainPollInterval,
 		drainGraceTimeout:                        cfg.DrainGraceTimeout,
+		volumeRetention:                          cfg.VolumeRetention,
 	}, nil
 }
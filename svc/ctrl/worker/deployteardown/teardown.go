@@ -25,11 +25,20 @@ const (
 	// forever on a stuck pod: billing must never hang on a drain that won't
 	// finish.
 	defaultDrainGraceTimeout = 5 * time.Minute
+
+	// defaultVolumeRetention is how long an archived workspace's persistent
+	// volumes keep their data. Long enough to recover from an accidental
+	// cancel; short enough that we don't pay for storage nobody bills for.
+	defaultVolumeRetention = 7 * 24 * time.Hour
 )
 
 // Teardown stops every running deployment in the workspace and polls until they
 // drain. The workspace id is the virtual object key.
 //
+// ARCHIVE additionally releases the workspace's persistent volumes and
+// schedules PurgeVolumes after the retention window, so a mistaken cancel can
+// still be undone without data loss.
+//
 // For each deployment that is its app's current deployment it first clears
 // apps.current_deployment_id: the DeploymentService guard refuses to change the
 // current deployment, and a torn-down app genuinely has no current deployment,
@@ -57,6 +66,15 @@ func (v *VirtualObject) Teardown(
 		return nil, fmt.Errorf("list running deployments: %w", err)
 	}
 
+	// ARCHIVE releases the workspace's persistent volumes whether or not
+	// anything is still running: an app stopped before the cancel still owns
+	// its data. SUSPEND leaves them mounted-ready for Resume.
+	if req.GetMode() == hydrav1.TeardownMode_TEARDOWN_MODE_ARCHIVE {
+		if err := v.releaseVolumes(ctx, workspaceID); err != nil {
+			return nil, err
+		}
+	}
+
 	if len(running) == 0 {
 		logger.Info("teardown: nothing running", "workspace_id", workspaceID)
 		return &hydrav1.TeardownResponse{DeploymentsStopped: 0, Drained: true}, nil
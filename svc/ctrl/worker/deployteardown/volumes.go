@@ -0,0 +1,122 @@
+package deployteardown
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/pkg/restate/restateutil"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
+)
+
+// releaseVolumes marks the workspace's active persistent volumes as released
+// and schedules PurgeVolumes for when their retention window ends. The
+// release time is journaled so a replay schedules the purge for the same
+// cutoff.
+func (v *VirtualObject) releaseVolumes(ctx restate.ObjectContext, workspaceID string) error {
+	now, err := restateutil.Now(ctx)
+	if err != nil {
+		return fmt.Errorf("get current time: %w", err)
+	}
+	releasedAt := now.UnixMilli()
+
+	released, err := restate.Run(ctx, func(rc restate.RunContext) (int64, error) {
+		return v.db.ReleaseVolumesByWorkspace(rc, db.ReleaseVolumesByWorkspaceParams{
+			ReleasedAt:  sql.NullInt64{Valid: true, Int64: releasedAt},
+			WorkspaceID: workspaceID,
+		})
+	}, restate.WithName("release volumes"))
+	if err != nil {
+		return fmt.Errorf("release volumes: %w", err)
+	}
+	if released == 0 {
+		return nil
+	}
+
+	hydrav1.NewDeployTeardownServiceClient(ctx, workspaceID).
+		PurgeVolumes().
+		Send(&hydrav1.PurgeVolumesRequest{ReleasedBefore: releasedAt}, restate.WithDelay(v.volumeRetention))
+
+	logger.Info("teardown: released volumes",
+		"workspace_id", workspaceID,
+		"volumes_released", released,
+		"retention", v.volumeRetention.String(),
+	)
+	return nil
+}
+
+// PurgeVolumes deletes the data of the workspace's released persistent
+// volumes whose retention window has passed. The workspace id is the virtual
+// object key.
+//
+// Each volume is first marked purged, guarded on it still being released, so
+// a volume reactivated during its window (by environments.createVolume with
+// its name, see ReactivateVolume in pkg/db) is never deleted. A deployment change
+// is then written to every region the app was deployed to; krane in each
+// region deletes its PersistentVolumeClaim when it sees it.
+func (v *VirtualObject) PurgeVolumes(ctx restate.ObjectContext, req *hydrav1.PurgeVolumesRequest) (*hydrav1.PurgeVolumesResponse, error) {
+	workspaceID := restate.Key(ctx)
+
+	volumes, err := restate.Run(ctx, func(rc restate.RunContext) ([]db.Volume, error) {
+		return v.db.ListReleasedVolumesByWorkspace(rc, db.ListReleasedVolumesByWorkspaceParams{
+			WorkspaceID:    workspaceID,
+			ReleasedBefore: sql.NullInt64{Valid: true, Int64: req.GetReleasedBefore()},
+		})
+	}, restate.WithName("list released volumes"))
+	if err != nil {
+		return nil, fmt.Errorf("list released volumes: %w", err)
+	}
+
+	purged := 0
+	for _, vol := range volumes {
+		claimed, err := restate.Run(ctx, func(rc restate.RunContext) (bool, error) {
+			rows, err := v.db.MarkVolumePurged(rc, db.MarkVolumePurgedParams{
+				UpdatedAt: sql.NullInt64{Valid: true, Int64: time.Now().UnixMilli()},
+				ID:        vol.ID,
+			})
+			return rows > 0, err
+		}, restate.WithName("mark volume purged "+vol.ID))
+		if err != nil {
+			return nil, fmt.Errorf("mark volume %s purged: %w", vol.ID, err)
+		}
+		if !claimed {
+			// Reactivated (or purged by an earlier run) since the list.
+			continue
+		}
+
+		regions, err := restate.Run(ctx, func(rc restate.RunContext) ([]string, error) {
+			return v.db.FindVolumeRegions(rc, db.FindVolumeRegionsParams{
+				AppID:         vol.AppID,
+				EnvironmentID: vol.EnvironmentID,
+			})
+		}, restate.WithName("find volume regions "+vol.ID))
+		if err != nil {
+			return nil, fmt.Errorf("find regions for volume %s: %w", vol.ID, err)
+		}
+
+		for _, regionID := range regions {
+			if err := restate.RunVoid(ctx, func(rc restate.RunContext) error {
+				return v.db.InsertDeploymentChange(rc, db.InsertDeploymentChangeParams{
+					ResourceType: db.DeploymentChangesResourceTypeVolume,
+					ResourceID:   vol.ID,
+					RegionID:     regionID,
+					CreatedAt:    time.Now().UnixMilli(),
+				})
+			}, restate.WithName(fmt.Sprintf("notify volume %s purge in %s", vol.ID, regionID))); err != nil {
+				return nil, fmt.Errorf("notify volume %s purge in %s: %w", vol.ID, regionID, err)
+			}
+		}
+
+		purged++
+	}
+
+	logger.Info("purge volumes complete",
+		"workspace_id", workspaceID,
+		"volumes_purged", purged,
+	)
+
+	return &hydrav1.PurgeVolumesResponse{VolumesPurged: int32(purged)}, nil
+}
@@ -0,0 +1,280 @@
+package keybulk
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/pkg/auditlog"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/actor"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
+)
+
+// errKeyNotFound is recorded for ids that match no live key of the api. Keys
+// of other apis and workspaces report the same error so bulk requests cannot
+// probe for them.
+const errKeyNotFound = "key not found"
+
+// applyItem applies the operation to one key and records the item's outcome
+// in the same transaction, together with the key's audit log. The outcome's
+// webhook event is stored on the item, so a retry that finds the item
+// finished rebuilds the same outcome instead of losing the event.
+func (s *Service) applyItem(
+	ctx context.Context,
+	operationID string,
+	req *hydrav1.RunKeyBulkOperationRequest,
+	keyID string,
+) (outcome, error) {
+	return db.TxWithResultRetry(ctx, s.db.RW(), func(txCtx context.Context, tx db.DBTX) (outcome, error) {
+		q := db.NewQueries(tx)
+		now := time.Now().UnixMilli()
+
+		result := outcome{Succeeded: false, KeyID: keyID, Hash: "", EventID: "", Expires: 0, At: now}
+		reason := ""
+
+		key, err := q.LockKeyForBulkOperation(txCtx, keyID)
+		switch {
+		case db.IsNotFound(err):
+			reason = errKeyNotFound
+		case err != nil:
+			return outcome{}, fmt.Errorf("lock key: %w", err)
+		case key.WorkspaceID != req.GetWorkspaceId() || key.KeyAuthID != req.GetKeyAuthId():
+			reason = errKeyNotFound
+		default:
+			var log auditlog.AuditLog
+			log, reason, err = s.apply(txCtx, q, req, key, now)
+			if err != nil {
+				return outcome{}, err
+			}
+			if reason == "" {
+				if err := s.auditlogs.Insert(txCtx, tx, []auditlog.AuditLog{log}); err != nil {
+					return outcome{}, fmt.Errorf("insert audit log: %w", err)
+				}
+				result.Succeeded = true
+				result.Hash = key.Hash
+				if req.GetDelete() != nil {
+					result.EventID = uid.New(uid.WebhookEventPrefix)
+				}
+				if expires, ok := setExpiry(req.GetUpdate()); ok {
+					result.EventID = uid.New(uid.WebhookEventPrefix)
+					result.Expires = expires
+				}
+			}
+		}
+
+		status := db.KeyBulkOperationItemsStatusSucceeded
+		if !result.Succeeded {
+			status = db.KeyBulkOperationItemsStatusFailed
+		}
+		err = q.UpdateKeyBulkOperationItem(txCtx, db.UpdateKeyBulkOperationItemParams{
+			Status:      status,
+			Error:       sql.NullString{String: reason, Valid: reason != ""},
+			EventID:     sql.NullString{String: result.EventID, Valid: result.EventID != ""},
+			Expires:     sql.NullInt64{Int64: result.Expires, Valid: result.Expires != 0},
+			Now:         sql.NullInt64{Int64: now, Valid: true},
+			OperationID: operationID,
+			KeyID:       keyID,
+		})
+		if err != nil {
+			return outcome{}, fmt.Errorf("update item: %w", err)
+		}
+
+		return result, nil
+	})
+}
+
+// apply changes a locked key and returns its audit log. A non-empty reason
+// means the key cannot take the change and nothing was written; an error
+// means the database failed.
+func (s *Service) apply(
+	ctx context.Context,
+	q *db.Queries,
+	req *hydrav1.RunKeyBulkOperationRequest,
+	key db.LockKeyForBulkOperationRow,
+	now int64,
+) (auditlog.AuditLog, string, error) {
+	switch op := req.GetOperation().(type) {
+	case *hydrav1.RunKeyBulkOperationRequest_Update:
+		params := updatedKey(key, op.Update, now)
+		if err := q.UpdateKeyForBulkOperation(ctx, params); err != nil {
+			return auditlog.AuditLog{}, "", fmt.Errorf("update key: %w", err)
+		}
+		return newAuditLog(req, auditlog.KeyUpdateEvent, fmt.Sprintf("Updated key %s", key.ID), params.Name.String, key.ID), "", nil
+
+	case *hydrav1.RunKeyBulkOperationRequest_Delete:
+		err := q.SoftDeleteKeyByID(ctx, db.SoftDeleteKeyByIDParams{
+			Now: sql.NullInt64{Int64: now, Valid: true},
+			ID:  key.ID,
+		})
+		if err != nil {
+			return auditlog.AuditLog{}, "", fmt.Errorf("delete key: %w", err)
+		}
+		return newAuditLog(req, auditlog.KeyDeleteEvent, fmt.Sprintf("Deleted %s", key.ID), key.Name.String, key.ID), "", nil
+
+	case *hydrav1.RunKeyBulkOperationRequest_SetPermissions:
+		if err := q.DeleteAllKeyPermissionsByKeyID(ctx, key.ID); err != nil {
+			return auditlog.AuditLog{}, "", fmt.Errorf("clear permissions: %w", err)
+		}
+
+		permissions := op.SetPermissions.GetPermissions()
+		slugs := make([]string, len(permissions))
+		resources := make([]auditlog.AuditLogResource, 0, len(permissions))
+		for i, permission := range permissions {
+			err := q.InsertKeyPermission(ctx, db.InsertKeyPermissionParams{
+				KeyID:        key.ID,
+				PermissionID: permission.GetId(),
+				WorkspaceID:  key.WorkspaceID,
+				CreatedAt:    now,
+				UpdatedAt:    sql.NullInt64{Int64: now, Valid: true},
+			})
+			if err != nil {
+				return auditlog.AuditLog{}, "", fmt.Errorf("insert permission %s: %w", permission.GetId(), err)
+			}
+			slugs[i] = permission.GetSlug()
+			resources = append(resources, auditlog.AuditLogResource{
+				Type:        auditlog.PermissionResourceType,
+				ID:          permission.GetId(),
+				Name:        permission.GetSlug(),
+				DisplayName: permission.GetName(),
+				Meta:        map[string]any{},
+			})
+		}
+
+		display := fmt.Sprintf("Removed all permissions from key %s", key.ID)
+		if len(slugs) > 0 {
+			display = fmt.Sprintf("Set permissions of key %s to %s", key.ID, strings.Join(slugs, ", "))
+		}
+		log := newAuditLog(req, auditlog.KeyUpdateEvent, display, key.Name.String, key.ID)
+		log.Resources = append(log.Resources, resources...)
+		return log, "", nil
+
+	default:
+		return auditlog.AuditLog{}, fmt.Sprintf("unsupported operation %T", op), nil
+	}
+}
+
+// updatedKey merges an update into the key's current values. Fields the
+// update does not set keep their value; clear flags remove it.
+func updatedKey(key db.LockKeyForBulkOperationRow, update *hydrav1.KeyBulkUpdate, now int64) db.UpdateKeyForBulkOperationParams {
+	params := db.UpdateKeyForBulkOperationParams{
+		Name:    key.Name,
+		Meta:    key.Meta,
+		Expires: key.Expires,
+		Enabled: key.Enabled,
+		Now:     sql.NullInt64{Int64: now, Valid: true},
+		ID:      key.ID,
+	}
+
+	switch {
+	case update.GetClearName():
+		params.Name = sql.NullString{String: "", Valid: false}
+	case update.Name != nil:
+		params.Name = sql.NullString{String: update.GetName(), Valid: true}
+	}
+
+	switch {
+	case update.GetClearMeta():
+		params.Meta = sql.NullString{String: "", Valid: false}
+	case update.Meta != nil:
+		params.Meta = sql.NullString{String: update.GetMeta(), Valid: true}
+	}
+
+	switch {
+	case update.GetClearExpires():
+		params.Expires = sql.NullTime{Time: time.Time{}, Valid: false}
+	case update.Expires != nil:
+		params.Expires = sql.NullTime{Time: time.UnixMilli(update.GetExpires()), Valid: true}
+	}
+
+	if update.Enabled != nil {
+		params.Enabled = update.GetEnabled()
+	}
+
+	return params
+}
+
+// setExpiry returns the expiry an update sets, which schedules a key.expired
+// event. Updates that keep or clear the expiry schedule none.
+func setExpiry(update *hydrav1.KeyBulkUpdate) (int64, bool) {
+	if update == nil || update.Expires == nil || update.GetClearExpires() {
+		return 0, false
+	}
+	return update.GetExpires(), true
+}
+
+// newAuditLog builds the audit log of one key, attributed to the caller of
+// the bulk route and grouped by the operation's correlation ID.
+func newAuditLog(req *hydrav1.RunKeyBulkOperationRequest, event auditlog.AuditLogEvent, display string, keyName string, keyID string) auditlog.AuditLog {
+	a := req.GetActor()
+	return auditlog.AuditLog{
+		WorkspaceID:   req.GetWorkspaceId(),
+		Event:         event,
+		Display:       display,
+		ActorID:       a.GetId(),
+		ActorName:     a.GetName(),
+		ActorType:     actor.AuditType(a.GetType()),
+		ActorMeta:     actor.Meta(a.GetMeta()),
+		RemoteIP:      a.GetRemoteIp(),
+		UserAgent:     a.GetUserAgent(),
+		CorrelationID: req.GetCorrelationId(),
+		Resources: []auditlog.AuditLogResource{
+			{
+				Type:        auditlog.KeyResourceType,
+				ID:          keyID,
+				Name:        keyName,
+				DisplayName: keyName,
+				Meta:        map[string]any{},
+			},
+			{
+				Type:        auditlog.APIResourceType,
+				ID:          req.GetApiId(),
+				Name:        "",
+				DisplayName: "",
+				Meta:        map[string]any{},
+			},
+		},
+	}
+}
+
+// parseMetaFilter decodes the filter's meta object. Empty input means no
+// meta criterion.
+func parseMetaFilter(raw []byte) (map[string]any, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var filter map[string]any
+	if err := json.Unmarshal(raw, &filter); err != nil {
+		return nil, fmt.Errorf("decode meta filter: %w", err)
+	}
+	return filter, nil
+}
+
+// metaMatches reports whether every top level entry of filter equals the
+// same entry of the key's meta. Keys without meta, or with meta that is not
+// a JSON object, only match an empty filter.
+func metaMatches(meta sql.NullString, filter map[string]any) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	if !meta.Valid || meta.String == "" {
+		return false
+	}
+
+	var values map[string]any
+	if err := json.Unmarshal([]byte(meta.String), &values); err != nil {
+		return false
+	}
+	for name, want := range filter {
+		got, ok := values[name]
+		if !ok || !reflect.DeepEqual(got, want) {
+			return false
+		}
+	}
+	return true
+}
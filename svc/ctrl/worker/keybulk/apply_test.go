@@ -0,0 +1,101 @@
+package keybulk
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUpdatedKey(t *testing.T) {
+	expires := sql.NullTime{Time: time.UnixMilli(1_900_000_000_000), Valid: true}
+	key := db.LockKeyForBulkOperationRow{
+		ID:      "key_1",
+		Name:    sql.NullString{String: "old", Valid: true},
+		Meta:    sql.NullString{String: `{"plan":"free"}`, Valid: true},
+		Expires: expires,
+		Enabled: true,
+	}
+
+	t.Run("unset fields keep their value", func(t *testing.T) {
+		params := updatedKey(key, &hydrav1.KeyBulkUpdate{Enabled: proto.Bool(false)}, 1)
+		require.Equal(t, key.Name, params.Name)
+		require.Equal(t, key.Meta, params.Meta)
+		require.Equal(t, expires, params.Expires)
+		require.False(t, params.Enabled)
+		require.Equal(t, "key_1", params.ID)
+	})
+
+	t.Run("set fields replace the value", func(t *testing.T) {
+		params := updatedKey(key, &hydrav1.KeyBulkUpdate{
+			Name:    proto.String("new"),
+			Meta:    proto.String(`{"plan":"pro"}`),
+			Expires: proto.Int64(2_000_000_000_000),
+		}, 1)
+		require.Equal(t, sql.NullString{String: "new", Valid: true}, params.Name)
+		require.Equal(t, sql.NullString{String: `{"plan":"pro"}`, Valid: true}, params.Meta)
+		require.Equal(t, int64(2_000_000_000_000), params.Expires.Time.UnixMilli())
+		require.True(t, params.Enabled)
+	})
+
+	t.Run("clear flags win", func(t *testing.T) {
+		params := updatedKey(key, &hydrav1.KeyBulkUpdate{
+			Name:         proto.String("ignored"),
+			ClearName:    true,
+			ClearMeta:    true,
+			ClearExpires: true,
+		}, 1)
+		require.False(t, params.Name.Valid)
+		require.False(t, params.Meta.Valid)
+		require.False(t, params.Expires.Valid)
+	})
+}
+
+func TestSetExpiry(t *testing.T) {
+	expires, ok := setExpiry(&hydrav1.KeyBulkUpdate{Expires: proto.Int64(2_000_000_000_000)})
+	require.True(t, ok)
+	require.Equal(t, int64(2_000_000_000_000), expires)
+
+	_, ok = setExpiry(&hydrav1.KeyBulkUpdate{Enabled: proto.Bool(false)})
+	require.False(t, ok)
+
+	_, ok = setExpiry(&hydrav1.KeyBulkUpdate{Expires: proto.Int64(2_000_000_000_000), ClearExpires: true})
+	require.False(t, ok)
+
+	_, ok = setExpiry(nil)
+	require.False(t, ok)
+}
+
+func TestMetaMatches(t *testing.T) {
+	filter, err := parseMetaFilter([]byte(`{"plan":"pro","limits":{"seats":5}}`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		meta sql.NullString
+		want bool
+	}{
+		{name: "all entries equal", meta: sql.NullString{String: `{"plan":"pro","limits":{"seats":5},"extra":true}`, Valid: true}, want: true},
+		{name: "one entry differs", meta: sql.NullString{String: `{"plan":"free","limits":{"seats":5}}`, Valid: true}, want: false},
+		{name: "nested value differs", meta: sql.NullString{String: `{"plan":"pro","limits":{"seats":6}}`, Valid: true}, want: false},
+		{name: "entry missing", meta: sql.NullString{String: `{"plan":"pro"}`, Valid: true}, want: false},
+		{name: "no meta", meta: sql.NullString{}, want: false},
+		{name: "meta is not an object", meta: sql.NullString{String: `["pro"]`, Valid: true}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, metaMatches(tt.meta, filter))
+		})
+	}
+
+	empty, err := parseMetaFilter(nil)
+	require.NoError(t, err)
+	require.True(t, metaMatches(sql.NullString{}, empty))
+
+	_, err = parseMetaFilter([]byte(`not json`))
+	require.Error(t, err)
+}
@@ -0,0 +1,352 @@
+package keybulk
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	cachev1 "github.com/unkeyed/unkey/gen/proto/cache/v1"
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/internal/services/caches"
+	"github.com/unkeyed/unkey/internal/services/webhooks"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
+)
+
+const (
+	// batchSize is the number of items applied per journaled step.
+	batchSize = 100
+
+	// resolvePageSize is the number of keys a filter scans per journaled step.
+	resolvePageSize = 1000
+)
+
+// outcome is the journaled result of applying the operation to one item.
+type outcome struct {
+	Succeeded bool   `json:"succeeded"`
+	KeyID     string `json:"key_id"`
+	// Hash is the key's hash, which its verification cache entry is keyed by.
+	Hash string `json:"hash"`
+	// EventID is the key.deleted or key.expired event, generated with the
+	// change and stored on the item so a retry dispatches the same event.
+	// Empty unless the operation deleted the key or set its expiry.
+	EventID string `json:"event_id"`
+	// Expires is the expiry the operation set, in unix milliseconds, or 0.
+	Expires int64 `json:"expires"`
+	// At is when the key was changed, in unix milliseconds.
+	At int64 `json:"at"`
+}
+
+// batch is the journaled result of one batch step.
+type batch struct {
+	LastPk   uint64    `json:"last_pk"`
+	Outcomes []outcome `json:"outcomes"`
+}
+
+// resolvedPage is the journaled result of resolving one page of a filter.
+type resolvedPage struct {
+	LastPk  uint64 `json:"last_pk"`
+	Scanned int    `json:"scanned"`
+	Matched int    `json:"matched"`
+}
+
+// Run applies the operation to every item and records each outcome.
+//
+// A key that is missing, belongs to another api or cannot take the change is
+// recorded as failed and skipped. Database errors fail the batch step, which
+// Restate retries; keys the failed attempt already finished are not applied
+// again because their items are no longer pending, and their recorded
+// outcomes still dispatch their webhooks.
+//
+// Key: operation_id
+func (s *Service) Run(
+	ctx restate.ObjectContext,
+	req *hydrav1.RunKeyBulkOperationRequest,
+) (*hydrav1.RunKeyBulkOperationResponse, error) {
+	operationID := restate.Key(ctx)
+
+	logger.Info("starting key bulk operation", "operation_id", operationID, "workspace_id", req.GetWorkspaceId())
+
+	metaFilter, err := parseMetaFilter(req.GetFilter().GetMeta())
+	if err == nil && req.GetOperation() == nil {
+		err = errors.New("request has no operation")
+	}
+	if err != nil {
+		if statusErr := s.setStatus(ctx, operationID, db.KeyBulkOperationsStatusFailed, 0, 0, err.Error(), "mark failed"); statusErr != nil {
+			return nil, statusErr
+		}
+		return nil, restate.TerminalError(err)
+	}
+
+	if err := s.setStatus(ctx, operationID, db.KeyBulkOperationsStatusRunning, 0, 0, "", "mark running"); err != nil {
+		return nil, err
+	}
+
+	if req.GetFilter() != nil {
+		if err := s.resolve(ctx, operationID, req, metaFilter); err != nil {
+			return nil, err
+		}
+	}
+
+	var succeeded, failed int
+	var cursor uint64
+	for n := 0; ; n++ {
+		b, err := restate.Run(ctx, func(rc restate.RunContext) (batch, error) {
+			return s.processBatch(rc, operationID, req, cursor)
+		}, restate.WithName(fmt.Sprintf("process batch %d", n)))
+		if err != nil {
+			return nil, fmt.Errorf("process batch %d: %w", n, err)
+		}
+		if len(b.Outcomes) == 0 {
+			break
+		}
+		cursor = b.LastPk
+
+		if err := s.invalidate(ctx, req, b.Outcomes, n); err != nil {
+			return nil, err
+		}
+
+		for _, o := range b.Outcomes {
+			if !o.Succeeded {
+				failed++
+				continue
+			}
+			succeeded++
+			if o.EventID != "" {
+				if err := s.emitWebhook(ctx, req, o); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if err := s.setStatus(ctx, operationID, db.KeyBulkOperationsStatusRunning, succeeded, failed, "", fmt.Sprintf("record progress %d", n)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.setStatus(ctx, operationID, db.KeyBulkOperationsStatusCompleted, succeeded, failed, "", "mark completed"); err != nil {
+		return nil, err
+	}
+
+	logger.Info("key bulk operation complete",
+		"operation_id", operationID,
+		"succeeded", succeeded,
+		"failed", failed,
+	)
+
+	return &hydrav1.RunKeyBulkOperationResponse{
+		Succeeded: int32(succeeded),
+		Failed:    int32(failed),
+	}, nil
+}
+
+// resolve pages through the keys matching the filter and writes one pending
+// item per match, then records the total.
+func (s *Service) resolve(
+	ctx restate.ObjectContext,
+	operationID string,
+	req *hydrav1.RunKeyBulkOperationRequest,
+	metaFilter map[string]any,
+) error {
+	filter := req.GetFilter()
+
+	var total int
+	var cursor uint64
+	for n := 0; ; n++ {
+		page, err := restate.Run(ctx, func(rc restate.RunContext) (resolvedPage, error) {
+			keys, err := s.db.ListKeysForBulkOperation(rc, db.ListKeysForBulkOperationParams{
+				KeyAuthID:      req.GetKeyAuthId(),
+				AfterPk:        cursor,
+				IdentityID:     sql.NullString{String: filter.GetIdentityId(), Valid: filter.GetIdentityId() != ""},
+				LastUsedBefore: sql.NullInt64{Int64: filter.GetLastUsedBefore(), Valid: filter.GetLastUsedBefore() > 0},
+				Limit:          resolvePageSize,
+			})
+			if err != nil {
+				return resolvedPage{}, fmt.Errorf("list keys: %w", err)
+			}
+			if len(keys) == 0 {
+				return resolvedPage{}, nil
+			}
+
+			items := make([]db.InsertKeyBulkOperationItemParams, 0, len(keys))
+			for _, key := range keys {
+				if !metaMatches(key.Meta, metaFilter) {
+					continue
+				}
+				items = append(items, db.InsertKeyBulkOperationItemParams{
+					OperationID: operationID,
+					KeyID:       key.ID,
+				})
+			}
+			if err := s.db.Bulk().InsertKeyBulkOperationItems(rc, items); err != nil {
+				return resolvedPage{}, fmt.Errorf("insert items: %w", err)
+			}
+
+			return resolvedPage{
+				LastPk:  keys[len(keys)-1].Pk,
+				Scanned: len(keys),
+				Matched: len(items),
+			}, nil
+		}, restate.WithName(fmt.Sprintf("resolve filter page %d", n)))
+		if err != nil {
+			return fmt.Errorf("resolve filter page %d: %w", n, err)
+		}
+		if page.Scanned == 0 {
+			break
+		}
+		cursor = page.LastPk
+		total += page.Matched
+	}
+
+	return restate.RunVoid(ctx, func(rc restate.RunContext) error {
+		return s.db.UpdateKeyBulkOperationTotal(rc, db.UpdateKeyBulkOperationTotalParams{
+			Total: uint32(total),
+			Now:   sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+			ID:    operationID,
+		})
+	}, restate.WithName("record total"))
+}
+
+// processBatch applies the operation to the next batch of items after cursor.
+// Items a previous attempt already finished report the outcome recorded on
+// the item, including its webhook event, without being applied again.
+func (s *Service) processBatch(
+	ctx context.Context,
+	operationID string,
+	req *hydrav1.RunKeyBulkOperationRequest,
+	cursor uint64,
+) (batch, error) {
+	items, err := s.db.ListKeyBulkOperationItems(ctx, db.ListKeyBulkOperationItemsParams{
+		OperationID: operationID,
+		AfterPk:     cursor,
+		Limit:       batchSize,
+	})
+	if err != nil {
+		return batch{}, fmt.Errorf("list items: %w", err)
+	}
+	if len(items) == 0 {
+		return batch{LastPk: cursor, Outcomes: nil}, nil
+	}
+
+	outcomes := make([]outcome, 0, len(items))
+	for _, item := range items {
+		if item.Status != db.KeyBulkOperationItemsStatusPending {
+			outcomes = append(outcomes, outcome{
+				Succeeded: item.Status == db.KeyBulkOperationItemsStatusSucceeded,
+				KeyID:     item.KeyID,
+				Hash:      item.Hash.String,
+				EventID:   item.EventID.String,
+				Expires:   item.Expires.Int64,
+				At:        item.UpdatedAtM.Int64,
+			})
+			continue
+		}
+
+		o, err := s.applyItem(ctx, operationID, req, item.KeyID)
+		if err != nil {
+			return batch{}, fmt.Errorf("apply to key %s: %w", item.KeyID, err)
+		}
+		outcomes = append(outcomes, o)
+	}
+
+	return batch{
+		LastPk:   items[len(items)-1].Pk,
+		Outcomes: outcomes,
+	}, nil
+}
+
+// setStatus records the operation's status and counts as a durable step.
+// Terminal statuses also stamp completed_at_m.
+func (s *Service) setStatus(
+	ctx restate.ObjectContext,
+	operationID string,
+	status db.KeyBulkOperationsStatus,
+	succeeded, failed int,
+	message string,
+	stepName string,
+) error {
+	err := restate.RunVoid(ctx, func(rc restate.RunContext) error {
+		now := time.Now().UnixMilli()
+		completedAt := sql.NullInt64{Int64: 0, Valid: false}
+		if status == db.KeyBulkOperationsStatusCompleted || status == db.KeyBulkOperationsStatusFailed {
+			completedAt = sql.NullInt64{Int64: now, Valid: true}
+		}
+		return s.db.UpdateKeyBulkOperationStatus(rc, db.UpdateKeyBulkOperationStatusParams{
+			Status:       status,
+			Succeeded:    uint32(succeeded),
+			Failed:       uint32(failed),
+			Error:        sql.NullString{String: message, Valid: message != ""},
+			Now:          sql.NullInt64{Int64: now, Valid: true},
+			CompletedAtM: completedAt,
+			ID:           operationID,
+		})
+	}, restate.WithName(stepName))
+	if err != nil {
+		return fmt.Errorf("%s: %w", stepName, err)
+	}
+	return nil
+}
+
+// invalidate removes the keys a batch changed from the API nodes' caches in
+// one broadcast. Failed items changed nothing and are skipped.
+func (s *Service) invalidate(ctx restate.ObjectContext, req *hydrav1.RunKeyBulkOperationRequest, outcomes []outcome, n int) error {
+	entities := make([]*cachev1.Entity, 0, len(outcomes))
+	for _, o := range outcomes {
+		if o.Succeeded && o.Hash != "" {
+			entities = append(entities, caches.KeyEntity(req.GetWorkspaceId(), o.KeyID, o.Hash))
+		}
+	}
+	if len(entities) == 0 {
+		return nil
+	}
+
+	return restate.RunVoid(ctx, func(rc restate.RunContext) error {
+		s.caches.Invalidate(rc, entities...)
+		return nil
+	}, restate.WithName(fmt.Sprintf("invalidate batch %d", n)))
+}
+
+// emitWebhook dispatches the event of a changed key: key.deleted for a
+// deleted key, as keys.deleteKey does, and key.expired for a key whose expiry
+// was set, scheduled for that expiry as keys.updateKey does. A later change of
+// the expiry supersedes the scheduled event; the webhook worker drops it
+// because it no longer matches the stored expiry.
+func (s *Service) emitWebhook(ctx restate.ObjectContext, req *hydrav1.RunKeyBulkOperationRequest, o outcome) error {
+	if !s.webhooks {
+		return nil
+	}
+
+	client := hydrav1.NewWebhookServiceClient(ctx)
+
+	if req.GetDelete() != nil {
+		data, err := json.Marshal(webhooks.KeyData{KeyID: o.KeyID, APIID: req.GetApiId()})
+		if err != nil {
+			return fmt.Errorf("marshal key.deleted data: %w", err)
+		}
+		client.Dispatch().Send(&hydrav1.DispatchWebhookRequest{
+			WorkspaceId: req.GetWorkspaceId(),
+			EventId:     o.EventID,
+			EventType:   string(webhooks.EventKeyDeleted),
+			CreatedAt:   o.At,
+			Data:        data,
+		})
+		return nil
+	}
+
+	data, err := json.Marshal(webhooks.KeyExpiredData{KeyID: o.KeyID, APIID: req.GetApiId(), Expires: o.Expires})
+	if err != nil {
+		return fmt.Errorf("marshal key.expired data: %w", err)
+	}
+	client.Dispatch().Send(&hydrav1.DispatchWebhookRequest{
+		WorkspaceId: req.GetWorkspaceId(),
+		EventId:     o.EventID,
+		EventType:   string(webhooks.EventKeyExpired),
+		CreatedAt:   o.Expires,
+		Data:        data,
+	}, restate.WithDelay(time.Duration(max(o.Expires-o.At, 0))*time.Millisecond))
+	return nil
+}
@@ -0,0 +1,92 @@
+package keybulk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/pkg/auditlog"
+	"github.com/unkeyed/unkey/pkg/mysql/sqlcomment"
+	"github.com/unkeyed/unkey/pkg/testutil/containers"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
+)
+
+// failingAuditlogs fails every insert after the first ok inserts, which
+// rolls back the key change it belongs to and fails the batch partway.
+type failingAuditlogs struct {
+	ok    int
+	calls int
+}
+
+func (f *failingAuditlogs) Insert(_ context.Context, _ db.DBTX, _ []auditlog.AuditLog) error {
+	f.calls++
+	if f.calls > f.ok {
+		return errors.New("outbox unavailable")
+	}
+	return nil
+}
+
+// TestProcessBatch_ResumedBatchKeepsWebhookEvents protects the key.deleted
+// webhooks of a retried batch: keys the failed attempt already deleted come
+// back finished, and must still carry the event that attempt generated.
+func TestProcessBatch_ResumedBatchKeepsWebhookEvents(t *testing.T) {
+	ctx := context.Background()
+	mysqlCfg := containers.MySQL(t)
+	database, err := db.New(mysqlCfg.DSN, sqlcomment.Disabled())
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, database.Close()) })
+
+	workspaceID := uid.New(uid.WorkspacePrefix)
+	keySpaceID := uid.New(uid.KeySpacePrefix)
+	operationID := uid.New(uid.KeyBulkOperationPrefix)
+
+	keyIDs := make([]string, 3)
+	items := make([]db.InsertKeyBulkOperationItemParams, len(keyIDs))
+	for i := range keyIDs {
+		keyIDs[i] = uid.New(uid.KeyPrefix)
+		require.NoError(t, database.InsertKey(ctx, db.InsertKeyParams{ //nolint:exhaustruct
+			ID:          keyIDs[i],
+			KeySpaceID:  keySpaceID,
+			Hash:        uid.New("hash"),
+			Start:       "test",
+			WorkspaceID: workspaceID,
+			CreatedAtM:  time.Now().UnixMilli(),
+			Enabled:     true,
+		}))
+		items[i] = db.InsertKeyBulkOperationItemParams{OperationID: operationID, KeyID: keyIDs[i]}
+	}
+	require.NoError(t, database.Bulk().InsertKeyBulkOperationItems(ctx, items))
+
+	req := &hydrav1.RunKeyBulkOperationRequest{ //nolint:exhaustruct
+		WorkspaceId: workspaceID,
+		KeyAuthId:   keySpaceID,
+		Operation:   &hydrav1.RunKeyBulkOperationRequest_Delete{Delete: &hydrav1.KeyBulkDelete{}},
+	}
+
+	failing := &Service{db: database, auditlogs: &failingAuditlogs{ok: 1}} //nolint:exhaustruct
+	_, err = failing.processBatch(ctx, operationID, req, 0)
+	require.Error(t, err, "the second key's audit log fails the batch")
+
+	resumed := &Service{db: database, auditlogs: &failingAuditlogs{ok: len(keyIDs)}} //nolint:exhaustruct
+	b, err := resumed.processBatch(ctx, operationID, req, 0)
+	require.NoError(t, err)
+	require.Len(t, b.Outcomes, len(keyIDs))
+
+	eventIDs := map[string]bool{}
+	for i, o := range b.Outcomes {
+		require.Equal(t, keyIDs[i], o.KeyID)
+		require.True(t, o.Succeeded)
+		require.NotEmpty(t, o.EventID, "key %d must keep its key.deleted event", i)
+		require.Positive(t, o.At)
+		eventIDs[o.EventID] = true
+	}
+	require.Len(t, eventIDs, len(keyIDs), "every key gets its own event")
+
+	replayed, err := resumed.processBatch(ctx, operationID, req, 0)
+	require.NoError(t, err)
+	require.Equal(t, b.Outcomes, replayed.Outcomes, "a replay rebuilds the same outcomes")
+}
@@ -0,0 +1,73 @@
+// Package keybulk implements the KeyBulkService Restate virtual object,
+// which runs the jobs behind keys.bulkUpdate, keys.bulkDelete and
+// keys.bulkSetPermissions.
+//
+// The API validates the request, inserts the key_bulk_operations row and, for
+// explicitly listed keys, one key_bulk_operation_items row per key before it
+// submits the job. [Service.Run] resolves a filter into items, then walks the
+// items in batches. Each key is changed in its own transaction together with
+// its audit log and its item's outcome, so one bad key never rolls back the
+// others and a retried batch skips the keys it already finished.
+//
+// After each batch the changed keys are invalidated in the API nodes'
+// verification caches, so a deleted or disabled key stops verifying right
+// away instead of once its cache entry goes stale.
+package keybulk
+
+import (
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/internal/services/caches"
+	"github.com/unkeyed/unkey/pkg/assert"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/auditlogs"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
+)
+
+// Service implements the KeyBulkService Restate virtual object. The virtual
+// object key is the operation ID.
+type Service struct {
+	hydrav1.UnimplementedKeyBulkServiceServer
+	db        db.Database
+	auditlogs auditlogs.AuditLogService
+	caches    *caches.Invalidator
+	webhooks  bool
+}
+
+var _ hydrav1.KeyBulkServiceServer = (*Service)(nil)
+
+// Config holds configuration for creating a [Service].
+type Config struct {
+	// DB is the primary application database. Must not be nil.
+	DB db.Database
+
+	// Auditlogs writes one audit log per changed key inside the key's
+	// transaction. Must not be nil.
+	Auditlogs auditlogs.AuditLogService
+
+	// Caches broadcasts the invalidation of changed keys to the API nodes.
+	// Must not be nil: a bulk delete or disable has to stop the keys from
+	// verifying right away, not once the cached entries go stale.
+	Caches *caches.Invalidator
+
+	// Webhooks enables key.deleted events for bulk deletes and key.expired
+	// events for bulk updates that set an expiry. Set it only when the
+	// WebhookService is bound on this worker.
+	Webhooks bool
+}
+
+// New creates a [Service] with the given configuration.
+func New(cfg Config) (*Service, error) {
+	if err := assert.All(
+		assert.NotNil(cfg.DB, "DB must not be nil"),
+		assert.NotNil(cfg.Auditlogs, "Auditlogs must not be nil"),
+		assert.True(cfg.Caches != nil, "Caches must not be nil"),
+	); err != nil {
+		return nil, err
+	}
+	return &Service{
+		UnimplementedKeyBulkServiceServer: hydrav1.UnimplementedKeyBulkServiceServer{},
+		db:                                cfg.DB,
+		auditlogs:                         cfg.Auditlogs,
+		caches:                            cfg.Caches,
+		webhooks:                          cfg.Webhooks,
+	}, nil
+}
@@ -20,6 +20,7 @@ import (
 	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
 	"github.com/unkeyed/unkey/gen/proto/vault/v1/vaultv1connect"
 	"github.com/unkeyed/unkey/gen/rpc/vault"
+	"github.com/unkeyed/unkey/internal/services/caches"
 	"github.com/unkeyed/unkey/pkg/batch"
 	"github.com/unkeyed/unkey/pkg/buildinfo"
 	"github.com/unkeyed/unkey/pkg/cache"
@@ -53,6 +54,7 @@ import (
 	workerenvironment "github.com/unkeyed/unkey/svc/ctrl/worker/environment"
 	"github.com/unkeyed/unkey/svc/ctrl/worker/githubstatus"
 	"github.com/unkeyed/unkey/svc/ctrl/worker/githubwebhook"
+	"github.com/unkeyed/unkey/svc/ctrl/worker/keybulk"
 	"github.com/unkeyed/unkey/svc/ctrl/worker/keylastusedsync"
 	"github.com/unkeyed/unkey/svc/ctrl/worker/legacybilling"
 
@@ -61,6 +63,7 @@ import (
 	"github.com/unkeyed/unkey/svc/ctrl/worker/openapi"
 	workerproject "github.com/unkeyed/unkey/svc/ctrl/worker/project"
 	"github.com/unkeyed/unkey/svc/ctrl/worker/routing"
+	"github.com/unkeyed/unkey/svc/ctrl/worker/webhook"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 	"k8s.io/client-go/kubernetes"
@@ -150,6 +153,31 @@ func Run(ctx context.Context, cfg Config) error {
 		logger.Info("Vault client initialized", "url", cfg.Vault.URL)
 	}
 
+	// Workers that change keys tell the API nodes to drop their cached
+	// copies over the Redis channel the API nodes share.
+	var keyCaches *caches.Invalidator
+	if cfg.RedisURL == "" {
+		logger.Warn("cache invalidation disabled: redis_url not configured")
+	} else {
+		broadcaster, broadcasterErr := caches.NewRedisBroadcaster(caches.RedisBroadcasterConfig{
+			RedisURL: cfg.RedisURL,
+			Channel:  "",
+		})
+		if broadcasterErr != nil {
+			return fmt.Errorf("unable to create cache invalidation broadcaster: %w", broadcasterErr)
+		}
+		r.Defer(broadcaster.Close)
+
+		keyCaches, err = caches.NewRemoteInvalidator(caches.RemoteInvalidatorConfig{
+			Clock:       cfg.Clock,
+			NodeID:      cfg.InstanceID,
+			Broadcaster: broadcaster,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to create cache invalidator: %w", err)
+		}
+	}
+
 	// Initialize database
 	database, err := db.New(cfg.Database, sqlcomment.ForService("ctrl-worker", cfg.Region))
 	if err != nil {
@@ -203,6 +231,7 @@ func Run(ctx context.Context, cfg Config) error {
 				Consumers:     1,
 				Drop:          true,
 				OnFlushError:  nil,
+				Spool:         nil,
 			})
 			buildStepLogs = clickhouse.NewBuffer[schema.BuildStepLogV1](clickhouseClient, clickhouse.BufferConfig{
 				Name:          "build_step_logs",
@@ -212,6 +241,7 @@ func Run(ctx context.Context, cfg Config) error {
 				Consumers:     1,
 				Drop:          true,
 				OnFlushError:  nil,
+				Spool:         nil,
 			})
 
 			// Close connection last (LIFO: first registered closes last)
@@ -333,10 +363,11 @@ func Run(ctx context.Context, cfg Config) error {
 	// later, the spend-cap check (SUSPEND).
 	teardownSvc, err := deployteardown.New(deployteardown.Config{
 		DB: database,
-		// Zero selects the production drain poll cadence and grace timeout; only
-		// tests override these to keep the drain loop fast.
+		// Zero selects the production drain poll cadence, grace timeout, and
+		// volume retention; only tests override these.
 		DrainPollInterval: 0,
 		DrainGraceTimeout: 0,
+		VolumeRetention:   0,
 	})
 	if err != nil {
 		return fmt.Errorf("create deploy teardown service: %w", err)
@@ -369,6 +400,28 @@ func Run(ctx context.Context, cfg Config) error {
 		),
 	))
 
+	// Outbound webhooks need vault to decrypt endpoint signing secrets.
+	// Deliver schedules its own attempts and records each one, so Restate
+	// retries only cover infrastructure failures (database, vault) and use a
+	// short, bounded policy.
+	if vaultClient == nil {
+		logger.Warn("WebhookService disabled: vault not configured")
+	} else {
+		restateSrv.Bind(hydrav1.NewWebhookServiceServer(webhook.New(webhook.Config{
+			DB:                   database,
+			Vault:                vaultClient,
+			AllowPrivateNetworks: cfg.Webhooks.AllowPrivateNetworks,
+		}),
+			restate.WithInvocationRetryPolicy(
+				restate.WithInitialInterval(5*time.Second),
+				restate.WithExponentiationFactor(2.0),
+				restate.WithMaxInterval(5*time.Minute),
+				restate.WithMaxAttempts(20),
+				restate.PauseOnMaxAttempts(),
+			),
+		))
+	}
+
 	restateSrv.Bind(hydrav1.NewGitHubWebhookServiceServer(githubwebhook.New(githubwebhook.Config{
 		DB:                              database,
 		GitHub:                          ghClient,
@@ -396,6 +449,26 @@ func Run(ctx context.Context, cfg Config) error {
 	}
 	restateSrv.Bind(hydrav1.NewAppServiceServer(appSvc))
 
+	// Bulk key jobs mirror keys.deleteKey, which emits key.deleted, so they
+	// dispatch webhooks only where the WebhookService is bound. They must
+	// invalidate the keys they change, so without redis_url the service stays
+	// unbound and the API's bulk routes fail to submit instead of leaving
+	// deleted keys verifying from cache.
+	if keyCaches == nil {
+		logger.Warn("KeyBulkService disabled: redis_url not configured")
+	} else {
+		keyBulkSvc, keyBulkErr := keybulk.New(keybulk.Config{
+			DB:        database,
+			Auditlogs: auditlogSvc,
+			Caches:    keyCaches,
+			Webhooks:  vaultClient != nil,
+		})
+		if keyBulkErr != nil {
+			return fmt.Errorf("failed to create key bulk worker service: %w", keyBulkErr)
+		}
+		restateSrv.Bind(hydrav1.NewKeyBulkServiceServer(keyBulkSvc))
+	}
+
 	envSvc, err := workerenvironment.New(workerenvironment.Config{
 		DB:        database,
 		Admin:     restateAdminClient,
@@ -548,6 +621,8 @@ func Run(ctx context.Context, cfg Config) error {
 		Clickhouse:                ch,
 		Clock:                     clk,
 		RatelimitDB:               ratelimitdb.New(database.RW(), database.RO()),
+		Vault:                     vaultClient,
+		Caches:                    keyCaches,
 		SlackQuotaCheckWebhookURL: cfg.Slack.QuotaCheckWebhookURL,
 		BillingUsageReader:        billingUsageReader,
 		StripeSecretKey:           cfg.Billing.StripeSecretKey,
@@ -560,9 +635,12 @@ func Run(ctx context.Context, cfg Config) error {
 		Heartbeats: cron.Heartbeats{
 			QuotaCheck:         cronHeartbeat(cfg.Heartbeat.QuotaCheckURL),
 			KeyRefill:          cronHeartbeat(cfg.Heartbeat.KeyRefillURL),
+			KeyRotation:        cronHeartbeat(cfg.Heartbeat.KeyRotationURL),
+			KeyExpiry:          cronHeartbeat(cfg.Heartbeat.KeyExpiryURL),
 			KeyLastUsedSync:    cronHeartbeat(cfg.Heartbeat.KeyLastUsedSyncURL),
 			AuditLogExport:     cronHeartbeat(cfg.Heartbeat.AuditLogExportURL),
 			AuditLogCleanup:    cronHeartbeat(cfg.Heartbeat.AuditLogOutboxCleanupURL),
+			IdempotencyCleanup: cronHeartbeat(cfg.Heartbeat.IdempotencyKeysCleanupURL),
 			RatelimitCleanup:   cronHeartbeat(cfg.Heartbeat.RatelimitGlobalCountersCleanupURL),
 			DeployBillingPush:  cronHeartbeat(cfg.Heartbeat.DeployBillingPushURL),
 			DeployBillingClose: cronHeartbeat(cfg.Heartbeat.DeployBillingCloseURL),
@@ -617,6 +695,16 @@ func Run(ctx context.Context, cfg Config) error {
 		restate.WithMaxAttempts(5),
 		restate.KillOnMaxAttempts(),
 	)
+	// IdempotencyKeysCleanup is the same shape as the other cleanup sweeps:
+	// a stateless, cutoff-bounded, batched DELETE on a fixed singleton key.
+	// Kill on exhaustion and let the next hourly tick retry.
+	cronIdempotencyCleanupRetry := restate.WithInvocationRetryPolicy(
+		restate.WithInitialInterval(100*time.Millisecond),
+		restate.WithExponentiationFactor(2.0),
+		restate.WithMaxInterval(5*time.Second),
+		restate.WithMaxAttempts(5),
+		restate.KillOnMaxAttempts(),
+	)
 	// AuditLogExport drains the outbox every minute on the fixed singleton
 	// key "audit-log-export", so a stuck invocation blocks every later tick.
 	// The SDK default retries forever, which is the same wedge as pausing
@@ -701,15 +789,41 @@ func Run(ctx context.Context, cfg Config) error {
 		restate.WithMaxAttempts(5),
 		restate.KillOnMaxAttempts(),
 	)
+	// KeyRotation runs hourly on the fixed "key-rotation" key. Every rotation
+	// locks the key and checks for an existing successor inside its own
+	// transaction, so a killed run leaves no half-rotated key and the next
+	// tick picks up whatever is still due. Kill on exhaustion so a failing
+	// run cannot park the singleton key.
+	cronKeyRotationRetry := restate.WithInvocationRetryPolicy(
+		restate.WithInitialInterval(100*time.Millisecond),
+		restate.WithExponentiationFactor(2.0),
+		restate.WithMaxInterval(5*time.Second),
+		restate.WithMaxAttempts(5),
+		restate.KillOnMaxAttempts(),
+	)
+	// KeyExpiryNotifications runs hourly on the fixed
+	// "key-expiry-notifications" key. Each warning is recorded after it is
+	// sent and emails carry an idempotency key, so a killed run resends at
+	// most one Slack message and the next tick picks up the rest.
+	cronKeyExpiryRetry := restate.WithInvocationRetryPolicy(
+		restate.WithInitialInterval(100*time.Millisecond),
+		restate.WithExponentiationFactor(2.0),
+		restate.WithMaxInterval(5*time.Second),
+		restate.WithMaxAttempts(5),
+		restate.KillOnMaxAttempts(),
+	)
 	restateSrv.Bind(hydrav1.NewCronServiceServer(cronSvc).
 		ConfigureHandler("RunKeyLastUsedSync", cronKeyLastUsedRetry).
 		ConfigureHandler("RunRatelimitGlobalCountersCleanup", cronRatelimitGCCRetry).
 		ConfigureHandler("RunAuditLogOutboxCleanup", cronAuditLogCleanupRetry).
+		ConfigureHandler("RunIdempotencyKeysCleanup", cronIdempotencyCleanupRetry).
 		// 1h journal retention keeps debugging headroom for an oncall to
 		// inspect a recent failure without bloating the journal store with
 		// ~1440 dead invocations/day.
 		ConfigureHandler("RunAuditLogExport", restate.WithJournalRetention(1*time.Hour), cronAuditLogExportRetry).
 		ConfigureHandler("RunQuotaCheck", cronQuotaCheckRetry).
+		ConfigureHandler("RunKeyRotation", cronKeyRotationRetry).
+		ConfigureHandler("RunKeyExpiryNotifications", cronKeyExpiryRetry).
 		ConfigureHandler("RunDeployBillingClose", cronDeployBillingFleetCloseRetry).
 		ConfigureHandler("CloseDeployBillingWorkspace", cronDeployBillingWorkspaceCloseRetry).
 		ConfigureHandler("RunDeployBillingPush", cronDeployBillingPushRetry).
@@ -0,0 +1,249 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	vaultv1 "github.com/unkeyed/unkey/gen/proto/vault/v1"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/pkg/webhook/signing"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
+)
+
+const (
+	// maxAttempts is how many times one delivery is tried before it is
+	// marked failed. With the backoff below the last attempt happens roughly
+	// six hours after the first.
+	maxAttempts = 8
+
+	initialBackoff = 10 * time.Second
+	maxBackoff     = 2 * time.Hour
+
+	// maxErrorLength matches webhook_deliveries.last_error.
+	maxErrorLength = 1024
+
+	// maxResponseBytes caps how much of a response body is drained so the
+	// connection can be reused. The body itself is never stored.
+	maxResponseBytes = 64 * 1024
+
+	userAgent = "Unkey-Webhooks/1.0"
+)
+
+// target is everything one attempt needs, journaled between steps. The
+// secret stays encrypted here and is only decrypted inside the send step.
+type target struct {
+	Found           bool   `json:"found"`
+	Delivered       bool   `json:"delivered"`
+	Reason          string `json:"reason"`
+	WorkspaceID     string `json:"workspaceId"`
+	URL             string `json:"url"`
+	EncryptedSecret string `json:"encryptedSecret"`
+	Payload         []byte `json:"payload"`
+	Attempts        uint32 `json:"attempts"`
+}
+
+// attemptResult is the outcome of one HTTP attempt. Failures are values, not
+// errors, so Restate journals them instead of retrying the step.
+type attemptResult struct {
+	StatusCode int    `json:"statusCode"`
+	Error      string `json:"error"`
+}
+
+func (r attemptResult) delivered() bool {
+	return r.Error == "" && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Deliver sends one delivery until the endpoint accepts it or the attempts
+// run out. The endpoint is re-read before every attempt, so disabling or
+// deleting it stops pending retries and URL changes apply to the next one.
+func (s *Service) Deliver(ctx restate.Context, req *hydrav1.DeliverWebhookRequest) (*hydrav1.DeliverWebhookResponse, error) {
+	deliveryID := req.GetDeliveryId()
+
+	for {
+		t, err := restate.Run(ctx, func(runCtx restate.RunContext) (target, error) {
+			return s.loadTarget(runCtx, deliveryID)
+		}, restate.WithName("load delivery"))
+		if err != nil {
+			return nil, fault.Wrap(err, fault.Internal("failed to load webhook delivery"))
+		}
+
+		if t.Delivered {
+			return &hydrav1.DeliverWebhookResponse{Delivered: true}, nil
+		}
+		if !t.Found {
+			logger.Info("abandoning webhook delivery", "delivery_id", deliveryID, "reason", t.Reason)
+			err = s.recordAttempt(ctx, deliveryID, t.Attempts, attemptResult{StatusCode: 0, Error: t.Reason}, true)
+			if err != nil {
+				return nil, err
+			}
+			return &hydrav1.DeliverWebhookResponse{Delivered: false}, nil
+		}
+
+		attempt := t.Attempts + 1
+		result, err := restate.Run(ctx, func(runCtx restate.RunContext) (attemptResult, error) {
+			return s.send(runCtx, deliveryID, t)
+		}, restate.WithName(fmt.Sprintf("attempt %d", attempt)))
+		if err != nil {
+			return nil, fault.Wrap(err, fault.Internal("failed to send webhook"))
+		}
+
+		last := attempt >= maxAttempts
+		if err = s.recordAttempt(ctx, deliveryID, attempt, result, last); err != nil {
+			return nil, err
+		}
+
+		if result.delivered() {
+			return &hydrav1.DeliverWebhookResponse{Delivered: true}, nil
+		}
+		if last {
+			logger.Info("webhook delivery failed permanently",
+				"delivery_id", deliveryID,
+				"status_code", result.StatusCode,
+				"error", result.Error,
+			)
+			return &hydrav1.DeliverWebhookResponse{Delivered: false}, nil
+		}
+
+		if err = restate.Sleep(ctx, backoff(attempt)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// loadTarget reads the delivery and its endpoint. A missing delivery,
+// a deleted endpoint or a disabled endpoint ends the delivery.
+func (s *Service) loadTarget(ctx context.Context, deliveryID string) (target, error) {
+	//nolint:exhaustruct
+	t := target{}
+
+	delivery, err := s.db.FindWebhookDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		if db.IsNotFound(err) {
+			t.Reason = "delivery not found"
+			return t, nil
+		}
+		return t, err
+	}
+	t.Attempts = delivery.Attempts
+
+	if delivery.Status == db.WebhookDeliveriesStatusDelivered {
+		t.Delivered = true
+		return t, nil
+	}
+
+	endpoint, err := s.db.FindWebhookEndpointByID(ctx, delivery.EndpointID)
+	if err != nil {
+		if db.IsNotFound(err) {
+			t.Reason = "endpoint deleted"
+			return t, nil
+		}
+		return t, err
+	}
+	if !endpoint.Enabled {
+		t.Reason = "endpoint disabled"
+		return t, nil
+	}
+
+	t.Found = true
+	t.WorkspaceID = delivery.WorkspaceID
+	t.URL = endpoint.Url
+	t.EncryptedSecret = endpoint.EncryptedSecret
+	t.Payload = delivery.Payload
+	return t, nil
+}
+
+// send signs and POSTs the payload once. Only vault failures are returned as
+// errors; everything the endpoint does is reported in the result.
+func (s *Service) send(ctx context.Context, deliveryID string, t target) (attemptResult, error) {
+	decrypted, err := s.vault.Decrypt(ctx, &vaultv1.DecryptRequest{
+		Keyring:   t.WorkspaceID,
+		Encrypted: t.EncryptedSecret,
+	})
+	if err != nil {
+		return attemptResult{}, fmt.Errorf("decrypt webhook secret: %w", err)
+	}
+
+	return s.post(ctx, deliveryID, decrypted.GetPlaintext(), t.URL, t.Payload, time.Now()), nil
+}
+
+func (s *Service) post(ctx context.Context, deliveryID, secret, url string, payload []byte, now time.Time) attemptResult {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return attemptResult{StatusCode: 0, Error: fmt.Sprintf("invalid endpoint URL: %s", err)}
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", userAgent)
+	if err = signing.SetHeaders(httpReq.Header, secret, deliveryID, now, payload); err != nil {
+		return attemptResult{StatusCode: 0, Error: fmt.Sprintf("failed to sign payload: %s", err)}
+	}
+
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return attemptResult{StatusCode: 0, Error: err.Error()}
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBytes))
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return attemptResult{StatusCode: resp.StatusCode, Error: fmt.Sprintf("endpoint responded with %d", resp.StatusCode)}
+	}
+	return attemptResult{StatusCode: resp.StatusCode, Error: ""}
+}
+
+// recordAttempt writes the outcome of an attempt to the delivery row. final
+// marks an undelivered attempt as the last one.
+func (s *Service) recordAttempt(ctx restate.Context, deliveryID string, attempts uint32, result attemptResult, final bool) error {
+	return restate.RunVoid(ctx, func(runCtx restate.RunContext) error {
+		now := time.Now()
+		params := db.UpdateWebhookDeliveryAttemptParams{
+			Status:         db.WebhookDeliveriesStatusPending,
+			Attempts:       attempts,
+			ResponseStatus: sql.NullInt32{Int32: int32(result.StatusCode), Valid: result.StatusCode != 0},
+			LastError:      sql.NullString{String: truncate(result.Error, maxErrorLength), Valid: result.Error != ""},
+			NextAttemptAt:  sql.NullInt64{Int64: 0, Valid: false},
+			DeliveredAt:    sql.NullInt64{Int64: 0, Valid: false},
+			UpdatedAt:      sql.NullInt64{Int64: now.UnixMilli(), Valid: true},
+			ID:             deliveryID,
+		}
+
+		switch {
+		case result.delivered():
+			params.Status = db.WebhookDeliveriesStatusDelivered
+			params.DeliveredAt = sql.NullInt64{Int64: now.UnixMilli(), Valid: true}
+		case final:
+			params.Status = db.WebhookDeliveriesStatusFailed
+		default:
+			params.NextAttemptAt = sql.NullInt64{Int64: now.Add(backoff(attempts)).UnixMilli(), Valid: true}
+		}
+
+		return s.db.UpdateWebhookDeliveryAttempt(runCtx, params)
+	}, restate.WithName("record attempt"))
+}
+
+// backoff returns the wait after the given attempt: ten seconds, quadrupling
+// per attempt, capped at two hours.
+func backoff(attempt uint32) time.Duration {
+	d := initialBackoff
+	for i := uint32(1); i < attempt; i++ {
+		d *= 4
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return d
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
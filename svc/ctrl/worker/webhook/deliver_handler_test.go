@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/pkg/webhook/signing"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
+)
+
+func TestPostSignsRequests(t *testing.T) {
+	secret, err := signing.NewSecret()
+	require.NoError(t, err)
+
+	payload := []byte(`{"id":"evt_1","type":"key.created"}`)
+	now := time.Now()
+
+	var verifyErr error
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, readErr := io.ReadAll(r.Body)
+		require.NoError(t, readErr)
+		verifyErr = signing.Verify(secret, r.Header, body, now, signing.DefaultTolerance)
+		contentType = r.Header.Get("Content-Type")
+		require.Equal(t, "whdl_1", r.Header.Get(signing.HeaderID))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	svc := New(Config{DB: nil, Vault: nil, AllowPrivateNetworks: true})
+	result := svc.post(t.Context(), "whdl_1", secret, server.URL, payload, now)
+
+	require.True(t, result.delivered())
+	require.Equal(t, http.StatusNoContent, result.StatusCode)
+	require.NoError(t, verifyErr)
+	require.Equal(t, "application/json", contentType)
+}
+
+func TestPostReportsFailures(t *testing.T) {
+	secret, err := signing.NewSecret()
+	require.NoError(t, err)
+
+	t.Run("non-2xx", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(server.Close)
+
+		svc := New(Config{DB: nil, Vault: nil, AllowPrivateNetworks: true})
+		result := svc.post(t.Context(), "whdl_1", secret, server.URL, []byte(`{}`), time.Now())
+
+		require.False(t, result.delivered())
+		require.Equal(t, http.StatusServiceUnavailable, result.StatusCode)
+		require.Contains(t, result.Error, "503")
+	})
+
+	t.Run("redirects are not followed", func(t *testing.T) {
+		followed := false
+		internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			followed = true
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(internal.Close)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, internal.URL, http.StatusTemporaryRedirect)
+		}))
+		t.Cleanup(server.Close)
+
+		svc := New(Config{DB: nil, Vault: nil, AllowPrivateNetworks: true})
+		result := svc.post(t.Context(), "whdl_1", secret, server.URL, []byte(`{}`), time.Now())
+
+		require.False(t, result.delivered())
+		require.Equal(t, http.StatusTemporaryRedirect, result.StatusCode)
+		require.False(t, followed)
+	})
+
+	t.Run("private addresses are refused", func(t *testing.T) {
+		reached := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			reached = true
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(server.Close)
+
+		svc := New(Config{DB: nil, Vault: nil, AllowPrivateNetworks: false})
+		result := svc.post(t.Context(), "whdl_1", secret, server.URL, []byte(`{}`), time.Now())
+
+		require.False(t, result.delivered())
+		require.Zero(t, result.StatusCode)
+		require.Contains(t, result.Error, "non-public address")
+		require.False(t, reached)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, 10*time.Second, backoff(1))
+	require.Equal(t, 40*time.Second, backoff(2))
+	require.Equal(t, 160*time.Second, backoff(3))
+	require.Equal(t, maxBackoff, backoff(6))
+	require.Equal(t, maxBackoff, backoff(maxAttempts))
+}
+
+func TestSubscribedEndpointIDs(t *testing.T) {
+	endpoint := func(id string, eventTypes string) db.WebhookEndpoint {
+		return db.WebhookEndpoint{ID: id, EventTypes: json.RawMessage(eventTypes)}
+	}
+
+	ids := subscribedEndpointIDs([]db.WebhookEndpoint{
+		endpoint("whep_1", `["key.created","key.deleted"]`),
+		endpoint("whep_2", `["key.deleted"]`),
+		endpoint("whep_3", `not json`),
+		endpoint("whep_4", `["key.created"]`),
+	}, "key.created")
+
+	require.Equal(t, []string{"whep_1", "whep_4"}, ids)
+}
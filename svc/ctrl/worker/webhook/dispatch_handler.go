@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	restate "github.com/restatedev/sdk-go"
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/internal/services/webhooks"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/pkg/uid"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
+)
+
+// envelope is the JSON body every endpoint receives. The delivery id travels
+// in the webhook-id header; the event id here is shared by all deliveries of
+// the same event.
+type envelope struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	CreatedAt   int64           `json:"createdAt"`
+	WorkspaceID string          `json:"workspaceId"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// Dispatch fans one event out to every enabled endpoint of the workspace that
+// subscribes to its type. It is idempotent per event id: the API sends it with
+// the event id as idempotency key, and delivery ids are journaled before the
+// rows are written.
+func (s *Service) Dispatch(ctx restate.Context, req *hydrav1.DispatchWebhookRequest) (*hydrav1.DispatchWebhookResponse, error) {
+	if req.GetEventType() == string(webhooks.EventKeyExpired) {
+		holds, err := restate.Run(ctx, func(runCtx restate.RunContext) (bool, error) {
+			return s.keyStillExpires(runCtx, req.GetData())
+		}, restate.WithName("check key expiry"))
+		if err != nil {
+			return nil, fault.Wrap(err, fault.Internal("failed to check key expiry"))
+		}
+		if !holds {
+			logger.Info("dropping stale key.expired event", "event_id", req.GetEventId())
+			return &hydrav1.DispatchWebhookResponse{DeliveryIds: nil}, nil
+		}
+	}
+
+	payload, err := json.Marshal(envelope{
+		ID:          req.GetEventId(),
+		Type:        req.GetEventType(),
+		CreatedAt:   req.GetCreatedAt(),
+		WorkspaceID: req.GetWorkspaceId(),
+		Data:        req.GetData(),
+	})
+	if err != nil {
+		return nil, restate.TerminalError(fmt.Errorf("marshal webhook envelope: %w", err))
+	}
+
+	endpointIDs, err := restate.Run(ctx, func(runCtx restate.RunContext) ([]string, error) {
+		endpoints, listErr := s.db.ListEnabledWebhookEndpointsByWorkspace(runCtx, req.GetWorkspaceId())
+		if listErr != nil {
+			return nil, listErr
+		}
+		return subscribedEndpointIDs(endpoints, req.GetEventType()), nil
+	}, restate.WithName("find subscribed endpoints"))
+	if err != nil {
+		return nil, fault.Wrap(err, fault.Internal("failed to list webhook endpoints"))
+	}
+
+	deliveryIDs := make([]string, 0, len(endpointIDs))
+	for _, endpointID := range endpointIDs {
+		deliveryID, runErr := restate.Run(ctx, func(restate.RunContext) (string, error) {
+			return uid.New(uid.WebhookDeliveryPrefix), nil
+		}, restate.WithName("generate delivery id"))
+		if runErr != nil {
+			return nil, runErr
+		}
+
+		runErr = restate.RunVoid(ctx, func(runCtx restate.RunContext) error {
+			insertErr := s.db.InsertWebhookDelivery(runCtx, db.InsertWebhookDeliveryParams{
+				ID:          deliveryID,
+				WorkspaceID: req.GetWorkspaceId(),
+				EndpointID:  endpointID,
+				EventID:     req.GetEventId(),
+				EventType:   req.GetEventType(),
+				Payload:     payload,
+				CreatedAt:   time.Now().UnixMilli(),
+			})
+			// A retry of this step after the row landed must not fail the
+			// invocation; the id was journaled, so the row is ours.
+			if db.IsDuplicateKeyError(insertErr) {
+				return nil
+			}
+			return insertErr
+		}, restate.WithName("insert delivery"))
+		if runErr != nil {
+			return nil, fault.Wrap(runErr, fault.Internal("failed to insert webhook delivery"))
+		}
+
+		hydrav1.NewWebhookServiceClient(ctx).Deliver().Send(&hydrav1.DeliverWebhookRequest{
+			DeliveryId: deliveryID,
+		})
+		deliveryIDs = append(deliveryIDs, deliveryID)
+	}
+
+	return &hydrav1.DispatchWebhookResponse{DeliveryIds: deliveryIDs}, nil
+}
+
+// subscribedEndpointIDs returns the endpoints whose event_types include
+// eventType. Endpoints with unparseable subscriptions are skipped rather than
+// failing the whole dispatch.
+func subscribedEndpointIDs(endpoints []db.WebhookEndpoint, eventType string) []string {
+	ids := []string{}
+	for _, endpoint := range endpoints {
+		var eventTypes []string
+		if err := json.Unmarshal(endpoint.EventTypes, &eventTypes); err != nil {
+			logger.Warn("skipping webhook endpoint with invalid event types",
+				"endpoint_id", endpoint.ID,
+				"error", err.Error(),
+			)
+			continue
+		}
+		if slices.Contains(eventTypes, eventType) {
+			ids = append(ids, endpoint.ID)
+		}
+	}
+	return ids
+}
+
+// keyStillExpires reports whether a scheduled key.expired event still
+// describes the key: it must exist, not be deleted, and still expire at the
+// time the event was scheduled for. Updating or removing a key's expiry
+// schedules a new event, so the old one is dropped here.
+func (s *Service) keyStillExpires(ctx context.Context, data []byte) (bool, error) {
+	var expired webhooks.KeyExpiredData
+	if err := json.Unmarshal(data, &expired); err != nil {
+		return false, restate.TerminalError(fmt.Errorf("unmarshal key.expired data: %w", err))
+	}
+
+	key, err := s.db.FindKeyByID(ctx, expired.KeyID)
+	if err != nil {
+		if db.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if key.DeletedAtM.Valid || !key.Expires.Valid {
+		return false, nil
+	}
+
+	// expires is datetime(3) and MySQL rounds sub-millisecond input where
+	// UnixMilli truncates, so allow one millisecond of drift.
+	drift := key.Expires.Time.UnixMilli() - expired.Expires
+	return drift >= -1 && drift <= 1, nil
+}
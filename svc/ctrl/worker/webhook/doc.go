@@ -0,0 +1,22 @@
+// Package webhook delivers signed outbound webhooks to workspace endpoints.
+//
+// The API hands every event to [Service.Dispatch] through the Restate ingress,
+// keyed by event id so one event fans out once. Dispatch snapshots the
+// subscribed endpoints into webhook_deliveries rows and starts one
+// [Service.Deliver] per row.
+//
+// Deliver owns the retry schedule instead of leaning on Restate's invocation
+// retries: a non-2xx answer or a network error is an expected outcome, not a
+// handler failure, and every attempt has to land on the delivery row so the
+// delivery log shows what happened. Attempts back off exponentially, from
+// ten seconds up to two hours between attempts, and the delivery is marked
+// failed after [maxAttempts]. Restate retries only cover infrastructure
+// failures such as the database or vault being unavailable.
+//
+// Endpoint URLs are customer-supplied and called from inside our network, so
+// the HTTP client refuses to dial addresses that are not publicly routable
+// and does not follow redirects.
+//
+// The signing secret is decrypted inside the same durable step that sends the
+// request, so the plaintext is never written to the Restate journal.
+package webhook
@@ -0,0 +1,25 @@
+package webhook
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/unkeyed/unkey/pkg/ssrf"
+)
+
+// attemptTimeout bounds one delivery attempt, including reading the response
+// headers. Receivers are expected to acknowledge quickly and do their work
+// asynchronously.
+const attemptTimeout = 15 * time.Second
+
+// newHTTPClient returns the client used to deliver webhooks. It refuses
+// non-public addresses unless allowPrivateNetworks is set. Redirects are not
+// followed: a 3xx counts as a failed attempt, which keeps a public endpoint
+// from bouncing the request somewhere it could not have been registered to.
+func newHTTPClient(allowPrivateNetworks bool) *http.Client {
+	return ssrf.NewClient(ssrf.Config{
+		Timeout:              attemptTimeout,
+		AllowPrivateNetworks: allowPrivateNetworks,
+		FollowRedirects:      false,
+	})
+}
@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"net/http"
+
+	hydrav1 "github.com/unkeyed/unkey/gen/proto/hydra/v1"
+	"github.com/unkeyed/unkey/gen/rpc/vault"
+	"github.com/unkeyed/unkey/svc/ctrl/internal/db"
+)
+
+// Service implements hydrav1.WebhookServiceServer.
+type Service struct {
+	hydrav1.UnimplementedWebhookServiceServer
+	db         db.Database
+	vault      vault.VaultServiceClient
+	httpClient *http.Client
+}
+
+var _ hydrav1.WebhookServiceServer = (*Service)(nil)
+
+// Config holds the dependencies required to create a Service.
+type Config struct {
+	// DB reads endpoints and writes the delivery log.
+	DB db.Database
+
+	// Vault decrypts endpoint signing secrets, which are encrypted under the
+	// workspace keyring.
+	Vault vault.VaultServiceClient
+
+	// AllowPrivateNetworks lets deliveries reach loopback and private
+	// addresses. Only for local development, where endpoints run next to
+	// the worker.
+	AllowPrivateNetworks bool
+}
+
+// New creates a new Service from the given configuration.
+func New(cfg Config) *Service {
+	return &Service{
+		UnimplementedWebhookServiceServer: hydrav1.UnimplementedWebhookServiceServer{},
+		db:                                cfg.DB,
+		vault:                             cfg.Vault,
+		httpClient:                        newHTTPClient(cfg.AllowPrivateNetworks),
+	}
+}
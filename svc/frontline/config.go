@@ -2,6 +2,7 @@ package frontline
 
 import (
 	"fmt"
+	"net/netip"
 	"time"
 
 	"github.com/unkeyed/unkey/pkg/config"
@@ -33,6 +34,15 @@ type RedisConfig struct {
 	URL string `toml:"url"`
 }
 
+// GeoIPConfig configures the GeoIP database used to resolve client IPs to
+// countries for CountryMatch policy expressions.
+type GeoIPConfig struct {
+	// DatabasePath is the path to a MaxMind DB country database, such as
+	// GeoLite2-Country.mmdb. It is read once at startup. When empty, a
+	// policy with a CountryMatch expression fails the request.
+	DatabasePath string `toml:"database_path"`
+}
+
 // Config holds the complete configuration for the frontline server. It is
 // designed to be loaded from a TOML file using [config.Load]:
 //
@@ -101,6 +111,18 @@ type Config struct {
 	// and usage limiting. Optional — falls back to in-memory when empty.
 	Redis RedisConfig `toml:"redis"`
 
+	// GeoIP configures country resolution for policy match expressions.
+	// Optional. See [GeoIPConfig].
+	GeoIP GeoIPConfig `toml:"geoip"`
+
+	// TrustedProxies lists the CIDR prefixes whose X-Forwarded-For entries
+	// frontline believes, typically the egress ranges of peer frontlines that
+	// forward cross-region requests. Policy match expressions see the
+	// right-most X-Forwarded-For entry outside these networks as the client,
+	// and only when the connection itself comes from one of them; otherwise
+	// the connection's peer address is the client. Empty trusts no proxy.
+	TrustedProxies []string `toml:"trusted_proxies"`
+
 	// RequestTimeout is the maximum duration for proxied requests before the
 	// context is cancelled and a 504 is returned.
 	RequestTimeout time.Duration `toml:"request_timeout" config:"default=15m"`
@@ -120,10 +142,27 @@ type Config struct {
 // calls it automatically after tag-level validation.
 //
 // Currently validates that TLS is either fully configured (both cert and key)
-// or explicitly disabled — partial TLS configuration is an error.
+// or explicitly disabled — partial TLS configuration is an error — and that
+// every trusted proxy is a valid CIDR prefix.
 func (c *Config) Validate() error {
 	if c.TLS != nil && !c.TLS.Disabled && (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
 		return fmt.Errorf("both tls.cert_file and tls.key_file must be provided together when TLS is not disabled")
 	}
+	if _, err := c.trustedProxies(); err != nil {
+		return err
+	}
 	return nil
 }
+
+// trustedProxies parses TrustedProxies.
+func (c *Config) trustedProxies() ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(c.TrustedProxies))
+	for _, cidr := range c.TrustedProxies {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted_proxies entry %q: %w", cidr, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
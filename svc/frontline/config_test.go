@@ -28,3 +28,31 @@ primary = "unkey:password@tcp(mysql:3306)/unkey"
 	require.Equal(t, "http://control:7091", cfg.Control.URL)
 	require.Equal(t, "control-token", cfg.Control.Token)
 }
+
+// TestConfig_RejectsInvalidTrustedProxies ensures a typo in trusted_proxies
+// fails startup instead of silently trusting fewer proxies than intended.
+func TestConfig_RejectsInvalidTrustedProxies(t *testing.T) {
+	t.Parallel()
+
+	load := func(proxies string) (Config, error) {
+		return sharedconfig.LoadBytes[Config]([]byte(`
+platform = "dev"
+region = "local"
+trusted_proxies = ` + proxies + `
+
+[control]
+url = "http://control:7091"
+token = "control-token"
+
+[database]
+primary = "unkey:password@tcp(mysql:3306)/unkey"
+`))
+	}
+
+	cfg, err := load(`["10.0.0.0/8", "fd00::/8"]`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.0/8", "fd00::/8"}, cfg.TrustedProxies)
+
+	_, err = load(`["10.0.0.1"]`)
+	require.ErrorContains(t, err, "trusted_proxies")
+}
@@ -1203,6 +1203,7 @@ type DeploymentTopology struct {
 	AutoscalingReplicasMax     uint32                          `db:"autoscaling_replicas_max"`
 	AutoscalingThresholdCpu    sql.NullInt16                   `db:"autoscaling_threshold_cpu"`
 	AutoscalingThresholdMemory sql.NullInt16                   `db:"autoscaling_threshold_memory"`
+	AutoscalingThresholdRps    sql.NullInt16                   `db:"autoscaling_threshold_rps"`
 	DesiredStatus              DeploymentTopologyDesiredStatus `db:"desired_status"`
 	CreatedAt                  int64                           `db:"created_at"`
 	UpdatedAt                  sql.NullInt64                   `db:"updated_at"`
@@ -1411,6 +1412,7 @@ type Permission struct {
 	Name        string         `db:"name"`
 	Slug        string         `db:"slug"`
 	Description sql.NullString `db:"description"`
+	Conditions  []byte         `db:"conditions"`
 	CreatedAtM  int64          `db:"created_at_m"`
 	UpdatedAtM  sql.NullInt64  `db:"updated_at_m"`
 }
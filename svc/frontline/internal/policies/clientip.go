@@ -0,0 +1,61 @@
+package policies
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// clientIP returns the address RemoteIpMatch and CountryMatch test.
+//
+// The connection's peer is the client unless it is a trusted proxy. Only
+// then is X-Forwarded-For consulted, walking it from the right and skipping
+// trusted hops: the first entry outside the trusted networks was appended by
+// trusted infrastructure and is the client. Anything left of it was supplied
+// by the client itself and is never used. A malformed hop makes the client
+// unknown, which RemoteIpMatch and CountryMatch treat as no match.
+func clientIP(req *http.Request, trusted []netip.Prefix) netip.Addr {
+	peer := parseHostAddr(req.RemoteAddr)
+	if !peer.IsValid() || !isTrustedProxy(peer, trusted) {
+		return peer
+	}
+
+	var hops []string
+	for _, value := range req.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+
+	client := peer
+	for i := len(hops) - 1; i >= 0; i-- {
+		client = parseHostAddr(strings.TrimSpace(hops[i]))
+		if !client.IsValid() || !isTrustedProxy(client, trusted) {
+			return client
+		}
+	}
+	// Every hop is trusted: the request originated inside our own network.
+	return client
+}
+
+func isTrustedProxy(addr netip.Addr, trusted []netip.Prefix) bool {
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseHostAddr parses an address with or without a port. IPv4-mapped IPv6
+// addresses are unmapped and zones dropped, so they compare against
+// configured networks like the plain address would.
+func parseHostAddr(s string) netip.Addr {
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}
+	}
+	return addr.Unmap().WithZone("")
+}
@@ -0,0 +1,84 @@
+package policies
+
+import (
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIP(t *testing.T) {
+	t.Parallel()
+
+	peerFrontlines := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		xff        []string
+		trusted    []netip.Prefix
+		want       string
+	}{
+		{
+			name:       "untrusted peer ignores a spoofed header",
+			remoteAddr: "198.51.100.7:4711",
+			xff:        []string{"203.0.113.1"},
+			trusted:    peerFrontlines,
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "no trusted proxies ignores the header",
+			remoteAddr: "10.1.2.3:4711",
+			xff:        []string{"203.0.113.1"},
+			trusted:    nil,
+			want:       "10.1.2.3",
+		},
+		{
+			name:       "trusted peer uses the hop it appended",
+			remoteAddr: "10.1.2.3:4711",
+			xff:        []string{"203.0.113.1, 198.51.100.7"},
+			trusted:    peerFrontlines,
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "trusted hops are skipped from the right",
+			remoteAddr: "10.1.2.3:4711",
+			xff:        []string{"203.0.113.1", "198.51.100.7, 10.9.9.9"},
+			trusted:    peerFrontlines,
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "trusted peer without a header is the client",
+			remoteAddr: "10.1.2.3:4711",
+			xff:        nil,
+			trusted:    peerFrontlines,
+			want:       "10.1.2.3",
+		},
+		{
+			name:       "malformed hop makes the client unknown",
+			remoteAddr: "10.1.2.3:4711",
+			xff:        []string{"198.51.100.7, not-an-ip"},
+			trusted:    peerFrontlines,
+			want:       "invalid IP",
+		},
+		{
+			name:       "ipv4-mapped peer is unmapped",
+			remoteAddr: "[::ffff:198.51.100.7]:4711",
+			xff:        nil,
+			trusted:    nil,
+			want:       "198.51.100.7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := &http.Request{RemoteAddr: tt.remoteAddr, Header: http.Header{}} //nolint:exhaustruct
+			for _, v := range tt.xff {
+				req.Header.Add("X-Forwarded-For", v)
+			}
+			require.Equal(t, tt.want, clientIP(req, tt.trusted).String())
+		})
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/netip"
 	"time"
 
 	frontlinev1 "github.com/unkeyed/unkey/gen/proto/frontline/v1"
 	"github.com/unkeyed/unkey/internal/services/keys"
 	rl "github.com/unkeyed/unkey/internal/services/ratelimit"
+	"github.com/unkeyed/unkey/internal/services/usagelimiter"
 	"github.com/unkeyed/unkey/pkg/assert"
 	"github.com/unkeyed/unkey/pkg/batch"
 	"github.com/unkeyed/unkey/pkg/clickhouse/schema"
@@ -18,6 +20,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/redaction"
 	"github.com/unkeyed/unkey/pkg/zen"
 	firewallExec "github.com/unkeyed/unkey/svc/frontline/internal/policies/firewall"
+	jwtauthExec "github.com/unkeyed/unkey/svc/frontline/internal/policies/jwtauth"
 	keyauthExec "github.com/unkeyed/unkey/svc/frontline/internal/policies/keyauth"
 	openapiExec "github.com/unkeyed/unkey/svc/frontline/internal/policies/openapi"
 	"github.com/unkeyed/unkey/svc/frontline/internal/policies/principal"
@@ -33,8 +36,23 @@ const PrincipalHeader = "X-Unkey-Principal"
 type Config struct {
 	KeyService       keys.KeyService
 	RateLimiter      rl.Service
+	UsageLimiter     usagelimiter.Service
 	Clock            clock.Clock
 	KeyVerifications *batch.BatchProcessor[schema.KeyVerification]
+
+	// Countries resolves client IPs for CountryMatch expressions. Optional;
+	// when nil, a policy with a CountryMatch fails with InvalidConfiguration.
+	Countries CountryLookup
+
+	// TrustedProxies are the networks whose X-Forwarded-For entries are
+	// believed when resolving the client IP. See clientIP.
+	TrustedProxies []netip.Prefix
+}
+
+// CountryLookup resolves an IP address to an ISO 3166-1 alpha-2 country
+// code. [*geoip.DB] implements it.
+type CountryLookup interface {
+	Country(ip netip.Addr) (string, bool)
 }
 
 // Evaluator evaluates policies against incoming requests.
@@ -45,10 +63,13 @@ type Evaluator interface {
 // Engine implements Evaluator.
 type Engine struct {
 	keyAuth     *keyauthExec.Executor
+	jwtAuth     *jwtauthExec.Executor
 	rateLimiter *ratelimitExec.Executor
 	firewall    *firewallExec.Executor
 	openapi     *openapiExec.Executor
 	regexCache  *regexCache
+	countries   CountryLookup
+	trusted     []netip.Prefix
 }
 
 var _ Evaluator = (*Engine)(nil)
@@ -58,6 +79,21 @@ type Result struct {
 	Principal     *principal.Principal
 	BodyRedactors []*redaction.Redactor
 
+	// Response is set when a Firewall policy answers the request at the edge
+	// (ACTION_REDIRECT, ACTION_RESPOND). Evaluation stops at that policy and
+	// the caller must write Response instead of proxying.
+	Response *firewallExec.Response
+
+	// CreditSettlement is set when the KeyAuth policy that authenticated the
+	// request settles its credit cost from the upstream response. The caller
+	// must settle it after proxying, or release it if the request is answered
+	// without reaching the upstream.
+	CreditSettlement *keyauthExec.Settlement
+
+	// FirewallMatches lists the ids of ACTION_LOG Firewall policies that
+	// matched, in evaluation order, for the request log.
+	FirewallMatches []string
+
 	// Capture flags set by matching enabled logging policies. Each is a
 	// separate opt-in; the base ClickHouse row is always written regardless
 	// of logging policies. LogRequestHeaders also covers the user agent and
@@ -74,6 +110,7 @@ func New(cfg Config) (*Engine, error) {
 	if err := assert.All(
 		assert.NotNil(cfg.KeyService, "cfg.KeyService must not be nil"),
 		assert.NotNil(cfg.RateLimiter, "cfg.RateLimiter must not be nil"),
+		assert.NotNil(cfg.UsageLimiter, "cfg.UsageLimiter must not be nil"),
 		assert.NotNil(cfg.Clock, "cfg.Clock must not be nil"),
 		assert.NotNil(cfg.KeyVerifications, "cfg.KeyVerifications must not be nil"),
 	); err != nil {
@@ -83,13 +120,20 @@ func New(cfg Config) (*Engine, error) {
 	if err != nil {
 		return nil, fmt.Errorf("create openapi executor: %w", err)
 	}
+	jwtAuth, err := jwtauthExec.New(cfg.Clock, jwtauthExec.NewHTTPClient())
+	if err != nil {
+		return nil, fmt.Errorf("create jwtauth executor: %w", err)
+	}
 
 	return &Engine{
-		keyAuth:     keyauthExec.New(cfg.KeyService, cfg.Clock, cfg.KeyVerifications),
+		keyAuth:     keyauthExec.New(cfg.KeyService, cfg.UsageLimiter, cfg.Clock, cfg.KeyVerifications),
+		jwtAuth:     jwtAuth,
 		rateLimiter: ratelimitExec.New(cfg.RateLimiter, cfg.Clock),
 		firewall:    firewallExec.New(),
 		openapi:     openapi,
 		regexCache:  newRegexCache(),
+		countries:   cfg.Countries,
+		trusted:     cfg.TrustedProxies,
 	}, nil
 }
 
@@ -120,12 +164,17 @@ func ParseMiddleware(raw []byte) ([]*frontlinev1.Policy, error) {
 
 // Evaluate processes all policies against the incoming request.
 // Policies are evaluated in order. Disabled policies are skipped.
-// Authentication policies produce a Principal; the first successful auth sets it.
+// Authentication policies (KeyAuth, JWTAuth) produce a Principal; the first
+// successful auth sets it and later authentication policies are skipped.
+//
+// Firewall policies short-circuit the request when their match expressions
+// hit: ACTION_DENY with a Firewall.Denied fault, ACTION_REDIRECT and
+// ACTION_RESPOND by returning a Result whose Response is set. ACTION_LOG
+// only records the policy id in Result.FirewallMatches and evaluation
+// continues.
 //
-// Firewall policies short-circuit the request with a Firewall.Denied fault
-// when their match expressions hit and the action is ACTION_DENY. The
-// action enum exists for forward compatibility — additional outcomes will
-// be wired into the dispatch when they're added to the proto.
+// A credit reservation taken by KeyAuth is released when a later policy
+// rejects the request, since the upstream is never called.
 func (e *Engine) Evaluate(
 	ctx context.Context,
 	sess *zen.Session,
@@ -133,15 +182,23 @@ func (e *Engine) Evaluate(
 	workspaceID string,
 	appID string,
 	policies []*frontlinev1.Policy,
-) (Result, error) {
-	var result Result
+) (result Result, err error) {
+	defer func() {
+		if err != nil && result.CreditSettlement != nil {
+			result.CreditSettlement.Release(context.WithoutCancel(ctx))
+			result.CreditSettlement = nil
+		}
+	}()
+
+	env := matchEnv{clientIP: clientIP(req, e.trusted), principal: nil, countries: e.countries}
 
 	for _, policy := range policies {
 		if !policy.GetEnabled() {
 			continue
 		}
 
-		matched, err := matchesRequest(req, policy.GetMatch(), e.regexCache)
+		env.principal = result.Principal
+		matched, err := matchesRequest(req, env, policy.GetMatch(), e.regexCache)
 		if err != nil {
 			return result, err
 		}
@@ -158,8 +215,9 @@ func (e *Engine) Evaluate(
 			}
 
 			t := time.Now()
-			principal, execErr := e.keyAuth.Execute(ctx, sess, req, appID, cfg.Keyauth)
+			principal, settlement, execErr := e.keyAuth.Execute(ctx, sess, req, appID, cfg.Keyauth)
 			engineEvaluationDuration.WithLabelValues("keyauth").Observe(time.Since(t).Seconds())
+			result.CreditSettlement = settlement
 
 			if execErr != nil {
 				engineEvaluationsTotal.WithLabelValues("keyauth", classifyKeyauthError(execErr)).Inc()
@@ -171,6 +229,26 @@ func (e *Engine) Evaluate(
 				engineEvaluationsTotal.WithLabelValues("keyauth", "success").Inc()
 			}
 
+		case *frontlinev1.Policy_Jwtauth:
+			if result.Principal != nil {
+				engineEvaluationsTotal.WithLabelValues("jwtauth", "skipped").Inc()
+				continue
+			}
+
+			t := time.Now()
+			principal, execErr := e.jwtAuth.Execute(ctx, sess, req, cfg.Jwtauth)
+			engineEvaluationDuration.WithLabelValues("jwtauth").Observe(time.Since(t).Seconds())
+
+			if execErr != nil {
+				engineEvaluationsTotal.WithLabelValues("jwtauth", classifyJwtauthError(execErr)).Inc()
+				return result, execErr
+			}
+
+			if principal != nil {
+				result.Principal = principal
+				engineEvaluationsTotal.WithLabelValues("jwtauth", "success").Inc()
+			}
+
 		case *frontlinev1.Policy_Ratelimit:
 			t := time.Now()
 			execErr := e.rateLimiter.Execute(ctx, sess, req, workspaceID, policy.GetId(), cfg.Ratelimit, result.Principal)
@@ -184,7 +262,7 @@ func (e *Engine) Evaluate(
 			engineEvaluationsTotal.WithLabelValues("ratelimit", "success").Inc()
 		case *frontlinev1.Policy_Firewall:
 			t := time.Now()
-			action, execErr := e.firewall.Execute(ctx, sess, req, cfg.Firewall)
+			action, response, execErr := e.firewall.Execute(ctx, sess, req, cfg.Firewall)
 			engineEvaluationDuration.WithLabelValues("firewall").Observe(time.Since(t).Seconds())
 
 			firewallMatchesTotal.WithLabelValues(policy.GetId(), firewallExec.ActionLabel(action)).Inc()
@@ -194,6 +272,18 @@ func (e *Engine) Evaluate(
 				return result, execErr
 			}
 
+			if response != nil {
+				result.Response = response
+				engineEvaluationsTotal.WithLabelValues("firewall", "responded").Inc()
+				return result, nil
+			}
+
+			if action == frontlinev1.Action_ACTION_LOG {
+				result.FirewallMatches = append(result.FirewallMatches, policy.GetId())
+				engineEvaluationsTotal.WithLabelValues("firewall", "logged").Inc()
+				continue
+			}
+
 			engineEvaluationsTotal.WithLabelValues("firewall", "noop").Inc()
 
 		case *frontlinev1.Policy_Openapi:
@@ -2,12 +2,16 @@ package firewall
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	frontlinev1 "github.com/unkeyed/unkey/gen/proto/frontline/v1"
 	"github.com/unkeyed/unkey/pkg/codes"
 	"github.com/unkeyed/unkey/pkg/fault"
 	"github.com/unkeyed/unkey/pkg/zen"
+	"golang.org/x/net/http/httpguts"
 )
 
 // Executor applies a [Firewall] policy's action to a matched request.
@@ -22,39 +26,195 @@ func New() *Executor {
 	return &Executor{}
 }
 
-// Execute applies the firewall action. ACTION_DENY returns a fault under
-// the Frontline.Firewall.Denied URN, which the middleware layer translates
-// to a 403 response with a fixed "Forbidden" body. Unspecified or unknown
-// action values are treated as a no-op for forward compatibility.
+// Response is a response a Firewall policy serves from the edge in place of
+// proxying the request.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Write sends the response on the session. Headers already set on the
+// response writer by earlier policies (e.g. rate limit headers) are kept.
+func (r *Response) Write(sess *zen.Session) error {
+	h := sess.ResponseWriter().Header()
+	for key, values := range r.Header {
+		h[key] = values
+	}
+	return sess.Send(r.StatusCode, r.Body)
+}
+
+// Execute applies the firewall action:
+//
+//   - ACTION_DENY returns a fault under the Frontline.Firewall.Denied URN,
+//     which the middleware layer translates to a 403 response with a fixed
+//     "Forbidden" body.
+//   - ACTION_LOG returns no response and no error; the caller records the
+//     match and keeps evaluating.
+//   - ACTION_REDIRECT and ACTION_RESPOND return the [Response] to serve.
+//
+// A redirect or respond action with a missing or invalid configuration
+// returns an InvalidConfiguration fault rather than passing the request
+// through, so a broken rule fails closed. Unspecified or unknown action
+// values are treated as a no-op for forward compatibility.
 func (e *Executor) Execute(
 	_ context.Context,
 	_ *zen.Session,
 	_ *http.Request,
 	cfg *frontlinev1.Firewall,
-) (frontlinev1.Action, error) {
+) (frontlinev1.Action, *Response, error) {
 	action := cfg.GetAction()
-	//nolint:exhaustive
 	switch action {
 	case frontlinev1.Action_ACTION_DENY:
-		return action, fault.New("firewall denied",
+		return action, nil, fault.New("firewall denied",
 			fault.Code(codes.Frontline.Firewall.Denied.URN()),
 			fault.Internal("request denied by Firewall policy"),
 			fault.Public("Forbidden"),
 		)
 
+	case frontlinev1.Action_ACTION_LOG:
+		return action, nil, nil
+
+	case frontlinev1.Action_ACTION_REDIRECT:
+		resp, err := redirectResponse(cfg.GetRedirect())
+		if err != nil {
+			return action, nil, invalidConfig(err)
+		}
+		return action, resp, nil
+
+	case frontlinev1.Action_ACTION_RESPOND:
+		resp, err := customResponse(cfg.GetResponse())
+		if err != nil {
+			return action, nil, invalidConfig(err)
+		}
+		return action, resp, nil
+
+	case frontlinev1.Action_ACTION_UNSPECIFIED:
+		return frontlinev1.Action_ACTION_UNSPECIFIED, nil, nil
+
 	default:
-		return frontlinev1.Action_ACTION_UNSPECIFIED, nil
+		return frontlinev1.Action_ACTION_UNSPECIFIED, nil, nil
+	}
+}
+
+// redirectStatusCodes are the status codes ACTION_REDIRECT may use.
+var redirectStatusCodes = map[int32]bool{
+	http.StatusMovedPermanently:  true,
+	http.StatusFound:             true,
+	http.StatusSeeOther:          true,
+	http.StatusTemporaryRedirect: true,
+	http.StatusPermanentRedirect: true,
+}
+
+// redirectResponse builds the response for ACTION_REDIRECT.
+func redirectResponse(cfg *frontlinev1.FirewallRedirect) (*Response, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("redirect is required for ACTION_REDIRECT")
+	}
+
+	location := cfg.GetLocation()
+	if err := validateLocation(location); err != nil {
+		return nil, err
+	}
+
+	status := cfg.GetStatusCode()
+	if status == 0 {
+		status = http.StatusFound
+	}
+	if !redirectStatusCodes[status] {
+		return nil, fmt.Errorf("redirect status code %d must be one of 301, 302, 303, 307 or 308", status)
+	}
+
+	return &Response{
+		StatusCode: int(status),
+		Header:     http.Header{"Location": {location}},
+		Body:       nil,
+	}, nil
+}
+
+// validateLocation accepts an absolute http(s) URL or a same-host path.
+// Protocol-relative "//host" targets are rejected: browsers treat them as
+// another host, which a path-looking value should never silently become.
+func validateLocation(location string) error {
+	if location == "" {
+		return fmt.Errorf("redirect location must not be empty")
+	}
+	if strings.ContainsAny(location, "\r\n") {
+		return fmt.Errorf("redirect location must not contain line breaks")
+	}
+	if strings.HasPrefix(location, "/") {
+		if strings.HasPrefix(location, "//") || strings.HasPrefix(location, "/\\") {
+			return fmt.Errorf("redirect location %q must be an absolute URL or a path", location)
+		}
+		return nil
+	}
+
+	u, err := url.Parse(location)
+	if err != nil {
+		return fmt.Errorf("invalid redirect location %q: %w", location, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("redirect location %q must be an absolute http(s) URL or a path", location)
+	}
+	return nil
+}
+
+// customResponse builds the response for ACTION_RESPOND.
+func customResponse(cfg *frontlinev1.FirewallResponse) (*Response, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("response is required for ACTION_RESPOND")
+	}
+
+	status := cfg.GetStatusCode()
+	if status < 200 || status > 599 {
+		return nil, fmt.Errorf("response status code %d must be between 200 and 599", status)
+	}
+
+	header := make(http.Header, len(cfg.GetHeaders())+1)
+	for name, value := range cfg.GetHeaders() {
+		if !httpguts.ValidHeaderFieldName(name) {
+			return nil, fmt.Errorf("invalid response header name %q", name)
+		}
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return nil, fmt.Errorf("invalid value for response header %q", name)
+		}
+		header.Set(name, value)
 	}
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "text/plain; charset=utf-8")
+	}
+
+	return &Response{
+		StatusCode: int(status),
+		Header:     header,
+		Body:       []byte(cfg.GetBody()),
+	}, nil
+}
+
+// invalidConfig wraps a redirect or respond configuration error.
+func invalidConfig(err error) error {
+	return fault.Wrap(err,
+		fault.Code(codes.Frontline.Internal.InvalidConfiguration.URN()),
+		fault.Internal("invalid firewall policy: "+err.Error()),
+		fault.Public("Service configuration error"),
+	)
 }
 
 // ActionLabel returns the metric label for a firewall action. Kept
 // separate from the proto String() so labels stay stable even if proto enum
 // names change.
 func ActionLabel(a frontlinev1.Action) string {
-	//nolint:exhaustive
 	switch a {
 	case frontlinev1.Action_ACTION_DENY:
 		return "deny"
+	case frontlinev1.Action_ACTION_LOG:
+		return "log"
+	case frontlinev1.Action_ACTION_REDIRECT:
+		return "redirect"
+	case frontlinev1.Action_ACTION_RESPOND:
+		return "respond"
+	case frontlinev1.Action_ACTION_UNSPECIFIED:
+		return "unspecified"
 	default:
 		return "unspecified"
 	}
@@ -21,8 +21,9 @@ func TestFirewallExecutor_Deny(t *testing.T) {
 	//nolint:exhaustruct
 	cfg := &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_DENY}
 
-	action, err := e.Execute(context.Background(), nil, req, cfg)
+	action, resp, err := e.Execute(context.Background(), nil, req, cfg)
 	require.Error(t, err)
+	require.Nil(t, resp)
 	require.Equal(t, frontlinev1.Action_ACTION_DENY, action)
 
 	urn, ok := fault.GetCode(err)
@@ -40,14 +41,153 @@ func TestFirewallExecutor_Unspecified(t *testing.T) {
 	//nolint:exhaustruct
 	cfg := &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_UNSPECIFIED}
 
-	action, err := e.Execute(context.Background(), nil, req, cfg)
+	action, resp, err := e.Execute(context.Background(), nil, req, cfg)
 	require.NoError(t, err)
+	require.Nil(t, resp)
 	require.Equal(t, frontlinev1.Action_ACTION_UNSPECIFIED, action)
 }
 
+func TestFirewallExecutor_Log(t *testing.T) {
+	t.Parallel()
+
+	e := &Executor{}
+	req := httptest.NewRequest("GET", "/xxx", nil)
+
+	//nolint:exhaustruct
+	cfg := &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_LOG}
+
+	action, resp, err := e.Execute(context.Background(), nil, req, cfg)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+	require.Equal(t, frontlinev1.Action_ACTION_LOG, action)
+}
+
+func TestFirewallExecutor_Redirect(t *testing.T) {
+	t.Parallel()
+
+	e := &Executor{}
+	req := httptest.NewRequest("GET", "/xxx", nil)
+
+	t.Run("defaults to 302", func(t *testing.T) {
+		t.Parallel()
+		//nolint:exhaustruct
+		cfg := &frontlinev1.Firewall{
+			Action:   frontlinev1.Action_ACTION_REDIRECT,
+			Redirect: &frontlinev1.FirewallRedirect{Location: "https://example.com/moved"},
+		}
+
+		action, resp, err := e.Execute(context.Background(), nil, req, cfg)
+		require.NoError(t, err)
+		require.Equal(t, frontlinev1.Action_ACTION_REDIRECT, action)
+		require.NotNil(t, resp)
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+		require.Equal(t, "https://example.com/moved", resp.Header.Get("Location"))
+	})
+
+	t.Run("relative path with explicit status", func(t *testing.T) {
+		t.Parallel()
+		//nolint:exhaustruct
+		cfg := &frontlinev1.Firewall{
+			Action:   frontlinev1.Action_ACTION_REDIRECT,
+			Redirect: &frontlinev1.FirewallRedirect{Location: "/login?next=%2F", StatusCode: 308},
+		}
+
+		_, resp, err := e.Execute(context.Background(), nil, req, cfg)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusPermanentRedirect, resp.StatusCode)
+		require.Equal(t, "/login?next=%2F", resp.Header.Get("Location"))
+	})
+}
+
+func TestFirewallExecutor_Respond(t *testing.T) {
+	t.Parallel()
+
+	e := &Executor{}
+	req := httptest.NewRequest("GET", "/xxx", nil)
+
+	t.Run("default content type", func(t *testing.T) {
+		t.Parallel()
+		//nolint:exhaustruct
+		cfg := &frontlinev1.Firewall{
+			Action: frontlinev1.Action_ACTION_RESPOND,
+			Response: &frontlinev1.FirewallResponse{
+				StatusCode: 503,
+				Headers:    map[string]string{"Retry-After": "120"},
+				Body:       "down for maintenance",
+			},
+		}
+
+		action, resp, err := e.Execute(context.Background(), nil, req, cfg)
+		require.NoError(t, err)
+		require.Equal(t, frontlinev1.Action_ACTION_RESPOND, action)
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		require.Equal(t, "120", resp.Header.Get("Retry-After"))
+		require.Equal(t, "text/plain; charset=utf-8", resp.Header.Get("Content-Type"))
+		require.Equal(t, "down for maintenance", string(resp.Body))
+	})
+
+	t.Run("custom content type", func(t *testing.T) {
+		t.Parallel()
+		//nolint:exhaustruct
+		cfg := &frontlinev1.Firewall{
+			Action: frontlinev1.Action_ACTION_RESPOND,
+			Response: &frontlinev1.FirewallResponse{
+				StatusCode: 200,
+				Headers:    map[string]string{"content-type": "application/json"},
+				Body:       `{"ok":true}`,
+			},
+		}
+
+		_, resp, err := e.Execute(context.Background(), nil, req, cfg)
+		require.NoError(t, err)
+		require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	})
+}
+
+func TestFirewallExecutor_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	e := &Executor{}
+	req := httptest.NewRequest("GET", "/xxx", nil)
+
+	//nolint:exhaustruct
+	tests := []struct {
+		name string
+		cfg  *frontlinev1.Firewall
+	}{
+		{name: "redirect missing", cfg: &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_REDIRECT}},
+		{name: "redirect empty location", cfg: &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_REDIRECT, Redirect: &frontlinev1.FirewallRedirect{}}},
+		{name: "redirect protocol relative", cfg: &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_REDIRECT, Redirect: &frontlinev1.FirewallRedirect{Location: "//evil.example"}}},
+		{name: "redirect backslash", cfg: &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_REDIRECT, Redirect: &frontlinev1.FirewallRedirect{Location: "/\\evil.example"}}},
+		{name: "redirect javascript scheme", cfg: &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_REDIRECT, Redirect: &frontlinev1.FirewallRedirect{Location: "javascript:alert(1)"}}},
+		{name: "redirect header injection", cfg: &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_REDIRECT, Redirect: &frontlinev1.FirewallRedirect{Location: "/a\r\nSet-Cookie: x=y"}}},
+		{name: "redirect bad status", cfg: &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_REDIRECT, Redirect: &frontlinev1.FirewallRedirect{Location: "/a", StatusCode: 200}}},
+		{name: "respond missing", cfg: &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_RESPOND}},
+		{name: "respond status out of range", cfg: &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_RESPOND, Response: &frontlinev1.FirewallResponse{StatusCode: 101}}},
+		{name: "respond bad header name", cfg: &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_RESPOND, Response: &frontlinev1.FirewallResponse{StatusCode: 200, Headers: map[string]string{"Bad Header": "x"}}}},
+		{name: "respond bad header value", cfg: &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_RESPOND, Response: &frontlinev1.FirewallResponse{StatusCode: 200, Headers: map[string]string{"X-A": "a\r\nb"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, resp, err := e.Execute(context.Background(), nil, req, tt.cfg)
+			require.Error(t, err)
+			require.Nil(t, resp)
+
+			urn, ok := fault.GetCode(err)
+			require.True(t, ok)
+			require.Equal(t, codes.Frontline.Internal.InvalidConfiguration.URN(), urn)
+		})
+	}
+}
+
 func TestFirewallActionLabel(t *testing.T) {
 	t.Parallel()
 	require.Equal(t, "deny", ActionLabel(frontlinev1.Action_ACTION_DENY))
+	require.Equal(t, "log", ActionLabel(frontlinev1.Action_ACTION_LOG))
+	require.Equal(t, "redirect", ActionLabel(frontlinev1.Action_ACTION_REDIRECT))
+	require.Equal(t, "respond", ActionLabel(frontlinev1.Action_ACTION_RESPOND))
 	require.Equal(t, "unspecified", ActionLabel(frontlinev1.Action_ACTION_UNSPECIFIED))
 }
 
@@ -59,7 +199,7 @@ func TestFirewallExecutor_DoesNotTouchRequest(t *testing.T) {
 	e := &Executor{}
 	req := httptest.NewRequest("GET", "/xxx", nil)
 	req.Header.Set("X-Original", "yes")
-	_, _ = e.Execute(context.Background(), nil, req, &frontlinev1.Firewall{ //nolint:exhaustruct
+	_, _, _ = e.Execute(context.Background(), nil, req, &frontlinev1.Firewall{ //nolint:exhaustruct
 		Action: frontlinev1.Action_ACTION_DENY,
 	})
 	require.Equal(t, "yes", req.Header.Get("X-Original"))
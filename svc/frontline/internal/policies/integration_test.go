@@ -108,6 +108,36 @@ func newTestHarness(t *testing.T) *testHarness {
 				Credits: sql.NullInt64{Int64: cost, Valid: true},
 			})
 		},
+		IncrementKeyCredits: func(ctx context.Context, keyID string, credits int64) error {
+			return db.Query.UpdateKeyCreditsIncrement(ctx, database.RW(), db.UpdateKeyCreditsIncrementParams{
+				ID:      keyID,
+				Credits: sql.NullInt64{Int64: credits, Valid: true},
+			})
+		},
+		FindIdentityCredits: func(ctx context.Context, identityID string) (int64, bool, error) {
+			credits, err := db.WithRetryContext(ctx, func() (db.IdentityCredit, error) {
+				return db.Query.FindIdentityCredits(ctx, database.RO(), identityID)
+			})
+			if db.IsNotFound(err) {
+				return 0, false, nil
+			}
+			if err != nil {
+				return 0, false, err
+			}
+			return int64(credits.Remaining), true, nil // nolint:gosec // balances never approach MaxInt64
+		},
+		DecrementIdentityCredits: func(ctx context.Context, identityID string, cost int64) error {
+			return db.Query.UpdateIdentityCreditsDecrement(ctx, database.RW(), db.UpdateIdentityCreditsDecrementParams{
+				IdentityID: identityID,
+				Credits:    uint64(cost), // nolint:gosec // the usage limiter never passes a negative cost
+			})
+		},
+		IncrementIdentityCredits: func(ctx context.Context, identityID string, credits int64) error {
+			return db.Query.UpdateIdentityCreditsIncrement(ctx, database.RW(), db.UpdateIdentityCreditsIncrementParams{
+				IdentityID: identityID,
+				Credits:    uint64(credits), // nolint:gosec // refunds are never negative
+			})
+		},
 		Counter:       redisCounter,
 		TTL:           60 * time.Second,
 		ReplayWorkers: 2,
@@ -154,6 +184,7 @@ func newTestHarness(t *testing.T) *testHarness {
 	eng, err := policies.New(policies.Config{
 		KeyService:       keyService,
 		RateLimiter:      rateLimiter,
+		UsageLimiter:     usageLimiter,
 		Clock:            clk,
 		KeyVerifications: keyVerifications,
 	})
@@ -588,6 +619,110 @@ func TestKeyAuth_CreditsOverride_ChargesConfiguredCost(t *testing.T) {
 	require.Equal(t, codes.Frontline.Auth.UsageExceeded.URN(), urn)
 }
 
+// responseCreditsPolicy reserves reserve credits and settles on the
+// X-Unkey-Cost response header.
+func responseCreditsPolicy(keySpaceID string, reserve int64) *frontlinev1.Policy {
+	return &frontlinev1.Policy{
+		Id:      "auth",
+		Enabled: proto.Bool(true),
+		Config: &frontlinev1.Policy_Keyauth{
+			Keyauth: &frontlinev1.KeyAuth{
+				KeySpaceIds:     []string{keySpaceID},
+				ResponseCredits: &frontlinev1.ResponseCredits{Header: "X-Unkey-Cost", Reserve: reserve},
+			},
+		},
+	}
+}
+
+// Settling a response credits reservation refunds what the upstream did not
+// use, so the refunded credits are available to the next request.
+func TestKeyAuth_ResponseCredits_SettleRefundsUnusedReservation(t *testing.T) {
+	h := newTestHarness(t)
+	ctx := context.Background()
+	s := h.seed(ctx)
+
+	err := db.Query.UpdateKeyCreditsSet(ctx, h.db.RW(), db.UpdateKeyCreditsSetParams{
+		Credits: sql.NullInt64{Int64: 100, Valid: true},
+		ID:      s.KeyID,
+	})
+	require.NoError(t, err)
+
+	evaluate := func(reserve int64) (policies.Result, error) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+s.RawKey)
+		return h.engine.Evaluate(ctx, newSession(t, req), req, s.WorkspaceID,
+			[]*frontlinev1.Policy{responseCreditsPolicy(s.KeySpaceID, reserve)})
+	}
+
+	result, err := evaluate(60)
+	require.NoError(t, err)
+	require.NotNil(t, result.CreditSettlement)
+	require.Equal(t, "X-Unkey-Cost", result.CreditSettlement.Header())
+
+	result.CreditSettlement.Settle(ctx, "10")
+
+	select {
+	case v := <-h.verificationEvents:
+		require.Equal(t, s.KeyID, v.KeyID)
+		require.Equal(t, int64(10), v.SpentCredits)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a key verification after settling")
+	}
+
+	// 90 credits remain: a full reservation of 90 succeeds, after which the
+	// key is exhausted.
+	result, err = evaluate(90)
+	require.NoError(t, err)
+	require.NotNil(t, result.CreditSettlement)
+
+	_, err = evaluate(1)
+	require.Error(t, err)
+	urn, ok := fault.GetCode(err)
+	require.True(t, ok)
+	require.Equal(t, codes.Frontline.Auth.UsageExceeded.URN(), urn)
+}
+
+// A reservation larger than the key's remaining credits is rejected up front,
+// so concurrent requests can never overdraw the key.
+func TestKeyAuth_ResponseCredits_ReservationExceedsRemaining(t *testing.T) {
+	h := newTestHarness(t)
+	ctx := context.Background()
+	s := h.seed(ctx)
+
+	err := db.Query.UpdateKeyCreditsSet(ctx, h.db.RW(), db.UpdateKeyCreditsSetParams{
+		Credits: sql.NullInt64{Int64: 5, Valid: true},
+		ID:      s.KeyID,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+s.RawKey)
+	sess := newSession(t, req)
+
+	_, err = h.engine.Evaluate(ctx, sess, req, s.WorkspaceID,
+		[]*frontlinev1.Policy{responseCreditsPolicy(s.KeySpaceID, 10)})
+	require.Error(t, err)
+	urn, ok := fault.GetCode(err)
+	require.True(t, ok)
+	require.Equal(t, codes.Frontline.Auth.UsageExceeded.URN(), urn)
+}
+
+// Keys without a usage limit never reserve, so there is nothing to settle.
+func TestKeyAuth_ResponseCredits_UnlimitedKeyHasNoSettlement(t *testing.T) {
+	h := newTestHarness(t)
+	ctx := context.Background()
+	s := h.seed(ctx)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+s.RawKey)
+	sess := newSession(t, req)
+
+	result, err := h.engine.Evaluate(ctx, sess, req, s.WorkspaceID,
+		[]*frontlinev1.Policy{responseCreditsPolicy(s.KeySpaceID, 10)})
+	require.NoError(t, err)
+	require.Nil(t, result.CreditSettlement)
+}
+
 func TestKeyAuth_MissingKey_Reject(t *testing.T) {
 	h := newTestHarness(t)
 	ctx := context.Background()
@@ -1175,6 +1310,75 @@ func TestFirewall_DenyByPath_NonMatchPasses(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestFirewall_LogRecordsMatchAndContinues(t *testing.T) {
+	h := newTestHarness(t)
+	ctx := context.Background()
+
+	req := httptest.NewRequest(http.MethodGet, "/xxx", nil)
+	sess := newSession(t, req)
+
+	policies := []*frontlinev1.Policy{
+		{
+			Id:      "shadow-xxx",
+			Enabled: proto.Bool(true),
+			Match: []*frontlinev1.MatchExpr{
+				{Expr: &frontlinev1.MatchExpr_Path{Path: &frontlinev1.PathMatch{
+					Path: &frontlinev1.StringMatch{Match: &frontlinev1.StringMatch_Prefix{Prefix: "/xxx"}},
+				}}},
+			},
+			Config: &frontlinev1.Policy_Firewall{
+				Firewall: &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_LOG},
+			},
+		},
+		{
+			Id:      "shadow-all",
+			Enabled: proto.Bool(true),
+			Config: &frontlinev1.Policy_Firewall{
+				Firewall: &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_LOG},
+			},
+		},
+	}
+
+	result, err := h.engine.Evaluate(ctx, sess, req, "ws_test", policies)
+	require.NoError(t, err)
+	require.Nil(t, result.Response)
+	require.Equal(t, []string{"shadow-xxx", "shadow-all"}, result.FirewallMatches)
+}
+
+func TestFirewall_RespondShortCircuits(t *testing.T) {
+	h := newTestHarness(t)
+	ctx := context.Background()
+
+	req := httptest.NewRequest(http.MethodGet, "/xxx", nil)
+	sess := newSession(t, req)
+
+	policies := []*frontlinev1.Policy{
+		{
+			Id:      "maintenance",
+			Enabled: proto.Bool(true),
+			Config: &frontlinev1.Policy_Firewall{
+				Firewall: &frontlinev1.Firewall{
+					Action:   frontlinev1.Action_ACTION_RESPOND,
+					Response: &frontlinev1.FirewallResponse{StatusCode: 503, Body: "maintenance"},
+				},
+			},
+		},
+		{
+			Id:      "block-all",
+			Enabled: proto.Bool(true),
+			Config: &frontlinev1.Policy_Firewall{
+				Firewall: &frontlinev1.Firewall{Action: frontlinev1.Action_ACTION_DENY},
+			},
+		},
+	}
+
+	result, err := h.engine.Evaluate(ctx, sess, req, "ws_test", policies)
+	require.NoError(t, err)
+	require.NotNil(t, result.Response)
+	require.Equal(t, http.StatusServiceUnavailable, result.Response.StatusCode)
+	require.Equal(t, "maintenance", string(result.Response.Body))
+}
+
 // --- Logging integration tests ---
 
 func TestLogging_EnabledMatchingPolicySetsCaptureFlags(t *testing.T) {
@@ -0,0 +1,267 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	frontlinev1 "github.com/unkeyed/unkey/gen/proto/frontline/v1"
+	"github.com/unkeyed/unkey/pkg/cache"
+	"github.com/unkeyed/unkey/pkg/clock"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/jwks"
+	"github.com/unkeyed/unkey/pkg/jwt"
+	"github.com/unkeyed/unkey/pkg/ssrf"
+	"github.com/unkeyed/unkey/pkg/zen"
+	"github.com/unkeyed/unkey/svc/frontline/internal/policies/principal"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// defaultSubjectClaim is used when the policy does not set subject_claim.
+	defaultSubjectClaim = "sub"
+
+	// defaultCacheTTL is used when the policy does not set jwks_cache_ms.
+	defaultCacheTTL = time.Hour
+)
+
+// defaultAlgorithms is used when the policy does not list algorithms.
+var defaultAlgorithms = []string{"RS256"}
+
+// supportedAlgorithms are the signing algorithms pkg/jwt can verify. A policy
+// listing anything else is rejected as misconfigured rather than silently
+// narrowed, so an operator never believes an algorithm is accepted when it
+// is not.
+var supportedAlgorithms = jwks.SupportedAlgorithms
+
+// Executor handles JWTAuth policy evaluation. It keeps one key source per
+// distinct policy configuration so JWKS documents are fetched once and shared
+// across requests and deployments using the same settings.
+type Executor struct {
+	clock      clock.Clock
+	httpClient *http.Client
+	sources    cache.Cache[string, *jwks.Source]
+}
+
+// NewHTTPClient returns the client used to fetch JWKS and OIDC discovery
+// documents. JWTAuth URLs are customer-supplied and fetched from inside the
+// frontline network, so the client refuses any address that is not publicly
+// routable.
+func NewHTTPClient() *http.Client {
+	return ssrf.NewClient(ssrf.Config{
+		Timeout:              jwks.FetchTimeout,
+		AllowPrivateNetworks: false,
+		FollowRedirects:      true,
+	})
+}
+
+// New creates a new JWTAuth policy executor. httpClient fetches JWKS and OIDC
+// discovery documents; production callers pass [NewHTTPClient].
+func New(clk clock.Clock, httpClient *http.Client) (*Executor, error) {
+	sources, err := cache.New(cache.Config[string, *jwks.Source]{
+		Fresh:    24 * time.Hour,
+		Stale:    7 * 24 * time.Hour,
+		MaxSize:  1024,
+		Resource: "jwtauth_key_sources",
+		Clock:    clk,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Executor{
+		clock:      clk,
+		httpClient: httpClient,
+		sources:    sources,
+	}, nil
+}
+
+// Execute evaluates a JWTAuth policy against the incoming request. It
+// extracts the Bearer token, verifies its signature and registered claims
+// with the policy's signing keys, and returns a JWT Principal on success.
+//
+// A request without a token returns (nil, nil) when the policy allows
+// anonymous access, so no Principal is produced and later policies still run.
+func (e *Executor) Execute(
+	ctx context.Context,
+	_ *zen.Session,
+	req *http.Request,
+	cfg *frontlinev1.JWTAuth,
+) (*principal.Principal, error) {
+	token := extractBearer(req)
+	if token == "" {
+		if cfg.GetAllowAnonymous() {
+			return nil, nil
+		}
+		return nil, fault.New("missing bearer token",
+			fault.Code(codes.Frontline.Auth.MissingCredentials.URN()),
+			fault.Internal("no bearer token found in Authorization header"),
+			fault.Public("Authentication required. Please provide a valid bearer token."),
+		)
+	}
+
+	parsed, err := parseToken(token)
+	if err != nil {
+		return nil, fault.Wrap(err,
+			fault.Code(codes.Frontline.Auth.InvalidToken.URN()),
+			fault.Internal("malformed JWT"),
+			fault.Public("Authentication failed. The provided token is invalid."),
+		)
+	}
+
+	source, err := e.keySource(ctx, cfg)
+	if err != nil {
+		return nil, fault.Wrap(err,
+			fault.Code(codes.Frontline.Internal.InvalidConfiguration.URN()),
+			fault.Internal("invalid jwtauth policy: "+err.Error()),
+			fault.Public("Service configuration error."),
+		)
+	}
+
+	// Reject disallowed algorithms before touching the key source, so a token
+	// naming "none" or HS256 never costs a JWKS fetch.
+	if !slices.Contains(source.Algorithms(), parsed.alg) {
+		return nil, fault.New("JWT algorithm not allowed",
+			fault.Code(codes.Frontline.Auth.InvalidToken.URN()),
+			fault.Internal(fmt.Sprintf("token algorithm %q not in %s", parsed.alg, strings.Join(source.Algorithms(), ","))),
+			fault.Public("Authentication failed. The provided token is invalid."),
+		)
+	}
+
+	payload, err := source.Verify(ctx, parsed.kid, parsed.alg, token)
+	if errors.Is(err, jwks.ErrUnavailable) {
+		return nil, fault.Wrap(err,
+			fault.Code(codes.Frontline.Auth.JWKSUnavailable.URN()),
+			fault.Internal("failed to fetch JWT signing keys"),
+			fault.Public("Unable to verify the bearer token right now. Please try again later."),
+		)
+	}
+	if err != nil {
+		return nil, fault.Wrap(err,
+			fault.Code(codes.Frontline.Auth.InvalidToken.URN()),
+			fault.Internal("JWT verification failed"),
+			fault.Public("Authentication failed. The provided token is invalid."),
+		)
+	}
+
+	subjectClaim := cfg.GetSubjectClaim()
+	if subjectClaim == "" {
+		subjectClaim = defaultSubjectClaim
+	}
+	p, err := principal.JWTPrincipalFromClaims(parsed.header, payload, parsed.signature, subjectClaim)
+	if err != nil {
+		return nil, fault.Wrap(err,
+			fault.Code(codes.Frontline.Auth.InvalidToken.URN()),
+			fault.Internal("JWT has no usable subject"),
+			fault.Public("Authentication failed. The provided token is invalid."),
+		)
+	}
+	return p, nil
+}
+
+// keySource returns the cached key source for cfg, creating it on first use.
+// Sources are keyed by the full policy configuration because issuer,
+// audience, and skew settings are baked into the verifiers they hold.
+func (e *Executor) keySource(ctx context.Context, cfg *frontlinev1.JWTAuth) (*jwks.Source, error) {
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("fingerprint policy: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+
+	source, _, err := e.sources.SWR(ctx, hex.EncodeToString(sum[:]),
+		func(context.Context) (*jwks.Source, error) {
+			return e.newKeySource(cfg)
+		},
+		func(err error) cache.Op {
+			if err != nil {
+				return cache.Noop
+			}
+			return cache.WriteValue
+		},
+	)
+	return source, err
+}
+
+// newKeySource validates cfg and builds its key source. Remote sources are
+// not fetched here; the first token to need them triggers the fetch.
+func (e *Executor) newKeySource(cfg *frontlinev1.JWTAuth) (*jwks.Source, error) {
+	algorithms := cfg.GetAlgorithms()
+	if len(algorithms) == 0 {
+		algorithms = defaultAlgorithms
+	}
+	for _, alg := range algorithms {
+		if !slices.Contains(supportedAlgorithms, alg) {
+			return nil, fmt.Errorf("unsupported algorithm %q, supported algorithms are %s", alg, strings.Join(supportedAlgorithms, ", "))
+		}
+	}
+
+	if cfg.GetClockSkewMs() < 0 {
+		return nil, fmt.Errorf("clock_skew_ms must not be negative, got %d", cfg.GetClockSkewMs())
+	}
+	if cfg.GetJwksCacheMs() < 0 {
+		return nil, fmt.Errorf("jwks_cache_ms must not be negative, got %d", cfg.GetJwksCacheMs())
+	}
+	ttl := defaultCacheTTL
+	if ms := cfg.GetJwksCacheMs(); ms > 0 {
+		ttl = time.Duration(ms) * time.Millisecond
+	}
+
+	// OIDC discovery already pins the provider's issuer, so it doubles as the
+	// required iss claim unless the policy names a different one explicitly.
+	issuer := cfg.GetIssuer()
+	if issuer == "" {
+		issuer = cfg.GetOidcIssuer()
+	}
+
+	config := jwks.SourceConfig{
+		JWKSURI:      "",
+		IssuerURL:    "",
+		PublicKeyPEM: "",
+		Algorithms:   algorithms,
+		VerifyOptions: []jwt.VerifyOption{
+			jwt.WithClock(e.clock),
+			jwt.WithIssuer(issuer),
+			jwt.WithAnyAudience(cfg.GetAudiences()...),
+			jwt.WithLeeway(time.Duration(cfg.GetClockSkewMs()) * time.Millisecond),
+		},
+		TTL:        ttl,
+		HTTPClient: e.httpClient,
+		Clock:      e.clock,
+	}
+
+	switch src := cfg.GetJwksSource().(type) {
+	case *frontlinev1.JWTAuth_JwksUri:
+		if err := jwks.ValidateURL(src.JwksUri); err != nil {
+			return nil, fmt.Errorf("jwks_uri: %w", err)
+		}
+		config.JWKSURI = src.JwksUri
+	case *frontlinev1.JWTAuth_OidcIssuer:
+		if err := jwks.ValidateURL(src.OidcIssuer); err != nil {
+			return nil, fmt.Errorf("oidc_issuer: %w", err)
+		}
+		config.IssuerURL = src.OidcIssuer
+	case *frontlinev1.JWTAuth_PublicKeyPem:
+		if len(src.PublicKeyPem) == 0 {
+			return nil, errors.New("public_key_pem must not be empty")
+		}
+		config.PublicKeyPEM = string(src.PublicKeyPem)
+	default:
+		return nil, errors.New("one of jwks_uri, oidc_issuer or public_key_pem is required")
+	}
+
+	source, err := jwks.NewSource(config)
+	if err != nil {
+		if config.PublicKeyPEM != "" {
+			return nil, fmt.Errorf("public_key_pem: %w", err)
+		}
+		return nil, err
+	}
+	return source, nil
+}
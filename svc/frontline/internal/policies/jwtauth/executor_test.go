@@ -0,0 +1,546 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	frontlinev1 "github.com/unkeyed/unkey/gen/proto/frontline/v1"
+	"github.com/unkeyed/unkey/pkg/clock"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/pkg/jwks"
+	"github.com/unkeyed/unkey/svc/frontline/internal/policies/principal"
+)
+
+type testKey struct {
+	kid string
+	rsa *rsa.PrivateKey
+	ec  *ecdsa.PrivateKey
+}
+
+func newRSAKey(t *testing.T, kid string) testKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return testKey{kid: kid, rsa: key, ec: nil}
+}
+
+func newECKey(t *testing.T, kid string) testKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return testKey{kid: kid, rsa: nil, ec: key}
+}
+
+// sign builds a compact JWT. pkg/jwt signers do not emit a kid header, so
+// tokens are assembled by hand here.
+func (k testKey) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	header := map[string]any{"typ": "JWT"}
+	if k.kid != "" {
+		header["kid"] = k.kid
+	}
+	if k.rsa != nil {
+		header["alg"] = "RS256"
+	} else {
+		header["alg"] = "ES256"
+	}
+
+	headerJSON, err := json.Marshal(header)
+	require.NoError(t, err)
+	payloadJSON, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	input := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(payloadJSON)
+	hash := sha256.Sum256([]byte(input))
+
+	var signature []byte
+	if k.rsa != nil {
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k.rsa, crypto.SHA256, hash[:])
+		require.NoError(t, err)
+	} else {
+		r, s, signErr := ecdsa.Sign(rand.Reader, k.ec, hash[:])
+		require.NoError(t, signErr)
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (k testKey) jwk() map[string]any {
+	if k.rsa != nil {
+		return map[string]any{
+			"kty": "RSA",
+			"kid": k.kid,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(k.rsa.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.rsa.E)).Bytes()),
+		}
+	}
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	k.ec.X.FillBytes(x)
+	k.ec.Y.FillBytes(y)
+	return map[string]any{
+		"kty": "EC",
+		"kid": k.kid,
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(x),
+		"y":   base64.RawURLEncoding.EncodeToString(y),
+	}
+}
+
+func (k testKey) publicKeyPEM(t *testing.T) []byte {
+	t.Helper()
+	var pub any
+	if k.rsa != nil {
+		pub = &k.rsa.PublicKey
+	} else {
+		pub = &k.ec.PublicKey
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+// idp is a fake identity provider serving a JWKS document and an OIDC
+// discovery document over TLS.
+type idp struct {
+	server   *httptest.Server
+	mu       sync.Mutex
+	keys     []testKey
+	issuer   string
+	jwksHits atomic.Int64
+	failJWKS atomic.Bool
+}
+
+func newIDP(t *testing.T, keys ...testKey) *idp {
+	t.Helper()
+	p := &idp{keys: keys}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jwks.json", func(w http.ResponseWriter, _ *http.Request) {
+		p.jwksHits.Add(1)
+		if p.failJWKS.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		p.mu.Lock()
+		jwks := make([]map[string]any, 0, len(p.keys))
+		for _, k := range p.keys {
+			jwks = append(jwks, k.jwk())
+		}
+		p.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": jwks})
+	})
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		p.mu.Lock()
+		issuer := p.issuer
+		p.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   issuer,
+			"jwks_uri": p.server.URL + "/jwks.json",
+		})
+	})
+	p.server = httptest.NewTLSServer(mux)
+	p.issuer = p.server.URL
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *idp) setKeys(keys ...testKey) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys = keys
+}
+
+func newTestExecutor(t *testing.T, clk clock.Clock, p *idp) *Executor {
+	t.Helper()
+	client := http.DefaultClient
+	if p != nil {
+		client = p.server.Client()
+	}
+	e, err := New(clk, client)
+	require.NoError(t, err)
+	return e
+}
+
+func bearerRequest(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/v1/orders", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func requireCode(t *testing.T, err error, want codes.URN) {
+	t.Helper()
+	require.Error(t, err)
+	urn, ok := fault.GetCode(err)
+	require.True(t, ok, "error has no fault code: %v", err)
+	require.Equal(t, want, urn)
+}
+
+func TestExecute_JWKS(t *testing.T) {
+	t.Parallel()
+
+	key := newRSAKey(t, "k1")
+	p := newIDP(t, key)
+	clk := clock.NewTestClock(time.Now())
+	e := newTestExecutor(t, clk, p)
+
+	//nolint:exhaustruct
+	cfg := &frontlinev1.JWTAuth{
+		JwksSource: &frontlinev1.JWTAuth_JwksUri{JwksUri: p.server.URL + "/jwks.json"},
+		Issuer:     "https://auth.example.com/",
+		Audiences:  []string{"orders-api"},
+	}
+
+	token := key.sign(t, map[string]any{
+		"sub":    "user_123",
+		"iss":    "https://auth.example.com/",
+		"aud":    "orders-api",
+		"exp":    clk.Now().Add(time.Hour).Unix(),
+		"org_id": "org_42",
+	})
+
+	p1, err := e.Execute(context.Background(), nil, bearerRequest(token), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, p1)
+	require.Equal(t, "user_123", p1.Subject)
+	require.Equal(t, principal.PrincipalTypeJWT, p1.Type)
+	require.Equal(t, "org_42", p1.Source.JWT.Payload["org_id"])
+	require.Equal(t, "k1", p1.Source.JWT.Header["kid"])
+
+	// The key set is cached: a second token does not refetch.
+	_, err = e.Execute(context.Background(), nil, bearerRequest(token), cfg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), p.jwksHits.Load())
+}
+
+func TestExecute_ClaimValidation(t *testing.T) {
+	t.Parallel()
+
+	key := newRSAKey(t, "k1")
+	p := newIDP(t, key)
+	clk := clock.NewTestClock(time.Now())
+	e := newTestExecutor(t, clk, p)
+
+	//nolint:exhaustruct
+	cfg := &frontlinev1.JWTAuth{
+		JwksSource:  &frontlinev1.JWTAuth_JwksUri{JwksUri: p.server.URL + "/jwks.json"},
+		Issuer:      "https://auth.example.com/",
+		Audiences:   []string{"orders-api", "billing-api"},
+		ClockSkewMs: 30_000,
+	}
+
+	valid := func() map[string]any {
+		return map[string]any{
+			"sub": "user_123",
+			"iss": "https://auth.example.com/",
+			"aud": []string{"billing-api"},
+			"exp": clk.Now().Add(time.Hour).Unix(),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(map[string]any)
+		ok     bool
+	}{
+		{name: "valid", mutate: func(map[string]any) {}, ok: true},
+		{name: "expired within skew", mutate: func(c map[string]any) { c["exp"] = clk.Now().Add(-10 * time.Second).Unix() }, ok: true},
+		{name: "expired", mutate: func(c map[string]any) { c["exp"] = clk.Now().Add(-time.Hour).Unix() }, ok: false},
+		{name: "not yet valid", mutate: func(c map[string]any) { c["nbf"] = clk.Now().Add(time.Hour).Unix() }, ok: false},
+		{name: "wrong issuer", mutate: func(c map[string]any) { c["iss"] = "https://evil.example.com/" }, ok: false},
+		{name: "wrong audience", mutate: func(c map[string]any) { c["aud"] = "admin-api" }, ok: false},
+		{name: "missing subject", mutate: func(c map[string]any) { delete(c, "sub") }, ok: false},
+		{name: "non-string subject", mutate: func(c map[string]any) { c["sub"] = 42 }, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := valid()
+			tt.mutate(claims)
+			p, err := e.Execute(context.Background(), nil, bearerRequest(key.sign(t, claims)), cfg)
+			if tt.ok {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				return
+			}
+			requireCode(t, err, codes.Frontline.Auth.InvalidToken.URN())
+			require.Nil(t, p)
+		})
+	}
+}
+
+func TestExecute_SubjectClaim(t *testing.T) {
+	t.Parallel()
+
+	key := newECKey(t, "")
+	clk := clock.NewTestClock(time.Now())
+	e := newTestExecutor(t, clk, nil)
+
+	//nolint:exhaustruct
+	cfg := &frontlinev1.JWTAuth{
+		JwksSource:   &frontlinev1.JWTAuth_PublicKeyPem{PublicKeyPem: key.publicKeyPEM(t)},
+		Algorithms:   []string{"ES256"},
+		SubjectClaim: "email",
+	}
+
+	token := key.sign(t, map[string]any{"sub": "user_123", "email": "jane@example.com"})
+	p, err := e.Execute(context.Background(), nil, bearerRequest(token), cfg)
+	require.NoError(t, err)
+	require.Equal(t, "jane@example.com", p.Subject)
+}
+
+func TestExecute_MissingToken(t *testing.T) {
+	t.Parallel()
+
+	key := newRSAKey(t, "")
+	e := newTestExecutor(t, clock.NewTestClock(time.Now()), nil)
+
+	//nolint:exhaustruct
+	cfg := &frontlinev1.JWTAuth{
+		JwksSource: &frontlinev1.JWTAuth_PublicKeyPem{PublicKeyPem: key.publicKeyPEM(t)},
+	}
+
+	p, err := e.Execute(context.Background(), nil, bearerRequest(""), cfg)
+	requireCode(t, err, codes.Frontline.Auth.MissingCredentials.URN())
+	require.Nil(t, p)
+
+	cfg.AllowAnonymous = true
+	p, err = e.Execute(context.Background(), nil, bearerRequest(""), cfg)
+	require.NoError(t, err)
+	require.Nil(t, p)
+
+	// allow_anonymous only covers absent tokens, never invalid ones.
+	p, err = e.Execute(context.Background(), nil, bearerRequest("not.a.jwt"), cfg)
+	requireCode(t, err, codes.Frontline.Auth.InvalidToken.URN())
+	require.Nil(t, p)
+}
+
+func TestExecute_AlgorithmNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	rsaKey := newRSAKey(t, "rsa")
+	ecKey := newECKey(t, "ec")
+	p := newIDP(t, rsaKey, ecKey)
+	e := newTestExecutor(t, clock.NewTestClock(time.Now()), p)
+
+	//nolint:exhaustruct
+	cfg := &frontlinev1.JWTAuth{
+		JwksSource: &frontlinev1.JWTAuth_JwksUri{JwksUri: p.server.URL + "/jwks.json"},
+	}
+
+	// Algorithms defaults to RS256, so an ES256 token is rejected before any
+	// JWKS fetch.
+	_, err := e.Execute(context.Background(), nil, bearerRequest(ecKey.sign(t, map[string]any{"sub": "u"})), cfg)
+	requireCode(t, err, codes.Frontline.Auth.InvalidToken.URN())
+	require.Equal(t, int64(0), p.jwksHits.Load())
+
+	cfg.Algorithms = []string{"RS256", "ES256"}
+	_, err = e.Execute(context.Background(), nil, bearerRequest(ecKey.sign(t, map[string]any{"sub": "u"})), cfg)
+	require.NoError(t, err)
+
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"u"}`)) + "."
+	_, err = e.Execute(context.Background(), nil, bearerRequest(unsigned), cfg)
+	requireCode(t, err, codes.Frontline.Auth.InvalidToken.URN())
+}
+
+func TestExecute_KeyRotation(t *testing.T) {
+	t.Parallel()
+
+	oldKey := newRSAKey(t, "old")
+	newKey := newRSAKey(t, "new")
+	p := newIDP(t, oldKey)
+	clk := clock.NewTestClock(time.Now())
+	e := newTestExecutor(t, clk, p)
+
+	//nolint:exhaustruct
+	cfg := &frontlinev1.JWTAuth{
+		JwksSource: &frontlinev1.JWTAuth_JwksUri{JwksUri: p.server.URL + "/jwks.json"},
+	}
+
+	_, err := e.Execute(context.Background(), nil, bearerRequest(oldKey.sign(t, map[string]any{"sub": "u"})), cfg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), p.jwksHits.Load())
+
+	// The provider rotates; once the refetch throttle has lapsed, a token
+	// naming the new kid triggers a refetch.
+	p.setKeys(oldKey, newKey)
+	clk.Tick(jwks.RefetchMinInterval)
+	_, err = e.Execute(context.Background(), nil, bearerRequest(newKey.sign(t, map[string]any{"sub": "u"})), cfg)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), p.jwksHits.Load())
+
+	// Unknown kids are throttled: a burst of forged tokens costs no fetches.
+	forged := newRSAKey(t, "forged")
+	for range 5 {
+		_, err = e.Execute(context.Background(), nil, bearerRequest(forged.sign(t, map[string]any{"sub": "u"})), cfg)
+		requireCode(t, err, codes.Frontline.Auth.InvalidToken.URN())
+	}
+	require.Equal(t, int64(2), p.jwksHits.Load())
+
+	// A token signed with a known kid but the wrong key never refetches.
+	clk.Tick(2 * jwks.RefetchMinInterval)
+	imposter := newRSAKey(t, "new")
+	_, err = e.Execute(context.Background(), nil, bearerRequest(imposter.sign(t, map[string]any{"sub": "u"})), cfg)
+	requireCode(t, err, codes.Frontline.Auth.InvalidToken.URN())
+	require.Equal(t, int64(2), p.jwksHits.Load())
+}
+
+func TestExecute_CacheExpiry(t *testing.T) {
+	t.Parallel()
+
+	key := newRSAKey(t, "k1")
+	p := newIDP(t, key)
+	clk := clock.NewTestClock(time.Now())
+	e := newTestExecutor(t, clk, p)
+
+	//nolint:exhaustruct
+	cfg := &frontlinev1.JWTAuth{
+		JwksSource:  &frontlinev1.JWTAuth_JwksUri{JwksUri: p.server.URL + "/jwks.json"},
+		JwksCacheMs: (5 * time.Minute).Milliseconds(),
+	}
+	token := key.sign(t, map[string]any{"sub": "u"})
+
+	_, err := e.Execute(context.Background(), nil, bearerRequest(token), cfg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), p.jwksHits.Load())
+
+	clk.Tick(6 * time.Minute)
+	_, err = e.Execute(context.Background(), nil, bearerRequest(token), cfg)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), p.jwksHits.Load())
+
+	// A failed refresh keeps the stale set in service.
+	p.failJWKS.Store(true)
+	clk.Tick(6 * time.Minute)
+	_, err = e.Execute(context.Background(), nil, bearerRequest(token), cfg)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), p.jwksHits.Load())
+}
+
+func TestExecute_JWKSUnavailable(t *testing.T) {
+	t.Parallel()
+
+	key := newRSAKey(t, "k1")
+	p := newIDP(t, key)
+	p.failJWKS.Store(true)
+	e := newTestExecutor(t, clock.NewTestClock(time.Now()), p)
+
+	//nolint:exhaustruct
+	cfg := &frontlinev1.JWTAuth{
+		JwksSource: &frontlinev1.JWTAuth_JwksUri{JwksUri: p.server.URL + "/jwks.json"},
+	}
+	token := key.sign(t, map[string]any{"sub": "u"})
+
+	_, err := e.Execute(context.Background(), nil, bearerRequest(token), cfg)
+	requireCode(t, err, codes.Frontline.Auth.JWKSUnavailable.URN())
+
+	// Cold-start failures are not throttled, so recovery is immediate.
+	p.failJWKS.Store(false)
+	_, err = e.Execute(context.Background(), nil, bearerRequest(token), cfg)
+	require.NoError(t, err)
+}
+
+func TestExecute_OIDCDiscovery(t *testing.T) {
+	t.Parallel()
+
+	key := newECKey(t, "k1")
+	p := newIDP(t, key)
+	clk := clock.NewTestClock(time.Now())
+	e := newTestExecutor(t, clk, p)
+
+	//nolint:exhaustruct
+	cfg := &frontlinev1.JWTAuth{
+		JwksSource: &frontlinev1.JWTAuth_OidcIssuer{OidcIssuer: p.server.URL},
+		Algorithms: []string{"ES256"},
+	}
+
+	// Without an explicit issuer the discovered issuer is required.
+	token := key.sign(t, map[string]any{"sub": "u", "iss": p.server.URL})
+	_, err := e.Execute(context.Background(), nil, bearerRequest(token), cfg)
+	require.NoError(t, err)
+
+	token = key.sign(t, map[string]any{"sub": "u", "iss": "https://other.example.com"})
+	_, err = e.Execute(context.Background(), nil, bearerRequest(token), cfg)
+	requireCode(t, err, codes.Frontline.Auth.InvalidToken.URN())
+}
+
+func TestExecute_OIDCIssuerMismatch(t *testing.T) {
+	t.Parallel()
+
+	key := newECKey(t, "k1")
+	p := newIDP(t, key)
+	p.issuer = "https://attacker.example.com"
+	e := newTestExecutor(t, clock.NewTestClock(time.Now()), p)
+
+	//nolint:exhaustruct
+	cfg := &frontlinev1.JWTAuth{
+		JwksSource: &frontlinev1.JWTAuth_OidcIssuer{OidcIssuer: p.server.URL},
+		Algorithms: []string{"ES256"},
+	}
+
+	token := key.sign(t, map[string]any{"sub": "u", "iss": p.server.URL})
+	_, err := e.Execute(context.Background(), nil, bearerRequest(token), cfg)
+	requireCode(t, err, codes.Frontline.Auth.JWKSUnavailable.URN())
+	require.Equal(t, int64(0), p.jwksHits.Load())
+}
+
+func TestExecute_InvalidConfiguration(t *testing.T) {
+	t.Parallel()
+
+	rsaKey := newRSAKey(t, "")
+	e := newTestExecutor(t, clock.NewTestClock(time.Now()), nil)
+	token := rsaKey.sign(t, map[string]any{"sub": "u"})
+
+	tests := []struct {
+		name string
+		cfg  *frontlinev1.JWTAuth
+	}{
+		{name: "no key source", cfg: &frontlinev1.JWTAuth{}},
+		{name: "plain http jwks", cfg: &frontlinev1.JWTAuth{JwksSource: &frontlinev1.JWTAuth_JwksUri{JwksUri: "http://auth.example.com/jwks.json"}}},
+		{name: "relative oidc issuer", cfg: &frontlinev1.JWTAuth{JwksSource: &frontlinev1.JWTAuth_OidcIssuer{OidcIssuer: "auth.example.com"}}},
+		{name: "garbage pem", cfg: &frontlinev1.JWTAuth{JwksSource: &frontlinev1.JWTAuth_PublicKeyPem{PublicKeyPem: []byte("nope")}}},
+		{name: "pem type not allowed", cfg: &frontlinev1.JWTAuth{
+			JwksSource: &frontlinev1.JWTAuth_PublicKeyPem{PublicKeyPem: rsaKey.publicKeyPEM(t)},
+			Algorithms: []string{"ES256"},
+		}},
+		{name: "unsupported algorithm", cfg: &frontlinev1.JWTAuth{
+			JwksSource: &frontlinev1.JWTAuth_PublicKeyPem{PublicKeyPem: rsaKey.publicKeyPEM(t)},
+			Algorithms: []string{"RS256", "HS256"},
+		}},
+		{name: "negative clock skew", cfg: &frontlinev1.JWTAuth{
+			JwksSource:  &frontlinev1.JWTAuth_PublicKeyPem{PublicKeyPem: rsaKey.publicKeyPEM(t)},
+			ClockSkewMs: -1,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := e.Execute(context.Background(), nil, bearerRequest(token), tt.cfg)
+			requireCode(t, err, codes.Frontline.Internal.InvalidConfiguration.URN())
+		})
+	}
+}
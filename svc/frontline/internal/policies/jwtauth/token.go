@@ -0,0 +1,66 @@
+package jwtauth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// extractBearer extracts the token from "Authorization: Bearer <token>".
+func extractBearer(req *http.Request) string {
+	auth := req.Header.Get("Authorization")
+	if auth == "" {
+		return ""
+	}
+	const prefix = "Bearer "
+	if len(auth) > len(prefix) && strings.EqualFold(auth[:len(prefix)], prefix) {
+		return strings.TrimSpace(auth[len(prefix):])
+	}
+	return ""
+}
+
+// parsedToken holds the unverified parts of a compact JWT that are needed
+// before verification: the header selects the key and algorithm, and the
+// signature is carried onto the Principal.
+type parsedToken struct {
+	header    map[string]any
+	alg       string
+	kid       string
+	signature string
+}
+
+// parseToken decodes the header of a compact JWT without verifying it. The
+// result is only used to pick a verifier; nothing in it is trusted until the
+// signature has been checked.
+func parseToken(token string) (parsedToken, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return parsedToken{}, errors.New("token must have 3 parts")
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return parsedToken{}, fmt.Errorf("invalid header encoding: %w", err)
+	}
+
+	var header map[string]any
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return parsedToken{}, fmt.Errorf("invalid header JSON: %w", err)
+	}
+
+	alg, _ := header["alg"].(string)
+	if alg == "" {
+		return parsedToken{}, errors.New("header has no alg")
+	}
+	kid, _ := header["kid"].(string)
+
+	return parsedToken{
+		header:    header,
+		alg:       alg,
+		kid:       kid,
+		signature: parts[2],
+	}, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	frontlinev1 "github.com/unkeyed/unkey/gen/proto/frontline/v1"
 	"github.com/unkeyed/unkey/internal/services/keys"
+	"github.com/unkeyed/unkey/internal/services/usagelimiter"
 	"github.com/unkeyed/unkey/pkg/batch"
 	"github.com/unkeyed/unkey/pkg/clickhouse/schema"
 	"github.com/unkeyed/unkey/pkg/clock"
@@ -24,16 +25,19 @@ import (
 // Executor handles KeyAuth policy evaluation by wrapping the existing KeyService.
 type Executor struct {
 	keyService       keys.KeyService
+	usageLimiter     usagelimiter.Service
 	clock            clock.Clock
 	keyVerifications *batch.BatchProcessor[schema.KeyVerification]
 }
 
 // New creates a new KeyAuth policy executor. keyVerifications receives one
 // telemetry snapshot per request that produced a KeyVerifier, regardless of the
-// final outcome.
-func New(keyService keys.KeyService, clk clock.Clock, keyVerifications *batch.BatchProcessor[schema.KeyVerification]) *Executor {
+// final outcome. usageLimiter refunds unused reservations of response_credits
+// policies.
+func New(keyService keys.KeyService, usageLimiter usagelimiter.Service, clk clock.Clock, keyVerifications *batch.BatchProcessor[schema.KeyVerification]) *Executor {
 	return &Executor{
 		keyService:       keyService,
+		usageLimiter:     usageLimiter,
 		clock:            clk,
 		keyVerifications: keyVerifications,
 	}
@@ -41,16 +45,21 @@ func New(keyService keys.KeyService, clk clock.Clock, keyVerifications *batch.Ba
 
 // Execute evaluates a KeyAuth policy against the incoming request.
 // It extracts the API key, verifies it using KeyService, and returns a Principal on success.
+//
+// When the policy sets response_credits and the key has limited usage, the
+// reservation is deducted during verification and a non-nil Settlement is
+// returned. The caller must settle it once the upstream response completes;
+// the key verification is only recorded then.
 func (e *Executor) Execute(
 	ctx context.Context,
 	sess *zen.Session,
 	req *http.Request,
 	appID string,
 	cfg *frontlinev1.KeyAuth,
-) (*principal.Principal, error) {
+) (*principal.Principal, *Settlement, error) {
 	rawKey := extractKey(req, cfg.GetLocations())
 	if rawKey == "" {
-		return nil, fault.New("missing API key",
+		return nil, nil, fault.New("missing API key",
 			fault.Code(codes.Frontline.Auth.MissingCredentials.URN()),
 			fault.Internal("no API key found in request"),
 			fault.Public("Authentication required. Please provide a valid API key."),
@@ -60,7 +69,7 @@ func (e *Executor) Execute(
 	keyHash := hash.Sha256(rawKey)
 	verifier, err := e.keyService.Get(ctx, sess, keyHash)
 	if err != nil {
-		return nil, fault.Wrap(err,
+		return nil, nil, fault.Wrap(err,
 			fault.Code(codes.Frontline.Auth.InvalidKey.URN()),
 			fault.Internal("key lookup failed"),
 			fault.Public("Authentication failed. The provided API key is invalid."),
@@ -68,16 +77,22 @@ func (e *Executor) Execute(
 	}
 	// Capture the final verifier state (after any Verify-stage mutations) into
 	// the key_verifications stream regardless of which branch returns below.
+	// A pending settlement records it instead, once the cost is known.
+	var settlement *Settlement
 	defer func() {
 		verification := verifier.TelemetrySnapshot()
 		verification.AppID = appID
+		if settlement != nil {
+			settlement.verification = verification
+			return
+		}
 		e.keyVerifications.Buffer(verification)
 	}()
 
 	// Fail fast on states that verification cannot recover from (not found,
 	// disabled, expired, workspace disabled, etc.) before spending a credit.
 	if verifier.Status != keys.StatusValid {
-		return nil, fault.New("invalid API key",
+		return nil, nil, fault.New("invalid API key",
 			fault.Code(codes.Frontline.Auth.InvalidKey.URN()),
 			fault.Internal("key status: "+string(verifier.Status)),
 			fault.Public("Authentication failed. The provided API key is invalid."),
@@ -85,7 +100,7 @@ func (e *Executor) Execute(
 	}
 
 	if !keyspaceAllowed(verifier.Key.KeyAuthID, cfg.GetKeySpaceIds()) {
-		return nil, fault.New("key does not belong to expected key space",
+		return nil, nil, fault.New("key does not belong to expected key space",
 			fault.Code(codes.Frontline.Auth.InvalidKey.URN()),
 			fault.Internal(fmt.Sprintf("key belongs to key space %s, expected one of %s", verifier.Key.KeyAuthID, strings.Join(cfg.GetKeySpaceIds(), ","))),
 			fault.Public("Authentication failed. The provided API key is invalid."),
@@ -97,17 +112,32 @@ func (e *Executor) Execute(
 	// routes or gateways that only prove the key is valid before proxying).
 	credits := ptr.SafeDeref(cfg.Credits, 1)
 	if credits < 0 {
-		return nil, fault.New("negative credits cost in keyauth policy",
+		return nil, nil, fault.New("negative credits cost in keyauth policy",
 			fault.Code(codes.Frontline.Internal.InvalidConfiguration.URN()),
 			fault.Internal(fmt.Sprintf("negative credits cost: %d", credits)),
 			fault.Public("Service configuration error."),
 		)
 	}
+
+	// With response_credits the reservation is charged like a fixed cost and
+	// settled against the upstream's reported cost after proxying.
+	rc := cfg.GetResponseCredits()
+	if rc != nil {
+		if cfg.Credits != nil || rc.GetHeader() == "" || rc.GetReserve() < 1 {
+			return nil, nil, fault.New("invalid response credits in keyauth policy",
+				fault.Code(codes.Frontline.Internal.InvalidConfiguration.URN()),
+				fault.Internal(fmt.Sprintf("response credits need a header, a reserve of at least 1 and no credits; header=%q reserve=%d credits set=%t",
+					rc.GetHeader(), rc.GetReserve(), cfg.Credits != nil)),
+				fault.Public("Service configuration error."),
+			)
+		}
+		credits = rc.GetReserve()
+	}
 	verifyOpts := []keys.VerifyOption{keys.WithCredits(credits)}
 	if pq := cfg.GetPermissionQuery(); pq != "" {
 		query, err := rbac.ParseQuery(pq)
 		if err != nil {
-			return nil, fault.Wrap(err,
+			return nil, nil, fault.Wrap(err,
 				fault.Code(codes.Frontline.Internal.InvalidConfiguration.URN()),
 				fault.Internal("invalid permission query: "+pq),
 				fault.Public("Service configuration error."),
@@ -121,7 +151,7 @@ func (e *Executor) Execute(
 	}
 
 	if err := verifier.Verify(ctx, verifyOpts...); err != nil {
-		return nil, fault.Wrap(err,
+		return nil, nil, fault.Wrap(err,
 			fault.Code(codes.Frontline.Internal.InternalServerError.URN()),
 			fault.Internal("verification error"),
 			fault.Public("An internal error occurred during authentication."),
@@ -136,26 +166,26 @@ func (e *Executor) Execute(
 	case keys.StatusValid:
 		// OK
 	case keys.StatusInsufficientPermissions:
-		return nil, fault.New("insufficient permissions",
+		return nil, nil, fault.New("insufficient permissions",
 			fault.Code(codes.Frontline.Auth.InsufficientPermissions.URN()),
 			fault.Internal("key lacks required permissions"),
 			fault.Public("Access denied. The API key does not have the required permissions."),
 		)
 	case keys.StatusRateLimited:
-		return nil, fault.New("rate limited",
+		return nil, nil, fault.New("rate limited",
 			fault.Code(codes.Frontline.Auth.RateLimited.URN()),
 			fault.Internal("auto-applied rate limit exceeded"),
 			fault.Public("Rate limit exceeded. Please try again later."),
 		)
 	case keys.StatusUsageExceeded:
-		return nil, fault.New("usage exceeded",
+		return nil, nil, fault.New("usage exceeded",
 			fault.Code(codes.Frontline.Auth.UsageExceeded.URN()),
 			fault.Internal("usage limit exceeded"),
 			fault.Public("Usage limit exceeded. This API key has no remaining credits."),
 		)
 	case keys.StatusNotFound, keys.StatusDisabled, keys.StatusExpired,
 		keys.StatusForbidden, keys.StatusWorkspaceDisabled, keys.StatusWorkspaceNotFound:
-		return nil, fault.New("key verification failed",
+		return nil, nil, fault.New("key verification failed",
 			fault.Code(codes.Frontline.Auth.InvalidKey.URN()),
 			fault.Internal("post-verification status: "+string(verifier.Status)),
 			fault.Public("Authentication failed."),
@@ -164,13 +194,33 @@ func (e *Executor) Execute(
 
 	p, err := principal.KeyPrincipalFromVerifier(verifier)
 	if err != nil {
-		return nil, fault.Wrap(err,
+		return nil, nil, fault.Wrap(err,
 			fault.Code(codes.Frontline.Internal.InternalServerError.URN()),
 			fault.Internal("failed to build principal"),
 			fault.Public("An internal error occurred during authentication."),
 		)
 	}
-	return p, nil
+
+	// Unlimited and test-mode keys spent nothing, so there is nothing to
+	// settle.
+	keyLimited := verifier.Key.RemainingRequests.Valid
+	identityLimited := verifier.Key.IdentityRemainingCredits.Valid
+	if rc != nil && (keyLimited || identityLimited) && !verifier.IsTestMode() {
+		//nolint:exhaustruct // verification is filled in by the deferred snapshot
+		settlement = &Settlement{
+			usageLimiter:     e.usageLimiter,
+			keyVerifications: e.keyVerifications,
+			header:           rc.GetHeader(),
+			reserved:         credits,
+		}
+		if keyLimited {
+			settlement.keyID = verifier.Key.ID
+		}
+		if identityLimited {
+			settlement.identityID = verifier.Key.IdentityID.String
+		}
+	}
+	return p, settlement, nil
 }
 
 // toVerifyRatelimits converts the policy's rate limit selectors into the
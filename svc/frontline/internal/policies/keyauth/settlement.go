@@ -0,0 +1,114 @@
+package keyauth
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/unkeyed/unkey/internal/services/usagelimiter"
+	"github.com/unkeyed/unkey/pkg/batch"
+	"github.com/unkeyed/unkey/pkg/clickhouse/schema"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/pkg/prometheus/lazy"
+)
+
+var creditSettlementsTotal = lazy.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "unkey",
+		Subsystem: "frontline",
+		Name:      "keyauth_credit_settlements_total",
+		Help:      "Total number of response credit settlements by outcome.",
+	},
+	[]string{"outcome"},
+)
+
+// Settlement is the pending credit charge of a request whose KeyAuth policy
+// sets response_credits. The reservation has already been deducted from the
+// key and, for keys of an identity with a credit pool, from the pool; Settle
+// gives back whatever the upstream reports it did not use.
+type Settlement struct {
+	usageLimiter     usagelimiter.Service
+	keyVerifications *batch.BatchProcessor[schema.KeyVerification]
+
+	// verification is recorded on Settle so the key_verifications row carries
+	// the settled cost rather than the reservation.
+	verification schema.KeyVerification
+
+	// keyID is empty when only the identity pool was charged, identityID when
+	// the identity has no pool.
+	keyID      string
+	identityID string
+	header     string
+	reserved   int64
+}
+
+// Header returns the response header (or trailer) the upstream reports the
+// request's cost in.
+func (s *Settlement) Header() string {
+	return s.header
+}
+
+// Settle charges the key the cost in reported, the raw header value or ""
+// when the upstream sent none, and records the key verification. A missing
+// or malformed cost keeps the full reservation, and a cost above the
+// reservation is capped at it. Exactly one of Settle or Release must be
+// called.
+func (s *Settlement) Settle(ctx context.Context, reported string) {
+	cost, outcome := settledCost(reported, s.reserved)
+	s.finish(ctx, cost, outcome)
+}
+
+// Release refunds the whole reservation, for requests that were rejected or
+// answered before they reached the upstream, or that it never answered.
+func (s *Settlement) Release(ctx context.Context) {
+	s.finish(ctx, 0, "released")
+}
+
+func (s *Settlement) finish(ctx context.Context, cost int64, outcome string) {
+	creditSettlementsTotal.WithLabelValues(outcome).Inc()
+
+	if refund := s.reserved - cost; refund > 0 {
+		refunded := true
+		if s.keyID != "" {
+			refunded = s.refund(ctx, usagelimiter.UsageRequest{KeyID: s.keyID, IdentityID: "", Cost: refund})
+		}
+		if s.identityID != "" {
+			refunded = s.refund(ctx, usagelimiter.UsageRequest{KeyID: "", IdentityID: s.identityID, Cost: refund}) && refunded
+		}
+		if !refunded {
+			cost = s.reserved
+		}
+	}
+
+	s.verification.SpentCredits = cost
+	s.keyVerifications.Buffer(s.verification)
+}
+
+// refund gives back credits of one balance and reports whether it did.
+func (s *Settlement) refund(ctx context.Context, req usagelimiter.UsageRequest) bool {
+	if err := s.usageLimiter.Refund(ctx, req); err != nil {
+		// The balance keeps paying the reservation; never credit it twice by
+		// retrying here.
+		logger.Error("failed to refund reserved credits", "error", err, "keyID", req.KeyID, "identityID", req.IdentityID, "refund", req.Cost)
+		return false
+	}
+	return true
+}
+
+// settledCost parses the reported cost and returns what to charge out of
+// reserved, with the metric outcome label.
+func settledCost(reported string, reserved int64) (int64, string) {
+	if reported == "" {
+		return reserved, "missing"
+	}
+
+	cost, err := strconv.ParseInt(strings.TrimSpace(reported), 10, 64)
+	if err != nil || cost < 0 {
+		return reserved, "invalid"
+	}
+	if cost > reserved {
+		return reserved, "capped"
+	}
+	return cost, "reported"
+}
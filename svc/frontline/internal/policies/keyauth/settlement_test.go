@@ -0,0 +1,37 @@
+package keyauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettledCost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		reported    string
+		wantCost    int64
+		wantOutcome string
+	}{
+		{name: "missing", reported: "", wantCost: 100, wantOutcome: "missing"},
+		{name: "reported", reported: "42", wantCost: 42, wantOutcome: "reported"},
+		{name: "surrounding whitespace", reported: " 7 ", wantCost: 7, wantOutcome: "reported"},
+		{name: "zero", reported: "0", wantCost: 0, wantOutcome: "reported"},
+		{name: "equal to reservation", reported: "100", wantCost: 100, wantOutcome: "reported"},
+		{name: "above reservation", reported: "101", wantCost: 100, wantOutcome: "capped"},
+		{name: "negative", reported: "-5", wantCost: 100, wantOutcome: "invalid"},
+		{name: "not a number", reported: "lots", wantCost: 100, wantOutcome: "invalid"},
+		{name: "fractional", reported: "1.5", wantCost: 100, wantOutcome: "invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cost, outcome := settledCost(tt.reported, 100)
+			require.Equal(t, tt.wantCost, cost)
+			require.Equal(t, tt.wantOutcome, outcome)
+		})
+	}
+}
@@ -3,13 +3,38 @@ package policies
 import (
 	"fmt"
 	"net/http"
+	"net/netip"
 	"regexp"
 	"strings"
 	"sync"
 
 	frontlinev1 "github.com/unkeyed/unkey/gen/proto/frontline/v1"
+	"github.com/unkeyed/unkey/pkg/codes"
+	"github.com/unkeyed/unkey/pkg/fault"
+	"github.com/unkeyed/unkey/svc/frontline/internal/policies/principal"
 )
 
+// maxMatchDepth bounds how deeply not and any_of may nest. The API rejects
+// anything deeper than four levels; this only guards against configs that
+// bypassed it.
+const maxMatchDepth = 16
+
+// matchEnv carries the request properties match expressions need beyond the
+// *http.Request itself.
+type matchEnv struct {
+	// clientIP is the address RemoteIpMatch and CountryMatch test. Invalid
+	// when it could not be parsed, in which case neither matches.
+	clientIP netip.Addr
+
+	// principal is the principal set by an earlier authentication policy,
+	// or nil.
+	principal *principal.Principal
+
+	// countries resolves clientIP for CountryMatch. Nil when no GeoIP
+	// database is configured, which makes any CountryMatch an error.
+	countries CountryLookup
+}
+
 // regexCache caches compiled regular expressions to avoid recompilation.
 type regexCache struct {
 	mu    sync.RWMutex
@@ -44,9 +69,9 @@ func (rc *regexCache) get(pattern string) (*regexp.Regexp, error) {
 
 // matchesRequest evaluates all match expressions against the request.
 // All expressions must match (AND semantics). An empty list matches all requests.
-func matchesRequest(req *http.Request, exprs []*frontlinev1.MatchExpr, rc *regexCache) (bool, error) {
+func matchesRequest(req *http.Request, env matchEnv, exprs []*frontlinev1.MatchExpr, rc *regexCache) (bool, error) {
 	for _, expr := range exprs {
-		matched, err := evalMatchExpr(req, expr, rc)
+		matched, err := evalMatchExpr(req, env, expr, rc, 0)
 		if err != nil {
 			return false, err
 		}
@@ -57,10 +82,13 @@ func matchesRequest(req *http.Request, exprs []*frontlinev1.MatchExpr, rc *regex
 	return true, nil
 }
 
-func evalMatchExpr(req *http.Request, expr *frontlinev1.MatchExpr, rc *regexCache) (bool, error) {
+func evalMatchExpr(req *http.Request, env matchEnv, expr *frontlinev1.MatchExpr, rc *regexCache, depth int) (bool, error) {
 	if expr == nil {
 		return false, nil
 	}
+	if depth > maxMatchDepth {
+		return false, fmt.Errorf("match expressions nested deeper than %d levels", maxMatchDepth)
+	}
 	switch e := expr.GetExpr().(type) {
 	case *frontlinev1.MatchExpr_Path:
 		return evalPathMatch(req, e.Path, rc)
@@ -70,12 +98,137 @@ func evalMatchExpr(req *http.Request, expr *frontlinev1.MatchExpr, rc *regexCach
 		return evalHeaderMatch(req, e.Header, rc)
 	case *frontlinev1.MatchExpr_QueryParam:
 		return evalQueryParamMatch(req, e.QueryParam, rc)
+	case *frontlinev1.MatchExpr_RemoteIp:
+		return evalRemoteIPMatch(env.clientIP, e.RemoteIp)
+	case *frontlinev1.MatchExpr_Country:
+		return evalCountryMatch(env, e.Country)
+	case *frontlinev1.MatchExpr_Principal:
+		return evalPrincipalMatch(env.principal, e.Principal, rc)
+	case *frontlinev1.MatchExpr_Not:
+		if e.Not == nil || e.Not.GetExpr() == nil {
+			return false, nil
+		}
+		matched, err := evalMatchExpr(req, env, e.Not, rc, depth+1)
+		if err != nil {
+			return false, err
+		}
+		return !matched, nil
+	case *frontlinev1.MatchExpr_AnyOf:
+		for _, inner := range e.AnyOf.GetExprs() {
+			matched, err := evalMatchExpr(req, env, inner, rc, depth+1)
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				return true, nil
+			}
+		}
+		return false, nil
 	default:
 		return false, nil
 	}
 
 }
 
+// evalRemoteIPMatch checks if ip falls inside any of the listed networks.
+// Entries are CIDR prefixes or bare addresses. An invalid client IP never
+// matches; an invalid entry is a configuration error.
+func evalRemoteIPMatch(ip netip.Addr, rm *frontlinev1.RemoteIpMatch) (bool, error) {
+	if rm == nil {
+		return true, nil
+	}
+	if !ip.IsValid() {
+		return false, nil
+	}
+	ip = ip.Unmap().WithZone("")
+	for _, cidr := range rm.GetCidrs() {
+		prefix, err := parseNetwork(cidr)
+		if err != nil {
+			return false, err
+		}
+		if prefix.Contains(ip) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// parseNetwork parses a CIDR prefix or a bare address, which is treated as a
+// single-address prefix. IPv4-mapped IPv6 entries are unmapped to match the
+// unmapped client address.
+func parseNetwork(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid network %q: %w", s, err)
+		}
+		if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid network %q: %w", s, err)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// evalCountryMatch checks if the client IP resolves to any of the listed
+// countries. An unresolvable client IP never matches. Without a GeoIP
+// database the expression is an error rather than a non-match, since
+// not(country) would otherwise match every request.
+func evalCountryMatch(env matchEnv, cm *frontlinev1.CountryMatch) (bool, error) {
+	if cm == nil {
+		return true, nil
+	}
+	if env.countries == nil {
+		return false, fault.New("no geoip database configured",
+			fault.Code(codes.Frontline.Internal.InvalidConfiguration.URN()),
+			fault.Internal("country match evaluated without a geoip database"),
+			fault.Public("Country matching is not available in this region. Please contact support at support@unkey.com."),
+		)
+	}
+	if !env.clientIP.IsValid() {
+		return false, nil
+	}
+	country, ok := env.countries.Country(env.clientIP)
+	if !ok {
+		return false, nil
+	}
+	for _, c := range cm.GetCountries() {
+		if strings.EqualFold(country, c) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// evalPrincipalMatch tests a field of the principal. Without a principal it
+// never matches, not even for present=false, so a PrincipalMatch can only
+// apply to authenticated requests.
+func evalPrincipalMatch(p *principal.Principal, pm *frontlinev1.PrincipalMatch, rc *regexCache) (bool, error) {
+	if pm == nil {
+		return true, nil
+	}
+	if p == nil {
+		return false, nil
+	}
+	value, exists := p.LookupField(pm.GetField())
+	switch m := pm.GetMatch().(type) {
+	case *frontlinev1.PrincipalMatch_Present:
+		return m.Present == exists, nil
+	case *frontlinev1.PrincipalMatch_Value:
+		if !exists {
+			return false, nil
+		}
+		return evalStringMatch(value, m.Value, rc)
+	default:
+		return exists, nil
+	}
+}
+
 func evalPathMatch(req *http.Request, pm *frontlinev1.PathMatch, rc *regexCache) (bool, error) {
 	if pm == nil || pm.GetPath() == nil {
 		return true, nil
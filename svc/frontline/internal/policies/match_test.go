@@ -2,17 +2,19 @@ package policies
 
 import (
 	"net/http"
+	"net/netip"
 	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	frontlinev1 "github.com/unkeyed/unkey/gen/proto/frontline/v1"
+	"github.com/unkeyed/unkey/svc/frontline/internal/policies/principal"
 )
 
 func TestMatchesRequest_EmptyList(t *testing.T) {
 	t.Parallel()
 	req := &http.Request{Method: "GET", URL: &url.URL{Path: "/api"}, Header: http.Header{}}
-	matched, err := matchesRequest(req, nil, newRegexCache())
+	matched, err := matchesRequest(req, matchEnv{}, nil, newRegexCache())
 	require.NoError(t, err)
 	require.True(t, matched)
 }
@@ -29,7 +31,7 @@ func TestMatchesRequest_PathExact(t *testing.T) {
 		}}},
 	}
 
-	matched, err := matchesRequest(req, exprs, rc)
+	matched, err := matchesRequest(req, matchEnv{}, exprs, rc)
 	require.NoError(t, err)
 	require.True(t, matched)
 }
@@ -46,7 +48,7 @@ func TestMatchesRequest_PathExactMismatch(t *testing.T) {
 		}}},
 	}
 
-	matched, err := matchesRequest(req, exprs, rc)
+	matched, err := matchesRequest(req, matchEnv{}, exprs, rc)
 	require.NoError(t, err)
 	require.False(t, matched)
 }
@@ -63,7 +65,7 @@ func TestMatchesRequest_PathPrefix(t *testing.T) {
 		}}},
 	}
 
-	matched, err := matchesRequest(req, exprs, rc)
+	matched, err := matchesRequest(req, matchEnv{}, exprs, rc)
 	require.NoError(t, err)
 	require.True(t, matched)
 }
@@ -80,7 +82,7 @@ func TestMatchesRequest_PathRegex(t *testing.T) {
 		}}},
 	}
 
-	matched, err := matchesRequest(req, exprs, rc)
+	matched, err := matchesRequest(req, matchEnv{}, exprs, rc)
 	require.NoError(t, err)
 	require.True(t, matched)
 }
@@ -100,7 +102,7 @@ func TestMatchesRequest_PathCaseInsensitive(t *testing.T) {
 		}}},
 	}
 
-	matched, err := matchesRequest(req, exprs, rc)
+	matched, err := matchesRequest(req, matchEnv{}, exprs, rc)
 	require.NoError(t, err)
 	require.True(t, matched)
 }
@@ -132,7 +134,7 @@ func TestMatchesRequest_MethodMatch(t *testing.T) {
 				{Expr: &frontlinev1.MatchExpr_Method{Method: &frontlinev1.MethodMatch{Methods: tt.methods}}},
 			}
 
-			matched, err := matchesRequest(req, exprs, rc)
+			matched, err := matchesRequest(req, matchEnv{}, exprs, rc)
 			require.NoError(t, err)
 			require.Equal(t, tt.expected, matched)
 		})
@@ -154,7 +156,7 @@ func TestMatchesRequest_HeaderPresent(t *testing.T) {
 		}}},
 	}
 
-	matched, err := matchesRequest(req, exprs, rc)
+	matched, err := matchesRequest(req, matchEnv{}, exprs, rc)
 	require.NoError(t, err)
 	require.True(t, matched)
 }
@@ -173,7 +175,7 @@ func TestMatchesRequest_HeaderNotPresent(t *testing.T) {
 		}}},
 	}
 
-	matched, err := matchesRequest(req, exprs, rc)
+	matched, err := matchesRequest(req, matchEnv{}, exprs, rc)
 	require.NoError(t, err)
 	require.False(t, matched)
 }
@@ -195,7 +197,7 @@ func TestMatchesRequest_HeaderValue(t *testing.T) {
 		}}},
 	}
 
-	matched, err := matchesRequest(req, exprs, rc)
+	matched, err := matchesRequest(req, matchEnv{}, exprs, rc)
 	require.NoError(t, err)
 	require.True(t, matched)
 }
@@ -218,7 +220,7 @@ func TestMatchesRequest_QueryParamPresent(t *testing.T) {
 		}}},
 	}
 
-	matched, err := matchesRequest(req, exprs, rc)
+	matched, err := matchesRequest(req, matchEnv{}, exprs, rc)
 	require.NoError(t, err)
 	require.True(t, matched)
 }
@@ -243,7 +245,7 @@ func TestMatchesRequest_QueryParamValue(t *testing.T) {
 		}}},
 	}
 
-	matched, err := matchesRequest(req, exprs, rc)
+	matched, err := matchesRequest(req, matchEnv{}, exprs, rc)
 	require.NoError(t, err)
 	require.True(t, matched)
 }
@@ -263,7 +265,7 @@ func TestMatchesRequest_ANDSemantics(t *testing.T) {
 		{Expr: &frontlinev1.MatchExpr_Method{Method: &frontlinev1.MethodMatch{Methods: []string{"GET", "POST"}}}},
 	}
 
-	matched, err := matchesRequest(req, exprs, rc)
+	matched, err := matchesRequest(req, matchEnv{}, exprs, rc)
 	require.NoError(t, err)
 	require.False(t, matched)
 }
@@ -282,11 +284,202 @@ func TestMatchesRequest_ANDSemanticsAllMatch(t *testing.T) {
 		{Expr: &frontlinev1.MatchExpr_Method{Method: &frontlinev1.MethodMatch{Methods: []string{"GET", "POST"}}}},
 	}
 
-	matched, err := matchesRequest(req, exprs, rc)
+	matched, err := matchesRequest(req, matchEnv{}, exprs, rc)
 	require.NoError(t, err)
 	require.True(t, matched)
 }
 
+//nolint:exhaustruct
+func remoteIPExpr(cidrs ...string) *frontlinev1.MatchExpr {
+	return &frontlinev1.MatchExpr{Expr: &frontlinev1.MatchExpr_RemoteIp{RemoteIp: &frontlinev1.RemoteIpMatch{Cidrs: cidrs}}}
+}
+
+//nolint:exhaustruct
+func methodExpr(methods ...string) *frontlinev1.MatchExpr {
+	return &frontlinev1.MatchExpr{Expr: &frontlinev1.MatchExpr_Method{Method: &frontlinev1.MethodMatch{Methods: methods}}}
+}
+
+func TestMatchesRequest_RemoteIP(t *testing.T) {
+	t.Parallel()
+	rc := newRegexCache()
+	req := &http.Request{Method: "GET", URL: &url.URL{Path: "/"}, Header: http.Header{}}
+
+	tests := []struct {
+		name  string
+		ip    string
+		cidrs []string
+		want  bool
+	}{
+		{name: "inside v4 network", ip: "203.0.113.7", cidrs: []string{"203.0.113.0/24"}, want: true},
+		{name: "outside v4 network", ip: "203.0.114.7", cidrs: []string{"203.0.113.0/24"}, want: false},
+		{name: "any of several", ip: "2001:db8::1", cidrs: []string{"203.0.113.0/24", "2001:db8::/32"}, want: true},
+		{name: "bare address", ip: "198.51.100.1", cidrs: []string{"198.51.100.1"}, want: true},
+		{name: "bare address mismatch", ip: "198.51.100.2", cidrs: []string{"198.51.100.1"}, want: false},
+		{name: "mapped client compared as v4", ip: "::ffff:203.0.113.7", cidrs: []string{"203.0.113.0/24"}, want: true},
+		{name: "mapped entry compared as v4", ip: "203.0.113.7", cidrs: []string{"::ffff:203.0.113.0/120"}, want: true},
+		{name: "unparseable client ip", ip: "", cidrs: []string{"0.0.0.0/0"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var ip netip.Addr
+			if tt.ip != "" {
+				ip = netip.MustParseAddr(tt.ip)
+			}
+			//nolint:exhaustruct
+			matched, err := matchesRequest(req, matchEnv{clientIP: ip}, []*frontlinev1.MatchExpr{remoteIPExpr(tt.cidrs...)}, rc)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, matched)
+		})
+	}
+
+	t.Run("invalid entry is an error", func(t *testing.T) {
+		t.Parallel()
+		//nolint:exhaustruct
+		env := matchEnv{clientIP: netip.MustParseAddr("203.0.113.7")}
+		_, err := matchesRequest(req, env, []*frontlinev1.MatchExpr{remoteIPExpr("203.0.113.0/33")}, rc)
+		require.Error(t, err)
+	})
+}
+
+type staticCountries map[netip.Addr]string
+
+func (s staticCountries) Country(ip netip.Addr) (string, bool) {
+	c, ok := s[ip]
+	return c, ok
+}
+
+func TestMatchesRequest_Country(t *testing.T) {
+	t.Parallel()
+	rc := newRegexCache()
+	req := &http.Request{Method: "GET", URL: &url.URL{Path: "/"}, Header: http.Header{}}
+	known := netip.MustParseAddr("81.2.69.142")
+	countries := staticCountries{known: "GB"}
+
+	//nolint:exhaustruct
+	exprs := []*frontlinev1.MatchExpr{
+		{Expr: &frontlinev1.MatchExpr_Country{Country: &frontlinev1.CountryMatch{Countries: []string{"us", "gb"}}}},
+	}
+
+	//nolint:exhaustruct
+	matched, err := matchesRequest(req, matchEnv{clientIP: known, countries: countries}, exprs, rc)
+	require.NoError(t, err)
+	require.True(t, matched, "country codes compare case-insensitively")
+
+	//nolint:exhaustruct
+	matched, err = matchesRequest(req, matchEnv{clientIP: netip.MustParseAddr("192.0.2.1"), countries: countries}, exprs, rc)
+	require.NoError(t, err)
+	require.False(t, matched, "unresolved country does not match")
+
+	// Without a database, neither the match nor its negation may succeed.
+	//nolint:exhaustruct
+	_, err = matchesRequest(req, matchEnv{clientIP: known}, exprs, rc)
+	require.Error(t, err)
+	//nolint:exhaustruct
+	_, err = matchesRequest(req, matchEnv{clientIP: known}, []*frontlinev1.MatchExpr{{Expr: &frontlinev1.MatchExpr_Not{Not: exprs[0]}}}, rc)
+	require.Error(t, err)
+}
+
+func TestMatchesRequest_Principal(t *testing.T) {
+	t.Parallel()
+	rc := newRegexCache()
+	req := &http.Request{Method: "GET", URL: &url.URL{Path: "/"}, Header: http.Header{}}
+
+	p, err := principal.JWTPrincipalFromClaims(
+		map[string]any{"alg": "RS256"},
+		map[string]any{"sub": "user_1", "tier": "pro", "admin": true},
+		"sig", "sub",
+	)
+	require.NoError(t, err)
+
+	//nolint:exhaustruct
+	valueExpr := func(field string, sm *frontlinev1.StringMatch) *frontlinev1.MatchExpr {
+		return &frontlinev1.MatchExpr{Expr: &frontlinev1.MatchExpr_Principal{Principal: &frontlinev1.PrincipalMatch{
+			Field: field,
+			Match: &frontlinev1.PrincipalMatch_Value{Value: sm},
+		}}}
+	}
+	//nolint:exhaustruct
+	presentExpr := func(field string, present bool) *frontlinev1.MatchExpr {
+		return &frontlinev1.MatchExpr{Expr: &frontlinev1.MatchExpr_Principal{Principal: &frontlinev1.PrincipalMatch{
+			Field: field,
+			Match: &frontlinev1.PrincipalMatch_Present{Present: present},
+		}}}
+	}
+
+	tests := []struct {
+		name string
+		expr *frontlinev1.MatchExpr
+		p    *principal.Principal
+		want bool
+	}{
+		//nolint:exhaustruct
+		{name: "value exact", expr: valueExpr("source.jwt.payload.tier", &frontlinev1.StringMatch{Match: &frontlinev1.StringMatch_Exact{Exact: "pro"}}), p: p, want: true},
+		//nolint:exhaustruct
+		{name: "value mismatch", expr: valueExpr("source.jwt.payload.tier", &frontlinev1.StringMatch{Match: &frontlinev1.StringMatch_Exact{Exact: "free"}}), p: p, want: false},
+		//nolint:exhaustruct
+		{name: "boolean by json text", expr: valueExpr("source.jwt.payload.admin", &frontlinev1.StringMatch{Match: &frontlinev1.StringMatch_Exact{Exact: "true"}}), p: p, want: true},
+		{name: "present", expr: presentExpr("subject", true), p: p, want: true},
+		{name: "absent", expr: presentExpr("source.jwt.payload.org", false), p: p, want: true},
+		{name: "no principal", expr: presentExpr("source.jwt.payload.org", false), p: nil, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			//nolint:exhaustruct
+			matched, err := matchesRequest(req, matchEnv{principal: tt.p}, []*frontlinev1.MatchExpr{tt.expr}, rc)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, matched)
+		})
+	}
+}
+
+func TestMatchesRequest_Combinators(t *testing.T) {
+	t.Parallel()
+	rc := newRegexCache()
+	req := &http.Request{Method: "POST", URL: &url.URL{Path: "/"}, Header: http.Header{}}
+
+	//nolint:exhaustruct
+	not := func(e *frontlinev1.MatchExpr) *frontlinev1.MatchExpr {
+		return &frontlinev1.MatchExpr{Expr: &frontlinev1.MatchExpr_Not{Not: e}}
+	}
+	//nolint:exhaustruct
+	anyOf := func(exprs ...*frontlinev1.MatchExpr) *frontlinev1.MatchExpr {
+		return &frontlinev1.MatchExpr{Expr: &frontlinev1.MatchExpr_AnyOf{AnyOf: &frontlinev1.AnyOfMatch{Exprs: exprs}}}
+	}
+
+	tests := []struct {
+		name string
+		expr *frontlinev1.MatchExpr
+		want bool
+	}{
+		{name: "not inverts a match", expr: not(methodExpr("POST")), want: false},
+		{name: "not inverts a mismatch", expr: not(methodExpr("GET")), want: true},
+		{name: "any of with one match", expr: anyOf(methodExpr("GET"), methodExpr("POST")), want: true},
+		{name: "any of without match", expr: anyOf(methodExpr("GET"), methodExpr("PUT")), want: false},
+		{name: "empty any of", expr: anyOf(), want: false},
+		{name: "nested", expr: not(anyOf(methodExpr("GET"), not(methodExpr("POST")))), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			matched, err := matchesRequest(req, matchEnv{}, []*frontlinev1.MatchExpr{tt.expr}, rc)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, matched)
+		})
+	}
+
+	t.Run("too deep is an error", func(t *testing.T) {
+		t.Parallel()
+		expr := methodExpr("POST")
+		for range maxMatchDepth + 1 {
+			expr = not(expr)
+		}
+		_, err := matchesRequest(req, matchEnv{}, []*frontlinev1.MatchExpr{expr}, rc)
+		require.Error(t, err)
+	})
+}
+
 func TestRegexCache(t *testing.T) {
 	t.Parallel()
 	rc := newRegexCache()
@@ -30,9 +30,9 @@ var (
 	)
 
 	// firewallMatchesTotal counts individual Firewall policy matches,
-	// labeled by the specific policy id and the action applied. The MVP only
-	// emits action="deny", but the label is kept so added actions (log,
-	// challenge, ...) don't require a breaking metric change.
+	// labeled by the specific policy id and the action applied (deny, log,
+	// redirect, respond). Comparing log counts against deny counts is how a
+	// shadow-mode rule is vetted before it is enforced.
 	firewallMatchesTotal = lazy.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: "unkey",
@@ -80,9 +80,28 @@ func classifyKeyauthError(err error) string {
 	}
 }
 
+// classifyJwtauthError maps a jwtauth executor error to a metric result label.
+// An unreachable JWKS endpoint is an error rather than a denial: the token
+// was never judged.
+func classifyJwtauthError(err error) string {
+	urn, ok := fault.GetCode(err)
+	if !ok {
+		return "error"
+	}
+
+	//nolint:exhaustive
+	switch urn {
+	case codes.Frontline.Auth.MissingCredentials.URN(),
+		codes.Frontline.Auth.InvalidToken.URN():
+		return "denied"
+	default:
+		return "error"
+	}
+}
+
 // classifyFirewallError maps a firewall executor error to a metric result label.
-// The executor only returns an error for DENY; anything else is an unexpected
-// failure.
+// DENY is a denial; anything else, such as a misconfigured redirect, is an
+// error.
 func classifyFirewallError(err error) string {
 	urn, ok := fault.GetCode(err)
 	if !ok {
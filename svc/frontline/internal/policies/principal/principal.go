@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/unkeyed/unkey/internal/services/keys"
 	"github.com/unkeyed/unkey/pkg/ptr"
@@ -223,17 +224,75 @@ func KeyPrincipalFromVerifier(verifier *keys.KeyVerifier) (*Principal, error) {
 	}, nil
 }
 
+// JWTPrincipalFromClaims builds a JWT Principal from a token whose signature
+// and registered claims have already been verified. header and payload are
+// the decoded token segments and signature is the raw third segment.
+//
+// subjectClaim names the payload claim used as the Principal subject. Returns
+// an error when that claim is missing or not a non-empty string, because a
+// Principal without a subject would collapse every caller into one
+// ratelimit bucket downstream.
+func JWTPrincipalFromClaims(header, payload map[string]any, signature, subjectClaim string) (*Principal, error) {
+	subject, ok := payload[subjectClaim].(string)
+	if !ok || subject == "" {
+		return nil, fmt.Errorf("subject claim %q is missing or not a string", subjectClaim)
+	}
+
+	return &Principal{
+		Version:  PrincipalVersion,
+		Subject:  subject,
+		Type:     PrincipalTypeJWT,
+		Identity: nil,
+		Source: Source{
+			Key: nil,
+			JWT: &JWTSource{
+				Header:    header,
+				Payload:   payload,
+				Signature: signature,
+			},
+		},
+	}, nil
+}
+
 // ResolveField navigates a dotted JSON path (e.g. "source.key.meta.org_id")
 // through the serialized Principal and returns the string value at that path.
 // Returns empty string if the path does not exist or the value is not a string.
 func (p *Principal) ResolveField(path string) string {
+	s, _ := p.lookupField(path).(string)
+	return s
+}
+
+// LookupField is like [Principal.ResolveField] but also reports whether the
+// path exists, and renders numbers and booleans as their JSON text (e.g.
+// "42", "true"). Objects, arrays and null exist but render as "".
+func (p *Principal) LookupField(path string) (string, bool) {
+	v := p.lookupField(path)
+	switch v := v.(type) {
+	case missingField:
+		return "", false
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", true
+	}
+}
+
+// missingField is returned by lookupField when the path does not exist, to
+// tell it apart from an explicit JSON null.
+type missingField struct{}
+
+func (p *Principal) lookupField(path string) any {
 	b, err := json.Marshal(p)
 	if err != nil {
-		return ""
+		return missingField{}
 	}
 	var m map[string]any
 	if err := json.Unmarshal(b, &m); err != nil {
-		return ""
+		return missingField{}
 	}
 	var cur any = m
 	start := 0
@@ -242,17 +301,16 @@ func (p *Principal) ResolveField(path string) string {
 			seg := path[start:i]
 			mm, ok := cur.(map[string]any)
 			if !ok {
-				return ""
+				return missingField{}
 			}
 			cur, ok = mm[seg]
 			if !ok {
-				return ""
+				return missingField{}
 			}
 			start = i + 1
 		}
 	}
-	s, _ := cur.(string)
-	return s
+	return cur
 }
 
 func parseMetaString(meta sql.NullString) (map[string]any, error) {
@@ -148,3 +148,64 @@ func TestParseMetaString(t *testing.T) {
 		})
 	}
 }
+
+func TestJWTPrincipalFromClaims(t *testing.T) {
+	t.Parallel()
+
+	header := map[string]any{"alg": "RS256", "kid": "key-1"}
+
+	t.Run("uses configured subject claim", func(t *testing.T) {
+		t.Parallel()
+		payload := map[string]any{"sub": "user_1", "email": "a@example.com"}
+
+		p, err := JWTPrincipalFromClaims(header, payload, "sig", "email")
+		require.NoError(t, err)
+		require.Equal(t, "a@example.com", p.Subject)
+		require.Equal(t, PrincipalTypeJWT, p.Type)
+		require.Nil(t, p.Source.Key)
+		require.Equal(t, payload, p.Source.JWT.Payload)
+		require.Equal(t, "user_1", p.ResolveField("source.jwt.payload.sub"))
+	})
+
+	t.Run("missing subject claim is an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := JWTPrincipalFromClaims(header, map[string]any{"email": "a@example.com"}, "sig", "sub")
+		require.Error(t, err)
+	})
+
+	t.Run("non-string subject claim is an error", func(t *testing.T) {
+		t.Parallel()
+		_, err := JWTPrincipalFromClaims(header, map[string]any{"sub": float64(42)}, "sig", "sub")
+		require.Error(t, err)
+	})
+}
+
+func TestLookupField(t *testing.T) {
+	t.Parallel()
+
+	payload := map[string]any{"sub": "user_1", "admin": true, "tier": float64(3), "org": map[string]any{"id": "org_1"}, "nothing": nil}
+	p, err := JWTPrincipalFromClaims(map[string]any{"alg": "RS256"}, payload, "sig", "sub")
+	require.NoError(t, err)
+
+	tests := []struct {
+		path   string
+		want   string
+		exists bool
+	}{
+		{path: "subject", want: "user_1", exists: true},
+		{path: "source.jwt.payload.admin", want: "true", exists: true},
+		{path: "source.jwt.payload.tier", want: "3", exists: true},
+		{path: "source.jwt.payload.org.id", want: "org_1", exists: true},
+		{path: "source.jwt.payload.org", want: "", exists: true},
+		{path: "source.jwt.payload.nothing", want: "", exists: true},
+		{path: "source.jwt.payload.missing", want: "", exists: false},
+		{path: "subject.nested", want: "", exists: false},
+	}
+	for _, tt := range tests {
+		got, exists := p.LookupField(tt.path)
+		require.Equal(t, tt.exists, exists, tt.path)
+		require.Equal(t, tt.want, got, tt.path)
+	}
+
+	require.Empty(t, p.ResolveField("source.jwt.payload.tier"))
+}
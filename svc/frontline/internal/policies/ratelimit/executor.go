@@ -57,6 +57,8 @@ func (e *Executor) Execute(
 		Identifier:  identifier,
 		Limit:       cfg.GetLimit(),
 		Duration:    time.Duration(cfg.GetWindowMs()) * time.Millisecond,
+		Algorithm:   algorithm(cfg.GetAlgorithm()),
+		RefillRate:  cfg.GetRefillRate(),
 		Cost:        1,
 		Time:        time.Time{},
 	})
@@ -80,3 +82,18 @@ func (e *Executor) Execute(
 
 	return nil
 }
+
+// algorithm maps the policy's algorithm onto the ratelimit service.
+// Unspecified keeps policies stored before the field existed on the sliding
+// window they were created with.
+func algorithm(a frontlinev1.RateLimitAlgorithm) rl.Algorithm {
+	switch a {
+	case frontlinev1.RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_FIXED_WINDOW:
+		return rl.AlgorithmFixedWindow
+	case frontlinev1.RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_TOKEN_BUCKET:
+		return rl.AlgorithmTokenBucket
+	case frontlinev1.RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_UNSPECIFIED,
+		frontlinev1.RateLimitAlgorithm_RATE_LIMIT_ALGORITHM_SLIDING_WINDOW:
+	}
+	return rl.AlgorithmSlidingWindow
+}
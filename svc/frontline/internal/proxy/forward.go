@@ -3,6 +3,7 @@ package proxy
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -144,6 +145,10 @@ func (s *service) forward(ctx context.Context, sess *zen.Session, cfg forwardCon
 				},
 			})
 
+			if hasTracking && tracking.CreditsHeader != "" {
+				takeCredits(resp, tracking)
+			}
+
 			// Capture response body for logging via TeeReader.
 			// Streaming: bytes flow to the client while accumulating in responseBuf.
 			// Non-streaming: the proxy buffers internally, same result.
@@ -239,3 +244,39 @@ func serveWithAbortRecovery(h http.Handler, w http.ResponseWriter, r *http.Reque
 	}()
 	h.ServeHTTP(w, r)
 }
+
+// takeCredits records the credits the upstream reported and removes them from
+// resp, so the client never sees frontline's billing header. A trailer only
+// arrives once the body is drained, so the body is wrapped to take it out
+// before ReverseProxy copies trailers to the client.
+func takeCredits(resp *http.Response, tracking *RequestTracking) {
+	tracking.CreditsResponded = true
+	tracking.CreditsReported = resp.Header.Get(tracking.CreditsHeader)
+	resp.Header.Del(tracking.CreditsHeader)
+	// Also keeps ReverseProxy from announcing the trailer.
+	resp.Trailer.Del(tracking.CreditsHeader)
+	if resp.Body != nil && resp.StatusCode != http.StatusSwitchingProtocols {
+		resp.Body = &creditsTrailerBody{ReadCloser: resp.Body, resp: resp, tracking: tracking}
+	}
+}
+
+// creditsTrailerBody reads the credits trailer into tracking when the
+// upstream body is drained, which is when the transport fills in trailers,
+// and removes it so it is not forwarded to the client. A credits header sent
+// up front takes precedence.
+type creditsTrailerBody struct {
+	io.ReadCloser
+	resp     *http.Response
+	tracking *RequestTracking
+}
+
+func (b *creditsTrailerBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if errors.Is(err, io.EOF) {
+		if b.tracking.CreditsReported == "" {
+			b.tracking.CreditsReported = b.resp.Trailer.Get(b.tracking.CreditsHeader)
+		}
+		b.resp.Trailer.Del(b.tracking.CreditsHeader)
+	}
+	return n, err
+}
@@ -2,8 +2,11 @@ package proxy
 
 import (
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
 	"testing"
 )
 
@@ -66,3 +69,86 @@ func TestServeWithAbortRecovery_NoPanicPassthrough(t *testing.T) {
 		t.Fatalf("expected status %d, got %d", http.StatusTeapot, w.Code)
 	}
 }
+
+func TestTakeCredits_StripsHeaderAndTrailer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		upstream http.HandlerFunc
+	}{
+		{
+			name: "header",
+			upstream: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Unkey-Cost", "7")
+				_, _ = io.WriteString(w, "ok")
+			},
+		},
+		{
+			name: "announced trailer",
+			upstream: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Trailer", "X-Unkey-Cost")
+				_, _ = io.WriteString(w, "ok")
+				w.Header().Set("X-Unkey-Cost", "7")
+			},
+		},
+		{
+			name: "unannounced trailer",
+			upstream: func(w http.ResponseWriter, r *http.Request) {
+				// Flushing forces a chunked body, which can carry trailers
+				// that were not announced up front.
+				_, _ = io.WriteString(w, "ok")
+				w.(http.Flusher).Flush()
+				w.Header().Set(http.TrailerPrefix+"X-Unkey-Cost", "7")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			upstream := httptest.NewServer(tt.upstream)
+			defer upstream.Close()
+			target, err := url.Parse(upstream.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tracking := &RequestTracking{CreditsHeader: "X-Unkey-Cost"} //nolint:exhaustruct
+			rp := httputil.NewSingleHostReverseProxy(target)
+			rp.ModifyResponse = func(resp *http.Response) error {
+				takeCredits(resp, tracking)
+				return nil
+			}
+			front := httptest.NewServer(rp)
+			defer front.Close()
+
+			resp, err := http.Get(front.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(body) != "ok" {
+				t.Fatalf("expected body %q, got %q", "ok", body)
+			}
+			if !tracking.CreditsResponded {
+				t.Fatal("expected the response to be recorded")
+			}
+			if tracking.CreditsReported != "7" {
+				t.Fatalf("expected credits %q, got %q", "7", tracking.CreditsReported)
+			}
+			if v := resp.Header.Get("X-Unkey-Cost"); v != "" {
+				t.Fatalf("credits header reached the client: %q", v)
+			}
+			if v := resp.Trailer.Get("X-Unkey-Cost"); v != "" {
+				t.Fatalf("credits trailer reached the client: %q", v)
+			}
+		})
+	}
+}
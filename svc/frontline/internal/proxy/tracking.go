@@ -51,6 +51,20 @@ type RequestTracking struct {
 	RedactedQueryParams []string
 	BodyRedactors       []*redaction.Redactor
 
+	// Ids of Firewall policies with ACTION_LOG that matched the request, set
+	// by the handler from the policy engine result.
+	FirewallMatches []string
+
+	// Response header (or trailer) a KeyAuth policy with response credits
+	// reads the request's cost from, set by the handler. CreditsReported is
+	// its value on the served response, set by the proxy once the stream
+	// completes; empty when the upstream sent neither. CreditsResponded is
+	// set by the proxy as soon as the upstream's response arrives, so the
+	// handler can tell a response without the header from no response.
+	CreditsHeader    string
+	CreditsReported  string
+	CreditsResponded bool
+
 	// Reset by the handler before each ForwardToInstance attempt.
 	InstanceID string
 	Address    string
@@ -123,6 +123,7 @@ func WithClickHouseLogging(buf *batch.BatchProcessor[schema.FrontlineRequest], c
 				TotalLatency:    totalLatency,
 				InstanceLatency: instanceLatency,
 				GatewayLatency:  gatewayLatency,
+				FirewallMatches: tracking.FirewallMatches,
 			})
 
 			return err
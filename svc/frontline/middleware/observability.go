@@ -262,11 +262,13 @@ func getErrorPageInfoFrontline(urn codes.URN) errorPageInfo {
 	// Engine errors — keyauth / firewall denials produced in-process.
 	// Status comes from the code's HTTP semantics (CategoryUnauthorized → 401,
 	// CategoryForbidden → 403, CategoryRateLimited → 429), not from upstream.
+	// MissingCredentials is shared by KeyAuth and JWTAuth, so the message
+	// comes from the fault to name the credential the policy expected.
 	case codes.Frontline.Auth.MissingCredentials.URN():
 		return errorPageInfo{
 			Status:  http.StatusUnauthorized,
 			Title:   http.StatusText(http.StatusUnauthorized),
-			Message: "Authentication required. Please provide a valid API key.",
+			Message: "",
 		}
 	case codes.Frontline.Auth.InvalidKey.URN():
 		return errorPageInfo{
@@ -274,6 +276,18 @@ func getErrorPageInfoFrontline(urn codes.URN) errorPageInfo {
 			Title:   http.StatusText(http.StatusUnauthorized),
 			Message: "Authentication failed. The provided API key is invalid.",
 		}
+	case codes.Frontline.Auth.InvalidToken.URN():
+		return errorPageInfo{
+			Status:  http.StatusUnauthorized,
+			Title:   http.StatusText(http.StatusUnauthorized),
+			Message: "Authentication failed. The provided token is invalid.",
+		}
+	case codes.Frontline.Auth.JWKSUnavailable.URN():
+		return errorPageInfo{
+			Status:  http.StatusServiceUnavailable,
+			Title:   http.StatusText(http.StatusServiceUnavailable),
+			Message: "Unable to verify the bearer token right now. Please try again later.",
+		}
 	case codes.Frontline.Auth.InsufficientPermissions.URN():
 		return errorPageInfo{
 			Status:  http.StatusForbidden,
@@ -11,6 +11,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/logger"
 	"github.com/unkeyed/unkey/pkg/zen"
 	"github.com/unkeyed/unkey/svc/frontline/internal/policies"
+	"github.com/unkeyed/unkey/svc/frontline/internal/policies/keyauth"
 	"github.com/unkeyed/unkey/svc/frontline/internal/proxy"
 	"github.com/unkeyed/unkey/svc/frontline/internal/router"
 )
@@ -83,6 +84,7 @@ func (h *Handler) Handle(ctx context.Context, sess *zen.Session) error {
 	// logging policy that matches the request turns those on. Without one,
 	// body capture below is skipped entirely and the row carries no headers
 	// or bodies.
+	var settlement *keyauth.Settlement
 	if len(decision.Policies) > 0 && h.Engine != nil {
 		result, evalErr := h.Engine.Evaluate(ctx, sess, req, decision.WorkspaceID, decision.AppID, decision.Policies)
 		if evalErr != nil {
@@ -94,6 +96,37 @@ func (h *Handler) Handle(ctx context.Context, sess *zen.Session) error {
 		tracking.LogResponseBody = result.LogResponseBody
 		tracking.LogQuery = result.LogQuery
 		tracking.BodyRedactors = result.BodyRedactors
+		tracking.FirewallMatches = result.FirewallMatches
+		settlement = result.CreditSettlement
+
+		// A Firewall policy with ACTION_REDIRECT or ACTION_RESPOND answers
+		// the request at the edge; the upstream is never invoked.
+		if result.Response != nil {
+			if settlement != nil {
+				settlement.Release(context.WithoutCancel(ctx))
+			}
+			return result.Response.Write(sess)
+		}
+
+		// KeyAuth reserved credits for this request; charge what the
+		// upstream reports once the response has been streamed. A request
+		// the upstream never answered, e.g. because every instance failed
+		// to dial, is refunded in full. The request context may already be
+		// cancelled by then.
+		if settlement != nil {
+			tracking.CreditsHeader = settlement.Header()
+			defer func() {
+				if settlement == nil {
+					return
+				}
+				if tracking.CreditsResponded {
+					settlement.Settle(context.WithoutCancel(ctx), tracking.CreditsReported)
+				} else {
+					settlement.Release(context.WithoutCancel(ctx))
+				}
+			}()
+		}
+
 		if result.Principal != nil {
 			principalJSON, serErr := result.Principal.Marshal()
 			if serErr != nil {
@@ -175,6 +208,12 @@ func (h *Handler) Handle(ctx context.Context, sess *zen.Session) error {
 	// routing and retry. Without a standby, surface the last dial error.
 	if decision.RemoteRegionAddress != "" {
 		regionFallbacksTotal.WithLabelValues(decision.RemoteRegionAddress).Inc()
+		// The peer evaluates the policies again and takes its own
+		// reservation, so give this one back.
+		if settlement != nil {
+			settlement.Release(context.WithoutCancel(ctx))
+			settlement = nil
+		}
 		return h.ProxyService.ForwardToRegion(ctx, sess, decision.RemoteRegionAddress)
 	}
 
@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"net/netip"
 	"time"
 
 	"connectrpc.com/connect"
@@ -30,6 +31,7 @@ import (
 	"github.com/unkeyed/unkey/pkg/clock"
 	"github.com/unkeyed/unkey/pkg/counter"
 	pkgdb "github.com/unkeyed/unkey/pkg/db"
+	"github.com/unkeyed/unkey/pkg/geoip"
 	"github.com/unkeyed/unkey/pkg/logger"
 	"github.com/unkeyed/unkey/pkg/mysql/sqlcomment"
 	"github.com/unkeyed/unkey/pkg/otel"
@@ -235,6 +237,7 @@ func Run(ctx context.Context, cfg Config) error {
 			Consumers:     cfg.ClickHouse.Consumers,
 			Drop:          true,
 			OnFlushError:  nil,
+			Spool:         nil,
 		})
 		keyVerifications = clickhouse.NewBuffer[schema.KeyVerification](chClient, clickhouse.BufferConfig{
 			Name:          "key_verifications",
@@ -244,6 +247,7 @@ func Run(ctx context.Context, cfg Config) error {
 			Consumers:     cfg.ClickHouse.Consumers,
 			Drop:          true,
 			OnFlushError:  nil,
+			Spool:         nil,
 		})
 
 		// Close buffers before the connection (LIFO).
@@ -301,7 +305,12 @@ func Run(ctx context.Context, cfg Config) error {
 		return fmt.Errorf("unable to create proxy service: %w", err)
 	}
 
-	policyEngine, err := buildEngine(r, engineDatabase, cfg.Redis.URL, cfg.Region, keyVerifications, clk)
+	trustedProxies, err := cfg.trustedProxies()
+	if err != nil {
+		return err
+	}
+
+	policyEngine, err := buildEngine(r, engineDatabase, cfg.Redis.URL, cfg.GeoIP.DatabasePath, trustedProxies, cfg.Region, keyVerifications, clk)
 	if err != nil {
 		return fmt.Errorf("unable to build policy engine: %w", err)
 	}
@@ -422,11 +431,15 @@ func Run(ctx context.Context, cfg Config) error {
 
 // buildEngine wires the engine's backing services. Redis is optional — when
 // no URL is configured the counter falls back to in-memory. Rate limits in
-// that mode are per-replica; distributed enforcement requires Redis.
+// that mode are per-replica; distributed enforcement requires Redis. The
+// GeoIP database is optional too, but a configured path that cannot be
+// loaded fails startup rather than silently disabling country matching.
 func buildEngine(
 	r *runner.Runner,
 	database pkgdb.Database,
 	redisURL string,
+	geoipPath string,
+	trustedProxies []netip.Prefix,
 	region string,
 	keyVerifications *batch.BatchProcessor[schema.KeyVerification],
 	clk clock.Clock,
@@ -474,6 +487,36 @@ func buildEngine(
 				Credits: sql.NullInt64{Int64: cost, Valid: true},
 			})
 		},
+		IncrementKeyCredits: func(ctx context.Context, keyID string, credits int64) error {
+			return pkgdb.Query.UpdateKeyCreditsIncrement(ctx, database.RW(), pkgdb.UpdateKeyCreditsIncrementParams{
+				ID:      keyID,
+				Credits: sql.NullInt64{Int64: credits, Valid: true},
+			})
+		},
+		FindIdentityCredits: func(ctx context.Context, identityID string) (int64, bool, error) {
+			credits, err := pkgdb.WithRetryContext(ctx, func() (pkgdb.IdentityCredit, error) {
+				return pkgdb.Query.FindIdentityCredits(ctx, database.RO(), identityID)
+			})
+			if pkgdb.IsNotFound(err) {
+				return 0, false, nil
+			}
+			if err != nil {
+				return 0, false, err
+			}
+			return int64(credits.Remaining), true, nil // nolint:gosec // balances never approach MaxInt64
+		},
+		DecrementIdentityCredits: func(ctx context.Context, identityID string, cost int64) error {
+			return pkgdb.Query.UpdateIdentityCreditsDecrement(ctx, database.RW(), pkgdb.UpdateIdentityCreditsDecrementParams{
+				IdentityID: identityID,
+				Credits:    uint64(cost), // nolint:gosec // the usage limiter never passes a negative cost
+			})
+		},
+		IncrementIdentityCredits: func(ctx context.Context, identityID string, credits int64) error {
+			return pkgdb.Query.UpdateIdentityCreditsIncrement(ctx, database.RW(), pkgdb.UpdateIdentityCreditsIncrementParams{
+				IdentityID: identityID,
+				Credits:    uint64(credits), // nolint:gosec // refunds are never negative
+			})
+		},
 		Counter:       ctr,
 		TTL:           60 * time.Second,
 		ReplayWorkers: 8,
@@ -507,12 +550,25 @@ func buildEngine(
 		return nil, fmt.Errorf("failed to create key service: %w", err)
 	}
 
+	var countries policies.CountryLookup
+	if geoipPath != "" {
+		db, err := geoip.Open(geoipPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load geoip database: %w", err)
+		}
+		logger.Info("geoip database loaded", "path", geoipPath, "type", db.DatabaseType())
+		countries = db
+	}
+
 	logger.Info("policy engine initialized")
 	eng, err := policies.New(policies.Config{
 		KeyService:       keyService,
 		RateLimiter:      rlSvc,
+		UsageLimiter:     usageLimiter,
 		Clock:            clk,
 		KeyVerifications: keyVerifications,
+		Countries:        countries,
+		TrustedProxies:   trustedProxies,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize policy engine: %w", err)
@@ -22,6 +22,7 @@ import (
 // tick (we never block a cgroup read on an API call).
 type podInfo struct {
 	name          string
+	namespace     string
 	uid           types.UID
 	qosClass      corev1.PodQOSClass
 	workspaceID   string
@@ -46,6 +47,9 @@ type podInfo struct {
 	// with this exact image"  — without needing a join to live pod state.
 	image   string
 	imageID string
+	// claims are the pod's PersistentVolumeClaim names, resolved to krane
+	// persistent volumes through the claim lister at read time.
+	claims []string
 }
 
 // collect runs one tick: list krane pods on this node, read their cgroup
@@ -107,11 +111,16 @@ func (c *Collector) collect(_ context.Context) error {
 		// check, but with both cpu and memory disabled for a deployment that cgroup
 		// read is skipped and nothing was left to stop disk accruing forever. Gate
 		// it on the same phase check network uses.
+		//
+		// Persistent volumes follow the same gate. They are resolved first so
+		// the ephemeral read can skip their mounts.
 		var diskUsed, diskAllocated int64
+		var volumes []persistentVolume
 		if c.collectors.Disk && info.phase == corev1.PodRunning {
+			volumes = c.persistentVolumes(info)
 			diskAllocated = info.diskAllocatedBytes
 			if c.kubeletRoot != "" && info.diskAllocatedBytes > 0 {
-				diskUsed = readEphemeralUsedBytes(c.kubeletRoot, info.uid)
+				diskUsed = readEphemeralUsedBytes(c.kubeletRoot, info.uid, persistentVolumeNames(volumes))
 			}
 		}
 
@@ -169,6 +178,8 @@ func (c *Collector) collect(_ context.Context) error {
 		})
 		metrics.CheckpointsWritten.Inc()
 		written++
+
+		c.emitVolumeCheckpoints(info, volumes, now)
 	}
 
 	metrics.KranePods.Set(float64(len(pods)))
@@ -243,6 +254,7 @@ func buildPodInfo(pod *corev1.Pod) podInfo {
 	image, imageID := primaryContainerImage(pod)
 	return podInfo{
 		name:                   pod.Name,
+		namespace:              pod.Namespace,
 		uid:                    pod.UID,
 		qosClass:               pod.Status.QOSClass,
 		workspaceID:            pod.Labels[LabelWorkspace],
@@ -261,6 +273,7 @@ func buildPodInfo(pod *corev1.Pod) podInfo {
 		diskAllocatedBytes:     ephemeralStorageBytes(pod),
 		image:                  image,
 		imageID:                imageID,
+		claims:                 persistentVolumeClaims(pod),
 	}
 }
 
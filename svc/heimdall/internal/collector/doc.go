@@ -3,4 +3,8 @@
 // carry raw kernel counter values (cpu.stat:usage_usec, memory.current) and
 // the allocated PVC size. All billing math is deferred to ClickHouse —
 // max(counter) - min(counter) over a window is monotone and replay-safe.
+//
+// Persistent volumes outlive their pods, so they are metered separately: one
+// volume checkpoint per mounted krane PersistentVolumeClaim per tick, keyed
+// by volume id instead of container.
 package collector
@@ -31,8 +31,14 @@ const (
 )
 
 type Config struct {
-	CH         *batch.BatchProcessor[schema.InstanceCheckpoint]
-	PodLister  corelisters.PodLister
+	CH        *batch.BatchProcessor[schema.InstanceCheckpoint]
+	PodLister corelisters.PodLister
+	// Volumes receives one row per persistent volume per tick. Nil disables
+	// persistent volume metering.
+	Volumes *batch.BatchProcessor[schema.VolumeCheckpoint]
+	// PVCLister resolves a pod's claims to krane persistent volumes. Only
+	// claims labelled with LabelVolume need to be in its cache.
+	PVCLister  corelisters.PersistentVolumeClaimLister
 	CgroupRoot string
 	// CgroupDriver controls pod-cgroup path shape. Must match kubelet's
 	// cgroupDriver. Detect via Preflight and pass the result through.
@@ -68,7 +74,9 @@ type Config struct {
 // to query time — collector output is raw counter values only.
 type Collector struct {
 	ch          *batch.BatchProcessor[schema.InstanceCheckpoint]
+	volumes     *batch.BatchProcessor[schema.VolumeCheckpoint]
 	podLister   corelisters.PodLister
+	pvcLister   corelisters.PersistentVolumeClaimLister
 	cgroup      *cgroupReader
 	network     network.Reader
 	collectors  CollectorSet
@@ -94,7 +102,9 @@ func New(cfg Config) *Collector {
 
 	return &Collector{
 		ch:          cfg.CH,
+		volumes:     cfg.Volumes,
 		podLister:   cfg.PodLister,
+		pvcLister:   cfg.PVCLister,
 		cgroup:      &cgroupReader{root: root, driver: cfg.CgroupDriver},
 		network:     cfg.Network,
 		collectors:  cfg.Collectors,
@@ -258,7 +268,8 @@ func (c *Collector) emitLifecycleCheckpoint(podUID, kind string) {
 	if c.collectors.Disk {
 		diskAllocated = info.diskAllocatedBytes
 		if c.kubeletRoot != "" && info.diskAllocatedBytes > 0 {
-			diskUsed = readEphemeralUsedBytes(c.kubeletRoot, pod.UID)
+			persistent := persistentVolumeNames(c.persistentVolumes(info))
+			diskUsed = readEphemeralUsedBytes(c.kubeletRoot, pod.UID, persistent)
 		}
 	}
 
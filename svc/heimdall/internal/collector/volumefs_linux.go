@@ -26,10 +26,14 @@ import (
 // every pod. Skipping them undercharges (safe direction) and avoids
 // accidentally billing the customer for the host's used bytes.
 //
+// Persistent volumes mounted by the pod are metered separately (see
+// readVolumeUsedBytes), so their PV names are passed in persistent and
+// skipped here.
+//
 // Path shape (set by kubelet; stable across K8s versions):
 //
 //	<kubeletRoot>/pods/<pod_uid>/volumes/<provider>/<volume_name>/mount
-func readEphemeralUsedBytes(kubeletRoot string, uid types.UID) int64 {
+func readEphemeralUsedBytes(kubeletRoot string, uid types.UID, persistent map[string]struct{}) int64 {
 	pattern := filepath.Join(kubeletRoot, "pods", string(uid), "volumes", "*", "*", "mount")
 	mounts, err := filepath.Glob(pattern)
 	if err != nil || len(mounts) == 0 {
@@ -46,6 +50,10 @@ func readEphemeralUsedBytes(kubeletRoot string, uid types.UID) int64 {
 
 	var total int64
 	for _, mount := range mounts {
+		if _, skip := persistent[filepath.Base(filepath.Dir(mount))]; skip {
+			continue
+		}
+
 		dev, ok := statDev(mount)
 		if !ok {
 			metrics.DiskReadErrors.WithLabelValues("stat_mount").Inc()
@@ -67,6 +75,41 @@ func readEphemeralUsedBytes(kubeletRoot string, uid types.UID) int64 {
 	return total
 }
 
+// readVolumeUsedBytes returns the used bytes of one persistent volume
+// mounted by the pod, identified by its PersistentVolume name. The same
+// bind-mount guard as readEphemeralUsedBytes applies. Returns (0, false) when
+// the mount is missing or can't be read.
+//
+// Path shape:
+//
+//	<kubeletRoot>/pods/<pod_uid>/volumes/kubernetes.io~csi/<pv_name>/mount
+func readVolumeUsedBytes(kubeletRoot string, uid types.UID, pvName string) (int64, bool) {
+	mount := filepath.Join(kubeletRoot, "pods", string(uid), "volumes", "kubernetes.io~csi", pvName, "mount")
+
+	rootDev, ok := statDev(kubeletRoot)
+	if !ok {
+		metrics.DiskReadErrors.WithLabelValues("stat_root").Inc()
+		return 0, false
+	}
+
+	dev, ok := statDev(mount)
+	if !ok {
+		metrics.DiskReadErrors.WithLabelValues("stat_mount").Inc()
+		return 0, false
+	}
+	if dev == rootDev {
+		return 0, false
+	}
+
+	used, ok := statfsUsedBytes(mount)
+	if !ok {
+		metrics.DiskReadErrors.WithLabelValues("statfs").Inc()
+		return 0, false
+	}
+
+	return used, true
+}
+
 // statDev returns the device id for path, or (0, false) if stat fails.
 // Bind mounts preserve the source fs's Dev, so a Dev that differs from the
 // kubelet root identifies a real per-volume filesystem.
@@ -6,6 +6,11 @@ import "k8s.io/apimachinery/pkg/types"
 
 // readEphemeralUsedBytes is a no-op on non-Linux platforms (heimdall only
 // runs on Linux in production; this stub keeps tests building on macOS).
-func readEphemeralUsedBytes(_ string, _ types.UID) int64 {
+func readEphemeralUsedBytes(_ string, _ types.UID, _ map[string]struct{}) int64 {
 	return 0
 }
+
+// readVolumeUsedBytes is a no-op on non-Linux platforms.
+func readVolumeUsedBytes(_ string, _ types.UID, _ string) (int64, bool) {
+	return 0, false
+}
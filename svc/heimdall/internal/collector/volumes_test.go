@@ -0,0 +1,75 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func pvcLister(t *testing.T, claims ...*corev1.PersistentVolumeClaim) corelisters.PersistentVolumeClaimLister {
+	t.Helper()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, c := range claims {
+		require.NoError(t, indexer.Add(c))
+	}
+	return corelisters.NewPersistentVolumeClaimLister(indexer)
+}
+
+func claim(name, volumeID, pvName string) *corev1.PersistentVolumeClaim {
+	labels := map[string]string{}
+	if volumeID != "" {
+		labels[LabelVolume] = volumeID
+	}
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Labels: labels},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			VolumeName: pvName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}
+}
+
+func TestPersistentVolumes_ResolvesKraneClaims(t *testing.T) {
+	t.Parallel()
+	c := &Collector{pvcLister: pvcLister(t,
+		claim("vol-a", "vol_a", "pvc-a"),
+		// Not labelled by krane: never metered.
+		claim("foreign", "", "pvc-foreign"),
+		// Not bound yet: skipped until the next tick.
+		claim("vol-pending", "vol_pending", ""),
+	)}
+
+	vols := c.persistentVolumes(podInfo{
+		namespace: "ns",
+		claims:    []string{"vol-a", "foreign", "vol-pending", "vol-missing"},
+	})
+
+	require.Equal(t, []persistentVolume{{
+		volumeID:       "vol_a",
+		pvName:         "pvc-a",
+		allocatedBytes: 1 << 30,
+	}}, vols)
+	require.Equal(t, map[string]struct{}{"pvc-a": {}}, persistentVolumeNames(vols))
+}
+
+func TestPersistentVolumes_NilListerDisables(t *testing.T) {
+	t.Parallel()
+	c := &Collector{}
+	require.Nil(t, c.persistentVolumes(podInfo{namespace: "ns", claims: []string{"vol-a"}}))
+}
+
+func TestPersistentVolumeClaims(t *testing.T) {
+	t.Parallel()
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{
+		{Name: "data", VolumeSource: corev1.VolumeSource{Ephemeral: &corev1.EphemeralVolumeSource{}}},
+		{Name: "vol-a", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "vol-a"}}},
+	}}}
+	require.Equal(t, []string{"vol-a"}, persistentVolumeClaims(pod))
+}
@@ -132,6 +132,18 @@ var (
 		},
 	)
 
+	// VolumeCheckpointsWritten counts persistent volume rows buffered for
+	// write to ClickHouse. Tracked apart from CheckpointsWritten because the
+	// rows go to a separate table and buffer.
+	VolumeCheckpointsWritten = lazy.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "unkey",
+			Subsystem: "heimdall",
+			Name:      "volume_checkpoints_written_total",
+			Help:      "Total number of persistent volume checkpoint rows buffered for write to ClickHouse.",
+		},
+	)
+
 	// CollectionTicksOverrun counts ticks that took longer than the
 	// checkpoint interval. Replaces a skipped-tick counter that keyed on
 	// mutex contention and so could never fire, since repeat.Every drives
@@ -22,6 +22,7 @@ import (
 	"github.com/unkeyed/unkey/svc/heimdall/internal/metrics"
 	"github.com/unkeyed/unkey/svc/heimdall/internal/network"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -86,6 +87,21 @@ func Run(ctx context.Context, cfg Config) error {
 		FlushInterval: 5 * time.Second,
 		Consumers:     2,
 		OnFlushError:  nil,
+		Spool:         nil,
+	})
+
+	// Persistent volume rows go to their own table. Same backpressure
+	// reasoning as the instance buffer; the volume is far lower (one row per
+	// mounted volume per tick), so a single consumer keeps up.
+	volumeBuffer := clickhouse.NewBuffer[schema.VolumeCheckpoint](ch, clickhouse.BufferConfig{
+		Name:          "volume_checkpoints",
+		Drop:          false,
+		BatchSize:     10_000,
+		BufferSize:    50_000,
+		FlushInterval: 5 * time.Second,
+		Consumers:     1,
+		OnFlushError:  nil,
+		Spool:         nil,
 	})
 
 	k8sCfg, err := rest.InClusterConfig()
@@ -102,6 +118,15 @@ func Run(ctx context.Context, cfg Config) error {
 	podInformer := factory.Core().V1().Pods().Informer()
 	podLister := factory.Core().V1().Pods().Lister()
 
+	// Claims are only needed for krane's persistent volumes, so the claim
+	// informer watches just those instead of every PVC in the cluster.
+	pvcFactory := informers.NewSharedInformerFactoryWithOptions(clientset, 0,
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = collector.LabelManagedBy + "=krane," + collector.LabelComponent + "=volume"
+		}),
+	)
+	pvcLister := pvcFactory.Core().V1().PersistentVolumeClaims().Lister()
+
 	collectors := collector.CollectorSetFrom(cfg.Collectors)
 
 	// Network byte counters via tc eBPF on the pod-side eth0 inside each
@@ -129,6 +154,8 @@ func Run(ctx context.Context, cfg Config) error {
 	kc := collector.New(collector.Config{
 		CH:           checkpointBuffer,
 		PodLister:    podLister,
+		Volumes:      volumeBuffer,
+		PVCLister:    pvcLister,
 		CgroupRoot:   "/sys/fs/cgroup",
 		CgroupDriver: cgroupDriver,
 		KubeletRoot:  cfg.KubeletRoot,
@@ -166,15 +193,18 @@ func Run(ctx context.Context, cfg Config) error {
 	}
 
 	factory.Start(ctx.Done())
+	pvcFactory.Start(ctx.Done())
 
 	// Refuse to start the collector with an unsynced cache. Without the
 	// check, a sync failure (ctx cancel before initial List completes, API
 	// server unreachable) would leave the lister returning empty results,
 	// every pod on the node would be silently unbilled.
-	synced := factory.WaitForCacheSync(ctx.Done())
-	for resource, ok := range synced {
-		if !ok {
-			return fmt.Errorf("informer cache sync failed for %v", resource)
+	for _, f := range []informers.SharedInformerFactory{factory, pvcFactory} {
+		synced := f.WaitForCacheSync(ctx.Done())
+		for resource, ok := range synced {
+			if !ok {
+				return fmt.Errorf("informer cache sync failed for %v", resource)
+			}
 		}
 	}
 
@@ -248,6 +278,7 @@ func Run(ctx context.Context, cfg Config) error {
 		}()
 
 		defer checkpointBuffer.Close()
+		defer volumeBuffer.Close()
 		defer func() {
 			if err := netReader.Close(); err != nil {
 				logger.Warn("network reader close failed", "error", err.Error())
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
 	"strconv"
 
 	ctrlv1 "github.com/unkeyed/unkey/gen/proto/ctrl/v1"
@@ -17,6 +18,7 @@ import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -45,6 +47,11 @@ import (
 // isolation (RuntimeClass "gvisor") since they execute untrusted user code,
 // and are scheduled on Karpenter-managed untrusted nodes with node- and
 // zone-spread constraints so replicas don't stack on a single node.
+//
+// Persistent volumes are applied as PersistentVolumeClaims before the
+// ReplicaSet. Like a StatefulSet with a Retain policy, the claims carry no
+// ownerReference: they outlive every ReplicaSet that mounts them and are only
+// removed by [Controller.DeleteVolume].
 func (c *Controller) ApplyDeployment(ctx context.Context, req *ctrlv1.ApplyDeployment) (retErr error) {
 	defer func() { metrics.RecordReconcile("deployment", "apply", retErr) }()
 	logger.Info("applying deployment",
@@ -71,10 +78,25 @@ func (c *Controller) ApplyDeployment(ctx context.Context, req *ctrlv1.ApplyDeplo
 		return err
 	}
 
+	for _, v := range req.GetVolumes() {
+		err = assert.All(
+			assert.NotEmpty(v.GetK8SName(), "Volume K8s name is required"),
+			assert.True(path.IsAbs(v.GetMountPath()), "Volume mount path must be absolute"),
+			assert.Greater(v.GetSizeMib(), int64(0), "Volume size must be greater than 0"),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	if err := c.ensureNamespaceExists(ctx, req.GetK8SNamespace()); err != nil {
 		return err
 	}
 
+	if err := c.ensurePersistentVolumeClaims(ctx, req); err != nil {
+		return fmt.Errorf("failed to ensure persistent volume claims: %w", err)
+	}
+
 	if err := c.ensureRegistryPullSecret(ctx, req.GetK8SNamespace()); err != nil {
 		return fmt.Errorf("failed to ensure registry pull secret: %w", err)
 	}
@@ -241,6 +263,23 @@ func (c *Controller) buildReplicaSet(req *ctrlv1.ApplyDeployment, hasSecrets boo
 		container.Env = append(container.Env, corev1.EnvVar{Name: "UNKEY_EPHEMERAL_DISK_PATH", Value: "/data"})
 	}
 
+	// Mount the app's persistent volumes. The pod volume is named after the
+	// claim, which is unique within the namespace.
+	for _, v := range req.GetVolumes() {
+		volumes = append(volumes, corev1.Volume{
+			Name: v.GetK8SName(),
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: v.GetK8SName(),
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      v.GetK8SName(),
+			MountPath: v.GetMountPath(),
+		})
+	}
+
 	// Configure healthcheck probes if provided
 	if hc := unmarshalHealthcheck(req.GetHealthcheck()); hc != nil {
 		handler := buildProbeHandler(hc, req.GetPort())
@@ -291,6 +330,10 @@ func (c *Controller) buildReplicaSet(req *ctrlv1.ApplyDeployment, hasSecrets boo
 		podSpec.Volumes = volumes
 	}
 
+	if len(req.GetVolumes()) > 0 {
+		podSpec.Affinity = persistentVolumeAffinity(req)
+	}
+
 	if hasSecrets {
 		podSpec.ServiceAccountName = deploymentResourcePrefix(req.GetDeploymentId())
 	}
@@ -361,12 +404,54 @@ func unmarshalHealthcheck(data []byte) *dbtype.Healthcheck {
 // ensureHPAExists creates or updates a HorizontalPodAutoscaler that scales the
 // deployment's ReplicaSet using the autoscaling policy from the control plane.
 // The HPA is owned by the ReplicaSet for automatic garbage collection.
+//
+// For request-rate scaled deployments krane leaves spec.minReplicas to the
+// request-rate autoscaler's field manager and only seeds it here, so a
+// resync never resets a floor the autoscaler has raised. See autoscale.go.
 func (c *Controller) ensureHPAExists(ctx context.Context, req *ctrlv1.ApplyDeployment, rs *appsv1.ReplicaSet) error {
 	client := c.clientSet.AutoscalingV2().HorizontalPodAutoscalers(req.GetK8SNamespace())
 
+	desired := buildHorizontalPodAutoscaler(req, rs)
+
+	patch, err := json.Marshal(desired)
+	if err != nil {
+		return fmt.Errorf("failed to marshal HPA: %w", err)
+	}
+
+	applied, err := client.Patch(ctx, req.GetK8SName(), types.ApplyPatchType, patch, metav1.PatchOptions{
+		FieldManager: fieldManagerKrane,
+	})
+	if k8serrors.IsConflict(err) && desired.Spec.MinReplicas != nil {
+		// The request-rate autoscaler still owns minReplicas from when the
+		// policy had an rps threshold. The policy no longer does, so krane
+		// takes the field back.
+		applied, err = client.Patch(ctx, req.GetK8SName(), types.ApplyPatchType, patch, metav1.PatchOptions{
+			FieldManager: fieldManagerKrane,
+			Force:        ptr.P(true),
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if desired.Spec.MinReplicas != nil {
+		return nil
+	}
+
+	minReplicas, maxReplicas := hpaReplicaBounds(req)
+	floor := clampReplicas(ptr.SafeDeref(applied.Spec.MinReplicas), minReplicas, maxReplicas)
+	return c.applyReplicaFloor(ctx, req.GetK8SNamespace(), req.GetK8SName(), floor)
+}
+
+// buildHorizontalPodAutoscaler renders the desired HPA for a deployment
+// request. It performs no I/O so the policy mapping is unit-testable.
+//
+// When the policy scales on request rate, spec.minReplicas is omitted and the
+// rps threshold and policy minimum are recorded as annotations for the
+// request-rate autoscaler to read.
+func buildHorizontalPodAutoscaler(req *ctrlv1.ApplyDeployment, rs *appsv1.ReplicaSet) *autoscalingv2.HorizontalPodAutoscaler {
 	policy := req.GetAutoscaling()
-	minReplicas := int32(max(policy.GetMinReplicas(), 1))
-	maxReplicas := max(int32(policy.GetMaxReplicas()), minReplicas)
+	minReplicas, maxReplicas := hpaReplicaBounds(req)
 	cpuThreshold := ptr.P(int32(defaultCPUTargetUtilization))
 
 	var metrics []autoscalingv2.MetricSpec
@@ -407,8 +492,18 @@ func (c *Controller) ensureHPAExists(ctx context.Context, req *ctrlv1.ApplyDeplo
 		},
 	)
 
+	var annotations map[string]string
+	hpaMinReplicas := ptr.P(minReplicas)
+	if scalesOnRequestRate(req) {
+		annotations = map[string]string{
+			annotationRPSThreshold:      strconv.Itoa(int(policy.GetRpsThreshold())),
+			annotationPolicyMinReplicas: strconv.Itoa(int(minReplicas)),
+		}
+		hpaMinReplicas = nil
+	}
+
 	//nolint:exhaustruct // k8s API types have many optional fields
-	desired := &autoscalingv2.HorizontalPodAutoscaler{
+	return &autoscalingv2.HorizontalPodAutoscaler{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "autoscaling/v2",
 			Kind:       "HorizontalPodAutoscaler",
@@ -417,6 +512,7 @@ func (c *Controller) ensureHPAExists(ctx context.Context, req *ctrlv1.ApplyDeplo
 			Name:            req.GetK8SName(),
 			Namespace:       req.GetK8SNamespace(),
 			Labels:          deploymentLabels(req),
+			Annotations:     annotations,
 			OwnerReferences: []metav1.OwnerReference{replicaSetOwnerRef(rs)},
 		},
 		//nolint:exhaustruct
@@ -426,7 +522,7 @@ func (c *Controller) ensureHPAExists(ctx context.Context, req *ctrlv1.ApplyDeplo
 				Kind:       "ReplicaSet",
 				Name:       req.GetK8SName(),
 			},
-			MinReplicas: ptr.P(minReplicas),
+			MinReplicas: hpaMinReplicas,
 			MaxReplicas: maxReplicas,
 			//nolint:exhaustruct
 			Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
@@ -438,16 +534,19 @@ func (c *Controller) ensureHPAExists(ctx context.Context, req *ctrlv1.ApplyDeplo
 			Metrics: metrics,
 		},
 	}
+}
 
-	patch, err := json.Marshal(desired)
-	if err != nil {
-		return fmt.Errorf("failed to marshal HPA: %w", err)
+// hpaReplicaBounds returns the HPA's min and max replicas for a deployment.
+// Persistent volumes are ReadWriteOnce, so a second replica could never attach
+// them on another node; deployments that mount any are pinned to one replica.
+func hpaReplicaBounds(req *ctrlv1.ApplyDeployment) (int32, int32) {
+	if len(req.GetVolumes()) > 0 {
+		return 1, 1
 	}
-
-	_, err = client.Patch(ctx, req.GetK8SName(), types.ApplyPatchType, patch, metav1.PatchOptions{
-		FieldManager: fieldManagerKrane,
-	})
-	return err
+	policy := req.GetAutoscaling()
+	minReplicas := int32(max(policy.GetMinReplicas(), 1))
+	maxReplicas := max(int32(policy.GetMaxReplicas()), minReplicas)
+	return minReplicas, maxReplicas
 }
 
 // ensurePodDisruptionBudget creates or updates a PodDisruptionBudget that caps
@@ -510,6 +609,68 @@ func buildPodDisruptionBudget(req *ctrlv1.ApplyDeployment, rs *appsv1.ReplicaSet
 	return pdb
 }
 
+// ensurePersistentVolumeClaims creates or updates the claims for the app's
+// persistent volumes. Existing claims keep their data; server-side apply only
+// touches the fields krane owns. A claim can grow but never shrink, so a
+// smaller size_mib is rejected by the API server and surfaces as an error.
+func (c *Controller) ensurePersistentVolumeClaims(ctx context.Context, req *ctrlv1.ApplyDeployment) error {
+	client := c.clientSet.CoreV1().PersistentVolumeClaims(req.GetK8SNamespace())
+
+	for _, v := range req.GetVolumes() {
+		desired := c.buildPersistentVolumeClaim(req, v)
+
+		patch, err := json.Marshal(desired)
+		if err != nil {
+			return fmt.Errorf("failed to marshal PVC: %w", err)
+		}
+
+		_, err = client.Patch(ctx, v.GetK8SName(), types.ApplyPatchType, patch, metav1.PatchOptions{
+			FieldManager: fieldManagerKrane,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply PVC %s: %w", v.GetK8SName(), err)
+		}
+	}
+
+	return nil
+}
+
+// buildPersistentVolumeClaim renders the claim for one persistent volume.
+// It is labelled with the app and environment rather than the deployment,
+// since every deployment of the app in that environment mounts it, and has no
+// owner so deleting a ReplicaSet never deletes the data.
+func (c *Controller) buildPersistentVolumeClaim(req *ctrlv1.ApplyDeployment, v *ctrlv1.VolumeMount) *corev1.PersistentVolumeClaim {
+	//nolint:exhaustruct
+	return &corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "PersistentVolumeClaim",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      v.GetK8SName(),
+			Namespace: req.GetK8SNamespace(),
+			Labels: labels.New().
+				WorkspaceID(req.GetWorkspaceId()).
+				ProjectID(req.GetProjectId()).
+				AppID(req.GetAppId()).
+				EnvironmentID(req.GetEnvironmentId()).
+				VolumeID(v.GetVolumeId()).
+				ManagedByKrane().
+				ComponentVolume(),
+		},
+		//nolint:exhaustruct
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: ptr.P(c.storageClassName),
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(fmt.Sprintf("%dMi", v.GetSizeMib())),
+				},
+			},
+		},
+	}
+}
+
 func deploymentLabels(req *ctrlv1.ApplyDeployment) labels.Labels {
 	return labels.New().
 		WorkspaceID(req.GetWorkspaceId()).
@@ -8,6 +8,7 @@ import (
 	ctrlv1 "github.com/unkeyed/unkey/gen/proto/ctrl/v1"
 	dbtype "github.com/unkeyed/unkey/pkg/db/types"
 	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/krane/pkg/labels"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -36,6 +37,10 @@ const (
 	testGitCommitMessage = "sentinel commit message"
 	testHealthcheckPath  = "/sentinel-healthz"
 	testEphemeralMib     = int64(2048)
+	testVolumeID         = "vol_sentinel"
+	testVolumeK8sName    = "vol-sentinel"
+	testVolumeMountPath  = "/var/lib/sentinel"
+	testVolumeMib        = int64(4096)
 )
 
 var testCommand = []string{"/sentinel-app", "serve", "--flag"}
@@ -81,6 +86,12 @@ func fullApplyRequest(t *testing.T) *ctrlv1.ApplyDeployment {
 		GitCommitMessage:              ptr.P(testGitCommitMessage),
 		Autoscaling:                   &ctrlv1.AutoscalingPolicy{MinReplicas: 2, MaxReplicas: 5},
 		EphemeralStorage:              &ctrlv1.EphemeralStorage{SizeMib: testEphemeralMib},
+		Volumes: []*ctrlv1.VolumeMount{{
+			VolumeId:  testVolumeID,
+			K8SName:   testVolumeK8sName,
+			MountPath: testVolumeMountPath,
+			SizeMib:   testVolumeMib,
+		}},
 	}
 }
 
@@ -240,6 +251,26 @@ var fieldAssertions = map[string]func(t *testing.T, rs *appsv1.ReplicaSet){
 		}
 		require.True(t, mounted, "ephemeral volume must be mounted at /data")
 	},
+	"volumes": func(t *testing.T, rs *appsv1.ReplicaSet) {
+		var found bool
+		for _, vol := range rs.Spec.Template.Spec.Volumes {
+			if vol.PersistentVolumeClaim != nil && vol.PersistentVolumeClaim.ClaimName == testVolumeK8sName {
+				found = true
+			}
+		}
+		require.True(t, found, "volumes must reference their PersistentVolumeClaim")
+		var mounted bool
+		for _, m := range mainContainer(t, rs).VolumeMounts {
+			if m.Name == testVolumeK8sName && m.MountPath == testVolumeMountPath {
+				mounted = true
+			}
+		}
+		require.True(t, mounted, "persistent volume must be mounted at its mount path")
+		affinity := rs.Spec.Template.Spec.Affinity
+		require.NotNil(t, affinity, "persistent volumes must pin pods to the node holding them")
+		require.NotNil(t, affinity.PodAffinity)
+		require.Len(t, affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution, 1)
+	},
 }
 
 // fieldsRenderedElsewhere lists proto fields that intentionally do not surface
@@ -317,3 +348,53 @@ func TestBuildReplicaSet_NoSecretsOmitsEnvFrom(t *testing.T) {
 	require.Empty(t, mainContainer(t, rs).EnvFrom)
 	require.Empty(t, rs.Spec.Template.Spec.ServiceAccountName)
 }
+
+// TestBuildReplicaSet_NoVolumesOmitsAffinity verifies that deployments without
+// persistent volumes schedule freely.
+func TestBuildReplicaSet_NoVolumesOmitsAffinity(t *testing.T) {
+	req := fullApplyRequest(t)
+	req.Volumes = nil
+
+	rs := testController().buildReplicaSet(req, true)
+	require.Nil(t, rs.Spec.Template.Spec.Affinity)
+	for _, vol := range rs.Spec.Template.Spec.Volumes {
+		require.Nil(t, vol.PersistentVolumeClaim)
+	}
+}
+
+// TestBuildPersistentVolumeClaim verifies the claim has no owner, so deleting
+// a ReplicaSet never deletes the data, and carries the labels heimdall uses to
+// attribute usage.
+func TestBuildPersistentVolumeClaim(t *testing.T) {
+	req := fullApplyRequest(t)
+
+	pvc := testController().buildPersistentVolumeClaim(req, req.GetVolumes()[0])
+
+	require.Equal(t, testVolumeK8sName, pvc.Name)
+	require.Equal(t, testNamespace, pvc.Namespace)
+	require.Empty(t, pvc.OwnerReferences)
+	require.Equal(t, testVolumeID, pvc.Labels[labels.LabelKeyVolumeID])
+	require.Equal(t, testAppID, pvc.Labels[labels.LabelKeyAppID])
+	require.Equal(t, testEnvironmentID, pvc.Labels[labels.LabelKeyEnvironmentID])
+	require.Equal(t, "volume", pvc.Labels[labels.LabelKeyComponent])
+	require.NotContains(t, pvc.Labels, labels.LabelKeyDeploymentID)
+	require.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, pvc.Spec.AccessModes)
+	require.Equal(t, "test-storage-class", *pvc.Spec.StorageClassName)
+	storage := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	require.Equal(t, testVolumeMib*1024*1024, storage.Value())
+}
+
+// TestHPAReplicaBounds verifies that persistent volumes pin a deployment to a
+// single replica regardless of its autoscaling policy.
+func TestHPAReplicaBounds(t *testing.T) {
+	req := fullApplyRequest(t)
+
+	minReplicas, maxReplicas := hpaReplicaBounds(req)
+	require.Equal(t, int32(1), minReplicas)
+	require.Equal(t, int32(1), maxReplicas)
+
+	req.Volumes = nil
+	minReplicas, maxReplicas = hpaReplicaBounds(req)
+	require.Equal(t, int32(2), minReplicas)
+	require.Equal(t, int32(5), maxReplicas)
+}
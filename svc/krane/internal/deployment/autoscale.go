@@ -0,0 +1,223 @@
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	ctrlv1 "github.com/unkeyed/unkey/gen/proto/ctrl/v1"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/pkg/repeat"
+	"github.com/unkeyed/unkey/svc/krane/pkg/labels"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// requestRateScaleInterval is how often the request-rate autoscaler
+// recomputes replica floors. Frontline's aggregates are per minute, so
+// polling faster would only re-read the same rate.
+const requestRateScaleInterval = 30 * time.Second
+
+// requestRateBatchSize bounds the deployment IDs sent per
+// GetDeploymentRequestRates call, well under the control plane's limit.
+const requestRateBatchSize = 500
+
+// scalesOnRequestRate reports whether a deployment's HPA floor is driven by
+// its request rate. Deployments pinned to a single replica by persistent
+// volumes, or with no room between min and max, have nothing to scale.
+func scalesOnRequestRate(req *ctrlv1.ApplyDeployment) bool {
+	if req.GetAutoscaling().GetRpsThreshold() <= 0 {
+		return false
+	}
+	minReplicas, maxReplicas := hpaReplicaBounds(req)
+	return maxReplicas > minReplicas
+}
+
+// requestRateFloor returns the replica floor needed to serve rps requests per
+// second at threshold requests per second per replica, clamped to the
+// policy's bounds.
+func requestRateFloor(rps float64, threshold, minReplicas, maxReplicas int32) int32 {
+	if threshold <= 0 || rps <= 0 {
+		return minReplicas
+	}
+	needed := math.Ceil(rps / float64(threshold))
+	if needed >= float64(maxReplicas) {
+		return maxReplicas
+	}
+	return clampReplicas(int32(needed), minReplicas, maxReplicas)
+}
+
+// clampReplicas bounds n to [minReplicas, maxReplicas].
+func clampReplicas(n, minReplicas, maxReplicas int32) int32 {
+	return min(max(n, minReplicas), maxReplicas)
+}
+
+// applyReplicaFloor server-side applies only spec.minReplicas of an HPA under
+// fieldManagerAutoscaler. The patch is a bare map rather than a typed HPA so
+// zero-valued required fields (scaleTargetRef, maxReplicas) are not claimed.
+func (c *Controller) applyReplicaFloor(ctx context.Context, namespace, name string, floor int32) error {
+	patch, err := json.Marshal(map[string]any{
+		"apiVersion": "autoscaling/v2",
+		"kind":       "HorizontalPodAutoscaler",
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]any{
+			"minReplicas": floor,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal HPA replica floor: %w", err)
+	}
+
+	_, err = c.clientSet.AutoscalingV2().HorizontalPodAutoscalers(namespace).Patch(
+		ctx, name, types.ApplyPatchType, patch, metav1.PatchOptions{
+			FieldManager: fieldManagerAutoscaler,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to apply HPA replica floor: %w", err)
+	}
+	return nil
+}
+
+// runRequestRateScaleLoop periodically recomputes the replica floor of every
+// request-rate scaled HPA in the cluster.
+func (c *Controller) runRequestRateScaleLoop(ctx context.Context) {
+	repeat.Every(requestRateScaleInterval, func() {
+		c.scaleByRequestRate(ctx)
+	})
+}
+
+// requestRateTarget is a request-rate scaled HPA with its scaling parameters
+// parsed from the annotations buildHorizontalPodAutoscaler records.
+type requestRateTarget struct {
+	namespace    string
+	name         string
+	deploymentID string
+	threshold    int32
+	minReplicas  int32
+	maxReplicas  int32
+	current      int32
+}
+
+// scaleByRequestRate runs one pass of the request-rate autoscaler. Errors
+// are logged rather than returned: when the control plane is unreachable the
+// floors simply stay where they are until the next pass.
+func (c *Controller) scaleByRequestRate(ctx context.Context) {
+	targets := c.listRequestRateTargets(ctx)
+	if len(targets) == 0 {
+		return
+	}
+
+	for start := 0; start < len(targets); start += requestRateBatchSize {
+		batch := targets[start:min(start+requestRateBatchSize, len(targets))]
+
+		deploymentIDs := make([]string, len(batch))
+		for i, t := range batch {
+			deploymentIDs[i] = t.deploymentID
+		}
+
+		res, err := c.cluster.GetDeploymentRequestRates(ctx, &ctrlv1.GetDeploymentRequestRatesRequest{
+			Cluster:       c.clusterKey(),
+			DeploymentIds: deploymentIDs,
+		})
+		if err != nil {
+			logger.Error("request rate autoscaler: unable to fetch request rates", "error", err.Error(), "deployments", len(batch))
+			return
+		}
+
+		rates := res.GetRequestsPerSecond()
+		for _, t := range batch {
+			floor := requestRateFloor(rates[t.deploymentID], t.threshold, t.minReplicas, t.maxReplicas)
+			if floor == t.current {
+				continue
+			}
+			if err := c.applyReplicaFloor(ctx, t.namespace, t.name, floor); err != nil {
+				logger.Error("request rate autoscaler: unable to apply replica floor", "error", err.Error(), "deployment_id", t.deploymentID)
+				continue
+			}
+			logger.Info("request rate autoscaler: adjusted replica floor",
+				"deployment_id", t.deploymentID,
+				"requests_per_second", rates[t.deploymentID],
+				"from", t.current,
+				"to", floor,
+			)
+		}
+	}
+}
+
+// listRequestRateTargets paginates through all krane-managed deployment HPAs
+// and returns those scaled on request rate.
+func (c *Controller) listRequestRateTargets(ctx context.Context) []requestRateTarget {
+	var targets []requestRateTarget
+	cursor := ""
+	for {
+		hpas, err := c.clientSet.AutoscalingV2().HorizontalPodAutoscalers("").List(ctx, metav1.ListOptions{
+			LabelSelector: labels.New().
+				ManagedByKrane().
+				ComponentDeployment().
+				ToString(),
+			Continue: cursor,
+		})
+		if err != nil {
+			logger.Error("request rate autoscaler: unable to list HPAs", "error", err.Error())
+			return targets
+		}
+
+		for i := range hpas.Items {
+			if t, ok := parseRequestRateTarget(&hpas.Items[i]); ok {
+				targets = append(targets, t)
+			}
+		}
+
+		cursor = hpas.Continue
+		if cursor == "" {
+			return targets
+		}
+	}
+}
+
+// parseRequestRateTarget reads the request-rate scaling parameters off an
+// HPA. HPAs without the annotations are not request-rate scaled and are
+// skipped silently; malformed annotations are logged and skipped.
+func parseRequestRateTarget(hpa *autoscalingv2.HorizontalPodAutoscaler) (requestRateTarget, bool) {
+	rawThreshold, ok := hpa.Annotations[annotationRPSThreshold]
+	if !ok {
+		return requestRateTarget{}, false
+	}
+
+	deploymentID, ok := labels.GetDeploymentID(hpa.Labels)
+	if !ok {
+		logger.Error("request rate autoscaler: HPA has no deployment ID", "namespace", hpa.Namespace, "name", hpa.Name)
+		return requestRateTarget{}, false
+	}
+
+	threshold, err := strconv.ParseInt(rawThreshold, 10, 32)
+	if err != nil || threshold <= 0 {
+		logger.Error("request rate autoscaler: invalid rps threshold annotation", "deployment_id", deploymentID, "value", rawThreshold)
+		return requestRateTarget{}, false
+	}
+
+	policyMin, err := strconv.ParseInt(hpa.Annotations[annotationPolicyMinReplicas], 10, 32)
+	if err != nil || policyMin < 1 {
+		logger.Error("request rate autoscaler: invalid min replicas annotation", "deployment_id", deploymentID, "value", hpa.Annotations[annotationPolicyMinReplicas])
+		return requestRateTarget{}, false
+	}
+
+	return requestRateTarget{
+		namespace:    hpa.Namespace,
+		name:         hpa.Name,
+		deploymentID: deploymentID,
+		threshold:    int32(threshold),
+		minReplicas:  int32(policyMin),
+		maxReplicas:  max(hpa.Spec.MaxReplicas, int32(policyMin)),
+		current:      ptr.SafeDeref(hpa.Spec.MinReplicas),
+	}, true
+}
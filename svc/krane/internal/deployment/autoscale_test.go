@@ -0,0 +1,125 @@
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ctrlv1 "github.com/unkeyed/unkey/gen/proto/ctrl/v1"
+	"github.com/unkeyed/unkey/pkg/ptr"
+	"github.com/unkeyed/unkey/svc/krane/internal/testutil"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRequestRateFloor(t *testing.T) {
+	tests := []struct {
+		name      string
+		rps       float64
+		threshold int32
+		want      int32
+	}{
+		{name: "no traffic holds the policy minimum", rps: 0, threshold: 50, want: 2},
+		{name: "low traffic holds the policy minimum", rps: 60, threshold: 50, want: 2},
+		{name: "rounds partial replicas up", rps: 151, threshold: 50, want: 4},
+		{name: "exact multiple needs no extra replica", rps: 150, threshold: 50, want: 3},
+		{name: "caps at the policy maximum", rps: 1e9, threshold: 50, want: 5},
+		{name: "invalid threshold holds the policy minimum", rps: 500, threshold: 0, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, requestRateFloor(tt.rps, tt.threshold, 2, 5))
+		})
+	}
+}
+
+// TestBuildHorizontalPodAutoscaler_RequestRate verifies that an rps threshold
+// hands spec.minReplicas to the request-rate autoscaler and records the
+// parameters it needs, and that without one krane keeps owning the floor.
+func TestBuildHorizontalPodAutoscaler_RequestRate(t *testing.T) {
+	req := fullApplyRequest(t)
+	req.Volumes = nil
+	rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: testK8sName}}
+
+	hpa := buildHorizontalPodAutoscaler(req, rs)
+	require.Equal(t, ptr.P(int32(2)), hpa.Spec.MinReplicas)
+	require.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+	require.Empty(t, hpa.Annotations)
+
+	req.Autoscaling.RpsThreshold = ptr.P(int32(50))
+	hpa = buildHorizontalPodAutoscaler(req, rs)
+	require.Nil(t, hpa.Spec.MinReplicas, "the request-rate autoscaler owns minReplicas")
+	require.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+	require.Equal(t, "50", hpa.Annotations[annotationRPSThreshold])
+	require.Equal(t, "2", hpa.Annotations[annotationPolicyMinReplicas])
+
+	target, ok := parseRequestRateTarget(hpa)
+	require.True(t, ok)
+	require.Equal(t, testDeploymentID, target.deploymentID)
+	require.Equal(t, int32(50), target.threshold)
+	require.Equal(t, int32(2), target.minReplicas)
+	require.Equal(t, int32(5), target.maxReplicas)
+}
+
+// TestBuildHorizontalPodAutoscaler_RequestRateWithVolumes verifies that
+// deployments pinned to one replica by persistent volumes ignore the rps
+// threshold: there is no room to scale.
+func TestBuildHorizontalPodAutoscaler_RequestRateWithVolumes(t *testing.T) {
+	req := fullApplyRequest(t)
+	req.Autoscaling.RpsThreshold = ptr.P(int32(50))
+	rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: testK8sName}}
+
+	hpa := buildHorizontalPodAutoscaler(req, rs)
+	require.Equal(t, ptr.P(int32(1)), hpa.Spec.MinReplicas)
+	require.Equal(t, int32(1), hpa.Spec.MaxReplicas)
+	require.Empty(t, hpa.Annotations)
+
+	_, ok := parseRequestRateTarget(hpa)
+	require.False(t, ok)
+}
+
+// TestScaleByRequestRate verifies one autoscaler pass raises the floor of a
+// request-rate scaled HPA from the control plane's reported rate.
+func TestScaleByRequestRate(t *testing.T) {
+	req := fullApplyRequest(t)
+	req.Volumes = nil
+	req.Autoscaling.RpsThreshold = ptr.P(int32(50))
+	hpa := buildHorizontalPodAutoscaler(req, &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: testK8sName}})
+
+	// Seed the HPA the way ApplyDeployment does: krane applies everything but
+	// minReplicas, then the autoscaler's field manager applies the initial floor.
+	client := fake.NewClientset()
+	patch, err := json.Marshal(hpa)
+	require.NoError(t, err)
+	_, err = client.AutoscalingV2().HorizontalPodAutoscalers(testNamespace).Patch(
+		context.Background(), testK8sName, types.ApplyPatchType, patch, metav1.PatchOptions{FieldManager: fieldManagerKrane},
+	)
+	require.NoError(t, err)
+
+	var requested []string
+	c := &Controller{
+		clientSet: client,
+		cluster: &testutil.MockClusterClient{
+			GetDeploymentRequestRatesFunc: func(_ context.Context, r *ctrlv1.GetDeploymentRequestRatesRequest) (*ctrlv1.GetDeploymentRequestRatesResponse, error) {
+				requested = r.GetDeploymentIds()
+				return &ctrlv1.GetDeploymentRequestRatesResponse{
+					RequestsPerSecond: map[string]float64{testDeploymentID: 180},
+				}, nil
+			},
+		},
+	}
+
+	require.NoError(t, c.applyReplicaFloor(context.Background(), testNamespace, testK8sName, 2))
+
+	c.scaleByRequestRate(context.Background())
+
+	require.Equal(t, []string{testDeploymentID}, requested)
+	got, err := client.AutoscalingV2().HorizontalPodAutoscalers(testNamespace).Get(context.Background(), testK8sName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, ptr.P(int32(4)), got.Spec.MinReplicas)
+	require.Equal(t, int32(5), got.Spec.MaxReplicas, "the floor apply must not touch krane's fields")
+}
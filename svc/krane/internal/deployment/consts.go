@@ -11,6 +11,20 @@ const (
 	// so field ownership/conflict detection is tracked per manager.
 	fieldManagerKrane = "krane"
 
+	// fieldManagerAutoscaler owns spec.minReplicas of request-rate scaled
+	// HPAs, so the request-rate autoscaler and krane's full HPA apply never
+	// conflict over the field.
+	fieldManagerAutoscaler = "krane-autoscaler"
+
+	// annotationRPSThreshold records a request-rate scaled HPA's target
+	// requests per second per replica for the request-rate autoscaler.
+	annotationRPSThreshold = "unkey.com/autoscaling.rps-threshold"
+
+	// annotationPolicyMinReplicas records the autoscaling policy's minimum
+	// on request-rate scaled HPAs, whose spec.minReplicas holds the current
+	// floor instead.
+	annotationPolicyMinReplicas = "unkey.com/autoscaling.min-replicas"
+
 	// CustomerNodeClass is the Karpenter nodepool name for untrusted customer
 	// workloads. Nodes in this pool have additional isolation and monitoring.
 	CustomerNodeClass = "untrusted"
@@ -152,11 +152,13 @@ func New(cfg Config) *Controller {
 
 // Start launches the background control loops.
 //
-// Three independent loops run concurrently:
+// Four independent loops run concurrently:
 //   - [Controller.runActualStateResyncLoop]: periodic safety net for instance
 //     state reporting (complements the real-time pod watch).
 //   - [Controller.runDesiredStateResyncLoop]: periodic reconciliation of desired
 //     state from the control plane (complements the streaming channel).
+//   - [Controller.runRequestRateScaleLoop]: periodic replica floor updates for
+//     HPAs scaled on request rate.
 //   - [Controller.runPodWatchLoop]: real-time Kubernetes watch for pod events.
 //
 // The actual-state and desired-state loops are decoupled so that slow control
@@ -167,6 +169,7 @@ func New(cfg Config) *Controller {
 func (c *Controller) Start(ctx context.Context) error {
 	go c.runActualStateResyncLoop(ctx)
 	go c.runDesiredStateResyncLoop(ctx)
+	go c.runRequestRateScaleLoop(ctx)
 
 	if err := c.runPodWatchLoop(ctx); err != nil {
 		return err
@@ -0,0 +1,43 @@
+package deployment
+
+import (
+	"context"
+
+	ctrlv1 "github.com/unkeyed/unkey/gen/proto/ctrl/v1"
+	"github.com/unkeyed/unkey/pkg/assert"
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/svc/krane/pkg/metrics"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeleteVolume removes a persistent volume's PersistentVolumeClaim. The
+// control plane only sends this once the volume's retention window has
+// passed, so the data is gone for good: the claim's StorageClass reclaim
+// policy decides whether the backing disk is deleted with it.
+//
+// Not-found errors are ignored since the desired end state (claim gone) is
+// already achieved.
+func (c *Controller) DeleteVolume(ctx context.Context, req *ctrlv1.DeleteVolume) (retErr error) {
+	defer func() { metrics.RecordReconcile("volume", "delete", retErr) }()
+	logger.Info("deleting volume",
+		"namespace", req.GetK8SNamespace(),
+		"name", req.GetK8SName(),
+		"volume_id", req.GetVolumeId(),
+	)
+
+	err := assert.All(
+		assert.NotEmpty(req.GetK8SNamespace(), "Namespace is required"),
+		assert.NotEmpty(req.GetK8SName(), "K8s name is required"),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = c.clientSet.CoreV1().PersistentVolumeClaims(req.GetK8SNamespace()).Delete(ctx, req.GetK8SName(), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -3,12 +3,12 @@
 //
 // The package provides [Controller], which handles Kubernetes reconciliation and
 // status reporting. Desired state is received from the unified WatchDeploymentChanges
-// stream via the watcher package, which calls [Controller.ApplyDeployment] and
-// [Controller.DeleteDeployment] directly.
+// stream via the watcher package, which calls [Controller.ApplyDeployment],
+// [Controller.DeleteDeployment], and [Controller.DeleteVolume] directly.
 //
 // # Architecture
 //
-// The controller runs two concurrent loops plus receives dispatched events:
+// The controller runs its concurrent loops plus receives dispatched events:
 //
 // [Controller.runPodWatchLoop] watches Kubernetes for pod changes and reports actual
 // state back to the control plane via ReportDeploymentStatus. Watching pods directly
@@ -33,6 +33,29 @@
 // constraint keeps a deployment's replicas from stacking on a single node; the
 // zone constraint adds cross-AZ redundancy once the cluster spans multiple zones.
 //
+// # Persistent volumes
+//
+// An app's named volumes are PersistentVolumeClaims shared by every deployment
+// of the app in an environment. Like a StatefulSet with a Retain policy, krane
+// applies the claims without an owner so they survive deploys and rollbacks,
+// and only deletes one when [Controller.DeleteVolume] is dispatched after the
+// control plane's retention window. The claims are ReadWriteOnce, so
+// deployments that mount them run a single replica and require pod affinity
+// to the node of the environment's running pod.
+//
+// # Request-rate autoscaling
+//
+// Frontline's request counts live in ClickHouse rather than in a metrics API
+// the HPA can read, so instead of serving an external-metrics adapter krane
+// translates them into the one input the HPA already honours: spec.minReplicas.
+// For deployments whose policy sets an rps threshold,
+// [Controller.runRequestRateScaleLoop] polls the control plane for recent
+// request rates and raises each HPA's floor to ceil(rate / threshold) within
+// the policy's bounds. CPU and memory targets keep working on top of the
+// floor, since the HPA acts on the largest replica count any signal asks for.
+// The floor is applied under its own field manager so the desired state
+// resync never resets it.
+//
 // # Usage
 //
 //	ctrl := deployment.New(deployment.Config{
@@ -1,6 +1,7 @@
 package deployment
 
 import (
+	ctrlv1 "github.com/unkeyed/unkey/gen/proto/ctrl/v1"
 	"github.com/unkeyed/unkey/svc/krane/pkg/labels"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -56,3 +57,32 @@ func deploymentTopologySpread(deploymentID string) []corev1.TopologySpreadConstr
 		},
 	}
 }
+
+// persistentVolumeAffinity keeps pods that mount persistent volumes on the
+// node of the app's running pods in the same environment.
+//
+// The volumes are ReadWriteOnce, which allows several pods to mount them as
+// long as they share a node. During a rollout or rollback the new
+// deployment's pod must therefore land next to the old one, or it would hang
+// in ContainerCreating until the old pod released the attachment. The very
+// first pod matches its own selector, so the scheduler places it freely.
+func persistentVolumeAffinity(req *ctrlv1.ApplyDeployment) *corev1.Affinity {
+	//nolint:exhaustruct
+	return &corev1.Affinity{
+		//nolint:exhaustruct
+		PodAffinity: &corev1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				//nolint:exhaustruct
+				{
+					TopologyKey: topologyKeyHostname,
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: labels.New().
+							AppID(req.GetAppId()).
+							EnvironmentID(req.GetEnvironmentId()).
+							ComponentDeployment(),
+					},
+				},
+			},
+		},
+	}
+}
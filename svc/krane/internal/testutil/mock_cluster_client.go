@@ -24,6 +24,7 @@ type MockClusterClient struct {
 	ReportInstanceEventsFunc      func(context.Context, *ctrlv1.ReportInstanceEventsRequest) (*ctrlv1.ReportInstanceEventsResponse, error)
 	HeartbeatFunc                 func(context.Context, *ctrlv1.HeartbeatRequest) (*ctrlv1.HeartbeatResponse, error)
 	SyncDesiredStateFunc          func(context.Context, *ctrlv1.SyncDesiredStateRequest) (*connect.ServerStreamForClient[ctrlv1.DeploymentChangeEvent], error)
+	GetDeploymentRequestRatesFunc func(context.Context, *ctrlv1.GetDeploymentRequestRatesRequest) (*ctrlv1.GetDeploymentRequestRatesResponse, error)
 	ReportDeploymentStatusCalls   []*ctrlv1.ReportDeploymentStatusRequest
 	ReportInstanceEventsCalls     []*ctrlv1.ReportInstanceEventsRequest
 }
@@ -71,3 +72,10 @@ func (m *MockClusterClient) SyncDesiredState(ctx context.Context, req *ctrlv1.Sy
 	}
 	return nil, nil
 }
+
+func (m *MockClusterClient) GetDeploymentRequestRates(ctx context.Context, req *ctrlv1.GetDeploymentRequestRatesRequest) (*ctrlv1.GetDeploymentRequestRatesResponse, error) {
+	if m.GetDeploymentRequestRatesFunc != nil {
+		return m.GetDeploymentRequestRatesFunc(ctx, req)
+	}
+	return &ctrlv1.GetDeploymentRequestRatesResponse{}, nil
+}
@@ -189,6 +189,8 @@ func eventResourceType(event *ctrlv1.DeploymentChangeEvent) string {
 	switch event.GetEvent().(type) {
 	case *ctrlv1.DeploymentChangeEvent_Deployment:
 		return "deployment"
+	case *ctrlv1.DeploymentChangeEvent_DeleteVolume:
+		return "volume"
 	default:
 		return "unknown"
 	}
@@ -210,6 +212,12 @@ func (s *Watcher) dispatch(ctx context.Context, event *ctrlv1.DeploymentChangeEv
 			return fmt.Errorf("unhandled deployment state type %T at version %d", op, event.GetVersion())
 		}
 
+	case *ctrlv1.DeploymentChangeEvent_DeleteVolume:
+		if e.DeleteVolume == nil {
+			return fmt.Errorf("received volume change event with nil volume at version %d", event.GetVersion())
+		}
+		return s.deployments.DeleteVolume(ctx, e.DeleteVolume)
+
 	case nil:
 		return fmt.Errorf("received deployment change event with nil event at version %d", event.GetVersion())
 
@@ -28,3 +28,15 @@ func TestDispatch_NilDeploymentState(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "nil deployment state")
 }
+
+func TestDispatch_NilDeleteVolume(t *testing.T) {
+	w := &Watcher{}
+	err := w.dispatch(context.Background(), &ctrlv1.DeploymentChangeEvent{
+		Version: 1,
+		Event: &ctrlv1.DeploymentChangeEvent_DeleteVolume{
+			DeleteVolume: nil,
+		},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "nil volume")
+}
@@ -15,6 +15,7 @@ const (
 	LabelKeyDeploymentID    = "unkey.com/deployment.id"
 	LabelKeyBuildID         = "unkey.com/build.id"
 	LabelKeyNetworkPolicyID = "unkey.com/networkpolicy.id"
+	LabelKeyVolumeID        = "unkey.com/volume.id"
 	LabelKeyPlatform        = "unkey.com/platform"
 	LabelKeyManagedBy       = "app.kubernetes.io/managed-by"
 	LabelKeyComponent       = "app.kubernetes.io/component"
@@ -117,6 +118,26 @@ func (l Labels) ComponentDeployment() Labels {
 	return l
 }
 
+// VolumeID adds volume ID label to the label set.
+//
+// This method sets the "unkey.com/volume.id" label for identifying
+// the persistent volume a claim belongs to. Returns the same Labels
+// instance for method chaining.
+func (l Labels) VolumeID(id string) Labels {
+	l[LabelKeyVolumeID] = id
+	return l
+}
+
+// ComponentVolume adds component label for persistent volume claims.
+//
+// This method sets "app.kubernetes.io/component" label to "volume"
+// to identify resource as a persistent volume component. Returns the same
+// Labels instance for method chaining.
+func (l Labels) ComponentVolume() Labels {
+	l[LabelKeyComponent] = "volume"
+	return l
+}
+
 // ComponentCiliumNetworkPolicy adds component label for Cilium network policy resources.
 //
 // This method sets "app.kubernetes.io/component" label to "ciliumnetworkpolicy"
@@ -216,3 +237,12 @@ func GetCiliumNetworkPolicyID(l map[string]string) (string, bool) {
 	v, ok := l[LabelKeyNetworkPolicyID]
 	return v, ok
 }
+
+// GetVolumeID extracts volume ID from Kubernetes label map.
+//
+// This helper function retrieves the "unkey.com/volume.id" label from
+// a Kubernetes resource's labels. Returns ID and a boolean indicating whether the label was found.
+func GetVolumeID(l map[string]string) (string, bool) {
+	v, ok := l[LabelKeyVolumeID]
+	return v, ok
+}
@@ -183,3 +183,11 @@ func (d *disk) ListObjectKeys(ctx context.Context, prefix string) ([]string, err
 	}
 	return keys, nil
 }
+
+func (d *disk) ListObjectKeysAfter(ctx context.Context, prefix string, startAfter string, limit int) ([]string, error) {
+	keys, err := d.ListObjectKeys(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return keysAfter(keys, startAfter, limit), nil
+}
@@ -136,6 +136,27 @@ func TestDisk_ListObjectKeys(t *testing.T) {
 	require.Len(t, keys, 0)
 }
 
+func TestDisk_ListObjectKeysAfter(t *testing.T) {
+	store := newTestDiskStorage(t)
+	ctx := context.Background()
+
+	for _, key := range []string{"keyring/b/dek", "keyring/d", "keyring/a", "keyring/c", "other/a"} {
+		require.NoError(t, store.PutObject(ctx, key, []byte("data")))
+	}
+
+	keys, err := store.ListObjectKeysAfter(ctx, "keyring/", "", 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"keyring/a", "keyring/b/dek"}, keys)
+
+	keys, err = store.ListObjectKeysAfter(ctx, "keyring/", "keyring/b/dek", 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"keyring/c", "keyring/d"}, keys)
+
+	keys, err = store.ListObjectKeysAfter(ctx, "keyring/", "keyring/d", 10)
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
+
 func TestDisk_KeyHelpers(t *testing.T) {
 	store := newTestDiskStorage(t)
 
@@ -22,6 +22,11 @@ type Storage interface {
 	// ListObjectKeys returns a list of object keys that match the given prefix
 	ListObjectKeys(ctx context.Context, prefix string) ([]string, error)
 
+	// ListObjectKeysAfter returns up to limit object keys that match the given
+	// prefix and sort strictly after startAfter, in lexical order. An empty
+	// startAfter lists from the beginning of the prefix.
+	ListObjectKeysAfter(ctx context.Context, prefix string, startAfter string, limit int) ([]string, error)
+
 	// Key returns the object key for the given shard and version
 	Key(shard string, dekID string) string
 
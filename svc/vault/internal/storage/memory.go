@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 )
@@ -62,3 +63,30 @@ func (s *memory) ListObjectKeys(ctx context.Context, prefix string) ([]string, e
 	}
 	return keys, nil
 }
+
+func (s *memory) ListObjectKeysAfter(ctx context.Context, prefix string, startAfter string, limit int) ([]string, error) {
+	keys, err := s.ListObjectKeys(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return keysAfter(keys, startAfter, limit), nil
+}
+
+// keysAfter sorts keys and returns up to limit of them that come strictly
+// after startAfter. The memory and disk backends have no ordered index, so
+// they filter a full listing, which is fine at their scale.
+func keysAfter(keys []string, startAfter string, limit int) []string {
+	if limit <= 0 {
+		return []string{}
+	}
+	slices.Sort(keys)
+	start, found := slices.BinarySearch(keys, startAfter)
+	if found {
+		start++
+	}
+	keys = keys[start:]
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys
+}
@@ -183,6 +183,34 @@ func TestMemory_ListObjectKeys(t *testing.T) {
 	require.Len(t, keys, 0)
 }
 
+// TestMemory_ListObjectKeysAfter verifies cursor listing returns keys in
+// lexical order, strictly after the cursor and capped at the limit.
+func TestMemory_ListObjectKeysAfter(t *testing.T) {
+	store := newTestMemoryStorage(t)
+	ctx := context.Background()
+
+	for _, key := range []string{"keyring/b", "keyring/d", "keyring/a", "keyring/c", "other/a"} {
+		require.NoError(t, store.PutObject(ctx, key, []byte("data")))
+	}
+
+	keys, err := store.ListObjectKeysAfter(ctx, "keyring/", "", 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"keyring/a", "keyring/b"}, keys)
+
+	keys, err = store.ListObjectKeysAfter(ctx, "keyring/", "keyring/b", 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"keyring/c", "keyring/d"}, keys)
+
+	// The cursor does not have to exist.
+	keys, err = store.ListObjectKeysAfter(ctx, "keyring/", "keyring/bb", 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"keyring/c", "keyring/d"}, keys)
+
+	keys, err = store.ListObjectKeysAfter(ctx, "keyring/", "keyring/d", 10)
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
+
 // TestMemory_KeyHelpers verifies the Key and Latest helper functions.
 func TestMemory_KeyHelpers(t *testing.T) {
 	store := newTestMemoryStorage(t)
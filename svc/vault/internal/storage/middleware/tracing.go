@@ -56,6 +56,21 @@ func (tm *tracingMiddleware) ListObjectKeys(ctx context.Context, prefix string)
 	return keys, err
 }
 
+func (tm *tracingMiddleware) ListObjectKeysAfter(ctx context.Context, prefix string, startAfter string, limit int) ([]string, error) {
+	ctx, span := tracing.Start(ctx, fmt.Sprintf("storage.%s.ListObjectKeysAfter", tm.name))
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("prefix", prefix),
+		attribute.String("startAfter", startAfter),
+		attribute.Int("limit", limit),
+	)
+	keys, err := tm.next.ListObjectKeysAfter(ctx, prefix, startAfter, limit)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return keys, err
+}
+
 func (tm *tracingMiddleware) Key(shard string, dekID string) string {
 	return tm.next.Key(shard, dekID)
 }
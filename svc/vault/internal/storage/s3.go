@@ -112,13 +112,56 @@ func (s *s3) ListObjectKeys(ctx context.Context, prefix string) ([]string, error
 		input.Prefix = aws.String(prefix)
 	}
 
-	o, err := s.client.ListObjectsV2(ctx, input)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list objects: %w", err)
+	// A single ListObjectsV2 call returns at most 1000 keys, so page through
+	// the bucket to list every key.
+	keys := []string{}
+	paginator := awsS3.NewListObjectsV2Paginator(s.client, input)
+	for paginator.HasMorePages() {
+		o, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+		for _, obj := range o.Contents {
+			keys = append(keys, *obj.Key)
+		}
+	}
+	return keys, nil
+}
+
+// s3MaxKeysPerPage is the most keys a single ListObjectsV2 call returns.
+const s3MaxKeysPerPage = 1000
+
+func (s *s3) ListObjectKeysAfter(ctx context.Context, prefix string, startAfter string, limit int) ([]string, error) {
+	keys := []string{}
+	if limit <= 0 {
+		return keys, nil
+	}
+
+	// S3 lists keys in lexical order, so StartAfter resumes the listing
+	// without reading the keys before it.
+	input := &awsS3.ListObjectsV2Input{
+		Bucket:  aws.String(s.config.S3Bucket),
+		MaxKeys: aws.Int32(int32(min(limit, s3MaxKeysPerPage))),
+	}
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+	if startAfter != "" {
+		input.StartAfter = aws.String(startAfter)
+	}
+
+	paginator := awsS3.NewListObjectsV2Paginator(s.client, input)
+	for paginator.HasMorePages() && len(keys) < limit {
+		o, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+		for _, obj := range o.Contents {
+			keys = append(keys, *obj.Key)
+		}
 	}
-	keys := make([]string, len(o.Contents))
-	for i, obj := range o.Contents {
-		keys[i] = *obj.Key
+	if len(keys) > limit {
+		keys = keys[:limit]
 	}
 	return keys, nil
 }
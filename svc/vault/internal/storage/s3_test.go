@@ -163,6 +163,30 @@ func TestS3_ListObjectKeys(t *testing.T) {
 	require.Len(t, keys, 0)
 }
 
+// TestS3_ListObjectKeysAfter verifies cursor listing resumes after the given
+// key and stops at the limit.
+func TestS3_ListObjectKeysAfter(t *testing.T) {
+	store := newTestS3Storage(t)
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("list-after-test-%d/", time.Now().UnixNano())
+	for _, key := range []string{"b", "d", "a", "c"} {
+		require.NoError(t, store.PutObject(ctx, prefix+key, []byte("data")))
+	}
+
+	keys, err := store.ListObjectKeysAfter(ctx, prefix, "", 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{prefix + "a", prefix + "b"}, keys)
+
+	keys, err = store.ListObjectKeysAfter(ctx, prefix, prefix+"b", 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{prefix + "c", prefix + "d"}, keys)
+
+	keys, err = store.ListObjectKeysAfter(ctx, prefix, prefix+"d", 10)
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
+
 // TestS3_KeyHelpers verifies the Key and Latest helper functions.
 func TestS3_KeyHelpers(t *testing.T) {
 	store := newTestS3Storage(t)
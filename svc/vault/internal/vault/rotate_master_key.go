@@ -0,0 +1,180 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/pkg/otel/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RotationProgress describes how far the re-wrap pass for the current master
+// key has progressed. Counts cover the whole pass, not a single batch.
+type RotationProgress struct {
+	// KekID is the id of the master key the pass re-wraps DEKs with.
+	KekID string
+
+	// Rewrapped counts DEKs that were wrapped with an older master key and
+	// have been re-wrapped.
+	Rewrapped uint64
+
+	// Skipped counts DEKs that were already wrapped with KekID.
+	Skipped uint64
+
+	// Remaining estimates how many DEKs the pass has not visited yet. It is
+	// based on the number of DEKs stored when the pass started, so DEKs
+	// created or deleted since then are not reflected.
+	Remaining uint64
+
+	// Done is true once every DEK is wrapped with KekID. Only then is it safe
+	// to stop loading the previous master key.
+	Done bool
+}
+
+// rotationCheckpoint is persisted in storage after every batch so an
+// interrupted pass resumes where it stopped instead of starting over.
+type rotationCheckpoint struct {
+	// After is the last object key the pass visited. Object keys are visited
+	// in lexical order.
+	After     string `json:"after"`
+	Rewrapped uint64 `json:"rewrapped"`
+	Skipped   uint64 `json:"skipped"`
+
+	// Total is the number of DEKs stored when the pass started. It is counted
+	// once per pass and only feeds RotationProgress.Remaining.
+	Total uint64 `json:"total"`
+}
+
+// rotationPrefix is where every stored DEK lives. Checkpoints are stored
+// outside of it, so the pass never visits them.
+const rotationPrefix = "keyring/"
+
+// rotationCheckpointKey is scoped to the master key, so rotating to yet
+// another master key starts a fresh pass.
+func (s *Service) rotationCheckpointKey() string {
+	return fmt.Sprintf("rotation/%s", s.encryptionKey.GetId())
+}
+
+// RotateMasterKeyBatch re-wraps up to batchSize DEKs that come after the
+// stored checkpoint with the current master key and advances the checkpoint.
+// DEKs are decrypted with any configured master key, so the previous master
+// key must stay loaded until the returned progress is done.
+//
+// Re-wrapping is idempotent: a DEK that is already wrapped with the current
+// master key is left untouched, so repeating a batch after a crash between
+// writing DEKs and writing the checkpoint is safe.
+func (s *Service) RotateMasterKeyBatch(ctx context.Context, batchSize int, restart bool) (RotationProgress, error) {
+	ctx, span := tracing.Start(ctx, "vault.RotateMasterKeyBatch")
+	defer span.End()
+	span.SetAttributes(attribute.Int("batchSize", batchSize), attribute.Bool("restart", restart))
+
+	s.rotationMu.Lock()
+	defer s.rotationMu.Unlock()
+
+	checkpoint := rotationCheckpoint{After: "", Rewrapped: 0, Skipped: 0, Total: 0}
+	found := false
+	if !restart {
+		b, ok, err := s.storage.GetObject(ctx, s.rotationCheckpointKey())
+		if err != nil {
+			return RotationProgress{}, fmt.Errorf("failed to load rotation checkpoint: %w", err)
+		}
+		if ok {
+			if err = json.Unmarshal(b, &checkpoint); err != nil {
+				return RotationProgress{}, fmt.Errorf("failed to decode rotation checkpoint: %w", err)
+			}
+		}
+		found = ok
+	}
+
+	// Counting needs a full listing, so it happens once when the pass starts
+	// rather than on every batch.
+	if !found {
+		all, err := s.storage.ListObjectKeys(ctx, rotationPrefix)
+		if err != nil {
+			return RotationProgress{}, fmt.Errorf("failed to count keys: %w", err)
+		}
+		checkpoint.Total = uint64(len(all))
+	}
+
+	// One extra key tells whether anything is left after this batch without
+	// another round trip.
+	objectKeys, err := s.storage.ListObjectKeysAfter(ctx, rotationPrefix, checkpoint.After, batchSize+1)
+	if err != nil {
+		return RotationProgress{}, fmt.Errorf("failed to list keys: %w", err)
+	}
+	done := len(objectKeys) <= batchSize
+	if !done {
+		objectKeys = objectKeys[:batchSize]
+	}
+
+	for _, objectKey := range objectKeys {
+		rewrapped, err := s.rewrapDEK(ctx, objectKey)
+		if err != nil {
+			return RotationProgress{}, err
+		}
+		if rewrapped {
+			checkpoint.Rewrapped++
+		} else {
+			checkpoint.Skipped++
+		}
+		checkpoint.After = objectKey
+	}
+
+	b, err := json.Marshal(checkpoint)
+	if err != nil {
+		return RotationProgress{}, fmt.Errorf("failed to encode rotation checkpoint: %w", err)
+	}
+	if err = s.storage.PutObject(ctx, s.rotationCheckpointKey(), b); err != nil {
+		return RotationProgress{}, fmt.Errorf("failed to store rotation checkpoint: %w", err)
+	}
+
+	progress := RotationProgress{
+		KekID:     s.encryptionKey.GetId(),
+		Rewrapped: checkpoint.Rewrapped,
+		Skipped:   checkpoint.Skipped,
+		Remaining: 0,
+		Done:      done,
+	}
+	if visited := checkpoint.Rewrapped + checkpoint.Skipped; !done && visited < checkpoint.Total {
+		progress.Remaining = checkpoint.Total - visited
+	}
+	logger.Info("rotated master key batch",
+		"kekId", progress.KekID,
+		"rewrapped", progress.Rewrapped,
+		"skipped", progress.Skipped,
+		"remaining", progress.Remaining,
+	)
+	return progress, nil
+}
+
+// rewrapDEK re-wraps a single stored DEK with the current master key and
+// reports whether it had to be rewritten. Objects deleted since they were
+// listed are skipped.
+func (s *Service) rewrapDEK(ctx context.Context, objectKey string) (bool, error) {
+	b, found, err := s.storage.GetObject(ctx, objectKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to get object %s: %w", objectKey, err)
+	}
+	if !found {
+		return false, nil
+	}
+
+	dek, kekID, err := s.keyring.DecodeAndDecryptKey(ctx, b)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode and decrypt key %s: %w", objectKey, err)
+	}
+	if kekID == s.encryptionKey.GetId() {
+		return false, nil
+	}
+
+	reencrypted, err := s.keyring.EncryptAndEncodeKey(ctx, dek)
+	if err != nil {
+		return false, fmt.Errorf("failed to re-encrypt key %s: %w", objectKey, err)
+	}
+	if err = s.storage.PutObject(ctx, objectKey, reencrypted); err != nil {
+		return false, fmt.Errorf("failed to put re-encrypted key %s: %w", objectKey, err)
+	}
+	return true, nil
+}
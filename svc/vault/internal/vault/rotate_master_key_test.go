@@ -0,0 +1,127 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	vaultv1 "github.com/unkeyed/unkey/gen/proto/vault/v1"
+	"github.com/unkeyed/unkey/svc/vault/internal/storage"
+	"github.com/unkeyed/unkey/svc/vault/keys"
+)
+
+// TestRotateMasterKey_RewrapsEveryDEK encrypts data with an old master key,
+// rotates to a new one in small resumable batches, and verifies the data can
+// then be decrypted without the old master key.
+func TestRotateMasterKey_RewrapsEveryDEK(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewMemory()
+	require.NoError(t, err)
+
+	_, oldMasterKey, err := keys.GenerateMasterKey()
+	require.NoError(t, err)
+	_, newMasterKey, err := keys.GenerateMasterKey()
+	require.NoError(t, err)
+
+	oldService, err := New(Config{Storage: store, MasterKey: oldMasterKey, BearerToken: "old"})
+	require.NoError(t, err)
+
+	encrypted := map[string]string{}
+	for i := 0; i < 5; i++ {
+		keyring := fmt.Sprintf("keyring-%d", i)
+		res, encErr := oldService.encrypt(ctx, &vaultv1.EncryptRequest{Keyring: keyring, Data: "secret-" + keyring})
+		require.NoError(t, encErr)
+		encrypted[keyring] = res.GetEncrypted()
+	}
+
+	rotating, err := New(Config{Storage: store, MasterKey: newMasterKey, PreviousMasterKey: &oldMasterKey, BearerToken: "new"})
+	require.NoError(t, err)
+
+	// Each keyring stores its DEK twice: under its id and as LATEST.
+	progress, err := rotating.RotateMasterKeyBatch(ctx, 4, false)
+	require.NoError(t, err)
+	require.False(t, progress.Done)
+	require.Equal(t, uint64(4), progress.Rewrapped)
+	require.Equal(t, uint64(6), progress.Remaining)
+
+	// A fresh instance resumes from the checkpoint instead of starting over.
+	resumed, err := New(Config{Storage: store, MasterKey: newMasterKey, PreviousMasterKey: &oldMasterKey, BearerToken: "new"})
+	require.NoError(t, err)
+	for !progress.Done {
+		progress, err = resumed.RotateMasterKeyBatch(ctx, 4, false)
+		require.NoError(t, err)
+	}
+	require.Equal(t, uint64(10), progress.Rewrapped)
+	require.Equal(t, uint64(0), progress.Skipped)
+	require.Equal(t, uint64(0), progress.Remaining)
+	require.Equal(t, resumed.encryptionKey.GetId(), progress.KekID)
+
+	newOnly, err := New(Config{Storage: store, MasterKey: newMasterKey, BearerToken: "new"})
+	require.NoError(t, err)
+	for keyring, ciphertext := range encrypted {
+		res, decErr := newOnly.decrypt(ctx, &vaultv1.DecryptRequest{Keyring: keyring, Encrypted: ciphertext})
+		require.NoError(t, decErr)
+		require.Equal(t, "secret-"+keyring, res.GetPlaintext())
+	}
+
+	// Restarting visits every DEK again but has nothing left to re-wrap.
+	progress, err = newOnly.RotateMasterKeyBatch(ctx, 100, true)
+	require.NoError(t, err)
+	require.True(t, progress.Done)
+	require.Equal(t, uint64(0), progress.Rewrapped)
+	require.Equal(t, uint64(10), progress.Skipped)
+}
+
+func TestRotateMasterKey_FailsWithoutPreviousMasterKey(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewMemory()
+	require.NoError(t, err)
+
+	_, oldMasterKey, err := keys.GenerateMasterKey()
+	require.NoError(t, err)
+	_, newMasterKey, err := keys.GenerateMasterKey()
+	require.NoError(t, err)
+
+	oldService, err := New(Config{Storage: store, MasterKey: oldMasterKey, BearerToken: "old"})
+	require.NoError(t, err)
+	_, err = oldService.encrypt(ctx, &vaultv1.EncryptRequest{Keyring: "keyring", Data: "secret"})
+	require.NoError(t, err)
+
+	newOnly, err := New(Config{Storage: store, MasterKey: newMasterKey, BearerToken: "new"})
+	require.NoError(t, err)
+	_, err = newOnly.RotateMasterKeyBatch(ctx, 100, false)
+	require.Error(t, err)
+}
+
+func TestRotateMasterKey_WithoutAuth(t *testing.T) {
+	service := setupTestService(t)
+
+	_, err := service.RotateMasterKey(context.Background(), connect.NewRequest(&vaultv1.RotateMasterKeyRequest{}))
+	require.Error(t, err)
+	require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+}
+
+func TestRotateMasterKey_RejectsOversizedBatch(t *testing.T) {
+	service := setupTestService(t)
+
+	req := connect.NewRequest(&vaultv1.RotateMasterKeyRequest{BatchSize: maxRotationBatchSize + 1})
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", service.bearer))
+
+	_, err := service.RotateMasterKey(context.Background(), req)
+	require.Error(t, err)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestRotateMasterKey_EmptyStorage(t *testing.T) {
+	service := setupTestService(t)
+
+	req := connect.NewRequest(&vaultv1.RotateMasterKeyRequest{})
+	req.Header().Set("Authorization", fmt.Sprintf("Bearer %s", service.bearer))
+
+	res, err := service.RotateMasterKey(context.Background(), req)
+	require.NoError(t, err)
+	require.True(t, res.Msg.GetDone())
+	require.Equal(t, service.encryptionKey.GetId(), res.Msg.GetKekId())
+}
@@ -0,0 +1,49 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	vaultv1 "github.com/unkeyed/unkey/gen/proto/vault/v1"
+	"github.com/unkeyed/unkey/pkg/otel/tracing"
+)
+
+const (
+	defaultRotationBatchSize = 100
+	maxRotationBatchSize     = 1000
+)
+
+func (s *Service) RotateMasterKey(
+	ctx context.Context,
+	req *connect.Request[vaultv1.RotateMasterKeyRequest],
+) (*connect.Response[vaultv1.RotateMasterKeyResponse], error) {
+	if err := s.authenticate(req); err != nil {
+		return nil, err
+	}
+
+	ctx, span := tracing.Start(ctx, "vault.RotateMasterKey")
+	defer span.End()
+
+	batchSize := int(req.Msg.GetBatchSize())
+	if batchSize == 0 {
+		batchSize = defaultRotationBatchSize
+	}
+	if batchSize > maxRotationBatchSize {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("batch_size must not exceed %d", maxRotationBatchSize))
+	}
+
+	progress, err := s.RotateMasterKeyBatch(ctx, batchSize, req.Msg.GetRestart())
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to rotate master key: %w", err))
+	}
+
+	return connect.NewResponse(&vaultv1.RotateMasterKeyResponse{
+		KekId:     progress.KekID,
+		Rewrapped: progress.Rewrapped,
+		Skipped:   progress.Skipped,
+		Remaining: progress.Remaining,
+		Done:      progress.Done,
+	}), nil
+}
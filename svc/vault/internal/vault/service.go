@@ -3,6 +3,7 @@ package vault
 import (
 	"encoding/base64"
 	"fmt"
+	"sync"
 	"time"
 
 	vaultv1 "github.com/unkeyed/unkey/gen/proto/vault/v1"
@@ -27,6 +28,10 @@ type Service struct {
 
 	keyring *keyring.Keyring
 	bearer  string
+
+	// rotationMu serializes master key rotation batches so concurrent callers
+	// cannot advance the checkpoint past each other.
+	rotationMu sync.Mutex
 }
 
 var _ vaultv1connect.VaultServiceHandler = (*Service)(nil)
@@ -74,6 +79,7 @@ func New(cfg Config) (*Service, error) {
 		encryptionKey: encryptionKey,
 		keyring:       kr,
 		bearer:        cfg.BearerToken,
+		rotationMu:    sync.Mutex{},
 	}, nil
 }
 
@@ -0,0 +1,80 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/unkeyed/unkey/pkg/logger"
+	"github.com/unkeyed/unkey/svc/vault/internal/vault"
+)
+
+// RotationProgress describes how far a master key rotation has progressed.
+// See [RotateMasterKey].
+type RotationProgress = vault.RotationProgress
+
+// RotateMasterKeyOptions configures [RotateMasterKey].
+type RotateMasterKeyOptions struct {
+	// BatchSize is the number of DEKs re-wrapped between two checkpoints.
+	BatchSize int
+
+	// Restart discards the stored checkpoint and starts the pass over.
+	Restart bool
+
+	// OnProgress is called after every batch. May be nil.
+	OnProgress func(RotationProgress)
+}
+
+// RotateMasterKey re-wraps every DEK in the configured storage backend with
+// cfg.Encryption.MasterKey, decrypting them with either the master key or
+// cfg.Encryption.PreviousMasterKey. It talks to storage directly and does not
+// need a running vault.
+//
+// Progress is checkpointed in storage after every batch, so an interrupted
+// rotation resumes where it stopped when called again with the same config.
+// Keep the previous master key configured on every vault instance until
+// RotateMasterKey returns successfully.
+func RotateMasterKey(ctx context.Context, cfg Config, opts RotateMasterKeyOptions) (RotationProgress, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return RotationProgress{}, fmt.Errorf("bad config: %w", err)
+	}
+	if opts.BatchSize <= 0 {
+		return RotationProgress{}, fmt.Errorf("batch size must be positive, got %d", opts.BatchSize)
+	}
+
+	store, _, err := newStorage(cfg.Storage)
+	if err != nil {
+		return RotationProgress{}, fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	v, err := vault.New(vault.Config{
+		Storage:           store,
+		MasterKey:         cfg.Encryption.MasterKey,
+		PreviousMasterKey: cfg.Encryption.PreviousMasterKey,
+		BearerToken:       cfg.BearerToken,
+	})
+	if err != nil {
+		return RotationProgress{}, fmt.Errorf("unable to create vault service: %w", err)
+	}
+
+	restart := opts.Restart
+	for {
+		progress, err := v.RotateMasterKeyBatch(ctx, opts.BatchSize, restart)
+		if err != nil {
+			return progress, err
+		}
+		restart = false
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(progress)
+		}
+		if progress.Done {
+			logger.Info("master key rotation complete",
+				"kekId", progress.KekID,
+				"rewrapped", progress.Rewrapped,
+				"skipped", progress.Skipped,
+			)
+			return progress, nil
+		}
+	}
+}
@@ -0,0 +1,179 @@
+"use client";
+import { revalidate } from "@/app/actions";
+import { Switch } from "@/components/ui/switch";
+import { useWorkspaceNavigation } from "@/hooks/use-workspace-navigation";
+import { routes } from "@/lib/navigation/routes";
+import { trpc } from "@/lib/trpc/client";
+import { zodResolver } from "@hookform/resolvers/zod";
+import { Button, Input, SettingCard } from "@unkey/ui";
+import { Controller, useForm } from "react-hook-form";
+import { z } from "zod";
+import {
+  createApiFormConfig,
+  createMutationHandlers,
+  getStandardButtonProps,
+} from "./key-settings-form-helper";
+
+const DAY_MS = 24 * 60 * 60 * 1000;
+
+// Thresholds are entered as a comma separated list of days, e.g. "7, 1".
+const parseDays = (value: string) =>
+  value
+    .split(",")
+    .map((day) => day.trim())
+    .filter((day) => day !== "")
+    .map(Number);
+
+const formSchema = z
+  .object({
+    days: z
+      .string()
+      .refine((value) => {
+        const days = parseDays(value);
+        return (
+          days.length > 0 &&
+          days.length <= 5 &&
+          days.every((day) => Number.isInteger(day) && day >= 1 && day <= 365)
+        );
+      }, "Enter up to five whole numbers of days between 1 and 365"),
+    email: z.boolean(),
+    slackWebhookUrl: z
+      .string()
+      .trim()
+      .refine(
+        (value) => value === "" || value.startsWith("https://hooks.slack.com/"),
+        "Must be a Slack incoming webhook URL",
+      ),
+  })
+  .refine((values) => values.email || values.slackWebhookUrl !== "", {
+    message: "Choose at least one channel to send warnings to",
+    path: ["email"],
+  });
+
+type Props = {
+  keyAuth: {
+    id: string;
+    expiryNotifications: {
+      thresholdsMs: number[];
+      email: boolean;
+      slackWebhookUrl: string | null;
+    } | null;
+  };
+  apiId: string;
+};
+
+export const ExpiryNotifications: React.FC<Props> = ({ keyAuth, apiId }) => {
+  const { onUpdateSuccess, onError } = createMutationHandlers();
+  const workspace = useWorkspaceNavigation();
+  const current = keyAuth.expiryNotifications;
+
+  const {
+    control,
+    handleSubmit,
+    formState: { isValid, isSubmitting, isDirty },
+  } = useForm<z.infer<typeof formSchema>>({
+    ...createApiFormConfig(formSchema),
+    // biome-ignore lint/suspicious/noExplicitAny: Zod v4 type inference with z.coerce creates resolver type mismatch
+    resolver: zodResolver(formSchema) as any,
+    defaultValues: {
+      days: current
+        ? current.thresholdsMs.map((ms) => Math.round(ms / DAY_MS)).join(", ")
+        : "7, 1",
+      email: current?.email ?? true,
+      slackWebhookUrl: current?.slackWebhookUrl ?? "",
+    },
+  });
+
+  const setKeyExpiryNotifications = trpc.api.setKeyExpiryNotifications.useMutation({
+    onSuccess: onUpdateSuccess("Expiry Warnings Updated"),
+    onError,
+  });
+
+  async function onSubmit(values: z.infer<typeof formSchema>) {
+    await setKeyExpiryNotifications.mutateAsync({
+      keyAuthId: keyAuth.id,
+      notifications: {
+        thresholdsMs: parseDays(values.days).map((day) => day * DAY_MS),
+        email: values.email,
+        slackWebhookUrl: values.slackWebhookUrl === "" ? null : values.slackWebhookUrl,
+      },
+    });
+
+    revalidate(routes.apis.settings({ workspaceSlug: workspace.slug, apiId }));
+  }
+
+  async function onDisable() {
+    await setKeyExpiryNotifications.mutateAsync({ keyAuthId: keyAuth.id, notifications: null });
+
+    revalidate(routes.apis.settings({ workspaceSlug: workspace.slug, apiId }));
+  }
+
+  return (
+    <SettingCard
+      title="Expiry Warnings"
+      description={
+        <div className="max-w-[380px]">
+          Warns before keys expire, once for each threshold (in days). Warnings are emailed to the
+          workspace admins and, if set, posted to a Slack webhook.
+        </div>
+      }
+      contentWidth="w-full lg:w-[420px] h-full justify-end items-end"
+    >
+      <form
+        onSubmit={handleSubmit(onSubmit)}
+        className="flex flex-col items-end gap-y-2 w-full"
+      >
+        <div className="flex flex-row justify-end items-center gap-x-2 h-9">
+          <Controller
+            control={control}
+            name="days"
+            render={({ field }) => (
+              <Input {...field} className="w-24 items-end h-9" autoComplete="off" type="text" />
+            )}
+          />
+          <div className="flex flex-row items-center gap-x-2 text-[13px] text-gray-11">
+            Email
+            <Controller
+              control={control}
+              name="email"
+              render={({ field }) => (
+                <Switch checked={field.value} onCheckedChange={field.onChange} />
+              )}
+            />
+          </div>
+        </div>
+        <div className="flex flex-row justify-end items-center gap-x-2 h-9 w-full">
+          <Controller
+            control={control}
+            name="slackWebhookUrl"
+            render={({ field }) => (
+              <Input
+                {...field}
+                className="w-full h-9"
+                autoComplete="off"
+                type="text"
+                placeholder="https://hooks.slack.com/services/..."
+              />
+            )}
+          />
+
+          {current ? (
+            <Button
+              size="lg"
+              variant="outline"
+              className="h-full px-3.5 rounded-lg"
+              type="button"
+              disabled={setKeyExpiryNotifications.isLoading}
+              onClick={onDisable}
+            >
+              Disable
+            </Button>
+          ) : null}
+          <Button {...getStandardButtonProps(isValid, isSubmitting, isDirty || !current)}>
+            Save
+          </Button>
+        </div>
+      </form>
+    </SettingCard>
+  );
+};
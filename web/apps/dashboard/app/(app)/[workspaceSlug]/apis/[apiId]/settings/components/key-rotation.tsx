@@ -0,0 +1,138 @@
+"use client";
+import { revalidate } from "@/app/actions";
+import { useWorkspaceNavigation } from "@/hooks/use-workspace-navigation";
+import { routes } from "@/lib/navigation/routes";
+import { trpc } from "@/lib/trpc/client";
+import { zodResolver } from "@hookform/resolvers/zod";
+import { Button, Input, SettingCard } from "@unkey/ui";
+import { Controller, useForm } from "react-hook-form";
+import { z } from "zod";
+import {
+  createApiFormConfig,
+  createMutationHandlers,
+  getStandardButtonProps,
+} from "./key-settings-form-helper";
+
+const DAY_MS = 24 * 60 * 60 * 1000;
+
+const formSchema = z
+  .object({
+    intervalDays: z.number().int().min(1).max(3650),
+    gracePeriodDays: z.number().int().min(0),
+  })
+  .refine((values) => values.gracePeriodDays <= values.intervalDays, {
+    message: "The grace period must not be longer than the rotation interval",
+    path: ["gracePeriodDays"],
+  });
+
+type Props = {
+  keyAuth: {
+    id: string;
+    storeEncryptedKeys: boolean;
+    rotation: { intervalMs: number; gracePeriodMs: number } | null;
+  };
+  apiId: string;
+};
+
+export const KeyRotation: React.FC<Props> = ({ keyAuth, apiId }) => {
+  const { onUpdateSuccess, onError } = createMutationHandlers();
+  const workspace = useWorkspaceNavigation();
+
+  const {
+    control,
+    handleSubmit,
+    formState: { isValid, isSubmitting, isDirty },
+  } = useForm<z.infer<typeof formSchema>>({
+    ...createApiFormConfig(formSchema),
+    // biome-ignore lint/suspicious/noExplicitAny: Zod v4 type inference with z.coerce creates resolver type mismatch
+    resolver: zodResolver(formSchema) as any,
+    defaultValues: {
+      intervalDays: keyAuth.rotation ? Math.round(keyAuth.rotation.intervalMs / DAY_MS) : 90,
+      gracePeriodDays: keyAuth.rotation ? Math.round(keyAuth.rotation.gracePeriodMs / DAY_MS) : 7,
+    },
+  });
+
+  const setKeyRotation = trpc.api.setKeyRotation.useMutation({
+    onSuccess: onUpdateSuccess("Key Rotation Updated"),
+    onError,
+  });
+
+  async function onSubmit(values: z.infer<typeof formSchema>) {
+    await setKeyRotation.mutateAsync({
+      keyAuthId: keyAuth.id,
+      rotation: {
+        intervalMs: values.intervalDays * DAY_MS,
+        gracePeriodMs: values.gracePeriodDays * DAY_MS,
+      },
+    });
+
+    revalidate(routes.apis.settings({ workspaceSlug: workspace.slug, apiId }));
+  }
+
+  async function onDisable() {
+    await setKeyRotation.mutateAsync({ keyAuthId: keyAuth.id, rotation: null });
+
+    revalidate(routes.apis.settings({ workspaceSlug: workspace.slug, apiId }));
+  }
+
+  const dayInput = (field: { onChange: (value: number) => void }) => ({
+    className: "w-24 items-end h-9",
+    autoComplete: "off",
+    type: "text",
+    disabled: !keyAuth.storeEncryptedKeys,
+    onChange: (e: React.ChangeEvent<HTMLInputElement>) =>
+      field.onChange(Number(e.target.value.replace(/\D/g, ""))),
+  });
+
+  return (
+    <SettingCard
+      title="Key Rotation"
+      description={
+        <div className="max-w-[380px]">
+          {keyAuth.storeEncryptedKeys
+            ? "Issues a new key every interval (in days) and keeps the old key valid for the grace period. Keys can override this schedule."
+            : "Key rotation is only available for APIs that store recoverable keys."}
+        </div>
+      }
+      contentWidth="w-full lg:w-[420px] h-full justify-end items-end"
+    >
+      <form
+        onSubmit={handleSubmit(onSubmit)}
+        className="flex flex-row justify-end items-center gap-x-2 h-9"
+      >
+        <Controller
+          control={control}
+          name="intervalDays"
+          render={({ field }) => <Input {...field} {...dayInput(field)} />}
+        />
+        <Controller
+          control={control}
+          name="gracePeriodDays"
+          render={({ field }) => <Input {...field} {...dayInput(field)} />}
+        />
+
+        {keyAuth.rotation ? (
+          <Button
+            size="lg"
+            variant="outline"
+            className="h-full px-3.5 rounded-lg"
+            type="button"
+            disabled={setKeyRotation.isLoading}
+            onClick={onDisable}
+          >
+            Disable
+          </Button>
+        ) : null}
+        <Button
+          {...getStandardButtonProps(
+            isValid && keyAuth.storeEncryptedKeys,
+            isSubmitting,
+            isDirty || !keyAuth.rotation,
+          )}
+        >
+          Save
+        </Button>
+      </form>
+    </SettingCard>
+  );
+};
@@ -8,6 +8,8 @@ import { DefaultBytes } from "./default-bytes";
 import { DefaultPrefix } from "./default-prefix";
 import { DeleteApi } from "./delete-api";
 import { DeleteProtection } from "./delete-protection";
+import { ExpiryNotifications } from "./expiry-notifications";
+import { KeyRotation } from "./key-rotation";
 import { SettingsClientSkeleton } from "./skeleton";
 import { UpdateApiName } from "./update-api-name";
 
@@ -46,6 +48,9 @@ export const SettingsClient = ({ apiId }: { apiId: string }) => {
     defaultPrefix: keyAuth.defaultPrefix,
     defaultBytes: keyAuth.defaultBytes,
     sizeApprox: keyAuth.sizeApprox,
+    storeEncryptedKeys: keyAuth.storeEncryptedKeys,
+    rotation: keyAuth.rotation,
+    expiryNotifications: keyAuth.expiryNotifications,
   };
 
   return (
@@ -61,6 +66,8 @@ export const SettingsClient = ({ apiId }: { apiId: string }) => {
         <SettingCardGroup>
           <DefaultBytes keyAuth={keyAuthForComponents} apiId={api.id} />
           <DefaultPrefix keyAuth={keyAuthForComponents} apiId={api.id} />
+          <KeyRotation keyAuth={keyAuthForComponents} apiId={api.id} />
+          <ExpiryNotifications keyAuth={keyAuthForComponents} apiId={api.id} />
         </SettingCardGroup>
       </div>
       <SettingsDangerZone>
@@ -1,29 +1,228 @@
 "use client";
 
-import { Strong } from "./summary-helpers";
-
-/**
- * Firewall has no per-policy configuration in the MVP — every matched
- * request is denied with a fixed 403 / "Forbidden" response. The fields
- * surface exists so the slide-panel layout stays uniform across policy
- * types and so additional configuration can land here without a layout
- * rework.
- */
+import {
+  type FirewallAction,
+  firewallRedirectStatusCodes,
+} from "@/lib/collections/deploy/policies.schema";
+import { ChevronDown } from "@unkey/icons";
+import {
+  FormInput,
+  FormTextarea,
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@unkey/ui";
+import { FormLabel } from "@unkey/ui/src/components/form/form-helpers";
+import { useController, useFormContext, useWatch } from "react-hook-form";
+import type { PolicyFormValues } from "../schema";
+import { Sep, Strong } from "./summary-helpers";
+
+type FirewallFormValues = Extract<PolicyFormValues, { type: "firewall" }>;
+
+const ACTION_OPTIONS: { value: FirewallAction; label: string }[] = [
+  { value: "ACTION_DENY", label: "Deny" },
+  { value: "ACTION_LOG", label: "Log only" },
+  { value: "ACTION_REDIRECT", label: "Redirect" },
+  { value: "ACTION_RESPOND", label: "Custom response" },
+];
+
+const ACTION_LABELS: Record<FirewallAction, string> = {
+  ACTION_DENY: "Deny",
+  ACTION_LOG: "Log only",
+  ACTION_REDIRECT: "Redirect",
+  ACTION_RESPOND: "Respond",
+};
+
+const REDIRECT_STATUS_OPTIONS = firewallRedirectStatusCodes.map((code) => ({
+  value: String(code),
+  label: String(code),
+}));
+
+function ActionDescription({ action }: { action: FirewallAction }) {
+  switch (action) {
+    case "ACTION_DENY":
+      return (
+        <>
+          Matching requests are denied with HTTP{" "}
+          <Strong className="font-mono">403 Forbidden</Strong>.
+        </>
+      );
+    case "ACTION_LOG":
+      return (
+        <>
+          Matching requests pass through. The gateway adds this policy to the request log entry, so
+          you can check what the rule would block before you enforce it.
+        </>
+      );
+    case "ACTION_REDIRECT":
+      return <>The gateway redirects matching requests. They never reach your app.</>;
+    case "ACTION_RESPOND":
+      return (
+        <>
+          The gateway answers matching requests with the response below, for example a maintenance
+          page. They never reach your app.
+        </>
+      );
+  }
+}
+
+function RedirectFields() {
+  const { control } = useFormContext<FirewallFormValues>();
+  const {
+    field: location,
+    fieldState: { error: locationError },
+  } = useController({ control, name: "redirect.location" });
+  const { field: statusCode } = useController({ control, name: "redirect.statusCode" });
+
+  return (
+    <div className="flex gap-3">
+      <FormInput
+        label="Location"
+        descriptionPosition="label"
+        description="An http(s) URL, or a path on the same host."
+        placeholder="https://example.com/new"
+        requirement="required"
+        value={location.value}
+        onChange={(e) => location.onChange(e.target.value)}
+        className="flex-1"
+        error={locationError?.message}
+      />
+      <div className="flex w-28 shrink-0 flex-col gap-1.5">
+        <FormLabel label="Status" htmlFor="firewall-redirect-status" />
+        <Select
+          value={String(statusCode.value)}
+          items={REDIRECT_STATUS_OPTIONS}
+          onValueChange={(v) => statusCode.onChange(Number(v))}
+        >
+          <SelectTrigger
+            id="firewall-redirect-status"
+            aria-label="Redirect status code"
+            rightIcon={<ChevronDown className="absolute right-2" iconSize="md-medium" />}
+          >
+            <SelectValue />
+          </SelectTrigger>
+          <SelectContent>
+            {REDIRECT_STATUS_OPTIONS.map((opt) => (
+              <SelectItem key={opt.value} value={opt.value}>
+                {opt.label}
+              </SelectItem>
+            ))}
+          </SelectContent>
+        </Select>
+      </div>
+    </div>
+  );
+}
+
+function ResponseFields() {
+  const { control } = useFormContext<FirewallFormValues>();
+  const {
+    field: statusCode,
+    fieldState: { error: statusError },
+  } = useController({ control, name: "response.statusCode" });
+  const { field: contentType } = useController({ control, name: "response.contentType" });
+  const {
+    field: body,
+    fieldState: { error: bodyError },
+  } = useController({ control, name: "response.body" });
+
+  return (
+    <div className="flex flex-col gap-4">
+      <div className="flex gap-3">
+        <FormInput
+          label="Status code"
+          type="number"
+          value={statusCode.value}
+          onChange={(e) => statusCode.onChange(Number.parseInt(e.target.value) || 0)}
+          className="w-32 shrink-0"
+          error={statusError?.message}
+        />
+        <FormInput
+          label="Content type"
+          descriptionPosition="label"
+          description="Defaults to text/plain."
+          placeholder="text/html; charset=utf-8"
+          value={contentType.value}
+          onChange={(e) => contentType.onChange(e.target.value)}
+          className="flex-1"
+        />
+      </div>
+      <FormTextarea
+        label="Body"
+        placeholder="We'll be back soon."
+        value={body.value}
+        onChange={(e) => body.onChange(e.target.value)}
+        className="min-h-24 w-full font-mono"
+        error={bodyError?.message}
+      />
+    </div>
+  );
+}
+
 export function FirewallFields() {
+  const { control } = useFormContext<FirewallFormValues>();
+  const { field: action } = useController({ control, name: "action" });
+
   return (
-    <div className="text-gray-11 text-[13px] leading-5">
-      Matching requests are denied with HTTP <Strong className="font-mono">403 Forbidden</Strong>.
-      Use match conditions below to scope which requests this rule blocks.
+    <div className="flex flex-col gap-4">
+      <div className="flex flex-col gap-1.5">
+        <FormLabel label="Action" htmlFor="firewall-action" />
+        <Select
+          value={action.value}
+          items={ACTION_OPTIONS}
+          onValueChange={(v) => action.onChange(v as FirewallAction)}
+        >
+          <SelectTrigger
+            id="firewall-action"
+            aria-label="Firewall action"
+            rightIcon={<ChevronDown className="absolute right-2" iconSize="md-medium" />}
+          >
+            <SelectValue />
+          </SelectTrigger>
+          <SelectContent>
+            {ACTION_OPTIONS.map((opt) => (
+              <SelectItem key={opt.value} value={opt.value}>
+                {opt.label}
+              </SelectItem>
+            ))}
+          </SelectContent>
+        </Select>
+      </div>
+      <div className="text-gray-11 text-[13px] leading-5">
+        <ActionDescription action={action.value} /> Use match conditions below to scope which
+        requests this rule applies to.
+      </div>
+      {action.value === "ACTION_REDIRECT" && <RedirectFields />}
+      {action.value === "ACTION_RESPOND" && <ResponseFields />}
     </div>
   );
 }
 
 /** Summary row shown next to the collapsed Firewall configuration section. */
 export function FirewallPolicySummary() {
+  const { control } = useFormContext<FirewallFormValues>();
+  const action = useWatch({ control, name: "action" });
+  const redirect = useWatch({ control, name: "redirect" });
+  const responseStatus = useWatch({ control, name: "response.statusCode" });
+
   return (
     <div className="max-w-75 truncate">
       <span className="text-gray-11">
-        Action: <Strong>Deny</Strong>
+        Action: <Strong>{ACTION_LABELS[action]}</Strong>
+        {action === "ACTION_REDIRECT" && redirect.location !== "" && (
+          <>
+            <Sep />
+            <Strong>{redirect.statusCode}</Strong> {redirect.location}
+          </>
+        )}
+        {action === "ACTION_RESPOND" && (
+          <>
+            <Sep />
+            <Strong>{responseStatus}</Strong>
+          </>
+        )}
       </span>
     </div>
   );
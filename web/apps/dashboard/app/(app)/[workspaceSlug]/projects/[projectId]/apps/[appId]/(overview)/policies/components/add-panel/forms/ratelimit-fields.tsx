@@ -1,6 +1,6 @@
 "use client";
 
-import { POLICY_LIMITS } from "@/lib/collections/deploy/policies.schema";
+import { POLICY_LIMITS, type RatelimitAlgorithm } from "@/lib/collections/deploy/policies.schema";
 import { parseDuration } from "@/lib/duration";
 import { formatMs } from "@/lib/ms";
 import { ChevronDown, Plus, Trash } from "@unkey/icons";
@@ -33,9 +33,29 @@ type RatelimitFormValues = {
   environmentId: string;
   limit: number;
   windowMs: number;
+  algorithm: RatelimitAlgorithm;
+  refillRate: number;
   identifiers: RatelimitIdentifierRowValues[];
 };
 
+// UNSPECIFIED is not offered: the form opens it as a sliding window.
+const ALGORITHM_OPTIONS: { value: RatelimitAlgorithm; label: string }[] = [
+  { value: "RATE_LIMIT_ALGORITHM_SLIDING_WINDOW", label: "Sliding window" },
+  { value: "RATE_LIMIT_ALGORITHM_FIXED_WINDOW", label: "Fixed window" },
+  { value: "RATE_LIMIT_ALGORITHM_TOKEN_BUCKET", label: "Token bucket" },
+];
+
+const ALGORITHM_DESCRIPTIONS: Record<RatelimitAlgorithm, string> = {
+  RATE_LIMIT_ALGORITHM_UNSPECIFIED:
+    "Weights the previous window's count by how much of it still overlaps.",
+  RATE_LIMIT_ALGORITHM_SLIDING_WINDOW:
+    "Weights the previous window's count by how much of it still overlaps.",
+  RATE_LIMIT_ALGORITHM_FIXED_WINDOW:
+    "Counts requests per window, aligned to UTC since the Unix epoch rather than calendar weeks or months. Cheapest, but allows bursts at window edges.",
+  RATE_LIMIT_ALGORITHM_TOKEN_BUCKET:
+    "Allows bursts up to the limit, then refills at a steady rate across the window.",
+};
+
 const IDENTIFIER_SOURCE_LABELS: Record<RateLimitIdentifierSource, string> = {
   remoteIp: "IP",
   header: "Header",
@@ -114,6 +134,15 @@ export function RateLimitFields() {
     fieldState: { error: windowError },
   } = useController({ control, name: "windowMs" });
 
+  const {
+    field: { value: algorithm, onChange: onAlgorithmChange },
+  } = useController({ control, name: "algorithm" });
+
+  const {
+    field: { value: refillRate, onChange: onRefillRateChange },
+    fieldState: { error: refillRateError },
+  } = useController({ control, name: "refillRate" });
+
   const [windowDisplay, setWindowDisplay] = useState(() => formatMs(windowMs));
   const [windowParseError, setWindowParseError] = useState<string>();
 
@@ -176,6 +205,48 @@ export function RateLimitFields() {
         />
       </div>
 
+      <div className="flex flex-col gap-1.5">
+        <FormLabel label="Algorithm" htmlFor="ratelimit-algorithm" />
+        <Select
+          value={algorithm}
+          items={ALGORITHM_OPTIONS}
+          onValueChange={(v) => onAlgorithmChange(v as RatelimitAlgorithm)}
+        >
+          <SelectTrigger
+            id="ratelimit-algorithm"
+            aria-label="Rate limit algorithm"
+            rightIcon={<ChevronDown className="absolute right-2" iconSize="md-medium" />}
+          >
+            <SelectValue />
+          </SelectTrigger>
+          <SelectContent>
+            {ALGORITHM_OPTIONS.map((opt) => (
+              <SelectItem key={opt.value} value={opt.value}>
+                {opt.label}
+              </SelectItem>
+            ))}
+          </SelectContent>
+        </Select>
+        <p className="text-gray-11 text-xs leading-5">{ALGORITHM_DESCRIPTIONS[algorithm]}</p>
+      </div>
+
+      {algorithm === "RATE_LIMIT_ALGORITHM_TOKEN_BUCKET" && (
+        <FormInput
+          label="Refill rate"
+          descriptionPosition="label"
+          description={
+            refillRate > 0
+              ? `The bucket regains ${refillRate} tokens per window, up to the limit.`
+              : "Tokens regained per window. Leave empty to refill the full limit."
+          }
+          type="number"
+          value={refillRate > 0 ? refillRate : ""}
+          placeholder={String(limit)}
+          onChange={(e) => onRefillRateChange(Number.parseInt(e.target.value) || 0)}
+          error={refillRateError?.message}
+        />
+      )}
+
       <fieldset className="flex flex-col gap-2 border-0 m-0 p-0">
         <div className="flex items-center justify-between">
           <FormLabel
@@ -280,12 +351,15 @@ export function RatelimitPolicySummary() {
   const { control } = useFormContext<RatelimitFormValues>();
   const limit = useWatch({ control, name: "limit" });
   const windowMs = useWatch({ control, name: "windowMs" });
+  const algorithm = useWatch({ control, name: "algorithm" });
   const identifiers = useWatch({ control, name: "identifiers" }) ?? [];
 
   return (
     <div className="max-w-75 truncate">
       <span className="text-gray-11">
         <Strong>{limit}</Strong> / {formatMs(windowMs)}
+        {algorithm === "RATE_LIMIT_ALGORITHM_FIXED_WINDOW" && " fixed"}
+        {algorithm === "RATE_LIMIT_ALGORITHM_TOKEN_BUCKET" && " bucket"}
         <Sep />
         per{" "}
         <Strong>
@@ -3,11 +3,7 @@
 import { ChevronDown, Trash } from "@unkey/icons";
 import { Button, Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from "@unkey/ui";
 import { useFormContext, useFormState, useWatch } from "react-hook-form";
-import {
-  type MatchConditionFormValues,
-  type PolicyFormValues,
-  getDefaultCondition,
-} from "../schema";
+import { type EditableConditionType, type PolicyFormValues, getDefaultCondition } from "../schema";
 import { ConditionFields } from "./condition-fields";
 import { MATCH_TYPE_OPTIONS } from "./constants";
 
@@ -31,28 +27,32 @@ export function MatchConditionCard({
     <div className="flex flex-col gap-4">
       <div className="flex items-center gap-4">
         <div className="flex-1">
-          <Select
-            value={condition?.type}
-            items={MATCH_TYPE_OPTIONS}
-            onValueChange={(v) => {
-              const newType = v as MatchConditionFormValues["type"];
-              setValue(`matchConditions.${index}`, getDefaultCondition(newType, condition?.id));
-            }}
-          >
-            <SelectTrigger
-              aria-label="Condition type"
-              rightIcon={<ChevronDown className="absolute right-2" iconSize="md-medium" />}
+          {condition?.type === "expression" ? (
+            <p className="text-[13px] text-gray-11">Advanced expression</p>
+          ) : (
+            <Select
+              value={condition?.type}
+              items={MATCH_TYPE_OPTIONS}
+              onValueChange={(v) => {
+                const newType = v as EditableConditionType;
+                setValue(`matchConditions.${index}`, getDefaultCondition(newType, condition?.id));
+              }}
             >
-              <SelectValue />
-            </SelectTrigger>
-            <SelectContent className="z-60">
-              {MATCH_TYPE_OPTIONS.map((opt) => (
-                <SelectItem key={opt.value} value={opt.value}>
-                  {opt.label}
-                </SelectItem>
-              ))}
-            </SelectContent>
-          </Select>
+              <SelectTrigger
+                aria-label="Condition type"
+                rightIcon={<ChevronDown className="absolute right-2" iconSize="md-medium" />}
+              >
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent className="z-60">
+                {MATCH_TYPE_OPTIONS.map((opt) => (
+                  <SelectItem key={opt.value} value={opt.value}>
+                    {opt.label}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+          )}
         </div>
         <Button
           type="button"
@@ -227,6 +227,96 @@ export function ConditionFields({
         </div>
       );
     })
+    .with({ type: "remoteIp" }, (c) => (
+      <FormInput
+        label="IPs or Networks"
+        requirement="required"
+        placeholder="203.0.113.0/24, 2001:db8::/32"
+        value={c.cidrs}
+        onChange={(e) => patch({ ...c, cidrs: e.target.value })}
+        descriptionPosition="label"
+        description="Comma-separated IPv4 or IPv6 addresses or CIDR networks."
+        error={errors?.cidrs?.message}
+      />
+    ))
+    .with({ type: "country" }, (c) => (
+      <FormInput
+        label="Countries"
+        requirement="required"
+        placeholder="US, CA"
+        value={c.countries}
+        onChange={(e) => patch({ ...c, countries: e.target.value })}
+        descriptionPosition="label"
+        description="Comma-separated two-letter country codes, resolved from the client IP."
+        error={errors?.countries?.message}
+      />
+    ))
+    .with({ type: "principal" }, (c) => (
+      <div className="flex flex-col gap-4">
+        <FormInput
+          label="Field"
+          requirement="required"
+          placeholder="source.key.meta.plan"
+          value={c.field}
+          onChange={(e) => patch({ ...c, field: e.target.value })}
+          descriptionPosition="label"
+          description="Dotted path into the principal set by an earlier auth policy."
+          error={errors?.field?.message}
+        />
+        {!c.present && (
+          <div className="flex gap-2">
+            <div className="w-28 shrink-0">
+              <fieldset className="flex flex-col gap-1.5 border-0 m-0 p-0">
+                <label htmlFor={`principal-mode-${c.id}`} className="text-gray-11 text-[13px]">
+                  Mode
+                </label>
+                <Select
+                  value={c.mode ?? "exact"}
+                  onValueChange={(v) => patch({ ...c, mode: v as StringMatchMode })}
+                  items={STRING_MATCH_MODES}
+                >
+                  <SelectTrigger
+                    id={`principal-mode-${c.id}`}
+                    rightIcon={<ChevronDown className="absolute right-2" iconSize="md-medium" />}
+                  >
+                    <SelectValue />
+                  </SelectTrigger>
+                  <SelectContent>
+                    {STRING_MATCH_MODES.map((m) => (
+                      <SelectItem key={m.value} value={m.value}>
+                        {m.label}
+                      </SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+              </fieldset>
+            </div>
+            <FormInput
+              label="Value"
+              requirement="required"
+              placeholder="Expected value"
+              value={c.value ?? ""}
+              onChange={(e) => patch({ ...c, value: e.target.value })}
+              className="flex-1"
+              error={
+                ((c.mode ?? "exact") === "regex" ? validateRegexSyntax(c.value ?? "") : undefined) ??
+                errors?.value?.message
+              }
+            />
+          </div>
+        )}
+      </div>
+    ))
+    .with({ type: "expression" }, (c) => (
+      <div className="flex flex-col gap-1.5">
+        <pre className="text-xs font-mono bg-grayA-2 border border-grayA-4 rounded-lg p-3 overflow-x-auto text-gray-12">
+          {JSON.stringify(c.expr, null, 2)}
+        </pre>
+        <p className="text-[12px] text-gray-11">
+          Combined conditions are edited through the API. Saving keeps this one unchanged.
+        </p>
+      </div>
+    ))
     .exhaustive();
 }
 
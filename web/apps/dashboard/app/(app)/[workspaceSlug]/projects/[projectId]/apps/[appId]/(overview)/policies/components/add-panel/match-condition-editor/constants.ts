@@ -1,5 +1,5 @@
 import type { StringMatchMode } from "@/lib/collections/deploy/policies.schema";
-import type { MatchConditionFormValues } from "../schema";
+import type { EditableConditionType } from "../schema";
 
 export const HTTP_METHODS = ["GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"] as const;
 export type HttpMethod = (typeof HTTP_METHODS)[number];
@@ -10,11 +10,14 @@ export const STRING_MATCH_MODES: { value: StringMatchMode; label: string }[] = [
   { value: "regex", label: "Regex" },
 ];
 
-export const MATCH_TYPE_OPTIONS: { value: MatchConditionFormValues["type"]; label: string }[] = [
+export const MATCH_TYPE_OPTIONS: { value: EditableConditionType; label: string }[] = [
   { value: "path", label: "Path" },
   { value: "method", label: "Method" },
   { value: "header", label: "Header" },
   { value: "queryParam", label: "Query Param" },
+  { value: "remoteIp", label: "Client IP" },
+  { value: "country", label: "Country" },
+  { value: "principal", label: "Principal Field" },
 ];
 
 export function validateRegexSyntax(pattern: string): string | undefined {
@@ -0,0 +1,92 @@
+import { describe, expect, it } from "vitest";
+import { fromPolicy, getDefaultValues, policyFormSchema, toPolicy } from "./schema";
+import type { Policy } from "./schema";
+
+// Exercises the firewall actions: only the group belonging to the chosen
+// action is validated and serialized, and extra response headers set through
+// the API survive an edit.
+function firewallWith(overrides: Record<string, unknown>) {
+  return { ...getDefaultValues("firewall"), name: "p", ...overrides };
+}
+
+describe("firewall actions", () => {
+  it("serializes deny and log without redirect or response", () => {
+    for (const action of ["ACTION_DENY", "ACTION_LOG"] as const) {
+      const parsed = policyFormSchema.parse(firewallWith({ action }));
+      const wire = toPolicy(parsed) as Extract<Policy, { type: "firewall" }>;
+      expect(wire.firewall).toEqual({ action });
+    }
+  });
+
+  it("requires a location for redirects", () => {
+    const r = policyFormSchema.safeParse(firewallWith({ action: "ACTION_REDIRECT" }));
+    expect(r.success).toBe(false);
+  });
+
+  it("rejects a protocol-relative redirect location", () => {
+    const r = policyFormSchema.safeParse(
+      firewallWith({
+        action: "ACTION_REDIRECT",
+        redirect: { location: "//evil.example", statusCode: 302 },
+      }),
+    );
+    expect(r.success).toBe(false);
+  });
+
+  it("serializes a redirect", () => {
+    const parsed = policyFormSchema.parse(
+      firewallWith({
+        action: "ACTION_REDIRECT",
+        redirect: { location: "/login", statusCode: 307 },
+      }),
+    );
+    const wire = toPolicy(parsed) as Extract<Policy, { type: "firewall" }>;
+    expect(wire.firewall).toEqual({
+      action: "ACTION_REDIRECT",
+      redirect: { location: "/login", statusCode: 307 },
+    });
+  });
+
+  it("rejects a response status code outside 200-599", () => {
+    const r = policyFormSchema.safeParse(
+      firewallWith({
+        action: "ACTION_RESPOND",
+        response: { statusCode: 101, contentType: "", body: "", headers: {} },
+      }),
+    );
+    expect(r.success).toBe(false);
+  });
+
+  it("round-trips a custom response with extra headers", () => {
+    const wire: Policy = {
+      id: "policy_1",
+      name: "maintenance",
+      enabled: true,
+      type: "firewall",
+      firewall: {
+        action: "ACTION_RESPOND",
+        response: {
+          statusCode: 503,
+          headers: { "Retry-After": "60", "content-type": "text/html" },
+          body: "<h1>Back soon</h1>",
+        },
+      },
+    };
+    const form = fromPolicy(wire, "__all__");
+    expect(form.type).toBe("firewall");
+    if (form.type !== "firewall") {
+      return;
+    }
+    expect(form.response.contentType).toBe("text/html");
+
+    const back = toPolicy(policyFormSchema.parse(form), "policy_1") as Extract<
+      Policy,
+      { type: "firewall" }
+    >;
+    expect(back.firewall.response).toEqual({
+      statusCode: 503,
+      headers: { "Retry-After": "60", "Content-Type": "text/html" },
+      body: "<h1>Back soon</h1>",
+    });
+  });
+});
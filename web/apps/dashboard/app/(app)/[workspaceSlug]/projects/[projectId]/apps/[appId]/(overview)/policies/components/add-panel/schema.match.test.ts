@@ -0,0 +1,74 @@
+import { describe, expect, it } from "vitest";
+import { fromPolicy, getDefaultValues, policyFormSchema, toPolicy } from "./schema";
+import type { Policy } from "./schema";
+
+// Exercises the client IP, country and principal conditions, and that
+// not/anyOf trees written through the API survive an edit unchanged.
+function firewallWithConditions(matchConditions: unknown[]) {
+  return { ...getDefaultValues("firewall"), name: "p", matchConditions };
+}
+
+describe("match conditions", () => {
+  it("splits networks and upper-cases countries on save", () => {
+    const parsed = policyFormSchema.parse(
+      firewallWithConditions([
+        { id: "a", type: "remoteIp", cidrs: "203.0.113.0/24,\n2001:db8::/32" },
+        { id: "b", type: "country", countries: "us, de" },
+      ]),
+    );
+    expect(toPolicy(parsed).match).toEqual([
+      { remoteIp: { cidrs: ["203.0.113.0/24", "2001:db8::/32"] } },
+      { country: { countries: ["US", "DE"] } },
+    ]);
+  });
+
+  it("rejects country names", () => {
+    const r = policyFormSchema.safeParse(
+      firewallWithConditions([{ id: "a", type: "country", countries: "Germany" }]),
+    );
+    expect(r.success).toBe(false);
+  });
+
+  it("requires a principal value unless present is set", () => {
+    const r = policyFormSchema.safeParse(
+      firewallWithConditions([{ id: "a", type: "principal", field: "subject" }]),
+    );
+    expect(r.success).toBe(false);
+
+    const parsed = policyFormSchema.parse(
+      firewallWithConditions([
+        { id: "a", type: "principal", field: "source.key.meta.plan", mode: "exact", value: "free" },
+      ]),
+    );
+    expect(toPolicy(parsed).match).toEqual([
+      { principal: { field: "source.key.meta.plan", value: { exact: "free" } } },
+    ]);
+  });
+
+  it("keeps combinators and principal absent-matches verbatim", () => {
+    const match = [
+      {
+        not: {
+          anyOf: {
+            exprs: [{ method: { methods: ["GET" as const] } }, { country: { countries: ["US"] } }],
+          },
+        },
+      },
+      { principal: { field: "source.key.meta.plan", present: false } },
+      { path: { path: { prefix: "/api" } } },
+    ];
+    const wire: Policy = {
+      id: "policy_1",
+      name: "geo",
+      enabled: true,
+      type: "firewall",
+      firewall: { action: "ACTION_DENY" },
+      match,
+    };
+    const form = fromPolicy(wire, "__all__");
+    expect(form.matchConditions.map((c) => c.type)).toEqual(["expression", "expression", "path"]);
+
+    const back = toPolicy(policyFormSchema.parse(form), "policy_1");
+    expect(back.match).toEqual(match);
+  });
+});
@@ -70,6 +70,8 @@ function ratelimitForm(
     matchConditions: [],
     limit: 100,
     windowMs: 60000,
+    algorithm: "RATE_LIMIT_ALGORITHM_SLIDING_WINDOW",
+    refillRate: 0,
     identifiers,
   };
 }
@@ -136,3 +138,59 @@ describe("ratelimit identifier serialization", () => {
     });
   });
 });
+
+// Sliding window is the default and stays off the wire, so blobs written
+// before algorithms existed re-save unchanged.
+describe("ratelimit algorithm", () => {
+  const remoteIp = [{ id: "1", source: "remoteIp" as const, value: "" }];
+
+  it("omits the default sliding window", () => {
+    const wire = toPolicy(ratelimitForm(remoteIp));
+    expect(wire).not.toHaveProperty("ratelimit.algorithm");
+    expect(wire).not.toHaveProperty("ratelimit.refillRate");
+  });
+
+  it("serializes a token bucket with its refill rate", () => {
+    const wire = toPolicy({
+      ...ratelimitForm(remoteIp),
+      algorithm: "RATE_LIMIT_ALGORITHM_TOKEN_BUCKET",
+      refillRate: 20,
+    });
+    expect(wire).toMatchObject({
+      ratelimit: { algorithm: "RATE_LIMIT_ALGORITHM_TOKEN_BUCKET", refillRate: 20 },
+    });
+  });
+
+  it("drops the refill rate for other algorithms", () => {
+    const wire = toPolicy({
+      ...ratelimitForm(remoteIp),
+      algorithm: "RATE_LIMIT_ALGORITHM_FIXED_WINDOW",
+      refillRate: 20,
+    });
+    expect(wire).toMatchObject({ ratelimit: { algorithm: "RATE_LIMIT_ALGORITHM_FIXED_WINDOW" } });
+    expect(wire).not.toHaveProperty("ratelimit.refillRate");
+  });
+
+  it("rejects a refill rate above the limit", () => {
+    const r = policyFormSchema.safeParse({
+      ...ratelimitForm(remoteIp),
+      algorithm: "RATE_LIMIT_ALGORITHM_TOKEN_BUCKET",
+      refillRate: 101,
+    });
+    expect(r.success).toBe(false);
+  });
+
+  it("opens a stored policy without an algorithm as a sliding window", () => {
+    const stored: Policy = {
+      id: "pol_1",
+      name: "rl",
+      enabled: true,
+      type: "ratelimit",
+      ratelimit: { limit: 100, windowMs: 60000, identifiers: [{ path: {} }] },
+    };
+    expect(fromPolicy(stored, "__all__")).toMatchObject({
+      algorithm: "RATE_LIMIT_ALGORITHM_SLIDING_WINDOW",
+      refillRate: 0,
+    });
+  });
+});
@@ -8,8 +8,11 @@ import {
   type RateLimitIdentifier,
   type RatelimitPolicy,
   type StringMatch,
+  countryCodeSchema,
   firewallActionSchema,
+  firewallRedirectStatusCodes,
   matchExprSchema,
+  ratelimitAlgorithmSchema,
   stringMatchModeSchema,
 } from "@/lib/collections/deploy/policies.schema";
 import { newUid } from "@unkey/id";
@@ -54,20 +57,62 @@ const queryParamConditionSchema = z.object({
   value: z.string().optional(),
 });
 
-// Header/queryParam conditions match against a stringMatch whose value must be
-// non-empty (canonical stringMatchValue = min(1)) unless `present` is set.
-// Refine on the union so the error attaches to the `value` field — users see a
-// field-level error instead of a generic 500 from savePolicies.
+// Networks and countries are edited as one comma- or whitespace-separated
+// string and split on save.
+const remoteIpConditionSchema = z.object({
+  id: z.string(),
+  type: z.literal("remoteIp"),
+  cidrs: z.string().refine((v) => splitList(v).length > 0, "Add at least one IP or network"),
+});
+
+const countryConditionSchema = z.object({
+  id: z.string(),
+  type: z.literal("country"),
+  countries: z
+    .string()
+    .refine((v) => splitList(v).length > 0, "Add at least one country code")
+    .refine(
+      (v) => splitList(v).every((c) => countryCodeSchema.safeParse(c).success),
+      "Use two-letter country codes, e.g. US, DE",
+    ),
+});
+
+const principalConditionSchema = z.object({
+  id: z.string(),
+  type: z.literal("principal"),
+  field: z.string().min(1, "Field is required"),
+  present: z.boolean().optional(),
+  mode: stringMatchModeSchema.optional(),
+  value: z.string().optional(),
+});
+
+// not/anyOf trees have no form editor. They are kept verbatim so editing a
+// policy written through the API does not drop them.
+const expressionConditionSchema = z.object({
+  id: z.string(),
+  type: z.literal("expression"),
+  expr: matchExprSchema,
+});
+
+// Header/queryParam/principal conditions match against a stringMatch whose
+// value must be non-empty (canonical stringMatchValue = min(1)) unless
+// `present` is set. Refine on the union so the error attaches to the `value`
+// field — users see a field-level error instead of a generic 500 from
+// savePolicies.
 export const matchConditionSchema = z
   .discriminatedUnion("type", [
     pathConditionSchema,
     methodConditionSchema,
     headerConditionSchema,
     queryParamConditionSchema,
+    remoteIpConditionSchema,
+    countryConditionSchema,
+    principalConditionSchema,
+    expressionConditionSchema,
   ])
   .superRefine((c, ctx) => {
     if (
-      (c.type === "header" || c.type === "queryParam") &&
+      (c.type === "header" || c.type === "queryParam" || c.type === "principal") &&
       !c.present &&
       (c.value ?? "").length === 0
     ) {
@@ -79,8 +124,16 @@ export const matchConditionSchema = z
     }
   });
 
+export function splitList(value: string): string[] {
+  return value.split(/[\s,]+/).filter((v) => v.length > 0);
+}
+
 export type MatchConditionFormValues = z.infer<typeof matchConditionSchema>;
 
+// Condition types the editor can create. "expression" only comes from reading
+// API-written policies.
+export type EditableConditionType = Exclude<MatchConditionFormValues["type"], "expression">;
+
 export const keyLocationTypeSchema = z.enum(["bearer", "header", "queryParam"]);
 export type KeyLocationType = z.infer<typeof keyLocationTypeSchema>;
 
@@ -225,6 +278,11 @@ const ratelimitFormSchema = z.object({
   type: z.literal("ratelimit"),
   limit: z.number().int().min(1, "Limit must be at least 1"),
   windowMs: z.number().int().min(1, "Window must be at least 1ms"),
+  algorithm: ratelimitAlgorithmSchema,
+  // Tokens a token bucket regains per window; 0 refills the full limit. The
+  // upper bound depends on limit, so it is checked in the superRefine on
+  // policyFormSchema.
+  refillRate: z.number().int().min(0, "Refill rate must not be negative"),
   // Rows form the identifiers list; 2+ rows form a compound key where each
   // unique combination of resolved values gets its own counter.
   identifiers: z
@@ -233,13 +291,27 @@ const ratelimitFormSchema = z.object({
     .max(POLICY_LIMITS.maxIdentifiersPerRatelimit),
 });
 
-// Firewall has a single action today (DENY) and no other configuration.
-// The action is kept on the form so the wire payload stays self-describing
-// and so adding more actions later is purely additive.
+// The redirect and response groups are always present on the form so the
+// inputs stay controlled while the user switches actions; toPolicy only
+// serializes the group that belongs to the chosen action. Which group is
+// required depends on the action, so that check lives in the superRefine on
+// policyFormSchema rather than here.
 const firewallFormSchema = z.object({
   ...basePolicyFields,
   type: z.literal("firewall"),
   action: firewallActionSchema,
+  redirect: z.object({
+    location: z.string().max(2048),
+    statusCode: z.number().int(),
+  }),
+  response: z.object({
+    statusCode: z.number().int(),
+    contentType: z.string(),
+    body: z.string().max(65536),
+    // Headers other than Content-Type, set through the API. Not editable in
+    // the form but carried through so saving doesn't drop them.
+    headers: z.record(z.string(), z.string()),
+  }),
 });
 
 const openapiFormSchema = z.object({
@@ -257,13 +329,75 @@ const loggingFormSchema = z.object({
   query: z.boolean(),
 });
 
-export const policyFormSchema = z.discriminatedUnion("type", [
-  keyauthFormSchema,
-  ratelimitFormSchema,
-  firewallFormSchema,
-  openapiFormSchema,
-  loggingFormSchema,
-]);
+export const policyFormSchema = z
+  .discriminatedUnion("type", [
+    keyauthFormSchema,
+    ratelimitFormSchema,
+    firewallFormSchema,
+    openapiFormSchema,
+    loggingFormSchema,
+  ])
+  .superRefine((v, ctx) => {
+    if (v.type === "ratelimit") {
+      if (v.algorithm === "RATE_LIMIT_ALGORITHM_TOKEN_BUCKET" && v.refillRate > v.limit) {
+        ctx.addIssue({
+          code: "custom",
+          message: "Refill rate must not exceed the limit",
+          path: ["refillRate"],
+        });
+      }
+      return;
+    }
+    if (v.type !== "firewall") {
+      return;
+    }
+    if (v.action === "ACTION_REDIRECT") {
+      const location = v.redirect.location.trim();
+      if (location === "") {
+        ctx.addIssue({
+          code: "custom",
+          message: "Location is required",
+          path: ["redirect", "location"],
+        });
+      } else if (!isValidRedirectLocation(location)) {
+        ctx.addIssue({
+          code: "custom",
+          message: "Use an http(s) URL or a path starting with /",
+          path: ["redirect", "location"],
+        });
+      }
+      if (!(firewallRedirectStatusCodes as readonly number[]).includes(v.redirect.statusCode)) {
+        ctx.addIssue({
+          code: "custom",
+          message: "Use 301, 302, 303, 307 or 308",
+          path: ["redirect", "statusCode"],
+        });
+      }
+    }
+    if (v.action === "ACTION_RESPOND") {
+      if (v.response.statusCode < 200 || v.response.statusCode > 599) {
+        ctx.addIssue({
+          code: "custom",
+          message: "Status code must be between 200 and 599",
+          path: ["response", "statusCode"],
+        });
+      }
+    }
+  });
+
+// Mirrors the API's redirect location check: an absolute http(s) URL, or a
+// path on the same host. Protocol-relative "//host" would leave the site.
+function isValidRedirectLocation(location: string): boolean {
+  if (location.startsWith("/")) {
+    return !location.startsWith("//") && !location.startsWith("/\\");
+  }
+  try {
+    const url = new URL(location);
+    return url.protocol === "http:" || url.protocol === "https:";
+  } catch {
+    return false;
+  }
+}
 export type PolicyFormValues = z.infer<typeof policyFormSchema>;
 export type PolicyType = PolicyFormValues["type"];
 
@@ -276,7 +410,7 @@ export const POLICY_TYPE_OPTIONS: { value: PolicyType; label: string }[] = [
 ];
 
 export function getDefaultCondition(
-  type: MatchConditionFormValues["type"],
+  type: EditableConditionType,
   id?: string,
 ): MatchConditionFormValues {
   const base = { id: id ?? crypto.randomUUID() };
@@ -289,6 +423,9 @@ export function getDefaultCondition(
     }))
     .with("header", () => ({ ...base, type: "header" as const, name: "" }))
     .with("queryParam", () => ({ ...base, type: "queryParam" as const, name: "" }))
+    .with("remoteIp", () => ({ ...base, type: "remoteIp" as const, cidrs: "" }))
+    .with("country", () => ({ ...base, type: "country" as const, countries: "" }))
+    .with("principal", () => ({ ...base, type: "principal" as const, field: "" }))
     .exhaustive();
 }
 
@@ -314,6 +451,8 @@ export function getDefaultValues(type: PolicyType): PolicyFormValues {
       type: "ratelimit" as const,
       limit: 100,
       windowMs: 60000,
+      algorithm: "RATE_LIMIT_ALGORITHM_SLIDING_WINDOW" as const,
+      refillRate: 0,
       identifiers: [
         { id: crypto.randomUUID(), source: "remoteIp" as const, value: "" },
       ] as RatelimitIdentifierRowValues[],
@@ -322,6 +461,8 @@ export function getDefaultValues(type: PolicyType): PolicyFormValues {
       ...base,
       type: "firewall" as const,
       action: "ACTION_DENY" as const,
+      redirect: { location: "", statusCode: 302 },
+      response: { statusCode: 503, contentType: "", body: "", headers: {} },
     }))
     .with("openapi", () => ({
       ...base,
@@ -386,6 +527,21 @@ function toMatchExpr(condition: MatchConditionFormValues): MatchExpr {
             },
           },
     )
+    .with({ type: "remoteIp" }, (c) => ({ remoteIp: { cidrs: splitList(c.cidrs) } }))
+    .with({ type: "country" }, (c) => ({
+      country: { countries: splitList(c.countries).map((cc) => cc.toUpperCase()) },
+    }))
+    .with({ type: "principal" }, (c) =>
+      c.present
+        ? { principal: { field: c.field, present: true } }
+        : {
+            principal: {
+              field: c.field,
+              value: toStringMatch(c.mode ?? "exact", c.value ?? ""),
+            },
+          },
+    )
+    .with({ type: "expression" }, (c) => c.expr)
     .exhaustive();
 }
 
@@ -403,6 +559,33 @@ function toRateLimitIdentifier(
     .exhaustive();
 }
 
+function toFirewallConfig(
+  v: Extract<PolicyFormValues, { type: "firewall" }>,
+): FirewallPolicy["firewall"] {
+  return match(v.action)
+    .returnType<FirewallPolicy["firewall"]>()
+    .with("ACTION_REDIRECT", (action) => ({
+      action,
+      redirect: { location: v.redirect.location.trim(), statusCode: v.redirect.statusCode },
+    }))
+    .with("ACTION_RESPOND", (action) => {
+      const headers = { ...v.response.headers };
+      if (v.response.contentType.trim() !== "") {
+        headers["Content-Type"] = v.response.contentType.trim();
+      }
+      return {
+        action,
+        response: {
+          statusCode: v.response.statusCode,
+          ...(Object.keys(headers).length > 0 ? { headers } : {}),
+          ...(v.response.body !== "" ? { body: v.response.body } : {}),
+        },
+      };
+    })
+    .with("ACTION_DENY", "ACTION_LOG", (action) => ({ action }))
+    .exhaustive();
+}
+
 export function toPolicy(
   values: PolicyFormValues,
   existingId?: string,
@@ -456,6 +639,15 @@ export function toPolicy(
       ratelimit: {
         limit: v.limit,
         windowMs: v.windowMs,
+        // Sliding window is the default, so it is left off the wire to keep
+        // blobs written before algorithms existed byte-identical on re-save.
+        ...(v.algorithm !== "RATE_LIMIT_ALGORITHM_SLIDING_WINDOW" &&
+        v.algorithm !== "RATE_LIMIT_ALGORITHM_UNSPECIFIED"
+          ? { algorithm: v.algorithm }
+          : {}),
+        ...(v.algorithm === "RATE_LIMIT_ALGORITHM_TOKEN_BUCKET" && v.refillRate > 0
+          ? { refillRate: v.refillRate }
+          : {}),
         // Always serialize the identifiers array, even for one row, so all
         // writes converge on the target shape. Deserialization still reads
         // the deprecated single identifier from old stored policies.
@@ -468,7 +660,7 @@ export function toPolicy(
       name: v.name,
       enabled: true,
       type: "firewall" as const,
-      firewall: { action: v.action },
+      firewall: toFirewallConfig(v),
       match: matchExprs,
     }))
     .with({ type: "openapi" }, (v) => ({
@@ -540,6 +732,28 @@ function fromMatchExpr(raw: unknown): MatchConditionFormValues | null {
       const { mode, value } = stringMatchToMode(e.queryParam.value);
       return { id, type: "queryParam" as const, name: e.queryParam.name, mode, value };
     })
+    .with({ remoteIp: P._ }, (e) => ({
+      id,
+      type: "remoteIp" as const,
+      cidrs: e.remoteIp.cidrs.join(", "),
+    }))
+    .with({ country: P._ }, (e) => ({
+      id,
+      type: "country" as const,
+      countries: e.country.countries.join(", "),
+    }))
+    .with({ principal: P._ }, (e) => {
+      if ("present" in e.principal) {
+        // The form has no absent-match toggle; keep present=false verbatim.
+        if (!e.principal.present) {
+          return { id, type: "expression" as const, expr: e };
+        }
+        return { id, type: "principal" as const, field: e.principal.field, present: true };
+      }
+      const { mode, value } = stringMatchToMode(e.principal.value);
+      return { id, type: "principal" as const, field: e.principal.field, mode, value };
+    })
+    .with({ not: P._ }, { anyOf: P._ }, (e) => ({ id, type: "expression" as const, expr: e }))
     .exhaustive();
 }
 
@@ -626,6 +840,12 @@ export function fromPolicy(policy: Policy, environmentId: string): PolicyFormVal
         matchConditions,
         limit: p.ratelimit.limit,
         windowMs: p.ratelimit.windowMs,
+        algorithm:
+          p.ratelimit.algorithm === undefined ||
+          p.ratelimit.algorithm === "RATE_LIMIT_ALGORITHM_UNSPECIFIED"
+            ? ("RATE_LIMIT_ALGORITHM_SLIDING_WINDOW" as const)
+            : p.ratelimit.algorithm,
+        refillRate: p.ratelimit.refillRate ?? 0,
         identifiers: wireIdentifiers.map(fromRateLimitIdentifier),
       };
     })
@@ -634,12 +854,34 @@ export function fromPolicy(policy: Policy, environmentId: string): PolicyFormVal
         .map(fromMatchExpr)
         .filter((c): c is MatchConditionFormValues => c !== null);
 
+      // Content-Type gets its own input; every other header is carried
+      // through untouched. Header names are case-insensitive on the wire.
+      const headers: Record<string, string> = {};
+      let contentType = "";
+      for (const [name, value] of Object.entries(p.firewall.response?.headers ?? {})) {
+        if (name.toLowerCase() === "content-type") {
+          contentType = value;
+        } else {
+          headers[name] = value;
+        }
+      }
+
       return {
         type: "firewall" as const,
         name: p.name,
         environmentId,
         matchConditions,
         action: p.firewall.action,
+        redirect: {
+          location: p.firewall.redirect?.location ?? "",
+          statusCode: p.firewall.redirect?.statusCode ?? 302,
+        },
+        response: {
+          statusCode: p.firewall.response?.statusCode ?? 503,
+          contentType,
+          body: p.firewall.response?.body ?? "",
+          headers,
+        },
       };
     })
     .with({ type: "openapi" }, (p) => ({
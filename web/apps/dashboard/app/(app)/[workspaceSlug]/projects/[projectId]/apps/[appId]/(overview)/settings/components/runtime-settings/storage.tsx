@@ -22,7 +22,7 @@ const storageConfig = defineResourceSlider({
   title: "Storage",
   description: "Ephemeral disk space per instance",
   settingDescription:
-    "We wipe this volume when the instance stops, so don't keep anything you need on it. Changes apply on next deploy. Contact support@unkey.com for larger volumes.",
+    "We wipe this volume when the instance stops, so don't keep anything you need on it. Changes apply on next deploy. For data that must survive restarts, create a persistent volume with the environments.createVolume API.",
   colorVar: "successA",
   options: STORAGE_OPTIONS,
   fallback: 0,
@@ -235,6 +235,30 @@ export const workspacePermissions = {
       permission: "portal.*.create_portal_session",
     },
   },
+  Webhooks: {
+    create_webhook: {
+      description: "Register new webhook endpoints in this workspace",
+      permission: "webhook.*.create_webhook",
+    },
+    read_webhook: {
+      description: "List webhook endpoints and their delivery logs in this workspace",
+      permission: "webhook.*.read_webhook",
+    },
+    update_webhook: {
+      description: "Update webhook endpoints and replay deliveries in this workspace",
+      permission: "webhook.*.update_webhook",
+    },
+    delete_webhook: {
+      description: "Delete webhook endpoints in this workspace",
+      permission: "webhook.*.delete_webhook",
+    },
+  },
+  "Audit Logs": {
+    read_audit_log: {
+      description: "List and export the audit logs of this workspace",
+      permission: "workspace.*.read_audit_log",
+    },
+  },
   Apps: {
     create_app: {
       description: "Create new apps in any project in this workspace",
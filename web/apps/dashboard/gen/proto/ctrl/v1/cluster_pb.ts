@@ -22,7 +22,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file ctrl/v1/cluster.proto.
  */
 export const file_ctrl_v1_cluster: GenFile = /*@__PURE__*/
-  fileDesc("ChVjdHJsL3YxL2NsdXN0ZXIucHJvdG8SB2N0cmwudjEiPwoKQ2x1c3RlcktleRIQCghwbGF0Zm9ybRgBIAEoCRIOCgZyZWdpb24YAiABKAkSDwoHY2VsbF9pZBgDIAEoCSJwCh1XYXRjaERlcGxveW1lbnRDaGFuZ2VzUmVxdWVzdBIkCgdjbHVzdGVyGAEgASgLMhMuY3RybC52MS5DbHVzdGVyS2V5EhkKEXZlcnNpb25fbGFzdF9zZWVuGAIgASgEEg4KBnJlcGxheRgDIAEoCCI/ChdTeW5jRGVzaXJlZFN0YXRlUmVxdWVzdBIkCgdjbHVzdGVyGAEgASgLMhMuY3RybC52MS5DbHVzdGVyS2V5ImEKFURlcGxveW1lbnRDaGFuZ2VFdmVudBIPCgd2ZXJzaW9uGAEgASgEEi4KCmRlcGxveW1lbnQYAiABKAsyGC5jdHJsLnYxLkRlcGxveW1lbnRTdGF0ZUgAQgcKBWV2ZW50Il8KIEdldERlc2lyZWREZXBsb3ltZW50U3RhdGVSZXF1ZXN0EiQKB2NsdXN0ZXIYASABKAsyEy5jdHJsLnYxLkNsdXN0ZXJLZXkSFQoNZGVwbG95bWVudF9pZBgCIAEoCSLdBAodUmVwb3J0RGVwbG95bWVudFN0YXR1c1JlcXVlc3QSJAoHY2x1c3RlchgDIAEoCzITLmN0cmwudjEuQ2x1c3RlcktleRI/CgZ1cGRhdGUYASABKAsyLS5jdHJsLnYxLlJlcG9ydERlcGxveW1lbnRTdGF0dXNSZXF1ZXN0LlVwZGF0ZUgAEj8KBmRlbGV0ZRgCIAEoCzItLmN0cmwudjEuUmVwb3J0RGVwbG95bWVudFN0YXR1c1JlcXVlc3QuRGVsZXRlSAAa7QIKBlVwZGF0ZRIQCghrOHNfbmFtZRgBIAEoCRJJCglpbnN0YW5jZXMYAiADKAsyNi5jdHJsLnYxLlJlcG9ydERlcGxveW1lbnRTdGF0dXNSZXF1ZXN0LlVwZGF0ZS5JbnN0YW5jZRqFAgoISW5zdGFuY2USEAoIazhzX25hbWUYASABKAkSDwoHYWRkcmVzcxgCIAEoCRIWCg5jcHVfbWlsbGljb3JlcxgDIAEoAxISCgptZW1vcnlfbWliGAQgASgDEk0KBnN0YXR1cxgFIAEoDjI9LmN0cmwudjEuUmVwb3J0RGVwbG95bWVudFN0YXR1c1JlcXVlc3QuVXBkYXRlLkluc3RhbmNlLlN0YXR1cyJbCgZTdGF0dXMSFgoSU1RBVFVTX1VOU1BFQ0lGSUVEEAASEgoOU1RBVFVTX1BFTkRJTkcQARISCg5TVEFUVVNfUlVOTklORxACEhEKDVNUQVRVU19GQUlMRUQQAxoaCgZEZWxldGUSEAoIazhzX25hbWUYASABKAlCCAoGY2hhbmdlIiAKHlJlcG9ydERlcGxveW1lbnRTdGF0dXNSZXNwb25zZSKJBAoNSW5zdGFuY2VFdmVudBIPCgdwb2RfdWlkGAEgASgJEhAKCHBvZF9uYW1lGAIgASgJEhEKCW5vZGVfbmFtZRgDIAEoCRIWCg5jb250YWluZXJfbmFtZRgEIAEoCRIUCgxjb250YWluZXJfaWQYBSABKAkSFQoNcmVzdGFydF9jb3VudBgGIAEoBRIUCgx3b3Jrc3BhY2VfaWQYByABKAkSEgoKcHJvamVjdF9pZBgIIAEoCRIOCgZhcHBfaWQYCSABKAkSFgoOZW52aXJvbm1lbnRfaWQYCiABKAkSFQoNZGVwbG95bWVudF9pZBgLIAEoCRIMCgR0aW1lGAwgASgDEhkKEWV2ZW50X2ZpbmdlcnByaW50GA0gASgJEiMKB3J1bm5pbmcYDiABKAsyEC5jdHJsLnYxLlJ1bm5pbmdIABIpCgp0ZXJtaW5hdGVkGA8gASgLMhMuY3RybC52MS5UZXJtaW5hdGVkSAASIwoHd2FpdGluZxgQIAEoCzIQLmN0cmwudjEuV2FpdGluZ0gAEjoKCmF0dHJpYnV0ZXMYESADKAsyJi5jdHJsLnYxLkluc3RhbmNlRXZlbnQuQXR0cmlidXRlc0VudHJ5GjEKD0F0dHJpYnV0ZXNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBQgcKBXN0YXRlIgkKB1J1bm5pbmciUAoKVGVybWluYXRlZBIRCglleGl0X2NvZGUYASABKAUSDgoGc2lnbmFsGAIgASgFEg4KBnJlYXNvbhgDIAEoCRIPCgdtZXNzYWdlGAQgASgJIioKB1dhaXRpbmcSDgoGcmVhc29uGAEgASgJEg8KB21lc3NhZ2UYAiABKAkiawobUmVwb3J0SW5zdGFuY2VFdmVudHNSZXF1ZXN0EiYKBmV2ZW50cxgBIAMoCzIWLmN0cmwudjEuSW5zdGFuY2VFdmVudBIkCgdjbHVzdGVyGAIgASgLMhMuY3RybC52MS5DbHVzdGVyS2V5Ih4KHFJlcG9ydEluc3RhbmNlRXZlbnRzUmVzcG9uc2UigwEKD0RlcGxveW1lbnRTdGF0ZRIPCgd2ZXJzaW9uGAMgASgEEikKBWFwcGx5GAEgASgLMhguY3RybC52MS5BcHBseURlcGxveW1lbnRIABIrCgZkZWxldGUYAiABKAsyGS5jdHJsLnYxLkRlbGV0ZURlcGxveW1lbnRIAEIHCgVzdGF0ZSKXBgoPQXBwbHlEZXBsb3ltZW50EhUKDWs4c19uYW1lc3BhY2UYASABKAkSEAoIazhzX25hbWUYAiABKAkSFAoMd29ya3NwYWNlX2lkGAMgASgJEhIKCnByb2plY3RfaWQYBCABKAkSFgoOZW52aXJvbm1lbnRfaWQYBSABKAkSFQoNZGVwbG95bWVudF9pZBgGIAEoCRINCgVpbWFnZRgHIAEoCRIWCg5jcHVfbWlsbGljb3JlcxgJIAEoAxISCgptZW1vcnlfbWliGAogASgDEhUKCGJ1aWxkX2lkGAsgASgJSACIAQESJwofZW5jcnlwdGVkX2Vudmlyb25tZW50X3ZhcmlhYmxlcxgMIAEoDBIPCgdjb21tYW5kGA0gAygJEgwKBHBvcnQYDiABKAUSFwoPc2h1dGRvd25fc2lnbmFsGA8gASgJEhgKC2hlYWx0aGNoZWNrGBEgASgMSAGIAQESDgoGYXBwX2lkGBIgASgJEh0KEGVudmlyb25tZW50X3NsdWcYFSABKAlIAogBARITCgZyZWdpb24YFiABKAlIA4gBARIbCg5naXRfY29tbWl0X3NoYRgXIAEoCUgEiAEBEhcKCmdpdF9icmFuY2gYGCABKAlIBYgBARIVCghnaXRfcmVwbxgZIAEoCUgGiAEBEh8KEmdpdF9jb21taXRfbWVzc2FnZRgaIAEoCUgHiAEBEi8KC2F1dG9zY2FsaW5nGBsgASgLMhouY3RybC52MS5BdXRvc2NhbGluZ1BvbGljeRI5ChFlcGhlbWVyYWxfc3RvcmFnZRgdIAEoCzIZLmN0cmwudjEuRXBoZW1lcmFsU3RvcmFnZUgIiAEBQgsKCV9idWlsZF9pZEIOCgxfaGVhbHRoY2hlY2tCEwoRX2Vudmlyb25tZW50X3NsdWdCCQoHX3JlZ2lvbkIRCg9fZ2l0X2NvbW1pdF9zaGFCDQoLX2dpdF9icmFuY2hCCwoJX2dpdF9yZXBvQhUKE19naXRfY29tbWl0X21lc3NhZ2VCFAoSX2VwaGVtZXJhbF9zdG9yYWdlIqEBChFBdXRvc2NhbGluZ1BvbGljeRIUCgxtaW5fcmVwbGljYXMYASABKA0SFAoMbWF4X3JlcGxpY2FzGAIgASgNEhoKDWNwdV90aHJlc2hvbGQYAyABKAVIAIgBARIdChBtZW1vcnlfdGhyZXNob2xkGAQgASgFSAGIAQFCEAoOX2NwdV90aHJlc2hvbGRCEwoRX21lbW9yeV90aHJlc2hvbGQiOwoQRGVsZXRlRGVwbG95bWVudBIVCg1rOHNfbmFtZXNwYWNlGAEgASgJEhAKCGs4c19uYW1lGAIgASgJIjgKEEhlYXJ0YmVhdFJlcXVlc3QSJAoHY2x1c3RlchgBIAEoCzITLmN0cmwudjEuQ2x1c3RlcktleSITChFIZWFydGJlYXRSZXNwb25zZSpdCgZIZWFsdGgSFgoSSEVBTFRIX1VOU1BFQ0lGSUVEEAASEgoOSEVBTFRIX0hFQUxUSFkQARIUChBIRUFMVEhfVU5IRUFMVEhZEAISEQoNSEVBTFRIX1BBVVNFRBADMsIECg5DbHVzdGVyU2VydmljZRJiChZXYXRjaERlcGxveW1lbnRDaGFuZ2VzEiYuY3RybC52MS5XYXRjaERlcGxveW1lbnRDaGFuZ2VzUmVxdWVzdBoeLmN0cmwudjEuRGVwbG95bWVudENoYW5nZUV2ZW50MAESVgoQU3luY0Rlc2lyZWRTdGF0ZRIgLmN0cmwudjEuU3luY0Rlc2lyZWRTdGF0ZVJlcXVlc3QaHi5jdHJsLnYxLkRlcGxveW1lbnRDaGFuZ2VFdmVudDABEmAKGUdldERlc2lyZWREZXBsb3ltZW50U3RhdGUSKS5jdHJsLnYxLkdldERlc2lyZWREZXBsb3ltZW50U3RhdGVSZXF1ZXN0GhguY3RybC52MS5EZXBsb3ltZW50U3RhdGUSaQoWUmVwb3J0RGVwbG95bWVudFN0YXR1cxImLmN0cmwudjEuUmVwb3J0RGVwbG95bWVudFN0YXR1c1JlcXVlc3QaJy5jdHJsLnYxLlJlcG9ydERlcGxveW1lbnRTdGF0dXNSZXNwb25zZRJjChRSZXBvcnRJbnN0YW5jZUV2ZW50cxIkLmN0cmwudjEuUmVwb3J0SW5zdGFuY2VFdmVudHNSZXF1ZXN0GiUuY3RybC52MS5SZXBvcnRJbnN0YW5jZUV2ZW50c1Jlc3BvbnNlEkIKCUhlYXJ0YmVhdBIZLmN0cmwudjEuSGVhcnRiZWF0UmVxdWVzdBoaLmN0cmwudjEuSGVhcnRiZWF0UmVzcG9uc2VCiwEKC2NvbS5jdHJsLnYxQgxDbHVzdGVyUHJvdG9QAVoxZ2l0aHViLmNvbS91bmtleWVkL3Vua2V5L2dlbi9wcm90by9jdHJsL3YxO2N0cmx2MaICA0NYWKoCB0N0cmwuVjHKAgdDdHJsXFYx4gITQ3RybFxWMVxHUEJNZXRhZGF0YeoCCEN0cmw6OlYxYgZwcm90bzM", [file_ctrl_v1_deployment]);
+  fileDesc("ChVjdHJsL3YxL2NsdXN0ZXIucHJvdG8SB2N0cmwudjEiPwoKQ2x1c3RlcktleRIQCghwbGF0Zm9ybRgBIAEoCRIOCgZyZWdpb24YAiABKAkSDwoHY2VsbF9pZBgDIAEoCSJwCh1XYXRjaERlcGxveW1lbnRDaGFuZ2VzUmVxdWVzdBIkCgdjbHVzdGVyGAEgASgLMhMuY3RybC52MS5DbHVzdGVyS2V5EhkKEXZlcnNpb25fbGFzdF9zZWVuGAIgASgEEg4KBnJlcGxheRgDIAEoCCI/ChdTeW5jRGVzaXJlZFN0YXRlUmVxdWVzdBIkCgdjbHVzdGVyGAEgASgLMhMuY3RybC52MS5DbHVzdGVyS2V5IpEBChVEZXBsb3ltZW50Q2hhbmdlRXZlbnQSDwoHdmVyc2lvbhgBIAEoBBIuCgpkZXBsb3ltZW50GAIgASgLMhguY3RybC52MS5EZXBsb3ltZW50U3RhdGVIABIuCg1kZWxldGVfdm9sdW1lGAMgASgLMhUuY3RybC52MS5EZWxldGVWb2x1bWVIAEIHCgVldmVudCJfCiBHZXREZXNpcmVkRGVwbG95bWVudFN0YXRlUmVxdWVzdBIkCgdjbHVzdGVyGAEgASgLMhMuY3RybC52MS5DbHVzdGVyS2V5EhUKDWRlcGxveW1lbnRfaWQYAiABKAki3QQKHVJlcG9ydERlcGxveW1lbnRTdGF0dXNSZXF1ZXN0EiQKB2NsdXN0ZXIYAyABKAsyEy5jdHJsLnYxLkNsdXN0ZXJLZXkSPwoGdXBkYXRlGAEgASgLMi0uY3RybC52MS5SZXBvcnREZXBsb3ltZW50U3RhdHVzUmVxdWVzdC5VcGRhdGVIABI/CgZkZWxldGUYAiABKAsyLS5jdHJsLnYxLlJlcG9ydERlcGxveW1lbnRTdGF0dXNSZXF1ZXN0LkRlbGV0ZUgAGu0CCgZVcGRhdGUSEAoIazhzX25hbWUYASABKAkSSQoJaW5zdGFuY2VzGAIgAygLMjYuY3RybC52MS5SZXBvcnREZXBsb3ltZW50U3RhdHVzUmVxdWVzdC5VcGRhdGUuSW5zdGFuY2UahQIKCEluc3RhbmNlEhAKCGs4c19uYW1lGAEgASgJEg8KB2FkZHJlc3MYAiABKAkSFgoOY3B1X21pbGxpY29yZXMYAyABKAMSEgoKbWVtb3J5X21pYhgEIAEoAxJNCgZzdGF0dXMYBSABKA4yPS5jdHJsLnYxLlJlcG9ydERlcGxveW1lbnRTdGF0dXNSZXF1ZXN0LlVwZGF0ZS5JbnN0YW5jZS5TdGF0dXMiWwoGU3RhdHVzEhYKElNUQVRVU19VTlNQRUNJRklFRBAAEhIKDlNUQVRVU19QRU5ESU5HEAESEgoOU1RBVFVTX1JVTk5JTkcQAhIRCg1TVEFUVVNfRkFJTEVEEAMaGgoGRGVsZXRlEhAKCGs4c19uYW1lGAEgASgJQggKBmNoYW5nZSIgCh5SZXBvcnREZXBsb3ltZW50U3RhdHVzUmVzcG9uc2UiiQQKDUluc3RhbmNlRXZlbnQSDwoHcG9kX3VpZBgBIAEoCRIQCghwb2RfbmFtZRgCIAEoCRIRCglub2RlX25hbWUYAyABKAkSFgoOY29udGFpbmVyX25hbWUYBCABKAkSFAoMY29udGFpbmVyX2lkGAUgASgJEhUKDXJlc3RhcnRfY291bnQYBiABKAUSFAoMd29ya3NwYWNlX2lkGAcgASgJEhIKCnByb2plY3RfaWQYCCABKAkSDgoGYXBwX2lkGAkgASgJEhYKDmVudmlyb25tZW50X2lkGAogASgJEhUKDWRlcGxveW1lbnRfaWQYCyABKAkSDAoEdGltZRgMIAEoAxIZChFldmVudF9maW5nZXJwcmludBgNIAEoCRIjCgdydW5uaW5nGA4gASgLMhAuY3RybC52MS5SdW5uaW5nSAASKQoKdGVybWluYXRlZBgPIAEoCzITLmN0cmwudjEuVGVybWluYXRlZEgAEiMKB3dhaXRpbmcYECABKAsyEC5jdHJsLnYxLldhaXRpbmdIABI6CgphdHRyaWJ1dGVzGBEgAygLMiYuY3RybC52MS5JbnN0YW5jZUV2ZW50LkF0dHJpYnV0ZXNFbnRyeRoxCg9BdHRyaWJ1dGVzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4AUIHCgVzdGF0ZSIJCgdSdW5uaW5nIlAKClRlcm1pbmF0ZWQSEQoJZXhpdF9jb2RlGAEgASgFEg4KBnNpZ25hbBgCIAEoBRIOCgZyZWFzb24YAyABKAkSDwoHbWVzc2FnZRgEIAEoCSIqCgdXYWl0aW5nEg4KBnJlYXNvbhgBIAEoCRIPCgdtZXNzYWdlGAIgASgJImsKG1JlcG9ydEluc3RhbmNlRXZlbnRzUmVxdWVzdBImCgZldmVudHMYASADKAsyFi5jdHJsLnYxLkluc3RhbmNlRXZlbnQSJAoHY2x1c3RlchgCIAEoCzITLmN0cmwudjEuQ2x1c3RlcktleSIeChxSZXBvcnRJbnN0YW5jZUV2ZW50c1Jlc3BvbnNlIoMBCg9EZXBsb3ltZW50U3RhdGUSDwoHdmVyc2lvbhgDIAEoBBIpCgVhcHBseRgBIAEoCzIYLmN0cmwudjEuQXBwbHlEZXBsb3ltZW50SAASKwoGZGVsZXRlGAIgASgLMhkuY3RybC52MS5EZWxldGVEZXBsb3ltZW50SABCBwoFc3RhdGUivgYKD0FwcGx5RGVwbG95bWVudBIVCg1rOHNfbmFtZXNwYWNlGAEgASgJEhAKCGs4c19uYW1lGAIgASgJEhQKDHdvcmtzcGFjZV9pZBgDIAEoCRISCgpwcm9qZWN0X2lkGAQgASgJEhYKDmVudmlyb25tZW50X2lkGAUgASgJEhUKDWRlcGxveW1lbnRfaWQYBiABKAkSDQoFaW1hZ2UYByABKAkSFgoOY3B1X21pbGxpY29yZXMYCSABKAMSEgoKbWVtb3J5X21pYhgKIAEoAxIVCghidWlsZF9pZBgLIAEoCUgAiAEBEicKH2VuY3J5cHRlZF9lbnZpcm9ubWVudF92YXJpYWJsZXMYDCABKAwSDwoHY29tbWFuZBgNIAMoCRIMCgRwb3J0GA4gASgFEhcKD3NodXRkb3duX3NpZ25hbBgPIAEoCRIYCgtoZWFsdGhjaGVjaxgRIAEoDEgBiAEBEg4KBmFwcF9pZBgSIAEoCRIdChBlbnZpcm9ubWVudF9zbHVnGBUgASgJSAKIAQESEwoGcmVnaW9uGBYgASgJSAOIAQESGwoOZ2l0X2NvbW1pdF9zaGEYFyABKAlIBIgBARIXCgpnaXRfYnJhbmNoGBggASgJSAWIAQESFQoIZ2l0X3JlcG8YGSABKAlIBogBARIfChJnaXRfY29tbWl0X21lc3NhZ2UYGiABKAlIB4gBARIvCgthdXRvc2NhbGluZxgbIAEoCzIaLmN0cmwudjEuQXV0b3NjYWxpbmdQb2xpY3kSOQoRZXBoZW1lcmFsX3N0b3JhZ2UYHSABKAsyGS5jdHJsLnYxLkVwaGVtZXJhbFN0b3JhZ2VICIgBARIlCgd2b2x1bWVzGB4gAygLMhQuY3RybC52MS5Wb2x1bWVNb3VudEILCglfYnVpbGRfaWRCDgoMX2hlYWx0aGNoZWNrQhMKEV9lbnZpcm9ubWVudF9zbHVnQgkKB19yZWdpb25CEQoPX2dpdF9jb21taXRfc2hhQg0KC19naXRfYnJhbmNoQgsKCV9naXRfcmVwb0IVChNfZ2l0X2NvbW1pdF9tZXNzYWdlQhQKEl9lcGhlbWVyYWxfc3RvcmFnZSJYCgtWb2x1bWVNb3VudBIRCgl2b2x1bWVfaWQYASABKAkSEAoIazhzX25hbWUYAiABKAkSEgoKbW91bnRfcGF0aBgDIAEoCRIQCghzaXplX21pYhgEIAEoAyLPAQoRQXV0b3NjYWxpbmdQb2xpY3kSFAoMbWluX3JlcGxpY2FzGAEgASgNEhQKDG1heF9yZXBsaWNhcxgCIAEoDRIaCg1jcHVfdGhyZXNob2xkGAMgASgFSACIAQESHQoQbWVtb3J5X3RocmVzaG9sZBgEIAEoBUgBiAEBEhoKDXJwc190aHJlc2hvbGQYBSABKAVIAogBAUIQCg5fY3B1X3RocmVzaG9sZEITChFfbWVtb3J5X3RocmVzaG9sZEIQCg5fcnBzX3RocmVzaG9sZCI7ChBEZWxldGVEZXBsb3ltZW50EhUKDWs4c19uYW1lc3BhY2UYASABKAkSEAoIazhzX25hbWUYAiABKAkiSgoMRGVsZXRlVm9sdW1lEhUKDWs4c19uYW1lc3BhY2UYASABKAkSEAoIazhzX25hbWUYAiABKAkSEQoJdm9sdW1lX2lkGAMgASgJIjgKEEhlYXJ0YmVhdFJlcXVlc3QSJAoHY2x1c3RlchgBIAEoCzITLmN0cmwudjEuQ2x1c3RlcktleSITChFIZWFydGJlYXRSZXNwb25zZSJgCiBHZXREZXBsb3ltZW50UmVxdWVzdFJhdGVzUmVxdWVzdBIkCgdjbHVzdGVyGAEgASgLMhMuY3RybC52MS5DbHVzdGVyS2V5EhYKDmRlcGxveW1lbnRfaWRzGAIgAygJIr0BCiFHZXREZXBsb3ltZW50UmVxdWVzdFJhdGVzUmVzcG9uc2USXgoTcmVxdWVzdHNfcGVyX3NlY29uZBgBIAMoCzJBLmN0cmwudjEuR2V0RGVwbG95bWVudFJlcXVlc3RSYXRlc1Jlc3BvbnNlLlJlcXVlc3RzUGVyU2Vjb25kRW50cnkaOAoWUmVxdWVzdHNQZXJTZWNvbmRFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAE6AjgBKl0KBkhlYWx0aBIWChJIRUFMVEhfVU5TUEVDSUZJRUQQABISCg5IRUFMVEhfSEVBTFRIWRABEhQKEEhFQUxUSF9VTkhFQUxUSFkQAhIRCg1IRUFMVEhfUEFVU0VEEAMytgUKDkNsdXN0ZXJTZXJ2aWNlEmIKFldhdGNoRGVwbG95bWVudENoYW5nZXMSJi5jdHJsLnYxLldhdGNoRGVwbG95bWVudENoYW5nZXNSZXF1ZXN0Gh4uY3RybC52MS5EZXBsb3ltZW50Q2hhbmdlRXZlbnQwARJWChBTeW5jRGVzaXJlZFN0YXRlEiAuY3RybC52MS5TeW5jRGVzaXJlZFN0YXRlUmVxdWVzdBoeLmN0cmwudjEuRGVwbG95bWVudENoYW5nZUV2ZW50MAESYAoZR2V0RGVzaXJlZERlcGxveW1lbnRTdGF0ZRIpLmN0cmwudjEuR2V0RGVzaXJlZERlcGxveW1lbnRTdGF0ZVJlcXVlc3QaGC5jdHJsLnYxLkRlcGxveW1lbnRTdGF0ZRJpChZSZXBvcnREZXBsb3ltZW50U3RhdHVzEiYuY3RybC52MS5SZXBvcnREZXBsb3ltZW50U3RhdHVzUmVxdWVzdBonLmN0cmwudjEuUmVwb3J0RGVwbG95bWVudFN0YXR1c1Jlc3BvbnNlEmMKFFJlcG9ydEluc3RhbmNlRXZlbnRzEiQuY3RybC52MS5SZXBvcnRJbnN0YW5jZUV2ZW50c1JlcXVlc3QaJS5jdHJsLnYxLlJlcG9ydEluc3RhbmNlRXZlbnRzUmVzcG9uc2USQgoJSGVhcnRiZWF0EhkuY3RybC52MS5IZWFydGJlYXRSZXF1ZXN0GhouY3RybC52MS5IZWFydGJlYXRSZXNwb25zZRJyChlHZXREZXBsb3ltZW50UmVxdWVzdFJhdGVzEikuY3RybC52MS5HZXREZXBsb3ltZW50UmVxdWVzdFJhdGVzUmVxdWVzdBoqLmN0cmwudjEuR2V0RGVwbG95bWVudFJlcXVlc3RSYXRlc1Jlc3BvbnNlQosBCgtjb20uY3RybC52MUIMQ2x1c3RlclByb3RvUAFaMWdpdGh1Yi5jb20vdW5rZXllZC91bmtleS9nZW4vcHJvdG8vY3RybC92MTtjdHJsdjGiAgNDWFiqAgdDdHJsLlYxygIHQ3RybFxWMeICE0N0cmxcVjFcR1BCTWV0YWRhdGHqAghDdHJsOjpWMWIGcHJvdG8z", [file_ctrl_v1_deployment]);
 
 /**
  * ClusterKey identifies an infrastructure cell on the wire. Every
@@ -125,6 +125,12 @@ export type DeploymentChangeEvent = Message<"ctrl.v1.DeploymentChangeEvent"> & {
      */
     value: DeploymentState;
     case: "deployment";
+  } | {
+    /**
+     * @generated from field: ctrl.v1.DeleteVolume delete_volume = 3;
+     */
+    value: DeleteVolume;
+    case: "deleteVolume";
   } | { case: undefined; value?: undefined };
 };
 
@@ -829,6 +835,17 @@ export type ApplyDeployment = Message<"ctrl.v1.ApplyDeployment"> & {
    * @generated from field: optional ctrl.v1.EphemeralStorage ephemeral_storage = 29;
    */
   ephemeralStorage?: EphemeralStorage;
+
+  /**
+   * volumes are the app's named persistent volumes in this environment.
+   * Unlike ephemeral_storage they are not owned by the deployment: Krane
+   * provisions one PVC per volume that every deployment of the app mounts, so
+   * the data survives deploys and rollbacks. The claims are deleted only when
+   * the control plane sends DeleteVolume.
+   *
+   * @generated from field: repeated ctrl.v1.VolumeMount volumes = 30;
+   */
+  volumes: VolumeMount[];
 };
 
 /**
@@ -838,6 +855,50 @@ export type ApplyDeployment = Message<"ctrl.v1.ApplyDeployment"> & {
 export const ApplyDeploymentSchema: GenMessage<ApplyDeployment> = /*@__PURE__*/
   messageDesc(file_ctrl_v1_cluster, 14);
 
+/**
+ * VolumeMount attaches a persistent volume to a deployment's pods.
+ *
+ * @generated from message ctrl.v1.VolumeMount
+ */
+export type VolumeMount = Message<"ctrl.v1.VolumeMount"> & {
+  /**
+   * volume_id is the volumes.id the claim belongs to.
+   *
+   * @generated from field: string volume_id = 1;
+   */
+  volumeId: string;
+
+  /**
+   * k8s_name is the name of the PersistentVolumeClaim. It is stable for the
+   * lifetime of the volume, so every deployment binds the same claim.
+   *
+   * @generated from field: string k8s_name = 2;
+   */
+  k8sName: string;
+
+  /**
+   * mount_path is the absolute path inside the container.
+   *
+   * @generated from field: string mount_path = 3;
+   */
+  mountPath: string;
+
+  /**
+   * size_mib is the requested size in mebibytes. Volumes can grow but never
+   * shrink.
+   *
+   * @generated from field: int64 size_mib = 4;
+   */
+  sizeMib: bigint;
+};
+
+/**
+ * Describes the message ctrl.v1.VolumeMount.
+ * Use `create(VolumeMountSchema)` to create a new message.
+ */
+export const VolumeMountSchema: GenMessage<VolumeMount> = /*@__PURE__*/
+  messageDesc(file_ctrl_v1_cluster, 15);
+
 /**
  * AutoscalingPolicy configures horizontal pod autoscaling for a deployment.
  * Snapshotted from the horizontal_autoscaling_policies table at query time.
@@ -874,6 +935,15 @@ export type AutoscalingPolicy = Message<"ctrl.v1.AutoscalingPolicy"> & {
    * @generated from field: optional int32 memory_threshold = 4;
    */
   memoryThreshold?: number;
+
+  /**
+   * Target requests per second per replica, measured by frontline. When set,
+   * Krane raises the HPA's replica floor to cover the observed request rate.
+   * When omitted, request rate is not used as a scaling signal.
+   *
+   * @generated from field: optional int32 rps_threshold = 5;
+   */
+  rpsThreshold?: number;
 };
 
 /**
@@ -881,7 +951,7 @@ export type AutoscalingPolicy = Message<"ctrl.v1.AutoscalingPolicy"> & {
  * Use `create(AutoscalingPolicySchema)` to create a new message.
  */
 export const AutoscalingPolicySchema: GenMessage<AutoscalingPolicy> = /*@__PURE__*/
-  messageDesc(file_ctrl_v1_cluster, 15);
+  messageDesc(file_ctrl_v1_cluster, 16);
 
 /**
  * DeleteDeployment identifies a deployment to remove from the cluster.
@@ -909,7 +979,38 @@ export type DeleteDeployment = Message<"ctrl.v1.DeleteDeployment"> & {
  * Use `create(DeleteDeploymentSchema)` to create a new message.
  */
 export const DeleteDeploymentSchema: GenMessage<DeleteDeployment> = /*@__PURE__*/
-  messageDesc(file_ctrl_v1_cluster, 16);
+  messageDesc(file_ctrl_v1_cluster, 17);
+
+/**
+ * DeleteVolume instructs Krane to delete a persistent volume's claim and,
+ * with it, the data. The control plane sends it once the volume's retention
+ * window has passed after the workspace was torn down.
+ *
+ * @generated from message ctrl.v1.DeleteVolume
+ */
+export type DeleteVolume = Message<"ctrl.v1.DeleteVolume"> & {
+  /**
+   * @generated from field: string k8s_namespace = 1;
+   */
+  k8sNamespace: string;
+
+  /**
+   * @generated from field: string k8s_name = 2;
+   */
+  k8sName: string;
+
+  /**
+   * @generated from field: string volume_id = 3;
+   */
+  volumeId: string;
+};
+
+/**
+ * Describes the message ctrl.v1.DeleteVolume.
+ * Use `create(DeleteVolumeSchema)` to create a new message.
+ */
+export const DeleteVolumeSchema: GenMessage<DeleteVolume> = /*@__PURE__*/
+  messageDesc(file_ctrl_v1_cluster, 18);
 
 /**
  * HeartbeatRequest is sent periodically by krane agents to register their
@@ -930,7 +1031,7 @@ export type HeartbeatRequest = Message<"ctrl.v1.HeartbeatRequest"> & {
  * Use `create(HeartbeatRequestSchema)` to create a new message.
  */
 export const HeartbeatRequestSchema: GenMessage<HeartbeatRequest> = /*@__PURE__*/
-  messageDesc(file_ctrl_v1_cluster, 17);
+  messageDesc(file_ctrl_v1_cluster, 19);
 
 /**
  * @generated from message ctrl.v1.HeartbeatResponse
@@ -943,7 +1044,50 @@ export type HeartbeatResponse = Message<"ctrl.v1.HeartbeatResponse"> & {
  * Use `create(HeartbeatResponseSchema)` to create a new message.
  */
 export const HeartbeatResponseSchema: GenMessage<HeartbeatResponse> = /*@__PURE__*/
-  messageDesc(file_ctrl_v1_cluster, 18);
+  messageDesc(file_ctrl_v1_cluster, 20);
+
+/**
+ * @generated from message ctrl.v1.GetDeploymentRequestRatesRequest
+ */
+export type GetDeploymentRequestRatesRequest = Message<"ctrl.v1.GetDeploymentRequestRatesRequest"> & {
+  /**
+   * @generated from field: ctrl.v1.ClusterKey cluster = 1;
+   */
+  cluster?: ClusterKey;
+
+  /**
+   * @generated from field: repeated string deployment_ids = 2;
+   */
+  deploymentIds: string[];
+};
+
+/**
+ * Describes the message ctrl.v1.GetDeploymentRequestRatesRequest.
+ * Use `create(GetDeploymentRequestRatesRequestSchema)` to create a new message.
+ */
+export const GetDeploymentRequestRatesRequestSchema: GenMessage<GetDeploymentRequestRatesRequest> = /*@__PURE__*/
+  messageDesc(file_ctrl_v1_cluster, 21);
+
+/**
+ * @generated from message ctrl.v1.GetDeploymentRequestRatesResponse
+ */
+export type GetDeploymentRequestRatesResponse = Message<"ctrl.v1.GetDeploymentRequestRatesResponse"> & {
+  /**
+   * Average requests per second per deployment ID over the last few minutes,
+   * counting only the traffic served in the calling cluster's region.
+   * Deployments without recent traffic there are omitted.
+   *
+   * @generated from field: map<string, double> requests_per_second = 1;
+   */
+  requestsPerSecond: { [key: string]: number };
+};
+
+/**
+ * Describes the message ctrl.v1.GetDeploymentRequestRatesResponse.
+ * Use `create(GetDeploymentRequestRatesResponseSchema)` to create a new message.
+ */
+export const GetDeploymentRequestRatesResponseSchema: GenMessage<GetDeploymentRequestRatesResponse> = /*@__PURE__*/
+  messageDesc(file_ctrl_v1_cluster, 22);
 
 /**
  * Health represents the health state of a resource (deployment instance, etc.)
@@ -1063,6 +1207,19 @@ export const ClusterService: GenService<{
     input: typeof HeartbeatRequestSchema;
     output: typeof HeartbeatResponseSchema;
   },
+  /**
+   * GetDeploymentRequestRates returns the recent request rate frontline
+   * observed for each of the given deployments in the calling cluster's
+   * region. Krane polls it to size the replica floor of deployments
+   * whose autoscaling policy sets an rps_threshold.
+   *
+   * @generated from rpc ctrl.v1.ClusterService.GetDeploymentRequestRates
+   */
+  getDeploymentRequestRates: {
+    methodKind: "unary";
+    input: typeof GetDeploymentRequestRatesRequestSchema;
+    output: typeof GetDeploymentRequestRatesResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_ctrl_v1_cluster, 0);
 
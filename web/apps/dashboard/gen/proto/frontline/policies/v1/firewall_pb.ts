@@ -10,13 +10,13 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file frontline/policies/v1/firewall.proto.
  */
 export const file_frontline_policies_v1_firewall: GenFile = /*@__PURE__*/
-  fileDesc("CiRmcm9udGxpbmUvcG9saWNpZXMvdjEvZmlyZXdhbGwucHJvdG8SDGZyb250bGluZS52MSIwCghGaXJld2FsbBIkCgZhY3Rpb24YASABKA4yFC5mcm9udGxpbmUudjEuQWN0aW9uKjEKBkFjdGlvbhIWChJBQ1RJT05fVU5TUEVDSUZJRUQQABIPCgtBQ1RJT05fREVOWRABQq8BChBjb20uZnJvbnRsaW5lLnYxQg1GaXJld2FsbFByb3RvUAFaO2dpdGh1Yi5jb20vdW5rZXllZC91bmtleS9nZW4vcHJvdG8vZnJvbnRsaW5lL3YxO2Zyb250bGluZXYxogIDRlhYqgIMRnJvbnRsaW5lLlYxygIMRnJvbnRsaW5lXFYx4gIYRnJvbnRsaW5lXFYxXEdQQk1ldGFkYXRh6gINRnJvbnRsaW5lOjpWMWIGcHJvdG8z");
+  fileDesc("CiRmcm9udGxpbmUvcG9saWNpZXMvdjEvZmlyZXdhbGwucHJvdG8SDGZyb250bGluZS52MSKUAQoIRmlyZXdhbGwSJAoGYWN0aW9uGAEgASgOMhQuZnJvbnRsaW5lLnYxLkFjdGlvbhIwCghyZWRpcmVjdBgCIAEoCzIeLmZyb250bGluZS52MS5GaXJld2FsbFJlZGlyZWN0EjAKCHJlc3BvbnNlGAMgASgLMh4uZnJvbnRsaW5lLnYxLkZpcmV3YWxsUmVzcG9uc2UiOQoQRmlyZXdhbGxSZWRpcmVjdBIQCghsb2NhdGlvbhgBIAEoCRITCgtzdGF0dXNfY29kZRgCIAEoBSKjAQoQRmlyZXdhbGxSZXNwb25zZRITCgtzdGF0dXNfY29kZRgBIAEoBRI8CgdoZWFkZXJzGAIgAygLMisuZnJvbnRsaW5lLnYxLkZpcmV3YWxsUmVzcG9uc2UuSGVhZGVyc0VudHJ5EgwKBGJvZHkYAyABKAkaLgoMSGVhZGVyc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEqagoGQWN0aW9uEhYKEkFDVElPTl9VTlNQRUNJRklFRBAAEg8KC0FDVElPTl9ERU5ZEAESDgoKQUNUSU9OX0xPRxACEhMKD0FDVElPTl9SRURJUkVDVBADEhIKDkFDVElPTl9SRVNQT05EEARCrwEKEGNvbS5mcm9udGxpbmUudjFCDUZpcmV3YWxsUHJvdG9QAVo7Z2l0aHViLmNvbS91bmtleWVkL3Vua2V5L2dlbi9wcm90by9mcm9udGxpbmUvdjE7ZnJvbnRsaW5ldjGiAgNGWFiqAgxGcm9udGxpbmUuVjHKAgxGcm9udGxpbmVcVjHiAhhGcm9udGxpbmVcVjFcR1BCTWV0YWRhdGHqAg1Gcm9udGxpbmU6OlYxYgZwcm90bzM");
 
 /**
  * Firewall applies an action to any request that matches the policy's
- * [MatchExpr] list. The action enum exists today only to carry ACTION_DENY,
- * but it gives the wire format a place to grow into more outcomes (allow,
- * log, challenge) without restructuring the message.
+ * [MatchExpr] list. Enforcing actions (deny, redirect, respond) answer the
+ * request at the edge; ACTION_LOG only records the match so a new rule can
+ * run in shadow mode before it is enforced.
  *
  * @generated from message frontline.v1.Firewall
  */
@@ -27,6 +27,22 @@ export type Firewall = Message<"frontline.v1.Firewall"> & {
    * @generated from field: frontline.v1.Action action = 1;
    */
   action: Action;
+
+  /**
+   * Where to send the client. Required when action is ACTION_REDIRECT and
+   * ignored otherwise.
+   *
+   * @generated from field: frontline.v1.FirewallRedirect redirect = 2;
+   */
+  redirect?: FirewallRedirect;
+
+  /**
+   * The response to serve. Required when action is ACTION_RESPOND and
+   * ignored otherwise.
+   *
+   * @generated from field: frontline.v1.FirewallResponse response = 3;
+   */
+  response?: FirewallResponse;
 };
 
 /**
@@ -36,6 +52,72 @@ export type Firewall = Message<"frontline.v1.Firewall"> & {
 export const FirewallSchema: GenMessage<Firewall> = /*@__PURE__*/
   messageDesc(file_frontline_policies_v1_firewall, 0);
 
+/**
+ * FirewallRedirect configures ACTION_REDIRECT.
+ *
+ * @generated from message frontline.v1.FirewallRedirect
+ */
+export type FirewallRedirect = Message<"frontline.v1.FirewallRedirect"> & {
+  /**
+   * The redirect target: an absolute http(s) URL, or a path starting with
+   * "/" on the same host. Sent verbatim in the Location header.
+   *
+   * @generated from field: string location = 1;
+   */
+  location: string;
+
+  /**
+   * The redirect status code: 301, 302, 303, 307 or 308. Defaults to 302
+   * when unset.
+   *
+   * @generated from field: int32 status_code = 2;
+   */
+  statusCode: number;
+};
+
+/**
+ * Describes the message frontline.v1.FirewallRedirect.
+ * Use `create(FirewallRedirectSchema)` to create a new message.
+ */
+export const FirewallRedirectSchema: GenMessage<FirewallRedirect> = /*@__PURE__*/
+  messageDesc(file_frontline_policies_v1_firewall, 1);
+
+/**
+ * FirewallResponse configures ACTION_RESPOND.
+ *
+ * @generated from message frontline.v1.FirewallResponse
+ */
+export type FirewallResponse = Message<"frontline.v1.FirewallResponse"> & {
+  /**
+   * The HTTP status code to respond with, between 200 and 599.
+   *
+   * @generated from field: int32 status_code = 1;
+   */
+  statusCode: number;
+
+  /**
+   * Response headers to set. Content-Type defaults to text/plain when not
+   * set here.
+   *
+   * @generated from field: map<string, string> headers = 2;
+   */
+  headers: { [key: string]: string };
+
+  /**
+   * The response body, sent verbatim.
+   *
+   * @generated from field: string body = 3;
+   */
+  body: string;
+};
+
+/**
+ * Describes the message frontline.v1.FirewallResponse.
+ * Use `create(FirewallResponseSchema)` to create a new message.
+ */
+export const FirewallResponseSchema: GenMessage<FirewallResponse> = /*@__PURE__*/
+  messageDesc(file_frontline_policies_v1_firewall, 2);
+
 /**
  * Action is the outcome a Firewall policy applies to a matched request.
  *
@@ -55,6 +137,32 @@ export enum Action {
    * @generated from enum value: ACTION_DENY = 1;
    */
   DENY = 1,
+
+  /**
+   * Record the match without affecting the request. The policy id is added
+   * to the request's firewall_matches in the request log and evaluation
+   * continues as if the policy had not matched. Use it to observe what a
+   * rule would block before switching it to an enforcing action.
+   *
+   * @generated from enum value: ACTION_LOG = 2;
+   */
+  LOG = 2,
+
+  /**
+   * Redirect the client to [FirewallRedirect.location]. Short-circuits like
+   * ACTION_DENY.
+   *
+   * @generated from enum value: ACTION_REDIRECT = 3;
+   */
+  REDIRECT = 3,
+
+  /**
+   * Serve [FirewallResponse] from the edge, for example a maintenance page.
+   * Short-circuits like ACTION_DENY.
+   *
+   * @generated from enum value: ACTION_RESPOND = 4;
+   */
+  RESPOND = 4,
 }
 
 /**
@@ -10,7 +10,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file frontline/policies/v1/keyauth.proto.
  */
 export const file_frontline_policies_v1_keyauth: GenFile = /*@__PURE__*/
-  fileDesc("CiNmcm9udGxpbmUvcG9saWNpZXMvdjEva2V5YXV0aC5wcm90bxIMZnJvbnRsaW5lLnYxItQBCgdLZXlBdXRoEhUKDWtleV9zcGFjZV9pZHMYASADKAkSLAoJbG9jYXRpb25zGAIgAygLMhkuZnJvbnRsaW5lLnYxLktleUxvY2F0aW9uEh0KEHBlcm1pc3Npb25fcXVlcnkYBSABKAlIAIgBARIuCgpyYXRlbGltaXRzGAYgAygLMhouZnJvbnRsaW5lLnYxLktleVJhdGVsaW1pdBIUCgdjcmVkaXRzGAcgASgDSAGIAQFCEwoRX3Blcm1pc3Npb25fcXVlcnlCCgoIX2NyZWRpdHMiegoMS2V5UmF0ZWxpbWl0EgwKBG5hbWUYASABKAkSEgoFbGltaXQYAiABKANIAIgBARIVCghkdXJhdGlvbhgDIAEoA0gBiAEBEhEKBGNvc3QYBCABKANIAogBAUIICgZfbGltaXRCCwoJX2R1cmF0aW9uQgcKBV9jb3N0Ir0BCgtLZXlMb2NhdGlvbhIzCgZiZWFyZXIYASABKAsyIS5mcm9udGxpbmUudjEuQmVhcmVyVG9rZW5Mb2NhdGlvbkgAEjEKBmhlYWRlchgCIAEoCzIfLmZyb250bGluZS52MS5IZWFkZXJLZXlMb2NhdGlvbkgAEjoKC3F1ZXJ5X3BhcmFtGAMgASgLMiMuZnJvbnRsaW5lLnYxLlF1ZXJ5UGFyYW1LZXlMb2NhdGlvbkgAQgoKCGxvY2F0aW9uIhUKE0JlYXJlclRva2VuTG9jYXRpb24iNwoRSGVhZGVyS2V5TG9jYXRpb24SDAoEbmFtZRgBIAEoCRIUCgxzdHJpcF9wcmVmaXgYAiABKAkiJQoVUXVlcnlQYXJhbUtleUxvY2F0aW9uEgwKBG5hbWUYASABKAlCrgEKEGNvbS5mcm9udGxpbmUudjFCDEtleWF1dGhQcm90b1ABWjtnaXRodWIuY29tL3Vua2V5ZWQvdW5rZXkvZ2VuL3Byb3RvL2Zyb250bGluZS92MTtmcm9udGxpbmV2MaICA0ZYWKoCDEZyb250bGluZS5WMcoCDEZyb250bGluZVxWMeICGEZyb250bGluZVxWMVxHUEJNZXRhZGF0YeoCDUZyb250bGluZTo6VjFiBnByb3RvMw");
+  fileDesc("CiNmcm9udGxpbmUvcG9saWNpZXMvdjEva2V5YXV0aC5wcm90bxIMZnJvbnRsaW5lLnYxIqcCCgdLZXlBdXRoEhUKDWtleV9zcGFjZV9pZHMYASADKAkSLAoJbG9jYXRpb25zGAIgAygLMhkuZnJvbnRsaW5lLnYxLktleUxvY2F0aW9uEh0KEHBlcm1pc3Npb25fcXVlcnkYBSABKAlIAIgBARIuCgpyYXRlbGltaXRzGAYgAygLMhouZnJvbnRsaW5lLnYxLktleVJhdGVsaW1pdBIUCgdjcmVkaXRzGAcgASgDSAGIAQESPAoQcmVzcG9uc2VfY3JlZGl0cxgIIAEoCzIdLmZyb250bGluZS52MS5SZXNwb25zZUNyZWRpdHNIAogBAUITChFfcGVybWlzc2lvbl9xdWVyeUIKCghfY3JlZGl0c0ITChFfcmVzcG9uc2VfY3JlZGl0cyIyCg9SZXNwb25zZUNyZWRpdHMSDgoGaGVhZGVyGAEgASgJEg8KB3Jlc2VydmUYAiABKAMiegoMS2V5UmF0ZWxpbWl0EgwKBG5hbWUYASABKAkSEgoFbGltaXQYAiABKANIAIgBARIVCghkdXJhdGlvbhgDIAEoA0gBiAEBEhEKBGNvc3QYBCABKANIAogBAUIICgZfbGltaXRCCwoJX2R1cmF0aW9uQgcKBV9jb3N0Ir0BCgtLZXlMb2NhdGlvbhIzCgZiZWFyZXIYASABKAsyIS5mcm9udGxpbmUudjEuQmVhcmVyVG9rZW5Mb2NhdGlvbkgAEjEKBmhlYWRlchgCIAEoCzIfLmZyb250bGluZS52MS5IZWFkZXJLZXlMb2NhdGlvbkgAEjoKC3F1ZXJ5X3BhcmFtGAMgASgLMiMuZnJvbnRsaW5lLnYxLlF1ZXJ5UGFyYW1LZXlMb2NhdGlvbkgAQgoKCGxvY2F0aW9uIhUKE0JlYXJlclRva2VuTG9jYXRpb24iNwoRSGVhZGVyS2V5TG9jYXRpb24SDAoEbmFtZRgBIAEoCRIUCgxzdHJpcF9wcmVmaXgYAiABKAkiJQoVUXVlcnlQYXJhbUtleUxvY2F0aW9uEgwKBG5hbWUYASABKAlCrgEKEGNvbS5mcm9udGxpbmUudjFCDEtleWF1dGhQcm90b1ABWjtnaXRodWIuY29tL3Vua2V5ZWQvdW5rZXkvZ2VuL3Byb3RvL2Zyb250bGluZS92MTtmcm9udGxpbmV2MaICA0ZYWKoCDEZyb250bGluZS5WMcoCDEZyb250bGluZVxWMeICGEZyb250bGluZVxWMVxHUEJNZXRhZGF0YeoCDUZyb250bGluZTo6VjFiBnByb3RvMw");
 
 /**
  * KeyAuth authenticates requests using Unkey API keys. This is the primary
@@ -122,6 +122,17 @@ export type KeyAuth = Message<"frontline.v1.KeyAuth"> & {
    * @generated from field: optional int64 credits = 7;
    */
   credits?: bigint;
+
+  /**
+   * Settles the credit cost from the upstream response instead of charging a
+   * fixed amount. Use this for metered endpoints whose cost is only known once
+   * the upstream has answered, e.g. tokens generated by a model.
+   *
+   * Mutually exclusive with credits.
+   *
+   * @generated from field: optional frontline.v1.ResponseCredits response_credits = 8;
+   */
+  responseCredits?: ResponseCredits;
 };
 
 /**
@@ -131,6 +142,55 @@ export type KeyAuth = Message<"frontline.v1.KeyAuth"> & {
 export const KeyAuthSchema: GenMessage<KeyAuth> = /*@__PURE__*/
   messageDesc(file_frontline_policies_v1_keyauth, 0);
 
+/**
+ * ResponseCredits charges a key what the upstream reports a request cost.
+ *
+ * Frontline reserves `reserve` credits while verifying the key, exactly as if
+ * credits were set to that value, so a key that cannot cover the reservation
+ * is rejected with 429 before the upstream is called. Because every in-flight
+ * request holds its own reservation, concurrent requests can never spend more
+ * than the key's remaining credits.
+ *
+ * Once the proxied response has completed, frontline reads the cost from the
+ * named header, or from a trailer of the same name for streamed responses
+ * that only know their cost at the end, and refunds the difference between
+ * the reservation and the reported cost. The reservation is an upper bound:
+ * reported costs above it are charged as the reservation. When the upstream
+ * reports nothing usable (header missing, not a non-negative integer, or the
+ * upstream failed to respond), the full reservation is kept. Requests that
+ * never reach the upstream, because a later policy rejected or answered
+ * them, are refunded in full.
+ *
+ * Keys with unlimited remaining usage are unaffected.
+ *
+ * @generated from message frontline.v1.ResponseCredits
+ */
+export type ResponseCredits = Message<"frontline.v1.ResponseCredits"> & {
+  /**
+   * The response header or trailer carrying the cost as a base-10 integer,
+   * e.g. "X-Unkey-Cost". Matched case-insensitively. The header and
+   * trailer are removed before the response reaches the client. Required.
+   *
+   * @generated from field: string header = 1;
+   */
+  header: string;
+
+  /**
+   * Credits held for the request until the response completes. Size it to
+   * the most a single request can cost. Must be at least 1.
+   *
+   * @generated from field: int64 reserve = 2;
+   */
+  reserve: bigint;
+};
+
+/**
+ * Describes the message frontline.v1.ResponseCredits.
+ * Use `create(ResponseCreditsSchema)` to create a new message.
+ */
+export const ResponseCreditsSchema: GenMessage<ResponseCredits> = /*@__PURE__*/
+  messageDesc(file_frontline_policies_v1_keyauth, 1);
+
 /**
  * KeyRatelimit selects a rate limit to enforce on the verified key. It mirrors
  * the per-request `ratelimits` entries accepted by Unkey's verifyKey API so
@@ -184,7 +244,7 @@ export type KeyRatelimit = Message<"frontline.v1.KeyRatelimit"> & {
  * Use `create(KeyRatelimitSchema)` to create a new message.
  */
 export const KeyRatelimitSchema: GenMessage<KeyRatelimit> = /*@__PURE__*/
-  messageDesc(file_frontline_policies_v1_keyauth, 1);
+  messageDesc(file_frontline_policies_v1_keyauth, 2);
 
 /**
  * KeyLocation specifies where in the HTTP request to look for an API key.
@@ -235,7 +295,7 @@ export type KeyLocation = Message<"frontline.v1.KeyLocation"> & {
  * Use `create(KeyLocationSchema)` to create a new message.
  */
 export const KeyLocationSchema: GenMessage<KeyLocation> = /*@__PURE__*/
-  messageDesc(file_frontline_policies_v1_keyauth, 2);
+  messageDesc(file_frontline_policies_v1_keyauth, 3);
 
 /**
  * BearerTokenLocation extracts the API key from the Authorization header
@@ -252,7 +312,7 @@ export type BearerTokenLocation = Message<"frontline.v1.BearerTokenLocation"> &
  * Use `create(BearerTokenLocationSchema)` to create a new message.
  */
 export const BearerTokenLocationSchema: GenMessage<BearerTokenLocation> = /*@__PURE__*/
-  messageDesc(file_frontline_policies_v1_keyauth, 3);
+  messageDesc(file_frontline_policies_v1_keyauth, 4);
 
 /**
  * HeaderKeyLocation extracts the API key from a named request header. This
@@ -286,7 +346,7 @@ export type HeaderKeyLocation = Message<"frontline.v1.HeaderKeyLocation"> & {
  * Use `create(HeaderKeyLocationSchema)` to create a new message.
  */
 export const HeaderKeyLocationSchema: GenMessage<HeaderKeyLocation> = /*@__PURE__*/
-  messageDesc(file_frontline_policies_v1_keyauth, 4);
+  messageDesc(file_frontline_policies_v1_keyauth, 5);
 
 /**
  * QueryParamKeyLocation extracts the API key from a URL query parameter.
@@ -307,5 +367,5 @@ export type QueryParamKeyLocation = Message<"frontline.v1.QueryParamKeyLocation"
  * Use `create(QueryParamKeyLocationSchema)` to create a new message.
  */
 export const QueryParamKeyLocationSchema: GenMessage<QueryParamKeyLocation> = /*@__PURE__*/
-  messageDesc(file_frontline_policies_v1_keyauth, 5);
+  messageDesc(file_frontline_policies_v1_keyauth, 6);
 
@@ -10,7 +10,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file frontline/policies/v1/match.proto.
  */
 export const file_frontline_policies_v1_match: GenFile = /*@__PURE__*/
-  fileDesc("CiFmcm9udGxpbmUvcG9saWNpZXMvdjEvbWF0Y2gucHJvdG8SDGZyb250bGluZS52MSLMAQoJTWF0Y2hFeHByEicKBHBhdGgYASABKAsyFy5mcm9udGxpbmUudjEuUGF0aE1hdGNoSAASKwoGbWV0aG9kGAIgASgLMhkuZnJvbnRsaW5lLnYxLk1ldGhvZE1hdGNoSAASKwoGaGVhZGVyGAMgASgLMhkuZnJvbnRsaW5lLnYxLkhlYWRlck1hdGNoSAASNAoLcXVlcnlfcGFyYW0YBCABKAsyHS5mcm9udGxpbmUudjEuUXVlcnlQYXJhbU1hdGNoSABCBgoEZXhwciJfCgtTdHJpbmdNYXRjaBITCgtpZ25vcmVfY2FzZRgBIAEoCBIPCgVleGFjdBgCIAEoCUgAEhAKBnByZWZpeBgDIAEoCUgAEg8KBXJlZ2V4GAQgASgJSABCBwoFbWF0Y2giNAoJUGF0aE1hdGNoEicKBHBhdGgYASABKAsyGS5mcm9udGxpbmUudjEuU3RyaW5nTWF0Y2giHgoLTWV0aG9kTWF0Y2gSDwoHbWV0aG9kcxgBIAMoCSJjCgtIZWFkZXJNYXRjaBIMCgRuYW1lGAEgASgJEhEKB3ByZXNlbnQYAiABKAhIABIqCgV2YWx1ZRgDIAEoCzIZLmZyb250bGluZS52MS5TdHJpbmdNYXRjaEgAQgcKBW1hdGNoImcKD1F1ZXJ5UGFyYW1NYXRjaBIMCgRuYW1lGAEgASgJEhEKB3ByZXNlbnQYAiABKAhIABIqCgV2YWx1ZRgDIAEoCzIZLmZyb250bGluZS52MS5TdHJpbmdNYXRjaEgAQgcKBW1hdGNoQqwBChBjb20uZnJvbnRsaW5lLnYxQgpNYXRjaFByb3RvUAFaO2dpdGh1Yi5jb20vdW5rZXllZC91bmtleS9nZW4vcHJvdG8vZnJvbnRsaW5lL3YxO2Zyb250bGluZXYxogIDRlhYqgIMRnJvbnRsaW5lLlYxygIMRnJvbnRsaW5lXFYx4gIYRnJvbnRsaW5lXFYxXEdQQk1ldGFkYXRh6gINRnJvbnRsaW5lOjpWMWIGcHJvdG8z");
+  fileDesc("frontline/policies/v1/match.proto CiFmcm9udGxpbmUvcG9saWNpZXMvdjEvbWF0Y2gucHJvdG8SDGZyb250bGluZS52MSK0AwoJTWF0Y2hFeHByEicKBHBhdGgYASABKAsyFy5mcm9udGxpbmUudjEuUGF0aE1hdGNoSAASKwoGbWV0aG9kGAIgASgLMhkuZnJvbnRsaW5lLnYxLk1ldGhvZE1hdGNoSAASKwoGaGVhZGVyGAMgASgLMhkuZnJvbnRsaW5lLnYxLkhlYWRlck1hdGNoSAASNAoLcXVlcnlfcGFyYW0YBCABKAsyHS5mcm9udGxpbmUudjEuUXVlcnlQYXJhbU1hdGNoSAASMAoJcmVtb3RlX2lwGAUgASgLMhsuZnJvbnRsaW5lLnYxLlJlbW90ZUlwTWF0Y2hIABItCgdjb3VudHJ5GAYgASgLMhouZnJvbnRsaW5lLnYxLkNvdW50cnlNYXRjaEgAEjEKCXByaW5jaXBhbBgHIAEoCzIcLmZyb250bGluZS52MS5QcmluY2lwYWxNYXRjaEgAEiYKA25vdBgIIAEoCzIXLmZyb250bGluZS52MS5NYXRjaEV4cHJIABIqCgZhbnlfb2YYCSABKAsyGC5mcm9udGxpbmUudjEuQW55T2ZNYXRjaEgAQgYKBGV4cHIiXwoLU3RyaW5nTWF0Y2gSEwoLaWdub3JlX2Nhc2UYASABKAgSDwoFZXhhY3QYAiABKAlIABIQCgZwcmVmaXgYAyABKAlIABIPCgVyZWdleBgEIAEoCUgAQgcKBW1hdGNoIjQKCVBhdGhNYXRjaBInCgRwYXRoGAEgASgLMhkuZnJvbnRsaW5lLnYxLlN0cmluZ01hdGNoIh4KC01ldGhvZE1hdGNoEg8KB21ldGhvZHMYASADKAkiYwoLSGVhZGVyTWF0Y2gSDAoEbmFtZRgBIAEoCRIRCgdwcmVzZW50GAIgASgISAASKgoFdmFsdWUYAyABKAsyGS5mcm9udGxpbmUudjEuU3RyaW5nTWF0Y2hIAEIHCgVtYXRjaCJnCg9RdWVyeVBhcmFtTWF0Y2gSDAoEbmFtZRgBIAEoCRIRCgdwcmVzZW50GAIgASgISAASKgoFdmFsdWUYAyABKAsyGS5mcm9udGxpbmUudjEuU3RyaW5nTWF0Y2hIAEIHCgVtYXRjaCI0CgpBbnlPZk1hdGNoEiYKBWV4cHJzGAEgAygLMhcuZnJvbnRsaW5lLnYxLk1hdGNoRXhwciIeCg1SZW1vdGVJcE1hdGNoEg0KBWNpZHJzGAEgAygJIiEKDENvdW50cnlNYXRjaBIRCgljb3VudHJpZXMYASADKAkiZwoOUHJpbmNpcGFsTWF0Y2gSDQoFZmllbGQYASABKAkSEQoHcHJlc2VudBgCIAEoCEgAEioKBXZhbHVlGAMgASgLMhkuZnJvbnRsaW5lLnYxLlN0cmluZ01hdGNoSABCBwoFbWF0Y2hCrAEKEGNvbS5mcm9udGxpbmUudjFCCk1hdGNoUHJvdG9QAVo7Z2l0aHViLmNvbS91bmtleWVkL3Vua2V5L2dlbi9wcm90by9mcm9udGxpbmUvdjE7ZnJvbnRsaW5ldjGiAgNGWFiqAgxGcm9udGxpbmUuVjHKAgxGcm9udGxpbmVcVjHiAhhGcm9udGxpbmVcVjFcR1BCTWV0YWRhdGHqAg1Gcm9udGxpbmU6OlYxYgZwcm90bzM");
 
 /**
  * MatchExpr tests a single property of an incoming HTTP request.
@@ -18,11 +18,9 @@ export const file_frontline_policies_v1_match: GenFile = /*@__PURE__*/
  * A Policy carries a repeated list of MatchExpr. All entries must match for
  * the policy to run (implicit AND). An empty list matches all requests.
  *
- * If you need OR semantics, create multiple policies with the same config
- * and different match lists. This is simpler to reason about than a recursive
- * expression tree, and covers the vast majority of real-world routing needs.
- * Combinators (And/Or/Not) can be added later as new oneof branches without
- * breaking the wire format.
+ * For OR semantics within one policy, wrap alternatives in [AnyOfMatch]; to
+ * invert a condition, wrap it in `not`. Combinators nest, but keep trees
+ * shallow: the API rejects nesting deeper than four levels.
  *
  * @generated from message frontline.v1.MatchExpr
  */
@@ -54,6 +52,38 @@ export type MatchExpr = Message<"frontline.v1.MatchExpr"> & {
      */
     value: QueryParamMatch;
     case: "queryParam";
+  } | {
+    /**
+     * @generated from field: frontline.v1.RemoteIpMatch remote_ip = 5;
+     */
+    value: RemoteIpMatch;
+    case: "remoteIp";
+  } | {
+    /**
+     * @generated from field: frontline.v1.CountryMatch country = 6;
+     */
+    value: CountryMatch;
+    case: "country";
+  } | {
+    /**
+     * @generated from field: frontline.v1.PrincipalMatch principal = 7;
+     */
+    value: PrincipalMatch;
+    case: "principal";
+  } | {
+    /**
+     * Matches when the wrapped expression does not match.
+     *
+     * @generated from field: frontline.v1.MatchExpr not = 8;
+     */
+    value: MatchExpr;
+    case: "not";
+  } | {
+    /**
+     * @generated from field: frontline.v1.AnyOfMatch any_of = 9;
+     */
+    value: AnyOfMatch;
+    case: "anyOf";
   } | { case: undefined; value?: undefined };
 };
 
@@ -278,3 +308,122 @@ export type QueryParamMatch = Message<"frontline.v1.QueryParamMatch"> & {
 export const QueryParamMatchSchema: GenMessage<QueryParamMatch> = /*@__PURE__*/
   messageDesc(file_frontline_policies_v1_match, 5);
 
+/**
+ * AnyOfMatch matches when at least one of its expressions matches. An empty
+ * list never matches.
+ *
+ * @generated from message frontline.v1.AnyOfMatch
+ */
+export type AnyOfMatch = Message<"frontline.v1.AnyOfMatch"> & {
+  /**
+   * @generated from field: repeated frontline.v1.MatchExpr exprs = 1;
+   */
+  exprs: MatchExpr[];
+};
+
+/**
+ * Describes the message frontline.v1.AnyOfMatch.
+ * Use `create(AnyOfMatchSchema)` to create a new message.
+ */
+export const AnyOfMatchSchema: GenMessage<AnyOfMatch> = /*@__PURE__*/
+  messageDesc(file_frontline_policies_v1_match, 6);
+
+/**
+ * RemoteIpMatch tests the client IP, the same address rate limiting keys on
+ * with RemoteIpKey. The match succeeds if the address falls inside any of the
+ * listed networks.
+ *
+ * @generated from message frontline.v1.RemoteIpMatch
+ */
+export type RemoteIpMatch = Message<"frontline.v1.RemoteIpMatch"> & {
+  /**
+   * Networks in CIDR notation, IPv4 or IPv6, e.g. "203.0.113.0/24" or
+   * "2001:db8::/32". A bare address matches that single address. IPv4-mapped
+   * IPv6 client addresses are compared as IPv4.
+   *
+   * @generated from field: repeated string cidrs = 1;
+   */
+  cidrs: string[];
+};
+
+/**
+ * Describes the message frontline.v1.RemoteIpMatch.
+ * Use `create(RemoteIpMatchSchema)` to create a new message.
+ */
+export const RemoteIpMatchSchema: GenMessage<RemoteIpMatch> = /*@__PURE__*/
+  messageDesc(file_frontline_policies_v1_match, 7);
+
+/**
+ * CountryMatch tests the country the client IP is located in, resolved with
+ * the GeoIP database frontline loads at startup. Requests whose country
+ * cannot be resolved, including every request when no database is
+ * configured, do not match.
+ *
+ * @generated from message frontline.v1.CountryMatch
+ */
+export type CountryMatch = Message<"frontline.v1.CountryMatch"> & {
+  /**
+   * ISO 3166-1 alpha-2 country codes, e.g. ["US", "CA"]. Compared
+   * case-insensitively. The match succeeds if the client's country equals
+   * any entry.
+   *
+   * @generated from field: repeated string countries = 1;
+   */
+  countries: string[];
+};
+
+/**
+ * Describes the message frontline.v1.CountryMatch.
+ * Use `create(CountryMatchSchema)` to create a new message.
+ */
+export const CountryMatchSchema: GenMessage<CountryMatch> = /*@__PURE__*/
+  messageDesc(file_frontline_policies_v1_match, 8);
+
+/**
+ * PrincipalMatch tests a field of the principal set by an earlier KeyAuth or
+ * JWTAuth policy in the same list. Policies are evaluated in order, so a
+ * PrincipalMatch placed before the authentication policy, or on a request no
+ * authentication policy matched, sees no principal and does not match.
+ *
+ * @generated from message frontline.v1.PrincipalMatch
+ */
+export type PrincipalMatch = Message<"frontline.v1.PrincipalMatch"> & {
+  /**
+   * Dotted path into the principal's JSON form, the same paths
+   * PrincipalFieldKey uses, e.g. "subject", "source.key.meta.plan" or
+   * "source.jwt.payload.tier". Numbers and booleans are compared by their
+   * JSON text, e.g. "true" or "42".
+   *
+   * @generated from field: string field = 1;
+   */
+  field: string;
+
+  /**
+   * @generated from oneof frontline.v1.PrincipalMatch.match
+   */
+  match: {
+    /**
+     * When true, the match succeeds if the field exists; when false, if it
+     * does not.
+     *
+     * @generated from field: bool present = 2;
+     */
+    value: boolean;
+    case: "present";
+  } | {
+    /**
+     * Match against the field value using a [StringMatch].
+     *
+     * @generated from field: frontline.v1.StringMatch value = 3;
+     */
+    value: StringMatch;
+    case: "value";
+  } | { case: undefined; value?: undefined };
+};
+
+/**
+ * Describes the message frontline.v1.PrincipalMatch.
+ * Use `create(PrincipalMatchSchema)` to create a new message.
+ */
+export const PrincipalMatchSchema: GenMessage<PrincipalMatch> = /*@__PURE__*/
+  messageDesc(file_frontline_policies_v1_match, 9);
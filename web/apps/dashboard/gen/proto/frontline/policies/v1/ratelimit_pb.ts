@@ -2,15 +2,15 @@
 // @generated from file frontline/policies/v1/ratelimit.proto (package frontline.v1, syntax proto3)
 /* eslint-disable */
 
-import type { GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
-import { fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import type { GenEnum, GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file frontline/policies/v1/ratelimit.proto.
  */
 export const file_frontline_policies_v1_ratelimit: GenFile = /*@__PURE__*/
-  fileDesc("CiVmcm9udGxpbmUvcG9saWNpZXMvdjEvcmF0ZWxpbWl0LnByb3RvEgxmcm9udGxpbmUudjEioAEKCVJhdGVMaW1pdBINCgVsaW1pdBgBIAEoAxIRCgl3aW5kb3dfbXMYAiABKAMSOQoKaWRlbnRpZmllchgDIAEoCzIhLmZyb250bGluZS52MS5SYXRlTGltaXRJZGVudGlmaWVyQgIYARI2CgtpZGVudGlmaWVycxgEIAMoCzIhLmZyb250bGluZS52MS5SYXRlTGltaXRJZGVudGlmaWVyIqUCChNSYXRlTGltaXRJZGVudGlmaWVyEi4KCXJlbW90ZV9pcBgBIAEoCzIZLmZyb250bGluZS52MS5SZW1vdGVJcEtleUgAEikKBmhlYWRlchgCIAEoCzIXLmZyb250bGluZS52MS5IZWFkZXJLZXlIABJGChVhdXRoZW50aWNhdGVkX3N1YmplY3QYAyABKAsyJS5mcm9udGxpbmUudjEuQXV0aGVudGljYXRlZFN1YmplY3RLZXlIABIlCgRwYXRoGAQgASgLMhUuZnJvbnRsaW5lLnYxLlBhdGhLZXlIABI6Cg9wcmluY2lwYWxfZmllbGQYBSABKAsyHy5mcm9udGxpbmUudjEuUHJpbmNpcGFsRmllbGRLZXlIAEIICgZzb3VyY2UiDQoLUmVtb3RlSXBLZXkiGQoJSGVhZGVyS2V5EgwKBG5hbWUYASABKAkiGQoXQXV0aGVudGljYXRlZFN1YmplY3RLZXkiCQoHUGF0aEtleSIhChFQcmluY2lwYWxGaWVsZEtleRIMCgRwYXRoGAEgASgJQrABChBjb20uZnJvbnRsaW5lLnYxQg5SYXRlbGltaXRQcm90b1ABWjtnaXRodWIuY29tL3Vua2V5ZWQvdW5rZXkvZ2VuL3Byb3RvL2Zyb250bGluZS92MTtmcm9udGxpbmV2MaICA0ZYWKoCDEZyb250bGluZS5WMcoCDEZyb250bGluZVxWMeICGEZyb250bGluZVxWMVxHUEJNZXRhZGF0YeoCDUZyb250bGluZTo6VjFiBnByb3RvMw");
+  fileDesc("frontline/policies/v1/ratelimit.proto CiVmcm9udGxpbmUvcG9saWNpZXMvdjEvcmF0ZWxpbWl0LnByb3RvEgxmcm9udGxpbmUudjEi6gEKCVJhdGVMaW1pdBINCgVsaW1pdBgBIAEoAxIRCgl3aW5kb3dfbXMYAiABKAMSOQoKaWRlbnRpZmllchgDIAEoCzIhLmZyb250bGluZS52MS5SYXRlTGltaXRJZGVudGlmaWVyQgIYARI2CgtpZGVudGlmaWVycxgEIAMoCzIhLmZyb250bGluZS52MS5SYXRlTGltaXRJZGVudGlmaWVyEjMKCWFsZ29yaXRobRgFIAEoDjIgLmZyb250bGluZS52MS5SYXRlTGltaXRBbGdvcml0aG0SEwoLcmVmaWxsX3JhdGUYBiABKAMipQIKE1JhdGVMaW1pdElkZW50aWZpZXISLgoJcmVtb3RlX2lwGAEgASgLMhkuZnJvbnRsaW5lLnYxLlJlbW90ZUlwS2V5SAASKQoGaGVhZGVyGAIgASgLMhcuZnJvbnRsaW5lLnYxLkhlYWRlcktleUgAEkYKFWF1dGhlbnRpY2F0ZWRfc3ViamVjdBgDIAEoCzIlLmZyb250bGluZS52MS5BdXRoZW50aWNhdGVkU3ViamVjdEtleUgAEiUKBHBhdGgYBCABKAsyFS5mcm9udGxpbmUudjEuUGF0aEtleUgAEjoKD3ByaW5jaXBhbF9maWVsZBgFIAEoCzIfLmZyb250bGluZS52MS5QcmluY2lwYWxGaWVsZEtleUgAQggKBnNvdXJjZSINCgtSZW1vdGVJcEtleSIZCglIZWFkZXJLZXkSDAoEbmFtZRgBIAEoCSIZChdBdXRoZW50aWNhdGVkU3ViamVjdEtleSIJCgdQYXRoS2V5IiEKEVByaW5jaXBhbEZpZWxkS2V5EgwKBHBhdGgYASABKAkqsQEKElJhdGVMaW1pdEFsZ29yaXRobRIkCiBSQVRFX0xJTUlUX0FMR09SSVRITV9VTlNQRUNJRklFRBAAEicKI1JBVEVfTElNSVRfQUxHT1JJVEhNX1NMSURJTkdfV0lORE9XEAESJQohUkFURV9MSU1JVF9BTEdPUklUSE1fRklYRURfV0lORE9XEAISJQohUkFURV9MSU1JVF9BTEdPUklUSE1fVE9LRU5fQlVDS0VUEANCsAEKEGNvbS5mcm9udGxpbmUudjFCDlJhdGVsaW1pdFByb3RvUAFaO2dpdGh1Yi5jb20vdW5rZXllZC91bmtleS9nZW4vcHJvdG8vZnJvbnRsaW5lL3YxO2Zyb250bGluZXYxogIDRlhYqgIMRnJvbnRsaW5lLlYxygIMRnJvbnRsaW5lXFYx4gIYRnJvbnRsaW5lXFYxXEdQQk1ldGFkYXRh6gINRnJvbnRsaW5lOjpWMWIGcHJvdG8z");
 
 /**
  * RateLimit enforces request rate limits at the gateway, protecting upstream
@@ -75,6 +75,24 @@ export type RateLimit = Message<"frontline.v1.RateLimit"> & {
    * @generated from field: repeated frontline.v1.RateLimitIdentifier identifiers = 4;
    */
   identifiers: RateLimitIdentifier[];
+
+  /**
+   * How requests are counted against limit. Unspecified means
+   * RATE_LIMIT_ALGORITHM_SLIDING_WINDOW, which is how policies stored before
+   * this field existed behave.
+   *
+   * @generated from field: frontline.v1.RateLimitAlgorithm algorithm = 5;
+   */
+  algorithm: RateLimitAlgorithm;
+
+  /**
+   * Tokens a RATE_LIMIT_ALGORITHM_TOKEN_BUCKET regains every window_ms, at
+   * most limit. Zero refills the whole bucket once per window. Ignored by the
+   * window algorithms.
+   *
+   * @generated from field: int64 refill_rate = 6;
+   */
+  refillRate: bigint;
 };
 
 /**
@@ -272,3 +290,49 @@ export type PrincipalFieldKey = Message<"frontline.v1.PrincipalFieldKey"> & {
 export const PrincipalFieldKeySchema: GenMessage<PrincipalFieldKey> = /*@__PURE__*/
   messageDesc(file_frontline_policies_v1_ratelimit, 6);
 
+/**
+ * RateLimitAlgorithm selects how a [RateLimit] policy counts requests. All
+ * algorithms share the same distributed counters; they differ in how those
+ * counts turn into a decision.
+ *
+ * @generated from enum frontline.v1.RateLimitAlgorithm
+ */
+export enum RateLimitAlgorithm {
+  /**
+   * @generated from enum value: RATE_LIMIT_ALGORITHM_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Counts the current window plus a weighted share of the previous one,
+   * which smooths the burst a fixed window allows at its boundary.
+   *
+   * @generated from enum value: RATE_LIMIT_ALGORITHM_SLIDING_WINDOW = 1;
+   */
+  SLIDING_WINDOW = 1,
+
+  /**
+   * Counts only the current window. Windows are epoch-aligned, not
+   * calendar-aligned: they start at multiples of window_ms since the Unix
+   * epoch, so hourly and daily limits reset on UTC hours and days, weekly
+   * limits on Thursdays at 00:00 UTC, and there are no monthly or time zone
+   * aligned windows.
+   *
+   * @generated from enum value: RATE_LIMIT_ALGORITHM_FIXED_WINDOW = 2;
+   */
+  FIXED_WINDOW = 2,
+
+  /**
+   * Treats limit as a bucket capacity refilled by refill_rate tokens every
+   * window_ms, allowing a burst of up to limit followed by a steady rate.
+   *
+   * @generated from enum value: RATE_LIMIT_ALGORITHM_TOKEN_BUCKET = 3;
+   */
+  TOKEN_BUCKET = 3,
+}
+
+/**
+ * Describes the enum frontline.v1.RateLimitAlgorithm.
+ */
+export const RateLimitAlgorithmSchema: GenEnum<RateLimitAlgorithm> = /*@__PURE__*/
+  enumDesc(file_frontline_policies_v1_ratelimit, 0);
@@ -10,7 +10,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file vault/v1/service.proto.
  */
 export const file_vault_v1_service: GenFile = /*@__PURE__*/
-  fileDesc("ChZ2YXVsdC92MS9zZXJ2aWNlLnByb3RvEgh2YXVsdC52MSIRCg9MaXZlbmVzc1JlcXVlc3QiIgoQTGl2ZW5lc3NSZXNwb25zZRIOCgZzdGF0dXMYASABKAkiLwoORW5jcnlwdFJlcXVlc3QSDwoHa2V5cmluZxgBIAEoCRIMCgRkYXRhGAIgASgJIjQKD0VuY3J5cHRSZXNwb25zZRIRCgllbmNyeXB0ZWQYASABKAkSDgoGa2V5X2lkGAIgASgJIjQKDkRlY3J5cHRSZXF1ZXN0Eg8KB2tleXJpbmcYASABKAkSEQoJZW5jcnlwdGVkGAIgASgJIiQKD0RlY3J5cHRSZXNwb25zZRIRCglwbGFpbnRleHQYASABKAkiVgoQUmVFbmNyeXB0UmVxdWVzdBIPCgdrZXlyaW5nGAEgASgJEhEKCWVuY3J5cHRlZBgCIAEoCRITCgZrZXlfaWQYAyABKAlIAIgBAUIJCgdfa2V5X2lkIjYKEVJlRW5jcnlwdFJlc3BvbnNlEhEKCWVuY3J5cHRlZBgBIAEoCRIOCgZrZXlfaWQYAiABKAkiFgoUUmVFbmNyeXB0REVLc1JlcXVlc3QiFwoVUmVFbmNyeXB0REVLc1Jlc3BvbnNlIosBChJFbmNyeXB0QnVsa1JlcXVlc3QSDwoHa2V5cmluZxgBIAEoCRI2CgVpdGVtcxgCIAMoCzInLnZhdWx0LnYxLkVuY3J5cHRCdWxrUmVxdWVzdC5JdGVtc0VudHJ5GiwKCkl0ZW1zRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASI8ChdFbmNyeXB0QnVsa1Jlc3BvbnNlSXRlbRIRCgllbmNyeXB0ZWQYASABKAkSDgoGa2V5X2lkGAIgASgJIp8BChNFbmNyeXB0QnVsa1Jlc3BvbnNlEjcKBWl0ZW1zGAEgAygLMigudmF1bHQudjEuRW5jcnlwdEJ1bGtSZXNwb25zZS5JdGVtc0VudHJ5Gk8KCkl0ZW1zRW50cnkSCwoDa2V5GAEgASgJEjAKBXZhbHVlGAIgASgLMiEudmF1bHQudjEuRW5jcnlwdEJ1bGtSZXNwb25zZUl0ZW06AjgBIosBChJEZWNyeXB0QnVsa1JlcXVlc3QSDwoHa2V5cmluZxgBIAEoCRI2CgVpdGVtcxgCIAMoCzInLnZhdWx0LnYxLkRlY3J5cHRCdWxrUmVxdWVzdC5JdGVtc0VudHJ5GiwKCkl0ZW1zRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASJ8ChNEZWNyeXB0QnVsa1Jlc3BvbnNlEjcKBWl0ZW1zGAEgAygLMigudmF1bHQudjEuRGVjcnlwdEJ1bGtSZXNwb25zZS5JdGVtc0VudHJ5GiwKCkl0ZW1zRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ATK7AwoMVmF1bHRTZXJ2aWNlEkMKCExpdmVuZXNzEhkudmF1bHQudjEuTGl2ZW5lc3NSZXF1ZXN0GhoudmF1bHQudjEuTGl2ZW5lc3NSZXNwb25zZSIAEkAKB0VuY3J5cHQSGC52YXVsdC52MS5FbmNyeXB0UmVxdWVzdBoZLnZhdWx0LnYxLkVuY3J5cHRSZXNwb25zZSIAEkAKB0RlY3J5cHQSGC52YXVsdC52MS5EZWNyeXB0UmVxdWVzdBoZLnZhdWx0LnYxLkRlY3J5cHRSZXNwb25zZSIAEkwKC0VuY3J5cHRCdWxrEhwudmF1bHQudjEuRW5jcnlwdEJ1bGtSZXF1ZXN0Gh0udmF1bHQudjEuRW5jcnlwdEJ1bGtSZXNwb25zZSIAEkwKC0RlY3J5cHRCdWxrEhwudmF1bHQudjEuRGVjcnlwdEJ1bGtSZXF1ZXN0Gh0udmF1bHQudjEuRGVjcnlwdEJ1bGtSZXNwb25zZSIAEkYKCVJlRW5jcnlwdBIaLnZhdWx0LnYxLlJlRW5jcnlwdFJlcXVlc3QaGy52YXVsdC52MS5SZUVuY3J5cHRSZXNwb25zZSIAQpIBCgxjb20udmF1bHQudjFCDFNlcnZpY2VQcm90b1ABWjNnaXRodWIuY29tL3Vua2V5ZWQvdW5rZXkvZ2VuL3Byb3RvL3ZhdWx0L3YxO3ZhdWx0djGiAgNWWFiqAghWYXVsdC5WMcoCCFZhdWx0XFYx4gIUVmF1bHRcVjFcR1BCTWV0YWRhdGHqAglWYXVsdDo6VjFiBnByb3RvMw");
+  fileDesc("ChZ2YXVsdC92MS9zZXJ2aWNlLnByb3RvEgh2YXVsdC52MSIRCg9MaXZlbmVzc1JlcXVlc3QiIgoQTGl2ZW5lc3NSZXNwb25zZRIOCgZzdGF0dXMYASABKAkiLwoORW5jcnlwdFJlcXVlc3QSDwoHa2V5cmluZxgBIAEoCRIMCgRkYXRhGAIgASgJIjQKD0VuY3J5cHRSZXNwb25zZRIRCgllbmNyeXB0ZWQYASABKAkSDgoGa2V5X2lkGAIgASgJIjQKDkRlY3J5cHRSZXF1ZXN0Eg8KB2tleXJpbmcYASABKAkSEQoJZW5jcnlwdGVkGAIgASgJIiQKD0RlY3J5cHRSZXNwb25zZRIRCglwbGFpbnRleHQYASABKAkiVgoQUmVFbmNyeXB0UmVxdWVzdBIPCgdrZXlyaW5nGAEgASgJEhEKCWVuY3J5cHRlZBgCIAEoCRITCgZrZXlfaWQYAyABKAlIAIgBAUIJCgdfa2V5X2lkIjYKEVJlRW5jcnlwdFJlc3BvbnNlEhEKCWVuY3J5cHRlZBgBIAEoCRIOCgZrZXlfaWQYAiABKAkiPQoWUm90YXRlTWFzdGVyS2V5UmVxdWVzdBISCgpiYXRjaF9zaXplGAEgASgNEg8KB3Jlc3RhcnQYAiABKAgibgoXUm90YXRlTWFzdGVyS2V5UmVzcG9uc2USDgoGa2VrX2lkGAEgASgJEhEKCXJld3JhcHBlZBgCIAEoBBIPCgdza2lwcGVkGAMgASgEEhEKCXJlbWFpbmluZxgEIAEoBBIMCgRkb25lGAUgASgIIosBChJFbmNyeXB0QnVsa1JlcXVlc3QSDwoHa2V5cmluZxgBIAEoCRI2CgVpdGVtcxgCIAMoCzInLnZhdWx0LnYxLkVuY3J5cHRCdWxrUmVxdWVzdC5JdGVtc0VudHJ5GiwKCkl0ZW1zRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASI8ChdFbmNyeXB0QnVsa1Jlc3BvbnNlSXRlbRIRCgllbmNyeXB0ZWQYASABKAkSDgoGa2V5X2lkGAIgASgJIp8BChNFbmNyeXB0QnVsa1Jlc3BvbnNlEjcKBWl0ZW1zGAEgAygLMigudmF1bHQudjEuRW5jcnlwdEJ1bGtSZXNwb25zZS5JdGVtc0VudHJ5Gk8KCkl0ZW1zRW50cnkSCwoDa2V5GAEgASgJEjAKBXZhbHVlGAIgASgLMiEudmF1bHQudjEuRW5jcnlwdEJ1bGtSZXNwb25zZUl0ZW06AjgBIosBChJEZWNyeXB0QnVsa1JlcXVlc3QSDwoHa2V5cmluZxgBIAEoCRI2CgVpdGVtcxgCIAMoCzInLnZhdWx0LnYxLkRlY3J5cHRCdWxrUmVxdWVzdC5JdGVtc0VudHJ5GiwKCkl0ZW1zRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASJ8ChNEZWNyeXB0QnVsa1Jlc3BvbnNlEjcKBWl0ZW1zGAEgAygLMigudmF1bHQudjEuRGVjcnlwdEJ1bGtSZXNwb25zZS5JdGVtc0VudHJ5GiwKCkl0ZW1zRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ATKVBAoMVmF1bHRTZXJ2aWNlEkMKCExpdmVuZXNzEhkudmF1bHQudjEuTGl2ZW5lc3NSZXF1ZXN0GhoudmF1bHQudjEuTGl2ZW5lc3NSZXNwb25zZSIAEkAKB0VuY3J5cHQSGC52YXVsdC52MS5FbmNyeXB0UmVxdWVzdBoZLnZhdWx0LnYxLkVuY3J5cHRSZXNwb25zZSIAEkAKB0RlY3J5cHQSGC52YXVsdC52MS5EZWNyeXB0UmVxdWVzdBoZLnZhdWx0LnYxLkRlY3J5cHRSZXNwb25zZSIAEkwKC0VuY3J5cHRCdWxrEhwudmF1bHQudjEuRW5jcnlwdEJ1bGtSZXF1ZXN0Gh0udmF1bHQudjEuRW5jcnlwdEJ1bGtSZXNwb25zZSIAEkwKC0RlY3J5cHRCdWxrEhwudmF1bHQudjEuRGVjcnlwdEJ1bGtSZXF1ZXN0Gh0udmF1bHQudjEuRGVjcnlwdEJ1bGtSZXNwb25zZSIAEkYKCVJlRW5jcnlwdBIaLnZhdWx0LnYxLlJlRW5jcnlwdFJlcXVlc3QaGy52YXVsdC52MS5SZUVuY3J5cHRSZXNwb25zZSIAElgKD1JvdGF0ZU1hc3RlcktleRIgLnZhdWx0LnYxLlJvdGF0ZU1hc3RlcktleVJlcXVlc3QaIS52YXVsdC52MS5Sb3RhdGVNYXN0ZXJLZXlSZXNwb25zZSIAQpIBCgxjb20udmF1bHQudjFCDFNlcnZpY2VQcm90b1ABWjNnaXRodWIuY29tL3Vua2V5ZWQvdW5rZXkvZ2VuL3Byb3RvL3ZhdWx0L3YxO3ZhdWx0djGiAgNWWFiqAghWYXVsdC5WMcoCCFZhdWx0XFYx4gIUVmF1bHRcVjFcR1BCTWV0YWRhdGHqAglWYXVsdDo6VjFiBnByb3RvMw");
 
 /**
  * @generated from message vault.v1.LivenessRequest
@@ -177,29 +177,74 @@ export const ReEncryptResponseSchema: GenMessage<ReEncryptResponse> = /*@__PURE_
   messageDesc(file_vault_v1_service, 7);
 
 /**
- * @generated from message vault.v1.ReEncryptDEKsRequest
+ * @generated from message vault.v1.RotateMasterKeyRequest
  */
-export type ReEncryptDEKsRequest = Message<"vault.v1.ReEncryptDEKsRequest"> & {
+export type RotateMasterKeyRequest = Message<"vault.v1.RotateMasterKeyRequest"> & {
+  /**
+   * Maximum number of data encryption keys to re-wrap in this call.
+   * Defaults to 100 and may not exceed 1000.
+   *
+   * @generated from field: uint32 batch_size = 1;
+   */
+  batchSize: number;
+
+  /**
+   * Discard the stored checkpoint and start the pass from the beginning.
+   *
+   * @generated from field: bool restart = 2;
+   */
+  restart: boolean;
 };
 
 /**
- * Describes the message vault.v1.ReEncryptDEKsRequest.
- * Use `create(ReEncryptDEKsRequestSchema)` to create a new message.
+ * Describes the message vault.v1.RotateMasterKeyRequest.
+ * Use `create(RotateMasterKeyRequestSchema)` to create a new message.
  */
-export const ReEncryptDEKsRequestSchema: GenMessage<ReEncryptDEKsRequest> = /*@__PURE__*/
+export const RotateMasterKeyRequestSchema: GenMessage<RotateMasterKeyRequest> = /*@__PURE__*/
   messageDesc(file_vault_v1_service, 8);
 
 /**
- * @generated from message vault.v1.ReEncryptDEKsResponse
+ * @generated from message vault.v1.RotateMasterKeyResponse
  */
-export type ReEncryptDEKsResponse = Message<"vault.v1.ReEncryptDEKsResponse"> & {
+export type RotateMasterKeyResponse = Message<"vault.v1.RotateMasterKeyResponse"> & {
+  /**
+   * The id of the master key every data encryption key is re-wrapped with.
+   *
+   * @generated from field: string kek_id = 1;
+   */
+  kekId: string;
+
+  /**
+   * Progress of the whole pass, not just this call.
+   *
+   * @generated from field: uint64 rewrapped = 2;
+   */
+  rewrapped: bigint;
+
+  /**
+   * @generated from field: uint64 skipped = 3;
+   */
+  skipped: bigint;
+
+  /**
+   * @generated from field: uint64 remaining = 4;
+   */
+  remaining: bigint;
+
+  /**
+   * All data encryption keys are wrapped with kek_id. The previous master key
+   * is no longer needed for decryption once this is true.
+   *
+   * @generated from field: bool done = 5;
+   */
+  done: boolean;
 };
 
 /**
- * Describes the message vault.v1.ReEncryptDEKsResponse.
- * Use `create(ReEncryptDEKsResponseSchema)` to create a new message.
+ * Describes the message vault.v1.RotateMasterKeyResponse.
+ * Use `create(RotateMasterKeyResponseSchema)` to create a new message.
  */
-export const ReEncryptDEKsResponseSchema: GenMessage<ReEncryptDEKsResponse> = /*@__PURE__*/
+export const RotateMasterKeyResponseSchema: GenMessage<RotateMasterKeyResponse> = /*@__PURE__*/
   messageDesc(file_vault_v1_service, 9);
 
 /**
@@ -362,6 +407,18 @@ export const VaultService: GenService<{
     input: typeof ReEncryptRequestSchema;
     output: typeof ReEncryptResponseSchema;
   },
+  /**
+   * RotateMasterKey re-wraps the next batch of stored data encryption keys with
+   * the current master key. Progress is checkpointed in storage, so callers
+   * repeat the call until done is true and may resume after an interruption.
+   *
+   * @generated from rpc vault.v1.VaultService.RotateMasterKey
+   */
+  rotateMasterKey: {
+    methodKind: "unary";
+    input: typeof RotateMasterKeyRequestSchema;
+    output: typeof RotateMasterKeyResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_vault_v1_service, 0);
 
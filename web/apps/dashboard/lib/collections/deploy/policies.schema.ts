@@ -56,11 +56,15 @@ export const stringMatchSchema = z.union([
 ]);
 export type StringMatch = z.infer<typeof stringMatchSchema>;
 
-// ── Match expressions (protojson oneof: path | method | header | queryParam) ─
+// ── Match expressions (protojson oneof: path | method | header | queryParam |
+//    remoteIp | country | principal | not | anyOf) ─────────────────────────
 
 const httpMethod = z.enum(["GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"]);
 
-export const matchExprSchema = z.union([
+// ISO 3166-1 alpha-2, any case. The API upper-cases on write.
+export const countryCodeSchema = z.string().regex(/^[A-Za-z]{2}$/);
+
+const leafMatchExprSchema = z.union([
   z.object({ path: z.object({ path: stringMatchSchema }).strict() }).strict(),
   z
     .object({
@@ -85,8 +89,42 @@ export const matchExprSchema = z.union([
         ),
     })
     .strict(),
+  z
+    .object({
+      remoteIp: z.object({ cidrs: z.array(z.string().min(1)).min(1) }).strict(),
+    })
+    .strict(),
+  z
+    .object({
+      country: z.object({ countries: z.array(countryCodeSchema).min(1) }).strict(),
+    })
+    .strict(),
+  z
+    .object({
+      principal: z
+        .object({ field: z.string().min(1) })
+        .and(
+          // Unlike header/queryParam, principal fields can match on absence.
+          z.union([z.object({ present: z.boolean() }), z.object({ value: stringMatchSchema })]),
+        ),
+    })
+    .strict(),
+]);
+type LeafMatchExpr = z.infer<typeof leafMatchExprSchema>;
+
+// not/anyOf nest other expressions, so the type is declared up front for
+// z.lazy. The API caps nesting at four combinators.
+export type MatchExpr = LeafMatchExpr | { not: MatchExpr } | { anyOf: { exprs: MatchExpr[] } };
+
+export const matchExprSchema: z.ZodType<MatchExpr> = z.union([
+  leafMatchExprSchema,
+  z.object({ not: z.lazy(() => matchExprSchema) }).strict(),
+  z
+    .object({
+      anyOf: z.object({ exprs: z.array(z.lazy(() => matchExprSchema)).min(1) }).strict(),
+    })
+    .strict(),
 ]);
-export type MatchExpr = z.infer<typeof matchExprSchema>;
 
 // ── Key location (protojson oneof: bearer | header | queryParam) ────────
 
@@ -156,8 +194,20 @@ export const keyauthPolicySchema = z
         // Usage credits deducted per matching request. Defaults to 1 on the
         // wire; 0 verifies the key without spending credits.
         credits: wireInt64NonNegative.optional(),
+        // Reserve credits up front and settle on the cost the upstream reports
+        // in a response header. Mutually exclusive with credits.
+        responseCredits: z
+          .object({
+            header: z.string().min(1).max(256),
+            reserve: wireInt64,
+          })
+          .strict()
+          .optional(),
       })
-      .strict(),
+      .strict()
+      .refine((k) => k.credits === undefined || k.responseCredits === undefined, {
+        message: "credits and responseCredits cannot be combined",
+      }),
   })
   .strict();
 export type KeyauthPolicy = z.infer<typeof keyauthPolicySchema>;
@@ -177,6 +227,16 @@ const rateLimitIdentifierSchema = z.union([
 ]);
 export type RateLimitIdentifier = z.infer<typeof rateLimitIdentifierSchema>;
 
+// Wire values match frontline.v1.RateLimitAlgorithm enum names. An omitted
+// algorithm runs as a sliding window, which keeps old stored blobs unchanged.
+export const ratelimitAlgorithmSchema = z.enum([
+  "RATE_LIMIT_ALGORITHM_UNSPECIFIED",
+  "RATE_LIMIT_ALGORITHM_SLIDING_WINDOW",
+  "RATE_LIMIT_ALGORITHM_FIXED_WINDOW",
+  "RATE_LIMIT_ALGORITHM_TOKEN_BUCKET",
+]);
+export type RatelimitAlgorithm = z.infer<typeof ratelimitAlgorithmSchema>;
+
 // A ratelimit carries the `identifiers` list (1-5 dimensions combined into
 // one bucket key). The deprecated single `identifier` is still readable for
 // old stored blobs; the Go side enforces exactly-one at write time and the
@@ -190,6 +250,8 @@ export const ratelimitPolicySchema = z
       .object({
         limit: wireInt64,
         windowMs: wireInt64,
+        algorithm: ratelimitAlgorithmSchema.optional(),
+        refillRate: wireInt64.optional(),
         identifier: rateLimitIdentifierSchema.optional(),
         identifiers: z
           .array(rateLimitIdentifierSchema)
@@ -207,12 +269,38 @@ export const ratelimitPolicySchema = z
 // ── Firewall policy ─────────────────────────────────────────────────────
 
 // Wire values match frontline.v1.Action enum names. Kept as string literals so
-// protojson round-trips them by name rather than numeric value. The MVP only
-// has ACTION_DENY; the enum exists so additional outcomes can land later
-// without changing the schema shape.
-export const firewallActionSchema = z.enum(["ACTION_DENY"]);
+// protojson round-trips them by name rather than numeric value.
+export const firewallActionSchema = z.enum([
+  "ACTION_DENY",
+  "ACTION_LOG",
+  "ACTION_REDIRECT",
+  "ACTION_RESPOND",
+]);
 export type FirewallAction = z.infer<typeof firewallActionSchema>;
 
+// Mirrors FirewallRedirect.statusCode in the API spec. protojson omits the
+// field when it is 0, and frontline then defaults to 302.
+export const firewallRedirectStatusCodes = [301, 302, 303, 307, 308] as const;
+
+export const firewallRedirectSchema = z
+  .object({
+    location: z.string().min(1).max(2048),
+    statusCode: z
+      .number()
+      .int()
+      .refine((c) => (firewallRedirectStatusCodes as readonly number[]).includes(c))
+      .optional(),
+  })
+  .strict();
+
+export const firewallResponseSchema = z
+  .object({
+    statusCode: z.number().int().min(200).max(599),
+    headers: z.record(z.string(), z.string()).optional(),
+    body: z.string().max(65536).optional(),
+  })
+  .strict();
+
 export const firewallPolicySchema = z
   .object({
     ...policyBase,
@@ -220,6 +308,8 @@ export const firewallPolicySchema = z
     firewall: z
       .object({
         action: firewallActionSchema,
+        redirect: firewallRedirectSchema.optional(),
+        response: firewallResponseSchema.optional(),
       })
       .strict(),
   })
@@ -1,4 +1,4 @@
-import { and, db, eq, isNull } from "@/lib/db";
+import { and, db, eq, isNull, schema } from "@/lib/db";
 import { ratelimit, withRatelimit, workspaceProcedure } from "@/lib/trpc/trpc";
 import { TRPCError } from "@trpc/server";
 import { apis } from "@unkey/db/src/schema";
@@ -35,7 +35,21 @@ const apiLayoutResponse = z.object({
       id: z.string(),
       defaultPrefix: z.string().nullable(),
       defaultBytes: z.number().nullable(),
+      storeEncryptedKeys: z.boolean(),
       sizeApprox: z.number(),
+      rotation: z
+        .object({
+          intervalMs: z.number(),
+          gracePeriodMs: z.number(),
+        })
+        .nullable(),
+      expiryNotifications: z
+        .object({
+          thresholdsMs: z.array(z.number()),
+          email: z.boolean(),
+          slackWebhookUrl: z.string().nullable(),
+        })
+        .nullable(),
     })
     .nullable(),
   workspace: z.object({
@@ -92,6 +106,27 @@ export const queryApiKeyDetails = workspaceProcedure
         });
       }
 
+      const [rotation] = currentApi.keyAuth
+        ? await db
+            .select({
+              intervalMs: schema.keyRotationPolicies.intervalMs,
+              gracePeriodMs: schema.keyRotationPolicies.gracePeriodMs,
+            })
+            .from(schema.keyRotationPolicies)
+            .where(eq(schema.keyRotationPolicies.scopeId, currentApi.keyAuth.id))
+        : [];
+
+      const [expiryNotifications] = currentApi.keyAuth
+        ? await db
+            .select({
+              thresholdsMs: schema.keyExpiryNotificationSettings.thresholdsMs,
+              email: schema.keyExpiryNotificationSettings.email,
+              slackWebhookUrl: schema.keyExpiryNotificationSettings.slackWebhookUrl,
+            })
+            .from(schema.keyExpiryNotificationSettings)
+            .where(eq(schema.keyExpiryNotificationSettings.keyAuthId, currentApi.keyAuth.id))
+        : [];
+
       // Fetch all APIs in the same workspace
       const workspaceApis = await db
         .select({ id: apis.id, name: apis.name })
@@ -116,7 +151,13 @@ export const queryApiKeyDetails = workspaceProcedure
             : null,
         },
         workspaceApis,
-        keyAuth: currentApi.keyAuth,
+        keyAuth: currentApi.keyAuth
+          ? {
+              ...currentApi.keyAuth,
+              rotation: rotation ?? null,
+              expiryNotifications: expiryNotifications ?? null,
+            }
+          : null,
         workspace: {
           id: currentApi.workspace.id,
         },
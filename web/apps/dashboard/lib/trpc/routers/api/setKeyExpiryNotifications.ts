@@ -0,0 +1,129 @@
+import { insertAuditLogs } from "@/lib/audit";
+import { db, eq, schema } from "@/lib/db";
+import { TRPCError } from "@trpc/server";
+import { z } from "zod";
+import { workspaceProcedure } from "../../trpc";
+
+const HOUR_MS = 60 * 60 * 1000;
+const DAY_MS = 24 * HOUR_MS;
+
+export const keyExpiryNotificationsSchema = z
+  .object({
+    thresholdsMs: z
+      .array(
+        z
+          .number()
+          .int()
+          .min(HOUR_MS, "Warnings must be at least one hour before expiry")
+          .max(365 * DAY_MS, "Warnings must be at most one year before expiry"),
+      )
+      .min(1, "Add at least one threshold")
+      .max(5, "Add at most five thresholds"),
+    email: z.boolean(),
+    slackWebhookUrl: z
+      .string()
+      .url()
+      .max(1024)
+      .startsWith("https://hooks.slack.com/", "Must be a Slack incoming webhook URL")
+      .nullable(),
+  })
+  .refine((settings) => settings.email || settings.slackWebhookUrl !== null, {
+    message: "Choose at least one channel to send warnings to",
+    path: ["email"],
+  });
+
+// Sets or clears the expiry warnings for every key in a keyspace.
+export const setKeyExpiryNotifications = workspaceProcedure
+  .input(
+    z.object({
+      keyAuthId: z.string(),
+      notifications: keyExpiryNotificationsSchema.nullable(),
+    }),
+  )
+  .mutation(async ({ ctx, input }) => {
+    const keyAuth = await db.query.keyAuth
+      .findFirst({
+        where: (table, { eq, and, isNull }) =>
+          and(
+            eq(table.workspaceId, ctx.workspace.id),
+            eq(table.id, input.keyAuthId),
+            isNull(table.deletedAtM),
+          ),
+      })
+      .catch((_err) => {
+        throw new TRPCError({
+          code: "INTERNAL_SERVER_ERROR",
+          message:
+            "We were unable to update the key auth. Please try again or contact support@unkey.com",
+        });
+      });
+
+    if (!keyAuth) {
+      throw new TRPCError({
+        code: "NOT_FOUND",
+        message:
+          "We are unable to find the correct key auth. Please try again or contact support@unkey.com.",
+      });
+    }
+
+    const notifications = input.notifications;
+    try {
+      await db.transaction(async (tx) => {
+        if (notifications) {
+          const thresholdsMs = [...new Set(notifications.thresholdsMs)].sort((a, b) => b - a);
+          const now = Date.now();
+          await tx
+            .insert(schema.keyExpiryNotificationSettings)
+            .values({
+              keyAuthId: keyAuth.id,
+              workspaceId: ctx.workspace.id,
+              thresholdsMs,
+              email: notifications.email,
+              slackWebhookUrl: notifications.slackWebhookUrl,
+              createdAtM: now,
+            })
+            .onDuplicateKeyUpdate({
+              set: {
+                thresholdsMs,
+                email: notifications.email,
+                slackWebhookUrl: notifications.slackWebhookUrl,
+                updatedAtM: now,
+              },
+            });
+        } else {
+          await tx
+            .delete(schema.keyExpiryNotificationSettings)
+            .where(eq(schema.keyExpiryNotificationSettings.keyAuthId, keyAuth.id));
+        }
+
+        await insertAuditLogs(tx, {
+          workspaceId: ctx.workspace.id,
+          actor: {
+            type: "user",
+            id: ctx.user.id,
+          },
+          event: "api.update",
+          description: notifications
+            ? `Set ${keyAuth.id} expiry warnings to ${notifications.thresholdsMs.join(", ")}ms before expiry`
+            : `Disabled expiry warnings for ${keyAuth.id}`,
+          resources: [
+            {
+              type: "keyAuth",
+              id: keyAuth.id,
+            },
+          ],
+          context: {
+            location: ctx.audit.location,
+            userAgent: ctx.audit.userAgent,
+          },
+        });
+      });
+    } catch (err) {
+      console.error(err);
+      throw new TRPCError({
+        code: "INTERNAL_SERVER_ERROR",
+        message:
+          "We were unable to update the expiry warnings. Please try again or contact support@unkey.com.",
+      });
+    }
+  });
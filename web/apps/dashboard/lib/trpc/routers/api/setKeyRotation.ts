@@ -0,0 +1,125 @@
+import { insertAuditLogs } from "@/lib/audit";
+import { db, eq, schema } from "@/lib/db";
+import { TRPCError } from "@trpc/server";
+import { z } from "zod";
+import { workspaceProcedure } from "../../trpc";
+
+const DAY_MS = 24 * 60 * 60 * 1000;
+
+export const keyRotationSchema = z
+  .object({
+    intervalMs: z
+      .number()
+      .int()
+      .min(DAY_MS, "Keys can be rotated at most once per day")
+      .max(3650 * DAY_MS, "Keys must be rotated at least once every 10 years"),
+    gracePeriodMs: z.number().int().min(0),
+  })
+  .refine((rotation) => rotation.gracePeriodMs <= rotation.intervalMs, {
+    message: "The grace period must not be longer than the rotation interval",
+    path: ["gracePeriodMs"],
+  });
+
+// Sets or clears the default rotation schedule for every key in a keyspace.
+// A key's own schedule, set through the API, wins over this default.
+export const setKeyRotation = workspaceProcedure
+  .input(
+    z.object({
+      keyAuthId: z.string(),
+      rotation: keyRotationSchema.nullable(),
+    }),
+  )
+  .mutation(async ({ ctx, input }) => {
+    const keyAuth = await db.query.keyAuth
+      .findFirst({
+        where: (table, { eq, and, isNull }) =>
+          and(
+            eq(table.workspaceId, ctx.workspace.id),
+            eq(table.id, input.keyAuthId),
+            isNull(table.deletedAtM),
+          ),
+      })
+      .catch((_err) => {
+        throw new TRPCError({
+          code: "INTERNAL_SERVER_ERROR",
+          message:
+            "We were unable to update the key auth. Please try again or contact support@unkey.com",
+        });
+      });
+
+    if (!keyAuth) {
+      throw new TRPCError({
+        code: "NOT_FOUND",
+        message:
+          "We are unable to find the correct key auth. Please try again or contact support@unkey.com.",
+      });
+    }
+
+    // Successors are only retrievable from the vault, so rotating keys
+    // nobody can recover would lock their owners out.
+    if (input.rotation && !keyAuth.storeEncryptedKeys) {
+      throw new TRPCError({
+        code: "BAD_REQUEST",
+        message: "Key rotation requires an API that stores recoverable keys.",
+      });
+    }
+
+    const rotation = input.rotation;
+    try {
+      await db.transaction(async (tx) => {
+        if (rotation) {
+          const now = Date.now();
+          await tx
+            .insert(schema.keyRotationPolicies)
+            .values({
+              scopeId: keyAuth.id,
+              workspaceId: ctx.workspace.id,
+              keyAuthId: keyAuth.id,
+              intervalMs: rotation.intervalMs,
+              gracePeriodMs: rotation.gracePeriodMs,
+              createdAtM: now,
+            })
+            .onDuplicateKeyUpdate({
+              set: {
+                intervalMs: rotation.intervalMs,
+                gracePeriodMs: rotation.gracePeriodMs,
+                updatedAtM: now,
+              },
+            });
+        } else {
+          await tx
+            .delete(schema.keyRotationPolicies)
+            .where(eq(schema.keyRotationPolicies.scopeId, keyAuth.id));
+        }
+
+        await insertAuditLogs(tx, {
+          workspaceId: ctx.workspace.id,
+          actor: {
+            type: "user",
+            id: ctx.user.id,
+          },
+          event: "api.update",
+          description: rotation
+            ? `Set ${keyAuth.id} key rotation to every ${rotation.intervalMs}ms with a ${rotation.gracePeriodMs}ms grace period`
+            : `Disabled key rotation for ${keyAuth.id}`,
+          resources: [
+            {
+              type: "keyAuth",
+              id: keyAuth.id,
+            },
+          ],
+          context: {
+            location: ctx.audit.location,
+            userAgent: ctx.audit.userAgent,
+          },
+        });
+      });
+    } catch (err) {
+      console.error(err);
+      throw new TRPCError({
+        code: "INTERNAL_SERVER_ERROR",
+        message:
+          "We were unable to update the key rotation. Please try again or contact support@unkey.com.",
+      });
+    }
+  });
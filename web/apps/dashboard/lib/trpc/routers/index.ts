@@ -14,6 +14,8 @@ import { queryVerificationTimeseries } from "./api/overview/query-timeseries";
 import { queryApiKeyDetails } from "./api/query-api-key-details";
 import { setDefaultApiBytes } from "./api/setDefaultBytes";
 import { setDefaultApiPrefix } from "./api/setDefaultPrefix";
+import { setKeyExpiryNotifications } from "./api/setKeyExpiryNotifications";
+import { setKeyRotation } from "./api/setKeyRotation";
 import { updateAPIDeleteProtection } from "./api/updateDeleteProtection";
 import { updateApiName } from "./api/updateName";
 import { fetchAuditLog } from "./audit/fetch";
@@ -243,6 +245,8 @@ export const router = t.router({
     updateName: updateApiName,
     setDefaultPrefix: setDefaultApiPrefix,
     setDefaultBytes: setDefaultApiBytes,
+    setKeyRotation,
+    setKeyExpiryNotifications,
     updateDeleteProtection: updateAPIDeleteProtection,
     queryApiKeyDetails,
     keys: t.router({
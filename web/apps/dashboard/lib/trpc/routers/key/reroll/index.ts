@@ -285,6 +285,33 @@ async function rerollKeyCore({
           );
       }
 
+      // Recording the successor stops the scheduled rotation from issuing
+      // another one for the old key. The new key inherits the old key's own
+      // rotation schedule; a keyspace schedule applies to it anyway.
+      await tx.insert(schema.keyRotations).values({
+        keyId: source.id,
+        successorKeyId: newKeyId,
+        workspaceId: source.workspaceId,
+        keyAuthId: source.keyAuthId,
+        reason: "reroll",
+        createdAtM: now,
+      });
+
+      const [rotationPolicy] = await tx
+        .select()
+        .from(schema.keyRotationPolicies)
+        .where(eq(schema.keyRotationPolicies.scopeId, source.id));
+      if (rotationPolicy) {
+        await tx.insert(schema.keyRotationPolicies).values({
+          scopeId: newKeyId,
+          workspaceId: source.workspaceId,
+          keyAuthId: source.keyAuthId,
+          intervalMs: rotationPolicy.intervalMs,
+          gracePeriodMs: rotationPolicy.gracePeriodMs,
+          createdAtM: now,
+        });
+      }
+
       const resources: UnkeyAuditLog["resources"] = [
         { type: "key", id: newKeyId, name: source.name ?? undefined },
         {
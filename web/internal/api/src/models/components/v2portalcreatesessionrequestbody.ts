@@ -9,6 +9,7 @@ export const Scope = {
   KeysRead: "keys:read",
   KeysCreate: "keys:create",
   KeysReroll: "keys:reroll",
+  KeysDelete: "keys:delete",
   AnalyticsRead: "analytics:read",
 } as const;
 export type Scope = ClosedEnum<typeof Scope>;
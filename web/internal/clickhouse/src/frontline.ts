@@ -116,6 +116,8 @@ export const requestLogsResponseSchema = z.object({
   total_latency: z.number().int(),
   instance_latency: z.number().int(),
   gateway_latency: z.number().int(),
+  // Ids of log-only firewall policies that matched the request.
+  firewall_matches: z.array(z.string()),
   query_string: z.string(),
   query_params: z.record(z.string(), z.array(z.string())),
   request_headers: z.array(z.string()),
@@ -212,7 +214,8 @@ export function getRequestLogs(ch: Querier) {
         SELECT request_id, time, deployment_id, region, method, path, host,
                response_status, total_latency, instance_latency, gateway_latency,
                query_string, query_params, request_headers, request_body,
-               response_headers, response_body, user_agent, ip_address
+               response_headers, response_body, user_agent, ip_address,
+               firewall_matches
         FROM ${TABLE}
         WHERE ${filterConditions}
         ORDER BY time DESC, request_id DESC
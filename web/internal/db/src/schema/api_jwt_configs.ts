@@ -0,0 +1,32 @@
+import { bigint, index, mysqlTable, text, varchar } from "drizzle-orm/mysql-core";
+import { id } from "./util/id";
+import { primaryKey } from "./util/primary_key";
+
+/**
+ * How keys.verifyKey checks JWTs for an api whose auth_type is "jwt".
+ *
+ * Tokens are routed to a config by their iss claim, verified against either
+ * the keys served at jwks_url or the static PEM public_key, and mapped to the
+ * identity whose external_id equals the identity_claim. permissions_claim
+ * optionally names a claim holding the token's permissions, as an array of
+ * strings or a space separated string like OAuth's scope.
+ */
+export const apiJwtConfigs = mysqlTable(
+  "api_jwt_configs",
+  {
+    pk: primaryKey(),
+    apiId: id("api_id").notNull().unique(),
+    workspaceId: id("workspace_id").notNull(),
+    issuer: varchar("issuer", { length: 256 }).notNull(),
+    audience: varchar("audience", { length: 256 }),
+    jwksUrl: varchar("jwks_url", { length: 1024 }),
+    publicKey: text("public_key"),
+    identityClaim: varchar("identity_claim", { length: 256 }).notNull().default("sub"),
+    permissionsClaim: varchar("permissions_claim", { length: 256 }),
+    createdAtM: bigint("created_at_m", { mode: "number" })
+      .notNull()
+      .$defaultFn(() => Date.now()),
+    updatedAtM: bigint("updated_at_m", { mode: "number" }).$onUpdateFn(() => Date.now()),
+  },
+  (table) => [index("workspace_id_issuer_idx").on(table.workspaceId, table.issuer)],
+);
@@ -10,6 +10,7 @@ export const deploymentChanges = mysqlTable(
       "deployment_topology",
       "sentinel",
       "cilium_network_policy",
+      "volume",
     ]).notNull(),
     resourceId: id("resource_id").notNull(),
     regionId: id("region_id").notNull(),
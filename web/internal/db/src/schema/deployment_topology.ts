@@ -27,6 +27,8 @@ export const deploymentTopology = mysqlTable(
     autoscalingThresholdCpu: tinyint("autoscaling_threshold_cpu", { unsigned: true }),
     // Average memory utilization percentage (0-100) that triggers scale-up. Null = not used as a signal.
     autoscalingThresholdMemory: tinyint("autoscaling_threshold_memory", { unsigned: true }),
+    // Target requests per second per replica, measured by frontline. Null = not used as a signal.
+    autoscalingThresholdRps: tinyint("autoscaling_threshold_rps", { unsigned: true }),
 
     // Deployment status
     desiredStatus: mysqlEnum("desired_status", ["stopped", "running"]).notNull(),
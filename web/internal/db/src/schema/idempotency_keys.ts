@@ -0,0 +1,43 @@
+import { bigint, index, int, mysqlTable, uniqueIndex, varchar } from "drizzle-orm/mysql-core";
+import { caseSensitiveVarchar } from "./util/case_sensitive_varchar";
+import { id } from "./util/id";
+import { longblob } from "./util/longblob";
+import { primaryKey } from "./util/primary_key";
+
+/**
+ * The outcome of a request sent with an Idempotency-Key header, so a retry
+ * with the same key replays the stored response instead of running again.
+ *
+ * Keys are scoped to the root key (or user) in subject_id, so two root keys of
+ * one workspace never replay each other's responses.
+ *
+ * A row without a response_status is a request that is still in flight.
+ * fingerprint is the hex sha256 of the request method, path and body; a retry
+ * with a different fingerprint is rejected. Responses that carry a secret are
+ * stored vault-encrypted and their body is cleared after the first replay. Rows
+ * are deleted once
+ * expires_at_m has passed.
+ */
+export const idempotencyKeys = mysqlTable(
+  "idempotency_keys",
+  {
+    pk: primaryKey(),
+    workspaceId: id("workspace_id").notNull(),
+    subjectId: caseSensitiveVarchar("subject_id", { length: 255 }).notNull(),
+    idempotencyKey: caseSensitiveVarchar("idempotency_key", { length: 255 }).notNull(),
+    fingerprint: varchar("fingerprint", { length: 64 }).notNull(),
+    responseStatus: int("response_status"),
+    responseContentType: varchar("response_content_type", { length: 255 }),
+    responseBody: longblob("response_body"),
+    createdAtM: bigint("created_at_m", { mode: "number" }).notNull(),
+    expiresAtM: bigint("expires_at_m", { mode: "number" }).notNull(),
+  },
+  (table) => [
+    uniqueIndex("idempotency_keys_workspace_id_subject_id_idempotency_key_unique").on(
+      table.workspaceId,
+      table.subjectId,
+      table.idempotencyKey,
+    ),
+    index("expires_at_m_idx").on(table.expiresAtM),
+  ],
+);
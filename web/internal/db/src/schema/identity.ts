@@ -1,5 +1,14 @@
 import { relations } from "drizzle-orm";
-import { bigint, boolean, index, json, mysqlTable, uniqueIndex } from "drizzle-orm/mysql-core";
+import {
+  bigint,
+  boolean,
+  datetime,
+  index,
+  json,
+  mysqlTable,
+  tinyint,
+  uniqueIndex,
+} from "drizzle-orm/mysql-core";
 import { keys } from "./keys";
 import { caseSensitiveVarchar } from "./util/case_sensitive_varchar";
 import { id } from "./util/id";
@@ -41,6 +50,46 @@ export const identitiesRelations = relations(identities, ({ one, many }) => ({
   }),
   keys: many(keys),
   ratelimits: many(ratelimits),
+  credits: one(identityCredits, {
+    fields: [identities.id],
+    references: [identityCredits.identityId],
+  }),
+}));
+
+/**
+ * A credit balance shared by every key attached to the identity.
+ *
+ * Keys of an identity with a row here draw from `remaining` in addition to
+ * their own remaining_requests. Identities without a row have no pool.
+ */
+export const identityCredits = mysqlTable(
+  "identity_credits",
+  {
+    pk: primaryKey(),
+    identityId: id("identity_id").notNull().unique(),
+    workspaceId: id("workspace_id").notNull(),
+    remaining: bigint("remaining", { mode: "number", unsigned: true }).notNull(),
+    /**
+     * Same semantics as keys.refill_day:
+     * - 1..31 = we refill on that day of the month, or the last available day
+     * - null  = we refill on every day
+     */
+    refillDay: tinyint("refill_day"),
+    refillAmount: bigint("refill_amount", { mode: "number", unsigned: true }),
+    lastRefillAt: datetime("last_refill_at", { fsp: 3 }),
+    createdAtM: bigint("created_at_m", { mode: "number" })
+      .notNull()
+      .$defaultFn(() => Date.now()),
+    updatedAtM: bigint("updated_at_m", { mode: "number" }).$onUpdateFn(() => Date.now()),
+  },
+  (table) => [index("idx_identity_credits_refill").on(table.refillAmount)],
+);
+
+export const identityCreditsRelations = relations(identityCredits, ({ one }) => ({
+  identity: one(identities, {
+    fields: [identityCredits.identityId],
+    references: [identities.id],
+  }),
 }));
 
 /**
@@ -1,7 +1,12 @@
 export * from "./apis";
+export * from "./api_jwt_configs";
 export * from "./rbac";
 export * from "./keyAuth";
 export * from "./keys";
+export * from "./key_rotation";
+export * from "./key_expiry_notifications";
+export * from "./key_bulk_operations";
+export * from "./idempotency_keys";
 export * from "./ratelimit";
 export * from "./workspaces";
 export * from "./identity";
@@ -19,6 +24,7 @@ export * from "./apps";
 export * from "./app_build_settings";
 export * from "./app_runtime_settings";
 export * from "./app_regional_settings";
+export * from "./volumes";
 
 export * from "./app_environment_variables";
 export * from "./deployments";
@@ -44,3 +50,6 @@ export * from "./deployment_changes";
 // Portal tables
 export * from "./portals";
 export * from "./portal_sessions";
+
+// Outbound webhooks
+export * from "./webhooks";
@@ -1,5 +1,13 @@
 import { relations } from "drizzle-orm";
-import { bigint, boolean, index, int, mysqlTable, varchar } from "drizzle-orm/mysql-core";
+import {
+  bigint,
+  boolean,
+  index,
+  int,
+  mysqlTable,
+  uniqueIndex,
+  varchar,
+} from "drizzle-orm/mysql-core";
 import { apis } from "./apis";
 import { keys } from "./keys";
 import { id } from "./util/id";
@@ -43,4 +51,38 @@ export const keyAuthRelations = relations(keyAuth, ({ one, many }) => ({
     references: [apis.keyAuthId],
   }),
   keys: many(keys),
+  environments: many(keySpaceEnvironments),
+}));
+
+/**
+ * Environments a keyspace separates its keys into, e.g. `test` and `live`.
+ *
+ * A key's `environment` names one of these rows. Keys created in an environment
+ * default to its prefix instead of the keyspace's `default_prefix`, and keys of
+ * a `test_mode` environment are never charged credits when verified.
+ */
+export const keySpaceEnvironments = mysqlTable(
+  "key_space_environments",
+  {
+    pk: primaryKey(),
+    workspaceId: id("workspace_id").notNull(),
+    keyAuthId: id("key_auth_id").notNull(),
+    name: varchar("name", { length: 256 }).notNull(),
+    prefix: varchar("prefix", { length: 16 }),
+    testMode: boolean("test_mode").notNull().default(false),
+    createdAtM: bigint("created_at_m", { mode: "number" })
+      .notNull()
+      .$defaultFn(() => Date.now()),
+    updatedAtM: bigint("updated_at_m", { mode: "number" }).$onUpdateFn(() => Date.now()),
+  },
+  (table) => [
+    uniqueIndex("key_space_environments_key_auth_id_name_unique").on(table.keyAuthId, table.name),
+  ],
+);
+
+export const keySpaceEnvironmentsRelations = relations(keySpaceEnvironments, ({ one }) => ({
+  keyAuth: one(keyAuth, {
+    fields: [keySpaceEnvironments.keyAuthId],
+    references: [keyAuth.id],
+  }),
 }));
@@ -0,0 +1,59 @@
+import { bigint, index, int, mysqlEnum, mysqlTable, unique, varchar } from "drizzle-orm/mysql-core";
+import { id } from "./util/id";
+import { primaryKey } from "./util/primary_key";
+
+/**
+ * A keys.bulkUpdate, keys.bulkDelete or keys.bulkSetPermissions job.
+ *
+ * The API inserts the row and hands the job to the ctrl worker, which moves
+ * it from pending through running to completed, or to failed when the job
+ * itself could not run. Individual keys that fail do not fail the job; they
+ * are counted in failed and explained on their item.
+ */
+export const keyBulkOperations = mysqlTable(
+  "key_bulk_operations",
+  {
+    pk: primaryKey(),
+    id: id("id").notNull().unique(),
+    workspaceId: id("workspace_id").notNull(),
+    apiId: id("api_id").notNull(),
+    operation: mysqlEnum("operation", ["update", "delete", "set_permissions"]).notNull(),
+    status: mysqlEnum("status", ["pending", "running", "completed", "failed"])
+      .notNull()
+      .default("pending"),
+    total: int("total", { unsigned: true }).notNull().default(0),
+    succeeded: int("succeeded", { unsigned: true }).notNull().default(0),
+    failed: int("failed", { unsigned: true }).notNull().default(0),
+    error: varchar("error", { length: 1024 }),
+    createdAtM: bigint("created_at_m", { mode: "number" })
+      .notNull()
+      .$defaultFn(() => Date.now()),
+    updatedAtM: bigint("updated_at_m", { mode: "number" }).$onUpdateFn(() => Date.now()),
+    completedAtM: bigint("completed_at_m", { mode: "number" }),
+  },
+  (table) => [index("workspace_id_idx").on(table.workspaceId)],
+);
+
+/**
+ * One row per key a bulk operation touches, with its outcome. key_id is the id
+ * as the caller sent it, so ids that match no key are reported back too.
+ *
+ * event_id and expires record the key.deleted or key.expired webhook the
+ * change produced, written with the change itself, so a retried batch still
+ * dispatches the webhooks of keys an earlier attempt already changed.
+ * updated_at_m is when the key was changed.
+ */
+export const keyBulkOperationItems = mysqlTable(
+  "key_bulk_operation_items",
+  {
+    pk: primaryKey(),
+    operationId: id("operation_id").notNull(),
+    keyId: varchar("key_id", { length: 255 }).notNull(),
+    status: mysqlEnum("status", ["pending", "succeeded", "failed"]).notNull().default("pending"),
+    error: varchar("error", { length: 1024 }),
+    eventId: id("event_id"),
+    expires: bigint("expires", { mode: "number" }),
+    updatedAtM: bigint("updated_at_m", { mode: "number" }),
+  },
+  (table) => [unique("operation_id_key_id_unique").on(table.operationId, table.keyId)],
+);
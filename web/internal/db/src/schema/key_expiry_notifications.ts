@@ -0,0 +1,54 @@
+import {
+  bigint,
+  boolean,
+  datetime,
+  json,
+  mysqlTable,
+  uniqueIndex,
+  varchar,
+} from "drizzle-orm/mysql-core";
+import { id } from "./util/id";
+import { primaryKey } from "./util/primary_key";
+
+/**
+ * Opts a keyspace into warnings before its keys expire.
+ *
+ * thresholds_ms lists how long before expiry to warn, e.g. 7 days and 1 day.
+ * Warnings go by email to the workspace's admins when email is set and to
+ * slack_webhook_url when it is not null.
+ */
+export const keyExpiryNotificationSettings = mysqlTable("key_expiry_notification_settings", {
+  pk: primaryKey(),
+  keyAuthId: id("key_auth_id").notNull().unique(),
+  workspaceId: id("workspace_id").notNull(),
+  thresholdsMs: json("thresholds_ms").$type<number[]>().notNull(),
+  email: boolean("email").notNull().default(false),
+  slackWebhookUrl: varchar("slack_webhook_url", { length: 1024 }),
+  createdAtM: bigint("created_at_m", { mode: "number" })
+    .notNull()
+    .$defaultFn(() => Date.now()),
+  updatedAtM: bigint("updated_at_m", { mode: "number" }).$onUpdateFn(() => Date.now()),
+});
+
+/**
+ * One row per warning sent, so a key is warned at most once per threshold
+ * and expiry. Moving a key's expiry starts its warnings over.
+ */
+export const keyExpiryNotifications = mysqlTable(
+  "key_expiry_notifications",
+  {
+    pk: primaryKey(),
+    keyId: id("key_id").notNull(),
+    workspaceId: id("workspace_id").notNull(),
+    thresholdMs: bigint("threshold_ms", { mode: "number" }).notNull(),
+    expires: datetime("expires", { fsp: 3 }).notNull(),
+    createdAtM: bigint("created_at_m", { mode: "number" }).notNull(),
+  },
+  (table) => [
+    uniqueIndex("key_expiry_notifications_key_threshold_expires_unique").on(
+      table.keyId,
+      table.thresholdMs,
+      table.expires,
+    ),
+  ],
+);
@@ -0,0 +1,48 @@
+import { bigint, index, mysqlEnum, mysqlTable } from "drizzle-orm/mysql-core";
+import { id } from "./util/id";
+import { primaryKey } from "./util/primary_key";
+
+/**
+ * How often a successor is issued for a key and how long the replaced key
+ * stays valid afterwards.
+ *
+ * scope_id is either a key id, for a policy on that key alone, or a key_auth
+ * id, for the default of every key in the keyspace. A key's own policy wins
+ * over its keyspace's. Only recoverable keys are rotated, because the
+ * successor's plaintext is only reachable through vault.
+ */
+export const keyRotationPolicies = mysqlTable(
+  "key_rotation_policies",
+  {
+    pk: primaryKey(),
+    scopeId: id("scope_id").notNull().unique(),
+    workspaceId: id("workspace_id").notNull(),
+    keyAuthId: id("key_auth_id").notNull(),
+    intervalMs: bigint("interval_ms", { mode: "number" }).notNull(),
+    gracePeriodMs: bigint("grace_period_ms", { mode: "number" }).notNull(),
+    createdAtM: bigint("created_at_m", { mode: "number" })
+      .notNull()
+      .$defaultFn(() => Date.now()),
+    updatedAtM: bigint("updated_at_m", { mode: "number" }).$onUpdateFn(() => Date.now()),
+  },
+  (table) => [index("key_auth_id_idx").on(table.keyAuthId)],
+);
+
+/**
+ * One row per successor issued for a key, either by a reroll or by the
+ * scheduled rotation. A key with a row here is never rotated again; its
+ * successor carries the schedule forward.
+ */
+export const keyRotations = mysqlTable(
+  "key_rotations",
+  {
+    pk: primaryKey(),
+    keyId: id("key_id").notNull(),
+    successorKeyId: id("successor_key_id").notNull().unique(),
+    workspaceId: id("workspace_id").notNull(),
+    keyAuthId: id("key_auth_id").notNull(),
+    reason: mysqlEnum("reason", ["reroll", "schedule"]).notNull(),
+    createdAtM: bigint("created_at_m", { mode: "number" }).notNull(),
+  },
+  (table) => [index("key_id_idx").on(table.keyId)],
+);
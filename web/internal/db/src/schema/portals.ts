@@ -1,11 +1,30 @@
 import { relations } from "drizzle-orm";
-import { boolean, mysqlTable, uniqueIndex, varchar } from "drizzle-orm/mysql-core";
+import {
+  bigint,
+  boolean,
+  int,
+  json,
+  mysqlTable,
+  uniqueIndex,
+  varchar,
+} from "drizzle-orm/mysql-core";
 import { portalSessions } from "./portal_sessions";
 import { id } from "./util/id";
 import { lifecycleDates } from "./util/lifecycle_dates";
 import { primaryKey } from "./util/primary_key";
 import { workspaces } from "./workspaces";
 
+/**
+ * A ratelimit stamped onto every key an end user creates through the portal.
+ * Durations are in milliseconds.
+ */
+export type PortalRatelimitTemplate = {
+  name: string;
+  limit: number;
+  duration: number;
+  autoApply: boolean;
+};
+
 /**
  * Branding is 1:1 with the portal and every config read needs it, so it lives on
  * the portal row rather than in a join. Stored as discrete columns rather than
@@ -26,6 +45,17 @@ export const portals = mysqlTable(
     logoUrl: varchar("logo_url", { length: 500 }),
     // Hex colour including the leading '#', e.g. "#3b82f6".
     primaryColor: varchar("primary_color", { length: 7 }),
+    // Key policy for end users creating keys through the portal. NULL leaves
+    // the dimension unbounded, except the allow lists: NULL or empty allows no
+    // permissions or roles at all.
+    maxKeys: int("max_keys"),
+    allowedPermissions: json("allowed_permissions").$type<string[]>(),
+    allowedRoles: json("allowed_roles").$type<string[]>(),
+    ratelimitTemplates: json("ratelimit_templates").$type<PortalRatelimitTemplate[]>(),
+    // Bounds on how far in the future a created key may expire. A max forces
+    // every created key to expire.
+    minExpiryMs: bigint("min_expiry_ms", { mode: "number" }),
+    maxExpiryMs: bigint("max_expiry_ms", { mode: "number" }),
     ...lifecycleDates,
   },
   (table) => [
@@ -1,5 +1,5 @@
 import { relations } from "drizzle-orm";
-import { bigint, index, mysqlTable, unique, uniqueIndex } from "drizzle-orm/mysql-core";
+import { bigint, index, int, mysqlTable, unique, uniqueIndex } from "drizzle-orm/mysql-core";
 import { caseSensitiveVarchar } from "./util/case_sensitive_varchar";
 import { id } from "./util/id";
 import { lifecycleDatesMigration } from "./util/lifecycle_dates";
@@ -50,6 +50,14 @@ export const ratelimitOverrides = mysqlTable(
      * window duration in milliseconds
      */
     duration: bigint("duration", { mode: "number", unsigned: true }).notNull(),
+    /**
+     * When several overrides match an identifier, the highest priority wins.
+     */
+    priority: int("priority").notNull().default(0),
+    /**
+     * unix milli after which the override no longer applies, null never expires
+     */
+    expiresAtM: bigint("expires_at_m", { mode: "number" }),
     ...lifecycleDatesMigration,
   },
   (table) => {
@@ -72,6 +80,76 @@ export const ratelimitOverridesRelations = relations(ratelimitOverrides, ({ one
   }),
 }));
 
+/**
+ * A named bundle of limits, referenced by name in `ratelimit.limit`.
+ *
+ * Every limit of a plan is applied to the same identifier in one atomic
+ * check: either all of them pass and consume cost, or none do.
+ */
+export const ratelimitPlans = mysqlTable(
+  "ratelimit_plans",
+  {
+    pk: primaryKey(),
+    id: id("id").notNull().unique(),
+    workspaceId: id("workspace_id").notNull(),
+    name: caseSensitiveVarchar("name", { length: 512 }).notNull(),
+    createdAtM: bigint("created_at_m", { mode: "number" }).notNull().default(0),
+    updatedAtM: bigint("updated_at_m", { mode: "number" }),
+  },
+  (table) => {
+    return {
+      uniqueNamePerWorkspaceIdx: unique("unique_name_per_workspace_idx").on(
+        table.workspaceId,
+        table.name,
+      ),
+    };
+  },
+);
+
+export const ratelimitPlansRelations = relations(ratelimitPlans, ({ one, many }) => ({
+  workspace: one(workspaces, {
+    fields: [ratelimitPlans.workspaceId],
+    references: [workspaces.id],
+  }),
+  limits: many(ratelimitPlanLimits),
+}));
+
+export const ratelimitPlanLimits = mysqlTable(
+  "ratelimit_plan_limits",
+  {
+    pk: primaryKey(),
+    planId: id("plan_id").notNull(),
+    workspaceId: id("workspace_id").notNull(),
+    namespaceId: id("namespace_id").notNull(),
+    limit: bigint("limit", { mode: "number", unsigned: true }).notNull(),
+    /**
+     * window duration in milliseconds
+     */
+    duration: bigint("duration", { mode: "number", unsigned: true }).notNull(),
+    createdAtM: bigint("created_at_m", { mode: "number" }).notNull().default(0),
+  },
+  (table) => {
+    return {
+      uniqueNamespaceDurationPerPlan: unique("unique_namespace_duration_per_plan_idx").on(
+        table.planId,
+        table.namespaceId,
+        table.duration,
+      ),
+    };
+  },
+);
+
+export const ratelimitPlanLimitsRelations = relations(ratelimitPlanLimits, ({ one }) => ({
+  plan: one(ratelimitPlans, {
+    fields: [ratelimitPlanLimits.planId],
+    references: [ratelimitPlans.id],
+  }),
+  namespace: one(ratelimitNamespaces, {
+    fields: [ratelimitPlanLimits.namespaceId],
+    references: [ratelimitNamespaces.id],
+  }),
+}));
+
 /**
  * Cross-region sharing of global rate-limit counters.
  *
@@ -1,11 +1,19 @@
 import { relations } from "drizzle-orm";
-import { bigint, index, mysqlTable, unique, uniqueIndex, varchar } from "drizzle-orm/mysql-core";
+import { bigint, index, json, mysqlTable, unique, uniqueIndex, varchar } from "drizzle-orm/mysql-core";
 import { keys } from "./keys";
 import { caseInsensitiveVarchar } from "./util/case_insensitive_varchar";
 import { id } from "./util/id";
 import { primaryKey } from "./util/primary_key";
 import { workspaces } from "./workspaces";
 
+// Mirrors rbac.Conditions in pkg/rbac. A permission with conditions only
+// applies to requests that meet all of them.
+export type PermissionConditions = {
+  environments?: string[];
+  timeWindow?: { start: string; end: string; timezone?: string };
+  meta?: Record<string, string>;
+};
+
 export const permissions = mysqlTable(
   "permissions",
   {
@@ -16,6 +24,8 @@ export const permissions = mysqlTable(
     name: caseInsensitiveVarchar("name", { length: 512 }).notNull(),
     slug: varchar("slug", { length: 128 }).notNull(),
     description: varchar("description", { length: 512 }),
+    // null = the permission applies unconditionally
+    conditions: json("conditions").$type<PermissionConditions>(),
     createdAtM: bigint("created_at_m", { mode: "number" })
       .notNull()
       .default(0)
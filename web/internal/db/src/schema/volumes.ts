@@ -0,0 +1,69 @@
+import { relations } from "drizzle-orm";
+import {
+  bigint,
+  index,
+  int,
+  mysqlEnum,
+  mysqlTable,
+  uniqueIndex,
+  varchar,
+} from "drizzle-orm/mysql-core";
+import { apps } from "./apps";
+import { environments } from "./environments";
+import { id } from "./util/id";
+import { lifecycleDates } from "./util/lifecycle_dates";
+import { primaryKey } from "./util/primary_key";
+import { workspaces } from "./workspaces";
+
+// Named persistent volumes of an app in one environment. Every deployment of
+// the app in that environment mounts them, so the data survives deploys and
+// rollbacks. Krane provisions one PersistentVolumeClaim per volume and region.
+export const volumes = mysqlTable(
+  "volumes",
+  {
+    pk: primaryKey(),
+    id: id("id").notNull().unique(),
+    workspaceId: id("workspace_id").notNull(),
+    projectId: id("project_id").notNull(),
+    appId: id("app_id").notNull(),
+    environmentId: id("environment_id").notNull(),
+
+    // User-facing name, unique per app and environment.
+    name: varchar("name", { length: 63 }).notNull(),
+    // Absolute path the volume is mounted at inside the container.
+    mountPath: varchar("mount_path", { length: 256 }).notNull(),
+    sizeMib: int("size_mib", { unsigned: true }).notNull(),
+
+    // Name of the PersistentVolumeClaim. Derived from the id once and never
+    // changed, so every deployment finds the same claim.
+    k8sName: varchar("k8s_name", { length: 63 }).notNull(),
+
+    // active: mounted by the app's deployments.
+    // released: the workspace was torn down; the data is kept until the
+    // retention window passes. Setting it back to active cancels the purge.
+    // purged: krane was told to delete the claim and its data.
+    status: mysqlEnum("status", ["active", "released", "purged"]).notNull().default("active"),
+    releasedAt: bigint("released_at", { mode: "number" }),
+
+    ...lifecycleDates,
+  },
+  (table) => [
+    uniqueIndex("volumes_app_env_name_idx").on(table.appId, table.environmentId, table.name),
+    index("workspace_status_idx").on(table.workspaceId, table.status),
+  ],
+);
+
+export const volumesRelations = relations(volumes, ({ one }) => ({
+  workspace: one(workspaces, {
+    fields: [volumes.workspaceId],
+    references: [workspaces.id],
+  }),
+  app: one(apps, {
+    fields: [volumes.appId],
+    references: [apps.id],
+  }),
+  environment: one(environments, {
+    fields: [volumes.environmentId],
+    references: [environments.id],
+  }),
+}));
@@ -0,0 +1,82 @@
+import { relations } from "drizzle-orm";
+import {
+  bigint,
+  boolean,
+  index,
+  int,
+  json,
+  mysqlEnum,
+  mysqlTable,
+  varchar,
+} from "drizzle-orm/mysql-core";
+import { id } from "./util/id";
+import { lifecycleDates } from "./util/lifecycle_dates";
+import { primaryKey } from "./util/primary_key";
+import { workspaces } from "./workspaces";
+
+/**
+ * A customer-owned URL that receives signed event payloads.
+ *
+ * The signing secret is stored encrypted by vault under the workspace keyring
+ * and is only returned in plaintext once, when the endpoint is created.
+ * event_types is a JSON array of event type strings such as "key.created";
+ * an endpoint only receives events it subscribed to.
+ */
+export const webhookEndpoints = mysqlTable(
+  "webhook_endpoints",
+  {
+    pk: primaryKey(),
+    id: id("id").notNull().unique(),
+    workspaceId: id("workspace_id").notNull(),
+    url: varchar("url", { length: 1024 }).notNull(),
+    description: varchar("description", { length: 256 }),
+    eventTypes: json("event_types").$type<string[]>().notNull(),
+    encryptedSecret: varchar("encrypted_secret", { length: 1024 }).notNull(),
+    encryptionKeyId: varchar("encryption_key_id", { length: 256 }).notNull(),
+    enabled: boolean("enabled").notNull().default(true),
+    ...lifecycleDates,
+  },
+  (table) => [index("workspace_id_idx").on(table.workspaceId)],
+);
+
+/**
+ * One row per attempt to deliver one event to one endpoint. The payload is
+ * stored as sent so a delivery can be replayed byte for byte. Rows are
+ * written by the ctrl worker, which owns retries; the API only reads them
+ * and enqueues replays.
+ */
+export const webhookDeliveries = mysqlTable(
+  "webhook_deliveries",
+  {
+    pk: primaryKey(),
+    id: id("id").notNull().unique(),
+    workspaceId: id("workspace_id").notNull(),
+    endpointId: id("endpoint_id").notNull(),
+    eventId: id("event_id").notNull(),
+    eventType: varchar("event_type", { length: 64 }).notNull(),
+    payload: json("payload").notNull(),
+    status: mysqlEnum("status", ["pending", "delivered", "failed"]).notNull().default("pending"),
+    attempts: int("attempts", { unsigned: true }).notNull().default(0),
+    responseStatus: int("response_status"),
+    lastError: varchar("last_error", { length: 1024 }),
+    nextAttemptAt: bigint("next_attempt_at", { mode: "number" }),
+    deliveredAt: bigint("delivered_at", { mode: "number" }),
+    ...lifecycleDates,
+  },
+  (table) => [index("endpoint_id_idx").on(table.endpointId, table.id)],
+);
+
+export const webhookEndpointsRelations = relations(webhookEndpoints, ({ one, many }) => ({
+  workspace: one(workspaces, {
+    fields: [webhookEndpoints.workspaceId],
+    references: [workspaces.id],
+  }),
+  deliveries: many(webhookDeliveries),
+}));
+
+export const webhookDeliveriesRelations = relations(webhookDeliveries, ({ one }) => ({
+  endpoint: one(webhookEndpoints, {
+    fields: [webhookDeliveries.endpointId],
+    references: [webhookEndpoints.id],
+  }),
+}));
@@ -71,3 +71,22 @@ describe("portal permissions", () => {
     ]);
   });
 });
+
+describe("webhook permissions", () => {
+  const testCases = [
+    { input: "webhook.*.create_webhook", valid: true },
+    { input: "webhook.whep_1234abcd.read_webhook", valid: true },
+    { input: "webhook.whep_1234abcd.update_webhook", valid: true },
+    { input: "webhook.whep_1234abcd.delete_webhook", valid: true },
+    // id does not carry the whep_ prefix
+    { input: "webhook.badid.read_webhook", valid: false },
+    // action is not part of the enum
+    { input: "webhook.*.replay_delivery", valid: false },
+  ];
+
+  for (const { input, valid } of testCases) {
+    test(`${input} should be ${valid ? "valid" : "invalid"}`, () => {
+      expect(unkeyPermissionValidation.safeParse(input).success).toBe(valid);
+    });
+  }
+});
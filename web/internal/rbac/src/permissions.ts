@@ -25,6 +25,7 @@ const appId = buildIdSchema("app");
 const environmentId = buildIdSchema("env");
 const workspaceId = buildIdSchema("ws");
 const portalId = buildIdSchema("pc");
+const webhookId = buildIdSchema("whep");
 export const apiActions = z.enum([
   "read_api",
   "create_api",
@@ -85,7 +86,7 @@ export const projectActions = z.enum([
   "read_runtime_logs",
 ]);
 export const appActions = z.enum(["read_app", "update_app", "delete_app", "connect_repository"]);
-export const workspaceActions = z.enum(["install_github"]);
+export const workspaceActions = z.enum(["install_github", "read_audit_log"]);
 export const portalActions = z.enum([
   "create_portal",
   "read_portal",
@@ -93,6 +94,12 @@ export const portalActions = z.enum([
   "delete_portal",
   "create_portal_session",
 ]);
+export const webhookActions = z.enum([
+  "create_webhook",
+  "read_webhook",
+  "update_webhook",
+  "delete_webhook",
+]);
 export const environmentActions = z.enum([
   "read_environment",
   "update_environment",
@@ -125,6 +132,7 @@ const scopedResources = {
   environment: { idSchema: environmentId, actionsSchema: environmentActions },
   workspace: { idSchema: workspaceId, actionsSchema: workspaceActions },
   portal: { idSchema: portalId, actionsSchema: portalActions },
+  webhook: { idSchema: webhookId, actionsSchema: webhookActions },
 } as const;
 
 export type Resources = {
@@ -149,6 +157,8 @@ export type Resources = {
   [resourceId in `workspace.${z.infer<typeof workspaceId>}`]: z.infer<typeof workspaceActions>;
 } & {
   [resourceId in `portal.${z.infer<typeof portalId>}`]: z.infer<typeof portalActions>;
+} & {
+  [resourceId in `webhook.${z.infer<typeof webhookId>}`]: z.infer<typeof webhookActions>;
 };
 
 export type UnkeyPermission = Flatten<Resources> | "*";
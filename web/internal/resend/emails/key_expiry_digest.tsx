@@ -0,0 +1,84 @@
+import { Button } from "@react-email/button";
+import { Heading } from "@react-email/heading";
+import { Hr } from "@react-email/hr";
+import { Link } from "@react-email/link";
+import { Preview } from "@react-email/preview";
+import { Section } from "@react-email/section";
+import { Text } from "@react-email/text";
+// biome-ignore lint/correctness/noUnusedImports: react-email needs this imported
+import React from "react";
+import { Layout } from "../src/components/layout";
+
+// This email is not sent from the dashboard. It is rendered with Resend
+// {{{VARIABLE}}} placeholders as props and uploaded as a Resend template by
+// scripts/sync-templates.tsx; the Go control plane sends it by template alias.
+//
+// keys is one line per key, already HTML-escaped by the sender, and ends with
+// a line counting the keys left out when the list is capped.
+export type Props = {
+  keyCount: string;
+  timeLeft: string;
+  apiName: string;
+  workspaceName: string;
+  keys: string;
+  keysUrl: string;
+  year: string;
+};
+
+export function KeyExpiryDigest({
+  keyCount,
+  timeLeft,
+  apiName,
+  workspaceName,
+  keys,
+  keysUrl,
+  year,
+}: Props) {
+  return (
+    <Layout>
+      <Preview>
+        {keyCount} of {apiName} will expire within {timeLeft}.
+      </Preview>
+      <Heading className="font-sans text-3xl font-semibold">Keys expiring soon</Heading>
+      <Text>Hey,</Text>
+      <Text>
+        <strong>{keyCount}</strong> of <strong>{apiName}</strong> in{" "}
+        <strong>{workspaceName}</strong> will expire within <strong>{timeLeft}</strong>. Once a
+        key expires, requests using it are rejected.
+      </Text>
+      <Section>
+        <Text className="font-mono text-sm whitespace-pre-line">{keys}</Text>
+      </Section>
+
+      <Section className="text-center py-3">
+        <Button href={keysUrl} className="bg-gray-900 text-gray-50 rounded-lg px-7 py-3">
+          Review keys
+        </Button>
+      </Section>
+
+      <Hr />
+      <Text>
+        Need help? Please reach out to{" "}
+        <Link href="mailto:support@unkey.com">support@unkey.com</Link> or just reply to this email.
+      </Text>
+
+      <Text className="text-xs">
+        You&apos;re receiving this because this API has key expiry notifications turned on.
+      </Text>
+      <Text className="text-xs">© {year} Unkey</Text>
+    </Layout>
+  );
+}
+
+KeyExpiryDigest.PreviewProps = {
+  keyCount: "3 keys",
+  timeLeft: "7 days",
+  apiName: "Production API",
+  workspaceName: "Acme Inc",
+  keys: "billing-service (key_3ZbK...)\ncron-worker (key_9PqW...)\nsk_live_4f...",
+  keysUrl: "https://app.unkey.com/acme/apis/api_123/keys/ks_123",
+  year: "2026",
+} satisfies Props;
+
+// biome-ignore lint/style/noDefaultExport: the email dev preview and render test load default exports
+export default KeyExpiryDigest;
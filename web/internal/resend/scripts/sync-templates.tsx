@@ -15,6 +15,7 @@ import type React from "react";
 import { Resend } from "resend";
 import ComputeBudgetAlert from "../emails/compute_budget_alert";
 import ComputeBudgetStopped from "../emails/compute_budget_stopped";
+import KeyExpiryDigest from "../emails/key_expiry_digest";
 
 type TemplateSync = {
   // alias is how svc/ctrl refers to the template; it must not change once a
@@ -74,6 +75,32 @@ const templates: TemplateSync[] = [
       { key: "YEAR", fallbackValue: "2026" },
     ],
   },
+  {
+    alias: "key-expiry-digest",
+    name: "Key expiry digest",
+    subject: "{{{KEY_COUNT}}} of {{{API_NAME}}} will expire within {{{TIME_LEFT}}}",
+    from: "James | Unkey <james@updates.unkey.com>",
+    element: (
+      <KeyExpiryDigest
+        keyCount="{{{KEY_COUNT}}}"
+        timeLeft="{{{TIME_LEFT}}}"
+        apiName="{{{API_NAME}}}"
+        workspaceName="{{{WORKSPACE_NAME}}}"
+        keys="{{{KEYS}}}"
+        keysUrl="{{{KEYS_URL}}}"
+        year="{{{YEAR}}}"
+      />
+    ),
+    variables: [
+      { key: "KEY_COUNT", fallbackValue: "Some keys" },
+      { key: "TIME_LEFT", fallbackValue: "a few days" },
+      { key: "API_NAME", fallbackValue: "your API" },
+      { key: "WORKSPACE_NAME", fallbackValue: "Your workspace" },
+      { key: "KEYS", fallbackValue: "" },
+      { key: "KEYS_URL", fallbackValue: "https://app.unkey.com" },
+      { key: "YEAR", fallbackValue: "2026" },
+    ],
+  },
 ];
 
 async function main(): Promise<void> {
@@ -14,6 +14,7 @@ export const unkeyAuditLogEvents = z.enum([
   "api.delete",
   "key.create",
   "key.reroll",
+  "key.rotate",
   "key.update",
   "key.delete",
   "ratelimitNamespace.create",
@@ -37,6 +38,7 @@ export const unkeyAuditLogEvents = z.enum([
   "webhook.create",
   "webhook.update",
   "webhook.delete",
+  "webhook.replay_delivery",
   "reporter.create",
   "identity.create",
   "identity.update",
@@ -47,6 +49,8 @@ export const unkeyAuditLogEvents = z.enum([
   "ratelimit.set_override",
   "ratelimit.read_override",
   "ratelimit.delete_override",
+  "ratelimit.set_plan",
+  "ratelimit.delete_plan",
   "auditLogBucket.create",
   "project.create",
   "project.update",